@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"iter"
+	"net/http"
+)
+
+// Page is one page of a cursor-paginated list. NextCursor is opaque to
+// clients: they send it back as the cursor query parameter to get the next
+// page, and it is empty on the last page.
+//
+// Unlike offset pagination, a cursor names the last item seen rather than
+// a position, so rows inserted or deleted while a client pages through a
+// list don't cause items to be skipped or shown twice.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// HasMore reports whether there is a page after p
+func (p Page[T]) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// MaxCursorLimit is the largest page size CursorLimit allows
+const MaxCursorLimit = 100
+
+// CursorLimit clamps a requested page size to 1..MaxCursorLimit, using
+// DefaultPagination.PerPage when limit is 0 or less
+func CursorLimit(limit int) int {
+	if limit < 1 {
+		return DefaultPagination.PerPage
+	}
+	return min(limit, MaxCursorLimit)
+}
+
+// Cursor is the cursor and limit query parameters of a page request
+type Cursor struct {
+	After string // the NextCursor of the previous page, "" for the first
+	Limit int
+}
+
+// Cursor extracts the cursor and limit query parameters, clamping limit
+// with CursorLimit
+func (q QueryParams) Cursor() Cursor {
+	return Cursor{
+		After: q.String("cursor", ""),
+		Limit: CursorLimit(q.Int("limit", 0)),
+	}
+}
+
+// EncodeCursor encodes the sort key of the last item on a page, such as its
+// ID or a struct of its creation time and ID, as an opaque cursor
+func EncodeCursor(key any) string {
+	data, err := json.Marshal(key)
+	if err != nil {
+		panic("api: encoding cursor: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor made by EncodeCursor into key. An empty
+// cursor leaves key unchanged. A malformed one is a 400 with code
+// "invalid_cursor", which services can return as is.
+func DecodeCursor(cursor string, key any) error {
+	if cursor == "" {
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(data, key) != nil {
+		return &Error{
+			Status:  http.StatusBadRequest,
+			Code:    "invalid_cursor",
+			Message: "invalid cursor",
+			Fields:  map[string]string{"cursor": "is not a cursor from this list"},
+		}
+	}
+	return nil
+}
+
+// NewPage builds a page from up to limit+1 items fetched after the
+// cursor's key. Fetching one extra item tells whether another page follows
+// without a count query; when it is there, it is dropped and NextCursor is
+// set from the key of the last item kept.
+//
+//	var after int
+//	if err := api.DecodeCursor(cursor, &after); err != nil {
+//		return api.Page[Post]{}, err
+//	}
+//	limit = api.CursorLimit(limit)
+//	posts, err := db.Posts(ctx, "id > ? ORDER BY id LIMIT ?", after, limit+1)
+//	...
+//	return api.NewPage(posts, limit, func(p Post) any { return p.ID }), nil
+func NewPage[T any](items []T, limit int, key func(T) any) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	if limit < 1 || len(items) <= limit {
+		return Page[T]{Items: items}
+	}
+	items = items[:limit]
+	return Page[T]{Items: items, NextCursor: EncodeCursor(key(items[limit-1]))}
+}
+
+// Pages iterates over the pages returned by fetch, starting with cursor ""
+// and following NextCursor until the last page, an error, or a break.
+// Generated clients wrap it as a Pages method for each cursor-paginated
+// route.
+//
+//	for page, err := range client.ListPages(ctx, 50) {
+//		if err != nil {
+//			return err
+//		}
+//		process(page.Items)
+//	}
+func Pages[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (Page[T], error)) iter.Seq2[Page[T], error] {
+	return func(yield func(Page[T], error) bool) {
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Page[T]{}, err)
+				return
+			}
+			page, err := fetch(ctx, cursor)
+			if !yield(page, err) || err != nil || !page.HasMore() {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// AllPages collects the items of every page from fetch. It is meant for
+// lists known to be small, such as options for a select; page through
+// large ones with Pages.
+func AllPages[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (Page[T], error)) ([]T, error) {
+	var items []T
+	for page, err := range Pages(ctx, fetch) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, page.Items...)
+	}
+	return items, nil
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the wire format of Date and of "date" time tags
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date with no time of day or time zone, such as a
+// birthday or a due date. It travels as "2006-01-02", so unlike a
+// time.Time at midnight it can't shift to the previous day when client and
+// server are in different zones. The zero Date travels as null.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the date for year, month, and day, normalized like
+// time.Date: NewDate(2024, 1, 32) is February 1
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar date of t in t's own location
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date in loc
+func Today(loc *time.Location) Date {
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseDate parses a "2006-01-02" date
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: want YYYY-MM-DD", s)
+	}
+	return DateOf(t), nil
+}
+
+// String formats d as "2006-01-02", or "" for the zero Date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.In(time.UTC).Format(DateLayout)
+}
+
+// IsZero reports whether d is the zero Date
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns midnight at the start of d in loc
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns d moved by n days
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// Before reports whether d is before other
+func (d Date) Before(other Date) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+// After reports whether d is after other
+func (d Date) After(other Date) bool {
+	return d.In(time.UTC).After(other.In(time.UTC))
+}
+
+// MarshalText implements encoding.TextMarshaler, for query parameters and
+// map keys
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string is
+// the zero Date.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts null, "", and
+// "2006-01-02".
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date %s: want a YYYY-MM-DD string", data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// TimeFormat is the wire format of a time.Time field, parsed from its time
+// struct tag: a format name followed by an optional zone.
+//
+//	`time:"rfc3339"`           "2024-05-01T13:30:00Z", always sent in UTC (the default)
+//	`time:"date"`              "2024-05-01", the field's calendar date
+//	`time:"unix"`              1714570200, seconds since the epoch
+//	`time:"unixms"`            1714570200000, milliseconds since the epoch
+//	`time:"02/01/2006 15:04"`  any other value is a Go layout with a full date
+//
+// The zone, "utc" (the default) or "local", sets the location of decoded
+// times, and of encoded ones for layouts without a zone. Dates decode to
+// midnight in that zone, so they show the same day that was sent.
+//
+//	`time:"date,local"`
+type TimeFormat struct {
+	Layout string         // Go layout, or "" for unix and unixms
+	Unit   time.Duration  // time.Second or time.Millisecond for unix formats
+	Loc    *time.Location // time.UTC or time.Local
+}
+
+// ParseTimeFormat parses a time struct tag. gux gen rejects tags it can't
+// parse, so generated code can rely on them.
+func ParseTimeFormat(tag string) (TimeFormat, error) {
+	name, zone, _ := strings.Cut(tag, ",")
+	f := TimeFormat{Layout: time.RFC3339Nano, Loc: time.UTC}
+	switch zone {
+	case "", "utc":
+	case "local":
+		f.Loc = time.Local
+	default:
+		return f, fmt.Errorf("unknown time zone %q (use utc or local)", zone)
+	}
+
+	switch name {
+	case "", "rfc3339":
+	case "date":
+		f.Layout = DateLayout
+	case "unix":
+		f.Layout, f.Unit = "", time.Second
+	case "unixms":
+		f.Layout, f.Unit = "", time.Millisecond
+	default:
+		// A custom layout has to hold a year, month, and day, or values
+		// would silently lose them
+		ref := time.Date(2024, 5, 17, 13, 30, 0, 0, time.UTC)
+		parsed, err := time.Parse(name, ref.Format(name))
+		if err != nil || parsed.Year() != 2024 || parsed.Month() != 5 || parsed.Day() != 17 {
+			return f, fmt.Errorf("unknown time format %q (use rfc3339, date, unix, unixms, or a Go layout with a full date)", name)
+		}
+		f.Layout = name
+	}
+	return f, nil
+}
+
+// Encode returns the JSON for t. The zero time is null.
+func (f TimeFormat) Encode(t time.Time) []byte {
+	switch {
+	case t.IsZero():
+		return []byte("null")
+	case f.Layout == "":
+		return strconv.AppendInt(nil, t.UnixNano()/int64(f.Unit), 10)
+	case f.Layout == DateLayout:
+		// The date as the field holds it, so converting zones can't move it
+		return []byte(`"` + t.Format(DateLayout) + `"`)
+	case f.Layout == time.RFC3339Nano:
+		return []byte(`"` + t.UTC().Format(time.RFC3339Nano) + `"`)
+	}
+	return strconv.AppendQuote(nil, t.In(f.Loc).Format(f.Layout))
+}
+
+// Decode parses JSON written by Encode. null, "", and 0 decode to the zero
+// time.
+func (f TimeFormat) Decode(data []byte) (time.Time, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return time.Time{}, nil
+	}
+
+	if f.Layout == "" {
+		n, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %s: want a number", data)
+		}
+		if n == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(0, n*int64(f.Unit)).In(f.Loc), nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s: want a string", data)
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(f.Layout, s, f.Loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: want the format %s", s, f.Layout)
+	}
+	if f.Layout == DateLayout {
+		return t, nil
+	}
+	return t.In(f.Loc), nil
+}
+
+// TimeField encodes a time.Time or *time.Time struct field in a TimeFormat.
+// The MarshalJSON and UnmarshalJSON methods that gux gen writes for
+// structs with time tags use it; it is not meant to be used directly.
+type TimeField struct {
+	t      *time.Time
+	p      **time.Time
+	format TimeFormat
+}
+
+// TimeOf returns a TimeField for a time.Time field
+func TimeOf(t *time.Time, tag string) *TimeField {
+	f, _ := ParseTimeFormat(tag)
+	return &TimeField{t: t, format: f}
+}
+
+// TimePtrOf returns a TimeField for a *time.Time field
+func TimePtrOf(p **time.Time, tag string) *TimeField {
+	f, _ := ParseTimeFormat(tag)
+	return &TimeField{p: p, format: f}
+}
+
+// OmitEmpty returns nil when the field is the zero time or nil, for fields
+// tagged omitempty
+func (tf *TimeField) OmitEmpty() *TimeField {
+	if tf.value().IsZero() {
+		return nil
+	}
+	return tf
+}
+
+func (tf *TimeField) value() time.Time {
+	if tf.p != nil {
+		if *tf.p == nil {
+			return time.Time{}
+		}
+		return **tf.p
+	}
+	return *tf.t
+}
+
+// MarshalJSON implements json.Marshaler
+func (tf *TimeField) MarshalJSON() ([]byte, error) {
+	return tf.format.Encode(tf.value()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (tf *TimeField) UnmarshalJSON(data []byte) error {
+	t, err := tf.format.Decode(data)
+	if err != nil {
+		return err
+	}
+	if tf.p != nil {
+		if t.IsZero() {
+			*tf.p = nil
+		} else {
+			*tf.p = &t
+		}
+		return nil
+	}
+	*tf.t = t
+	return nil
+}
@@ -2,35 +2,84 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 )
 
-// Error represents an API error with HTTP status code
+// RequestIDHeader is the response header carrying the request ID, set by
+// server.RequestID
+const RequestIDHeader = "X-Request-ID"
+
+// Error represents an API error with HTTP status code. Key, Params, and
+// FieldKeys let clients translate Message and Fields into their own locale.
 type Error struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Status    int                `json:"-"`
+	Code      string             `json:"code"`
+	Message   string             `json:"message"`
+	Key       string             `json:"key,omitempty"`       // i18n key of Message
+	Params    []any              `json:"params,omitempty"`    // args of Key's message
+	Fields    map[string]string  `json:"fields,omitempty"`    // per-field validation messages
+	FieldKeys map[string]Message `json:"fieldKeys,omitempty"` // i18n keys of Fields
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// WithField adds a validation message for a field and returns e
+func (e *Error) WithField(field, message string) *Error {
+	if e.Fields == nil {
+		e.Fields = make(map[string]string)
+	}
+	e.Fields[field] = message
+	return e
+}
+
+// WithKey sets the i18n key of e's message and returns e. Message becomes
+// its translation in the server's locale.
+//
+//	gqapi.Conflict("").WithKey("app.errors.slugTaken", slug)
+func (e *Error) WithKey(key string, params ...any) *Error {
+	e.Key = key
+	e.Params = params
+	e.Message = Msg(key, params...).String()
+	return e
+}
+
+// WithFieldKey adds a translatable validation message for a field and
+// returns e
+func (e *Error) WithFieldKey(field, key string, params ...any) *Error {
+	msg := Msg(key, params...)
+	if e.FieldKeys == nil {
+		e.FieldKeys = make(map[string]Message)
+	}
+	e.FieldKeys[field] = msg
+	return e.WithField(field, msg.String())
+}
+
 // ErrorResponse is the JSON structure returned to clients
 type ErrorResponse struct {
 	Error ErrorBody `json:"error"`
 }
 
 type ErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string             `json:"code"`
+	Message   string             `json:"message"`
+	Key       string             `json:"key,omitempty"`
+	Params    []any              `json:"params,omitempty"`
+	Fields    map[string]string  `json:"fields,omitempty"`
+	FieldKeys map[string]Message `json:"fieldKeys,omitempty"`
+	RequestID string             `json:"requestId,omitempty"` // the X-Request-ID response header
 }
 
-// WriteError writes an API error as JSON response
+// WriteError writes an API error as JSON response. The body includes the
+// request ID set by server.RequestID, and server errors (5xx) are logged
+// with it.
 func WriteError(w http.ResponseWriter, err error) {
-	apiErr, ok := err.(*Error)
-	if !ok {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
 		apiErr = &Error{
 			Status:  http.StatusInternalServerError,
 			Code:    "internal_error",
@@ -38,12 +87,26 @@ func WriteError(w http.ResponseWriter, err error) {
 		}
 	}
 
+	requestID := w.Header().Get(RequestIDHeader)
+	if apiErr.Status >= http.StatusInternalServerError {
+		if requestID != "" {
+			log.Printf("[%s] %s: %v", requestID, apiErr.Code, err)
+		} else {
+			log.Printf("%s: %v", apiErr.Code, err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(apiErr.Status)
 	json.NewEncoder(w).Encode(ErrorResponse{
 		Error: ErrorBody{
-			Code:    apiErr.Code,
-			Message: apiErr.Message,
+			Code:      apiErr.Code,
+			Message:   apiErr.Message,
+			Key:       apiErr.Key,
+			Params:    apiErr.Params,
+			Fields:    apiErr.Fields,
+			FieldKeys: apiErr.FieldKeys,
+			RequestID: requestID,
 		},
 	})
 }
@@ -78,6 +141,92 @@ func Conflict(message string) *Error {
 	return &Error{Status: http.StatusConflict, Code: "conflict", Message: message}
 }
 
+// Validation returns a 422 error carrying a message per invalid field, which
+// generated clients expose as Error.Fields
+func Validation(fields map[string]string) *Error {
+	return &Error{Status: http.StatusUnprocessableEntity, Code: "validation_failed", Message: "validation failed", Key: validationFailedKey, Fields: fields}
+}
+
+// validationFailedKey is the i18n key of a validation error's message
+const validationFailedKey = "gux.validation.failed"
+
+// ValidationMessages returns a 422 error carrying a translatable message per
+// invalid field. Generated clients translate them in the user's locale;
+// Fields holds them in the server's, for other clients. Generated Validate
+// methods return it.
+func ValidationMessages(fields map[string]Message) *Error {
+	text := make(map[string]string, len(fields))
+	for field, msg := range fields {
+		text[field] = msg.String()
+	}
+	err := Validation(text)
+	err.FieldKeys = fields
+	return err
+}
+
+// NestFields adds the field messages of err, from a nested struct's
+// Validate method, to fields under prefix: "city" becomes "address.city".
+// An error without field messages is added as prefix's message. Validate
+// methods built on Validation use it for fields holding other validated
+// structs; generated ones use NestMessages.
+func NestFields(fields map[string]string, prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		fields[prefix] = err.Error()
+		return
+	}
+	for key, message := range apiErr.Fields {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		fields[key] = message
+	}
+}
+
+// NestMessages is NestFields for the translatable messages of
+// ValidationMessages. Fields of err without a key, from hand-written
+// Validate methods, keep their text as the key, which shows as is.
+func NestMessages(fields map[string]Message, prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		fields[prefix] = Message{Key: err.Error()}
+		if apiErr != nil && apiErr.Key != "" {
+			fields[prefix] = Msg(apiErr.Key, apiErr.Params...)
+		}
+		return
+	}
+	for key, text := range apiErr.Fields {
+		msg, ok := apiErr.FieldKeys[key]
+		if !ok {
+			msg = Message{Key: text}
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		fields[key] = msg
+	}
+}
+
+// Invalid returns the *Error in err's chain, or a 400 with err's message.
+// Generated handlers use it for errors from a request body's Validate method.
+func Invalid(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return BadRequest(err.Error())
+}
+
+func TooManyRequests(message string) *Error {
+	return &Error{Status: http.StatusTooManyRequests, Code: "rate_limited", Message: message}
+}
+
 func InternalError(message string) *Error {
 	return &Error{Status: http.StatusInternalServerError, Code: "internal_error", Message: message}
 }
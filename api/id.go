@@ -0,0 +1,334 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/dougbarrett/gux/compat"
+)
+
+// ErrMalformedID matches the errors from parsing a UUID or HashID, so
+// callers can tell a bad ID from other failures with errors.Is
+var ErrMalformedID = errors.New("malformed id")
+
+// malformedIDError reports text that is not an ID of the wanted kind
+type malformedIDError struct {
+	text string
+	kind string // "a UUID" or "an ID"
+}
+
+func (e *malformedIDError) Error() string {
+	return fmt.Sprintf("%q is not %s", e.text, e.kind)
+}
+
+func (e *malformedIDError) Is(target error) bool {
+	return target == ErrMalformedID
+}
+
+// InvalidID returns a 400 for a path parameter that is not a valid ID, with
+// code "invalid_id" and the reason as the parameter's field message.
+// Generated handlers return it before calling the service.
+func InvalidID(param, reason string) *Error {
+	return &Error{
+		Status:  http.StatusBadRequest,
+		Code:    "invalid_id",
+		Message: "invalid " + param + ": " + reason,
+		Fields:  map[string]string{param: reason},
+	}
+}
+
+// UUID is a 128-bit identifier in the canonical
+// "6ba7b810-9dad-41d1-80b4-00c04fd430c8" form. Unlike sequential integers,
+// UUIDs don't reveal how many records exist or let clients guess each
+// other's. The zero UUID travels as null.
+type UUID [16]byte
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() UUID {
+	var u UUID
+	if _, err := compat.Read(u[:]); err != nil {
+		panic("api: reading random bytes: " + err.Error())
+	}
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return u
+}
+
+// ParseUUID parses a UUID in the canonical hyphenated form or as 32 hex
+// digits, in either case
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	var digits []byte
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, &malformedIDError{s, "a UUID"}
+		}
+		digits = []byte(s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	case 32:
+		digits = []byte(s)
+	default:
+		return u, &malformedIDError{s, "a UUID"}
+	}
+	if _, err := hex.Decode(u[:], digits); err != nil {
+		return UUID{}, &malformedIDError{s, "a UUID"}
+	}
+	return u, nil
+}
+
+// MustParseUUID is like ParseUUID but panics on error, for constants
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String formats u in the canonical lowercase hyphenated form
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// IsZero reports whether u is the zero UUID
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// MarshalText implements encoding.TextMarshaler, for path and query
+// parameters and map keys
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string is
+// the zero UUID.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*u = UUID{}
+		return nil
+	}
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + u.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts null, "", and a
+// UUID string.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*u = UUID{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &malformedIDError{string(data), "a UUID string"}
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer. UUIDs are stored as their string form,
+// which UUID columns in PostgreSQL and text columns elsewhere both accept.
+// The zero UUID is NULL.
+func (u UUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Scan implements sql.Scanner. It reads NULL, UUID strings, and 16 raw
+// bytes from BINARY(16) columns.
+func (u *UUID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.UnmarshalText(v)
+	}
+	return fmt.Errorf("api: cannot scan %T into UUID", src)
+}
+
+// hashIDAlphabet is the digits of encoded HashIDs
+const hashIDAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// hashIDLen is the length of every encoded HashID: 62^11 > 2^64
+const hashIDLen = 11
+
+// hashIDKeys are the round keys derived by SetHashIDKey
+var hashIDKeys = deriveHashIDKeys("")
+
+// HashID is an integer ID, such as a database's auto-increment key, that
+// travels as an 11-character string like "3kTMd9Qx2Bf". Consecutive IDs
+// encode to unrelated strings, so a public API doesn't reveal how many
+// records exist or invite clients to walk them. The encoding is a keyed
+// permutation, so there are no collisions and no lookup table.
+//
+// HashIDs obfuscate; they are not a substitute for authorization. The key
+// is set with SetHashIDKey and is visible to anyone who inspects the WASM
+// client.
+type HashID int64
+
+// SetHashIDKey sets the key HashIDs are encoded with. Call it once, before
+// any IDs are encoded, from an init function in the package both the
+// client and the server import, so they agree. Changing it changes every
+// encoded ID, breaking links that contain the old ones.
+func SetHashIDKey(key string) {
+	hashIDKeys = deriveHashIDKeys(key)
+}
+
+func deriveHashIDKeys(key string) [8]uint32 {
+	sum := sha256.Sum256([]byte("gux hashid " + key))
+	var keys [8]uint32
+	for i := range keys {
+		keys[i] = binary.BigEndian.Uint32(sum[i*4:])
+	}
+	return keys
+}
+
+// hashIDRound is the Feistel round function
+func hashIDRound(x, key uint32) uint32 {
+	x ^= key
+	x *= 0x9e3779b1
+	x ^= x >> 15
+	x *= 0x85ebca77
+	x ^= x >> 13
+	return x
+}
+
+// ParseHashID decodes a string made by HashID.String
+func ParseHashID(s string) (HashID, error) {
+	if len(s) != hashIDLen {
+		return 0, &malformedIDError{s, "an ID"}
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		d := indexHashIDDigit(s[i])
+		if d < 0 || n > (math.MaxUint64-uint64(d))/62 {
+			return 0, &malformedIDError{s, "an ID"}
+		}
+		n = n*62 + uint64(d)
+	}
+
+	left, right := uint32(n>>32), uint32(n)
+	for i := len(hashIDKeys) - 1; i >= 0; i-- {
+		left, right = right^hashIDRound(left, hashIDKeys[i]), left
+	}
+	v := uint64(left)<<32 | uint64(right)
+	if v > math.MaxInt64 {
+		// Only about one 11-character string in six decodes to an ID, so most
+		// typos are caught here rather than looked up
+		return 0, &malformedIDError{s, "an ID"}
+	}
+	return HashID(v), nil
+}
+
+func indexHashIDDigit(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 36
+	}
+	return -1
+}
+
+// Int64 returns the underlying integer, for database queries
+func (id HashID) Int64() int64 {
+	return int64(id)
+}
+
+// String returns the encoded form of id. Negative IDs have none and
+// format as "".
+func (id HashID) String() string {
+	if id < 0 {
+		return ""
+	}
+	left, right := uint32(uint64(id)>>32), uint32(id)
+	for _, key := range hashIDKeys {
+		left, right = right, left^hashIDRound(right, key)
+	}
+	n := uint64(left)<<32 | uint64(right)
+
+	var b [hashIDLen]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = hashIDAlphabet[n%62]
+		n /= 62
+	}
+	return string(b[:])
+}
+
+// MarshalText implements encoding.TextMarshaler, so HashIDs travel as
+// their encoded strings in JSON and URLs
+func (id HashID) MarshalText() ([]byte, error) {
+	if id < 0 {
+		return nil, fmt.Errorf("api: cannot encode negative HashID %d", int64(id))
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (id *HashID) UnmarshalText(text []byte) error {
+	parsed, err := ParseHashID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The database stores the plain integer.
+func (id HashID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner for integer columns
+func (id *HashID) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*id = HashID(v)
+		return nil
+	case nil:
+		*id = 0
+		return nil
+	}
+	return fmt.Errorf("api: cannot scan %T into HashID", src)
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"math"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/validate"
+)
+
+// Message is a message for the client to translate: an i18n catalog key and
+// the args of its format string. Error bodies carry messages alongside their
+// text, so one server can serve clients in any locale.
+//
+//	gqapi.Msg("gux.validation.minlength", 3)
+type Message struct {
+	Key    string `json:"key"`
+	Params []any  `json:"params,omitempty"`
+}
+
+// Msg returns the Message for key and params
+func Msg(key string, params ...any) Message {
+	return Message{Key: key, Params: params}
+}
+
+// String translates m in the current locale. A key without a translation
+// is shown as is, so hand-written text passes through. Whole numbers are
+// passed as ints, as JSON decodes them to float64, and date params in
+// validate.DateLayout are formatted for the locale.
+func (m Message) String() string {
+	if len(m.Params) == 0 {
+		return i18n.T(m.Key)
+	}
+	params := make([]any, len(m.Params))
+	for i, p := range m.Params {
+		switch v := p.(type) {
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				p = int(v)
+			}
+		case string:
+			if len(v) == len(validate.DateLayout) {
+				if t, err := time.Parse(validate.DateLayout, v); err == nil {
+					p = i18n.FormatDate(t)
+				}
+			}
+		}
+		params[i] = p
+	}
+	return i18n.T(m.Key, params...)
+}
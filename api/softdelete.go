@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// IncludeDeletedParam is the query parameter that makes GET routes of
+// @softdelete APIs return soft-deleted records too
+const IncludeDeletedParam = "include_deleted"
+
+type includeDeletedKey struct{}
+
+// WithIncludeDeleted returns a context that asks for soft-deleted records
+func WithIncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// IncludeDeleted reports whether the request asked for soft-deleted records
+// with ?include_deleted=true. Services can use it to skip the deleted_at
+// filter in their queries; generated handlers filter the results either way.
+func IncludeDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
+
+// SoftDeleteContext returns the request context, marked with
+// WithIncludeDeleted when the include_deleted query parameter is true
+func SoftDeleteContext(r *http.Request) context.Context {
+	if include, _ := strconv.ParseBool(r.URL.Query().Get(IncludeDeletedParam)); include {
+		return WithIncludeDeleted(r.Context())
+	}
+	return r.Context()
+}
+
+// IsDeleted reports whether v is a soft-deleted record: it has an
+// IsDeleted() bool method that returns true, or a DeletedAt field of type
+// *time.Time or time.Time that is set. Pointers are followed; anything else
+// is never deleted.
+func IsDeleted(v any) bool {
+	if d, ok := v.(interface{ IsDeleted() bool }); ok {
+		return d.IsDeleted()
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return false
+	}
+	switch f := rv.FieldByName("DeletedAt"); {
+	case !f.IsValid():
+		return false
+	case f.Type() == reflect.TypeOf(&time.Time{}):
+		return !f.IsNil()
+	case f.Type() == reflect.TypeOf(time.Time{}):
+		return !f.Interface().(time.Time).IsZero()
+	}
+	return false
+}
+
+// ExcludeDeleted returns the items that are not soft-deleted
+func ExcludeDeleted[T any](items []T) []T {
+	kept := items[:0:0]
+	for _, item := range items {
+		if !IsDeleted(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -12,11 +12,12 @@ import (
 
 // User represents an authenticated user
 type User struct {
-	ID       string         `json:"id"`
-	Email    string         `json:"email"`
-	Name     string         `json:"name"`
-	Roles    []string       `json:"roles"`
-	Metadata map[string]any `json:"metadata,omitempty"`
+	ID          string         `json:"id"`
+	Email       string         `json:"email"`
+	Name        string         `json:"name"`
+	Roles       []string       `json:"roles"`
+	Permissions []string       `json:"permissions,omitempty"` // granted on top of their roles' (see SetRolePermissions)
+	Metadata    map[string]any `json:"metadata,omitempty"`
 }
 
 // AuthState represents the current authentication state
@@ -29,9 +30,16 @@ type AuthState struct {
 
 // Auth manages authentication state
 type Auth struct {
-	state       AuthState
-	subscribers []func(AuthState)
-	storageKey  string
+	state           AuthState
+	subscribers     []subscriber
+	nextSubscriber  int
+	storageKey      string
+	rolePermissions map[string][]string
+}
+
+type subscriber struct {
+	id int
+	fn func(AuthState)
 }
 
 var globalAuth *Auth
@@ -151,20 +159,69 @@ func HasAnyRole(roles ...string) bool {
 	return false
 }
 
+// SetRolePermissions sets the permissions each role grants, such as
+// "posts:edit", and notifies OnAuthChange subscribers. A permission ending
+// in "*" grants every permission with that prefix, so "posts:*" grants
+// "posts:edit" and "*" grants everything.
+//
+//	auth.SetRolePermissions(map[string][]string{
+//		"admin":  {"*"},
+//		"editor": {"posts:*", "comments:moderate"},
+//		"viewer": {"posts:read"},
+//	})
+func SetRolePermissions(permissions map[string][]string) {
+	auth := GetAuth()
+	auth.rolePermissions = permissions
+	auth.notify()
+}
+
+// Can checks if the user has a permission, directly or through one of
+// their roles. It only decides what the UI offers; the server must check
+// too.
+func Can(permission string) bool {
+	user := GetUser()
+	if user == nil {
+		return false
+	}
+	if grants(user.Permissions, permission) {
+		return true
+	}
+	for _, role := range user.Roles {
+		if grants(GetAuth().rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// grants reports whether any of granted, which may end in "*", matches
+// permission
+func grants(granted []string, permission string) bool {
+	for _, g := range granted {
+		if g == permission || strings.HasSuffix(g, "*") && strings.HasPrefix(permission, strings.TrimSuffix(g, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
 // OnAuthChange subscribes to auth state changes
 func OnAuthChange(fn func(AuthState)) func() {
 	auth := GetAuth()
-	auth.subscribers = append(auth.subscribers, fn)
-	idx := len(auth.subscribers) - 1
+	auth.nextSubscriber++
+	id := auth.nextSubscriber
+	auth.subscribers = append(auth.subscribers, subscriber{id, fn})
 
 	// Call immediately with current state
 	fn(auth.state)
 
 	return func() {
-		auth.subscribers = append(
-			auth.subscribers[:idx],
-			auth.subscribers[idx+1:]...,
-		)
+		for i, s := range auth.subscribers {
+			if s.id == id {
+				auth.subscribers = append(auth.subscribers[:i], auth.subscribers[i+1:]...)
+				return
+			}
+		}
 	}
 }
 
@@ -177,6 +234,21 @@ func SetToken(token string) {
 	auth.notify()
 }
 
+// UseStorageKey keeps the sign-in under key in localStorage instead of
+// "auth_state", switching to the one saved there and notifying
+// OnAuthChange subscribers. components.EnvironmentSwitcher uses it so each
+// backend has its own sign-in.
+func UseStorageKey(key string) {
+	auth := GetAuth()
+	if auth.storageKey == key {
+		return
+	}
+	auth.storageKey = key
+	auth.state = AuthState{}
+	auth.restore()
+	auth.notify()
+}
+
 func (a *Auth) persist() {
 	data, _ := json.Marshal(a.state)
 	js.Global().Get("localStorage").Call("setItem", a.storageKey, string(data))
@@ -197,8 +269,9 @@ func (a *Auth) restore() {
 }
 
 func (a *Auth) notify() {
-	for _, fn := range a.subscribers {
-		fn(a.state)
+	// A subscriber may unsubscribe while being notified
+	for _, s := range append([]subscriber(nil), a.subscribers...) {
+		s.fn(a.state)
 	}
 }
 
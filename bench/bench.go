@@ -0,0 +1,276 @@
+//go:build js && wasm
+
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"syscall/js"
+	"time"
+)
+
+// maxN caps iterations for very fast benchmarks
+const maxN = 100000
+
+type benchmark struct {
+	name string
+	fn   func(*B)
+}
+
+var benchmarks []benchmark
+
+// Register adds a benchmark. Names use "component/case" form, e.g.
+// "table/1k-rows", so budgets and reports group by component.
+func Register(name string, fn func(*B)) {
+	benchmarks = append(benchmarks, benchmark{name: name, fn: fn})
+}
+
+// B is passed to benchmark functions. Loop b.N times over the code being
+// measured; setup before the loop is excluded by calling ResetTimer.
+type B struct {
+	N int
+
+	timerOn  bool
+	start    float64
+	elapsed  float64
+	calls    float64
+	kinds    map[string]float64
+	startCnt counts
+	sandbox  js.Value
+	mounted  []js.Value
+	failed   string
+}
+
+// ResetTimer zeroes the elapsed time and interop count
+func (b *B) ResetTimer() {
+	b.elapsed, b.calls = 0, 0
+	b.kinds = make(map[string]float64)
+	if b.timerOn {
+		b.start = now()
+		b.startCnt = readCounts()
+	}
+}
+
+// StartTimer resumes timing, e.g. after StopTimer around per-iteration setup
+func (b *B) StartTimer() {
+	if b.timerOn {
+		return
+	}
+	b.timerOn = true
+	b.startCnt = readCounts()
+	b.start = now()
+}
+
+// StopTimer pauses timing and interop counting
+func (b *B) StopTimer() {
+	if !b.timerOn {
+		return
+	}
+	b.elapsed += now() - b.start
+	end := readCounts()
+	b.calls += float64(end.total - b.startCnt.total - readOverhead.total)
+	for k, v := range end.byKind {
+		if d := v - b.startCnt.byKind[k] - readOverhead.byKind[k]; d > 0 {
+			b.kinds[k] += float64(d)
+		}
+	}
+	b.timerOn = false
+}
+
+// Mount appends el to an off-screen sandbox and forces layout, so the
+// measurement includes the browser's style and layout work. Whatever the
+// previous iteration mounted is removed first, outside the timer.
+func (b *B) Mount(el js.Value) {
+	if len(b.mounted) > 0 {
+		running := b.timerOn
+		b.StopTimer()
+		b.Unmount()
+		if running {
+			b.StartTimer()
+		}
+	}
+	b.sandbox.Call("appendChild", el)
+	b.sandbox.Get("offsetHeight") // force layout
+	b.mounted = append(b.mounted, el)
+}
+
+// Unmount removes everything mounted so far
+func (b *B) Unmount() {
+	for _, el := range b.mounted {
+		if parent := el.Get("parentNode"); parent.Truthy() {
+			parent.Call("removeChild", el)
+		}
+	}
+	b.mounted = b.mounted[:0]
+}
+
+// Fatal marks the benchmark as failed and stops it
+func (b *B) Fatal(msg string) {
+	b.failed = msg
+	panic(errFatal{})
+}
+
+type errFatal struct{}
+
+// Main runs the registered benchmarks and reports the results to the
+// guxbench page. It blocks forever, like a gux app's main.
+func Main() {
+	global := js.Global()
+	params := global.Get("URLSearchParams").New(global.Get("location").Get("search"))
+
+	benchTime := DefaultBenchTime
+	if v := params.Call("get", "benchtime"); v.Truthy() {
+		if d, err := time.ParseDuration(v.String()); err == nil {
+			benchTime = d
+		}
+	}
+	var filter *regexp.Regexp
+	if v := params.Call("get", "run"); v.Truthy() {
+		filter, _ = regexp.Compile(v.String())
+	}
+
+	sandbox := global.Get("document").Call("createElement", "div")
+	sandbox.Set("id", "guxbench-sandbox")
+	style := sandbox.Get("style")
+	style.Set("position", "absolute")
+	style.Set("left", "-10000px")
+	style.Set("top", "0")
+	style.Set("width", "1280px")
+	global.Get("document").Get("body").Call("appendChild", sandbox)
+
+	calibrate()
+
+	report := Report{
+		Compiler:  params.Call("get", "compiler").String(),
+		UserAgent: global.Get("navigator").Get("userAgent").String(),
+	}
+	for _, bm := range benchmarks {
+		if filter != nil && !filter.MatchString(bm.name) {
+			continue
+		}
+		logf("running %s", bm.name)
+		report.Results = append(report.Results, run(bm, benchTime, sandbox))
+	}
+
+	data, _ := json.Marshal(report)
+	if h := global.Get("__guxbench"); h.Truthy() {
+		h.Call("report", string(data))
+	} else {
+		global.Get("console").Call("log", string(data))
+	}
+	select {}
+}
+
+// run grows N until the benchmark runs for at least benchTime, the way
+// testing.B does
+func run(bm benchmark, benchTime time.Duration, sandbox js.Value) Result {
+	target := float64(benchTime.Milliseconds())
+	n := 1
+	for {
+		b, err := runN(bm, n, sandbox)
+		if err != "" {
+			return Result{Name: bm.name, Error: err}
+		}
+		if b.elapsed >= target || n >= maxN {
+			res := Result{
+				Name:       bm.name,
+				N:          n,
+				MsPerOp:    round(b.elapsed / float64(n)),
+				CallsPerOp: round(b.calls / float64(n)),
+			}
+			if len(b.kinds) > 0 {
+				res.CallsByKind = make(map[string]float64, len(b.kinds))
+				for k, v := range b.kinds {
+					res.CallsByKind[k] = round(v / float64(n))
+				}
+			}
+			return res
+		}
+		// Predict the N that reaches the target, growing at most 100x
+		next := n * 100
+		if b.elapsed > 0 {
+			next = int(target/(b.elapsed/float64(n))*1.2) + 1
+		}
+		if next > n*100 {
+			next = n * 100
+		}
+		if next <= n {
+			next = n + 1
+		}
+		if next > maxN {
+			next = maxN
+		}
+		n = next
+	}
+}
+
+func runN(bm benchmark, n int, sandbox js.Value) (b *B, failure string) {
+	b = &B{N: n, sandbox: sandbox, kinds: make(map[string]float64)}
+	defer func() {
+		b.Unmount()
+		sandbox.Set("innerHTML", "")
+		if r := recover(); r != nil {
+			if _, ok := r.(errFatal); ok {
+				failure = b.failed
+				return
+			}
+			failure = fmt.Sprint("panic: ", r)
+		}
+	}()
+	b.StartTimer()
+	bm.fn(b)
+	b.StopTimer()
+	return b, ""
+}
+
+type counts struct {
+	total  int
+	byKind map[string]int
+}
+
+// readOverhead is the interop calls a StartTimer/StopTimer pair makes
+// itself, subtracted from every measurement
+var readOverhead counts
+
+func calibrate() {
+	a := readCounts()
+	now()
+	now()
+	b := readCounts()
+	readOverhead = counts{total: b.total - a.total, byKind: make(map[string]int)}
+	for k, v := range b.byKind {
+		readOverhead.byKind[k] = v - a.byKind[k]
+	}
+}
+
+// readCounts reads the harness's total and per-kind interop counters
+func readCounts() counts {
+	h := js.Global().Get("__guxbench")
+	if !h.Truthy() {
+		return counts{}
+	}
+	snap := h.Call("snapshot")
+	c := counts{total: snap.Index(0).Int(), byKind: make(map[string]int)}
+	if kinds := snap.Index(1).String(); kinds != "" {
+		var m map[string]int
+		if json.Unmarshal([]byte(kinds), &m) == nil {
+			c.byKind = m
+		}
+	}
+	return c
+}
+
+func now() float64 {
+	return js.Global().Get("performance").Call("now").Float()
+}
+
+func round(v float64) float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'f', 3, 64), 64)
+	return f
+}
+
+func logf(format string, args ...any) {
+	js.Global().Get("console").Call("log", "guxbench: "+fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,41 @@
+// Package bench measures component render time and JS interop calls in the
+// browser. Benchmarks are written like testing.B benchmarks and run by the
+// guxbench command, which builds them with Go and TinyGo, runs them in
+// headless Chrome, and checks the results against performance budgets.
+//
+//	func main() {
+//		bench.Register("table/1k-rows", func(b *bench.B) {
+//			rows := makeRows(1000)
+//			b.ResetTimer()
+//			for i := 0; i < b.N; i++ {
+//				b.Mount(components.NewTable(components.TableProps{Columns: cols, Data: rows}).Element())
+//			}
+//		})
+//		bench.Main()
+//	}
+//
+// Interop calls are counted by the guxbench page, which wraps the
+// syscall/js imports of the WASM module. Outside that page the count is 0.
+package bench
+
+import "time"
+
+// DefaultBenchTime is how long each benchmark runs when the page does not set one
+const DefaultBenchTime = 300 * time.Millisecond
+
+// Result is the measurement of one benchmark
+type Result struct {
+	Name        string             `json:"name"`
+	N           int                `json:"n"`
+	MsPerOp     float64            `json:"msPerOp"`
+	CallsPerOp  float64            `json:"callsPerOp"`
+	CallsByKind map[string]float64 `json:"callsByKind,omitempty"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// Report is what a benchmark program sends back to guxbench
+type Report struct {
+	Compiler  string   `json:"compiler"`
+	UserAgent string   `json:"userAgent"`
+	Results   []Result `json:"results"`
+}
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -17,28 +23,60 @@ type InterfaceInfo struct {
 	Name       string
 	ClientName string
 	BasePath   string
+	Doc        string   // doc comment without annotations
+	SoftDelete bool     // @softdelete: GET routes hide records with DeletedAt set
+	Auth       bool     // @auth: every route requires a signed-in user
+	Roles      []string // @roles: every route requires one of these roles
 	Methods    []MethodInfo
 }
 
 // PathParam represents a path parameter with its name and type
 type PathParam struct {
-	Name   string
-	Type   string // "int" or "string"
-	IsInt  bool   // convenience field for templates
+	Name  string
+	Type  string // int, int64, string, or a type implementing encoding.TextUnmarshaler and fmt.Stringer
+	IsInt bool   // int or int64, parsed with strconv
+}
+
+// Arg is a method argument after ctx, in declaration order
+type Arg struct {
+	Name string
+	Type string
+	Kind string // "path", "query", "params" (query struct), or "body"
+}
+
+// QueryParam is a URL query parameter, from an @query annotation or a field
+// of a Params struct argument
+type QueryParam struct {
+	Key     string   // key in the URL
+	Expr    string   // Go expression holding the value, e.g. "page" or "params.Page"
+	Type    string   // string, int, int64, float64, bool, []string, or filter.Filter
+	Default string   // Go literal applied by the server when the key is absent
+	IsField bool     // Expr is a struct field rather than an argument
+	Fields  []string // for filter.Filter: the fields from @filter, if any
 }
 
 type MethodInfo struct {
-	Name       string
-	HTTPMethod string
-	Path       string
-	PathParams []PathParam
-	HasBody    bool
-	BodyParam  string
-	BodyType   string
-	ReturnType string
-	IsPointer  bool
-	IsSlice    bool
-	HasReturn  bool
+	Name        string
+	Doc         string // doc comment without annotations
+	HTTPMethod  string
+	Path        string
+	PathParams  []PathParam
+	QueryParams []QueryParam
+	Args        []Arg
+	HasBody     bool
+	BodyParam   string
+	BodyType    string
+	ReturnType  string
+	IsPointer   bool
+	IsSlice     bool
+	HasReturn   bool
+	IsStream    bool   // returns <-chan T, served as Server-Sent Events; ReturnType is T
+	PagesFunc   string // for cursor-paginated methods, the api.Pages function to wrap, e.g. "gqapi.Pages"
+
+	// Access, from @auth, @roles and @public on the method or else its
+	// interface. Roles imply Auth.
+	Auth  bool
+	Roles []string
 }
 
 // GenerateAPI generates client and server code from a source file
@@ -57,13 +95,29 @@ func GenerateAPI(sourceFile, outputFile string) error {
 	}
 
 	// Find interfaces with @client annotation
-	interfaces := findInterfaces(node)
+	interfaces, err := findInterfaces(node)
+	if err != nil {
+		return err
+	}
 	if len(interfaces) == 0 {
 		return fmt.Errorf("no interfaces with @client annotation found")
 	}
 
+	// Path parameters and results may use types from other packages, such
+	// as api.UUID or api.Page[Post]. The server only names path parameter
+	// types.
+	var pathTypes, resultTypes []string
+	for _, iface := range interfaces {
+		for _, method := range iface.Methods {
+			for _, p := range method.PathParams {
+				pathTypes = append(pathTypes, p.Type)
+			}
+			resultTypes = append(resultTypes, method.ReturnType)
+		}
+	}
+
 	// Generate client code
-	clientCode, err := generateClientCode(interfaces)
+	clientCode, err := generateClientCode(interfaces, typeImports(node, append(pathTypes, resultTypes...)))
 	if err != nil {
 		return fmt.Errorf("generate client: %w", err)
 	}
@@ -74,7 +128,7 @@ func GenerateAPI(sourceFile, outputFile string) error {
 	fmt.Printf("    generated: %s\n", clientPath)
 
 	// Generate server code
-	serverCode, err := generateServerCode(interfaces)
+	serverCode, err := generateServerCode(interfaces, typeImports(node, pathTypes))
 	if err != nil {
 		return fmt.Errorf("generate server: %w", err)
 	}
@@ -88,12 +142,64 @@ func GenerateAPI(sourceFile, outputFile string) error {
 	return nil
 }
 
-func findInterfaces(node *ast.File) []InterfaceInfo {
+// typeImports returns the import specs, as written in the source file, of
+// the packages that the types come from
+func typeImports(node *ast.File, types []string) []string {
+	qualifier := regexp.MustCompile(`(\w+)\.`)
+	used := make(map[string]bool)
+	for _, typ := range types {
+		for _, match := range qualifier.FindAllStringSubmatch(typ, -1) {
+			used[match[1]] = true
+		}
+	}
+
+	var specs []string
+	for _, imp := range node.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if !used[name] {
+			continue
+		}
+		if imp.Name != nil {
+			specs = append(specs, imp.Name.Name+" "+imp.Path.Value)
+		} else {
+			specs = append(specs, imp.Path.Value)
+		}
+	}
+	return specs
+}
+
+func findInterfaces(node *ast.File) ([]InterfaceInfo, error) {
 	var interfaces []InterfaceInfo
 
 	clientRegex := regexp.MustCompile(`@client\s+(\w+)`)
 	basepathRegex := regexp.MustCompile(`@basepath\s+(\S+)`)
 	routeRegex := regexp.MustCompile(`@route\s+(GET|POST|PUT|DELETE|PATCH)\s+(\S+)`)
+	queryRegex := regexp.MustCompile(`@query\s+(.+)`)
+	filterRegex := regexp.MustCompile(`@filter\s+(.+)`)
+	softDeleteRegex := regexp.MustCompile(`@softdelete\b`)
+	authRegex := regexp.MustCompile(`@auth\b`)
+	rolesRegex := regexp.MustCompile(`@roles\s+(.+)`)
+	publicRegex := regexp.MustCompile(`@public\b`)
+
+	structs := findStructs(node)
+
+	// The name github.com/dougbarrett/gux/api is imported as, for api.Page
+	var guxAPI string
+	for _, imp := range node.Imports {
+		if imp.Path.Value == `"github.com/dougbarrett/gux/api"` {
+			guxAPI = "api"
+			if imp.Name != nil {
+				guxAPI = imp.Name.Name
+			}
+		}
+	}
 
 	for _, decl := range node.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
@@ -114,6 +220,8 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 
 			// Check for @client annotation in doc comments
 			var clientName, basePath string
+			var softDelete, auth bool
+			var roles []string
 			if genDecl.Doc != nil {
 				for _, comment := range genDecl.Doc.List {
 					if match := clientRegex.FindStringSubmatch(comment.Text); match != nil {
@@ -122,6 +230,15 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 					if match := basepathRegex.FindStringSubmatch(comment.Text); match != nil {
 						basePath = match[1]
 					}
+					if softDeleteRegex.MatchString(comment.Text) {
+						softDelete = true
+					}
+					if authRegex.MatchString(comment.Text) {
+						auth = true
+					}
+					if match := rolesRegex.FindStringSubmatch(comment.Text); match != nil {
+						roles = splitList(match[1])
+					}
 				}
 			}
 
@@ -133,6 +250,10 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 				Name:       typeSpec.Name.Name,
 				ClientName: clientName,
 				BasePath:   basePath,
+				Doc:        docText(genDecl.Doc),
+				SoftDelete: softDelete,
+				Auth:       auth || len(roles) > 0,
+				Roles:      roles,
 			}
 
 			// Parse methods
@@ -147,19 +268,53 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 				}
 
 				methodInfo := MethodInfo{
-					Name: method.Names[0].Name,
+					Name:  method.Names[0].Name,
+					Doc:   docText(method.Doc),
+					Auth:  info.Auth,
+					Roles: info.Roles,
 				}
 
-				// Parse route annotation from comments
+				// Parse route and query annotations from comments
+				queryDefaults := make(map[string]string) // query param name -> default
+				var filterFields []string                // fields allowed in filter.Filter arguments
 				if method.Doc != nil {
 					for _, comment := range method.Doc.List {
 						if match := routeRegex.FindStringSubmatch(comment.Text); match != nil {
 							methodInfo.HTTPMethod = match[1]
 							methodInfo.Path = match[2]
 						}
+						if match := queryRegex.FindStringSubmatch(comment.Text); match != nil {
+							for _, item := range strings.Split(match[1], ",") {
+								name, def, _ := strings.Cut(strings.TrimSpace(item), "=")
+								if name != "" {
+									queryDefaults[name] = def
+								}
+							}
+						}
+						if match := filterRegex.FindStringSubmatch(comment.Text); match != nil {
+							filterFields = append(filterFields, splitList(match[1])...)
+						}
+						if authRegex.MatchString(comment.Text) {
+							methodInfo.Auth = true
+						}
+						if match := rolesRegex.FindStringSubmatch(comment.Text); match != nil {
+							methodInfo.Auth = true
+							methodInfo.Roles = splitList(match[1])
+						}
+						if publicRegex.MatchString(comment.Text) {
+							methodInfo.Auth = false
+							methodInfo.Roles = nil
+						}
 					}
 				}
 
+				// Restore on a @softdelete interface defaults to POST /{id}/restore
+				if methodInfo.HTTPMethod == "" && softDelete && methodInfo.Name == "Restore" &&
+					funcType.Params != nil && len(funcType.Params.List) > 1 && len(funcType.Params.List[1].Names) > 0 {
+					methodInfo.HTTPMethod = "POST"
+					methodInfo.Path = "/{" + funcType.Params.List[1].Names[0].Name + "}/restore"
+				}
+
 				if methodInfo.HTTPMethod == "" {
 					continue
 				}
@@ -178,27 +333,59 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 						if i == 0 {
 							continue // Skip context
 						}
-						if len(param.Names) == 0 {
-							continue
+						// Grouped parameters such as "page, limit int" share one field
+						for _, name := range param.Names {
+							paramName := name.Name
+							paramType := exprToString(param.Type)
+							if reservedArgs[paramName] || strings.HasPrefix(paramName, "gq") {
+								return nil, fmt.Errorf("%s.%s: argument name %q is used by the generated code; rename it", typeSpec.Name.Name, methodInfo.Name, paramName)
+							}
+
+							if pathParamNames[paramName] {
+								// This is a path parameter - store with its type
+								isInt := paramType == "int" || paramType == "int64"
+								methodInfo.PathParams = append(methodInfo.PathParams, PathParam{
+									Name:  paramName,
+									Type:  paramType,
+									IsInt: isInt,
+								})
+								methodInfo.Args = append(methodInfo.Args, Arg{Name: paramName, Type: paramType, Kind: "path"})
+							} else if def, ok := queryDefaults[paramName]; ok || paramType == "filter.Filter" {
+								// Listed in @query, or a filter sent as a query parameter
+								qp, err := newQueryParam(paramName, paramName, paramType, def)
+								if err != nil {
+									return nil, fmt.Errorf("%s.%s: %w", typeSpec.Name.Name, methodInfo.Name, err)
+								}
+								qp.Fields = filterFields
+								methodInfo.QueryParams = append(methodInfo.QueryParams, qp)
+								methodInfo.Args = append(methodInfo.Args, Arg{Name: paramName, Type: paramType, Kind: "query"})
+							} else if fields, ok := structs[paramType]; ok && strings.HasSuffix(paramType, "Params") {
+								// A Params struct: every field is a query parameter
+								for _, f := range fields {
+									qp, err := newQueryParam(f.key, paramName+"."+f.name, f.typ, f.def)
+									if err != nil {
+										return nil, fmt.Errorf("%s.%s: %s.%s: %w", typeSpec.Name.Name, methodInfo.Name, paramType, f.name, err)
+									}
+									qp.IsField = true
+									qp.Fields = filterFields
+									methodInfo.QueryParams = append(methodInfo.QueryParams, qp)
+								}
+								methodInfo.Args = append(methodInfo.Args, Arg{Name: paramName, Type: paramType, Kind: "params"})
+							} else {
+								// Not a path or query param - must be body
+								methodInfo.HasBody = true
+								methodInfo.BodyParam = paramName
+								methodInfo.BodyType = paramType
+								methodInfo.Args = append(methodInfo.Args, Arg{Name: paramName, Type: paramType, Kind: "body"})
+							}
 						}
+					}
+				}
 
-						paramName := param.Names[0].Name
-						paramType := exprToString(param.Type)
-
-						if pathParamNames[paramName] {
-							// This is a path parameter - store with its type
-							isInt := paramType == "int"
-							methodInfo.PathParams = append(methodInfo.PathParams, PathParam{
-								Name:  paramName,
-								Type:  paramType,
-								IsInt: isInt,
-							})
-						} else {
-							// Not a path param - must be body
-							methodInfo.HasBody = true
-							methodInfo.BodyParam = paramName
-							methodInfo.BodyType = paramType
-						}
+				// Every @query name must be an argument
+				for _, name := range slices.Sorted(maps.Keys(queryDefaults)) {
+					if !hasArg(methodInfo, name) {
+						return nil, fmt.Errorf("%s.%s: @query %s is not an argument of the method", typeSpec.Name.Name, methodInfo.Name, name)
 					}
 				}
 
@@ -207,8 +394,16 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 					firstResult := funcType.Results.List[0]
 					returnType := exprToString(firstResult.Type)
 
-					// If return is just "error", there's no data return
-					if returnType != "error" {
+					if ch, ok := firstResult.Type.(*ast.ChanType); ok {
+						// A receive channel streams its values as Server-Sent Events
+						if ch.Dir != ast.RECV || methodInfo.HTTPMethod != "GET" || methodInfo.HasBody {
+							return nil, fmt.Errorf("%s.%s: streaming methods return <-chan T from a GET route without a body", typeSpec.Name.Name, methodInfo.Name)
+						}
+						methodInfo.ReturnType = fieldType(ch.Value)
+						methodInfo.HasReturn = true
+						methodInfo.IsStream = true
+					} else if returnType != "error" {
+						// If return is just "error", there's no data return
 						methodInfo.ReturnType = returnType
 						methodInfo.HasReturn = true
 
@@ -222,14 +417,305 @@ func findInterfaces(node *ast.File) []InterfaceInfo {
 					}
 				}
 
+				// An api.Page result with a cursor query parameter gets a Pages
+				// iterator on the client
+				if qual, ok := strings.CutSuffix(strings.SplitN(methodInfo.ReturnType, "[", 2)[0], ".Page"); ok &&
+					guxAPI != "" && qual == guxAPI && !methodInfo.IsPointer && !methodInfo.IsSlice && hasCursorArg(methodInfo) {
+					methodInfo.PagesFunc = guxAPI + ".Pages"
+				}
+
 				info.Methods = append(info.Methods, methodInfo)
 			}
 
+			if softDelete && !hasMethod(info, "Restore") {
+				return nil, fmt.Errorf("%s: @softdelete needs a Restore method, e.g. Restore(ctx context.Context, id int) (*T, error)", info.Name)
+			}
+
 			interfaces = append(interfaces, info)
 		}
 	}
 
-	return interfaces
+	return interfaces, nil
+}
+
+// reservedArgs are the names the generated client and handlers use
+// besides their gq-prefixed locals: receivers, the handler's parameters,
+// and the packages they call
+var reservedArgs = map[string]bool{
+	"c": true, "h": true, "w": true, "r": true,
+	"fmt": true, "url": true, "strconv": true, "strings": true, "json": true, "http": true, "filter": true,
+}
+
+// hasArg reports whether method has an argument called name
+func hasArg(method MethodInfo, name string) bool {
+	for _, a := range method.Args {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// docText returns a doc comment's text without its annotation lines
+func docText(doc *ast.CommentGroup) string {
+	var lines []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "@") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// splitList splits a comma-separated annotation value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// hasCursorArg reports whether a method takes a cursor string query
+// parameter
+func hasCursorArg(method MethodInfo) bool {
+	for _, a := range method.Args {
+		if a.Name == "cursor" && a.Type == "string" && a.Kind == "query" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMethod reports whether the interface has a routed method with the name
+func hasMethod(info InterfaceInfo, name string) bool {
+	for _, m := range info.Methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// structField is an exported field of a struct declared in the source file
+type structField struct {
+	name string
+	typ  string
+	key  string // from the query tag, else the json tag, else the name with a lowercase first letter
+	def  string // from the default tag
+}
+
+// findStructs collects the struct types declared in the file, so Params
+// struct arguments can be expanded into query parameters
+func findStructs(node *ast.File) map[string][]structField {
+	structs := make(map[string][]structField)
+	ast.Inspect(node, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		var fields []structField
+		for _, field := range st.Fields.List {
+			var tag reflect.StructTag
+			if field.Tag != nil {
+				if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+					tag = reflect.StructTag(unquoted)
+				}
+			}
+			key, _, _ := strings.Cut(tag.Get("query"), ",")
+			if key == "-" {
+				continue
+			}
+			if key == "" {
+				key, _, _ = strings.Cut(tag.Get("json"), ",")
+				if key == "-" {
+					key = ""
+				}
+			}
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+				k := key
+				if k == "" {
+					k = strings.ToLower(name.Name[:1]) + name.Name[1:]
+				}
+				fields = append(fields, structField{name: name.Name, typ: exprToString(field.Type), key: k, def: tag.Get("default")})
+			}
+		}
+		structs[typeSpec.Name.Name] = fields
+		return false
+	})
+	return structs
+}
+
+// newQueryParam validates a query parameter's type and turns its default
+// into a Go literal
+func newQueryParam(key, expr, typ, def string) (QueryParam, error) {
+	qp := QueryParam{Key: key, Expr: expr, Type: typ}
+	if def == "" {
+		switch typ {
+		case "string", "int", "int64", "float64", "bool", "[]string", "filter.Filter":
+			return qp, nil
+		}
+		return qp, fmt.Errorf("query parameter %s: unsupported type %q (use string, int, int64, float64, bool, []string, or filter.Filter)", key, typ)
+	}
+
+	var err error
+	switch typ {
+	case "string":
+		qp.Default = strconv.Quote(def)
+	case "int", "int64":
+		_, err = strconv.ParseInt(def, 10, 64)
+		qp.Default = def
+	case "float64":
+		_, err = strconv.ParseFloat(def, 64)
+		qp.Default = def
+	case "bool":
+		// Clients omit false, so a true default could never be turned off
+		if def != "false" {
+			err = fmt.Errorf("bool parameters must default to false")
+		}
+	case "[]string", "filter.Filter":
+		err = fmt.Errorf("%s parameters cannot have a default", typ)
+	default:
+		err = fmt.Errorf("unsupported type %q (use string, int, int64, float64, bool, []string, or filter.Filter)", typ)
+	}
+	if err != nil {
+		return qp, fmt.Errorf("query parameter %s: invalid default %q: %w", key, def, err)
+	}
+	return qp, nil
+}
+
+// clientQueryCode builds the statements that append a method's query
+// parameters to path. Zero values are left out so the server's default
+// applies.
+func clientQueryCode(params []QueryParam) string {
+	var b strings.Builder
+	b.WriteString("\tgqQuery := url.Values{}\n")
+	for _, p := range params {
+		switch p.Type {
+		case "string":
+			fmt.Fprintf(&b, "\tif %s != \"\" {\n\t\tgqQuery.Set(%q, %s)\n\t}\n", p.Expr, p.Key, p.Expr)
+		case "int":
+			fmt.Fprintf(&b, "\tif %s != 0 {\n\t\tgqQuery.Set(%q, strconv.Itoa(%s))\n\t}\n", p.Expr, p.Key, p.Expr)
+		case "int64":
+			fmt.Fprintf(&b, "\tif %s != 0 {\n\t\tgqQuery.Set(%q, strconv.FormatInt(%s, 10))\n\t}\n", p.Expr, p.Key, p.Expr)
+		case "float64":
+			fmt.Fprintf(&b, "\tif %s != 0 {\n\t\tgqQuery.Set(%q, strconv.FormatFloat(%s, 'f', -1, 64))\n\t}\n", p.Expr, p.Key, p.Expr)
+		case "bool":
+			fmt.Fprintf(&b, "\tif %s {\n\t\tgqQuery.Set(%q, \"true\")\n\t}\n", p.Expr, p.Key)
+		case "[]string":
+			fmt.Fprintf(&b, "\tfor _, gqV := range %s {\n\t\tgqQuery.Add(%q, gqV)\n\t}\n", p.Expr, p.Key)
+		case "filter.Filter":
+			fmt.Fprintf(&b, "\tif !%s.IsZero() {\n\t\tgqQuery.Set(%q, %s.String())\n\t}\n", p.Expr, p.Key, p.Expr)
+		}
+	}
+	b.WriteString("\tif len(gqQuery) > 0 {\n\t\tgqPath += \"?\" + gqQuery.Encode()\n\t}\n")
+	return b.String()
+}
+
+// pathParamIndex finds the index of a parameter in the path parts, e.g.
+// in "/{userId}/posts/{postId}" userId is at index 0 and postId at index 2
+func pathParamIndex(path, param string) int {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == "{"+param+"}" {
+			return i
+		}
+	}
+	return 0
+}
+
+// serverPathCode builds the statements that parse a method's path
+// parameters. Malformed IDs get a 400 with code "invalid_id" before the
+// service is called.
+func serverPathCode(method MethodInfo) string {
+	var b strings.Builder
+	for _, p := range method.PathParams {
+		part := fmt.Sprintf("gqParts[%d]", pathParamIndex(method.Path, p.Name))
+		switch p.Type {
+		case "string":
+			fmt.Fprintf(&b, "\t%s := %s\n", p.Name, part)
+		case "int":
+			fmt.Fprintf(&b, "\t%s, gqErr := strconv.Atoi(%s)\n", p.Name, part)
+			fmt.Fprintf(&b, "\tif gqErr != nil {\n\t\tgqapi.WriteError(w, gqapi.InvalidID(%q, \"must be an integer\"))\n\t\treturn\n\t}\n", p.Name)
+		case "int64":
+			fmt.Fprintf(&b, "\t%s, gqErr := strconv.ParseInt(%s, 10, 64)\n", p.Name, part)
+			fmt.Fprintf(&b, "\tif gqErr != nil {\n\t\tgqapi.WriteError(w, gqapi.InvalidID(%q, \"must be an integer\"))\n\t\treturn\n\t}\n", p.Name)
+		default:
+			// IDs such as api.UUID and api.HashID parse themselves
+			fmt.Fprintf(&b, "\tvar %s %s\n", p.Name, p.Type)
+			fmt.Fprintf(&b, "\tif gqErr := %s.UnmarshalText([]byte(%s)); gqErr != nil {\n", p.Name, part)
+			fmt.Fprintf(&b, "\t\tgqapi.WriteError(w, gqapi.InvalidID(%q, gqErr.Error()))\n\t\treturn\n\t}\n", p.Name)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// serverQueryCode builds the statements that parse a method's query
+// parameters, applying defaults for absent keys and rejecting bad values
+func serverQueryCode(method MethodInfo) string {
+	var b strings.Builder
+	b.WriteString("\tgqQuery := r.URL.Query()\n")
+	for _, a := range method.Args {
+		if a.Kind == "params" {
+			fmt.Fprintf(&b, "\tvar %s %s\n", a.Name, a.Type)
+		}
+	}
+	for _, p := range method.QueryParams {
+		switch {
+		case p.IsField && p.Default != "":
+			fmt.Fprintf(&b, "\t%s = %s\n", p.Expr, p.Default)
+		case !p.IsField && p.Default != "" && (p.Type == "int" || p.Type == "string"):
+			fmt.Fprintf(&b, "\t%s := %s\n", p.Expr, p.Default)
+		case !p.IsField && p.Default != "":
+			fmt.Fprintf(&b, "\t%s := %s(%s)\n", p.Expr, p.Type, p.Default)
+		case !p.IsField:
+			fmt.Fprintf(&b, "\tvar %s %s\n", p.Expr, p.Type)
+		}
+
+		parse, what := "", ""
+		switch p.Type {
+		case "string":
+			fmt.Fprintf(&b, "\tif gqV := gqQuery.Get(%q); gqV != \"\" {\n\t\t%s = gqV\n\t}\n", p.Key, p.Expr)
+			continue
+		case "[]string":
+			fmt.Fprintf(&b, "\tif gqVs := gqQuery[%q]; len(gqVs) > 0 {\n\t\t%s = gqVs\n\t}\n", p.Key, p.Expr)
+			continue
+		case "filter.Filter":
+			// Parse checks the fields against @filter
+			args := ""
+			for _, f := range p.Fields {
+				args += ", " + strconv.Quote(f)
+			}
+			fmt.Fprintf(&b, "\tif gqV := gqQuery.Get(%q); gqV != \"\" {\n", p.Key)
+			fmt.Fprintf(&b, "\t\tgqParsed, gqErr := filter.Parse(gqV%s)\n", args)
+			fmt.Fprintf(&b, "\t\tif gqErr != nil {\n\t\t\tgqapi.WriteError(w, gqapi.BadRequest(\"invalid %s: \"+gqErr.Error()))\n\t\t\treturn\n\t\t}\n", p.Key)
+			fmt.Fprintf(&b, "\t\t%s = gqParsed\n\t}\n", p.Expr)
+			continue
+		case "int":
+			parse, what = "strconv.Atoi(gqV)", "an integer"
+		case "int64":
+			parse, what = "strconv.ParseInt(gqV, 10, 64)", "an integer"
+		case "float64":
+			parse, what = "strconv.ParseFloat(gqV, 64)", "a number"
+		case "bool":
+			parse, what = "strconv.ParseBool(gqV)", "a boolean"
+		}
+		fmt.Fprintf(&b, "\tif gqV := gqQuery.Get(%q); gqV != \"\" {\n", p.Key)
+		fmt.Fprintf(&b, "\t\tgqParsed, gqErr := %s\n", parse)
+		fmt.Fprintf(&b, "\t\tif gqErr != nil {\n\t\t\tgqapi.WriteError(w, gqapi.BadRequest(\"invalid %s: must be %s\"))\n\t\t\treturn\n\t\t}\n", p.Key, what)
+		fmt.Fprintf(&b, "\t\t%s = gqParsed\n\t}\n", p.Expr)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 func exprToString(expr ast.Expr) string {
@@ -242,6 +728,14 @@ func exprToString(expr ast.Expr) string {
 		return "[]" + exprToString(t.Elt)
 	case *ast.SelectorExpr:
 		return exprToString(t.X) + "." + t.Sel.Name
+	case *ast.IndexExpr:
+		return exprToString(t.X) + "[" + exprToString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		var args []string
+		for _, index := range t.Indices {
+			args = append(args, exprToString(index))
+		}
+		return exprToString(t.X) + "[" + strings.Join(args, ", ") + "]"
 	default:
 		return ""
 	}
@@ -255,12 +749,125 @@ func GenerateClientSharedCode() (string, error) {
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	gqapi "github.com/dougbarrett/gux/api"
 	"github.com/dougbarrett/gux/fetch"
+	"github.com/dougbarrett/gux/fetch/sse"
 )
 
+// APIError is a non-2xx response. Code, Message, and Fields come from the
+// JSON error body written by the server; use AsAPIError or errors.As to get
+// at them. The prefix keeps it clear of an Error type of your own.
+// Messages the server sent with i18n keys are translated in the current
+// locale.
+type APIError struct {
+	Status    int                      // HTTP status code
+	Code      string                   // machine-readable code, e.g. "not_found"
+	Message   string                   // human-readable message
+	Fields    map[string]string        // validation messages keyed by field name
+	FieldKeys map[string]gqapi.Message // i18n keys of Fields, if the server sent them
+	RequestID string                   // server's request ID, to find its logs
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Reference returns the request ID, which components.ShowErrorToast shows
+// for the user to quote to support
+func (e *APIError) Reference() string {
+	return e.RequestID
+}
+
+// ServerError reports whether the server failed (5xx), in which case its
+// message isn't meant for users
+func (e *APIError) ServerError() bool {
+	return e.Status >= 500
+}
+
+// Field returns the validation message for a field, or "". Keyed messages
+// are translated in the locale current when it is called.
+func (e *APIError) Field(name string) string {
+	if msg, ok := e.FieldKeys[name]; ok {
+		return msg.String()
+	}
+	return e.Fields[name]
+}
+
+// Unavailable reports whether the server was down for maintenance (503) or
+// rate limiting the client (429). A components.ServiceBanner already tells
+// the user, so callers can skip their own error message.
+func (e *APIError) Unavailable() bool {
+	return e.Status == 503 || e.Status == 429
+}
+
+// AsAPIError returns the API error in err's chain, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// errorBody relies on encoding/json's case-insensitive field matching
+type errorBody struct {
+	Code      string
+	Message   string
+	Key       string
+	Params    []any
+	Fields    map[string]string
+	FieldKeys map[string]gqapi.Message
+	RequestID string
+}
+
+// responseError builds an APIError from a non-2xx response. It reads the
+// {"error": {...}} body written by gux servers and falls back to a flat
+// {"code": ..., "message": ...} body.
+func responseError(resp *fetch.Response) error {
+	var body errorBody
+	var envelope struct {
+		Error *errorBody
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &envelope); err == nil && envelope.Error != nil {
+		body = *envelope.Error
+	} else {
+		json.Unmarshal([]byte(resp.Body), &body)
+	}
+
+	apiErr := &APIError{
+		Status:    resp.Status,
+		Code:      body.Code,
+		Message:   body.Message,
+		Fields:    body.Fields,
+		FieldKeys: body.FieldKeys,
+		RequestID: body.RequestID,
+	}
+	// Translate keyed messages through the client's i18n catalogs, so one
+	// server serves every locale
+	if body.Key != "" {
+		apiErr.Message = gqapi.Msg(body.Key, body.Params...).String()
+	}
+	for field, msg := range body.FieldKeys {
+		if apiErr.Fields == nil {
+			apiErr.Fields = make(map[string]string)
+		}
+		apiErr.Fields[field] = msg.String()
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header("X-Request-ID")
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected status %d: %s", resp.Status, resp.StatusText)
+	}
+	return apiErr
+}
+
 // ClientOption configures a client
 type ClientOption func(*clientConfig)
 
@@ -269,6 +876,9 @@ type clientConfig struct {
 	basePath     string
 	headers      map[string]string
 	authProvider func() string
+	timeout      time.Duration
+	retries      int
+	dedupe       *requestGroup
 }
 
 // WithBaseURL sets the base URL for API calls (e.g., "https://api.example.com")
@@ -304,10 +914,148 @@ func WithAuthProvider(provider func() string) ClientOption {
 	}
 }
 
-func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, error) {
+// WithTimeout aborts requests that take longer than d. Per-call deadlines
+// can also be set on the context passed to each method.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = d
+	}
+}
+
+// WithRetries retries requests that fail with a network error, 429, or 5xx
+// up to n times, with exponential backoff. POST and PATCH are only retried
+// when the context carries an idempotency key (see fetch.WithIdempotencyKey).
+func WithRetries(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.retries = n
+	}
+}
+
+// WithDedupe makes identical GET requests share one network request: a
+// call made while the same request is in flight waits for it and gets the
+// same result, so several components loading the same data at once cost
+// one fetch. With a window above zero, a response is also reused for that
+// long after it arrives, which covers calls made one after another in a
+// render pass. Any other request through the client clears reused
+// responses, so reads after a write see it.
+func WithDedupe(window time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.dedupe = &requestGroup{window: window, calls: make(map[string]*sharedCall)}
+	}
+}
+
+type noDedupeKey struct{}
+
+// NoDedupe returns a context whose calls always make their own request,
+// on clients created WithDedupe
+func NoDedupe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDedupeKey{}, true)
+}
+
+// requestGroup shares GET responses between callers
+type requestGroup struct {
+	mu     sync.Mutex
+	window time.Duration
+	calls  map[string]*sharedCall
+}
+
+type sharedCall struct {
+	done     chan struct{}
+	resp     *fetch.Response
+	err      error
+	finished time.Time
+}
+
+// do returns the response of the call for key, making it with fn unless it
+// is in flight or finished within the window
+func (g *requestGroup) do(ctx context.Context, key string, fn func() (*fetch.Response, error)) (*fetch.Response, error) {
+	g.mu.Lock()
+	now := time.Now()
+	for k, c := range g.calls {
+		if !c.finished.IsZero() && now.Sub(c.finished) > g.window {
+			delete(g.calls, k)
+		}
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &sharedCall{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			c.resp, c.err = fn()
+			g.mu.Lock()
+			c.finished = time.Now()
+			// Failures go to the callers waiting now but are not reused
+			if (g.window == 0 || c.err != nil || !c.resp.OK) && g.calls[key] == c {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.resp, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forget drops reusable responses and keeps new calls from joining ones in flight
+func (g *requestGroup) forget() {
+	g.mu.Lock()
+	g.calls = make(map[string]*sharedCall)
+	g.mu.Unlock()
+}
+
+// send performs a request, sharing identical GETs on clients created WithDedupe
+func send(ctx context.Context, cfg *clientConfig, url string, opts *fetch.Options) (*fetch.Response, error) {
+	if cfg.dedupe == nil {
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	if opts.Method != "GET" {
+		defer cfg.dedupe.forget()
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	if skip, _ := ctx.Value(noDedupeKey{}).(bool); skip {
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	key := url + "\n" + opts.Headers["Authorization"]
+	return cfg.dedupe.do(ctx, key, func() (*fetch.Response, error) {
+		// The request is shared, so one caller canceling must not fail the
+		// others; each caller still stops waiting when its own ctx is done
+		return fetch.FetchContext(context.WithoutCancel(ctx), url, opts)
+	})
+}
+
+type includeDeletedKey struct{}
+
+// IncludeDeleted returns a context that makes calls to APIs marked
+// @softdelete return soft-deleted records too (?include_deleted=true)
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// requestURL joins the base URL, base path, and path, adding the
+// include_deleted parameter when ctx asks for it. The base URL of the
+// environment set with fetch.UseEnvironment replaces the client's own.
+func requestURL(ctx context.Context, cfg *clientConfig, path string) string {
+	url := fetch.EnvironmentBaseURL(cfg.baseURL) + cfg.basePath + path
+	if include, _ := ctx.Value(includeDeletedKey{}).(bool); include {
+		if strings.Contains(url, "?") {
+			url += "&include_deleted=true"
+		} else {
+			url += "?include_deleted=true"
+		}
+	}
+	return url
+}
+
+func doRequest[T any](ctx context.Context, cfg *clientConfig, method, path string, body any) (T, error) {
 	var result T
 
-	url := cfg.baseURL + cfg.basePath + path
+	url := requestURL(ctx, cfg, path)
 
 	var bodyStr string
 	if body != nil {
@@ -331,17 +1079,19 @@ func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, erro
 		headers["Content-Type"] = "application/json"
 	}
 
-	resp, err := fetch.Fetch(url, &fetch.Options{
+	resp, err := send(ctx, cfg, url, &fetch.Options{
 		Method:  method,
 		Headers: headers,
 		Body:    bodyStr,
+		Timeout: cfg.timeout,
+		Retries: cfg.retries,
 	})
 	if err != nil {
 		return result, fmt.Errorf("fetch failed: %w", err)
 	}
 
 	if !resp.OK {
-		return result, fmt.Errorf("unexpected status %d: %s", resp.Status, resp.StatusText)
+		return result, responseError(resp)
 	}
 
 	// For DELETE or no-content responses
@@ -356,8 +1106,8 @@ func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, erro
 	return result, nil
 }
 
-func doRequestNoResponse(cfg *clientConfig, method, path string) error {
-	url := cfg.baseURL + cfg.basePath + path
+func doRequestNoResponse(ctx context.Context, cfg *clientConfig, method, path string) error {
+	url := requestURL(ctx, cfg, path)
 
 	headers := make(map[string]string)
 	for k, v := range cfg.headers {
@@ -369,35 +1119,94 @@ func doRequestNoResponse(cfg *clientConfig, method, path string) error {
 		}
 	}
 
-	resp, err := fetch.Fetch(url, &fetch.Options{
+	resp, err := send(ctx, cfg, url, &fetch.Options{
 		Method:  method,
 		Headers: headers,
+		Timeout: cfg.timeout,
+		Retries: cfg.retries,
 	})
 	if err != nil {
 		return fmt.Errorf("fetch failed: %w", err)
 	}
 
 	if !resp.OK {
-		return fmt.Errorf("unexpected status %d: %s", resp.Status, resp.StatusText)
+		return responseError(resp)
 	}
 
 	return nil
 }
+
+// doStream opens a Server-Sent Events stream and decodes the data of each
+// event as JSON. Dropped connections are reopened with Last-Event-ID; the
+// channel is closed when ctx is canceled or the server sends an "end" event
+// because the stream is finished.
+func doStream[T any](ctx context.Context, cfg *clientConfig, path string) (<-chan T, error) {
+	url := requestURL(ctx, cfg, path)
+
+	stream, err := sse.Connect(ctx, url, &sse.Options{
+		Headers:      cfg.headers,
+		AuthProvider: cfg.authProvider,
+	})
+	if err != nil {
+		var statusErr *sse.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, responseError(&fetch.Response{
+				Status:     statusErr.Status,
+				StatusText: statusErr.StatusText,
+				Body:       statusErr.Body,
+			})
+		}
+		return nil, fmt.Errorf("stream failed: %w", err)
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+		for ev := range stream.Events() {
+			if ev.Event == "end" {
+				return
+			}
+			var v T
+			if err := json.Unmarshal([]byte(ev.Data), &v); err != nil {
+				continue
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
 `, nil
 }
 
-func generateClientCode(interfaces []InterfaceInfo) (string, error) {
+func generateClientCode(interfaces []InterfaceInfo, imports []string) (string, error) {
 	// Check if any method has path parameters (needs fmt import for Sprintf)
-	needsFmt := false
+	// or query parameters (needs net/url, and strconv for non-strings)
+	// and whether a signature takes a filter.Filter
+	needsFmt, needsURL, needsStrconv, needsFilter, needsIter := false, false, false, false, false
 	for _, iface := range interfaces {
 		for _, method := range iface.Methods {
 			if len(method.PathParams) > 0 {
 				needsFmt = true
-				break
 			}
-		}
-		if needsFmt {
-			break
+			if method.PagesFunc != "" {
+				needsIter = true
+			}
+			for _, p := range method.QueryParams {
+				needsURL = true
+				if p.Type != "string" && p.Type != "[]string" && p.Type != "filter.Filter" {
+					needsStrconv = true
+				}
+			}
+			for _, a := range method.Args {
+				if a.Type == "filter.Filter" {
+					needsFilter = true
+				}
+			}
 		}
 	}
 
@@ -405,9 +1214,31 @@ func generateClientCode(interfaces []InterfaceInfo) (string, error) {
 //go:build js && wasm
 
 package api
-{{if .NeedsFmt}}
-import "fmt"
+
+import (
+	"context"
+{{- if .NeedsFmt}}
+	"fmt"
+{{- end}}
+{{- if .NeedsIter}}
+	"iter"
+{{- end}}
+{{- if .NeedsURL}}
+	"net/url"
+{{- end}}
+{{- if .NeedsStrconv}}
+	"strconv"
+{{- end}}
+{{- if or .NeedsFilter .Imports}}
 {{end}}
+{{- if .NeedsFilter}}
+	"github.com/dougbarrett/gux/filter"
+{{- end}}
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
 {{range $iface := .Interfaces}}
 // {{$iface.ClientName}} is a client for {{$iface.Name}}
 type {{$iface.ClientName}} struct {
@@ -427,66 +1258,89 @@ func New{{$iface.ClientName}}(opts ...ClientOption) *{{$iface.ClientName}} {
 }
 
 {{range $method := $iface.Methods}}
+{{- if $method.IsStream}}
+// {{$method.Name}} streams events from {{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}. The channel is
+// closed when ctx is canceled or the server ends the stream.
+func (c *{{$iface.ClientName}}) {{$method.Name}}(ctx context.Context{{range $a := $method.Args}}, {{$a.Name}} {{$a.Type}}{{end}}) (<-chan {{$method.ReturnType}}, error) {
+	{{- if $method.QueryParams}}
+	gqPath := {{buildPath $method.Path $method.PathParams}}
+{{queryCode $method.QueryParams}}
+	{{- end}}
+	return doStream[{{$method.ReturnType}}](ctx, c.cfg, {{pathExpr $method}})
+}
+{{- else}}
 // {{$method.Name}} {{if eq $method.HTTPMethod "GET"}}fetches{{else if eq $method.HTTPMethod "POST"}}creates{{else if eq $method.HTTPMethod "PUT"}}updates{{else if eq $method.HTTPMethod "DELETE"}}deletes{{else}}handles{{end}} data via {{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}
 {{- if $method.HasReturn}}
-func (c *{{$iface.ClientName}}) {{$method.Name}}({{range $i, $p := $method.PathParams}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}{{if and $method.PathParams $method.HasBody}}, {{end}}{{if $method.HasBody}}{{$method.BodyParam}} {{$method.BodyType}}{{end}}) ({{if $method.IsPointer}}*{{end}}{{if $method.IsSlice}}[]{{end}}{{$method.ReturnType | stripPrefix}}, error) {
+func (c *{{$iface.ClientName}}) {{$method.Name}}(ctx context.Context{{range $a := $method.Args}}, {{$a.Name}} {{$a.Type}}{{end}}) ({{if $method.IsPointer}}*{{end}}{{if $method.IsSlice}}[]{{end}}{{$method.ReturnType | stripPrefix}}, error) {
+	{{- if $method.QueryParams}}
+	gqPath := {{buildPath $method.Path $method.PathParams}}
+{{queryCode $method.QueryParams}}
+	{{- end}}
 	{{- if $method.IsPointer}}
-	result, err := doRequest[{{$method.ReturnType}}](c.cfg, "{{$method.HTTPMethod}}", {{buildPath $method.Path $method.PathParams}}{{if $method.HasBody}}, {{$method.BodyParam}}{{else}}, nil{{end}})
-	if err != nil {
-		return nil, err
+	gqResult, gqErr := doRequest[{{$method.ReturnType}}](ctx, c.cfg, "{{$method.HTTPMethod}}", {{pathExpr $method}}{{if $method.HasBody}}, {{$method.BodyParam}}{{else}}, nil{{end}})
+	if gqErr != nil {
+		return nil, gqErr
 	}
-	return &result, nil
+	return &gqResult, nil
 	{{- else}}
-	return doRequest[{{if $method.IsSlice}}[]{{end}}{{$method.ReturnType | stripPrefix}}](c.cfg, "{{$method.HTTPMethod}}", {{buildPath $method.Path $method.PathParams}}{{if $method.HasBody}}, {{$method.BodyParam}}{{else}}, nil{{end}})
+	return doRequest[{{if $method.IsSlice}}[]{{end}}{{$method.ReturnType | stripPrefix}}](ctx, c.cfg, "{{$method.HTTPMethod}}", {{pathExpr $method}}{{if $method.HasBody}}, {{$method.BodyParam}}{{else}}, nil{{end}})
 	{{- end}}
 }
+{{- if $method.PagesFunc}}
+
+// {{$method.Name}}Pages iterates over every page of {{$method.Name}}, following NextCursor
+// from the first page until the last, an error, or a break
+func (c *{{$iface.ClientName}}) {{$method.Name}}Pages(ctx context.Context{{range $a := $method.Args}}{{if ne $a.Name "cursor"}}, {{$a.Name}} {{$a.Type}}{{end}}{{end}}) iter.Seq2[{{$method.ReturnType}}, error] {
+	return {{$method.PagesFunc}}(ctx, func(ctx context.Context, cursor string) ({{$method.ReturnType}}, error) {
+		return c.{{$method.Name}}(ctx{{range $a := $method.Args}}, {{$a.Name}}{{end}})
+	})
+}
+{{- end}}
 {{- else}}
-func (c *{{$iface.ClientName}}) {{$method.Name}}({{range $i, $p := $method.PathParams}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}) error {
-	return doRequestNoResponse(c.cfg, "{{$method.HTTPMethod}}", {{buildPath $method.Path $method.PathParams}})
+func (c *{{$iface.ClientName}}) {{$method.Name}}(ctx context.Context{{range $a := $method.Args}}{{if ne $a.Kind "body"}}, {{$a.Name}} {{$a.Type}}{{end}}{{end}}) error {
+	{{- if $method.QueryParams}}
+	gqPath := {{buildPath $method.Path $method.PathParams}}
+{{queryCode $method.QueryParams}}
+	{{- end}}
+	return doRequestNoResponse(ctx, c.cfg, "{{$method.HTTPMethod}}", {{pathExpr $method}})
 }
 {{- end}}
+{{- end}}
 {{end}}
 {{end}}`
 
 	funcMap := template.FuncMap{
-		"buildPath": func(path string, params []PathParam) string {
-			if len(params) == 0 {
-				return `"` + path + `"`
-			}
-			// Build a map of param name to type for lookup
-			paramTypes := make(map[string]string)
-			for _, p := range params {
-				paramTypes[p.Name] = p.Type
-			}
-			// Replace each {param} with the appropriate format specifier
-			re := regexp.MustCompile(`\{(\w+)\}`)
-			result := re.ReplaceAllStringFunc(path, func(match string) string {
-				paramName := match[1 : len(match)-1] // strip { and }
-				if paramTypes[paramName] == "int" {
-					return "%d"
-				}
-				return "%s"
-			})
-			// Build the parameter list
-			var paramNames []string
-			for _, p := range params {
-				paramNames = append(paramNames, p.Name)
-			}
-			return `fmt.Sprintf("` + result + `", ` + strings.Join(paramNames, ", ") + `)`
-		},
+		"buildPath": buildPath,
 		"stripPrefix": func(s string) string {
 			return strings.TrimPrefix(s, "[]")
 		},
+		"queryCode": clientQueryCode,
+		"pathExpr": func(method MethodInfo) string {
+			if len(method.QueryParams) > 0 {
+				return "gqPath"
+			}
+			return buildPath(method.Path, method.PathParams)
+		},
 	}
 
 	t := template.Must(template.New("client").Funcs(funcMap).Parse(tmpl))
 
 	data := struct {
-		Interfaces []InterfaceInfo
-		NeedsFmt   bool
+		Interfaces   []InterfaceInfo
+		NeedsFmt     bool
+		NeedsURL     bool
+		NeedsStrconv bool
+		NeedsFilter  bool
+		NeedsIter    bool
+		Imports      []string
 	}{
-		Interfaces: interfaces,
-		NeedsFmt:   needsFmt,
+		Interfaces:   interfaces,
+		NeedsFmt:     needsFmt,
+		NeedsURL:     needsURL,
+		NeedsStrconv: needsStrconv,
+		NeedsFilter:  needsFilter,
+		NeedsIter:    needsIter,
+		Imports:      imports,
 	}
 
 	var buf bytes.Buffer
@@ -494,10 +1348,42 @@ func (c *{{$iface.ClientName}}) {{$method.Name}}({{range $i, $p := $method.PathP
 		return "", fmt.Errorf("execute template: %w", err)
 	}
 
-	return buf.String(), nil
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("format client: %w", err)
+	}
+	return string(code), nil
+}
+
+// buildPath returns the Go expression for a route path, formatting path
+// parameters into it with fmt.Sprintf
+func buildPath(path string, params []PathParam) string {
+	if len(params) == 0 {
+		return `"` + path + `"`
+	}
+	// Build a map of param name to type for lookup
+	paramTypes := make(map[string]string)
+	for _, p := range params {
+		paramTypes[p.Name] = p.Type
+	}
+	// Replace each {param} with the appropriate format specifier
+	re := regexp.MustCompile(`\{(\w+)\}`)
+	result := re.ReplaceAllStringFunc(path, func(match string) string {
+		paramName := match[1 : len(match)-1] // strip { and }
+		if t := paramTypes[paramName]; t == "int" || t == "int64" {
+			return "%d"
+		}
+		return "%s"
+	})
+	// Build the parameter list
+	var paramNames []string
+	for _, p := range params {
+		paramNames = append(paramNames, p.Name)
+	}
+	return `fmt.Sprintf("` + result + `", ` + strings.Join(paramNames, ", ") + `)`
 }
 
-func generateServerCode(interfaces []InterfaceInfo) (string, error) {
+func generateServerCode(interfaces []InterfaceInfo, imports []string) (string, error) {
 	tmpl := `// Code generated by gux. DO NOT EDIT.
 
 package api
@@ -513,6 +1399,13 @@ import (
 {{- end}}
 
 	gqapi "github.com/dougbarrett/gux/api"
+{{- if .NeedsFilter}}
+	"github.com/dougbarrett/gux/filter"
+{{- end}}
+	gqserver "github.com/dougbarrett/gux/server"
+{{- range .Imports}}
+	{{.}}
+{{- end}}
 )
 
 {{range $iface := .Interfaces}}
@@ -532,19 +1425,26 @@ func (h *{{$iface.Name}}Handler) Use(mw ...func(http.Handler) http.Handler) {
 	h.middleware = append(h.middleware, mw...)
 }
 
-// wrap applies middleware chain to a handler
-func (h *{{$iface.Name}}Handler) wrap(handler http.HandlerFunc) http.Handler {
+// wrap applies middleware chain to a handler, and records its route for
+// server.Otel
+func (h *{{$iface.Name}}Handler) wrap(route string, handler http.HandlerFunc) http.Handler {
 	var result http.Handler = handler
 	for i := len(h.middleware) - 1; i >= 0; i-- {
 		result = h.middleware[i](result)
 	}
-	return result
+	return gqserver.WithRoute(route, result)
 }
 
 // RegisterRoutes registers all routes for {{$iface.Name}}
 func (h *{{$iface.Name}}Handler) RegisterRoutes(mux *http.ServeMux) {
 {{- range $method := $iface.Methods}}
-	mux.Handle("{{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}", h.wrap(h.handle{{$method.Name}}))
+{{- if $method.Roles}}
+	mux.Handle("{{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}", h.wrap("{{$iface.BasePath}}{{$method.Path}}", gqserver.RequireRoles({{quoteList $method.Roles}})(http.HandlerFunc(h.handle{{$method.Name}})).ServeHTTP))
+{{- else if $method.Auth}}
+	mux.Handle("{{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}", h.wrap("{{$iface.BasePath}}{{$method.Path}}", gqserver.RequireAuth()(http.HandlerFunc(h.handle{{$method.Name}})).ServeHTTP))
+{{- else}}
+	mux.Handle("{{$method.HTTPMethod}} {{$iface.BasePath}}{{$method.Path}}", h.wrap("{{$iface.BasePath}}{{$method.Path}}", h.handle{{$method.Name}}))
+{{- end}}
 {{- end}}
 }
 
@@ -552,42 +1452,68 @@ func (h *{{$iface.Name}}Handler) RegisterRoutes(mux *http.ServeMux) {
 func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r *http.Request) {
 {{- if $method.PathParams}}
 	// Extract path parameters
-	path := strings.TrimPrefix(r.URL.Path, "{{$iface.BasePath}}")
-	parts := strings.Split(strings.Trim(path, "/"), "/")
-	_ = parts // avoid unused variable if no params extracted
-{{- range $p := $method.PathParams}}
-{{- if $p.IsInt}}
-	{{$p.Name}}, err := strconv.Atoi(parts[{{pathParamIndex $method.Path $p.Name}}])
-	if err != nil {
-		gqapi.WriteError(w, gqapi.BadRequest("invalid {{$p.Name}}: must be an integer"))
-		return
-	}
-{{- else}}
-	{{$p.Name}} := parts[{{pathParamIndex $method.Path $p.Name}}]
-{{- end}}
+	gqPath := strings.TrimPrefix(r.URL.Path, "{{$iface.BasePath}}")
+	gqParts := strings.Split(strings.Trim(gqPath, "/"), "/")
+	_ = gqParts // avoid unused variable if no params extracted
+{{serverPathCode $method}}
 {{- end}}
+{{- if $method.QueryParams}}
+{{serverQueryCode $method}}
 {{- end}}
 {{- if $method.HasBody}}
-	var req {{$method.BodyType}}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var gqReq {{$method.BodyType}}
+	if gqErr := json.NewDecoder(r.Body).Decode(&gqReq); gqErr != nil {
 		gqapi.WriteError(w, gqapi.BadRequest("invalid request body"))
 		return
 	}
+	if gqV, ok := any(&gqReq).(interface{ Validate() error }); ok {
+		if gqErr := gqV.Validate(); gqErr != nil {
+			gqapi.WriteError(w, gqapi.Invalid(gqErr))
+			return
+		}
+	}
 {{- end}}
 
-	{{if $method.HasReturn}}result, {{end}}err {{if or $method.HasReturn (not (hasIntPathParam $method.PathParams))}}:{{end}}= h.service.{{$method.Name}}(r.Context(){{range $method.PathParams}}, {{.Name}}{{end}}{{if $method.HasBody}}, req{{end}})
-	if err != nil {
-		gqapi.WriteError(w, err)
+{{- if softDeleteGET $iface $method}}
+	gqCtx := gqapi.SoftDeleteContext(r)
+{{- end}}
+{{- if $method.IsStream}}
+
+	gqEvents, gqErr := h.service.{{$method.Name}}({{if softDeleteGET $iface $method}}gqCtx{{else}}r.Context(){{end}}{{range $method.Args}}, {{.Name}}{{end}})
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
+	gqserver.StreamSSE(w, r, gqEvents)
+}
+{{- else}}
+
+	{{if $method.HasReturn}}gqResult, {{end}}gqErr {{if or $method.HasReturn (not (hasIntPathParam $method.PathParams))}}:{{end}}= h.service.{{$method.Name}}({{if softDeleteGET $iface $method}}gqCtx{{else}}r.Context(){{end}}{{range $method.Args}}, {{if eq .Kind "body"}}gqReq{{else}}{{.Name}}{{end}}{{end}})
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
+		return
+	}
+{{- if and (softDeleteGET $iface $method) $method.HasReturn}}
+	if !gqapi.IncludeDeleted(gqCtx) {
+{{- if $method.IsSlice}}
+		gqResult = gqapi.ExcludeDeleted(gqResult)
+{{- else}}
+		if gqapi.IsDeleted(gqResult) {
+			gqapi.WriteError(w, gqapi.NotFound("not found"))
+			return
+		}
+{{- end}}
+	}
+{{- end}}
 
 {{- if $method.HasReturn}}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(gqResult)
 {{- else}}
 	w.WriteHeader(http.StatusNoContent)
 {{- end}}
 }
+{{- end}}
 {{end}}
 {{end}}
 `
@@ -596,6 +1522,7 @@ func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r
 	// and if any have int path parameters (needs strconv import)
 	needsStrconv := false
 	hasPathParams := false
+	needsFilter := false
 	for _, iface := range interfaces {
 		for _, method := range iface.Methods {
 			if len(method.PathParams) > 0 {
@@ -606,6 +1533,15 @@ func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r
 					needsStrconv = true
 				}
 			}
+			for _, p := range method.QueryParams {
+				switch p.Type {
+				case "string", "[]string":
+				case "filter.Filter":
+					needsFilter = true
+				default:
+					needsStrconv = true
+				}
+			}
 		}
 	}
 
@@ -613,16 +1549,17 @@ func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r
 		"methodName": func(method string) string {
 			return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
 		},
-		"pathParamIndex": func(path, param string) int {
-			// Find the index of the parameter in the path parts
-			// e.g., "/{userId}/posts/{postId}" -> userId is at index 0, postId is at index 2
-			parts := strings.Split(strings.Trim(path, "/"), "/")
-			for i, part := range parts {
-				if part == "{"+param+"}" {
-					return i
-				}
+		"serverQueryCode": serverQueryCode,
+		"serverPathCode":  serverPathCode,
+		"softDeleteGET": func(iface InterfaceInfo, method MethodInfo) bool {
+			return iface.SoftDelete && method.HTTPMethod == "GET"
+		},
+		"quoteList": func(items []string) string {
+			quoted := make([]string, len(items))
+			for i, item := range items {
+				quoted[i] = strconv.Quote(item)
 			}
-			return 0
+			return strings.Join(quoted, ", ")
 		},
 		"hasIntPathParam": func(params []PathParam) bool {
 			for _, p := range params {
@@ -636,14 +1573,26 @@ func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r
 
 	t := template.Must(template.New("server").Funcs(funcMap).Parse(tmpl))
 
+	// The template already imports gux/api as gqapi
+	var extra []string
+	for _, spec := range imports {
+		if spec != `gqapi "github.com/dougbarrett/gux/api"` {
+			extra = append(extra, spec)
+		}
+	}
+
 	data := struct {
 		Interfaces    []InterfaceInfo
 		NeedsStrconv  bool
 		HasPathParams bool
+		NeedsFilter   bool
+		Imports       []string
 	}{
 		Interfaces:    interfaces,
 		NeedsStrconv:  needsStrconv,
 		HasPathParams: hasPathParams,
+		NeedsFilter:   needsFilter,
+		Imports:       extra,
 	}
 
 	var buf bytes.Buffer
@@ -651,6 +1600,9 @@ func (h *{{$iface.Name}}Handler) handle{{$method.Name}}(w http.ResponseWriter, r
 		return "", fmt.Errorf("execute template: %w", err)
 	}
 
-	return buf.String(), nil
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("format server: %w", err)
+	}
+	return string(code), nil
 }
-
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// itemsAPI is an API source covering the kinds of arguments apigen handles
+const itemsAPI = `package api
+
+import (
+	"context"
+
+	gqapi "github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/filter"
+)
+
+// ItemsAPI is a test API
+// @client ItemsClient
+// @basepath /api/items
+type ItemsAPI interface {
+	// @route GET /
+	// @query q, limit=20, archived=false, tag
+	// @filter status, total
+	List(ctx context.Context, q string, limit int, archived bool, tag []string, where filter.Filter) ([]Item, error)
+
+	// @route GET /search
+	Search(ctx context.Context, params SearchParams) ([]Item, error)
+
+	// @route GET /{id}
+	Get(ctx context.Context, id int) (*Item, error)
+
+	// @route GET /keys/{key}
+	ByKey(ctx context.Context, key gqapi.UUID) (*Item, error)
+
+	// @route POST /
+	Create(ctx context.Context, req CreateItemRequest) (*Item, error)
+
+	// @route DELETE /{id}
+	Delete(ctx context.Context, id int) error
+}
+
+// Item is a test record
+type Item struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// SearchParams are the query parameters of Search
+type SearchParams struct {
+	Page  int     ` + "`query:\"page\" default:\"1\"`" + `
+	Sort  string  ` + "`default:\"-created\"`" + `
+	Price float64
+}
+
+// Error is an app type named like the generated client's errors once were
+type Error struct {
+	Reason string
+}
+
+// CreateItemRequest is Create's body
+type CreateItemRequest struct {
+	Name  string ` + "`json:\"name\" validate:\"required,minlen=3\"`" + `
+	Email string ` + "`json:\"email\" validate:\"email\"`" + `
+	Qty   int    ` + "`json:\"qty\" validate:\"min=1,max=10\"`" + `
+}
+`
+
+// itemsHandlerTest runs the generated handler against a fake service
+const itemsHandlerTest = `package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gqapi "github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/filter"
+)
+
+type fakeItems struct{ call string }
+
+func (f *fakeItems) List(ctx context.Context, q string, limit int, archived bool, tag []string, where filter.Filter) ([]Item, error) {
+	f.call = fmt.Sprintf("List q=%q limit=%d archived=%t tag=%q where=%q", q, limit, archived, tag, where.String())
+	return []Item{}, nil
+}
+
+func (f *fakeItems) Search(ctx context.Context, params SearchParams) ([]Item, error) {
+	f.call = fmt.Sprintf("Search %+v", params)
+	return nil, nil
+}
+
+func (f *fakeItems) Get(ctx context.Context, id int) (*Item, error) {
+	f.call = fmt.Sprintf("Get %d", id)
+	return &Item{ID: id}, nil
+}
+
+func (f *fakeItems) ByKey(ctx context.Context, key gqapi.UUID) (*Item, error) {
+	f.call = "ByKey " + key.String()
+	return &Item{}, nil
+}
+
+func (f *fakeItems) Create(ctx context.Context, req CreateItemRequest) (*Item, error) {
+	f.call = "Create " + req.Name
+	return &Item{Name: req.Name}, nil
+}
+
+func (f *fakeItems) Delete(ctx context.Context, id int) error {
+	f.call = fmt.Sprintf("Delete %d", id)
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		method, target, body string
+		status               int
+		call                 string
+	}{
+		{"GET", "/api/items/", "", 200, "List q=\"\" limit=20 archived=false tag=[] where=\"\""},
+		{"GET", "/api/items/?q=a+b&limit=5&archived=true&tag=x&tag=y&where=status+eq+open", "", 200,
+			"List q=\"a b\" limit=5 archived=true tag=[\"x\" \"y\"] where=\"status eq open\""},
+		{"GET", "/api/items/?limit=many", "", 400, ""},
+		{"GET", "/api/items/?archived=maybe", "", 400, ""},
+		{"GET", "/api/items/?where=secret+eq+1", "", 400, ""},
+		{"GET", "/api/items/search", "", 200, "Search {Page:1 Sort:-created Price:0}"},
+		{"GET", "/api/items/search?page=3&sort=name&price=9.5", "", 200, "Search {Page:3 Sort:name Price:9.5}"},
+		{"GET", "/api/items/search?price=cheap", "", 400, ""},
+		{"GET", "/api/items/42", "", 200, "Get 42"},
+		{"GET", "/api/items/forty-two", "", 400, ""},
+		{"GET", "/api/items/keys/0190a4c2-0000-7000-8000-000000000001", "", 200, "ByKey 0190a4c2-0000-7000-8000-000000000001"},
+		{"GET", "/api/items/keys/nope", "", 400, ""},
+		{"POST", "/api/items/", "{\"name\":\"Widget\",\"email\":\"a@example.com\",\"qty\":2}", 200, "Create Widget"},
+		{"POST", "/api/items/", "{\"name\":\"Wi\",\"email\":\"nope\",\"qty\":11}", 422, ""},
+		{"POST", "/api/items/", "not json", 400, ""},
+		{"DELETE", "/api/items/7", "", 204, "Delete 7"},
+	}
+	for _, tt := range tests {
+		service := &fakeItems{}
+		mux := http.NewServeMux()
+		NewItemsAPIHandler(service).RegisterRoutes(mux)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.status || service.call != tt.call {
+			t.Errorf("%s %s = %d, %s; want %d, %s", tt.method, tt.target, rec.Code, service.call, tt.status, tt.call)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := CreateItemRequest{Name: "Wi", Email: "nope", Qty: 11}.Validate()
+	apiErr, ok := err.(*gqapi.Error)
+	if !ok {
+		t.Fatalf("Validate = %v, want an *api.Error", err)
+	}
+	for _, field := range []string{"name", "email", "qty"} {
+		if apiErr.FieldKeys[field].Key == "" {
+			t.Errorf("no message for %s in %+v", field, apiErr.FieldKeys)
+		}
+	}
+	data, _ := json.Marshal(apiErr)
+	if !strings.Contains(string(data), "gux.validation.minlength") {
+		t.Errorf("error JSON %s lacks the minlength key", data)
+	}
+
+	if err := (CreateItemRequest{Name: "Widget", Qty: 1}).Validate(); err != nil {
+		t.Errorf("valid request: %v", err)
+	}
+}
+`
+
+// generateFixture writes the API source into a new package of the module,
+// under testdata so go ./... skips it, and generates its code
+func generateFixture(t *testing.T) string {
+	t.Helper()
+	if err := os.MkdirAll("testdata", 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := os.MkdirTemp("testdata", "items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Remove("testdata") // if no other test left files there
+	})
+	if err := os.WriteFile(filepath.Join(dir, "items.go"), []byte(itemsAPI), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generateAPIDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestGenerateAPI(t *testing.T) {
+	dir := generateFixture(t)
+	for _, name := range []string{"client_shared_gen.go", "items_client_gen.go", "items_server_gen.go", "validate_gen.go", "validate_ui_gen.go"} {
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if formatted, err := format.Source(src); err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if !bytes.Equal(formatted, src) {
+			t.Errorf("%s isn't gofmt-formatted", name)
+		}
+	}
+
+	server, err := os.ReadFile(filepath.Join(dir, "items_server_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`limit := 20`,
+		`if gqVs := gqQuery["tag"]; len(gqVs) > 0 {`,
+		`gqParsed, gqErr := filter.Parse(gqV, "status", "total")`,
+		`params.Page = 1`,
+		`params.Sort = "-created"`,
+	} {
+		if !strings.Contains(string(server), want) {
+			t.Errorf("server code lacks %q", want)
+		}
+	}
+	client, err := os.ReadFile(filepath.Join(dir, "items_client_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `gqQuery.Set("limit", strconv.Itoa(limit))`; !strings.Contains(string(client), want) {
+		t.Errorf("client code lacks %q", want)
+	}
+}
+
+// TestGeneratedCode compiles the generated code for the server and the
+// browser and runs the handler's own tests
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	dir := generateFixture(t)
+	if err := os.WriteFile(filepath.Join(dir, "items_test.go"), []byte(itemsHandlerTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	test := exec.Command("go", "test", ".")
+	test.Dir = dir
+	if out, err := test.CombinedOutput(); err != nil {
+		t.Errorf("go test: %v\n%s", err, out)
+	}
+	vet := exec.Command("go", "vet", ".")
+	vet.Dir = dir
+	vet.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if out, err := vet.CombinedOutput(); err != nil {
+		t.Errorf("go vet for js/wasm: %v\n%s", err, out)
+	}
+}
+
+func TestAPIRejectsBadAnnotations(t *testing.T) {
+	tests := []struct {
+		method string
+		err    string
+	}{
+		{"// @route GET /\n\t// @query limit=20\n\tList(ctx context.Context, max int) error", "@query limit is not an argument"},
+		{"// @route GET /\n\t// @query w\n\tList(ctx context.Context, w string) error", `argument name "w" is used by the generated code`},
+		{"// @route GET /\n\t// @query gqPath\n\tList(ctx context.Context, gqPath string) error", `argument name "gqPath" is used by the generated code`},
+		{"// @route GET /\n\t// @query on=true\n\tList(ctx context.Context, on bool) error", "bool parameters must default to false"},
+		{"// @route GET /\n\t// @query at\n\tList(ctx context.Context, at complex128) error", `unsupported type "complex128"`},
+	}
+	for _, tt := range tests {
+		src := "package api\n\nimport \"context\"\n\n// @client ItemsClient\ntype ItemsAPI interface {\n\t" + tt.method + "\n}\n"
+		node, err := parser.ParseFile(token.NewFileSet(), "items.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := findInterfaces(node); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: err = %v, want %q", tt.method, err, tt.err)
+		}
+	}
+}
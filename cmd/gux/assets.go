@@ -0,0 +1,191 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// assetManifestName is the file mapping logical asset names to hashed ones.
+// manifest.json is taken by the PWA manifest.
+const assetManifestName = "asset-manifest.json"
+
+// hashedExts are the file types given content-hashed names. Images and
+// fonts keep theirs, since manifest.json and stylesheets refer to them.
+var hashedExts = map[string]bool{
+	".wasm": true,
+	".js":   true,
+	".mjs":  true,
+	".css":  true,
+}
+
+// stableAssets keep their names whatever their type, because browsers
+// request them at fixed URLs
+var stableAssets = map[string]bool{
+	"service-worker.js": true,
+	"sw.js":             true,
+}
+
+// assetOptions configures buildAssets
+type assetOptions struct {
+	Hash       bool     // give assets content-hashed names
+	Compress   []string // precompressed encodings: "gzip", "br"
+	Stylesheet string   // linked from each HTML page, such as the built Tailwind CSS
+}
+
+// buildAssets copies the static files in src to dst for production. Assets
+// get content-hashed names such as main.3f2a9c1e.wasm and the pages and
+// service worker referring to them are rewritten, so they can be cached
+// forever and a deploy still reaches every browser at once. Compressible
+// files get .gz and .br siblings, and asset-manifest.json maps each logical
+// name to its hashed one.
+func buildAssets(src, dst string, opts assetOptions) (map[string]string, error) {
+	if err := os.RemoveAll(dst); err != nil {
+		return nil, fmt.Errorf("clean %s: %w", dst, err)
+	}
+	if err := copyDir(src, dst); err != nil {
+		return nil, fmt.Errorf("copy %s: %w", src, err)
+	}
+	if opts.Stylesheet != "" {
+		if err := linkStylesheet(dst, opts.Stylesheet); err != nil {
+			return nil, fmt.Errorf("link %s: %w", opts.Stylesheet, err)
+		}
+	}
+
+	manifest := make(map[string]string)
+	if opts.Hash {
+		if err := hashAssets(dst, manifest); err != nil {
+			return nil, err
+		}
+		if err := rewriteReferences(dst, manifest); err != nil {
+			return nil, err
+		}
+		fmt.Printf("  hashed %d asset(s)\n", len(manifest))
+	}
+
+	for _, encoding := range opts.Compress {
+		switch encoding {
+		case "gzip":
+			n, err := compressDir(dst, ".gz", gzipFile)
+			if err != nil {
+				return nil, fmt.Errorf("compress with gzip: %w", err)
+			}
+			fmt.Printf("  precompressed %d file(s) with gzip\n", n)
+		case "br":
+			// Go has no brotli encoder in the standard library, so use the brotli CLI
+			if _, err := exec.LookPath("brotli"); err != nil {
+				fmt.Println("  skipped brotli: 'brotli' not found in PATH")
+				continue
+			}
+			n, err := compressDir(dst, ".br", brotliFile)
+			if err != nil {
+				return nil, fmt.Errorf("compress with brotli: %w", err)
+			}
+			fmt.Printf("  precompressed %d file(s) with brotli\n", n)
+		default:
+			return nil, fmt.Errorf("unknown compression '%s' (use gzip or br)", encoding)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dst, assetManifestName), append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("write %s: %w", assetManifestName, err)
+	}
+	return manifest, nil
+}
+
+// hashAssets renames each hashable file in dir to include the first 8 hex
+// digits of its SHA-256, recording the rename in manifest by slash path
+func hashAssets(dir string, manifest map[string]string) error {
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name := info.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if !hashedExts[ext] || stableAssets[name] {
+			return nil
+		}
+
+		hash, err := fileHash(p)
+		if err != nil {
+			return fmt.Errorf("hash %s: %w", p, err)
+		}
+		hashedName := strings.TrimSuffix(name, filepath.Ext(name)) + "." + hash + filepath.Ext(name)
+		if err := os.Rename(p, filepath.Join(filepath.Dir(p), hashedName)); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		manifest[rel] = path.Join(path.Dir(rel), hashedName)
+		return nil
+	})
+}
+
+func fileHash(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:8], nil
+}
+
+// rewriteReferences points the quoted references to renamed assets in the
+// HTML pages and stable scripts of dir at their hashed names: "main.wasm",
+// "/main.wasm", and "./main.wasm", in double or single quotes
+func rewriteReferences(dir string, manifest map[string]string) error {
+	logical := make([]string, 0, len(manifest))
+	for name := range manifest {
+		logical = append(logical, name)
+	}
+	sort.Strings(logical)
+
+	var pairs []string
+	for _, name := range logical {
+		for _, q := range []string{`"`, `'`} {
+			for _, prefix := range []string{"", "/", "./"} {
+				pairs = append(pairs, q+prefix+name+q, q+prefix+manifest[name]+q)
+			}
+		}
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		if ext != ".html" && !stableAssets[info.Name()] {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rewritten := replacer.Replace(string(data))
+		if rewritten == string(data) {
+			return nil
+		}
+		return os.WriteFile(p, []byte(rewritten), info.Mode())
+	})
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// configFile is the project configuration file read by gux deploy
+const configFile = "gux.json"
+
+// DeployConfig is the "deploy" section of gux.json
+type DeployConfig struct {
+	Target      string   `json:"target"`      // "static" or "docker" (default "static")
+	OutDir      string   `json:"outDir"`      // Static output directory (default "dist")
+	Compress    []string `json:"compress"`    // Precompressed encodings: "gzip", "br" (default both)
+	SPAFallback *bool    `json:"spaFallback"` // Write _redirects routing unknown paths to index.html (default true)
+	Headers     *bool    `json:"headers"`     // Write _headers with WASM content type and caching (default true)
+	Image       string   `json:"image"`       // Docker image tag (default "<dir>:latest")
+	Dockerfile  string   `json:"dockerfile"`  // Dockerfile path (default "Dockerfile", generated if missing)
+	Platform    string   `json:"platform"`    // Optional docker build --platform
+	Push        bool     `json:"push"`        // Push the image after building
+}
+
+// ProjectConfig is the gux.json file at the project root
+type ProjectConfig struct {
+	Name     string         `json:"name"`
+	Deploy   DeployConfig   `json:"deploy"`
+	Tailwind TailwindConfig `json:"tailwind"`
+}
+
+// loadProjectConfig reads gux.json, returning defaults if the file does not exist
+func loadProjectConfig(path string) (*ProjectConfig, error) {
+	cfg := &ProjectConfig{}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if cfg.Name == "" {
+		if wd, err := os.Getwd(); err == nil {
+			cfg.Name = filepath.Base(wd)
+		}
+	}
+	d := &cfg.Deploy
+	if d.Target == "" {
+		d.Target = "static"
+	}
+	if d.OutDir == "" {
+		d.OutDir = "dist"
+	}
+	if d.Compress == nil {
+		d.Compress = []string{"gzip", "br"}
+	}
+	if d.Image == "" {
+		d.Image = cfg.Name + ":latest"
+	}
+	if d.Dockerfile == "" {
+		d.Dockerfile = "Dockerfile"
+	}
+	return cfg, nil
+}
+
+func runDeploy(configPath, target string, tinygo bool) {
+	cfg, err := loadProjectConfig(configPath)
+	if err != nil {
+		fmt.Printf("Error reading config: %v\n", err)
+		os.Exit(1)
+	}
+	if target != "" {
+		cfg.Deploy.Target = target
+	}
+
+	switch cfg.Deploy.Target {
+	case "static":
+		deployStatic(cfg, tinygo)
+	case "docker":
+		deployDocker(cfg)
+	default:
+		fmt.Printf("Error: unknown deploy target '%s' (use static or docker)\n", cfg.Deploy.Target)
+		os.Exit(1)
+	}
+}
+
+// deployStatic builds the WASM and writes a dist/ folder ready for static
+// hosts such as Netlify and Cloudflare Pages
+func deployStatic(cfg *ProjectConfig, tinygo bool) {
+	d := cfg.Deploy
+
+	if _, err := os.Stat("public/wasm_exec.js"); os.IsNotExist(err) {
+		fmt.Println("Error: public/wasm_exec.js not found")
+		fmt.Println("Run 'gux setup' first to copy wasm_exec.js from your Go/TinyGo installation.")
+		os.Exit(1)
+	}
+
+	buildWasm(tinygo)
+	stylesheet, err := buildTailwind(cfg.Tailwind, tinygo)
+	if err != nil {
+		fmt.Printf("Error building Tailwind CSS: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Writing static site to %s/...\n", d.OutDir)
+	if err := os.RemoveAll(d.OutDir); err != nil {
+		fmt.Printf("Error cleaning %s: %v\n", d.OutDir, err)
+		os.Exit(1)
+	}
+	if err := copyDir("public", d.OutDir); err != nil {
+		fmt.Printf("Error copying public directory: %v\n", err)
+		os.Exit(1)
+	}
+	if stylesheet != "" {
+		if err := linkStylesheet(d.OutDir, stylesheet); err != nil {
+			fmt.Printf("Error linking %s: %v\n", stylesheet, err)
+			os.Exit(1)
+		}
+	}
+
+	if d.Headers == nil || *d.Headers {
+		if err := os.WriteFile(filepath.Join(d.OutDir, "_headers"), []byte(staticHeaders), 0644); err != nil {
+			fmt.Printf("Error writing _headers: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("  wrote _headers")
+	}
+	if d.SPAFallback == nil || *d.SPAFallback {
+		if err := os.WriteFile(filepath.Join(d.OutDir, "_redirects"), []byte("/*    /index.html   200\n"), 0644); err != nil {
+			fmt.Printf("Error writing _redirects: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("  wrote _redirects")
+	}
+
+	for _, encoding := range d.Compress {
+		switch encoding {
+		case "gzip":
+			n, err := compressDir(d.OutDir, ".gz", gzipFile)
+			if err != nil {
+				fmt.Printf("Error compressing with gzip: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("  precompressed %d file(s) with gzip\n", n)
+		case "br":
+			// Go has no brotli encoder in the standard library, so use the brotli CLI
+			if _, err := exec.LookPath("brotli"); err != nil {
+				fmt.Println("  skipped brotli: 'brotli' not found in PATH")
+				continue
+			}
+			n, err := compressDir(d.OutDir, ".br", brotliFile)
+			if err != nil {
+				fmt.Printf("Error compressing with brotli: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("  precompressed %d file(s) with brotli\n", n)
+		default:
+			fmt.Printf("Error: unknown compression '%s' (use gzip or br)\n", encoding)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("\nStatic site ready in %s/\n", d.OutDir)
+	fmt.Println("Deploy it with, for example:")
+	fmt.Printf("  netlify deploy --prod --dir %s\n", d.OutDir)
+	fmt.Printf("  wrangler pages deploy %s\n", d.OutDir)
+	fmt.Println("\nNote: static hosting serves the frontend only. API routes in cmd/server")
+	fmt.Println("must be hosted separately (point generated clients at it with WithBaseURL).")
+}
+
+// staticHeaders is written to _headers (Netlify and Cloudflare Pages format).
+// main.wasm keeps a stable name, so it must be revalidated on every load.
+const staticHeaders = `/*.wasm
+  Content-Type: application/wasm
+  Cache-Control: public, max-age=0, must-revalidate
+
+/wasm_exec.js
+  Cache-Control: public, max-age=0, must-revalidate
+
+/service-worker.js
+  Cache-Control: no-cache
+`
+
+// compressibleExts are the file types worth precompressing
+var compressibleExts = map[string]bool{
+	".wasm": true,
+	".js":   true,
+	".html": true,
+	".css":  true,
+	".json": true,
+	".svg":  true,
+	".txt":  true,
+}
+
+// compressDir writes a compressed sibling (file + suffix) for each compressible file
+func compressDir(dir, suffix string, compress func(src, dst string) error) (int, error) {
+	count := 0
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if !compressibleExts[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		if err := compress(path, path+suffix); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw, err := gzip.NewWriterLevel(out, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(zw, in); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func brotliFile(src, dst string) error {
+	cmd := exec.Command("brotli", "--best", "--force", "--output="+dst, src)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// deployDocker builds (and optionally pushes) an image from the project Dockerfile
+func deployDocker(cfg *ProjectConfig) {
+	d := cfg.Deploy
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		fmt.Println("Error: docker not found in PATH")
+		os.Exit(1)
+	}
+
+	if _, err := os.Stat(d.Dockerfile); os.IsNotExist(err) {
+		guxVersion := getVersion()
+		if guxVersion == "dev" {
+			guxVersion = "latest"
+		}
+		data := TemplateData{
+			AppName:    cfg.Name,
+			GuxModule:  "github.com/dougbarrett/gux",
+			GuxVersion: guxVersion,
+		}
+		if err := renderTemplate(".", "templates/Dockerfile.tmpl", d.Dockerfile, data); err != nil {
+			fmt.Printf("Error generating %s: %v\n", d.Dockerfile, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Generated %s\n", d.Dockerfile)
+	}
+
+	args := []string{"build", "-t", d.Image, "-f", d.Dockerfile}
+	if d.Platform != "" {
+		args = append(args, "--platform", d.Platform)
+	}
+	args = append(args, ".")
+
+	fmt.Printf("Building image %s...\n", d.Image)
+	if err := runCommand("docker", args...); err != nil {
+		fmt.Printf("Docker build failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if d.Push {
+		fmt.Printf("Pushing image %s...\n", d.Image)
+		if err := runCommand("docker", "push", d.Image); err != nil {
+			fmt.Printf("Docker push failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("\nImage %s ready\n", d.Image)
+	fmt.Printf("Run with: docker run -p 8080:8080 %s\n", d.Image)
+}
+
+func runCommand(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -124,8 +124,10 @@ func buildWasm(tinygo bool) {
 	fmt.Printf("Built public/main.wasm (%.2f MB) with %s\n", wasmSize, compiler)
 }
 
-// runBuild builds the WASM and then the server binary with all assets embedded
-func runBuild(tinygo bool) {
+// runBuild builds the WASM, and with tailwind the purged Tailwind CSS,
+// and then the server binary with all assets embedded, after running them
+// through the asset pipeline
+func runBuild(tinygo, tailwind bool, opts assetOptions) {
 	// Check for wasm_exec.js
 	if _, err := os.Stat("public/wasm_exec.js"); os.IsNotExist(err) {
 		fmt.Println("Error: public/wasm_exec.js not found")
@@ -136,17 +138,34 @@ func runBuild(tinygo bool) {
 	// Build the WASM first
 	buildWasm(tinygo)
 
-	// Copy public/ to cmd/server/public/ for embedding
-	// (go:embed paths are relative to the source file)
-	fmt.Println("Building server binary with embedded assets...")
+	if tailwind {
+		cfg, err := loadProjectConfig(configFile)
+		if err != nil {
+			fmt.Printf("Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+		if opts.Stylesheet, err = buildTailwind(cfg.Tailwind, tinygo); err != nil {
+			fmt.Printf("Error building Tailwind CSS: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
+	// Process public/ into cmd/server/public/ for embedding
+	// (go:embed paths are relative to the source file)
+	fmt.Println("Processing assets...")
 	serverPublic := filepath.Join("cmd", "server", "public")
-	if err := copyDir("public", serverPublic); err != nil {
-		fmt.Printf("Error copying public directory: %v\n", err)
+	manifest, err := buildAssets("public", serverPublic, opts)
+	if err != nil {
+		os.RemoveAll(serverPublic)
+		fmt.Printf("Error processing assets: %v\n", err)
 		os.Exit(1)
 	}
 	defer os.RemoveAll(serverPublic) // Clean up after build
+	if wasm, ok := manifest["main.wasm"]; ok {
+		fmt.Printf("  main.wasm -> %s\n", wasm)
+	}
 
+	fmt.Println("Building server binary with embedded assets...")
 	cmd := exec.Command("go", "build", "-ldflags=-s -w", "-o", "server", "./cmd/server")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// doctorRule flags an import, or uses of some of its members, known to
+// fail in the browser
+type doctorRule struct {
+	Path     string   // import path
+	Names    []string // only flag uses of these members, not the import
+	Compiler string   // "tinygo", "go", or "" for both
+	Message  string
+}
+
+var doctorRules = []doctorRule{
+	{Path: "encoding/json", Names: []string{"Marshal", "MarshalIndent", "Unmarshal", "NewDecoder", "NewEncoder"}, Compiler: "tinygo", Message: "TinyGo's reflection is incomplete, so some types panic instead of returning an error; compat.Marshal and compat.Unmarshal return the error"},
+	{Path: "encoding/xml", Compiler: "tinygo", Message: "relies on reflection TinyGo only partly implements"},
+	{Path: "encoding/gob", Compiler: "tinygo", Message: "relies on reflection TinyGo only partly implements"},
+	{Path: "text/template", Compiler: "tinygo", Message: "calls methods through reflection, which TinyGo doesn't support"},
+	{Path: "html/template", Compiler: "tinygo", Message: "calls methods through reflection, which TinyGo doesn't support"},
+	{Path: "reflect", Compiler: "tinygo", Message: "TinyGo implements only part of reflect (e.g. not MakeFunc), so code that works under Go can panic"},
+	{Path: "net/http", Names: []string{"Get", "Post", "PostForm", "Head", "Client", "DefaultClient"}, Compiler: "tinygo", Message: "TinyGo has no browser transport for net/http; use the fetch package"},
+	{Path: "crypto/rand", Names: []string{"Read", "Int", "Prime", "Text"}, Compiler: "tinygo", Message: "TinyGo's random source depends on the target; compat.Read always uses the browser's crypto.getRandomValues"},
+	{Path: "time", Names: []string{"LoadLocation"}, Message: "there is no time zone database in the browser unless time/tzdata is imported (about 450 KB); compat.LoadLocation uses the browser's time zones"},
+	{Path: "net", Message: "the browser has no sockets; use the fetch or ws packages"},
+	{Path: "os/exec", Message: "the browser can't run processes"},
+	{Path: "os/signal", Message: "the browser has no signals"},
+	{Path: "plugin", Message: "plugins can't be loaded in the browser"},
+}
+
+// doctorPackage is the part of "go list -json" output the doctor reads
+type doctorPackage struct {
+	ImportPath string
+	Dir        string
+	GoFiles    []string
+	Standard   bool
+	Module     *struct{ Main bool }
+}
+
+// doctorIssue is a rule broken at a position in the project
+type doctorIssue struct {
+	Pos  token.Position
+	What string // the import path or package member
+	Rule doctorRule
+}
+
+// runDoctor checks the project's frontend code for imports known to fail
+// under the selected compiler
+func runDoctor(tinygo bool, pkg string) {
+	compiler, name := "go", "Go"
+	if tinygo {
+		compiler, name = "tinygo", "TinyGo"
+	}
+	fmt.Printf("Checking %s for %s...\n\n", pkg, name)
+
+	if _, err := exec.LookPath(compiler); err != nil {
+		fmt.Printf("Warning: %s not found in PATH", compiler)
+		if tinygo {
+			fmt.Print("; install TinyGo or use --go")
+		}
+		fmt.Println()
+	}
+
+	packages, err := listWasmPackages(pkg, tinygo)
+	if err != nil {
+		fmt.Printf("Error listing packages: %v\n", err)
+		os.Exit(1)
+	}
+
+	tzdata := false
+	for _, p := range packages {
+		if p.ImportPath == "time/tzdata" {
+			tzdata = true
+		}
+	}
+
+	var issues []doctorIssue
+	fset := token.NewFileSet()
+	for _, p := range packages {
+		if p.Module == nil || !p.Module.Main {
+			continue // only the project's own code
+		}
+		for _, file := range p.GoFiles {
+			f, err := parser.ParseFile(fset, filepath.Join(p.Dir, file), nil, parser.ParseComments)
+			if err != nil {
+				fmt.Printf("Error parsing %s: %v\n", file, err)
+				continue
+			}
+			if ast.IsGenerated(f) {
+				continue
+			}
+			for _, rule := range doctorRules {
+				if rule.Compiler != "" && rule.Compiler != compiler {
+					continue
+				}
+				if rule.Path == "time" && tzdata {
+					continue
+				}
+				issues = append(issues, ruleMatches(fset, f, rule)...)
+			}
+		}
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No problems found.")
+		return
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i].Pos, issues[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Line < b.Line
+	})
+	cwd, _ := os.Getwd()
+	for _, issue := range issues {
+		file := issue.Pos.Filename
+		if rel, err := filepath.Rel(cwd, file); err == nil {
+			file = rel
+		}
+		fmt.Printf("%s:%d: %s: %s\n", file, issue.Pos.Line, issue.What, issue.Rule.Message)
+	}
+	fmt.Printf("\n%d warning(s) for %s\n", len(issues), name)
+	os.Exit(1)
+}
+
+// listWasmPackages returns pkg and its dependencies as built for the browser
+func listWasmPackages(pkg string, tinygo bool) ([]doctorPackage, error) {
+	args := []string{"list", "-deps", "-json"}
+	if tinygo {
+		args = append(args, "-tags", "tinygo")
+	}
+	cmd := exec.Command("go", append(args, pkg)...)
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			return nil, errors.New(string(bytes.TrimSpace(stderr.Bytes())))
+		}
+		return nil, err
+	}
+
+	var packages []doctorPackage
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var p doctorPackage
+		if err := dec.Decode(&p); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		packages = append(packages, p)
+	}
+	return packages, nil
+}
+
+// ruleMatches returns where f breaks rule: the import, or each use of
+// rule.Names
+func ruleMatches(fset *token.FileSet, f *ast.File, rule doctorRule) []doctorIssue {
+	var issues []doctorIssue
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if path != rule.Path {
+			continue
+		}
+		if len(rule.Names) == 0 {
+			issues = append(issues, doctorIssue{Pos: fset.Position(spec.Pos()), What: path, Rule: rule})
+			continue
+		}
+
+		local := filepath.Base(path)
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok || !slices.Contains(rule.Names, sel.Sel.Name) {
+				return true
+			}
+			// An unresolved identifier is the package, not a local variable
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == local && x.Obj == nil {
+				issues = append(issues, doctorIssue{Pos: fset.Position(sel.Pos()), What: path + "." + sel.Sel.Name, Rule: rule})
+			}
+			return true
+		})
+	}
+	return issues
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// enumType is a string type marked @enum, with its const values
+type enumType struct {
+	Name    string
+	Values  []enumValue
+	methods map[string]bool // hand-written methods, which are not generated
+}
+
+type enumValue struct {
+	Const string // Go const name
+	Value string // wire value
+	Label string // display name, from the const's comment
+	Color string // badge variant from @color, or ""
+}
+
+// enumColors are the @color values, matching components.BadgeVariant
+var enumColors = map[string]bool{"primary": true, "success": true, "warning": true, "error": true, "info": true}
+
+// generateEnums writes enum_gen.go with parsing, validation, and display
+// methods for every @enum type in dir, and enum_ui_gen.go with Select
+// options and Table badge renderers for the WASM client. Stale files are
+// removed when there are no enums.
+func generateEnums(dir string) (bool, error) {
+	pkg, enums, err := findEnums(dir)
+	if err != nil {
+		return false, err
+	}
+	sharedPath := filepath.Join(dir, "enum_gen.go")
+	uiPath := filepath.Join(dir, "enum_ui_gen.go")
+	if len(enums) == 0 {
+		for _, p := range []string{sharedPath, uiPath} {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	shared, err := generateEnumCode(pkg, enums)
+	if err != nil {
+		return false, err
+	}
+	ui, err := generateEnumUICode(pkg, enums)
+	if err != nil {
+		return false, err
+	}
+	for path, code := range map[string][]byte{sharedPath: shared, uiPath: ui} {
+		if err := os.WriteFile(path, code, 0644); err != nil {
+			return false, fmt.Errorf("write enums: %w", err)
+		}
+	}
+	fmt.Printf("  generated: %s\n", sharedPath)
+	fmt.Printf("  generated: %s\n", uiPath)
+	return true, nil
+}
+
+// findEnums parses the hand-written Go files in dir for types whose doc
+// comment has an @enum line, and the typed string consts declaring their
+// values:
+//
+//	// PostStatus is where a post is in its life
+//	// @enum
+//	type PostStatus string
+//
+//	const (
+//		PostDraft     PostStatus = "draft"     // Draft @color warning
+//		PostPublished PostStatus = "published" // Published @color success
+//	)
+func findEnums(dir string) (string, []enumType, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkg string
+	byName := make(map[string]*enumType)
+	var order []string
+	var specs []*ast.ValueSpec
+	methods := make(map[string]map[string]bool)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		pkg = file.Name.Name
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) == 1 {
+					recv := strings.TrimPrefix(exprToString(d.Recv.List[0].Type), "*")
+					if methods[recv] == nil {
+						methods[recv] = make(map[string]bool)
+					}
+					methods[recv][d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				switch d.Tok {
+				case token.TYPE:
+					for _, spec := range d.Specs {
+						typeSpec := spec.(*ast.TypeSpec)
+						doc := typeSpec.Doc
+						if doc == nil && len(d.Specs) == 1 {
+							doc = d.Doc
+						}
+						if !hasEnumAnnotation(doc) {
+							continue
+						}
+						if ident, ok := typeSpec.Type.(*ast.Ident); !ok || ident.Name != "string" {
+							return "", nil, fmt.Errorf("@enum %s: only string types are supported", typeSpec.Name.Name)
+						}
+						byName[typeSpec.Name.Name] = &enumType{Name: typeSpec.Name.Name}
+						order = append(order, typeSpec.Name.Name)
+					}
+				case token.CONST:
+					for _, spec := range d.Specs {
+						specs = append(specs, spec.(*ast.ValueSpec))
+					}
+				}
+			}
+		}
+	}
+
+	// Consts can come before their type, so they are matched once every
+	// file has been read
+	for _, spec := range specs {
+		ident, ok := spec.Type.(*ast.Ident)
+		if !ok || byName[ident.Name] == nil {
+			continue
+		}
+		e := byName[ident.Name]
+		comment := spec.Comment
+		if comment == nil {
+			comment = spec.Doc
+		}
+		label, color := parseEnumComment(comment)
+		if color != "" && !enumColors[color] {
+			return "", nil, fmt.Errorf("@enum %s: unknown @color %q (use primary, success, warning, error, or info)", e.Name, color)
+		}
+		for i, name := range spec.Names {
+			if i >= len(spec.Values) {
+				return "", nil, fmt.Errorf("@enum %s: %s needs a string value", e.Name, name.Name)
+			}
+			lit, ok := spec.Values[i].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return "", nil, fmt.Errorf("@enum %s: %s needs a string literal value", e.Name, name.Name)
+			}
+			value, _ := strconv.Unquote(lit.Value)
+			l := label
+			if l == "" || len(spec.Names) > 1 {
+				l = defaultEnumLabel(value)
+			}
+			e.Values = append(e.Values, enumValue{Const: name.Name, Value: value, Label: l, Color: color})
+		}
+	}
+
+	var enums []enumType
+	for _, name := range order {
+		e := byName[name]
+		if len(e.Values) == 0 {
+			return "", nil, fmt.Errorf("@enum %s: no consts of type %s found", name, name)
+		}
+		seen := make(map[string]string)
+		for _, v := range e.Values {
+			if other, ok := seen[v.Value]; ok {
+				return "", nil, fmt.Errorf("@enum %s: %s and %s have the same value %q", name, other, v.Const, v.Value)
+			}
+			seen[v.Value] = v.Const
+		}
+		e.methods = methods[name]
+		enums = append(enums, *e)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+	return pkg, enums, nil
+}
+
+func hasEnumAnnotation(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if strings.TrimSpace(line) == "@enum" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEnumComment splits a const comment such as "Needs review @color
+// warning" into its label and color
+func parseEnumComment(c *ast.CommentGroup) (label, color string) {
+	if c == nil {
+		return "", ""
+	}
+	text := strings.TrimSpace(c.Text())
+	if before, after, ok := strings.Cut(text, "@color"); ok {
+		text = strings.TrimSpace(before)
+		color = strings.TrimSpace(after)
+	}
+	return text, color
+}
+
+// defaultEnumLabel turns a value such as "in_review" into "In review"
+func defaultEnumLabel(value string) string {
+	label := strings.NewReplacer("_", " ", "-", " ").Replace(value)
+	for i, r := range label {
+		return label[:i] + string(unicode.ToUpper(r)) + label[i+len(string(r)):]
+	}
+	return label
+}
+
+// enumList joins the values for messages: "draft, published, or archived"
+func enumList(e enumType) string {
+	values := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = v.Value
+	}
+	switch len(values) {
+	case 1:
+		return values[0]
+	case 2:
+		return values[0] + " or " + values[1]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + ", or " + values[len(values)-1]
+}
+
+func generateEnumCode(pkg string, enums []enumType) ([]byte, error) {
+	var body bytes.Buffer
+	usesI18n := false
+	for _, e := range enums {
+		consts := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			consts[i] = v.Const
+		}
+		n := e.Name
+		has := func(method string) bool { return e.methods[method] }
+
+		fmt.Fprintf(&body, "\n// %sValues lists every %s in declaration order\n", n, n)
+		fmt.Fprintf(&body, "var %sValues = []%s{%s}\n", n, n, strings.Join(consts, ", "))
+
+		fmt.Fprintf(&body, "\n// Parse%s returns the %s for s, or an error if s is not one\n", n, n)
+		fmt.Fprintf(&body, "func Parse%s(s string) (%s, error) {\n", n, n)
+		fmt.Fprintf(&body, "\tif e := %s(s); e.Valid() {\n\t\treturn e, nil\n\t}\n", n)
+		fmt.Fprintf(&body, "\treturn \"\", fmt.Errorf(\"invalid %s %%q (use %s)\", s)\n}\n", n, enumList(e))
+
+		if !has("Valid") {
+			fmt.Fprintf(&body, "\n// Valid reports whether e is one of the declared values\n")
+			fmt.Fprintf(&body, "func (e %s) Valid() bool {\n\tswitch e {\n\tcase %s:\n\t\treturn true\n\t}\n\treturn false\n}\n", n, strings.Join(consts, ", "))
+		}
+
+		if !has("String") {
+			fmt.Fprintf(&body, "\n// String returns the wire value\n")
+			fmt.Fprintf(&body, "func (e %s) String() string {\n\treturn string(e)\n}\n", n)
+		}
+
+		if !has("Label") {
+			usesI18n = true
+			fmt.Fprintf(&body, "\n// Label returns the display name of e: the %q translation\n// when there is one, or the comment on its const\n", "enum."+n+".<value>")
+			fmt.Fprintf(&body, "func (e %s) Label() string {\n", n)
+			fmt.Fprintf(&body, "\tkey := \"enum.%s.\" + string(e)\n", n)
+			body.WriteString("\tif label := i18n.T(key); label != key {\n\t\treturn label\n\t}\n\tswitch e {\n")
+			for _, v := range e.Values {
+				fmt.Fprintf(&body, "\tcase %s:\n\t\treturn %q\n", v.Const, v.Label)
+			}
+			body.WriteString("\t}\n\treturn string(e)\n}\n")
+		}
+
+		if !has("Color") {
+			fmt.Fprintf(&body, "\n// Color returns the badge variant of e from its @color: \"primary\",\n// \"success\", \"warning\", \"error\", \"info\", or \"\" for the default\n")
+			fmt.Fprintf(&body, "func (e %s) Color() string {\n", n)
+			colored := false
+			for _, v := range e.Values {
+				if v.Color != "" {
+					if !colored {
+						body.WriteString("\tswitch e {\n")
+						colored = true
+					}
+					fmt.Fprintf(&body, "\tcase %s:\n\t\treturn %q\n", v.Const, v.Color)
+				}
+			}
+			if colored {
+				body.WriteString("\t}\n")
+			}
+			body.WriteString("\treturn \"\"\n}\n")
+		}
+
+		if !has("MarshalText") {
+			fmt.Fprintf(&body, "\n// MarshalText implements encoding.TextMarshaler\n")
+			fmt.Fprintf(&body, "func (e %s) MarshalText() ([]byte, error) {\n\treturn []byte(e), nil\n}\n", n)
+		}
+
+		if !has("UnmarshalText") {
+			fmt.Fprintf(&body, "\n// UnmarshalText implements encoding.TextUnmarshaler, so JSON bodies and\n// query parameters with unknown values are rejected. \"\" is the zero value.\n")
+			fmt.Fprintf(&body, "func (e *%s) UnmarshalText(text []byte) error {\n", n)
+			body.WriteString("\tif len(text) == 0 {\n\t\t*e = \"\"\n\t\treturn nil\n\t}\n")
+			fmt.Fprintf(&body, "\tv, err := Parse%s(string(text))\n", n)
+			body.WriteString("\tif err != nil {\n\t\treturn err\n\t}\n\t*e = v\n\treturn nil\n}\n")
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if usesI18n {
+		b.WriteString("import (\n\t\"fmt\"\n\n\t\"github.com/dougbarrett/gux/i18n\"\n)\n")
+	} else {
+		b.WriteString("import \"fmt\"\n")
+	}
+	b.Write(body.Bytes())
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format enums: %w", err)
+	}
+	return code, nil
+}
+
+// generateEnumUICode writes the component helpers, which only build for WASM
+func generateEnumUICode(pkg string, enums []enumType) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n//go:build js && wasm\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\t\"syscall/js\"\n\n\t\"github.com/dougbarrett/gux/components\"\n)\n")
+
+	for _, e := range enums {
+		n := e.Name
+		fmt.Fprintf(&b, "\n// %sOptions returns every %s as a SelectOption with its label, for\n// Select components and FormBuilder select and radio fields\n", n, n)
+		fmt.Fprintf(&b, "func %sOptions() []components.SelectOption {\n", n)
+		fmt.Fprintf(&b, "\toptions := make([]components.SelectOption, len(%sValues))\n", n)
+		fmt.Fprintf(&b, "\tfor i, e := range %sValues {\n", n)
+		b.WriteString("\t\toptions[i] = components.SelectOption{Label: e.Label(), Value: string(e)}\n\t}\n\treturn options\n}\n")
+
+		fmt.Fprintf(&b, "\n// %sBadge renders a %s cell as a Badge in its color, for\n// TableColumn.Render. The value may be a %s or a string.\n", n, n, n)
+		fmt.Fprintf(&b, "func %sBadge(row map[string]any, value any) js.Value {\n", n)
+		fmt.Fprintf(&b, "\tvar e %s\n", n)
+		fmt.Fprintf(&b, "\tswitch v := value.(type) {\n\tcase %s:\n\t\te = v\n\tcase string:\n\t\te = %s(v)\n\t}\n", n, n)
+		b.WriteString("\treturn components.Badge(components.BadgeProps{\n\t\tText:    e.Label(),\n\t\tVariant: components.BadgeVariant(e.Color()),\n\t\tRounded: true,\n\t})\n}\n")
+	}
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format enum components: %w", err)
+	}
+	return code, nil
+}
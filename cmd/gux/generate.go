@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-func runGenerate(apiDir string) {
+func runGenerate(apiDir string, postman bool) {
 	// Check if directory exists
 	info, err := os.Stat(apiDir)
 	if err != nil {
@@ -24,31 +24,54 @@ func runGenerate(apiDir string) {
 		os.Exit(1)
 	}
 
-	// Find all .go files with @client annotation
-	files, err := findAPIFiles(apiDir)
+	count, err := generateAPIDir(apiDir)
 	if err != nil {
-		fmt.Printf("Error scanning directory: %v\n", err)
+		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if len(files) == 0 {
+	if count == 0 {
 		fmt.Printf("No API interface files found in '%s'\n", apiDir)
 		fmt.Println("API files should contain a '@client' annotation in interface comments.")
 		return
 	}
 
+	if postman {
+		if err := generatePostman(apiDir); err != nil {
+			fmt.Printf("Error: generating Postman collection: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("\nGenerated %d API file(s) + shared client code\n", count)
+
+	// Check for updates
+	checkForUpdates()
+}
+
+// generateAPIDir generates client and server code for every @client file in
+// apiDir and returns the number of API files processed
+func generateAPIDir(apiDir string) (int, error) {
+	// Find all .go files with @client annotation
+	files, err := findAPIFiles(apiDir)
+	if err != nil {
+		return 0, fmt.Errorf("scanning directory: %w", err)
+	}
+
+	if len(files) == 0 {
+		return 0, nil
+	}
+
 	fmt.Printf("Generating API clients from %d file(s)...\n\n", len(files))
 
 	// Generate shared client code once
 	sharedCode, err := GenerateClientSharedCode()
 	if err != nil {
-		fmt.Printf("Error generating shared client code: %v\n", err)
-		os.Exit(1)
+		return 0, fmt.Errorf("generating shared client code: %w", err)
 	}
 	sharedPath := filepath.Join(apiDir, "client_shared_gen.go")
 	if err := os.WriteFile(sharedPath, []byte(sharedCode), 0644); err != nil {
-		fmt.Printf("Error writing shared client code: %v\n", err)
-		os.Exit(1)
+		return 0, fmt.Errorf("writing shared client code: %w", err)
 	}
 	fmt.Printf("  generated: %s\n\n", sharedPath)
 
@@ -60,15 +83,26 @@ func runGenerate(apiDir string) {
 		fmt.Printf("  %s:\n", filepath.Base(file))
 
 		if err := GenerateAPI(file, outputFile); err != nil {
-			fmt.Printf("Error generating %s: %v\n", file, err)
-			os.Exit(1)
+			return 0, fmt.Errorf("generating %s: %w", file, err)
 		}
 	}
 
-	fmt.Printf("\nGenerated %d API file(s) + shared client code\n", len(files))
+	// Parsing, labels, and component helpers for @enum types
+	if _, err := generateEnums(apiDir); err != nil {
+		return 0, fmt.Errorf("generating enums: %w", err)
+	}
 
-	// Check for updates
-	checkForUpdates()
+	// Validate methods for request structs with validate tags
+	if _, err := generateValidators(apiDir); err != nil {
+		return 0, fmt.Errorf("generating validators: %w", err)
+	}
+
+	// JSON methods for structs with time tags
+	if _, err := generateTimeCodecs(apiDir); err != nil {
+		return 0, fmt.Errorf("generating time codecs: %w", err)
+	}
+
+	return len(files), nil
 }
 
 // findAPIFiles finds all .go files in the directory that contain @client annotation
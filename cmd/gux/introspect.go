@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// routeEntry is a page route as gux routes prints it
+type routeEntry struct {
+	Path   string   `json:"path"`
+	Page   string   `json:"page"`
+	Loader string   `json:"loader,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+	Hidden bool     `json:"hidden,omitempty"`
+	File   string   `json:"file"`
+}
+
+// runRoutes prints the routes of the page files in pagesDir, as gux gen
+// routes would register them
+func runRoutes(pagesDir string, asJSON bool) {
+	_, routes, err := findPages(pagesDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries := make([]routeEntry, len(routes))
+	for i, r := range routes {
+		entries[i] = routeEntry{
+			Path:   r.Path,
+			Page:   qualify(r.Dir, r.Page),
+			Loader: qualify(r.Dir, r.Loader),
+			Title:  r.Title,
+			Roles:  r.Roles,
+			Hidden: r.Hidden,
+			File:   filepath.ToSlash(r.File),
+		}
+	}
+	if asJSON {
+		printJSON(entries)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No pages found in '%s'\n", pagesDir)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tPAGE\tLOADER\tROLES\tFILE")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Path, e.Page, dash(e.Loader), dash(strings.Join(e.Roles, ", ")), e.File)
+	}
+	w.Flush()
+}
+
+// qualify prefixes a page function with its package directory, if it isn't
+// in the root package
+func qualify(dir, name string) string {
+	if name == "" || dir == "" || dir == "." {
+		return name
+	}
+	return filepath.ToSlash(dir) + "." + name
+}
+
+// apiEntry is an API route as gux api prints it
+type apiEntry struct {
+	Method   string   `json:"method"`
+	Route    string   `json:"route"`
+	Handler  string   `json:"handler"`         // Interface.Method
+	Path     []string `json:"path,omitempty"`  // path parameters, "name type"
+	Query    []string `json:"query,omitempty"` // query parameters, "key type"
+	Request  string   `json:"request,omitempty"`
+	Response string   `json:"response,omitempty"`
+	Stream   bool     `json:"stream,omitempty"`
+	Auth     bool     `json:"auth"`
+	Roles    []string `json:"roles,omitempty"`
+}
+
+// runAPI prints the routes of the @client interfaces in apiDir, as gux gen
+// would serve them
+func runAPI(apiDir string, asJSON bool) {
+	files, err := findAPIFiles(apiDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries := []apiEntry{}
+	for _, file := range files {
+		node, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Printf("Error: parse %s: %v\n", file, err)
+			os.Exit(1)
+		}
+		interfaces, err := findInterfaces(node)
+		if err != nil {
+			fmt.Printf("Error: %s: %v\n", file, err)
+			os.Exit(1)
+		}
+		for _, iface := range interfaces {
+			for _, m := range iface.Methods {
+				entries = append(entries, newAPIEntry(iface, m))
+			}
+		}
+	}
+
+	if asJSON {
+		printJSON(entries)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No API interface files found in '%s'\n", apiDir)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tROUTE\tHANDLER\tREQUEST\tRESPONSE\tAUTH")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Method, e.Route, e.Handler, dash(e.Request), dash(e.Response), authLabel(e))
+	}
+	w.Flush()
+}
+
+func newAPIEntry(iface InterfaceInfo, m MethodInfo) apiEntry {
+	e := apiEntry{
+		Method:  m.HTTPMethod,
+		Route:   iface.BasePath + m.Path,
+		Handler: iface.Name + "." + m.Name,
+		Request: m.BodyType,
+		Stream:  m.IsStream,
+		Auth:    m.Auth,
+		Roles:   m.Roles,
+	}
+	for _, p := range m.PathParams {
+		e.Path = append(e.Path, p.Name+" "+p.Type)
+	}
+	for _, q := range m.QueryParams {
+		e.Query = append(e.Query, q.Key+" "+q.Type)
+	}
+	if m.HasReturn {
+		// ReturnType drops the pointer, and is a stream's element type
+		e.Response = m.ReturnType
+		if m.IsPointer {
+			e.Response = "*" + m.ReturnType
+		}
+		if m.IsStream {
+			e.Response = "<-chan " + m.ReturnType
+		}
+	}
+	return e
+}
+
+// authLabel describes an API route's access for the table
+func authLabel(e apiEntry) string {
+	switch {
+	case len(e.Roles) > 0:
+		return strings.Join(e.Roles, ", ")
+	case e.Auth:
+		return "signed in"
+	default:
+		return "public"
+	}
+}
+
+// dash shows an empty table cell as "-"
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func printJSON(v any) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+}
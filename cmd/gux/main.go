@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
 )
 
 // getVersion returns the version from module info (set by go install @vX.Y.Z)
@@ -27,31 +28,76 @@ func main() {
 	case "init":
 		initCmd := flag.NewFlagSet("init", flag.ExitOnError)
 		modulePath := initCmd.String("module", "", "Go module path (e.g., github.com/user/myapp)")
+		templateName := initCmd.String("template", "minimal", "Project template: "+presetNames())
 		initCmd.Parse(os.Args[2:])
 
 		if initCmd.NArg() < 1 {
 			fmt.Println("Error: app name required (use '.' for current directory)")
-			fmt.Println("Usage: gux init [--module <module-path>] <appname>")
+			fmt.Println("Usage: gux init [--module <module-path>] [--template <name>] <appname>")
 			fmt.Println("       gux init --module <module-path> .")
 			os.Exit(1)
 		}
 
 		appName := initCmd.Arg(0)
-		runInit(appName, *modulePath)
+		runInit(appName, *modulePath, *templateName)
 
 	case "gen", "generate":
+		if len(os.Args) > 2 && os.Args[2] == "routes" {
+			routesCmd := flag.NewFlagSet("gen routes", flag.ExitOnError)
+			pagesDir := routesCmd.String("dir", "cmd/app/pages", "Directory containing page files")
+			routesCmd.Parse(os.Args[3:])
+
+			runGenerateRoutes(*pagesDir)
+			return
+		}
+
 		genCmd := flag.NewFlagSet("gen", flag.ExitOnError)
 		apiDir := genCmd.String("dir", "internal/api", "Directory containing API interface files")
+		postman := genCmd.Bool("postman", false, "Also write postman_collection.json for Postman, Bruno, or Insomnia")
 		genCmd.Parse(os.Args[2:])
 
-		runGenerate(*apiDir)
+		runGenerate(*apiDir, *postman)
+
+	case "routes":
+		routesCmd := flag.NewFlagSet("routes", flag.ExitOnError)
+		pagesDir := routesCmd.String("dir", "cmd/app/pages", "Directory containing page files")
+		asJSON := routesCmd.Bool("json", false, "Print JSON instead of a table")
+		routesCmd.Parse(os.Args[2:])
+
+		runRoutes(*pagesDir, *asJSON)
+
+	case "api":
+		apiCmd := flag.NewFlagSet("api", flag.ExitOnError)
+		apiDir := apiCmd.String("dir", "internal/api", "Directory containing API interface files")
+		asJSON := apiCmd.Bool("json", false, "Print JSON instead of a table")
+		apiCmd.Parse(os.Args[2:])
+
+		runAPI(*apiDir, *asJSON)
 
 	case "build":
 		buildCmd := flag.NewFlagSet("build", flag.ExitOnError)
 		useGo := buildCmd.Bool("go", false, "Use standard Go instead of TinyGo (~5MB vs ~500KB)")
+		hash := buildCmd.Bool("hash", true, "Give assets content-hashed names for cache-busting")
+		compress := buildCmd.String("compress", "gzip,br", "Precompressed encodings to write, comma-separated (empty for none)")
+		tailwind := buildCmd.Bool("tailwind", true, "Build purged Tailwind CSS with the standalone CLI instead of loading the CDN")
 		buildCmd.Parse(os.Args[2:])
 
-		runBuild(!*useGo) // TinyGo is default
+		opts := assetOptions{Hash: *hash}
+		for _, encoding := range strings.Split(*compress, ",") {
+			if encoding = strings.TrimSpace(encoding); encoding != "" {
+				opts.Compress = append(opts.Compress, encoding)
+			}
+		}
+		runBuild(!*useGo, *tailwind, opts) // TinyGo is default
+
+	case "deploy":
+		deployCmd := flag.NewFlagSet("deploy", flag.ExitOnError)
+		target := deployCmd.String("target", "", "Deploy target: static or docker (default from gux.json, else static)")
+		config := deployCmd.String("config", configFile, "Path to project config file")
+		useGo := deployCmd.Bool("go", false, "Use standard Go instead of TinyGo (static target)")
+		deployCmd.Parse(os.Args[2:])
+
+		runDeploy(*config, *target, !*useGo) // TinyGo is default
 
 	case "dev":
 		devCmd := flag.NewFlagSet("dev", flag.ExitOnError)
@@ -68,6 +114,58 @@ func main() {
 
 		runSetup(!*useGo) // TinyGo is default
 
+	case "doctor":
+		doctorCmd := flag.NewFlagSet("doctor", flag.ExitOnError)
+		useGo := doctorCmd.Bool("go", false, "Check for standard Go instead of TinyGo")
+		pkg := doctorCmd.String("pkg", "./cmd/app", "WASM entry package to check")
+		doctorCmd.Parse(os.Args[2:])
+
+		runDoctor(!*useGo, *pkg) // TinyGo is default
+
+	case "test":
+		testCmd := flag.NewFlagSet("test", flag.ExitOnError)
+		browser := testCmd.Bool("browser", false, "Run in headless Chrome instead of Node with jsdom")
+		run := testCmd.String("run", "", "Run only tests matching this regular expression")
+		verbose := testCmd.Bool("v", false, "Print each test's name and result")
+		timeout := testCmd.String("timeout", "", "Fail a package's tests after this long (default go test's 10m)")
+		testCmd.Parse(os.Args[2:])
+
+		var goFlags []string
+		if *run != "" {
+			goFlags = append(goFlags, "-run", *run)
+		}
+		if *verbose {
+			goFlags = append(goFlags, "-v")
+		}
+		if *timeout != "" {
+			goFlags = append(goFlags, "-timeout", *timeout)
+		}
+		runTest(testCmd.Args(), goFlags, *browser)
+
+	case "wasmexec": // go test's -exec program, used by gux test
+		runWasmExec(os.Args[2:])
+
+	case "plugin":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: gux plugin add <import-path>")
+			fmt.Println("       gux plugin list")
+			os.Exit(1)
+		}
+		switch os.Args[2] {
+		case "add":
+			if len(os.Args) < 4 {
+				fmt.Println("Error: import path required")
+				fmt.Println("Usage: gux plugin add <import-path>")
+				os.Exit(1)
+			}
+			runPluginAdd(os.Args[3])
+		case "list":
+			runPluginList()
+		default:
+			fmt.Printf("Unknown plugin command: %s\n", os.Args[2])
+			os.Exit(1)
+		}
+
 	case "claude":
 		runClaude()
 
@@ -97,10 +195,19 @@ func printUsage() {
 Usage:
     gux init [--module <module-path>] <appname>   Create a new Gux application
     gux init --module <module-path> .             Initialize in current directory
+    gux init --template <name> <appname>          Scaffold from a project template
     gux setup [--go]                              Copy wasm_exec.js to public/
-    gux gen [--dir <api-dir>]                     Generate API client code
-    gux build [--go]                              Build WASM and server binary
+    gux gen [--dir <api-dir>] [--postman]         Generate API client code (and a Postman collection)
+    gux gen routes [--dir <pages-dir>]            Generate router registration from page files
+    gux routes [--dir <pages-dir>] [--json]       Print the page routes, loaders, and roles
+    gux api [--dir <api-dir>] [--json]            Print the API routes, types, and auth
+    gux build [--go] [--hash=false]               Build WASM, Tailwind CSS, and server binary with hashed, precompressed assets
     gux dev [--port <port>] [--go]                Build and run dev server
+    gux deploy [--target static|docker] [--go]    Build production artifacts for deployment
+    gux doctor [--go]                             Warn about imports that fail under the compiler
+    gux test [--browser] [-v] [packages]          Run WASM component tests headless
+    gux plugin add <import-path>                  Enable a plugin package in cmd/app/plugins.go
+    gux plugin list                               List enabled plugins
     gux claude                                    Install Claude Code skill
     gux update [--check]                          Update gux to latest version
     gux version                                   Show version
@@ -111,23 +218,41 @@ TinyGo is the default compiler (~500KB WASM). Use --go for standard Go (~5MB).
 Examples:
     gux init --module github.com/myuser/myapp myapp   # Create new directory
     gux init --module github.com/myuser/myapp .       # Use current directory
+    gux init --template admin-dashboard myapp         # Start from the admin template
     gux setup                # Copy wasm_exec.js from TinyGo to public/
     gux setup --go           # Copy wasm_exec.js from standard Go to public/
+    gux gen --postman        # Also write internal/api/postman_collection.json
+    gux routes               # Table of cmd/app/pages routes
+    gux api --json > api.json    # Snapshot the API to diff in CI
     gux build                # Build with TinyGo (~500KB WASM)
     gux build --go           # Build with standard Go (~5MB WASM)
+    gux build --hash=false   # Keep asset names as they are in public/
+    gux build --tailwind=false   # Keep loading Tailwind from the CDN
     gux dev                  # Run dev server on :8080 (TinyGo)
     gux dev --port 3000      # Run on custom port
+    gux deploy               # Write dist/ with precompressed assets for static hosts
+    gux deploy --target docker   # Build a Docker image (config in gux.json)
+    gux doctor               # Check cmd/app's imports for TinyGo problems
+    gux test ./components/...    # Run WASM tests in Node with jsdom
+    gux test --browser -v ./...  # Run WASM tests in headless Chrome
     gux claude               # Install Claude Code skill for AI assistance
     gux update               # Update gux to latest release
     gux update --check       # Check for updates without installing
 
 The init command creates a Gux application scaffold including:
     - cmd/app/main.go       - WASM frontend entry point
+    - cmd/app/plugins.go    - Enabled plugin packages
     - cmd/server/main.go    - HTTP server
     - internal/api/         - Shared API definitions
     - public/               - Static files (index.html, manifest.json, etc.)
     - Dockerfile            - Multi-stage Docker build
 
+Templates (--template):
+    minimal           Home and About pages with an example Items API (default)
+    admin-dashboard   Dashboard with stats, charts, and a users table
+    auth              Login page, JWT-protected API, and auth-aware routes
+    blog              Post list, reader, and editor backed by a Posts API
+
 After scaffolding, run:
     gux setup     # Copy wasm_exec.js to public/
     gux claude    # Install Claude Code skill (optional)
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// pluginsFile lists the plugin imports of a scaffolded app
+const pluginsFile = "cmd/app/plugins.go"
+
+// pluginsMarker is the line in plugins.go above which imports are inserted
+const pluginsMarker = "// gux:plugins"
+
+func runPluginAdd(importPath string) {
+	content, err := os.ReadFile(pluginsFile)
+	if err != nil {
+		fmt.Printf("Error: %s not found\n", pluginsFile)
+		fmt.Println("Run this command from your gux project root.")
+		os.Exit(1)
+	}
+
+	for _, p := range pluginImports(string(content)) {
+		if p == importPath {
+			fmt.Printf("Plugin %s is already enabled\n", importPath)
+			return
+		}
+	}
+
+	lines := strings.Split(string(content), "\n")
+	markerLine := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), pluginsMarker) {
+			markerLine = i
+			break
+		}
+	}
+	if markerLine < 0 {
+		fmt.Printf("Error: %s has no '%s' marker\n", pluginsFile, pluginsMarker)
+		fmt.Printf("Add the import manually: _ %q\n", importPath)
+		os.Exit(1)
+	}
+
+	importLine := "\t_ " + strconv.Quote(importPath)
+	lines = append(lines[:markerLine], append([]string{importLine}, lines[markerLine:]...)...)
+	updated := []byte(strings.Join(lines, "\n"))
+	if formatted, err := format.Source(updated); err == nil {
+		updated = formatted
+	}
+	if err := os.WriteFile(pluginsFile, updated, 0644); err != nil {
+		fmt.Printf("Error writing %s: %v\n", pluginsFile, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Enabled plugin %s in %s\n", importPath, pluginsFile)
+
+	// Fetch the module unless it lives inside this project
+	if module := currentModule(); module == "" || !strings.HasPrefix(importPath, module+"/") {
+		fmt.Printf("\nRunning go get %s...\n", importPath)
+		cmd := exec.Command("go", "get", importPath)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Printf("Warning: go get failed: %v\n", err)
+			fmt.Println("You may need to run 'go get' manually.")
+		}
+	}
+}
+
+func runPluginList() {
+	content, err := os.ReadFile(pluginsFile)
+	if err != nil {
+		fmt.Printf("Error: %s not found\n", pluginsFile)
+		fmt.Println("Run this command from your gux project root.")
+		os.Exit(1)
+	}
+
+	plugins := pluginImports(string(content))
+	if len(plugins) == 0 {
+		fmt.Println("No plugins enabled. Add one with: gux plugin add <import-path>")
+		return
+	}
+	for _, p := range plugins {
+		fmt.Println(p)
+	}
+}
+
+// pluginImports returns the blank-imported packages in plugins.go
+func pluginImports(content string) []string {
+	var imports []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "_ ") {
+			continue
+		}
+		if path, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(line, "_ "))); err == nil {
+			imports = append(imports, path)
+		}
+	}
+	return imports
+}
+
+// currentModule returns the module path from ./go.mod, or "" if unavailable
+func currentModule() string {
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module "))
+		}
+	}
+	return ""
+}
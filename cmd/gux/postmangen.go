@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// postmanSchema is the Postman collection format written, which Bruno,
+// Insomnia and Hoppscotch import too
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Variable []postmanVariable `json:"variable"`
+	Item     []postmanItem     `json:"item"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Schema      string `json:"schema"`
+}
+
+// postmanAuth is bearer auth with the token variable, or "noauth"
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanVariable `json:"bearer,omitempty"`
+}
+
+type postmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// postmanItem is a folder, with Item, or a request
+type postmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []postmanItem   `json:"item,omitempty"`
+	Request     *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string            `json:"method"`
+	Auth        *postmanAuth      `json:"auth,omitempty"`
+	Header      []postmanVariable `json:"header"`
+	URL         postmanURL        `json:"url"`
+	Body        *postmanBody      `json:"body,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanVariable `json:"query,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanBody struct {
+	Mode    string `json:"mode"`
+	Raw     string `json:"raw"`
+	Options struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+// generatePostman writes postman_collection.json in apiDir, with a request
+// for each route of its @client interfaces
+func generatePostman(apiDir string) error {
+	files, err := findAPIFiles(apiDir)
+	if err != nil {
+		return err
+	}
+	types, err := findAPITypes(apiDir)
+	if err != nil {
+		return err
+	}
+
+	name := "API"
+	if abs, err := filepath.Abs(apiDir); err == nil {
+		if module, _, err := findModule(abs); err == nil {
+			name = path.Base(module)
+		}
+	}
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name: name,
+			Description: "Generated by gux gen --postman. Set baseUrl to the server, e.g. http://localhost:8080. " +
+				"Routes marked @auth or @roles send the token variable as a bearer token: sign in, copy the JWT into token, " +
+				"and every request in the collection uses it. Public routes send no token.",
+			Schema: postmanSchema,
+		},
+		Auth: &postmanAuth{Type: "bearer", Bearer: []postmanVariable{{Key: "token", Value: "{{token}}", Type: "string"}}},
+		Variable: []postmanVariable{
+			{Key: "baseUrl", Value: "http://localhost:8080", Type: "string"},
+			{Key: "token", Value: "", Type: "string", Description: "JWT for routes that require auth"},
+		},
+	}
+
+	for _, file := range files {
+		node, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", file, err)
+		}
+		interfaces, err := findInterfaces(node)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		for _, iface := range interfaces {
+			folder := postmanItem{Name: iface.Name, Description: iface.Doc}
+			for _, m := range iface.Methods {
+				folder.Item = append(folder.Item, postmanRequestItem(iface, m, types))
+			}
+			collection.Item = append(collection.Item, folder)
+		}
+	}
+	if len(collection.Item) == 0 {
+		return nil
+	}
+
+	out, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	outPath := filepath.Join(apiDir, "postman_collection.json")
+	if err := os.WriteFile(outPath, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("write collection: %w", err)
+	}
+	fmt.Printf("  generated: %s\n", outPath)
+	return nil
+}
+
+func postmanRequestItem(iface InterfaceInfo, m MethodInfo, types apiTypes) postmanItem {
+	req := &postmanRequest{
+		Method:      m.HTTPMethod,
+		Header:      []postmanVariable{},
+		Description: m.Doc,
+	}
+
+	// Path parameters in Postman's :name form
+	route := strings.TrimSuffix(iface.BasePath, "/") + m.Path
+	segments := []string{}
+	for _, seg := range strings.Split(strings.Trim(route, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			seg = ":" + strings.Trim(seg, "{}")
+		}
+		segments = append(segments, seg)
+	}
+	req.URL.Host = []string{"{{baseUrl}}"}
+	req.URL.Path = segments
+	raw := "{{baseUrl}}/" + strings.Join(segments, "/")
+	if strings.HasSuffix(route, "/") && len(segments) > 0 {
+		raw += "/"
+		req.URL.Path = append(req.URL.Path, "")
+	}
+	for _, p := range m.PathParams {
+		req.URL.Variable = append(req.URL.Variable, postmanVariable{Key: p.Name, Value: "", Description: p.Type})
+	}
+
+	// Query parameters with a default are enabled; the rest are listed
+	// disabled, to be switched on as needed
+	var query []string
+	for _, q := range m.QueryParams {
+		value := q.Default
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		req.URL.Query = append(req.URL.Query, postmanVariable{Key: q.Key, Value: value, Description: q.Type, Disabled: q.Default == ""})
+		if q.Default != "" {
+			query = append(query, q.Key+"="+value)
+		}
+	}
+	if len(query) > 0 {
+		raw += "?" + strings.Join(query, "&")
+	}
+	req.URL.Raw = raw
+
+	if m.HasBody {
+		req.Header = append(req.Header, postmanVariable{Key: "Content-Type", Value: "application/json"})
+		example, _ := json.MarshalIndent(types.example(m.BodyType, nil), "", "  ")
+		req.Body = &postmanBody{Mode: "raw", Raw: string(example)}
+		req.Body.Options.Raw.Language = "json"
+	}
+	if m.IsStream {
+		req.Header = append(req.Header, postmanVariable{Key: "Accept", Value: "text/event-stream"})
+	}
+
+	switch {
+	case len(m.Roles) > 0:
+		req.Description = strings.TrimSpace(req.Description + "\n\nRequires one of the roles: " + strings.Join(m.Roles, ", ") + ".")
+	case m.Auth:
+		req.Description = strings.TrimSpace(req.Description + "\n\nRequires a signed-in user.")
+	default:
+		req.Auth = &postmanAuth{Type: "noauth"}
+	}
+	return postmanItem{Name: m.Name, Request: req}
+}
+
+// apiTypes are the types declared in an API package, for example bodies
+type apiTypes struct {
+	structs map[string]*ast.StructType
+	named   map[string]string // other named types, by underlying type
+}
+
+// findAPITypes parses the hand-written Go files in dir for type declarations
+func findAPITypes(dir string) (apiTypes, error) {
+	types := apiTypes{structs: make(map[string]*ast.StructType), named: make(map[string]string)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return types, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return types, fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			d, ok := decl.(*ast.GenDecl)
+			if !ok || d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if st, ok := typeSpec.Type.(*ast.StructType); ok {
+					types.structs[typeSpec.Name.Name] = st
+				} else {
+					types.named[typeSpec.Name.Name] = fieldType(typeSpec.Type)
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+// example returns the JSON of typ's zero value, except that slices and
+// maps are empty rather than null and pointers to structs are filled in, so
+// every field shows. seen stops recursive types.
+func (types apiTypes) example(typ string, seen map[string]bool) any {
+	typ = strings.TrimPrefix(typ, "*")
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		return []any{}
+	case strings.HasPrefix(typ, "map["):
+		return map[string]any{}
+	}
+	switch typ {
+	case "string":
+		return ""
+	case "bool":
+		return false
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return 0
+	case "time.Time":
+		return "0001-01-01T00:00:00Z"
+	case "any", "interface{}":
+		return nil
+	}
+	if underlying, ok := types.named[typ]; ok {
+		return types.example(underlying, seen)
+	}
+	st, ok := types.structs[typ]
+	if !ok || seen[typ] {
+		return nil // types from other packages marshal themselves, mostly to null when zero
+	}
+	seen = withSeen(seen, typ)
+
+	obj := orderedObject{}
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+		key, _, _ := strings.Cut(tag.Get("json"), ",")
+		if key == "-" {
+			continue
+		}
+		typ := fieldType(field.Type)
+		names := field.Names
+		if len(names) == 0 {
+			// Embedded structs' fields are promoted, unless it has a key
+			value := types.example(typ, seen)
+			if embedded, ok := value.(orderedObject); ok && key == "" {
+				obj = append(obj, embedded...)
+				continue
+			}
+			typeName := strings.TrimPrefix(typ, "*")
+			if i := strings.LastIndex(typeName, "."); i >= 0 {
+				typeName = typeName[i+1:]
+			}
+			names = []*ast.Ident{ast.NewIdent(typeName)}
+		}
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			k := key
+			if k == "" {
+				k = name.Name
+			}
+			var value any
+			if _, timed := tag.Lookup("time"); !timed {
+				value = types.example(typ, seen) // time tags write null for the zero time
+			}
+			obj = append(obj, orderedField{k, value})
+		}
+	}
+	return obj
+}
+
+func withSeen(seen map[string]bool, typ string) map[string]bool {
+	next := map[string]bool{typ: true}
+	for k := range seen {
+		next[k] = true
+	}
+	return next
+}
+
+// orderedObject is a JSON object keeping its fields in declaration order
+type orderedObject []orderedField
+
+type orderedField struct {
+	key   string
+	value any
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
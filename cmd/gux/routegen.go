@@ -0,0 +1,463 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pageRoute is a page file and the route its path maps to
+type pageRoute struct {
+	Path    string // route, e.g. "/users/{id}"
+	File    string // file, relative to the pages directory
+	Dir     string // package directory, relative to the pages directory
+	Package string // package name
+	Page    string // @page function
+	Params  int    // parameters of the @page function
+	Loader  string // @loader function, or ""
+	Title   string // @title, or ""
+
+	// Menu metadata, from @label, @icon, @description, @roles, @parent
+	// and @hidden
+	Label       string
+	Icon        string
+	Description string
+	Roles       []string
+	Parent      string
+	Hidden      bool
+}
+
+// hasMeta reports whether the page sets any menu metadata
+func (r pageRoute) hasMeta() bool {
+	return r.Label != "" || r.Icon != "" || r.Description != "" || len(r.Roles) > 0 || r.Parent != "" || r.Hidden
+}
+
+// runGenerateRoutes writes routes_gen.go in pagesDir, registering the page
+// in each file under it
+func runGenerateRoutes(pagesDir string) {
+	info, err := os.Stat(pagesDir)
+	if err != nil || !info.IsDir() {
+		fmt.Printf("Error: pages directory '%s' does not exist\n", pagesDir)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Generating routes from %s...\n\n", pagesDir)
+	routes, err := generateRoutes(pagesDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(routes) == 0 {
+		fmt.Printf("No pages found in '%s'\n", pagesDir)
+		fmt.Println("Page files need a function with an '@page' annotation in its doc comment.")
+		return
+	}
+	for _, r := range routes {
+		fmt.Printf("  %-24s %s\n", r.Path, r.File)
+	}
+	fmt.Printf("\nGenerated %d route(s)\n", len(routes))
+}
+
+// generateRoutes finds the pages under pagesDir and writes routes_gen.go
+// registering them, or removes it when there are none
+func generateRoutes(pagesDir string) ([]pageRoute, error) {
+	pkg, routes, err := findPages(pagesDir)
+	if err != nil {
+		return nil, err
+	}
+	outPath := filepath.Join(pagesDir, "routes_gen.go")
+	if len(routes) == 0 {
+		if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	importPath, err := packageImportPath(pagesDir)
+	if err != nil {
+		return nil, err
+	}
+	code, err := generateRoutesCode(pkg, importPath, routes)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(outPath, code, 0644); err != nil {
+		return nil, fmt.Errorf("write routes: %w", err)
+	}
+	fmt.Printf("  generated: %s\n\n", outPath)
+	return routes, nil
+}
+
+// findPages walks pagesDir for page files and returns the root package's
+// name and the routes, in registration order. A file's path gives its
+// route: "index.go" is its directory's route, "users/id_param.go" is
+// "/users/{id}", and "docs/path_rest.go" is "/docs/{path...}", matching
+// the rest of the URL. Go rejects brackets in file and package names, hence
+// the suffixes instead of "[id].go". Dots separate further segments, so
+// parameters can be followed by more of the path: "users/id_param.edit.go"
+// is "/users/{id}/edit".
+//
+// A page file has one function whose doc comment has an @page line, which
+// returns the page's content, and optionally an @title line. Lines for the
+// fields of components.RouteMeta add the page to the menus built from the
+// router: @label, @icon, @description, @roles (comma-separated), @parent
+// and @hidden. A function marked @loader loads the page's data first:
+//
+//	// Post shows a post
+//	// @page
+//	// @title Post {id}
+//	// @roles admin, editor
+//	func Post(params map[string]string, post api.Post) js.Value
+//
+//	// LoadPost fetches the post before Post is shown
+//	// @loader
+//	func LoadPost(ctx context.Context, params map[string]string) (api.Post, error)
+func findPages(pagesDir string) (string, []pageRoute, error) {
+	var rootPkg string
+	var routes []pageRoute
+	seen := make(map[string]string)
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(pagesDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != pagesDir && (strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", p, err)
+		}
+		rel, _ := filepath.Rel(pagesDir, p)
+		rel = filepath.ToSlash(rel)
+		dir := path.Dir(rel)
+		if dir == "." {
+			dir = ""
+			rootPkg = file.Name.Name
+		}
+
+		route := pageRoute{File: rel, Dir: dir, Package: file.Name.Name}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			annotations := pageAnnotations(fn.Doc)
+			if _, ok := annotations["page"]; ok {
+				if route.Page != "" {
+					return fmt.Errorf("%s: %s and %s are both marked @page", rel, route.Page, fn.Name.Name)
+				}
+				route.Page = fn.Name.Name
+				route.Title = annotations["title"]
+				route.Label = annotations["label"]
+				route.Icon = annotations["icon"]
+				route.Description = annotations["description"]
+				route.Parent = annotations["parent"]
+				_, route.Hidden = annotations["hidden"]
+				for _, role := range strings.Split(annotations["roles"], ",") {
+					if role = strings.TrimSpace(role); role != "" {
+						route.Roles = append(route.Roles, role)
+					}
+				}
+				route.Params = fn.Type.Params.NumFields()
+				if fn.Type.Results.NumFields() != 1 {
+					return fmt.Errorf("%s: @page %s must return the page's js.Value", rel, fn.Name.Name)
+				}
+			}
+			if _, ok := annotations["loader"]; ok {
+				if route.Loader != "" {
+					return fmt.Errorf("%s: %s and %s are both marked @loader", rel, route.Loader, fn.Name.Name)
+				}
+				if fn.Type.Params.NumFields() != 2 || fn.Type.Results.NumFields() != 2 {
+					return fmt.Errorf("%s: @loader %s must be func(ctx context.Context, params map[string]string) (T, error)", rel, fn.Name.Name)
+				}
+				route.Loader = fn.Name.Name
+			}
+		}
+		if route.Page == "" {
+			if route.Loader != "" {
+				return fmt.Errorf("%s: @loader %s has no @page", rel, route.Loader)
+			}
+			return nil // a helper file
+		}
+		switch {
+		case route.Loader == "" && route.Params > 1:
+			return fmt.Errorf("%s: @page %s takes (params map[string]string) or nothing", rel, route.Page)
+		case route.Loader != "" && route.Params != 2:
+			return fmt.Errorf("%s: @page %s must take (params map[string]string, data) to receive %s's data", rel, route.Page, route.Loader)
+		}
+		if !ast.IsExported(route.Page) && dir != "" {
+			return fmt.Errorf("%s: @page %s must be exported", rel, route.Page)
+		}
+		if route.Loader != "" && !ast.IsExported(route.Loader) && dir != "" {
+			return fmt.Errorf("%s: @loader %s must be exported", rel, route.Loader)
+		}
+
+		route.Path, err = pageRoutePath(rel)
+		if err != nil {
+			return err
+		}
+		if other, ok := seen[route.Path]; ok {
+			return fmt.Errorf("%s and %s are both the route %s", other, rel, route.Path)
+		}
+		seen[route.Path] = rel
+		routes = append(routes, route)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(routes) > 0 && rootPkg == "" {
+		return "", nil, fmt.Errorf("%s has no Go files; add one (e.g. index.go or doc.go) for the generated RegisterRoutes", pagesDir)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	return rootPkg, routes, nil
+}
+
+// pageRoutePath turns a page file's path into its route:
+// "users/id_param.edit.go" is "/users/{id}/edit"
+func pageRoutePath(file string) (string, error) {
+	dir, name := path.Split(strings.TrimSuffix(file, ".go"))
+	segments := strings.Split(strings.Trim(dir, "/"), "/")
+	if segments[0] == "" {
+		segments = nil
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		switch {
+		case part == "index" && i == len(parts)-1:
+			continue
+		case strings.HasSuffix(part, "_rest") && part != "_rest":
+			if i != len(parts)-1 {
+				return "", fmt.Errorf("%s: %s must be the last segment", file, part)
+			}
+			segments = append(segments, "{"+strings.TrimSuffix(part, "_rest")+"...}")
+		case strings.HasSuffix(part, "_param") && part != "_param":
+			segments = append(segments, "{"+strings.TrimSuffix(part, "_param")+"}")
+		case part == "":
+			return "", fmt.Errorf("%s: empty route segment", file)
+		default:
+			segments = append(segments, part)
+		}
+	}
+	return "/" + strings.Join(segments, "/"), nil
+}
+
+// pageAnnotations returns the @name lines of a doc comment, with the rest
+// of each line as the value
+func pageAnnotations(doc *ast.CommentGroup) map[string]string {
+	annotations := make(map[string]string)
+	if doc == nil {
+		return annotations
+	}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, value, _ := strings.Cut(line[1:], " ")
+		annotations[name] = strings.TrimSpace(value)
+	}
+	return annotations
+}
+
+// packageImportPath returns dir's import path, from the nearest go.mod
+func packageImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	module, root, err := findModule(abs)
+	if err != nil {
+		return "", err
+	}
+	rel, _ := filepath.Rel(root, abs)
+	return path.Join(module, filepath.ToSlash(rel)), nil
+}
+
+// findModule returns the module path and root directory of the nearest
+// go.mod above the absolute directory dir
+func findModule(dir string) (module, root string, err error) {
+	for root := dir; ; root = filepath.Dir(root) {
+		content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(content), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return strings.Trim(strings.TrimSpace(module), `"`), root, nil
+				}
+			}
+			return "", "", fmt.Errorf("%s has no module line", filepath.Join(root, "go.mod"))
+		}
+		if filepath.Dir(root) == root {
+			return "", "", errors.New("no go.mod found above " + dir)
+		}
+	}
+}
+
+// routeMetaFields returns the fields of r's components.RouteMeta literal
+func routeMetaFields(r pageRoute) string {
+	var fields []string
+	for _, f := range []struct{ name, value string }{
+		{"Label", r.Label},
+		{"Icon", r.Icon},
+		{"Description", r.Description},
+		{"Parent", r.Parent},
+	} {
+		if f.value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %q", f.name, f.value))
+		}
+	}
+	if len(r.Roles) > 0 {
+		roles := make([]string, len(r.Roles))
+		for i, role := range r.Roles {
+			roles[i] = strconv.Quote(role)
+		}
+		fields = append(fields, "Roles: []string{"+strings.Join(roles, ", ")+"}")
+	}
+	if r.Hidden {
+		fields = append(fields, "Hidden: true")
+	}
+	return strings.Join(fields, ", ")
+}
+
+func generateRoutesCode(pkg, importPath string, routes []pageRoute) ([]byte, error) {
+	// Name each subpackage, qualifying those whose names clash
+	names := make(map[string]string) // dir -> name used in the code
+	used := map[string]bool{pkg: true, "components": true, "context": true, "js": true}
+	var dirs []string
+	for _, r := range routes {
+		if r.Dir == "" || names[r.Dir] != "" {
+			continue
+		}
+		name := r.Package
+		if used[name] {
+			name = strings.NewReplacer("/", "", "-", "", ".", "").Replace(r.Dir)
+		}
+		for i := 2; used[name]; i++ {
+			name = r.Package + strconv.Itoa(i)
+		}
+		used[name] = true
+		names[r.Dir] = name
+		dirs = append(dirs, r.Dir)
+	}
+	sort.Strings(dirs)
+
+	usesLoader := false
+	var body bytes.Buffer
+	for _, r := range routes {
+		qualify := func(fn string) string {
+			if r.Dir == "" {
+				return fn
+			}
+			return names[r.Dir] + "." + fn
+		}
+		fmt.Fprintf(&body, "\n\t// %s\n", r.File)
+		switch {
+		case r.Loader != "":
+			usesLoader = true
+			fmt.Fprintf(&body, "\tregisterLoadedPage(r, %q, %s, %s, mount)\n", r.Path, qualify(r.Loader), qualify(r.Page))
+		case r.Params == 1:
+			fmt.Fprintf(&body, "\tr.Register(%q, func() { mount(%s(r.Params())) })\n", r.Path, qualify(r.Page))
+		default:
+			fmt.Fprintf(&body, "\tr.Register(%q, func() { mount(%s()) })\n", r.Path, qualify(r.Page))
+		}
+		if r.Title != "" {
+			fmt.Fprintf(&body, "\tr.Title(%q, %q)\n", r.Path, r.Title)
+		}
+		if r.hasMeta() {
+			fmt.Fprintf(&body, "\tr.Meta(%q, components.RouteMeta{%s})\n", r.Path, routeMetaFields(r))
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n//go:build js && wasm\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n")
+	if usesLoader {
+		b.WriteString("\t\"context\"\n")
+	}
+	b.WriteString("\t\"syscall/js\"\n\n\t\"github.com/dougbarrett/gux/components\"\n")
+	if len(dirs) > 0 {
+		b.WriteString("\n")
+	}
+	for _, dir := range dirs {
+		p := path.Join(importPath, dir)
+		if names[dir] == path.Base(p) {
+			fmt.Fprintf(&b, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&b, "\t%s %q\n", names[dir], p)
+		}
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// Routes lists the pages' routes\n")
+	b.WriteString("var Routes = []string{\n")
+	for _, r := range routes {
+		fmt.Fprintf(&b, "\t%q,\n", r.Path)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// RegisterRoutes registers each page with r, along with its loader, title\n")
+	b.WriteString("// and menu metadata. mount shows a page's content, e.g. layout.SetContent.\n")
+	b.WriteString("func RegisterRoutes(r *components.Router, mount func(page js.Value)) {")
+	b.Write(body.Bytes())
+	b.WriteString("}\n")
+
+	if usesLoader {
+		b.WriteString(`
+// registerLoadedPage registers a page whose loader runs first, as the
+// route's resolver, and passes its data to the page. The data is kept with
+// the navigation's context, so a load that finishes after the user moved
+// on is never shown for another visit.
+func registerLoadedPage[T any](r *components.Router, path string, load func(ctx context.Context, params map[string]string) (T, error), page func(params map[string]string, data T) js.Value, mount func(page js.Value)) {
+	type resolved struct {
+		ctx  context.Context
+		data T
+	}
+	var last resolved
+	r.Resolve(path, func(ctx context.Context) error {
+		loaded, err := load(ctx, r.Params())
+		if err != nil {
+			return err
+		}
+		if ctx.Err() == nil {
+			last = resolved{ctx: ctx, data: loaded}
+		}
+		return nil
+	})
+	r.Register(path, func() {
+		var data T
+		if last.ctx == r.Context() {
+			data = last.data
+		}
+		mount(page(r.Params(), data))
+	})
+}
+`)
+	}
+
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format routes: %w", err)
+	}
+	return formatted, nil
+}
@@ -3,9 +3,13 @@ package main
 import (
 	"embed"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"text/template"
 )
 
@@ -18,9 +22,120 @@ type TemplateData struct {
 	ModulePath string
 	GuxModule  string
 	GuxVersion string
+	Template   string
 }
 
-func runInit(appName, modulePath string) {
+// templateFile maps an embedded template to its destination in the new project
+type templateFile struct {
+	tmplPath string
+	destPath string
+}
+
+// baseTemplateFiles are created for every project; presets may replace or exclude them
+var baseTemplateFiles = []templateFile{
+	{"templates/go.mod.tmpl", "go.mod"},
+	{"templates/cmd/app/main.go.tmpl", "cmd/app/main.go"},
+	{"templates/cmd/app/plugins.go.tmpl", "cmd/app/plugins.go"},
+	{"templates/cmd/server/main.go.tmpl", "cmd/server/main.go"},
+	{"templates/internal/api/types.go.tmpl", "internal/api/types.go"},
+	{"templates/internal/api/example.go.tmpl", "internal/api/example.go"},
+	{"templates/public/index.html.tmpl", "public/index.html"},
+	{"templates/public/manifest.json.tmpl", "public/manifest.json"},
+	{"templates/public/service-worker.js.tmpl", "public/service-worker.js"},
+	{"templates/Dockerfile.tmpl", "Dockerfile"},
+}
+
+// initPreset is a project template selectable with `gux init --template`.
+// Files under templates/presets/<name>/ mirror destination paths (with a
+// .tmpl suffix) and are layered over the base files.
+type initPreset struct {
+	Name     string
+	Excludes []string // Base files the preset does not use
+	Generate bool     // Run API generation after scaffolding (preset code uses generated clients)
+}
+
+var initPresets = []initPreset{
+	{Name: "minimal"},
+	{Name: "admin-dashboard", Excludes: []string{"internal/api/example.go"}, Generate: true},
+	{Name: "auth", Excludes: []string{"internal/api/example.go"}, Generate: true},
+	{Name: "blog", Excludes: []string{"internal/api/example.go"}, Generate: true},
+}
+
+// presetNames returns the available template names for help output
+func presetNames() string {
+	names := make([]string, len(initPresets))
+	for i, p := range initPresets {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func findPreset(name string) (initPreset, bool) {
+	for _, p := range initPresets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return initPreset{}, false
+}
+
+// presetFiles returns the base files merged with the preset's own files
+func presetFiles(preset initPreset) ([]templateFile, error) {
+	excluded := make(map[string]bool)
+	for _, e := range preset.Excludes {
+		excluded[e] = true
+	}
+
+	overrides := make(map[string]string)
+	var added []templateFile
+	root := path.Join("templates/presets", preset.Name)
+	if _, err := fs.Stat(templates, root); err == nil {
+		err := fs.WalkDir(templates, root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			dest := strings.TrimSuffix(strings.TrimPrefix(p, root+"/"), ".tmpl")
+			overrides[dest] = p
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var files []templateFile
+	for _, f := range baseTemplateFiles {
+		if excluded[f.destPath] {
+			continue
+		}
+		if tmpl, ok := overrides[f.destPath]; ok {
+			f.tmplPath = tmpl
+			delete(overrides, f.destPath)
+		}
+		files = append(files, f)
+	}
+	for dest, tmpl := range overrides {
+		added = append(added, templateFile{tmpl, dest})
+	}
+	// Stable output order for the "created" listing
+	sort.Slice(added, func(i, j int) bool { return added[i].destPath < added[j].destPath })
+	return append(files, added...), nil
+}
+
+func runInit(appName, modulePath, templateName string) {
+	preset, ok := findPreset(templateName)
+	if !ok {
+		fmt.Printf("Error: unknown template '%s'\n", templateName)
+		fmt.Printf("Available templates: %s\n", presetNames())
+		os.Exit(1)
+	}
+
+	filesToCreate, err := presetFiles(preset)
+	if err != nil {
+		fmt.Printf("Error loading template '%s': %v\n", templateName, err)
+		os.Exit(1)
+	}
+
 	// Check if initializing in current directory
 	initHere := appName == "."
 	var targetDir string
@@ -45,7 +160,7 @@ func runInit(appName, modulePath string) {
 		}
 
 		// Check if directory has conflicting files
-		conflicts := checkForConflicts(targetDir)
+		conflicts := checkForConflicts(targetDir, filesToCreate)
 		if len(conflicts) > 0 {
 			fmt.Println("Error: directory contains files that would be overwritten:")
 			for _, f := range conflicts {
@@ -95,25 +210,10 @@ func runInit(appName, modulePath string) {
 		ModulePath: modulePath,
 		GuxModule:  "github.com/dougbarrett/gux",
 		GuxVersion: guxVersion,
+		Template:   preset.Name,
 	}
 
-	// Define files to create from templates
-	filesToCreate := []struct {
-		tmplPath string
-		destPath string
-	}{
-		{"templates/go.mod.tmpl", "go.mod"},
-		{"templates/cmd/app/main.go.tmpl", "cmd/app/main.go"},
-		{"templates/cmd/server/main.go.tmpl", "cmd/server/main.go"},
-		{"templates/internal/api/types.go.tmpl", "internal/api/types.go"},
-		{"templates/internal/api/example.go.tmpl", "internal/api/example.go"},
-		{"templates/public/index.html.tmpl", "public/index.html"},
-		{"templates/public/manifest.json.tmpl", "public/manifest.json"},
-		{"templates/public/service-worker.js.tmpl", "public/service-worker.js"},
-		{"templates/Dockerfile.tmpl", "Dockerfile"},
-	}
-
-	fmt.Printf("Creating Gux application '%s'...\n\n", appName)
+	fmt.Printf("Creating Gux application '%s' (template: %s)...\n\n", appName, preset.Name)
 
 	for _, f := range filesToCreate {
 		if err := renderTemplate(targetDir, f.tmplPath, f.destPath, data); err != nil {
@@ -123,6 +223,15 @@ func runInit(appName, modulePath string) {
 		fmt.Printf("  created %s\n", f.destPath)
 	}
 
+	// Presets reference generated API clients and handlers, so generate them now
+	if preset.Generate {
+		fmt.Println()
+		if _, err := generateAPIDir(filepath.Join(targetDir, "internal", "api")); err != nil {
+			fmt.Printf("Error generating API code: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Run go mod tidy to download dependencies
 	fmt.Println("\nRunning go mod tidy...")
 	cmd := exec.Command("go", "mod", "tidy")
@@ -189,24 +298,12 @@ func isValidAppName(name string) bool {
 }
 
 // checkForConflicts returns a list of files that would be overwritten
-func checkForConflicts(targetDir string) []string {
-	filesToCheck := []string{
-		"go.mod",
-		"cmd/app/main.go",
-		"cmd/server/main.go",
-		"internal/api/types.go",
-		"internal/api/example.go",
-		"public/index.html",
-		"public/manifest.json",
-		"public/service-worker.js",
-		"Dockerfile",
-	}
-
+func checkForConflicts(targetDir string, files []templateFile) []string {
 	var conflicts []string
-	for _, f := range filesToCheck {
-		path := filepath.Join(targetDir, f)
+	for _, f := range files {
+		path := filepath.Join(targetDir, f.destPath)
 		if _, err := os.Stat(path); err == nil {
-			conflicts = append(conflicts, f)
+			conflicts = append(conflicts, f.destPath)
 		}
 	}
 	return conflicts
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TailwindConfig is the "tailwind" section of gux.json
+type TailwindConfig struct {
+	CLI      string   `json:"cli"`      // Standalone Tailwind CLI, v3 (default "tailwindcss" in PATH)
+	Input    string   `json:"input"`    // CSS entry with @tailwind directives and custom styles (default the three directives)
+	Output   string   `json:"output"`   // Stylesheet written to public/ (default "tailwind.css")
+	Safelist []string `json:"safelist"` // Classes put together at run time, which the scan can't find
+	Disabled bool     `json:"disabled"` // Keep loading the CDN build
+}
+
+// tailwindConfigJS is the Tailwind config the CLI runs with: the CDN
+// setup components.LoadTailwind uses, with the scanned classes as content
+const tailwindConfigJS = `module.exports = {
+  darkMode: "class",
+  content: [%s],
+};
+`
+
+// tailwindInput is the CSS entry when gux.json names none
+const tailwindInput = `@tailwind base;
+@tailwind components;
+@tailwind utilities;
+`
+
+// stylesheetAttr marks the link to the built stylesheet, which tells
+// components.LoadTailwind not to load the CDN script
+const stylesheetAttr = "data-gux-tailwind"
+
+// buildTailwind writes a purged Tailwind stylesheet to public/ with the
+// standalone CLI, from the classes in the string literals of the app and
+// every package it imports, gux's components included. It returns the
+// stylesheet's name in public/, or "" when the CLI isn't installed and
+// pages keep loading the CDN build.
+func buildTailwind(cfg TailwindConfig, tinygo bool) (string, error) {
+	if cfg.Disabled {
+		return "", nil
+	}
+	cli := cfg.CLI
+	if cli == "" {
+		cli = "tailwindcss"
+	}
+	if _, err := exec.LookPath(cli); err != nil {
+		fmt.Printf("  skipped Tailwind CSS: '%s' not found in PATH, so pages load the CDN build\n", cli)
+		fmt.Println("  (install the standalone CLI from https://github.com/tailwindlabs/tailwindcss/releases)")
+		return "", nil
+	}
+	output := cfg.Output
+	if output == "" {
+		output = "tailwind.css"
+	}
+
+	classes, err := scanClasses("./cmd/app", tinygo)
+	if err != nil {
+		return "", fmt.Errorf("scan classes: %w", err)
+	}
+	classes = append(classes, cfg.Safelist...)
+
+	tmp, err := os.MkdirTemp("", "gux-tailwind-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	content := filepath.Join(tmp, "classes.txt")
+	if err := os.WriteFile(content, []byte(strings.Join(classes, "\n")), 0644); err != nil {
+		return "", err
+	}
+	config := filepath.Join(tmp, "tailwind.config.js")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf(tailwindConfigJS, strconv.Quote(filepath.ToSlash(content)))), 0644); err != nil {
+		return "", err
+	}
+	input := cfg.Input
+	if input == "" {
+		input = filepath.Join(tmp, "input.css")
+		if err := os.WriteFile(input, []byte(tailwindInput), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	dst := filepath.Join("public", filepath.FromSlash(output))
+	cmd := exec.Command(cli, "-c", config, "-i", input, "-o", dst, "--minify")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", cli, err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Built %s (%.1f KB) from %d class strings\n", filepath.ToSlash(dst), float64(info.Size())/1024, len(classes))
+	return output, nil
+}
+
+// scanClasses returns the string literals of the packages pkg is built
+// from, which Tailwind reads its class names from. Tailwind only finds
+// whole class names, so classes put together at run time must be in a
+// literal somewhere or in the safelist.
+func scanClasses(pkg string, tinygo bool) ([]string, error) {
+	packages, err := listWasmPackages(pkg, tinygo)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range packages {
+		if p.Standard {
+			continue
+		}
+		for _, file := range p.GoFiles {
+			src, err := os.ReadFile(filepath.Join(p.Dir, file))
+			if err != nil {
+				return nil, err
+			}
+			fset := token.NewFileSet()
+			var s scanner.Scanner
+			s.Init(fset.AddFile(file, fset.Base(), len(src)), src, nil, 0)
+			for {
+				_, tok, lit := s.Scan()
+				if tok == token.EOF {
+					break
+				}
+				if tok != token.STRING {
+					continue
+				}
+				if value, err := strconv.Unquote(lit); err == nil && strings.TrimSpace(value) != "" {
+					seen[value] = true
+				}
+			}
+		}
+	}
+
+	classes := make([]string, 0, len(seen))
+	for value := range seen {
+		classes = append(classes, value)
+	}
+	sort.Strings(classes)
+	return classes, nil
+}
+
+// headClose matches the end of an HTML page's head
+var headClose = regexp.MustCompile(`(?i)</head>`)
+
+// linkStylesheet links the stylesheet at href from each HTML page in dir
+// that doesn't already link it, before the end of its head
+func linkStylesheet(dir, href string) error {
+	link := `<link rel="stylesheet" href="/` + href + `" ` + stylesheetAttr + `>`
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || strings.ToLower(filepath.Ext(p)) != ".html" {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		page := string(data)
+		loc := headClose.FindStringIndex(page)
+		if loc == nil || strings.Contains(page, stylesheetAttr) {
+			return nil
+		}
+		page = page[:loc[0]] + "    " + link + "\n" + page[loc[0]:]
+		return os.WriteFile(p, []byte(page), info.Mode())
+	})
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed wasmtest/node.js
+var wasmTestNodeJS []byte
+
+//go:embed wasmtest/browser.html
+var wasmTestBrowserHTML []byte
+
+// runTest runs go test for js/wasm on packages, with this binary as the
+// test binaries' runner (see runWasmExec). Output is go test's own.
+func runTest(packages []string, goFlags []string, browser bool) {
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	execCmd := quoteExecField(self) + " wasmexec"
+	if browser {
+		execCmd += " --browser"
+	}
+
+	args := append([]string{"test", "-exec", execCmd}, goFlags...)
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+	args = append(args, packages...)
+
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// quoteExecField quotes path for go test's -exec flag if it has spaces
+func quoteExecField(path string) string {
+	if !strings.ContainsAny(path, " \t") {
+		return path
+	}
+	if strings.Contains(path, `"`) {
+		return "'" + path + "'"
+	}
+	return `"` + path + `"`
+}
+
+// runWasmExec runs one js/wasm test binary, as go test's -exec program:
+// args are the binary and its flags. The binary runs in Node with a jsdom
+// document, or in headless Chrome with --browser. It exits with the test
+// binary's exit code.
+func runWasmExec(args []string) {
+	browser := len(args) > 0 && args[0] == "--browser"
+	if browser {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: gux wasmexec [--browser] <test binary> [flags]")
+		os.Exit(2)
+	}
+
+	wasmExec, err := goWasmExecJS()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gux test: %v\n", err)
+		os.Exit(1)
+	}
+	var code int
+	if browser {
+		code, err = runWasmInBrowser(wasmExec, args[0], args[1:])
+	} else {
+		code, err = runWasmInNode(wasmExec, args[0], args[1:])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gux test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// goWasmExecJS returns the path of the Go installation's wasm_exec.js
+func goWasmExecJS() (string, error) {
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOROOT: %w", err)
+	}
+	goRoot := strings.TrimSpace(string(out))
+	for _, dir := range []string{"lib/wasm", "misc/wasm"} { // moved to lib in Go 1.24
+		path := filepath.Join(goRoot, dir, "wasm_exec.js")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("wasm_exec.js not found in %s", goRoot)
+}
+
+// runWasmInNode runs binary with Node, in the current directory so tests
+// can read their testdata
+func runWasmInNode(wasmExec, binary string, args []string) (int, error) {
+	if _, err := exec.LookPath("node"); err != nil {
+		return 0, errors.New("node not found; install Node.js, or run with --browser")
+	}
+	runner, err := os.CreateTemp("", "gux-test-*.js")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(runner.Name())
+	if _, err := runner.Write(wasmTestNodeJS); err != nil {
+		runner.Close()
+		return 0, err
+	}
+	runner.Close()
+
+	// Node's default stack is too small for some Go programs, as in Go's
+	// own go_js_wasm_exec
+	cmd := exec.Command("node", append([]string{"--stack-size=8192", runner.Name(), wasmExec, binary}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 0, err
+	}
+	return 0, nil
+}
+
+// runWasmInBrowser serves binary to a page that runs it in headless
+// Chrome, which posts the output and exit code back
+func runWasmInBrowser(wasmExec, binary string, args []string) (int, error) {
+	chrome, err := findChrome()
+	if err != nil {
+		return 0, err
+	}
+	run, err := json.Marshal(map[string]any{
+		"argv": append([]string{filepath.Base(binary)}, args...),
+		"env":  map[string]string{"TMPDIR": "/tmp"},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	exitCode := make(chan int, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(wasmTestBrowserHTML)
+	})
+	mux.HandleFunc("/wasm_exec.js", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, wasmExec)
+	})
+	mux.HandleFunc("/test.wasm", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/wasm")
+		http.ServeFile(w, r, binary)
+	})
+	mux.HandleFunc("/run.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(run)
+	})
+	mux.HandleFunc("POST /output", func(w http.ResponseWriter, r *http.Request) {
+		out := os.Stdout
+		if r.URL.Query().Get("fd") == "2" {
+			out = os.Stderr
+		}
+		io.Copy(out, r.Body)
+	})
+	mux.HandleFunc("POST /exit", func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(r.URL.Query().Get("code"))
+		select {
+		case exitCode <- code:
+		default:
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	profile, err := os.MkdirTemp("", "gux-test-chrome-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(profile)
+
+	cmd := exec.Command(chrome,
+		"--headless=new",
+		"--disable-gpu",
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--user-data-dir="+profile,
+		"http://"+listener.Addr().String()+"/",
+	)
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("start %s: %w", chrome, err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	select {
+	case code := <-exitCode:
+		cmd.Process.Kill()
+		<-exited
+		return code, nil
+	case err := <-exited:
+		// The browser quit before the test binary finished
+		select {
+		case code := <-exitCode:
+			return code, nil
+		case <-time.After(100 * time.Millisecond):
+		}
+		return 0, fmt.Errorf("%s exited before the tests finished: %v", filepath.Base(chrome), err)
+	}
+}
+
+// findChrome returns the path of Chrome or Chromium, from $GUX_CHROME or
+// the usual names and install locations
+func findChrome() (string, error) {
+	if path := os.Getenv("GUX_CHROME"); path != "" {
+		return path, nil
+	}
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	var paths []string
+	switch runtime.GOOS {
+	case "darwin":
+		paths = []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+		}
+	case "windows":
+		for _, dir := range []string{os.Getenv("ProgramFiles"), os.Getenv("ProgramFiles(x86)"), os.Getenv("LocalAppData")} {
+			if dir != "" {
+				paths = append(paths, filepath.Join(dir, "Google", "Chrome", "Application", "chrome.exe"))
+			}
+		}
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("Chrome or Chromium not found; install one or set GUX_CHROME to its path")
+}
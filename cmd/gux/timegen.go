@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	gqapi "github.com/dougbarrett/gux/api"
+)
+
+// timedStruct is a struct with time tags on its fields
+type timedStruct struct {
+	Name      string
+	Fields    []timedField
+	Marshal   bool // generate MarshalJSON
+	Unmarshal bool // generate UnmarshalJSON
+}
+
+type timedField struct {
+	Name      string // Go field name
+	JSONTag   string // the field's json tag, reused on the replacement field
+	Pointer   bool   // *time.Time rather than time.Time
+	Format    string // the time tag
+	OmitEmpty bool
+}
+
+// generateTimeCodecs writes time_gen.go with MarshalJSON and UnmarshalJSON
+// methods for every struct in dir that has time tags, so client and server
+// agree on each field's wire format. Methods the struct already has are not
+// generated. A stale file is removed when no struct has tags.
+func generateTimeCodecs(dir string) (bool, error) {
+	pkg, structs, err := findTimedStructs(dir)
+	if err != nil {
+		return false, err
+	}
+	outPath := filepath.Join(dir, "time_gen.go")
+	if len(structs) == 0 {
+		if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	code, err := generateTimeCodecCode(pkg, structs)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(outPath, code, 0644); err != nil {
+		return false, fmt.Errorf("write time codecs: %w", err)
+	}
+	fmt.Printf("  generated: %s\n", outPath)
+	return true, nil
+}
+
+// findTimedStructs parses the hand-written Go files in dir
+func findTimedStructs(dir string) (string, []timedStruct, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkg string
+	var structs []timedStruct
+	hasMethod := make(map[string]bool) // "Type.Method" for hand-written methods
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		pkg = file.Name.Name
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) == 1 {
+					recv := strings.TrimPrefix(exprToString(d.Recv.List[0].Type), "*")
+					hasMethod[recv+"."+d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					ts, err := parseTimedStruct(typeSpec.Name.Name, st)
+					if err != nil {
+						return "", nil, err
+					}
+					if len(ts.Fields) > 0 {
+						structs = append(structs, ts)
+					}
+				}
+			}
+		}
+	}
+
+	kept := structs[:0]
+	for _, s := range structs {
+		s.Marshal = !hasMethod[s.Name+".MarshalJSON"]
+		s.Unmarshal = !hasMethod[s.Name+".UnmarshalJSON"]
+		if s.Marshal || s.Unmarshal {
+			kept = append(kept, s)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Name < kept[j].Name })
+	return pkg, kept, nil
+}
+
+func parseTimedStruct(name string, st *ast.StructType) (timedStruct, error) {
+	ts := timedStruct{Name: name}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		unquoted, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag := reflect.StructTag(unquoted)
+		spec, ok := tag.Lookup("time")
+		if !ok {
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			typ := fieldType(field.Type)
+			if typ != "time.Time" && typ != "*time.Time" {
+				return ts, fmt.Errorf("%s.%s: time tags are only supported on time.Time and *time.Time fields, not %s", name, ident.Name, typ)
+			}
+			if _, err := gqapi.ParseTimeFormat(spec); err != nil {
+				return ts, fmt.Errorf("%s.%s: %w", name, ident.Name, err)
+			}
+			jsonTag := tag.Get("json")
+			key, opts, _ := strings.Cut(jsonTag, ",")
+			if key == "-" && opts == "" {
+				continue
+			}
+			if key == "" {
+				jsonTag = ident.Name
+				if opts != "" {
+					jsonTag += "," + opts
+				}
+			}
+			ts.Fields = append(ts.Fields, timedField{
+				Name:      ident.Name,
+				JSONTag:   jsonTag,
+				Pointer:   typ == "*time.Time",
+				Format:    spec,
+				OmitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			})
+		}
+	}
+	return ts, nil
+}
+
+// generateTimeCodecCode writes methods that marshal the struct through a
+// copy without methods, with each timed field shadowed by a TimeField under
+// the same JSON key
+func generateTimeCodecCode(pkg string, structs []timedStruct) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\t\"encoding/json\"\n\n\tgqapi \"github.com/dougbarrett/gux/api\"\n)\n")
+
+	for _, s := range structs {
+		if s.Marshal {
+			fmt.Fprintf(&b, "\n// MarshalJSON encodes %s with the formats in its time tags\n", s.Name)
+			fmt.Fprintf(&b, "func (r %s) MarshalJSON() ([]byte, error) {\n", s.Name)
+			fmt.Fprintf(&b, "\ttype plain %s\n", s.Name)
+			b.WriteString("\treturn json.Marshal(struct {\n\t\tplain\n")
+			for _, f := range s.Fields {
+				fmt.Fprintf(&b, "\t\t%s *gqapi.TimeField `json:%q`\n", f.Name, f.JSONTag)
+			}
+			b.WriteString("\t}{\n\t\tplain: plain(r),\n")
+			for _, f := range s.Fields {
+				omit := ""
+				if f.OmitEmpty {
+					omit = ".OmitEmpty()"
+				}
+				fmt.Fprintf(&b, "\t\t%s: %s%s,\n", f.Name, timeFieldCall(f), omit)
+			}
+			b.WriteString("\t})\n}\n")
+		}
+
+		if s.Unmarshal {
+			fmt.Fprintf(&b, "\n// UnmarshalJSON decodes %s with the formats in its time tags\n", s.Name)
+			fmt.Fprintf(&b, "func (r *%s) UnmarshalJSON(data []byte) error {\n", s.Name)
+			fmt.Fprintf(&b, "\ttype plain %s\n", s.Name)
+			b.WriteString("\taux := struct {\n\t\t*plain\n")
+			for _, f := range s.Fields {
+				fmt.Fprintf(&b, "\t\t%s gqapi.TimeField `json:%q`\n", f.Name, f.JSONTag)
+			}
+			b.WriteString("\t}{\n\t\tplain: (*plain)(r),\n")
+			for _, f := range s.Fields {
+				fmt.Fprintf(&b, "\t\t%s: *%s,\n", f.Name, timeFieldCall(f))
+			}
+			b.WriteString("\t}\n\treturn json.Unmarshal(data, &aux)\n}\n")
+		}
+	}
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format time codecs: %w", err)
+	}
+	return code, nil
+}
+
+func timeFieldCall(f timedField) string {
+	if f.Pointer {
+		return fmt.Sprintf("gqapi.TimePtrOf(&r.%s, %q)", f.Name, f.Format)
+	}
+	return fmt.Sprintf("gqapi.TimeOf(&r.%s, %q)", f.Name, f.Format)
+}
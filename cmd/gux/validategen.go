@@ -0,0 +1,721 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dougbarrett/gux/validate"
+)
+
+// validatedStruct is a struct with validate tags on its fields
+type validatedStruct struct {
+	Name   string
+	Fields []validatedField
+	Nested []nestedField
+}
+
+// nestedField is a field holding structs that have a Validate method. Their
+// errors are added under the field's key, e.g. "address.city" or
+// "items.2.quantity".
+type nestedField struct {
+	Name    string // Go field name, empty for an embedded struct
+	Key     string // JSON key, empty for an embedded struct
+	Type    string // the struct type
+	Pointer bool   // *T or []*T
+	Slice   bool   // []T or []*T
+}
+
+type validatedField struct {
+	Name  string // Go field name
+	Key   string // JSON key, used as the key in the error's Fields
+	Type  string
+	Rules []validateRule
+	Enum  []string // the values when Type is an @enum
+}
+
+type validateRule struct {
+	Name  string // e.g. "required", "phone", "min"
+	Value string // text after "=", e.g. "US"
+}
+
+// generateValidators writes validate_gen.go with a Validate method for every
+// struct in dir that has validate tags, or fields holding such structs, and
+// no Validate method of its own. validate_ui_gen.go gets the matching form
+// rules for the WASM client, so forms and handlers check the same things.
+// Stale files are removed when no struct has tags.
+func generateValidators(dir string) (bool, error) {
+	pkg, structs, err := findValidatedStructs(dir)
+	if err != nil {
+		return false, err
+	}
+	outPath := filepath.Join(dir, "validate_gen.go")
+	uiPath := filepath.Join(dir, "validate_ui_gen.go")
+	var tagged []validatedStruct // structs with rules of their own
+	for _, s := range structs {
+		if len(s.Fields) > 0 {
+			tagged = append(tagged, s)
+		}
+	}
+	if len(tagged) == 0 {
+		if err := os.Remove(uiPath); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+	if len(structs) == 0 {
+		if err := os.Remove(outPath); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	files := map[string][]byte{}
+	if files[outPath], err = generateValidatorCode(pkg, structs); err != nil {
+		return false, err
+	}
+	if len(tagged) > 0 {
+		if files[uiPath], err = generateRulesCode(pkg, tagged); err != nil {
+			return false, err
+		}
+	}
+	for _, path := range []string{outPath, uiPath} {
+		code, ok := files[path]
+		if !ok {
+			continue
+		}
+		if err := os.WriteFile(path, code, 0644); err != nil {
+			return false, fmt.Errorf("write validators: %w", err)
+		}
+		fmt.Printf("  generated: %s\n", path)
+	}
+	return true, nil
+}
+
+// findValidatedStructs parses the hand-written Go files in dir
+func findValidatedStructs(dir string) (string, []validatedStruct, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, enumTypes, err := findEnums(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	enums := make(map[string][]string)
+	for _, e := range enumTypes {
+		for _, v := range e.Values {
+			enums[e.Name] = append(enums[e.Name], v.Value)
+		}
+	}
+
+	var pkg string
+	var structs []validatedStruct
+	var types []*ast.TypeSpec            // every struct type, for nested fields
+	hasValidate := make(map[string]bool) // types with a hand-written Validate method
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		pkg = file.Name.Name
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && d.Name.Name == "Validate" && len(d.Recv.List) == 1 {
+					recv := strings.TrimPrefix(exprToString(d.Recv.List[0].Type), "*")
+					hasValidate[recv] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					vs, err := parseValidatedStruct(typeSpec.Name.Name, st, enums)
+					if err != nil {
+						return "", nil, err
+					}
+					structs = append(structs, vs)
+					types = append(types, typeSpec)
+				}
+			}
+		}
+	}
+
+	// A struct is validated when it has tags, a hand-written Validate, or
+	// fields holding validated structs. Nesting can go several levels deep,
+	// so repeat until nothing changes.
+	validated := make(map[string]bool)
+	for name := range hasValidate {
+		validated[name] = true
+	}
+	for _, s := range structs {
+		if len(s.Fields) > 0 {
+			validated[s.Name] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for i, typeSpec := range types {
+			nested := nestedFields(typeSpec.Type.(*ast.StructType), validated)
+			if len(nested) == len(structs[i].Nested) {
+				continue
+			}
+			structs[i].Nested = nested
+			validated[structs[i].Name] = true
+			changed = true
+		}
+	}
+
+	kept := structs[:0]
+	for _, s := range structs {
+		if !hasValidate[s.Name] && (len(s.Fields) > 0 || len(s.Nested) > 0) {
+			kept = append(kept, s)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Name < kept[j].Name })
+	return pkg, kept, nil
+}
+
+// nestedFields returns the fields of st holding structs in validated: T,
+// *T, []T, and []*T, and embedded T and *T
+func nestedFields(st *ast.StructType, validated map[string]bool) []nestedField {
+	var nested []nestedField
+	for _, field := range st.Fields.List {
+		var key string
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				key, _, _ = strings.Cut(reflect.StructTag(unquoted).Get("json"), ",")
+			}
+		}
+		if key == "-" {
+			continue // not sent, so not checked
+		}
+
+		n := nestedField{}
+		typ := field.Type
+		if arr, ok := typ.(*ast.ArrayType); ok && arr.Len == nil {
+			n.Slice = true
+			typ = arr.Elt
+		}
+		if star, ok := typ.(*ast.StarExpr); ok {
+			n.Pointer = true
+			typ = star.X
+		}
+		ident, ok := typ.(*ast.Ident)
+		if !ok || !validated[ident.Name] {
+			continue
+		}
+		n.Type = ident.Name
+
+		if len(field.Names) == 0 {
+			if !n.Slice && key == "" {
+				nested = append(nested, n) // embedded: its keys are the parent's
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			f := n
+			f.Name, f.Key = name.Name, key
+			if f.Key == "" {
+				f.Key = name.Name
+			}
+			nested = append(nested, f)
+		}
+	}
+	return nested
+}
+
+// parseValidatedStruct collects the fields with validate tags. Fields of
+// an @enum type in enums are also checked against its values.
+func parseValidatedStruct(name string, st *ast.StructType, enums map[string][]string) (validatedStruct, error) {
+	vs := validatedStruct{Name: name}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		unquoted, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag := reflect.StructTag(unquoted)
+		spec := tag.Get("validate")
+		if spec == "" || spec == "-" {
+			continue
+		}
+		jsonKey, _, _ := strings.Cut(tag.Get("json"), ",")
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			f := validatedField{Name: ident.Name, Key: jsonKey, Type: fieldType(field.Type), Enum: enums[fieldType(field.Type)]}
+			if f.Key == "" || f.Key == "-" {
+				f.Key = ident.Name
+			}
+			for _, item := range strings.Split(spec, ",") {
+				ruleName, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+				if ruleName == "" {
+					continue
+				}
+				f.Rules = append(f.Rules, validateRule{Name: ruleName, Value: value})
+			}
+			if err := checkFieldRules(f); err != nil {
+				return vs, fmt.Errorf("%s.%s: %w", name, f.Name, err)
+			}
+			vs.Fields = append(vs.Fields, f)
+		}
+	}
+	return vs, nil
+}
+
+// fieldType is like exprToString but keeps pointers and maps, which need
+// their own checks
+func fieldType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + fieldType(t.X)
+	case *ast.MapType:
+		return "map[" + fieldType(t.Key) + "]" + fieldType(t.Value)
+	case *ast.ArrayType:
+		return "[]" + fieldType(t.Elt)
+	}
+	return exprToString(expr)
+}
+
+// fieldKind groups field types by the rules they support
+func fieldKind(typ string) string {
+	switch {
+	case typ == "string":
+		return "string"
+	case typ == "bool":
+		return "bool"
+	case typ == "time.Time":
+		return "time"
+	case typ == "types.Money":
+		return "money"
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return "collection"
+	case strings.HasPrefix(typ, "*"):
+		return "pointer"
+	}
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return "number"
+	}
+	return ""
+}
+
+// rulesByKind lists the validate tag rules each kind of field supports
+var rulesByKind = map[string][]string{
+	"string":     {"required", "email", "url", "uuid", "slug", "phone", "iban", "creditcard", "before", "after", "min", "max", "minlen", "maxlen", "oneof"},
+	"number":     {"required", "min", "max"},
+	"money":      {"required", "min", "max"},
+	"time":       {"required", "before", "after"},
+	"bool":       {"required"},
+	"collection": {"required"},
+	"pointer":    {"required"},
+	"enum":       {"required"},
+}
+
+// kind is fieldKind, with "enum" for @enum fields
+func (f validatedField) kind() string {
+	if f.Enum != nil {
+		return "enum"
+	}
+	return fieldKind(f.Type)
+}
+
+func checkFieldRules(f validatedField) error {
+	kind := f.kind()
+	allowed := rulesByKind[kind]
+	if allowed == nil {
+		return fmt.Errorf("validate tags are not supported on %s fields", f.Type)
+	}
+	for _, r := range f.Rules {
+		supported := false
+		for _, a := range allowed {
+			if r.Name == a {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf("validate rule %q is not supported on %s fields (use %s)", r.Name, f.Type, strings.Join(allowed, ", "))
+		}
+
+		switch r.Name {
+		case "before", "after":
+			if _, ok := validate.ParseDate(r.Value); !ok || len(r.Value) != len(validate.DateLayout) {
+				return fmt.Errorf("validate rule %s: %q is not a date like 2024-12-31", r.Name, r.Value)
+			}
+		case "min", "max":
+			if _, err := strconv.ParseFloat(r.Value, 64); err != nil {
+				return fmt.Errorf("validate rule %s: %q is not a number", r.Name, r.Value)
+			}
+			if _, err := strconv.ParseInt(r.Value, 10, 64); err != nil && kind == "number" && !strings.HasPrefix(f.Type, "float") {
+				return fmt.Errorf("validate rule %s: %q is not an integer", r.Name, r.Value)
+			}
+		case "minlen", "maxlen":
+			if n, err := strconv.Atoi(r.Value); err != nil || n < 0 {
+				return fmt.Errorf("validate rule %s: %q is not a length", r.Name, r.Value)
+			}
+		case "oneof":
+			if r.Value == "" {
+				return fmt.Errorf("validate rule oneof needs values, e.g. oneof=draft|published")
+			}
+		case "phone":
+			// The country is optional
+		default:
+			if r.Value != "" {
+				return fmt.Errorf("validate rule %s takes no value", r.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCase is one case of the generated switch for a field
+type validateCase struct {
+	cond string
+	msg  string // empty for the case that skips optional empty strings
+}
+
+func fieldCases(f validatedField) []validateCase {
+	v := "r." + f.Name
+	kind := f.kind()
+
+	var cases []validateCase
+	required := false
+	var min, max string
+	for _, r := range f.Rules {
+		switch r.Name {
+		case "required":
+			required = true
+		case "min":
+			min = r.Value
+		case "max":
+			max = r.Value
+		}
+	}
+
+	if required {
+		var cond string
+		switch kind {
+		case "string", "enum":
+			cond = v + ` == ""`
+		case "number":
+			cond = v + " == 0"
+		case "time", "money":
+			cond = v + ".IsZero()"
+		case "bool":
+			cond = "!" + v
+		case "collection":
+			cond = "len(" + v + ") == 0"
+		case "pointer":
+			cond = v + " == nil"
+		}
+		cases = append(cases, validateCase{cond, `gqapi.Msg("gux.validation.required")`})
+	} else if kind == "string" || kind == "enum" {
+		// Like the form rules, optional fields may be left empty
+		cases = append(cases, validateCase{v + ` == ""`, ""})
+	} else if kind == "time" {
+		cases = append(cases, validateCase{v + ".IsZero()", ""})
+	}
+
+	for _, r := range f.Rules {
+		switch r.Name {
+		case "email", "url", "uuid", "slug", "iban", "creditcard":
+			fn := map[string]string{"email": "Email", "url": "URL", "uuid": "UUID", "slug": "Slug", "iban": "IBAN", "creditcard": "CreditCard"}[r.Name]
+			cases = append(cases, validateCase{"!validate." + fn + "(" + v + ")", `gqapi.Msg("gux.validation.` + r.Name + `")`})
+		case "phone":
+			cases = append(cases, validateCase{"!validate.Phone(" + v + ", " + strconv.Quote(r.Value) + ")", `gqapi.Msg("gux.validation.phone")`})
+		case "before", "after":
+			fn := map[string]string{"before": "Before", "after": "After"}[r.Name]
+			date := "validate.MustDate(" + strconv.Quote(r.Value) + ")"
+			cond := "!validate." + fn + "(" + v + ", " + date + ")"
+			if kind == "time" {
+				cond = "!" + v + "." + fn + "(" + date + ")"
+			}
+			// The client formats the date for its locale
+			cases = append(cases, validateCase{cond, `gqapi.Msg("gux.validation.` + r.Name + `", ` + strconv.Quote(r.Value) + `)`})
+		case "minlen":
+			cases = append(cases, validateCase{"len(" + v + ") < " + r.Value, `gqapi.Msg("gux.validation.minlength", ` + r.Value + `)`})
+		case "maxlen":
+			cases = append(cases, validateCase{"len(" + v + ") > " + r.Value, `gqapi.Msg("gux.validation.maxlength", ` + r.Value + `)`})
+		case "oneof":
+			options := strings.Split(r.Value, "|")
+			quoted := make([]string, len(options))
+			for i, o := range options {
+				quoted[i] = strconv.Quote(o)
+			}
+			cases = append(cases, validateCase{"!validate.OneOf(" + v + ", " + strings.Join(quoted, ", ") + ")", `gqapi.Msg("gux.validation.oneof", ` + strconv.Quote(strings.Join(options, ", ")) + `)`})
+		}
+	}
+
+	// Enums only take their declared values
+	if kind == "enum" {
+		cases = append(cases, validateCase{"!" + v + ".Valid()", `gqapi.Msg("gux.validation.oneof", ` + strconv.Quote(strings.Join(f.Enum, ", ")) + `)`})
+	}
+
+	// min and max share one message when both are set, like the Range rule
+	if min != "" || max != "" {
+		lo, hi := numberLabel(min), numberLabel(max)
+		if kind == "money" {
+			// The minor units over a power of ten round to the same float64
+			// as the tag's decimal, so the comparison is exact
+			v += ".Float64()"
+		}
+		var cond, msg string
+		switch {
+		case kind == "string" && min != "" && max != "":
+			cond, msg = "!validate.Range("+v+", "+min+", "+max+")", `gqapi.Msg("gux.validation.range", "`+lo+`", "`+hi+`")`
+		case kind == "string" && min != "":
+			cond, msg = "!validate.Min("+v+", "+min+")", `gqapi.Msg("gux.validation.min", "`+lo+`")`
+		case kind == "string":
+			cond, msg = "!validate.Max("+v+", "+max+")", `gqapi.Msg("gux.validation.max", "`+hi+`")`
+		case min != "" && max != "":
+			cond, msg = v+" < "+min+" || "+v+" > "+max, `gqapi.Msg("gux.validation.range", "`+lo+`", "`+hi+`")`
+		case min != "":
+			cond, msg = v+" < "+min, `gqapi.Msg("gux.validation.min", "`+lo+`")`
+		default:
+			cond, msg = v+" > "+max, `gqapi.Msg("gux.validation.max", "`+hi+`")`
+		}
+		cases = append(cases, validateCase{cond, msg})
+	}
+	return cases
+}
+
+// numberLabel formats a min or max tag value the way the form rules do
+func numberLabel(s string) string {
+	if s == "" {
+		return ""
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return validate.FormatNumber(v)
+}
+
+func generateValidatorCode(pkg string, structs []validatedStruct) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n")
+	if usesStrconv(structs) {
+		b.WriteString("\t\"strconv\"\n\n")
+	}
+	b.WriteString("\tgqapi \"github.com/dougbarrett/gux/api\"\n")
+	if usesValidate(structs) {
+		b.WriteString("\t\"github.com/dougbarrett/gux/validate\"\n")
+	}
+	b.WriteString(")\n")
+
+	for _, s := range structs {
+		if len(s.Nested) > 0 {
+			fmt.Fprintf(&b, "\n// Validate checks %s against its validate tags, and the structs it\n// holds with their Validate methods. It returns an *api.Error with a\n// translatable message for each invalid field.\n", s.Name)
+		} else {
+			fmt.Fprintf(&b, "\n// Validate checks %s against its validate tags. It returns an\n// *api.Error with a translatable message for each invalid field.\n", s.Name)
+		}
+		fmt.Fprintf(&b, "func (r %s) Validate() error {\n", s.Name)
+		b.WriteString("\tfields := make(map[string]gqapi.Message)\n")
+		for _, f := range s.Fields {
+			cases := fieldCases(f)
+			if len(cases) == 0 {
+				continue
+			}
+			if len(cases) == 1 {
+				fmt.Fprintf(&b, "\tif %s {\n\t\tfields[%q] = %s\n\t}\n", cases[0].cond, f.Key, cases[0].msg)
+				continue
+			}
+			b.WriteString("\tswitch {\n")
+			for _, c := range cases {
+				fmt.Fprintf(&b, "\tcase %s:\n", c.cond)
+				if c.msg != "" {
+					fmt.Fprintf(&b, "\t\tfields[%q] = %s\n", f.Key, c.msg)
+				}
+			}
+			b.WriteString("\t}\n")
+		}
+		for _, n := range s.Nested {
+			b.WriteString(nestedCode(n))
+		}
+		b.WriteString("\tif len(fields) > 0 {\n\t\treturn gqapi.ValidationMessages(fields)\n\t}\n\treturn nil\n}\n")
+	}
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format validators: %w", err)
+	}
+	return code, nil
+}
+
+// nestedCode checks a nested field with its type's Validate method
+func nestedCode(n nestedField) string {
+	field := "r." + n.Type
+	prefix := `""`
+	if n.Name != "" {
+		field = "r." + n.Name
+		prefix = strconv.Quote(n.Key)
+	}
+	switch {
+	case n.Slice:
+		item := field + "[i]"
+		check := fmt.Sprintf("\t\tgqapi.NestMessages(fields, %s+strconv.Itoa(i), %s.Validate())\n", strconv.Quote(n.Key+"."), item)
+		if n.Pointer {
+			check = fmt.Sprintf("\t\tif %s != nil {\n\t%s\t\t}\n", item, check)
+		}
+		return fmt.Sprintf("\tfor i := range %s {\n%s\t}\n", field, check)
+	case n.Pointer:
+		return fmt.Sprintf("\tif %s != nil {\n\t\tgqapi.NestMessages(fields, %s, %s.Validate())\n\t}\n", field, prefix, field)
+	}
+	return fmt.Sprintf("\tgqapi.NestMessages(fields, %s, %s.Validate())\n", prefix, field)
+}
+
+func usesStrconv(structs []validatedStruct) bool {
+	for _, s := range structs {
+		for _, n := range s.Nested {
+			if n.Slice {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func usesValidate(structs []validatedStruct) bool {
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			for _, c := range fieldCases(f) {
+				if strings.Contains(c.cond, "validate.") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// fieldRules returns the components.ValidationRule expressions matching
+// f's tags, in the order the server checks them
+func fieldRules(f validatedField) []string {
+	kind := f.kind()
+	var rules []string
+	var min, max string
+	for _, r := range f.Rules {
+		switch r.Name {
+		case "required":
+			switch kind {
+			case "bool":
+				rules = append(rules, "components.Accepted")
+			case "number", "money":
+				rules = append(rules, "components.NonZero")
+			case "collection":
+				// Forms have no empty-list check; the server still has one
+			default:
+				rules = append(rules, "components.Required")
+			}
+		case "email", "url", "uuid", "slug", "iban", "creditcard":
+			fn := map[string]string{"email": "Email", "url": "URL", "uuid": "UUID", "slug": "Slug", "iban": "IBAN", "creditcard": "CreditCard"}[r.Name]
+			rules = append(rules, "components."+fn)
+		case "phone":
+			rules = append(rules, "components.Phone("+strconv.Quote(r.Value)+")")
+		case "before", "after":
+			fn := map[string]string{"before": "Before", "after": "After"}[r.Name]
+			rules = append(rules, "components."+fn+"(validate.MustDate("+strconv.Quote(r.Value)+"))")
+		case "minlen":
+			rules = append(rules, "components.MinLength("+r.Value+")")
+		case "maxlen":
+			rules = append(rules, "components.MaxLength("+r.Value+")")
+		case "oneof":
+			options := strings.Split(r.Value, "|")
+			quoted := make([]string, len(options))
+			for i, o := range options {
+				quoted[i] = strconv.Quote(o)
+			}
+			rules = append(rules, "components.OneOf("+strings.Join(quoted, ", ")+")")
+		case "min":
+			min = r.Value
+		case "max":
+			max = r.Value
+		}
+	}
+	if kind == "enum" {
+		quoted := make([]string, len(f.Enum))
+		for i, v := range f.Enum {
+			quoted[i] = strconv.Quote(v)
+		}
+		rules = append(rules, "components.OneOf("+strings.Join(quoted, ", ")+")")
+	}
+	switch {
+	case min != "" && max != "":
+		rules = append(rules, "components.Range("+min+", "+max+")")
+	case min != "":
+		rules = append(rules, "components.Min("+min+")")
+	case max != "":
+		rules = append(rules, "components.Max("+max+")")
+	}
+	return rules
+}
+
+func generateRulesCode(pkg string, structs []validatedStruct) ([]byte, error) {
+	usesDates := false
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			for _, r := range f.Rules {
+				if r.Name == "before" || r.Name == "after" {
+					usesDates = true
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gux. DO NOT EDIT.\n//go:build js && wasm\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\t\"github.com/dougbarrett/gux/components\"\n")
+	if usesDates {
+		b.WriteString("\t\"github.com/dougbarrett/gux/validate\"\n")
+	}
+	b.WriteString(")\n")
+
+	for _, s := range structs {
+		fmt.Fprintf(&b, "\n// %sRules returns the form rules matching the validate tags on\n// %s, keyed by JSON name, for FormProps.Rules and FormBuilderProps.Rules\n", s.Name, s.Name)
+		fmt.Fprintf(&b, "func %sRules() map[string][]components.ValidationRule {\n", s.Name)
+		b.WriteString("\treturn map[string][]components.ValidationRule{\n")
+		for _, f := range s.Fields {
+			if rules := fieldRules(f); len(rules) > 0 {
+				fmt.Fprintf(&b, "\t\t%q: {%s},\n", f.Key, strings.Join(rules, ", "))
+			}
+		}
+		b.WriteString("\t}\n}\n")
+	}
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format form rules: %w", err)
+	}
+	return code, nil
+}
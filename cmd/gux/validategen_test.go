@@ -0,0 +1,35 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestValidateRulesRejected(t *testing.T) {
+	tests := []struct {
+		field string
+		err   string
+	}{
+		{"Age int `validate:\"email\"`", `rule "email" is not supported on int fields`},
+		{"Age int `validate:\"min=1.5\"`", `"1.5" is not an integer`},
+		{"Name string `validate:\"minlen=-1\"`", `"-1" is not a length`},
+		{"Due string `validate:\"before=31/12/2024\"`", "is not a date like 2024-12-31"},
+		{"Kind string `validate:\"oneof=\"`", "oneof needs values"},
+		{"Name string `validate:\"required=yes\"`", "required takes no value"},
+		{"Done bool `validate:\"min=1\"`", `rule "min" is not supported on bool fields`},
+	}
+	for _, tt := range tests {
+		src := "package api\n\ntype Request struct {\n\t" + tt.field + "\n}\n"
+		node, err := parser.ParseFile(token.NewFileSet(), "request.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st := node.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType)
+		if _, err := parseValidatedStruct("Request", st, nil); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: err = %v, want %q", tt.field, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Runs a js/wasm test binary in Node with a jsdom document, for gux test.
+// Usage: node node.js <wasm_exec.js> <test binary> [test flags]
+"use strict";
+
+const fs = require("fs");
+const os = require("os");
+const path = require("path");
+const { createRequire } = require("module");
+
+const [wasmExec, binary, ...args] = process.argv.slice(2);
+
+// jsdom, from the project's node_modules
+let JSDOM;
+try {
+	({ JSDOM } = createRequire(path.join(process.cwd(), "gux-test.js"))("jsdom"));
+} catch (err) {
+	console.error("gux test: jsdom not found. Install it with 'npm install --save-dev jsdom', or run 'gux test --browser'.");
+	process.exit(1);
+}
+const dom = new JSDOM("<!DOCTYPE html><html><head></head><body></body></html>", { url: "http://localhost/" });
+const window = dom.window;
+
+// Make the window's globals Node's, as in a browser. JavaScript's own
+// globals and Node's timers, console and fetch stay; functions are bound to
+// the window since jsdom checks what they are called on.
+const builtins = new Set(Object.getOwnPropertyNames(require("vm").runInNewContext("this")));
+const keep = new Set([
+	"console", "crypto", "performance", "queueMicrotask", "structuredClone",
+	"setTimeout", "clearTimeout", "setInterval", "clearInterval", "setImmediate", "clearImmediate",
+	"TextEncoder", "TextDecoder", "fetch", "Request", "Response", "Headers", "AbortController", "AbortSignal",
+]);
+for (const key of Object.getOwnPropertyNames(window)) {
+	if (builtins.has(key) || keep.has(key) || key.startsWith("_")) {
+		continue;
+	}
+	try {
+		const value = window[key];
+		globalThis[key] = typeof value === "function" && !/^[A-Z]/.test(key) ? value.bind(window) : value;
+	} catch {
+		// read-only in Node
+	}
+}
+globalThis.window = globalThis;
+globalThis.requestAnimationFrame ??= (fn) => setTimeout(() => fn(performance.now()), 16);
+globalThis.cancelAnimationFrame ??= (id) => clearTimeout(id);
+
+// As Go's wasm_exec_node.js
+globalThis.require = require;
+globalThis.fs = fs;
+globalThis.path = path;
+globalThis.TextEncoder = require("util").TextEncoder;
+globalThis.TextDecoder = require("util").TextDecoder;
+globalThis.performance ??= require("perf_hooks").performance;
+globalThis.crypto ??= require("crypto").webcrypto;
+
+require(wasmExec);
+
+const go = new Go();
+go.argv = [binary, ...args];
+go.env = Object.assign({ TMPDIR: os.tmpdir() }, process.env);
+go.exit = process.exit;
+WebAssembly.instantiate(fs.readFileSync(binary), go.importObject).then((result) => {
+	process.on("exit", (code) => { // Node.js exits if no event handler is pending
+		if (code === 0 && !go.exited) {
+			// deadlock, make Go print error and stack traces
+			go._pendingEvent = { id: 0 };
+			go._resume();
+		}
+	});
+	return go.run(result.instance);
+}).catch((err) => {
+	console.error(err);
+	process.exit(1);
+});
@@ -0,0 +1,463 @@
+// Command guxbench measures render time and JS interop calls for gux
+// components. It builds a benchmark program (see package bench) with Go
+// and/or TinyGo, runs it in headless Chrome, prints the results, and exits
+// non-zero when a result exceeds its budget or regresses against a baseline.
+//
+//	guxbench                                   # run the default suite with Go
+//	guxbench -compiler go,tinygo -budgets guxbench.json
+//	guxbench -baseline main.json -out pr.json  # compare against an earlier run
+//	guxbench -update-budgets guxbench.json     # write budgets from this run
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dougbarrett/gux/bench"
+)
+
+//go:embed harness.html
+var harnessHTML []byte
+
+const defaultSuite = "github.com/dougbarrett/gux/cmd/guxbench/suite"
+
+func main() {
+	compilers := flag.String("compiler", "go", "Compilers to build with: go, tinygo, or go,tinygo")
+	pkg := flag.String("pkg", defaultSuite, "Benchmark program package (a js/wasm main package calling bench.Main)")
+	benchTime := flag.Duration("benchtime", bench.DefaultBenchTime, "Minimum run time per benchmark")
+	run := flag.String("run", "", "Only run benchmarks matching this regular expression")
+	budgetsFile := flag.String("budgets", "", "Budgets JSON file to enforce")
+	baselineFile := flag.String("baseline", "", "Results JSON from an earlier run to compare against")
+	maxCallsRegress := flag.Float64("max-calls-regress", 5, "Allowed interop call increase over the baseline, in percent")
+	maxTimeRegress := flag.Float64("max-time-regress", 25, "Allowed ms/op increase over the baseline, in percent (0 disables)")
+	out := flag.String("out", "", "Write results JSON to this file")
+	updateBudgets := flag.String("update-budgets", "", "Write budgets from this run to this file")
+	headroom := flag.Float64("headroom", 50, "Headroom added to measured values by -update-budgets, in percent")
+	browser := flag.String("browser", os.Getenv("CHROME_PATH"), "Chrome or Chromium binary (default: search PATH)")
+	serve := flag.Bool("serve", false, "Serve the harness and wait for a browser instead of launching one")
+	timeout := flag.Duration("timeout", 5*time.Minute, "Timeout per compiler run")
+	flag.Parse()
+
+	var budgets Budgets
+	if *budgetsFile != "" {
+		if err := readJSON(*budgetsFile, &budgets); err != nil {
+			fatalf("Error reading budgets: %v", err)
+		}
+	}
+	var baseline []bench.Report
+	if *baselineFile != "" {
+		if err := readJSON(*baselineFile, &baseline); err != nil {
+			fatalf("Error reading baseline: %v", err)
+		}
+	}
+
+	if !*serve && *browser == "" {
+		*browser = findChrome()
+		if *browser == "" {
+			fatalf("Error: Chrome not found. Set -browser or CHROME_PATH, or use -serve and open the URL yourself.")
+		}
+	}
+
+	var reports []bench.Report
+	for _, compiler := range strings.Split(*compilers, ",") {
+		compiler = strings.TrimSpace(compiler)
+		if compiler != "go" && compiler != "tinygo" {
+			fatalf("Error: unknown compiler %q (use go or tinygo)", compiler)
+		}
+		report, err := runCompiler(compiler, runOptions{
+			pkg:       *pkg,
+			benchTime: *benchTime,
+			run:       *run,
+			browser:   *browser,
+			serve:     *serve,
+			timeout:   *timeout,
+		})
+		if err != nil {
+			fatalf("Error running %s benchmarks: %v", compiler, err)
+		}
+		reports = append(reports, report)
+	}
+
+	if *out != "" {
+		if err := writeJSON(*out, reports); err != nil {
+			fatalf("Error writing results: %v", err)
+		}
+	}
+	if *updateBudgets != "" {
+		if err := writeJSON(*updateBudgets, budgetsFromReports(reports, *headroom)); err != nil {
+			fatalf("Error writing budgets: %v", err)
+		}
+		fmt.Printf("Wrote budgets to %s\n", *updateBudgets)
+	}
+
+	violations := check(reports, budgets, baseline, *maxCallsRegress, *maxTimeRegress)
+	printReports(reports, baseline)
+
+	if len(violations) > 0 {
+		fmt.Printf("\n%d performance check(s) failed:\n", len(violations))
+		for _, v := range violations {
+			fmt.Println("  " + v)
+		}
+		os.Exit(1)
+	}
+}
+
+type runOptions struct {
+	pkg       string
+	benchTime time.Duration
+	run       string
+	browser   string
+	serve     bool
+	timeout   time.Duration
+}
+
+// runCompiler builds the benchmark program with one compiler and runs it
+func runCompiler(compiler string, opts runOptions) (bench.Report, error) {
+	dir, err := os.MkdirTemp("", "guxbench-")
+	if err != nil {
+		return bench.Report{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	fmt.Printf("Building %s with %s...\n", opts.pkg, compilerName(compiler))
+	wasmPath := filepath.Join(dir, "bench.wasm")
+	if err := buildWasm(compiler, opts.pkg, wasmPath); err != nil {
+		return bench.Report{}, err
+	}
+	execJS, err := wasmExecJS(compiler)
+	if err != nil {
+		return bench.Report{}, err
+	}
+
+	results := make(chan []byte, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(harnessHTML)
+	})
+	mux.HandleFunc("GET /wasm_exec.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Write(execJS)
+	})
+	mux.HandleFunc("GET /bench.wasm", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/wasm")
+		http.ServeFile(w, r, wasmPath)
+	})
+	mux.HandleFunc("POST /results", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		select {
+		case results <- data:
+		default:
+		}
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return bench.Report{}, err
+	}
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	query := url.Values{"compiler": {compiler}, "benchtime": {opts.benchTime.String()}}
+	if opts.run != "" {
+		query.Set("run", opts.run)
+	}
+	pageURL := "http://" + ln.Addr().String() + "/?" + query.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	if opts.serve {
+		fmt.Printf("Open %s in a browser to run the benchmarks\n", pageURL)
+	} else {
+		fmt.Println("Running benchmarks in headless Chrome...")
+		browser, err := startChrome(ctx, opts.browser, pageURL)
+		if err != nil {
+			return bench.Report{}, err
+		}
+		defer browser()
+	}
+
+	select {
+	case data := <-results:
+		var report struct {
+			bench.Report
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(data, &report); err != nil {
+			return bench.Report{}, fmt.Errorf("invalid report: %w", err)
+		}
+		if report.Error != "" {
+			return bench.Report{}, errors.New(report.Error)
+		}
+		report.Report.Compiler = compiler
+		return report.Report, nil
+	case <-ctx.Done():
+		return bench.Report{}, fmt.Errorf("no results after %s", opts.timeout)
+	}
+}
+
+func buildWasm(compiler, pkg, output string) error {
+	var cmd *exec.Cmd
+	if compiler == "tinygo" {
+		cmd = exec.Command("tinygo", "build", "-o", output, "-target", "wasm", "-no-debug", pkg)
+	} else {
+		cmd = exec.Command("go", "build", "-o", output, pkg)
+		cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("WASM build failed: %w", err)
+	}
+	return nil
+}
+
+// wasmExecJS reads the wasm_exec.js that matches the compiler
+func wasmExecJS(compiler string) ([]byte, error) {
+	if compiler == "tinygo" {
+		root, err := exec.Command("tinygo", "env", "TINYGOROOT").Output()
+		if err != nil {
+			return nil, errors.New("TinyGo not found")
+		}
+		return os.ReadFile(filepath.Join(strings.TrimSpace(string(root)), "targets", "wasm_exec.js"))
+	}
+	root, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return nil, errors.New("Go not found")
+	}
+	return os.ReadFile(filepath.Join(strings.TrimSpace(string(root)), "lib", "wasm", "wasm_exec.js"))
+}
+
+// findChrome looks for a Chrome or Chromium binary on PATH and in the usual
+// macOS location
+func findChrome() string {
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	mac := "/Applications/Google Chrome.app/Contents/MacOS/Google Chrome"
+	if _, err := os.Stat(mac); err == nil {
+		return mac
+	}
+	return ""
+}
+
+// startChrome opens pageURL in headless Chrome with a throwaway profile and
+// returns a function that stops it
+func startChrome(ctx context.Context, path, pageURL string) (func(), error) {
+	profile, err := os.MkdirTemp("", "guxbench-chrome-")
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"--headless=new",
+		"--disable-gpu",
+		"--disable-extensions",
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--user-data-dir=" + profile,
+		"--window-size=1280,800",
+	}
+	// Chrome refuses to run as root with its sandbox, which is the norm in CI containers
+	if os.Geteuid() == 0 {
+		args = append(args, "--no-sandbox")
+	}
+	cmd := exec.CommandContext(ctx, path, append(args, pageURL)...)
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(profile)
+		return nil, fmt.Errorf("starting Chrome: %w", err)
+	}
+	return func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		os.RemoveAll(profile)
+	}, nil
+}
+
+// Budget is the most a benchmark may cost. Zero fields are not checked.
+type Budget struct {
+	MaxMs    float64 `json:"maxMs,omitempty"`
+	MaxCalls float64 `json:"maxCalls,omitempty"`
+}
+
+// Budgets maps benchmark names to budgets. A "name@tinygo" or "name@go" key
+// overrides "name" for that compiler.
+type Budgets map[string]Budget
+
+func (b Budgets) lookup(name, compiler string) (Budget, bool) {
+	if budget, ok := b[name+"@"+compiler]; ok {
+		return budget, true
+	}
+	budget, ok := b[name]
+	return budget, ok
+}
+
+// check returns a message for every result over budget, failed, or regressed
+func check(reports []bench.Report, budgets Budgets, baseline []bench.Report, maxCallsRegress, maxTimeRegress float64) []string {
+	var violations []string
+	for _, report := range reports {
+		for _, res := range report.Results {
+			id := res.Name + "@" + report.Compiler
+			if res.Error != "" {
+				violations = append(violations, fmt.Sprintf("%s: %s", id, res.Error))
+				continue
+			}
+
+			if budget, ok := budgets.lookup(res.Name, report.Compiler); ok {
+				if budget.MaxMs > 0 && res.MsPerOp > budget.MaxMs {
+					violations = append(violations, fmt.Sprintf("%s: %.3f ms/op exceeds budget of %.3f", id, res.MsPerOp, budget.MaxMs))
+				}
+				if budget.MaxCalls > 0 && res.CallsPerOp > budget.MaxCalls {
+					violations = append(violations, fmt.Sprintf("%s: %.0f calls/op exceeds budget of %.0f", id, res.CallsPerOp, budget.MaxCalls))
+				}
+			}
+
+			if base, ok := findResult(baseline, report.Compiler, res.Name); ok {
+				if d := percentChange(base.CallsPerOp, res.CallsPerOp); d > maxCallsRegress {
+					violations = append(violations, fmt.Sprintf("%s: calls/op regressed %.1f%% (%.0f -> %.0f)", id, d, base.CallsPerOp, res.CallsPerOp))
+				}
+				if d := percentChange(base.MsPerOp, res.MsPerOp); maxTimeRegress > 0 && d > maxTimeRegress {
+					violations = append(violations, fmt.Sprintf("%s: ms/op regressed %.1f%% (%.3f -> %.3f)", id, d, base.MsPerOp, res.MsPerOp))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+func printReports(reports []bench.Report, baseline []bench.Report) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BENCHMARK\tCOMPILER\tN\tMS/OP\tCALLS/OP\tTOP CALLS\tVS BASELINE")
+	for _, report := range reports {
+		for _, res := range report.Results {
+			if res.Error != "" {
+				fmt.Fprintf(w, "%s\t%s\t-\t-\t-\tFAILED: %s\t\n", res.Name, report.Compiler, res.Error)
+				continue
+			}
+			delta := ""
+			if base, ok := findResult(baseline, report.Compiler, res.Name); ok {
+				delta = fmt.Sprintf("time %+.1f%%, calls %+.1f%%", percentChange(base.MsPerOp, res.MsPerOp), percentChange(base.CallsPerOp, res.CallsPerOp))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%.3f\t%.0f\t%s\t%s\n", res.Name, report.Compiler, res.N, res.MsPerOp, res.CallsPerOp, topCalls(res.CallsByKind, 3), delta)
+		}
+	}
+	w.Flush()
+}
+
+// topCalls summarizes the most frequent interop calls, e.g. "valueGet 812, valueCall 240"
+func topCalls(byKind map[string]float64, n int) string {
+	kinds := make([]string, 0, len(byKind))
+	for k := range byKind {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if byKind[kinds[i]] != byKind[kinds[j]] {
+			return byKind[kinds[i]] > byKind[kinds[j]]
+		}
+		return kinds[i] < kinds[j]
+	})
+	if len(kinds) > n {
+		kinds = kinds[:n]
+	}
+	parts := make([]string, len(kinds))
+	for i, k := range kinds {
+		parts[i] = fmt.Sprintf("%s %.0f", k, byKind[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// budgetsFromReports sets each budget to the measured value plus headroom
+func budgetsFromReports(reports []bench.Report, headroom float64) Budgets {
+	budgets := make(Budgets)
+	scale := 1 + headroom/100
+	for _, report := range reports {
+		for _, res := range report.Results {
+			if res.Error != "" {
+				continue
+			}
+			key := res.Name
+			if len(reports) > 1 {
+				key += "@" + report.Compiler
+			}
+			budgets[key] = Budget{
+				MaxMs:    float64(int(res.MsPerOp*scale*1000)+1) / 1000,
+				MaxCalls: float64(int(res.CallsPerOp*scale) + 1),
+			}
+		}
+	}
+	return budgets
+}
+
+func findResult(reports []bench.Report, compiler, name string) (bench.Result, bool) {
+	for _, report := range reports {
+		if report.Compiler != compiler {
+			continue
+		}
+		for _, res := range report.Results {
+			if res.Name == name && res.Error == "" {
+				return res, true
+			}
+		}
+	}
+	return bench.Result{}, false
+}
+
+// percentChange returns how much v grew over base, in percent. Any growth
+// from a zero base is infinite, so it exceeds every regression limit.
+func percentChange(base, v float64) float64 {
+	if base == 0 {
+		if v > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return (v - base) / base * 100
+}
+
+func compilerName(compiler string) string {
+	if compiler == "tinygo" {
+		return "TinyGo"
+	}
+	return "Go"
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Printf(format+"\n", args...)
+	os.Exit(1)
+}
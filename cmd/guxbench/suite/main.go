@@ -0,0 +1,137 @@
+//go:build js && wasm
+
+// Command suite is the default guxbench benchmark program. It covers the
+// components whose cost grows with data size.
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dougbarrett/gux/bench"
+	"github.com/dougbarrett/gux/components"
+)
+
+func main() {
+	bench.Register("table/1k-rows", tableBench(1000))
+	bench.Register("table/10k-rows", tableBench(10000))
+	bench.Register("table/1k-rows-sort", tableSortBench(1000))
+	bench.Register("formbuilder/100-fields", formBuilderBench(100))
+	bench.Register("barchart/50-bars", barChartBench(50))
+	bench.Register("timeseries/update-1k-points", timeSeriesUpdateBench(1000))
+	bench.Main()
+}
+
+var tableColumns = []components.TableColumn{
+	{Header: "ID", Key: "id", Sortable: true},
+	{Header: "Name", Key: "name", Sortable: true},
+	{Header: "Email", Key: "email", Sortable: true},
+	{Header: "Role", Key: "role"},
+	{Header: "Status", Key: "status"},
+}
+
+func tableRows(n int) []map[string]any {
+	roles := []string{"admin", "editor", "viewer"}
+	rows := make([]map[string]any, n)
+	for i := range rows {
+		rows[i] = map[string]any{
+			"id":     i + 1,
+			"name":   fmt.Sprintf("User %05d", (i*7919)%n),
+			"email":  fmt.Sprintf("user%d@example.com", i),
+			"role":   roles[i%len(roles)],
+			"status": "Active",
+		}
+	}
+	return rows
+}
+
+func tableBench(n int) func(*bench.B) {
+	return func(b *bench.B) {
+		rows := tableRows(n)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			table := components.NewTable(components.TableProps{Columns: tableColumns, Data: rows, Striped: true})
+			b.Mount(table.Element())
+		}
+	}
+}
+
+func tableSortBench(n int) func(*bench.B) {
+	return func(b *bench.B) {
+		table := components.NewTable(components.TableProps{Columns: tableColumns, Data: tableRows(n)})
+		b.Mount(table.Element())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			table.Sort("name")
+		}
+	}
+}
+
+func formBuilderBench(n int) func(*bench.B) {
+	return func(b *bench.B) {
+		types := []components.BuilderFieldType{
+			components.BuilderFieldText,
+			components.BuilderFieldEmail,
+			components.BuilderFieldNumber,
+			components.BuilderFieldTextarea,
+			components.BuilderFieldSelect,
+			components.BuilderFieldCheckbox,
+		}
+		options := []components.SelectOption{{Label: "One", Value: "1"}, {Label: "Two", Value: "2"}, {Label: "Three", Value: "3"}}
+
+		fields := make([]components.BuilderField, n)
+		for i := range fields {
+			fields[i] = components.BuilderField{
+				Name:    fmt.Sprintf("field%d", i),
+				Type:    types[i%len(types)],
+				Label:   fmt.Sprintf("Field %d", i),
+				Options: options,
+				Rules:   []components.ValidationRule{components.Required},
+			}
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			form := components.NewFormBuilder(components.FormBuilderProps{Fields: fields})
+			b.Mount(form.Element())
+		}
+	}
+}
+
+func barChartBench(n int) func(*bench.B) {
+	return func(b *bench.B) {
+		data := make([]components.ChartData, n)
+		for i := range data {
+			data[i] = components.ChartData{Label: fmt.Sprint(i), Value: float64((i * 37) % 100)}
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.Mount(components.BarChart(components.BarChartProps{Data: data, ShowValues: true, ShowLabels: true}))
+		}
+	}
+}
+
+func timeSeriesUpdateBench(n int) func(*bench.B) {
+	return func(b *bench.B) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		series := func(phase float64) []components.TimePoint {
+			points := make([]components.TimePoint, n)
+			for i := range points {
+				points[i] = components.TimePoint{
+					Time:  start.Add(time.Duration(i) * time.Minute),
+					Value: 50 + 40*math.Sin(float64(i)/30+phase),
+				}
+			}
+			return points
+		}
+		chart := components.NewTimeSeriesChart(components.TimeSeriesChartProps{Data: series(0), ShowGrid: true})
+		b.Mount(chart.Element())
+		updates := [2][]components.TimePoint{series(1), series(2)}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			chart.SetData(updates[i%2])
+		}
+	}
+}
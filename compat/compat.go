@@ -0,0 +1,15 @@
+// Package compat smooths over standard library features that behave
+// differently, or are missing, when the frontend is built with TinyGo
+// instead of standard Go:
+//
+//   - Marshal and Unmarshal return an error where TinyGo's incomplete
+//     reflection would panic in encoding/json
+//   - LoadLocation and Local use the browser's time zones, since neither
+//     compiler ships the time zone database to the browser
+//   - Read fills a buffer from crypto.getRandomValues
+//
+// Outside the browser every function defers to the standard library, so
+// packages shared with the server, like api, can use them too. Run
+// "gux doctor" to find imports that are known to break under the compiler
+// you build with.
+package compat
@@ -0,0 +1,6 @@
+//go:build tinygo
+
+package compat
+
+// TinyGo reports whether the program was built with TinyGo
+const TinyGo = true
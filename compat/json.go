@@ -0,0 +1,47 @@
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// UnsupportedError reports a value the compiler's reflection can't encode
+// or decode. Under TinyGo this is typically a type using reflection
+// features TinyGo hasn't implemented.
+type UnsupportedError struct {
+	Op    string // "marshal" or "unmarshal"
+	Type  string // the value's type
+	Panic any    // what encoding/json panicked with
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("compat: cannot %s %s: %v", e.Op, e.Type, e.Panic)
+}
+
+// Marshal is json.Marshal, returning an *UnsupportedError instead of
+// panicking when the value's type isn't supported by the compiler
+func Marshal(v any) (data []byte, err error) {
+	defer recoverJSON("marshal", v, &err)
+	return json.Marshal(v)
+}
+
+// MarshalIndent is json.MarshalIndent, returning an *UnsupportedError
+// instead of panicking when the value's type isn't supported by the compiler
+func MarshalIndent(v any, prefix, indent string) (data []byte, err error) {
+	defer recoverJSON("marshal", v, &err)
+	return json.MarshalIndent(v, prefix, indent)
+}
+
+// Unmarshal is json.Unmarshal, returning an *UnsupportedError instead of
+// panicking when the target's type isn't supported by the compiler
+func Unmarshal(data []byte, v any) (err error) {
+	defer recoverJSON("unmarshal", v, &err)
+	return json.Unmarshal(data, v)
+}
+
+func recoverJSON(op string, v any, err *error) {
+	if r := recover(); r != nil {
+		*err = &UnsupportedError{Op: op, Type: fmt.Sprint(reflect.TypeOf(v)), Panic: r}
+	}
+}
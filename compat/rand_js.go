@@ -0,0 +1,25 @@
+//go:build js && wasm
+
+package compat
+
+import (
+	"crypto/rand"
+	"syscall/js"
+)
+
+// Read fills b with cryptographically secure random bytes from the
+// browser's crypto.getRandomValues, whichever compiler built the module
+func Read(b []byte) (int, error) {
+	crypto := js.Global().Get("crypto")
+	if crypto.IsUndefined() {
+		return rand.Read(b)
+	}
+	// getRandomValues fills at most 64 KB per call
+	for start := 0; start < len(b); start += 65536 {
+		chunk := b[start:min(start+65536, len(b))]
+		array := js.Global().Get("Uint8Array").New(len(chunk))
+		crypto.Call("getRandomValues", array)
+		js.CopyBytesToGo(chunk, array)
+	}
+	return len(b), nil
+}
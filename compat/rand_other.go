@@ -0,0 +1,10 @@
+//go:build !(js && wasm)
+
+package compat
+
+import "crypto/rand"
+
+// Read fills b with cryptographically secure random bytes from crypto/rand
+func Read(b []byte) (int, error) {
+	return rand.Read(b)
+}
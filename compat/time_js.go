@@ -0,0 +1,194 @@
+//go:build js && wasm
+
+package compat
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// transitionYears is how many years either side of now a browser time zone
+// records its offset changes
+const transitionYears = 10
+
+// locations caches the time zones built from the browser's
+var locations = map[string]*time.Location{}
+
+// LoadLocation is time.LoadLocation, falling back to the browser's time
+// zones when the Go database isn't available, which in the browser is
+// unless the program imports time/tzdata. A browser time zone knows its
+// daylight saving changes for ten years either side of now; beyond that,
+// the nearest offset applies.
+func LoadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+	if loc, ok := locations[name]; ok {
+		return loc, nil
+	}
+	loc, browserErr := browserLocation(name)
+	if browserErr != nil {
+		return nil, err
+	}
+	locations[name] = loc
+	return loc, nil
+}
+
+// Local returns the browser's time zone. In the browser time.Local is UTC
+// under TinyGo, and a fixed offset without daylight saving changes under
+// Go, so apps that show local times set it at startup:
+//
+//	time.Local = compat.Local()
+func Local() *time.Location {
+	intl := js.Global().Get("Intl")
+	if intl.IsUndefined() {
+		return time.Local
+	}
+	name := intl.Get("DateTimeFormat").New().Call("resolvedOptions").Get("timeZone")
+	if name.Type() != js.TypeString {
+		return time.Local
+	}
+	loc, err := LoadLocation(name.String())
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// browserLocation builds a time zone from the offsets Intl.DateTimeFormat
+// reports for it
+func browserLocation(name string) (loc *time.Location, err error) {
+	intl := js.Global().Get("Intl")
+	if intl.IsUndefined() {
+		return nil, errors.New("compat: Intl is not available")
+	}
+	defer func() {
+		// DateTimeFormat throws a RangeError for unknown time zones
+		if r := recover(); r != nil {
+			loc, err = nil, fmt.Errorf("compat: unknown time zone %q", name)
+		}
+	}()
+
+	options := js.Global().Get("Object").New()
+	options.Set("timeZone", name)
+	options.Set("hourCycle", "h23")
+	for _, field := range []string{"year", "month", "day", "hour", "minute", "second"} {
+		options.Set(field, "numeric")
+	}
+	format := intl.Get("DateTimeFormat").New("en-US", options)
+	abbrevOptions := js.Global().Get("Object").New()
+	abbrevOptions.Set("timeZone", name)
+	abbrevOptions.Set("timeZoneName", "short")
+	abbrevFormat := intl.Get("DateTimeFormat").New("en-US", abbrevOptions)
+
+	// offset returns the zone's UTC offset in seconds at Unix time t
+	offset := func(t int64) int {
+		var n []int
+		for _, field := range strings.FieldsFunc(format.Call("format", float64(t)*1000).String(), func(r rune) bool {
+			return r < '0' || r > '9'
+		}) {
+			v, _ := strconv.Atoi(field)
+			n = append(n, v)
+		}
+		if len(n) < 6 {
+			return 0
+		}
+		// en-US order: month/day/year, hour:minute:second
+		return int(time.Date(n[2], time.Month(n[0]), n[1], n[3], n[4], n[5], 0, time.UTC).Unix() - t)
+	}
+	abbrev := func(t int64) string {
+		s := abbrevFormat.Call("format", float64(t)*1000).String()
+		return s[strings.LastIndex(s, " ")+1:]
+	}
+
+	// Find the offset changes two weeks at a time, then to the second. No
+	// zone changes its offset twice within two weeks.
+	now := time.Now()
+	start := now.AddDate(-transitionYears, 0, 0).Unix()
+	end := min(now.AddDate(transitionYears, 0, 0).Unix(), math.MaxInt32)
+	const step = 14 * 24 * 60 * 60
+	var transitions []int64
+	var offsets []int
+	first := offset(start)
+	prev := first
+	for t := start + step; t < end+step; t += step {
+		off := offset(t)
+		if off == prev {
+			continue
+		}
+		lo, hi := t-step, t
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if offset(mid) == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transitions = append(transitions, hi)
+		offsets = append(offsets, off)
+		prev = off
+	}
+
+	return time.LoadLocationFromTZData(name, tzif(start, first, transitions, offsets, abbrev))
+}
+
+// tzif encodes a time zone in the version 1 TZif format (RFC 8536) read by
+// time.LoadLocationFromTZData. Zone types are distinct offset and
+// abbreviation pairs; the first applies before the first transition.
+func tzif(start int64, first int, transitions []int64, offsets []int, abbrev func(int64) string) []byte {
+	type zone struct {
+		offset int
+		abbrev string
+	}
+	var zones []zone
+	index := func(z zone) byte {
+		for i, existing := range zones {
+			if existing == z {
+				return byte(i)
+			}
+		}
+		zones = append(zones, z)
+		return byte(len(zones) - 1)
+	}
+	standard := first
+	for _, off := range offsets {
+		standard = min(standard, off)
+	}
+
+	index(zone{first, abbrev(start)})
+	types := make([]byte, len(transitions))
+	for i, t := range transitions {
+		types[i] = index(zone{offsets[i], abbrev(t)})
+	}
+
+	var chars []byte
+	var infos []byte
+	for _, z := range zones {
+		dst := byte(0)
+		if z.offset > standard {
+			dst = 1
+		}
+		infos = binary.BigEndian.AppendUint32(infos, uint32(int32(z.offset)))
+		infos = append(infos, dst, byte(len(chars)))
+		chars = append(append(chars, z.abbrev...), 0)
+	}
+
+	data := append([]byte("TZif"), make([]byte, 16)...) // version 1, reserved
+	for _, count := range []int{0, 0, 0, len(transitions), len(zones), len(chars)} {
+		data = binary.BigEndian.AppendUint32(data, uint32(count))
+	}
+	for _, t := range transitions {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(t)))
+	}
+	data = append(data, types...)
+	data = append(data, infos...)
+	return append(data, chars...)
+}
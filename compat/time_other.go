@@ -0,0 +1,17 @@
+//go:build !(js && wasm)
+
+package compat
+
+import "time"
+
+// LoadLocation is time.LoadLocation. In the browser it falls back to the
+// browser's time zones.
+func LoadLocation(name string) (*time.Location, error) {
+	return time.LoadLocation(name)
+}
+
+// Local returns time.Local. In the browser it returns the browser's time
+// zone.
+func Local() *time.Location {
+	return time.Local
+}
@@ -0,0 +1,633 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/owner"
+)
+
+// A11ySeverity is how serious an accessibility issue is
+type A11ySeverity string
+
+const (
+	A11yError   A11ySeverity = "error"   // fails WCAG 2.1 AA or blocks assistive technology
+	A11yWarning A11ySeverity = "warning" // likely a problem; check by hand
+)
+
+// A11yIssue is an accessibility problem found by AuditA11y
+type A11yIssue struct {
+	Rule        string // e.g. "button-name", "color-contrast"
+	Severity    A11ySeverity
+	Message     string
+	Element     js.Value
+	Description string      // e.g. `button.p-2.rounded "Save"`
+	Owner       *owner.Info // the innermost annotated owner, if any
+}
+
+// a11yRoles are the valid WAI-ARIA 1.2 roles
+var a11yRoles = map[string]bool{}
+
+func init() {
+	for _, role := range strings.Fields(`alert alertdialog application article banner blockquote button
+		caption cell checkbox code columnheader combobox complementary contentinfo definition deletion
+		dialog directory document emphasis feed figure form generic grid gridcell group heading img
+		insertion link list listbox listitem log main marquee math menu menubar menuitem
+		menuitemcheckbox menuitemradio meter navigation none note option paragraph presentation
+		progressbar radio radiogroup region row rowgroup rowheader scrollbar search searchbox separator
+		slider spinbutton status strong subscript superscript switch tab table tablist tabpanel term
+		textbox time timer toolbar tooltip tree treegrid treeitem`) {
+		a11yRoles[role] = true
+	}
+}
+
+// namedRoles are the roles whose elements need an accessible name
+var namedRoles = map[string]string{
+	"button":   "button-name",
+	"link":     "link-name",
+	"checkbox": "control-label",
+	"combobox": "control-label",
+	"listbox":  "control-label",
+	"radio":    "control-label",
+	"slider":   "control-label",
+	"switch":   "control-label",
+	"textbox":  "control-label",
+	"dialog":   "dialog-name",
+	"img":      "img-alt",
+}
+
+// labelOnlyRoles are the named roles that don't take a name from their
+// content
+var labelOnlyRoles = map[string]bool{
+	"combobox": true, "dialog": true, "img": true, "listbox": true,
+	"slider": true, "textbox": true,
+}
+
+// AuditA11y checks root and its descendants for common accessibility
+// problems: images without alt text, controls, buttons, links, and dialogs
+// without an accessible name, unknown ARIA roles, ARIA references to
+// missing IDs, duplicate IDs, positive tabindex, and text below the WCAG AA
+// contrast ratio. Hidden elements are skipped, except for ID checks. The
+// checks are heuristics for catching regressions during development, not
+// a replacement for axe-core or testing with a screen reader.
+func AuditA11y(root js.Value) []A11yIssue {
+	if !root.Truthy() {
+		return nil
+	}
+	a := &a11yAuditor{document: js.Global().Get("document"), ids: make(map[string][]js.Value)}
+
+	lang := a.document.Get("documentElement")
+	if lang.Truthy() && strings.TrimSpace(attr(lang, "lang")) == "" {
+		a.add("page-lang", A11yWarning, lang, "The page has no lang attribute, so screen readers may read it in the wrong language")
+	}
+
+	a.walk(root, false)
+
+	for id, els := range a.ids {
+		if len(els) > 1 {
+			for _, el := range els[1:] {
+				a.add("duplicate-id", A11yError, el, fmt.Sprintf("id %q is used %d times; labels and ARIA references find only the first", id, len(els)))
+			}
+		}
+	}
+	return a.issues
+}
+
+type a11yAuditor struct {
+	document js.Value
+	issues   []A11yIssue
+	ids      map[string][]js.Value
+}
+
+func (a *a11yAuditor) add(rule string, severity A11ySeverity, el js.Value, message string) {
+	issue := A11yIssue{Rule: rule, Severity: severity, Message: message, Element: el, Description: describeElement(el)}
+	if owners := owner.Chain(el); len(owners) > 0 {
+		issue.Owner = &owners[0]
+	}
+	a.issues = append(a.issues, issue)
+}
+
+// walk checks el and its subtree. hidden is set below elements that are
+// aria-hidden or not rendered.
+func (a *a11yAuditor) walk(el js.Value, hidden bool) {
+	if el.Get("id").String() == "gux-inspector" {
+		return
+	}
+	if id := el.Get("id"); id.Type() == js.TypeString && id.String() != "" {
+		a.ids[id.String()] = append(a.ids[id.String()], el)
+	}
+	if !hidden && (attr(el, "aria-hidden") == "true" || !rendered(el)) {
+		hidden = true
+	}
+	if !hidden {
+		a.check(el)
+	}
+
+	children := el.Get("children")
+	for i := 0; i < children.Length(); i++ {
+		a.walk(children.Index(i), hidden)
+	}
+}
+
+func (a *a11yAuditor) check(el js.Value) {
+	tag := strings.ToLower(el.Get("tagName").String())
+	role := strings.ToLower(strings.TrimSpace(attr(el, "role")))
+	if fields := strings.Fields(role); len(fields) > 0 {
+		// Later roles are fallbacks; browsers use the first they know
+		role = fields[0]
+		if !a11yRoles[role] {
+			a.add("aria-role", A11yError, el, fmt.Sprintf("role %q is not a WAI-ARIA role", role))
+		}
+	}
+
+	for _, name := range []string{"aria-labelledby", "aria-describedby", "aria-controls"} {
+		for _, id := range strings.Fields(attr(el, name)) {
+			if !a.document.Call("getElementById", id).Truthy() {
+				a.add("aria-reference", A11yWarning, el, fmt.Sprintf("%s refers to id %q, which is not in the page", name, id))
+			}
+		}
+	}
+
+	if n, err := strconv.Atoi(attr(el, "tabindex")); err == nil && n > 0 {
+		a.add("tabindex", A11yWarning, el, fmt.Sprintf("tabindex=%d moves the element out of the page's focus order; use 0 or -1", n))
+	}
+
+	switch {
+	case tag == "img":
+		if !el.Call("hasAttribute", "alt").Bool() && attr(el, "aria-label") == "" && attr(el, "aria-labelledby") == "" {
+			a.add("img-alt", A11yError, el, `Image has no alt text; use alt="" if it is decorative`)
+		}
+	case tag == "input" || tag == "select" || tag == "textarea":
+		typ := strings.ToLower(attr(el, "type"))
+		if typ == "hidden" {
+			break
+		}
+		if accessibleName(a.document, el) == "" {
+			msg := "Form control has no label"
+			if attr(el, "placeholder") != "" {
+				msg += "; a placeholder disappears on input and is not a label"
+			}
+			a.add("control-label", A11yError, el, msg)
+		}
+	case tag == "button" || role == "button":
+		if accessibleName(a.document, el) == "" {
+			a.add("button-name", A11yError, el, "Button has no accessible name; icon-only buttons need an aria-label")
+		}
+	case (tag == "a" && el.Call("hasAttribute", "href").Bool()) || role == "link":
+		if accessibleName(a.document, el) == "" {
+			a.add("link-name", A11yError, el, "Link has no accessible name")
+		}
+	default:
+		if rule, ok := namedRoles[role]; ok && accessibleName(a.document, el) == "" {
+			a.add(rule, A11yError, el, fmt.Sprintf("Element with role %q has no accessible name", role))
+		}
+	}
+
+	a.checkContrast(el)
+}
+
+// accessibleName approximates the accessible name computation: the text of
+// aria-labelledby, aria-label, associated labels for form controls, alt
+// text for images, the text content of other elements, and title
+func accessibleName(document, el js.Value) string {
+	if ids := attr(el, "aria-labelledby"); ids != "" {
+		var parts []string
+		for _, id := range strings.Fields(ids) {
+			if ref := document.Call("getElementById", id); ref.Truthy() {
+				parts = append(parts, strings.TrimSpace(ref.Get("textContent").String()))
+			}
+		}
+		if name := strings.TrimSpace(strings.Join(parts, " ")); name != "" {
+			return name
+		}
+	}
+	if name := strings.TrimSpace(attr(el, "aria-label")); name != "" {
+		return name
+	}
+
+	switch tag := strings.ToLower(el.Get("tagName").String()); tag {
+	case "input", "select", "textarea":
+		switch strings.ToLower(attr(el, "type")) {
+		case "submit", "reset":
+			// Browsers label these "Submit" and "Reset" without a value
+			return "submit"
+		case "button":
+			if name := strings.TrimSpace(attr(el, "value")); name != "" {
+				return name
+			}
+		case "image":
+			if name := strings.TrimSpace(attr(el, "alt")); name != "" {
+				return name
+			}
+		}
+		if labels := el.Get("labels"); labels.Truthy() {
+			for i := 0; i < labels.Length(); i++ {
+				if name := strings.TrimSpace(labels.Index(i).Get("textContent").String()); name != "" {
+					return name
+				}
+			}
+		}
+		if label := el.Call("closest", "label"); label.Truthy() {
+			if name := strings.TrimSpace(label.Get("textContent").String()); name != "" {
+				return name
+			}
+		}
+	case "img":
+		if name := strings.TrimSpace(attr(el, "alt")); name != "" {
+			return name
+		}
+	default:
+		if role := strings.Fields(strings.ToLower(attr(el, "role"))); len(role) > 0 && labelOnlyRoles[role[0]] {
+			break
+		}
+		if name := strings.TrimSpace(el.Get("textContent").String()); name != "" {
+			return name
+		}
+		images := el.Call("querySelectorAll", "img[alt], [aria-label]")
+		for i := 0; i < images.Length(); i++ {
+			img := images.Index(i)
+			if name := strings.TrimSpace(attr(img, "alt") + attr(img, "aria-label")); name != "" {
+				return name
+			}
+		}
+	}
+	return strings.TrimSpace(attr(el, "title"))
+}
+
+// checkContrast flags elements whose own text is below the WCAG AA ratio
+// against the background behind it: 4.5:1, or 3:1 for large text.
+// Disabled controls are exempt, and text over background images is skipped.
+func (a *a11yAuditor) checkContrast(el js.Value) {
+	if !hasOwnText(el) || el.Call("closest", "[disabled], [aria-disabled='true']").Truthy() {
+		return
+	}
+	style := js.Global().Call("getComputedStyle", el)
+	if style.Get("visibility").String() == "hidden" {
+		return
+	}
+	bg, ok := effectiveBackground(el)
+	if !ok {
+		return
+	}
+	fg, ok := parseCSSColor(style.Get("color").String())
+	if !ok {
+		return
+	}
+	fg = fg.over(bg)
+
+	size, _ := strconv.ParseFloat(strings.TrimSuffix(style.Get("fontSize").String(), "px"), 64)
+	weight, _ := strconv.Atoi(style.Get("fontWeight").String())
+	large := size >= 24 || (weight >= 700 && size >= 18.66)
+	min := 4.5
+	if large {
+		min = 3
+	}
+
+	ratio := contrastRatio(fg, bg)
+	if ratio < min {
+		a.add("color-contrast", A11yWarning, el, fmt.Sprintf("Text contrast is %.2f:1, below the %.1f:1 WCAG AA minimum (%s on %s)", ratio, min, fg.hex(), bg.hex()))
+	}
+}
+
+// hasOwnText reports whether el has a non-blank text node as a child
+func hasOwnText(el js.Value) bool {
+	nodes := el.Get("childNodes")
+	for i := 0; i < nodes.Length(); i++ {
+		node := nodes.Index(i)
+		if node.Get("nodeType").Int() == 3 && strings.TrimSpace(node.Get("nodeValue").String()) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// rendered reports whether el takes up space on the page
+func rendered(el js.Value) bool {
+	if rects := el.Call("getClientRects"); rects.Truthy() && rects.Length() == 0 {
+		return false
+	}
+	return true
+}
+
+// rgba is a color with components from 0 to 1
+type rgba struct{ r, g, b, a float64 }
+
+// over composites c onto an opaque background
+func (c rgba) over(bg rgba) rgba {
+	return rgba{
+		r: c.r*c.a + bg.r*(1-c.a),
+		g: c.g*c.a + bg.g*(1-c.a),
+		b: c.b*c.a + bg.b*(1-c.a),
+		a: 1,
+	}
+}
+
+func (c rgba) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", int(math.Round(c.r*255)), int(math.Round(c.g*255)), int(math.Round(c.b*255)))
+}
+
+// luminance is the WCAG relative luminance
+func (c rgba) luminance() float64 {
+	channel := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.r) + 0.7152*channel(c.g) + 0.0722*channel(c.b)
+}
+
+func contrastRatio(x, y rgba) float64 {
+	lx, ly := x.luminance(), y.luminance()
+	if lx < ly {
+		lx, ly = ly, lx
+	}
+	return (lx + 0.05) / (ly + 0.05)
+}
+
+// effectiveBackground composites the background colors of el and its
+// ancestors over white. It fails when a background image is in the way.
+func effectiveBackground(el js.Value) (rgba, bool) {
+	var layers []rgba
+	for node := el; node.Truthy() && node.Get("nodeType").Int() == 1; node = node.Get("parentElement") {
+		style := js.Global().Call("getComputedStyle", node)
+		if image := style.Get("backgroundImage"); image.Truthy() && image.String() != "none" {
+			return rgba{}, false
+		}
+		c, ok := parseCSSColor(style.Get("backgroundColor").String())
+		if !ok || c.a == 0 {
+			continue
+		}
+		layers = append(layers, c)
+		if c.a == 1 {
+			break
+		}
+	}
+
+	bg := rgba{1, 1, 1, 1}
+	for i := len(layers) - 1; i >= 0; i-- {
+		bg = layers[i].over(bg)
+	}
+	return bg, true
+}
+
+var (
+	colorCanvas js.Value
+	colorCache  = make(map[string]rgba)
+)
+
+// parseCSSColor resolves any CSS color, including oklch() from Tailwind v4,
+// by painting it on a 1x1 canvas
+func parseCSSColor(color string) (rgba, bool) {
+	if c, ok := colorCache[color]; ok {
+		return c, true
+	}
+	if color == "" {
+		return rgba{}, false
+	}
+	if color == "transparent" {
+		return rgba{}, true
+	}
+	if !colorCanvas.Truthy() {
+		canvas := js.Global().Get("document").Call("createElement", "canvas")
+		canvas.Set("width", 1)
+		canvas.Set("height", 1)
+		colorCanvas = canvas.Call("getContext", "2d", map[string]any{"willReadFrequently": true})
+		if !colorCanvas.Truthy() {
+			return rgba{}, false
+		}
+	}
+	colorCanvas.Call("clearRect", 0, 0, 1, 1)
+	colorCanvas.Set("fillStyle", color)
+	colorCanvas.Call("fillRect", 0, 0, 1, 1)
+	data := colorCanvas.Call("getImageData", 0, 0, 1, 1).Get("data")
+	c := rgba{
+		r: float64(data.Index(0).Int()) / 255,
+		g: float64(data.Index(1).Int()) / 255,
+		b: float64(data.Index(2).Int()) / 255,
+		a: float64(data.Index(3).Int()) / 255,
+	}
+	colorCache[color] = c
+	return c, true
+}
+
+// attr returns an attribute of el, or "" when it is absent
+func attr(el js.Value, name string) string {
+	v := el.Call("getAttribute", name)
+	if v.IsNull() || v.IsUndefined() {
+		return ""
+	}
+	return v.String()
+}
+
+// describeElement renders an element as tag#id.class "text"
+func describeElement(el js.Value) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(el.Get("tagName").String()))
+	if id := el.Get("id"); id.Type() == js.TypeString && id.String() != "" {
+		b.WriteString("#" + id.String())
+	}
+	if class := el.Get("className"); class.Type() == js.TypeString {
+		classes := strings.Fields(class.String())
+		if len(classes) > 3 {
+			classes = classes[:3]
+		}
+		for _, c := range classes {
+			b.WriteString("." + c)
+		}
+	}
+	if text := []rune(strings.TrimSpace(el.Get("textContent").String())); len(text) > 0 {
+		if len(text) > 30 {
+			text = append(text[:30], '…')
+		}
+		fmt.Fprintf(&b, " %q", string(text))
+	}
+	return b.String()
+}
+
+// a11yAudit is the state of EnableA11yAudit
+var a11yAudit struct {
+	enabled  bool
+	observer js.Value
+	callback js.Func
+	timer    js.Value
+	run      js.Func
+	logged   js.Value // WeakMap of element to the issues logged for it
+	issues   []A11yIssue
+}
+
+// EnableA11yAudit audits the mounted app (#app) now and again whenever it
+// changes, for use in development builds. Issues are listed in the
+// Inspector's A11y tab, where hovering one outlines its element, and each
+// new issue is logged once with console.warn as an object with rule,
+// severity, message, element, and owner fields, so teams catch
+// regressions before shipping. Calling it again has no effect.
+func EnableA11yAudit() {
+	if a11yAudit.enabled {
+		return
+	}
+	a11yAudit.enabled = true
+	a11yAudit.logged = js.Global().Get("WeakMap").New()
+
+	inspector := InitInspector()
+	a11yAudit.run = js.FuncOf(func(this js.Value, args []js.Value) any {
+		a11yAudit.timer = js.Undefined()
+		runA11yAudit(inspector)
+		return nil
+	})
+
+	// Re-audit shortly after the app changes, batching bursts of mutations
+	a11yAudit.callback = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if a11yAudit.timer.Truthy() {
+			return nil
+		}
+		a11yAudit.timer = js.Global().Call("setTimeout", a11yAudit.run, 500)
+		return nil
+	})
+	observerType := js.Global().Get("MutationObserver")
+	app := js.Global().Get("document").Call("getElementById", "app")
+	if observerType.Truthy() && app.Truthy() {
+		a11yAudit.observer = observerType.New(a11yAudit.callback)
+		a11yAudit.observer.Call("observe", app, map[string]any{
+			"childList":     true,
+			"subtree":       true,
+			"characterData": true,
+			"attributes":    true,
+			// Not "style": the Inspector outlines elements with it
+			"attributeFilter": []any{"alt", "aria-label", "aria-labelledby", "aria-describedby", "aria-controls", "aria-hidden", "role", "id", "for", "title", "tabindex", "class", "disabled"},
+		})
+	}
+
+	runA11yAudit(inspector)
+}
+
+// DisableA11yAudit stops the audit started by EnableA11yAudit
+func DisableA11yAudit() {
+	if !a11yAudit.enabled {
+		return
+	}
+	if a11yAudit.observer.Truthy() {
+		a11yAudit.observer.Call("disconnect")
+	}
+	if a11yAudit.timer.Truthy() {
+		js.Global().Call("clearTimeout", a11yAudit.timer)
+	}
+	a11yAudit.callback.Release()
+	a11yAudit.run.Release()
+	a11yAudit.enabled = false
+	a11yAudit.observer, a11yAudit.timer = js.Undefined(), js.Undefined()
+	a11yAudit.issues = nil
+	if globalInspector != nil {
+		globalInspector.renderA11y(nil)
+	}
+}
+
+// A11yIssues returns the issues from the latest audit run by EnableA11yAudit
+func A11yIssues() []A11yIssue {
+	return a11yAudit.issues
+}
+
+func runA11yAudit(inspector *Inspector) {
+	issues := AuditA11y(js.Global().Get("document").Call("getElementById", "app"))
+	a11yAudit.issues = issues
+
+	console := js.Global().Get("console")
+	for _, issue := range issues {
+		seen := a11yAudit.logged.Call("get", issue.Element)
+		if seen.IsUndefined() {
+			seen = js.Global().Get("Object").New()
+			a11yAudit.logged.Call("set", issue.Element, seen)
+		}
+		key := issue.Rule + ": " + issue.Message
+		if seen.Get(key).Truthy() {
+			continue
+		}
+		seen.Set(key, true)
+		entry := map[string]any{
+			"rule":     issue.Rule,
+			"severity": string(issue.Severity),
+			"message":  issue.Message,
+			"element":  issue.Element,
+		}
+		if issue.Owner != nil {
+			entry["owner"] = issue.Owner.String()
+		}
+		console.Call("warn", "[gux a11y] "+issue.Rule+": "+issue.Message, entry)
+	}
+
+	inspector.renderA11y(issues)
+}
+
+// renderA11y lists issues in the A11y tab and counts them on its button
+func (i *Inspector) renderA11y(issues []A11yIssue) {
+	document := js.Global().Get("document")
+	for _, f := range i.a11yFuncs {
+		f.Release()
+	}
+	i.a11yFuncs = nil
+	i.a11yView.Set("innerHTML", "")
+
+	label := "A11y"
+	if len(issues) > 0 {
+		label = fmt.Sprintf("A11y (%d)", len(issues))
+	}
+	i.tabButtons["a11y"].Set("textContent", label)
+
+	if len(issues) == 0 {
+		ok := document.Call("createElement", "div")
+		ok.Set("className", "text-green-400")
+		ok.Set("textContent", "No accessibility issues found")
+		i.a11yView.Call("appendChild", ok)
+		return
+	}
+
+	for _, issue := range issues {
+		item := document.Call("createElement", "div")
+		item.Set("className", "py-1 border-b border-gray-800 cursor-pointer hover:bg-gray-800")
+
+		msg := document.Call("createElement", "div")
+		color, icon := "text-yellow-400", "⚠ "
+		if issue.Severity == A11yError {
+			color, icon = "text-red-400", "✖ "
+		}
+		msg.Set("className", color)
+		msg.Set("textContent", icon+issue.Rule+": "+issue.Message)
+		item.Call("appendChild", msg)
+
+		line := document.Call("createElement", "div")
+		line.Set("className", "ml-4 text-gray-300 truncate")
+		text := issue.Description
+		if issue.Owner != nil {
+			text += "  @" + issue.Owner.String()
+		}
+		line.Set("textContent", text)
+		item.Call("appendChild", line)
+
+		el := issue.Element
+		item.Call("addEventListener", "mouseenter", i.a11yFunc(func(this js.Value, args []js.Value) any {
+			el.Get("style").Set("outline", "2px solid #ef4444")
+			return nil
+		}))
+		item.Call("addEventListener", "mouseleave", i.a11yFunc(func(this js.Value, args []js.Value) any {
+			el.Get("style").Set("outline", "")
+			return nil
+		}))
+		item.Call("addEventListener", "click", i.a11yFunc(func(this js.Value, args []js.Value) any {
+			el.Call("scrollIntoView", map[string]any{"block": "center"})
+			return nil
+		}))
+		i.a11yView.Call("appendChild", item)
+	}
+}
+
+// a11yFunc creates a listener for an issue row, released on the next render
+func (i *Inspector) a11yFunc(fn func(this js.Value, args []js.Value) any) js.Func {
+	f := js.FuncOf(fn)
+	i.a11yFuncs = append(i.a11yFuncs, f)
+	return f
+}
@@ -22,10 +22,11 @@ type AccordionProps struct {
 
 // Accordion creates a collapsible accordion component
 type Accordion struct {
-	element  js.Value
-	panels   []js.Value
-	headers  []js.Value // store headers for ARIA updates
-	baseID   string     // base ID for generating unique IDs
+	element   js.Value
+	panels    []js.Value
+	headers   []js.Value // store headers for ARIA updates
+	baseID    string     // base ID for generating unique IDs
+	listeners listeners
 }
 
 // NewAccordion creates a new Accordion component
@@ -53,6 +54,7 @@ func NewAccordion(props AccordionProps) *Accordion {
 		acc.panels[i] = panel
 		acc.headers[i] = header
 	}
+	onUnmount(container, acc.Unmount)
 
 	return acc
 }
@@ -119,7 +121,7 @@ func (a *Accordion) createPanel(item AccordionItem, index int, allowMultiple boo
 
 	// Toggle handler
 	isOpen := item.Open
-	header.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	header.Call("addEventListener", "click", a.listeners.fn(func(this js.Value, args []js.Value) any {
 		isOpen = !isOpen
 
 		if isOpen {
@@ -156,6 +158,17 @@ func (a *Accordion) Element() js.Value {
 	return a.element
 }
 
+// Mount appends the accordion to parent
+func (a *Accordion) Mount(parent js.Value) {
+	parent.Call("appendChild", a.element)
+}
+
+// Unmount removes the accordion and releases its listeners
+func (a *Accordion) Unmount() {
+	unmount(a.element)
+	a.listeners.release()
+}
+
 // OpenPanel opens a specific panel by index
 func (a *Accordion) OpenPanel(index int) {
 	if index < 0 || index >= len(a.panels) {
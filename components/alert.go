@@ -14,16 +14,12 @@ const (
 	AlertError   AlertVariant = "error"
 )
 
-var alertStyles = map[AlertVariant]struct {
-	bg     string
-	border string
-	text   string
-	icon   string
-}{
-	AlertInfo:    {bg: "bg-blue-50 dark:bg-blue-900/30", border: "border-blue-200 dark:border-blue-700", text: "text-blue-800 dark:text-blue-300", icon: "ℹ️"},
-	AlertSuccess: {bg: "bg-green-50 dark:bg-green-900/30", border: "border-green-200 dark:border-green-700", text: "text-green-800 dark:text-green-300", icon: "✓"},
-	AlertWarning: {bg: "bg-yellow-50 dark:bg-yellow-900/30", border: "border-yellow-200 dark:border-yellow-700", text: "text-yellow-800 dark:text-yellow-300", icon: "⚠️"},
-	AlertError:   {bg: "bg-red-50 dark:bg-red-900/30", border: "border-red-200 dark:border-red-700", text: "text-red-800 dark:text-red-300", icon: "✕"},
+// alertIcons are the decorative icons of each variant
+var alertIcons = map[AlertVariant]string{
+	AlertInfo:    "ℹ️",
+	AlertSuccess: "✓",
+	AlertWarning: "⚠️",
+	AlertError:   "✕",
 }
 
 // AlertProps configures an Alert component
@@ -37,7 +33,8 @@ type AlertProps struct {
 
 // Alert creates an alert message component
 type Alert struct {
-	element js.Value
+	element   js.Value
+	listeners listeners
 }
 
 // NewAlert creates a new Alert component
@@ -49,10 +46,9 @@ func NewAlert(props AlertProps) *Alert {
 		variant = AlertInfo
 	}
 
-	style := alertStyles[variant]
-
 	alert := document.Call("createElement", "div")
-	alert.Set("className", style.bg+" "+style.border+" "+style.text+" border rounded-lg p-4 mb-4")
+	alert.Set("className", Classes("alert", "alert."+string(variant)))
+	a := &Alert{element: alert}
 
 	// ARIA live region: urgent alerts interrupt, status messages wait
 	if variant == AlertError || variant == AlertWarning {
@@ -65,23 +61,23 @@ func NewAlert(props AlertProps) *Alert {
 
 	// Content wrapper
 	content := document.Call("createElement", "div")
-	content.Set("className", "flex items-start")
+	content.Set("className", Classes("alert.content"))
 
 	// Icon (decorative)
 	icon := document.Call("createElement", "span")
-	icon.Set("className", "mr-3 text-lg")
-	icon.Set("textContent", style.icon)
+	icon.Set("className", Classes("alert.icon"))
+	icon.Set("textContent", alertIcons[variant])
 	icon.Call("setAttribute", "aria-hidden", "true")
 	content.Call("appendChild", icon)
 
 	// Text container
 	textContainer := document.Call("createElement", "div")
-	textContainer.Set("className", "flex-1")
+	textContainer.Set("className", Classes("alert.text"))
 
 	// Title
 	if props.Title != "" {
 		title := document.Call("createElement", "h4")
-		title.Set("className", "font-semibold mb-1")
+		title.Set("className", Classes("alert.title"))
 		title.Set("textContent", props.Title)
 		textContainer.Call("appendChild", title)
 	}
@@ -89,7 +85,7 @@ func NewAlert(props AlertProps) *Alert {
 	// Message
 	if props.Message != "" {
 		message := document.Call("createElement", "p")
-		message.Set("className", "text-sm")
+		message.Set("className", Classes("alert.message"))
 		message.Set("textContent", props.Message)
 		textContainer.Call("appendChild", message)
 	}
@@ -99,11 +95,11 @@ func NewAlert(props AlertProps) *Alert {
 	// Dismiss button
 	if props.Dismissible {
 		dismiss := document.Call("createElement", "button")
-		dismiss.Set("className", "ml-4 text-lg opacity-50 hover:opacity-100 cursor-pointer")
+		dismiss.Set("className", Classes("alert.dismiss"))
 		dismiss.Set("textContent", "×")
 		dismiss.Call("setAttribute", "aria-label", "Dismiss alert")
-		dismiss.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
-			alert.Get("parentNode").Call("removeChild", alert)
+		dismiss.Call("addEventListener", "click", a.listeners.fn(func(this js.Value, args []js.Value) any {
+			a.Unmount()
 			if props.OnDismiss != nil {
 				props.OnDismiss()
 			}
@@ -113,8 +109,9 @@ func NewAlert(props AlertProps) *Alert {
 	}
 
 	alert.Call("appendChild", content)
+	onUnmount(alert, a.Unmount)
 
-	return &Alert{element: alert}
+	return a
 }
 
 // Element returns the DOM element
@@ -122,6 +119,17 @@ func (a *Alert) Element() js.Value {
 	return a.element
 }
 
+// Mount appends the alert to parent
+func (a *Alert) Mount(parent js.Value) {
+	parent.Call("appendChild", a.element)
+}
+
+// Unmount removes the alert and releases its listeners
+func (a *Alert) Unmount() {
+	unmount(a.element)
+	a.listeners.release()
+}
+
 // Quick alert creators
 func AlertInfoMsg(message string) js.Value {
 	return NewAlert(AlertProps{Variant: AlertInfo, Message: message}).Element()
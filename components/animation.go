@@ -186,7 +186,7 @@ func InitAnimations() {
 
 	document := js.Global().Get("document")
 
-	style := document.Call("createElement", "style")
+	style := newStyle()
 	style.Set("id", "gux-animations")
 	style.Set("textContent", animationsCSS)
 
@@ -244,10 +244,7 @@ func Animate(props AnimateProps) {
 
 	if props.OnComplete != nil {
 		totalDuration := anim.Duration + anim.Delay
-		js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-			props.OnComplete()
-			return nil
-		}), totalDuration)
+		setTimeout(props.OnComplete, totalDuration)
 	}
 }
 
@@ -436,10 +433,9 @@ func AnimatedList(children ...js.Value) js.Value {
 
 		// Stagger the fade-in
 		delay := i * 100
-		js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
+		setTimeout(func() {
 			FadeIn(wrapper, 300, nil)
-			return nil
-		}), delay)
+		}, delay)
 	}
 
 	return container
@@ -47,6 +47,7 @@ func Avatar(props AvatarProps) js.Value {
 	}
 
 	container := document.Call("createElement", "div")
+	var funcs listeners
 	container.Set("className", "relative inline-block")
 
 	avatar := document.Call("createElement", "div")
@@ -71,7 +72,7 @@ func Avatar(props AvatarProps) js.Value {
 		img.Set("className", "w-full h-full object-cover")
 
 		// Fallback to initials on error
-		img.Call("addEventListener", "error", js.FuncOf(func(this js.Value, args []js.Value) any {
+		img.Call("addEventListener", "error", funcs.fn(func(this js.Value, args []js.Value) any {
 			avatar.Set("innerHTML", "")
 			initials := document.Call("createElement", "span")
 			initials.Set("textContent", getInitials(props.Name))
@@ -87,7 +88,7 @@ func Avatar(props AvatarProps) js.Value {
 	}
 
 	if props.OnClick != nil {
-		avatar.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		avatar.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 			props.OnClick()
 			return nil
 		}))
@@ -117,6 +118,9 @@ func Avatar(props AvatarProps) js.Value {
 		container.Call("appendChild", status)
 	}
 
+	if len(funcs.funcs) > 0 {
+		onUnmount(container, funcs.release)
+	}
 	return container
 }
 
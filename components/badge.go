@@ -16,15 +16,6 @@ const (
 	BadgeInfo    BadgeVariant = "info"
 )
 
-var badgeStyles = map[BadgeVariant]string{
-	BadgeDefault: "surface-overlay text-primary",
-	BadgePrimary: "bg-blue-100 dark:bg-blue-900 text-blue-800 dark:text-blue-200",
-	BadgeSuccess: "bg-green-100 dark:bg-green-900 text-green-800 dark:text-green-200",
-	BadgeWarning: "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
-	BadgeError:   "bg-red-100 dark:bg-red-900 text-red-800 dark:text-red-200",
-	BadgeInfo:    "bg-cyan-100 dark:bg-cyan-900 text-cyan-800 dark:text-cyan-200",
-}
-
 // BadgeProps configures a Badge component
 type BadgeProps struct {
 	Text      string
@@ -39,23 +30,15 @@ func Badge(props BadgeProps) js.Value {
 
 	badge := document.Call("createElement", "span")
 
-	variant := props.Variant
-	if variant == "" {
-		variant = BadgeDefault
+	variant := "badge." + string(props.Variant)
+	if !hasClass(variant) || props.Variant == BadgeDefault {
+		variant = "badge.default"
 	}
-
-	variantClass := badgeStyles[variant]
-	if variantClass == "" {
-		variantClass = badgeStyles[BadgeDefault]
-	}
-
-	className := "inline-flex items-center px-2.5 py-0.5 text-xs font-medium"
+	shape := "badge.rect"
 	if props.Rounded {
-		className += " rounded-full"
-	} else {
-		className += " rounded"
+		shape = "badge.pill"
 	}
-	className += " " + variantClass
+	className := Classes("badge", shape, variant)
 
 	if props.ClassName != "" {
 		className = props.ClassName
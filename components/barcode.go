@@ -0,0 +1,165 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall/js"
+)
+
+// BarcodeProps configures a Barcode
+type BarcodeProps struct {
+	Value       string  // Text to encode: printable ASCII
+	Height      float64 // Bar height in px (default 48)
+	ModuleWidth float64 // Width of the narrowest bar in px (default 2)
+	HideText    bool    // Leave out the value printed under the bars
+	ClassName   string
+}
+
+// Barcode renders value as a Code 128 barcode in an SVG, readable by
+// handheld and fixed scanners. Values of digits only are encoded two digits
+// per symbol, so long numbers stay narrow. A value that can't be encoded
+// renders as its text in red.
+func Barcode(props BarcodeProps) js.Value {
+	document := js.Global().Get("document")
+
+	if props.Height <= 0 {
+		props.Height = 48
+	}
+	if props.ModuleWidth <= 0 {
+		props.ModuleWidth = 2
+	}
+
+	widths, err := code128(props.Value)
+	if err != nil {
+		el := document.Call("createElement", "span")
+		el.Set("className", "text-xs text-red-600")
+		el.Set("textContent", props.Value)
+		el.Set("title", err.Error())
+		return el
+	}
+
+	// Quiet zones of 10 modules either side
+	modules := 20
+	for _, w := range widths {
+		modules += w
+	}
+	width := float64(modules) * props.ModuleWidth
+	height := props.Height
+	if !props.HideText {
+		height += 14
+	}
+
+	svgNS := "http://www.w3.org/2000/svg"
+	svg := document.Call("createElementNS", svgNS, "svg")
+	svg.Call("setAttribute", "width", fmt.Sprintf("%g", width))
+	svg.Call("setAttribute", "height", fmt.Sprintf("%g", height))
+	svg.Call("setAttribute", "viewBox", fmt.Sprintf("0 0 %g %g", width, height))
+	svg.Call("setAttribute", "role", "img")
+	svg.Call("setAttribute", "aria-label", props.Value)
+	if props.ClassName != "" {
+		svg.Call("setAttribute", "class", props.ClassName)
+	}
+
+	// Draw the bars as a single path; bars and spaces alternate, starting
+	// with a bar
+	var path strings.Builder
+	x := 10 * props.ModuleWidth
+	for i, w := range widths {
+		bw := float64(w) * props.ModuleWidth
+		if i%2 == 0 {
+			fmt.Fprintf(&path, "M%g 0h%gv%gh%gz", x, bw, props.Height, -bw)
+		}
+		x += bw
+	}
+	bars := document.Call("createElementNS", svgNS, "path")
+	bars.Call("setAttribute", "d", path.String())
+	bars.Call("setAttribute", "fill", "#000")
+	bars.Call("setAttribute", "shape-rendering", "crispEdges")
+	svg.Call("appendChild", bars)
+
+	if !props.HideText {
+		text := document.Call("createElementNS", svgNS, "text")
+		text.Call("setAttribute", "x", fmt.Sprintf("%g", width/2))
+		text.Call("setAttribute", "y", fmt.Sprintf("%g", height-2))
+		text.Call("setAttribute", "text-anchor", "middle")
+		text.Call("setAttribute", "font-family", "monospace")
+		text.Call("setAttribute", "font-size", "12")
+		text.Set("textContent", props.Value)
+		svg.Call("appendChild", text)
+	}
+
+	return svg
+}
+
+// code128Patterns are the bar and space widths of the Code 128 symbols, by
+// value. 103-105 are Start A, B and C.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232",
+}
+
+const (
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = "2331112"
+)
+
+// code128 returns the bar and space widths, in modules, encoding value
+func code128(value string) ([]int, error) {
+	if value == "" {
+		return nil, errors.New("barcode: empty value")
+	}
+
+	// Code set C packs digit pairs; anything else uses code set B
+	digits := len(value)%2 == 0
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			digits = false
+		}
+		if r < 32 || r > 126 {
+			return nil, fmt.Errorf("barcode: can't encode %q", r)
+		}
+	}
+
+	var symbols []int
+	if digits {
+		symbols = append(symbols, code128StartC)
+		for i := 0; i < len(value); i += 2 {
+			symbols = append(symbols, int(value[i]-'0')*10+int(value[i+1]-'0'))
+		}
+	} else {
+		symbols = append(symbols, code128StartB)
+		for i := 0; i < len(value); i++ {
+			symbols = append(symbols, int(value[i])-32)
+		}
+	}
+
+	check := symbols[0]
+	for i, s := range symbols[1:] {
+		check += (i + 1) * s
+	}
+	symbols = append(symbols, check%103)
+
+	var widths []int
+	for _, s := range symbols {
+		for _, c := range code128Patterns[s] {
+			widths = append(widths, int(c-'0'))
+		}
+	}
+	for _, c := range code128Stop {
+		widths = append(widths, int(c-'0'))
+	}
+	return widths, nil
+}
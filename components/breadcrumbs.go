@@ -68,18 +68,24 @@ func Breadcrumbs(props BreadcrumbsProps) js.Value {
 		isLast := i == len(props.Items)-1
 		if item.Path != "" && !isLast {
 			link := document.Call("createElement", "a")
-			link.Set("href", item.Path)
+			href := item.Path
+			if globalRouter != nil {
+				href = globalRouter.Href(href)
+			}
+			link.Set("href", href)
 			link.Set("className", "text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-300 hover:underline")
 			link.Set("textContent", item.Label)
 
 			// Handle click for SPA routing
-			link.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			click := js.FuncOf(func(this js.Value, args []js.Value) any {
 				args[0].Call("preventDefault")
 				if globalRouter != nil {
 					globalRouter.Navigate(item.Path)
 				}
 				return nil
-			}))
+			})
+			link.Call("addEventListener", "click", click)
+			onUnmount(link, click.Release)
 
 			itemEl.Call("appendChild", link)
 		} else {
@@ -17,16 +17,6 @@ const (
 	ButtonGhost     ButtonVariant = "ghost"
 )
 
-var buttonVariantClasses = map[ButtonVariant]string{
-	ButtonPrimary:   "bg-blue-600 text-white hover:bg-blue-700",
-	ButtonSecondary: "bg-gray-200 dark:bg-gray-700 text-gray-800 dark:text-gray-200 hover:bg-gray-300 dark:hover:bg-gray-600",
-	ButtonSuccess:   "bg-green-700 text-white hover:bg-green-800",
-	ButtonWarning:   "bg-yellow-500 text-gray-900 hover:bg-yellow-600",
-	ButtonDanger:    "bg-red-600 text-white hover:bg-red-700",
-	ButtonInfo:      "bg-cyan-600 text-white hover:bg-cyan-700",
-	ButtonGhost:     "bg-transparent text-gray-700 dark:text-gray-300 hover:bg-gray-100 dark:hover:bg-gray-700",
-}
-
 // ButtonSize defines button sizes
 type ButtonSize string
 
@@ -36,12 +26,6 @@ const (
 	ButtonLG ButtonSize = "lg"
 )
 
-var buttonSizeClasses = map[ButtonSize]string{
-	ButtonSM: "px-2 py-1 text-sm",
-	ButtonMD: "px-4 py-2",
-	ButtonLG: "px-6 py-3 text-lg",
-}
-
 // ButtonProps configures a Button component
 type ButtonProps struct {
 	Text      string
@@ -67,17 +51,19 @@ func Button(props ButtonProps) js.Value {
 		if size == "" {
 			size = ButtonMD
 		}
-		className = buttonSizeClasses[size] + " " + buttonVariantClasses[variant] + " rounded cursor-pointer transition-colors"
+		className = Classes("button", "button."+string(variant), "button."+string(size))
 	}
 
 	btn.Set("className", className)
 	btn.Set("textContent", props.Text)
 
 	if props.OnClick != nil {
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		click := js.FuncOf(func(this js.Value, args []js.Value) any {
 			props.OnClick()
 			return nil
-		}))
+		})
+		btn.Call("addEventListener", "click", click)
+		onUnmount(btn, click.Release)
 	}
 
 	return btn
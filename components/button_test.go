@@ -0,0 +1,39 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+func TestButtonClick(t *testing.T) {
+	clicks := 0
+	root := testutil.Mount(t, components.PrimaryButton("Save", func() { clicks++ }))
+
+	testutil.Click(testutil.QueryText(t, root, "button", "Save"))
+	if clicks != 1 {
+		t.Fatalf("OnClick called %d times, want 1", clicks)
+	}
+}
+
+func TestButtonClassName(t *testing.T) {
+	root := testutil.Mount(t, components.Button(components.ButtonProps{Text: "Go", ClassName: "btn btn-go"}))
+
+	btn := testutil.Query(t, root, "button")
+	testutil.AssertText(t, btn, "Go")
+	testutil.AssertClass(t, btn, "btn", "btn-go")
+}
+
+func TestBadgeClassProvider(t *testing.T) {
+	components.SetClassProvider(components.HeadlessClasses)
+	defer components.SetClassProvider(nil)
+
+	root := testutil.Mount(t, components.Badge(components.BadgeProps{Text: "New", Variant: components.BadgeSuccess}))
+
+	badge := testutil.Query(t, root, "span")
+	testutil.AssertText(t, badge, "New")
+	testutil.AssertClass(t, badge, "gux-badge-success")
+}
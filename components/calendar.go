@@ -0,0 +1,550 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// CalendarView is the grid a Calendar shows
+type CalendarView string
+
+const (
+	CalendarMonth CalendarView = "month"
+	CalendarWeek  CalendarView = "week"
+	CalendarDay   CalendarView = "day"
+)
+
+// CalendarEvent is an event on a Calendar. End is exclusive: an all-day
+// event on the 3rd ends on the 4th. An event without an End lasts one slot,
+// or one day if it is all-day.
+type CalendarEvent struct {
+	ID     string
+	Title  string
+	Start  time.Time
+	End    time.Time
+	AllDay bool   // shown in the all-day row of week and day views
+	Color  string // background class, e.g. "bg-green-600" (default "bg-blue-600")
+	Locked bool   // can't be moved or resized
+	Data   any    // app data
+}
+
+// CalendarProps configures a Calendar
+type CalendarProps struct {
+	View  CalendarView   // default CalendarMonth
+	Views []CalendarView // views offered by the switcher (default all three; one hides it)
+	Date  time.Time      // day the calendar opens on (default today)
+
+	Events []CalendarEvent
+
+	// LoadEvents loads the events between start and end (exclusive) each
+	// time the visible range changes, replacing Events. Wrap a generated
+	// client method with CalendarEvents.
+	LoadEvents func(ctx context.Context, start, end time.Time) ([]CalendarEvent, error)
+
+	OnEventClick func(event CalendarEvent)
+
+	// OnEventChange is called after an event is moved or resized, with the
+	// event as it was. Call UpdateEvent with previous to undo a change the
+	// server rejects.
+	OnEventChange func(event, previous CalendarEvent)
+
+	// OnSlotSelect is called when empty time is clicked or dragged across:
+	// slots in week and day views, whole days in month view
+	OnSlotSelect func(start, end time.Time)
+
+	// OnRangeChange is called when the view or the visible range changes
+	OnRangeChange func(view CalendarView, start, end time.Time)
+
+	ReadOnly        bool   // events can't be moved or resized
+	SlotMinutes     int    // week and day grid step, which drags snap to (default 30)
+	StartHour       int    // first hour of week and day views (default 0)
+	EndHour         int    // hour week and day views end at (default 24)
+	ScrollToHour    int    // hour week and day views open scrolled to (default 8)
+	HourHeight      int    // pixels per hour (default 48)
+	MaxEventsPerDay int    // events in a month cell before "+N more" (default 3)
+	Height          string // height of the week and day grid (default "600px")
+	ClassName       string
+}
+
+// Calendar shows events in month, week, and day grids. Events can be
+// dragged to move them, resized from their bottom edge in week and day
+// views, or moved with Alt+Arrow keys and resized with Alt+Shift+Up/Down.
+// With LoadEvents set, it loads the events of each range it shows.
+type Calendar struct {
+	container js.Value
+	title     js.Value
+	status    js.Value
+	switcher  map[CalendarView]js.Value
+	body      js.Value
+	scroll    js.Value   // scrolling time grid of week and day views
+	columns   []js.Value // day columns of the time grid
+
+	props  CalendarProps
+	view   CalendarView
+	date   time.Time
+	events []CalendarEvent
+
+	listeners listeners // toolbar and body listeners, for the calendar's lifetime
+	drag      *calendarDrag
+
+	loading bool
+	loadErr error
+	cancel  context.CancelFunc
+}
+
+const (
+	calendarDateKey     = "2006-01-02"
+	calendarEventColor  = "bg-blue-600"
+	calendarNavClass    = "p-1.5 rounded-md text-secondary hover:surface-overlay cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500"
+	calendarTodayClass  = "px-3 py-1 text-sm rounded-md border border-default text-primary hover:surface-overlay cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500"
+	calendarViewClass   = "px-3 py-1 text-sm text-secondary hover:surface-overlay cursor-pointer focus:outline-none focus:ring-2 focus:ring-inset focus:ring-blue-500"
+	calendarActiveClass = "px-3 py-1 text-sm bg-blue-600 text-white cursor-pointer focus:outline-none focus:ring-2 focus:ring-inset focus:ring-blue-500"
+)
+
+// CalendarEvents adapts a client method that lists items in a time range
+// for CalendarProps.LoadEvents, turning each item into an event with
+// toEvent
+//
+//	LoadEvents: components.CalendarEvents(func(ctx context.Context, start, end time.Time) ([]api.Booking, error) {
+//		return client.List(ctx, start.Format(time.RFC3339), end.Format(time.RFC3339))
+//	}, bookingEvent),
+func CalendarEvents[T any](fetch func(ctx context.Context, start, end time.Time) ([]T, error), toEvent func(T) CalendarEvent) func(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+	return func(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+		items, err := fetch(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+		events := make([]CalendarEvent, len(items))
+		for i, item := range items {
+			events[i] = toEvent(item)
+		}
+		return events, nil
+	}
+}
+
+// NewCalendar creates a Calendar
+func NewCalendar(props CalendarProps) *Calendar {
+	if props.View == "" {
+		props.View = CalendarMonth
+	}
+	if len(props.Views) == 0 {
+		props.Views = []CalendarView{CalendarMonth, CalendarWeek, CalendarDay}
+	}
+	if props.Date.IsZero() {
+		props.Date = time.Now()
+	}
+	if props.SlotMinutes <= 0 || props.SlotMinutes > 60 {
+		props.SlotMinutes = 30
+	}
+	if props.EndHour <= 0 || props.EndHour > 24 {
+		props.EndHour = 24
+	}
+	if props.StartHour < 0 || props.StartHour >= props.EndHour {
+		props.StartHour = 0
+	}
+	if props.ScrollToHour == 0 {
+		props.ScrollToHour = 8
+	}
+	if props.HourHeight <= 0 {
+		props.HourHeight = 48
+	}
+	if props.MaxEventsPerDay <= 0 {
+		props.MaxEventsPerDay = 3
+	}
+	if props.Height == "" {
+		props.Height = "600px"
+	}
+
+	c := &Calendar{
+		props:  props,
+		view:   props.View,
+		date:   startOfDay(props.Date),
+		events: append([]CalendarEvent(nil), props.Events...),
+	}
+	c.container = Div("flex flex-col gap-3 " + props.ClassName)
+	c.container.Call("setAttribute", "role", "region")
+	c.container.Call("setAttribute", "aria-label", i18n.T("gux.calendar.label"))
+	c.container.Call("appendChild", c.renderToolbar())
+
+	c.body = Div("select-none")
+	c.bindBody()
+	c.container.Call("appendChild", c.body)
+
+	c.rangeChanged()
+	onUnmount(c.container, c.Unmount)
+	return c
+}
+
+// Element returns the calendar element
+func (c *Calendar) Element() js.Value {
+	return c.container
+}
+
+// Mount appends the calendar to parent
+func (c *Calendar) Mount(parent js.Value) {
+	parent.Call("appendChild", c.container)
+	c.scrollToStart()
+}
+
+// Unmount removes the calendar, cancels a load in progress, and releases
+// its listeners
+func (c *Calendar) Unmount() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	unmount(c.container)
+	c.listeners.release()
+}
+
+// View returns the current view
+func (c *Calendar) View() CalendarView {
+	return c.view
+}
+
+// SetView switches to view, keeping the current date in sight
+func (c *Calendar) SetView(view CalendarView) {
+	if view == c.view {
+		return
+	}
+	c.view = view
+	c.rangeChanged()
+}
+
+// Date returns the day the calendar is on
+func (c *Calendar) Date() time.Time {
+	return c.date
+}
+
+// SetDate moves the calendar to the month, week, or day containing date
+func (c *Calendar) SetDate(date time.Time) {
+	start, _ := c.Range()
+	c.date = startOfDay(date)
+	if next, _ := c.Range(); next.Equal(start) {
+		c.render(false)
+		return
+	}
+	c.rangeChanged()
+}
+
+// Next moves forward one month, week, or day
+func (c *Calendar) Next() {
+	c.SetDate(c.step(1))
+}
+
+// Prev moves back one month, week, or day
+func (c *Calendar) Prev() {
+	c.SetDate(c.step(-1))
+}
+
+// Today moves to today
+func (c *Calendar) Today() {
+	c.SetDate(time.Now())
+}
+
+func (c *Calendar) step(n int) time.Time {
+	switch c.view {
+	case CalendarDay:
+		return c.date.AddDate(0, 0, n)
+	case CalendarWeek:
+		return c.date.AddDate(0, 0, 7*n)
+	default:
+		first := time.Date(c.date.Year(), c.date.Month(), 1, 0, 0, 0, 0, time.Local)
+		return first.AddDate(0, n, 0)
+	}
+}
+
+// Range returns the first day shown and the day after the last: six
+// weeks in month view, a week from the locale's first weekday in week view
+func (c *Calendar) Range() (start, end time.Time) {
+	switch c.view {
+	case CalendarDay:
+		return c.date, c.date.AddDate(0, 0, 1)
+	case CalendarWeek:
+		start = startOfWeek(c.date)
+		return start, start.AddDate(0, 0, 7)
+	default:
+		first := time.Date(c.date.Year(), c.date.Month(), 1, 0, 0, 0, 0, time.Local)
+		start = startOfWeek(first)
+		return start, start.AddDate(0, 0, 42)
+	}
+}
+
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) - int(i18n.CurrentFormat().FirstWeekday) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Events returns the calendar's events
+func (c *Calendar) Events() []CalendarEvent {
+	return append([]CalendarEvent(nil), c.events...)
+}
+
+// SetEvents replaces the calendar's events
+func (c *Calendar) SetEvents(events []CalendarEvent) {
+	c.events = append([]CalendarEvent(nil), events...)
+	c.render(false)
+}
+
+// AddEvent adds an event to the calendar
+func (c *Calendar) AddEvent(event CalendarEvent) {
+	c.events = append(c.events, event)
+	c.render(false)
+}
+
+// UpdateEvent replaces the event with event's ID. It returns false if
+// there is none.
+func (c *Calendar) UpdateEvent(event CalendarEvent) bool {
+	i := c.eventIndex(event.ID)
+	if i < 0 {
+		return false
+	}
+	c.events[i] = event
+	c.render(false)
+	return true
+}
+
+// RemoveEvent removes the event with id. It returns false if there is none.
+func (c *Calendar) RemoveEvent(id string) bool {
+	i := c.eventIndex(id)
+	if i < 0 {
+		return false
+	}
+	c.events = append(c.events[:i], c.events[i+1:]...)
+	c.render(false)
+	return true
+}
+
+// Reload loads the visible range again with LoadEvents
+func (c *Calendar) Reload() {
+	if c.props.LoadEvents == nil {
+		return
+	}
+	if c.cancel != nil {
+		c.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.loading, c.loadErr = true, nil
+	c.renderStatus()
+
+	start, end := c.Range()
+	go func() {
+		defer cancel()
+		events, err := c.props.LoadEvents(ctx, start, end)
+		if ctx.Err() != nil {
+			return // moved on or unmounted while loading
+		}
+		c.loading = false
+		if err != nil {
+			c.loadErr = err
+			c.renderStatus()
+			return
+		}
+		c.renderStatus()
+		c.SetEvents(events)
+	}()
+}
+
+func (c *Calendar) eventIndex(id string) int {
+	for i, ev := range c.events {
+		if ev.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// changeEvent moves event id to start and end, and reports the change
+func (c *Calendar) changeEvent(id string, start, end time.Time) {
+	i := c.eventIndex(id)
+	if i < 0 {
+		return
+	}
+	previous := c.events[i]
+	if start.Equal(previous.Start) && end.Equal(eventEnd(previous, c.props.SlotMinutes)) {
+		c.render(false)
+		return
+	}
+	c.events[i].Start, c.events[i].End = start, end
+	c.render(false)
+	if c.props.OnEventChange != nil {
+		c.props.OnEventChange(c.events[i], previous)
+	}
+}
+
+// editable reports whether ev can be moved and resized
+func (c *Calendar) editable(ev CalendarEvent) bool {
+	return !c.props.ReadOnly && !ev.Locked
+}
+
+// eventEnd returns ev's exclusive end, filling in a missing one
+func eventEnd(ev CalendarEvent, slotMinutes int) time.Time {
+	if ev.End.After(ev.Start) {
+		return ev.End
+	}
+	if ev.AllDay {
+		return startOfDay(ev.Start).AddDate(0, 0, 1)
+	}
+	return ev.Start.Add(time.Duration(slotMinutes) * time.Minute)
+}
+
+// eventsOn returns the events overlapping day, all-day events first, then
+// by start time
+func (c *Calendar) eventsOn(day time.Time, include func(CalendarEvent) bool) []CalendarEvent {
+	next := day.AddDate(0, 0, 1)
+	var out []CalendarEvent
+	for _, ev := range c.events {
+		if include != nil && !include(ev) {
+			continue
+		}
+		if ev.Start.Before(next) && eventEnd(ev, c.props.SlotMinutes).After(day) {
+			out = append(out, ev)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].AllDay != out[j].AllDay {
+			return out[i].AllDay
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
+
+// rangeChanged renders a new range, loads its events, and reports it
+func (c *Calendar) rangeChanged() {
+	c.render(true)
+	c.Reload()
+	if c.props.OnRangeChange != nil {
+		start, end := c.Range()
+		c.props.OnRangeChange(c.view, start, end)
+	}
+}
+
+func (c *Calendar) renderToolbar() js.Value {
+	bar := Div("flex flex-wrap items-center gap-2")
+
+	nav := func(label, path string, onClick func()) js.Value {
+		btn := El("button", calendarNavClass)
+		btn.Set("type", "button")
+		btn.Call("setAttribute", "aria-label", label)
+		btn.Set("innerHTML", `<svg class="w-5 h-5" fill="none" stroke="currentColor" viewBox="0 0 24 24"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="`+path+`"/></svg>`)
+		c.listeners.on(btn, "click", func(this js.Value, args []js.Value) any {
+			onClick()
+			return nil
+		})
+		return btn
+	}
+	bar.Call("appendChild", nav(i18n.T("gux.calendar.previous"), "M15 19l-7-7 7-7", c.Prev))
+	bar.Call("appendChild", nav(i18n.T("gux.calendar.next"), "M9 5l7 7-7 7", c.Next))
+
+	today := El("button", calendarTodayClass)
+	today.Set("type", "button")
+	today.Set("textContent", i18n.T("gux.calendar.today"))
+	c.listeners.on(today, "click", func(this js.Value, args []js.Value) any {
+		c.Today()
+		return nil
+	})
+	bar.Call("appendChild", today)
+
+	c.title = El("h2", "text-lg font-semibold text-primary ml-2")
+	c.title.Call("setAttribute", "aria-live", "polite")
+	bar.Call("appendChild", c.title)
+
+	c.status = Div("flex items-center gap-2 text-sm")
+	bar.Call("appendChild", c.status)
+
+	if len(c.props.Views) > 1 {
+		group := Div("ml-auto inline-flex rounded-md border border-default overflow-hidden")
+		group.Call("setAttribute", "role", "group")
+		group.Call("setAttribute", "aria-label", i18n.T("gux.calendar.views"))
+		c.switcher = make(map[CalendarView]js.Value)
+		for _, view := range c.props.Views {
+			view := view
+			btn := El("button", calendarViewClass)
+			btn.Set("type", "button")
+			btn.Set("textContent", i18n.T("gux.calendar."+string(view)))
+			c.listeners.on(btn, "click", func(this js.Value, args []js.Value) any {
+				c.SetView(view)
+				return nil
+			})
+			c.switcher[view] = btn
+			group.Call("appendChild", btn)
+		}
+		bar.Call("appendChild", group)
+	}
+	return bar
+}
+
+func (c *Calendar) renderTitle() {
+	start, end := c.Range()
+	var title string
+	switch c.view {
+	case CalendarDay:
+		title = i18n.DayName(c.date.Weekday()) + ", " + i18n.FormatDate(c.date)
+	case CalendarWeek:
+		title = i18n.FormatDate(start) + " – " + i18n.FormatDate(end.AddDate(0, 0, -1))
+	default:
+		title = fmt.Sprintf("%s %d", i18n.MonthName(c.date.Month()), c.date.Year())
+	}
+	c.title.Set("textContent", title)
+
+	for view, btn := range c.switcher {
+		className := calendarViewClass
+		if view == c.view {
+			className = calendarActiveClass
+		}
+		btn.Set("className", className)
+		btn.Call("setAttribute", "aria-pressed", fmt.Sprint(view == c.view))
+	}
+}
+
+func (c *Calendar) renderStatus() {
+	UnmountChildren(c.status)
+	switch {
+	case c.loading:
+		c.status.Call("appendChild", Spinner(SpinnerProps{Size: SpinnerSM, AriaLabel: i18n.T("gux.calendar.loading")}))
+	case c.loadErr != nil:
+		msg := Span("text-red-600 dark:text-red-400", i18n.T("gux.calendar.loadFailed"))
+		msg.Call("setAttribute", "role", "alert")
+		c.status.Call("appendChild", msg)
+		c.status.Call("appendChild", Button(ButtonProps{Text: i18n.T("gux.pagination.retry"), Variant: ButtonSecondary, Size: ButtonSM, OnClick: c.Reload}))
+	}
+}
+
+// render draws the current view. resetScroll scrolls week and day views to
+// ScrollToHour; otherwise their scroll position is kept.
+func (c *Calendar) render(resetScroll bool) {
+	scrollTop := -1
+	if !resetScroll && c.scroll.Truthy() {
+		scrollTop = c.scroll.Get("scrollTop").Int()
+	}
+	c.drag = nil
+	c.renderTitle()
+	UnmountChildren(c.body)
+	c.scroll, c.columns = js.Undefined(), nil
+	if c.view == CalendarMonth {
+		c.body.Call("appendChild", c.renderMonth())
+		return
+	}
+	c.body.Call("appendChild", c.renderTimeGrid())
+	if scrollTop >= 0 {
+		c.scroll.Set("scrollTop", scrollTop)
+	} else {
+		c.scrollToStart()
+	}
+}
+
+// scrollToStart scrolls the time grid to ScrollToHour. It does nothing
+// until the calendar is in the page.
+func (c *Calendar) scrollToStart() {
+	if c.scroll.Truthy() {
+		c.scroll.Set("scrollTop", (c.props.ScrollToHour-c.props.StartHour)*c.props.HourHeight)
+	}
+}
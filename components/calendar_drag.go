@@ -0,0 +1,375 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"math"
+	"syscall/js"
+	"time"
+)
+
+type calendarDragMode int
+
+const (
+	calendarClick  calendarDragMode = iota // pressing an event that can't be edited
+	calendarMove                           // moving an event
+	calendarResize                         // moving an event's end
+	calendarSelect                         // selecting empty time
+)
+
+// calendarDrag is a pointer press on the calendar body, which becomes a
+// drag once the pointer moves a few pixels
+type calendarDrag struct {
+	mode   calendarDragMode
+	event  CalendarEvent
+	el     js.Value // event element, or the selection in a day column
+	timed  bool     // in a day column, rather than a month cell or the all-day row
+	x, y   float64  // where the pointer went down
+	day    time.Time
+	minute int // minutes since midnight, snapped to a slot, where it went down
+	moved  bool
+
+	start, end time.Time // the event or selection as dragged so far
+}
+
+// bindBody listens on the body for presses, drags, and keys on events and
+// empty time, so renders add no listeners of their own
+func (c *Calendar) bindBody() {
+	c.listeners.on(c.body, "pointerdown", func(this js.Value, args []js.Value) any {
+		c.pointerDown(args[0])
+		return nil
+	})
+	c.listeners.on(c.body, "pointermove", func(this js.Value, args []js.Value) any {
+		c.pointerMove(args[0])
+		return nil
+	})
+	c.listeners.on(c.body, "pointerup", func(this js.Value, args []js.Value) any {
+		c.pointerUp()
+		return nil
+	})
+	c.listeners.on(c.body, "pointercancel", func(this js.Value, args []js.Value) any {
+		if c.drag != nil {
+			c.render(false)
+		}
+		return nil
+	})
+	c.listeners.on(c.body, "click", func(this js.Value, args []js.Value) any {
+		// "+N more" and week day headers open their day
+		link := args[0].Get("target").Call("closest", "[data-more]")
+		if !link.Truthy() {
+			return nil
+		}
+		day, err := time.ParseInLocation(calendarDateKey, link.Call("getAttribute", "data-more").String(), time.Local)
+		if err == nil {
+			c.date, c.view = day, CalendarDay
+			c.rangeChanged()
+		}
+		return nil
+	})
+	c.listeners.on(c.body, "keydown", func(this js.Value, args []js.Value) any {
+		c.keyDown(args[0])
+		return nil
+	})
+}
+
+func (c *Calendar) pointerDown(event js.Value) {
+	if event.Get("button").Int() != 0 {
+		return
+	}
+	target := event.Get("target")
+	if target.Call("closest", "[data-more]").Truthy() {
+		return // handled on click
+	}
+	x, y := event.Get("clientX").Float(), event.Get("clientY").Float()
+	d := &calendarDrag{x: x, y: y}
+
+	if el := target.Call("closest", "[data-event]"); el.Truthy() {
+		i := c.eventIndex(el.Call("getAttribute", "data-event").String())
+		if i < 0 {
+			return
+		}
+		d.event, d.el = c.events[i], el
+		d.start, d.end = d.event.Start, eventEnd(d.event, c.props.SlotMinutes)
+		d.timed = c.view != CalendarMonth && !d.event.AllDay
+		switch {
+		case !c.editable(d.event):
+			d.mode = calendarClick
+		case target.Call("closest", "[data-resize]").Truthy():
+			d.mode = calendarResize
+		default:
+			d.mode = calendarMove
+		}
+	} else {
+		if c.props.OnSlotSelect == nil {
+			return
+		}
+		cell := target.Call("closest", "[data-date]")
+		if !cell.Truthy() {
+			return
+		}
+		d.mode = calendarSelect
+		d.timed = cell.Call("hasAttribute", "data-column").Bool()
+	}
+
+	var ok bool
+	if d.timed {
+		d.day, d.minute, ok = c.slotAt(x, y)
+	} else {
+		d.day, _, ok = c.cellDay(target)
+	}
+	if !ok {
+		return
+	}
+	if d.mode == calendarSelect {
+		d.start, d.end = c.selection(d, d.day, d.minute)
+	}
+	c.drag = d
+	c.body.Call("setPointerCapture", event.Get("pointerId"))
+}
+
+func (c *Calendar) pointerMove(event js.Value) {
+	d := c.drag
+	if d == nil || d.mode == calendarClick {
+		return
+	}
+	x, y := event.Get("clientX").Float(), event.Get("clientY").Float()
+	if !d.moved {
+		if math.Abs(x-d.x) < 4 && math.Abs(y-d.y) < 4 {
+			return
+		}
+		d.moved = true
+		if d.mode == calendarSelect {
+			if d.timed {
+				d.el = Div(calendarSelectClass)
+				c.column(d.day).Call("appendChild", d.el)
+			}
+		} else {
+			d.el.Get("classList").Call("add", "opacity-75", "shadow-lg", "z-10")
+		}
+	}
+
+	if !d.timed {
+		day, cell, ok := c.dayAt(x, y)
+		if !ok {
+			return
+		}
+		switch d.mode {
+		case calendarMove:
+			days := dayDiff(d.day, day)
+			d.start = d.event.Start.AddDate(0, 0, days)
+			d.end = eventEnd(d.event, c.props.SlotMinutes).AddDate(0, 0, days)
+			if !cell.Call("hasAttribute", "data-column").Bool() {
+				cell.Call("appendChild", d.el)
+			}
+		case calendarSelect:
+			d.start, d.end = c.selection(d, day, 0)
+			c.highlightDays(d.start, d.end)
+		}
+		return
+	}
+
+	day, minute, ok := c.slotAt(x, y)
+	if !ok {
+		return
+	}
+	slot := time.Duration(c.props.SlotMinutes) * time.Minute
+	switch d.mode {
+	case calendarMove:
+		shift := time.Duration(minute-d.minute) * time.Minute
+		days := dayDiff(d.day, day)
+		d.start = d.event.Start.AddDate(0, 0, days).Add(shift)
+		d.end = eventEnd(d.event, c.props.SlotMinutes).AddDate(0, 0, days).Add(shift)
+		c.column(day).Call("appendChild", d.el)
+		c.placeEvent(d.el, day, d.start, d.end)
+		d.el.Get("style").Set("left", "0")
+		d.el.Get("style").Set("width", "100%")
+	case calendarResize:
+		end := day.Add(time.Duration(minute)*time.Minute + slot)
+		if !end.After(d.start) {
+			end = d.start.Add(slot)
+		}
+		d.end = end
+		start := startOfDay(d.start)
+		c.placeEvent(d.el, start, d.start, d.end)
+	case calendarSelect:
+		d.start, d.end = c.selection(d, d.day, minute)
+		c.placeEvent(d.el, d.day, d.start, d.end)
+	}
+}
+
+func (c *Calendar) pointerUp() {
+	d := c.drag
+	if d == nil {
+		return
+	}
+	c.drag = nil
+
+	if d.mode == calendarSelect {
+		if d.moved {
+			c.render(false)
+		}
+		c.props.OnSlotSelect(d.start, d.end)
+		return
+	}
+	if !d.moved {
+		if c.props.OnEventClick != nil {
+			c.props.OnEventClick(d.event)
+		}
+		return
+	}
+	c.changeEvent(d.event.ID, d.start, d.end)
+}
+
+// selection returns the slots or days from where d started to day and
+// minute, in either direction
+func (c *Calendar) selection(d *calendarDrag, day time.Time, minute int) (time.Time, time.Time) {
+	if !d.timed {
+		from, to := d.day, day
+		if to.Before(from) {
+			from, to = to, from
+		}
+		return from, to.AddDate(0, 0, 1)
+	}
+	from, to := min(d.minute, minute), max(d.minute, minute)
+	return day.Add(time.Duration(from) * time.Minute), day.Add(time.Duration(to+c.props.SlotMinutes) * time.Minute)
+}
+
+// highlightDays marks the month cells or all-day cells from start to end
+func (c *Calendar) highlightDays(start, end time.Time) {
+	cells := c.body.Call("querySelectorAll", "[data-date]:not([data-column])")
+	for i := 0; i < cells.Length(); i++ {
+		cell := cells.Index(i)
+		day, err := time.ParseInLocation(calendarDateKey, cell.Call("getAttribute", "data-date").String(), time.Local)
+		selected := err == nil && !day.Before(start) && day.Before(end)
+		cell.Get("classList").Call("toggle", "bg-blue-500/10", selected)
+	}
+}
+
+// dayAt returns the day of the cell or column under a point
+func (c *Calendar) dayAt(x, y float64) (time.Time, js.Value, bool) {
+	return c.cellDay(js.Global().Get("document").Call("elementFromPoint", x, y))
+}
+
+// cellDay returns the day of the cell or column containing el
+func (c *Calendar) cellDay(el js.Value) (time.Time, js.Value, bool) {
+	if !el.Truthy() {
+		return time.Time{}, js.Undefined(), false
+	}
+	cell := el.Call("closest", "[data-date]")
+	if !cell.Truthy() || !c.body.Call("contains", cell).Bool() {
+		return time.Time{}, js.Undefined(), false
+	}
+	day, err := time.ParseInLocation(calendarDateKey, cell.Call("getAttribute", "data-date").String(), time.Local)
+	return day, cell, err == nil
+}
+
+// slotAt returns the day column under a point, or the nearest, and the
+// start of the slot at its height in minutes since midnight
+func (c *Calendar) slotAt(x, y float64) (time.Time, int, bool) {
+	if len(c.columns) == 0 {
+		return time.Time{}, 0, false
+	}
+	col := c.columns[0]
+	for _, el := range c.columns[1:] {
+		if x >= el.Call("getBoundingClientRect").Get("left").Float() {
+			col = el
+		}
+	}
+	day, err := time.ParseInLocation(calendarDateKey, col.Call("getAttribute", "data-date").String(), time.Local)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	top := col.Call("getBoundingClientRect").Get("top").Float()
+	minute := int((y-top)*60/float64(c.props.HourHeight)) + c.props.StartHour*60
+	last := c.props.EndHour*60 - c.props.SlotMinutes
+	minute = min(max(minute, c.props.StartHour*60), last)
+	return day, minute / c.props.SlotMinutes * c.props.SlotMinutes, true
+}
+
+// column returns the time grid column of day
+func (c *Calendar) column(day time.Time) js.Value {
+	key := day.Format(calendarDateKey)
+	for _, col := range c.columns {
+		if col.Call("getAttribute", "data-date").String() == key {
+			return col
+		}
+	}
+	return c.columns[0]
+}
+
+func (c *Calendar) keyDown(event js.Value) {
+	el := event.Get("target").Call("closest", "[data-event]")
+	if !el.Truthy() {
+		return
+	}
+	id := el.Call("getAttribute", "data-event").String()
+	i := c.eventIndex(id)
+	if i < 0 {
+		return
+	}
+	ev := c.events[i]
+	key := event.Get("key").String()
+
+	if key == "Enter" || key == " " {
+		event.Call("preventDefault")
+		if c.props.OnEventClick != nil {
+			c.props.OnEventClick(ev)
+		}
+		return
+	}
+	if !event.Get("altKey").Bool() || !c.editable(ev) {
+		return
+	}
+
+	start, end := ev.Start, eventEnd(ev, c.props.SlotMinutes)
+	slot := time.Duration(c.props.SlotMinutes) * time.Minute
+	timed := c.view != CalendarMonth && !ev.AllDay
+	resize := timed && event.Get("shiftKey").Bool()
+	switch key {
+	case "ArrowLeft":
+		start, end = start.AddDate(0, 0, -1), end.AddDate(0, 0, -1)
+	case "ArrowRight":
+		start, end = start.AddDate(0, 0, 1), end.AddDate(0, 0, 1)
+	case "ArrowUp":
+		switch {
+		case resize:
+			if end.Add(-slot).After(start) {
+				end = end.Add(-slot)
+			}
+		case timed:
+			start, end = start.Add(-slot), end.Add(-slot)
+		case c.view == CalendarMonth:
+			start, end = start.AddDate(0, 0, -7), end.AddDate(0, 0, -7)
+		default:
+			return
+		}
+	case "ArrowDown":
+		switch {
+		case resize:
+			end = end.Add(slot)
+		case timed:
+			start, end = start.Add(slot), end.Add(slot)
+		case c.view == CalendarMonth:
+			start, end = start.AddDate(0, 0, 7), end.AddDate(0, 0, 7)
+		default:
+			return
+		}
+	default:
+		return
+	}
+	event.Call("preventDefault")
+	c.changeEvent(id, start, end)
+
+	events := c.body.Call("querySelectorAll", "[data-event]")
+	for i := 0; i < events.Length(); i++ {
+		if events.Index(i).Call("getAttribute", "data-event").String() == id {
+			events.Index(i).Call("focus")
+			break
+		}
+	}
+}
+
+// dayDiff returns the number of days from a to b, across DST changes
+func dayDiff(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
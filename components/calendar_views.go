@@ -0,0 +1,314 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+const (
+	calendarChipClass     = "block w-full truncate rounded px-1 text-left text-xs text-white focus:outline-none focus:ring-2 focus:ring-blue-500 "
+	calendarEventClass    = "absolute overflow-hidden rounded px-1 py-0.5 text-xs text-white border border-white dark:border-gray-900 focus:outline-none focus:ring-2 focus:ring-blue-500 "
+	calendarSelectClass   = "absolute inset-x-0 rounded bg-blue-500/20 border border-blue-500 pointer-events-none"
+	calendarDayNumClass   = "inline-flex items-center justify-center w-6 h-6 text-xs rounded-full"
+	calendarTodayNumClass = "inline-flex items-center justify-center w-6 h-6 text-xs rounded-full bg-blue-600 text-white"
+)
+
+// renderMonth draws six weeks of day cells, each with its first events and
+// a "+N more" link to its day view
+func (c *Calendar) renderMonth() js.Value {
+	start, _ := c.Range()
+	first := time.Date(c.date.Year(), c.date.Month(), 1, 0, 0, 0, 0, time.Local)
+	limit := c.props.MaxEventsPerDay
+
+	wrap := Div("border border-subtle rounded-lg overflow-hidden")
+	head := Div("grid grid-cols-7 surface-raised border-b border-subtle")
+	for i := 0; i < 7; i++ {
+		day := start.AddDate(0, 0, i)
+		head.Call("appendChild", Div("px-2 py-1 text-center text-xs font-medium text-tertiary", Span("", i18n.DayName(day.Weekday()))))
+	}
+	wrap.Call("appendChild", head)
+
+	grid := Div("grid grid-cols-7")
+	grid.Call("setAttribute", "role", "grid")
+	for w := 0; w < 6; w++ {
+		row := Div("contents")
+		row.Call("setAttribute", "role", "row")
+		for d := 0; d < 7; d++ {
+			day := start.AddDate(0, 0, w*7+d)
+			className := "min-h-24 flex flex-col gap-0.5 p-1 border-subtle"
+			if d > 0 {
+				className += " border-l"
+			}
+			if w > 0 {
+				className += " border-t"
+			}
+			if day.Month() != first.Month() {
+				className += " surface-sunken text-tertiary"
+			}
+			cell := Div(className)
+			cell.Call("setAttribute", "role", "gridcell")
+			cell.Call("setAttribute", "data-date", day.Format(calendarDateKey))
+			cell.Call("setAttribute", "aria-label", i18n.FormatDate(day))
+
+			num := Span(calendarDayNumClass, strconv.Itoa(day.Day()))
+			if sameDay(day, time.Now()) {
+				num.Set("className", calendarTodayNumClass)
+				cell.Call("setAttribute", "aria-current", "date")
+			}
+			cell.Call("appendChild", num)
+
+			events := c.eventsOn(day, nil)
+			shown := len(events)
+			if shown > limit {
+				shown = limit - 1
+			}
+			for _, ev := range events[:shown] {
+				cell.Call("appendChild", c.renderChip(ev))
+			}
+			if hidden := len(events) - shown; hidden > 0 {
+				more := El("button", "text-left text-xs text-secondary hover:underline px-1 cursor-pointer")
+				more.Set("type", "button")
+				more.Set("textContent", i18n.T("gux.calendar.more", hidden))
+				more.Call("setAttribute", "data-more", day.Format(calendarDateKey))
+				cell.Call("appendChild", more)
+			}
+			row.Call("appendChild", cell)
+		}
+		grid.Call("appendChild", row)
+	}
+	wrap.Call("appendChild", grid)
+	return wrap
+}
+
+// renderTimeGrid draws the days of week and day views as columns of hours,
+// below a row of all-day events
+func (c *Calendar) renderTimeGrid() js.Value {
+	start, end := c.Range()
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+	hours := c.props.EndHour - c.props.StartHour
+	height := strconv.Itoa(hours*c.props.HourHeight) + "px"
+
+	wrap := Div("border border-subtle rounded-lg overflow-hidden flex flex-col")
+
+	head := Div("flex surface-raised border-b border-subtle")
+	head.Call("appendChild", Div("w-14 flex-shrink-0"))
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		cell := Div("flex-1 min-w-0 flex items-center justify-center gap-1 px-2 py-1 text-xs font-medium text-tertiary border-l border-subtle")
+		cell.Call("appendChild", Span("", i18n.DayName(day.Weekday())))
+		num := Span(calendarDayNumClass, strconv.Itoa(day.Day()))
+		if sameDay(day, time.Now()) {
+			num.Set("className", calendarTodayNumClass)
+		}
+		if c.view == CalendarWeek {
+			// Headers open their day view
+			link := El("button", "rounded-full cursor-pointer hover:surface-overlay focus:outline-none focus:ring-2 focus:ring-blue-500", num)
+			link.Set("type", "button")
+			link.Call("setAttribute", "data-more", day.Format(calendarDateKey))
+			link.Call("setAttribute", "aria-label", i18n.FormatDate(day))
+			num = link
+		}
+		cell.Call("appendChild", num)
+		head.Call("appendChild", cell)
+	}
+	wrap.Call("appendChild", head)
+
+	allDay := Div("flex border-b border-subtle")
+	allDay.Call("appendChild", Div("w-14 flex-shrink-0 px-1 py-1 text-[10px] text-tertiary text-right", Span("", i18n.T("gux.calendar.allDay"))))
+	isAllDay := func(ev CalendarEvent) bool { return ev.AllDay }
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		cell := Div("flex-1 min-w-0 min-h-8 flex flex-col gap-0.5 p-0.5 border-l border-subtle")
+		cell.Call("setAttribute", "data-date", day.Format(calendarDateKey))
+		for _, ev := range c.eventsOn(day, isAllDay) {
+			cell.Call("appendChild", c.renderChip(ev))
+		}
+		allDay.Call("appendChild", cell)
+	}
+	wrap.Call("appendChild", allDay)
+
+	c.scroll = Div("overflow-y-auto")
+	c.scroll.Get("style").Set("maxHeight", c.props.Height)
+	grid := Div("flex")
+
+	gutter := Div("w-14 flex-shrink-0 relative")
+	gutter.Get("style").Set("height", height)
+	for h := c.props.StartHour; h < c.props.EndHour; h++ {
+		label := Span("absolute right-2 text-[10px] text-tertiary", fmt.Sprintf("%02d:00", h))
+		label.Get("style").Set("top", strconv.Itoa((h-c.props.StartHour)*c.props.HourHeight)+"px")
+		gutter.Call("appendChild", label)
+	}
+	grid.Call("appendChild", gutter)
+
+	isTimed := func(ev CalendarEvent) bool { return !ev.AllDay }
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		col := Div("flex-1 min-w-0 relative border-l border-subtle")
+		col.Get("style").Set("height", height)
+		col.Call("setAttribute", "data-date", day.Format(calendarDateKey))
+		col.Call("setAttribute", "data-column", "")
+		if sameDay(day, time.Now()) {
+			col.Call("setAttribute", "aria-current", "date")
+		}
+		for h := 1; h < hours; h++ {
+			line := Div("absolute inset-x-0 border-t border-subtle pointer-events-none")
+			line.Get("style").Set("top", strconv.Itoa(h*c.props.HourHeight)+"px")
+			col.Call("appendChild", line)
+		}
+		for _, p := range layoutDay(c.eventsOn(day, isTimed), c.props.SlotMinutes) {
+			col.Call("appendChild", c.renderTimedEvent(p, day))
+		}
+		c.columns = append(c.columns, col)
+		grid.Call("appendChild", col)
+	}
+	c.scroll.Call("appendChild", grid)
+	wrap.Call("appendChild", c.scroll)
+	return wrap
+}
+
+// renderChip renders an event in a month cell or the all-day row
+func (c *Calendar) renderChip(ev CalendarEvent) js.Value {
+	chip := Div(calendarChipClass + eventColor(ev))
+	chip.Call("setAttribute", "role", "button")
+	chip.Call("setAttribute", "tabindex", "0")
+	chip.Call("setAttribute", "data-event", ev.ID)
+	chip.Call("setAttribute", "aria-label", c.eventLabel(ev))
+	text := ev.Title
+	if !ev.AllDay {
+		text = ev.Start.Format("15:04") + " " + text
+	}
+	chip.Set("textContent", text)
+	if c.editable(ev) {
+		chip.Get("classList").Call("add", "cursor-grab", "touch-none")
+	} else {
+		chip.Get("classList").Call("add", "cursor-pointer")
+	}
+	return chip
+}
+
+// renderTimedEvent renders an event in a day column, clipped to the day
+// and the grid's hours
+func (c *Calendar) renderTimedEvent(p placedEvent, day time.Time) js.Value {
+	ev := p.event
+	el := Div(calendarEventClass + eventColor(ev))
+	el.Call("setAttribute", "role", "button")
+	el.Call("setAttribute", "tabindex", "0")
+	el.Call("setAttribute", "data-event", ev.ID)
+	el.Call("setAttribute", "aria-label", c.eventLabel(ev))
+	c.placeEvent(el, day, ev.Start, eventEnd(ev, c.props.SlotMinutes))
+	width := 100 / float64(p.columns)
+	el.Get("style").Set("left", fmt.Sprintf("%.4f%%", width*float64(p.column)))
+	el.Get("style").Set("width", fmt.Sprintf("%.4f%%", width))
+
+	el.Call("appendChild", Div("font-medium truncate", Span("", ev.Title)))
+	el.Call("appendChild", Div("opacity-90 truncate", Span("", ev.Start.Format("15:04")+" – "+eventEnd(ev, c.props.SlotMinutes).Format("15:04"))))
+
+	if c.editable(ev) {
+		el.Get("classList").Call("add", "cursor-grab", "touch-none")
+		handle := Div("absolute inset-x-0 bottom-0 h-1.5 cursor-ns-resize")
+		handle.Call("setAttribute", "data-resize", "")
+		el.Call("appendChild", handle)
+	} else {
+		el.Get("classList").Call("add", "cursor-pointer")
+	}
+	return el
+}
+
+// placeEvent sets the top and height of an element spanning start to end
+// in day's column
+func (c *Calendar) placeEvent(el js.Value, day, start, end time.Time) {
+	top := c.minuteOffset(day, start)
+	bottom := c.minuteOffset(day, end)
+	if bottom-top < 15 {
+		bottom = top + 15
+	}
+	px := func(minutes int) string {
+		return strconv.Itoa(minutes*c.props.HourHeight/60) + "px"
+	}
+	el.Get("style").Set("top", px(top))
+	el.Get("style").Set("height", px(bottom-top))
+}
+
+// minuteOffset returns the minutes from the top of day's column to t,
+// clamped to the column
+func (c *Calendar) minuteOffset(day, t time.Time) int {
+	minutes := int(t.Sub(day).Minutes()) - c.props.StartHour*60
+	return min(max(minutes, 0), (c.props.EndHour-c.props.StartHour)*60)
+}
+
+// eventLabel describes an event for screen readers
+func (c *Calendar) eventLabel(ev CalendarEvent) string {
+	if ev.AllDay {
+		return ev.Title + ", " + i18n.T("gux.calendar.allDay")
+	}
+	return ev.Title + ", " + i18n.FormatDate(ev.Start) + " " + ev.Start.Format("15:04") + " – " + eventEnd(ev, c.props.SlotMinutes).Format("15:04")
+}
+
+func eventColor(ev CalendarEvent) string {
+	if ev.Color == "" {
+		return calendarEventColor
+	}
+	return ev.Color
+}
+
+// placedEvent is an event in a day column, in one of columns side by side
+type placedEvent struct {
+	event           CalendarEvent
+	column, columns int
+}
+
+// layoutDay places overlapping events side by side. Events that overlap,
+// directly or through others, share a group; each takes the first column
+// of the group that is free at its start.
+func layoutDay(events []CalendarEvent, slotMinutes int) []placedEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		return eventEnd(events[i], slotMinutes).After(eventEnd(events[j], slotMinutes))
+	})
+
+	placed := make([]placedEvent, 0, len(events))
+	var colEnds []time.Time // end of the last event in each column of the group
+	group := 0              // index in placed where the group starts
+	var groupEnd time.Time
+
+	closeGroup := func() {
+		for i := group; i < len(placed); i++ {
+			placed[i].columns = len(colEnds)
+		}
+		group, colEnds = len(placed), nil
+	}
+	for _, ev := range events {
+		end := eventEnd(ev, slotMinutes)
+		if len(colEnds) > 0 && !ev.Start.Before(groupEnd) {
+			closeGroup()
+		}
+		col := -1
+		for i, colEnd := range colEnds {
+			if !ev.Start.Before(colEnd) {
+				col = i
+				break
+			}
+		}
+		if col < 0 {
+			col = len(colEnds)
+			colEnds = append(colEnds, end)
+		} else {
+			colEnds[col] = end
+		}
+		if end.After(groupEnd) || len(placed) == group {
+			groupEnd = end
+		}
+		placed = append(placed, placedEvent{event: ev, column: col})
+	}
+	closeGroup()
+	return placed
+}
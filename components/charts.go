@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"math"
 	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
 // ChartData represents data for charts
@@ -15,6 +18,13 @@ type ChartData struct {
 	Color string
 }
 
+// ChartSeries is a named set of values in a multi-series chart
+type ChartSeries struct {
+	Name  string
+	Data  []ChartData // a value per category, in the same order in each series
+	Color string      // default from the chart colors
+}
+
 // BarChartProps configures a BarChart
 type BarChartProps struct {
 	Data       []ChartData
@@ -25,6 +35,13 @@ type BarChartProps struct {
 	Horizontal bool
 	BarColor   string // default color if not specified per-item
 	ClassName  string
+
+	// Series, instead of Data, plots several values per category, labeled
+	// by the first series. Bars are grouped side by side, or stacked with
+	// Stacked, when ShowValues labels each stack with its total.
+	Series     []ChartSeries
+	Stacked    bool
+	ShowLegend bool // list the series under the chart
 }
 
 // BarChart creates a bar chart component
@@ -50,10 +67,15 @@ func BarChart(props BarChartProps) js.Value {
 	container.Get("style").Set("width", props.Width)
 	container.Get("style").Set("height", props.Height)
 	container.Get("style").Set("maxWidth", "100%")
+	container.Get("style").Set("position", "relative")
 
+	if len(props.Series) > 0 {
+		return barChartSeries(container, props)
+	}
 	if len(props.Data) == 0 {
 		return container
 	}
+	bindChartTooltip(container)
 
 	// Find max value for scaling
 	maxVal := 0.0
@@ -89,6 +111,7 @@ func BarChart(props BarChartProps) js.Value {
 				color = props.BarColor
 			}
 			bar.Get("style").Set("backgroundColor", color)
+			bar.Call("setAttribute", "data-tooltip", chartTooltipText(d.Label, formatNumber(d.Value)))
 			percentage := (d.Value / maxVal) * 100
 			bar.Get("style").Set("width", fmt.Sprintf("%.1f%%", percentage))
 
@@ -140,6 +163,7 @@ func BarChart(props BarChartProps) js.Value {
 				color = props.BarColor
 			}
 			bar.Get("style").Set("backgroundColor", color)
+			bar.Call("setAttribute", "data-tooltip", chartTooltipText(d.Label, formatNumber(d.Value)))
 			// Use flex-basis with a percentage of the column height for bar sizing
 			percentage := (d.Value / maxVal) * 100
 			bar.Get("style").Set("height", fmt.Sprintf("%.1f%%", percentage))
@@ -165,6 +189,157 @@ func BarChart(props BarChartProps) js.Value {
 	return container
 }
 
+// barChartSeries fills container with a bar chart of props.Series
+func barChartSeries(container js.Value, props BarChartProps) js.Value {
+	categories := len(props.Series[0].Data)
+	if categories == 0 {
+		return container
+	}
+	bindChartTooltip(container)
+	container.Get("style").Set("display", "flex")
+	container.Get("style").Set("flexDirection", "column")
+	container.Get("style").Set("gap", "8px")
+
+	colors := make([]string, len(props.Series))
+	legend := make([]ChartData, len(props.Series))
+	for i, series := range props.Series {
+		colors[i] = chartColor(series.Color, i)
+		legend[i] = ChartData{Label: series.Name, Color: colors[i]}
+	}
+	value := func(series, category int) ChartData {
+		if data := props.Series[series].Data; category < len(data) {
+			return data[category]
+		}
+		return ChartData{}
+	}
+
+	// Scale to the tallest bar, or the tallest stack
+	maxVal := 0.0
+	for c := 0; c < categories; c++ {
+		total := 0.0
+		for s := range props.Series {
+			v := value(s, c).Value
+			if props.Stacked {
+				total += v
+			} else {
+				total = math.Max(total, v)
+			}
+		}
+		maxVal = math.Max(maxVal, total)
+	}
+	if maxVal == 0 {
+		maxVal = 1
+	}
+	percent := func(v float64) string {
+		return fmt.Sprintf("%.1f%%", v/maxVal*100)
+	}
+	segment := func(s, c int, className string) js.Value {
+		d := value(s, c)
+		bar := Div(className)
+		color := d.Color
+		if color == "" {
+			color = colors[s]
+		}
+		bar.Get("style").Set("backgroundColor", color)
+		bar.Call("setAttribute", "data-tooltip", chartTooltipText(props.Series[s].Name+", "+props.Series[0].Data[c].Label, formatNumber(d.Value)))
+		return bar
+	}
+	total := func(c int) float64 {
+		sum := 0.0
+		for s := range props.Series {
+			sum += value(s, c).Value
+		}
+		return sum
+	}
+
+	if props.Horizontal {
+		for c := 0; c < categories; c++ {
+			row := Div("flex items-center gap-2")
+			if props.ShowLabels {
+				row.Call("appendChild", Div("w-20 text-sm text-gray-600 truncate", Span("", props.Series[0].Data[c].Label)))
+			}
+			if props.Stacked {
+				track := Div("flex-1 flex bg-gray-100 rounded h-6 overflow-hidden")
+				for s := range props.Series {
+					bar := segment(s, c, "h-full transition-all duration-300")
+					bar.Get("style").Set("width", percent(value(s, c).Value))
+					track.Call("appendChild", bar)
+				}
+				row.Call("appendChild", track)
+				if props.ShowValues {
+					row.Call("appendChild", Div("w-12 text-sm text-gray-700 text-right", Span("", formatNumber(total(c)))))
+				}
+			} else {
+				bars := Div("flex-1 flex flex-col gap-0.5")
+				for s := range props.Series {
+					line := Div("flex items-center gap-2")
+					track := Div("flex-1 bg-gray-100 rounded h-3 overflow-hidden")
+					bar := segment(s, c, "h-full rounded transition-all duration-300")
+					bar.Get("style").Set("width", percent(value(s, c).Value))
+					track.Call("appendChild", bar)
+					line.Call("appendChild", track)
+					if props.ShowValues {
+						line.Call("appendChild", Div("w-12 text-xs text-gray-700 text-right", Span("", formatNumber(value(s, c).Value))))
+					}
+					bars.Call("appendChild", line)
+				}
+				row.Call("appendChild", bars)
+			}
+			container.Call("appendChild", row)
+		}
+	} else {
+		barArea := Div("flex items-end gap-3")
+		barArea.Get("style").Set("flex", "1")
+		barArea.Get("style").Set("minHeight", "0")
+		labelsArea := Div("flex gap-3")
+		for c := 0; c < categories; c++ {
+			col := Div("flex-1 flex flex-col items-center justify-end h-full min-w-0")
+			if props.Stacked {
+				if props.ShowValues {
+					col.Call("appendChild", Div("text-xs text-gray-600 mb-1", Span("", formatNumber(total(c)))))
+				}
+				stack := Div("w-full flex flex-col-reverse rounded-t overflow-hidden")
+				stack.Get("style").Set("height", percent(total(c)))
+				for s := range props.Series {
+					bar := segment(s, c, "w-full transition-all duration-300")
+					if t := total(c); t > 0 {
+						bar.Get("style").Set("height", fmt.Sprintf("%.1f%%", value(s, c).Value/t*100))
+					}
+					stack.Call("appendChild", bar)
+				}
+				col.Call("appendChild", stack)
+			} else {
+				group := Div("w-full h-full flex items-end gap-0.5")
+				for s := range props.Series {
+					cell := Div("flex-1 flex flex-col items-center justify-end h-full min-w-0")
+					if props.ShowValues {
+						cell.Call("appendChild", Div("text-xs text-gray-600 mb-1 truncate", Span("", formatNumber(value(s, c).Value))))
+					}
+					bar := segment(s, c, "w-full rounded-t transition-all duration-300")
+					bar.Get("style").Set("height", percent(value(s, c).Value))
+					bar.Get("style").Set("minHeight", "2px")
+					cell.Call("appendChild", bar)
+					group.Call("appendChild", cell)
+				}
+				col.Call("appendChild", group)
+			}
+			barArea.Call("appendChild", col)
+			labelsArea.Call("appendChild", Div("flex-1 text-xs text-gray-600 truncate text-center", Span("", props.Series[0].Data[c].Label)))
+		}
+		container.Call("appendChild", barArea)
+		if props.ShowLabels {
+			container.Call("appendChild", labelsArea)
+		}
+	}
+
+	if props.ShowLegend {
+		l := chartLegend(legend, false)
+		l.Set("className", "flex flex-wrap justify-center gap-x-4 gap-y-1")
+		container.Call("appendChild", l)
+	}
+	return container
+}
+
 // LineChartProps configures a LineChart
 type LineChartProps struct {
 	Data       []ChartData
@@ -206,6 +381,7 @@ func LineChart(props LineChartProps) js.Value {
 	if len(props.Data) == 0 {
 		return container
 	}
+	bindChartTooltip(container)
 
 	// SVG dimensions
 	svgWidth := 400
@@ -292,12 +468,13 @@ func LineChart(props LineChartProps) js.Value {
 
 	// Draw points
 	if props.ShowPoints {
-		for _, p := range points {
+		for i, p := range points {
 			circle := document.Call("createElementNS", "http://www.w3.org/2000/svg", "circle")
 			circle.Call("setAttribute", "cx", fmt.Sprintf("%.1f", p.x))
 			circle.Call("setAttribute", "cy", fmt.Sprintf("%.1f", p.y))
 			circle.Call("setAttribute", "r", "4")
 			circle.Call("setAttribute", "fill", props.LineColor)
+			circle.Call("setAttribute", "data-tooltip", chartTooltipText(props.Data[i].Label, formatNumber(props.Data[i].Value)))
 			svg.Call("appendChild", circle)
 		}
 	}
@@ -340,7 +517,7 @@ func PieChart(props PieChartProps) js.Value {
 
 	container := document.Call("createElement", "div")
 	// Responsive: stack vertically on mobile, horizontal on larger screens
-	className := "flex flex-col sm:flex-row items-center gap-4"
+	className := "relative flex flex-col sm:flex-row items-center gap-4"
 	if props.ClassName != "" {
 		className += " " + props.ClassName
 	}
@@ -349,9 +526,7 @@ func PieChart(props PieChartProps) js.Value {
 	if len(props.Data) == 0 {
 		return container
 	}
-
-	// Default colors
-	defaultColors := []string{"#3b82f6", "#ef4444", "#22c55e", "#f59e0b", "#8b5cf6", "#ec4899", "#06b6d4", "#84cc16"}
+	bindChartTooltip(container)
 
 	// Calculate total
 	total := 0.0
@@ -375,11 +550,10 @@ func PieChart(props PieChartProps) js.Value {
 
 	// Draw slices
 	startAngle := -math.Pi / 2
+	legend := make([]ChartData, len(props.Data))
 	for i, d := range props.Data {
-		color := d.Color
-		if color == "" {
-			color = defaultColors[i%len(defaultColors)]
-		}
+		color := chartColor(d.Color, i)
+		legend[i] = ChartData{Label: d.Label, Value: d.Value, Color: color}
 
 		sliceAngle := (d.Value / total) * 2 * math.Pi
 		endAngle := startAngle + sliceAngle
@@ -417,6 +591,7 @@ func PieChart(props PieChartProps) js.Value {
 		slice.Call("setAttribute", "fill", color)
 		slice.Call("setAttribute", "stroke", "white")
 		slice.Call("setAttribute", "stroke-width", "2")
+		slice.Call("setAttribute", "data-tooltip", fmt.Sprintf("%s (%.0f%%)", chartTooltipText(d.Label, formatNumber(d.Value)), d.Value/total*100))
 		svg.Call("appendChild", slice)
 
 		// Label on slice
@@ -447,50 +622,84 @@ func PieChart(props PieChartProps) js.Value {
 
 	container.Call("appendChild", svg)
 
-	// Legend
 	if props.ShowLegend {
-		legend := document.Call("createElement", "div")
-		legend.Set("className", "space-y-1")
-
-		for i, d := range props.Data {
-			color := d.Color
-			if color == "" {
-				color = defaultColors[i%len(defaultColors)]
-			}
+		container.Call("appendChild", chartLegend(legend, true))
+	}
 
-			item := document.Call("createElement", "div")
-			item.Set("className", "flex items-center gap-2 text-sm")
+	return container
+}
 
-			dot := document.Call("createElement", "div")
-			dot.Set("className", "w-3 h-3 rounded-full")
-			dot.Get("style").Set("backgroundColor", color)
-			item.Call("appendChild", dot)
+// chartColors are the colors of slices and series without their own
+var chartColors = []string{"#3b82f6", "#ef4444", "#22c55e", "#f59e0b", "#8b5cf6", "#ec4899", "#06b6d4", "#84cc16"}
 
-			label := document.Call("createElement", "span")
-			label.Set("className", "text-gray-700")
-			label.Set("textContent", d.Label)
-			item.Call("appendChild", label)
+// chartColor returns color, or the default color for the ith item
+func chartColor(color string, i int) string {
+	if color != "" {
+		return color
+	}
+	return chartColors[i%len(chartColors)]
+}
 
-			value := document.Call("createElement", "span")
-			value.Set("className", "text-gray-500")
-			value.Set("textContent", fmt.Sprintf("(%.0f)", d.Value))
-			item.Call("appendChild", value)
+// chartLegend lists each item's color and label, and its value if
+// showValues is set
+func chartLegend(items []ChartData, showValues bool) js.Value {
+	legend := Div("space-y-1")
+	for _, d := range items {
+		dot := Div("w-3 h-3 rounded-full flex-shrink-0")
+		dot.Get("style").Set("backgroundColor", d.Color)
+		item := Div("flex items-center gap-2 text-sm", dot, Span("text-gray-700", d.Label))
+		if showValues {
+			item.Call("appendChild", Span("text-gray-500", fmt.Sprintf("(%.0f)", d.Value)))
+		}
+		legend.Call("appendChild", item)
+	}
+	return legend
+}
 
-			legend.Call("appendChild", item)
+// bindChartTooltip shows a tooltip in container, which must be positioned,
+// above each element with a data-tooltip attribute while the pointer is
+// over it
+func bindChartTooltip(container js.Value) {
+	tip := Div("absolute z-50 px-2 py-1 text-xs text-white bg-gray-900 rounded shadow-lg whitespace-nowrap pointer-events-none -translate-x-1/2 -translate-y-full hidden")
+	tip.Call("setAttribute", "role", "tooltip")
+	container.Call("appendChild", tip)
+
+	var l listeners
+	l.on(container, "mouseover", func(this js.Value, args []js.Value) any {
+		el := args[0].Get("target").Call("closest", "[data-tooltip]")
+		if !el.Truthy() || !container.Call("contains", el).Bool() {
+			tip.Get("classList").Call("add", "hidden")
+			return nil
 		}
+		rect := el.Call("getBoundingClientRect")
+		box := container.Call("getBoundingClientRect")
+		tip.Set("textContent", el.Call("getAttribute", "data-tooltip"))
+		tip.Get("style").Set("left", fmt.Sprintf("%.1fpx", rect.Get("left").Float()+rect.Get("width").Float()/2-box.Get("left").Float()))
+		tip.Get("style").Set("top", fmt.Sprintf("%.1fpx", rect.Get("top").Float()-box.Get("top").Float()-6))
+		tip.Get("classList").Call("remove", "hidden")
+		return nil
+	})
+	l.on(container, "mouseleave", func(this js.Value, args []js.Value) any {
+		tip.Get("classList").Call("add", "hidden")
+		return nil
+	})
+	onUnmount(container, l.release)
+}
 
-		container.Call("appendChild", legend)
+// chartTooltipText describes a formatted value for a chart tooltip
+func chartTooltipText(label, value string) string {
+	if label == "" {
+		return value
 	}
-
-	return container
+	return label + ": " + value
 }
 
-// formatNumber formats a number for display
+// formatNumber formats a number for display in the current locale
 func formatNumber(n float64) string {
 	if n == math.Floor(n) {
-		return fmt.Sprintf("%.0f", n)
+		return i18n.FormatNumber(n, 0)
 	}
-	return fmt.Sprintf("%.1f", n)
+	return i18n.FormatNumber(n, 1)
 }
 
 // SimpleBarChart creates a quick bar chart from labels and values
@@ -524,3 +733,478 @@ func SimplePieChart(labels []string, values []float64) js.Value {
 func DonutChart(data []ChartData, donutWidth int) js.Value {
 	return PieChart(PieChartProps{Data: data, DonutWidth: donutWidth, ShowLabels: true, ShowLegend: true})
 }
+
+// HeatmapProps configures a Heatmap
+type HeatmapProps struct {
+	// Data is a matrix of values: a row for each of Rows, with a value for
+	// each of Columns
+	Data    [][]float64
+	Rows    []string
+	Columns []string
+
+	// Days, instead of Data, shows a cell for each day from the first to
+	// the last, in a column for each week, like a GitHub contribution graph.
+	// Values on the same day are added up.
+	Days []TimePoint
+
+	// Colors is the scale from lowest to highest (default five blues, or
+	// gray and four greens with Days). The lowest color is only used for
+	// values at Min.
+	Colors []string
+
+	// Min and Max fix the ends of the scale. By default it spans the
+	// values, from 0 with Days.
+	Min, Max float64
+
+	CellSize    int                    // pixels (default 32, or 12 with Days)
+	ShowValues  bool                   // print values in the cells
+	ShowLegend  bool                   // show the scale under the chart
+	ValueFormat func(v float64) string // default formatNumber
+	ClassName   string
+}
+
+var (
+	heatmapColors = []string{"#eff6ff", "#bfdbfe", "#60a5fa", "#2563eb", "#1e3a8a"}
+	heatmapDays   = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+)
+
+// Heatmap creates a grid of cells colored by value, from a matrix or a
+// series of days. Hovering a cell shows its value.
+func Heatmap(props HeatmapProps) js.Value {
+	document := js.Global().Get("document")
+	days := props.Days != nil
+	if len(props.Colors) == 0 {
+		props.Colors = heatmapColors
+		if days {
+			props.Colors = heatmapDays
+		}
+	}
+	if props.CellSize <= 0 {
+		props.CellSize = 32
+		if days {
+			props.CellSize = 12
+		}
+	}
+	if props.ValueFormat == nil {
+		props.ValueFormat = formatNumber
+	}
+
+	container := document.Call("createElement", "div")
+	className := "relative inline-flex flex-col gap-2 max-w-full"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	container.Set("className", className)
+	bindChartTooltip(container)
+
+	// A cell per matrix value, or per day
+	type cell struct {
+		row, col int
+		value    float64
+		tooltip  string
+	}
+	var cells []cell
+	var rowLabels, colLabels []string
+	var monthCols []int // Days: columns starting a month
+	if days {
+		totals := make(map[time.Time]float64)
+		var first, last time.Time
+		for _, p := range props.Days {
+			day := startOfDay(p.Time)
+			totals[day] += p.Value
+			if first.IsZero() || day.Before(first) {
+				first = day
+			}
+			if day.After(last) {
+				last = day
+			}
+		}
+		start := startOfWeek(first)
+		for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+			offset := dayDiff(start, day)
+			c := cell{row: offset % 7, col: offset / 7, value: totals[day]}
+			c.tooltip = chartTooltipText(i18n.FormatDate(day), props.ValueFormat(c.value))
+			cells = append(cells, c)
+			if day.Day() == 1 || day.Equal(first) {
+				// A month starting within a few columns of the last hides its label
+				if n := len(monthCols); n > 0 && c.col-monthCols[n-1] < 3 {
+					monthCols, colLabels = monthCols[:n-1], colLabels[:n-1]
+				}
+				monthCols = append(monthCols, c.col)
+				colLabels = append(colLabels, i18n.ShortMonthName(day.Month()))
+			}
+		}
+		for i := 0; i < 7; i++ {
+			label := ""
+			if i%2 == 1 {
+				label = i18n.DayName(start.AddDate(0, 0, i).Weekday())
+			}
+			rowLabels = append(rowLabels, label)
+		}
+	} else {
+		rowLabels, colLabels = props.Rows, props.Columns
+		for r, row := range props.Data {
+			for c, value := range row {
+				label := ""
+				if r < len(props.Rows) {
+					label = props.Rows[r]
+				}
+				if c < len(props.Columns) {
+					if label != "" {
+						label += ", "
+					}
+					label += props.Columns[c]
+				}
+				cells = append(cells, cell{row: r, col: c, value: value, tooltip: chartTooltipText(label, props.ValueFormat(value))})
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return container
+	}
+
+	// Scale
+	lo, hi := props.Min, props.Max
+	if lo == 0 && hi == 0 {
+		lo, hi = cells[0].value, cells[0].value
+		if days {
+			lo = 0
+		}
+		for _, c := range cells {
+			lo, hi = math.Min(lo, c.value), math.Max(hi, c.value)
+		}
+	}
+	level := func(v float64) int {
+		if hi <= lo {
+			return len(props.Colors) - 1
+		}
+		i := int(math.Ceil((v - lo) / (hi - lo) * float64(len(props.Colors)-1)))
+		return min(max(i, 0), len(props.Colors)-1)
+	}
+
+	// Layout: row labels on the left, column labels on top
+	size := float64(props.CellSize)
+	gap := math.Max(2, size/8)
+	labelWidth := 0.0
+	for _, label := range rowLabels {
+		labelWidth = math.Max(labelWidth, float64(len([]rune(label)))*6.5)
+	}
+	if labelWidth > 0 {
+		labelWidth += 6
+	}
+	top := 0.0
+	if len(colLabels) > 0 {
+		top = 16
+	}
+	rows, cols := 0, 0
+	for _, c := range cells {
+		rows, cols = max(rows, c.row+1), max(cols, c.col+1)
+	}
+	width := labelWidth + float64(cols)*(size+gap)
+	height := top + float64(rows)*(size+gap)
+
+	svg := document.Call("createElementNS", "http://www.w3.org/2000/svg", "svg")
+	svg.Call("setAttribute", "width", fmt.Sprintf("%.0f", width))
+	svg.Call("setAttribute", "height", fmt.Sprintf("%.0f", height))
+	svg.Call("setAttribute", "viewBox", fmt.Sprintf("0 0 %.0f %.0f", width, height))
+	newEl := func(tag string, attrs map[string]string) js.Value {
+		el := document.Call("createElementNS", "http://www.w3.org/2000/svg", tag)
+		for k, v := range attrs {
+			el.Call("setAttribute", k, v)
+		}
+		svg.Call("appendChild", el)
+		return el
+	}
+	text := func(s string, x, y float64, anchor string) {
+		if s == "" {
+			return
+		}
+		label := newEl("text", map[string]string{
+			"x": fmt.Sprintf("%.1f", x), "y": fmt.Sprintf("%.1f", y),
+			"text-anchor": anchor, "dominant-baseline": "middle", "font-size": "10", "fill": "#6b7280",
+		})
+		label.Set("textContent", s)
+	}
+
+	for r, label := range rowLabels {
+		text(label, labelWidth-6, top+float64(r)*(size+gap)+size/2, "end")
+	}
+	for i, label := range colLabels {
+		if days {
+			text(label, labelWidth+float64(monthCols[i])*(size+gap), top/2, "start")
+		} else {
+			text(label, labelWidth+float64(i)*(size+gap)+size/2, top/2, "middle")
+		}
+	}
+	for _, c := range cells {
+		x := labelWidth + float64(c.col)*(size+gap)
+		y := top + float64(c.row)*(size+gap)
+		newEl("rect", map[string]string{
+			"x": fmt.Sprintf("%.1f", x), "y": fmt.Sprintf("%.1f", y),
+			"width": fmt.Sprintf("%.1f", size), "height": fmt.Sprintf("%.1f", size),
+			"rx": fmt.Sprintf("%.1f", size/6), "fill": props.Colors[level(c.value)],
+			"data-tooltip": c.tooltip,
+		})
+		if props.ShowValues {
+			// Light text on the darker half of the scale
+			fill := "#111827"
+			if level(c.value) > (len(props.Colors)-1)/2 {
+				fill = "white"
+			}
+			value := newEl("text", map[string]string{
+				"x": fmt.Sprintf("%.1f", x+size/2), "y": fmt.Sprintf("%.1f", y+size/2),
+				"text-anchor": "middle", "dominant-baseline": "middle", "font-size": "10",
+				"fill": fill, "pointer-events": "none",
+			})
+			value.Set("textContent", props.ValueFormat(c.value))
+		}
+	}
+
+	scroll := document.Call("createElement", "div")
+	scroll.Set("className", "overflow-x-auto")
+	scroll.Call("appendChild", svg)
+	container.Call("appendChild", scroll)
+
+	// Legend: the scale's colors between its ends
+	if props.ShowLegend {
+		legend := Div("flex items-center justify-end gap-1 text-xs text-gray-500", Span("mr-1", i18n.T("gux.chart.less")))
+		step := (hi - lo) / float64(max(len(props.Colors)-1, 1))
+		for i, color := range props.Colors {
+			swatch := Div("w-3 h-3 rounded-sm")
+			swatch.Get("style").Set("backgroundColor", color)
+			tooltip := props.ValueFormat(lo)
+			if i > 0 {
+				tooltip = props.ValueFormat(lo+step*float64(i-1)) + " – " + props.ValueFormat(lo+step*float64(i))
+			}
+			swatch.Call("setAttribute", "data-tooltip", tooltip)
+			legend.Call("appendChild", swatch)
+		}
+		legend.Call("appendChild", Span("ml-1", i18n.T("gux.chart.more")))
+		container.Call("appendChild", legend)
+	}
+
+	return container
+}
+
+// ScatterPoint is a point in a ScatterChart
+type ScatterPoint struct {
+	X, Y  float64
+	Label string // names the point in its tooltip
+}
+
+// ScatterSeries is a set of points drawn in one color
+type ScatterSeries struct {
+	Name   string
+	Points []ScatterPoint
+	Color  string // default from the chart colors
+}
+
+// ScatterChartProps configures a ScatterChart
+type ScatterChartProps struct {
+	Series      []ScatterSeries
+	Width       string // default "100%"
+	Height      string // default "240px"
+	XLabel      string // axis titles
+	YLabel      string
+	TrendLine   bool // draw each series' least squares line
+	ShowGrid    bool
+	ShowLegend  bool
+	PointRadius int                    // default 4
+	XFormat     func(v float64) string // default formatNumber
+	YFormat     func(v float64) string // default formatNumber
+	ClassName   string
+}
+
+// ScatterChart creates a chart of x/y points in one or more series, with
+// optional trend lines. Hovering a point shows its values.
+func ScatterChart(props ScatterChartProps) js.Value {
+	document := js.Global().Get("document")
+
+	if props.Width == "" {
+		props.Width = "100%"
+	}
+	if props.Height == "" {
+		props.Height = "240px"
+	}
+	if props.PointRadius <= 0 {
+		props.PointRadius = 4
+	}
+	if props.XFormat == nil {
+		props.XFormat = formatNumber
+	}
+	if props.YFormat == nil {
+		props.YFormat = formatNumber
+	}
+
+	container := document.Call("createElement", "div")
+	className := "relative flex flex-col gap-2"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	container.Set("className", className)
+	container.Get("style").Set("width", props.Width)
+	container.Get("style").Set("maxWidth", "100%")
+
+	var points []ScatterPoint
+	for _, series := range props.Series {
+		points = append(points, series.Points...)
+	}
+	if len(points) == 0 {
+		return container
+	}
+	bindChartTooltip(container)
+
+	// Ranges, with a margin so points aren't cut off at the edges
+	minX, maxX, minY, maxY := points[0].X, points[0].X, points[0].Y, points[0].Y
+	for _, p := range points {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	pad := func(lo, hi float64) (float64, float64) {
+		if lo == hi {
+			return lo - 1, hi + 1
+		}
+		margin := (hi - lo) * 0.05
+		return lo - margin, hi + margin
+	}
+	minX, maxX = pad(minX, maxX)
+	minY, maxY = pad(minY, maxY)
+
+	svgWidth, svgHeight := 400.0, 240.0
+	padL, padR, padT, padB := 44.0, 12.0, 12.0, 24.0
+	if props.YLabel != "" {
+		padL += 14
+	}
+	if props.XLabel != "" {
+		padB += 14
+	}
+	chartW, chartH := svgWidth-padL-padR, svgHeight-padT-padB
+	xFor := func(v float64) float64 { return padL + (v-minX)/(maxX-minX)*chartW }
+	yFor := func(v float64) float64 { return padT + chartH - (v-minY)/(maxY-minY)*chartH }
+
+	svg := document.Call("createElementNS", "http://www.w3.org/2000/svg", "svg")
+	svg.Call("setAttribute", "width", "100%")
+	svg.Call("setAttribute", "height", props.Height)
+	svg.Call("setAttribute", "viewBox", fmt.Sprintf("0 0 %.0f %.0f", svgWidth, svgHeight))
+	svg.Get("style").Set("overflow", "visible")
+	newEl := func(parent js.Value, tag string, attrs map[string]string) js.Value {
+		el := document.Call("createElementNS", "http://www.w3.org/2000/svg", tag)
+		for k, v := range attrs {
+			el.Call("setAttribute", k, v)
+		}
+		parent.Call("appendChild", el)
+		return el
+	}
+	text := func(s string, attrs map[string]string) {
+		attrs["font-size"], attrs["fill"] = "10", "#6b7280"
+		newEl(svg, "text", attrs).Set("textContent", s)
+	}
+
+	// Axes, ticks, and grid
+	for i := 0; i <= 4; i++ {
+		x := minX + (maxX-minX)*float64(i)/4
+		y := minY + (maxY-minY)*float64(i)/4
+		if props.ShowGrid {
+			newEl(svg, "line", map[string]string{
+				"x1": fmt.Sprintf("%.1f", xFor(x)), "x2": fmt.Sprintf("%.1f", xFor(x)),
+				"y1": fmt.Sprintf("%.1f", padT), "y2": fmt.Sprintf("%.1f", padT+chartH),
+				"stroke": "#f3f4f6", "stroke-width": "1",
+			})
+			newEl(svg, "line", map[string]string{
+				"x1": fmt.Sprintf("%.1f", padL), "x2": fmt.Sprintf("%.1f", padL+chartW),
+				"y1": fmt.Sprintf("%.1f", yFor(y)), "y2": fmt.Sprintf("%.1f", yFor(y)),
+				"stroke": "#e5e7eb", "stroke-width": "1",
+			})
+		}
+		text(props.XFormat(x), map[string]string{
+			"x": fmt.Sprintf("%.1f", xFor(x)), "y": fmt.Sprintf("%.1f", padT+chartH+14),
+			"text-anchor": "middle",
+		})
+		text(props.YFormat(y), map[string]string{
+			"x": fmt.Sprintf("%.1f", padL-6), "y": fmt.Sprintf("%.1f", yFor(y)),
+			"text-anchor": "end", "dominant-baseline": "middle",
+		})
+	}
+	newEl(svg, "path", map[string]string{
+		"d":    fmt.Sprintf("M %.1f %.1f V %.1f H %.1f", padL, padT, padT+chartH, padL+chartW),
+		"fill": "none", "stroke": "#9ca3af", "stroke-width": "1",
+	})
+	if props.XLabel != "" {
+		text(props.XLabel, map[string]string{
+			"x": fmt.Sprintf("%.1f", padL+chartW/2), "y": fmt.Sprintf("%.1f", svgHeight-4),
+			"text-anchor": "middle",
+		})
+	}
+	if props.YLabel != "" {
+		cy := padT + chartH/2
+		text(props.YLabel, map[string]string{
+			"x": "10", "y": fmt.Sprintf("%.1f", cy),
+			"text-anchor": "middle", "transform": fmt.Sprintf("rotate(-90 10 %.1f)", cy),
+		})
+	}
+
+	// Trend lines are clipped to the plot area
+	clipID := "scatter-clip-" + js.Global().Get("crypto").Call("randomUUID").String()
+	clip := newEl(newEl(svg, "defs", nil), "clipPath", map[string]string{"id": clipID})
+	newEl(clip, "rect", map[string]string{
+		"x": fmt.Sprintf("%.1f", padL), "y": fmt.Sprintf("%.1f", padT),
+		"width": fmt.Sprintf("%.1f", chartW), "height": fmt.Sprintf("%.1f", chartH),
+	})
+
+	legend := make([]ChartData, len(props.Series))
+	for i, series := range props.Series {
+		color := chartColor(series.Color, i)
+		legend[i] = ChartData{Label: series.Name, Color: color}
+
+		if props.TrendLine {
+			if slope, intercept, ok := leastSquares(series.Points); ok {
+				newEl(svg, "line", map[string]string{
+					"x1": fmt.Sprintf("%.1f", xFor(minX)), "y1": fmt.Sprintf("%.1f", yFor(slope*minX+intercept)),
+					"x2": fmt.Sprintf("%.1f", xFor(maxX)), "y2": fmt.Sprintf("%.1f", yFor(slope*maxX+intercept)),
+					"stroke": color, "stroke-width": "2", "stroke-dasharray": "6 4", "opacity": "0.7",
+					"clip-path": "url(#" + clipID + ")",
+				})
+			}
+		}
+		for _, p := range series.Points {
+			label := p.Label
+			if label == "" {
+				label = series.Name
+			}
+			newEl(svg, "circle", map[string]string{
+				"cx": fmt.Sprintf("%.1f", xFor(p.X)), "cy": fmt.Sprintf("%.1f", yFor(p.Y)),
+				"r": fmt.Sprint(props.PointRadius), "fill": color, "fill-opacity": "0.8",
+				"stroke": "white", "stroke-width": "1",
+				"data-tooltip": chartTooltipText(label, "("+props.XFormat(p.X)+", "+props.YFormat(p.Y)+")"),
+			})
+		}
+	}
+
+	container.Call("appendChild", svg)
+	if props.ShowLegend {
+		container.Call("appendChild", chartLegend(legend, false))
+	}
+	return container
+}
+
+// leastSquares returns the slope and intercept of the line best fitting
+// points, or false if there are fewer than two distinct x values
+func leastSquares(points []ScatterPoint) (slope, intercept float64, ok bool) {
+	if len(points) < 2 {
+		return 0, 0, false
+	}
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		sumX += p.X
+		sumY += p.Y
+		sumXY += p.X * p.Y
+		sumXX += p.X * p.X
+	}
+	d := n*sumXX - sumX*sumX
+	if math.Abs(d) < 1e-12 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / d
+	return slope, (sumY - slope*sumX) / n, true
+}
@@ -19,6 +19,7 @@ type Checkbox struct {
 	input      js.Value
 	label      js.Value
 	checkboxID string
+	listeners  listeners
 }
 
 // NewCheckbox creates a new Checkbox component
@@ -52,7 +53,7 @@ func NewCheckbox(props CheckboxProps) *Checkbox {
 	}
 
 	if props.OnChange != nil {
-		input.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		input.Call("addEventListener", "change", cb.listeners.fn(func(this js.Value, args []js.Value) any {
 			checked := input.Get("checked").Bool()
 			props.OnChange(checked)
 			return nil
@@ -77,6 +78,7 @@ func NewCheckbox(props CheckboxProps) *Checkbox {
 		cb.label = label
 	}
 
+	onUnmount(cb.container, cb.Unmount)
 	return cb
 }
 
@@ -85,6 +87,17 @@ func (c *Checkbox) Element() js.Value {
 	return c.container
 }
 
+// Mount appends the checkbox to parent
+func (c *Checkbox) Mount(parent js.Value) {
+	parent.Call("appendChild", c.container)
+}
+
+// Unmount removes the checkbox and releases its listeners
+func (c *Checkbox) Unmount() {
+	unmount(c.container)
+	c.listeners.release()
+}
+
 // Checked returns whether the checkbox is checked
 func (c *Checkbox) Checked() bool {
 	return c.input.Get("checked").Bool()
@@ -0,0 +1,167 @@
+//go:build js && wasm
+
+package components
+
+import "strings"
+
+// ClassProvider supplies the CSS classes of components' parts, by key such
+// as "button", "button.primary", or "modal.header". See DefaultClasses for
+// the keys, and SetClassProvider for the components that use them.
+type ClassProvider interface {
+	// Class returns the classes for key, and false if the provider has
+	// none, so the default Tailwind classes are used
+	Class(key string) (string, bool)
+}
+
+// ClassMap is a ClassProvider from a map. An empty string leaves the part
+// unstyled.
+type ClassMap map[string]string
+
+// Class implements ClassProvider
+func (m ClassMap) Class(key string) (string, bool) {
+	c, ok := m[key]
+	return c, ok
+}
+
+// headlessClasses gives every part a "gux-" class named after its key and
+// nothing else
+type headlessClasses struct{}
+
+func (headlessClasses) Class(key string) (string, bool) {
+	return "gux-" + strings.ReplaceAll(key, ".", "-"), true
+}
+
+// HeadlessClasses leaves the components that use the class provider
+// unstyled, with one stable class per part for your own CSS: "gux-button",
+// "gux-button-primary", "gux-modal-header", and so on. Other components
+// keep their Tailwind classes.
+var HeadlessClasses ClassProvider = headlessClasses{}
+
+var classProvider ClassProvider
+
+// SetClassProvider replaces the classes components are built with, such as
+// Bootstrap's or a design system's. Button, Badge, Card, Input, TextArea,
+// Select, Modal, Alert, Form, FormBuilder's buttons, ServiceBanner's
+// colors, and EmbeddedApp's frame use it; other components have their own
+// Tailwind classes, which ClassName props replace. Keys p has no classes
+// for keep the default Tailwind ones; nil restores them all. Set it before
+// building the UI, as components read their classes when created.
+//
+//	classes := components.ClassMap{
+//		"button":         "btn",
+//		"button.primary": "btn-primary",
+//		"button.md":      "",
+//		"input":          "form-control",
+//	}
+//	components.SetClassProvider(classes)
+func SetClassProvider(p ClassProvider) {
+	classProvider = p
+}
+
+// DefaultClasses returns a copy of the default Tailwind classes, by key,
+// to change some and pass to SetClassProvider
+func DefaultClasses() ClassMap {
+	m := make(ClassMap, len(defaultClasses))
+	for key, c := range defaultClasses {
+		m[key] = c
+	}
+	return m
+}
+
+// Classes returns the classes of the parts named by keys, joined, from the
+// class provider. Components of your own can use it to follow the app's
+// classes.
+func Classes(keys ...string) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		c, ok := "", false
+		if classProvider != nil {
+			c, ok = classProvider.Class(key)
+		}
+		if !ok {
+			c = defaultClasses[key]
+		}
+		if c != "" {
+			parts = append(parts, c)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// hasClass reports whether the class provider or the defaults know key,
+// such as a variant a component checks before falling back to its default
+func hasClass(key string) bool {
+	if classProvider != nil {
+		if _, ok := classProvider.Class(key); ok {
+			return true
+		}
+	}
+	_, ok := defaultClasses[key]
+	return ok
+}
+
+// defaultClasses are the Tailwind classes of each component part. Sizes
+// and variants add to their component's base key: a primary medium button
+// is "button", "button.primary", and "button.md".
+var defaultClasses = ClassMap{
+	"button":           tokenRadius("button", DefaultRadius) + " cursor-pointer transition-colors",
+	"button.primary":   "bg-blue-600 text-white hover:bg-blue-700",
+	"button.secondary": "bg-gray-200 dark:bg-gray-700 text-gray-800 dark:text-gray-200 hover:bg-gray-300 dark:hover:bg-gray-600",
+	"button.success":   "bg-green-700 text-white hover:bg-green-800",
+	"button.warning":   "bg-yellow-500 text-gray-900 hover:bg-yellow-600",
+	"button.danger":    "bg-red-600 text-white hover:bg-red-700",
+	"button.info":      "bg-cyan-600 text-white hover:bg-cyan-700",
+	"button.ghost":     "bg-transparent text-gray-700 dark:text-gray-300 hover:bg-gray-100 dark:hover:bg-gray-700",
+	"button.sm":        tokenPadding("button", 0.5, 0.25) + " text-sm",
+	"button.md":        tokenPadding("button", 1, 0.5),
+	"button.lg":        tokenPadding("button", 1.5, 0.75) + " text-lg",
+
+	"badge":         "inline-flex items-center " + tokenPadding("badge", 0.625, 0.125) + " text-xs font-medium",
+	"badge.rect":    tokenRadius("badge", DefaultRadius),
+	"badge.pill":    "rounded-full",
+	"badge.default": "surface-overlay text-primary",
+	"badge.primary": "bg-blue-100 dark:bg-blue-900 text-blue-800 dark:text-blue-200",
+	"badge.success": "bg-green-100 dark:bg-green-900 text-green-800 dark:text-green-200",
+	"badge.warning": "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
+	"badge.error":   "bg-red-100 dark:bg-red-900 text-red-800 dark:text-red-200",
+	"badge.info":    "bg-cyan-100 dark:bg-cyan-900 text-cyan-800 dark:text-cyan-200",
+
+	"card": "bg-white dark:bg-gray-800 " + tokenRadius("card", DefaultRadiusLarge) + " shadow dark:shadow-gray-900 " + tokenPadding("card", 1.5, 1.5),
+
+	// Inputs, text areas, and selects share the field parts
+	"field":          "mb-4",
+	"field.label":    "block text-sm font-medium text-secondary mb-1",
+	"field.error":    "text-red-500 text-sm mt-1",
+	"input":          "w-full " + inputShapeClasses + " border border-default surface-base text-primary shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 placeholder:text-tertiary",
+	"input.error":    "w-full " + inputShapeClasses + " border border-red-500 surface-base text-primary shadow-sm focus:outline-none focus:ring-2 focus:ring-red-500 focus:border-red-500",
+	"input.disabled": "surface-overlay cursor-not-allowed",
+	"textarea":       "w-full " + inputShapeClasses + " border border-default surface-base text-primary shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 resize-y placeholder:text-tertiary",
+	"select":         "w-full " + inputShapeClasses + " border border-default shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 surface-base text-primary",
+
+	"modal.overlay": "fixed inset-0 bg-black bg-opacity-50 flex items-center justify-center z-50",
+	"modal.dialog":  "surface-base " + tokenRadius("modal", DefaultRadiusLarge) + " shadow-xl w-full mx-4 max-h-[90vh] flex flex-col",
+	"modal.sm":      "max-w-sm",
+	"modal.md":      "max-w-md",
+	"modal.lg":      "max-w-lg",
+	"modal.xl":      "max-w-xl",
+	"modal.full":    "max-w-4xl",
+	"modal.header":  "flex justify-between items-center " + tokenPadding("modal", 1.5, 1) + " border-b border-subtle",
+	"modal.title":   "text-lg font-semibold text-primary",
+	"modal.close":   "text-secondary hover:text-primary text-2xl leading-none cursor-pointer",
+	"modal.body":    tokenPadding("modal", 1.5, 1) + " overflow-y-auto flex-1",
+	"modal.footer":  tokenPadding("modal", 1.5, 1) + " border-t border-subtle surface-raised rounded-b-[var(--modal-radius," + DefaultRadiusLarge + ")]",
+
+	"alert":         "border rounded-lg p-4 mb-4",
+	"alert.info":    "bg-blue-50 dark:bg-blue-900/30 border-blue-200 dark:border-blue-700 text-blue-800 dark:text-blue-300",
+	"alert.success": "bg-green-50 dark:bg-green-900/30 border-green-200 dark:border-green-700 text-green-800 dark:text-green-300",
+	"alert.warning": "bg-yellow-50 dark:bg-yellow-900/30 border-yellow-200 dark:border-yellow-700 text-yellow-800 dark:text-yellow-300",
+	"alert.error":   "bg-red-50 dark:bg-red-900/30 border-red-200 dark:border-red-700 text-red-800 dark:text-red-300",
+	"alert.content": "flex items-start",
+	"alert.icon":    "mr-3 text-lg",
+	"alert.text":    "flex-1",
+	"alert.title":   "font-semibold mb-1",
+	"alert.message": "text-sm",
+	"alert.dismiss": "ml-4 text-lg opacity-50 hover:opacity-100 cursor-pointer",
+
+	"embed": "block w-full border-0",
+}
@@ -74,7 +74,20 @@ func CopyButton(props CopyButtonProps) js.Value {
 	labelSpan.Set("textContent", label)
 	btn.Call("appendChild", labelSpan)
 
-	btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	var funcs listeners
+	var resetTimer js.Value
+	reset := funcs.fn(func(this js.Value, args []js.Value) any {
+		labelSpan.Set("textContent", label)
+		btn.Get("classList").Call("remove", "bg-green-100", "text-green-700")
+		btn.Get("classList").Call("add", "bg-gray-100", "text-gray-700")
+		return nil
+	})
+	funcs.onRelease(func() {
+		if resetTimer.Truthy() {
+			js.Global().Call("clearTimeout", resetTimer)
+		}
+	})
+	btn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 		if CopyToClipboard(props.Text) {
 			// Update label temporarily
 			labelSpan.Set("textContent", copiedLabel)
@@ -92,15 +105,14 @@ func CopyButton(props CopyButtonProps) js.Value {
 			}
 
 			// Reset after 2 seconds
-			js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-				labelSpan.Set("textContent", label)
-				btn.Get("classList").Call("remove", "bg-green-100", "text-green-700")
-				btn.Get("classList").Call("add", "bg-gray-100", "text-gray-700")
-				return nil
-			}), 2000)
+			if resetTimer.Truthy() {
+				js.Global().Call("clearTimeout", resetTimer)
+			}
+			resetTimer = js.Global().Call("setTimeout", reset, 2000)
 		}
 		return nil
 	}))
+	onUnmount(btn, funcs.release)
 
 	return btn
 }
@@ -3,9 +3,13 @@
 package components
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
 // ComboboxOption represents an option in a combobox
@@ -24,26 +28,56 @@ type ComboboxProps struct {
 	Value        string
 	Disabled     bool
 	Required     bool
-	AllowCustom  bool // Allow typing custom values not in options
+	AllowCustom  bool // Allow typing custom values not in options, offered as "Create …"
 	OnChange     func(value string)
-	OnSearch     func(query string) // For async search
-	EmptyMessage string             // Message when no results
+	EmptyMessage string // Message when no results
+
+	// Multiple selects several values, shown as removable chips before
+	// the input. Values are the initially selected ones, and OnChangeValues
+	// is called with all of them after each change.
+	Multiple       bool
+	Values         []string
+	OnChangeValues func(values []string)
+
+	// OnSearch loads the options matching query, e.g. from the server,
+	// instead of filtering Options. It runs in a goroutine SearchDebounce
+	// (default 250ms) after typing stops; ctx is canceled when a newer
+	// query starts.
+	OnSearch       func(ctx context.Context, query string) ([]ComboboxOption, error)
+	SearchDebounce time.Duration
+
+	// OnCreate creates an option from text that matches none, offered as
+	// "Create …" at the end of the list, e.g. a tag saved on the server.
+	// It runs in a goroutine; an error is shown in a toast. With
+	// AllowCustom and no OnCreate, the text is the new option's label and
+	// value.
+	OnCreate func(text string) (ComboboxOption, error)
 }
 
 // Combobox creates an autocomplete/combobox component
 type Combobox struct {
-	container     js.Value
-	input         js.Value
-	dropdown      js.Value
-	options       []ComboboxOption
-	filteredOpts  []ComboboxOption
-	value         string
-	isOpen        bool
-	highlightIdx  int
-	props         ComboboxProps
-	cleanup       js.Func
-	listboxID     string   // unique ID for listbox
-	baseOptionID  string   // base ID for generating option IDs
+	container    js.Value
+	input        js.Value
+	dropdown     js.Value
+	chips        js.Value // selected values in Multiple mode
+	options      []ComboboxOption
+	filteredOpts []ComboboxOption
+	selected     []ComboboxOption // in Multiple mode
+	value        string
+	isOpen       bool
+	highlightIdx int
+	createText   string // typed text offered as a new option, if any
+	loading      bool   // OnSearch is running
+	searchErr    error
+	searchTimer  js.Value
+	searchFunc   js.Func
+	searchCancel context.CancelFunc
+	props        ComboboxProps
+	listeners    listeners
+	optionFuncs  listeners // of the rendered options
+	chipFuncs    listeners // of the rendered chips
+	listboxID    string    // unique ID for listbox
+	baseOptionID string    // base ID for generating option IDs
 }
 
 // NewCombobox creates a new Combobox component
@@ -51,7 +85,10 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	document := js.Global().Get("document")
 
 	if props.EmptyMessage == "" {
-		props.EmptyMessage = "No results found"
+		props.EmptyMessage = i18n.T("gux.combobox.empty")
+	}
+	if props.SearchDebounce <= 0 {
+		props.SearchDebounce = 250 * time.Millisecond
 	}
 
 	// Generate unique IDs for ARIA relationships
@@ -108,7 +145,7 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	}
 
 	// Set initial value
-	if props.Value != "" {
+	if props.Value != "" && !props.Multiple {
 		for _, opt := range props.Options {
 			if opt.Value == props.Value {
 				input.Set("value", opt.Label)
@@ -118,7 +155,31 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	}
 
 	c.input = input
-	inputWrap.Call("appendChild", input)
+	if props.Multiple {
+		// Chips and the input share one bordered box
+		box := document.Call("createElement", "div")
+		box.Set("className", "flex flex-wrap items-center gap-1 w-full px-2 py-1 pr-10 border border-gray-300 rounded-md shadow-sm focus-within:ring-2 focus-within:ring-blue-500 focus-within:border-blue-500")
+		if props.Disabled {
+			box.Get("classList").Call("add", "bg-gray-100", "cursor-not-allowed")
+		}
+		c.chips = document.Call("createElement", "div")
+		c.chips.Set("className", "contents")
+		box.Call("appendChild", c.chips)
+		input.Set("className", "flex-1 min-w-[6rem] py-1 border-0 bg-transparent focus:outline-none focus:ring-0")
+		box.Call("appendChild", input)
+		box.Call("addEventListener", "click", c.listeners.fn(func(this js.Value, args []js.Value) any {
+			input.Call("focus")
+			return nil
+		}))
+		inputWrap.Call("appendChild", box)
+
+		for _, value := range props.Values {
+			c.selected = append(c.selected, c.optionFor(value))
+		}
+		c.renderChips()
+	} else {
+		inputWrap.Call("appendChild", input)
+	}
 
 	// Dropdown arrow
 	arrow := document.Call("createElement", "div")
@@ -135,7 +196,10 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	// ARIA listbox attributes
 	dropdown.Call("setAttribute", "role", "listbox")
 	dropdown.Set("id", listboxID)
-	dropdown.Call("setAttribute", "aria-label", "Options")
+	dropdown.Call("setAttribute", "aria-label", i18n.T("gux.combobox.options"))
+	if props.Multiple {
+		dropdown.Call("setAttribute", "aria-multiselectable", "true")
+	}
 
 	c.dropdown = dropdown
 	container.Call("appendChild", dropdown)
@@ -143,23 +207,30 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	c.container = container
 	c.renderOptions()
 
+	c.searchFunc = c.listeners.fn(func(this js.Value, args []js.Value) any {
+		c.searchTimer = js.Undefined()
+		c.runSearch(input.Get("value").String())
+		return nil
+	})
+
 	// Input events
-	input.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "input", c.listeners.fn(func(this js.Value, args []js.Value) any {
 		query := input.Get("value").String()
 		c.filter(query)
 		c.Open()
-		if props.OnSearch != nil {
-			props.OnSearch(query)
-		}
 		return nil
 	}))
 
-	input.Call("addEventListener", "focus", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "focus", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		// Load the first options for an empty query
+		if props.OnSearch != nil && len(c.options) == 0 && !c.loading {
+			c.runSearch(input.Get("value").String())
+		}
 		c.Open()
 		return nil
 	}))
 
-	input.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "keydown", c.listeners.fn(func(this js.Value, args []js.Value) any {
 		key := args[0].Get("key").String()
 		switch key {
 		case "ArrowDown":
@@ -170,15 +241,27 @@ func NewCombobox(props ComboboxProps) *Combobox {
 			c.highlightPrev()
 		case "Enter":
 			args[0].Call("preventDefault")
-			if c.highlightIdx >= 0 && c.highlightIdx < len(c.filteredOpts) {
+			switch {
+			case c.highlightIdx >= 0 && c.highlightIdx < len(c.filteredOpts):
 				c.selectOption(c.filteredOpts[c.highlightIdx])
-			} else if props.AllowCustom {
+			case c.highlightIdx == len(c.filteredOpts) && c.createText != "":
+				c.create(c.createText)
+			case props.Multiple && c.createText != "":
+				c.create(c.createText)
+			case props.AllowCustom && !props.Multiple:
 				c.value = input.Get("value").String()
 				if props.OnChange != nil {
 					props.OnChange(c.value)
 				}
 			}
-			c.Close()
+			if !props.Multiple {
+				c.Close()
+			}
+		case "Backspace":
+			// Remove the last chip from an empty input
+			if props.Multiple && input.Get("value").String() == "" && len(c.selected) > 0 {
+				c.removeValue(c.selected[len(c.selected)-1].Value)
+			}
 		case "Escape":
 			c.Close()
 		}
@@ -186,7 +269,7 @@ func NewCombobox(props ComboboxProps) *Combobox {
 	}))
 
 	// Close on outside click
-	c.cleanup = js.FuncOf(func(this js.Value, args []js.Value) any {
+	c.listeners.on(document, "click", func(this js.Value, args []js.Value) any {
 		if c.isOpen {
 			target := args[0].Get("target")
 			if !container.Call("contains", target).Bool() {
@@ -195,16 +278,33 @@ func NewCombobox(props ComboboxProps) *Combobox {
 		}
 		return nil
 	})
-	document.Call("addEventListener", "click", c.cleanup)
+	onUnmount(container, c.Unmount)
 
 	return c
 }
 
 func (c *Combobox) renderOptions() {
 	document := js.Global().Get("document")
+	c.optionFuncs.release()
 	c.dropdown.Set("innerHTML", "")
 
-	if len(c.filteredOpts) == 0 {
+	if c.loading || c.searchErr != nil {
+		status := document.Call("createElement", "div")
+		status.Set("className", "px-3 py-2 text-sm text-gray-500")
+		status.Call("setAttribute", "role", "status")
+		if c.searchErr != nil {
+			status.Set("className", "px-3 py-2 text-sm text-red-600")
+			status.Set("textContent", i18n.T("gux.combobox.error"))
+			status.Set("title", c.searchErr.Error())
+		} else {
+			status.Set("textContent", i18n.T("gux.combobox.loading"))
+		}
+		c.dropdown.Call("appendChild", status)
+		c.input.Call("removeAttribute", "aria-activedescendant")
+		return
+	}
+
+	if len(c.filteredOpts) == 0 && c.createText == "" {
 		empty := document.Call("createElement", "div")
 		empty.Set("className", "px-3 py-2 text-sm text-gray-500")
 		empty.Set("textContent", c.props.EmptyMessage)
@@ -231,9 +331,14 @@ func (c *Combobox) renderOptions() {
 		item.Call("setAttribute", "role", "option")
 		item.Set("id", optionID)
 		if i == c.highlightIdx {
-			item.Call("setAttribute", "aria-selected", "true")
 			// Update aria-activedescendant on input
 			c.input.Call("setAttribute", "aria-activedescendant", optionID)
+		}
+		if c.props.Multiple {
+			// Selection, not the highlight, in a multi-select listbox
+			item.Call("setAttribute", "aria-selected", strconv.FormatBool(c.isSelected(opt.Value)))
+		} else if i == c.highlightIdx {
+			item.Call("setAttribute", "aria-selected", "true")
 		} else {
 			item.Call("setAttribute", "aria-selected", "false")
 		}
@@ -241,6 +346,14 @@ func (c *Combobox) renderOptions() {
 		label := document.Call("createElement", "div")
 		label.Set("className", "text-sm font-medium")
 		label.Set("textContent", opt.Label)
+		if c.props.Multiple && c.isSelected(opt.Value) {
+			label.Set("className", "text-sm font-medium flex items-center justify-between gap-2")
+			check := document.Call("createElement", "span")
+			check.Set("className", "text-blue-600")
+			check.Call("setAttribute", "aria-hidden", "true")
+			check.Set("textContent", "✓")
+			label.Call("appendChild", check)
+		}
 		item.Call("appendChild", label)
 
 		if opt.Description != "" {
@@ -252,9 +365,11 @@ func (c *Combobox) renderOptions() {
 
 		if !opt.Disabled {
 			option := opt
-			item.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			item.Call("addEventListener", "click", c.optionFuncs.fn(func(this js.Value, args []js.Value) any {
 				c.selectOption(option)
-				c.Close()
+				if !c.props.Multiple {
+					c.Close()
+				}
 				return nil
 			}))
 		}
@@ -262,17 +377,86 @@ func (c *Combobox) renderOptions() {
 		c.dropdown.Call("appendChild", item)
 	}
 
+	// "Create …" for text that matches no option
+	if c.createText != "" {
+		i := len(c.filteredOpts)
+		item := document.Call("createElement", "div")
+		if i == c.highlightIdx {
+			item.Set("className", "px-3 py-2 cursor-pointer text-sm text-blue-700 bg-blue-50")
+		} else {
+			item.Set("className", "px-3 py-2 cursor-pointer text-sm text-blue-700 hover:bg-gray-100")
+		}
+		optionID := c.baseOptionID + "-" + strconv.Itoa(i)
+		item.Call("setAttribute", "role", "option")
+		item.Set("id", optionID)
+		item.Call("setAttribute", "aria-selected", strconv.FormatBool(!c.props.Multiple && i == c.highlightIdx))
+		if i == c.highlightIdx {
+			c.input.Call("setAttribute", "aria-activedescendant", optionID)
+		}
+		item.Set("textContent", i18n.T("gux.combobox.create", c.createText))
+		text := c.createText
+		item.Call("addEventListener", "click", c.optionFuncs.fn(func(this js.Value, args []js.Value) any {
+			c.create(text)
+			if !c.props.Multiple {
+				c.Close()
+			}
+			return nil
+		}))
+		c.dropdown.Call("appendChild", item)
+	}
+
 	// Clear aria-activedescendant if nothing is highlighted
 	if c.highlightIdx < 0 {
 		c.input.Call("removeAttribute", "aria-activedescendant")
 	}
 }
 
+// renderChips shows the selected values in Multiple mode, each with a
+// button that removes it
+func (c *Combobox) renderChips() {
+	document := js.Global().Get("document")
+	c.chipFuncs.release()
+	c.chips.Set("innerHTML", "")
+
+	for _, opt := range c.selected {
+		chip := document.Call("createElement", "span")
+		chip.Set("className", "inline-flex items-center gap-1 pl-2 pr-1 py-0.5 rounded-full bg-blue-100 text-blue-800 text-sm")
+		text := document.Call("createElement", "span")
+		text.Set("textContent", opt.Label)
+		chip.Call("appendChild", text)
+
+		if !c.props.Disabled {
+			value := opt.Value
+			remove := document.Call("createElement", "button")
+			remove.Set("type", "button")
+			remove.Set("className", "px-1 rounded-full hover:bg-blue-200 focus:outline-none focus:ring-2 focus:ring-blue-500")
+			remove.Call("setAttribute", "aria-label", i18n.T("gux.combobox.remove", opt.Label))
+			remove.Set("innerHTML", "&times;")
+			remove.Call("addEventListener", "click", c.chipFuncs.fn(func(this js.Value, args []js.Value) any {
+				args[0].Call("stopPropagation")
+				c.removeValue(value)
+				c.input.Call("focus")
+				return nil
+			}))
+			chip.Call("appendChild", remove)
+		}
+		c.chips.Call("appendChild", chip)
+	}
+}
+
 func (c *Combobox) filter(query string) {
-	query = strings.ToLower(query)
-	c.filteredOpts = nil
 	c.highlightIdx = -1
+	c.updateCreate(query)
+
+	// Options come from OnSearch once typing stops
+	if c.props.OnSearch != nil {
+		c.scheduleSearch()
+		c.renderOptions()
+		return
+	}
 
+	query = strings.ToLower(query)
+	c.filteredOpts = nil
 	for _, opt := range c.options {
 		if strings.Contains(strings.ToLower(opt.Label), query) ||
 			strings.Contains(strings.ToLower(opt.Value), query) ||
@@ -284,7 +468,110 @@ func (c *Combobox) filter(query string) {
 	c.renderOptions()
 }
 
+// updateCreate offers query as a new option when creating is allowed and
+// no option has it as its label
+func (c *Combobox) updateCreate(query string) {
+	c.createText = ""
+	query = strings.TrimSpace(query)
+	if query == "" || (!c.props.AllowCustom && c.props.OnCreate == nil) {
+		return
+	}
+	for _, opt := range c.options {
+		if strings.EqualFold(opt.Label, query) {
+			return
+		}
+	}
+	for _, opt := range c.selected {
+		if strings.EqualFold(opt.Label, query) {
+			return
+		}
+	}
+	c.createText = query
+}
+
+func (c *Combobox) cancelSearch() {
+	if !c.searchTimer.IsUndefined() && !c.searchTimer.IsNull() {
+		js.Global().Call("clearTimeout", c.searchTimer)
+		c.searchTimer = js.Undefined()
+	}
+	if c.searchCancel != nil {
+		c.searchCancel()
+		c.searchCancel = nil
+	}
+}
+
+// scheduleSearch runs OnSearch once typing has stopped for SearchDebounce
+func (c *Combobox) scheduleSearch() {
+	c.cancelSearch()
+	c.searchTimer = js.Global().Call("setTimeout", c.searchFunc, c.props.SearchDebounce.Milliseconds())
+}
+
+// runSearch loads the options for query with OnSearch, dropping the
+// results of earlier queries
+func (c *Combobox) runSearch(query string) {
+	c.cancelSearch()
+	ctx, cancel := context.WithCancel(context.Background())
+	c.searchCancel = cancel
+	c.loading, c.searchErr = true, nil
+	c.renderOptions()
+
+	go func() {
+		options, err := c.props.OnSearch(ctx, query)
+		if ctx.Err() != nil {
+			return // a newer query started, or the combobox was destroyed
+		}
+		cancel()
+		c.searchCancel = nil
+		c.loading, c.searchErr = false, err
+		if err == nil {
+			c.options, c.filteredOpts = options, options
+		}
+		c.highlightIdx = -1
+		c.updateCreate(c.input.Get("value").String())
+		c.renderOptions()
+	}()
+}
+
+// create selects a new option made from text, with OnCreate if set
+func (c *Combobox) create(text string) {
+	add := func(opt ComboboxOption) {
+		c.options = append(c.options, opt)
+		if c.props.OnSearch == nil {
+			c.filteredOpts = c.options
+		}
+		c.selectOption(opt)
+	}
+	if c.props.OnCreate == nil {
+		add(ComboboxOption{Label: text, Value: text})
+		return
+	}
+
+	c.createText = ""
+	c.renderOptions()
+	go func() {
+		opt, err := c.props.OnCreate(text)
+		if err != nil {
+			ShowError(err.Error())
+			return
+		}
+		add(opt)
+	}()
+}
+
 func (c *Combobox) selectOption(opt ComboboxOption) {
+	if c.props.Multiple {
+		if c.isSelected(opt.Value) {
+			c.removeValue(opt.Value)
+		} else {
+			c.selected = append(c.selected, opt)
+			c.renderChips()
+			c.notifyValues()
+		}
+		c.input.Set("value", "")
+		c.filter("")
+		return
+	}
+
 	c.value = opt.Value
 	c.input.Set("value", opt.Label)
 	if c.props.OnChange != nil {
@@ -292,12 +579,69 @@ func (c *Combobox) selectOption(opt ComboboxOption) {
 	}
 }
 
+// removeValue deselects value in Multiple mode
+func (c *Combobox) removeValue(value string) {
+	for i, opt := range c.selected {
+		if opt.Value == value {
+			c.selected = append(c.selected[:i], c.selected[i+1:]...)
+			c.renderChips()
+			c.renderOptions()
+			c.notifyValues()
+			return
+		}
+	}
+}
+
+func (c *Combobox) notifyValues() {
+	if c.props.OnChangeValues != nil {
+		c.props.OnChangeValues(c.Values())
+	}
+}
+
+func (c *Combobox) isSelected(value string) bool {
+	for _, opt := range c.selected {
+		if opt.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// optionFor returns the option with value, or one labeled with the value
+// itself if there is none, e.g. before async options have loaded
+func (c *Combobox) optionFor(value string) ComboboxOption {
+	for _, opt := range c.options {
+		if opt.Value == value {
+			return opt
+		}
+	}
+	for _, opt := range c.selected {
+		if opt.Value == value {
+			return opt
+		}
+	}
+	return ComboboxOption{Label: value, Value: value}
+}
+
+// itemCount is the number of rows that can be highlighted
+func (c *Combobox) itemCount() int {
+	if c.loading || c.searchErr != nil {
+		return 0
+	}
+	n := len(c.filteredOpts)
+	if c.createText != "" {
+		n++
+	}
+	return n
+}
+
 func (c *Combobox) highlightNext() {
-	if len(c.filteredOpts) == 0 {
+	n := c.itemCount()
+	if n == 0 {
 		return
 	}
 	c.highlightIdx++
-	if c.highlightIdx >= len(c.filteredOpts) {
+	if c.highlightIdx >= n {
 		c.highlightIdx = 0
 	}
 	c.renderOptions()
@@ -305,12 +649,13 @@ func (c *Combobox) highlightNext() {
 }
 
 func (c *Combobox) highlightPrev() {
-	if len(c.filteredOpts) == 0 {
+	n := c.itemCount()
+	if n == 0 {
 		return
 	}
 	c.highlightIdx--
 	if c.highlightIdx < 0 {
-		c.highlightIdx = len(c.filteredOpts) - 1
+		c.highlightIdx = n - 1
 	}
 	c.renderOptions()
 	c.scrollToHighlighted()
@@ -331,6 +676,17 @@ func (c *Combobox) Element() js.Value {
 	return c.container
 }
 
+// Mount appends the combobox to parent
+func (c *Combobox) Mount(parent js.Value) {
+	parent.Call("appendChild", c.container)
+}
+
+// Unmount removes the combobox and releases its listeners
+func (c *Combobox) Unmount() {
+	c.Destroy()
+	unmount(c.container)
+}
+
 // Open opens the dropdown
 func (c *Combobox) Open() {
 	c.dropdown.Get("classList").Call("remove", "hidden")
@@ -366,6 +722,28 @@ func (c *Combobox) SetValue(value string) {
 	}
 }
 
+// Values returns the selected values in Multiple mode, in the order they
+// were selected
+func (c *Combobox) Values() []string {
+	values := make([]string, len(c.selected))
+	for i, opt := range c.selected {
+		values[i] = opt.Value
+	}
+	return values
+}
+
+// SetValues replaces the selected values in Multiple mode, without calling
+// OnChangeValues
+func (c *Combobox) SetValues(values []string) {
+	selected := make([]ComboboxOption, len(values))
+	for i, value := range values {
+		selected[i] = c.optionFor(value)
+	}
+	c.selected = selected
+	c.renderChips()
+	c.renderOptions()
+}
+
 // SetOptions updates the available options
 func (c *Combobox) SetOptions(options []ComboboxOption) {
 	c.options = options
@@ -375,8 +753,10 @@ func (c *Combobox) SetOptions(options []ComboboxOption) {
 
 // Destroy cleans up event listeners
 func (c *Combobox) Destroy() {
-	js.Global().Get("document").Call("removeEventListener", "click", c.cleanup)
-	c.cleanup.Release()
+	c.cancelSearch()
+	c.listeners.release()
+	c.optionFuncs.release()
+	c.chipFuncs.release()
 }
 
 // SimpleCombobox creates a combobox with string options
@@ -5,6 +5,8 @@ package components
 import (
 	"strings"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
 // Command represents a command in the palette
@@ -31,6 +33,7 @@ type CommandPalette struct {
 	overlay          js.Value
 	container        js.Value
 	input            js.Value
+	search           *SearchField
 	resultsList      js.Value
 	commands         []Command
 	filteredCommands []Command
@@ -39,9 +42,11 @@ type CommandPalette struct {
 	highlightIdx     int
 	props            CommandPaletteProps
 	focusTrap        *FocusTrap
-	keyboardListener js.Func
+	shortcut         *ShortcutBinding
 	listboxID        string // ARIA: unique ID for listbox
 	optionIDs        []string // ARIA: generated IDs for each option
+	listeners        listeners
+	itemFuncs        listeners // of the rendered commands
 }
 
 // NewCommandPalette creates a new CommandPalette component
@@ -83,19 +88,25 @@ func NewCommandPalette(props CommandPaletteProps) *CommandPalette {
 	inputContainer := document.Call("createElement", "div")
 	inputContainer.Set("className", "px-4 py-3 border-b border-gray-200 dark:border-gray-700")
 
-	// Search input with ARIA combobox attributes
-	input := document.Call("createElement", "input")
-	input.Set("type", "text")
-	input.Set("className", "w-full bg-transparent text-gray-900 dark:text-gray-100 placeholder-gray-400 dark:placeholder-gray-500 focus:outline-none text-base")
-	input.Set("placeholder", props.Placeholder)
-	input.Set("autocomplete", "off")
+	// Search input with ARIA combobox attributes; filters on every keystroke
+	cp.search = NewSearchField(SearchFieldProps{
+		Placeholder: props.Placeholder,
+		AriaLabel:   "Search commands",
+		Borderless:  true,
+		InputClass:  "w-full pl-9 pr-8 bg-transparent text-gray-900 dark:text-gray-100 placeholder-gray-400 dark:placeholder-gray-500 focus:outline-none text-base",
+		OnSearch: func(query SearchQuery) {
+			cp.query = query.Text
+			cp.filter()
+		},
+	})
+	input := cp.search.InputElement()
 	input.Call("setAttribute", "role", "combobox")
 	input.Call("setAttribute", "aria-autocomplete", "list")
 	input.Call("setAttribute", "aria-controls", listboxID)
 	input.Call("setAttribute", "aria-expanded", "true")
 	cp.input = input
 
-	inputContainer.Call("appendChild", input)
+	inputContainer.Call("appendChild", cp.search.Element())
 	container.Call("appendChild", inputContainer)
 
 	// Results list with listbox role
@@ -125,14 +136,8 @@ func NewCommandPalette(props CommandPaletteProps) *CommandPalette {
 	// Render initial commands
 	cp.renderCommands()
 
-	// Input event handlers
-	input.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
-		cp.query = input.Get("value").String()
-		cp.filter()
-		return nil
-	}))
-
-	input.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+	// Navigation keys (Escape only reaches here once the search is empty)
+	input.Call("addEventListener", "keydown", cp.listeners.fn(func(this js.Value, args []js.Value) any {
 		event := args[0]
 		key := event.Get("key").String()
 
@@ -154,19 +159,21 @@ func NewCommandPalette(props CommandPaletteProps) *CommandPalette {
 	}))
 
 	// Close on overlay click (not container)
-	overlay.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	overlay.Call("addEventListener", "click", cp.listeners.fn(func(this js.Value, args []js.Value) any {
 		if args[0].Get("target").Equal(overlay) {
 			cp.Close()
 		}
 		return nil
 	}))
 
+	onUnmount(overlay, cp.Unmount)
 	return cp
 }
 
 func (cp *CommandPalette) renderCommands() {
 	document := js.Global().Get("document")
 	cp.resultsList.Set("innerHTML", "")
+	cp.itemFuncs.release()
 
 	// Reset option IDs
 	cp.optionIDs = nil
@@ -283,14 +290,14 @@ func (cp *CommandPalette) renderCommandItem(cmd Command, index int) js.Value {
 
 	// Click handler
 	command := cmd
-	item.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	item.Call("addEventListener", "click", cp.itemFuncs.fn(func(this js.Value, args []js.Value) any {
 		cp.executeCommand(command)
 		return nil
 	}))
 
 	// Hover handler to update highlight visually without re-rendering
 	idx := index
-	item.Call("addEventListener", "mouseenter", js.FuncOf(func(this js.Value, args []js.Value) any {
+	item.Call("addEventListener", "mouseenter", cp.itemFuncs.fn(func(this js.Value, args []js.Value) any {
 		cp.highlightIdx = idx
 		cp.updateHighlightStyles()
 		return nil
@@ -406,6 +413,18 @@ func (cp *CommandPalette) Element() js.Value {
 	return cp.overlay
 }
 
+// Mount appends the command palette's overlay to parent
+func (cp *CommandPalette) Mount(parent js.Value) {
+	parent.Call("appendChild", cp.overlay)
+}
+
+// Unmount removes the command palette and releases its listeners and
+// keyboard shortcut
+func (cp *CommandPalette) Unmount() {
+	cp.Destroy()
+	unmount(cp.overlay)
+}
+
 // Open shows the command palette
 func (cp *CommandPalette) Open() {
 	if cp.isOpen {
@@ -414,7 +433,7 @@ func (cp *CommandPalette) Open() {
 
 	cp.isOpen = true
 	cp.query = ""
-	cp.input.Set("value", "")
+	cp.search.SetValue("")
 	cp.filteredCommands = cp.commands
 	cp.highlightIdx = 0 // Pre-highlight first item
 	cp.renderCommands()
@@ -461,35 +480,36 @@ func (cp *CommandPalette) SetCommands(commands []Command) {
 	cp.renderCommands()
 }
 
-// RegisterKeyboardShortcut registers global Cmd+K / Ctrl+K listener
+// RegisterKeyboardShortcut registers Cmd+K / Ctrl+K with the shared
+// ShortcutManager to open and close the palette
 func (cp *CommandPalette) RegisterKeyboardShortcut() {
-	cp.keyboardListener = js.FuncOf(func(this js.Value, args []js.Value) any {
-		event := args[0]
-		key := strings.ToLower(event.Get("key").String())
-
-		// Check for Cmd+K (Mac) or Ctrl+K (Windows/Linux)
-		metaKey := event.Get("metaKey").Bool()
-		ctrlKey := event.Get("ctrlKey").Bool()
-
-		if key == "k" && (metaKey || ctrlKey) {
-			event.Call("preventDefault")
+	if cp.shortcut != nil {
+		return
+	}
+	b, err := GetShortcutManager().Register(Shortcut{
+		Keys:          "mod+k",
+		Description:   i18n.T("gux.shortcuts.commandPalette"),
+		AllowInInputs: true,
+		Handler: func() {
 			if cp.isOpen {
 				cp.Close()
 			} else {
 				cp.Open()
 			}
-		}
-		return nil
+		},
 	})
-
-	js.Global().Get("document").Call("addEventListener", "keydown", cp.keyboardListener)
+	if err != nil {
+		js.Global().Get("console").Call("warn", "command palette:", err.Error())
+		return
+	}
+	cp.shortcut = b
 }
 
-// UnregisterKeyboardShortcut removes the global keyboard listener
+// UnregisterKeyboardShortcut removes the Cmd+K / Ctrl+K shortcut
 func (cp *CommandPalette) UnregisterKeyboardShortcut() {
-	if !cp.keyboardListener.IsUndefined() {
-		js.Global().Get("document").Call("removeEventListener", "keydown", cp.keyboardListener)
-		cp.keyboardListener.Release()
+	if cp.shortcut != nil {
+		cp.shortcut.Unregister()
+		cp.shortcut = nil
 	}
 }
 
@@ -497,4 +517,6 @@ func (cp *CommandPalette) UnregisterKeyboardShortcut() {
 func (cp *CommandPalette) Destroy() {
 	cp.UnregisterKeyboardShortcut()
 	cp.focusTrap.Destroy()
+	cp.listeners.release()
+	cp.itemFuncs.release()
 }
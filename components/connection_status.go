@@ -29,26 +29,29 @@ const (
 
 // Default labels for each state
 var defaultStatusLabels = map[state.WebSocketState]string{
-	state.WSConnecting: "Connecting",
-	state.WSOpen:       "Connected",
-	state.WSClosing:    "Disconnecting",
-	state.WSClosed:     "Disconnected",
+	state.WSConnecting:   "Connecting",
+	state.WSOpen:         "Connected",
+	state.WSClosing:      "Disconnecting",
+	state.WSClosed:       "Disconnected",
+	state.WSReconnecting: "Reconnecting",
 }
 
 // Status colors for each state
 var statusDotColors = map[state.WebSocketState]string{
-	state.WSConnecting: "bg-yellow-400 dark:bg-yellow-500",
-	state.WSOpen:       "bg-green-500 dark:bg-green-400",
-	state.WSClosing:    "bg-yellow-500 dark:bg-yellow-400",
-	state.WSClosed:     "bg-red-500 dark:bg-red-400",
+	state.WSConnecting:   "bg-yellow-400 dark:bg-yellow-500",
+	state.WSOpen:         "bg-green-500 dark:bg-green-400",
+	state.WSClosing:      "bg-yellow-500 dark:bg-yellow-400",
+	state.WSClosed:       "bg-red-500 dark:bg-red-400",
+	state.WSReconnecting: "bg-orange-500 dark:bg-orange-400",
 }
 
 // Badge variant colors for each state
 var statusBadgeColors = map[state.WebSocketState]string{
-	state.WSConnecting: "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
-	state.WSOpen:       "bg-green-100 dark:bg-green-900 text-green-800 dark:text-green-200",
-	state.WSClosing:    "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
-	state.WSClosed:     "bg-red-100 dark:bg-red-900 text-red-800 dark:text-red-200",
+	state.WSConnecting:   "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
+	state.WSOpen:         "bg-green-100 dark:bg-green-900 text-green-800 dark:text-green-200",
+	state.WSClosing:      "bg-yellow-100 dark:bg-yellow-900 text-yellow-800 dark:text-yellow-200",
+	state.WSClosed:       "bg-red-100 dark:bg-red-900 text-red-800 dark:text-red-200",
+	state.WSReconnecting: "bg-orange-100 dark:bg-orange-900 text-orange-800 dark:text-orange-200",
 }
 
 // Dot sizes
@@ -199,8 +202,8 @@ func (cs *ConnectionStatus) updateDotStyle() {
 
 	className := size + " " + color + " rounded-full inline-block"
 
-	// Add pulse animation for connecting state
-	if cs.state == state.WSConnecting {
+	// Add pulse animation while connecting
+	if cs.pending() {
 		className += " animate-pulse"
 	}
 
@@ -215,8 +218,8 @@ func (cs *ConnectionStatus) updateBadgeStyle() {
 	color := statusBadgeColors[cs.state]
 	className := "inline-flex items-center gap-1.5 px-2.5 py-0.5 text-xs font-medium rounded-full " + color
 
-	// Add pulse for connecting
-	if cs.state == state.WSConnecting {
+	// Add pulse while connecting
+	if cs.pending() {
 		className += " animate-pulse"
 	}
 
@@ -239,10 +242,12 @@ func (cs *ConnectionStatus) updateTextStyle() {
 		textColor = "text-yellow-600 dark:text-yellow-400"
 	case state.WSClosed:
 		textColor = "text-red-600 dark:text-red-400"
+	case state.WSReconnecting:
+		textColor = "text-orange-600 dark:text-orange-400"
 	}
 
 	className := "text-sm font-medium " + textColor
-	if cs.state == state.WSConnecting {
+	if cs.pending() {
 		className += " animate-pulse"
 	}
 
@@ -250,6 +255,11 @@ func (cs *ConnectionStatus) updateTextStyle() {
 	cs.labelEl.Set("textContent", cs.getLabel())
 }
 
+// pending reports whether a connection attempt is underway or scheduled
+func (cs *ConnectionStatus) pending() bool {
+	return cs.state == state.WSConnecting || cs.state == state.WSReconnecting
+}
+
 func (cs *ConnectionStatus) getLabel() string {
 	if label, ok := cs.props.Labels[cs.state]; ok {
 		return label
@@ -310,29 +320,13 @@ func (cs *ConnectionStatus) BindToWebSocket(store *state.WebSocketStore) {
 	}
 
 	// Set initial state based on store
-	storeState := store.State()
-	if storeState.Connected {
-		cs.state = state.WSOpen
-	} else if storeState.Connecting {
-		cs.state = state.WSConnecting
-	} else {
-		cs.state = state.WSClosed
-	}
+	cs.state = store.State().State
 	cs.updateDisplay()
 
 	// Subscribe to store changes
 	cs.unsubscribe = store.Subscribe(func(s state.WSStoreState) {
-		var newState state.WebSocketState
-		if s.Connected {
-			newState = state.WSOpen
-		} else if s.Connecting {
-			newState = state.WSConnecting
-		} else {
-			newState = state.WSClosed
-		}
-
-		if newState != cs.state {
-			cs.state = newState
+		if s.State != cs.state {
+			cs.state = s.State
 			cs.updateDisplay()
 		}
 	})
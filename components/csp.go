@@ -0,0 +1,37 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"sync"
+	"syscall/js"
+)
+
+var (
+	cspNonce     string
+	cspNonceOnce sync.Once
+)
+
+// CSPNonce returns the nonce of the page's scripts, which server.CSP sets
+// and a Content-Security-Policy requires on inline scripts and styles, or
+// "" if they have none. The components add it to the styles they inject.
+func CSPNonce() string {
+	cspNonceOnce.Do(func() {
+		// Browsers hide the attribute's value once the page has loaded, but
+		// keep the property
+		script := js.Global().Get("document").Call("querySelector", "script[nonce]")
+		if script.Truthy() {
+			cspNonce = script.Get("nonce").String()
+		}
+	})
+	return cspNonce
+}
+
+// newStyle returns a style element carrying the page's CSP nonce
+func newStyle() js.Value {
+	style := js.Global().Get("document").Call("createElement", "style")
+	if nonce := CSPNonce(); nonce != "" {
+		style.Set("nonce", nonce)
+	}
+	return style
+}
@@ -0,0 +1,188 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/types"
+)
+
+// CurrencyInputProps configures a CurrencyInput component
+type CurrencyInputProps struct {
+	Label       string
+	Name        string      // Sets the input name and id
+	Value       types.Money // Initial amount; the zero Money leaves the field empty
+	Currency    string      // ISO 4217 code when Value has none (default types.DefaultCurrency)
+	Placeholder string
+	ClassName   string
+	Disabled    bool
+	OnChange    func(value types.Money)
+}
+
+// CurrencyInput is a text field for an amount of money. It shows the
+// currency symbol on the side the locale puts it, accepts the locale's
+// separators, and keeps the value as a types.Money so no float rounding
+// creeps in. Typed text is parsed when the field loses focus or on Enter;
+// text that isn't an amount is replaced by the last valid one.
+type CurrencyInput struct {
+	container js.Value
+	input     js.Value
+	props     CurrencyInputProps
+	value     types.Money
+	currency  string
+	listeners listeners
+}
+
+// NewCurrencyInput creates a new CurrencyInput component
+func NewCurrencyInput(props CurrencyInputProps) *CurrencyInput {
+	document := js.Global().Get("document")
+	crypto := js.Global().Get("crypto")
+
+	currency := props.Value.Currency()
+	if currency == "" {
+		currency = props.Currency
+	}
+	if currency == "" {
+		currency = types.DefaultCurrency
+	}
+	c := &CurrencyInput{props: props, value: props.Value, currency: strings.ToUpper(currency)}
+
+	container := document.Call("createElement", "div")
+	className := "mb-4"
+	if props.ClassName != "" {
+		className = props.ClassName
+	}
+	container.Set("className", className)
+	c.container = container
+
+	inputID := props.Name
+	if inputID == "" {
+		inputID = "currency-" + crypto.Call("randomUUID").String()
+	}
+
+	if props.Label != "" {
+		label := document.Call("createElement", "label")
+		label.Set("className", "block text-sm font-medium text-secondary mb-1")
+		label.Set("textContent", props.Label)
+		label.Set("htmlFor", inputID)
+		container.Call("appendChild", label)
+	}
+
+	group := document.Call("createElement", "div")
+	groupClass := "flex items-stretch border border-default surface-base rounded-md shadow-sm overflow-hidden focus-within:ring-2 focus-within:ring-blue-500 focus-within:border-blue-500"
+	if props.Disabled {
+		groupClass += " surface-overlay cursor-not-allowed opacity-60"
+	}
+	group.Set("className", groupClass)
+
+	symbolFirst := !strings.HasSuffix(strings.TrimSpace(i18n.CurrentFormat().Currency), "¤")
+	symbol := numberAdornment(types.Symbol(c.currency))
+	if symbolFirst {
+		group.Call("appendChild", symbol)
+	}
+
+	input := document.Call("createElement", "input")
+	input.Set("type", "text")
+	input.Set("id", inputID)
+	if props.Name != "" {
+		input.Set("name", props.Name)
+	}
+	input.Set("className", "flex-1 min-w-0 px-3 py-2 bg-transparent text-primary text-right tabular-nums focus:outline-none placeholder:text-tertiary")
+	input.Call("setAttribute", "inputmode", "decimal")
+	input.Call("setAttribute", "autocomplete", "off")
+	input.Call("setAttribute", "aria-description", c.currency)
+	if props.Placeholder != "" {
+		input.Set("placeholder", props.Placeholder)
+	}
+	if props.Disabled {
+		input.Set("disabled", true)
+	}
+	group.Call("appendChild", input)
+	c.input = input
+
+	if !symbolFirst {
+		group.Call("appendChild", symbol)
+	}
+
+	container.Call("appendChild", group)
+	c.render()
+
+	input.Call("addEventListener", "keydown", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		if args[0].Get("key").String() == "Enter" {
+			c.commit()
+		}
+		return nil
+	}))
+
+	input.Call("addEventListener", "blur", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		c.commit()
+		return nil
+	}))
+
+	onUnmount(c.container, c.Unmount)
+	return c
+}
+
+func (c *CurrencyInput) render() {
+	if c.value == (types.Money{}) {
+		c.input.Set("value", "")
+		return
+	}
+	c.input.Set("value", c.value.AmountIn(i18n.CurrentFormat()))
+}
+
+// commit parses the typed text, restoring the last valid amount if it is not one
+func (c *CurrencyInput) commit() {
+	raw := strings.TrimSpace(c.input.Get("value").String())
+	value := types.Money{}
+	if raw != "" {
+		parsed, err := types.ParseIn(raw, c.currency, i18n.CurrentFormat())
+		if err != nil {
+			c.render()
+			return
+		}
+		value = parsed
+	}
+
+	changed := value != c.value
+	c.value = value
+	c.render()
+	if changed && c.props.OnChange != nil {
+		c.props.OnChange(value)
+	}
+}
+
+// Element returns the container DOM element
+func (c *CurrencyInput) Element() js.Value {
+	return c.container
+}
+
+// Mount appends the input to parent
+func (c *CurrencyInput) Mount(parent js.Value) {
+	parent.Call("appendChild", c.container)
+}
+
+// Unmount removes the input and releases its listeners
+func (c *CurrencyInput) Unmount() {
+	unmount(c.container)
+	c.listeners.release()
+}
+
+// Value returns the current amount, or the zero Money when the field is empty
+func (c *CurrencyInput) Value() types.Money {
+	return c.value
+}
+
+// SetValue sets the amount without firing OnChange
+func (c *CurrencyInput) SetValue(value types.Money) {
+	c.value = value
+	c.render()
+}
+
+// Focus sets focus on the input
+func (c *CurrencyInput) Focus() {
+	c.input.Call("focus")
+}
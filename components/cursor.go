@@ -0,0 +1,137 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// CursorRows adapts a cursor-paginated client method for TableProps.LoadPage,
+// turning each item into a row with toRow
+//
+//	LoadPage: components.CursorRows(func(ctx context.Context, cursor string) (gqapi.Page[api.Post], error) {
+//		return client.List(ctx, cursor, 50)
+//	}, postRow),
+func CursorRows[T any](fetch func(ctx context.Context, cursor string) (api.Page[T], error), toRow func(T) map[string]any) func(ctx context.Context, cursor string) ([]map[string]any, string, error) {
+	return func(ctx context.Context, cursor string) ([]map[string]any, string, error) {
+		page, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		rows := make([]map[string]any, len(page.Items))
+		for i, item := range page.Items {
+			rows[i] = toRow(item)
+		}
+		return rows, page.NextCursor, nil
+	}
+}
+
+// CursorItems adapts a cursor-paginated client method for
+// InfiniteListProps.LoadPage
+func CursorItems[T any](fetch func(ctx context.Context, cursor string) (api.Page[T], error)) func(ctx context.Context, cursor string) ([]any, string, error) {
+	return func(ctx context.Context, cursor string) ([]any, string, error) {
+		page, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		items := make([]any, len(page.Items))
+		for i, item := range page.Items {
+			items[i] = item
+		}
+		return items, page.NextCursor, nil
+	}
+}
+
+// cursorLoader follows the cursors of a paginated source for Table and
+// InfiniteList. It loads one page at a time, drops pages that arrive after
+// a reload, and shows the loading, error, and "Load more" states in footer.
+type cursorLoader[T any] struct {
+	load    func(ctx context.Context, cursor string) ([]T, string, error)
+	onPage  func(items []T, reset bool) // reset is set for the first page after a reload
+	footer  js.Value
+	button  bool // show a "Load more" button rather than loading on scroll
+	next    string
+	done    bool
+	loading bool
+	reset   bool
+	err     error
+	cancel  context.CancelFunc
+}
+
+func newCursorLoader[T any](load func(ctx context.Context, cursor string) ([]T, string, error), button bool, onPage func([]T, bool)) *cursorLoader[T] {
+	footer := js.Global().Get("document").Call("createElement", "div")
+	footer.Set("className", "flex items-center justify-center gap-3 py-3 text-sm text-muted")
+	return &cursorLoader[T]{load: load, onPage: onPage, footer: footer, button: button}
+}
+
+// reload starts again from the first page, canceling a load in progress.
+// The current items stay until the first page replaces them.
+func (l *cursorLoader[T]) reload() {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.next, l.done, l.loading, l.err = "", false, false, nil
+	l.reset = true
+	l.more()
+}
+
+// more loads the next page unless one is loading or the last has loaded
+func (l *cursorLoader[T]) more() {
+	if l.loading || l.done {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.loading, l.err = true, nil
+	l.render()
+
+	cursor := l.next
+	go func() {
+		defer cancel()
+		items, next, err := l.load(ctx, cursor)
+		if ctx.Err() != nil {
+			return // reloaded or destroyed while loading
+		}
+		l.loading = false
+		if err != nil {
+			l.err = err
+			l.render()
+			return
+		}
+		l.next, l.done = next, next == ""
+		reset := l.reset
+		l.reset = false
+		l.render()
+		l.onPage(items, reset)
+	}()
+}
+
+// stop cancels a load in progress
+func (l *cursorLoader[T]) stop() {
+	if l.cancel != nil {
+		l.cancel()
+	}
+}
+
+func (l *cursorLoader[T]) render() {
+	document := js.Global().Get("document")
+	UnmountChildren(l.footer)
+
+	switch {
+	case l.loading:
+		l.footer.Call("appendChild", Spinner(SpinnerProps{Size: SpinnerSM, AriaLabel: i18n.T("gux.pagination.loading")}))
+	case l.err != nil:
+		msg := document.Call("createElement", "span")
+		msg.Set("className", "text-red-600 dark:text-red-400")
+		msg.Call("setAttribute", "role", "alert")
+		msg.Set("textContent", i18n.T("gux.pagination.loadFailed"))
+		l.footer.Call("appendChild", msg)
+		l.footer.Call("appendChild", Button(ButtonProps{Text: i18n.T("gux.pagination.retry"), Variant: ButtonSecondary, Size: ButtonSM, OnClick: l.more}))
+	case !l.done && l.button:
+		l.footer.Call("appendChild", Button(ButtonProps{Text: i18n.T("gux.pagination.loadMore"), Variant: ButtonSecondary, Size: ButtonSM, OnClick: l.more}))
+	}
+}
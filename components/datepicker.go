@@ -6,38 +6,87 @@ import (
 	"fmt"
 	"syscall/js"
 	"time"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
-var monthNames = []string{
-	"January", "February", "March", "April", "May", "June",
-	"July", "August", "September", "October", "November", "December",
-}
+// DatePickerMode selects what a DatePicker picks
+type DatePickerMode string
 
-var dayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+const (
+	DatePickerSingle   DatePickerMode = "single"
+	DatePickerRange    DatePickerMode = "range"
+	DatePickerDateTime DatePickerMode = "datetime"
+)
+
+// DateRangePreset is a quick range offered in range mode, e.g. "Last 7 days"
+type DateRangePreset struct {
+	Label string
+	Range func() (start, end time.Time) // Evaluated when the preset is clicked
+}
 
 // DatePickerProps configures a DatePicker
 type DatePickerProps struct {
 	Label       string
+	Mode        DatePickerMode // Default DatePickerSingle
 	Value       time.Time
 	Placeholder string
 	MinDate     time.Time
 	MaxDate     time.Time
 	OnChange    func(time.Time)
+
+	// Range mode
+	RangeStart    time.Time
+	RangeEnd      time.Time
+	OnRangeChange func(start, end time.Time) // Called once both ends are picked
+	Presets       []DateRangePreset          // Default DefaultDateRangePresets(); pass an empty slice for none
+
+	// Datetime mode
+	MinuteStep int // Minute increments in the time selector (default 5)
+}
+
+// DefaultDateRangePresets returns Today, Last 7 days, Last 30 days, and This month
+func DefaultDateRangePresets() []DateRangePreset {
+	return []DateRangePreset{
+		{Label: i18n.T("gux.datepicker.preset.today"), Range: func() (time.Time, time.Time) {
+			today := startOfDay(time.Now())
+			return today, today
+		}},
+		{Label: i18n.T("gux.datepicker.preset.last7"), Range: func() (time.Time, time.Time) {
+			today := startOfDay(time.Now())
+			return today.AddDate(0, 0, -6), today
+		}},
+		{Label: i18n.T("gux.datepicker.preset.last30"), Range: func() (time.Time, time.Time) {
+			today := startOfDay(time.Now())
+			return today.AddDate(0, 0, -29), today
+		}},
+		{Label: i18n.T("gux.datepicker.preset.thisMonth"), Range: func() (time.Time, time.Time) {
+			today := startOfDay(time.Now())
+			first := today.AddDate(0, 0, 1-today.Day())
+			return first, first.AddDate(0, 1, -1)
+		}},
+	}
 }
 
 // DatePicker is a date selection component
 type DatePicker struct {
-	container   js.Value
-	input       js.Value
-	calendar    js.Value
-	calendarID  string    // unique ID for aria-controls
-	displayed   time.Time // currently displayed month
-	selected    time.Time
-	isOpen      bool
-	props       DatePickerProps
-	focusedDay  int        // currently focused day (1-31)
-	keyHandler  js.Func    // keyboard navigation handler
-	dayButtons  []js.Value // day button references for focus management
+	container    js.Value
+	input        js.Value
+	calendar     js.Value
+	calendarID   string    // unique ID for aria-controls
+	displayed    time.Time // currently displayed month
+	selected     time.Time
+	isOpen       bool
+	props        DatePickerProps
+	focusedDay   int        // currently focused day (1-31)
+	keyHandler   js.Func    // keyboard navigation handler
+	dayButtons   []js.Value // day button references for focus management
+	rangeStart   time.Time  // range mode: first picked day
+	rangeEnd     time.Time  // range mode: zero until the second day is picked
+	hourSelect   js.Value   // datetime mode time selectors
+	minuteSelect js.Value
+	listeners    listeners
+	dayFuncs     listeners // of the rendered calendar
 }
 
 // NewDatePicker creates a new DatePicker component
@@ -56,12 +105,24 @@ func NewDatePicker(props DatePickerProps) *DatePicker {
 		calendarID: calendarID,
 		displayed:  time.Now(),
 		selected:   props.Value,
+		rangeStart: props.RangeStart,
+		rangeEnd:   props.RangeEnd,
 		props:      props,
 	}
 
+	if props.Mode == DatePickerRange && props.Presets == nil {
+		dp.props.Presets = DefaultDateRangePresets()
+	}
+	if props.MinuteStep <= 0 || props.MinuteStep > 60 {
+		dp.props.MinuteStep = 5
+	}
+
 	if !props.Value.IsZero() {
 		dp.displayed = props.Value
 	}
+	if !props.RangeStart.IsZero() {
+		dp.displayed = props.RangeStart
+	}
 
 	// Label
 	if props.Label != "" {
@@ -83,8 +144,10 @@ func NewDatePicker(props DatePickerProps) *DatePicker {
 	input.Set("className", "w-full px-3 py-2 pr-10 border border-default rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 cursor-pointer")
 
 	placeholder := props.Placeholder
-	if placeholder == "" {
-		placeholder = "Select date"
+	if placeholder == "" && props.Mode == DatePickerRange {
+		placeholder = i18n.T("gux.datepicker.rangePlaceholder")
+	} else if placeholder == "" {
+		placeholder = i18n.T("gux.datepicker.placeholder")
 	}
 	input.Set("placeholder", placeholder)
 
@@ -98,10 +161,6 @@ func NewDatePicker(props DatePickerProps) *DatePicker {
 		input.Call("setAttribute", "aria-label", placeholder)
 	}
 
-	if !props.Value.IsZero() {
-		input.Set("value", props.Value.Format("Jan 2, 2006"))
-	}
-
 	// Calendar icon
 	icon := document.Call("createElement", "div")
 	icon.Set("className", "absolute right-3 top-1/2 -translate-y-1/2 pointer-events-none icon-muted")
@@ -112,6 +171,7 @@ func NewDatePicker(props DatePickerProps) *DatePicker {
 	container.Call("appendChild", inputWrapper)
 
 	dp.input = input
+	dp.updateInput()
 
 	// Calendar dropdown with dialog role
 	calendar := document.Call("createElement", "div")
@@ -119,32 +179,34 @@ func NewDatePicker(props DatePickerProps) *DatePicker {
 	calendar.Set("className", "absolute z-50 mt-1 surface-base border border-subtle rounded-lg shadow-lg p-4 hidden")
 	calendar.Call("setAttribute", "role", "dialog")
 	calendar.Call("setAttribute", "aria-modal", "false")
-	calendar.Call("setAttribute", "aria-label", "Choose date")
+	calendar.Call("setAttribute", "aria-label", i18n.T("gux.datepicker.choose"))
 	container.Call("appendChild", calendar)
 	dp.calendar = calendar
 
 	dp.renderCalendar()
 
 	// Toggle calendar on input click
-	input.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "click", dp.listeners.fn(func(this js.Value, args []js.Value) any {
 		dp.toggle()
 		return nil
 	}))
 
 	// Close on outside click
-	js.Global().Get("document").Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	dp.listeners.on(document, "click", func(this js.Value, args []js.Value) any {
 		target := args[0].Get("target")
 		if !container.Call("contains", target).Bool() {
 			dp.close()
 		}
 		return nil
-	}))
+	})
+	onUnmount(container, dp.Unmount)
 
 	return dp
 }
 
 func (dp *DatePicker) renderCalendar() {
 	document := js.Global().Get("document")
+	dp.dayFuncs.release()
 	dp.calendar.Set("innerHTML", "")
 
 	// Header with month/year and navigation
@@ -154,9 +216,9 @@ func (dp *DatePicker) renderCalendar() {
 	prevBtn := document.Call("createElement", "button")
 	prevBtn.Set("type", "button")
 	prevBtn.Set("className", "p-1 hover:surface-overlay rounded cursor-pointer")
-	prevBtn.Call("setAttribute", "aria-label", "Previous month")
+	prevBtn.Call("setAttribute", "aria-label", i18n.T("gux.datepicker.previousMonth"))
 	prevBtn.Set("innerHTML", `<svg class="w-5 h-5" fill="none" stroke="currentColor" viewBox="0 0 24 24"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M15 19l-7-7 7-7"></path></svg>`)
-	prevBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	prevBtn.Call("addEventListener", "click", dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("stopPropagation")
 		dp.displayed = dp.displayed.AddDate(0, -1, 0)
 		dp.renderCalendar()
@@ -165,16 +227,16 @@ func (dp *DatePicker) renderCalendar() {
 
 	monthYear := document.Call("createElement", "span")
 	monthYear.Set("className", "font-semibold text-primary")
-	monthYear.Set("textContent", fmt.Sprintf("%s %d", monthNames[dp.displayed.Month()-1], dp.displayed.Year()))
+	monthYear.Set("textContent", fmt.Sprintf("%s %d", i18n.MonthName(dp.displayed.Month()), dp.displayed.Year()))
 	monthYear.Call("setAttribute", "aria-live", "polite")
 	monthYear.Call("setAttribute", "aria-atomic", "true")
 
 	nextBtn := document.Call("createElement", "button")
 	nextBtn.Set("type", "button")
 	nextBtn.Set("className", "p-1 hover:surface-overlay rounded cursor-pointer")
-	nextBtn.Call("setAttribute", "aria-label", "Next month")
+	nextBtn.Call("setAttribute", "aria-label", i18n.T("gux.datepicker.nextMonth"))
 	nextBtn.Set("innerHTML", `<svg class="w-5 h-5" fill="none" stroke="currentColor" viewBox="0 0 24 24"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M9 5l7 7-7 7"></path></svg>`)
-	nextBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	nextBtn.Call("addEventListener", "click", dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("stopPropagation")
 		dp.displayed = dp.displayed.AddDate(0, 1, 0)
 		dp.renderCalendar()
@@ -190,13 +252,15 @@ func (dp *DatePicker) renderCalendar() {
 	daysGrid := document.Call("createElement", "table")
 	daysGrid.Set("className", "w-full")
 	daysGrid.Call("setAttribute", "role", "grid")
-	daysGrid.Call("setAttribute", "aria-label", fmt.Sprintf("%s %d", monthNames[dp.displayed.Month()-1], dp.displayed.Year()))
+	daysGrid.Call("setAttribute", "aria-label", fmt.Sprintf("%s %d", i18n.MonthName(dp.displayed.Month()), dp.displayed.Year()))
 
 	// Day names header row
 	thead := document.Call("createElement", "thead")
 	dayNamesRow := document.Call("createElement", "tr")
 	dayNamesRow.Call("setAttribute", "role", "row")
-	for _, day := range dayNames {
+	format := i18n.CurrentFormat()
+	for i := 0; i < 7; i++ {
+		day := format.Days[(int(format.FirstWeekday)+i)%7]
 		th := document.Call("createElement", "th")
 		th.Set("className", "text-center text-xs text-tertiary font-medium py-1 w-8")
 		th.Set("textContent", day)
@@ -212,7 +276,7 @@ func (dp *DatePicker) renderCalendar() {
 
 	// Get first day of month and number of days
 	firstOfMonth := time.Date(dp.displayed.Year(), dp.displayed.Month(), 1, 0, 0, 0, 0, time.Local)
-	startWeekday := int(firstOfMonth.Weekday())
+	startWeekday := (int(firstOfMonth.Weekday()) - int(format.FirstWeekday) + 7) % 7
 	daysInMonth := time.Date(dp.displayed.Year(), dp.displayed.Month()+1, 0, 0, 0, 0, 0, time.Local).Day()
 
 	today := time.Now()
@@ -268,8 +332,9 @@ func (dp *DatePicker) renderCalendar() {
 		className := "w-8 h-8 rounded-full text-sm hover:surface-overlay cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-inset"
 
 		// Determine states
-		isSelected := !dp.selected.IsZero() && dp.selected.Year() == dayDate.Year() && dp.selected.Month() == dayDate.Month() && dp.selected.Day() == dayDate.Day()
-		isToday := today.Year() == dayDate.Year() && today.Month() == dayDate.Month() && today.Day() == dayDate.Day()
+		isSelected := dp.isSelectedDay(dayDate)
+		inRange := dp.isInRange(dayDate)
+		isToday := sameDay(today, dayDate)
 		disabled := false
 
 		if !dp.props.MinDate.IsZero() && dayDate.Before(dp.props.MinDate) {
@@ -282,6 +347,8 @@ func (dp *DatePicker) renderCalendar() {
 		// Apply visual styles
 		if isSelected {
 			className = "w-8 h-8 rounded-full text-sm bg-blue-600 text-white cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-600 focus:ring-inset"
+		} else if inRange {
+			className = "w-8 h-8 rounded-full text-sm bg-blue-100 dark:bg-blue-900/40 text-blue-900 dark:text-blue-100 cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-inset"
 		} else if isToday {
 			className = "w-8 h-8 rounded-full text-sm border border-blue-500 text-blue-500 cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-inset"
 		}
@@ -291,6 +358,7 @@ func (dp *DatePicker) renderCalendar() {
 
 		dayBtn.Set("className", className)
 		dayBtn.Set("textContent", fmt.Sprintf("%d", day))
+		dayBtn.Get("dataset").Set("day", day)
 
 		// Roving tabindex - focused day gets tabindex=0, others get -1
 		if day == dp.focusedDay {
@@ -303,7 +371,7 @@ func (dp *DatePicker) renderCalendar() {
 		dp.dayButtons[day-1] = dayBtn
 
 		// ARIA attributes for gridcell
-		if isSelected {
+		if isSelected || inRange {
 			td.Call("setAttribute", "aria-selected", "true")
 		}
 		if disabled {
@@ -316,9 +384,9 @@ func (dp *DatePicker) renderCalendar() {
 		// Click handler
 		if !disabled {
 			capturedDay := day
-			dayBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			dayBtn.Call("addEventListener", "click", dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
 				args[0].Call("stopPropagation")
-				dp.selectDate(time.Date(dp.displayed.Year(), dp.displayed.Month(), capturedDay, 0, 0, 0, 0, time.Local))
+				dp.pickDay(time.Date(dp.displayed.Year(), dp.displayed.Month(), capturedDay, 0, 0, 0, 0, time.Local))
 				return nil
 			}))
 		}
@@ -339,22 +407,240 @@ func (dp *DatePicker) renderCalendar() {
 	daysGrid.Call("appendChild", tbody)
 	dp.calendar.Call("appendChild", daysGrid)
 
+	if dp.props.Mode == DatePickerDateTime {
+		dp.calendar.Call("appendChild", dp.renderTimeSelector())
+	}
+
+	// Range mode offers presets in place of the Today button
+	if dp.props.Mode == DatePickerRange {
+		if len(dp.props.Presets) > 0 {
+			dp.calendar.Call("appendChild", dp.renderPresets())
+		}
+		return
+	}
+
 	// Today button
 	todayBtn := document.Call("createElement", "button")
 	todayBtn.Set("type", "button")
 	todayBtn.Set("className", "w-full mt-3 py-1 text-sm text-blue-600 hover:bg-blue-50 rounded cursor-pointer")
-	todayBtn.Set("textContent", "Today")
-	todayBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	todayBtn.Set("textContent", i18n.T("gux.datepicker.today"))
+	todayBtn.Call("addEventListener", "click", dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("stopPropagation")
-		dp.selectDate(time.Now())
+		if dp.props.Mode == DatePickerDateTime {
+			dp.displayed = time.Now()
+			dp.pickDay(startOfDay(time.Now()))
+		} else {
+			dp.selectDate(time.Now())
+		}
 		return nil
 	}))
 	dp.calendar.Call("appendChild", todayBtn)
 }
 
+// renderTimeSelector builds the hour and minute selects for datetime mode
+func (dp *DatePicker) renderTimeSelector() js.Value {
+	document := js.Global().Get("document")
+
+	row := document.Call("createElement", "div")
+	row.Set("className", "flex items-center justify-center gap-2 mt-3")
+
+	selectClass := "px-2 py-1 border border-default rounded-md surface-base text-primary text-sm focus:outline-none focus:ring-2 focus:ring-blue-500 cursor-pointer"
+	hour, minute := 0, 0
+	if !dp.selected.IsZero() {
+		hour, minute = dp.selected.Hour(), dp.selected.Minute()
+	}
+
+	dp.hourSelect = document.Call("createElement", "select")
+	dp.hourSelect.Set("className", selectClass)
+	dp.hourSelect.Call("setAttribute", "aria-label", i18n.T("gux.datepicker.hour"))
+	for h := 0; h < 24; h++ {
+		opt := document.Call("createElement", "option")
+		opt.Set("value", h)
+		opt.Set("textContent", fmt.Sprintf("%02d", h))
+		opt.Set("selected", h == hour)
+		dp.hourSelect.Call("appendChild", opt)
+	}
+
+	dp.minuteSelect = document.Call("createElement", "select")
+	dp.minuteSelect.Set("className", selectClass)
+	dp.minuteSelect.Call("setAttribute", "aria-label", i18n.T("gux.datepicker.minute"))
+	for m := 0; m < 60; m += dp.props.MinuteStep {
+		opt := document.Call("createElement", "option")
+		opt.Set("value", m)
+		opt.Set("textContent", fmt.Sprintf("%02d", m))
+		opt.Set("selected", m <= minute && minute < m+dp.props.MinuteStep)
+		dp.minuteSelect.Call("appendChild", opt)
+	}
+
+	onChange := dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
+		dp.setTime(dp.hourSelect.Get("value").Int(), dp.minuteSelect.Get("value").Int())
+		return nil
+	})
+	dp.hourSelect.Call("addEventListener", "change", onChange)
+	dp.minuteSelect.Call("addEventListener", "change", onChange)
+
+	separator := document.Call("createElement", "span")
+	separator.Set("className", "text-secondary")
+	separator.Set("textContent", ":")
+
+	row.Call("appendChild", dp.hourSelect)
+	row.Call("appendChild", separator)
+	row.Call("appendChild", dp.minuteSelect)
+	return row
+}
+
+// renderPresets builds the quick range buttons for range mode
+func (dp *DatePicker) renderPresets() js.Value {
+	document := js.Global().Get("document")
+
+	row := document.Call("createElement", "div")
+	row.Set("className", "flex flex-wrap gap-1 mt-3 pt-3 border-t border-subtle")
+
+	for _, preset := range dp.props.Presets {
+		preset := preset
+		btn := document.Call("createElement", "button")
+		btn.Set("type", "button")
+		btn.Set("className", "px-2 py-1 text-xs text-blue-600 hover:bg-blue-50 dark:hover:bg-blue-900/30 rounded cursor-pointer")
+		btn.Set("textContent", preset.Label)
+		btn.Call("addEventListener", "click", dp.dayFuncs.fn(func(this js.Value, args []js.Value) any {
+			args[0].Call("stopPropagation")
+			start, end := preset.Range()
+			dp.displayed = start
+			dp.completeRange(startOfDay(start), startOfDay(end))
+			return nil
+		}))
+		row.Call("appendChild", btn)
+	}
+	return row
+}
+
+// pickDay handles a day being chosen by click or keyboard according to the mode
+func (dp *DatePicker) pickDay(date time.Time) {
+	switch dp.props.Mode {
+	case DatePickerRange:
+		if dp.rangeStart.IsZero() || !dp.rangeEnd.IsZero() {
+			// Start a new range and wait for the second day
+			dp.rangeStart = date
+			dp.rangeEnd = time.Time{}
+			dp.updateInput()
+			dp.refocus(date.Day())
+			return
+		}
+		start, end := dp.rangeStart, date
+		if end.Before(start) {
+			start, end = end, start
+		}
+		dp.completeRange(start, end)
+
+	case DatePickerDateTime:
+		// Keep the chosen time of day and leave the calendar open for the time selector
+		dp.selected = time.Date(date.Year(), date.Month(), date.Day(), dp.selected.Hour(), dp.selected.Minute(), 0, 0, time.Local)
+		dp.updateInput()
+		dp.refocus(date.Day())
+		if dp.props.OnChange != nil {
+			dp.props.OnChange(dp.selected)
+		}
+
+	default:
+		dp.selectDate(date)
+	}
+}
+
+// completeRange stores a finished range, closes the calendar, and notifies
+func (dp *DatePicker) completeRange(start, end time.Time) {
+	dp.rangeStart = start
+	dp.rangeEnd = end
+	dp.updateInput()
+	dp.close()
+
+	if dp.props.OnRangeChange != nil {
+		dp.props.OnRangeChange(start, end)
+	}
+}
+
+// setTime applies the time selector in datetime mode
+func (dp *DatePicker) setTime(hour, minute int) {
+	date := dp.selected
+	firstPick := date.IsZero()
+	if firstPick {
+		date = time.Now()
+	}
+	dp.selected = time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, time.Local)
+	if firstPick {
+		// Show today as selected
+		dp.displayed = date
+		dp.renderCalendar()
+	}
+	dp.updateInput()
+
+	if dp.props.OnChange != nil {
+		dp.props.OnChange(dp.selected)
+	}
+}
+
+// refocus re-renders the calendar to show new selection state and keeps
+// keyboard focus on day
+func (dp *DatePicker) refocus(day int) {
+	dp.focusedDay = day
+	dp.renderCalendar()
+	if day > 0 && day <= len(dp.dayButtons) {
+		dp.dayButtons[day-1].Call("focus")
+	}
+}
+
+// updateInput shows the current selection in the input
+func (dp *DatePicker) updateInput() {
+	value := ""
+	switch dp.props.Mode {
+	case DatePickerRange:
+		if !dp.rangeStart.IsZero() {
+			value = i18n.FormatDate(dp.rangeStart) + " – "
+			if !dp.rangeEnd.IsZero() {
+				value += i18n.FormatDate(dp.rangeEnd)
+			}
+		}
+	case DatePickerDateTime:
+		if !dp.selected.IsZero() {
+			value = i18n.FormatDate(dp.selected) + " " + dp.selected.Format("15:04")
+		}
+	default:
+		if !dp.selected.IsZero() {
+			value = i18n.FormatDate(dp.selected)
+		}
+	}
+	dp.input.Set("value", value)
+}
+
+// isSelectedDay reports whether date is the selection or a range endpoint
+func (dp *DatePicker) isSelectedDay(date time.Time) bool {
+	if dp.props.Mode == DatePickerRange {
+		return sameDay(dp.rangeStart, date) || sameDay(dp.rangeEnd, date)
+	}
+	return sameDay(dp.selected, date)
+}
+
+// isInRange reports whether date lies strictly between the range endpoints
+func (dp *DatePicker) isInRange(date time.Time) bool {
+	if dp.props.Mode != DatePickerRange || dp.rangeStart.IsZero() || dp.rangeEnd.IsZero() {
+		return false
+	}
+	return date.After(startOfDay(dp.rangeStart)) && date.Before(startOfDay(dp.rangeEnd))
+}
+
+func sameDay(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return false
+	}
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
 func (dp *DatePicker) selectDate(date time.Time) {
 	dp.selected = date
-	dp.input.Set("value", date.Format("Jan 2, 2006"))
+	dp.updateInput()
 	dp.close()
 
 	if dp.props.OnChange != nil {
@@ -376,8 +662,12 @@ func (dp *DatePicker) open() {
 	dp.input.Call("setAttribute", "aria-expanded", "true")
 
 	// Initialize focusedDay: prefer selected date, then today if in displayed month, else 1
-	if !dp.selected.IsZero() && dp.selected.Year() == dp.displayed.Year() && dp.selected.Month() == dp.displayed.Month() {
-		dp.focusedDay = dp.selected.Day()
+	selected := dp.selected
+	if dp.props.Mode == DatePickerRange {
+		selected = dp.rangeStart
+	}
+	if !selected.IsZero() && selected.Year() == dp.displayed.Year() && selected.Month() == dp.displayed.Month() {
+		dp.focusedDay = selected.Day()
 	} else {
 		today := time.Now()
 		if today.Year() == dp.displayed.Year() && today.Month() == dp.displayed.Month() {
@@ -399,6 +689,12 @@ func (dp *DatePicker) open() {
 		event := args[0]
 		key := event.Get("key").String()
 
+		// Arrow and Enter keys belong to the day grid; leave them to the
+		// time selects and preset buttons
+		if !event.Get("target").Get("dataset").Get("day").Truthy() && key != "Escape" {
+			return nil
+		}
+
 		switch key {
 		case "ArrowRight":
 			event.Call("preventDefault")
@@ -417,7 +713,7 @@ func (dp *DatePicker) open() {
 			// Select the focused date if not disabled
 			focusedDate := time.Date(dp.displayed.Year(), dp.displayed.Month(), dp.focusedDay, 0, 0, 0, 0, time.Local)
 			if !dp.isDateDisabled(focusedDate) {
-				dp.selectDate(focusedDate)
+				dp.pickDay(focusedDate)
 			}
 		case "Escape":
 			event.Call("preventDefault")
@@ -438,6 +734,7 @@ func (dp *DatePicker) close() {
 	if dp.keyHandler.Truthy() {
 		dp.calendar.Call("removeEventListener", "keydown", dp.keyHandler)
 		dp.keyHandler.Release()
+		dp.keyHandler = js.Func{}
 	}
 
 	// Clear dayButtons slice
@@ -494,6 +791,19 @@ func (dp *DatePicker) Element() js.Value {
 	return dp.container
 }
 
+// Mount appends the date picker to parent
+func (dp *DatePicker) Mount(parent js.Value) {
+	parent.Call("appendChild", dp.container)
+}
+
+// Unmount removes the date picker and releases its listeners
+func (dp *DatePicker) Unmount() {
+	dp.close()
+	unmount(dp.container)
+	dp.listeners.release()
+	dp.dayFuncs.release()
+}
+
 // Value returns the selected date
 func (dp *DatePicker) Value() time.Time {
 	return dp.selected
@@ -503,12 +813,32 @@ func (dp *DatePicker) Value() time.Time {
 func (dp *DatePicker) SetValue(date time.Time) {
 	dp.selected = date
 	dp.displayed = date
-	dp.input.Set("value", date.Format("Jan 2, 2006"))
+	dp.updateInput()
+	dp.renderCalendar()
+}
+
+// Range returns the selected range in range mode. End is zero while only
+// the first day has been picked.
+func (dp *DatePicker) Range() (start, end time.Time) {
+	return dp.rangeStart, dp.rangeEnd
+}
+
+// SetRange sets the selected range in range mode
+func (dp *DatePicker) SetRange(start, end time.Time) {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	dp.rangeStart = startOfDay(start)
+	dp.rangeEnd = startOfDay(end)
+	dp.displayed = start
+	dp.updateInput()
 	dp.renderCalendar()
 }
 
-// Clear clears the selected date
+// Clear clears the selected date or range
 func (dp *DatePicker) Clear() {
 	dp.selected = time.Time{}
-	dp.input.Set("value", "")
+	dp.rangeStart = time.Time{}
+	dp.rangeEnd = time.Time{}
+	dp.updateInput()
 }
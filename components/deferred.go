@@ -0,0 +1,92 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/idle"
+)
+
+// deferredTimeout is how long Deferred waits for an idle period before
+// rendering anyway, so a busy page still gets its components
+const deferredTimeout = 2 * time.Second
+
+// Deferred returns an element showing placeholder, such as a Skeleton, in
+// the initial paint, and replaces it with render's element once the page
+// has loaded and painted and the browser is idle. Use it for heavy
+// components such as charts, maps, and editors, so the rest of the page
+// becomes interactive first.
+//
+//	components.Deferred(func() js.Value {
+//		return components.NewTimeSeriesChart(props).Element()
+//	}, components.SkeletonCard())
+//
+// The wrapper has display: contents, so it doesn't affect layout, and is
+// aria-busy until render runs. If the wrapper is unmounted first, render
+// never runs.
+func Deferred(render func() js.Value, placeholder js.Value) js.Value {
+	el := Div("")
+	el.Get("style").Set("display", "contents")
+	el.Call("setAttribute", "data-gux-deferred", "")
+	el.Call("setAttribute", "aria-busy", "true")
+	el.Call("appendChild", placeholder)
+
+	var l listeners
+	cancelIdle := func() {}
+	done := false
+	stop := func() {
+		done = true
+		cancelIdle()
+		l.release()
+	}
+	onUnmount(el, stop)
+
+	swap := func(idle.Deadline) {
+		if done {
+			return
+		}
+		stop()
+		forget(el)
+		content := render()
+		UnmountChildren(el)
+		el.Call("appendChild", content)
+		el.Call("removeAttribute", "aria-busy")
+	}
+
+	// Wait for two animation frames, so the placeholder has been painted,
+	// then for the browser to go idle
+	afterPaint := func() {
+		var frame js.Func
+		var pending js.Value
+		frames := 0
+		frame = l.fn(func(this js.Value, args []js.Value) any {
+			pending = js.Undefined()
+			if frames++; frames < 2 {
+				pending = js.Global().Call("requestAnimationFrame", frame)
+				return nil
+			}
+			cancelIdle = idle.Callback(swap, deferredTimeout)
+			return nil
+		})
+		l.onRelease(func() {
+			if !pending.IsUndefined() {
+				js.Global().Call("cancelAnimationFrame", pending)
+			}
+		})
+		pending = js.Global().Call("requestAnimationFrame", frame)
+	}
+
+	// The load event waits for images and stylesheets, which the
+	// placeholder shouldn't compete with
+	if js.Global().Get("document").Get("readyState").String() == "complete" {
+		afterPaint()
+	} else {
+		l.on(js.Global().Get("window"), "load", func(this js.Value, args []js.Value) any {
+			afterPaint()
+			return nil
+		})
+	}
+	return el
+}
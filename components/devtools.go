@@ -0,0 +1,277 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/debug"
+	"github.com/dougbarrett/gux/owner"
+	"github.com/dougbarrett/gux/state"
+	"github.com/dougbarrett/gux/storage"
+)
+
+// DevtoolsFlag is the localStorage key that turns on a bridge enabled with
+// DevtoolsOptions.RequireFlag, e.g. from the console:
+//
+//	localStorage.setItem("gux-devtools", "1")
+const DevtoolsFlag = "gux-devtools"
+
+// DevtoolsVersion is the version of the devtools message protocol
+const DevtoolsVersion = 1
+
+// Message sources of the devtools protocol: requests from the panel carry
+// devtoolsPanel, and replies and events from the app devtoolsPage
+const (
+	devtoolsPanel = "gux-devtools"
+	devtoolsPage  = "gux-page"
+)
+
+// DevtoolsOptions configures EnableDevtools
+type DevtoolsOptions struct {
+	// RequireFlag ignores the panel unless localStorage has DevtoolsFlag set
+	// to "1", so production builds can keep the bridge without exposing it
+	RequireFlag bool
+}
+
+// devtoolsRequest is a message from the devtools panel
+type devtoolsRequest struct {
+	Source string          `json:"source"`
+	ID     int             `json:"id"`
+	Type   string          `json:"type"`
+	Path   []int           `json:"path"`   // element, as child indexes from #app
+	Name   string          `json:"name"`   // store
+	Value  json.RawMessage `json:"value"`  // store value
+	Stream string          `json:"stream"` // "state" or "memory"
+	On     bool            `json:"on"`
+}
+
+// devtoolsNode is a ComponentNode as sent to the panel. A node's path is
+// its children's indexes from the root.
+type devtoolsNode struct {
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	Props    map[string]any `json:"props,omitempty"`
+	Owner    *owner.Info    `json:"owner,omitempty"`
+	Children []devtoolsNode `json:"children,omitempty"`
+}
+
+var devtools struct {
+	opts    DevtoolsOptions
+	onMsg   js.Func
+	streams map[string]func() // stops each watched stream
+}
+
+// EnableDevtools answers the Gux devtools extension over window.postMessage,
+// with the data the Inspector shows: the component tree, named stores,
+// memory samples and leak checks, js.Func counts, and accessibility issues.
+// The extension shows them in a browser devtools panel, so nothing is added
+// to the page. The bridge doesn't use the Inspector, which isn't linked into
+// builds that never call InitInspector. It returns a function that turns
+// the bridge off.
+func EnableDevtools(opts DevtoolsOptions) func() {
+	if devtools.onMsg.Truthy() {
+		devtools.opts = opts
+		return disableDevtools
+	}
+	devtools.opts = opts
+	devtools.streams = make(map[string]func())
+	devtools.onMsg = js.FuncOf(func(this js.Value, args []js.Value) any {
+		e := args[0]
+		// Only messages posted in this window, e.g. by the extension's
+		// content script, not by other frames
+		if !e.Get("source").Equal(js.Global()) {
+			return nil
+		}
+		data := e.Get("data")
+		if data.Type() != js.TypeObject || data.Get("source").String() != devtoolsPanel {
+			return nil
+		}
+		var req devtoolsRequest
+		if err := json.Unmarshal([]byte(js.Global().Get("JSON").Call("stringify", data).String()), &req); err != nil {
+			return nil
+		}
+		if opts := devtools.opts; opts.RequireFlag && storage.Local.Get(DevtoolsFlag) != "1" {
+			return nil
+		}
+		handleDevtools(req)
+		return nil
+	})
+	js.Global().Call("addEventListener", "message", devtools.onMsg)
+	devtoolsPost(map[string]any{"type": "ready", "version": DevtoolsVersion})
+	return disableDevtools
+}
+
+func disableDevtools() {
+	if !devtools.onMsg.Truthy() {
+		return
+	}
+	for _, stop := range devtools.streams {
+		stop()
+	}
+	devtools.streams = nil
+	js.Global().Call("removeEventListener", "message", devtools.onMsg)
+	devtools.onMsg.Release()
+	devtools.onMsg = js.Func{}
+}
+
+// devtoolsPost sends a message to the panel
+func devtoolsPost(msg map[string]any) {
+	msg["source"] = devtoolsPage
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	value := js.Global().Get("JSON").Call("parse", string(data))
+	js.Global().Call("postMessage", value, js.Global().Get("location").Get("origin"))
+}
+
+// handleDevtools answers a request from the panel with a "result" message
+// carrying the same ID
+func handleDevtools(req devtoolsRequest) {
+	result, err := devtoolsResult(req)
+	msg := map[string]any{"type": "result", "id": req.ID, "data": result}
+	if err != nil {
+		msg["error"] = err.Error()
+	}
+	devtoolsPost(msg)
+}
+
+func devtoolsResult(req devtoolsRequest) (any, error) {
+	document := js.Global().Get("document")
+	switch req.Type {
+	case "hello":
+		return map[string]any{"version": DevtoolsVersion, "title": document.Get("title").String(), "url": js.Global().Get("location").Get("href").String()}, nil
+
+	case "components":
+		root := scanComponents(document.Call("getElementById", "app"), 0)
+		if root == nil {
+			return nil, nil
+		}
+		return devtoolsTree(root), nil
+
+	case "element":
+		el := devtoolsElement(req.Path)
+		if !el.Truthy() {
+			return nil, nil
+		}
+		html := el.Get("outerHTML").String()
+		if len(html) > 2000 {
+			html = html[:2000] + "…"
+		}
+		return map[string]any{"html": html, "owners": owner.Chain(el)}, nil
+
+	case "highlight":
+		if el := devtoolsElement(req.Path); el.Truthy() {
+			el.Call("scrollIntoView", map[string]any{"block": "nearest"})
+			flashElement(el)
+		}
+		return nil, nil
+
+	case "stores":
+		var stores []map[string]any
+		for _, store := range state.Stores() {
+			stores = append(stores, map[string]any{"name": store.Name(), "value": store.Snapshot()})
+		}
+		return stores, nil
+
+	case "setStore":
+		for _, store := range state.Stores() {
+			if store.Name() == req.Name {
+				return nil, store.SetJSON(req.Value)
+			}
+		}
+		return nil, errors.New("no store named " + req.Name)
+
+	case "memory":
+		return map[string]any{"current": debug.MemoryStats(), "history": debug.History()}, nil
+
+	case "leaks":
+		var leaks []map[string]any
+		for _, leak := range debug.Leaks() {
+			nodes := make([]string, len(leak.Nodes))
+			for i, n := range leak.Nodes {
+				nodes[i] = n.Description
+			}
+			listeners := make([]map[string]any, len(leak.Listeners))
+			for i, l := range leak.Listeners {
+				listeners[i] = map[string]any{"event": l.Event, "description": l.Description, "component": l.Component, "site": l.Site}
+			}
+			leaks = append(leaks, map[string]any{"kind": leak.Kind, "message": leak.Message, "nodes": nodes, "listeners": listeners})
+		}
+		return leaks, nil
+
+	case "funcs":
+		counts := make([]map[string]any, 0)
+		for _, c := range debug.FuncCounts() {
+			counts = append(counts, map[string]any{"component": c.Component, "created": c.Created, "live": c.Live, "listening": c.Listening})
+		}
+		return map[string]any{"tracking": debug.FuncTracking(), "counts": counts}, nil
+
+	case "trackFuncs":
+		return debug.StartFuncTracking(), nil
+
+	case "a11y":
+		var issues []map[string]any
+		for _, issue := range AuditA11y(document.Call("getElementById", "app")) {
+			issues = append(issues, map[string]any{"rule": issue.Rule, "severity": issue.Severity, "message": issue.Message, "description": issue.Description, "owner": issue.Owner})
+		}
+		return issues, nil
+
+	case "watch":
+		devtoolsWatch(req.Stream, req.On)
+		return nil, nil
+	}
+	return nil, errors.New("unknown request type " + req.Type)
+}
+
+// devtoolsWatch starts or stops sending "event" messages for a stream:
+// "state" with each named store change, or "memory" with each sample
+func devtoolsWatch(stream string, on bool) {
+	if stop, ok := devtools.streams[stream]; ok {
+		stop()
+		delete(devtools.streams, stream)
+	}
+	if !on {
+		return
+	}
+	switch stream {
+	case "state":
+		devtools.streams[stream] = state.OnChange(func(store state.Inspectable) {
+			devtoolsPost(map[string]any{"type": "event", "stream": "state", "data": map[string]any{"name": store.Name(), "value": store.Snapshot()}})
+		})
+	case "memory":
+		if !debug.Monitoring() {
+			debug.StartMonitor(debug.MonitorOptions{})
+		}
+		devtools.streams[stream] = debug.OnSample(func(s debug.MemStats) {
+			devtoolsPost(map[string]any{"type": "event", "stream": "memory", "data": s})
+		})
+	}
+}
+
+func devtoolsTree(node *ComponentNode) devtoolsNode {
+	n := devtoolsNode{Name: node.Name, Type: node.Type, Props: node.Props, Owner: node.Owner}
+	for _, child := range node.Children {
+		n.Children = append(n.Children, devtoolsTree(child))
+	}
+	return n
+}
+
+// devtoolsElement finds the element at path below #app
+func devtoolsElement(path []int) js.Value {
+	el := js.Global().Get("document").Call("getElementById", "app")
+	for _, i := range path {
+		if !el.Truthy() {
+			return js.Undefined()
+		}
+		children := el.Get("children")
+		if i < 0 || i >= children.Length() {
+			return js.Undefined()
+		}
+		el = children.Index(i)
+	}
+	return el
+}
@@ -0,0 +1,255 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/downloads"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// DownloadsTrayProps configures a DownloadsTray
+type DownloadsTrayProps struct {
+	Manager   *downloads.Manager // Manager to follow (default: downloads.Default())
+	Title     string             // Heading (default: "Downloads")
+	MaxItems  int                // Downloads listed, newest first (default 5)
+	ClassName string
+}
+
+// DownloadsTray lists in-progress and recent downloads with their progress,
+// a cancel button while they run, and a button to clear finished ones. It
+// hides itself while there are no downloads.
+type DownloadsTray struct {
+	element     js.Value
+	list        js.Value
+	props       DownloadsTrayProps
+	rows        map[int]*downloadRow
+	clearFunc   js.Func
+	unsubscribe func()
+}
+
+type downloadRow struct {
+	element  js.Value
+	name     js.Value
+	status   js.Value
+	progress *Progress
+	button   js.Value
+	active   bool
+	sized    bool // the size is known, so progress isn't indeterminate
+	onClick  js.Func
+}
+
+// NewDownloadsTray creates a DownloadsTray. Place it once, e.g. in a corner
+// of the layout.
+func NewDownloadsTray(props DownloadsTrayProps) *DownloadsTray {
+	document := js.Global().Get("document")
+
+	if props.Manager == nil {
+		props.Manager = downloads.Default()
+	}
+	if props.Title == "" {
+		props.Title = i18n.T("gux.downloads.title")
+	}
+	if props.MaxItems <= 0 {
+		props.MaxItems = 5
+	}
+
+	t := &DownloadsTray{props: props, rows: make(map[int]*downloadRow)}
+
+	className := "w-80 bg-white border border-gray-200 rounded-lg shadow-lg text-sm"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	t.element = document.Call("createElement", "section")
+	t.element.Set("className", className)
+	t.element.Call("setAttribute", "aria-label", props.Title)
+
+	header := document.Call("createElement", "div")
+	header.Set("className", "flex items-center justify-between px-3 py-2 border-b border-gray-200")
+	title := document.Call("createElement", "h2")
+	title.Set("className", "font-semibold text-gray-900")
+	title.Set("textContent", props.Title)
+	header.Call("appendChild", title)
+
+	t.clearFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+		props.Manager.Clear()
+		return nil
+	})
+	clear := document.Call("createElement", "button")
+	clear.Set("type", "button")
+	clear.Set("className", "text-blue-600 hover:text-blue-800")
+	clear.Set("textContent", i18n.T("gux.downloads.clear"))
+	clear.Call("addEventListener", "click", t.clearFunc)
+	header.Call("appendChild", clear)
+	t.element.Call("appendChild", header)
+
+	t.list = document.Call("createElement", "ul")
+	t.list.Set("className", "divide-y divide-gray-100")
+	t.element.Call("appendChild", t.list)
+
+	t.unsubscribe = props.Manager.Subscribe(func(downloads.Download) {
+		t.render()
+	})
+	t.render()
+	onUnmount(t.element, t.Unmount)
+	return t
+}
+
+// render brings the rows up to date with the manager's downloads
+func (t *DownloadsTray) render() {
+	list := t.props.Manager.Downloads()
+	if len(list) > t.props.MaxItems {
+		list = list[:t.props.MaxItems]
+	}
+
+	shown := make(map[int]bool, len(list))
+	for j, d := range list {
+		shown[d.ID] = true
+		row := t.rows[d.ID]
+		if row == nil || row.active != d.Active() || row.sized != (d.Progress() >= 0) {
+			fresh := t.newRow(d)
+			if row != nil {
+				row.element.Call("replaceWith", fresh.element)
+				row.onClick.Release()
+			}
+			row = fresh
+			t.rows[d.ID] = row
+		}
+		t.updateRow(row, d)
+		// Keep newest first
+		children := t.list.Get("children")
+		if j >= children.Length() {
+			t.list.Call("appendChild", row.element)
+		} else if at := children.Index(j); !at.Equal(row.element) {
+			t.list.Call("insertBefore", row.element, at)
+		}
+	}
+	for id, row := range t.rows {
+		if !shown[id] {
+			row.element.Call("remove")
+			row.onClick.Release()
+			delete(t.rows, id)
+		}
+	}
+
+	if len(list) == 0 {
+		t.element.Get("style").Set("display", "none")
+	} else {
+		t.element.Get("style").Set("display", "")
+	}
+}
+
+// newRow builds a download's row: a progress bar and Cancel button while it
+// runs, a Remove button after
+func (t *DownloadsTray) newRow(d downloads.Download) *downloadRow {
+	document := js.Global().Get("document")
+	row := &downloadRow{active: d.Active(), sized: d.Progress() >= 0}
+
+	row.element = document.Call("createElement", "li")
+	row.element.Set("className", "px-3 py-2")
+
+	top := document.Call("createElement", "div")
+	top.Set("className", "flex items-center gap-2")
+	row.name = document.Call("createElement", "span")
+	row.name.Set("className", "flex-1 truncate font-medium text-gray-900")
+	top.Call("appendChild", row.name)
+
+	id, manager := d.ID, t.props.Manager
+	row.button = document.Call("createElement", "button")
+	row.button.Set("type", "button")
+	row.button.Set("className", "text-gray-400 hover:text-gray-700")
+	if row.active {
+		row.button.Set("textContent", i18n.T("gux.downloads.cancel"))
+		row.button.Call("setAttribute", "aria-label", i18n.T("gux.downloads.cancel.aria", d.Filename))
+		row.onClick = js.FuncOf(func(this js.Value, args []js.Value) any {
+			manager.Cancel(id)
+			return nil
+		})
+	} else {
+		row.button.Set("innerHTML", "&times;")
+		row.button.Call("setAttribute", "aria-label", i18n.T("gux.downloads.remove.aria", d.Filename))
+		row.onClick = js.FuncOf(func(this js.Value, args []js.Value) any {
+			manager.Remove(id)
+			return nil
+		})
+	}
+	row.button.Call("addEventListener", "click", row.onClick)
+	top.Call("appendChild", row.button)
+	row.element.Call("appendChild", top)
+
+	if row.active {
+		row.progress = NewProgress(ProgressProps{
+			Height:        "h-1.5",
+			Indeterminate: !row.sized,
+			AriaLabel:     d.Filename,
+		})
+		row.progress.Element().Get("classList").Call("add", "mt-1")
+		row.element.Call("appendChild", row.progress.Element())
+	}
+
+	row.status = document.Call("createElement", "div")
+	row.status.Set("className", "mt-1 text-xs text-gray-500")
+	row.element.Call("appendChild", row.status)
+	return row
+}
+
+func (t *DownloadsTray) updateRow(row *downloadRow, d downloads.Download) {
+	// The name can change when the response names the file
+	if row.name.Get("textContent").String() != d.Filename {
+		row.name.Set("textContent", d.Filename)
+		row.name.Set("title", d.Filename)
+	}
+	if row.progress != nil && d.Progress() >= 0 {
+		row.progress.SetValue(int(d.Progress() * 100))
+	}
+
+	var text string
+	switch d.Status {
+	case downloads.Downloading:
+		if d.Total > 0 {
+			text = i18n.T("gux.downloads.progress", formatFileSize(d.Loaded), formatFileSize(d.Total))
+		} else {
+			text = formatFileSize(d.Loaded)
+		}
+	case downloads.Done:
+		text = i18n.T("gux.downloads.done", formatFileSize(d.Loaded))
+	case downloads.Canceled:
+		text = i18n.T("gux.downloads.canceled")
+	case downloads.Failed:
+		text = i18n.T("gux.downloads.failed")
+		row.status.Set("className", "mt-1 text-xs text-red-600")
+		row.status.Set("title", d.Err.Error())
+	}
+	row.status.Set("textContent", text)
+}
+
+// Element returns the tray's DOM element
+func (t *DownloadsTray) Element() js.Value {
+	return t.element
+}
+
+// Mount appends the tray to parent
+func (t *DownloadsTray) Mount(parent js.Value) {
+	parent.Call("appendChild", t.element)
+}
+
+// Unmount removes the tray and releases its listeners
+func (t *DownloadsTray) Unmount() {
+	t.Destroy()
+	unmount(t.element)
+}
+
+// Destroy stops following the manager and releases event listeners. It
+// doesn't cancel downloads.
+func (t *DownloadsTray) Destroy() {
+	if t.unsubscribe != nil {
+		t.unsubscribe()
+		t.unsubscribe = nil
+	}
+	for id, row := range t.rows {
+		row.onClick.Release()
+		delete(t.rows, id)
+	}
+	t.clearFunc.Release()
+}
@@ -2,7 +2,11 @@
 
 package components
 
-import "syscall/js"
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/prefs"
+)
 
 // DrawerPosition defines which side the drawer opens from
 type DrawerPosition string
@@ -24,6 +28,7 @@ type DrawerProps struct {
 	ShowClose  bool           // Show close button (default true)
 	Overlay    bool           // Show overlay behind drawer (default true)
 	CloseOnEsc bool           // Close on Escape key (default true)
+	PersistKey string         // Restore/save width via prefs.Layout (left/right drawers)
 	OnClose    func()
 }
 
@@ -33,7 +38,7 @@ type Drawer struct {
 	drawer    js.Value
 	isOpen    bool
 	props     DrawerProps
-	escHandler js.Func
+	listeners listeners
 }
 
 // NewDrawer creates a new Drawer component
@@ -50,6 +55,9 @@ func NewDrawer(props DrawerProps) *Drawer {
 	if props.Height == "" {
 		props.Height = "auto"
 	}
+	if props.PersistKey != "" {
+		props.Width = prefs.Layout.GetString(prefs.DrawerWidthKey(props.PersistKey), props.Width)
+	}
 
 	d := &Drawer{props: props}
 
@@ -117,7 +125,7 @@ func NewDrawer(props DrawerProps) *Drawer {
 			closeBtn.Set("className", "p-1 hover:surface-overlay rounded text-secondary text-xl")
 			closeBtn.Set("textContent", "×")
 			closeBtn.Call("setAttribute", "aria-label", "Close drawer")
-			closeBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			closeBtn.Call("addEventListener", "click", d.listeners.fn(func(this js.Value, args []js.Value) any {
 				d.Close()
 				return nil
 			}))
@@ -137,7 +145,7 @@ func NewDrawer(props DrawerProps) *Drawer {
 
 	// Overlay click to close
 	if props.Overlay {
-		overlay.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		overlay.Call("addEventListener", "click", d.listeners.fn(func(this js.Value, args []js.Value) any {
 			d.Close()
 			return nil
 		}))
@@ -145,13 +153,12 @@ func NewDrawer(props DrawerProps) *Drawer {
 
 	// Escape key handler
 	if props.CloseOnEsc {
-		d.escHandler = js.FuncOf(func(this js.Value, args []js.Value) any {
+		d.listeners.on(document, "keydown", func(this js.Value, args []js.Value) any {
 			if d.isOpen && args[0].Get("key").String() == "Escape" {
 				d.Close()
 			}
 			return nil
 		})
-		document.Call("addEventListener", "keydown", d.escHandler)
 	}
 
 	// Append to body
@@ -162,6 +169,23 @@ func NewDrawer(props DrawerProps) *Drawer {
 	return d
 }
 
+// SetWidth changes the width of a left/right drawer and persists it when PersistKey is set
+func (d *Drawer) SetWidth(width string) {
+	if d.props.Position != DrawerLeft && d.props.Position != DrawerRight {
+		return
+	}
+	d.props.Width = width
+	d.drawer.Get("style").Set("width", width)
+	if d.props.PersistKey != "" {
+		prefs.Layout.SetString(prefs.DrawerWidthKey(d.props.PersistKey), width)
+	}
+}
+
+// Width returns the current drawer width
+func (d *Drawer) Width() string {
+	return d.props.Width
+}
+
 // Open opens the drawer
 func (d *Drawer) Open() {
 	if d.isOpen {
@@ -203,12 +227,11 @@ func (d *Drawer) Close() {
 	js.Global().Get("document").Get("body").Get("style").Set("overflow", "")
 
 	// Hide overlay after animation
-	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
+	setTimeout(func() {
 		if !d.isOpen {
 			d.overlay.Get("classList").Call("add", "hidden")
 		}
-		return nil
-	}), 300)
+	}, 300)
 
 	if d.props.OnClose != nil {
 		d.props.OnClose()
@@ -229,10 +252,11 @@ func (d *Drawer) IsOpen() bool {
 	return d.isOpen
 }
 
-// SetContent updates the drawer content
+// SetContent updates the drawer content, unmounting the components in the
+// content it replaces
 func (d *Drawer) SetContent(content js.Value) {
 	contentArea := d.drawer.Call("querySelector", ".overflow-auto")
-	contentArea.Set("innerHTML", "")
+	UnmountChildren(contentArea)
 	contentArea.Call("appendChild", content)
 }
 
@@ -241,14 +265,28 @@ func (d *Drawer) Element() js.Value {
 	return d.drawer
 }
 
-// Destroy removes the drawer from DOM and cleans up
+// Mount moves the drawer and its overlay from the body to parent
+func (d *Drawer) Mount(parent js.Value) {
+	parent.Call("appendChild", d.overlay)
+	parent.Call("appendChild", d.drawer)
+}
+
+// Unmount removes the drawer and unmounts its content. It is the same as
+// Destroy.
+func (d *Drawer) Unmount() {
+	d.Destroy()
+}
+
+// Destroy removes the drawer from DOM, unmounts its content and releases
+// its listeners
 func (d *Drawer) Destroy() {
-	if d.props.CloseOnEsc {
-		js.Global().Get("document").Call("removeEventListener", "keydown", d.escHandler)
-		d.escHandler.Release()
+	if d.isOpen {
+		d.isOpen = false
+		js.Global().Get("document").Get("body").Get("style").Set("overflow", "")
 	}
+	unmount(d.drawer)
 	d.overlay.Call("remove")
-	d.drawer.Call("remove")
+	d.listeners.release()
 }
 
 // RightDrawer creates a drawer that slides from the right
@@ -29,10 +29,10 @@ type Dropdown struct {
 	menu         js.Value
 	menuID       string // unique ID for aria-controls
 	isOpen       bool
-	cleanup      js.Func
 	highlightIdx int
 	menuItems    []js.Value
 	keyHandler   js.Func
+	listeners    listeners
 }
 
 // NewDropdown creates a new Dropdown component
@@ -129,7 +129,7 @@ func NewDropdown(props DropdownProps) *Dropdown {
 
 		if !item.Disabled && item.OnClick != nil {
 			onClick := item.OnClick
-			menuItem.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			menuItem.Call("addEventListener", "click", d.listeners.fn(func(this js.Value, args []js.Value) any {
 				d.Close()
 				onClick()
 				return nil
@@ -139,7 +139,7 @@ func NewDropdown(props DropdownProps) *Dropdown {
 		// Add mouseenter handler to sync highlight on hover
 		if !item.Disabled {
 			idx := itemIdx
-			menuItem.Call("addEventListener", "mouseenter", js.FuncOf(func(this js.Value, args []js.Value) any {
+			menuItem.Call("addEventListener", "mouseenter", d.listeners.fn(func(this js.Value, args []js.Value) any {
 				d.highlightIdx = idx
 				d.updateHighlightStyles()
 				return nil
@@ -155,14 +155,14 @@ func NewDropdown(props DropdownProps) *Dropdown {
 	d.menu = menu
 
 	// Toggle on trigger click
-	triggerWrap.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	triggerWrap.Call("addEventListener", "click", d.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("stopPropagation")
 		d.Toggle()
 		return nil
 	}))
 
 	// Close on blur (when focus leaves dropdown)
-	menu.Call("addEventListener", "focusout", js.FuncOf(func(this js.Value, args []js.Value) any {
+	menu.Call("addEventListener", "focusout", d.listeners.fn(func(this js.Value, args []js.Value) any {
 		if !d.isOpen {
 			return nil
 		}
@@ -176,7 +176,7 @@ func NewDropdown(props DropdownProps) *Dropdown {
 	}))
 
 	// Close on outside click
-	d.cleanup = js.FuncOf(func(this js.Value, args []js.Value) any {
+	d.listeners.on(document, "click", func(this js.Value, args []js.Value) any {
 		if d.isOpen {
 			target := args[0].Get("target")
 			if !container.Call("contains", target).Bool() {
@@ -185,8 +185,8 @@ func NewDropdown(props DropdownProps) *Dropdown {
 		}
 		return nil
 	})
-	js.Global().Get("document").Call("addEventListener", "click", d.cleanup)
 
+	onUnmount(d.container, d.Unmount)
 	return d
 }
 
@@ -195,6 +195,17 @@ func (d *Dropdown) Element() js.Value {
 	return d.container
 }
 
+// Mount appends the dropdown to parent
+func (d *Dropdown) Mount(parent js.Value) {
+	parent.Call("appendChild", d.container)
+}
+
+// Unmount removes the dropdown and releases its listeners
+func (d *Dropdown) Unmount() {
+	d.Destroy()
+	unmount(d.container)
+}
+
 // Open opens the dropdown menu
 func (d *Dropdown) Open() {
 	if d.isOpen {
@@ -343,8 +354,7 @@ func (d *Dropdown) IsOpen() bool {
 func (d *Dropdown) Destroy() {
 	// Close first to clean up keyHandler
 	d.Close()
-	js.Global().Get("document").Call("removeEventListener", "click", d.cleanup)
-	d.cleanup.Release()
+	d.listeners.release()
 }
 
 // ActionDropdown creates a dropdown with a button trigger
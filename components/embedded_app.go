@@ -0,0 +1,353 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/auth"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// embedProtocol marks the bridge's messages, so other postMessage traffic
+// is ignored
+const embedProtocol = "gux-embed"
+
+// ErrEmbeddedAppClosed is returned by calls pending when an EmbeddedApp is
+// unmounted
+var ErrEmbeddedAppClosed = errors.New("embedded app: closed")
+
+// EmbeddedMethod answers a call from an embedded app. The result is sent
+// back as JSON.
+type EmbeddedMethod func(ctx context.Context, params json.RawMessage) (any, error)
+
+// EmbeddedHandler makes an EmbeddedMethod of a typed function, decoding
+// the call's params into P:
+//
+//	Methods: map[string]components.EmbeddedMethod{
+//		"getCustomer": components.EmbeddedHandler(func(ctx context.Context, p struct{ ID int }) (api.Customer, error) {
+//			return client.GetCustomer(ctx, p.ID)
+//		}),
+//	},
+func EmbeddedHandler[P, R any](fn func(ctx context.Context, params P) (R, error)) EmbeddedMethod {
+	return func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params P
+		if len(raw) > 0 && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &params); err != nil {
+				return nil, fmt.Errorf("bad params: %w", err)
+			}
+		}
+		return fn(ctx, params)
+	}
+}
+
+// EmbeddedAppProps configures an EmbeddedApp
+type EmbeddedAppProps struct {
+	URL   string // the app to embed
+	Title string // the frame's accessible name (default "Embedded app")
+
+	// Origin is the only origin messages are sent to and accepted from
+	// (default URL's origin)
+	Origin string
+
+	// Sandbox restricts the frame (default "allow-scripts allow-same-origin
+	// allow-forms allow-popups"). allow-same-origin keeps the app's own
+	// origin, which the bridge needs. When the app is served from the
+	// host's origin, that origin is the host's: with allow-scripts, the
+	// app can remove its own sandbox and reach the host page, so only embed
+	// same-origin apps you trust, and serve third-party ones from another
+	// origin. NewEmbeddedApp warns in the console in that case.
+	Sandbox string
+	Allow   string // permissions policy, e.g. "clipboard-write"
+
+	PassToken  bool // send the auth token when the app is ready and whenever it changes
+	AutoResize bool // size the frame to the height the app reports
+	MinHeight  int  // smallest height in px when auto-resizing (default 150)
+	MaxHeight  int  // largest height in px when auto-resizing (default none)
+
+	// Methods are what the app may call on the host, by name
+	Methods map[string]EmbeddedMethod
+
+	OnReady    func()            // the app's bridge is listening
+	OnNavigate func(path string) // the app moved to another page
+
+	ClassName string
+}
+
+// EmbeddedApp mounts another app, such as a legacy or third-party
+// frontend, in an iframe, with a postMessage bridge to it: typed calls both
+// ways, the auth token, the app's height, and its navigation. Messages go
+// only to the app's origin and are only accepted from its frame.
+//
+//	billing := components.NewEmbeddedApp(components.EmbeddedAppProps{
+//		URL:        "https://billing.example.com/embed",
+//		Title:      "Billing",
+//		PassToken:  true,
+//		AutoResize: true,
+//		OnNavigate: func(path string) { router.SetQuery(map[string]string{"billing": path}) },
+//	})
+//
+// The embedded app speaks the protocol described in the components docs;
+// a few lines of JavaScript in any frontend are enough.
+type EmbeddedApp struct {
+	frame  js.Value
+	origin string
+	props  EmbeddedAppProps
+
+	ready   bool
+	queue   []map[string]any // posted before the app was ready
+	nextID  int
+	pending map[int]chan embedMessage
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	listeners listeners
+}
+
+// embedMessage is a message of the bridge, in either direction
+type embedMessage struct {
+	Protocol string          `json:"protocol"`
+	Type     string          `json:"type"` // ready, token, resize, navigate, call, or result
+	ID       int             `json:"id,omitempty"`
+	Method   string          `json:"method,omitempty"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Height   float64         `json:"height,omitempty"`
+	Path     string          `json:"path,omitempty"`
+}
+
+// NewEmbeddedApp creates an EmbeddedApp loading props.URL
+func NewEmbeddedApp(props EmbeddedAppProps) *EmbeddedApp {
+	if props.Title == "" {
+		props.Title = i18n.T("gux.embed.title")
+	}
+	if props.Sandbox == "" {
+		props.Sandbox = "allow-scripts allow-same-origin allow-forms allow-popups"
+	}
+	if props.MinHeight <= 0 {
+		props.MinHeight = 150
+	}
+	origin := props.Origin
+	if origin == "" {
+		origin = js.Global().Get("URL").New(props.URL, js.Global().Get("location").Get("href")).Get("origin").String()
+	}
+
+	if origin == js.Global().Get("location").Get("origin").String() &&
+		strings.Contains(props.Sandbox, "allow-scripts") && strings.Contains(props.Sandbox, "allow-same-origin") {
+		js.Global().Get("console").Call("warn", "EmbeddedApp: "+props.URL+" is same-origin, so allow-scripts with allow-same-origin doesn't sandbox it")
+	}
+
+	a := &EmbeddedApp{
+		origin:  origin,
+		props:   props,
+		pending: make(map[int]chan embedMessage),
+	}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+
+	className := props.ClassName
+	if className == "" {
+		className = Classes("embed")
+	}
+	frame := El("iframe", className)
+	frame.Set("title", props.Title)
+	frame.Call("setAttribute", "sandbox", props.Sandbox)
+	if props.Allow != "" {
+		frame.Set("allow", props.Allow)
+	}
+	if props.AutoResize {
+		frame.Get("style").Set("height", strconv.Itoa(props.MinHeight)+"px")
+	}
+	frame.Set("src", props.URL)
+	a.frame = frame
+
+	a.listeners.on(js.Global(), "message", func(this js.Value, args []js.Value) any {
+		a.receive(args[0])
+		return nil
+	})
+	if props.PassToken {
+		a.listeners.onRelease(auth.OnAuthChange(func(auth.AuthState) {
+			if a.ready {
+				a.send(map[string]any{"type": "token", "token": auth.GetToken()})
+			}
+		}))
+	}
+	onUnmount(frame, a.Unmount)
+	return a
+}
+
+// Element returns the iframe
+func (a *EmbeddedApp) Element() js.Value {
+	return a.frame
+}
+
+// Mount appends the frame to parent
+func (a *EmbeddedApp) Mount(parent js.Value) {
+	parent.Call("appendChild", a.frame)
+}
+
+// Unmount removes the frame, fails pending calls with
+// ErrEmbeddedAppClosed, and releases its listeners
+func (a *EmbeddedApp) Unmount() {
+	a.cancel()
+	unmount(a.frame)
+	a.listeners.release()
+}
+
+// Ready reports whether the app's bridge has said it is listening
+func (a *EmbeddedApp) Ready() bool {
+	return a.ready
+}
+
+// Call calls method in the app with params and decodes its result into
+// result, which may be nil. Calls made before the app is ready are sent
+// once it is. It blocks until the app answers, so call it from a
+// goroutine, with a deadline in ctx.
+func (a *EmbeddedApp) Call(ctx context.Context, method string, params, result any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("embedded app: %s: %w", method, err)
+	}
+	id := a.nextID + 1
+	a.nextID = id
+	reply := make(chan embedMessage, 1)
+	a.pending[id] = reply
+	defer delete(a.pending, id)
+
+	a.post(map[string]any{"type": "call", "id": id, "method": method, "params": json.RawMessage(raw)})
+	select {
+	case msg := <-reply:
+		if msg.Error != "" {
+			return fmt.Errorf("embedded app: %s: %s", method, msg.Error)
+		}
+		if result == nil || len(msg.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-a.ctx.Done():
+		return ErrEmbeddedAppClosed
+	}
+}
+
+// CallEmbedded is Call with a typed result
+func CallEmbedded[R any](ctx context.Context, a *EmbeddedApp, method string, params any) (R, error) {
+	var result R
+	err := a.Call(ctx, method, params, &result)
+	return result, err
+}
+
+// Navigate asks the app to show path
+func (a *EmbeddedApp) Navigate(path string) {
+	a.post(map[string]any{"type": "navigate", "path": path})
+}
+
+// receive handles a message event, ignoring any not from the frame
+func (a *EmbeddedApp) receive(event js.Value) {
+	window := a.frame.Get("contentWindow")
+	if !window.Truthy() || !event.Get("source").Equal(window) || event.Get("origin").String() != a.origin {
+		return
+	}
+	data := event.Get("data")
+	if data.Type() != js.TypeObject || data.Get("protocol").String() != embedProtocol {
+		return
+	}
+	var msg embedMessage
+	if err := json.Unmarshal([]byte(js.Global().Get("JSON").Call("stringify", data).String()), &msg); err != nil {
+		return
+	}
+
+	switch msg.Type {
+	case "ready":
+		a.ready = true
+		if a.props.PassToken {
+			a.send(map[string]any{"type": "token", "token": auth.GetToken()})
+		}
+		queue := a.queue
+		a.queue = nil
+		for _, m := range queue {
+			a.send(m)
+		}
+		if a.props.OnReady != nil {
+			a.props.OnReady()
+		}
+	case "resize":
+		if a.props.AutoResize && msg.Height > 0 {
+			height := max(int(math.Ceil(msg.Height)), a.props.MinHeight)
+			if a.props.MaxHeight > 0 {
+				height = min(height, a.props.MaxHeight)
+			}
+			a.frame.Get("style").Set("height", strconv.Itoa(height)+"px")
+		}
+	case "navigate":
+		if a.props.OnNavigate != nil {
+			a.props.OnNavigate(msg.Path)
+		}
+	case "call":
+		a.answer(msg)
+	case "result":
+		// The first result settles the call; a frame answering again
+		// mustn't block the page's event loop
+		if reply, ok := a.pending[msg.ID]; ok {
+			delete(a.pending, msg.ID)
+			select {
+			case reply <- msg:
+			default:
+			}
+		}
+	}
+}
+
+// answer runs the method the app called and posts its result
+func (a *EmbeddedApp) answer(msg embedMessage) {
+	method, ok := a.props.Methods[msg.Method]
+	if !ok {
+		a.post(map[string]any{"type": "result", "id": msg.ID, "error": "unknown method " + msg.Method})
+		return
+	}
+	go func() {
+		result, err := method(a.ctx, msg.Params)
+		if a.ctx.Err() != nil {
+			return
+		}
+		var raw []byte
+		if err == nil {
+			raw, err = json.Marshal(result)
+		}
+		if err != nil {
+			a.post(map[string]any{"type": "result", "id": msg.ID, "error": err.Error()})
+			return
+		}
+		a.post(map[string]any{"type": "result", "id": msg.ID, "result": json.RawMessage(raw)})
+	}()
+}
+
+// post sends msg to the app, or queues it until the app is ready
+func (a *EmbeddedApp) post(msg map[string]any) {
+	if !a.ready {
+		a.queue = append(a.queue, msg)
+		return
+	}
+	a.send(msg)
+}
+
+func (a *EmbeddedApp) send(msg map[string]any) {
+	window := a.frame.Get("contentWindow")
+	if !window.Truthy() {
+		return
+	}
+	msg["protocol"] = embedProtocol
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	window.Call("postMessage", js.Global().Get("JSON").Call("parse", string(data)), a.origin)
+}
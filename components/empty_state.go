@@ -2,7 +2,11 @@
 
 package components
 
-import "syscall/js"
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
 
 // EmptyStateProps configures an EmptyState component
 type EmptyStateProps struct {
@@ -110,7 +114,7 @@ func NoData(title string) *EmptyState {
 	return NewEmptyState(EmptyStateProps{
 		Icon:        "📭",
 		Title:       title,
-		Description: "There's nothing here yet.",
+		Description: i18n.T("gux.empty.noData.desc"),
 	})
 }
 
@@ -118,9 +122,9 @@ func NoData(title string) *EmptyState {
 func NoResults(onClear func()) *EmptyState {
 	return NewEmptyState(EmptyStateProps{
 		Icon:        "🔍",
-		Title:       "No results found",
-		Description: "Try adjusting your search or filter to find what you're looking for.",
-		ActionLabel: "Clear filter",
+		Title:       i18n.T("gux.empty.noResults.title"),
+		Description: i18n.T("gux.empty.noResults.desc"),
+		ActionLabel: i18n.T("gux.empty.noResults.action"),
 		OnAction:    onClear,
 	})
 }
@@ -129,8 +133,8 @@ func NoResults(onClear func()) *EmptyState {
 func NoSelection() *EmptyState {
 	return NewEmptyState(EmptyStateProps{
 		Icon:        "👆",
-		Title:       "Nothing selected",
-		Description: "Select items to see details or perform actions.",
+		Title:       i18n.T("gux.empty.noSelection.title"),
+		Description: i18n.T("gux.empty.noSelection.desc"),
 		Compact:     true,
 	})
 }
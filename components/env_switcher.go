@@ -0,0 +1,285 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/auth"
+	"github.com/dougbarrett/gux/fetch"
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/storage"
+)
+
+// EnvironmentSwitcherFlag is the localStorage key that shows an
+// EnvironmentSwitcher created with RequireFlag, e.g. from the console:
+//
+//	localStorage.setItem("gux-env-switcher", "1")
+const EnvironmentSwitcherFlag = "gux-env-switcher"
+
+// EnvironmentSwitcherProps configures an EnvironmentSwitcher
+type EnvironmentSwitcherProps struct {
+	Environments []fetch.Environment // the first is the default
+	Position     string              // "bottom-left" (default) or "bottom-right"
+
+	// RequireFlag hides the switcher, and ignores a saved choice, unless
+	// localStorage has EnvironmentSwitcherFlag set to "1"
+	RequireFlag bool
+
+	// OnChange is called after the user picks an environment. The default
+	// reloads the page, so nothing loaded from the previous backend stays
+	// on screen.
+	OnChange func(env fetch.Environment)
+}
+
+// EnvironmentSwitcher is a floating button for development and QA builds
+// that points the generated API clients at another backend, so one bundle
+// can be tested against local, staging, and production servers without a
+// rebuild. The choice is saved in localStorage, and each environment keeps
+// its own sign-in.
+type EnvironmentSwitcher struct {
+	element   js.Value
+	button    js.Value
+	label     js.Value
+	panel     js.Value
+	options   []js.Value
+	identity  js.Value
+	props     EnvironmentSwitcherProps
+	current   int
+	saved     *storage.Value[string]
+	listeners listeners
+}
+
+// NewEnvironmentSwitcher creates an EnvironmentSwitcher and switches to the
+// saved environment. Create it before the app makes its first requests:
+//
+//	components.NewEnvironmentSwitcher(components.EnvironmentSwitcherProps{
+//		Environments: []fetch.Environment{
+//			{Name: "local"},
+//			{Name: "staging", BaseURL: "https://staging.example.com"},
+//			{Name: "production", BaseURL: "https://api.example.com"},
+//		},
+//	}).Mount(js.Global().Get("document").Get("body"))
+func NewEnvironmentSwitcher(props EnvironmentSwitcherProps) *EnvironmentSwitcher {
+	document := js.Global().Get("document")
+	e := &EnvironmentSwitcher{
+		props: props,
+		saved: storage.Typed[string]("gux-environment"),
+	}
+
+	position := "bottom-4 left-4"
+	if props.Position == "bottom-right" {
+		position = "bottom-4 right-4"
+	}
+	e.element = document.Call("createElement", "div")
+	e.element.Set("className", "fixed "+position+" z-50 text-sm")
+
+	enabled := len(props.Environments) > 0 && (!props.RequireFlag || storage.Local.Get(EnvironmentSwitcherFlag) == "1")
+	if !enabled {
+		e.element.Get("style").Set("display", "none")
+		return e
+	}
+
+	// Restore the saved choice
+	if name, ok := e.saved.Get(); ok {
+		for i, env := range props.Environments {
+			if env.Name == name {
+				e.current = i
+			}
+		}
+	}
+	e.apply()
+
+	e.panel = document.Call("createElement", "div")
+	e.panel.Set("id", "gux-env-switcher")
+	e.panel.Set("className", "absolute bottom-full mb-2 w-72 surface-raised border border-default rounded-lg shadow-lg p-3 space-y-2")
+	e.panel.Set("hidden", true)
+	e.panel.Call("setAttribute", "role", "dialog")
+	e.panel.Call("setAttribute", "aria-label", i18n.T("gux.env.title"))
+	if props.Position == "bottom-right" {
+		e.panel.Get("classList").Call("add", "right-0")
+	}
+
+	title := document.Call("createElement", "div")
+	title.Set("className", "text-xs font-semibold uppercase tracking-wide text-tertiary")
+	title.Set("textContent", i18n.T("gux.env.title"))
+	e.panel.Call("appendChild", title)
+
+	list := document.Call("createElement", "div")
+	list.Set("className", "space-y-1")
+	list.Call("setAttribute", "role", "radiogroup")
+	for i, env := range props.Environments {
+		option := document.Call("createElement", "button")
+		option.Set("type", "button")
+		option.Set("className", "w-full text-left px-2 py-1.5 rounded hover:surface-overlay")
+		option.Call("setAttribute", "role", "radio")
+
+		name := document.Call("createElement", "div")
+		name.Set("className", "font-medium text-primary")
+		name.Set("textContent", env.Name)
+		option.Call("appendChild", name)
+
+		url := document.Call("createElement", "div")
+		url.Set("className", "text-xs text-tertiary truncate")
+		url.Set("textContent", env.BaseURL)
+		if env.BaseURL == "" {
+			url.Set("textContent", i18n.T("gux.env.sameOrigin"))
+		}
+		option.Call("appendChild", url)
+
+		option.Call("addEventListener", "click", e.listeners.fn(func(this js.Value, args []js.Value) any {
+			e.Switch(i)
+			return nil
+		}))
+		list.Call("appendChild", option)
+		e.options = append(e.options, option)
+	}
+	e.panel.Call("appendChild", list)
+
+	e.identity = document.Call("createElement", "div")
+	e.identity.Set("className", "border-t border-default pt-2 text-xs text-secondary truncate")
+	e.panel.Call("appendChild", e.identity)
+	e.listeners.onRelease(auth.OnAuthChange(func(state auth.AuthState) {
+		e.showIdentity(state.User)
+	}))
+
+	e.button = document.Call("createElement", "button")
+	e.button.Set("type", "button")
+	e.button.Call("setAttribute", "aria-haspopup", "dialog")
+	e.button.Call("setAttribute", "aria-controls", "gux-env-switcher")
+	e.button.Call("setAttribute", "aria-expanded", "false")
+	dot := document.Call("createElement", "span")
+	dot.Set("className", "w-2 h-2 rounded-full bg-current opacity-75")
+	e.button.Call("appendChild", dot)
+	e.label = document.Call("createElement", "span")
+	e.button.Call("appendChild", e.label)
+	e.button.Call("addEventListener", "click", e.listeners.fn(func(this js.Value, args []js.Value) any {
+		e.setOpen(e.panel.Get("hidden").Bool())
+		return nil
+	}))
+
+	e.element.Call("appendChild", e.panel)
+	e.element.Call("appendChild", e.button)
+	e.render()
+
+	// Close on Escape and on clicks elsewhere
+	e.listeners.on(document, "keydown", func(this js.Value, args []js.Value) any {
+		if args[0].Get("key").String() == "Escape" && !e.panel.Get("hidden").Bool() {
+			e.setOpen(false)
+			e.button.Call("focus")
+		}
+		return nil
+	})
+	e.listeners.on(document, "click", func(this js.Value, args []js.Value) any {
+		if !e.element.Call("contains", args[0].Get("target")).Bool() {
+			e.setOpen(false)
+		}
+		return nil
+	})
+
+	onUnmount(e.element, e.Unmount)
+	return e
+}
+
+// Current returns the environment in use
+func (e *EnvironmentSwitcher) Current() fetch.Environment {
+	if len(e.props.Environments) == 0 {
+		return fetch.Environment{}
+	}
+	return e.props.Environments[e.current]
+}
+
+// Switch points the API clients at the environment at index, saves the
+// choice, and calls OnChange
+func (e *EnvironmentSwitcher) Switch(index int) {
+	if e.panel.IsUndefined() || index < 0 || index >= len(e.props.Environments) {
+		return // hidden by RequireFlag, or no such environment
+	}
+	e.setOpen(false)
+	if index == e.current {
+		return
+	}
+	e.current = index
+	env := e.props.Environments[index]
+	if index == 0 {
+		e.saved.Remove()
+	} else {
+		e.saved.Set(env.Name)
+	}
+	e.apply()
+	e.render()
+
+	if e.props.OnChange != nil {
+		e.props.OnChange(env)
+	} else {
+		js.Global().Get("location").Call("reload")
+	}
+}
+
+// apply makes the current environment the one fetch and auth use. The
+// default environment keeps auth's usual storage key, so sign-ins from
+// before the switcher was added still apply.
+func (e *EnvironmentSwitcher) apply() {
+	env := e.props.Environments[e.current]
+	fetch.UseEnvironment(&env)
+	if e.current == 0 {
+		auth.UseStorageKey("auth_state")
+	} else {
+		auth.UseStorageKey("auth_state:" + env.Name)
+	}
+}
+
+func (e *EnvironmentSwitcher) render() {
+	env := e.props.Environments[e.current]
+	e.label.Set("textContent", env.Name)
+	e.button.Call("setAttribute", "aria-label", i18n.T("gux.env.switch", env.Name))
+	// Anything but the default stands out, so testers know where they are
+	color := "bg-gray-800 text-white"
+	if e.current != 0 {
+		color = "bg-amber-500 text-white"
+	}
+	e.button.Set("className", "flex items-center gap-2 px-3 py-1.5 rounded-full shadow-lg font-medium "+color)
+	for i, option := range e.options {
+		option.Call("setAttribute", "aria-checked", strconv.FormatBool(i == e.current))
+		option.Get("classList").Call("toggle", "surface-overlay", i == e.current)
+	}
+}
+
+func (e *EnvironmentSwitcher) showIdentity(user *auth.User) {
+	switch {
+	case user == nil:
+		e.identity.Set("textContent", i18n.T("gux.env.signedOut"))
+	case user.Name != "" && user.Email != "":
+		e.identity.Set("textContent", i18n.T("gux.env.signedIn", user.Name+" <"+user.Email+">"))
+	case user.Name != "":
+		e.identity.Set("textContent", i18n.T("gux.env.signedIn", user.Name))
+	default:
+		e.identity.Set("textContent", i18n.T("gux.env.signedIn", user.Email))
+	}
+}
+
+func (e *EnvironmentSwitcher) setOpen(open bool) {
+	if e.panel.IsUndefined() {
+		return
+	}
+	e.panel.Set("hidden", !open)
+	e.button.Call("setAttribute", "aria-expanded", strconv.FormatBool(open))
+}
+
+// Element returns the container DOM element
+func (e *EnvironmentSwitcher) Element() js.Value {
+	return e.element
+}
+
+// Mount appends the switcher to parent
+func (e *EnvironmentSwitcher) Mount(parent js.Value) {
+	parent.Call("appendChild", e.element)
+}
+
+// Unmount removes the switcher and releases its listeners. The environment
+// stays in use.
+func (e *EnvironmentSwitcher) Unmount() {
+	unmount(e.element)
+	e.listeners.release()
+}
@@ -43,6 +43,11 @@ func triggerDownload(data []byte, filename, mimeType string) {
 	URL.Call("revokeObjectURL", objectURL)
 }
 
+// DownloadFile saves data as a file through the browser's download prompt
+func DownloadFile(data []byte, filename, mimeType string) {
+	triggerDownload(data, filename, mimeType)
+}
+
 // escapeCSVField escapes a field for CSV output
 // Handles quotes, commas, and newlines
 func escapeCSVField(value string) string {
@@ -0,0 +1,383 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dougbarrett/gux/types"
+)
+
+// XLSXExportOptions configures XLSX export behavior
+type XLSXExportOptions struct {
+	SheetName string // Name of the sheet (default "Sheet1")
+	GroupBy   string // Data key to split rows into one sheet per value, named after it
+}
+
+// XLSXSheet is one worksheet of a workbook written by ExportXLSXSheets
+type XLSXSheet struct {
+	Name    string           // Sheet name, shortened to Excel's 31 characters
+	Headers []string         // Column headers
+	Keys    []string         // Data keys, in the same order as Headers
+	Data    []map[string]any // Rows
+}
+
+// ExportXLSX exports data to an Excel workbook and triggers browser download.
+// headers are the column display names, keys are the data field keys.
+// Numbers, types.Money, booleans, and dates (time.Time, or strings in
+// RFC 3339 or "2006-01-02" form) are written as typed cells, so they sort
+// and sum in Excel; everything else is text.
+func ExportXLSX(data []map[string]any, headers []string, keys []string, filename string, options XLSXExportOptions) {
+	if len(data) == 0 || len(keys) == 0 {
+		return
+	}
+
+	var sheets []XLSXSheet
+	if options.GroupBy == "" {
+		sheets = []XLSXSheet{{Name: options.SheetName, Headers: headers, Keys: keys, Data: data}}
+	} else {
+		// One sheet per group, in order of first appearance
+		index := make(map[string]int)
+		for _, row := range data {
+			name := toString(row[options.GroupBy])
+			i, ok := index[name]
+			if !ok {
+				i = len(sheets)
+				index[name] = i
+				sheets = append(sheets, XLSXSheet{Name: name, Headers: headers, Keys: keys})
+			}
+			sheets[i].Data = append(sheets[i].Data, row)
+		}
+	}
+	ExportXLSXSheets(sheets, filename)
+}
+
+// ExportXLSXSheets exports several sheets to one Excel workbook and triggers
+// browser download
+func ExportXLSXSheets(sheets []XLSXSheet, filename string) {
+	if len(sheets) == 0 {
+		return
+	}
+	data, err := buildXLSX(sheets)
+	if err != nil {
+		ShowError(err.Error())
+		return
+	}
+
+	// Ensure filename has .xlsx extension
+	if !strings.HasSuffix(filename, ".xlsx") {
+		filename += ".xlsx"
+	}
+
+	triggerDownload(data, filename, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+}
+
+// Cell styles, indexes into cellXfs of xlsxStyles
+const (
+	xlsxStyleDefault  = iota
+	xlsxStyleHeader   // bold
+	xlsxStyleDate     // numFmt 14, e.g. 3/14/2024
+	xlsxStyleDateTime // numFmt 22, e.g. 3/14/2024 13:30
+	xlsxStyleDecimal  // numFmt 4, #,##0.00
+	xlsxStyleInteger  // numFmt 3, #,##0
+)
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="6">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+</cellXfs>
+</styleSheet>`
+
+// xlsxCell is a typed cell value
+type xlsxCell struct {
+	kind  byte // 'n' number, 'b' boolean, 's' text, 0 empty
+	text  string
+	style int
+	width int // characters, for the column width
+}
+
+// xlsxEpoch is day 0 of Excel's date serial numbers
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// newXLSXCell converts a data value to a cell
+func newXLSXCell(v any) xlsxCell {
+	number := func(f float64, style int) xlsxCell {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return xlsxCell{}
+		}
+		text := strconv.FormatFloat(f, 'f', -1, 64)
+		return xlsxCell{kind: 'n', text: text, style: style, width: len(text) + len(text)/3}
+	}
+	switch val := v.(type) {
+	case nil:
+		return xlsxCell{}
+	case string:
+		if t, ok := parseXLSXDate(val); ok {
+			return dateXLSXCell(t, len(val) > len("2006-01-02"))
+		}
+		return xlsxCell{kind: 's', text: val, width: utf8.RuneCountInString(val)}
+	case bool:
+		if val {
+			return xlsxCell{kind: 'b', text: "1", width: 5}
+		}
+		return xlsxCell{kind: 'b', text: "0", width: 5}
+	case int:
+		return number(float64(val), xlsxStyleDefault)
+	case int8:
+		return number(float64(val), xlsxStyleDefault)
+	case int16:
+		return number(float64(val), xlsxStyleDefault)
+	case int32:
+		return number(float64(val), xlsxStyleDefault)
+	case int64:
+		return number(float64(val), xlsxStyleDefault)
+	case uint:
+		return number(float64(val), xlsxStyleDefault)
+	case uint8:
+		return number(float64(val), xlsxStyleDefault)
+	case uint16:
+		return number(float64(val), xlsxStyleDefault)
+	case uint32:
+		return number(float64(val), xlsxStyleDefault)
+	case uint64:
+		return number(float64(val), xlsxStyleDefault)
+	case float32:
+		return number(float64(val), xlsxStyleDefault)
+	case float64:
+		return number(val, xlsxStyleDefault)
+	case json.Number:
+		if f, err := val.Float64(); err == nil {
+			return number(f, xlsxStyleDefault)
+		}
+		return xlsxCell{kind: 's', text: val.String(), width: len(val)}
+	case types.Money:
+		style := xlsxStyleDecimal
+		if types.Digits(val.Currency()) == 0 {
+			style = xlsxStyleInteger
+		}
+		return number(val.Float64(), style)
+	case time.Time:
+		if val.IsZero() {
+			return xlsxCell{}
+		}
+		h, m, s := val.Clock()
+		return dateXLSXCell(val, h != 0 || m != 0 || s != 0 || val.Nanosecond() != 0)
+	case fmt.Stringer:
+		text := val.String()
+		return xlsxCell{kind: 's', text: text, width: utf8.RuneCountInString(text)}
+	default:
+		text := fmt.Sprint(val)
+		return xlsxCell{kind: 's', text: text, width: utf8.RuneCountInString(text)}
+	}
+}
+
+// dateXLSXCell returns a date cell for t's wall clock time, as Excel has
+// no time zones
+func dateXLSXCell(t time.Time, withTime bool) xlsxCell {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	wall := time.Date(y, mo, d, h, mi, s, t.Nanosecond(), time.UTC)
+	serial := wall.Sub(xlsxEpoch).Hours() / 24
+	if withTime {
+		return xlsxCell{kind: 'n', text: strconv.FormatFloat(serial, 'f', -1, 64), style: xlsxStyleDateTime, width: 16}
+	}
+	return xlsxCell{kind: 'n', text: strconv.FormatFloat(math.Floor(serial), 'f', -1, 64), style: xlsxStyleDate, width: 10}
+}
+
+// parseXLSXDate parses the date formats JSON APIs commonly use
+func parseXLSXDate(s string) (time.Time, bool) {
+	if len(s) < len("2006-01-02") || s[4] != '-' || s[7] != '-' {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// xlsxSheetName makes name valid and unique among used: at most 31
+// characters, none of []:*?/\, and not blank
+func xlsxSheetName(name string, n int, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(n)
+	}
+	base := name
+	for i := 2; ; i++ {
+		if r := []rune(name); len(r) > 31 {
+			name = string(r[:31])
+		}
+		if !used[strings.ToLower(name)] {
+			break
+		}
+		suffix := " (" + strconv.Itoa(i) + ")"
+		r := []rune(base)
+		if len(r)+len(suffix) > 31 {
+			r = r[:31-len(suffix)]
+		}
+		name = string(r) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+// xlsxColumnName returns the letters of the zero-based column i, e.g. "AB"
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// buildXLSX writes a workbook of sheets, each with a bold, frozen header row
+// and columns sized to their content, up to 60 characters wide
+func buildXLSX(sheets []XLSXSheet) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name, content string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte(content))
+		return err
+	}
+
+	const header = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	var contentTypes, workbook, rels strings.Builder
+	contentTypes.WriteString(header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		n := strconv.Itoa(i + 1)
+		contentTypes.WriteString(`<Override PartName="/xl/worksheets/sheet` + n + `.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`)
+		workbook.WriteString(`<sheet name="` + xlsxEscape(xlsxSheetName(sheet.Name, i+1, used)) + `" sheetId="` + n + `" r:id="rId` + n + `"/>`)
+		rels.WriteString(`<Relationship Id="rId` + n + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet` + n + `.xml"/>`)
+		if err := write("xl/worksheets/sheet"+n+".xml", xlsxWorksheet(sheet)); err != nil {
+			return nil, err
+		}
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(`<Relationship Id="rId` + strconv.Itoa(len(sheets)+1) + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`)
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, f := range files {
+		if err := write(f.name, f.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxWorksheet returns the worksheet XML of sheet
+func xlsxWorksheet(sheet XLSXSheet) string {
+	widths := make([]int, len(sheet.Keys))
+	var rows strings.Builder
+	writeRow := func(r int, cells []xlsxCell) {
+		rows.WriteString(`<row r="` + strconv.Itoa(r) + `">`)
+		for c, cell := range cells {
+			widths[c] = max(widths[c], cell.width)
+			if cell.kind == 0 {
+				continue
+			}
+			ref := xlsxColumnName(c) + strconv.Itoa(r)
+			style := ""
+			if cell.style != xlsxStyleDefault {
+				style = ` s="` + strconv.Itoa(cell.style) + `"`
+			}
+			switch cell.kind {
+			case 's':
+				rows.WriteString(`<c r="` + ref + `" t="inlineStr"` + style + `><is><t xml:space="preserve">` + xlsxEscape(cell.text) + `</t></is></c>`)
+			case 'b':
+				rows.WriteString(`<c r="` + ref + `" t="b"` + style + `><v>` + cell.text + `</v></c>`)
+			default:
+				rows.WriteString(`<c r="` + ref + `"` + style + `><v>` + cell.text + `</v></c>`)
+			}
+		}
+		rows.WriteString(`</row>`)
+	}
+
+	cells := make([]xlsxCell, len(sheet.Keys))
+	for c, key := range sheet.Keys {
+		text := key
+		if c < len(sheet.Headers) {
+			text = sheet.Headers[c]
+		}
+		cells[c] = xlsxCell{kind: 's', text: text, style: xlsxStyleHeader, width: utf8.RuneCountInString(text) + 2}
+	}
+	writeRow(1, cells)
+	for r, row := range sheet.Data {
+		for c, key := range sheet.Keys {
+			cells[c] = newXLSXCell(row[key])
+		}
+		writeRow(r+2, cells)
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	if len(widths) > 0 {
+		b.WriteString(`<cols>`)
+		for c, w := range widths {
+			width := min(max(w+2, 8), 60)
+			col := strconv.Itoa(c + 1)
+			b.WriteString(`<col min="` + col + `" max="` + col + `" width="` + strconv.Itoa(width) + `" customWidth="1"/>`)
+		}
+		b.WriteString(`</cols>`)
+	}
+	b.WriteString(`<sheetData>`)
+	b.WriteString(rows.String())
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
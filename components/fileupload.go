@@ -12,9 +12,10 @@ type FileInfo struct {
 	Name     string
 	Size     int64
 	Type     string
-	File     js.Value // The actual File object
-	DataURL  string   // Base64 data URL (for images)
-	Progress int      // Upload progress 0-100
+	File     js.Value     // The actual File object
+	DataURL  string       // Base64 data URL (for images)
+	Progress int          // Upload progress 0-100, set by UploadTo
+	Status   UploadStatus // Upload state, set by UploadTo
 }
 
 // FileUploadProps configures a FileUpload component
@@ -37,6 +38,9 @@ type FileUpload struct {
 	preview   js.Value
 	files     []FileInfo
 	props     FileUploadProps
+	listeners listeners
+	previews  listeners // of the preview cards
+	upload    *uploader // set by UploadTo
 }
 
 // NewFileUpload creates a new FileUpload component
@@ -115,31 +119,31 @@ func NewFileUpload(props FileUploadProps) *FileUpload {
 	f.container = container
 
 	// Event handlers
-	dropzone.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	dropzone.Call("addEventListener", "click", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		input.Call("click")
 		return nil
 	}))
 
-	input.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "change", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		files := input.Get("files")
 		f.handleFiles(files)
 		return nil
 	}))
 
 	// Drag and drop
-	dropzone.Call("addEventListener", "dragover", js.FuncOf(func(this js.Value, args []js.Value) any {
+	dropzone.Call("addEventListener", "dragover", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		dropzone.Set("className", "border-2 border-dashed border-blue-500 rounded-lg p-6 text-center bg-blue-50 cursor-pointer")
 		return nil
 	}))
 
-	dropzone.Call("addEventListener", "dragleave", js.FuncOf(func(this js.Value, args []js.Value) any {
+	dropzone.Call("addEventListener", "dragleave", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		dropzone.Set("className", "border-2 border-dashed border-default rounded-lg p-6 text-center hover:border-blue-400 transition-colors cursor-pointer")
 		return nil
 	}))
 
-	dropzone.Call("addEventListener", "drop", js.FuncOf(func(this js.Value, args []js.Value) any {
+	dropzone.Call("addEventListener", "drop", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		dropzone.Set("className", "border-2 border-dashed border-default rounded-lg p-6 text-center hover:border-blue-400 transition-colors cursor-pointer")
 		files := args[0].Get("dataTransfer").Get("files")
@@ -147,12 +151,23 @@ func NewFileUpload(props FileUploadProps) *FileUpload {
 		return nil
 	}))
 
+	onUnmount(container, f.Unmount)
 	return f
 }
 
 func (f *FileUpload) handleFiles(fileList js.Value) {
 	count := fileList.Length()
 
+	// When uploading, new selections add to the files being uploaded
+	keep := f.upload != nil && f.props.Multiple
+	if keep {
+		for _, file := range f.files {
+			if !selected(fileList, file.Name) {
+				count++
+			}
+		}
+	}
+
 	if f.props.MaxFiles > 0 && count > f.props.MaxFiles {
 		if f.props.OnError != nil {
 			f.props.OnError(fmt.Sprintf("Maximum %d files allowed", f.props.MaxFiles))
@@ -160,8 +175,23 @@ func (f *FileUpload) handleFiles(fileList js.Value) {
 		return
 	}
 
-	f.files = nil
-	f.preview.Set("innerHTML", "")
+	if keep {
+		// Files selected again replace their earlier selection
+		kept := f.files[:0]
+		for _, file := range f.files {
+			if !selected(fileList, file.Name) {
+				kept = append(kept, file)
+			}
+		}
+		f.files = kept
+		f.renderPreviews()
+	} else {
+		f.cancelUploads()
+		f.files = nil
+		f.preview.Set("innerHTML", "")
+		f.previews.release()
+	}
+	count = fileList.Length()
 
 	for i := 0; i < count; i++ {
 		file := fileList.Index(i)
@@ -183,6 +213,10 @@ func (f *FileUpload) handleFiles(fileList js.Value) {
 			Type: fileType,
 			File: file,
 		}
+		f.files = append(f.files, info)
+		if f.upload != nil {
+			f.startUpload(info)
+		}
 
 		// Generate preview for images
 		if f.props.ShowPreview && isImageType(fileType) {
@@ -190,8 +224,6 @@ func (f *FileUpload) handleFiles(fileList js.Value) {
 		} else {
 			f.createFilePreview(info)
 		}
-
-		f.files = append(f.files, info)
 	}
 
 	if f.props.OnSelect != nil && len(f.files) > 0 {
@@ -204,7 +236,9 @@ func (f *FileUpload) createImagePreview(file js.Value, info *FileInfo) {
 
 	reader := js.Global().Get("FileReader").New()
 
-	reader.Set("onload", js.FuncOf(func(this js.Value, args []js.Value) any {
+	var onload js.Func
+	onload = js.FuncOf(func(this js.Value, args []js.Value) any {
+		onload.Release()
 		dataURL := reader.Get("result").String()
 		info.DataURL = dataURL
 
@@ -230,10 +264,12 @@ func (f *FileUpload) createImagePreview(file js.Value, info *FileInfo) {
 		// Remove button
 		removeBtn := f.createRemoveButton(info.Name)
 		card.Call("appendChild", removeBtn)
+		f.addUploadControls(card, info.Name)
 
 		f.preview.Call("appendChild", card)
 		return nil
-	}))
+	})
+	reader.Set("onload", onload)
 
 	reader.Call("readAsDataURL", file)
 }
@@ -265,6 +301,7 @@ func (f *FileUpload) createFilePreview(info FileInfo) {
 	// Remove button
 	removeBtn := f.createRemoveButton(info.Name)
 	card.Call("appendChild", removeBtn)
+	f.addUploadControls(card, info.Name)
 
 	f.preview.Call("appendChild", card)
 }
@@ -276,7 +313,7 @@ func (f *FileUpload) createRemoveButton(fileName string) js.Value {
 	btn.Set("className", "absolute top-1 right-1 w-5 h-5 bg-red-500 text-white rounded-full text-xs hover:bg-red-600")
 	btn.Set("textContent", "×")
 
-	btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	btn.Call("addEventListener", "click", f.previews.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("stopPropagation")
 		f.removeFile(fileName)
 		return nil
@@ -294,9 +331,21 @@ func (f *FileUpload) removeFile(name string) {
 		}
 	}
 	f.files = newFiles
+	if f.upload != nil && f.upload.files[name] != nil {
+		f.cancelUpload(f.upload.files[name])
+	}
+
+	f.renderPreviews()
+
+	if f.props.OnSelect != nil {
+		f.props.OnSelect(f.files)
+	}
+}
 
-	// Re-render previews
+// renderPreviews re-creates the preview cards of the selected files
+func (f *FileUpload) renderPreviews() {
 	f.preview.Set("innerHTML", "")
+	f.previews.release()
 	for _, file := range f.files {
 		if f.props.ShowPreview && isImageType(file.Type) && file.DataURL != "" {
 			// Re-create image preview with stored dataURL
@@ -305,10 +354,16 @@ func (f *FileUpload) removeFile(name string) {
 			f.createFilePreview(file)
 		}
 	}
+}
 
-	if f.props.OnSelect != nil {
-		f.props.OnSelect(f.files)
+// selected reports whether a file named name is in fileList
+func selected(fileList js.Value, name string) bool {
+	for i := 0; i < fileList.Length(); i++ {
+		if fileList.Index(i).Get("name").String() == name {
+			return true
+		}
 	}
+	return false
 }
 
 func (f *FileUpload) createImagePreviewFromURL(info FileInfo) {
@@ -334,6 +389,7 @@ func (f *FileUpload) createImagePreviewFromURL(info FileInfo) {
 
 	removeBtn := f.createRemoveButton(info.Name)
 	card.Call("appendChild", removeBtn)
+	f.addUploadControls(card, info.Name)
 
 	f.preview.Call("appendChild", card)
 }
@@ -343,15 +399,30 @@ func (f *FileUpload) Element() js.Value {
 	return f.container
 }
 
+// Mount appends the file upload to parent
+func (f *FileUpload) Mount(parent js.Value) {
+	parent.Call("appendChild", f.container)
+}
+
+// Unmount removes the file upload and releases its listeners
+func (f *FileUpload) Unmount() {
+	unmount(f.container)
+	f.PauseAll()
+	f.listeners.release()
+	f.previews.release()
+}
+
 // Files returns the selected files
 func (f *FileUpload) Files() []FileInfo {
 	return f.files
 }
 
-// Clear clears all selected files
+// Clear clears all selected files, canceling their uploads
 func (f *FileUpload) Clear() {
+	f.cancelUploads()
 	f.files = nil
 	f.preview.Set("innerHTML", "")
+	f.previews.release()
 	f.input.Set("value", "")
 }
 
@@ -0,0 +1,378 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/filter"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// FilterField is a field offered by a FilterBuilder
+type FilterField struct {
+	Name    string         // field name in the filter, e.g. "status"
+	Label   string         // shown in the field menu (default Name)
+	Type    string         // "text" (default), "number", "date", or "select"
+	Options []SelectOption // values for "select" fields
+	Ops     []filter.Op    // operators offered (default depends on Type)
+}
+
+// ops returns the operators offered for the field
+func (f FilterField) ops() []filter.Op {
+	if len(f.Ops) > 0 {
+		return f.Ops
+	}
+	switch f.Type {
+	case "number", "date":
+		return []filter.Op{filter.Eq, filter.Ne, filter.Gt, filter.Gte, filter.Lt, filter.Lte}
+	case "select":
+		return []filter.Op{filter.Eq, filter.Ne}
+	}
+	return []filter.Op{filter.Contains, filter.Eq, filter.Ne}
+}
+
+// FilterBuilderProps configures a FilterBuilder component
+type FilterBuilderProps struct {
+	Fields    []FilterField
+	Value     filter.Filter       // initial filter
+	NoGroups  bool                // hide "Add group", for flat all/any filters
+	OnChange  func(filter.Filter) // called after each change
+	ClassName string
+}
+
+// FilterBuilder edits a filter.Filter: rows of field, operator, and value,
+// matched all or any, with optional nested groups. Send Value to a generated
+// client method that takes a filter.Filter, or use Value().Match to filter
+// rows on the client.
+type FilterBuilder struct {
+	container js.Value
+	props     FilterBuilderProps
+	fields    map[string]FilterField
+	root      *filterNode
+	listeners listeners // of the current render
+}
+
+// filterNode is a condition, or a group when children is non-nil
+type filterNode struct {
+	field    string
+	op       filter.Op
+	value    string
+	any      bool
+	children []*filterNode
+}
+
+// NewFilterBuilder creates a new FilterBuilder component
+func NewFilterBuilder(props FilterBuilderProps) *FilterBuilder {
+	container := js.Global().Get("document").Call("createElement", "div")
+	className := "space-y-3"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	container.Set("className", className)
+
+	fb := &FilterBuilder{container: container, props: props, fields: make(map[string]FilterField)}
+	for _, f := range props.Fields {
+		fb.fields[f.Name] = f
+	}
+	fb.SetValue(props.Value)
+	onUnmount(container, fb.Unmount)
+	return fb
+}
+
+// Element returns the container DOM element
+func (fb *FilterBuilder) Element() js.Value {
+	return fb.container
+}
+
+// Mount appends the filter builder to parent
+func (fb *FilterBuilder) Mount(parent js.Value) {
+	parent.Call("appendChild", fb.container)
+}
+
+// Unmount removes the filter builder and releases its listeners
+func (fb *FilterBuilder) Unmount() {
+	unmount(fb.container)
+	fb.listeners.release()
+}
+
+// Value returns the filter. Conditions without a value are left out.
+func (fb *FilterBuilder) Value() filter.Filter {
+	return fb.root.filter()
+}
+
+func (n *filterNode) filter() filter.Filter {
+	if n.children == nil {
+		if n.value == "" {
+			return filter.Filter{}
+		}
+		return filter.Where(n.field, n.op, n.value)
+	}
+	parts := make([]filter.Filter, 0, len(n.children))
+	for _, child := range n.children {
+		parts = append(parts, child.filter())
+	}
+	if n.any {
+		return filter.Or(parts...)
+	}
+	return filter.And(parts...)
+}
+
+// SetValue replaces the filter being edited, without calling OnChange
+func (fb *FilterBuilder) SetValue(f filter.Filter) {
+	fb.root = toFilterNode(f)
+	if fb.root.children == nil {
+		fb.root = &filterNode{children: []*filterNode{fb.root}}
+	}
+	fb.render()
+}
+
+func toFilterNode(f filter.Filter) *filterNode {
+	if f.Field != "" {
+		return &filterNode{field: f.Field, op: f.Op, value: f.Value}
+	}
+	n := &filterNode{any: f.Any, children: []*filterNode{}}
+	for _, child := range f.Filters {
+		n.children = append(n.children, toFilterNode(child))
+	}
+	return n
+}
+
+// Clear removes every condition
+func (fb *FilterBuilder) Clear() {
+	fb.SetValue(filter.Filter{})
+	fb.changed()
+}
+
+func (fb *FilterBuilder) changed() {
+	if fb.props.OnChange != nil {
+		fb.props.OnChange(fb.Value())
+	}
+}
+
+// update re-renders after a change to the structure and reports it
+func (fb *FilterBuilder) update() {
+	fb.render()
+	fb.changed()
+}
+
+func (fb *FilterBuilder) render() {
+	UnmountChildren(fb.container)
+	fb.listeners.release()
+	fb.container.Call("appendChild", fb.renderGroup(fb.root, nil))
+}
+
+func (fb *FilterBuilder) renderGroup(group, parent *filterNode) js.Value {
+	document := js.Global().Get("document")
+
+	el := document.Call("createElement", "div")
+	el.Call("setAttribute", "role", "group")
+	if parent == nil {
+		el.Set("className", "space-y-2")
+	} else {
+		el.Set("className", "space-y-2 p-3 border border-subtle rounded-md surface-raised")
+	}
+
+	// All / any
+	header := document.Call("createElement", "div")
+	header.Set("className", "flex items-center gap-2")
+	mode := "all"
+	if group.any {
+		mode = "any"
+	}
+	match := fb.selectEl(i18n.T("gux.filter.match"), []SelectOption{
+		{Label: i18n.T("gux.filter.matchAll"), Value: "all"},
+		{Label: i18n.T("gux.filter.matchAny"), Value: "any"},
+	}, mode, func(v string) {
+		group.any = v == "any"
+		fb.changed()
+	})
+	match.Get("classList").Call("add", "w-auto")
+	header.Call("appendChild", match)
+	if parent != nil {
+		header.Call("appendChild", fb.removeButton(i18n.T("gux.filter.removeGroup"), parent, group))
+	}
+	el.Call("appendChild", header)
+
+	if len(group.children) == 0 && parent == nil {
+		empty := document.Call("createElement", "p")
+		empty.Set("className", "text-sm text-secondary")
+		empty.Set("textContent", i18n.T("gux.filter.empty"))
+		el.Call("appendChild", empty)
+	}
+
+	for _, child := range group.children {
+		if child.children != nil {
+			el.Call("appendChild", fb.renderGroup(child, group))
+		} else {
+			el.Call("appendChild", fb.renderCondition(child, group))
+		}
+	}
+
+	actions := document.Call("createElement", "div")
+	actions.Set("className", "flex gap-2")
+	actions.Call("appendChild", fb.addButton(i18n.T("gux.filter.addCondition"), func() {
+		group.children = append(group.children, fb.newCondition())
+		fb.update()
+	}))
+	if parent == nil && !fb.props.NoGroups {
+		actions.Call("appendChild", fb.addButton(i18n.T("gux.filter.addGroup"), func() {
+			group.children = append(group.children, &filterNode{children: []*filterNode{fb.newCondition()}})
+			fb.update()
+		}))
+	}
+	el.Call("appendChild", actions)
+	return el
+}
+
+// newCondition starts a condition on the first field
+func (fb *FilterBuilder) newCondition() *filterNode {
+	n := &filterNode{}
+	if len(fb.props.Fields) > 0 {
+		n.field = fb.props.Fields[0].Name
+		n.op = fb.props.Fields[0].ops()[0]
+	}
+	return n
+}
+
+func (fb *FilterBuilder) renderCondition(cond, group *filterNode) js.Value {
+	document := js.Global().Get("document")
+
+	row := document.Call("createElement", "div")
+	row.Set("className", "flex flex-wrap items-center gap-2")
+
+	field, known := fb.fields[cond.field]
+	if !known {
+		// A field set through SetValue that is not offered; keep it selectable
+		field = FilterField{Name: cond.field}
+	}
+
+	// Field
+	var fieldOpts []SelectOption
+	for _, f := range fb.props.Fields {
+		fieldOpts = append(fieldOpts, SelectOption{Label: fieldLabel(f), Value: f.Name})
+	}
+	if !known {
+		fieldOpts = append(fieldOpts, SelectOption{Label: cond.field, Value: cond.field})
+	}
+	row.Call("appendChild", fb.selectEl(i18n.T("gux.filter.field"), fieldOpts, cond.field, func(v string) {
+		next := fb.fields[v]
+		cond.field = v
+		if !containsOp(next.ops(), cond.op) {
+			cond.op = next.ops()[0]
+		}
+		if next.Type != field.Type {
+			cond.value = ""
+		}
+		fb.update()
+	}))
+
+	// Operator
+	var opOpts []SelectOption
+	for _, op := range field.ops() {
+		opOpts = append(opOpts, SelectOption{Label: i18n.T("gux.filter.op." + string(op)), Value: string(op)})
+	}
+	row.Call("appendChild", fb.selectEl(i18n.T("gux.filter.operator"), opOpts, string(cond.op), func(v string) {
+		cond.op = filter.Op(v)
+		fb.changed()
+	}))
+
+	// Value
+	var value js.Value
+	if field.Type == "select" {
+		opts := append([]SelectOption{{Label: "", Value: ""}}, field.Options...)
+		value = fb.selectEl(i18n.T("gux.filter.value"), opts, cond.value, func(v string) {
+			cond.value = v
+			fb.changed()
+		})
+	} else {
+		value = document.Call("createElement", "input")
+		switch field.Type {
+		case "number", "date":
+			value.Set("type", field.Type)
+		default:
+			value.Set("type", "text")
+		}
+		value.Set("value", cond.value)
+		value.Set("className", "flex-1 min-w-[8rem] "+inputShapeClasses+" border border-default shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 surface-base text-primary")
+		value.Call("setAttribute", "aria-label", i18n.T("gux.filter.value"))
+		input := value
+		value.Call("addEventListener", "input", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+			cond.value = input.Get("value").String()
+			return nil
+		}))
+		// Report on change rather than every keystroke, so servers are not
+		// queried for each letter
+		value.Call("addEventListener", "change", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+			fb.changed()
+			return nil
+		}))
+	}
+	row.Call("appendChild", value)
+
+	row.Call("appendChild", fb.removeButton(i18n.T("gux.filter.removeCondition"), group, cond))
+	return row
+}
+
+func fieldLabel(f FilterField) string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
+}
+
+func containsOp(ops []filter.Op, op filter.Op) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// selectEl creates a labeled <select> that calls onChange with the new value
+func (fb *FilterBuilder) selectEl(label string, options []SelectOption, value string, onChange func(string)) js.Value {
+	document := js.Global().Get("document")
+
+	sel := document.Call("createElement", "select")
+	sel.Set("className", inputShapeClasses+" border border-default shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 surface-base text-primary")
+	sel.Call("setAttribute", "aria-label", label)
+	for _, opt := range options {
+		option := document.Call("createElement", "option")
+		option.Set("value", opt.Value)
+		option.Set("textContent", opt.Label)
+		if opt.Value == value {
+			option.Set("selected", true)
+		}
+		sel.Call("appendChild", option)
+	}
+	sel.Call("addEventListener", "change", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+		onChange(sel.Get("value").String())
+		return nil
+	}))
+	return sel
+}
+
+func (fb *FilterBuilder) addButton(text string, onClick func()) js.Value {
+	return Button(ButtonProps{Text: "+ " + text, Variant: ButtonGhost, Size: ButtonSM, OnClick: onClick})
+}
+
+// removeButton removes node from group
+func (fb *FilterBuilder) removeButton(label string, group, node *filterNode) js.Value {
+	btn := js.Global().Get("document").Call("createElement", "button")
+	btn.Set("type", "button")
+	btn.Set("className", "px-2 py-1 text-secondary hover:text-primary text-lg leading-none cursor-pointer")
+	btn.Set("innerHTML", "&times;")
+	btn.Call("setAttribute", "aria-label", label)
+	btn.Call("addEventListener", "click", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+		for i, child := range group.children {
+			if child == node {
+				group.children = append(group.children[:i], group.children[i+1:]...)
+				break
+			}
+		}
+		fb.update()
+		return nil
+	}))
+	return btn
+}
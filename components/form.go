@@ -4,13 +4,37 @@ package components
 
 import (
 	"regexp"
+	"slices"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/validate"
 )
 
 // ValidationRule defines a validation check
 type ValidationRule struct {
 	Validate func(value string) bool
 	Message  string
+
+	// Key and Args translate the message in the current locale when it is
+	// shown. Built-in rules set them; Message is the English fallback.
+	Key  string
+	Args []any
+}
+
+// Text returns the message to show when the rule fails
+func (r ValidationRule) Text() string {
+	if r.Key != "" {
+		return i18n.T(r.Key, r.Args...)
+	}
+	return r.Message
+}
+
+// WithMessage returns a copy of the rule that shows message instead of the
+// built-in translated text
+func (r ValidationRule) WithMessage(message string) ValidationRule {
+	r.Message, r.Key, r.Args = message, "", nil
+	return r
 }
 
 // Common validation rules
@@ -18,6 +42,7 @@ var (
 	Required = ValidationRule{
 		Validate: func(v string) bool { return v != "" },
 		Message:  "This field is required",
+		Key:      "gux.validation.required",
 	}
 
 	Email = ValidationRule{
@@ -25,10 +50,10 @@ var (
 			if v == "" {
 				return true // Use Required for empty check
 			}
-			re := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-			return re.MatchString(v)
+			return validate.Email(v)
 		},
 		Message: "Please enter a valid email address",
+		Key:     "gux.validation.email",
 	}
 )
 
@@ -37,6 +62,8 @@ func MinLength(n int) ValidationRule {
 	return ValidationRule{
 		Validate: func(v string) bool { return len(v) >= n },
 		Message:  "Must be at least " + itoa(n) + " characters",
+		Key:      "gux.validation.minlength",
+		Args:     []any{n},
 	}
 }
 
@@ -45,6 +72,8 @@ func MaxLength(n int) ValidationRule {
 	return ValidationRule{
 		Validate: func(v string) bool { return len(v) <= n },
 		Message:  "Must be at most " + itoa(n) + " characters",
+		Key:      "gux.validation.maxlength",
+		Args:     []any{n},
 	}
 }
 
@@ -92,12 +121,21 @@ type FormProps struct {
 	OnSubmit    func(values map[string]string)
 	OnCancel    func()
 	CancelLabel string
+
+	// SpamProtection adds a honeypot field and a minimum fill time
+	SpamProtection SpamProtection
+
+	// Rules adds rules to fields by name, ahead of their own, e.g. the
+	// rules gux gen derives from a request struct's validate tags
+	Rules map[string][]ValidationRule
 }
 
 // Form is a validated form component
 type Form struct {
-	element js.Value
-	fields  map[string]*formFieldInstance
+	element   js.Value
+	fields    map[string]*formFieldInstance
+	guard     *spamGuard
+	listeners listeners
 }
 
 type formFieldInstance struct {
@@ -119,6 +157,7 @@ func NewForm(props FormProps) *Form {
 	f := &Form{
 		element: form,
 		fields:  make(map[string]*formFieldInstance),
+		guard:   newSpamGuard(props.SpamProtection),
 	}
 
 	// Create fields
@@ -140,7 +179,7 @@ func NewForm(props FormProps) *Form {
 		// Error message element with id for aria-describedby
 		errorEl := document.Call("createElement", "p")
 		errorEl.Set("id", errorID)
-		errorEl.Set("className", "text-red-500 text-sm mt-1 hidden")
+		errorEl.Set("className", Classes("field.error")+" hidden")
 		errorEl.Call("setAttribute", "role", "alert")
 		fieldContainer.Call("appendChild", errorEl)
 
@@ -150,10 +189,14 @@ func NewForm(props FormProps) *Form {
 			input:   input,
 			errorEl: errorEl,
 			errorID: errorID,
-			rules:   field.Rules,
+			rules:   slices.Concat(props.Rules[field.Name], field.Rules),
 		}
 	}
 
+	if trap := f.guard.honeypot(); !trap.IsNull() {
+		form.Call("appendChild", trap)
+	}
+
 	// Button container
 	buttonContainer := document.Call("createElement", "div")
 	buttonContainer.Set("className", "flex gap-2 pt-4")
@@ -167,7 +210,7 @@ func NewForm(props FormProps) *Form {
 	submitBtn := Button(ButtonProps{
 		Text: submitLabel,
 		OnClick: func() {
-			if f.Validate() && props.OnSubmit != nil {
+			if f.Validate() && f.guard.allow() && props.OnSubmit != nil {
 				props.OnSubmit(f.Values())
 			}
 		},
@@ -181,9 +224,9 @@ func NewForm(props FormProps) *Form {
 			cancelLabel = "Cancel"
 		}
 		cancelBtn := Button(ButtonProps{
-			Text:      cancelLabel,
-			ClassName: "px-4 py-2 bg-gray-200 text-gray-800 rounded-md hover:bg-gray-300 cursor-pointer transition-colors",
-			OnClick:   props.OnCancel,
+			Text:    cancelLabel,
+			Variant: ButtonSecondary,
+			OnClick: props.OnCancel,
 		})
 		buttonContainer.Call("appendChild", cancelBtn)
 	}
@@ -191,11 +234,12 @@ func NewForm(props FormProps) *Form {
 	form.Call("appendChild", buttonContainer)
 
 	// Prevent default form submission
-	form.Call("addEventListener", "submit", js.FuncOf(func(this js.Value, args []js.Value) any {
+	form.Call("addEventListener", "submit", f.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		return nil
 	}))
 
+	onUnmount(f.element, f.Unmount)
 	return f
 }
 
@@ -204,6 +248,17 @@ func (f *Form) Element() js.Value {
 	return f.element
 }
 
+// Mount appends the form to parent
+func (f *Form) Mount(parent js.Value) {
+	parent.Call("appendChild", f.element)
+}
+
+// Unmount removes the form and releases its listeners
+func (f *Form) Unmount() {
+	unmount(f.element)
+	f.listeners.release()
+}
+
 // Values returns all field values
 func (f *Form) Values() map[string]string {
 	values := make(map[string]string)
@@ -245,12 +300,12 @@ func (f *Form) validateField(field *formFieldInstance) bool {
 	for _, rule := range field.rules {
 		if !rule.Validate(value) {
 			// Set error styling on input (without creating duplicate error element)
-			field.input.input.Set("className", "w-full px-3 py-2 border border-red-500 bg-white dark:bg-gray-700 text-gray-900 dark:text-gray-100 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-red-500 focus:border-red-500")
+			field.input.input.Set("className", Classes("input.error"))
 			field.input.input.Call("setAttribute", "aria-invalid", "true")
 			field.input.input.Call("setAttribute", "aria-describedby", field.errorID)
 
 			// Show error message
-			field.errorEl.Set("textContent", rule.Message)
+			field.errorEl.Set("textContent", rule.Text())
 			field.errorEl.Get("classList").Call("remove", "hidden")
 			field.errorShown = true
 			return false
@@ -260,7 +315,7 @@ func (f *Form) validateField(field *formFieldInstance) bool {
 	// Clear any previous error
 	if field.errorShown {
 		// Remove error styling and ARIA attributes
-		field.input.input.Set("className", "w-full px-3 py-2 border border-gray-300 dark:border-gray-600 bg-white dark:bg-gray-700 text-gray-900 dark:text-gray-100 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500")
+		field.input.input.Set("className", Classes("input"))
 		field.input.input.Call("removeAttribute", "aria-invalid")
 		field.input.input.Call("removeAttribute", "aria-describedby")
 
@@ -271,12 +326,20 @@ func (f *Form) validateField(field *formFieldInstance) bool {
 	return true
 }
 
+// IdempotencyKey returns a random key for the current submission. Send it
+// with fetch.WithIdempotencyKey so server.Idempotency rejects the same
+// submission twice; Reset starts a new one.
+func (f *Form) IdempotencyKey() string {
+	return f.guard.idemKey
+}
+
 // Reset clears all fields and errors
 func (f *Form) Reset() {
+	f.guard.reset()
 	for _, field := range f.fields {
 		field.input.SetValue("")
 		// Remove error styling and ARIA attributes
-		field.input.input.Set("className", "w-full px-3 py-2 border border-gray-300 dark:border-gray-600 bg-white dark:bg-gray-700 text-gray-900 dark:text-gray-100 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500")
+		field.input.input.Set("className", Classes("input"))
 		field.input.input.Call("removeAttribute", "aria-invalid")
 		field.input.input.Call("removeAttribute", "aria-describedby")
 		field.errorEl.Get("classList").Call("add", "hidden")
@@ -288,7 +351,7 @@ func (f *Form) Reset() {
 func (f *Form) SetFieldError(name, message string) {
 	if field, ok := f.fields[name]; ok {
 		// Set error styling and ARIA attributes on input
-		field.input.input.Set("className", "w-full px-3 py-2 border border-red-500 bg-white dark:bg-gray-700 text-gray-900 dark:text-gray-100 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-red-500 focus:border-red-500")
+		field.input.input.Set("className", Classes("input.error"))
 		field.input.input.Call("setAttribute", "aria-invalid", "true")
 		field.input.input.Call("setAttribute", "aria-describedby", field.errorID)
 
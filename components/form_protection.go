@@ -0,0 +1,109 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// Reasons passed to SpamProtection.OnBlocked
+const (
+	SpamHoneypot = "honeypot" // the hidden field was filled in
+	SpamTooFast  = "too_fast" // submitted before MinFillTime
+)
+
+// SpamProtection adds anti-spam checks to Form and FormBuilder for public
+// forms such as contact and signup pages. Blocked submissions never reach
+// OnSubmit. Pair it with server.Idempotency and send the form's
+// IdempotencyKey with the request to reject double submissions.
+//
+//	SpamProtection: components.SpamProtection{
+//		Honeypot:    "website",
+//		MinFillTime: 3 * time.Second,
+//	},
+type SpamProtection struct {
+	// Honeypot is the name of a hidden field that people never see but bots
+	// that fill in every input do. Pick a plausible name such as "website"
+	// that is not a real field. Empty disables the check.
+	Honeypot string
+
+	// MinFillTime blocks submissions made sooner after the form was shown,
+	// which is faster than a person can type. 0 disables the check.
+	MinFillTime time.Duration
+
+	// OnBlocked is called with SpamHoneypot or SpamTooFast when a submission
+	// is blocked, e.g. to log it or show a neutral message
+	OnBlocked func(reason string)
+}
+
+// spamGuard applies SpamProtection to one form
+type spamGuard struct {
+	opts    SpamProtection
+	trap    js.Value
+	shown   time.Time
+	idemKey string
+}
+
+func newSpamGuard(opts SpamProtection) *spamGuard {
+	return &spamGuard{opts: opts, shown: time.Now(), idemKey: newIdempotencyKey()}
+}
+
+// honeypot returns the hidden trap field, or a null value when there is none.
+// It is moved off-screen rather than display:none, which some bots skip, and
+// hidden from assistive technology and autofill.
+func (g *spamGuard) honeypot() js.Value {
+	if g.opts.Honeypot == "" {
+		return js.Null()
+	}
+	document := js.Global().Get("document")
+
+	wrapper := document.Call("createElement", "div")
+	wrapper.Call("setAttribute", "aria-hidden", "true")
+	wrapper.Get("style").Set("cssText", "position:absolute;left:-10000px;top:auto;width:1px;height:1px;overflow:hidden")
+
+	label := document.Call("createElement", "label")
+	label.Set("textContent", g.opts.Honeypot)
+
+	g.trap = document.Call("createElement", "input")
+	g.trap.Set("type", "text")
+	g.trap.Set("name", g.opts.Honeypot)
+	g.trap.Set("tabIndex", -1)
+	g.trap.Set("autocomplete", "off")
+	label.Call("appendChild", g.trap)
+	wrapper.Call("appendChild", label)
+	return wrapper
+}
+
+// allow reports whether a submission passes the checks, calling OnBlocked
+// when it does not
+func (g *spamGuard) allow() bool {
+	reason := ""
+	switch {
+	case g.trap.Truthy() && g.trap.Get("value").String() != "":
+		reason = SpamHoneypot
+	case g.opts.MinFillTime > 0 && time.Since(g.shown) < g.opts.MinFillTime:
+		reason = SpamTooFast
+	}
+	if reason == "" {
+		return true
+	}
+	if g.opts.OnBlocked != nil {
+		g.opts.OnBlocked(reason)
+	}
+	return false
+}
+
+// reset starts a new submission: a fresh idempotency key and fill timer
+func (g *spamGuard) reset() {
+	g.idemKey = newIdempotencyKey()
+	g.shown = time.Now()
+	if g.trap.Truthy() {
+		g.trap.Set("value", "")
+	}
+}
+
+// newIdempotencyKey returns a random UUID
+func newIdempotencyKey() string {
+	return js.Global().Get("crypto").Call("randomUUID").String()
+}
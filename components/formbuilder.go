@@ -4,7 +4,12 @@ package components
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/types"
 )
 
 // BuilderFieldType defines the type of form field for builder
@@ -15,6 +20,8 @@ const (
 	BuilderFieldEmail    BuilderFieldType = "email"
 	BuilderFieldPassword BuilderFieldType = "password"
 	BuilderFieldNumber   BuilderFieldType = "number"
+	BuilderFieldStepper  BuilderFieldType = "stepper"  // NumberField with +/- buttons
+	BuilderFieldCurrency BuilderFieldType = "currency" // CurrencyInput, valued as types.Money
 	BuilderFieldTextarea BuilderFieldType = "textarea"
 	BuilderFieldSelect   BuilderFieldType = "select"
 	BuilderFieldCheckbox BuilderFieldType = "checkbox"
@@ -44,6 +51,10 @@ type BuilderField struct {
 	Min          string // For number/date
 	Max          string // For number/date
 	Step         string // For number
+	Precision    int    // For stepper
+	Prefix       string // For stepper, e.g. "$"
+	Suffix       string // For stepper, e.g. "kg"
+	Currency     string // For currency, e.g. "EUR" (default types.DefaultCurrency)
 	CustomRender func(field BuilderField, value any, onChange func(any)) js.Value
 }
 
@@ -68,16 +79,25 @@ type FormBuilderProps struct {
 	ClassName      string
 	FieldClassName string
 	Inline         bool // Render fields inline
+
+	// SpamProtection adds a honeypot field and a minimum fill time
+	SpamProtection SpamProtection
+
+	// Rules adds rules to fields by name, ahead of their own, e.g. the
+	// rules gux gen derives from a request struct's validate tags
+	Rules map[string][]ValidationRule
 }
 
 // FormBuilder creates dynamic forms from configuration
 type FormBuilder struct {
-	props    FormBuilderProps
-	values   map[string]any
-	errors   map[string]string
-	touched  map[string]bool
-	form     js.Value
-	onChange []func(string, any)
+	props     FormBuilderProps
+	values    map[string]any
+	errors    map[string]string
+	touched   map[string]bool
+	form      js.Value
+	onChange  []func(string, any)
+	guard     *spamGuard
+	listeners listeners
 }
 
 // NewFormBuilder creates a new form builder instance
@@ -85,12 +105,22 @@ func NewFormBuilder(props FormBuilderProps) *FormBuilder {
 	if props.SubmitText == "" {
 		props.SubmitText = "Submit"
 	}
+	if props.Rules != nil {
+		props.Fields = withRules(props.Fields, props.Rules)
+		sections := make([]BuilderSection, len(props.Sections))
+		for i, section := range props.Sections {
+			section.Fields = withRules(section.Fields, props.Rules)
+			sections[i] = section
+		}
+		props.Sections = sections
+	}
 
 	fb := &FormBuilder{
 		props:   props,
 		values:  make(map[string]any),
 		errors:  make(map[string]string),
 		touched: make(map[string]bool),
+		guard:   newSpamGuard(props.SpamProtection),
 	}
 
 	// Initialize default values
@@ -102,6 +132,10 @@ func NewFormBuilder(props FormBuilderProps) *FormBuilder {
 			switch field.Type {
 			case BuilderFieldCheckbox:
 				fb.values[field.Name] = false
+			case BuilderFieldStepper:
+				fb.values[field.Name] = 0.0
+			case BuilderFieldCurrency:
+				fb.values[field.Name] = types.Money{}
 			default:
 				fb.values[field.Name] = ""
 			}
@@ -109,9 +143,21 @@ func NewFormBuilder(props FormBuilderProps) *FormBuilder {
 	}
 
 	fb.form = fb.render()
+	onUnmount(fb.form, fb.Unmount)
 	return fb
 }
 
+// withRules returns a copy of fields with rules[name] ahead of each field's
+// own rules
+func withRules(fields []BuilderField, rules map[string][]ValidationRule) []BuilderField {
+	out := make([]BuilderField, len(fields))
+	for i, field := range fields {
+		field.Rules = slices.Concat(rules[field.Name], field.Rules)
+		out[i] = field
+	}
+	return out
+}
+
 func (fb *FormBuilder) getAllFields() []BuilderField {
 	if len(fb.props.Sections) > 0 {
 		var fields []BuilderField
@@ -130,7 +176,7 @@ func (fb *FormBuilder) render() js.Value {
 	form.Set("className", "space-y-6 "+fb.props.ClassName)
 
 	// Prevent default form submission
-	form.Call("addEventListener", "submit", js.FuncOf(func(this js.Value, args []js.Value) any {
+	form.Call("addEventListener", "submit", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		fb.handleSubmit()
 		return nil
@@ -156,25 +202,29 @@ func (fb *FormBuilder) render() js.Value {
 		form.Call("appendChild", fieldsContainer)
 	}
 
+	if trap := fb.guard.honeypot(); !trap.IsNull() {
+		form.Call("appendChild", trap)
+	}
+
 	// Buttons
 	buttonContainer := document.Call("createElement", "div")
 	buttonContainer.Set("className", "flex gap-3 pt-4")
 
 	submitBtn := document.Call("createElement", "button")
 	submitBtn.Set("type", "submit")
-	submitBtn.Set("className", "px-4 py-2 bg-blue-600 text-white rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2 transition-colors")
+	submitBtn.Set("className", Classes("button", "button.primary", "button.md"))
 	submitBtn.Set("textContent", fb.props.SubmitText)
 	buttonContainer.Call("appendChild", submitBtn)
 
 	if fb.props.ShowCancel {
 		cancelBtn := document.Call("createElement", "button")
 		cancelBtn.Set("type", "button")
-		cancelBtn.Set("className", "px-4 py-2 bg-gray-200 text-gray-700 rounded-md hover:bg-gray-300 focus:outline-none focus:ring-2 focus:ring-gray-500 focus:ring-offset-2 transition-colors")
+		cancelBtn.Set("className", Classes("button", "button.secondary", "button.md"))
 		cancelBtn.Set("textContent", fb.props.CancelText)
 		if fb.props.CancelText == "" {
 			cancelBtn.Set("textContent", "Cancel")
 		}
-		cancelBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		cancelBtn.Call("addEventListener", "click", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 			if fb.props.OnCancel != nil {
 				fb.props.OnCancel()
 			}
@@ -276,6 +326,10 @@ func (fb *FormBuilder) renderField(field BuilderField) js.Value {
 		input = fb.renderCheckbox(field)
 	case BuilderFieldRadio:
 		input = fb.renderRadioGroup(field)
+	case BuilderFieldStepper:
+		input = fb.renderStepper(field)
+	case BuilderFieldCurrency:
+		input = fb.renderCurrency(field)
 	default:
 		input = fb.renderInput(field)
 	}
@@ -301,7 +355,7 @@ func (fb *FormBuilder) renderInput(field BuilderField) js.Value {
 	input.Set("type", string(field.Type))
 	input.Set("name", field.Name)
 	input.Set("id", field.Name)
-	input.Set("className", "w-full px-3 py-2 border border-gray-300 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors")
+	input.Set("className", "w-full "+inputShapeClasses+" border border-gray-300 shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors")
 
 	if field.Placeholder != "" {
 		input.Set("placeholder", field.Placeholder)
@@ -338,7 +392,7 @@ func (fb *FormBuilder) renderInput(field BuilderField) js.Value {
 	// Change handler
 	fieldName := field.Name
 	fieldType := field.Type
-	input.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "input", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		var value any
 		if fieldType == BuilderFieldNumber {
 			value = input.Get("valueAsNumber").Float()
@@ -352,7 +406,7 @@ func (fb *FormBuilder) renderInput(field BuilderField) js.Value {
 	}))
 
 	// Blur handler for validation
-	input.Call("addEventListener", "blur", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "blur", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.touched[fieldName] = true
 		fb.validateField(field)
 		return nil
@@ -361,13 +415,91 @@ func (fb *FormBuilder) renderInput(field BuilderField) js.Value {
 	return input
 }
 
+func (fb *FormBuilder) renderStepper(field BuilderField) js.Value {
+	parse := func(s string) float64 {
+		v, _ := strconv.ParseFloat(s, 64)
+		return v
+	}
+
+	var initial float64
+	switch v := fb.values[field.Name].(type) {
+	case float64:
+		initial = v
+	case int:
+		initial = float64(v)
+	case string:
+		initial = parse(v)
+	}
+
+	fieldName := field.Name
+	stepper := NewNumberField(NumberFieldProps{
+		Name:        field.Name,
+		Value:       initial,
+		Min:         parse(field.Min),
+		Max:         parse(field.Max),
+		Step:        parse(field.Step),
+		Precision:   field.Precision,
+		Prefix:      field.Prefix,
+		Suffix:      field.Suffix,
+		Placeholder: field.Placeholder,
+		Disabled:    field.Disabled || field.ReadOnly,
+		ClassName:   "w-full",
+		OnChange: func(value float64) {
+			fb.setValue(fieldName, value)
+		},
+	})
+	fb.values[field.Name] = stepper.Value()
+
+	stepper.input.Call("addEventListener", "blur", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+		fb.touched[fieldName] = true
+		fb.validateField(field)
+		return nil
+	}))
+
+	return stepper.Element()
+}
+
+func (fb *FormBuilder) renderCurrency(field BuilderField) js.Value {
+	var initial types.Money
+	switch v := fb.values[field.Name].(type) {
+	case types.Money:
+		initial = v
+	case string:
+		if v != "" {
+			initial, _ = types.Parse(v, field.Currency)
+		}
+	}
+
+	fieldName := field.Name
+	currency := NewCurrencyInput(CurrencyInputProps{
+		Name:        field.Name,
+		Value:       initial,
+		Currency:    field.Currency,
+		Placeholder: field.Placeholder,
+		Disabled:    field.Disabled || field.ReadOnly,
+		ClassName:   "w-full",
+		OnChange: func(value types.Money) {
+			fb.setValue(fieldName, value)
+		},
+	})
+	fb.values[field.Name] = currency.Value()
+
+	currency.input.Call("addEventListener", "blur", fb.listeners.fn(func(this js.Value, args []js.Value) any {
+		fb.touched[fieldName] = true
+		fb.validateField(field)
+		return nil
+	}))
+
+	return currency.Element()
+}
+
 func (fb *FormBuilder) renderTextarea(field BuilderField) js.Value {
 	document := js.Global().Get("document")
 
 	textarea := document.Call("createElement", "textarea")
 	textarea.Set("name", field.Name)
 	textarea.Set("id", field.Name)
-	textarea.Set("className", "w-full px-3 py-2 border border-gray-300 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors resize-y")
+	textarea.Set("className", "w-full "+inputShapeClasses+" border border-gray-300 shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors resize-y")
 
 	rows := field.Rows
 	if rows == 0 {
@@ -390,12 +522,12 @@ func (fb *FormBuilder) renderTextarea(field BuilderField) js.Value {
 	}
 
 	fieldName := field.Name
-	textarea.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+	textarea.Call("addEventListener", "input", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.setValue(fieldName, textarea.Get("value").String())
 		return nil
 	}))
 
-	textarea.Call("addEventListener", "blur", js.FuncOf(func(this js.Value, args []js.Value) any {
+	textarea.Call("addEventListener", "blur", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.touched[fieldName] = true
 		fb.validateField(field)
 		return nil
@@ -410,7 +542,7 @@ func (fb *FormBuilder) renderSelect(field BuilderField) js.Value {
 	selectEl := document.Call("createElement", "select")
 	selectEl.Set("name", field.Name)
 	selectEl.Set("id", field.Name)
-	selectEl.Set("className", "w-full px-3 py-2 border border-gray-300 rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors bg-white")
+	selectEl.Set("className", "w-full "+inputShapeClasses+" border border-gray-300 shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 transition-colors bg-white")
 
 	if field.Disabled {
 		selectEl.Set("disabled", true)
@@ -444,12 +576,12 @@ func (fb *FormBuilder) renderSelect(field BuilderField) js.Value {
 	}
 
 	fieldName := field.Name
-	selectEl.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+	selectEl.Call("addEventListener", "change", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.setValue(fieldName, selectEl.Get("value").String())
 		return nil
 	}))
 
-	selectEl.Call("addEventListener", "blur", js.FuncOf(func(this js.Value, args []js.Value) any {
+	selectEl.Call("addEventListener", "blur", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.touched[fieldName] = true
 		fb.validateField(field)
 		return nil
@@ -479,7 +611,7 @@ func (fb *FormBuilder) renderCheckbox(field BuilderField) js.Value {
 	}
 
 	fieldName := field.Name
-	input.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+	input.Call("addEventListener", "change", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 		fb.setValue(fieldName, input.Get("checked").Bool())
 		return nil
 	}))
@@ -525,7 +657,7 @@ func (fb *FormBuilder) renderRadioGroup(field BuilderField) js.Value {
 
 		optValue := opt.Value
 		fieldName := field.Name
-		input.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		input.Call("addEventListener", "change", fb.listeners.fn(func(this js.Value, args []js.Value) any {
 			fb.setValue(fieldName, optValue)
 			return nil
 		}))
@@ -569,12 +701,19 @@ func (fb *FormBuilder) setValue(name string, value any) {
 func (fb *FormBuilder) validateField(field BuilderField) bool {
 	value := fb.values[field.Name]
 	strVal := fmt.Sprintf("%v", value)
+	if m, ok := value.(types.Money); ok {
+		// Rules such as Min see the plain amount; an empty field is ""
+		strVal = ""
+		if m != (types.Money{}) {
+			strVal = m.Decimal()
+		}
+	}
 
 	for _, rule := range field.Rules {
 		// Use the existing ValidationRule which has a Validate function
 		if !rule.Validate(strVal) {
-			fb.errors[field.Name] = rule.Message
-			fb.showError(field.Name, rule.Message)
+			fb.errors[field.Name] = rule.Text()
+			fb.showError(field.Name, rule.Text())
 			return false
 		}
 	}
@@ -634,7 +773,7 @@ func (fb *FormBuilder) handleSubmit() {
 		}
 	}
 
-	if !valid {
+	if !valid || !fb.guard.allow() {
 		return
 	}
 
@@ -653,6 +792,17 @@ func (fb *FormBuilder) Element() js.Value {
 	return fb.form
 }
 
+// Mount appends the form to parent
+func (fb *FormBuilder) Mount(parent js.Value) {
+	parent.Call("appendChild", fb.form)
+}
+
+// Unmount removes the form and releases its listeners
+func (fb *FormBuilder) Unmount() {
+	unmount(fb.form)
+	fb.listeners.release()
+}
+
 // GetValues returns all current form values
 func (fb *FormBuilder) GetValues() map[string]any {
 	result := make(map[string]any)
@@ -682,14 +832,28 @@ func (fb *FormBuilder) SetFormValue(name string, value any) {
 			if boolVal, ok := value.(bool); ok {
 				input.Set("checked", boolVal)
 			}
+		} else if m, ok := value.(types.Money); ok {
+			text := ""
+			if m != (types.Money{}) {
+				text = m.AmountIn(i18n.CurrentFormat())
+			}
+			input.Set("value", text)
 		} else if tagName == "SELECT" || tagName == "INPUT" || tagName == "TEXTAREA" {
 			input.Set("value", fmt.Sprintf("%v", value))
 		}
 	}
 }
 
+// IdempotencyKey returns a random key for the current submission. Send it
+// with fetch.WithIdempotencyKey so server.Idempotency rejects the same
+// submission twice; Reset starts a new one.
+func (fb *FormBuilder) IdempotencyKey() string {
+	return fb.guard.idemKey
+}
+
 // Reset resets the form to initial values
 func (fb *FormBuilder) Reset() {
+	fb.guard.reset()
 	for _, field := range fb.getAllFields() {
 		if field.DefaultValue != nil {
 			fb.SetFormValue(field.Name, field.DefaultValue)
@@ -697,6 +861,10 @@ func (fb *FormBuilder) Reset() {
 			switch field.Type {
 			case BuilderFieldCheckbox:
 				fb.SetFormValue(field.Name, false)
+			case BuilderFieldStepper:
+				fb.SetFormValue(field.Name, 0.0)
+			case BuilderFieldCurrency:
+				fb.SetFormValue(field.Name, types.Money{})
 			default:
 				fb.SetFormValue(field.Name, "")
 			}
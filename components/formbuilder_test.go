@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+func TestFormBuilderValidation(t *testing.T) {
+	var submitted map[string]any
+	form := components.NewFormBuilder(components.FormBuilderProps{
+		Fields: []components.BuilderField{{
+			Name: "email", Label: "Email", Type: components.BuilderFieldEmail,
+			Rules: []components.ValidationRule{components.Required, components.Email},
+		}},
+		OnSubmit: func(values map[string]any) error { submitted = values; return nil },
+	})
+	root := testutil.Render(t, form)
+
+	testutil.Submit(t, testutil.Query(t, root, "form"))
+	testutil.AssertVisible(t, testutil.Query(t, root, "#email-error"))
+	testutil.AssertAttr(t, testutil.Query(t, root, "#email"), "aria-invalid", "true")
+	if submitted != nil {
+		t.Fatalf("submitted %v with an invalid email", submitted)
+	}
+
+	testutil.Type(testutil.Query(t, root, "#email"), "ada@example.com")
+	testutil.Blur(testutil.Query(t, root, "#email"))
+	testutil.AssertHidden(t, testutil.Query(t, root, "#email-error"))
+
+	testutil.Submit(t, testutil.Query(t, root, "form"))
+	if submitted["email"] != "ada@example.com" {
+		t.Errorf("submitted %v", submitted)
+	}
+}
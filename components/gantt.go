@@ -0,0 +1,625 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// GanttZoom is the time scale of a Gantt chart
+type GanttZoom string
+
+const (
+	GanttDay   GanttZoom = "day"
+	GanttWeek  GanttZoom = "week"
+	GanttMonth GanttZoom = "month"
+)
+
+// GanttTask is a row of a Gantt chart. Start and End are whole days, End
+// exclusive: a task on the 3rd alone ends on the 4th.
+type GanttTask struct {
+	ID           string
+	Name         string
+	Start        time.Time
+	End          time.Time
+	Progress     float64  // share done, 0 to 1, shaded on the bar
+	Dependencies []string // IDs of tasks that must finish before this one starts
+	Milestone    bool     // shown as a diamond at Start
+	Color        string   // bar background class (default "bg-blue-600")
+	Locked       bool     // can't be moved or resized
+	Data         any      // app data
+}
+
+// GanttProps configures a Gantt chart
+type GanttProps struct {
+	Tasks []GanttTask
+	Zoom  GanttZoom   // default GanttDay
+	Zooms []GanttZoom // zoom levels offered by the switcher (default all three; one hides it)
+
+	// Start and End fix the visible range. By default it spans the tasks,
+	// with a few days either side.
+	Start time.Time
+	End   time.Time
+
+	// OnTaskChange is called after a task is moved or resized, with the task
+	// as it was. Call UpdateTask with previous to undo a change the server
+	// rejects.
+	OnTaskChange func(task, previous GanttTask)
+	OnTaskClick  func(task GanttTask)
+
+	ReadOnly  bool   // tasks can't be moved or resized
+	RowHeight int    // pixels per task (default 36)
+	ListWidth string // width of the task name column (default "14rem")
+	ClassName string
+}
+
+// Gantt shows tasks as bars across a time axis, with arrows from each task
+// to the tasks that depend on it. Bars can be dragged to reschedule them and
+// resized from their right edge, or moved with Alt+Left/Right and resized
+// with Alt+Shift+Left/Right, a day at a time.
+type Gantt struct {
+	container js.Value
+	switcher  map[GanttZoom]js.Value
+	list      js.Value // task names
+	scroll    js.Value // horizontally scrolling chart
+	chart     js.Value // scale, grid, bars, and links
+	links     js.Value // SVG of dependency arrows
+
+	props     GanttProps
+	tasks     []GanttTask
+	zoom      GanttZoom
+	start     time.Time // first day shown
+	days      int       // days shown
+	markerID  string
+	listeners listeners
+	drag      *ganttDrag
+}
+
+const (
+	ganttTaskColor   = "bg-blue-600"
+	ganttScaleHeight = 48 // two rows of labels
+	ganttViewClass   = "px-3 py-1 text-sm text-secondary hover:surface-overlay cursor-pointer focus:outline-none focus:ring-2 focus:ring-inset focus:ring-blue-500"
+	ganttActiveClass = "px-3 py-1 text-sm bg-blue-600 text-white cursor-pointer focus:outline-none focus:ring-2 focus:ring-inset focus:ring-blue-500"
+	ganttBarClass    = "absolute rounded overflow-hidden text-xs text-white px-2 flex items-center focus:outline-none focus:ring-2 focus:ring-offset-1 focus:ring-blue-500 "
+	ganttMilestone   = "absolute rotate-45 focus:outline-none focus:ring-2 focus:ring-offset-1 focus:ring-blue-500 "
+	ganttSVGNS       = "http://www.w3.org/2000/svg"
+)
+
+// ganttDayWidths are the pixels per day of each zoom level
+var ganttDayWidths = map[GanttZoom]float64{
+	GanttDay:   40,
+	GanttWeek:  18,
+	GanttMonth: 5,
+}
+
+// NewGantt creates a Gantt chart
+func NewGantt(props GanttProps) *Gantt {
+	if props.Zoom == "" {
+		props.Zoom = GanttDay
+	}
+	if len(props.Zooms) == 0 {
+		props.Zooms = []GanttZoom{GanttDay, GanttWeek, GanttMonth}
+	}
+	if props.RowHeight <= 0 {
+		props.RowHeight = 36
+	}
+	if props.ListWidth == "" {
+		props.ListWidth = "14rem"
+	}
+
+	g := &Gantt{
+		props:    props,
+		tasks:    append([]GanttTask(nil), props.Tasks...),
+		zoom:     props.Zoom,
+		markerID: "gantt-arrow-" + js.Global().Get("crypto").Call("randomUUID").String(),
+	}
+	g.container = Div("flex flex-col gap-3 " + props.ClassName)
+	g.container.Call("setAttribute", "role", "region")
+	g.container.Call("setAttribute", "aria-label", i18n.T("gux.gantt.label"))
+
+	if len(props.Zooms) > 1 {
+		group := Div("self-end inline-flex rounded-md border border-default overflow-hidden")
+		group.Call("setAttribute", "role", "group")
+		group.Call("setAttribute", "aria-label", i18n.T("gux.gantt.zoom"))
+		g.switcher = make(map[GanttZoom]js.Value)
+		for _, zoom := range props.Zooms {
+			zoom := zoom
+			btn := El("button", ganttViewClass)
+			btn.Set("type", "button")
+			btn.Set("textContent", i18n.T("gux.gantt."+string(zoom)))
+			g.listeners.on(btn, "click", func(this js.Value, args []js.Value) any {
+				g.SetZoom(zoom)
+				return nil
+			})
+			g.switcher[zoom] = btn
+			group.Call("appendChild", btn)
+		}
+		g.container.Call("appendChild", group)
+	}
+
+	body := Div("flex border border-subtle rounded-lg overflow-hidden select-none")
+	g.list = Div("flex-shrink-0 border-r border-subtle")
+	g.list.Get("style").Set("width", props.ListWidth)
+	g.scroll = Div("flex-1 min-w-0 overflow-x-auto")
+	body.Call("appendChild", g.list)
+	body.Call("appendChild", g.scroll)
+	g.container.Call("appendChild", body)
+
+	g.bindChart()
+	g.render()
+	onUnmount(g.container, g.Unmount)
+	return g
+}
+
+// Element returns the chart element
+func (g *Gantt) Element() js.Value {
+	return g.container
+}
+
+// Mount appends the chart to parent
+func (g *Gantt) Mount(parent js.Value) {
+	parent.Call("appendChild", g.container)
+}
+
+// Unmount removes the chart and releases its listeners
+func (g *Gantt) Unmount() {
+	unmount(g.container)
+	g.listeners.release()
+}
+
+// Tasks returns the chart's tasks
+func (g *Gantt) Tasks() []GanttTask {
+	return append([]GanttTask(nil), g.tasks...)
+}
+
+// SetTasks replaces the chart's tasks
+func (g *Gantt) SetTasks(tasks []GanttTask) {
+	g.tasks = append([]GanttTask(nil), tasks...)
+	g.render()
+}
+
+// AddTask adds a task as the last row
+func (g *Gantt) AddTask(task GanttTask) {
+	g.tasks = append(g.tasks, task)
+	g.render()
+}
+
+// UpdateTask replaces the task with task's ID. It returns false if there
+// is none.
+func (g *Gantt) UpdateTask(task GanttTask) bool {
+	i := g.taskIndex(task.ID)
+	if i < 0 {
+		return false
+	}
+	g.tasks[i] = task
+	g.render()
+	return true
+}
+
+// RemoveTask removes the task with id. It returns false if there is none.
+func (g *Gantt) RemoveTask(id string) bool {
+	i := g.taskIndex(id)
+	if i < 0 {
+		return false
+	}
+	g.tasks = append(g.tasks[:i], g.tasks[i+1:]...)
+	g.render()
+	return true
+}
+
+// Zoom returns the current zoom level
+func (g *Gantt) Zoom() GanttZoom {
+	return g.zoom
+}
+
+// SetZoom changes the time scale, keeping the day at the left edge in view
+func (g *Gantt) SetZoom(zoom GanttZoom) {
+	if zoom == g.zoom {
+		return
+	}
+	left := g.dayAtOffset(g.scroll.Get("scrollLeft").Float())
+	g.zoom = zoom
+	g.render()
+	g.ScrollTo(left)
+}
+
+// ScrollTo scrolls the chart so day is at its left edge
+func (g *Gantt) ScrollTo(day time.Time) {
+	g.scroll.Set("scrollLeft", g.x(day))
+}
+
+func (g *Gantt) taskIndex(id string) int {
+	for i, task := range g.tasks {
+		if task.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// changeTask moves task id to start and end, and reports the change
+func (g *Gantt) changeTask(id string, start, end time.Time) {
+	i := g.taskIndex(id)
+	if i < 0 {
+		return
+	}
+	previous := g.tasks[i]
+	if start.Equal(previous.Start) && end.Equal(taskEnd(previous)) {
+		g.render()
+		return
+	}
+	g.tasks[i].Start = start
+	if !previous.Milestone || !previous.End.IsZero() {
+		g.tasks[i].End = end
+	}
+	g.render()
+	if g.props.OnTaskChange != nil {
+		g.props.OnTaskChange(g.tasks[i], previous)
+	}
+}
+
+// editable reports whether task can be moved and resized
+func (g *Gantt) editable(task GanttTask) bool {
+	return !g.props.ReadOnly && !task.Locked
+}
+
+// taskEnd returns task's exclusive end, filling in a missing one
+func taskEnd(task GanttTask) time.Time {
+	if task.End.After(task.Start) {
+		return task.End
+	}
+	return startOfDay(task.Start).AddDate(0, 0, 1)
+}
+
+// dayWidth returns the pixels per day at the current zoom
+func (g *Gantt) dayWidth() float64 {
+	return ganttDayWidths[g.zoom]
+}
+
+// x returns the horizontal offset of t in the chart
+func (g *Gantt) x(t time.Time) float64 {
+	return t.Sub(g.start).Hours() / 24 * g.dayWidth()
+}
+
+// dayAtOffset returns the day at a horizontal offset in the chart
+func (g *Gantt) dayAtOffset(x float64) time.Time {
+	return g.start.AddDate(0, 0, int(x/g.dayWidth()))
+}
+
+// updateRange sets the days shown: the props' range, or the tasks' with
+// a margin, aligned to whole weeks or months when zoomed out
+func (g *Gantt) updateRange() {
+	start, end := g.props.Start, g.props.End
+	if start.IsZero() || end.IsZero() {
+		var first, last time.Time
+		for _, task := range g.tasks {
+			if first.IsZero() || task.Start.Before(first) {
+				first = task.Start
+			}
+			if end := taskEnd(task); last.IsZero() || end.After(last) {
+				last = end
+			}
+		}
+		if first.IsZero() {
+			first = time.Now()
+			last = first
+		}
+		if start.IsZero() {
+			start = first.AddDate(0, 0, -3)
+		}
+		if end.IsZero() {
+			end = last.AddDate(0, 0, 7)
+		}
+	}
+	start, end = startOfDay(start), startOfDay(end)
+	switch g.zoom {
+	case GanttWeek:
+		start = startOfWeek(start)
+	case GanttMonth:
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.Local)
+		end = time.Date(end.Year(), end.Month()+1, 1, 0, 0, 0, 0, time.Local)
+	}
+	g.start = start
+	g.days = max(dayDiff(start, end), 1)
+}
+
+func (g *Gantt) render() {
+	g.drag = nil
+	g.updateRange()
+	for zoom, btn := range g.switcher {
+		className := ganttViewClass
+		if zoom == g.zoom {
+			className = ganttActiveClass
+		}
+		btn.Set("className", className)
+		btn.Call("setAttribute", "aria-pressed", fmt.Sprint(zoom == g.zoom))
+	}
+
+	rowHeight := strconv.Itoa(g.props.RowHeight) + "px"
+	UnmountChildren(g.list)
+	head := Div("flex items-end px-3 pb-2 text-xs font-medium text-tertiary uppercase tracking-wider border-b border-subtle surface-raised", Span("", i18n.T("gux.gantt.task")))
+	head.Get("style").Set("height", strconv.Itoa(ganttScaleHeight)+"px")
+	g.list.Call("appendChild", head)
+	for _, task := range g.tasks {
+		row := Div("flex items-center px-3 text-sm text-primary truncate border-b border-subtle", Span("truncate", task.Name))
+		row.Get("style").Set("height", rowHeight)
+		row.Set("title", task.Name)
+		g.list.Call("appendChild", row)
+	}
+
+	width := float64(g.days) * g.dayWidth()
+	height := len(g.tasks) * g.props.RowHeight
+	UnmountChildren(g.scroll)
+	g.chart = Div("relative")
+	g.chart.Get("style").Set("width", ganttPx(width))
+	g.chart.Call("appendChild", g.renderScale())
+
+	grid := Div("relative")
+	grid.Get("style").Set("height", strconv.Itoa(height)+"px")
+	g.renderGrid(grid)
+	for i := range g.tasks {
+		line := Div("absolute inset-x-0 border-b border-subtle pointer-events-none")
+		line.Get("style").Set("top", strconv.Itoa((i+1)*g.props.RowHeight-1)+"px")
+		grid.Call("appendChild", line)
+	}
+
+	g.links = js.Global().Get("document").Call("createElementNS", ganttSVGNS, "svg")
+	g.links.Call("setAttribute", "class", "absolute inset-0 pointer-events-none overflow-visible")
+	g.links.Call("setAttribute", "width", ganttPx(width))
+	g.links.Call("setAttribute", "height", strconv.Itoa(height))
+	g.links.Call("setAttribute", "aria-hidden", "true")
+	grid.Call("appendChild", g.links)
+
+	for i, task := range g.tasks {
+		grid.Call("appendChild", g.renderBar(task, i))
+	}
+	g.chart.Call("appendChild", grid)
+	g.scroll.Call("appendChild", g.chart)
+	g.drawLinks(nil)
+}
+
+// renderScale draws the time axis: months over days or weeks, or years
+// over months
+func (g *Gantt) renderScale() js.Value {
+	scale := Div("relative border-b border-subtle surface-raised text-xs text-tertiary")
+	scale.Get("style").Set("height", strconv.Itoa(ganttScaleHeight)+"px")
+	end := g.start.AddDate(0, 0, g.days)
+
+	label := func(text string, from, to time.Time, top bool) {
+		className := "absolute h-6 flex items-center px-1 truncate border-l border-subtle"
+		if top {
+			className += " top-0 font-medium text-secondary"
+		} else {
+			className += " bottom-0"
+		}
+		el := Div(className, Span("truncate", text))
+		el.Get("style").Set("left", ganttPx(g.x(from)))
+		el.Get("style").Set("width", ganttPx(g.x(to)-g.x(from)))
+		scale.Call("appendChild", el)
+	}
+
+	// Top row: months, or years when zoomed to months
+	for t := g.start; t.Before(end); {
+		var next time.Time
+		var text string
+		if g.zoom == GanttMonth {
+			next = time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, time.Local)
+			text = strconv.Itoa(t.Year())
+		} else {
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.Local)
+			text = fmt.Sprintf("%s %d", i18n.MonthName(t.Month()), t.Year())
+		}
+		if next.After(end) {
+			next = end
+		}
+		label(text, t, next, true)
+		t = next
+	}
+
+	// Bottom row: the zoom's units
+	for t := g.start; t.Before(end); {
+		next := g.nextUnit(t)
+		var text string
+		switch g.zoom {
+		case GanttMonth:
+			text = i18n.ShortMonthName(t.Month())
+		case GanttWeek:
+			text = i18n.ShortMonthName(t.Month()) + " " + strconv.Itoa(t.Day())
+		default:
+			text = strconv.Itoa(t.Day())
+		}
+		if next.After(end) {
+			next = end
+		}
+		label(text, t, next, false)
+		t = next
+	}
+	return scale
+}
+
+// nextUnit returns the start of the day, week, or month after t's
+func (g *Gantt) nextUnit(t time.Time) time.Time {
+	switch g.zoom {
+	case GanttMonth:
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.Local)
+	case GanttWeek:
+		return startOfWeek(t).AddDate(0, 0, 7)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// renderGrid draws unit lines, weekend shading at day zoom, and a line at
+// today
+func (g *Gantt) renderGrid(grid js.Value) {
+	end := g.start.AddDate(0, 0, g.days)
+	for t := g.start; t.Before(end); t = g.nextUnit(t) {
+		if g.zoom == GanttDay && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+			shade := Div("absolute inset-y-0 surface-sunken pointer-events-none")
+			shade.Get("style").Set("left", ganttPx(g.x(t)))
+			shade.Get("style").Set("width", ganttPx(g.dayWidth()))
+			grid.Call("appendChild", shade)
+		}
+		line := Div("absolute inset-y-0 border-l border-subtle pointer-events-none")
+		line.Get("style").Set("left", ganttPx(g.x(t)))
+		grid.Call("appendChild", line)
+	}
+	if now := time.Now(); !now.Before(g.start) && now.Before(end) {
+		today := Div("absolute inset-y-0 w-0.5 bg-red-500 pointer-events-none")
+		today.Get("style").Set("left", ganttPx(g.x(now)))
+		grid.Call("appendChild", today)
+	}
+}
+
+// renderBar renders a task's bar, or its diamond if it is a milestone
+func (g *Gantt) renderBar(task GanttTask, row int) js.Value {
+	color := task.Color
+	if color == "" {
+		color = ganttTaskColor
+	}
+	className := ganttBarClass
+	if task.Milestone {
+		className = ganttMilestone
+	}
+	el := Div(className + color)
+	el.Call("setAttribute", "role", "button")
+	el.Call("setAttribute", "tabindex", "0")
+	el.Call("setAttribute", "data-task", task.ID)
+	el.Call("setAttribute", "aria-label", g.taskLabel(task))
+	el.Set("title", task.Name)
+	if g.editable(task) {
+		el.Get("classList").Call("add", "cursor-grab", "touch-none")
+	} else {
+		el.Get("classList").Call("add", "cursor-pointer")
+	}
+
+	size := g.props.RowHeight * 3 / 5
+	el.Get("style").Set("top", strconv.Itoa(row*g.props.RowHeight+(g.props.RowHeight-size)/2)+"px")
+	el.Get("style").Set("height", strconv.Itoa(size)+"px")
+	if task.Milestone {
+		el.Get("style").Set("width", strconv.Itoa(size)+"px")
+		el.Get("style").Set("left", ganttPx(g.x(task.Start)-float64(size)/2))
+		return el
+	}
+	g.placeBar(el, task.Start, taskEnd(task))
+
+	if task.Progress > 0 {
+		done := Div("absolute inset-y-0 left-0 bg-black/25 pointer-events-none")
+		done.Get("style").Set("width", fmt.Sprintf("%.1f%%", min(task.Progress, 1)*100))
+		el.Call("appendChild", done)
+	}
+	el.Call("appendChild", Span("relative truncate pointer-events-none", task.Name))
+	if g.editable(task) {
+		handle := Div("absolute inset-y-0 right-0 w-2 cursor-ew-resize")
+		handle.Call("setAttribute", "data-resize", "")
+		el.Call("appendChild", handle)
+	}
+	return el
+}
+
+// placeBar sets a bar's left edge and width to span start to end
+func (g *Gantt) placeBar(el js.Value, start, end time.Time) {
+	el.Get("style").Set("left", ganttPx(g.x(start)))
+	el.Get("style").Set("width", ganttPx(max(g.x(end)-g.x(start), 4)))
+}
+
+// drawLinks draws an arrow from each task to each task depending on it,
+// red where the dependent starts before the task ends. moved, if set, is
+// a task being dragged.
+func (g *Gantt) drawLinks(moved *GanttTask) {
+	g.links.Set("innerHTML", "")
+	document := js.Global().Get("document")
+	newEl := func(parent js.Value, tag string, attrs map[string]string) js.Value {
+		el := document.Call("createElementNS", ganttSVGNS, tag)
+		for k, v := range attrs {
+			el.Call("setAttribute", k, v)
+		}
+		parent.Call("appendChild", el)
+		return el
+	}
+	defs := newEl(g.links, "defs", nil)
+	const onTime, late = "text-gray-400 dark:text-gray-500", "text-red-500"
+	for id, className := range map[string]string{g.markerID: onTime, g.markerID + "-late": late} {
+		marker := newEl(defs, "marker", map[string]string{
+			"id": id, "viewBox": "0 0 8 8", "refX": "8", "refY": "4",
+			"markerWidth": "8", "markerHeight": "8", "orient": "auto",
+		})
+		newEl(marker, "path", map[string]string{"d": "M0,0 L8,4 L0,8 z", "fill": "currentColor", "class": className})
+	}
+
+	rows := make(map[string]int, len(g.tasks))
+	tasks := make([]GanttTask, len(g.tasks))
+	for i, task := range g.tasks {
+		if moved != nil && task.ID == moved.ID {
+			task = *moved
+		}
+		rows[task.ID] = i
+		tasks[i] = task
+	}
+	rowMid := func(i int) float64 {
+		return float64(i*g.props.RowHeight) + float64(g.props.RowHeight)/2
+	}
+	for i, task := range tasks {
+		for _, dep := range task.Dependencies {
+			j, ok := rows[dep]
+			if !ok {
+				continue
+			}
+			from := tasks[j]
+			x1, y1 := g.x(taskEnd(from)), rowMid(j)
+			if from.Milestone {
+				x1 = g.x(from.Start) + float64(g.props.RowHeight)*3/10
+			}
+			x2, y2 := g.x(task.Start), rowMid(i)
+			if task.Milestone {
+				x2 -= float64(g.props.RowHeight) * 3 / 10
+			}
+
+			// Out of the end, across to the row, and into the start; back
+			// around between the rows when the start is further left
+			var d string
+			if x2-x1 >= 16 {
+				d = fmt.Sprintf("M%.1f,%.1f H%.1f V%.1f H%.1f", x1, y1, x1+8, y2, x2)
+			} else {
+				between := y2 - float64(g.props.RowHeight)/2
+				if y2 < y1 {
+					between = y2 + float64(g.props.RowHeight)/2
+				}
+				d = fmt.Sprintf("M%.1f,%.1f H%.1f V%.1f H%.1f V%.1f H%.1f", x1, y1, x1+8, between, x2-8, y2, x2)
+			}
+			blocked := task.Start.Before(taskEnd(from))
+			if from.Milestone {
+				blocked = task.Start.Before(from.Start)
+			}
+			className, marker := onTime, g.markerID
+			if blocked {
+				className, marker = late, g.markerID+"-late"
+			}
+			newEl(g.links, "path", map[string]string{
+				"d": d, "fill": "none", "stroke": "currentColor", "stroke-width": "1.5",
+				"class": className, "marker-end": "url(#" + marker + ")",
+			})
+		}
+	}
+}
+
+// taskLabel describes a task for screen readers
+func (g *Gantt) taskLabel(task GanttTask) string {
+	if task.Milestone {
+		return task.Name + ", " + i18n.T("gux.gantt.milestone") + ", " + i18n.FormatDate(task.Start)
+	}
+	label := task.Name + ", " + i18n.FormatDate(task.Start) + " – " + i18n.FormatDate(taskEnd(task).AddDate(0, 0, -1))
+	if task.Progress > 0 {
+		label += ", " + i18n.T("gux.gantt.progress", int(min(task.Progress, 1)*100))
+	}
+	return label
+}
+
+func ganttPx(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + "px"
+}
@@ -0,0 +1,166 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"math"
+	"syscall/js"
+	"time"
+)
+
+// ganttDrag is a pointer press on a bar, which becomes a drag once the
+// pointer moves a few pixels
+type ganttDrag struct {
+	task   GanttTask
+	el     js.Value
+	resize bool // moving the end rather than the whole task
+	x      float64
+	moved  bool
+
+	start, end time.Time // the task as dragged so far
+}
+
+// bindChart listens on the scroll area for presses, drags, and keys on
+// bars, so renders add no listeners of their own
+func (g *Gantt) bindChart() {
+	g.listeners.on(g.scroll, "pointerdown", func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if event.Get("button").Int() != 0 {
+			return nil
+		}
+		target := event.Get("target")
+		el := target.Call("closest", "[data-task]")
+		if !el.Truthy() {
+			return nil
+		}
+		i := g.taskIndex(el.Call("getAttribute", "data-task").String())
+		if i < 0 {
+			return nil
+		}
+		task := g.tasks[i]
+		g.drag = &ganttDrag{
+			task:   task,
+			el:     el,
+			resize: target.Call("closest", "[data-resize]").Truthy(),
+			x:      event.Get("clientX").Float(),
+			start:  task.Start,
+			end:    taskEnd(task),
+		}
+		g.scroll.Call("setPointerCapture", event.Get("pointerId"))
+		return nil
+	})
+	g.listeners.on(g.scroll, "pointermove", func(this js.Value, args []js.Value) any {
+		d := g.drag
+		if d == nil || !g.editable(d.task) {
+			return nil
+		}
+		dx := args[0].Get("clientX").Float() - d.x
+		if !d.moved {
+			if math.Abs(dx) < 4 {
+				return nil
+			}
+			d.moved = true
+			d.el.Get("classList").Call("add", "opacity-75", "shadow-lg", "z-10")
+		}
+
+		days := int(math.Round(dx / g.dayWidth()))
+		end := taskEnd(d.task)
+		if d.resize {
+			d.end = end.AddDate(0, 0, days)
+			if !d.end.After(d.start) {
+				d.end = startOfDay(d.start).AddDate(0, 0, 1)
+			}
+		} else {
+			d.start, d.end = d.task.Start.AddDate(0, 0, days), end.AddDate(0, 0, days)
+		}
+
+		if d.task.Milestone {
+			d.el.Get("style").Set("left", ganttPx(g.x(d.start)-d.el.Get("offsetWidth").Float()/2))
+		} else {
+			g.placeBar(d.el, d.start, d.end)
+		}
+		moved := d.task
+		moved.Start, moved.End = d.start, d.end
+		g.drawLinks(&moved)
+		return nil
+	})
+	g.listeners.on(g.scroll, "pointerup", func(this js.Value, args []js.Value) any {
+		d := g.drag
+		if d == nil {
+			return nil
+		}
+		g.drag = nil
+		if !d.moved {
+			if g.props.OnTaskClick != nil {
+				g.props.OnTaskClick(d.task)
+			}
+			return nil
+		}
+		g.changeTask(d.task.ID, d.start, d.end)
+		return nil
+	})
+	g.listeners.on(g.scroll, "pointercancel", func(this js.Value, args []js.Value) any {
+		if g.drag != nil {
+			g.render()
+		}
+		return nil
+	})
+	g.listeners.on(g.scroll, "keydown", func(this js.Value, args []js.Value) any {
+		g.keyDown(args[0])
+		return nil
+	})
+}
+
+func (g *Gantt) keyDown(event js.Value) {
+	el := event.Get("target").Call("closest", "[data-task]")
+	if !el.Truthy() {
+		return
+	}
+	id := el.Call("getAttribute", "data-task").String()
+	i := g.taskIndex(id)
+	if i < 0 {
+		return
+	}
+	task := g.tasks[i]
+	key := event.Get("key").String()
+
+	if key == "Enter" || key == " " {
+		event.Call("preventDefault")
+		if g.props.OnTaskClick != nil {
+			g.props.OnTaskClick(task)
+		}
+		return
+	}
+	if !event.Get("altKey").Bool() || !g.editable(task) {
+		return
+	}
+
+	days := 0
+	switch key {
+	case "ArrowLeft":
+		days = -1
+	case "ArrowRight":
+		days = 1
+	default:
+		return
+	}
+	event.Call("preventDefault")
+	start, end := task.Start, taskEnd(task)
+	if event.Get("shiftKey").Bool() && !task.Milestone {
+		end = end.AddDate(0, 0, days)
+		if !end.After(start) {
+			return
+		}
+	} else {
+		start, end = start.AddDate(0, 0, days), end.AddDate(0, 0, days)
+	}
+	g.changeTask(id, start, end)
+
+	bars := g.scroll.Call("querySelectorAll", "[data-task]")
+	for i := 0; i < bars.Length(); i++ {
+		if bars.Index(i).Call("getAttribute", "data-task").String() == id {
+			bars.Index(i).Call("focus")
+			break
+		}
+	}
+}
@@ -30,6 +30,7 @@ type Header struct {
 	userMenu           *UserMenu
 	notificationCenter *NotificationCenter
 	connectionStatus   *ConnectionStatus
+	listeners          listeners
 }
 
 // NewHeader creates a new Header component
@@ -38,6 +39,7 @@ func NewHeader(props HeaderProps) *Header {
 
 	header := document.Call("createElement", "header")
 	header.Set("className", "bg-white dark:bg-gray-800 shadow dark:shadow-gray-900 px-4 md:px-6 py-4 flex justify-between items-center")
+	var funcs listeners
 
 	// Left side: hamburger menu (mobile) + title
 	leftDiv := document.Call("createElement", "div")
@@ -49,7 +51,7 @@ func NewHeader(props HeaderProps) *Header {
 		menuBtn.Set("className", "md:hidden p-2 -ml-2 text-gray-600 dark:text-gray-300 hover:text-gray-900 dark:hover:text-white hover:bg-gray-100 dark:hover:bg-gray-700 rounded-lg transition-colors")
 		menuBtn.Set("innerHTML", `<svg xmlns="http://www.w3.org/2000/svg" class="h-6 w-6" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M4 6h16M4 12h16M4 18h16"/></svg>`)
 		menuBtn.Set("ariaLabel", "Open menu")
-		menuBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		menuBtn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 			props.OnMenuToggle()
 			return nil
 		}))
@@ -96,6 +98,7 @@ func NewHeader(props HeaderProps) *Header {
 		userMenu:           props.UserMenu,
 		notificationCenter: props.NotificationCenter,
 		connectionStatus:   props.ConnectionStatus,
+		listeners:          funcs,
 	}
 
 	for _, action := range props.Actions {
@@ -106,6 +109,7 @@ func NewHeader(props HeaderProps) *Header {
 		})
 		buttonsDiv.Call("appendChild", btn)
 	}
+	onUnmount(header, h.Unmount)
 
 	return h
 }
@@ -115,6 +119,17 @@ func (h *Header) Element() js.Value {
 	return h.element
 }
 
+// Mount appends the header to parent
+func (h *Header) Mount(parent js.Value) {
+	parent.Call("appendChild", h.element)
+}
+
+// Unmount removes the header and releases its listeners
+func (h *Header) Unmount() {
+	unmount(h.element)
+	h.listeners.release()
+}
+
 // SetTitle updates the header title
 func (h *Header) SetTitle(title string) {
 	h.titleText = title
@@ -0,0 +1,87 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/prefs"
+)
+
+var localeInitialized bool
+
+// InitLocale restores the saved locale, falling back to the best registered
+// match for the browser language. Register app catalogs before calling it.
+func InitLocale() {
+	if localeInitialized {
+		return
+	}
+	localeInitialized = true
+
+	locale := prefs.Layout.GetString(prefs.KeyLocale, "")
+	if locale == "" {
+		if lang := js.Global().Get("navigator").Get("language"); lang.Truthy() {
+			locale = i18n.Match(lang.String())
+		}
+	}
+	if locale != "" {
+		i18n.SetLocale(locale)
+	}
+	applyLocale()
+
+	// Follow locale changes synced from other devices
+	prefs.Layout.Subscribe(prefs.KeyLocale, func() {
+		locale := prefs.Layout.GetString(prefs.KeyLocale, i18n.Locale())
+		if locale != i18n.Locale() {
+			i18n.SetLocale(locale)
+			applyLocale()
+		}
+	})
+}
+
+// SetLocale switches the UI locale at runtime and persists the choice.
+// Components render strings when built, so rebuild visible views from an
+// OnLocaleChange callback.
+func SetLocale(locale string) {
+	i18n.SetLocale(locale)
+	prefs.Layout.SetString(prefs.KeyLocale, i18n.Locale())
+	applyLocale()
+}
+
+// GetLocale returns the current locale
+func GetLocale() string {
+	return i18n.Locale()
+}
+
+// OnLocaleChange subscribes to locale changes. Returns an unsubscribe function.
+func OnLocaleChange(fn func(locale string)) func() {
+	return i18n.OnChange(fn)
+}
+
+// T translates a message key in the current locale, formatting args with fmt verbs
+func T(key string, args ...any) string {
+	return i18n.T(key, args...)
+}
+
+// TN translates the plural form of a message key for count n
+func TN(key string, n int, args ...any) string {
+	return i18n.N(key, n, args...)
+}
+
+// FormatNumber formats a number with the current locale's separators
+func FormatNumber(v float64, decimals int) string {
+	return i18n.FormatNumber(v, decimals)
+}
+
+// FormatDate formats a date with the current locale's date layout
+func FormatDate(t time.Time) string {
+	return i18n.FormatDate(t)
+}
+
+// applyLocale sets the document language so screen readers and the browser
+// pick the right pronunciation and hyphenation
+func applyLocale() {
+	js.Global().Get("document").Get("documentElement").Set("lang", i18n.Locale())
+}
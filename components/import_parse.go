@@ -0,0 +1,216 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// parseImportFile reads the records of a CSV, TSV, or XLSX file, the first
+// being the header row. Empty rows are dropped.
+func parseImportFile(name string, data []byte) ([][]string, error) {
+	var records [][]string
+	var err error
+	if strings.HasSuffix(strings.ToLower(name), ".xlsx") {
+		records, err = parseXLSX(data)
+	} else {
+		records, err = parseCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := records[:0]
+	for _, record := range records {
+		for _, value := range record {
+			if strings.TrimSpace(value) != "" {
+				rows = append(rows, record)
+				break
+			}
+		}
+	}
+	return rows, nil
+}
+
+// parseCSV reads comma, semicolon, or tab separated values, whichever the
+// header row has most of
+func parseCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM, as Excel writes
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = ','
+	for _, sep := range []rune{';', '\t'} {
+		if bytes.Count(header, []byte(string(sep))) > bytes.Count(header, []byte(string(r.Comma))) {
+			r.Comma = sep
+		}
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	return r.ReadAll()
+}
+
+// XLSX parts read by parseXLSX
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxSheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string    `xml:"r,attr"`
+				Type   string    `xml:"t,attr"`
+				Value  string    `xml:"v"`
+				Inline *xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+// parseXLSX reads the cell text of an Excel workbook's first sheet. Cells
+// hold what Excel stores: dates are serial day numbers, and numbers aren't
+// formatted.
+func parseXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("not an Excel workbook")
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	readXML := func(name string, v any) error {
+		f, ok := files[name]
+		if !ok {
+			return errors.New("missing " + name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return xml.NewDecoder(io.LimitReader(rc, 256<<20)).Decode(v)
+	}
+
+	// The first sheet, found through the workbook's relationships
+	sheetName := "xl/worksheets/sheet1.xml"
+	var wb xlsxWorkbook
+	var rels xlsxRels
+	if readXML("xl/workbook.xml", &wb) == nil && len(wb.Sheets) > 0 && readXML("xl/_rels/workbook.xml.rels", &rels) == nil {
+		for _, rel := range rels.Items {
+			if rel.ID == wb.Sheets[0].RelID {
+				if strings.HasPrefix(rel.Target, "/") {
+					sheetName = strings.TrimPrefix(rel.Target, "/")
+				} else {
+					sheetName = path.Join("xl", rel.Target)
+				}
+				break
+			}
+		}
+	}
+
+	var shared xlsxStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readXML("xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+	var sheet xlsxSheet
+	if err := readXML(sheetName, &sheet); err != nil {
+		return nil, err
+	}
+
+	records := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var record []string
+		for i, cell := range row.Cells {
+			col := xlsxColumn(cell.Ref)
+			switch {
+			case col >= xlsxMaxColumns:
+				return nil, errors.New("cell " + cell.Ref + " is beyond Excel's last column, XFD")
+			case col < 0:
+				col = i
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				if n, err := strconv.Atoi(cell.Value); err == nil && n >= 0 && n < len(shared.Items) {
+					record[col] = shared.Items[n].String()
+				}
+			case "inlineStr":
+				if cell.Inline != nil {
+					record[col] = cell.Inline.String()
+				}
+			case "b":
+				record[col] = strconv.FormatBool(cell.Value == "1")
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// xlsxMaxColumns is the number of columns of a sheet, A to XFD
+const xlsxMaxColumns = 16384
+
+// xlsxColumn returns the column index of a cell reference such as "AB12",
+// or -1 if it has none. Columns past XFD return xlsxMaxColumns, so a crafted
+// reference can't make a huge row.
+func xlsxColumn(ref string) int {
+	col := 0
+	n := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+		if col > xlsxMaxColumns {
+			return xlsxMaxColumns
+		}
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
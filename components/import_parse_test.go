@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestXLSXColumn(t *testing.T) {
+	tests := map[string]int{
+		"A1": 0, "Z9": 25, "AA1": 26, "AB12": 27, "XFD1": 16383,
+		"XFE1": xlsxMaxColumns, "ZZZZZZZZZZZZZZ1": xlsxMaxColumns, "12": -1, "": -1,
+	}
+	for ref, want := range tests {
+		if got := xlsxColumn(ref); got != want {
+			t.Errorf("xlsxColumn(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
+
+// workbook returns a workbook whose first sheet has the given rows XML
+func workbook(t *testing.T, rows string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(`<worksheet><sheetData>` + rows + `</sheetData></worksheet>`))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseXLSX(t *testing.T) {
+	records, err := parseXLSX(workbook(t, `<row><c r="A1" t="inlineStr"><is><t>name</t></is></c><c r="C1"><v>3</v></c></row>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || strings.Join(records[0], "|") != "name||3" {
+		t.Errorf("records = %q", records)
+	}
+
+	_, err = parseXLSX(workbook(t, `<row><c r="XFE1"><v>1</v></c></row>`))
+	if err == nil || !strings.Contains(err.Error(), "XFD") {
+		t.Errorf("column past XFD: err = %v", err)
+	}
+}
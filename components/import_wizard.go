@@ -0,0 +1,536 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"unicode"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+// ImportField is a field of the rows an ImportWizard produces, which a
+// column of the file is mapped to
+type ImportField struct {
+	Name     string                          // Key in the imported rows
+	Label    string                          // Shown in the mapping step (default Name)
+	Required bool                            // Must be mapped, and have a value in every row
+	Aliases  []string                        // Other column headers mapped to the field automatically
+	Rules    []ValidationRule                // Checked against each row's text
+	Parse    func(value string) (any, error) // Converts the text, e.g. to a number (default: kept as text)
+}
+
+// ImportWizardProps configures an ImportWizard
+type ImportWizardProps struct {
+	Fields  []ImportField
+	MaxSize int64 // Largest file accepted, in bytes (default 10 MB)
+
+	// OnComplete receives the rows that passed validation, keyed by field
+	// name, leaving out empty values. It runs in a goroutine, so it may
+	// call the server; an error is shown and the wizard stays on the review.
+	OnComplete func(rows []map[string]any) error
+	OnCancel   func() // shows a Cancel button on the first step
+	ClassName  string
+}
+
+// ImportError is a value that failed validation
+type ImportError struct {
+	Row     int    // Row in the file, counting the header as 1 and skipping empty rows
+	Field   string // Label of the field
+	Value   string
+	Message string
+}
+
+// ImportWizard imports rows from a CSV or Excel file in three steps: upload
+// the file, map its columns to the target fields, and review the
+// validation errors before importing the rows that passed. It's the
+// counterpart of ExportCSV.
+type ImportWizard struct {
+	props   ImportWizardProps
+	element js.Value
+	status  js.Value
+	errorEl js.Value
+	step    int // 0 upload, 1 mapping, 2 review, 3 done
+
+	fileName string
+	headers  []string
+	records  [][]string
+	mapping  map[string]int // file column of each field, by name
+	rows     []map[string]any
+	errors   []ImportError
+	busy     bool
+}
+
+// importSteps are the titles of the steps, as i18n keys
+var importSteps = []string{"gux.import.upload", "gux.import.mapping", "gux.import.review"}
+
+// NewImportWizard creates a new ImportWizard component
+func NewImportWizard(props ImportWizardProps) *ImportWizard {
+	if props.MaxSize <= 0 {
+		props.MaxSize = 10 << 20
+	}
+
+	document := js.Global().Get("document")
+	element := document.Call("createElement", "div")
+	className := "w-full"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	element.Set("className", className)
+
+	// Announces the step after each move, since the content is replaced
+	status := document.Call("createElement", "p")
+	status.Set("className", "sr-only")
+	status.Call("setAttribute", "aria-live", "polite")
+
+	w := &ImportWizard{props: props, element: element, status: status}
+	w.render(false)
+	onUnmount(element, w.Unmount)
+	return w
+}
+
+// Element returns the container DOM element
+func (w *ImportWizard) Element() js.Value {
+	return w.element
+}
+
+// Mount appends the wizard to parent
+func (w *ImportWizard) Mount(parent js.Value) {
+	parent.Call("appendChild", w.element)
+}
+
+// Unmount removes the wizard and releases its listeners
+func (w *ImportWizard) Unmount() {
+	unmount(w.element)
+}
+
+// Rows returns the rows that passed validation, once the columns are mapped
+func (w *ImportWizard) Rows() []map[string]any {
+	return w.rows
+}
+
+// Errors returns the values that failed validation, once the columns are
+// mapped
+func (w *ImportWizard) Errors() []ImportError {
+	return w.errors
+}
+
+// Reset starts over with a new file
+func (w *ImportWizard) Reset() {
+	w.fileName, w.headers, w.records, w.mapping = "", nil, nil, nil
+	w.rows, w.errors = nil, nil
+	w.goTo(0)
+}
+
+func (w *ImportWizard) goTo(step int) {
+	w.step = step
+	w.render(true)
+}
+
+// load reads and parses the uploaded file, then moves to the mapping
+func (w *ImportWizard) load(file FileInfo) {
+	w.hideError()
+	// Reading the file blocks, so it can't run on the event callback
+	go func() {
+		buf, err := jsutil.Await(context.Background(), file.File.Call("arrayBuffer"), promiseError)
+		var records [][]string
+		if err == nil {
+			data := make([]byte, buf.Get("byteLength").Int())
+			js.CopyBytesToGo(data, js.Global().Get("Uint8Array").New(buf))
+			records, err = parseImportFile(file.Name, data)
+		}
+		if err == nil && len(records) < 2 {
+			err = errors.New(i18n.T("gux.import.empty"))
+		}
+		if err != nil {
+			w.showError(i18n.T("gux.import.read", file.Name, err.Error()))
+			return
+		}
+
+		w.fileName = file.Name
+		w.headers, w.records = records[0], records[1:]
+		w.autoMap()
+		w.goTo(1)
+	}()
+}
+
+// autoMap maps each field to the column whose header matches its name,
+// label, or an alias, ignoring case, spaces, and punctuation
+func (w *ImportWizard) autoMap() {
+	w.mapping = make(map[string]int)
+	for _, f := range w.props.Fields {
+		names := append([]string{f.Name, f.Label}, f.Aliases...)
+		for col, header := range w.headers {
+			for _, name := range names {
+				if name != "" && importKey(name) == importKey(header) {
+					w.mapping[f.Name] = col
+					break
+				}
+			}
+			if _, ok := w.mapping[f.Name]; ok {
+				break
+			}
+		}
+	}
+}
+
+func importKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// validate checks every row against the fields' rules, splitting the
+// records into clean rows and errors
+func (w *ImportWizard) validate() {
+	w.rows, w.errors = nil, nil
+	for i, record := range w.records {
+		row := make(map[string]any)
+		valid := true
+		for _, f := range w.props.Fields {
+			col, ok := w.mapping[f.Name]
+			if !ok {
+				continue
+			}
+			text := ""
+			if col < len(record) {
+				text = strings.TrimSpace(record[col])
+			}
+			fail := func(message string) {
+				w.errors = append(w.errors, ImportError{Row: i + 2, Field: importLabel(f), Value: text, Message: message})
+				valid = false
+			}
+
+			rules := f.Rules
+			if f.Required {
+				rules = append([]ValidationRule{Required}, rules...)
+			}
+			passed := true
+			for _, rule := range rules {
+				if !rule.Validate(text) {
+					fail(rule.Text())
+					passed = false
+					break
+				}
+			}
+			if !passed || text == "" {
+				continue
+			}
+
+			var value any = text
+			if f.Parse != nil {
+				v, err := f.Parse(text)
+				if err != nil {
+					fail(err.Error())
+					continue
+				}
+				value = v
+			}
+			row[f.Name] = value
+		}
+		if valid {
+			w.rows = append(w.rows, row)
+		}
+	}
+}
+
+// confirmMapping checks that every required field is mapped, then
+// validates the rows and moves to the review
+func (w *ImportWizard) confirmMapping() {
+	for _, f := range w.props.Fields {
+		if _, ok := w.mapping[f.Name]; f.Required && !ok {
+			w.showError(i18n.T("gux.import.unmapped", importLabel(f)))
+			return
+		}
+	}
+	w.validate()
+	w.goTo(2)
+}
+
+func (w *ImportWizard) complete() {
+	if w.busy || len(w.rows) == 0 {
+		return
+	}
+	if w.props.OnComplete == nil {
+		w.goTo(3)
+		return
+	}
+	rows := w.rows
+	w.setBusy(true)
+	w.hideError()
+	// OnComplete usually calls the server, which blocks, so it can't run on
+	// the event callback
+	go func() {
+		err := w.props.OnComplete(rows)
+		w.setBusy(false)
+		if err != nil {
+			w.showError(i18n.T("gux.wizard.failed", err.Error()))
+			return
+		}
+		w.goTo(3)
+	}()
+}
+
+func (w *ImportWizard) setBusy(busy bool) {
+	w.busy = busy
+	buttons := w.element.Call("querySelectorAll", "button")
+	for i := 0; i < buttons.Get("length").Int(); i++ {
+		buttons.Index(i).Set("disabled", busy)
+	}
+	w.element.Call("setAttribute", "aria-busy", strconv.FormatBool(busy))
+}
+
+func (w *ImportWizard) showError(message string) {
+	w.errorEl.Set("innerHTML", "")
+	w.errorEl.Call("appendChild", AlertErrorMsg(message))
+}
+
+func (w *ImportWizard) hideError() {
+	w.errorEl.Set("innerHTML", "")
+}
+
+// render rebuilds the stepper with the current step's content
+func (w *ImportWizard) render(focus bool) {
+	document := js.Global().Get("document")
+
+	content := document.Call("createElement", "div")
+	content.Set("className", "space-y-4")
+	w.errorEl = document.Call("createElement", "div")
+	content.Call("appendChild", w.errorEl)
+
+	switch w.step {
+	case 0:
+		content.Call("appendChild", w.renderUpload())
+	case 1:
+		content.Call("appendChild", w.renderMapping())
+	case 2:
+		content.Call("appendChild", w.renderReview())
+	default:
+		content.Call("appendChild", w.renderDone())
+	}
+
+	pos := min(w.step, len(importSteps)-1)
+	steps := make([]Step, len(importSteps))
+	for i, key := range importSteps {
+		steps[i] = Step{Title: i18n.T(key)}
+	}
+	steps[pos].Content = content
+	stepper := NewStepper(StepperProps{
+		Steps:       steps,
+		CurrentStep: pos,
+		OnStepClick: func(i int) {
+			// Earlier steps can be revisited until the rows are imported
+			if i < w.step && w.step < 3 && !w.busy {
+				w.hideError()
+				w.goTo(i)
+			}
+		},
+	})
+
+	UnmountChildren(w.element)
+	w.element.Set("innerHTML", "")
+	w.element.Call("appendChild", w.status)
+	w.element.Call("appendChild", stepper.Element())
+
+	if focus {
+		w.status.Set("textContent", i18n.T("gux.wizard.progress", pos+1, len(importSteps), steps[pos].Title))
+		target := content.Call("querySelector", "input:not([type=hidden]), select, button")
+		if !target.IsNull() {
+			target.Call("focus")
+		}
+	}
+}
+
+func (w *ImportWizard) buttons(children ...js.Value) js.Value {
+	return Div("flex gap-3 pt-4", children...)
+}
+
+// renderUpload shows the file picker
+func (w *ImportWizard) renderUpload() js.Value {
+	upload := NewFileUpload(FileUploadProps{
+		Label:   i18n.T("gux.import.file"),
+		Accept:  ".csv,.tsv,.txt,.xlsx",
+		MaxSize: w.props.MaxSize,
+		OnSelect: func(files []FileInfo) {
+			if len(files) > 0 {
+				w.load(files[len(files)-1])
+			}
+		},
+		OnError: w.showError,
+	})
+	section := Div("space-y-2", upload.Element(), Text(i18n.T("gux.import.hint")))
+	if w.props.OnCancel != nil {
+		section.Call("appendChild", w.buttons(
+			Button(ButtonProps{Text: i18n.T("gux.wizard.cancel"), Variant: ButtonSecondary, OnClick: w.props.OnCancel}),
+		))
+	}
+	return section
+}
+
+// renderMapping lists the fields, each with a select of the file's columns
+// and the first row's value in the chosen one
+func (w *ImportWizard) renderMapping() js.Value {
+	document := js.Global().Get("document")
+
+	table := document.Call("createElement", "table")
+	table.Set("className", "min-w-full text-sm")
+	head := document.Call("createElement", "thead")
+	headRow := document.Call("createElement", "tr")
+	for _, key := range []string{"gux.import.field", "gux.import.column", "gux.import.sample"} {
+		th := document.Call("createElement", "th")
+		th.Set("className", "px-3 py-2 text-left font-medium text-secondary")
+		th.Set("scope", "col")
+		th.Set("textContent", i18n.T(key))
+		headRow.Call("appendChild", th)
+	}
+	head.Call("appendChild", headRow)
+	table.Call("appendChild", head)
+
+	options := []SelectOption{{Label: i18n.T("gux.import.skip"), Value: ""}}
+	for col, header := range w.headers {
+		label := strings.TrimSpace(header)
+		if label == "" {
+			label = i18n.T("gux.import.untitled", col+1)
+		}
+		options = append(options, SelectOption{Label: label, Value: strconv.Itoa(col)})
+	}
+
+	body := document.Call("createElement", "tbody")
+	for _, f := range w.props.Fields {
+		field := f
+		tr := document.Call("createElement", "tr")
+		tr.Set("className", "border-t border-default")
+
+		name := document.Call("createElement", "th")
+		name.Set("className", "px-3 py-2 text-left font-normal text-primary")
+		name.Set("scope", "row")
+		name.Set("textContent", importLabel(f))
+		if f.Required {
+			mark := document.Call("createElement", "span")
+			mark.Set("className", "text-red-500 ml-1")
+			mark.Call("setAttribute", "aria-hidden", "true")
+			mark.Set("textContent", "*")
+			name.Call("appendChild", mark)
+		}
+		tr.Call("appendChild", name)
+
+		sample := document.Call("createElement", "td")
+		sample.Set("className", "px-3 py-2 text-tertiary truncate max-w-xs")
+		showSample := func() {
+			sample.Set("textContent", "")
+			if col, ok := w.mapping[field.Name]; ok && col < len(w.records[0]) {
+				sample.Set("textContent", w.records[0][col])
+			}
+		}
+		showSample()
+
+		value := ""
+		if col, ok := w.mapping[f.Name]; ok {
+			value = strconv.Itoa(col)
+		}
+		sel := NewSelect(SelectProps{
+			Options:  options,
+			Value:    value,
+			Required: f.Required,
+			OnChange: func(v string) {
+				delete(w.mapping, field.Name)
+				if col, err := strconv.Atoi(v); err == nil {
+					w.mapping[field.Name] = col
+				}
+				showSample()
+			},
+		})
+		sel.selectEl.Call("setAttribute", "aria-label", i18n.T("gux.import.column.aria", importLabel(f)))
+		cell := document.Call("createElement", "td")
+		cell.Set("className", "px-3 py-2")
+		cell.Call("appendChild", sel.Element())
+		tr.Call("appendChild", cell)
+		tr.Call("appendChild", sample)
+		body.Call("appendChild", tr)
+	}
+	table.Call("appendChild", body)
+
+	return Div("space-y-4",
+		Text(i18n.N("gux.import.mapping.intro", len(w.records), w.fileName)),
+		Div("overflow-x-auto", table),
+		w.buttons(
+			Button(ButtonProps{Text: i18n.T("gux.wizard.next"), OnClick: w.confirmMapping}),
+			Button(ButtonProps{Text: i18n.T("gux.wizard.back"), Variant: ButtonSecondary, OnClick: func() { w.goTo(0) }}),
+		),
+	)
+}
+
+// renderReview summarizes the validation, with a table of the errors
+func (w *ImportWizard) renderReview() js.Value {
+	review := Div("space-y-4")
+	summary := i18n.N("gux.import.ready", len(w.rows))
+	if invalid := len(w.records) - len(w.rows); invalid > 0 {
+		summary += " " + i18n.N("gux.import.invalid", invalid)
+	}
+	if len(w.errors) == 0 {
+		review.Call("appendChild", AlertSuccessMsg(summary))
+	} else {
+		review.Call("appendChild", AlertWarningMsg(summary))
+
+		data := make([]map[string]any, len(w.errors))
+		for i, e := range w.errors {
+			data[i] = map[string]any{"row": e.Row, "field": e.Field, "value": e.Value, "error": e.Message}
+		}
+		table := NewTable(TableProps{
+			Columns: []TableColumn{
+				{Header: i18n.T("gux.import.row"), Key: "row", Width: "5rem"},
+				{Header: i18n.T("gux.import.field"), Key: "field"},
+				{Header: i18n.T("gux.import.value"), Key: "value"},
+				{Header: i18n.T("gux.import.error"), Key: "error"},
+			},
+			Data:      data,
+			Compact:   true,
+			Striped:   true,
+			Paginated: len(data) > 10,
+			PageSize:  10,
+		})
+		download := Button(ButtonProps{Text: i18n.T("gux.import.download"), Variant: ButtonGhost, Size: ButtonSM, OnClick: func() {
+			ExportCSV(data, []string{"row", "field", "value", "error"}, "import-errors.csv")
+		}})
+		review.Call("appendChild", Div("space-y-2",
+			Div("flex items-center justify-between", HeadingWithClass(3, i18n.T("gux.import.errors"), "text-lg font-semibold text-primary"), download),
+			table.Element(),
+		))
+	}
+
+	confirm := Button(ButtonProps{Text: i18n.N("gux.import.confirm", len(w.rows)), OnClick: w.complete})
+	if len(w.rows) == 0 {
+		confirm.Set("disabled", true)
+	}
+	review.Call("appendChild", w.buttons(
+		confirm,
+		Button(ButtonProps{Text: i18n.T("gux.wizard.back"), Variant: ButtonSecondary, OnClick: func() { w.goTo(1) }}),
+	))
+	return review
+}
+
+// renderDone confirms the import
+func (w *ImportWizard) renderDone() js.Value {
+	return Div("space-y-4",
+		AlertSuccessMsg(i18n.N("gux.import.done", len(w.rows))),
+		w.buttons(Button(ButtonProps{Text: i18n.T("gux.import.again"), Variant: ButtonSecondary, OnClick: w.Reset})),
+	)
+}
+
+func importLabel(f ImportField) string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
+}
+
+// promiseError is a rejected promise's error: its message, or "failed"
+var promiseError = jsutil.Message("failed")
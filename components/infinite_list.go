@@ -0,0 +1,142 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// InfiniteListProps configures an InfiniteList component
+type InfiniteListProps struct {
+	LoadPage     func(ctx context.Context, cursor string) (items []any, next string, err error) // Loads the page after cursor ("" for the first); wrap a client method with CursorItems
+	RenderItem   func(item any, index int) js.Value                                             // Function to render each item
+	ItemHeight   int                                                                            // Fixed height of each item in pixels
+	Height       string                                                                         // Container height (default "400px")
+	EndThreshold int                                                                            // Pixels from the end at which the next page loads (default 200)
+	EmptyText    string                                                                         // Shown when there are no items
+	ClassName    string
+}
+
+// InfiniteList is a VirtualList that loads its items from a
+// cursor-paginated API, fetching the next page as the user scrolls near
+// the end. Pages are followed by cursor rather than offset, so items added
+// while the user scrolls don't repeat or go missing.
+type InfiniteList struct {
+	container js.Value
+	list      *VirtualList
+	cursor    *cursorLoader[any]
+	empty     js.Value
+}
+
+// NewInfiniteList creates an InfiniteList and starts loading its first page
+func NewInfiniteList(props InfiniteListProps) *InfiniteList {
+	document := js.Global().Get("document")
+
+	if props.Height == "" {
+		props.Height = "400px"
+	}
+	if props.EmptyText == "" {
+		props.EmptyText = i18n.T("gux.empty.noData.title")
+	}
+
+	l := &InfiniteList{}
+
+	container := document.Call("createElement", "div")
+	className := "flex flex-col"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	container.Set("className", className)
+	container.Get("style").Set("height", props.Height)
+	l.container = container
+
+	l.list = NewVirtualList(VirtualListProps{
+		ItemHeight:   props.ItemHeight,
+		RenderItem:   props.RenderItem,
+		Height:       "100%",
+		EndThreshold: props.EndThreshold,
+		OnEndReached: func() { l.cursor.more() },
+	})
+	listWrap := document.Call("createElement", "div")
+	listWrap.Set("className", "flex-1 min-h-0")
+	listWrap.Call("appendChild", l.list.Element())
+	container.Call("appendChild", listWrap)
+
+	l.empty = document.Call("createElement", "div")
+	l.empty.Set("className", "hidden py-6 text-center text-sm text-muted")
+	l.empty.Set("textContent", props.EmptyText)
+	container.Call("appendChild", l.empty)
+
+	l.cursor = newCursorLoader(props.LoadPage, false, l.addPage)
+	container.Call("appendChild", l.cursor.footer)
+
+	l.cursor.reload()
+	onUnmount(container, l.Unmount)
+	return l
+}
+
+// addPage shows a page of items, replacing the items for the first page
+// after a reload
+func (l *InfiniteList) addPage(items []any, reset bool) {
+	if reset {
+		l.list.SetItems(items)
+		l.list.ScrollToTop()
+	} else {
+		l.list.AppendItems(items)
+	}
+
+	if l.list.ItemCount() == 0 && l.cursor.done {
+		l.empty.Set("className", "py-6 text-center text-sm text-muted")
+	} else {
+		l.empty.Set("className", "hidden")
+	}
+
+	// A page too short to scroll never reaches the end, so keep loading
+	// until the list fills its viewport
+	if l.list.ItemCount()*l.list.itemHeight < l.list.viewport.Get("clientHeight").Int() {
+		l.cursor.more()
+	}
+}
+
+// Element returns the container DOM element
+func (l *InfiniteList) Element() js.Value {
+	return l.container
+}
+
+// Mount appends the list to parent
+func (l *InfiniteList) Mount(parent js.Value) {
+	parent.Call("appendChild", l.container)
+}
+
+// Unmount cancels a load in progress, removes the list and releases its
+// listeners
+func (l *InfiniteList) Unmount() {
+	l.cursor.stop()
+	unmount(l.container)
+}
+
+// Reload loads the list again from the first page, for example after a
+// search changes. The current items stay until the first page replaces
+// them.
+func (l *InfiniteList) Reload() {
+	l.cursor.reload()
+}
+
+// HasMore reports whether there are more pages to load
+func (l *InfiniteList) HasMore() bool {
+	return !l.cursor.done
+}
+
+// ItemCount returns the number of items loaded
+func (l *InfiniteList) ItemCount() int {
+	return l.list.ItemCount()
+}
+
+// Destroy cancels a load in progress and cleans up event listeners
+func (l *InfiniteList) Destroy() {
+	l.cursor.stop()
+	l.list.Destroy()
+}
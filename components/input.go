@@ -17,6 +17,10 @@ const (
 	InputURL      InputType = "url"
 )
 
+// inputShapeClasses pad and round text inputs, selects, and text areas by
+// the theme's density and radius
+var inputShapeClasses = tokenPadding("input", 0.75, 0.5) + " " + tokenRadius("input", DefaultRadius)
+
 // InputProps configures an Input component
 type InputProps struct {
 	Type        InputType
@@ -38,6 +42,7 @@ type Input struct {
 	errorEl   js.Value
 	inputID   string
 	errorID   string
+	listeners listeners
 }
 
 // NewInput creates a new Input component
@@ -46,7 +51,7 @@ func NewInput(props InputProps) *Input {
 	crypto := js.Global().Get("crypto")
 
 	container := document.Call("createElement", "div")
-	container.Set("className", "mb-4")
+	container.Set("className", Classes("field"))
 
 	inputType := props.Type
 	if inputType == "" {
@@ -61,7 +66,7 @@ func NewInput(props InputProps) *Input {
 	// Label
 	if props.Label != "" {
 		label := document.Call("createElement", "label")
-		label.Set("className", "block text-sm font-medium text-secondary mb-1")
+		label.Set("className", Classes("field.label"))
 		label.Set("textContent", props.Label)
 		label.Set("htmlFor", inputID)
 		container.Call("appendChild", label)
@@ -70,9 +75,9 @@ func NewInput(props InputProps) *Input {
 
 	// Input field
 	input := document.Call("createElement", "input")
-	className := "w-full px-3 py-2 border border-default surface-base text-primary rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 placeholder:text-tertiary"
+	className := Classes("input")
 	if props.Disabled {
-		className += " surface-overlay cursor-not-allowed"
+		className = Classes("input", "input.disabled")
 	}
 	if props.ClassName != "" {
 		className = props.ClassName
@@ -97,7 +102,7 @@ func NewInput(props InputProps) *Input {
 
 	// Event handlers
 	if props.OnChange != nil {
-		input.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+		input.Call("addEventListener", "input", inp.listeners.fn(func(this js.Value, args []js.Value) any {
 			value := input.Get("value").String()
 			props.OnChange(value)
 			return nil
@@ -105,7 +110,7 @@ func NewInput(props InputProps) *Input {
 	}
 
 	if props.OnEnter != nil {
-		input.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		input.Call("addEventListener", "keydown", inp.listeners.fn(func(this js.Value, args []js.Value) any {
 			if args[0].Get("key").String() == "Enter" {
 				value := input.Get("value").String()
 				props.OnEnter(value)
@@ -117,6 +122,7 @@ func NewInput(props InputProps) *Input {
 	container.Call("appendChild", input)
 	inp.input = input
 
+	onUnmount(inp.container, inp.Unmount)
 	return inp
 }
 
@@ -125,6 +131,17 @@ func (i *Input) Element() js.Value {
 	return i.container
 }
 
+// Mount appends the input to parent
+func (i *Input) Mount(parent js.Value) {
+	parent.Call("appendChild", i.container)
+}
+
+// Unmount removes the input and releases its listeners
+func (i *Input) Unmount() {
+	unmount(i.container)
+	i.listeners.release()
+}
+
 // Value returns the current input value
 func (i *Input) Value() string {
 	return i.input.Get("value").String()
@@ -145,7 +162,7 @@ func (i *Input) SetError(message string) {
 	document := js.Global().Get("document")
 	crypto := js.Global().Get("crypto")
 
-	i.input.Set("className", "w-full px-3 py-2 border border-red-500 surface-base text-primary rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-red-500 focus:border-red-500")
+	i.input.Set("className", Classes("input.error"))
 	i.input.Call("setAttribute", "aria-invalid", "true")
 
 	// Generate error ID if not already set
@@ -157,7 +174,7 @@ func (i *Input) SetError(message string) {
 	if i.errorEl.IsUndefined() || i.errorEl.IsNull() {
 		i.errorEl = document.Call("createElement", "p")
 		i.errorEl.Set("id", i.errorID)
-		i.errorEl.Set("className", "text-red-500 text-sm mt-1")
+		i.errorEl.Set("className", Classes("field.error"))
 		i.errorEl.Call("setAttribute", "role", "alert")
 		i.container.Call("appendChild", i.errorEl)
 	}
@@ -170,7 +187,7 @@ func (i *Input) SetError(message string) {
 
 // ClearError removes error styling and ARIA error attributes
 func (i *Input) ClearError() {
-	i.input.Set("className", "w-full px-3 py-2 border border-default surface-base text-primary rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500")
+	i.input.Set("className", Classes("input"))
 	i.input.Call("removeAttribute", "aria-invalid")
 	i.input.Call("removeAttribute", "aria-describedby")
 
@@ -197,12 +214,14 @@ func PasswordInput(label, placeholder string) *Input {
 	return NewInput(InputProps{Type: InputPassword, Label: label, Placeholder: placeholder})
 }
 
-// NumberInput creates a number input with label and placeholder
+// NumberInput creates a number input with label and placeholder. For
+// steppers, bounds and precision, use NewNumberField.
 func NumberInput(label, placeholder string) *Input {
 	return NewInput(InputProps{Type: InputNumber, Label: label, Placeholder: placeholder})
 }
 
-// SearchInput creates a search input with placeholder
+// SearchInput creates a search input with placeholder. For scopes,
+// debouncing and a clear button, use NewSearchField.
 func SearchInput(placeholder string) *Input {
 	return NewInput(InputProps{Type: InputSearch, Placeholder: placeholder})
 }
@@ -5,6 +5,9 @@ package components
 import (
 	"fmt"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/debug"
+	"github.com/dougbarrett/gux/owner"
 )
 
 // ComponentNode represents a node in the component tree
@@ -15,6 +18,7 @@ type ComponentNode struct {
 	Element    js.Value
 	Children   []*ComponentNode
 	Expanded   bool
+	Owner      *owner.Info // set when the element itself is annotated
 }
 
 // InspectorProps configures the Inspector component
@@ -35,6 +39,20 @@ type Inspector struct {
 	root         *ComponentNode
 	selectedNode *ComponentNode
 	toggle       js.Value
+
+	tab          string // "components", "memory", "a11y", or "state"
+	tabButtons   map[string]js.Value
+	content      js.Value
+	memoryView   js.Value
+	statsView    js.Value
+	funcsView    js.Value
+	funcsButton  js.Value
+	leakView     js.Value
+	stopSampling func()
+	treeFuncs    []js.Func
+	a11yView     js.Value
+	a11yFuncs    []js.Func
+	state        *stateTab
 }
 
 var globalInspector *Inspector
@@ -54,7 +72,9 @@ func NewInspector(props InspectorProps) *Inspector {
 	}
 
 	i := &Inspector{
-		isOpen: !props.Collapsed,
+		isOpen:     !props.Collapsed,
+		tab:        "components",
+		tabButtons: make(map[string]js.Value),
 	}
 
 	// Container
@@ -107,7 +127,22 @@ func NewInspector(props InspectorProps) *Inspector {
 	title := document.Call("createElement", "span")
 	title.Set("className", "text-purple-400 font-bold")
 	title.Set("textContent", "Gux Inspector")
-	header.Call("appendChild", title)
+
+	tabs := document.Call("createElement", "div")
+	tabs.Set("className", "flex items-center gap-3")
+	tabs.Call("appendChild", title)
+	for _, tab := range []struct{ id, label string }{{"components", "Components"}, {"memory", "Memory"}, {"a11y", "A11y"}, {"state", "State"}} {
+		id := tab.id
+		btn := document.Call("createElement", "button")
+		btn.Set("textContent", tab.label)
+		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			i.ShowTab(id)
+			return nil
+		}))
+		tabs.Call("appendChild", btn)
+		i.tabButtons[id] = btn
+	}
+	header.Call("appendChild", tabs)
 
 	headerButtons := document.Call("createElement", "div")
 	headerButtons.Set("className", "flex gap-2")
@@ -117,7 +152,20 @@ func NewInspector(props InspectorProps) *Inspector {
 	refreshBtn.Set("textContent", "↻")
 	refreshBtn.Set("title", "Refresh")
 	refreshBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
-		i.Refresh()
+		switch i.tab {
+		case "memory":
+			i.renderMemory()
+		case "state":
+			i.state.render()
+		case "a11y":
+			if a11yAudit.enabled {
+				runA11yAudit(i)
+			} else {
+				i.renderA11y(AuditA11y(document.Call("getElementById", "app")))
+			}
+		default:
+			i.Refresh()
+		}
 		return nil
 	}))
 	headerButtons.Call("appendChild", refreshBtn)
@@ -152,8 +200,35 @@ func NewInspector(props InspectorProps) *Inspector {
 	content.Call("appendChild", propsView)
 
 	panel.Call("appendChild", content)
+	i.content = content
+
+	// Memory view
+	memoryView := document.Call("createElement", "div")
+	memoryView.Set("className", "overflow-auto p-2")
+	memoryView.Get("style").Set("height", "calc(100% - 36px)")
+	memoryView.Get("style").Set("display", "none")
+	i.statsView = document.Call("createElement", "div")
+	memoryView.Call("appendChild", i.statsView)
+	memoryView.Call("appendChild", i.funcControls())
+	memoryView.Call("appendChild", i.leakControls())
+	i.memoryView = memoryView
+	panel.Call("appendChild", memoryView)
+
+	// Accessibility view
+	a11yView := document.Call("createElement", "div")
+	a11yView.Set("className", "overflow-auto p-2")
+	a11yView.Get("style").Set("height", "calc(100% - 36px)")
+	a11yView.Get("style").Set("display", "none")
+	i.a11yView = a11yView
+	panel.Call("appendChild", a11yView)
+
+	// State view
+	i.state = newStateTab()
+	panel.Call("appendChild", i.state.view)
+
 	container.Call("appendChild", panel)
 	i.panel = panel
+	i.updateTabButtons()
 
 	// Append to body
 	document.Get("body").Call("appendChild", container)
@@ -173,11 +248,12 @@ func (i *Inspector) Refresh() {
 		return
 	}
 
-	i.root = i.scanElement(appEl, 0)
+	i.root = scanComponents(appEl, 0)
 	i.renderTree()
 }
 
-func (i *Inspector) scanElement(el js.Value, depth int) *ComponentNode {
+// scanComponents builds the component tree of el, expanding the top levels
+func scanComponents(el js.Value, depth int) *ComponentNode {
 	if el.IsNull() || el.IsUndefined() {
 		return nil
 	}
@@ -213,15 +289,21 @@ func (i *Inspector) scanElement(el js.Value, depth int) *ComponentNode {
 		node.Type = role.String()
 	}
 
-	// Detect component type from class names
-	node.Type = i.detectComponentType(className)
+	// Detect component type from class names, unless the element declares it
+	node.Type = detectComponentType(className)
+	if info, ok := owner.Own(el); ok {
+		node.Owner = &info
+		if info.Name != "" {
+			node.Type = info.Name
+		}
+	}
 
 	// Scan children
 	children := el.Get("children")
 	if !children.IsUndefined() && !children.IsNull() {
 		for j := 0; j < children.Length(); j++ {
 			child := children.Index(j)
-			childNode := i.scanElement(child, depth+1)
+			childNode := scanComponents(child, depth+1)
 			if childNode != nil {
 				node.Children = append(node.Children, childNode)
 			}
@@ -231,7 +313,7 @@ func (i *Inspector) scanElement(el js.Value, depth int) *ComponentNode {
 	return node
 }
 
-func (i *Inspector) detectComponentType(className string) string {
+func detectComponentType(className string) string {
 	// Detect component types from Tailwind classes
 	patterns := map[string]string{
 		"bg-white rounded-lg shadow":         "Card",
@@ -260,6 +342,7 @@ func (i *Inspector) detectComponentType(className string) string {
 }
 
 func (i *Inspector) renderTree() {
+	i.releaseTreeFuncs()
 	i.treeView.Set("innerHTML", "")
 
 	if i.root == nil {
@@ -286,7 +369,7 @@ func (i *Inspector) renderNode(node *ComponentNode, indent int) {
 			arrow.Set("textContent", "▶")
 		}
 		nodeRef := node
-		arrow.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		arrow.Call("addEventListener", "click", i.treeFunc(func(this js.Value, args []js.Value) any {
 			args[0].Call("stopPropagation")
 			nodeRef.Expanded = !nodeRef.Expanded
 			i.renderTree()
@@ -326,21 +409,28 @@ func (i *Inspector) renderNode(node *ComponentNode, indent int) {
 	name.Set("textContent", node.Name)
 	row.Call("appendChild", name)
 
+	if node.Owner != nil && node.Owner.Team != "" {
+		team := document.Call("createElement", "span")
+		team.Set("className", "text-gray-500 ml-2")
+		team.Set("textContent", "@"+node.Owner.Team)
+		row.Call("appendChild", team)
+	}
+
 	// Click to select
 	nodeRef := node
-	row.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	row.Call("addEventListener", "click", i.treeFunc(func(this js.Value, args []js.Value) any {
 		i.selectNode(nodeRef)
 		return nil
 	}))
 
 	// Highlight on hover
-	row.Call("addEventListener", "mouseenter", js.FuncOf(func(this js.Value, args []js.Value) any {
+	row.Call("addEventListener", "mouseenter", i.treeFunc(func(this js.Value, args []js.Value) any {
 		if !nodeRef.Element.IsUndefined() && !nodeRef.Element.IsNull() {
 			nodeRef.Element.Get("style").Set("outline", "2px solid #a855f7")
 		}
 		return nil
 	}))
-	row.Call("addEventListener", "mouseleave", js.FuncOf(func(this js.Value, args []js.Value) any {
+	row.Call("addEventListener", "mouseleave", i.treeFunc(func(this js.Value, args []js.Value) any {
 		if !nodeRef.Element.IsUndefined() && !nodeRef.Element.IsNull() {
 			nodeRef.Element.Get("style").Set("outline", "")
 		}
@@ -357,6 +447,21 @@ func (i *Inspector) renderNode(node *ComponentNode, indent int) {
 	}
 }
 
+// treeFunc creates a listener for a tree row. Rows are rebuilt on every
+// render, so their listeners are released with them.
+func (i *Inspector) treeFunc(fn func(this js.Value, args []js.Value) any) js.Func {
+	f := js.FuncOf(fn)
+	i.treeFuncs = append(i.treeFuncs, f)
+	return f
+}
+
+func (i *Inspector) releaseTreeFuncs() {
+	for _, f := range i.treeFuncs {
+		f.Release()
+	}
+	i.treeFuncs = nil
+}
+
 func (i *Inspector) selectNode(node *ComponentNode) {
 	i.selectedNode = node
 	i.renderProps()
@@ -407,6 +512,8 @@ func (i *Inspector) renderProps() {
 		}
 	}
 
+	i.renderOwners()
+
 	// Element info
 	if !i.selectedNode.Element.IsUndefined() && !i.selectedNode.Element.IsNull() {
 		rect := i.selectedNode.Element.Call("getBoundingClientRect")
@@ -429,6 +536,275 @@ func (i *Inspector) renderProps() {
 	}
 }
 
+// renderOwners lists the owners of the selected element and its annotated
+// ancestors, innermost first, followed by the owner of the current route
+func (i *Inspector) renderOwners() {
+	document := js.Global().Get("document")
+
+	owners := owner.Chain(i.selectedNode.Element)
+	labels := make([]string, len(owners))
+	for n := range owners {
+		labels[n] = "component"
+	}
+	if info, ok := owner.Current(); ok {
+		owners = append(owners, info)
+		labels = append(labels, "route")
+	}
+	if len(owners) == 0 {
+		return
+	}
+
+	header := document.Call("createElement", "div")
+	header.Set("className", "text-gray-500 mt-3 mb-1")
+	header.Set("textContent", "Owners:")
+	i.propsView.Call("appendChild", header)
+
+	for n, info := range owners {
+		block := document.Call("createElement", "div")
+		block.Set("className", "ml-2 mb-2")
+
+		title := document.Call("createElement", "div")
+		title.Set("className", "text-gray-300")
+		title.Set("textContent", info.String()+" ")
+		kind := document.Call("createElement", "span")
+		kind.Set("className", "text-gray-500")
+		kind.Set("textContent", labels[n])
+		title.Call("appendChild", kind)
+		block.Call("appendChild", title)
+
+		if info.Source != "" {
+			source := document.Call("createElement", "div")
+			source.Set("className", "text-orange-300 break-all")
+			source.Set("textContent", info.Source)
+			block.Call("appendChild", source)
+		}
+		if info.Docs != "" {
+			link := document.Call("createElement", "a")
+			link.Set("className", "text-cyan-400 underline break-all")
+			link.Set("href", info.Docs)
+			link.Set("target", "_blank")
+			link.Set("rel", "noopener noreferrer")
+			link.Set("textContent", info.Docs)
+			block.Call("appendChild", link)
+		}
+		i.propsView.Call("appendChild", block)
+	}
+}
+
+// ShowTab switches between the "components", "memory", "a11y", and
+// "state" tabs. The memory tab starts the debug memory monitor if it is not
+// already running. The a11y tab audits the app when EnableA11yAudit is not
+// keeping it up to date. The state tab lists the stores registered with
+// state.Store.Named and, while open, outlines the elements each store
+// change updates.
+func (i *Inspector) ShowTab(tab string) {
+	views := map[string]js.Value{
+		"components": i.content,
+		"memory":     i.memoryView,
+		"a11y":       i.a11yView,
+		"state":      i.state.view,
+	}
+	if _, ok := views[tab]; !ok {
+		return
+	}
+	i.tab = tab
+	i.updateTabButtons()
+	for id, view := range views {
+		if id == tab {
+			view.Get("style").Set("display", "")
+		} else {
+			view.Get("style").Set("display", "none")
+		}
+	}
+
+	if tab != "memory" && i.stopSampling != nil {
+		i.stopSampling()
+		i.stopSampling = nil
+	}
+	if tab != "state" {
+		i.state.stop()
+	}
+
+	switch tab {
+	case "memory":
+		if !debug.Monitoring() {
+			debug.StartMonitor(debug.MonitorOptions{})
+		}
+		if i.stopSampling == nil {
+			i.stopSampling = debug.OnSample(func(debug.MemStats) { i.renderMemory() })
+		}
+		i.renderMemory()
+	case "a11y":
+		if !a11yAudit.enabled {
+			i.renderA11y(AuditA11y(js.Global().Get("document").Call("getElementById", "app")))
+		}
+	case "state":
+		i.state.watch()
+		i.state.render()
+	}
+}
+
+func (i *Inspector) updateTabButtons() {
+	for id, btn := range i.tabButtons {
+		if id == i.tab {
+			btn.Set("className", "text-white border-b border-purple-400")
+		} else {
+			btn.Set("className", "text-gray-400 hover:text-white")
+		}
+	}
+}
+
+// memoryMetric is a row in the memory tab
+type memoryMetric struct {
+	label string
+	color string
+	value func(debug.MemStats) float64
+	text  func(debug.MemStats) string
+}
+
+var memoryMetrics = []memoryMetric{
+	{"Go heap", "#a855f7", func(s debug.MemStats) float64 { return float64(s.GoHeapAlloc) }, func(s debug.MemStats) string { return debug.FormatBytes(float64(s.GoHeapAlloc)) }},
+	{"WASM memory", "#ec4899", func(s debug.MemStats) float64 { return float64(s.WASMMemory) }, func(s debug.MemStats) string { return debug.FormatBytes(float64(s.WASMMemory)) }},
+	{"JS heap", "#f59e0b", func(s debug.MemStats) float64 { return float64(s.JSHeapUsed) }, func(s debug.MemStats) string {
+		if !s.JSHeapAvailable {
+			return "n/a"
+		}
+		return debug.FormatBytes(float64(s.JSHeapUsed))
+	}},
+	{"JS refs from Go", "#22d3ee", func(s debug.MemStats) float64 { return float64(s.JSRefs) }, func(s debug.MemStats) string { return fmt.Sprint(s.JSRefs) }},
+	{"Live js.Funcs", "#f97316", func(s debug.MemStats) float64 { return float64(max(s.JSFuncs, 0)) }, func(s debug.MemStats) string {
+		if s.JSFuncs < 0 {
+			return "not tracked"
+		}
+		return fmt.Sprint(s.JSFuncs)
+	}},
+	{"DOM nodes", "#4ade80", func(s debug.MemStats) float64 { return float64(s.DOMNodes) }, func(s debug.MemStats) string { return fmt.Sprint(s.DOMNodes) }},
+	{"Goroutines", "#94a3b8", func(s debug.MemStats) float64 { return float64(s.Goroutines) }, func(s debug.MemStats) string { return fmt.Sprint(s.Goroutines) }},
+}
+
+// renderMemory draws the current sample and a sparkline of the history for
+// each metric
+func (i *Inspector) renderMemory() {
+	document := js.Global().Get("document")
+	i.statsView.Set("innerHTML", "")
+
+	history := debug.History()
+	if len(history) == 0 {
+		history = []debug.MemStats{debug.MemoryStats()}
+	}
+	current := history[len(history)-1]
+
+	for _, m := range memoryMetrics {
+		row := document.Call("createElement", "div")
+		row.Set("className", "flex items-center justify-between py-1 border-b border-gray-800")
+
+		label := document.Call("createElement", "span")
+		label.Set("className", "text-gray-400 w-28")
+		label.Set("textContent", m.label)
+		row.Call("appendChild", label)
+
+		data := make([]float64, len(history))
+		for j, s := range history {
+			data[j] = m.value(s)
+		}
+		row.Call("appendChild", Sparkline(SparklineProps{Data: data, Color: m.color, Width: "140px", Height: "20px", ShowMax: true}))
+
+		value := document.Call("createElement", "span")
+		value.Set("className", "text-gray-100 w-20 text-right")
+		value.Set("textContent", m.text(current))
+		row.Call("appendChild", value)
+
+		i.statsView.Call("appendChild", row)
+	}
+
+	footer := document.Call("createElement", "div")
+	footer.Set("className", "text-gray-500 mt-1")
+	footer.Set("textContent", fmt.Sprintf("%d samples since %s", len(history), history[0].Time.Format("Jan 2 15:04")))
+	i.statsView.Call("appendChild", footer)
+
+	i.renderFuncs()
+}
+
+// leakControls builds the "Check for leaks" button and its result list
+func (i *Inspector) leakControls() js.Value {
+	document := js.Global().Get("document")
+
+	wrapper := document.Call("createElement", "div")
+	wrapper.Set("className", "mt-3")
+
+	btn := document.Call("createElement", "button")
+	btn.Set("className", "bg-purple-600 hover:bg-purple-500 text-white px-2 py-1 rounded")
+	btn.Set("textContent", "Check for leaks")
+	btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		// Drop the component tree first; it references elements that may
+		// have left the page and would be reported as leaks
+		i.root, i.selectedNode = nil, nil
+		i.renderTree()
+		i.renderLeaks(debug.Leaks())
+		return nil
+	}))
+	wrapper.Call("appendChild", btn)
+
+	i.leakView = document.Call("createElement", "div")
+	i.leakView.Set("className", "mt-2 space-y-2")
+	wrapper.Call("appendChild", i.leakView)
+	return wrapper
+}
+
+// renderLeaks lists the leak heuristics' findings. Only descriptions of
+// detached nodes and listeners are kept, so the panel does not keep them
+// alive itself.
+func (i *Inspector) renderLeaks(leaks []debug.Leak) {
+	document := js.Global().Get("document")
+	i.leakView.Set("innerHTML", "")
+
+	if len(leaks) == 0 {
+		ok := document.Call("createElement", "div")
+		ok.Set("className", "text-green-400")
+		ok.Set("textContent", "No likely leaks found")
+		i.leakView.Call("appendChild", ok)
+		return
+	}
+
+	for _, leak := range leaks {
+		item := document.Call("createElement", "div")
+
+		msg := document.Call("createElement", "div")
+		msg.Set("className", "text-yellow-400")
+		msg.Set("textContent", "⚠ "+leak.Message)
+		item.Call("appendChild", msg)
+
+		for j, node := range leak.Nodes {
+			if j == 10 {
+				more := document.Call("createElement", "div")
+				more.Set("className", "ml-4 text-gray-500")
+				more.Set("textContent", fmt.Sprintf("and %d more", len(leak.Nodes)-10))
+				item.Call("appendChild", more)
+				break
+			}
+			line := document.Call("createElement", "div")
+			line.Set("className", "ml-4 text-gray-300 truncate")
+			line.Set("textContent", node.Description)
+			item.Call("appendChild", line)
+		}
+		for j, l := range leak.Listeners {
+			if j == 10 {
+				more := document.Call("createElement", "div")
+				more.Set("className", "ml-4 text-gray-500")
+				more.Set("textContent", fmt.Sprintf("and %d more", len(leak.Listeners)-10))
+				item.Call("appendChild", more)
+				break
+			}
+			line := document.Call("createElement", "div")
+			line.Set("className", "ml-4 text-gray-300 truncate")
+			line.Set("textContent", fmt.Sprintf("%s on %s, from %s", l.Event, l.Description, l.Site))
+			line.Set("title", l.Site)
+			item.Call("appendChild", line)
+		}
+		i.leakView.Call("appendChild", item)
+	}
+}
+
 // Open opens the inspector panel
 func (i *Inspector) Open() {
 	i.panel.Get("style").Set("display", "")
@@ -460,8 +836,29 @@ func (i *Inspector) Element() js.Value {
 	return i.container
 }
 
+// Mount appends the inspector to parent
+func (i *Inspector) Mount(parent js.Value) {
+	parent.Call("appendChild", i.container)
+}
+
+// Unmount removes the inspector and releases its listeners. It is the same
+// as Destroy.
+func (i *Inspector) Unmount() {
+	i.Destroy()
+}
+
 // Destroy removes the inspector from the DOM
 func (i *Inspector) Destroy() {
+	if i.stopSampling != nil {
+		i.stopSampling()
+		i.stopSampling = nil
+	}
+	i.releaseTreeFuncs()
+	for _, f := range i.a11yFuncs {
+		f.Release()
+	}
+	i.a11yFuncs = nil
+	i.state.destroy()
 	i.container.Call("remove")
 }
 
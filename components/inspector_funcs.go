@@ -0,0 +1,105 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/debug"
+)
+
+// funcControls builds the memory tab's js.Func section: a button that
+// starts debug.StartFuncTracking and, once tracking, the live js.Funcs per
+// component
+func (i *Inspector) funcControls() js.Value {
+	document := js.Global().Get("document")
+
+	wrapper := document.Call("createElement", "div")
+	wrapper.Set("className", "mt-3")
+
+	title := document.Call("createElement", "div")
+	title.Set("className", "text-gray-400 mb-1")
+	title.Set("textContent", "js.Funcs by component")
+	wrapper.Call("appendChild", title)
+
+	i.funcsView = document.Call("createElement", "div")
+	wrapper.Call("appendChild", i.funcsView)
+
+	btn := document.Call("createElement", "button")
+	btn.Set("className", "bg-gray-700 hover:bg-gray-600 text-white px-2 py-1 rounded")
+	btn.Set("textContent", "Track js.Funcs")
+	btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if !debug.StartFuncTracking() {
+			btn.Set("disabled", true)
+			btn.Set("textContent", "Not supported by this wasm_exec.js")
+			return nil
+		}
+		i.renderFuncs()
+		return nil
+	}))
+	wrapper.Call("appendChild", btn)
+	i.funcsButton = btn
+
+	i.renderFuncs()
+	return wrapper
+}
+
+// renderFuncs lists the components holding the most live js.Funcs
+func (i *Inspector) renderFuncs() {
+	document := js.Global().Get("document")
+	i.funcsView.Set("innerHTML", "")
+
+	if !debug.FuncTracking() {
+		i.funcsButton.Get("style").Set("display", "")
+		note := document.Call("createElement", "div")
+		note.Set("className", "text-gray-500 mb-1")
+		note.Set("textContent", "Counts the js.Funcs created from now on. Call debug.StartFuncTracking() first thing in main to count them all.")
+		i.funcsView.Call("appendChild", note)
+		return
+	}
+	i.funcsButton.Get("style").Set("display", "none")
+
+	counts := debug.FuncCounts()
+	if len(counts) == 0 {
+		empty := document.Call("createElement", "div")
+		empty.Set("className", "text-gray-500")
+		empty.Set("textContent", "No js.Funcs created since tracking started")
+		i.funcsView.Call("appendChild", empty)
+		return
+	}
+
+	table := document.Call("createElement", "table")
+	table.Set("className", "w-full")
+	head := document.Call("createElement", "tr")
+	head.Set("className", "text-gray-500 text-left")
+	for _, h := range []string{"Component", "Live", "Listening", "Created"} {
+		th := document.Call("createElement", "th")
+		th.Set("className", "font-normal")
+		th.Set("textContent", h)
+		head.Call("appendChild", th)
+	}
+	table.Call("appendChild", head)
+
+	for j, c := range counts {
+		if j == 10 {
+			break
+		}
+		tr := document.Call("createElement", "tr")
+		tr.Set("className", "text-gray-300")
+		for _, v := range []string{c.Component, fmt.Sprint(c.Live), fmt.Sprint(c.Listening), fmt.Sprint(c.Created)} {
+			td := document.Call("createElement", "td")
+			td.Set("textContent", v)
+			tr.Call("appendChild", td)
+		}
+		table.Call("appendChild", tr)
+	}
+	i.funcsView.Call("appendChild", table)
+
+	if len(counts) > 10 {
+		more := document.Call("createElement", "div")
+		more.Set("className", "text-gray-500")
+		more.Set("textContent", fmt.Sprintf("and %d more components", len(counts)-10))
+		i.funcsView.Call("appendChild", more)
+	}
+}
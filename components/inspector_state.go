@@ -0,0 +1,382 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/state"
+)
+
+// storeActivity is what the State tab knows about a store's changes
+type storeActivity struct {
+	changes  int
+	last     time.Time
+	rendered int // elements the last change updated
+}
+
+// stateTab is the Inspector's State tab: the stores registered with
+// state.Store.Named, their values as JSON trees, and a JSON editor
+type stateTab struct {
+	view     js.Value
+	blocks   map[string]js.Value  // name -> the store's block in view
+	funcs    map[string][]js.Func // name -> listeners of the block
+	editing  map[string]bool      // names whose JSON editor is open
+	activity map[string]*storeActivity
+	observer js.Value // MutationObserver on #app, read on each change
+	noop     js.Func
+	unwatch  func()
+	unbefore func()
+}
+
+func newStateTab() *stateTab {
+	document := js.Global().Get("document")
+	view := document.Call("createElement", "div")
+	view.Set("className", "overflow-auto p-2")
+	view.Get("style").Set("height", "calc(100% - 36px)")
+	view.Get("style").Set("display", "none")
+	return &stateTab{
+		view:     view,
+		blocks:   make(map[string]js.Value),
+		funcs:    make(map[string][]js.Func),
+		editing:  make(map[string]bool),
+		activity: make(map[string]*storeActivity),
+	}
+}
+
+// watch starts following store changes, highlighting the elements each
+// change updates
+func (s *stateTab) watch() {
+	if s.unwatch != nil {
+		return
+	}
+	// Mutations are read with takeRecords as soon as a store's subscribers
+	// have run; the observer's own callback has nothing left to do
+	s.noop = js.FuncOf(func(this js.Value, args []js.Value) any { return nil })
+	observerType := js.Global().Get("MutationObserver")
+	app := js.Global().Get("document").Call("getElementById", "app")
+	if observerType.Truthy() && app.Truthy() {
+		s.observer = observerType.New(s.noop)
+		s.observer.Call("observe", app, map[string]any{
+			"childList":     true,
+			"subtree":       true,
+			"attributes":    true,
+			"characterData": true,
+		})
+	}
+	s.unbefore = state.BeforeChange(s.changing)
+	s.unwatch = state.OnChange(s.changed)
+}
+
+// stop stops following store changes
+func (s *stateTab) stop() {
+	if s.unwatch == nil {
+		return
+	}
+	s.unwatch()
+	s.unbefore()
+	s.unwatch, s.unbefore = nil, nil
+	if s.observer.Truthy() {
+		s.observer.Call("disconnect")
+		s.observer = js.Undefined()
+	}
+	s.noop.Release()
+}
+
+// changing drops the mutations queued before a store's subscribers run,
+// which weren't made by them
+func (s *stateTab) changing(state.Inspectable) {
+	if s.observer.Truthy() {
+		s.observer.Call("takeRecords")
+	}
+}
+
+func (s *stateTab) changed(store state.Inspectable) {
+	var updated []js.Value
+	if s.observer.Truthy() {
+		updated = mutatedElements(s.observer.Call("takeRecords"))
+	}
+	for _, el := range updated {
+		flashElement(el)
+	}
+
+	name := store.Name()
+	a := s.activity[name]
+	if a == nil {
+		a = &storeActivity{}
+		s.activity[name] = a
+	}
+	a.changes++
+	a.last = time.Now()
+	a.rendered = len(updated)
+
+	if block, ok := s.blocks[name]; ok && !s.editing[name] {
+		fresh := s.renderStore(store)
+		block.Call("replaceWith", fresh)
+		flashElement(fresh)
+	}
+}
+
+// render lists every registered store
+func (s *stateTab) render() {
+	document := js.Global().Get("document")
+	for name := range s.blocks {
+		s.release(name)
+	}
+	s.blocks = make(map[string]js.Value)
+	s.view.Set("innerHTML", "")
+
+	stores := state.Stores()
+	if len(stores) == 0 {
+		help := document.Call("createElement", "div")
+		help.Set("className", "text-gray-500 text-center mt-4")
+		help.Set("textContent", `No named stores. Register one with state.New(value).Named("cart").`)
+		s.view.Call("appendChild", help)
+		return
+	}
+	sort.SliceStable(stores, func(a, b int) bool { return stores[a].Name() < stores[b].Name() })
+	for _, store := range stores {
+		s.view.Call("appendChild", s.renderStore(store))
+	}
+}
+
+// renderStore builds the block for one store: its name and activity, its
+// value as a JSON tree, and an editor when open
+func (s *stateTab) renderStore(store state.Inspectable) js.Value {
+	document := js.Global().Get("document")
+	name := store.Name()
+	s.release(name)
+
+	block := document.Call("createElement", "div")
+	block.Set("className", "py-2 border-b border-gray-800")
+	s.blocks[name] = block
+
+	header := document.Call("createElement", "div")
+	header.Set("className", "flex items-center gap-2 mb-1")
+	title := document.Call("createElement", "span")
+	title.Set("className", "text-purple-400 font-bold")
+	title.Set("textContent", name)
+	header.Call("appendChild", title)
+
+	info := document.Call("createElement", "span")
+	info.Set("className", "text-gray-500 flex-1")
+	if a := s.activity[name]; a != nil {
+		info.Set("textContent", fmt.Sprintf("%d changes, last %s ago, updated %d elements",
+			a.changes, time.Since(a.last).Round(time.Second), a.rendered))
+	}
+	header.Call("appendChild", info)
+
+	data, err := json.MarshalIndent(store.Snapshot(), "", "  ")
+	if err != nil {
+		data = []byte(fmt.Sprintf("%q", "cannot show value: "+err.Error()))
+	}
+
+	editBtn := document.Call("createElement", "button")
+	editBtn.Set("className", "text-gray-400 hover:text-white")
+	editBtn.Set("textContent", "Edit")
+	if s.editing[name] {
+		editBtn.Set("textContent", "Cancel")
+	}
+	editBtn.Call("addEventListener", "click", s.listen(name, func() {
+		s.editing[name] = !s.editing[name]
+		block.Call("replaceWith", s.renderStore(store))
+	}))
+	header.Call("appendChild", editBtn)
+	block.Call("appendChild", header)
+
+	if !s.editing[name] {
+		var value any
+		json.Unmarshal(data, &value)
+		block.Call("appendChild", jsonTree("", value, 0))
+		return block
+	}
+
+	editor := document.Call("createElement", "textarea")
+	editor.Set("className", "w-full h-32 bg-gray-800 text-gray-100 font-mono text-xs p-1 rounded")
+	editor.Call("setAttribute", "aria-label", "Edit "+name+" as JSON")
+	editor.Set("spellcheck", false)
+	editor.Set("value", string(data))
+	block.Call("appendChild", editor)
+
+	errorLine := document.Call("createElement", "div")
+	errorLine.Set("className", "text-red-400")
+	errorLine.Call("setAttribute", "role", "alert")
+
+	save := document.Call("createElement", "button")
+	save.Set("className", "mt-1 px-2 py-0.5 bg-purple-600 hover:bg-purple-500 text-white rounded")
+	save.Set("textContent", "Apply")
+	save.Call("addEventListener", "click", s.listen(name, func() {
+		s.editing[name] = false
+		if err := store.SetJSON([]byte(editor.Get("value").String())); err != nil {
+			s.editing[name] = true
+			errorLine.Set("textContent", err.Error())
+			return
+		}
+		// SetJSON notified changed, which skipped the block while editing
+		if block.Get("isConnected").Truthy() {
+			block.Call("replaceWith", s.renderStore(store))
+		}
+	}))
+	block.Call("appendChild", save)
+	block.Call("appendChild", errorLine)
+	return block
+}
+
+// listen creates a click listener for a store's block, released when the
+// block is rendered again
+func (s *stateTab) listen(name string, fn func()) js.Func {
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		fn()
+		return nil
+	})
+	s.funcs[name] = append(s.funcs[name], f)
+	return f
+}
+
+func (s *stateTab) release(name string) {
+	for _, f := range s.funcs[name] {
+		f.Release()
+	}
+	delete(s.funcs, name)
+}
+
+// destroy stops watching and releases every listener
+func (s *stateTab) destroy() {
+	s.stop()
+	for name := range s.funcs {
+		s.release(name)
+	}
+}
+
+// jsonTree renders a decoded JSON value. Objects and arrays are collapsible,
+// open for the first two levels.
+func jsonTree(key string, value any, depth int) js.Value {
+	document := js.Global().Get("document")
+
+	label := func(parent js.Value) {
+		if key == "" {
+			return
+		}
+		k := document.Call("createElement", "span")
+		k.Set("className", "text-cyan-400")
+		k.Set("textContent", key+": ")
+		parent.Call("appendChild", k)
+	}
+
+	var children []struct {
+		key   string
+		value any
+	}
+	var summary string
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			children = append(children, struct {
+				key   string
+				value any
+			}{k, v[k]})
+		}
+		summary = fmt.Sprintf("{…} %d keys", len(v))
+	case []any:
+		for i, item := range v {
+			children = append(children, struct {
+				key   string
+				value any
+			}{fmt.Sprint(i), item})
+		}
+		summary = fmt.Sprintf("[…] %d items", len(v))
+	default:
+		row := document.Call("createElement", "div")
+		row.Set("className", "ml-3")
+		label(row)
+		text := document.Call("createElement", "span")
+		switch v := value.(type) {
+		case string:
+			text.Set("className", "text-orange-300")
+			text.Set("textContent", fmt.Sprintf("%q", v))
+		case nil:
+			text.Set("className", "text-gray-500")
+			text.Set("textContent", "null")
+		default:
+			text.Set("className", "text-green-400")
+			text.Set("textContent", fmt.Sprint(v))
+		}
+		row.Call("appendChild", text)
+		return row
+	}
+
+	details := document.Call("createElement", "details")
+	details.Set("className", "ml-3")
+	details.Set("open", depth < 2)
+	sum := document.Call("createElement", "summary")
+	sum.Set("className", "cursor-pointer")
+	label(sum)
+	count := document.Call("createElement", "span")
+	count.Set("className", "text-gray-500")
+	count.Set("textContent", summary)
+	sum.Call("appendChild", count)
+	details.Call("appendChild", sum)
+	for _, c := range children {
+		details.Call("appendChild", jsonTree(c.key, c.value, depth+1))
+	}
+	return details
+}
+
+// mutatedElements returns the elements changed by MutationRecords, outside
+// the Inspector, up to 50
+func mutatedElements(records js.Value) []js.Value {
+	var els []js.Value
+	for i := 0; i < records.Length() && len(els) < 50; i++ {
+		record := records.Index(i)
+		target := record.Get("target")
+		// flashElement's own outline isn't an update
+		if attr := record.Get("attributeName"); attr.Truthy() &&
+			(attr.String() == "data-gux-flash" || attr.String() == "style" && target.Call("hasAttribute", "data-gux-flash").Bool()) {
+			continue
+		}
+		if target.Get("nodeType").Int() != 1 {
+			target = target.Get("parentElement")
+		}
+		if !target.Truthy() || target.Call("closest", "#gux-inspector").Truthy() {
+			continue
+		}
+		seen := false
+		for _, el := range els {
+			if el.Equal(target) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			els = append(els, target)
+		}
+	}
+	return els
+}
+
+// flashElement outlines an element briefly to show that it changed
+func flashElement(el js.Value) {
+	if el.Call("hasAttribute", "data-gux-flash").Bool() {
+		return // already flashing
+	}
+	style := el.Get("style")
+	el.Call("setAttribute", "data-gux-flash", style.Get("outline").String())
+	style.Set("outline", "2px solid #a855f7")
+	var restore js.Func
+	restore = js.FuncOf(func(this js.Value, args []js.Value) any {
+		style.Set("outline", el.Call("getAttribute", "data-gux-flash").String())
+		el.Call("removeAttribute", "data-gux-flash")
+		restore.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", restore, 800)
+}
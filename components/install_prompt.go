@@ -5,6 +5,8 @@ package components
 import (
 	"syscall/js"
 	"time"
+
+	"github.com/dougbarrett/gux/storage"
 )
 
 // InstallPromptPosition defines where the install prompt banner appears
@@ -23,9 +25,8 @@ var installPromptPositionClasses = map[InstallPromptPosition]string{
 }
 
 const (
-	installPromptDismissKey     = "gux-install-prompt-dismissed"
-	installPromptDismissDays    = 7
-	installPromptDismissDaysStr = "7"
+	installPromptDismissKey    = "gux-install-prompt-dismissed"
+	installPromptDismissPeriod = 7 * 24 * time.Hour
 )
 
 // InstallPromptProps configures the InstallPrompt component
@@ -39,9 +40,10 @@ type InstallPromptProps struct {
 
 // InstallPrompt creates a PWA install prompt banner
 type InstallPrompt struct {
-	element js.Value
-	manager *InstallPromptManager
-	visible bool
+	element   js.Value
+	manager   *InstallPromptManager
+	visible   bool
+	listeners listeners
 }
 
 // NewInstallPrompt creates a new InstallPrompt component
@@ -118,7 +120,7 @@ func NewInstallPrompt(props InstallPromptProps, manager *InstallPromptManager) *
 	notNowBtn := document.Call("createElement", "button")
 	notNowBtn.Set("className", "flex-1 px-3 py-1.5 text-sm text-gray-600 dark:text-gray-400 hover:text-gray-800 dark:hover:text-gray-200 transition-colors cursor-pointer")
 	notNowBtn.Set("textContent", "Not now")
-	notNowBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	notNowBtn.Call("addEventListener", "click", ip.listeners.fn(func(this js.Value, args []js.Value) any {
 		ip.dismiss()
 		if props.OnDismiss != nil {
 			props.OnDismiss()
@@ -131,7 +133,7 @@ func NewInstallPrompt(props InstallPromptProps, manager *InstallPromptManager) *
 	installBtn := document.Call("createElement", "button")
 	installBtn.Set("className", "flex-1 px-3 py-1.5 text-sm bg-blue-600 text-white rounded-md hover:bg-blue-700 transition-colors cursor-pointer font-medium")
 	installBtn.Set("textContent", "Install")
-	installBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	installBtn.Call("addEventListener", "click", ip.listeners.fn(func(this js.Value, args []js.Value) any {
 		if manager != nil {
 			manager.ShowPrompt()
 		}
@@ -144,6 +146,7 @@ func NewInstallPrompt(props InstallPromptProps, manager *InstallPromptManager) *
 
 	container.Call("appendChild", buttons)
 
+	onUnmount(ip.element, ip.Unmount)
 	return ip
 }
 
@@ -152,6 +155,17 @@ func (ip *InstallPrompt) Element() js.Value {
 	return ip.element
 }
 
+// Mount appends the install prompt to parent
+func (ip *InstallPrompt) Mount(parent js.Value) {
+	parent.Call("appendChild", ip.element)
+}
+
+// Unmount removes the install prompt and releases its listeners
+func (ip *InstallPrompt) Unmount() {
+	unmount(ip.element)
+	ip.listeners.release()
+}
+
 // Show displays the install prompt banner with animation
 func (ip *InstallPrompt) Show() {
 	if ip.visible {
@@ -207,34 +221,23 @@ func (ip *InstallPrompt) Hide() {
 // dismiss hides the banner and stores dismissal time
 func (ip *InstallPrompt) dismiss() {
 	ip.Hide()
-	localStorage := js.Global().Get("localStorage")
-	dismissTime := time.Now().UnixMilli()
-	localStorage.Call("setItem", installPromptDismissKey, js.ValueOf(dismissTime))
+	installPromptDismissed().Set(time.Now().UnixMilli())
 }
 
 // wasRecentlyDismissed checks if the prompt was dismissed within the last N days
 func (ip *InstallPrompt) wasRecentlyDismissed() bool {
-	localStorage := js.Global().Get("localStorage")
-	dismissedValue := localStorage.Call("getItem", installPromptDismissKey)
-	if dismissedValue.IsNull() || dismissedValue.IsUndefined() {
-		return false
-	}
-
-	dismissedStr := dismissedValue.String()
-	if dismissedStr == "" {
-		return false
-	}
-
-	// Parse the timestamp
-	dismissedTime := js.Global().Call("parseInt", dismissedStr, 10).Int()
-	if dismissedTime == 0 {
+	dismissedTime, ok := installPromptDismissed().Get()
+	if !ok || dismissedTime == 0 {
 		return false
 	}
+	return time.Since(time.UnixMilli(dismissedTime)) < installPromptDismissPeriod
+}
 
-	// Check if within the dismiss period
-	now := time.Now().UnixMilli()
-	dismissPeriod := int64(installPromptDismissDays * 24 * 60 * 60 * 1000) // days in milliseconds
-	return (now - int64(dismissedTime)) < dismissPeriod
+// installPromptDismissed is when the prompt was last dismissed, in Unix ms.
+// It expires after the dismiss period; values stored before then have no
+// expiry, so the time is checked as well.
+func installPromptDismissed() *storage.Value[int64] {
+	return storage.Typed[int64](installPromptDismissKey, storage.Options{TTL: installPromptDismissPeriod})
 }
 
 // Helper to split class string
@@ -0,0 +1,532 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// KanbanCard is a card on a Kanban board
+type KanbanCard struct {
+	ID          string
+	Title       string
+	Description string
+	Tags        []string
+	Data        any // app data for RenderCard
+}
+
+// KanbanColumn is a column of cards
+type KanbanColumn struct {
+	ID       string
+	Title    string
+	Cards    []KanbanCard
+	WIPLimit int // max cards in the column (0 = no limit)
+}
+
+// KanbanProps configures a Kanban board
+type KanbanProps struct {
+	Columns []KanbanColumn
+
+	// RenderCard renders the body of a card. Default: title, description, and tags.
+	RenderCard func(card KanbanCard) js.Value
+
+	// OnCardMove is called after a card is dropped, with its index in the
+	// destination column. fromCol and toCol are equal when reordering.
+	OnCardMove func(cardID, fromCol, toCol string, index int)
+
+	OnCardClick func(card KanbanCard)
+
+	// EnforceWIPLimits rejects moves into a column that is at its WIP limit.
+	// Otherwise the move is allowed and the column is marked as over the limit.
+	EnforceWIPLimits bool
+
+	ColumnWidth string // default "18rem"
+	ClassName   string
+}
+
+// Kanban is a board of columns whose cards can be dragged between columns
+// with HTML5 drag and drop, or moved with Alt+Arrow keys
+type Kanban struct {
+	container js.Value
+	props     KanbanProps
+	columns   []KanbanColumn
+
+	lists   map[string]js.Value // column ID -> card list element
+	headers map[string]js.Value // column ID -> count element
+	cols    map[string]js.Value // column ID -> column element
+
+	dragging    string // ID of the card being dragged
+	placeholder js.Value
+	funcs       []js.Func            // column listeners, released on render
+	cardFuncs   map[string][]js.Func // card listeners per column
+	staleFuncs  map[string][]js.Func // listeners of the previous render, see renderColumn
+}
+
+const (
+	kanbanColumnClass     = "flex flex-col flex-shrink-0 bg-gray-100 dark:bg-gray-900 rounded-lg border border-subtle"
+	kanbanColumnOverClass = "flex flex-col flex-shrink-0 bg-red-50 dark:bg-red-900/20 rounded-lg border border-red-400"
+	kanbanCardClass       = "bg-white dark:bg-gray-800 rounded-md shadow-sm border border-subtle p-3 cursor-grab focus:outline-none focus:ring-2 focus:ring-blue-500"
+)
+
+// NewKanban creates a Kanban board
+func NewKanban(props KanbanProps) *Kanban {
+	if props.ColumnWidth == "" {
+		props.ColumnWidth = "18rem"
+	}
+
+	k := &Kanban{props: props}
+	k.container = Div("flex gap-4 overflow-x-auto pb-2 " + props.ClassName)
+	k.container.Call("setAttribute", "role", "region")
+
+	k.placeholder = Div("rounded-md border-2 border-dashed border-blue-400 bg-blue-50 dark:bg-blue-900/20 h-12")
+
+	k.SetColumns(props.Columns)
+	onUnmount(k.container, k.Unmount)
+	return k
+}
+
+// Element returns the board element
+func (k *Kanban) Element() js.Value {
+	return k.container
+}
+
+// Mount appends the board to parent
+func (k *Kanban) Mount(parent js.Value) {
+	parent.Call("appendChild", k.container)
+}
+
+// Unmount removes the board and releases its listeners
+func (k *Kanban) Unmount() {
+	unmount(k.container)
+	releaseFuncs(k.funcs)
+	k.funcs = nil
+	for id := range k.cardFuncs {
+		releaseFuncs(k.cardFuncs[id])
+		releaseFuncs(k.staleFuncs[id])
+	}
+	k.cardFuncs, k.staleFuncs = nil, nil
+}
+
+// Columns returns the columns and their cards in their current order
+func (k *Kanban) Columns() []KanbanColumn {
+	out := make([]KanbanColumn, len(k.columns))
+	for i, col := range k.columns {
+		out[i] = col
+		out[i].Cards = append([]KanbanCard(nil), col.Cards...)
+	}
+	return out
+}
+
+// SetColumns replaces the board's columns and cards
+func (k *Kanban) SetColumns(columns []KanbanColumn) {
+	k.columns = make([]KanbanColumn, len(columns))
+	for i, col := range columns {
+		k.columns[i] = col
+		k.columns[i].Cards = append([]KanbanCard(nil), col.Cards...)
+	}
+	k.render()
+}
+
+// AddCard appends a card to a column. It returns false if the column does
+// not exist or is at its WIP limit and limits are enforced.
+func (k *Kanban) AddCard(columnID string, card KanbanCard) bool {
+	ci := k.columnIndex(columnID)
+	if ci < 0 || (k.props.EnforceWIPLimits && k.atLimit(ci)) {
+		return false
+	}
+	k.columns[ci].Cards = append(k.columns[ci].Cards, card)
+	k.renderColumn(ci)
+	return true
+}
+
+// UpdateCard replaces a card, matched by ID, and re-renders it
+func (k *Kanban) UpdateCard(card KanbanCard) bool {
+	ci, cj := k.find(card.ID)
+	if ci < 0 {
+		return false
+	}
+	k.columns[ci].Cards[cj] = card
+	k.renderColumn(ci)
+	return true
+}
+
+// RemoveCard removes a card from the board
+func (k *Kanban) RemoveCard(cardID string) bool {
+	ci, cj := k.find(cardID)
+	if ci < 0 {
+		return false
+	}
+	cards := k.columns[ci].Cards
+	k.columns[ci].Cards = append(cards[:cj:cj], cards[cj+1:]...)
+	k.renderColumn(ci)
+	return true
+}
+
+// Card returns a card and the ID of its column
+func (k *Kanban) Card(cardID string) (KanbanCard, string, bool) {
+	ci, cj := k.find(cardID)
+	if ci < 0 {
+		return KanbanCard{}, "", false
+	}
+	return k.columns[ci].Cards[cj], k.columns[ci].ID, true
+}
+
+// MoveCard moves a card to index in a column (clamped to the column's
+// length) without calling OnCardMove. It returns false if the card or column
+// does not exist or the move would break an enforced WIP limit.
+func (k *Kanban) MoveCard(cardID, toCol string, index int) bool {
+	_, ok := k.move(cardID, toCol, index)
+	return ok
+}
+
+// move moves a card and returns the column it came from
+func (k *Kanban) move(cardID, toCol string, index int) (string, bool) {
+	from, cj := k.find(cardID)
+	to := k.columnIndex(toCol)
+	if from < 0 || to < 0 {
+		return "", false
+	}
+	if from != to && k.props.EnforceWIPLimits && k.atLimit(to) {
+		return "", false
+	}
+
+	card := k.columns[from].Cards[cj]
+	src := k.columns[from].Cards
+	k.columns[from].Cards = append(src[:cj:cj], src[cj+1:]...)
+
+	dst := k.columns[to].Cards
+	if index < 0 || index > len(dst) {
+		index = len(dst)
+	}
+	cards := make([]KanbanCard, 0, len(dst)+1)
+	cards = append(cards, dst[:index]...)
+	cards = append(cards, card)
+	cards = append(cards, dst[index:]...)
+	k.columns[to].Cards = cards
+
+	k.renderColumn(from)
+	if to != from {
+		k.renderColumn(to)
+	}
+	return k.columns[from].ID, true
+}
+
+// userMove moves a card in response to a drop or key press and reports it
+func (k *Kanban) userMove(cardID, toCol string, index int) {
+	ci, _ := k.find(cardID)
+	to := k.columnIndex(toCol)
+	if ci < 0 || to < 0 {
+		return
+	}
+	if ci != to && k.props.EnforceWIPLimits && k.atLimit(to) {
+		col := k.columns[to]
+		Announce(i18n.T("gux.kanban.atLimit", col.Title, col.WIPLimit))
+		return
+	}
+
+	from, ok := k.move(cardID, toCol, index)
+	if !ok {
+		return
+	}
+	_, cj := k.find(cardID)
+	card := k.columns[to].Cards[cj]
+	Announce(i18n.T("gux.kanban.moved", card.Title, k.columns[to].Title, cj+1))
+	k.focusCard(cardID)
+
+	if k.props.OnCardMove != nil {
+		k.props.OnCardMove(cardID, from, toCol, cj)
+	}
+}
+
+func (k *Kanban) find(cardID string) (col, idx int) {
+	for ci, c := range k.columns {
+		for cj, card := range c.Cards {
+			if card.ID == cardID {
+				return ci, cj
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (k *Kanban) columnIndex(id string) int {
+	for i, c := range k.columns {
+		if c.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (k *Kanban) atLimit(ci int) bool {
+	col := k.columns[ci]
+	return col.WIPLimit > 0 && len(col.Cards) >= col.WIPLimit
+}
+
+// addTrackedListener adds an event listener and records it in funcs so it can be
+// released when its element is re-rendered
+func addTrackedListener(funcs *[]js.Func, el js.Value, event string, fn func(js.Value)) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		fn(args[0])
+		return nil
+	})
+	*funcs = append(*funcs, f)
+	el.Call("addEventListener", event, f)
+}
+
+func releaseFuncs(funcs []js.Func) {
+	for _, f := range funcs {
+		f.Release()
+	}
+}
+
+func (k *Kanban) render() {
+	releaseFuncs(k.funcs)
+	k.funcs = nil
+	for id := range k.cardFuncs {
+		releaseFuncs(k.cardFuncs[id])
+		releaseFuncs(k.staleFuncs[id])
+	}
+	k.cardFuncs = make(map[string][]js.Func, len(k.columns))
+	k.staleFuncs = make(map[string][]js.Func, len(k.columns))
+	k.container.Set("innerHTML", "")
+	k.lists = make(map[string]js.Value, len(k.columns))
+	k.headers = make(map[string]js.Value, len(k.columns))
+	k.cols = make(map[string]js.Value, len(k.columns))
+
+	for ci := range k.columns {
+		k.container.Call("appendChild", k.renderColumnShell(ci))
+		k.renderColumn(ci)
+	}
+}
+
+// renderColumnShell builds a column's header and drop zone
+func (k *Kanban) renderColumnShell(ci int) js.Value {
+	col := k.columns[ci]
+	colID := col.ID
+
+	el := Div(kanbanColumnClass)
+	el.Get("style").Set("width", k.props.ColumnWidth)
+	el.Call("setAttribute", "data-column", colID)
+
+	header := Div("flex items-center justify-between px-3 py-2 border-b border-subtle")
+	header.Call("appendChild", Span("text-sm font-semibold text-primary", col.Title))
+	count := Span("text-xs text-tertiary", "")
+	header.Call("appendChild", count)
+	el.Call("appendChild", header)
+
+	list := Div("flex flex-col gap-2 p-2 min-h-[4rem] flex-1")
+	list.Call("setAttribute", "role", "list")
+	el.Call("appendChild", list)
+
+	addTrackedListener(&k.funcs, list, "dragover", func(e js.Value) {
+		if k.dragging == "" {
+			return
+		}
+		if from, _ := k.find(k.dragging); from != ci && k.props.EnforceWIPLimits && k.atLimit(ci) {
+			e.Get("dataTransfer").Set("dropEffect", "none")
+			return
+		}
+		e.Call("preventDefault")
+		e.Get("dataTransfer").Set("dropEffect", "move")
+		k.showPlaceholder(list, k.dropIndex(list, e.Get("clientY").Float()))
+	})
+	addTrackedListener(&k.funcs, list, "dragleave", func(e js.Value) {
+		related := e.Get("relatedTarget")
+		if related.Truthy() && list.Call("contains", related).Bool() {
+			return
+		}
+		k.hidePlaceholder()
+	})
+	addTrackedListener(&k.funcs, list, "drop", func(e js.Value) {
+		e.Call("preventDefault")
+		cardID := e.Get("dataTransfer").Call("getData", "text/plain").String()
+		if cardID == "" {
+			cardID = k.dragging
+		}
+		index := k.dropIndex(list, e.Get("clientY").Float())
+		k.hidePlaceholder()
+		k.dragging = ""
+
+		// The drop index counts the dragged card when reordering in place
+		if from, cj := k.find(cardID); from == ci && cj < index {
+			index--
+		}
+		k.userMove(cardID, colID, index)
+	})
+
+	k.cols[colID] = el
+	k.lists[colID] = list
+	k.headers[colID] = count
+	return el
+}
+
+// renderColumn re-renders a column's cards and count
+func (k *Kanban) renderColumn(ci int) {
+	col := k.columns[ci]
+	list, ok := k.lists[col.ID]
+	if !ok {
+		return
+	}
+
+	// A dropped card's old element still gets dragend after it is replaced,
+	// so its listeners are released one render later
+	releaseFuncs(k.staleFuncs[col.ID])
+	k.staleFuncs[col.ID] = k.cardFuncs[col.ID]
+	var funcs []js.Func
+	list.Set("innerHTML", "")
+	for _, card := range col.Cards {
+		list.Call("appendChild", k.renderCard(card, &funcs))
+	}
+	k.cardFuncs[col.ID] = funcs
+	if len(col.Cards) == 0 {
+		list.Call("appendChild", TextWithClass(i18n.T("gux.kanban.empty"), "text-xs text-tertiary text-center py-4 pointer-events-none"))
+	}
+
+	count := k.headers[col.ID]
+	if col.WIPLimit > 0 {
+		count.Set("textContent", fmt.Sprintf("%d / %d", len(col.Cards), col.WIPLimit))
+	} else {
+		count.Set("textContent", fmt.Sprint(len(col.Cards)))
+	}
+
+	over := col.WIPLimit > 0 && len(col.Cards) > col.WIPLimit
+	if over {
+		k.cols[col.ID].Set("className", kanbanColumnOverClass)
+		count.Set("className", "text-xs font-semibold text-red-600")
+	} else {
+		k.cols[col.ID].Set("className", kanbanColumnClass)
+		count.Set("className", "text-xs text-tertiary")
+	}
+	k.cols[col.ID].Call("setAttribute", "aria-label", i18n.N("gux.kanban.column", len(col.Cards), col.Title))
+}
+
+func (k *Kanban) renderCard(card KanbanCard, funcs *[]js.Func) js.Value {
+	el := Div(kanbanCardClass)
+	el.Set("draggable", true)
+	el.Set("tabIndex", 0)
+	el.Call("setAttribute", "role", "listitem")
+	el.Call("setAttribute", "data-card", card.ID)
+	el.Call("setAttribute", "aria-roledescription", i18n.T("gux.kanban.card"))
+
+	if k.props.RenderCard != nil {
+		el.Call("appendChild", k.props.RenderCard(card))
+	} else {
+		el.Call("appendChild", TextWithClass(card.Title, "text-sm font-medium text-primary"))
+		if card.Description != "" {
+			el.Call("appendChild", TextWithClass(card.Description, "text-xs text-secondary mt-1"))
+		}
+		if len(card.Tags) > 0 {
+			tags := Div("flex flex-wrap gap-1 mt-2")
+			for _, tag := range card.Tags {
+				tags.Call("appendChild", Badge(BadgeProps{Text: tag, Rounded: true}))
+			}
+			el.Call("appendChild", tags)
+		}
+	}
+
+	cardID := card.ID
+	addTrackedListener(funcs, el, "dragstart", func(e js.Value) {
+		k.dragging = cardID
+		dt := e.Get("dataTransfer")
+		dt.Call("setData", "text/plain", cardID)
+		dt.Set("effectAllowed", "move")
+		el.Get("classList").Call("add", "opacity-50")
+	})
+	addTrackedListener(funcs, el, "dragend", func(e js.Value) {
+		k.dragging = ""
+		el.Get("classList").Call("remove", "opacity-50")
+		k.hidePlaceholder()
+	})
+	addTrackedListener(funcs, el, "click", func(e js.Value) {
+		if k.props.OnCardClick != nil {
+			if c, _, ok := k.Card(cardID); ok {
+				k.props.OnCardClick(c)
+			}
+		}
+	})
+	addTrackedListener(funcs, el, "keydown", func(e js.Value) {
+		k.handleKey(e, cardID)
+	})
+	return el
+}
+
+// handleKey moves the focused card: Alt+Left/Right to the neighboring
+// column, Alt+Up/Down within its column, Enter to click it
+func (k *Kanban) handleKey(e js.Value, cardID string) {
+	ci, cj := k.find(cardID)
+	if ci < 0 {
+		return
+	}
+	key := e.Get("key").String()
+	if key == "Enter" && k.props.OnCardClick != nil {
+		e.Call("preventDefault")
+		k.props.OnCardClick(k.columns[ci].Cards[cj])
+		return
+	}
+	if !e.Get("altKey").Bool() {
+		return
+	}
+
+	switch key {
+	case "ArrowLeft":
+		if ci > 0 {
+			e.Call("preventDefault")
+			k.userMove(cardID, k.columns[ci-1].ID, cj)
+		}
+	case "ArrowRight":
+		if ci < len(k.columns)-1 {
+			e.Call("preventDefault")
+			k.userMove(cardID, k.columns[ci+1].ID, cj)
+		}
+	case "ArrowUp":
+		if cj > 0 {
+			e.Call("preventDefault")
+			k.userMove(cardID, k.columns[ci].ID, cj-1)
+		}
+	case "ArrowDown":
+		if cj < len(k.columns[ci].Cards)-1 {
+			e.Call("preventDefault")
+			k.userMove(cardID, k.columns[ci].ID, cj+1)
+		}
+	}
+}
+
+// dropIndex returns the position among the list's cards above which a drop
+// at clientY lands
+func (k *Kanban) dropIndex(list js.Value, clientY float64) int {
+	cards := list.Call("querySelectorAll", "[data-card]")
+	n := cards.Length()
+	for i := 0; i < n; i++ {
+		rect := cards.Index(i).Call("getBoundingClientRect")
+		if clientY < rect.Get("top").Float()+rect.Get("height").Float()/2 {
+			return i
+		}
+	}
+	return n
+}
+
+func (k *Kanban) showPlaceholder(list js.Value, index int) {
+	cards := list.Call("querySelectorAll", "[data-card]")
+	if index < cards.Length() {
+		list.Call("insertBefore", k.placeholder, cards.Index(index))
+	} else {
+		list.Call("appendChild", k.placeholder)
+	}
+}
+
+func (k *Kanban) hidePlaceholder() {
+	if parent := k.placeholder.Get("parentNode"); parent.Truthy() {
+		parent.Call("removeChild", k.placeholder)
+	}
+}
+
+func (k *Kanban) focusCard(cardID string) {
+	selector := `[data-card="` + js.Global().Get("CSS").Call("escape", cardID).String() + `"]`
+	if el := k.container.Call("querySelector", selector); el.Truthy() {
+		el.Call("focus")
+	}
+}
@@ -0,0 +1,252 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// LabelFormat describes a label or receipt and the sheet it prints on.
+// Lengths are CSS lengths such as "2.625in" or "80mm".
+type LabelFormat struct {
+	Name       string // Shown in the print preview
+	Width      string // Label width
+	Height     string // Label height; empty for receipts, which are as long as their content
+	Columns    int    // Labels across a sheet (default 1)
+	Rows       int    // Labels down a sheet (default 1)
+	PageWidth  string // Sheet width (default: the label's width)
+	PageHeight string // Sheet height (default: the label's height)
+	Margin     string // Space between the sheet's edge and the labels (default "0")
+	Gap        string // Space between labels, as for the CSS gap property (default "0")
+	Padding    string // Space inside each label (default "0.0625in")
+}
+
+// Common label formats
+var (
+	// LabelAddress is a US Letter sheet of 30 address labels, 1" × 2⅝"
+	// (Avery 5160 and compatible)
+	LabelAddress = LabelFormat{
+		Name:       "Address labels, 30 per sheet",
+		Width:      "2.625in",
+		Height:     "1in",
+		Columns:    3,
+		Rows:       10,
+		PageWidth:  "8.5in",
+		PageHeight: "11in",
+		Margin:     "0.5in 0.1875in",
+		Gap:        "0 0.125in",
+		Padding:    "0.0625in 0.125in",
+	}
+
+	// LabelShipping is a 4" × 6" shipping label for thermal label printers
+	LabelShipping = LabelFormat{
+		Name:    "Shipping label, 4 × 6 in",
+		Width:   "4in",
+		Height:  "6in",
+		Padding: "0.125in",
+	}
+
+	// LabelReceipt is a receipt for 80mm thermal receipt printers
+	LabelReceipt = LabelFormat{
+		Name:    "Receipt, 80 mm",
+		Width:   "80mm",
+		Padding: "4mm",
+	}
+)
+
+// perPage returns the number of labels on a sheet
+func (f LabelFormat) perPage() int {
+	return max(f.Columns, 1) * max(f.Rows, 1)
+}
+
+// pageSize returns the @page size, or "" when the sheet's height follows
+// its content
+func (f LabelFormat) pageSize() string {
+	w, h := f.PageWidth, f.PageHeight
+	if w == "" {
+		w = f.Width
+	}
+	if h == "" {
+		h = f.Height
+	}
+	if w == "" || h == "" {
+		return ""
+	}
+	return w + " " + h
+}
+
+// LabelPrinterProps configures a LabelPrinter
+type LabelPrinterProps struct {
+	Format LabelFormat                       // Label size and sheet layout (default LabelAddress)
+	Render func(row map[string]any) js.Value // Builds the content of a row's label
+	Title  string                            // Preview dialog title (default "Print preview")
+}
+
+// LabelPrinter prints rows, such as a Table's selection, as address
+// labels, shipping labels or receipts: one label per row, laid out on
+// sheets of the format's size. Add a Barcode to a label for scanning.
+type LabelPrinter struct {
+	props LabelPrinterProps
+}
+
+// NewLabelPrinter creates a LabelPrinter
+func NewLabelPrinter(props LabelPrinterProps) *LabelPrinter {
+	if props.Format.Width == "" {
+		props.Format = LabelAddress
+	}
+	if props.Format.Padding == "" {
+		props.Format.Padding = "0.0625in"
+	}
+	if props.Title == "" {
+		props.Title = i18n.T("gux.labels.preview")
+	}
+	return &LabelPrinter{props: props}
+}
+
+// Sheets builds the printable sheets for rows, one label per row
+func (p *LabelPrinter) Sheets(rows []map[string]any) []js.Value {
+	document := js.Global().Get("document")
+	f := p.props.Format
+
+	var sheets []js.Value
+	var sheet js.Value
+	for i, row := range rows {
+		if i%f.perPage() == 0 {
+			sheet = p.newSheet()
+			sheets = append(sheets, sheet)
+		}
+
+		label := document.Call("createElement", "div")
+		style := label.Get("style")
+		style.Set("width", f.Width)
+		if f.Height != "" {
+			style.Set("height", f.Height)
+		}
+		style.Set("padding", f.Padding)
+		style.Set("boxSizing", "border-box")
+		style.Set("overflow", "hidden")
+		if p.props.Render != nil {
+			label.Call("appendChild", p.props.Render(row))
+		}
+		sheet.Call("appendChild", label)
+	}
+	return sheets
+}
+
+func (p *LabelPrinter) newSheet() js.Value {
+	f := p.props.Format
+	sheet := js.Global().Get("document").Call("createElement", "div")
+	sheet.Set("className", "bg-white text-black")
+	style := sheet.Get("style")
+	if w := f.PageWidth; w != "" {
+		style.Set("width", w)
+	} else {
+		style.Set("width", f.Width)
+	}
+	if h := f.PageHeight; h != "" {
+		style.Set("height", h)
+	} else if f.Height != "" {
+		style.Set("height", f.Height)
+	}
+	if f.Margin != "" {
+		style.Set("padding", f.Margin)
+	}
+	if f.Gap != "" {
+		style.Set("gap", f.Gap)
+	}
+	style.Set("boxSizing", "border-box")
+	style.Set("overflow", "hidden")
+	style.Set("display", "grid")
+	style.Set("gridTemplateColumns", fmt.Sprintf("repeat(%d, %s)", max(f.Columns, 1), f.Width))
+	style.Set("alignContent", "start")
+	style.Set("breakAfter", "page")
+	return sheet
+}
+
+// Print prints rows without a preview
+func (p *LabelPrinter) Print(rows []map[string]any) {
+	if len(rows) == 0 {
+		return
+	}
+	document := js.Global().Get("document")
+	body := document.Get("body")
+
+	// Print only the sheets: everything else on the page is hidden while
+	// printing, and the sheets are hidden on screen
+	root := document.Call("createElement", "div")
+	root.Set("className", "gux-print-root")
+	for _, sheet := range p.Sheets(rows) {
+		root.Call("appendChild", sheet)
+	}
+
+	page := "margin: 0;"
+	if size := p.props.Format.pageSize(); size != "" {
+		page += " size: " + size + ";"
+	}
+	style := newStyle()
+	style.Set("textContent", "@media screen { .gux-print-root { display: none; } }\n"+
+		"@media print { body > :not(.gux-print-root) { display: none !important; } }\n"+
+		"@page { "+page+" }")
+
+	body.Call("appendChild", style)
+	body.Call("appendChild", root)
+
+	var done js.Func
+	done = js.FuncOf(func(this js.Value, args []js.Value) any {
+		done.Release()
+		unmount(root)
+		style.Call("remove")
+		return nil
+	})
+	js.Global().Call("addEventListener", "afterprint", done, map[string]any{"once": true})
+	js.Global().Call("print")
+}
+
+// Preview shows rows' labels in a dialog, with a button to print them
+func (p *LabelPrinter) Preview(rows []map[string]any) {
+	document := js.Global().Get("document")
+	f := p.props.Format
+	sheets := p.Sheets(rows)
+
+	summary := i18n.T("gux.labels.summary", len(rows), len(sheets))
+	if f.Name != "" {
+		summary = f.Name + " · " + summary
+	}
+
+	pages := Div("max-h-[70vh] overflow-auto bg-gray-200 dark:bg-gray-700 p-4 rounded")
+	for _, sheet := range sheets {
+		sheet.Get("classList").Call("add", "shadow", "mx-auto", "mb-4")
+		pages.Call("appendChild", sheet)
+	}
+	content := Div("space-y-3",
+		Div("text-sm text-secondary", Text(summary)),
+		pages,
+	)
+
+	var modal *Modal
+	footer := Div("flex justify-end gap-2",
+		SecondaryButton(i18n.T("gux.labels.cancel"), func() {
+			modal.Close()
+		}),
+		PrimaryButton(i18n.T("gux.labels.print"), func() {
+			modal.Close()
+			p.Print(rows)
+		}),
+	)
+	modal = NewModal(ModalProps{
+		Title:      p.props.Title,
+		Content:    content,
+		Footer:     footer,
+		Width:      "full",
+		CloseOnEsc: true,
+		OnClose: func() {
+			// Unmount after the close handler has returned
+			setTimeout(modal.Unmount, 0)
+		},
+	})
+	modal.Mount(document.Get("body"))
+	modal.Open()
+}
@@ -62,6 +62,16 @@ func (l *Layout) Element() js.Value {
 	return l.element
 }
 
+// Mount appends the layout to parent
+func (l *Layout) Mount(parent js.Value) {
+	parent.Call("appendChild", l.element)
+}
+
+// Unmount removes the layout and unmounts its sidebar, header and content
+func (l *Layout) Unmount() {
+	unmount(l.element)
+}
+
 // Sidebar returns the sidebar component
 func (l *Layout) Sidebar() *Sidebar {
 	return l.sidebar
@@ -72,9 +82,10 @@ func (l *Layout) Header() *Header {
 	return l.header
 }
 
-// SetContent replaces the main content area
+// SetContent replaces the main content area, unmounting the components in
+// the content it replaces
 func (l *Layout) SetContent(content js.Value) {
-	l.contentEl.Set("innerHTML", "")
+	UnmountChildren(l.contentEl)
 	l.contentEl.Call("appendChild", content)
 }
 
@@ -0,0 +1,149 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+)
+
+// Component is a component that owns a DOM element and the listeners and
+// js.Funcs behind it. Mount places it in the page; Unmount removes it and
+// releases everything it attached, including listeners on document and
+// window. A component can't be used after Unmount.
+type Component interface {
+	Element() js.Value
+	Mount(parent js.Value)
+	Unmount()
+}
+
+// unmountAttr marks elements with cleanup registered by onUnmount
+const unmountAttr = "data-gux-unmount"
+
+// unmounters are the cleanups registered with onUnmount, by the ID in an
+// element's unmountAttr
+var (
+	unmounters    = make(map[string][]func())
+	nextUnmountID int
+)
+
+// onUnmount registers fn to run when el is removed by UnmountTree, e.g.
+// when Layout.SetContent replaces the page it is on
+func onUnmount(el js.Value, fn func()) {
+	id := el.Call("getAttribute", unmountAttr)
+	if id.IsNull() {
+		nextUnmountID++
+		id = js.ValueOf(strconv.Itoa(nextUnmountID))
+		el.Call("setAttribute", unmountAttr, id)
+	}
+	unmounters[id.String()] = append(unmounters[id.String()], fn)
+}
+
+// forget drops the cleanup registered for el, once a component has run it
+// itself
+func forget(el js.Value) {
+	id := el.Call("getAttribute", unmountAttr)
+	if id.IsNull() {
+		return
+	}
+	delete(unmounters, id.String())
+	el.Call("removeAttribute", unmountAttr)
+}
+
+// UnmountTree unmounts every component in root, root included, releasing
+// their listeners and js.Funcs. Call it before discarding DOM built from
+// components; Layout.SetContent does it for the page it replaces.
+func UnmountTree(root js.Value) {
+	if !root.Truthy() || root.Get("nodeType").Int() != 1 {
+		return
+	}
+	els := []js.Value{root}
+	found := root.Call("querySelectorAll", "["+unmountAttr+"]")
+	for i := 0; i < found.Length(); i++ {
+		els = append(els, found.Index(i))
+	}
+	for _, el := range els {
+		id := el.Call("getAttribute", unmountAttr)
+		if id.IsNull() {
+			continue // forgotten by a component unmounted earlier in the loop
+		}
+		fns := unmounters[id.String()]
+		delete(unmounters, id.String())
+		el.Call("removeAttribute", unmountAttr)
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
+// unmount removes a component's element after unmounting the components
+// inside it
+func unmount(el js.Value) {
+	forget(el)
+	UnmountTree(el)
+	el.Call("remove")
+}
+
+// UnmountChildren unmounts the components in el's children and removes them
+func UnmountChildren(el js.Value) {
+	children := el.Get("children")
+	for i := children.Length() - 1; i >= 0; i-- {
+		UnmountTree(children.Index(i))
+	}
+	el.Set("innerHTML", "")
+}
+
+// listeners collects the js.Funcs a component creates and the listeners it
+// adds, so they can be released together. A component keeps one for its
+// lifetime, and one per part it renders again, such as table rows.
+type listeners struct {
+	funcs   []js.Func
+	cleanup []func()
+}
+
+// fn creates a js.Func released with the others
+func (l *listeners) fn(f func(this js.Value, args []js.Value) any) js.Func {
+	fn := js.FuncOf(f)
+	l.funcs = append(l.funcs, fn)
+	return fn
+}
+
+// on adds an event listener to target, removed and released with the
+// others. Use it for document and window listeners, which outlive the
+// component's element.
+func (l *listeners) on(target js.Value, event string, f func(this js.Value, args []js.Value) any) js.Func {
+	fn := l.fn(f)
+	target.Call("addEventListener", event, fn)
+	l.cleanup = append(l.cleanup, func() {
+		target.Call("removeEventListener", event, fn)
+	})
+	return fn
+}
+
+// onRelease runs fn on release, before the js.Funcs are released
+func (l *listeners) onRelease(fn func()) {
+	l.cleanup = append(l.cleanup, fn)
+}
+
+// release removes the listeners and releases the js.Funcs. The listeners can
+// be used again afterwards.
+func (l *listeners) release() {
+	for j := len(l.cleanup) - 1; j >= 0; j-- {
+		l.cleanup[j]()
+	}
+	for _, fn := range l.funcs {
+		fn.Release()
+	}
+	l.funcs, l.cleanup = nil, nil
+}
+
+// setTimeout calls fn once after ms milliseconds and releases its js.Func
+func setTimeout(fn func(), ms int) js.Value {
+	var f js.Func
+	f = js.FuncOf(func(this js.Value, args []js.Value) any {
+		f.Release()
+		fn()
+		return nil
+	})
+	return js.Global().Call("setTimeout", f, ms)
+}
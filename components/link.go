@@ -16,19 +16,36 @@ func Link(props LinkProps) js.Value {
 	document := js.Global().Get("document")
 	a := document.Call("createElement", "a")
 
-	a.Set("href", props.To)
+	href := props.To
+	if globalRouter != nil {
+		href = globalRouter.Href(href)
+	}
+	a.Set("href", href)
 	if props.ClassName != "" {
 		a.Set("className", props.ClassName)
 	}
 
 	// Prevent default navigation, use router instead
-	a.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	click := js.FuncOf(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
 		if globalRouter != nil {
 			globalRouter.Navigate(props.To)
 		}
 		return nil
-	}))
+	})
+	a.Call("addEventListener", "click", click)
+	onUnmount(a, click.Release)
+
+	// Warm the page's data while the user is about to click
+	prefetch := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if globalRouter != nil {
+			globalRouter.PrefetchRoute(props.To)
+		}
+		return nil
+	})
+	a.Call("addEventListener", "pointerenter", prefetch)
+	a.Call("addEventListener", "focus", prefetch)
+	onUnmount(a, prefetch.Release)
 
 	if props.Children != nil {
 		props.Children(a)
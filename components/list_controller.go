@@ -0,0 +1,257 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/state"
+)
+
+// ListControllerOptions configures a ListController. Create, Update and
+// Delete call the API, usually by wrapping a generated client's methods;
+// leave one nil to apply that change locally only.
+type ListControllerOptions[T any] struct {
+	// Key identifies an item, e.g. its ID as a string (required). A new
+	// item is followed by its place in the list until Create returns it,
+	// since its key is often a zero ID shared with other new items.
+	Key func(item T) string
+
+	Create func(ctx context.Context, item T) (T, error) // returns the item as saved
+	Update func(ctx context.Context, item T) (T, error) // returns the item as saved
+	Delete func(ctx context.Context, item T) error
+
+	// Prepend adds new items at the start of the list instead of the end
+	Prepend bool
+
+	// OnError is called after a failed change is rolled back and its error
+	// toast shown
+	OnError func(err error)
+}
+
+// ListController makes optimistic changes to the list in an AsyncStore:
+// each change shows at once, the API is called in the background, and a
+// failed change is undone with an error toast. Only the failed change is
+// undone, so others made meanwhile are kept.
+//
+//	store := state.NewAsync[[]api.Post]()
+//	posts := components.NewListController(store, components.ListControllerOptions[api.Post]{
+//		Key: func(p api.Post) string { return strconv.Itoa(p.ID) },
+//		Delete: func(ctx context.Context, p api.Post) error {
+//			return client.Delete(ctx, p.ID)
+//		},
+//	})
+//	posts.Delete(post)
+type ListController[T any] struct {
+	store *state.AsyncStore[[]T]
+	opts  ListControllerOptions[T]
+
+	last     []T              // the list as the controller last set it
+	creating []*pendingCreate // new items not yet saved
+}
+
+// pendingCreate is the place in the list of an item Create added, or -1
+// once it is gone
+type pendingCreate struct {
+	index int
+}
+
+// NewListController creates a ListController for store's list
+func NewListController[T any](store *state.AsyncStore[[]T], opts ListControllerOptions[T]) *ListController[T] {
+	return &ListController[T]{store: store, opts: opts}
+}
+
+// Store returns the store holding the list
+func (c *ListController[T]) Store() *state.AsyncStore[[]T] {
+	return c.store
+}
+
+// Items returns the list, including changes still being saved
+func (c *ListController[T]) Items() []T {
+	return c.store.Data()
+}
+
+// Create adds item to the list, then replaces it with the item Create
+// returns, or removes it again if Create fails
+func (c *ListController[T]) Create(item T) {
+	if c.opts.Create == nil {
+		c.mutate(func(items []T) []T {
+			return c.insert(items, c.newIndex(items), item)
+		})
+		return
+	}
+	pending := &pendingCreate{}
+	c.mutate(func(items []T) []T {
+		pending.index = c.newIndex(items)
+		items = c.insert(items, pending.index, item)
+		c.creating = append(c.creating, pending)
+		return items
+	})
+	go func() {
+		saved, err := c.opts.Create(context.Background(), item)
+		c.mutate(func(items []T) []T {
+			i := pending.index
+			if i < 0 {
+				return items // removed, or the list reloaded, meanwhile
+			}
+			if err != nil {
+				return c.remove(items, i)
+			}
+			c.forget(pending)
+			items[i] = saved
+			return items
+		})
+		if err != nil {
+			c.failed(i18n.T("gux.list.createError", err.Error()), err)
+		}
+	}()
+}
+
+// newIndex returns where Create adds an item
+func (c *ListController[T]) newIndex(items []T) int {
+	if c.opts.Prepend {
+		return 0
+	}
+	return len(items)
+}
+
+// Update replaces the item with item's key, then with the item Update
+// returns, or puts the previous item back if Update fails
+func (c *ListController[T]) Update(item T) {
+	key := c.opts.Key(item)
+	var previous T
+	found := false
+	c.mutate(func(items []T) []T {
+		if i := c.index(items, key); i >= 0 {
+			previous, found = items[i], true
+			items[i] = item
+		}
+		return items
+	})
+	if c.opts.Update == nil {
+		return
+	}
+	go func() {
+		saved, err := c.opts.Update(context.Background(), item)
+		if err != nil {
+			if found {
+				c.replace(key, previous)
+			}
+			c.failed(i18n.T("gux.list.updateError", err.Error()), err)
+			return
+		}
+		c.replace(key, saved)
+	}()
+}
+
+// Delete removes the item with item's key, putting it back where it was if
+// Delete fails
+func (c *ListController[T]) Delete(item T) {
+	key := c.opts.Key(item)
+	index := -1
+	var removed T
+	c.mutate(func(items []T) []T {
+		if index = c.index(items, key); index >= 0 {
+			removed = items[index]
+			return c.remove(items, index)
+		}
+		return items
+	})
+	if c.opts.Delete == nil {
+		return
+	}
+	go func() {
+		err := c.opts.Delete(context.Background(), item)
+		if err == nil {
+			return
+		}
+		if index >= 0 {
+			c.mutate(func(items []T) []T {
+				if c.index(items, key) >= 0 {
+					return items // added again meanwhile
+				}
+				return c.insert(items, min(index, len(items)), removed)
+			})
+		}
+		c.failed(i18n.T("gux.list.deleteError", err.Error()), err)
+	}()
+}
+
+// mutate applies fn to a copy of the list, so subscribers' earlier
+// snapshots don't change under them. When the list was replaced since the
+// controller last changed it, as by a reload, the places of new items are
+// unknown, so saving them leaves the list as it is.
+func (c *ListController[T]) mutate(fn func(items []T) []T) {
+	c.store.Update(func(s *state.AsyncState[[]T]) {
+		if !c.owns(s.Data) {
+			for _, p := range c.creating {
+				p.index = -1
+			}
+			c.creating = nil
+		}
+		s.Data = fn(slices.Clone(s.Data))
+		c.last = s.Data
+	})
+}
+
+// owns reports whether items is the list the controller last set
+func (c *ListController[T]) owns(items []T) bool {
+	return len(items) == len(c.last) && (len(items) == 0 || &items[0] == &c.last[0])
+}
+
+// insert adds item at i, moving the new items after it along
+func (c *ListController[T]) insert(items []T, i int, item T) []T {
+	for _, p := range c.creating {
+		if p.index >= i {
+			p.index++
+		}
+	}
+	return slices.Insert(items, i, item)
+}
+
+// remove deletes the item at i, moving the new items after it back
+func (c *ListController[T]) remove(items []T, i int) []T {
+	kept := c.creating[:0]
+	for _, p := range c.creating {
+		switch {
+		case p.index == i:
+			p.index = -1
+			continue
+		case p.index > i:
+			p.index--
+		}
+		kept = append(kept, p)
+	}
+	c.creating = kept
+	return slices.Delete(items, i, i+1)
+}
+
+// forget stops following a new item once it is saved
+func (c *ListController[T]) forget(pending *pendingCreate) {
+	c.creating = slices.DeleteFunc(c.creating, func(p *pendingCreate) bool { return p == pending })
+}
+
+// replace swaps the item with key for item, if it is still in the list
+func (c *ListController[T]) replace(key string, item T) {
+	c.mutate(func(items []T) []T {
+		if i := c.index(items, key); i >= 0 {
+			items[i] = item
+		}
+		return items
+	})
+}
+
+// index returns the index of the item with key, or -1
+func (c *ListController[T]) index(items []T, key string) int {
+	return slices.IndexFunc(items, func(item T) bool { return c.opts.Key(item) == key })
+}
+
+// failed reports a change that was rolled back
+func (c *ListController[T]) failed(message string, err error) {
+	Toast(message, ToastError)
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
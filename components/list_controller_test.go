@@ -0,0 +1,86 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dougbarrett/gux/state"
+)
+
+type listItem struct {
+	ID   int
+	Name string
+}
+
+// waitFor polls cond until it holds or a second passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func names(items []listItem) string {
+	var s string
+	for _, item := range items {
+		s += item.Name + "=" + strconv.Itoa(item.ID) + " "
+	}
+	return s
+}
+
+func TestListControllerCreatesWithZeroKeys(t *testing.T) {
+	saves := map[string]chan error{"a": make(chan error), "b": make(chan error)}
+	ids := map[string]int{"a": 1, "b": 2}
+	store := state.NewAsync[[]listItem]()
+	c := NewListController(store, ListControllerOptions[listItem]{
+		Key: func(item listItem) string { return strconv.Itoa(item.ID) },
+		Create: func(ctx context.Context, item listItem) (listItem, error) {
+			if err := <-saves[item.Name]; err != nil {
+				return listItem{}, err
+			}
+			item.ID = ids[item.Name]
+			return item, nil
+		},
+		OnError: func(error) {},
+	})
+
+	c.Create(listItem{Name: "a"})
+	c.Create(listItem{Name: "b"})
+	if got := names(c.Items()); got != "a=0 b=0 " {
+		t.Fatalf("items = %q", got)
+	}
+
+	// b is saved first, and must not take a's place
+	saves["b"] <- nil
+	waitFor(t, func() bool { return names(c.Items()) == "a=0 b=2 " })
+	saves["a"] <- nil
+	waitFor(t, func() bool { return names(c.Items()) == "a=1 b=2 " })
+}
+
+func TestListControllerRollsBackTheFailedCreate(t *testing.T) {
+	saves := map[string]chan error{"a": make(chan error), "b": make(chan error)}
+	store := state.NewAsync[[]listItem]()
+	c := NewListController(store, ListControllerOptions[listItem]{
+		Key: func(item listItem) string { return strconv.Itoa(item.ID) },
+		Create: func(ctx context.Context, item listItem) (listItem, error) {
+			return item, <-saves[item.Name]
+		},
+		Prepend: true,
+		OnError: func(error) {},
+	})
+
+	c.Create(listItem{Name: "a"})
+	c.Create(listItem{Name: "b"})
+	saves["a"] <- errors.New("rejected")
+	waitFor(t, func() bool { return names(c.Items()) == "b=0 " })
+	saves["b"] <- nil
+}
@@ -22,14 +22,7 @@ type Modal struct {
 	isOpen    bool
 	titleID   string // ARIA: unique ID for aria-labelledby
 	focusTrap *FocusTrap
-}
-
-var modalWidths = map[string]string{
-	"sm":   "max-w-sm",
-	"md":   "max-w-md",
-	"lg":   "max-w-lg",
-	"xl":   "max-w-xl",
-	"full": "max-w-4xl",
+	listeners listeners
 }
 
 // NewModal creates a new Modal component
@@ -38,20 +31,19 @@ func NewModal(props ModalProps) *Modal {
 
 	// Overlay
 	overlay := document.Call("createElement", "div")
-	overlay.Set("className", "fixed inset-0 bg-black bg-opacity-50 flex items-center justify-center z-50 hidden")
+	overlay.Set("className", Classes("modal.overlay")+" hidden")
 
 	// Modal container
 	width := props.Width
 	if width == "" {
 		width = "md"
 	}
-	widthClass := modalWidths[width]
-	if widthClass == "" {
-		widthClass = modalWidths["md"]
+	if !hasClass("modal." + width) {
+		width = "md"
 	}
 
 	modal := document.Call("createElement", "div")
-	modal.Set("className", "surface-base rounded-lg shadow-xl "+widthClass+" w-full mx-4 max-h-[90vh] flex flex-col")
+	modal.Set("className", Classes("modal.dialog", "modal."+width))
 
 	// Generate unique ID for ARIA labelledby
 	titleID := ""
@@ -78,19 +70,19 @@ func NewModal(props ModalProps) *Modal {
 	// Header
 	if props.Title != "" {
 		header := document.Call("createElement", "div")
-		header.Set("className", "flex justify-between items-center px-6 py-4 border-b border-subtle")
+		header.Set("className", Classes("modal.header"))
 
 		title := document.Call("createElement", "h3")
-		title.Set("className", "text-lg font-semibold text-primary")
+		title.Set("className", Classes("modal.title"))
 		title.Set("textContent", props.Title)
 		title.Set("id", m.titleID) // ARIA: referenced by aria-labelledby
 		header.Call("appendChild", title)
 
 		closeBtn := document.Call("createElement", "button")
-		closeBtn.Set("className", "text-secondary hover:text-primary text-2xl leading-none cursor-pointer")
+		closeBtn.Set("className", Classes("modal.close"))
 		closeBtn.Set("innerHTML", "&times;")
 		closeBtn.Call("setAttribute", "aria-label", "Close") // ARIA: accessible name for close button
-		closeBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		closeBtn.Call("addEventListener", "click", m.listeners.fn(func(this js.Value, args []js.Value) any {
 			m.Close()
 			return nil
 		}))
@@ -101,7 +93,7 @@ func NewModal(props ModalProps) *Modal {
 
 	// Content
 	content := document.Call("createElement", "div")
-	content.Set("className", "px-6 py-4 overflow-y-auto flex-1")
+	content.Set("className", Classes("modal.body"))
 	if !props.Content.IsUndefined() && !props.Content.IsNull() {
 		content.Call("appendChild", props.Content)
 	}
@@ -111,7 +103,7 @@ func NewModal(props ModalProps) *Modal {
 	// Footer
 	if !props.Footer.IsUndefined() && !props.Footer.IsNull() {
 		footer := document.Call("createElement", "div")
-		footer.Set("className", "px-6 py-4 border-t border-subtle surface-raised rounded-b-lg")
+		footer.Set("className", Classes("modal.footer"))
 		footer.Call("appendChild", props.Footer)
 		modal.Call("appendChild", footer)
 	}
@@ -119,7 +111,7 @@ func NewModal(props ModalProps) *Modal {
 	overlay.Call("appendChild", modal)
 
 	// Close on overlay click
-	overlay.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	overlay.Call("addEventListener", "click", m.listeners.fn(func(this js.Value, args []js.Value) any {
 		if args[0].Get("target").Equal(overlay) {
 			m.Close()
 		}
@@ -128,22 +120,23 @@ func NewModal(props ModalProps) *Modal {
 
 	// Close on Escape key
 	if props.CloseOnEsc {
-		js.Global().Get("document").Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		m.listeners.on(document, "keydown", func(this js.Value, args []js.Value) any {
 			if m.isOpen && args[0].Get("key").String() == "Escape" {
 				m.Close()
 			}
 			return nil
-		}))
+		})
 	}
 
 	// Store onClose callback
 	if props.OnClose != nil {
-		m.overlay.Set("_onClose", js.FuncOf(func(this js.Value, args []js.Value) any {
+		m.overlay.Set("_onClose", m.listeners.fn(func(this js.Value, args []js.Value) any {
 			props.OnClose()
 			return nil
 		}))
 	}
 
+	onUnmount(overlay, m.Unmount)
 	return m
 }
 
@@ -183,9 +176,10 @@ func (m *Modal) IsOpen() bool {
 	return m.isOpen
 }
 
-// SetContent replaces the modal content
+// SetContent replaces the modal content, unmounting the components in the
+// content it replaces
 func (m *Modal) SetContent(content js.Value) {
-	m.content.Set("innerHTML", "")
+	UnmountChildren(m.content)
 	m.content.Call("appendChild", content)
 }
 
@@ -199,7 +193,24 @@ func (m *Modal) ModalElement() js.Value {
 	return m.modal
 }
 
+// Mount appends the modal's overlay to parent
+func (m *Modal) Mount(parent js.Value) {
+	parent.Call("appendChild", m.overlay)
+}
+
+// Unmount removes the modal, unmounts its content and releases its
+// listeners
+func (m *Modal) Unmount() {
+	m.Destroy()
+	unmount(m.overlay)
+}
+
 // Destroy cleans up the modal's resources
 func (m *Modal) Destroy() {
+	if m.isOpen {
+		m.isOpen = false
+		js.Global().Get("document").Get("body").Get("style").Set("overflow", "")
+	}
 	m.focusTrap.Destroy()
+	m.listeners.release()
 }
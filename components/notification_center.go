@@ -2,7 +2,11 @@
 
 package components
 
-import "syscall/js"
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
 
 // Notification represents a single notification item
 type Notification struct {
@@ -21,23 +25,40 @@ type NotificationCenterProps struct {
 	OnMarkAllRead       func()
 	OnClear             func()
 	OnNotificationClick func(id string)
+
+	// Store keeps the list, unread badge, and read state in sync with a
+	// server. Clicking a notification marks it read, and older pages load
+	// from a button at the end of the list. Notifications is ignored.
+	Store *NotificationStore
+
+	// TitleBadge, when set, also shows the unread count in the browser
+	// tab's title and favicon, e.g. "(3) Dashboard"
+	TitleBadge *TitleBadgeProps
 }
 
 // NotificationCenter creates a notification bell with dropdown
 type NotificationCenter struct {
 	element       js.Value
 	dropdown      *Dropdown
+	trigger       js.Value
 	badgeEl       js.Value
 	listContainer js.Value
 	emptyState    js.Value
+	loadMoreBtn   js.Value
 	notifications []Notification
 	props         NotificationCenterProps
+	titleBadge    *TitleBadge
+	unsubscribe   func()
+	listeners     listeners
+	itemFuncs     listeners // of the rendered notifications
 }
 
 // NewNotificationCenter creates a new NotificationCenter component
 func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 	document := js.Global().Get("document")
 
+	var funcs listeners
+
 	// Create bell button trigger with badge container
 	// Use button as outer container so ARIA attributes are valid
 	triggerContainer := document.Call("createElement", "button")
@@ -78,10 +99,15 @@ func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 	markAllBtn := document.Call("createElement", "button")
 	markAllBtn.Set("className", "text-xs text-blue-600 dark:text-blue-400 hover:underline")
 	markAllBtn.Set("textContent", "Mark all read")
-	if props.OnMarkAllRead != nil {
-		markAllBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	if props.OnMarkAllRead != nil || props.Store != nil {
+		markAllBtn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 			args[0].Call("stopPropagation")
-			props.OnMarkAllRead()
+			if props.Store != nil {
+				props.Store.MarkAllRead()
+			}
+			if props.OnMarkAllRead != nil {
+				props.OnMarkAllRead()
+			}
 			return nil
 		}))
 	}
@@ -99,6 +125,19 @@ func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 	emptyState.Set("textContent", "No notifications")
 	listContainer.Call("appendChild", emptyState)
 
+	// Older pages from the store (hidden until there are more)
+	loadMoreBtn := document.Call("createElement", "button")
+	loadMoreBtn.Set("className", "w-full py-2 text-center text-xs text-blue-600 dark:text-blue-400 hover:underline disabled:opacity-50 hidden")
+	loadMoreBtn.Set("textContent", i18n.T("gux.notifications.loadMore"))
+	if props.Store != nil {
+		loadMoreBtn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
+			args[0].Call("stopPropagation")
+			props.Store.LoadMore()
+			return nil
+		}))
+	}
+	listContainer.Call("appendChild", loadMoreBtn)
+
 	// Footer with clear all
 	footer := document.Call("createElement", "div")
 	footer.Set("className", "px-4 py-2 border-t border-gray-200 dark:border-gray-700")
@@ -106,10 +145,15 @@ func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 	clearBtn := document.Call("createElement", "button")
 	clearBtn.Set("className", "w-full text-center text-xs text-gray-500 dark:text-gray-400 hover:text-gray-700 dark:hover:text-gray-200")
 	clearBtn.Set("textContent", "Clear all")
-	if props.OnClear != nil {
-		clearBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	if props.OnClear != nil || props.Store != nil {
+		clearBtn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 			args[0].Call("stopPropagation")
-			props.OnClear()
+			if props.Store != nil {
+				props.Store.Clear()
+			}
+			if props.OnClear != nil {
+				props.OnClear()
+			}
 			return nil
 		}))
 	}
@@ -132,16 +176,31 @@ func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 	nc := &NotificationCenter{
 		element:       dropdown.Element(),
 		dropdown:      dropdown,
+		trigger:       triggerContainer,
 		badgeEl:       badgeEl,
 		listContainer: listContainer,
 		emptyState:    emptyState,
+		loadMoreBtn:   loadMoreBtn,
 		notifications: props.Notifications,
 		props:         props,
+		listeners:     funcs,
+	}
+
+	if props.TitleBadge != nil {
+		nc.titleBadge = NewTitleBadge(*props.TitleBadge)
+	}
+	if props.Store != nil {
+		nc.notifications = props.Store.State().Notifications
+		nc.unsubscribe = props.Store.Subscribe(func(st NotificationState) {
+			nc.notifications = st.Notifications
+			nc.renderNotifications()
+		})
 	}
 
 	// Render initial notifications
 	nc.renderNotifications()
 
+	onUnmount(nc.element, nc.Unmount)
 	return nc
 }
 
@@ -149,42 +208,55 @@ func NewNotificationCenter(props NotificationCenterProps) *NotificationCenter {
 func (nc *NotificationCenter) renderNotifications() {
 	document := js.Global().Get("document")
 
-	// Clear existing items (but keep empty state)
+	// Clear existing items (but keep empty state and load more)
 	children := nc.listContainer.Get("children")
 	length := children.Get("length").Int()
 	for i := length - 1; i >= 0; i-- {
 		child := children.Index(i)
-		if child.Equal(nc.emptyState) {
+		if child.Equal(nc.emptyState) || child.Equal(nc.loadMoreBtn) {
 			continue
 		}
 		nc.listContainer.Call("removeChild", child)
 	}
+	nc.itemFuncs.release()
 
-	// Show/hide empty state
-	if len(nc.notifications) == 0 {
-		nc.emptyState.Get("classList").Call("remove", "hidden")
+	// Update badge, and the bell's label since the badge is aria-hidden
+	unreadCount := nc.UnreadCount()
+	if unreadCount > 0 {
+		text := itoa(unreadCount)
+		if unreadCount > 99 {
+			text = "99+"
+		}
+		nc.badgeEl.Set("textContent", text)
+		nc.badgeEl.Get("classList").Call("remove", "hidden")
+		nc.trigger.Call("setAttribute", "aria-label", i18n.N("gux.notifications.unread", unreadCount))
+	} else {
 		nc.badgeEl.Get("classList").Call("add", "hidden")
-		return
+		nc.trigger.Call("setAttribute", "aria-label", "Notifications")
+	}
+	if nc.titleBadge != nil {
+		nc.titleBadge.SetCount(unreadCount)
 	}
 
-	nc.emptyState.Get("classList").Call("add", "hidden")
-
-	// Calculate unread count
-	unreadCount := 0
-	for _, n := range nc.notifications {
-		if !n.Read {
-			unreadCount++
+	if nc.props.Store != nil {
+		st := nc.props.Store.State()
+		nc.loadMoreBtn.Get("classList").Call("toggle", "hidden", !st.HasMore)
+		nc.loadMoreBtn.Set("disabled", st.Loading)
+		if st.Loading {
+			nc.loadMoreBtn.Set("textContent", i18n.T("gux.notifications.loading"))
+		} else {
+			nc.loadMoreBtn.Set("textContent", i18n.T("gux.notifications.loadMore"))
 		}
 	}
 
-	// Update badge
-	if unreadCount > 0 {
-		nc.badgeEl.Set("textContent", itoa(unreadCount))
-		nc.badgeEl.Get("classList").Call("remove", "hidden")
-	} else {
-		nc.badgeEl.Get("classList").Call("add", "hidden")
+	// Show/hide empty state
+	if len(nc.notifications) == 0 {
+		nc.emptyState.Get("classList").Call("remove", "hidden")
+		return
 	}
 
+	nc.emptyState.Get("classList").Call("add", "hidden")
+
 	// Render notification items (insert before empty state)
 	for _, notification := range nc.notifications {
 		item := nc.createNotificationItem(document, notification)
@@ -242,10 +314,15 @@ func (nc *NotificationCenter) createNotificationItem(document js.Value, notifica
 	item.Call("appendChild", content)
 
 	// Click handlers
-	id := notification.ID
-	if nc.props.OnNotificationClick != nil {
-		item.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
-			nc.props.OnNotificationClick(id)
+	id, read := notification.ID, notification.Read
+	if nc.props.OnNotificationClick != nil || nc.props.Store != nil {
+		item.Call("addEventListener", "click", nc.itemFuncs.fn(func(this js.Value, args []js.Value) any {
+			if nc.props.Store != nil && !read {
+				nc.props.Store.MarkRead(id)
+			}
+			if nc.props.OnNotificationClick != nil {
+				nc.props.OnNotificationClick(id)
+			}
 			return nil
 		}))
 	}
@@ -258,14 +335,30 @@ func (nc *NotificationCenter) Element() js.Value {
 	return nc.element
 }
 
+// Mount appends the notification center to parent
+func (nc *NotificationCenter) Mount(parent js.Value) {
+	parent.Call("appendChild", nc.element)
+}
+
+// Unmount removes the notification center, releases its listeners and stops
+// following the store
+func (nc *NotificationCenter) Unmount() {
+	nc.Destroy()
+	nc.dropdown.Unmount()
+}
+
 // SetNotifications updates the notification list
 func (nc *NotificationCenter) SetNotifications(notifications []Notification) {
 	nc.notifications = notifications
 	nc.renderNotifications()
 }
 
-// UnreadCount returns the number of unread notifications
+// UnreadCount returns the number of unread notifications, as counted by the
+// store when there is one
 func (nc *NotificationCenter) UnreadCount() int {
+	if nc.props.Store != nil {
+		return nc.props.Store.Unread()
+	}
 	count := 0
 	for _, n := range nc.notifications {
 		if !n.Read {
@@ -285,7 +378,18 @@ func (nc *NotificationCenter) Close() {
 	nc.dropdown.Close()
 }
 
-// Destroy cleans up event listeners
+// Destroy cleans up event listeners and the store subscription, and
+// restores the page title. The store itself keeps running.
 func (nc *NotificationCenter) Destroy() {
+	if nc.unsubscribe != nil {
+		nc.unsubscribe()
+		nc.unsubscribe = nil
+	}
+	if nc.titleBadge != nil {
+		nc.titleBadge.Destroy()
+		nc.titleBadge = nil
+	}
 	nc.dropdown.Destroy()
+	nc.listeners.release()
+	nc.itemFuncs.release()
 }
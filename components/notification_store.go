@@ -0,0 +1,473 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/fetch"
+	"github.com/dougbarrett/gux/state"
+	"github.com/dougbarrett/gux/ws"
+)
+
+// Live update events, sent as SSE event names or WebSocket message types
+const (
+	NotificationEventNew    = "notification"        // a Notification; replaces one with the same ID
+	NotificationEventRead   = "notification.read"   // {"ids": [...]}; no IDs means all
+	NotificationEventUnread = "notification.unread" // {"count": 3}
+	NotificationEventClear  = "notification.clear"
+)
+
+// NotificationStoreConfig configures a NotificationStore
+type NotificationStoreConfig struct {
+	// Endpoint serves the history and records reads:
+	//
+	//	GET    {Endpoint}?limit=20&before=<cursor>  a page, newest first
+	//	POST   {Endpoint}/read                      {"ids": ["1", "2"]}
+	//	POST   {Endpoint}/read-all
+	//	DELETE {Endpoint}
+	//
+	// A page is {"notifications": [...], "unread": 12, "next": "<cursor>"} or
+	// a bare array. Without "next", the last ID is the cursor and a full page
+	// means there is more.
+	Endpoint string
+
+	// StreamURL is a Server-Sent Events endpoint for live updates. The
+	// browser reconnects on its own; the first page is reloaded after a
+	// reconnect to catch up. EventSource cannot send headers, so it relies on
+	// cookies.
+	StreamURL string
+
+	// WebSocket receives live updates instead of SSE, as messages whose type
+	// is one of the NotificationEvent names. Connecting it is up to the app.
+	WebSocket *ws.Client
+
+	PageSize     int               // default 20
+	Headers      map[string]string // sent with every request
+	AuthProvider func() string     // Authorization header value, read per request
+	OnError      func(err error)   // failed loads and reads
+}
+
+// NotificationState is a snapshot of a NotificationStore
+type NotificationState struct {
+	Notifications []Notification // newest first
+	Unread        int            // the server's count when it reports one
+	HasMore       bool           // older notifications can be loaded
+	Loading       bool
+	Connected     bool // the SSE stream is open
+}
+
+// NotificationStore keeps notifications in sync with a server: it pages
+// through the history, applies live updates from SSE or a WebSocket, and
+// records reads. Pass it to NotificationCenterProps.Store to drive the bell.
+type NotificationStore struct {
+	config NotificationStoreConfig
+	store  *state.Store[NotificationState]
+
+	mu        sync.Mutex
+	cursor    string
+	loading   bool
+	closed    bool
+	started   bool // Start ran and Close hasn't since
+	wsBound   bool // the WebSocket handlers are registered
+	source    js.Value
+	funcs     []js.Func
+	reconnect bool
+}
+
+// NewNotificationStore creates a NotificationStore. Call Start to load and
+// connect.
+func NewNotificationStore(config NotificationStoreConfig) *NotificationStore {
+	if config.PageSize <= 0 {
+		config.PageSize = 20
+	}
+	return &NotificationStore{
+		config: config,
+		store:  state.New(NotificationState{}),
+	}
+}
+
+// Start loads the first page and opens the live stream. Calling it again
+// before Close does nothing.
+func (s *NotificationStore) Start() {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started, s.closed, s.reconnect = true, false, false
+	bind := !s.wsBound && s.config.WebSocket != nil
+	s.wsBound = s.wsBound || bind
+	s.mu.Unlock()
+
+	s.Refresh()
+	if s.config.StreamURL != "" {
+		s.openStream()
+	}
+	// Handlers can't be removed from the client, so they are added once and
+	// ignore updates while the store is closed
+	if c := s.config.WebSocket; bind {
+		c.On(NotificationEventNew, func(data json.RawMessage) { s.apply(NotificationEventNew, data) })
+		c.On(NotificationEventRead, func(data json.RawMessage) { s.apply(NotificationEventRead, data) })
+		c.On(NotificationEventUnread, func(data json.RawMessage) { s.apply(NotificationEventUnread, data) })
+		c.On(NotificationEventClear, func(data json.RawMessage) { s.apply(NotificationEventClear, data) })
+	}
+}
+
+// Close stops live updates. WebSocket handlers stay registered on the
+// client but are ignored until Start is called again.
+func (s *NotificationStore) Close() {
+	s.mu.Lock()
+	s.closed, s.started = true, false
+	source, funcs := s.source, s.funcs
+	s.source, s.funcs = js.Undefined(), nil
+	s.mu.Unlock()
+
+	if source.Truthy() {
+		source.Call("close")
+	}
+	for _, fn := range funcs {
+		fn.Release()
+	}
+	s.store.Update(func(st *NotificationState) { st.Connected = false })
+}
+
+// State returns the current state
+func (s *NotificationStore) State() NotificationState {
+	return s.store.Get()
+}
+
+// Unread returns the number of unread notifications
+func (s *NotificationStore) Unread() int {
+	return s.store.Get().Unread
+}
+
+// Subscribe calls fn on every change. Returns an unsubscribe function.
+func (s *NotificationStore) Subscribe(fn func(NotificationState)) func() {
+	return s.store.Subscribe(fn)
+}
+
+// Refresh reloads the first page, replacing the loaded history
+func (s *NotificationStore) Refresh() {
+	s.load(true)
+}
+
+// LoadMore appends the next page of older notifications
+func (s *NotificationStore) LoadMore() {
+	if !s.store.Get().HasMore {
+		return
+	}
+	s.load(false)
+}
+
+// Add inserts or replaces a notification locally, as a live update would
+func (s *NotificationStore) Add(n Notification) {
+	s.store.Update(func(st *NotificationState) {
+		for i, existing := range st.Notifications {
+			if existing.ID == n.ID {
+				if existing.Read && !n.Read {
+					st.Unread++
+				} else if !existing.Read && n.Read && st.Unread > 0 {
+					st.Unread--
+				}
+				list := append([]Notification(nil), st.Notifications...)
+				list[i] = n
+				st.Notifications = list
+				return
+			}
+		}
+		st.Notifications = append([]Notification{n}, st.Notifications...)
+		if !n.Read {
+			st.Unread++
+		}
+	})
+}
+
+// MarkRead marks notifications read, locally at once and then on the server.
+// If the server rejects it, the history is reloaded.
+func (s *NotificationStore) MarkRead(ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	s.markRead(ids)
+	s.send("POST", "/read", map[string][]string{"ids": ids})
+}
+
+// MarkAllRead marks every notification read, including ones not loaded yet
+func (s *NotificationStore) MarkAllRead() {
+	s.markRead(nil)
+	s.send("POST", "/read-all", nil)
+}
+
+// Clear deletes all notifications
+func (s *NotificationStore) Clear() {
+	s.clear()
+	s.send("DELETE", "", nil)
+}
+
+func (s *NotificationStore) markRead(ids []string) {
+	s.store.Update(func(st *NotificationState) {
+		if ids == nil {
+			st.Unread = 0
+		}
+		marked := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			marked[id] = true
+		}
+		list := make([]Notification, len(st.Notifications))
+		for i, n := range st.Notifications {
+			if !n.Read && (ids == nil || marked[n.ID]) {
+				n.Read = true
+				if ids != nil && st.Unread > 0 {
+					st.Unread--
+				}
+			}
+			list[i] = n
+		}
+		st.Notifications = list
+	})
+}
+
+func (s *NotificationStore) clear() {
+	s.mu.Lock()
+	s.cursor = ""
+	s.mu.Unlock()
+	s.store.Update(func(st *NotificationState) {
+		st.Notifications, st.Unread, st.HasMore = nil, 0, false
+	})
+}
+
+func (s *NotificationStore) load(first bool) {
+	if s.config.Endpoint == "" {
+		return
+	}
+	s.mu.Lock()
+	if s.loading && !first {
+		s.mu.Unlock()
+		return
+	}
+	s.loading = true
+	cursor := s.cursor
+	if first {
+		cursor = ""
+	}
+	s.mu.Unlock()
+	s.store.Update(func(st *NotificationState) { st.Loading = true })
+
+	go func() {
+		query := url.Values{"limit": {strconv.Itoa(s.config.PageSize)}}
+		if cursor != "" {
+			query.Set("before", cursor)
+		}
+		page, err := s.fetchPage(s.config.Endpoint + "?" + query.Encode())
+
+		s.mu.Lock()
+		s.loading = false
+		if err == nil {
+			s.cursor = page.Next
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			s.store.Update(func(st *NotificationState) { st.Loading = false })
+			s.fail(err)
+			return
+		}
+		s.store.Update(func(st *NotificationState) {
+			st.Loading = false
+			st.HasMore = page.Next != ""
+			if first {
+				st.Notifications = page.Notifications
+			} else {
+				st.Notifications = appendNew(st.Notifications, page.Notifications)
+			}
+			if page.Unread != nil {
+				st.Unread = *page.Unread
+			} else if first {
+				st.Unread = 0
+				for _, n := range st.Notifications {
+					if !n.Read {
+						st.Unread++
+					}
+				}
+			}
+		})
+	}()
+}
+
+type notificationPage struct {
+	Notifications []Notification
+	Unread        *int
+	Next          string
+}
+
+func (s *NotificationStore) fetchPage(u string) (notificationPage, error) {
+	var page notificationPage
+	resp, err := fetch.Fetch(u, &fetch.Options{Headers: s.headers()})
+	if err != nil {
+		return page, err
+	}
+	if !resp.OK {
+		return page, fmt.Errorf("notifications: GET %s: status %d", u, resp.Status)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(resp.Body), "[") {
+		err = json.Unmarshal([]byte(resp.Body), &page.Notifications)
+	} else {
+		err = json.Unmarshal([]byte(resp.Body), &page)
+	}
+	if err != nil {
+		return page, fmt.Errorf("notifications: decode page: %w", err)
+	}
+	if page.Next == "" && len(page.Notifications) >= s.config.PageSize {
+		page.Next = page.Notifications[len(page.Notifications)-1].ID
+	}
+	return page, nil
+}
+
+// appendNew appends the notifications of an older page, skipping ones that
+// arrived live in the meantime
+func appendNew(list, page []Notification) []Notification {
+	seen := make(map[string]bool, len(list))
+	for _, n := range list {
+		seen[n.ID] = true
+	}
+	for _, n := range page {
+		if !seen[n.ID] {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// send records a change on the server and resyncs if it fails
+func (s *NotificationStore) send(method, path string, body any) {
+	if s.config.Endpoint == "" {
+		return
+	}
+	go func() {
+		opts := &fetch.Options{Method: method, Headers: s.headers()}
+		if body != nil {
+			data, err := json.Marshal(body)
+			if err != nil {
+				s.fail(err)
+				return
+			}
+			opts.Body = string(data)
+			opts.Headers["Content-Type"] = "application/json"
+		}
+		resp, err := fetch.Fetch(s.config.Endpoint+path, opts)
+		if err == nil && !resp.OK {
+			err = fmt.Errorf("notifications: %s %s: status %d", method, s.config.Endpoint+path, resp.Status)
+		}
+		if err != nil {
+			s.fail(err)
+			s.Refresh()
+		}
+	}()
+}
+
+func (s *NotificationStore) headers() map[string]string {
+	headers := make(map[string]string, len(s.config.Headers)+1)
+	for k, v := range s.config.Headers {
+		headers[k] = v
+	}
+	if s.config.AuthProvider != nil {
+		if auth := s.config.AuthProvider(); auth != "" {
+			headers["Authorization"] = auth
+		}
+	}
+	return headers
+}
+
+func (s *NotificationStore) fail(err error) {
+	if s.config.OnError != nil {
+		s.config.OnError(err)
+	}
+}
+
+func (s *NotificationStore) openStream() {
+	opts := js.Global().Get("Object").New()
+	opts.Set("withCredentials", true)
+	source := js.Global().Get("EventSource").New(s.config.StreamURL, opts)
+
+	var funcs []js.Func
+	listen := func(event string, fn func(js.Value)) {
+		f := js.FuncOf(func(this js.Value, args []js.Value) any {
+			fn(args[0])
+			return nil
+		})
+		source.Call("addEventListener", event, f)
+		funcs = append(funcs, f)
+	}
+
+	for _, event := range []string{NotificationEventNew, NotificationEventRead, NotificationEventUnread, NotificationEventClear} {
+		event := event
+		listen(event, func(e js.Value) { s.apply(event, json.RawMessage(e.Get("data").String())) })
+	}
+	// Unnamed events carry new notifications
+	listen("message", func(e js.Value) { s.apply(NotificationEventNew, json.RawMessage(e.Get("data").String())) })
+
+	listen("open", func(js.Value) {
+		s.mu.Lock()
+		reconnect := s.reconnect
+		s.reconnect = true
+		s.mu.Unlock()
+
+		s.store.Update(func(st *NotificationState) { st.Connected = true })
+		if reconnect {
+			s.Refresh() // catch up on events missed while disconnected
+		}
+	})
+	listen("error", func(js.Value) {
+		s.store.Update(func(st *NotificationState) { st.Connected = false })
+		if source.Get("readyState").Int() == 2 {
+			s.fail(errors.New("notifications: stream closed"))
+		}
+	})
+
+	s.mu.Lock()
+	s.source, s.funcs = source, funcs
+	s.mu.Unlock()
+}
+
+// apply handles one live update
+func (s *NotificationStore) apply(event string, data json.RawMessage) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return
+	}
+
+	switch event {
+	case NotificationEventNew:
+		var n Notification
+		if err := json.Unmarshal(data, &n); err != nil || n.ID == "" {
+			return
+		}
+		s.Add(n)
+	case NotificationEventRead:
+		var body struct{ IDs []string }
+		json.Unmarshal(data, &body)
+		if len(body.IDs) == 0 {
+			s.markRead(nil)
+		} else {
+			s.markRead(body.IDs)
+		}
+	case NotificationEventUnread:
+		var body struct{ Count int }
+		if err := json.Unmarshal(data, &body); err == nil {
+			s.store.Update(func(st *NotificationState) { st.Unread = body.Count })
+		}
+	case NotificationEventClear:
+		s.clear()
+	}
+}
@@ -0,0 +1,55 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+)
+
+// stubEventSource replaces EventSource with one that counts the streams
+// opened and closed
+func stubEventSource(t *testing.T) (opened, closed *int) {
+	opened, closed = new(int), new(int)
+	noop := js.FuncOf(func(this js.Value, args []js.Value) any { return nil })
+	closeFn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		*closed++
+		return nil
+	})
+	ctor := js.FuncOf(func(this js.Value, args []js.Value) any {
+		*opened++
+		this.Set("addEventListener", noop)
+		this.Set("close", closeFn)
+		this.Set("readyState", 0)
+		return nil
+	})
+	previous := js.Global().Get("EventSource")
+	js.Global().Set("EventSource", ctor)
+	t.Cleanup(func() {
+		js.Global().Set("EventSource", previous)
+		ctor.Release()
+		closeFn.Release()
+		noop.Release()
+	})
+	return opened, closed
+}
+
+func TestNotificationStoreStartTwice(t *testing.T) {
+	opened, closed := stubEventSource(t)
+	store := components.NewNotificationStore(components.NotificationStoreConfig{StreamURL: "/notifications/stream"})
+
+	store.Start()
+	store.Start()
+	if *opened != 1 {
+		t.Errorf("opened %d streams, want 1", *opened)
+	}
+
+	store.Close()
+	store.Start()
+	store.Close()
+	if *opened != 2 || *closed != 2 {
+		t.Errorf("opened %d and closed %d streams, want 2 and 2", *opened, *closed)
+	}
+}
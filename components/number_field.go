@@ -0,0 +1,319 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// NumberFieldProps configures a NumberField component
+type NumberFieldProps struct {
+	Label       string
+	Name        string // Sets the input name and id
+	Value       float64
+	Min         float64
+	Max         float64 // Bounds are enforced only when Max > Min
+	Step        float64 // Defaults to 1
+	Precision   int     // Decimal places shown and stored
+	Prefix      string  // e.g. "$"
+	Suffix      string  // e.g. "kg", "%"
+	Placeholder string
+	ClassName   string
+	Disabled    bool
+	OnChange    func(value float64)
+}
+
+// NumberField creates a numeric field with increment/decrement buttons.
+// Supports ArrowUp/ArrowDown (Shift for 10x), PageUp/PageDown, Home/End,
+// and mouse-wheel stepping while focused.
+type NumberField struct {
+	container js.Value
+	input     js.Value
+	decBtn    js.Value
+	incBtn    js.Value
+	props     NumberFieldProps
+	value     float64
+	listeners listeners
+}
+
+// NewNumberField creates a new NumberField component
+func NewNumberField(props NumberFieldProps) *NumberField {
+	document := js.Global().Get("document")
+	crypto := js.Global().Get("crypto")
+
+	if props.Step <= 0 {
+		props.Step = 1
+	}
+	if props.Precision < 0 {
+		props.Precision = 0
+	}
+
+	n := &NumberField{props: props}
+
+	container := document.Call("createElement", "div")
+	className := "mb-4"
+	if props.ClassName != "" {
+		className = props.ClassName
+	}
+	container.Set("className", className)
+	n.container = container
+
+	inputID := props.Name
+	if inputID == "" {
+		inputID = "number-" + crypto.Call("randomUUID").String()
+	}
+
+	if props.Label != "" {
+		label := document.Call("createElement", "label")
+		label.Set("className", "block text-sm font-medium text-secondary mb-1")
+		label.Set("textContent", props.Label)
+		label.Set("htmlFor", inputID)
+		container.Call("appendChild", label)
+	}
+
+	group := document.Call("createElement", "div")
+	groupClass := "flex items-stretch border border-default surface-base rounded-md shadow-sm overflow-hidden focus-within:ring-2 focus-within:ring-blue-500 focus-within:border-blue-500"
+	if props.Disabled {
+		groupClass += " surface-overlay cursor-not-allowed opacity-60"
+	}
+	group.Set("className", groupClass)
+
+	n.decBtn = n.stepButton("−", "Decrease value", -1)
+	group.Call("appendChild", n.decBtn)
+
+	if props.Prefix != "" {
+		group.Call("appendChild", numberAdornment(props.Prefix))
+	}
+
+	input := document.Call("createElement", "input")
+	input.Set("type", "text")
+	input.Set("id", inputID)
+	if props.Name != "" {
+		input.Set("name", props.Name)
+	}
+	input.Set("className", "flex-1 min-w-0 px-3 py-2 bg-transparent text-primary text-right tabular-nums focus:outline-none placeholder:text-tertiary")
+	input.Call("setAttribute", "inputmode", "decimal")
+	input.Call("setAttribute", "role", "spinbutton")
+	if props.Max > props.Min {
+		input.Call("setAttribute", "aria-valuemin", props.Min)
+		input.Call("setAttribute", "aria-valuemax", props.Max)
+	}
+	if props.Placeholder != "" {
+		input.Set("placeholder", props.Placeholder)
+	}
+	if props.Disabled {
+		input.Set("disabled", true)
+	}
+	group.Call("appendChild", input)
+	n.input = input
+
+	if props.Suffix != "" {
+		group.Call("appendChild", numberAdornment(props.Suffix))
+	}
+
+	n.incBtn = n.stepButton("+", "Increase value", 1)
+	group.Call("appendChild", n.incBtn)
+
+	container.Call("appendChild", group)
+
+	n.value = n.normalize(props.Value)
+	n.render()
+
+	input.Call("addEventListener", "keydown", n.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		multiplier := 1.0
+		if event.Get("shiftKey").Bool() {
+			multiplier = 10
+		}
+
+		switch event.Get("key").String() {
+		case "ArrowUp":
+			event.Call("preventDefault")
+			n.step(multiplier)
+		case "ArrowDown":
+			event.Call("preventDefault")
+			n.step(-multiplier)
+		case "PageUp":
+			event.Call("preventDefault")
+			n.step(10)
+		case "PageDown":
+			event.Call("preventDefault")
+			n.step(-10)
+		case "Home":
+			if n.props.Max > n.props.Min {
+				event.Call("preventDefault")
+				n.set(n.props.Min)
+			}
+		case "End":
+			if n.props.Max > n.props.Min {
+				event.Call("preventDefault")
+				n.set(n.props.Max)
+			}
+		case "Enter":
+			n.commit()
+		}
+		return nil
+	}))
+
+	input.Call("addEventListener", "blur", n.listeners.fn(func(this js.Value, args []js.Value) any {
+		n.commit()
+		return nil
+	}))
+
+	// Wheel stepping only applies while focused so page scrolling is unaffected
+	input.Call("addEventListener", "wheel", n.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if !document.Get("activeElement").Equal(input) {
+			return nil
+		}
+		event.Call("preventDefault")
+		if event.Get("deltaY").Float() < 0 {
+			n.step(1)
+		} else {
+			n.step(-1)
+		}
+		return nil
+	}), map[string]any{"passive": false})
+
+	onUnmount(n.container, n.Unmount)
+	return n
+}
+
+func (n *NumberField) stepButton(text, ariaLabel string, direction float64) js.Value {
+	document := js.Global().Get("document")
+
+	btn := document.Call("createElement", "button")
+	btn.Set("type", "button")
+	btn.Set("className", "px-3 text-secondary hover:bg-gray-100 dark:hover:bg-gray-700 disabled:opacity-40 disabled:cursor-not-allowed cursor-pointer select-none")
+	btn.Set("textContent", text)
+	btn.Set("tabIndex", -1)
+	btn.Call("setAttribute", "aria-label", ariaLabel)
+	if n.props.Disabled {
+		btn.Set("disabled", true)
+	}
+
+	btn.Call("addEventListener", "click", n.listeners.fn(func(this js.Value, args []js.Value) any {
+		n.step(direction)
+		return nil
+	}))
+
+	return btn
+}
+
+func numberAdornment(text string) js.Value {
+	document := js.Global().Get("document")
+	span := document.Call("createElement", "span")
+	span.Set("className", "flex items-center px-2 text-sm text-tertiary select-none")
+	span.Set("textContent", text)
+	span.Call("setAttribute", "aria-hidden", "true")
+	return span
+}
+
+// normalize rounds to the configured precision and clamps to the bounds
+func (n *NumberField) normalize(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		value = 0
+	}
+	scale := math.Pow(10, float64(n.props.Precision))
+	value = math.Round(value*scale) / scale
+	if n.props.Max > n.props.Min {
+		value = math.Max(n.props.Min, math.Min(n.props.Max, value))
+	}
+	return value
+}
+
+func (n *NumberField) format(value float64) string {
+	return strconv.FormatFloat(value, 'f', n.props.Precision, 64)
+}
+
+func (n *NumberField) render() {
+	n.input.Set("value", n.format(n.value))
+	n.input.Call("setAttribute", "aria-valuenow", n.value)
+	n.input.Call("setAttribute", "aria-valuetext", strings.TrimSpace(n.props.Prefix+n.format(n.value)+" "+n.props.Suffix))
+
+	if n.props.Disabled {
+		return
+	}
+	bounded := n.props.Max > n.props.Min
+	n.decBtn.Set("disabled", bounded && n.value <= n.props.Min)
+	n.incBtn.Set("disabled", bounded && n.value >= n.props.Max)
+}
+
+func (n *NumberField) set(value float64) {
+	value = n.normalize(value)
+	changed := value != n.value
+	n.value = value
+	n.render()
+	if changed && n.props.OnChange != nil {
+		n.props.OnChange(value)
+	}
+}
+
+func (n *NumberField) step(multiplier float64) {
+	if n.props.Disabled {
+		return
+	}
+	// Parse any pending typed text first so stepping starts from what the user sees
+	n.commit()
+	n.set(n.value + n.props.Step*multiplier)
+}
+
+// commit parses the typed text, restoring the last valid value if it is not a number
+func (n *NumberField) commit() {
+	raw := strings.TrimSpace(n.input.Get("value").String())
+	raw = strings.TrimPrefix(raw, n.props.Prefix)
+	raw = strings.TrimSuffix(raw, n.props.Suffix)
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), ",", "")
+
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		n.render()
+		return
+	}
+	n.set(parsed)
+}
+
+// Element returns the container DOM element
+func (n *NumberField) Element() js.Value {
+	return n.container
+}
+
+// Mount appends the input to parent
+func (n *NumberField) Mount(parent js.Value) {
+	parent.Call("appendChild", n.container)
+}
+
+// Unmount removes the input and releases its listeners
+func (n *NumberField) Unmount() {
+	unmount(n.container)
+	n.listeners.release()
+}
+
+// Value returns the current numeric value
+func (n *NumberField) Value() float64 {
+	return n.value
+}
+
+// SetValue sets the value, applying precision and bounds without firing OnChange
+func (n *NumberField) SetValue(value float64) {
+	n.value = n.normalize(value)
+	n.render()
+}
+
+// Increment increases the value by one step
+func (n *NumberField) Increment() {
+	n.step(1)
+}
+
+// Decrement decreases the value by one step
+func (n *NumberField) Decrement() {
+	n.step(-1)
+}
+
+// Focus sets focus on the input
+func (n *NumberField) Focus() {
+	n.input.Call("focus")
+}
@@ -5,6 +5,8 @@ package components
 import (
 	"fmt"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
 // PaginationProps configures a Pagination component
@@ -14,7 +16,7 @@ type PaginationProps struct {
 	TotalItems   int
 	ItemsPerPage int
 	OnPageChange func(page int)
-	ShowInfo     bool // Show "Showing X-Y of Z items"
+	ShowInfo     bool // Show "Showing X-Y of Z items" (gux.pagination.showing)
 	MaxVisible   int  // Max page buttons to show (default 5)
 }
 
@@ -22,6 +24,7 @@ type PaginationProps struct {
 type Pagination struct {
 	container js.Value
 	props     PaginationProps
+	listeners listeners // of the current render
 }
 
 // NewPagination creates a new Pagination component
@@ -39,6 +42,7 @@ func NewPagination(props PaginationProps) *Pagination {
 }
 
 func (p *Pagination) render() {
+	p.listeners.release()
 	document := js.Global().Get("document")
 	container := document.Call("createElement", "div")
 	container.Set("className", "flex items-center justify-between")
@@ -52,7 +56,7 @@ func (p *Pagination) render() {
 		if end > p.props.TotalItems {
 			end = p.props.TotalItems
 		}
-		info.Set("textContent", fmt.Sprintf("Showing %d-%d of %d items", start, end, p.props.TotalItems))
+		info.Set("textContent", i18n.T("gux.pagination.showing", i18n.FormatInt(start), i18n.FormatInt(end), i18n.FormatInt(p.props.TotalItems)))
 		container.Call("appendChild", info)
 	}
 
@@ -60,10 +64,10 @@ func (p *Pagination) render() {
 	nav := document.Call("createElement", "nav")
 	nav.Set("className", "flex items-center gap-1")
 	nav.Set("role", "navigation")
-	nav.Set("aria-label", "Pagination")
+	nav.Set("aria-label", i18n.T("gux.pagination.label"))
 
 	// Previous button
-	prevBtn := p.createNavButton("←", "Previous", p.props.CurrentPage > 1, func() {
+	prevBtn := p.createNavButton("←", i18n.T("gux.pagination.previous"), p.props.CurrentPage > 1, func() {
 		if p.props.OnPageChange != nil && p.props.CurrentPage > 1 {
 			p.props.OnPageChange(p.props.CurrentPage - 1)
 		}
@@ -86,7 +90,7 @@ func (p *Pagination) render() {
 	}
 
 	// Next button
-	nextBtn := p.createNavButton("→", "Next", p.props.CurrentPage < p.props.TotalPages, func() {
+	nextBtn := p.createNavButton("→", i18n.T("gux.pagination.next"), p.props.CurrentPage < p.props.TotalPages, func() {
 		if p.props.OnPageChange != nil && p.props.CurrentPage < p.props.TotalPages {
 			p.props.OnPageChange(p.props.CurrentPage + 1)
 		}
@@ -94,6 +98,13 @@ func (p *Pagination) render() {
 	nav.Call("appendChild", nextBtn)
 
 	container.Call("appendChild", nav)
+	onUnmount(container, p.Unmount)
+	if p.container.Truthy() {
+		forget(p.container)
+		if p.container.Get("parentNode").Truthy() {
+			p.container.Call("replaceWith", container)
+		}
+	}
 	p.container = container
 }
 
@@ -114,7 +125,7 @@ func (p *Pagination) createNavButton(text, label string, enabled bool, onClick f
 	btn.Set("disabled", !enabled)
 
 	if enabled {
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		btn.Call("addEventListener", "click", p.listeners.fn(func(this js.Value, args []js.Value) any {
 			onClick()
 			return nil
 		}))
@@ -143,7 +154,7 @@ func (p *Pagination) createPageButton(page int) js.Value {
 
 	if !isCurrent && p.props.OnPageChange != nil {
 		pageNum := page
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		btn.Call("addEventListener", "click", p.listeners.fn(func(this js.Value, args []js.Value) any {
 			p.props.OnPageChange(pageNum)
 			return nil
 		}))
@@ -206,6 +217,17 @@ func (p *Pagination) Element() js.Value {
 	return p.container
 }
 
+// Mount appends the pagination to parent
+func (p *Pagination) Mount(parent js.Value) {
+	parent.Call("appendChild", p.container)
+}
+
+// Unmount removes the pagination and releases its listeners
+func (p *Pagination) Unmount() {
+	unmount(p.container)
+	p.listeners.release()
+}
+
 // SetPage updates the current page and re-renders
 func (p *Pagination) SetPage(page int) {
 	p.props.CurrentPage = page
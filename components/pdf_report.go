@@ -0,0 +1,448 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+// PDFReportOptions configures a PDFReport
+type PDFReportOptions struct {
+	Orientation string    // "portrait" or "landscape" (default: "portrait")
+	PageSize    string    // "a4" or "letter" (default: "a4")
+	Margin      float64   // Page margin in mm (default 14)
+	Header      string    // Text at the top of every page; {page} and {pages} are replaced
+	Footer      string    // Text at the bottom of every page; {page} and {pages} are replaced
+	PageNumbers bool      // Show "Page 1 of 3" at the bottom right of every page
+	Font        string    // Font family of all text, e.g. one in Fonts (default "helvetica")
+	Fonts       []PDFFont // TrueType fonts to embed
+}
+
+// PDFFont is a TrueType font embedded in a PDFReport, e.g. for scripts the
+// standard PDF fonts don't cover
+type PDFFont struct {
+	Name  string // Family name, as used in PDFReportOptions.Font
+	URL   string // .ttf file, fetched when the report is built
+	Style string // "normal", "bold", "italic", or "bolditalic" (default "normal")
+}
+
+// PDFTableOptions configures a table in a PDFReport
+type PDFTableOptions struct {
+	GroupBy string   // Data key to group rows under a heading row per value
+	Foot    []string // Footer row, e.g. totals
+}
+
+// PDFReport builds a multi-page PDF with jsPDF and jsPDF-AutoTable:
+//
+//	report := components.NewPDFReport(components.PDFReportOptions{
+//		Footer:      "ACME Inc.",
+//		PageNumbers: true,
+//	})
+//	report.Title("Invoice 1042", "Issued March 14, 2024").
+//		Text("Bill to: Jane Doe, 1 Main St").
+//		Table(headers, keys, lines, components.PDFTableOptions{Foot: totals}).
+//		Heading("Usage").
+//		Chart(usageChart, 120, 0)
+//	go func() {
+//		if err := report.Save("invoice-1042.pdf"); err != nil {
+//			components.ShowError(err.Error())
+//		}
+//	}()
+//
+// Blocks are laid out top to bottom, and a new page is started when one
+// doesn't fit. Save and Bytes wait for fonts and charts to load, so call
+// them from a goroutine.
+type PDFReport struct {
+	opts   PDFReportOptions
+	blocks []func(r *pdfLayout) error
+}
+
+// pdfLayout is the state of a report being built
+type pdfLayout struct {
+	opts         PDFReportOptions
+	doc          js.Value
+	width        float64 // page width in mm
+	top, bottom  float64 // content area
+	contentWidth float64
+	y            float64 // next block's top
+}
+
+// NewPDFReport creates an empty report
+func NewPDFReport(opts PDFReportOptions) *PDFReport {
+	if opts.Orientation == "" {
+		opts.Orientation = "portrait"
+	}
+	if opts.PageSize == "" {
+		opts.PageSize = "a4"
+	}
+	if opts.Margin <= 0 {
+		opts.Margin = 14
+	}
+	if opts.Font == "" {
+		opts.Font = "helvetica"
+	}
+	return &PDFReport{opts: opts}
+}
+
+// Title adds a large title with an optional subtitle and a rule below
+func (r *PDFReport) Title(title, subtitle string) *PDFReport {
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		height := 12.0
+		if subtitle != "" {
+			height += 6
+		}
+		l.ensure(height)
+		l.font("bold", 20, 17, 24, 39)
+		l.y += 8
+		l.doc.Call("text", title, l.opts.Margin, l.y)
+		if subtitle != "" {
+			l.font("normal", 11, 107, 114, 128)
+			l.y += 6
+			l.doc.Call("text", subtitle, l.opts.Margin, l.y)
+		}
+		l.y += 4
+		l.doc.Call("setDrawColor", 229, 231, 235)
+		l.doc.Call("line", l.opts.Margin, l.y, l.width-l.opts.Margin, l.y)
+		l.y += 6
+		return nil
+	})
+	return r
+}
+
+// Heading adds a section heading, kept on the same page as what follows it
+func (r *PDFReport) Heading(text string) *PDFReport {
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		l.ensure(20) // the heading and the start of its section
+		l.y += 4
+		l.font("bold", 14, 17, 24, 39)
+		l.y += 5
+		l.doc.Call("text", text, l.opts.Margin, l.y)
+		l.y += 4
+		return nil
+	})
+	return r
+}
+
+// Text adds a paragraph, wrapped to the page width
+func (r *PDFReport) Text(text string) *PDFReport {
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		const lineHeight = 5
+		l.font("normal", 10, 55, 65, 81)
+		lines := l.doc.Call("splitTextToSize", text, l.contentWidth)
+		for i := 0; i < lines.Length(); i++ {
+			l.ensure(lineHeight)
+			l.y += lineHeight
+			l.doc.Call("text", lines.Index(i), l.opts.Margin, l.y-1)
+		}
+		l.y += 3
+		return nil
+	})
+	return r
+}
+
+// Table adds a table, continued on the next page with its header repeated
+// when it's too long. headers are the column display names, keys are the
+// data field keys.
+func (r *PDFReport) Table(headers []string, keys []string, data []map[string]any, options ...PDFTableOptions) *PDFReport {
+	var opts PDFTableOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		row := func(item map[string]any) []any {
+			cells := make([]any, len(keys))
+			for j, key := range keys {
+				if value := item[key]; value != nil {
+					cells[j] = toString(value)
+				} else {
+					cells[j] = ""
+				}
+			}
+			return cells
+		}
+
+		body := make([]any, 0, len(data))
+		if opts.GroupBy == "" {
+			for _, item := range data {
+				body = append(body, row(item))
+			}
+		} else {
+			// Rows under a heading row per group, in order of first appearance
+			var groups []string
+			rows := make(map[string][]any)
+			for _, item := range data {
+				group := toString(item[opts.GroupBy])
+				if _, ok := rows[group]; !ok {
+					groups = append(groups, group)
+				}
+				rows[group] = append(rows[group], row(item))
+			}
+			for _, group := range groups {
+				body = append(body, []any{map[string]any{
+					"content": group,
+					"colSpan": len(keys),
+					"styles":  map[string]any{"fontStyle": "bold", "fillColor": []any{243, 244, 246}},
+				}})
+				body = append(body, rows[group]...)
+			}
+		}
+
+		head := make([]any, len(headers))
+		for i, h := range headers {
+			head[i] = h
+		}
+		table := map[string]any{
+			"head":   []any{head},
+			"body":   body,
+			"startY": l.y,
+			"margin": map[string]any{"top": l.top, "bottom": l.pageHeight() - l.bottom, "left": l.opts.Margin, "right": l.opts.Margin},
+			"styles": map[string]any{"font": l.opts.Font, "fontSize": 9},
+		}
+		if len(opts.Foot) > 0 {
+			foot := make([]any, len(opts.Foot))
+			for i, f := range opts.Foot {
+				foot[i] = f
+			}
+			table["foot"] = []any{foot}
+		}
+		if !l.doc.Get("autoTable").Truthy() {
+			return errors.New("jsPDF-AutoTable not loaded")
+		}
+		l.doc.Call("autoTable", js.ValueOf(table))
+		l.y = l.doc.Get("lastAutoTable").Get("finalY").Float() + 6
+		return nil
+	})
+	return r
+}
+
+// Chart adds an image of an SVG chart, such as a LineChart or PieChart, or
+// any element containing an SVG. width is in mm; a height of 0 keeps the
+// chart's aspect ratio. Only the SVG is drawn, not HTML around it such as a
+// PieChart's legend.
+func (r *PDFReport) Chart(chart js.Value, width, height float64) *PDFReport {
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		png, w, h, err := svgToPNG(chart)
+		if err != nil {
+			return err
+		}
+		width, height := min(width, l.contentWidth), height
+		if width <= 0 {
+			width = l.contentWidth
+		}
+		if height <= 0 {
+			height = width * h / w
+		}
+		l.ensure(height + 4)
+		l.doc.Call("addImage", png, "PNG", l.opts.Margin, l.y, width, height)
+		l.y += height + 4
+		return nil
+	})
+	return r
+}
+
+// PageBreak starts a new page
+func (r *PDFReport) PageBreak() *PDFReport {
+	r.blocks = append(r.blocks, func(l *pdfLayout) error {
+		l.newPage()
+		return nil
+	})
+	return r
+}
+
+// Save builds the report and downloads it. It blocks until fonts and
+// charts are loaded.
+func (r *PDFReport) Save(filename string) error {
+	doc, err := r.build()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(filename, ".pdf") {
+		filename += ".pdf"
+	}
+	doc.Call("save", filename)
+	return nil
+}
+
+// Bytes builds the report and returns the PDF, e.g. to upload it. It blocks
+// until fonts and charts are loaded.
+func (r *PDFReport) Bytes() ([]byte, error) {
+	doc, err := r.build()
+	if err != nil {
+		return nil, err
+	}
+	array := js.Global().Get("Uint8Array").New(doc.Call("output", "arraybuffer"))
+	data := make([]byte, array.Length())
+	js.CopyBytesToGo(data, array)
+	return data, nil
+}
+
+func (r *PDFReport) build() (js.Value, error) {
+	// jsPDF UMD exposes window.jspdf.jsPDF
+	jspdfModule := js.Global().Get("jspdf")
+	if !jspdfModule.Truthy() || !jspdfModule.Get("jsPDF").Truthy() {
+		return js.Undefined(), errors.New("jsPDF not loaded")
+	}
+	doc := jspdfModule.Get("jsPDF").New(js.ValueOf(map[string]any{
+		"orientation": r.opts.Orientation,
+		"unit":        "mm",
+		"format":      r.opts.PageSize,
+	}))
+
+	for _, font := range r.opts.Fonts {
+		if err := addPDFFont(doc, font); err != nil {
+			return js.Undefined(), err
+		}
+	}
+
+	l := &pdfLayout{opts: r.opts, doc: doc}
+	pageSize := doc.Get("internal").Get("pageSize")
+	l.width = pageSize.Call("getWidth").Float()
+	l.contentWidth = l.width - 2*r.opts.Margin
+	l.top = r.opts.Margin
+	if r.opts.Header != "" {
+		l.top += 8
+	}
+	l.bottom = l.pageHeight() - r.opts.Margin
+	if r.opts.Footer != "" || r.opts.PageNumbers {
+		l.bottom -= 8
+	}
+	l.y = l.top
+
+	for _, block := range r.blocks {
+		if err := block(l); err != nil {
+			return js.Undefined(), err
+		}
+	}
+	l.decorate()
+	return doc, nil
+}
+
+func (l *pdfLayout) pageHeight() float64 {
+	return l.doc.Get("internal").Get("pageSize").Call("getHeight").Float()
+}
+
+// ensure starts a new page unless height fits below y
+func (l *pdfLayout) ensure(height float64) {
+	if l.y+height > l.bottom && l.y > l.top {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) newPage() {
+	l.doc.Call("addPage")
+	l.y = l.top
+}
+
+// font sets the font style, size in points, and text color
+func (l *pdfLayout) font(style string, size float64, red, green, blue int) {
+	l.doc.Call("setFont", l.opts.Font, style)
+	l.doc.Call("setFontSize", size)
+	l.doc.Call("setTextColor", red, green, blue)
+}
+
+// decorate draws the header, footer, and page number on every page
+func (l *pdfLayout) decorate() {
+	pages := l.doc.Call("getNumberOfPages").Int()
+	height := l.pageHeight()
+	expand := func(s string, page int) string {
+		return strings.NewReplacer("{page}", strconv.Itoa(page), "{pages}", strconv.Itoa(pages)).Replace(s)
+	}
+	for page := 1; page <= pages; page++ {
+		l.doc.Call("setPage", page)
+		l.font("normal", 8, 107, 114, 128)
+		if l.opts.Header != "" {
+			l.doc.Call("text", expand(l.opts.Header, page), l.opts.Margin, l.opts.Margin)
+		}
+		footerY := height - l.opts.Margin + 2
+		if l.opts.Footer != "" {
+			l.doc.Call("text", expand(l.opts.Footer, page), l.opts.Margin, footerY)
+		}
+		if l.opts.PageNumbers {
+			l.doc.Call("text", i18n.T("gux.pdf.page", page, pages), l.width-l.opts.Margin, footerY, map[string]any{"align": "right"})
+		}
+	}
+}
+
+// addPDFFont fetches a TrueType font and registers it with doc
+func addPDFFont(doc js.Value, font PDFFont) error {
+	style := font.Style
+	if style == "" {
+		style = "normal"
+	}
+	resp, err := jsutil.Await(context.Background(), js.Global().Call("fetch", font.URL), promiseError)
+	if err != nil {
+		return errors.New("loading font " + font.Name + ": " + err.Error())
+	}
+	if !resp.Get("ok").Bool() {
+		return errors.New("loading font " + font.Name + ": " + resp.Get("status").String())
+	}
+	buffer, err := jsutil.Await(context.Background(), resp.Call("arrayBuffer"), promiseError)
+	if err != nil {
+		return errors.New("loading font " + font.Name + ": " + err.Error())
+	}
+	array := js.Global().Get("Uint8Array").New(buffer)
+	data := make([]byte, array.Length())
+	js.CopyBytesToGo(data, array)
+
+	file := font.Name + "-" + style + ".ttf"
+	doc.Call("addFileToVFS", file, base64.StdEncoding.EncodeToString(data))
+	doc.Call("addFont", file, font.Name, style)
+	return nil
+}
+
+// svgToPNG draws the SVG in el onto a canvas at twice its size, for
+// sharpness in print, and returns it as a PNG data URL with the SVG's size
+// in pixels
+func svgToPNG(el js.Value) (png string, width, height float64, err error) {
+	svg := el
+	if !strings.EqualFold(el.Get("tagName").String(), "svg") {
+		svg = el.Call("querySelector", "svg")
+	}
+	if !svg.Truthy() {
+		return "", 0, 0, errors.New("chart has no SVG")
+	}
+
+	// Size from the viewBox, or the rendered size
+	if viewBox := svg.Get("viewBox"); viewBox.Truthy() && viewBox.Get("baseVal").Truthy() {
+		width = viewBox.Get("baseVal").Get("width").Float()
+		height = viewBox.Get("baseVal").Get("height").Float()
+	}
+	if width <= 0 || height <= 0 {
+		rect := svg.Call("getBoundingClientRect")
+		width, height = rect.Get("width").Float(), rect.Get("height").Float()
+	}
+	if width <= 0 || height <= 0 {
+		return "", 0, 0, errors.New("chart has no size")
+	}
+
+	// An image needs explicit dimensions, not "100%"
+	clone := svg.Call("cloneNode", true)
+	clone.Call("setAttribute", "xmlns", "http://www.w3.org/2000/svg")
+	clone.Call("setAttribute", "width", width)
+	clone.Call("setAttribute", "height", height)
+	markup := js.Global().Get("XMLSerializer").New().Call("serializeToString", clone).String()
+
+	img := js.Global().Get("Image").New()
+	img.Set("src", "data:image/svg+xml;charset=utf-8,"+js.Global().Call("encodeURIComponent", markup).String())
+	if _, err := jsutil.Await(context.Background(), img.Call("decode"), promiseError); err != nil {
+		return "", 0, 0, errors.New("rendering chart: " + err.Error())
+	}
+
+	const scale = 2
+	canvas := js.Global().Get("document").Call("createElement", "canvas")
+	canvas.Set("width", width*scale)
+	canvas.Set("height", height*scale)
+	ctx := canvas.Call("getContext", "2d")
+	ctx.Set("fillStyle", "#ffffff") // the SVG background is transparent
+	ctx.Call("fillRect", 0, 0, width*scale, height*scale)
+	ctx.Call("drawImage", img, 0, 0, width*scale, height*scale)
+	return canvas.Call("toDataURL", "image/png").String(), width, height, nil
+}
@@ -0,0 +1,96 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/auth"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// DeniedBehavior sets what WithPermission does with an element the user
+// lacks the permission for. By default it is disabled, with a tooltip
+// saying why.
+type DeniedBehavior struct {
+	Hide       bool   // remove the element from view instead
+	Disable    bool   // disable it (the default unless Hide is set)
+	TooltipMsg string // why it is unavailable (default "You don't have permission to do this")
+}
+
+// WithPermission wraps node, such as a Button or menu item, so it is only
+// usable by users with permission (see auth.Can). Otherwise it is hidden
+// or disabled, as denied says, and follows the user as they log in and
+// out.
+//
+//	components.WithPermission(components.Button(components.ButtonProps{
+//		Text:    "Edit",
+//		OnClick: editPost,
+//	}), "posts:edit", components.DeniedBehavior{TooltipMsg: "Only editors can change posts"})
+//
+// A disabled element stays focusable, so keyboard and screen reader users
+// hear why, with aria-disabled set and clicks and Enter stopped before
+// they reach it. The check only decides what the UI offers; the server
+// must check too.
+func WithPermission(node js.Value, permission string, denied DeniedBehavior) js.Value {
+	msg := denied.TooltipMsg
+	if msg == "" {
+		msg = i18n.T("gux.permission.denied")
+	}
+
+	wrapper := El("span", "relative inline-flex")
+	tip := Div("absolute z-50 bottom-full left-1/2 -translate-x-1/2 mb-2 px-2 py-1 text-sm text-white bg-gray-900 rounded shadow-lg whitespace-nowrap pointer-events-none hidden")
+	tip.Set("id", "permission-"+js.Global().Get("crypto").Call("randomUUID").String())
+	tip.Call("setAttribute", "role", "tooltip")
+	tip.Set("textContent", msg)
+	wrapper.Call("appendChild", node)
+	wrapper.Call("appendChild", tip)
+
+	var l listeners
+	allowed := true
+
+	// Stop activation in the capture phase, before node's own listeners
+	block := l.fn(func(this js.Value, args []js.Value) any {
+		if !allowed {
+			args[0].Call("preventDefault")
+			args[0].Call("stopPropagation")
+		}
+		return nil
+	})
+	wrapper.Call("addEventListener", "click", block, true)
+	l.onRelease(func() {
+		wrapper.Call("removeEventListener", "click", block, true)
+	})
+
+	show := func(visible bool) {
+		tip.Get("classList").Call("toggle", "hidden", allowed || !visible)
+	}
+	for event, visible := range map[string]bool{"mouseenter": true, "focusin": true, "mouseleave": false, "focusout": false} {
+		visible := visible
+		l.on(wrapper, event, func(this js.Value, args []js.Value) any {
+			show(visible)
+			return nil
+		})
+	}
+
+	apply := func() {
+		allowed = auth.Can(permission)
+		hidden := !allowed && denied.Hide
+		wrapper.Get("classList").Call("toggle", "hidden", hidden)
+
+		disabled := !allowed && !denied.Hide
+		node.Get("classList").Call("toggle", "opacity-50", disabled)
+		node.Get("classList").Call("toggle", "cursor-not-allowed", disabled)
+		if disabled {
+			node.Call("setAttribute", "aria-disabled", "true")
+			node.Call("setAttribute", "aria-describedby", tip.Get("id"))
+		} else {
+			node.Call("removeAttribute", "aria-disabled")
+			node.Call("removeAttribute", "aria-describedby")
+			show(false)
+		}
+	}
+	l.onRelease(auth.OnAuthChange(func(auth.AuthState) { apply() }))
+	onUnmount(wrapper, l.release)
+	return wrapper
+}
@@ -0,0 +1,249 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// popoutParam is the query parameter that names a popout window's content
+const popoutParam = "gux-popout"
+
+// PopoutWindowProps configures content that can be popped out into its own
+// window
+type PopoutWindowProps struct {
+	Title   string          // Window title, and the name used in the Popout's labels
+	Content func() js.Value // Builds the content, in its own window or in place
+	Width   int             // Window width in px (default 800)
+	Height  int             // Window height in px (default 600)
+}
+
+var (
+	popouts       = make(map[string]PopoutWindowProps)
+	popoutWindows = make(map[string]js.Value) // open popout windows, by name
+)
+
+// RegisterPopout registers content that a Popout can move into a separate
+// browser window, e.g. a chart or log viewer for a second monitor. A
+// popout window loads the app again, so register popouts in main, before
+// App.RunPopout, in every window. Share state between the windows with
+// state.Sync.
+func RegisterPopout(name string, props PopoutWindowProps) {
+	if props.Width <= 0 {
+		props.Width = 800
+	}
+	if props.Height <= 0 {
+		props.Height = 600
+	}
+	popouts[name] = props
+}
+
+// PopoutName returns the name of the content this window was opened to
+// show, or "" if it isn't a popout window
+func PopoutName() string {
+	search := js.Global().Get("location").Get("search")
+	name := js.Global().Get("URLSearchParams").New(search).Call("get", popoutParam)
+	if name.IsNull() {
+		return ""
+	}
+	return name.String()
+}
+
+// RunPopout is the bootstrap of a popout window: if this window was opened
+// by a Popout, it shows only the popout's content, filling the window, and
+// never returns. In any other window it returns straight away. Call it
+// after registering popouts and syncing state, and before building the
+// layout and starting the router, which a popout window doesn't need.
+func (a *App) RunPopout() {
+	props, ok := popouts[PopoutName()]
+	if !ok {
+		return
+	}
+	document := js.Global().Get("document")
+	document.Set("title", props.Title)
+	document.Get("body").Set("className", "m-0 p-0 bg-white dark:bg-gray-900")
+	a.Mount(Div("h-screen overflow-auto p-4", props.Content()))
+	a.Run()
+}
+
+// OpenPopout opens the content registered as name in its own window, or
+// brings its window to the front if it's already open. It returns false if
+// nothing is registered as name or the browser blocked the window.
+func OpenPopout(name string) bool {
+	props, ok := popouts[name]
+	if !ok {
+		return false
+	}
+	if win, ok := popoutWindows[name]; ok && !win.Get("closed").Bool() {
+		win.Call("focus")
+		return true
+	}
+
+	location := js.Global().Get("location")
+	url := js.Global().Get("URL").New(location.Get("pathname"), location.Get("href"))
+	url.Get("searchParams").Call("set", popoutParam, name)
+
+	features := fmt.Sprintf("popup,width=%d,height=%d", props.Width, props.Height)
+	win := js.Global().Call("open", url.Call("toString"), "gux-popout-"+name, features)
+	if !win.Truthy() {
+		return false
+	}
+	popoutWindows[name] = win
+	return true
+}
+
+// ClosePopout closes the popout window showing name, if it's open
+func ClosePopout(name string) {
+	if win, ok := popoutWindows[name]; ok {
+		win.Call("close")
+		delete(popoutWindows, name)
+	}
+}
+
+// popoutOpen reports whether name is showing in a popout window
+func popoutOpen(name string) bool {
+	win, ok := popoutWindows[name]
+	if ok && win.Get("closed").Bool() {
+		delete(popoutWindows, name)
+		return false
+	}
+	return ok
+}
+
+// PopoutProps configures a Popout
+type PopoutProps struct {
+	Name      string // Content registered with RegisterPopout
+	ClassName string
+}
+
+// Popout shows content registered with RegisterPopout in place, with a
+// button that moves it into its own window. While the window is open the
+// Popout shows a placeholder with buttons to bring the window to the front
+// or back into the page; closing the window brings the content back too.
+type Popout struct {
+	element   js.Value
+	body      js.Value
+	button    js.Value
+	props     PopoutProps
+	window    PopoutWindowProps
+	listeners listeners
+	poll      js.Func
+	interval  js.Value
+}
+
+// NewPopout creates a Popout for the content registered as props.Name
+func NewPopout(props PopoutProps) *Popout {
+	document := js.Global().Get("document")
+	p := &Popout{props: props, window: popouts[props.Name]}
+
+	className := "relative"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	p.element = document.Call("createElement", "div")
+	p.element.Set("className", className)
+
+	p.body = document.Call("createElement", "div")
+	p.element.Call("appendChild", p.body)
+
+	label := i18n.T("gux.popout.open", p.window.Title)
+	p.button = document.Call("createElement", "button")
+	p.button.Set("type", "button")
+	p.button.Set("className", "absolute top-2 right-2 p-1 rounded text-gray-400 hover:text-gray-700 hover:bg-gray-100 dark:hover:text-gray-200 dark:hover:bg-gray-700")
+	p.button.Call("setAttribute", "aria-label", label)
+	p.button.Set("title", label)
+	p.button.Call("appendChild", Icon(IconProps{Name: "arrow-top-right-on-square", Size: IconSM}))
+	p.listeners.on(p.button, "click", func(this js.Value, args []js.Value) any {
+		p.PopOut()
+		return nil
+	})
+	p.element.Call("appendChild", p.button)
+
+	// Notice the window closing, which fires no event in this window
+	p.poll = p.listeners.fn(func(this js.Value, args []js.Value) any {
+		if !popoutOpen(p.props.Name) {
+			p.render()
+		}
+		return nil
+	})
+	p.listeners.onRelease(p.stopPolling)
+
+	p.render()
+	onUnmount(p.element, p.Unmount)
+	return p
+}
+
+// render shows the content in place, or the placeholder while it's in its
+// own window
+func (p *Popout) render() {
+	UnmountChildren(p.body)
+	if !popoutOpen(p.props.Name) {
+		p.stopPolling()
+		p.button.Get("style").Set("display", "")
+		if p.window.Content != nil {
+			p.body.Call("appendChild", p.window.Content())
+		}
+		return
+	}
+
+	p.button.Get("style").Set("display", "none")
+	p.body.Call("appendChild", Div("flex flex-col items-center justify-center gap-3 p-6 border border-dashed border-gray-300 dark:border-gray-600 rounded-lg text-sm text-gray-500 dark:text-gray-400",
+		Text(i18n.T("gux.popout.placeholder", p.window.Title)),
+		Div("flex gap-2",
+			Button(ButtonProps{Text: i18n.T("gux.popout.focus"), Variant: ButtonSecondary, Size: ButtonSM, OnClick: func() {
+				OpenPopout(p.props.Name)
+			}}),
+			Button(ButtonProps{Text: i18n.T("gux.popout.restore"), Variant: ButtonPrimary, Size: ButtonSM, OnClick: p.Restore}),
+		),
+	))
+	if p.interval.IsUndefined() || p.interval.IsNull() {
+		p.interval = js.Global().Call("setInterval", p.poll, 500)
+	}
+}
+
+func (p *Popout) stopPolling() {
+	if !p.interval.IsUndefined() && !p.interval.IsNull() {
+		js.Global().Call("clearInterval", p.interval)
+		p.interval = js.Undefined()
+	}
+}
+
+// PopOut moves the content into its own window. If the browser blocks the
+// window, the content stays in place.
+func (p *Popout) PopOut() {
+	if OpenPopout(p.props.Name) {
+		p.render()
+	}
+}
+
+// Restore closes the content's window and shows it in place again
+func (p *Popout) Restore() {
+	ClosePopout(p.props.Name)
+	p.render()
+}
+
+// IsPoppedOut reports whether the content is in its own window
+func (p *Popout) IsPoppedOut() bool {
+	return popoutOpen(p.props.Name)
+}
+
+// Element returns the Popout's DOM element
+func (p *Popout) Element() js.Value {
+	return p.element
+}
+
+// Mount appends the Popout to parent
+func (p *Popout) Mount(parent js.Value) {
+	parent.Call("appendChild", p.element)
+}
+
+// Unmount removes the Popout and releases its listeners. A popout window
+// that's open stays open, and a Popout created later for the same content
+// shows the placeholder.
+func (p *Popout) Unmount() {
+	p.listeners.release()
+	unmount(p.element)
+}
@@ -165,7 +165,7 @@ func addProgressStyles() {
 	progressStylesAdded = true
 
 	document := js.Global().Get("document")
-	style := document.Call("createElement", "style")
+	style := newStyle()
 	style.Set("textContent", `
 		.bg-stripes {
 			background-image: linear-gradient(
@@ -0,0 +1,188 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strings"
+
+	"github.com/dougbarrett/gux/auth"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// RouteMeta describes a route for the menus built from the router: the
+// sidebar (NavItems), command palette (Commands) and Breadcrumbs. Adding a
+// page then means registering it with its metadata, rather than adding it
+// to each menu.
+type RouteMeta struct {
+	// Label names the route in the menus (default: its title). Parameters
+	// in braces are replaced with their values, as in titles.
+	Label string
+
+	Icon        string
+	Description string // shown under the label in the command palette
+
+	// Roles limits the route's menu entries to users with one of them
+	// (see auth.HasAnyRole). The route itself isn't protected; check the
+	// user in a NavigationGuard or RouteResolver, and on the server.
+	Roles []string
+
+	// Parent is the route shown before this one in breadcrumbs (default:
+	// the nearest registered route whose path is a prefix of this one's)
+	Parent string
+
+	// Hidden leaves the route out of the sidebar and command palette, but
+	// not breadcrumbs. Routes with parameters are always left out.
+	Hidden bool
+}
+
+// Meta sets path's menu metadata. The sidebar and command palette list
+// routes in the order their metadata was set.
+//
+//	router.Register("/users", showUsers)
+//	router.Meta("/users", components.RouteMeta{Label: "Users", Icon: "👥", Roles: []string{"admin"}})
+func (r *Router) Meta(path string, meta RouteMeta) {
+	if r.meta == nil {
+		r.meta = make(map[string]RouteMeta)
+	}
+	if _, ok := r.meta[path]; !ok {
+		r.metaOrder = append(r.metaOrder, path)
+	}
+	r.meta[path] = meta
+}
+
+// NavItems returns sidebar items for the routes the current user may see
+func (r *Router) NavItems() []NavItem {
+	var items []NavItem
+	for _, path := range r.menuRoutes() {
+		items = append(items, NavItem{
+			Label: r.label(path, nil),
+			Icon:  r.meta[path].Icon,
+			Path:  path,
+		})
+	}
+	return items
+}
+
+// Commands returns command palette commands navigating to the routes the
+// current user may see
+func (r *Router) Commands() []Command {
+	var commands []Command
+	for _, path := range r.menuRoutes() {
+		meta := r.meta[path]
+		commands = append(commands, Command{
+			ID:          "nav:" + path,
+			Label:       r.label(path, nil),
+			Description: meta.Description,
+			Icon:        meta.Icon,
+			Category:    i18n.T("gux.router.navigation"),
+			OnExecute:   func() { r.Navigate(path) },
+		})
+	}
+	return commands
+}
+
+// Breadcrumbs returns the trail to the current route through its parents,
+// for BreadcrumbsProps.Items. Parents the current user may not see are
+// shown without links.
+//
+//	components.Breadcrumbs(components.BreadcrumbsProps{Items: router.Breadcrumbs()})
+func (r *Router) Breadcrumbs() []BreadcrumbItem {
+	if !r.registered(r.route) {
+		return nil
+	}
+	items := []BreadcrumbItem{{Label: r.label(r.route, r.params), Icon: r.meta[r.route].Icon}}
+	seen := map[string]bool{r.route: true}
+	for path := r.parent(r.route); path != "" && !seen[path]; path = r.parent(path) {
+		seen[path] = true
+		item := BreadcrumbItem{Label: r.label(path, r.params), Icon: r.meta[path].Icon}
+		if r.allowed(path) {
+			item.Path = fillParams(path, r.params)
+		}
+		items = append([]BreadcrumbItem{item}, items...)
+	}
+	return items
+}
+
+// BindSidebar keeps s's items to NavItems, updating them when the user
+// logs in or out. It returns a function that stops the updates.
+func (r *Router) BindSidebar(s *Sidebar) func() {
+	return auth.OnAuthChange(func(auth.AuthState) {
+		s.SetItems(r.NavItems())
+	})
+}
+
+// BindCommandPalette keeps cp's commands to Commands followed by commands,
+// such as the app's actions, updating them when the user logs in or out.
+// It returns a function that stops the updates.
+func (r *Router) BindCommandPalette(cp *CommandPalette, commands ...Command) func() {
+	return auth.OnAuthChange(func(auth.AuthState) {
+		cp.SetCommands(append(r.Commands(), commands...))
+	})
+}
+
+// menuRoutes returns the routes for the sidebar and command palette
+func (r *Router) menuRoutes() []string {
+	var paths []string
+	for _, path := range r.metaOrder {
+		if r.meta[path].Hidden || strings.Contains(path, "{") || !r.allowed(path) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// allowed reports whether the current user has one of path's roles
+func (r *Router) allowed(path string) bool {
+	roles := r.meta[path].Roles
+	return len(roles) == 0 || auth.HasAnyRole(roles...)
+}
+
+// registered reports whether path is a registered route
+func (r *Router) registered(path string) bool {
+	_, handled := r.routes[path]
+	_, resolved := r.resolvers[path]
+	return handled || resolved
+}
+
+// label returns path's label, or its title, or its last segment, with
+// params filled in
+func (r *Router) label(path string, params map[string]string) string {
+	label := r.meta[path].Label
+	if label == "" {
+		label = r.titles[path]
+	}
+	if label == "" {
+		label = path[strings.LastIndex(path, "/")+1:]
+	}
+	return fillParams(label, params)
+}
+
+// parent returns the route before path in breadcrumbs: its Parent, or the
+// nearest registered prefix with a label, or ""
+func (r *Router) parent(path string) string {
+	if parent := r.meta[path].Parent; parent != "" {
+		return parent
+	}
+	for p := path; p != "/"; {
+		p = p[:strings.LastIndex(p, "/")]
+		if p == "" {
+			p = "/"
+		}
+		_, hasMeta := r.meta[p]
+		_, hasTitle := r.titles[p]
+		if r.registered(p) && (hasMeta || hasTitle) {
+			return p
+		}
+	}
+	return ""
+}
+
+// fillParams replaces the parameters in braces in s with their values
+func fillParams(s string, params map[string]string) string {
+	for name, value := range params {
+		s = strings.ReplaceAll(s, "{"+name+"...}", value)
+		s = strings.ReplaceAll(s, "{"+name+"}", value)
+	}
+	return s
+}
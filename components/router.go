@@ -2,7 +2,20 @@
 
 package components
 
-import "syscall/js"
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/owner"
+	"github.com/dougbarrett/gux/trail"
+)
 
 // RouteHandler is called when a route is matched
 type RouteHandler func()
@@ -10,23 +23,174 @@ type RouteHandler func()
 // NavigateCallback is called after navigation completes
 type NavigateCallback func(path string)
 
+// NavigationGuard runs before the router navigates from one path to
+// another. It returns to to let the navigation go ahead, another path to
+// redirect there, or "" to stay on from.
+type NavigationGuard func(from, to string) string
+
+// RouteResolver runs before a route's handler, in a goroutine, to load the
+// data the page needs or check access. ctx is the route's context. Return
+// Redirect(path) to go elsewhere, e.g. to a login page; any other error is
+// passed to OnResolveError and the handler isn't called.
+type RouteResolver func(ctx context.Context) error
+
+// RedirectError is returned by a RouteResolver to redirect the navigation
+type RedirectError struct {
+	Path string
+}
+
+func (e *RedirectError) Error() string {
+	return "redirect to " + e.Path
+}
+
+// Redirect returns an error that makes a RouteResolver redirect to path
+func Redirect(path string) error {
+	return &RedirectError{Path: path}
+}
+
+// maxRedirects stops guards and resolvers that redirect to each other
+const maxRedirects = 10
+
 // Router handles client-side routing with browser history
 type Router struct {
 	routes      map[string]RouteHandler
+	resolvers   map[string]RouteResolver
+	guards      []*NavigationGuard
 	onNavigate  NavigateCallback
+	onError     func(path string, err error)
+	onLoading   func(loading bool)
+	loading     bool
 	currentPath string
+	route       string            // the registered path currentPath matched
+	params      map[string]string // the parameters in currentPath
+	patterns    []string          // registered paths with parameters, most specific first
+	titles      map[string]string
+	meta        map[string]RouteMeta
+	metaOrder   []string // paths given metadata, in order
+	url         string   // currentPath with its query string and hash
+	search      string   // query string last passed to onQuery
+	hash        string   // hash last passed to onHash
+	onQuery     []*func(url.Values)
+	onHash      []*func(string)
+	ctx         context.Context
+	cancel      context.CancelFunc
+	basePath    string // prefix of every URL, such as "/app"
+
+	prefetchers  map[string]func(ctx context.Context)
+	prefetchOpts *PrefetchOptions
+	prefetched   map[string]time.Time      // when each route was last prefetched
+	stats        map[string]map[string]int // visits to each route from each route
 }
 
-// NewRouter creates a new Router instance
+// NewRouter creates a new Router instance. Its base path is the one
+// server.SPAHandler put in the page, if any.
 func NewRouter() *Router {
-	return &Router{
-		routes: make(map[string]RouteHandler),
+	r := &Router{
+		routes:    make(map[string]RouteHandler),
+		resolvers: make(map[string]RouteResolver),
+		onLoading: routeLoadingBar(),
+	}
+	if meta := js.Global().Get("document").Call("querySelector", `meta[name="gux-base-path"]`); meta.Truthy() {
+		r.SetBasePath(meta.Call("getAttribute", "content").String())
+	}
+	return r
+}
+
+// SetBasePath serves the app under base, such as "/app" for an app behind
+// a reverse proxy at https://example.com/app/. Routes, Navigate, and Link
+// still use paths without it, such as "/users/1", and the router adds it to
+// the URLs it shows. Set it before Start.
+func (r *Router) SetBasePath(base string) {
+	base = strings.TrimSuffix(base, "/")
+	if base != "" && !strings.HasPrefix(base, "/") {
+		base = "/" + base
+	}
+	r.basePath = base
+	owner.SetBasePath(base)
+}
+
+// BasePath returns the prefix of the app's URLs, or ""
+func (r *Router) BasePath() string {
+	return r.basePath
+}
+
+// Href returns the URL of path, which starts with "/", under the base
+// path. Use it for links and assets outside Link; other URLs are returned
+// unchanged.
+func (r *Router) Href(path string) string {
+	if r.basePath == "" || !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return path
+	}
+	if path == "/" {
+		return r.basePath + "/"
 	}
+	return r.basePath + path
 }
 
-// Register adds a route handler
+// Register adds a route handler. A path segment in braces is a parameter
+// matching any one segment, and a final "{name...}" matches the rest of
+// the path; the handler reads them with Params:
+//
+//	router.Register("/users/{id}", func() {
+//		showUser(router.Param("id"))
+//	})
 func (r *Router) Register(path string, handler RouteHandler) {
 	r.routes[path] = handler
+	r.addPattern(path)
+}
+
+// Resolve sets a resolver that runs before path's handler. The URL
+// changes at once; the handler is called when the resolver returns, with
+// a loading indicator shown meanwhile.
+func (r *Router) Resolve(path string, resolver RouteResolver) {
+	r.resolvers[path] = resolver
+	r.addPattern(path)
+}
+
+// Title sets the document title shown on path's route. Parameters in
+// braces are replaced with their values, e.g. "User {id}".
+func (r *Router) Title(path, title string) {
+	if r.titles == nil {
+		r.titles = make(map[string]string)
+	}
+	r.titles[path] = title
+}
+
+// Params returns the parameters of the current route, e.g. {"id": "42"}
+// for "/users/42" registered as "/users/{id}"
+func (r *Router) Params() map[string]string {
+	params := make(map[string]string, len(r.params))
+	for k, v := range r.params {
+		params[k] = v
+	}
+	return params
+}
+
+// Param returns the current route's parameter name, or "" if it has none
+func (r *Router) Param(name string) string {
+	return r.params[name]
+}
+
+// Route returns the registered path the current route matched, such as
+// "/users/{id}"
+func (r *Router) Route() string {
+	return r.route
+}
+
+// BeforeNavigate adds a guard that runs before every navigation, including
+// back/forward and the first route in Start. Guards run in the order they
+// were added. It returns a function that removes the guard.
+func (r *Router) BeforeNavigate(guard NavigationGuard) func() {
+	g := &guard
+	r.guards = append(r.guards, g)
+	return func() {
+		for i, other := range r.guards {
+			if other == g {
+				r.guards = append(r.guards[:i], r.guards[i+1:]...)
+				return
+			}
+		}
+	}
 }
 
 // OnNavigate sets a callback for navigation events
@@ -34,56 +198,321 @@ func (r *Router) OnNavigate(cb NavigateCallback) {
 	r.onNavigate = cb
 }
 
-// Navigate programmatically navigates to a path
+// OnResolveError sets a callback for errors returned by resolvers, other
+// than redirects. Without one, they are logged to the console.
+func (r *Router) OnResolveError(cb func(path string, err error)) {
+	r.onError = cb
+}
+
+// OnLoading replaces the loading indicator shown while resolvers run: fn
+// is called with true when one starts and false when the route renders.
+// Pass a no-op function to show none.
+func (r *Router) OnLoading(fn func(loading bool)) {
+	if r.loading && r.onLoading != nil {
+		r.onLoading(false)
+	}
+	r.onLoading = fn
+	if r.loading && fn != nil {
+		fn(true)
+	}
+}
+
+// Navigate programmatically navigates to a path, which may have a query
+// string and hash. A path that only changes the current route's query or
+// hash, such as "?page=2", adds a history entry without rendering the
+// route again.
 func (r *Router) Navigate(path string) {
-	if path == r.currentPath {
+	if path == r.locationURL() {
 		return
 	}
+	if r.currentPath != "" && routePath(path, r.currentPath) == r.currentPath {
+		js.Global().Get("history").Call("pushState", nil, "", r.Href(path))
+		r.locationChanged()
+		return
+	}
+	r.navigate(path, "pushState", 0)
+}
 
-	r.currentPath = path
+// Start initializes the router and handles the current URL
+func (r *Router) Start() {
+	// Handle browser back/forward
+	js.Global().Call("addEventListener", "popstate", js.FuncOf(func(this js.Value, args []js.Value) any {
+		to := r.locationURL()
+		if routePath(to, r.currentPath) == r.currentPath {
+			// Only the query or hash changed
+			r.locationChanged()
+			return nil
+		}
+		if !r.navigate(to, "replaceState", 0) {
+			// A guard kept us here, so put the URL back
+			js.Global().Get("history").Call("pushState", nil, "", r.Href(r.url))
+		}
+		return nil
+	}))
 
-	// Update browser URL
-	js.Global().Get("history").Call("pushState", nil, "", path)
+	// Handle initial URL
+	r.navigate(r.locationURL(), "replaceState", 0)
+}
 
-	// Call route handler
-	if handler, ok := r.routes[path]; ok {
-		handler()
+// navigate runs the guards, updates the URL with the history method
+// ("pushState" or "replaceState"), then resolves and renders the route. It
+// reports whether the navigation went ahead.
+func (r *Router) navigate(to, method string, redirects int) bool {
+	path := routePath(to, r.currentPath)
+	for _, guard := range r.guards {
+		next := (*guard)(r.currentPath, path)
+		if next == "" {
+			return false
+		}
+		if next != path {
+			if redirects >= maxRedirects {
+				js.Global().Get("console").Call("error", "router: too many redirects at", next)
+				return false
+			}
+			if next == r.currentPath {
+				return false
+			}
+			return r.navigate(next, method, redirects+1)
+		}
+	}
+
+	r.leave(r.currentPath, path)
+	r.enter(path)
+	r.url = to
+	if method == "pushState" || r.locationURL() != to {
+		js.Global().Get("history").Call(method, nil, "", r.Href(to))
 	}
 
-	// Notify listeners
+	route := r.route
+	resolver, ok := r.resolvers[route]
+	if !ok {
+		r.setLoading(false)
+		r.render(path, route)
+		return true
+	}
+
+	ctx := r.ctx
+	r.setLoading(true)
+	go func() {
+		err := resolver(ctx)
+		if ctx.Err() != nil {
+			return // navigated away while resolving
+		}
+		r.setLoading(false)
+
+		var redirect *RedirectError
+		switch {
+		case errors.As(err, &redirect):
+			if redirects >= maxRedirects {
+				js.Global().Get("console").Call("error", "router: too many redirects at", redirect.Path)
+				return
+			}
+			// The URL already shows to, so replace it
+			r.navigate(redirect.Path, "replaceState", redirects+1)
+		case err != nil:
+			if r.onError != nil {
+				r.onError(path, err)
+			} else {
+				js.Global().Get("console").Call("error", "router: resolving "+path+":", err.Error())
+			}
+		default:
+			r.render(path, route)
+		}
+	}()
+	return true
+}
+
+// render calls the handler of route, which path matched, and notifies
+// listeners
+func (r *Router) render(path, route string) {
+	if title, ok := r.titles[route]; ok {
+		js.Global().Get("document").Set("title", fillParams(title, r.params))
+	}
+	if handler, ok := r.routes[route]; ok {
+		handler()
+	}
 	if r.onNavigate != nil {
 		r.onNavigate(path)
 	}
+	r.locationChanged()
+	r.prefetchNext(path)
 }
 
-// Start initializes the router and handles the current URL
-func (r *Router) Start() {
-	// Handle browser back/forward
-	js.Global().Call("addEventListener", "popstate", js.FuncOf(func(this js.Value, args []js.Value) any {
-		path := js.Global().Get("location").Get("pathname").String()
-		r.currentPath = path
+// Query returns the current URL's query parameters
+func (r *Router) Query() url.Values {
+	search := js.Global().Get("location").Get("search").String()
+	values, _ := url.ParseQuery(strings.TrimPrefix(search, "?"))
+	return values
+}
 
-		if handler, ok := r.routes[path]; ok {
-			handler()
+// SetQuery sets query parameters in the URL, keeping the others, and
+// removes those set to "". It replaces the history entry rather than
+// adding one, so state such as a table's sort and page is kept on reload
+// and in shared links without filling the back button's history.
+func (r *Router) SetQuery(params map[string]string) {
+	query := r.Query()
+	for key, value := range params {
+		if value == "" {
+			query.Del(key)
+		} else {
+			query.Set(key, value)
 		}
+	}
+	search := query.Encode()
+	if search != "" {
+		search = "?" + search
+	}
+	r.replaceURL(r.Path() + search + js.Global().Get("location").Get("hash").String())
+}
+
+// Hash returns the current URL's hash, without the "#"
+func (r *Router) Hash() string {
+	return strings.TrimPrefix(js.Global().Get("location").Get("hash").String(), "#")
+}
+
+// SetHash sets the URL's hash, replacing the history entry. Pass "" to
+// remove it.
+func (r *Router) SetHash(hash string) {
+	if hash != "" {
+		hash = "#" + hash
+	}
+	r.replaceURL(r.Path() + js.Global().Get("location").Get("search").String() + hash)
+}
 
-		if r.onNavigate != nil {
-			r.onNavigate(path)
+// OnQueryChange calls fn with the query parameters each time they change:
+// on SetQuery, back/forward and navigation. It returns a function that
+// stops the calls; call it when the component following the query is
+// unmounted.
+func (r *Router) OnQueryChange(fn func(query url.Values)) func() {
+	f := &fn
+	r.onQuery = append(r.onQuery, f)
+	return func() {
+		for i, other := range r.onQuery {
+			if other == f {
+				r.onQuery = append(r.onQuery[:i], r.onQuery[i+1:]...)
+				return
+			}
 		}
+	}
+}
 
-		return nil
-	}))
+// OnHashChange calls fn with the hash, without the "#", each time it
+// changes. It returns a function that stops the calls.
+func (r *Router) OnHashChange(fn func(hash string)) func() {
+	f := &fn
+	r.onHash = append(r.onHash, f)
+	return func() {
+		for i, other := range r.onHash {
+			if other == f {
+				r.onHash = append(r.onHash[:i], r.onHash[i+1:]...)
+				return
+			}
+		}
+	}
+}
 
-	// Handle initial URL
+// replaceURL replaces the current history entry with u, on the same route
+func (r *Router) replaceURL(u string) {
+	if u == r.locationURL() {
+		return
+	}
+	history := js.Global().Get("history")
+	history.Call("replaceState", history.Get("state"), "", r.Href(u))
+	r.locationChanged()
+}
+
+// locationChanged notes the current URL and tells the OnQueryChange and
+// OnHashChange callbacks about changes to the query or hash
+func (r *Router) locationChanged() {
+	location := js.Global().Get("location")
+	r.url = r.locationURL()
+
+	if search := location.Get("search").String(); search != r.search {
+		r.search = search
+		query := r.Query()
+		for _, fn := range append([]*func(url.Values){}, r.onQuery...) {
+			(*fn)(query)
+		}
+	}
+	if hash := location.Get("hash").String(); hash != r.hash {
+		r.hash = hash
+		for _, fn := range append([]*func(string){}, r.onHash...) {
+			(*fn)(strings.TrimPrefix(hash, "#"))
+		}
+	}
+}
+
+// locationURL returns the current URL's path under the base path, query
+// string and hash
+func (r *Router) locationURL() string {
+	location := js.Global().Get("location")
+	return r.Path() + location.Get("search").String() + location.Get("hash").String()
+}
+
+// Path returns the current URL's path under the base path, such as
+// "/users/1" for "/app/users/1". Unlike CurrentPath it is read from the
+// URL, so it is right before the first route has resolved.
+func (r *Router) Path() string {
 	path := js.Global().Get("location").Get("pathname").String()
-	r.currentPath = path
+	if r.basePath == "" {
+		return path
+	}
+	if path == r.basePath {
+		return "/"
+	}
+	if rest, ok := strings.CutPrefix(path, r.basePath+"/"); ok {
+		return "/" + rest
+	}
+	return path
+}
 
-	if handler, ok := r.routes[path]; ok {
-		handler()
+// routePath returns the path part of u, which may have a query string and
+// hash, or current if u has only those
+func routePath(u, current string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	if u == "" {
+		return current
 	}
+	return u
+}
 
-	if r.onNavigate != nil {
-		r.onNavigate(path)
+func (r *Router) setLoading(loading bool) {
+	if r.loading == loading {
+		return
+	}
+	r.loading = loading
+	if r.onLoading != nil {
+		r.onLoading(loading)
+	}
+}
+
+// routeLoadingBar returns the default loading indicator: a bar across the
+// top of the page, shown when a resolver takes longer than 100ms
+func routeLoadingBar() func(bool) {
+	var bar js.Value
+	var loading bool
+	return func(l bool) {
+		loading = l
+		if !loading {
+			if bar.Truthy() {
+				bar.Call("remove")
+			}
+			return
+		}
+		setTimeout(func() {
+			if !loading {
+				return
+			}
+			if !bar.Truthy() {
+				bar = js.Global().Get("document").Call("createElement", "div")
+				bar.Set("className", "fixed top-0 inset-x-0 z-50 h-0.5 bg-blue-600 animate-pulse")
+				bar.Call("setAttribute", "role", "progressbar")
+				bar.Call("setAttribute", "aria-label", i18n.T("gux.router.loading"))
+			}
+			js.Global().Get("document").Get("body").Call("appendChild", bar)
+		}, 100)
 	}
 }
 
@@ -92,6 +521,110 @@ func (r *Router) CurrentPath() string {
 	return r.currentPath
 }
 
+// Context returns a context for the current route. It is canceled when the
+// router navigates away, so requests started with it are aborted instead of
+// finishing for a page that is no longer shown.
+func (r *Router) Context() context.Context {
+	if r.ctx == nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+	}
+	return r.ctx
+}
+
+// enter makes path the current route and cancels the previous route's context
+func (r *Router) enter(path string) {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.currentPath = path
+	r.route, r.params = r.match(path)
+	trail.Navigation(path)
+}
+
+// addPattern notes path if it has parameters, keeping the patterns with
+// the most fixed segments first so "/users/new" wins over "/users/{id}"
+func (r *Router) addPattern(path string) {
+	if !strings.Contains(path, "{") || slices.Contains(r.patterns, path) {
+		return
+	}
+	r.patterns = append(r.patterns, path)
+	fixed := func(pattern string) int {
+		n := 0
+		for _, segment := range strings.Split(pattern, "/") {
+			if !strings.HasPrefix(segment, "{") {
+				n++
+			}
+		}
+		return n
+	}
+	sort.SliceStable(r.patterns, func(i, j int) bool {
+		a, b := r.patterns[i], r.patterns[j]
+		if fixed(a) != fixed(b) {
+			return fixed(a) > fixed(b)
+		}
+		// A rest parameter matches more, so try it last
+		return !strings.Contains(a, "...}") && strings.Contains(b, "...}")
+	})
+}
+
+// match returns the registered path that path matches, and its parameters
+func (r *Router) match(path string) (string, map[string]string) {
+	if _, ok := r.routes[path]; ok {
+		return path, nil
+	}
+	if _, ok := r.resolvers[path]; ok {
+		return path, nil
+	}
+	for _, pattern := range r.patterns {
+		if params, ok := matchRoute(pattern, path); ok {
+			return pattern, params
+		}
+	}
+	return path, nil
+}
+
+// matchRoute matches path against pattern's segments, returning the
+// parameters' values unescaped
+func matchRoute(pattern, path string) (map[string]string, bool) {
+	want := strings.Split(strings.Trim(pattern, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	params := make(map[string]string)
+	for i, segment := range want {
+		name, isParam := strings.CutPrefix(segment, "{")
+		name, _ = strings.CutSuffix(name, "}")
+		if rest, ok := strings.CutSuffix(name, "..."); isParam && ok {
+			if i >= len(got) || got[i] == "" {
+				return nil, false
+			}
+			value, err := url.PathUnescape(strings.Join(got[i:], "/"))
+			if err != nil {
+				return nil, false
+			}
+			params[rest] = value
+			return params, true
+		}
+		if i >= len(got) {
+			return nil, false
+		}
+		if !isParam {
+			if segment != got[i] {
+				return nil, false
+			}
+			continue
+		}
+		value, err := url.PathUnescape(got[i])
+		if err != nil || value == "" {
+			return nil, false
+		}
+		params[name] = value
+	}
+	if len(got) != len(want) {
+		return nil, false
+	}
+	return params, true
+}
+
 // global router instance for Link component
 var globalRouter *Router
 
@@ -0,0 +1,176 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dougbarrett/gux/idle"
+	"github.com/dougbarrett/gux/state"
+)
+
+// routeStatsKey is the localStorage key of the counts of which route
+// followed which
+const routeStatsKey = "gux-route-stats"
+
+// routePrefetchKey prefixes the idle queue keys of route prefetches
+const routePrefetchKey = "route:"
+
+// maxRouteStats is the number of next routes counted for each route
+const maxRouteStats = 20
+
+// PrefetchOptions configures which routes the router prefetches when the
+// browser is idle after each navigation
+type PrefetchOptions struct {
+	Hints     map[string][]string // Routes likely to follow each route, prefetched first
+	Learn     bool                // Also prefetch the routes users most often open next, counted in localStorage
+	MaxRoutes int                 // Routes prefetched after each navigation (default 3)
+	MinVisits int                 // Times a route must have followed the current one to be prefetched from the counts (default 2)
+	MaxAge    time.Duration       // Don't prefetch a route again within this long (default 1 minute)
+	Queue     *idle.Queue         // Queue the prefetches run in (default idle.Default())
+}
+
+// Prefetch sets a function that warms the data path's page loads, e.g.
+// with state.AsyncCache.Warm, so the page shows it without waiting. The
+// router runs it in idle time, after navigating to a route that path is
+// likely to follow (see EnablePrefetch), and when a Link to path is
+// hovered or focused. It isn't run for the current route.
+func (r *Router) Prefetch(path string, fn func(ctx context.Context)) {
+	if r.prefetchers == nil {
+		r.prefetchers = make(map[string]func(ctx context.Context))
+	}
+	r.prefetchers[path] = fn
+}
+
+// EnablePrefetch prefetches the routes likely to follow each route, from
+// opts.Hints and, with opts.Learn, from counts of where users went next.
+// Only routes with a Prefetch function are prefetched.
+func (r *Router) EnablePrefetch(opts PrefetchOptions) {
+	r.prefetchOpts = &opts
+	r.prefetchDefaults()
+}
+
+// PrefetchRoute queues path's Prefetch function to run when the browser is
+// idle, unless path is the current route or was prefetched within MaxAge
+func (r *Router) PrefetchRoute(path string) {
+	path = routePath(path, r.currentPath)
+	fn, ok := r.prefetchers[path]
+	if !ok || path == r.currentPath {
+		return
+	}
+	opts := r.prefetchDefaults()
+	if at, ok := r.prefetched[path]; ok && time.Since(at) < opts.MaxAge {
+		return
+	}
+	opts.Queue.Add(routePrefetchKey+path, func(ctx context.Context) {
+		r.prefetched[path] = time.Now()
+		fn(ctx)
+	})
+}
+
+// prefetchDefaults returns the prefetch options, with defaults filled in
+func (r *Router) prefetchDefaults() *PrefetchOptions {
+	if r.prefetchOpts == nil {
+		r.prefetchOpts = &PrefetchOptions{}
+	}
+	opts := r.prefetchOpts
+	if opts.MaxRoutes <= 0 {
+		opts.MaxRoutes = 3
+	}
+	if opts.MinVisits <= 0 {
+		opts.MinVisits = 2
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = time.Minute
+	}
+	if opts.Queue == nil {
+		opts.Queue = idle.Default()
+	}
+	if r.prefetched == nil {
+		r.prefetched = make(map[string]time.Time)
+	}
+	return opts
+}
+
+// leave drops the prefetches queued for the route being left, leaving other
+// work in a shared queue, and, when learning, counts the navigation from
+// one route to the next
+func (r *Router) leave(from, to string) {
+	if r.prefetchOpts == nil {
+		return
+	}
+	r.prefetchOpts.Queue.ClearPrefix(routePrefetchKey)
+	if !r.prefetchOpts.Learn || from == "" || from == to {
+		return
+	}
+
+	stats := r.routeStats()
+	next := stats[from]
+	if next == nil {
+		next = make(map[string]int)
+		stats[from] = next
+	}
+	next[to]++
+	if len(next) > maxRouteStats {
+		// Forget the least visited
+		least := ""
+		for path, n := range next {
+			if path != to && (least == "" || n < next[least]) {
+				least = path
+			}
+		}
+		delete(next, least)
+	}
+	state.SetLocalJSON(routeStatsKey, stats)
+}
+
+// prefetchNext queues the prefetches of the routes likely to follow path
+func (r *Router) prefetchNext(path string) {
+	if r.prefetchOpts == nil || len(r.prefetchers) == 0 {
+		return
+	}
+	opts := r.prefetchOpts
+
+	next := append([]string(nil), opts.Hints[path]...)
+	if opts.Learn {
+		counts := r.routeStats()[path]
+		var learned []string
+		for p, n := range counts {
+			if n >= opts.MinVisits {
+				learned = append(learned, p)
+			}
+		}
+		sort.Slice(learned, func(i, j int) bool {
+			if counts[learned[i]] != counts[learned[j]] {
+				return counts[learned[i]] > counts[learned[j]]
+			}
+			return learned[i] < learned[j]
+		})
+		next = append(next, learned...)
+	}
+
+	queued := make(map[string]bool)
+	for _, p := range next {
+		if len(queued) == opts.MaxRoutes {
+			break
+		}
+		if _, ok := r.prefetchers[p]; ok && !queued[p] && p != path {
+			queued[p] = true
+			r.PrefetchRoute(p)
+		}
+	}
+}
+
+// routeStats returns the counts of which route followed which, loading
+// them from localStorage the first time
+func (r *Router) routeStats() map[string]map[string]int {
+	if r.stats == nil {
+		r.stats, _ = state.GetLocalJSON[map[string]map[string]int](routeStatsKey)
+		if r.stats == nil {
+			r.stats = make(map[string]map[string]int)
+		}
+	}
+	return r.stats
+}
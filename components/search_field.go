@@ -0,0 +1,295 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// SearchScope is an option in the SearchField scope dropdown (e.g. "in: posts")
+type SearchScope struct {
+	Label string
+	Value string
+}
+
+// SearchQuery is the structured query emitted by SearchField
+type SearchQuery struct {
+	Text  string // Search text with any "in:scope" prefix removed
+	Scope string // Selected scope value ("" when no scopes are configured)
+}
+
+// IsEmpty returns true if the query has no search text
+func (q SearchQuery) IsEmpty() bool {
+	return strings.TrimSpace(q.Text) == ""
+}
+
+// SearchFieldProps configures a SearchField component
+type SearchFieldProps struct {
+	Placeholder  string                  // Default "Search..."
+	Value        string                  // Initial text
+	AriaLabel    string                  // Default "Search"
+	Debounce     time.Duration           // Delay before OnSearch fires; 0 fires on every keystroke
+	Scopes       []SearchScope           // Optional scope dropdown
+	DefaultScope string                  // Initial scope value (defaults to first scope)
+	ClassName    string                  // Container classes
+	InputClass   string                  // Overrides the input classes
+	Borderless   bool                    // Omit the field border, e.g. when embedded in a palette
+	OnSearch     func(query SearchQuery) // Debounced on input, immediate on clear/scope change
+	OnSubmit     func(query SearchQuery) // Enter key
+	OnClear      func()
+}
+
+// SearchField creates a search field with magnifier icon, clear button,
+// Escape-to-clear, debounced change events, and optional scopes.
+// Typing "in:<scope> text" selects a matching scope inline.
+type SearchField struct {
+	container     js.Value
+	input         js.Value
+	clearBtn      js.Value
+	scopeSelect   js.Value
+	props         SearchFieldProps
+	scope         string
+	debounceTimer js.Value
+	debounceFunc  js.Func
+	listeners     listeners
+}
+
+// NewSearchField creates a new SearchField component
+func NewSearchField(props SearchFieldProps) *SearchField {
+	document := js.Global().Get("document")
+
+	if props.Placeholder == "" {
+		props.Placeholder = "Search..."
+	}
+	if props.AriaLabel == "" {
+		props.AriaLabel = "Search"
+	}
+
+	s := &SearchField{props: props, scope: props.DefaultScope}
+	if s.scope == "" && len(props.Scopes) > 0 {
+		s.scope = props.Scopes[0].Value
+	}
+
+	container := document.Call("createElement", "div")
+	containerClass := "relative flex items-stretch w-full"
+	if !props.Borderless {
+		containerClass += " border border-default surface-base rounded-md shadow-sm focus-within:ring-2 focus-within:ring-blue-500 focus-within:border-blue-500"
+	}
+	if props.ClassName != "" {
+		containerClass += " " + props.ClassName
+	}
+	container.Set("className", containerClass)
+	container.Call("setAttribute", "role", "search")
+	s.container = container
+
+	// Scope dropdown
+	if len(props.Scopes) > 0 {
+		sel := document.Call("createElement", "select")
+		sel.Set("className", "pl-3 pr-7 text-sm bg-transparent text-secondary border-r border-default focus:outline-none cursor-pointer")
+		sel.Call("setAttribute", "aria-label", "Search scope")
+		for _, scope := range props.Scopes {
+			opt := document.Call("createElement", "option")
+			opt.Set("value", scope.Value)
+			opt.Set("textContent", "in: "+scope.Label)
+			if scope.Value == s.scope {
+				opt.Set("selected", true)
+			}
+			sel.Call("appendChild", opt)
+		}
+		sel.Call("addEventListener", "change", s.listeners.fn(func(this js.Value, args []js.Value) any {
+			s.scope = sel.Get("value").String()
+			s.emit()
+			return nil
+		}))
+		container.Call("appendChild", sel)
+		s.scopeSelect = sel
+	}
+
+	field := document.Call("createElement", "div")
+	field.Set("className", "relative flex-1")
+
+	// Magnifier icon (decorative)
+	icon := document.Call("createElement", "span")
+	icon.Set("className", "absolute left-3 top-1/2 -translate-y-1/2 icon-muted pointer-events-none")
+	icon.Set("innerHTML", `<svg class="w-4 h-4" fill="none" stroke="currentColor" viewBox="0 0 24 24"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M21 21l-6-6m2-5a7 7 0 11-14 0 7 7 0 0114 0z"></path></svg>`)
+	icon.Call("setAttribute", "aria-hidden", "true")
+	field.Call("appendChild", icon)
+
+	input := document.Call("createElement", "input")
+	input.Set("type", "text")
+	input.Set("placeholder", props.Placeholder)
+	input.Set("autocomplete", "off")
+	input.Call("setAttribute", "aria-label", props.AriaLabel)
+	inputClass := "w-full pl-9 pr-8 py-2 bg-transparent text-primary focus:outline-none placeholder:text-tertiary"
+	if props.InputClass != "" {
+		inputClass = props.InputClass
+	}
+	input.Set("className", inputClass)
+	if props.Value != "" {
+		input.Set("value", props.Value)
+	}
+	field.Call("appendChild", input)
+	s.input = input
+
+	// Clear button
+	clearBtn := document.Call("createElement", "button")
+	clearBtn.Set("type", "button")
+	clearBtn.Set("className", "absolute right-2 top-1/2 -translate-y-1/2 px-1 text-lg leading-none icon-muted hover:text-primary cursor-pointer")
+	clearBtn.Set("textContent", "×")
+	clearBtn.Call("setAttribute", "aria-label", "Clear search")
+	clearBtn.Call("addEventListener", "click", s.listeners.fn(func(this js.Value, args []js.Value) any {
+		s.Clear()
+		s.input.Call("focus")
+		return nil
+	}))
+	field.Call("appendChild", clearBtn)
+	s.clearBtn = clearBtn
+
+	container.Call("appendChild", field)
+	s.updateClearButton()
+
+	s.debounceFunc = s.listeners.fn(func(this js.Value, args []js.Value) any {
+		s.debounceTimer = js.Undefined()
+		s.emit()
+		return nil
+	})
+
+	input.Call("addEventListener", "input", s.listeners.fn(func(this js.Value, args []js.Value) any {
+		s.updateClearButton()
+		s.schedule()
+		return nil
+	}))
+
+	input.Call("addEventListener", "keydown", s.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		switch event.Get("key").String() {
+		case "Escape":
+			// Only swallow Escape when there is something to clear so that
+			// hosts (modals, palettes) still close on an empty field
+			if input.Get("value").String() != "" {
+				event.Call("preventDefault")
+				event.Call("stopImmediatePropagation")
+				s.Clear()
+			}
+		case "Enter":
+			s.cancelTimer()
+			if props.OnSubmit != nil {
+				props.OnSubmit(s.Query())
+			}
+		}
+		return nil
+	}))
+
+	onUnmount(s.container, s.Unmount)
+	return s
+}
+
+func (s *SearchField) updateClearButton() {
+	if s.input.Get("value").String() == "" {
+		s.clearBtn.Get("classList").Call("add", "hidden")
+	} else {
+		s.clearBtn.Get("classList").Call("remove", "hidden")
+	}
+}
+
+func (s *SearchField) cancelTimer() {
+	if !s.debounceTimer.IsUndefined() && !s.debounceTimer.IsNull() {
+		js.Global().Call("clearTimeout", s.debounceTimer)
+		s.debounceTimer = js.Undefined()
+	}
+}
+
+func (s *SearchField) schedule() {
+	s.cancelTimer()
+	if s.props.Debounce <= 0 {
+		s.emit()
+		return
+	}
+	s.debounceTimer = js.Global().Call("setTimeout", s.debounceFunc, s.props.Debounce.Milliseconds())
+}
+
+func (s *SearchField) emit() {
+	if s.props.OnSearch != nil {
+		s.props.OnSearch(s.Query())
+	}
+}
+
+// Query returns the current structured query.
+// A leading "in:<scope>" token matching a configured scope overrides the dropdown.
+func (s *SearchField) Query() SearchQuery {
+	text := s.input.Get("value").String()
+	scope := s.scope
+
+	if rest, ok := strings.CutPrefix(text, "in:"); ok && len(s.props.Scopes) > 0 {
+		name, remainder, _ := strings.Cut(rest, " ")
+		for _, sc := range s.props.Scopes {
+			if strings.EqualFold(sc.Value, name) || strings.EqualFold(sc.Label, name) {
+				scope = sc.Value
+				text = remainder
+				break
+			}
+		}
+	}
+
+	return SearchQuery{Text: strings.TrimSpace(text), Scope: scope}
+}
+
+// Element returns the container DOM element
+func (s *SearchField) Element() js.Value {
+	return s.container
+}
+
+// Mount appends the search input to parent
+func (s *SearchField) Mount(parent js.Value) {
+	parent.Call("appendChild", s.container)
+}
+
+// Unmount removes the search input and releases its listeners
+func (s *SearchField) Unmount() {
+	s.cancelTimer()
+	unmount(s.container)
+	s.listeners.release()
+}
+
+// InputElement returns the underlying input element, e.g. for ARIA wiring
+func (s *SearchField) InputElement() js.Value {
+	return s.input
+}
+
+// Value returns the raw input text
+func (s *SearchField) Value() string {
+	return s.input.Get("value").String()
+}
+
+// SetValue sets the input text without firing OnSearch
+func (s *SearchField) SetValue(value string) {
+	s.cancelTimer()
+	s.input.Set("value", value)
+	s.updateClearButton()
+}
+
+// SetScope selects a scope without firing OnSearch
+func (s *SearchField) SetScope(value string) {
+	s.scope = value
+	if !s.scopeSelect.IsUndefined() {
+		s.scopeSelect.Set("value", value)
+	}
+}
+
+// Clear empties the input and fires OnClear and OnSearch
+func (s *SearchField) Clear() {
+	s.cancelTimer()
+	s.input.Set("value", "")
+	s.updateClearButton()
+	if s.props.OnClear != nil {
+		s.props.OnClear()
+	}
+	s.emit()
+}
+
+// Focus sets focus on the input
+func (s *SearchField) Focus() {
+	s.input.Call("focus")
+}
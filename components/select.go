@@ -29,6 +29,7 @@ type Select struct {
 	selectEl  js.Value
 	label     js.Value
 	selectID  string
+	listeners listeners
 }
 
 // NewSelect creates a new Select component
@@ -37,7 +38,7 @@ func NewSelect(props SelectProps) *Select {
 	crypto := js.Global().Get("crypto")
 
 	container := document.Call("createElement", "div")
-	container.Set("className", "mb-4")
+	container.Set("className", Classes("field"))
 
 	// Generate unique ID for label-input association
 	selectID := "select-" + crypto.Call("randomUUID").String()
@@ -47,7 +48,7 @@ func NewSelect(props SelectProps) *Select {
 	// Label
 	if props.Label != "" {
 		label := document.Call("createElement", "label")
-		label.Set("className", "block text-sm font-medium text-secondary mb-1")
+		label.Set("className", Classes("field.label"))
 		label.Set("textContent", props.Label)
 		label.Set("htmlFor", selectID)
 		container.Call("appendChild", label)
@@ -57,9 +58,9 @@ func NewSelect(props SelectProps) *Select {
 	// Select
 	selectEl := document.Call("createElement", "select")
 	selectEl.Set("id", selectID)
-	className := "w-full px-3 py-2 border border-default rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 surface-base text-primary"
+	className := Classes("select")
 	if props.Disabled {
-		className += " surface-overlay cursor-not-allowed"
+		className = Classes("select", "input.disabled")
 	}
 	if props.ClassName != "" {
 		className = props.ClassName
@@ -101,7 +102,7 @@ func NewSelect(props SelectProps) *Select {
 	}
 
 	if props.OnChange != nil {
-		selectEl.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		selectEl.Call("addEventListener", "change", s.listeners.fn(func(this js.Value, args []js.Value) any {
 			value := selectEl.Get("value").String()
 			props.OnChange(value)
 			return nil
@@ -111,6 +112,7 @@ func NewSelect(props SelectProps) *Select {
 	container.Call("appendChild", selectEl)
 	s.selectEl = selectEl
 
+	onUnmount(s.container, s.Unmount)
 	return s
 }
 
@@ -119,6 +121,17 @@ func (s *Select) Element() js.Value {
 	return s.container
 }
 
+// Mount appends the select to parent
+func (s *Select) Mount(parent js.Value) {
+	parent.Call("appendChild", s.container)
+}
+
+// Unmount removes the select and releases its listeners
+func (s *Select) Unmount() {
+	unmount(s.container)
+	s.listeners.release()
+}
+
 // Value returns the current selected value
 func (s *Select) Value() string {
 	return s.selectEl.Get("value").String()
@@ -0,0 +1,174 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/fetch"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// ServiceBannerProps configures a ServiceBanner
+type ServiceBannerProps struct {
+	Throttle        *fetch.Throttle // Throttle to follow (default: the one installed with fetch.UseThrottle)
+	MaintenanceText string          // Shown after a 503 with Retry-After
+	ThrottledText   string          // Shown after a 429
+	HideRetry       bool            // Hide the "Retry now" button
+	ClassName       string
+}
+
+// ServiceBanner shows one app-wide message while a fetch.Throttle holds
+// requests, with a countdown to the retry and a "Retry now" button, in
+// place of an error for every request that failed during a deploy or
+// rate limit. It hides itself when the service is available again.
+type ServiceBanner struct {
+	element     js.Value
+	message     js.Value
+	countdown   js.Value
+	props       ServiceBannerProps
+	status      fetch.ServiceStatus
+	tick        js.Func
+	interval    js.Value
+	retryFunc   js.Func
+	unsubscribe func()
+}
+
+// NewServiceBanner creates a ServiceBanner. Place it once near the top of
+// the layout.
+func NewServiceBanner(props ServiceBannerProps) *ServiceBanner {
+	document := js.Global().Get("document")
+
+	if props.Throttle == nil {
+		props.Throttle = fetch.CurrentThrottle()
+	}
+	if props.MaintenanceText == "" {
+		props.MaintenanceText = i18n.T("gux.service.maintenance")
+	}
+	if props.ThrottledText == "" {
+		props.ThrottledText = i18n.T("gux.service.throttled")
+	}
+
+	b := &ServiceBanner{props: props}
+
+	b.element = document.Call("createElement", "div")
+
+	// Only the message is a live region, so the countdown isn't read out
+	// every second
+	b.message = document.Call("createElement", "span")
+	b.message.Set("className", "font-medium")
+	b.message.Call("setAttribute", "role", "status")
+	b.message.Call("setAttribute", "aria-live", "polite")
+	b.element.Call("appendChild", b.message)
+
+	b.countdown = document.Call("createElement", "span")
+	b.countdown.Set("className", "flex-1 opacity-75")
+	b.element.Call("appendChild", b.countdown)
+
+	if !props.HideRetry && props.Throttle != nil {
+		b.retryFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+			props.Throttle.Resume()
+			return nil
+		})
+		retry := document.Call("createElement", "button")
+		retry.Set("type", "button")
+		retry.Set("className", "px-2 py-1 text-sm font-medium rounded border border-current opacity-80 hover:opacity-100")
+		retry.Set("textContent", i18n.T("gux.service.retryNow"))
+		retry.Call("addEventListener", "click", b.retryFunc)
+		b.element.Call("appendChild", retry)
+	}
+
+	b.tick = js.FuncOf(func(this js.Value, args []js.Value) any {
+		b.updateCountdown()
+		return nil
+	})
+
+	if props.Throttle != nil {
+		b.status = props.Throttle.Status()
+		b.unsubscribe = props.Throttle.Subscribe(func(status fetch.ServiceStatus) {
+			b.status = status
+			b.render()
+		})
+	}
+	b.render()
+	onUnmount(b.element, b.Unmount)
+	return b
+}
+
+func (b *ServiceBanner) render() {
+	if b.status.State == fetch.ServiceAvailable {
+		b.element.Set("className", "hidden")
+		b.message.Set("textContent", "")
+		b.stopCountdown()
+		return
+	}
+
+	variant, text := AlertWarning, b.props.MaintenanceText
+	if b.status.State == fetch.ServiceThrottled {
+		variant, text = AlertInfo, b.props.ThrottledText
+	}
+	className := Classes("alert."+string(variant)) + " border-b px-4 py-2 flex items-center gap-3 text-sm"
+	if b.props.ClassName != "" {
+		className += " " + b.props.ClassName
+	}
+	b.element.Set("className", className)
+	if b.message.Get("textContent").String() != text {
+		b.message.Set("textContent", text)
+	}
+
+	b.updateCountdown()
+	if b.interval.IsUndefined() || b.interval.IsNull() {
+		b.interval = js.Global().Call("setInterval", b.tick, 1000)
+	}
+}
+
+func (b *ServiceBanner) updateCountdown() {
+	remaining := time.Until(b.status.Until)
+	if remaining <= 0 {
+		b.countdown.Set("textContent", i18n.T("gux.service.retrying"))
+		return
+	}
+	b.countdown.Set("textContent", i18n.T("gux.service.retryIn", remaining.Round(time.Second).String()))
+}
+
+func (b *ServiceBanner) stopCountdown() {
+	if !b.interval.IsUndefined() && !b.interval.IsNull() {
+		js.Global().Call("clearInterval", b.interval)
+		b.interval = js.Undefined()
+	}
+}
+
+// Element returns the banner's DOM element
+func (b *ServiceBanner) Element() js.Value {
+	return b.element
+}
+
+// Mount appends the banner to parent
+func (b *ServiceBanner) Mount(parent js.Value) {
+	parent.Call("appendChild", b.element)
+}
+
+// Unmount removes the banner and releases its listeners
+func (b *ServiceBanner) Unmount() {
+	b.Destroy()
+	unmount(b.element)
+}
+
+// Status returns the service status the banner shows
+func (b *ServiceBanner) Status() fetch.ServiceStatus {
+	return b.status
+}
+
+// Destroy stops following the throttle and releases event listeners
+func (b *ServiceBanner) Destroy() {
+	if b.unsubscribe != nil {
+		b.unsubscribe()
+		b.unsubscribe = nil
+	}
+	b.stopCountdown()
+	b.tick.Release()
+	if b.retryFunc.Truthy() {
+		b.retryFunc.Release()
+	}
+}
@@ -0,0 +1,709 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// Shortcut is a keyboard shortcut registered with a ShortcutManager
+type Shortcut struct {
+	// Keys is a chord such as "mod+k" or "shift+?", or a sequence of chords
+	// separated by spaces such as "g d" (press g, then d). "mod" is Cmd on
+	// macOS and Ctrl elsewhere; ctrl, meta (cmd), alt (option), and shift
+	// match one modifier each.
+	Keys string
+
+	// Description is shown in the help modal
+	Description string
+
+	// Group is the help modal heading (default "General")
+	Group string
+
+	// Scope limits the shortcut to while the scope is active; see
+	// ShortcutManager.ActivateScope. Empty means always active.
+	Scope string
+
+	// Priority decides which shortcut runs when several match; higher wins.
+	// Shortcuts with the same keys and priority in one scope conflict.
+	Priority int
+
+	// AllowInInputs also runs the shortcut while focus is in a text field.
+	// By default typing never triggers shortcuts.
+	AllowInInputs bool
+
+	// Hidden leaves the shortcut out of the help modal
+	Hidden bool
+
+	// Handler runs when the shortcut is pressed. The browser default for the
+	// last key is prevented.
+	Handler func()
+}
+
+// ShortcutConflictError is returned by Register when a shortcut would shadow
+// one that is already registered, or be shadowed by it
+type ShortcutConflictError struct {
+	Shortcut Shortcut // the shortcut being registered
+	Existing Shortcut // the shortcut it conflicts with
+}
+
+func (e *ShortcutConflictError) Error() string {
+	msg := fmt.Sprintf("shortcut %q conflicts with %q", e.Shortcut.Keys, e.Existing.Keys)
+	if e.Existing.Description != "" {
+		msg += " (" + e.Existing.Description + ")"
+	}
+	if e.Shortcut.Scope != "" {
+		msg += " in scope " + e.Shortcut.Scope
+	}
+	return msg
+}
+
+// shortcutSequenceTimeout is how long a sequence such as "g d" waits for its
+// next key
+const shortcutSequenceTimeout = time.Second
+
+// ShortcutManager owns the document keydown listener and dispatches key
+// presses to registered shortcuts, so components do not each install their
+// own. Use the shared manager from GetShortcutManager.
+//
+//	sm := components.GetShortcutManager()
+//	sm.MustRegister(components.Shortcut{
+//		Keys:        "g d",
+//		Description: "Go to dashboard",
+//		Group:       "Navigation",
+//		Handler:     func() { router.Navigate("/") },
+//	})
+//	sm.RegisterHelpShortcut() // "?" opens the list of shortcuts
+type ShortcutManager struct {
+	bindings []*ShortcutBinding
+	scopes   []string // active scopes, innermost last
+	disabled bool
+	nextSeq  int
+
+	pending   []js.Value // keydown events so far in a sequence
+	pendingAt time.Time
+
+	listener js.Func
+	help     *Modal
+	helpKeys *ShortcutBinding
+}
+
+// ShortcutBinding is a registered shortcut
+type ShortcutBinding struct {
+	manager  *ShortcutManager
+	shortcut Shortcut
+	steps    []chord
+	seq      int
+	disabled bool
+}
+
+var globalShortcutManager *ShortcutManager
+
+// GetShortcutManager returns the shared ShortcutManager
+func GetShortcutManager() *ShortcutManager {
+	if globalShortcutManager == nil {
+		globalShortcutManager = NewShortcutManager()
+	}
+	return globalShortcutManager
+}
+
+// NewShortcutManager creates a ShortcutManager. Most apps use the shared one
+// from GetShortcutManager; a second manager listens independently.
+func NewShortcutManager() *ShortcutManager {
+	return &ShortcutManager{}
+}
+
+// Register adds a shortcut. It fails with a *ShortcutConflictError if a
+// shortcut in the same scope with the same priority already uses the keys,
+// or one is a prefix of the other ("g" and "g d").
+func (m *ShortcutManager) Register(s Shortcut) (*ShortcutBinding, error) {
+	steps, err := parseShortcut(s.Keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range m.bindings {
+		if b.shortcut.Scope == s.Scope && b.shortcut.Priority == s.Priority && stepsOverlap(b.steps, steps) {
+			return nil, &ShortcutConflictError{Shortcut: s, Existing: b.shortcut}
+		}
+	}
+
+	m.nextSeq++
+	b := &ShortcutBinding{manager: m, shortcut: s, steps: steps, seq: m.nextSeq}
+	m.bindings = append(m.bindings, b)
+	m.listen()
+	return b, nil
+}
+
+// MustRegister is like Register but panics on an invalid or conflicting
+// shortcut, for shortcuts fixed at build time
+func (m *ShortcutManager) MustRegister(s Shortcut) *ShortcutBinding {
+	b, err := m.Register(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Shortcuts returns the registered shortcuts in registration order
+func (m *ShortcutManager) Shortcuts() []Shortcut {
+	shortcuts := make([]Shortcut, len(m.bindings))
+	for i, b := range m.bindings {
+		shortcuts[i] = b.shortcut
+	}
+	return shortcuts
+}
+
+// ActivateScope turns on the shortcuts registered with scope, e.g. while an
+// editor or dialog has focus. The most recently activated scope wins over
+// the others and over global shortcuts with the same priority.
+func (m *ShortcutManager) ActivateScope(scope string) {
+	m.DeactivateScope(scope)
+	m.scopes = append(m.scopes, scope)
+}
+
+// DeactivateScope turns off the shortcuts registered with scope
+func (m *ShortcutManager) DeactivateScope(scope string) {
+	for i, s := range m.scopes {
+		if s == scope {
+			m.scopes = append(m.scopes[:i], m.scopes[i+1:]...)
+			return
+		}
+	}
+}
+
+// ActiveScopes returns the active scopes, innermost last
+func (m *ShortcutManager) ActiveScopes() []string {
+	return append([]string(nil), m.scopes...)
+}
+
+// Enable resumes handling shortcuts after Disable
+func (m *ShortcutManager) Enable() {
+	m.disabled = false
+}
+
+// Disable stops handling every shortcut, e.g. while a game or a rich text
+// editor needs the keyboard to itself
+func (m *ShortcutManager) Disable() {
+	m.disabled = true
+	m.pending = nil
+}
+
+// Enabled reports whether the manager is handling shortcuts
+func (m *ShortcutManager) Enabled() bool {
+	return !m.disabled
+}
+
+// Destroy removes the keydown listener and every shortcut
+func (m *ShortcutManager) Destroy() {
+	if m.listener.Truthy() {
+		js.Global().Get("document").Call("removeEventListener", "keydown", m.listener)
+		m.listener.Release()
+		m.listener = js.Func{}
+	}
+	m.bindings = nil
+	m.pending = nil
+	m.helpKeys = nil
+	if m.help != nil {
+		m.help.Element().Call("remove")
+		m.help = nil
+	}
+}
+
+// Shortcut returns the registered shortcut
+func (b *ShortcutBinding) Shortcut() Shortcut {
+	return b.shortcut
+}
+
+// SetEnabled turns the shortcut on or off without unregistering it
+func (b *ShortcutBinding) SetEnabled(enabled bool) {
+	b.disabled = !enabled
+}
+
+// Enabled reports whether the shortcut is on
+func (b *ShortcutBinding) Enabled() bool {
+	return !b.disabled
+}
+
+// Unregister removes the shortcut
+func (b *ShortcutBinding) Unregister() {
+	m := b.manager
+	if m.helpKeys == b {
+		m.helpKeys = nil
+	}
+	for i, other := range m.bindings {
+		if other == b {
+			m.bindings = append(m.bindings[:i], m.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// listen installs the document keydown listener on first use
+func (m *ShortcutManager) listen() {
+	if m.listener.Truthy() {
+		return
+	}
+	m.listener = js.FuncOf(func(this js.Value, args []js.Value) any {
+		m.handleKeydown(args[0])
+		return nil
+	})
+	js.Global().Get("document").Call("addEventListener", "keydown", m.listener)
+}
+
+func (m *ShortcutManager) handleKeydown(event js.Value) {
+	if m.disabled || event.Get("repeat").Bool() || event.Get("isComposing").Bool() {
+		return
+	}
+	switch event.Get("key").String() {
+	case "Shift", "Control", "Alt", "Meta", "CapsLock", "Dead", "Unidentified":
+		return // wait for the key the modifiers apply to
+	}
+
+	if len(m.pending) > 0 && time.Since(m.pendingAt) > shortcutSequenceTimeout {
+		m.pending = nil
+	}
+	typing := isTextInput(event.Get("target"))
+
+	b, partial := m.match(event, typing)
+	if b == nil && !partial && len(m.pending) > 0 {
+		// The sequence broke off; the key may start a new one
+		m.pending = nil
+		b, partial = m.match(event, typing)
+	}
+
+	switch {
+	case b != nil:
+		m.pending = nil
+		event.Call("preventDefault")
+		if b.shortcut.Handler != nil {
+			b.shortcut.Handler()
+		}
+	case partial:
+		m.pending = append(m.pending, event)
+		m.pendingAt = time.Now()
+	default:
+		m.pending = nil
+	}
+}
+
+// match returns the best shortcut completed by event, or reports whether
+// event continues a longer sequence
+func (m *ShortcutManager) match(event js.Value, typing bool) (*ShortcutBinding, bool) {
+	var best *ShortcutBinding
+	partial := false
+	n := len(m.pending)
+	for _, b := range m.bindings {
+		if b.disabled || len(b.steps) <= n || (typing && !b.shortcut.AllowInInputs) || m.scopeRank(b.shortcut.Scope) < 0 {
+			continue
+		}
+		if !b.steps[n].matches(event) || !b.pressed(m.pending) {
+			continue
+		}
+		if len(b.steps) > n+1 {
+			partial = true
+			continue
+		}
+		if best == nil || m.outranks(b, best) {
+			best = b
+		}
+	}
+	return best, partial
+}
+
+// scopeRank orders scopes for tie-breaking: innermost active scope highest,
+// global 0, inactive -1
+func (m *ShortcutManager) scopeRank(scope string) int {
+	if scope == "" {
+		return 0
+	}
+	for i, s := range m.scopes {
+		if s == scope {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (m *ShortcutManager) outranks(a, b *ShortcutBinding) bool {
+	if a.shortcut.Priority != b.shortcut.Priority {
+		return a.shortcut.Priority > b.shortcut.Priority
+	}
+	if ra, rb := m.scopeRank(a.shortcut.Scope), m.scopeRank(b.shortcut.Scope); ra != rb {
+		return ra > rb
+	}
+	return a.seq > b.seq
+}
+
+// isTextInput reports whether el takes typed text
+func isTextInput(el js.Value) bool {
+	if !el.Truthy() || el.Get("tagName").IsUndefined() {
+		return false
+	}
+	if el.Get("isContentEditable").Truthy() {
+		return true
+	}
+	switch el.Get("tagName").String() {
+	case "TEXTAREA", "SELECT":
+		return true
+	case "INPUT":
+		switch el.Get("type").String() {
+		case "button", "submit", "reset", "checkbox", "radio", "range", "color", "file", "image":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// chord is one step of a shortcut: a key and its modifiers
+type chord struct {
+	mod, ctrl, meta, alt, shift bool
+	key                         string // lowercase event.key, e.g. "k", "escape", "arrowup", " "
+}
+
+// shortcutKeyAliases maps the names accepted in Shortcut.Keys to event.key
+var shortcutKeyAliases = map[string]string{
+	"esc":    "escape",
+	"return": "enter",
+	"space":  " ",
+	"up":     "arrowup",
+	"down":   "arrowdown",
+	"left":   "arrowleft",
+	"right":  "arrowright",
+	"del":    "delete",
+	"plus":   "+",
+}
+
+// parseShortcut parses Shortcut.Keys into its steps
+func parseShortcut(keys string) ([]chord, error) {
+	fields := strings.Fields(keys)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("shortcut: no keys")
+	}
+	steps := make([]chord, len(fields))
+	for i, field := range fields {
+		parts := strings.Split(strings.ToLower(field), "+")
+		// A trailing "+" is the plus key: "mod++"
+		if len(parts) > 1 && parts[len(parts)-1] == "" && parts[len(parts)-2] == "" {
+			parts = append(parts[:len(parts)-2], "+")
+		}
+		var c chord
+		for _, mod := range parts[:len(parts)-1] {
+			switch mod {
+			case "mod":
+				c.mod = true
+			case "ctrl", "control":
+				c.ctrl = true
+			case "meta", "cmd", "command", "super":
+				c.meta = true
+			case "alt", "option", "opt":
+				c.alt = true
+			case "shift":
+				c.shift = true
+			default:
+				return nil, fmt.Errorf("shortcut %q: unknown modifier %q", keys, mod)
+			}
+		}
+		c.key = parts[len(parts)-1]
+		if alias, ok := shortcutKeyAliases[c.key]; ok {
+			c.key = alias
+		}
+		if c.key == "" {
+			return nil, fmt.Errorf("shortcut %q: missing key", keys)
+		}
+		steps[i] = c
+	}
+	return steps, nil
+}
+
+// matches reports whether a keydown event presses the chord
+func (c chord) matches(event js.Value) bool {
+	ctrl, meta := event.Get("ctrlKey").Bool(), event.Get("metaKey").Bool()
+	if c.mod {
+		if !ctrl && !meta {
+			return false
+		}
+	} else if c.ctrl != ctrl || c.meta != meta {
+		return false
+	}
+	if c.alt != event.Get("altKey").Bool() {
+		return false
+	}
+
+	if len(c.key) == 1 {
+		k := c.key[0]
+		switch {
+		case k >= 'a' && k <= 'z':
+			// Alt and Shift change event.key ("®" for Option+R), so match
+			// letters and digits by physical key
+			return c.shift == event.Get("shiftKey").Bool() && event.Get("code").String() == "Key"+strings.ToUpper(c.key)
+		case k >= '0' && k <= '9':
+			return c.shift == event.Get("shiftKey").Bool() && event.Get("code").String() == "Digit"+c.key
+		default:
+			// Symbols such as "?" already imply Shift on most layouts
+			return event.Get("key").String() == c.key
+		}
+	}
+	return c.shift == event.Get("shiftKey").Bool() && strings.ToLower(event.Get("key").String()) == c.key
+}
+
+// overlaps reports whether one key press could match both chords
+func (c chord) overlaps(o chord) bool {
+	if c.key != o.key || c.alt != o.alt {
+		return false
+	}
+	if len(c.key) != 1 || isAlnum(c.key[0]) {
+		if c.shift != o.shift {
+			return false
+		}
+	}
+	switch {
+	case c.mod && o.mod:
+		return true
+	case c.mod:
+		return o.ctrl || o.meta
+	case o.mod:
+		return c.ctrl || c.meta
+	}
+	return c.ctrl == o.ctrl && c.meta == o.meta
+}
+
+// stepsOverlap reports whether one sequence equals or begins the other
+func stepsOverlap(a, b []chord) bool {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if !a[i].overlaps(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// pressed reports whether the keydown events so far begin the shortcut
+func (b *ShortcutBinding) pressed(events []js.Value) bool {
+	for i, event := range events {
+		if !b.steps[i].matches(event) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+// isMacPlatform reports whether the browser runs on an Apple platform,
+// where "mod" is shown as ⌘
+func isMacPlatform() bool {
+	nav := js.Global().Get("navigator")
+	if !nav.Truthy() {
+		return false
+	}
+	platform := nav.Get("platform")
+	if platform.Truthy() {
+		p := platform.String()
+		return strings.HasPrefix(p, "Mac") || strings.HasPrefix(p, "iP")
+	}
+	return strings.Contains(nav.Get("userAgent").String(), "Mac OS")
+}
+
+var shortcutKeyLabels = map[string]string{
+	"escape":     "Esc",
+	" ":          "Space",
+	"arrowup":    "↑",
+	"arrowdown":  "↓",
+	"arrowleft":  "←",
+	"arrowright": "→",
+	"enter":      "Enter",
+	"tab":        "Tab",
+	"backspace":  "Backspace",
+	"delete":     "Delete",
+	"pageup":     "PageUp",
+	"pagedown":   "PageDown",
+	"home":       "Home",
+	"end":        "End",
+}
+
+// shortcutKeyParts returns the labels of each key in each step, e.g.
+// [["⌘", "K"]] or [["G"], ["D"]]
+func shortcutKeyParts(keys string) [][]string {
+	steps, err := parseShortcut(keys)
+	if err != nil {
+		return [][]string{{keys}}
+	}
+	mac := isMacPlatform()
+	parts := make([][]string, len(steps))
+	for i, c := range steps {
+		var labels []string
+		if c.ctrl || (c.mod && !mac) {
+			labels = append(labels, "Ctrl")
+		}
+		if c.alt {
+			labels = append(labels, platformLabel(mac, "⌥", "Alt"))
+		}
+		if c.shift {
+			labels = append(labels, platformLabel(mac, "⇧", "Shift"))
+		}
+		if c.meta || (c.mod && mac) {
+			labels = append(labels, platformLabel(mac, "⌘", "Meta"))
+		}
+		key, ok := shortcutKeyLabels[c.key]
+		if !ok {
+			key = strings.ToUpper(c.key[:1]) + c.key[1:]
+		}
+		parts[i] = append(labels, key)
+	}
+	return parts
+}
+
+func platformLabel(mac bool, macLabel, label string) string {
+	if mac {
+		return macLabel
+	}
+	return label
+}
+
+// FormatShortcut returns Keys as it is shown to the user on this platform:
+// "⌘K" on macOS and "Ctrl+K" elsewhere for "mod+k", "G then D" for "g d".
+// Use it for CommandPalette shortcut hints.
+func FormatShortcut(keys string) string {
+	sep := "+"
+	if isMacPlatform() {
+		sep = ""
+	}
+	var steps []string
+	for _, labels := range shortcutKeyParts(keys) {
+		steps = append(steps, strings.Join(labels, sep))
+	}
+	return strings.Join(steps, " "+i18n.T("gux.shortcuts.then")+" ")
+}
+
+// shortcutHelpScope is active while the help modal is open
+const shortcutHelpScope = "gux.shortcuts.help"
+
+// RegisterHelpShortcut binds "?" to ShowHelp
+func (m *ShortcutManager) RegisterHelpShortcut() error {
+	if m.helpKeys != nil {
+		return nil
+	}
+	b, err := m.Register(Shortcut{
+		Keys:        "?",
+		Description: i18n.T("gux.shortcuts.help"),
+		Handler:     m.ShowHelp,
+	})
+	if err != nil {
+		return err
+	}
+	m.helpKeys = b
+	return nil
+}
+
+// ShowHelp opens a modal listing the shortcuts that are active now, grouped
+// by Shortcut.Group. Hidden, disabled, and undescribed shortcuts are left out.
+func (m *ShortcutManager) ShowHelp() {
+	if m.help == nil {
+		m.help = NewModal(ModalProps{
+			Title:   i18n.T("gux.shortcuts.title"),
+			Width:   "lg",
+			OnClose: func() { m.DeactivateScope(shortcutHelpScope) },
+		})
+		js.Global().Get("document").Get("body").Call("appendChild", m.help.Element())
+		m.MustRegister(Shortcut{
+			Keys:          "escape",
+			Scope:         shortcutHelpScope,
+			AllowInInputs: true,
+			Hidden:        true,
+			Handler:       m.HideHelp,
+		})
+	}
+	if m.help.IsOpen() {
+		return
+	}
+	m.help.SetContent(m.helpContent())
+	m.ActivateScope(shortcutHelpScope)
+	m.help.Open()
+}
+
+// HideHelp closes the help modal
+func (m *ShortcutManager) HideHelp() {
+	if m.help != nil && m.help.IsOpen() {
+		m.help.Close()
+	}
+}
+
+func (m *ShortcutManager) helpContent() js.Value {
+	document := js.Global().Get("document")
+	general := i18n.T("gux.shortcuts.general")
+
+	var groups []string
+	byGroup := map[string][]Shortcut{}
+	for _, b := range m.bindings {
+		s := b.shortcut
+		if s.Hidden || s.Description == "" || b.disabled || m.scopeRank(s.Scope) < 0 {
+			continue
+		}
+		group := s.Group
+		if group == "" {
+			group = general
+		}
+		if _, ok := byGroup[group]; !ok {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], s)
+	}
+	// Keep registration order, but put the general group first
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i] == general && groups[j] != general })
+
+	content := document.Call("createElement", "div")
+	content.Set("className", "grid gap-6 sm:grid-cols-2")
+	for _, group := range groups {
+		section := document.Call("createElement", "section")
+
+		heading := document.Call("createElement", "h4")
+		heading.Set("className", "text-xs font-semibold uppercase tracking-wide text-secondary mb-2")
+		heading.Set("textContent", group)
+		section.Call("appendChild", heading)
+
+		list := document.Call("createElement", "dl")
+		list.Set("className", "space-y-2")
+		for _, s := range byGroup[group] {
+			row := document.Call("createElement", "div")
+			row.Set("className", "flex items-center justify-between gap-4")
+
+			desc := document.Call("createElement", "dt")
+			desc.Set("className", "text-sm text-primary")
+			desc.Set("textContent", s.Description)
+			row.Call("appendChild", desc)
+
+			keys := document.Call("createElement", "dd")
+			keys.Set("className", "flex items-center gap-1 shrink-0")
+			keys.Call("setAttribute", "aria-label", FormatShortcut(s.Keys))
+			for i, labels := range shortcutKeyParts(s.Keys) {
+				if i > 0 {
+					then := document.Call("createElement", "span")
+					then.Set("className", "text-xs text-secondary")
+					then.Set("textContent", i18n.T("gux.shortcuts.then"))
+					keys.Call("appendChild", then)
+				}
+				for _, label := range labels {
+					kbd := document.Call("createElement", "kbd")
+					kbd.Set("className", "min-w-[1.5rem] px-1.5 py-0.5 text-xs font-mono text-center rounded border border-subtle surface-raised text-secondary")
+					kbd.Set("textContent", label)
+					keys.Call("appendChild", kbd)
+				}
+			}
+			row.Call("appendChild", keys)
+			list.Call("appendChild", row)
+		}
+		section.Call("appendChild", list)
+		content.Call("appendChild", section)
+	}
+	return content
+}
@@ -2,7 +2,13 @@
 
 package components
 
-import "syscall/js"
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/prefs"
+	"github.com/dougbarrett/gux/storage"
+)
 
 const (
 	// Expanded mode classes
@@ -12,7 +18,8 @@ const (
 	sidebarItemCollapsedClass   = "flex items-center justify-center px-2 py-3 text-gray-300 hover:bg-gray-700 hover:text-white rounded-lg transition-colors cursor-pointer"
 	sidebarActiveCollapsedClass = "flex items-center justify-center px-2 py-3 bg-gray-700 text-white rounded-lg cursor-pointer"
 
-	// localStorage key for sidebar collapse state
+	// Legacy localStorage key for sidebar collapse state, read when no
+	// prefs.Layout value exists yet
 	sidebarStorageKey = "gux-sidebar-collapsed"
 )
 
@@ -41,14 +48,17 @@ type Sidebar struct {
 	items              []NavItem
 	navItems           []js.Value
 	navLabels          []js.Value // Store label elements for show/hide on collapse
+	active             string     // path passed to SetActive
 	isOpen             bool
 	isCollapsed        bool
 	onToggle           func(isOpen bool)
 	onCollapse         func(isCollapsed bool)
 	collapseBtn        js.Value
-	closeBtn           js.Value // Mobile close button for focus management
-	lastFocusedElement js.Value // Stores element that had focus before sidebar opened
-	keyboardShortcut   js.Func  // Stored for cleanup
+	closeBtn           js.Value         // Mobile close button for focus management
+	lastFocusedElement js.Value         // Stores element that had focus before sidebar opened
+	shortcut           *ShortcutBinding // Cmd/Ctrl+B, stored for cleanup
+	listeners          listeners
+	itemFuncs          listeners // of the nav items
 }
 
 // NewSidebar creates a new Sidebar component
@@ -108,8 +118,6 @@ func NewSidebar(props SidebarProps) *Sidebar {
 		title:       title,
 		nav:         nav,
 		items:       props.Items,
-		navItems:    make([]js.Value, len(props.Items)),
-		navLabels:   make([]js.Value, len(props.Items)),
 		isOpen:      false,
 		isCollapsed: false,
 		onToggle:    props.OnToggle,
@@ -119,43 +127,46 @@ func NewSidebar(props SidebarProps) *Sidebar {
 	}
 
 	// Collapse button click handler
-	collapseBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	collapseBtn.Call("addEventListener", "click", s.listeners.fn(func(this js.Value, args []js.Value) any {
 		s.ToggleCollapse()
 		return nil
 	}))
 
 	// Close button click handler
-	closeBtn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	closeBtn.Call("addEventListener", "click", s.listeners.fn(func(this js.Value, args []js.Value) any {
 		s.Close()
 		return nil
 	}))
 
 	// Overlay click handler (close sidebar)
-	overlay.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	overlay.Call("addEventListener", "click", s.listeners.fn(func(this js.Value, args []js.Value) any {
 		s.Close()
 		return nil
 	}))
 
-	for i, item := range props.Items {
-		navItem, label := s.createNavItemWithLabel(document, item)
-		s.navItems[i] = navItem
-		s.navLabels[i] = label
-		nav.Call("appendChild", navItem)
-	}
+	s.renderItems()
 
 	sidebar.Call("appendChild", nav)
 
-	// Load saved collapse state from localStorage
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		saved := localStorage.Call("getItem", sidebarStorageKey)
-		if !saved.IsNull() && !saved.IsUndefined() && saved.String() == "true" {
-			// Directly set collapsed state without triggering callback during init
-			s.isCollapsed = true
-			s.applyCollapsedState()
-		}
+	// Load saved collapse state from layout preferences
+	legacyCollapsed := storage.Local.Get(sidebarStorageKey) == "true"
+	if prefs.Layout.GetBool(prefs.KeySidebarCollapsed, legacyCollapsed) {
+		// Directly set collapsed state without triggering callback during init
+		s.isCollapsed = true
+		s.applyCollapsedState()
 	}
 
+	// Follow changes synced from other devices
+	s.listeners.onRelease(prefs.Layout.Subscribe(prefs.KeySidebarCollapsed, func() {
+		collapsed := prefs.Layout.GetBool(prefs.KeySidebarCollapsed, s.isCollapsed)
+		if collapsed && !s.isCollapsed {
+			s.Collapse()
+		} else if !collapsed && s.isCollapsed {
+			s.Expand()
+		}
+	}))
+
+	onUnmount(s.element, s.Unmount)
 	return s
 }
 
@@ -164,8 +175,54 @@ func (s *Sidebar) Element() js.Value {
 	return s.element
 }
 
+// Mount appends the sidebar to parent
+func (s *Sidebar) Mount(parent js.Value) {
+	parent.Call("appendChild", s.element)
+}
+
+// Unmount removes the sidebar and its overlay, unregisters its keyboard
+// shortcut and releases its listeners
+func (s *Sidebar) Unmount() {
+	s.UnregisterKeyboardShortcut()
+	unmount(s.element)
+	s.overlay.Call("remove")
+	s.listeners.release()
+	s.itemFuncs.release()
+}
+
+// SetItems replaces the nav items, e.g. with Router.NavItems when the
+// user's roles change. The active item stays active if it is among them.
+func (s *Sidebar) SetItems(items []NavItem) {
+	for _, navItem := range s.navItems {
+		unmount(navItem)
+	}
+	s.itemFuncs.release()
+	s.items = items
+	s.renderItems()
+	if s.isCollapsed {
+		for _, label := range s.navLabels {
+			label.Set("className", "hidden")
+		}
+	}
+	s.SetActive(s.active)
+}
+
+// renderItems adds an element to the nav for each item
+func (s *Sidebar) renderItems() {
+	document := js.Global().Get("document")
+	s.navItems = make([]js.Value, len(s.items))
+	s.navLabels = make([]js.Value, len(s.items))
+	for i, item := range s.items {
+		navItem, label := s.createNavItemWithLabel(document, item)
+		s.navItems[i] = navItem
+		s.navLabels[i] = label
+		s.nav.Call("appendChild", navItem)
+	}
+}
+
 // SetActive updates the active state of nav items
 func (s *Sidebar) SetActive(path string) {
+	s.active = path
 	for i, item := range s.items {
 		if item.Path == path {
 			if s.isCollapsed {
@@ -225,19 +282,19 @@ func (s *Sidebar) createNavItemWithLabel(document js.Value, item NavItem) (js.Va
 	link.Call("appendChild", tooltip)
 
 	// Show tooltip on hover when collapsed
-	link.Call("addEventListener", "mouseenter", js.FuncOf(func(this js.Value, args []js.Value) any {
+	link.Call("addEventListener", "mouseenter", s.itemFuncs.fn(func(this js.Value, args []js.Value) any {
 		if s.isCollapsed {
 			tooltip.Set("className", "absolute left-full ml-2 px-2 py-1 bg-gray-900 text-white text-sm rounded whitespace-nowrap opacity-100 pointer-events-none transition-opacity z-50")
 		}
 		return nil
 	}))
-	link.Call("addEventListener", "mouseleave", js.FuncOf(func(this js.Value, args []js.Value) any {
+	link.Call("addEventListener", "mouseleave", s.itemFuncs.fn(func(this js.Value, args []js.Value) any {
 		tooltip.Set("className", "absolute left-full ml-2 px-2 py-1 bg-gray-900 text-white text-sm rounded whitespace-nowrap opacity-0 pointer-events-none transition-opacity z-50")
 		return nil
 	}))
 
 	// Close sidebar on mobile when a nav item is clicked
-	link.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	link.Call("addEventListener", "click", s.itemFuncs.fn(func(this js.Value, args []js.Value) any {
 		// Check if we're on mobile (sidebar is in fixed position mode)
 		if s.isOpen {
 			s.Close()
@@ -345,11 +402,7 @@ func (s *Sidebar) Collapse() {
 	s.isCollapsed = true
 	s.applyCollapsedState()
 
-	// Save state to localStorage
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		localStorage.Call("setItem", sidebarStorageKey, "true")
-	}
+	prefs.Layout.SetBool(prefs.KeySidebarCollapsed, true)
 
 	if s.onCollapse != nil {
 		s.onCollapse(true)
@@ -387,11 +440,7 @@ func (s *Sidebar) Expand() {
 		}
 	}
 
-	// Save state to localStorage
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		localStorage.Call("setItem", sidebarStorageKey, "false")
-	}
+	prefs.Layout.SetBool(prefs.KeySidebarCollapsed, false)
 
 	if s.onCollapse != nil {
 		s.onCollapse(false)
@@ -423,37 +472,32 @@ func (s *Sidebar) SetCollapsed(collapsed bool) {
 
 // ClearSavedState removes the saved collapse preference from localStorage
 func (s *Sidebar) ClearSavedState() {
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		localStorage.Call("removeItem", sidebarStorageKey)
-	}
+	storage.Local.Remove(sidebarStorageKey)
 }
 
 // RegisterKeyboardShortcut registers Cmd/Ctrl+B to toggle sidebar collapse
+// with the shared ShortcutManager
 func (s *Sidebar) RegisterKeyboardShortcut() {
-	document := js.Global().Get("document")
-
-	s.keyboardShortcut = js.FuncOf(func(this js.Value, args []js.Value) any {
-		event := args[0]
-		key := event.Get("key").String()
-
-		// Check for Cmd+B (Mac) or Ctrl+B (Windows/Linux)
-		// event.key returns lowercase "b" for the B key
-		if (key == "b" || key == "B") && (event.Get("metaKey").Bool() || event.Get("ctrlKey").Bool()) {
-			event.Call("preventDefault") // Prevent browser default (e.g., bold in rich text)
-			s.ToggleCollapse()
-		}
-		return nil
+	if s.shortcut != nil {
+		return
+	}
+	b, err := GetShortcutManager().Register(Shortcut{
+		Keys:          "mod+b",
+		Description:   i18n.T("gux.shortcuts.toggleSidebar"),
+		AllowInInputs: true,
+		Handler:       s.ToggleCollapse,
 	})
-
-	document.Call("addEventListener", "keydown", s.keyboardShortcut)
+	if err != nil {
+		js.Global().Get("console").Call("warn", "sidebar:", err.Error())
+		return
+	}
+	s.shortcut = b
 }
 
 // UnregisterKeyboardShortcut removes the Cmd/Ctrl+B keyboard shortcut
 func (s *Sidebar) UnregisterKeyboardShortcut() {
-	if s.keyboardShortcut.Truthy() {
-		document := js.Global().Get("document")
-		document.Call("removeEventListener", "keydown", s.keyboardShortcut)
-		s.keyboardShortcut.Release()
+	if s.shortcut != nil {
+		s.shortcut.Unregister()
+		s.shortcut = nil
 	}
 }
@@ -30,6 +30,7 @@ func SkipLinks(props SkipLinksProps) js.Value {
 
 	container := document.Call("createElement", "div")
 	container.Set("className", "skip-links")
+	var funcs listeners
 
 	for _, link := range props.Links {
 		a := document.Call("createElement", "a")
@@ -39,7 +40,7 @@ func SkipLinks(props SkipLinksProps) js.Value {
 
 		// Handle click to focus target
 		target := link.Target
-		a.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		a.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 			args[0].Call("preventDefault")
 
 			// Try to find target element
@@ -63,6 +64,7 @@ func SkipLinks(props SkipLinksProps) js.Value {
 
 		container.Call("appendChild", a)
 	}
+	onUnmount(container, funcs.release)
 
 	return container
 }
@@ -98,7 +100,7 @@ func AddSkipLinksCSS() {
 		return
 	}
 
-	style := document.Call("createElement", "style")
+	style := newStyle()
 	style.Set("id", "skip-links-css")
 	style.Set("textContent", `
 		.sr-only {
@@ -166,10 +168,9 @@ func (a *AriaLive) Announce(message string) {
 	a.element.Set("textContent", "")
 
 	// Use setTimeout to ensure the change is detected
-	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
+	setTimeout(func() {
 		a.element.Set("textContent", message)
-		return nil
-	}), 100)
+	}, 100)
 }
 
 // Clear clears the live region
@@ -199,8 +200,5 @@ func AnnounceAssertive(message string) {
 	announcer.Announce(message)
 
 	// Clean up after announcement
-	js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-		announcer.Destroy()
-		return nil
-	}), 5000)
+	setTimeout(announcer.Destroy, 5000)
 }
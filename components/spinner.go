@@ -112,7 +112,7 @@ func addSpinnerStyles() {
 	}
 
 	document := js.Global().Get("document")
-	style := document.Call("createElement", "style")
+	style := newStyle()
 	style.Set("textContent", `
 		@keyframes spin {
 			to { transform: rotate(360deg); }
@@ -40,6 +40,7 @@ type Stepper struct {
 	current     int
 	stepEls     []js.Value
 	onComplete  func()
+	listeners   listeners
 }
 
 // NewStepper creates a new Stepper component
@@ -91,6 +92,7 @@ func NewStepper(props StepperProps) *Stepper {
 	container.Call("appendChild", contentArea)
 	s.contentArea = contentArea
 
+	onUnmount(s.element, s.Unmount)
 	return s
 }
 
@@ -173,7 +175,7 @@ func (s *Stepper) createStepIndicator(index int, step Step, vertical bool, onCli
 
 	// Click handler
 	if onClick != nil {
-		container.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		container.Call("addEventListener", "click", s.listeners.fn(func(this js.Value, args []js.Value) any {
 			onClick(index)
 			return nil
 		}))
@@ -187,6 +189,21 @@ func (s *Stepper) Element() js.Value {
 	return s.element
 }
 
+// Mount appends the stepper to parent
+func (s *Stepper) Mount(parent js.Value) {
+	parent.Call("appendChild", s.element)
+}
+
+// Unmount removes the stepper and releases its listeners, unmounting the
+// content of every step
+func (s *Stepper) Unmount() {
+	for _, step := range s.steps {
+		UnmountTree(step.Content)
+	}
+	unmount(s.element)
+	s.listeners.release()
+}
+
 // GoTo navigates to a specific step
 func (s *Stepper) GoTo(step int) {
 	if step < 0 || step >= len(s.steps) {
@@ -3,9 +3,14 @@
 package components
 
 import (
+	"context"
 	"sort"
 	"strings"
 	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/types"
 )
 
 // TableColumn defines a table column
@@ -14,9 +19,19 @@ type TableColumn struct {
 	Key       string
 	Width     string
 	ClassName string
-	Sortable  bool                                          // Whether this column is sortable
-	SortKey   string                                        // Key to sort by (defaults to Key if not set)
+	Sortable  bool                                         // Whether this column is sortable
+	SortKey   string                                       // Key to sort by (defaults to Key if not set)
 	Render    func(row map[string]any, value any) js.Value // Custom cell renderer
+	Hidden    bool                                         // Hidden until shown from the column settings
+	Abbr      string                                       // Short header screen readers repeat with each cell, e.g. "Qty"
+	RowHeader bool                                         // Cells label their rows (<th scope="row">), e.g. a name column
+
+	// Editable cells switch to an input on double-click (or Enter/F2) and
+	// are saved through TableProps.OnCellEdit
+	Editable    bool
+	EditType    InputType        // Input type for the editor (default text)
+	EditOptions []SelectOption   // Edit with a select of these options
+	Rules       []ValidationRule // Checked before an edit is saved
 }
 
 // BulkAction defines an action that can be performed on selected rows
@@ -52,9 +67,32 @@ type TableProps struct {
 	Exportable        bool                                  // Enable export dropdown
 	ExportFilename    string                                // Base filename for exports (default "export")
 	ExportColumns     []string                              // Columns to export (nil = all column keys)
+	ExportGroupBy     string                                // Data key to split XLSX exports into one sheet per value
 	EmptyState        *EmptyState                           // Custom empty state (optional)
 	EmptyTitle        string                                // Title for default empty state (optional)
 	EmptyDescription  string                                // Description for default empty state (optional)
+	ColumnSettings    bool                                  // Show a dropdown to show/hide columns
+	ReorderColumns    bool                                  // Drag headers (or Alt+Arrow keys) to reorder columns
+	ResizeColumns     bool                                  // Drag header edges to resize columns
+	PersistKey        string                                // Restore/save the column layout via prefs.Layout
+	QueryKey          string                                // Sync sort, filter, and page with the URL query via the global router
+	OnColumnsChange   func(layout TableLayout)              // Callback when columns are shown, hidden, moved, or resized
+	Caption           string                                // Title of the table, rendered as its <caption>
+	HideCaption       bool                                  // Keep the caption for screen readers only
+	Summary           string                                // Describes the table's layout to screen readers (aria-describedby)
+
+	// OnCellEdit saves an edit to an Editable column. The row shows the new
+	// value at once; if OnCellEdit returns an error, the old value is
+	// restored and the error shown in a toast. It runs in a goroutine, so
+	// it can call the server.
+	OnCellEdit func(row map[string]any, key string, oldValue, newValue any) error
+
+	// LoadPage loads rows a page at a time from a cursor-paginated API
+	// instead of Data. The table loads the first page, then shows a "Load
+	// more" button while there are more; Paginated is ignored. Filtering
+	// and sorting apply to the loaded rows, so call Reload after changing
+	// a server-side query. Wrap a generated client method with CursorRows.
+	LoadPage func(ctx context.Context, cursor string) (rows []map[string]any, next string, err error)
 }
 
 // Table creates a data table component
@@ -62,18 +100,18 @@ type Table struct {
 	container       js.Value
 	tbody           js.Value
 	thead           js.Value
-	columns         []TableColumn
+	columns         []TableColumn   // All columns, in display order
+	hiddenColumns   map[string]bool // Column IDs hidden by the user
 	props           TableProps
-	data            []map[string]any
+	data            []map[string]any // Rows after the filter and sort, as last rendered
 	allData         []map[string]any // Unfiltered data
 	sortColumn      string
 	sortDirection   string // "asc", "desc", or "" (none)
 	filterText      string
-	filterInput     js.Value
-	debounceTimer   js.Value    // For debouncing filter input
-	currentPage     int         // Current page (1-indexed)
-	pagination      *Pagination // Pagination component instance
-	paginationMount js.Value    // Container where pagination is mounted
+	filterInput     *SearchField
+	currentPage     int          // Current page (1-indexed)
+	pagination      *Pagination  // Pagination component instance
+	paginationMount js.Value     // Container where pagination is mounted
 	selectedKeys    map[any]bool // Set of selected row keys
 	rowCheckboxes   []js.Value   // References to row checkboxes for updates
 	selectAllCb     js.Value     // Reference to select-all checkbox
@@ -82,12 +120,28 @@ type Table struct {
 	exportDropdown  *Dropdown    // Export dropdown component
 	emptyStateEl    js.Value     // Container for empty state display
 	tableWrapper    js.Value     // Table wrapper element (to show/hide)
+	columnMenu      js.Value     // Column settings panel
+	resizing        bool         // A column is being resized
+	dragColumn      string       // ID of the column being dragged
+	listeners       listeners    // Toolbar, bulk action bar, and document listeners
+	headFuncs       listeners    // Listeners of the rendered headers
+	rowFuncs        listeners    // Listeners of the rendered rows
+	menuFuncs       listeners    // Listeners of the column settings panel
+	status          js.Value     // Live region announcing sort, filter, and page changes
+	resultCount     int          // Rows left after filtering, for announcements
+
+	cursor *cursorLoader[map[string]any] // Pages from LoadPage
 }
 
 // NewTable creates a new Table component
 func NewTable(props TableProps) *Table {
 	document := js.Global().Get("document")
 
+	// Cursor pages replace page numbers
+	if props.LoadPage != nil {
+		props.Paginated = false
+	}
+
 	// Set default PageSize if not specified
 	if props.PageSize == 0 {
 		props.PageSize = 10
@@ -118,6 +172,26 @@ func NewTable(props TableProps) *Table {
 		table.Call("setAttribute", "aria-multiselectable", "true")
 	}
 
+	// Caption names the table; the summary describes it
+	if props.Caption != "" {
+		caption := document.Call("createElement", "caption")
+		captionClass := "caption-top text-left text-sm font-semibold text-primary pb-2"
+		if props.HideCaption {
+			captionClass = "sr-only"
+		}
+		caption.Set("className", captionClass)
+		caption.Set("textContent", props.Caption)
+		table.Call("appendChild", caption)
+	}
+	if props.Summary != "" {
+		summary := document.Call("createElement", "p")
+		summary.Set("className", "sr-only")
+		summary.Set("id", "table-summary-"+js.Global().Get("crypto").Call("randomUUID").String())
+		summary.Set("textContent", props.Summary)
+		tableWrapper.Call("appendChild", summary)
+		table.Call("setAttribute", "aria-describedby", summary.Get("id"))
+	}
+
 	// Header
 	thead := document.Call("createElement", "thead")
 	thead.Set("className", "surface-raised")
@@ -135,20 +209,30 @@ func NewTable(props TableProps) *Table {
 	emptyStateEl := document.Call("createElement", "div")
 	emptyStateEl.Set("className", "hidden")
 
+	// Announces sort, filter, and page changes
+	status := document.Call("createElement", "div")
+	status.Set("className", "sr-only")
+	status.Call("setAttribute", "role", "status")
+	status.Call("setAttribute", "aria-live", "polite")
+	status.Call("setAttribute", "aria-atomic", "true")
+
 	t := &Table{
 		container:    container,
 		tbody:        tbody,
 		thead:        thead,
-		columns:      props.Columns,
 		props:        props,
 		currentPage:  1,
 		selectedKeys: make(map[any]bool),
 		tableWrapper: tableWrapper,
 		emptyStateEl: emptyStateEl,
+		status:       status,
 	}
 
-	// Add toolbar if Filterable or Exportable
-	if props.Filterable || props.Exportable {
+	// Column order, visibility, and widths, restored from PersistKey
+	t.initColumns()
+
+	// Add toolbar if Filterable, Exportable, or ColumnSettings
+	if props.Filterable || props.Exportable || props.ColumnSettings {
 		toolbar := t.createToolbar(document)
 		container.Call("appendChild", toolbar)
 	}
@@ -161,6 +245,7 @@ func NewTable(props TableProps) *Table {
 
 	container.Call("appendChild", tableWrapper)
 	container.Call("appendChild", emptyStateEl)
+	container.Call("appendChild", status)
 
 	// Add pagination container if Paginated
 	if props.Paginated {
@@ -170,16 +255,29 @@ func NewTable(props TableProps) *Table {
 		t.paginationMount = paginationMount
 	}
 
+	// Add the "Load more" footer if loading pages by cursor
+	if props.LoadPage != nil {
+		t.cursor = newCursorLoader(props.LoadPage, true, t.addPage)
+		container.Call("appendChild", t.cursor.footer)
+	}
+
+	// Sort, filter, and page, restored from the URL query
+	t.initQuery()
+
 	// Render headers (with sort indicators)
 	t.renderHeaders()
 
 	// Render initial data
 	t.SetData(props.Data)
+	if t.cursor != nil {
+		t.cursor.reload()
+	}
 
+	onUnmount(container, t.Unmount)
 	return t
 }
 
-// createToolbar creates the toolbar containing filter and export dropdown
+// createToolbar creates the toolbar containing filter, column settings, and export dropdown
 func (t *Table) createToolbar(document js.Value) js.Value {
 	toolbar := document.Call("createElement", "div")
 	toolbar.Set("className", "flex items-center gap-4 mb-4")
@@ -190,6 +288,11 @@ func (t *Table) createToolbar(document js.Value) js.Value {
 		toolbar.Call("appendChild", filterContainer)
 	}
 
+	// Add column settings if ColumnSettings
+	if t.props.ColumnSettings {
+		toolbar.Call("appendChild", t.createColumnSettings(document))
+	}
+
 	// Add export dropdown if Exportable
 	if t.props.Exportable {
 		exportDropdown := t.createExportDropdown()
@@ -200,7 +303,7 @@ func (t *Table) createToolbar(document js.Value) js.Value {
 	return toolbar
 }
 
-// createExportDropdown creates the export dropdown with CSV/JSON/PDF options
+// createExportDropdown creates the export dropdown with CSV/JSON/Excel/PDF options
 func (t *Table) createExportDropdown() *Dropdown {
 	return NewDropdown(DropdownProps{
 		Trigger: Button(ButtonProps{
@@ -223,6 +326,13 @@ func (t *Table) createExportDropdown() *Dropdown {
 					t.exportData("json")
 				},
 			},
+			{
+				Label: "Excel",
+				Icon:  "📊",
+				OnClick: func() {
+					t.exportData("xlsx")
+				},
+			},
 			{
 				Label: "PDF",
 				Icon:  "📑",
@@ -262,10 +372,9 @@ func (t *Table) exportData(format string) {
 	// Determine columns to export
 	columns := t.props.ExportColumns
 	if len(columns) == 0 {
-		// Use all column keys from table definition
-		columns = make([]string, len(t.columns))
-		for i, col := range t.columns {
-			columns[i] = col.Key
+		// Use the visible column keys, in display order
+		for _, col := range t.visibleColumns() {
+			columns = append(columns, col.Key)
 		}
 	}
 
@@ -275,66 +384,56 @@ func (t *Table) exportData(format string) {
 		ExportCSV(dataToExport, columns, filename)
 	case "json":
 		ExportJSON(dataToExport, filename)
+	case "xlsx":
+		ExportXLSX(dataToExport, t.exportHeaders(columns), columns, filename, XLSXExportOptions{GroupBy: t.props.ExportGroupBy})
 	case "pdf":
-		// Extract headers from columns
-		headers := make([]string, len(t.columns))
-		for i, col := range t.columns {
-			headers[i] = col.Header
+		ExportPDF(dataToExport, t.exportHeaders(columns), columns, filename, PDFExportOptions{})
+	}
+}
+
+// exportHeaders returns the column headers of the data keys in columns
+func (t *Table) exportHeaders(columns []string) []string {
+	headers := make([]string, 0, len(columns))
+	for _, key := range columns {
+		header := key
+		for _, col := range t.columns {
+			if col.Key == key {
+				header = col.Header
+				break
+			}
 		}
-		ExportPDF(dataToExport, headers, columns, filename, PDFExportOptions{})
+		headers = append(headers, header)
 	}
+	return headers
 }
 
-// createFilterInput creates the filter input with search icon
+// createFilterInput creates the debounced filter SearchField
 func (t *Table) createFilterInput(document js.Value) js.Value {
-	filterContainer := document.Call("createElement", "div")
-	filterContainer.Set("className", "relative flex-1")
-
-	// Search icon
-	iconSpan := document.Call("createElement", "span")
-	iconSpan.Set("className", "absolute left-3 top-1/2 -translate-y-1/2 icon-muted pointer-events-none")
-	iconSpan.Set("textContent", "🔍")
-	filterContainer.Call("appendChild", iconSpan)
-
-	// Input field
-	input := document.Call("createElement", "input")
-	input.Set("type", "text")
 	placeholder := t.props.FilterPlaceholder
 	if placeholder == "" {
-		placeholder = "Search..."
+		placeholder = i18n.T("gux.table.search")
 	}
-	input.Set("placeholder", placeholder)
-	input.Set("className", "w-full pl-10 pr-4 py-2 border border-default surface-base text-primary rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 placeholder:text-tertiary")
 
-	// Debounced input handler
-	input.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
-		value := input.Get("value").String()
-
-		// Clear previous timer
-		if !t.debounceTimer.IsUndefined() && !t.debounceTimer.IsNull() {
-			js.Global().Call("clearTimeout", t.debounceTimer)
-		}
-
-		// Set new debounced timer (150ms)
-		t.debounceTimer = js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-			t.filterText = value
+	t.filterInput = NewSearchField(SearchFieldProps{
+		Placeholder: placeholder,
+		AriaLabel:   i18n.T("gux.table.filter"),
+		Debounce:    150 * time.Millisecond,
+		ClassName:   "flex-1",
+		OnSearch: func(query SearchQuery) {
+			t.filterText = query.Text
 			// Reset to page 1 when filter changes
 			t.currentPage = 1
 			if t.props.OnFilter != nil {
-				t.props.OnFilter(value)
+				t.props.OnFilter(query.Text)
 			}
+			t.syncQuery()
 			// Re-render with filter applied
 			t.renderData()
-			return nil
-		}), 150)
-
-		return nil
-	}))
-
-	filterContainer.Call("appendChild", input)
-	t.filterInput = input
+			t.announce(i18n.N("gux.table.results", t.resultCount))
+		},
+	})
 
-	return filterContainer
+	return t.filterInput.Element()
 }
 
 // createBulkActionBar creates the bulk action bar that appears when rows are selected
@@ -347,7 +446,7 @@ func (t *Table) createBulkActionBar(document js.Value) js.Value {
 	// Selected count text
 	countText := document.Call("createElement", "span")
 	countText.Set("className", "text-sm font-medium text-blue-700 dark:text-blue-300")
-	countText.Set("textContent", "0 items selected")
+	countText.Set("textContent", i18n.N("gux.table.selected", 0))
 	t.bulkActionCount = countText
 	bar.Call("appendChild", countText)
 
@@ -379,7 +478,7 @@ func (t *Table) createBulkActionBar(document js.Value) js.Value {
 
 		// Click handler
 		capturedAction := action
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		btn.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
 			if capturedAction.OnExecute != nil {
 				capturedAction.OnExecute(t.SelectedKeys())
 			}
@@ -394,8 +493,8 @@ func (t *Table) createBulkActionBar(document js.Value) js.Value {
 	// Clear selection link
 	clearLink := document.Call("createElement", "button")
 	clearLink.Set("className", "ml-auto text-sm text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-200 hover:underline")
-	clearLink.Set("textContent", "Clear selection")
-	clearLink.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	clearLink.Set("textContent", i18n.T("gux.table.clearSelection"))
+	clearLink.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
 		t.ClearSelection()
 		return nil
 	}))
@@ -418,11 +517,7 @@ func (t *Table) updateBulkActionBar() {
 		t.bulkActionBar.Set("className", newClass)
 
 		// Update count text
-		itemText := "items"
-		if count == 1 {
-			itemText = "item"
-		}
-		t.bulkActionCount.Set("textContent", toString(count)+" "+itemText+" selected")
+		t.bulkActionCount.Set("textContent", i18n.N("gux.table.selected", count))
 	} else {
 		// Hide bar
 		currentClass := t.bulkActionBar.Get("className").String()
@@ -436,13 +531,14 @@ func (t *Table) updateBulkActionBar() {
 func (t *Table) renderHeaders() {
 	document := js.Global().Get("document")
 	t.thead.Set("innerHTML", "")
+	t.headFuncs.release()
 
 	headerRow := document.Call("createElement", "tr")
 
 	// Add checkbox column header if selectable
 	if t.props.Selectable {
 		th := document.Call("createElement", "th")
-		thClass := "px-4 py-3 w-10"
+		thClass := tokenPadding("table", 1, 0.75) + " w-10"
 		if t.props.Compact {
 			thClass = "px-2 py-2 w-10"
 		}
@@ -455,13 +551,13 @@ func (t *Table) renderHeaders() {
 		checkbox := document.Call("createElement", "input")
 		checkbox.Set("type", "checkbox")
 		checkbox.Set("className", "h-4 w-4 text-blue-600 border-default rounded focus:ring-blue-500 surface-base cursor-pointer")
-		checkbox.Call("setAttribute", "aria-label", "Select all rows")
+		checkbox.Call("setAttribute", "aria-label", i18n.T("gux.table.selectAll"))
 
 		// Set initial state based on current selection
 		t.updateSelectAllState(checkbox)
 
 		// Add click handler for select-all
-		checkbox.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		checkbox.Call("addEventListener", "change", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
 			t.handleSelectAll(checkbox.Get("checked").Bool())
 			return nil
 		}))
@@ -471,9 +567,9 @@ func (t *Table) renderHeaders() {
 		headerRow.Call("appendChild", th)
 	}
 
-	for _, col := range t.columns {
+	for _, col := range t.visibleColumns() {
 		th := document.Call("createElement", "th")
-		thClass := "px-6 py-3 text-left text-xs font-medium text-tertiary uppercase tracking-wider"
+		thClass := tokenPadding("table", 1.5, 0.75) + " text-left text-xs font-medium text-tertiary uppercase tracking-wider"
 		if t.props.Compact {
 			thClass = "px-4 py-2 text-left text-xs font-medium text-tertiary uppercase tracking-wider"
 		}
@@ -483,6 +579,9 @@ func (t *Table) renderHeaders() {
 		if col.Sortable {
 			thClass += " cursor-pointer select-none hover:surface-overlay"
 		}
+		if t.props.ResizeColumns {
+			thClass += " relative"
+		}
 		if col.Width != "" {
 			th.Get("style").Set("width", col.Width)
 		}
@@ -490,6 +589,9 @@ func (t *Table) renderHeaders() {
 
 		// ARIA: scope for column header
 		th.Call("setAttribute", "scope", "col")
+		if col.Abbr != "" {
+			th.Call("setAttribute", "abbr", col.Abbr)
+		}
 
 		// Header text with sort indicator
 		headerText := col.Header
@@ -518,13 +620,20 @@ func (t *Table) renderHeaders() {
 
 			// Add click handler
 			colSortKey := sortKey // capture for closure
-			th.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			th.Call("addEventListener", "click", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
 				t.handleHeaderClick(colSortKey)
 				return nil
 			}))
 		}
 		th.Set("textContent", headerText)
 
+		if t.props.ReorderColumns {
+			t.makeReorderable(th, columnID(col))
+		}
+		if t.props.ResizeColumns {
+			th.Call("appendChild", t.createResizeHandle(document, th, col))
+		}
+
 		headerRow.Call("appendChild", th)
 	}
 	t.thead.Call("appendChild", headerRow)
@@ -556,11 +665,44 @@ func (t *Table) handleHeaderClick(sortKey string) {
 	if t.props.OnSort != nil {
 		t.props.OnSort(t.sortColumn, t.sortDirection)
 	}
+	t.syncQuery()
 
 	// Re-render data with new sort
 	if len(t.allData) > 0 {
 		t.renderData()
 	}
+	t.announceSort()
+}
+
+// announceSort announces the sort order and the number of rows, e.g.
+// "Sorted by Name ascending, 42 results"
+func (t *Table) announceSort() {
+	results := i18n.N("gux.table.results", t.resultCount)
+	if t.sortColumn == "" || t.sortDirection == "" {
+		t.announce(i18n.T("gux.table.unsorted") + ", " + results)
+		return
+	}
+	label := t.sortColumn
+	for _, col := range t.columns {
+		if col.SortKey == t.sortColumn || (col.SortKey == "" && col.Key == t.sortColumn) {
+			label = columnLabel(col)
+			break
+		}
+	}
+	direction := i18n.T("gux.table.ascending")
+	if t.sortDirection == "desc" {
+		direction = i18n.T("gux.table.descending")
+	}
+	t.announce(i18n.T("gux.table.sortedBy", label, direction) + ", " + results)
+}
+
+// announce reads message through the table's live region. It is cleared
+// first, so the same message is read again.
+func (t *Table) announce(message string) {
+	t.status.Set("textContent", "")
+	setTimeout(func() {
+		t.status.Set("textContent", message)
+	}, 100)
 }
 
 // Element returns the container DOM element
@@ -568,11 +710,28 @@ func (t *Table) Element() js.Value {
 	return t.container
 }
 
+// Mount appends the table to parent
+func (t *Table) Mount(parent js.Value) {
+	parent.Call("appendChild", t.container)
+}
+
+// Unmount cancels a page load in progress, removes the table and releases
+// its listeners
+func (t *Table) Unmount() {
+	if t.cursor != nil {
+		t.cursor.stop()
+	}
+	unmount(t.container)
+	t.listeners.release()
+	t.headFuncs.release()
+	t.rowFuncs.release()
+	t.menuFuncs.release()
+}
+
 // SetData updates the table data
 func (t *Table) SetData(data []map[string]any) {
 	// Store unfiltered data
 	t.allData = data
-	t.data = data
 
 	// Clear selection when data changes
 	t.selectedKeys = make(map[any]bool)
@@ -651,6 +810,7 @@ func (t *Table) updatePagination(totalItems int) {
 	// Reset to page 1 if current page exceeds total
 	if t.currentPage > totalPages {
 		t.currentPage = 1
+		t.syncQuery()
 	}
 
 	// Show page info - default is true if TotalItems > 0
@@ -668,13 +828,15 @@ func (t *Table) updatePagination(totalItems int) {
 			if t.props.OnPageChange != nil {
 				t.props.OnPageChange(page)
 			}
+			t.syncQuery()
 			t.renderData()
+			t.announce(i18n.T("gux.table.page", page, totalPages))
 		},
 	})
 
 	// Mount pagination
 	if !t.paginationMount.IsUndefined() && !t.paginationMount.IsNull() {
-		t.paginationMount.Set("innerHTML", "")
+		UnmountChildren(t.paginationMount)
 		t.paginationMount.Call("appendChild", t.pagination.Element())
 	}
 }
@@ -686,12 +848,22 @@ func (t *Table) renderData() {
 	// Apply filter first, then sort
 	displayData := t.filterData(t.allData)
 	displayData = t.sortData(displayData)
+	t.data = displayData
 
 	// Check for empty state conditions
 	filteredCount := len(displayData)
+	t.resultCount = filteredCount
 	hasData := len(t.allData) > 0
 	hasFilteredData := filteredCount > 0
 
+	// While the first page loads, show the headers over the footer's spinner
+	if !hasData && t.cursor != nil && t.cursor.loading {
+		t.hideEmptyState()
+		UnmountChildren(t.tbody)
+		t.rowFuncs.release()
+		return
+	}
+
 	// Handle empty states
 	if !hasFilteredData {
 		t.showEmptyState(!hasData)
@@ -707,7 +879,8 @@ func (t *Table) renderData() {
 	// Apply pagination to get current page slice
 	displayData = t.paginateData(displayData)
 
-	t.tbody.Set("innerHTML", "")
+	UnmountChildren(t.tbody)
+	t.rowFuncs.release()
 
 	// Reset row checkboxes array
 	t.rowCheckboxes = nil
@@ -716,6 +889,9 @@ func (t *Table) renderData() {
 		tr := document.Call("createElement", "tr")
 		rowKey := t.getRowKey(row)
 		isSelected := t.selectedKeys[rowKey]
+		if rowKey != nil {
+			tr.Call("setAttribute", "data-row", editText(rowKey))
+		}
 
 		rowClass := ""
 		if isSelected {
@@ -731,7 +907,7 @@ func (t *Table) renderData() {
 			rowClass += " cursor-pointer"
 			idx := i
 			rowData := row
-			tr.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			tr.Call("addEventListener", "click", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
 				t.props.OnRowClick(rowData, idx)
 				return nil
 			}))
@@ -748,7 +924,7 @@ func (t *Table) renderData() {
 			}
 
 			td := document.Call("createElement", "td")
-			tdClass := "px-4 py-4 w-10"
+			tdClass := tokenPadding("table", 1, 1) + " w-10"
 			if t.props.Compact {
 				tdClass = "px-2 py-2 w-10"
 			}
@@ -763,15 +939,15 @@ func (t *Table) renderData() {
 			checkbox.Set("checked", isSelected)
 
 			// ARIA: label for row checkbox
-			rowLabel := "Select row"
+			rowLabel := i18n.T("gux.table.selectRow")
 			if rowKey != nil {
-				rowLabel = "Select row " + toString(rowKey)
+				rowLabel += " " + toString(rowKey)
 			}
 			checkbox.Call("setAttribute", "aria-label", rowLabel)
 
 			// Capture key for closure
 			capturedKey := rowKey
-			checkbox.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+			checkbox.Call("addEventListener", "change", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
 				checked := checkbox.Get("checked").Bool()
 				t.handleRowSelection(capturedKey, checked)
 				// Re-render to update row styling
@@ -780,7 +956,7 @@ func (t *Table) renderData() {
 			}))
 
 			// Stop click propagation so row click doesn't fire
-			checkbox.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+			checkbox.Call("addEventListener", "click", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
 				args[0].Call("stopPropagation")
 				return nil
 			}))
@@ -790,22 +966,31 @@ func (t *Table) renderData() {
 			t.rowCheckboxes = append(t.rowCheckboxes, checkbox)
 		}
 
-		for _, col := range t.columns {
+		for _, col := range t.visibleColumns() {
 			td := document.Call("createElement", "td")
-			tdClass := "px-6 py-4 whitespace-nowrap text-sm text-primary"
+			tdClass := tokenPadding("table", 1.5, 1) + " whitespace-nowrap text-sm text-primary"
 			if t.props.Compact {
 				tdClass = "px-4 py-2 whitespace-nowrap text-sm text-primary"
 			}
+			if col.RowHeader {
+				// Screen readers read the row header with the row's other cells
+				td = document.Call("createElement", "th")
+				td.Call("setAttribute", "scope", "row")
+				tdClass += " text-left font-medium"
+			}
 			if t.props.Bordered {
 				tdClass += " border-b border-subtle"
 			}
+			value := row[col.Key]
+			if _, ok := value.(types.Money); ok {
+				// Amounts line up on their decimal separator
+				tdClass += " text-right tabular-nums"
+			}
 			if col.ClassName != "" {
 				tdClass = col.ClassName
 			}
 			td.Set("className", tdClass)
 
-			value := row[col.Key]
-
 			if col.Render != nil {
 				// Custom renderer
 				rendered := col.Render(row, value)
@@ -817,6 +1002,11 @@ func (t *Table) renderData() {
 				}
 			}
 
+			if col.Editable && col.Key != "" {
+				td.Call("setAttribute", "data-key", col.Key)
+				t.makeEditable(td, row, col)
+			}
+
 			tr.Call("appendChild", td)
 		}
 
@@ -874,6 +1064,16 @@ func compareValues(a, b any) int {
 		}
 	}
 
+	// Amounts of one currency compare exactly; different currencies group
+	if mA, okA := a.(types.Money); okA {
+		if mB, okB := b.(types.Money); okB {
+			if !mA.SameCurrency(mB) {
+				return strings.Compare(mA.Currency(), mB.Currency())
+			}
+			return mA.Cmp(mB)
+		}
+	}
+
 	// Try numeric comparison
 	numA := toFloat64(a)
 	numB := toFloat64(b)
@@ -926,6 +1126,7 @@ func toFloat64(v any) *float64 {
 func (t *Table) SetSort(column, direction string) {
 	t.sortColumn = column
 	t.sortDirection = direction
+	t.syncQuery()
 	t.renderHeaders()
 	if len(t.allData) > 0 {
 		t.renderData()
@@ -944,14 +1145,15 @@ func (t *Table) SetFilter(text string) {
 	t.currentPage = 1
 
 	// Update input field if it exists
-	if !t.filterInput.IsUndefined() && !t.filterInput.IsNull() {
-		t.filterInput.Set("value", text)
+	if t.filterInput != nil {
+		t.filterInput.SetValue(text)
 	}
 
 	// Notify callback
 	if t.props.OnFilter != nil {
 		t.props.OnFilter(text)
 	}
+	t.syncQuery()
 
 	// Re-render
 	if len(t.allData) > 0 {
@@ -977,6 +1179,7 @@ func (t *Table) SetPage(page int) {
 	if t.props.OnPageChange != nil {
 		t.props.OnPageChange(page)
 	}
+	t.syncQuery()
 	t.renderData()
 }
 
@@ -1196,6 +1399,8 @@ func toString(v any) string {
 			return "true"
 		}
 		return "false"
+	case types.Money:
+		return val.Format()
 	default:
 		return ""
 	}
@@ -1211,8 +1416,13 @@ func (t *Table) showEmptyState(noData bool) {
 		t.paginationMount.Set("className", "hidden")
 	}
 
-	// Clear and populate empty state container
-	t.emptyStateEl.Set("innerHTML", "")
+	// Clear and populate empty state container. A custom empty state is
+	// shown again later, so only the default ones are unmounted.
+	if t.props.EmptyState != nil {
+		t.emptyStateEl.Set("innerHTML", "")
+	} else {
+		UnmountChildren(t.emptyStateEl)
+	}
 	t.emptyStateEl.Set("className", "")
 
 	var emptyState *EmptyState
@@ -1224,11 +1434,11 @@ func (t *Table) showEmptyState(noData bool) {
 		// No data at all - show "no data" state
 		title := t.props.EmptyTitle
 		if title == "" {
-			title = "No data"
+			title = i18n.T("gux.empty.noData.title")
 		}
 		desc := t.props.EmptyDescription
 		if desc == "" {
-			desc = "There's nothing here yet."
+			desc = i18n.T("gux.empty.noData.desc")
 		}
 		emptyState = NewEmptyState(EmptyStateProps{
 			Icon:        "📭",
@@ -0,0 +1,562 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/prefs"
+)
+
+// minColumnWidth is the narrowest a column can be resized to, in pixels
+const minColumnWidth = 48
+
+// TableLayout is a user's arrangement of table columns, as saved under
+// PersistKey. Columns are identified by Key, or by Header if Key is empty.
+type TableLayout struct {
+	Order  []string          `json:"order"`            // Column IDs in display order
+	Hidden []string          `json:"hidden,omitempty"` // Column IDs hidden by the user
+	Widths map[string]string `json:"widths,omitempty"` // Resized widths, e.g. "180px"
+}
+
+// columnID identifies a column in layouts
+func columnID(col TableColumn) string {
+	if col.Key != "" {
+		return col.Key
+	}
+	return col.Header
+}
+
+// initColumns sets up the column order and visibility from the column
+// definitions and any saved layout
+func (t *Table) initColumns() {
+	t.applyLayout(TableLayout{})
+	if t.props.PersistKey == "" {
+		return
+	}
+	key := prefs.TableColumnsKey(t.props.PersistKey)
+	if !prefs.Layout.Has(key) {
+		return
+	}
+	// A layout that no longer parses is ignored rather than blocking the table
+	var layout TableLayout
+	if err := prefs.Layout.Get(key, &layout); err == nil {
+		t.applyLayout(layout)
+	}
+}
+
+// applyLayout arranges the column definitions by layout. Columns the layout
+// does not know, such as ones added since it was saved, keep their defaults
+// and go at the end.
+func (t *Table) applyLayout(layout TableLayout) {
+	defs := t.props.Columns
+	placed := make(map[string]bool, len(defs))
+	known := make(map[string]bool, len(layout.Order))
+	t.columns = make([]TableColumn, 0, len(defs))
+
+	for _, id := range layout.Order {
+		for _, col := range defs {
+			if columnID(col) == id && !placed[id] {
+				t.columns = append(t.columns, col)
+				placed[id] = true
+				known[id] = true
+			}
+		}
+	}
+	for _, col := range defs {
+		if !placed[columnID(col)] {
+			t.columns = append(t.columns, col)
+			placed[columnID(col)] = true
+		}
+	}
+
+	hidden := make(map[string]bool, len(layout.Hidden))
+	for _, id := range layout.Hidden {
+		hidden[id] = true
+	}
+	t.hiddenColumns = make(map[string]bool)
+	for i, col := range t.columns {
+		id := columnID(col)
+		if known[id] && hidden[id] || !known[id] && col.Hidden {
+			t.hiddenColumns[id] = true
+		}
+		if width, ok := layout.Widths[id]; ok {
+			t.columns[i].Width = width
+		}
+	}
+
+	// Never hide every column
+	if len(t.visibleColumns()) == 0 {
+		t.hiddenColumns = make(map[string]bool)
+	}
+}
+
+// visibleColumns returns the columns that are not hidden, in display order
+func (t *Table) visibleColumns() []TableColumn {
+	visible := make([]TableColumn, 0, len(t.columns))
+	for _, col := range t.columns {
+		if !t.hiddenColumns[columnID(col)] {
+			visible = append(visible, col)
+		}
+	}
+	return visible
+}
+
+// columnIndex returns the display position of a column, or -1
+func (t *Table) columnIndex(id string) int {
+	for i, col := range t.columns {
+		if columnID(col) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// defaultWidth returns the width a column was defined with
+func (t *Table) defaultWidth(id string) string {
+	for _, col := range t.props.Columns {
+		if columnID(col) == id {
+			return col.Width
+		}
+	}
+	return ""
+}
+
+// ColumnLayout returns the current column order, visibility, and widths
+func (t *Table) ColumnLayout() TableLayout {
+	layout := TableLayout{Order: make([]string, 0, len(t.columns))}
+	for _, col := range t.columns {
+		id := columnID(col)
+		layout.Order = append(layout.Order, id)
+		if t.hiddenColumns[id] {
+			layout.Hidden = append(layout.Hidden, id)
+		}
+		if col.Width != t.defaultWidth(id) {
+			if layout.Widths == nil {
+				layout.Widths = make(map[string]string)
+			}
+			layout.Widths[id] = col.Width
+		}
+	}
+	return layout
+}
+
+// SetColumnLayout applies a layout, such as one loaded from a server
+func (t *Table) SetColumnLayout(layout TableLayout) {
+	t.applyLayout(layout)
+	t.columnsChanged()
+}
+
+// SetColumnVisible shows or hides a column. The last visible column cannot
+// be hidden.
+func (t *Table) SetColumnVisible(key string, visible bool) {
+	if t.columnIndex(key) < 0 || t.hiddenColumns[key] == !visible {
+		return
+	}
+	if !visible && len(t.visibleColumns()) <= 1 {
+		return
+	}
+	if visible {
+		delete(t.hiddenColumns, key)
+	} else {
+		t.hiddenColumns[key] = true
+	}
+	t.columnsChanged()
+}
+
+// IsColumnVisible reports whether a column is shown
+func (t *Table) IsColumnVisible(key string) bool {
+	return t.columnIndex(key) >= 0 && !t.hiddenColumns[key]
+}
+
+// MoveColumn moves a column to a position in the display order, counting
+// hidden columns
+func (t *Table) MoveColumn(key string, index int) {
+	from := t.columnIndex(key)
+	if from < 0 {
+		return
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(t.columns) {
+		index = len(t.columns) - 1
+	}
+	if index == from {
+		return
+	}
+	col := t.columns[from]
+	t.columns = append(t.columns[:from], t.columns[from+1:]...)
+	t.columns = append(t.columns[:index], append([]TableColumn{col}, t.columns[index:]...)...)
+	t.columnsChanged()
+}
+
+// SetColumnWidth sets a column's CSS width, e.g. "180px". An empty width
+// restores the defined width.
+func (t *Table) SetColumnWidth(key, width string) {
+	i := t.columnIndex(key)
+	if i < 0 {
+		return
+	}
+	if width == "" {
+		width = t.defaultWidth(key)
+	}
+	if t.columns[i].Width == width {
+		return
+	}
+	t.columns[i].Width = width
+	t.columnsChanged()
+}
+
+// ResetColumns restores the defined column order, visibility, and widths,
+// and forgets the saved layout
+func (t *Table) ResetColumns() {
+	t.applyLayout(TableLayout{})
+	if t.props.PersistKey != "" {
+		prefs.Layout.Remove(prefs.TableColumnsKey(t.props.PersistKey))
+	}
+	t.refreshColumns()
+	if t.props.OnColumnsChange != nil {
+		t.props.OnColumnsChange(t.ColumnLayout())
+	}
+}
+
+// columnsChanged saves the layout, notifies, and re-renders
+func (t *Table) columnsChanged() {
+	layout := t.ColumnLayout()
+	if t.props.PersistKey != "" {
+		prefs.Layout.Set(prefs.TableColumnsKey(t.props.PersistKey), layout)
+	}
+	t.refreshColumns()
+	if t.props.OnColumnsChange != nil {
+		t.props.OnColumnsChange(layout)
+	}
+}
+
+func (t *Table) refreshColumns() {
+	t.renderHeaders()
+	t.renderData()
+	t.renderColumnMenu()
+}
+
+// createColumnSettings creates the button and panel for showing and hiding columns
+func (t *Table) createColumnSettings(document js.Value) js.Value {
+	container := document.Call("createElement", "div")
+	container.Set("className", "relative inline-block")
+
+	menuID := "table-columns-" + js.Global().Get("crypto").Call("randomUUID").String()
+
+	trigger := Button(ButtonProps{
+		Text:    i18n.T("gux.table.columns") + " ▼",
+		Variant: ButtonSecondary,
+		Size:    ButtonSM,
+	})
+	trigger.Call("setAttribute", "aria-haspopup", "true")
+	trigger.Call("setAttribute", "aria-expanded", "false")
+	trigger.Call("setAttribute", "aria-controls", menuID)
+	container.Call("appendChild", trigger)
+
+	menu := document.Call("createElement", "div")
+	menu.Set("id", menuID)
+	menu.Set("className", "absolute right-0 mt-2 surface-base rounded-md shadow-lg border border-subtle py-1 z-50 hidden")
+	menu.Get("style").Set("minWidth", "180px")
+	menu.Call("setAttribute", "role", "group")
+	menu.Call("setAttribute", "aria-label", i18n.T("gux.table.columns"))
+	container.Call("appendChild", menu)
+	t.columnMenu = menu
+
+	setOpen := func(open bool) {
+		if open {
+			menu.Get("classList").Call("remove", "hidden")
+		} else {
+			menu.Get("classList").Call("add", "hidden")
+		}
+		trigger.Call("setAttribute", "aria-expanded", strconv.FormatBool(open))
+	}
+	isOpen := func() bool {
+		return !menu.Get("classList").Call("contains", "hidden").Bool()
+	}
+
+	trigger.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		setOpen(!isOpen())
+		return nil
+	}))
+
+	// Escape closes and returns focus to the button
+	menu.Call("addEventListener", "keydown", t.listeners.fn(func(this js.Value, args []js.Value) any {
+		if args[0].Get("key").String() == "Escape" {
+			setOpen(false)
+			trigger.Call("focus")
+		}
+		return nil
+	}))
+
+	// Close on outside click; the panel stays open while toggling columns
+	t.listeners.on(document, "click", func(this js.Value, args []js.Value) any {
+		if isOpen() && !container.Call("contains", args[0].Get("target")).Bool() {
+			setOpen(false)
+		}
+		return nil
+	})
+
+	t.renderColumnMenu()
+	return container
+}
+
+// renderColumnMenu fills the column settings panel with a checkbox per column
+func (t *Table) renderColumnMenu() {
+	if t.columnMenu.IsUndefined() || t.columnMenu.IsNull() {
+		return
+	}
+	document := js.Global().Get("document")
+	t.columnMenu.Set("innerHTML", "")
+	t.menuFuncs.release()
+
+	lastVisible := len(t.visibleColumns()) <= 1
+	for _, col := range t.columns {
+		id := columnID(col)
+		visible := !t.hiddenColumns[id]
+
+		label := document.Call("createElement", "label")
+		label.Set("className", "flex items-center gap-2 px-4 py-2 text-sm text-secondary hover:surface-overlay cursor-pointer")
+
+		checkbox := document.Call("createElement", "input")
+		checkbox.Set("type", "checkbox")
+		checkbox.Set("className", "h-4 w-4 text-blue-600 border-default rounded focus:ring-blue-500 surface-base cursor-pointer")
+		checkbox.Set("checked", visible)
+		// Keep at least one column on screen
+		checkbox.Set("disabled", visible && lastVisible)
+		checkbox.Call("addEventListener", "change", t.menuFuncs.fn(func(this js.Value, args []js.Value) any {
+			t.SetColumnVisible(id, checkbox.Get("checked").Bool())
+			return nil
+		}))
+		label.Call("appendChild", checkbox)
+
+		text := document.Call("createElement", "span")
+		text.Set("textContent", columnLabel(col))
+		label.Call("appendChild", text)
+
+		t.columnMenu.Call("appendChild", label)
+	}
+
+	divider := document.Call("createElement", "div")
+	divider.Set("className", "border-t border-subtle my-1")
+	t.columnMenu.Call("appendChild", divider)
+
+	reset := document.Call("createElement", "button")
+	reset.Set("type", "button")
+	reset.Set("className", "w-full text-left px-4 py-2 text-sm text-blue-600 dark:text-blue-400 hover:surface-overlay cursor-pointer")
+	reset.Set("textContent", i18n.T("gux.table.resetColumns"))
+	reset.Call("addEventListener", "click", t.menuFuncs.fn(func(this js.Value, args []js.Value) any {
+		t.ResetColumns()
+		return nil
+	}))
+	t.columnMenu.Call("appendChild", reset)
+}
+
+// columnLabel names a column in menus and announcements
+func columnLabel(col TableColumn) string {
+	if col.Header != "" {
+		return col.Header
+	}
+	return col.Key
+}
+
+// makeReorderable lets a header be dragged onto another header, or moved
+// with Alt+ArrowLeft and Alt+ArrowRight
+func (t *Table) makeReorderable(th js.Value, id string) {
+	th.Set("draggable", true)
+	th.Call("setAttribute", "data-column", id)
+	th.Call("setAttribute", "tabindex", "0")
+	th.Call("setAttribute", "aria-keyshortcuts", "Alt+ArrowLeft Alt+ArrowRight")
+
+	th.Call("addEventListener", "dragstart", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if t.resizing {
+			event.Call("preventDefault")
+			return nil
+		}
+		t.dragColumn = id
+		event.Get("dataTransfer").Set("effectAllowed", "move")
+		event.Get("dataTransfer").Call("setData", "text/plain", id)
+		th.Get("classList").Call("add", "opacity-50")
+		return nil
+	}))
+
+	th.Call("addEventListener", "dragend", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		t.dragColumn = ""
+		th.Get("classList").Call("remove", "opacity-50")
+		return nil
+	}))
+
+	th.Call("addEventListener", "dragover", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		if t.dragColumn == "" || t.dragColumn == id {
+			return nil
+		}
+		event := args[0]
+		event.Call("preventDefault")
+		event.Get("dataTransfer").Set("dropEffect", "move")
+		th.Get("classList").Call("add", "surface-overlay")
+		return nil
+	}))
+
+	th.Call("addEventListener", "dragleave", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		th.Get("classList").Call("remove", "surface-overlay")
+		return nil
+	}))
+
+	th.Call("addEventListener", "drop", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("preventDefault")
+		dragged := t.dragColumn
+		t.dragColumn = ""
+		if dragged != "" && dragged != id {
+			t.MoveColumn(dragged, t.columnIndex(id))
+		}
+		return nil
+	}))
+
+	th.Call("addEventListener", "keydown", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if !event.Get("altKey").Bool() {
+			return nil
+		}
+		step := 0
+		switch event.Get("key").String() {
+		case "ArrowLeft":
+			step = -1
+		case "ArrowRight":
+			step = 1
+		default:
+			return nil
+		}
+		event.Call("preventDefault")
+		t.moveColumnBy(id, step)
+		return nil
+	}))
+}
+
+// moveColumnBy moves a column past its visible neighbour, keeps focus on its
+// header, and announces the new position
+func (t *Table) moveColumnBy(id string, step int) {
+	visible := t.visibleColumns()
+	pos := -1
+	for i, col := range visible {
+		if columnID(col) == id {
+			pos = i
+		}
+	}
+	target := pos + step
+	if pos < 0 || target < 0 || target >= len(visible) {
+		return
+	}
+	t.MoveColumn(id, t.columnIndex(columnID(visible[target])))
+
+	if th := t.headerElement("data-column", id); !th.IsNull() {
+		th.Call("focus")
+	}
+	Announce(i18n.T("gux.table.columnMoved", columnLabel(visible[pos]), target+1))
+}
+
+// headerElement finds the header element whose attr is the column ID, or null
+func (t *Table) headerElement(attr, id string) js.Value {
+	escaped := js.Global().Get("CSS").Call("escape", id).String()
+	return t.thead.Call("querySelector", "["+attr+"=\""+escaped+"\"]")
+}
+
+// createResizeHandle creates the drag handle on a header's right edge.
+// Double-click restores the defined width; arrow keys resize by 16px.
+func (t *Table) createResizeHandle(document js.Value, th js.Value, col TableColumn) js.Value {
+	id := columnID(col)
+
+	handle := document.Call("createElement", "div")
+	handle.Set("className", "absolute top-0 right-0 h-full w-1.5 cursor-col-resize select-none hover:bg-blue-500/50 focus:bg-blue-500/50 focus:outline-none")
+	handle.Call("setAttribute", "role", "separator")
+	handle.Call("setAttribute", "aria-orientation", "vertical")
+	handle.Call("setAttribute", "aria-label", i18n.T("gux.table.resizeColumn", columnLabel(col)))
+	handle.Call("setAttribute", "tabindex", "0")
+	handle.Call("setAttribute", "data-resize", id)
+
+	var startX, startWidth float64
+	active := false
+
+	handle.Call("addEventListener", "pointerdown", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if event.Get("button").Int() != 0 {
+			return nil
+		}
+		event.Call("preventDefault")
+		event.Call("stopPropagation")
+		active = true
+		t.resizing = true
+		startX = event.Get("clientX").Float()
+		startWidth = th.Get("offsetWidth").Float()
+		handle.Call("setPointerCapture", event.Get("pointerId"))
+		return nil
+	}))
+
+	handle.Call("addEventListener", "pointermove", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		if !active {
+			return nil
+		}
+		width := startWidth + args[0].Get("clientX").Float() - startX
+		if width < minColumnWidth {
+			width = minColumnWidth
+		}
+		th.Get("style").Set("width", strconv.Itoa(int(width))+"px")
+		return nil
+	}))
+
+	endResize := t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		if !active {
+			return nil
+		}
+		active = false
+		t.resizing = false
+		t.SetColumnWidth(id, th.Get("style").Get("width").String())
+		return nil
+	})
+	handle.Call("addEventListener", "pointerup", endResize)
+	handle.Call("addEventListener", "pointercancel", endResize)
+
+	// Don't let a resize sort the column
+	handle.Call("addEventListener", "click", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		return nil
+	}))
+
+	handle.Call("addEventListener", "dblclick", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		t.SetColumnWidth(id, "")
+		return nil
+	}))
+
+	handle.Call("addEventListener", "keydown", t.headFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		delta := 0
+		switch event.Get("key").String() {
+		case "ArrowLeft":
+			delta = -16
+		case "ArrowRight":
+			delta = 16
+		default:
+			return nil
+		}
+		event.Call("preventDefault")
+		event.Call("stopPropagation")
+		width := int(th.Get("offsetWidth").Float()) + delta
+		if width < minColumnWidth {
+			width = minColumnWidth
+		}
+		t.SetColumnWidth(id, strconv.Itoa(width)+"px")
+		// The header was re-rendered, so focus its new handle
+		if next := t.headerElement("data-resize", id); !next.IsNull() {
+			next.Call("focus")
+		}
+		return nil
+	}))
+
+	return handle
+}
@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+package components
+
+// addPage shows a page of rows from LoadPage, replacing the rows for the
+// first page after a reload
+func (t *Table) addPage(rows []map[string]any, reset bool) {
+	if reset {
+		t.allData = rows
+		t.selectedKeys = make(map[any]bool)
+	} else {
+		t.allData = append(t.allData, rows...)
+	}
+	// renderData filters and sorts the new rows with the others
+	t.renderData()
+}
+
+// Reload loads LoadPage again from the first page, for example after the
+// server-side sort or filter changes. The loaded rows stay until the first
+// page replaces them.
+func (t *Table) Reload() {
+	if t.cursor != nil {
+		t.cursor.reload()
+	}
+}
+
+// LoadMore loads the next page from LoadPage, as the "Load more" button
+// does. It does nothing while a page is loading or after the last page.
+func (t *Table) LoadMore() {
+	if t.cursor != nil {
+		t.cursor.more()
+	}
+}
+
+// HasMore reports whether LoadPage has more pages to load
+func (t *Table) HasMore() bool {
+	return t.cursor != nil && !t.cursor.done
+}
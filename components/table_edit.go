@@ -0,0 +1,250 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/types"
+)
+
+// makeEditable lets a cell be edited by double-clicking it, or by pressing
+// Enter or F2 when it has focus
+func (t *Table) makeEditable(td js.Value, row map[string]any, col TableColumn) {
+	td.Call("setAttribute", "tabindex", "0")
+	td.Call("setAttribute", "aria-description", i18n.T("gux.table.editHint"))
+	td.Get("classList").Call("add", "cursor-text")
+
+	td.Call("addEventListener", "dblclick", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		t.startEdit(td, row, col)
+		return nil
+	}))
+	td.Call("addEventListener", "keydown", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if !event.Get("target").Equal(td) {
+			return nil
+		}
+		switch event.Get("key").String() {
+		case "Enter", "F2":
+			event.Call("preventDefault")
+			t.startEdit(td, row, col)
+		}
+		return nil
+	}))
+}
+
+// startEdit replaces the cell's content with an input, or a select when the
+// column has EditOptions
+func (t *Table) startEdit(td js.Value, row map[string]any, col TableColumn) {
+	document := js.Global().Get("document")
+	oldValue := row[col.Key]
+
+	td.Set("innerHTML", "")
+
+	var editor js.Value
+	if len(col.EditOptions) > 0 {
+		editor = document.Call("createElement", "select")
+		for _, opt := range col.EditOptions {
+			option := document.Call("createElement", "option")
+			option.Set("value", opt.Value)
+			option.Set("textContent", opt.Label)
+			if opt.Value == editText(oldValue) {
+				option.Set("selected", true)
+			}
+			editor.Call("appendChild", option)
+		}
+	} else {
+		editor = document.Call("createElement", "input")
+		editType := col.EditType
+		if editType == "" {
+			editType = InputText
+		}
+		editor.Set("type", string(editType))
+		editor.Set("value", editText(oldValue))
+	}
+	editor.Set("className", tableEditorClass)
+	editor.Call("setAttribute", "aria-label", col.Header)
+	td.Call("appendChild", editor)
+
+	errorEl := document.Call("createElement", "p")
+	errorEl.Set("id", "table-edit-error-"+js.Global().Get("crypto").Call("randomUUID").String())
+	errorEl.Set("className", "mt-1 text-xs text-red-600 dark:text-red-400 whitespace-normal hidden")
+	td.Call("appendChild", errorEl)
+
+	// refocus returns focus to the cell when the edit ends from the keyboard
+	done := false
+	finish := func(save, refocus bool) {
+		if done {
+			return
+		}
+		if !save {
+			done = true
+			t.renderData()
+			if refocus {
+				t.focusCell(row, col)
+			}
+			return
+		}
+		text := editor.Get("value").String()
+		newValue, msg := t.parseEdit(col, oldValue, text)
+		if msg != "" {
+			editor.Set("className", tableEditorErrorClass)
+			editor.Call("setAttribute", "aria-invalid", "true")
+			editor.Call("setAttribute", "aria-describedby", errorEl.Get("id").String())
+			errorEl.Set("textContent", msg)
+			errorEl.Get("classList").Call("remove", "hidden")
+			return
+		}
+		done = true
+		t.saveEdit(row, col, oldValue, newValue)
+		if refocus {
+			t.focusCell(row, col)
+		}
+	}
+
+	// Clicks in the editor shouldn't select or open the row, or restart the edit
+	stop := t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		return nil
+	})
+	editor.Call("addEventListener", "click", stop)
+	editor.Call("addEventListener", "dblclick", stop)
+	editor.Call("addEventListener", "keydown", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		switch event.Get("key").String() {
+		case "Enter":
+			event.Call("preventDefault")
+			finish(true, true)
+		case "Escape":
+			event.Call("preventDefault")
+			event.Call("stopPropagation")
+			finish(false, true)
+		}
+		return nil
+	}))
+	// Leaving the cell saves; an invalid value keeps the editor open with
+	// its message
+	editor.Call("addEventListener", "blur", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+		finish(true, false)
+		return nil
+	}))
+	if len(col.EditOptions) > 0 {
+		editor.Call("addEventListener", "change", t.rowFuncs.fn(func(this js.Value, args []js.Value) any {
+			finish(true, true)
+			return nil
+		}))
+	}
+
+	editor.Call("focus")
+	if len(col.EditOptions) == 0 {
+		editor.Call("select")
+	}
+}
+
+const (
+	tableEditorClass      = "w-full px-2 py-1 text-sm border border-default rounded surface-base text-primary focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500"
+	tableEditorErrorClass = "w-full px-2 py-1 text-sm border border-red-500 rounded surface-base text-primary focus:outline-none focus:ring-2 focus:ring-red-500 focus:border-red-500"
+)
+
+// parseEdit validates text against the column's rules and converts it to
+// the type of the old value. It returns a message if the text is rejected.
+func (t *Table) parseEdit(col TableColumn, oldValue any, text string) (any, string) {
+	for _, rule := range col.Rules {
+		if !rule.Validate(text) {
+			return nil, rule.Text()
+		}
+	}
+
+	invalid := i18n.T("gux.table.invalidValue")
+	switch old := oldValue.(type) {
+	case int:
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, invalid
+		}
+		return n, ""
+	case int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return n, ""
+	case float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return f, ""
+	case types.Money:
+		m, err := types.ParseIn(text, old.Currency(), i18n.CurrentFormat())
+		if err != nil {
+			return nil, invalid
+		}
+		return m, ""
+	case bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, invalid
+		}
+		return b, ""
+	}
+	return text, ""
+}
+
+// saveEdit applies an edit at once and calls OnCellEdit. If it returns an
+// error, the old value is put back, unless the cell was edited again.
+func (t *Table) saveEdit(row map[string]any, col TableColumn, oldValue, newValue any) {
+	if oldValue == newValue {
+		t.renderData()
+		return
+	}
+	row[col.Key] = newValue
+	t.renderData()
+
+	if t.props.OnCellEdit == nil {
+		return
+	}
+	// OnCellEdit usually calls the server, which blocks, so it can't run on
+	// the event callback
+	go func() {
+		err := t.props.OnCellEdit(row, col.Key, oldValue, newValue)
+		if err == nil {
+			return
+		}
+		if row[col.Key] == newValue {
+			row[col.Key] = oldValue
+			t.renderData()
+		}
+		ShowError(i18n.T("gux.table.saveFailed", columnLabel(col), err.Error()))
+	}()
+}
+
+// focusCell moves focus to a row's cell after the body was re-rendered
+func (t *Table) focusCell(row map[string]any, col TableColumn) {
+	key := t.getRowKey(row)
+	if key == nil {
+		return
+	}
+	css := js.Global().Get("CSS")
+	rowSel := "[data-row=\"" + css.Call("escape", editText(key)).String() + "\"]"
+	keySel := "[data-key=\"" + css.Call("escape", col.Key).String() + "\"]"
+	cell := t.tbody.Call("querySelector", rowSel+" "+keySel)
+	if !cell.IsNull() {
+		cell.Call("focus")
+	}
+}
+
+// editText formats a value for an editor
+func editText(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case types.Money:
+		return val.AmountIn(i18n.CurrentFormat())
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,109 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// tableQuery is a table's sort, filter, and page as kept in the URL query
+// under QueryKey: <key>.sort is the sort column, prefixed with "-" when
+// descending, <key>.q the filter text, and <key>.page the page after the
+// first.
+type tableQuery struct {
+	sortColumn    string
+	sortDirection string
+	filter        string
+	page          int
+}
+
+func parseTableQuery(query url.Values, key string) tableQuery {
+	q := tableQuery{filter: query.Get(key + ".q"), page: 1}
+	if sort := query.Get(key + ".sort"); sort != "" {
+		q.sortColumn, q.sortDirection = sort, "asc"
+		if desc, ok := strings.CutPrefix(sort, "-"); ok {
+			q.sortColumn, q.sortDirection = desc, "desc"
+		}
+	}
+	if page, err := strconv.Atoi(query.Get(key + ".page")); err == nil && page > 1 {
+		q.page = page
+	}
+	return q
+}
+
+// query returns the table's current sort, filter, and page
+func (t *Table) query() tableQuery {
+	return tableQuery{
+		sortColumn:    t.sortColumn,
+		sortDirection: t.sortDirection,
+		filter:        t.filterText,
+		page:          t.currentPage,
+	}
+}
+
+// initQuery restores the sort, filter, and page from the URL query and
+// follows changes to it, such as back/forward
+func (t *Table) initQuery() {
+	router := GetGlobalRouter()
+	if t.props.QueryKey == "" || router == nil {
+		return
+	}
+	q := parseTableQuery(router.Query(), t.props.QueryKey)
+	t.sortColumn, t.sortDirection = q.sortColumn, q.sortDirection
+	t.filterText, t.currentPage = q.filter, q.page
+	if t.filterInput != nil {
+		t.filterInput.SetValue(q.filter)
+	}
+	t.listeners.onRelease(router.OnQueryChange(t.applyQuery))
+}
+
+// applyQuery shows the sort, filter, and page from a changed URL query,
+// notifying the OnSort, OnFilter, and OnPageChange callbacks of what changed
+func (t *Table) applyQuery(query url.Values) {
+	q, old := parseTableQuery(query, t.props.QueryKey), t.query()
+	if q == old {
+		return
+	}
+	t.sortColumn, t.sortDirection = q.sortColumn, q.sortDirection
+	t.filterText, t.currentPage = q.filter, q.page
+	if t.filterInput != nil && q.filter != old.filter {
+		t.filterInput.SetValue(q.filter)
+	}
+
+	if (q.sortColumn != old.sortColumn || q.sortDirection != old.sortDirection) && t.props.OnSort != nil {
+		t.props.OnSort(q.sortColumn, q.sortDirection)
+	}
+	if q.filter != old.filter && t.props.OnFilter != nil {
+		t.props.OnFilter(q.filter)
+	}
+	if q.page != old.page && t.props.OnPageChange != nil {
+		t.props.OnPageChange(q.page)
+	}
+	t.renderHeaders()
+	t.renderData()
+}
+
+// syncQuery writes the sort, filter, and page to the URL query, replacing
+// the history entry
+func (t *Table) syncQuery() {
+	router := GetGlobalRouter()
+	if t.props.QueryKey == "" || router == nil {
+		return
+	}
+	key := t.props.QueryKey
+	sort := t.sortColumn
+	if sort != "" && t.sortDirection == "desc" {
+		sort = "-" + sort
+	}
+	page := ""
+	if t.currentPage > 1 {
+		page = strconv.Itoa(t.currentPage)
+	}
+	router.SetQuery(map[string]string{
+		key + ".sort": sort,
+		key + ".q":    t.filterText,
+		key + ".page": page,
+	})
+}
@@ -0,0 +1,63 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+func TestTableSortByName(t *testing.T) {
+	table := components.NewTable(components.TableProps{
+		Columns: []components.TableColumn{{Header: "Name", Key: "name", Sortable: true}},
+		Data:    []map[string]any{{"name": "Bea"}, {"name": "Al"}},
+	})
+	root := testutil.Render(t, table)
+
+	testutil.Click(testutil.QueryText(t, root, "th", "Name"))
+
+	testutil.AssertText(t, testutil.Query(t, root, "tbody td"), "Al")
+	testutil.AssertAttr(t, testutil.Query(t, root, "th"), "aria-sort", "ascending")
+
+	testutil.Click(testutil.QueryText(t, root, "th", "Name"))
+
+	testutil.AssertText(t, testutil.Query(t, root, "tbody td"), "Bea")
+	testutil.AssertAttr(t, testutil.Query(t, root, "th"), "aria-sort", "descending")
+}
+
+func TestTableLoadMoreKeepsFilterAndSort(t *testing.T) {
+	pages := map[string][]map[string]any{
+		"":  {{"name": "Cy"}, {"name": "Al"}, {"name": "Bo"}},
+		"2": {{"name": "Ava"}, {"name": "Dee"}},
+	}
+	table := components.NewTable(components.TableProps{
+		Columns: []components.TableColumn{{Header: "Name", Key: "name", Sortable: true}},
+		LoadPage: func(ctx context.Context, cursor string) ([]map[string]any, string, error) {
+			next := ""
+			if cursor == "" {
+				next = "2"
+			}
+			return pages[cursor], next, nil
+		},
+	})
+	root := testutil.Render(t, table)
+	testutil.WaitFor(t, func() bool { return len(testutil.QueryAll(root, "tbody td")) == 3 }, time.Second)
+
+	table.SetSort("name", "desc")
+	table.SetFilter("a")
+	table.LoadMore()
+	testutil.WaitFor(t, func() bool { return !table.HasMore() }, time.Second)
+
+	var names []string
+	for _, td := range testutil.QueryAll(root, "tbody td") {
+		names = append(names, testutil.Text(td))
+	}
+	if want := []string{"Ava", "Al"}; !slices.Equal(names, want) {
+		t.Errorf("rows = %v, want %v", names, want)
+	}
+}
@@ -33,6 +33,7 @@ type Tabs struct {
 	activeIndex int
 	props       TabsProps
 	keyHandler  js.Func // keyboard navigation handler
+	listeners   listeners
 }
 
 // NewTabs creates a new Tabs component
@@ -93,7 +94,7 @@ func NewTabs(props TabsProps) *Tabs {
 		}
 
 		idx := i
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		btn.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
 			t.SetActive(idx)
 			return nil
 		}))
@@ -105,7 +106,7 @@ func NewTabs(props TabsProps) *Tabs {
 	t.tabNav = tabNav
 
 	// Keyboard navigation handler - WAI-ARIA Tabs pattern
-	t.keyHandler = js.FuncOf(func(this js.Value, args []js.Value) any {
+	t.keyHandler = t.listeners.fn(func(this js.Value, args []js.Value) any {
 		event := args[0]
 		key := event.Get("key").String()
 
@@ -167,6 +168,7 @@ func NewTabs(props TabsProps) *Tabs {
 	// Set initial active state
 	t.updateStyles()
 
+	onUnmount(t.container, t.Unmount)
 	return t
 }
 
@@ -175,6 +177,17 @@ func (t *Tabs) Element() js.Value {
 	return t.container
 }
 
+// Mount appends the tabs to parent
+func (t *Tabs) Mount(parent js.Value) {
+	parent.Call("appendChild", t.container)
+}
+
+// Unmount removes the tabs and releases its listeners
+func (t *Tabs) Unmount() {
+	unmount(t.container)
+	t.listeners.release()
+}
+
 // SetActive sets the active tab by index
 func (t *Tabs) SetActive(index int) {
 	if index < 0 || index >= len(t.tabButtons) {
@@ -227,6 +240,6 @@ func (t *Tabs) SetTabContent(index int, content js.Value) {
 		return
 	}
 
-	t.tabPanels[index].Set("innerHTML", "")
+	UnmountChildren(t.tabPanels[index])
 	t.tabPanels[index].Call("appendChild", content)
 }
@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+func TestTabsSelect(t *testing.T) {
+	var changed []int
+	tabs := components.NewTabs(components.TabsProps{
+		Tabs: []components.Tab{
+			{Label: "Profile"},
+			{Label: "Billing"},
+		},
+		OnChange: func(index int) { changed = append(changed, index) },
+	})
+	root := testutil.Render(t, tabs)
+
+	testutil.Click(testutil.QueryText(t, root, "button", "Billing"))
+
+	testutil.AssertAttr(t, testutil.QueryText(t, root, "button", "Billing"), "aria-selected", "true")
+	testutil.AssertAttr(t, testutil.QueryText(t, root, "button", "Profile"), "aria-selected", "false")
+	if len(changed) != 1 || changed[0] != 1 {
+		t.Errorf("OnChange calls = %v, want [1]", changed)
+	}
+}
+
+func TestTabsArrowKeys(t *testing.T) {
+	tabs := components.NewTabs(components.TabsProps{
+		Tabs: []components.Tab{{Label: "One"}, {Label: "Two"}, {Label: "Three"}},
+	})
+	root := testutil.Render(t, tabs)
+
+	testutil.KeyDown(testutil.Query(t, root, "[role=tablist]"), "ArrowLeft")
+
+	testutil.AssertAttr(t, testutil.QueryText(t, root, "button", "Three"), "aria-selected", "true")
+}
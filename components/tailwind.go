@@ -6,10 +6,19 @@ import "syscall/js"
 
 // LoadTailwind injects Tailwind CSS into the document head.
 // This blocks until Tailwind is fully loaded to ensure styles are applied.
+//
+// Pages built by gux build link a purged stylesheet generated with the
+// Tailwind CLI, marked data-gux-tailwind; with it, the CDN script, which is
+// meant for development, isn't loaded.
 func LoadTailwind() {
 	document := js.Global().Get("document")
 	head := document.Get("head")
 
+	if document.Call("querySelector", "link[data-gux-tailwind]").Truthy() {
+		injectMobileStyles()
+		return
+	}
+
 	script := document.Call("createElement", "script")
 	script.Set("src", "https://cdn.tailwindcss.com")
 
@@ -35,7 +44,7 @@ func injectMobileStyles() {
 	document := js.Global().Get("document")
 	head := document.Get("head")
 
-	style := document.Call("createElement", "style")
+	style := newStyle()
 	style.Set("textContent", `
 		/* Hide scrollbar but keep scroll functionality */
 		.scrollbar-hide {
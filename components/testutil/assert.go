@@ -0,0 +1,120 @@
+//go:build js && wasm
+
+package testutil
+
+import (
+	"strings"
+	"syscall/js"
+	"testing"
+)
+
+// AssertText fails the test unless el's trimmed text is want
+func AssertText(t testing.TB, el js.Value, want string) {
+	t.Helper()
+	if got := Text(el); got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+}
+
+// AssertContainsText fails the test unless el's text contains want
+func AssertContainsText(t testing.TB, el js.Value, want string) {
+	t.Helper()
+	if got := Text(el); !strings.Contains(got, want) {
+		t.Errorf("text = %q, want it to contain %q", got, want)
+	}
+}
+
+// AssertAttr fails the test unless el's attribute name is want
+func AssertAttr(t testing.TB, el js.Value, name, want string) {
+	t.Helper()
+	got := el.Call("getAttribute", name)
+	if got.IsNull() {
+		t.Errorf("attribute %s missing, want %q", name, want)
+	} else if got.String() != want {
+		t.Errorf("attribute %s = %q, want %q", name, got.String(), want)
+	}
+}
+
+// AssertNoAttr fails the test if el has the attribute name
+func AssertNoAttr(t testing.TB, el js.Value, name string) {
+	t.Helper()
+	if got := el.Call("getAttribute", name); !got.IsNull() {
+		t.Errorf("attribute %s = %q, want none", name, got.String())
+	}
+}
+
+// AssertClass fails the test unless el has each of the classes
+func AssertClass(t testing.TB, el js.Value, classes ...string) {
+	t.Helper()
+	for _, class := range classes {
+		if !el.Get("classList").Call("contains", class).Bool() {
+			t.Errorf("class %q missing from %q", class, el.Get("className").String())
+		}
+	}
+}
+
+// AssertNoClass fails the test if el has any of the classes
+func AssertNoClass(t testing.TB, el js.Value, classes ...string) {
+	t.Helper()
+	for _, class := range classes {
+		if el.Get("classList").Call("contains", class).Bool() {
+			t.Errorf("class %q present in %q", class, el.Get("className").String())
+		}
+	}
+}
+
+// AssertCount fails the test unless root has want elements matching
+// selector
+func AssertCount(t testing.TB, root js.Value, selector string, want int) {
+	t.Helper()
+	if got := root.Call("querySelectorAll", selector).Length(); got != want {
+		t.Errorf("%d elements match %q, want %d", got, selector, want)
+	}
+}
+
+// AssertExists fails the test unless an element in root matches selector
+func AssertExists(t testing.TB, root js.Value, selector string) {
+	t.Helper()
+	if root.Call("querySelector", selector).IsNull() {
+		t.Errorf("no element matches %q", selector)
+	}
+}
+
+// AssertMissing fails the test if an element in root matches selector
+func AssertMissing(t testing.TB, root js.Value, selector string) {
+	t.Helper()
+	if !root.Call("querySelector", selector).IsNull() {
+		t.Errorf("an element matches %q, want none", selector)
+	}
+}
+
+// AssertVisible fails the test if el or an ancestor is hidden with the
+// hidden attribute, the hidden class or display: none
+func AssertVisible(t testing.TB, el js.Value) {
+	t.Helper()
+	if hidden(el) {
+		t.Errorf("element is hidden, want visible")
+	}
+}
+
+// AssertHidden fails the test unless el or an ancestor is hidden with the
+// hidden attribute, the hidden class or display: none
+func AssertHidden(t testing.TB, el js.Value) {
+	t.Helper()
+	if !hidden(el) {
+		t.Errorf("element is visible, want hidden")
+	}
+}
+
+// hidden reports whether el or an ancestor is hidden. It reads attributes,
+// classes and inline styles rather than layout, which jsdom doesn't do.
+func hidden(el js.Value) bool {
+	for ; el.Truthy() && el.Get("nodeType").Int() == 1; el = el.Get("parentElement") {
+		if el.Get("hidden").Truthy() ||
+			el.Get("classList").Call("contains", "hidden").Bool() ||
+			el.Get("style").Get("display").String() == "none" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+//go:build js && wasm
+
+// Package testutil helps test components in the DOM, run with gux test: it
+// mounts them in a container that is removed after the test, queries and
+// fires events on their elements, and asserts on what they render.
+//
+//	func TestBadge(t *testing.T) {
+//		root := testutil.Mount(t, components.Badge(components.BadgeProps{Text: "New"}))
+//		testutil.AssertText(t, testutil.Query(t, root, "span"), "New")
+//	}
+//
+// Mount takes an element; Render takes a Component such as a Table.
+package testutil
+
+import (
+	"strings"
+	"syscall/js"
+	"testing"
+	"time"
+
+	"github.com/dougbarrett/gux/components"
+)
+
+var document = js.Global().Get("document")
+
+// container adds an empty div to the body, which is unmounted and removed
+// when the test ends
+func container(t testing.TB) js.Value {
+	t.Helper()
+	root := document.Call("createElement", "div")
+	root.Call("setAttribute", "data-testid", t.Name())
+	document.Get("body").Call("appendChild", root)
+	t.Cleanup(func() {
+		components.UnmountTree(root)
+		root.Call("remove")
+	})
+	return root
+}
+
+// Mount appends el to a new container in the document and returns the
+// container. Components in it are unmounted when the test ends.
+func Mount(t testing.TB, el js.Value) js.Value {
+	t.Helper()
+	root := container(t)
+	root.Call("appendChild", el)
+	return root
+}
+
+// Render mounts c, such as a Table or FormBuilder, in a new container in
+// the document and returns the container. c is unmounted when the test
+// ends.
+func Render(t testing.TB, c components.Component) js.Value {
+	t.Helper()
+	root := container(t)
+	c.Mount(root)
+	t.Cleanup(c.Unmount) // runs before the container's cleanup
+	return root
+}
+
+// Query returns the first element in root matching selector, failing the
+// test if there is none
+func Query(t testing.TB, root js.Value, selector string) js.Value {
+	t.Helper()
+	el := root.Call("querySelector", selector)
+	if el.IsNull() {
+		t.Fatalf("no element matches %q", selector)
+	}
+	return el
+}
+
+// QueryAll returns the elements in root matching selector
+func QueryAll(root js.Value, selector string) []js.Value {
+	found := root.Call("querySelectorAll", selector)
+	els := make([]js.Value, found.Length())
+	for i := range els {
+		els[i] = found.Index(i)
+	}
+	return els
+}
+
+// QueryText returns the first element in root matching selector whose text
+// contains text, e.g. a button by its label, failing the test if there is
+// none
+func QueryText(t testing.TB, root js.Value, selector, text string) js.Value {
+	t.Helper()
+	for _, el := range QueryAll(root, selector) {
+		if strings.Contains(Text(el), text) {
+			return el
+		}
+	}
+	t.Fatalf("no element matches %q with text %q", selector, text)
+	return js.Undefined()
+}
+
+// Text returns el's text content with surrounding whitespace trimmed
+func Text(el js.Value) string {
+	return strings.TrimSpace(el.Get("textContent").String())
+}
+
+// Click clicks el, as a user would
+func Click(el js.Value) {
+	el.Call("click")
+}
+
+// Type sets an input's value and fires input and change events, as typing
+// it would
+func Type(el js.Value, value string) {
+	el.Set("value", value)
+	Dispatch(el, "input")
+	Dispatch(el, "change")
+}
+
+// Submit submits el's form, or el if it is a form, by firing a submit
+// event as the submit button would. The page doesn't navigate, whether or
+// not the form's handler prevents it.
+func Submit(t testing.TB, el js.Value) {
+	t.Helper()
+	form := el
+	if el.Get("tagName").String() != "FORM" {
+		form = el.Call("closest", "form")
+	}
+	if form.IsNull() {
+		t.Fatalf("no form to submit")
+	}
+	Dispatch(form, "submit")
+}
+
+// Blur moves focus away from el, firing blur and focusout, e.g. to run a
+// field's validation
+func Blur(el js.Value) {
+	init := js.Global().Get("Object").New()
+	el.Call("dispatchEvent", js.Global().Get("FocusEvent").New("blur", init))
+	init.Set("bubbles", true)
+	el.Call("dispatchEvent", js.Global().Get("FocusEvent").New("focusout", init))
+}
+
+// KeyDown fires a keydown event for key, e.g. "Enter" or "Escape"
+func KeyDown(el js.Value, key string) {
+	init := js.Global().Get("Object").New()
+	init.Set("key", key)
+	init.Set("bubbles", true)
+	init.Set("cancelable", true)
+	el.Call("dispatchEvent", js.Global().Get("KeyboardEvent").New("keydown", init))
+}
+
+// Dispatch fires a bubbling event of type typ at el
+func Dispatch(el js.Value, typ string) {
+	init := js.Global().Get("Object").New()
+	init.Set("bubbles", true)
+	init.Set("cancelable", true)
+	el.Call("dispatchEvent", js.Global().Get("Event").New(typ, init))
+}
+
+// WaitFor waits up to timeout for cond to hold, failing the test if it
+// doesn't. Use it for what happens after a timer, a fetch or an animation
+// frame; sleeping lets the browser run them.
+func WaitFor(t testing.TB, cond func() bool, timeout time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met after %v", timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
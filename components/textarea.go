@@ -22,6 +22,7 @@ type TextArea struct {
 	textarea   js.Value
 	label      js.Value
 	textareaID string
+	listeners  listeners
 }
 
 // NewTextArea creates a new TextArea component
@@ -30,7 +31,7 @@ func NewTextArea(props TextAreaProps) *TextArea {
 	crypto := js.Global().Get("crypto")
 
 	container := document.Call("createElement", "div")
-	container.Set("className", "mb-4")
+	container.Set("className", Classes("field"))
 
 	// Generate unique ID for label-input association
 	textareaID := "textarea-" + crypto.Call("randomUUID").String()
@@ -40,7 +41,7 @@ func NewTextArea(props TextAreaProps) *TextArea {
 	// Label
 	if props.Label != "" {
 		label := document.Call("createElement", "label")
-		label.Set("className", "block text-sm font-medium text-secondary mb-1")
+		label.Set("className", Classes("field.label"))
 		label.Set("textContent", props.Label)
 		label.Set("htmlFor", textareaID)
 		container.Call("appendChild", label)
@@ -50,9 +51,9 @@ func NewTextArea(props TextAreaProps) *TextArea {
 	// TextArea
 	textarea := document.Call("createElement", "textarea")
 	textarea.Set("id", textareaID)
-	className := "w-full px-3 py-2 border border-default surface-base text-primary rounded-md shadow-sm focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-blue-500 resize-y placeholder:text-tertiary"
+	className := Classes("textarea")
 	if props.Disabled {
-		className += " surface-overlay cursor-not-allowed"
+		className = Classes("textarea", "input.disabled")
 	}
 	if props.ClassName != "" {
 		className = props.ClassName
@@ -80,7 +81,7 @@ func NewTextArea(props TextAreaProps) *TextArea {
 	}
 
 	if props.OnChange != nil {
-		textarea.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+		textarea.Call("addEventListener", "input", ta.listeners.fn(func(this js.Value, args []js.Value) any {
 			value := textarea.Get("value").String()
 			props.OnChange(value)
 			return nil
@@ -90,6 +91,7 @@ func NewTextArea(props TextAreaProps) *TextArea {
 	container.Call("appendChild", textarea)
 	ta.textarea = textarea
 
+	onUnmount(ta.container, ta.Unmount)
 	return ta
 }
 
@@ -98,6 +100,17 @@ func (t *TextArea) Element() js.Value {
 	return t.container
 }
 
+// Mount appends the text area to parent
+func (t *TextArea) Mount(parent js.Value) {
+	parent.Call("appendChild", t.container)
+}
+
+// Unmount removes the text area and releases its listeners
+func (t *TextArea) Unmount() {
+	unmount(t.container)
+	t.listeners.release()
+}
+
 // Value returns the current textarea value
 func (t *TextArea) Value() string {
 	return t.textarea.Get("value").String()
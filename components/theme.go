@@ -3,7 +3,12 @@
 package components
 
 import (
+	"fmt"
+	"strings"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/prefs"
+	"github.com/dougbarrett/gux/storage"
 )
 
 // ThemeMode represents light or dark mode
@@ -15,120 +20,16 @@ const (
 	ThemeSystem ThemeMode = "system"
 )
 
-// ThemeColors defines the color palette for a theme
-type ThemeColors struct {
-	// Background colors
-	Background      string
-	BackgroundAlt   string
-	BackgroundHover string
-
-	// Text colors
-	Text        string
-	TextMuted   string
-	TextInverse string
-
-	// Primary colors
-	Primary      string
-	PrimaryHover string
-	PrimaryText  string
-
-	// Secondary colors
-	Secondary      string
-	SecondaryHover string
-	SecondaryText  string
-
-	// Accent colors
-	Accent      string
-	AccentHover string
-	AccentText  string
-
-	// Status colors
-	Success string
-	Warning string
-	Error   string
-	Info    string
-
-	// Border colors
-	Border      string
-	BorderFocus string
-
-	// Shadow
-	Shadow string
-}
-
-// DefaultLightColors provides default light theme colors
-var DefaultLightColors = ThemeColors{
-	Background:      "#ffffff",
-	BackgroundAlt:   "#f9fafb",
-	BackgroundHover: "#f3f4f6",
-
-	Text:        "#111827",
-	TextMuted:   "#6b7280",
-	TextInverse: "#ffffff",
-
-	Primary:      "#3b82f6",
-	PrimaryHover: "#2563eb",
-	PrimaryText:  "#ffffff",
-
-	Secondary:      "#6b7280",
-	SecondaryHover: "#4b5563",
-	SecondaryText:  "#ffffff",
-
-	Accent:      "#8b5cf6",
-	AccentHover: "#7c3aed",
-	AccentText:  "#ffffff",
-
-	Success: "#22c55e",
-	Warning: "#f59e0b",
-	Error:   "#ef4444",
-	Info:    "#3b82f6",
-
-	Border:      "#e5e7eb",
-	BorderFocus: "#3b82f6",
-
-	Shadow: "rgba(0, 0, 0, 0.1)",
-}
-
-// DefaultDarkColors provides default dark theme colors
-var DefaultDarkColors = ThemeColors{
-	Background:      "#111827",
-	BackgroundAlt:   "#1f2937",
-	BackgroundHover: "#374151",
-
-	Text:        "#f9fafb",
-	TextMuted:   "#9ca3af",
-	TextInverse: "#111827",
-
-	Primary:      "#3b82f6",
-	PrimaryHover: "#60a5fa",
-	PrimaryText:  "#ffffff",
-
-	Secondary:      "#9ca3af",
-	SecondaryHover: "#d1d5db",
-	SecondaryText:  "#111827",
-
-	Accent:      "#a78bfa",
-	AccentHover: "#c4b5fd",
-	AccentText:  "#111827",
-
-	Success: "#4ade80",
-	Warning: "#fbbf24",
-	Error:   "#f87171",
-	Info:    "#60a5fa",
-
-	Border:      "#374151",
-	BorderFocus: "#60a5fa",
-
-	Shadow: "rgba(0, 0, 0, 0.3)",
-}
-
 // ThemeManager handles dark/light mode switching with CSS variables
 type ThemeManager struct {
 	current      ThemeMode
-	lightColors  ThemeColors
-	darkColors   ThemeColors
+	lightColors  ThemeTokens
+	darkColors   ThemeTokens
+	customThemes map[ThemeMode]ThemeTokens
+	themeOrder   []ThemeMode
+	density      Density // the user's choice, overriding the theme's
 	styleElement js.Value
-	subscribers  []func(ThemeMode)
+	subscribers  []*func(ThemeMode)
 }
 
 var globalThemeManager *ThemeManager
@@ -139,46 +40,58 @@ func InitTheme() *ThemeManager {
 }
 
 // InitThemeWithColors initializes with custom colors
-func InitThemeWithColors(lightColors, darkColors ThemeColors) *ThemeManager {
+func InitThemeWithColors(lightColors, darkColors ThemeTokens) *ThemeManager {
 	if globalThemeManager != nil {
 		return globalThemeManager
 	}
 
 	globalThemeManager = &ThemeManager{
-		current:     ThemeSystem,
-		lightColors: lightColors,
-		darkColors:  darkColors,
+		current:      ThemeSystem,
+		lightColors:  lightColors,
+		darkColors:   darkColors,
+		customThemes: make(map[ThemeMode]ThemeTokens),
 	}
 
 	document := js.Global().Get("document")
 
 	// Create style element for CSS variables
-	globalThemeManager.styleElement = document.Call("createElement", "style")
+	globalThemeManager.styleElement = newStyle()
 	globalThemeManager.styleElement.Set("id", "gux-theme")
 	document.Get("head").Call("appendChild", globalThemeManager.styleElement)
 
-	// Check for saved preference
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		saved := localStorage.Call("getItem", "gux-theme")
-		if !saved.IsNull() && !saved.IsUndefined() {
-			switch saved.String() {
-			case "light":
-				globalThemeManager.current = ThemeLight
-			case "dark":
-				globalThemeManager.current = ThemeDark
-			default:
-				globalThemeManager.current = ThemeSystem
-			}
-		}
+	// Check for saved preference, falling back to the legacy localStorage key
+	legacy := storage.Local.Get("gux-theme")
+	if saved := prefs.Layout.GetString(prefs.KeyTheme, legacy); saved != "" {
+		// Custom theme names are kept even if not yet registered so the
+		// selection is restored once the app calls RegisterTheme
+		globalThemeManager.current = ThemeMode(saved)
 	}
 
+	globalThemeManager.density = Density(prefs.Layout.GetString(prefs.KeyDensity, ""))
+
+	// Follow theme changes synced from other devices
+	prefs.Layout.Subscribe(prefs.KeyTheme, func() {
+		mode := ThemeMode(prefs.Layout.GetString(prefs.KeyTheme, string(globalThemeManager.current)))
+		if mode != globalThemeManager.current {
+			globalThemeManager.current = mode
+			globalThemeManager.apply()
+			globalThemeManager.notify()
+		}
+	})
+	prefs.Layout.Subscribe(prefs.KeyDensity, func() {
+		density := Density(prefs.Layout.GetString(prefs.KeyDensity, ""))
+		if density != globalThemeManager.density {
+			globalThemeManager.density = density
+			globalThemeManager.apply()
+		}
+	})
+
 	globalThemeManager.apply()
 
 	// Listen for system preference changes
 	mediaQuery := js.Global().Call("matchMedia", "(prefers-color-scheme: dark)")
 	mediaQuery.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
-		if globalThemeManager.current == ThemeSystem {
+		if !globalThemeManager.isExplicit() {
 			globalThemeManager.apply()
 			globalThemeManager.notify()
 		}
@@ -204,22 +117,92 @@ func GetTheme() ThemeMode {
 	return globalThemeManager.current
 }
 
+// RegisterTheme adds a named custom theme that can be selected with SetTheme.
+// Registering an existing name replaces its colors. If the saved preference
+// refers to this theme, it becomes active immediately.
+func RegisterTheme(name string, colors ThemeTokens) {
+	if globalThemeManager == nil {
+		InitTheme()
+	}
+
+	mode := ThemeMode(name)
+	if mode == ThemeLight || mode == ThemeDark || mode == ThemeSystem || name == "" {
+		return
+	}
+
+	if _, exists := globalThemeManager.customThemes[mode]; !exists {
+		globalThemeManager.themeOrder = append(globalThemeManager.themeOrder, mode)
+	}
+	globalThemeManager.customThemes[mode] = colors
+
+	if globalThemeManager.current == mode {
+		globalThemeManager.apply()
+		globalThemeManager.notify()
+	}
+}
+
+// RegisteredThemes returns the names of registered custom themes in registration order
+func RegisteredThemes() []ThemeMode {
+	if globalThemeManager == nil {
+		InitTheme()
+	}
+	themes := make([]ThemeMode, len(globalThemeManager.themeOrder))
+	copy(themes, globalThemeManager.themeOrder)
+	return themes
+}
+
+// SetThemeByName selects a built-in mode ("light", "dark", "system") or a registered custom theme.
+// Names that are neither are ignored, so a stale or mistyped name doesn't blank the page.
+func SetThemeByName(name string) {
+	if globalThemeManager == nil {
+		InitTheme()
+	}
+	mode := ThemeMode(name)
+	if _, ok := globalThemeManager.customThemes[mode]; !ok && mode != ThemeLight && mode != ThemeDark && mode != ThemeSystem {
+		return
+	}
+	SetTheme(mode)
+}
+
 // SetTheme changes the theme
 func SetTheme(theme ThemeMode) {
 	if globalThemeManager == nil {
 		InitTheme()
 	}
 	globalThemeManager.current = theme
-
-	localStorage := js.Global().Get("localStorage")
-	if !localStorage.IsUndefined() && !localStorage.IsNull() {
-		localStorage.Call("setItem", "gux-theme", string(theme))
-	}
+	prefs.Layout.SetString(prefs.KeyTheme, string(theme))
 
 	globalThemeManager.apply()
 	globalThemeManager.notify()
 }
 
+// SetDensity switches every theme to density, such as DensityCompact for
+// a user who wants more on screen, and saves the choice. "" goes back to
+// each theme's own density.
+func SetDensity(density Density) {
+	if globalThemeManager == nil {
+		InitTheme()
+	}
+	globalThemeManager.density = density
+	if density == "" {
+		prefs.Layout.Remove(prefs.KeyDensity)
+	} else {
+		prefs.Layout.SetString(prefs.KeyDensity, string(density))
+	}
+	globalThemeManager.apply()
+}
+
+// GetDensity returns the density in use
+func GetDensity() Density {
+	if globalThemeManager == nil {
+		InitTheme()
+	}
+	if d := globalThemeManager.activeColors().Density; d != "" {
+		return d
+	}
+	return DensityComfortable
+}
+
 // ToggleTheme switches between light and dark
 func ToggleTheme() {
 	if globalThemeManager == nil {
@@ -239,15 +222,16 @@ func OnThemeChange(fn func(ThemeMode)) func() {
 		InitTheme()
 	}
 
-	globalThemeManager.subscribers = append(globalThemeManager.subscribers, fn)
-	idx := len(globalThemeManager.subscribers) - 1
+	sub := &fn
+	globalThemeManager.subscribers = append(globalThemeManager.subscribers, sub)
 
 	return func() {
-		if idx < len(globalThemeManager.subscribers) {
-			globalThemeManager.subscribers = append(
-				globalThemeManager.subscribers[:idx],
-				globalThemeManager.subscribers[idx+1:]...,
-			)
+		subs := globalThemeManager.subscribers
+		for i, s := range subs {
+			if s == sub {
+				globalThemeManager.subscribers = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
 		}
 	}
 }
@@ -260,29 +244,54 @@ func IsDarkMode() bool {
 	return globalThemeManager.isDark()
 }
 
-// GetActiveColors returns the currently active color palette
-func GetActiveColors() ThemeColors {
+// GetActiveColors returns the active theme's tokens
+func GetActiveColors() ThemeTokens {
 	if globalThemeManager == nil {
 		InitTheme()
 	}
-	if globalThemeManager.isDark() {
-		return globalThemeManager.darkColors
+	return globalThemeManager.activeColors()
+}
+
+// isExplicit reports whether the current theme ignores the system preference
+func (tm *ThemeManager) isExplicit() bool {
+	if tm.current == ThemeLight || tm.current == ThemeDark {
+		return true
 	}
-	return globalThemeManager.lightColors
+	_, ok := tm.customThemes[tm.current]
+	return ok
 }
 
 func (tm *ThemeManager) isDark() bool {
-	if tm.current == ThemeSystem {
-		return js.Global().Call("matchMedia", "(prefers-color-scheme: dark)").Get("matches").Bool()
+	switch tm.current {
+	case ThemeLight:
+		return false
+	case ThemeDark:
+		return true
 	}
-	return tm.current == ThemeDark
+	if colors, ok := tm.customThemes[tm.current]; ok {
+		return colors.Dark
+	}
+	// System mode, or a saved custom theme that has not been registered yet
+	return js.Global().Call("matchMedia", "(prefers-color-scheme: dark)").Get("matches").Bool()
 }
 
-func (tm *ThemeManager) apply() {
-	colors := tm.lightColors
-	if tm.isDark() {
+func (tm *ThemeManager) activeColors() ThemeTokens {
+	colors, ok := tm.customThemes[tm.current]
+	switch {
+	case ok:
+	case tm.isDark():
 		colors = tm.darkColors
+	default:
+		colors = tm.lightColors
 	}
+	if tm.density != "" {
+		colors.Density = tm.density
+	}
+	return colors
+}
+
+func (tm *ThemeManager) apply() {
+	colors := tm.activeColors()
 
 	css := `:root {
 		--bg: ` + colors.Background + `;
@@ -307,11 +316,13 @@ func (tm *ThemeManager) apply() {
 		--border: ` + colors.Border + `;
 		--border-focus: ` + colors.BorderFocus + `;
 		--shadow: ` + colors.Shadow + `;
+		` + strings.ReplaceAll(strings.TrimSpace(colors.shapeVariables()), "\n", "\n\t\t") + `
 	}
 
 	body {
 		background-color: var(--bg);
 		color: var(--text);
+		font-family: var(--font-family);
 		transition: background-color 0.3s ease, color 0.3s ease;
 	}
 
@@ -477,11 +488,18 @@ func (tm *ThemeManager) apply() {
 		html.Get("classList").Call("add", "light")
 		body.Get("classList").Call("add", "theme-light")
 	}
+
+	// Expose custom theme name for app-specific CSS hooks
+	if _, ok := tm.customThemes[tm.current]; ok {
+		html.Call("setAttribute", "data-theme", string(tm.current))
+	} else {
+		html.Call("removeAttribute", "data-theme")
+	}
 }
 
 func (tm *ThemeManager) notify() {
 	for _, fn := range tm.subscribers {
-		fn(tm.current)
+		(*fn)(tm.current)
 	}
 }
 
@@ -529,16 +547,18 @@ func ThemeToggle(props ...ThemeToggleProps) js.Value {
 
 	updateIcon()
 
-	btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+	var funcs listeners
+	btn.Call("addEventListener", "click", funcs.fn(func(this js.Value, args []js.Value) any {
 		ToggleTheme()
 		updateIcon()
 		return nil
 	}))
 
 	// Subscribe to theme changes from elsewhere
-	OnThemeChange(func(mode ThemeMode) {
+	funcs.onRelease(OnThemeChange(func(mode ThemeMode) {
 		updateIcon()
-	})
+	}))
+	onUnmount(btn, funcs.release)
 
 	return btn
 }
@@ -549,7 +569,8 @@ type ThemeSelectorProps struct {
 	Label     string
 }
 
-// ThemeSelector creates a dropdown to select theme mode
+// ThemeSelector creates a dropdown to select theme mode.
+// Custom themes registered with RegisterTheme are listed after the built-in modes.
 func ThemeSelector(props ...ThemeSelectorProps) js.Value {
 	document := js.Global().Get("document")
 
@@ -580,41 +601,96 @@ func ThemeSelector(props ...ThemeSelectorProps) js.Value {
 		{"light", "Light"},
 		{"dark", "Dark"},
 	}
+	for _, name := range RegisteredThemes() {
+		options = append(options, struct {
+			value string
+			label string
+		}{string(name), themeLabel(string(name))})
+	}
 
-	current := GetTheme()
-	for _, opt := range options {
+	listed := map[string]bool{}
+	addOption := func(value, label string) {
 		option := document.Call("createElement", "option")
-		option.Set("value", opt.value)
-		option.Set("textContent", opt.label)
-		if string(current) == opt.value {
-			option.Set("selected", true)
-		}
+		option.Set("value", value)
+		option.Set("textContent", label)
 		selectEl.Call("appendChild", option)
+		listed[value] = true
 	}
+	for _, opt := range options {
+		addOption(opt.value, opt.label)
+	}
+	selectEl.Set("value", string(GetTheme()))
 
-	selectEl.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
-		value := selectEl.Get("value").String()
-		switch value {
-		case "light":
-			SetTheme(ThemeLight)
-		case "dark":
-			SetTheme(ThemeDark)
-		default:
-			SetTheme(ThemeSystem)
-		}
+	var funcs listeners
+	selectEl.Call("addEventListener", "change", funcs.fn(func(this js.Value, args []js.Value) any {
+		SetThemeByName(selectEl.Get("value").String())
 		return nil
 	}))
 
+	// Keep selection in sync with theme changes from elsewhere
+	funcs.onRelease(OnThemeChange(func(mode ThemeMode) {
+		// A theme registered after the selector was built gets its option now
+		if !listed[string(mode)] {
+			addOption(string(mode), themeLabel(string(mode)))
+		}
+		selectEl.Set("value", string(mode))
+	}))
+	onUnmount(container, funcs.release)
+
 	container.Call("appendChild", selectEl)
 	return container
 }
 
+// themeLabel converts a theme name like "ocean-blue" to "Ocean Blue"
+func themeLabel(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	})
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// tokenPadding returns the padding classes of a component, x and y rem at
+// comfortable density, scaled by the theme's density for it. Without a
+// theme the padding is the comfortable one.
+func tokenPadding(component string, x, y float64) string {
+	return fmt.Sprintf("px-[calc(%grem*var(--%s-density,1))] py-[calc(%grem*var(--%s-density,1))]", x, component, y, component)
+}
+
+// tokenRadius returns the class rounding a component's corners by the
+// theme's radius for it, or fallback without a theme
+func tokenRadius(component, fallback string) string {
+	return "rounded-[var(--" + component + "-radius," + fallback + ")]"
+}
+
+// tokenClasses spells out the classes tokenPadding and tokenRadius build,
+// since gux build's Tailwind scan only finds string literals. Add new
+// sizes here when calling them with other values.
+var _ = []string{
+	"px-[calc(0.5rem*var(--button-density,1))] py-[calc(0.25rem*var(--button-density,1))]",
+	"px-[calc(1rem*var(--button-density,1))] py-[calc(0.5rem*var(--button-density,1))]",
+	"px-[calc(1.5rem*var(--button-density,1))] py-[calc(0.75rem*var(--button-density,1))]",
+	"px-[calc(0.625rem*var(--badge-density,1))] py-[calc(0.125rem*var(--badge-density,1))]",
+	"px-[calc(1rem*var(--card-density,1))] py-[calc(1rem*var(--card-density,1))]",
+	"px-[calc(1.5rem*var(--card-density,1))] py-[calc(1.5rem*var(--card-density,1))]",
+	"px-[calc(0.75rem*var(--input-density,1))] py-[calc(0.5rem*var(--input-density,1))]",
+	"px-[calc(1.5rem*var(--modal-density,1))] py-[calc(1rem*var(--modal-density,1))]",
+	"px-[calc(1rem*var(--table-density,1))] py-[calc(0.75rem*var(--table-density,1))]",
+	"px-[calc(1rem*var(--table-density,1))] py-[calc(1rem*var(--table-density,1))]",
+	"px-[calc(1.5rem*var(--table-density,1))] py-[calc(0.75rem*var(--table-density,1))]",
+	"px-[calc(1.5rem*var(--table-density,1))] py-[calc(1rem*var(--table-density,1))]",
+	"rounded-[var(--button-radius,0.375rem)] rounded-[var(--badge-radius,0.375rem)] rounded-[var(--input-radius,0.375rem)]",
+	"rounded-[var(--card-radius,0.5rem)] rounded-[var(--modal-radius,0.5rem)] rounded-b-[var(--modal-radius,0.5rem)]",
+}
+
 // ThemedCard creates a card with theme-aware styling
 func ThemedCard(children ...js.Value) js.Value {
 	document := js.Global().Get("document")
 
 	card := document.Call("createElement", "div")
-	card.Set("className", "card-theme rounded-lg p-4")
+	card.Set("className", "card-theme "+tokenRadius("card", DefaultRadiusLarge)+" "+tokenPadding("card", 1, 1))
 
 	for _, child := range children {
 		card.Call("appendChild", child)
@@ -629,7 +705,7 @@ func ThemedButton(text string, variant string, onClick func()) js.Value {
 
 	btn := document.Call("createElement", "button")
 
-	className := "px-4 py-2 rounded-md font-medium transition-colors "
+	className := tokenPadding("button", 1, 0.5) + " " + tokenRadius("button", DefaultRadius) + " font-medium transition-colors "
 	switch variant {
 	case "secondary":
 		className += "btn-secondary-theme"
@@ -644,10 +720,12 @@ func ThemedButton(text string, variant string, onClick func()) js.Value {
 	btn.Set("textContent", text)
 
 	if onClick != nil {
-		btn.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		click := js.FuncOf(func(this js.Value, args []js.Value) any {
 			onClick()
 			return nil
-		}))
+		})
+		btn.Call("addEventListener", "click", click)
+		onUnmount(btn, click.Release)
 	}
 
 	return btn
@@ -658,9 +736,42 @@ func ThemedInput(placeholder string) js.Value {
 	document := js.Global().Get("document")
 
 	input := document.Call("createElement", "input")
-	input.Set("className", "input-theme px-3 py-2 rounded-md w-full")
+	input.Set("className", "input-theme "+tokenPadding("input", 0.75, 0.5)+" "+tokenRadius("input", DefaultRadius)+" w-full")
 	input.Set("type", "text")
 	input.Set("placeholder", placeholder)
 
 	return input
 }
+
+// ImportThemeTokensFromClipboard reads design tokens from the clipboard,
+// e.g. copied from a design tool's token export, and calls fn with the
+// result of ImportThemeTokens. Reading the clipboard needs a user gesture
+// and permission in most browsers.
+func ImportThemeTokensFromClipboard(opts ThemeTokenOptions, fn func(ThemeTokens, error)) {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if !clipboard.Truthy() || clipboard.Get("readText").Type() != js.TypeFunction {
+		fn(opts.base(), fmt.Errorf("theme tokens: clipboard is not available"))
+		return
+	}
+
+	var onRead, onError js.Func
+	release := func() {
+		onRead.Release()
+		onError.Release()
+	}
+	onRead = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		fn(ImportThemeTokens([]byte(args[0].String()), opts))
+		return nil
+	})
+	onError = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		msg := "clipboard read failed"
+		if len(args) > 0 && args[0].Truthy() && args[0].Get("message").Truthy() {
+			msg = args[0].Get("message").String()
+		}
+		fn(opts.base(), fmt.Errorf("theme tokens: %s", msg))
+		return nil
+	})
+	clipboard.Call("readText").Call("then", onRead, onError)
+}
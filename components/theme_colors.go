@@ -0,0 +1,228 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The theme's tokens build without js && wasm, so server code such as
+// email templates shares them with the UI.
+
+// ThemeTokens defines a theme: its color palette, and the density, corner
+// radius, and font the components use
+type ThemeTokens struct {
+	// Background colors
+	Background      string
+	BackgroundAlt   string
+	BackgroundHover string
+
+	// Text colors
+	Text        string
+	TextMuted   string
+	TextInverse string
+
+	// Primary colors
+	Primary      string
+	PrimaryHover string
+	PrimaryText  string
+
+	// Secondary colors
+	Secondary      string
+	SecondaryHover string
+	SecondaryText  string
+
+	// Accent colors
+	Accent      string
+	AccentHover string
+	AccentText  string
+
+	// Status colors
+	Success string
+	Warning string
+	Error   string
+	Info    string
+
+	// Border colors
+	Border      string
+	BorderFocus string
+
+	// Shadow
+	Shadow string
+
+	// Density sets the padding of controls, cards, and table cells
+	// (default DensityComfortable)
+	Density Density
+
+	// Radius rounds controls such as buttons, inputs, and badges (default
+	// "0.375rem"), and RadiusLarge surfaces such as cards and modals
+	// (default "0.5rem"). Use "0" for square corners.
+	Radius      string
+	RadiusLarge string
+
+	// FontFamily is the app's font stack (default the system UI font)
+	FontFamily string
+
+	// Components overrides Density and Radius for one kind of component
+	Components ThemeComponents
+
+	// Dark marks a registered custom palette as a dark theme so that
+	// the .dark and .theme-dark classes are applied when it is active
+	Dark bool
+}
+
+// ThemeColors is the former name of ThemeTokens.
+//
+// Deprecated: use ThemeTokens.
+type ThemeColors = ThemeTokens
+
+// Density is how tightly components are padded
+type Density string
+
+const (
+	DensityComfortable Density = "comfortable"
+	DensityCompact     Density = "compact"
+)
+
+// scale returns the factor applied to the comfortable padding
+func (d Density) scale() string {
+	if d == DensityCompact {
+		return "0.75"
+	}
+	return "1"
+}
+
+// ThemeComponents holds per-component overrides of the theme's density
+// and radius, such as compact tables in an otherwise comfortable app
+type ThemeComponents struct {
+	Button ComponentTokens
+	Input  ComponentTokens // also selects and text areas
+	Badge  ComponentTokens
+	Card   ComponentTokens
+	Modal  ComponentTokens
+	Table  ComponentTokens // Density only, as cells have no corners
+}
+
+// ComponentTokens overrides the theme's tokens for one kind of component.
+// Empty fields follow the theme.
+type ComponentTokens struct {
+	Density Density
+	Radius  string
+}
+
+// Default shape tokens, used where a theme leaves them empty
+const (
+	DefaultRadius      = "0.375rem"
+	DefaultRadiusLarge = "0.5rem"
+	DefaultFontFamily  = `ui-sans-serif, system-ui, sans-serif, "Apple Color Emoji", "Segoe UI Emoji", "Segoe UI Symbol", "Noto Color Emoji"`
+)
+
+// DefaultLightColors provides default light theme colors
+var DefaultLightColors = ThemeTokens{
+	Background:      "#ffffff",
+	BackgroundAlt:   "#f9fafb",
+	BackgroundHover: "#f3f4f6",
+
+	Text:        "#111827",
+	TextMuted:   "#6b7280",
+	TextInverse: "#ffffff",
+
+	Primary:      "#3b82f6",
+	PrimaryHover: "#2563eb",
+	PrimaryText:  "#ffffff",
+
+	Secondary:      "#6b7280",
+	SecondaryHover: "#4b5563",
+	SecondaryText:  "#ffffff",
+
+	Accent:      "#8b5cf6",
+	AccentHover: "#7c3aed",
+	AccentText:  "#ffffff",
+
+	Success: "#22c55e",
+	Warning: "#f59e0b",
+	Error:   "#ef4444",
+	Info:    "#3b82f6",
+
+	Border:      "#e5e7eb",
+	BorderFocus: "#3b82f6",
+
+	Shadow: "rgba(0, 0, 0, 0.1)",
+}
+
+// DefaultDarkColors provides default dark theme colors
+var DefaultDarkColors = ThemeTokens{
+	Background:      "#111827",
+	BackgroundAlt:   "#1f2937",
+	BackgroundHover: "#374151",
+
+	Text:        "#f9fafb",
+	TextMuted:   "#9ca3af",
+	TextInverse: "#111827",
+
+	Primary:      "#3b82f6",
+	PrimaryHover: "#60a5fa",
+	PrimaryText:  "#ffffff",
+
+	Secondary:      "#9ca3af",
+	SecondaryHover: "#d1d5db",
+	SecondaryText:  "#111827",
+
+	Accent:      "#a78bfa",
+	AccentHover: "#c4b5fd",
+	AccentText:  "#111827",
+
+	Success: "#4ade80",
+	Warning: "#fbbf24",
+	Error:   "#f87171",
+	Info:    "#60a5fa",
+
+	Border:      "#374151",
+	BorderFocus: "#60a5fa",
+
+	Shadow: "rgba(0, 0, 0, 0.3)",
+}
+
+// shapeVariables returns the density, radius, and font tokens as CSS
+// custom properties: --density, --radius, --radius-lg, and --font-family,
+// and --<component>-density and --<component>-radius, which the
+// components' classes read
+func (t ThemeTokens) shapeVariables() string {
+	radius, radiusLarge, font := t.Radius, t.RadiusLarge, t.FontFamily
+	if radius == "" {
+		radius = DefaultRadius
+	}
+	if radiusLarge == "" {
+		radiusLarge = DefaultRadiusLarge
+	}
+	if font == "" {
+		font = DefaultFontFamily
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--density: %s;\n--radius: %s;\n--radius-lg: %s;\n--font-family: %s;\n", t.Density.scale(), radius, radiusLarge, font)
+	for _, c := range []struct {
+		name   string
+		tokens ComponentTokens
+		radius string
+	}{
+		{"button", t.Components.Button, radius},
+		{"input", t.Components.Input, radius},
+		{"badge", t.Components.Badge, radius},
+		{"card", t.Components.Card, radiusLarge},
+		{"modal", t.Components.Modal, radiusLarge},
+		{"table", t.Components.Table, ""},
+	} {
+		density := t.Density
+		if c.tokens.Density != "" {
+			density = c.tokens.Density
+		}
+		fmt.Fprintf(&b, "--%s-density: %s;\n", c.name, density.scale())
+		if c.tokens.Radius != "" {
+			c.radius = c.tokens.Radius
+		}
+		if c.radius != "" {
+			fmt.Fprintf(&b, "--%s-radius: %s;\n", c.name, c.radius)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+// stubMatchMedia gives the theme a light system preference, since jsdom
+// has no matchMedia
+func stubMatchMedia() {
+	if js.Global().Get("matchMedia").Truthy() {
+		return
+	}
+	noop := js.FuncOf(func(this js.Value, args []js.Value) any { return nil })
+	js.Global().Set("matchMedia", js.FuncOf(func(this js.Value, args []js.Value) any {
+		return js.ValueOf(map[string]any{"matches": false, "addEventListener": noop, "removeEventListener": noop})
+	}))
+}
+
+func TestSetThemeByNameIgnoresUnknown(t *testing.T) {
+	stubMatchMedia()
+	components.SetThemeByName("dark")
+	components.SetThemeByName("no-such-theme")
+	if got := components.GetTheme(); got != components.ThemeDark {
+		t.Errorf("theme = %q, want dark", got)
+	}
+}
+
+func TestThemeSelectorListsLateThemes(t *testing.T) {
+	stubMatchMedia()
+	components.SetThemeByName("light")
+	root := testutil.Mount(t, components.ThemeSelector())
+	selectEl := testutil.Query(t, root, "select")
+
+	components.RegisterTheme("late-night", components.DefaultDarkColors)
+	components.SetThemeByName("late-night")
+
+	testutil.QueryText(t, root, "option", "Late Night")
+	if got := selectEl.Get("value").String(); got != "late-night" {
+		t.Errorf("select value = %q, want late-night", got)
+	}
+}
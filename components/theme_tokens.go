@@ -0,0 +1,576 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ThemeTokenOptions configures ImportThemeTokens
+type ThemeTokenOptions struct {
+	// Base supplies the colors no token sets, and the density, radius, and
+	// font (default DefaultLightColors)
+	Base *ThemeTokens
+
+	// Group imports only the tokens under this path, e.g. "modes.dark" when
+	// one file holds several modes. Aliases still resolve against the whole
+	// file.
+	Group string
+
+	// Map assigns token paths to ThemeTokens fields explicitly, e.g.
+	// {"brand.500": "Primary"}. Paths not in Map are matched by name.
+	Map map[string]string
+}
+
+func (o ThemeTokenOptions) base() ThemeTokens {
+	if o.Base != nil {
+		return *o.Base
+	}
+	return DefaultLightColors
+}
+
+// ThemeTokenError lists the problems found by ImportThemeTokens. The palette
+// returned alongside it still has every token that could be mapped.
+type ThemeTokenError struct {
+	Unmapped []string // color tokens that match no ThemeTokens field
+	Invalid  []string // tokens with bad values or broken aliases
+}
+
+func (e *ThemeTokenError) Error() string {
+	var parts []string
+	if len(e.Unmapped) > 0 {
+		parts = append(parts, fmt.Sprintf("%d unmapped token(s): %s", len(e.Unmapped), strings.Join(e.Unmapped, ", ")))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, fmt.Sprintf("%d invalid token(s): %s", len(e.Invalid), strings.Join(e.Invalid, "; ")))
+	}
+	return "theme tokens: " + strings.Join(parts, "; ")
+}
+
+// designToken is a token found in the file
+type designToken struct {
+	path  string
+	typ   string
+	value any
+}
+
+var (
+	hexColorPattern  = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	funcColorPattern = regexp.MustCompile(`^(rgb|rgba|hsl|hsla|hwb|lab|lch|oklab|oklch|color)\([0-9a-zA-Z .,%/+-]*\)$`)
+	aliasPattern     = regexp.MustCompile(`^\{([^{}]+)\}$`)
+)
+
+// tokenPrefixes are leading group names that carry no meaning for matching
+var tokenPrefixes = map[string]bool{
+	"color": true, "colors": true, "theme": true, "palette": true, "semantic": true,
+	"sys": true, "system": true, "status": true, "state": true,
+}
+
+// tokenSynonyms maps common design-tool names onto ThemeTokens wording
+var tokenSynonyms = map[string]string{
+	"bg":          "background",
+	"surface":     "background",
+	"fg":          "text",
+	"foreground":  "text",
+	"content":     "text",
+	"danger":      "error",
+	"destructive": "error",
+	"critical":    "error",
+	"positive":    "success",
+	"caution":     "warning",
+	"subtle":      "muted",
+	"stroke":      "border",
+	"outline":     "border",
+	"focused":     "focus",
+	"ring":        "focus",
+	"hovered":     "hover",
+	"inverted":    "inverse",
+	"alternate":   "alt",
+	"shadows":     "shadow",
+}
+
+// tokenSuffixes are trailing segments that name the default variant
+var tokenSuffixes = map[string]bool{"default": true, "base": true, "main": true}
+
+// ImportThemeTokens builds a palette from W3C design tokens (the Design
+// Tokens Community Group format exported by Figma plugins such as Tokens
+// Studio). Color tokens are matched to ThemeTokens fields by name, so
+// "color.background.alt", "bg.alt", and "colors.backgroundAlt" all set
+// BackgroundAlt, and aliases like "{color.blue.500}" are resolved.
+//
+// Density, radius, and font family tokens set Density, Radius,
+// RadiusLarge, and FontFamily: "density", "border.radius" and "radius.lg"
+// dimensions, and a fontFamily token such as "font.body".
+//
+// Color tokens that match no field are returned in a *ThemeTokenError along
+// with the palette, as are bad values. Tokens only used as alias targets,
+// such as a primitive palette, are not reported.
+//
+//	colors, err := components.ImportThemeTokens(data, components.ThemeTokenOptions{})
+//	components.RegisterTheme("brand", colors)
+func ImportThemeTokens(data []byte, opts ThemeTokenOptions) (ThemeTokens, error) {
+	colors := opts.base()
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return colors, fmt.Errorf("theme tokens: %w", err)
+	}
+
+	all := make(map[string]designToken)
+	collectTokens(doc, "", "", all)
+
+	// Tokens referenced by aliases are primitives, not palette entries
+	referenced := make(map[string]bool)
+	for _, t := range all {
+		if s, ok := t.value.(string); ok {
+			if m := aliasPattern.FindStringSubmatch(s); m != nil {
+				referenced[m[1]] = true
+			}
+		}
+	}
+
+	prefix := ""
+	if opts.Group != "" {
+		prefix = opts.Group + "."
+	}
+	var paths []string
+	for path := range all {
+		if strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 && opts.Group != "" {
+		return colors, fmt.Errorf("theme tokens: no tokens under %q", opts.Group)
+	}
+	sort.Strings(paths)
+
+	fields := themeColorFields()
+	mappable := themeStringFields()
+	target := reflect.ValueOf(&colors).Elem()
+	tokErr := &ThemeTokenError{}
+
+	for _, path := range paths {
+		t := all[path]
+		rel := strings.TrimPrefix(path, prefix)
+
+		field, explicit := opts.Map[path]
+		if !explicit {
+			field, explicit = opts.Map[rel]
+		}
+		if explicit {
+			if _, ok := mappable[strings.ToLower(field)]; !ok {
+				tokErr.Invalid = append(tokErr.Invalid, fmt.Sprintf("%s: Map names unknown field %q", path, field))
+				continue
+			}
+		}
+
+		value, typ, err := resolveToken(t, all, 0)
+		if err != nil {
+			tokErr.Invalid = append(tokErr.Invalid, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+
+		shape := ""
+		if explicit {
+			if name := mappable[strings.ToLower(field)]; themeShapeFields[name] {
+				shape = name
+			}
+		} else if _, isColor := tokenColor(value); !isColor || typ != "" {
+			shape = matchShapeField(rel, typ)
+		}
+		if shape != "" {
+			css, err := tokenShape(shape, value)
+			if err != nil {
+				tokErr.Invalid = append(tokErr.Invalid, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+			target.FieldByName(shape).SetString(css)
+			continue
+		}
+		if !explicit && typ != "" && typ != "color" && typ != "shadow" {
+			continue // spacing, typography, and other tokens ThemeTokens has no field for
+		}
+
+		if !explicit {
+			field = matchThemeField(rel, fields)
+		}
+		if field == "" {
+			if _, isColor := tokenColor(value); isColor && !referenced[path] {
+				tokErr.Unmapped = append(tokErr.Unmapped, path)
+			}
+			continue
+		}
+
+		css, ok := tokenColor(value)
+		if !ok && strings.EqualFold(field, "Shadow") {
+			css, ok = tokenShadowColor(value)
+		}
+		if !ok {
+			tokErr.Invalid = append(tokErr.Invalid, fmt.Sprintf("%s: %v is not a color", path, value))
+			continue
+		}
+		target.FieldByName(fields[strings.ToLower(field)]).SetString(css)
+	}
+
+	if len(tokErr.Unmapped) > 0 || len(tokErr.Invalid) > 0 {
+		return colors, tokErr
+	}
+	return colors, nil
+}
+
+// collectTokens walks a token document. Objects with $value (or value, as
+// older Tokens Studio exports use) are tokens; other objects are groups whose
+// $type is inherited by their tokens. Exports sometimes nest variants such as
+// "hover" inside a token, so tokens are searched for children too.
+func collectTokens(node map[string]any, path, inherited string, out map[string]designToken) {
+	typ := inherited
+	if t, ok := node["$type"].(string); ok {
+		typ = t
+	} else if t, ok := node["type"].(string); ok {
+		if _, isToken := node["value"]; isToken {
+			typ = t
+		}
+	}
+
+	legacy := false
+	if v, ok := node["$value"]; ok && path != "" {
+		out[path] = designToken{path: path, typ: typ, value: v}
+	} else if v, ok := node["value"]; ok && path != "" {
+		if _, nested := v.(map[string]any); !nested || typ != "" {
+			out[path] = designToken{path: path, typ: typ, value: v}
+			legacy = true
+		}
+	}
+
+	for key, child := range node {
+		if strings.HasPrefix(key, "$") || (legacy && key == "value") {
+			continue
+		}
+		group, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		collectTokens(group, childPath, typ, out)
+	}
+}
+
+// resolveToken follows aliases to a concrete value
+func resolveToken(t designToken, all map[string]designToken, depth int) (any, string, error) {
+	s, ok := t.value.(string)
+	if !ok {
+		return t.value, t.typ, nil
+	}
+	m := aliasPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return t.value, t.typ, nil
+	}
+	if depth > 16 {
+		return nil, "", fmt.Errorf("alias cycle through {%s}", m[1])
+	}
+	target, ok := all[m[1]]
+	if !ok {
+		return nil, "", fmt.Errorf("alias {%s} not found", m[1])
+	}
+	value, typ, err := resolveToken(target, all, depth+1)
+	if t.typ != "" {
+		typ = t.typ
+	}
+	return value, typ, err
+}
+
+// themeShapeFields are the ThemeTokens string fields that aren't colors
+var themeShapeFields = map[string]bool{"Density": true, "Radius": true, "RadiusLarge": true, "FontFamily": true}
+
+// themeStringFields maps lowercase field names to the string fields of
+// ThemeTokens, which Map may name
+func themeStringFields() map[string]string {
+	fields := make(map[string]string)
+	rt := reflect.TypeOf(ThemeTokens{})
+	for i := 0; i < rt.NumField(); i++ {
+		if f := rt.Field(i); f.Type.Kind() == reflect.String {
+			fields[strings.ToLower(f.Name)] = f.Name
+		}
+	}
+	return fields
+}
+
+// themeColorFields maps lowercase field names to the color fields of
+// ThemeTokens
+func themeColorFields() map[string]string {
+	fields := themeStringFields()
+	for key, name := range fields {
+		if themeShapeFields[name] {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
+
+// Words that name a shape token, and the words around them that carry no
+// meaning, as in "border.radius.default" or "typography.font-family"
+var (
+	radiusWords  = map[string]bool{"radius": true, "radii": true, "rounded": true, "corner": true, "corners": true}
+	fontWords    = map[string]bool{"font": true, "fonts": true, "family": true, "families": true, "typeface": true}
+	densityWords = map[string]bool{"density": true}
+	shapeFillers = map[string]bool{"border": true, "shape": true, "size": true, "sizes": true, "dimension": true, "typography": true}
+)
+
+// matchShapeField finds the density, radius, or font field a token names:
+// "radius" or "border-radius" sets Radius and "radius.lg" RadiusLarge,
+// "font.family" or a fontFamily token named "body" or "sans" sets
+// FontFamily, and "density" sets Density. Other sizes, such as
+// "radius.sm", have no field.
+func matchShapeField(path, typ string) string {
+	var words []string
+	for _, seg := range strings.Split(path, ".") {
+		words = append(words, splitTokenWords(seg)...)
+	}
+	for len(words) > 1 && tokenPrefixes[words[0]] {
+		words = words[1:]
+	}
+	has := func(set map[string]bool) bool {
+		for _, w := range words {
+			if set[w] {
+				return true
+			}
+		}
+		return false
+	}
+	rest := func(set map[string]bool) string {
+		var kept []string
+		for _, w := range words {
+			if !set[w] && !shapeFillers[w] && !tokenSuffixes[w] {
+				kept = append(kept, w)
+			}
+		}
+		return strings.Join(kept, "")
+	}
+
+	switch {
+	case (typ == "" || typ == "dimension" || typ == "borderRadius") && has(radiusWords):
+		switch rest(radiusWords) {
+		case "":
+			return "Radius"
+		case "lg", "large":
+			return "RadiusLarge"
+		}
+	case (typ == "fontFamily" || typ == "fontFamilies") || (typ == "" && has(fontWords)):
+		switch rest(fontWords) {
+		case "", "body", "sans", "ui", "primary":
+			return "FontFamily"
+		}
+	case (typ == "" || typ == "string" || typ == "other") && has(densityWords):
+		if rest(densityWords) == "" {
+			return "Density"
+		}
+	}
+	return ""
+}
+
+var (
+	dimensionPattern = regexp.MustCompile(`^(0|[0-9]*\.?[0-9]+(px|rem|em|%))$`)
+	fontNamePattern  = regexp.MustCompile(`^[A-Za-z0-9 ._-]+$`)
+)
+
+// tokenShape converts a token value to the CSS of a density, radius, or
+// font field. Like tokenColor it only accepts what it can check, since the
+// result is written into a stylesheet.
+func tokenShape(field string, v any) (string, error) {
+	switch field {
+	case "Radius", "RadiusLarge":
+		return tokenDimension(v)
+	case "FontFamily":
+		return tokenFontFamily(v)
+	case "Density":
+		if s, ok := v.(string); ok {
+			switch d := Density(strings.ToLower(strings.TrimSpace(s))); d {
+			case DensityComfortable, DensityCompact:
+				return string(d), nil
+			}
+		}
+		return "", fmt.Errorf("%v is not %q or %q", v, DensityComfortable, DensityCompact)
+	}
+	return "", fmt.Errorf("%s can't be set from a token", field)
+}
+
+// tokenDimension accepts lengths such as "4px" and "0.25rem", bare numbers
+// as pixels, and DTCG dimension objects such as {"value": 4, "unit": "px"}
+func tokenDimension(v any) (string, error) {
+	switch d := v.(type) {
+	case string:
+		d = strings.TrimSpace(d)
+		if dimensionPattern.MatchString(d) {
+			return d, nil
+		}
+	case float64:
+		if d == 0 {
+			return "0", nil
+		}
+		if d > 0 {
+			return trimFloat(d) + "px", nil
+		}
+	case map[string]any:
+		value, ok := d["value"].(float64)
+		unit, _ := d["unit"].(string)
+		if ok && value >= 0 && (unit == "px" || unit == "rem") {
+			return trimFloat(value) + unit, nil
+		}
+	}
+	return "", fmt.Errorf("%v is not a length", v)
+}
+
+// tokenFontFamily accepts a font stack as a string or a DTCG array of
+// names, quoting names with spaces
+func tokenFontFamily(v any) (string, error) {
+	var names []string
+	switch f := v.(type) {
+	case string:
+		names = strings.Split(f, ",")
+	case []any:
+		for _, name := range f {
+			s, ok := name.(string)
+			if !ok {
+				return "", fmt.Errorf("%v is not a font stack", v)
+			}
+			names = append(names, s)
+		}
+	}
+
+	var stack []string
+	for _, name := range names {
+		name = strings.Trim(strings.TrimSpace(name), `"'`)
+		if !fontNamePattern.MatchString(name) {
+			return "", fmt.Errorf("%v is not a font stack", v)
+		}
+		if strings.Contains(name, " ") {
+			name = `"` + name + `"`
+		}
+		stack = append(stack, name)
+	}
+	if len(stack) == 0 {
+		return "", fmt.Errorf("%v is not a font stack", v)
+	}
+	return strings.Join(stack, ", "), nil
+}
+
+// matchThemeField finds the ThemeTokens field a token path names
+func matchThemeField(path string, fields map[string]string) string {
+	var segments []string
+	for _, seg := range strings.Split(path, ".") {
+		// Split camelCase, kebab-case, and snake_case segments into words
+		for _, word := range splitTokenWords(seg) {
+			if syn, ok := tokenSynonyms[word]; ok {
+				word = syn
+			}
+			segments = append(segments, word)
+		}
+	}
+	for len(segments) > 1 && tokenPrefixes[segments[0]] {
+		segments = segments[1:]
+	}
+	for len(segments) > 1 && tokenSuffixes[segments[len(segments)-1]] {
+		segments = segments[:len(segments)-1]
+	}
+
+	// "on-primary" is the text color used on primary
+	if len(segments) > 1 && segments[0] == "on" {
+		segments = append(segments[1:], "text")
+	}
+
+	return fields[strings.Join(segments, "")]
+}
+
+func splitTokenWords(s string) []string {
+	var words []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			words = append(words, strings.ToLower(cur.String()))
+			cur.Reset()
+		}
+	}
+	for i, r := range s {
+		switch {
+		case r == '-' || r == '_' || r == ' ':
+			flush()
+		case r >= 'A' && r <= 'Z' && i > 0:
+			flush()
+			cur.WriteRune(r)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return words
+}
+
+// tokenColor converts a token value to a CSS color. It accepts hex and CSS
+// color functions, and DTCG color objects with hex or sRGB components.
+// Anything else is rejected, since the result is written into a stylesheet.
+func tokenColor(v any) (string, bool) {
+	switch c := v.(type) {
+	case string:
+		c = strings.TrimSpace(c)
+		if hexColorPattern.MatchString(c) || funcColorPattern.MatchString(strings.ToLower(c)) {
+			return c, true
+		}
+		if c == "transparent" {
+			return c, true
+		}
+	case map[string]any:
+		alpha := 1.0
+		if a, ok := c["alpha"].(float64); ok {
+			alpha = a
+		}
+		if hex, ok := c["hex"].(string); ok && hexColorPattern.MatchString(hex) && alpha >= 1 {
+			return hex, true
+		}
+		comps, ok := c["components"].([]any)
+		space, _ := c["colorSpace"].(string)
+		if !ok || len(comps) != 3 || (space != "" && space != "srgb") {
+			if hex, ok := c["hex"].(string); ok && hexColorPattern.MatchString(hex) {
+				return hex, true
+			}
+			return "", false
+		}
+		var rgb [3]int
+		for i, comp := range comps {
+			f, ok := comp.(float64)
+			if !ok {
+				return "", false
+			}
+			rgb[i] = int(math.Round(math.Max(0, math.Min(1, f)) * 255))
+		}
+		if alpha < 1 {
+			return fmt.Sprintf("rgba(%d, %d, %d, %s)", rgb[0], rgb[1], rgb[2], trimFloat(alpha)), true
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), true
+	}
+	return "", false
+}
+
+// tokenShadowColor takes the color of a DTCG shadow token, since Shadow is
+// the color used in box-shadow
+func tokenShadowColor(v any) (string, bool) {
+	switch s := v.(type) {
+	case map[string]any:
+		return tokenColor(s["color"])
+	case []any:
+		if len(s) > 0 {
+			return tokenShadowColor(s[0])
+		}
+	}
+	return "", false
+}
+
+func trimFloat(f float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", f), "0"), ".")
+}
@@ -0,0 +1,62 @@
+package components
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestImportThemeTokensShape(t *testing.T) {
+	data := []byte(`{
+		"color": {"primary": {"$value": "#2563eb", "$type": "color"}},
+		"border": {"radius": {"$value": "4px", "$type": "dimension"}},
+		"radius": {
+			"lg": {"$value": {"value": 0.75, "unit": "rem"}, "$type": "dimension"},
+			"sm": {"$value": "2px", "$type": "dimension"}
+		},
+		"font": {"body": {"$value": ["Inter Variable", "sans-serif"], "$type": "fontFamily"}},
+		"density": {"$value": "compact"}
+	}`)
+	got, err := ImportThemeTokens(data, ThemeTokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Primary != "#2563eb" {
+		t.Errorf("Primary = %q", got.Primary)
+	}
+	if got.Radius != "4px" || got.RadiusLarge != "0.75rem" {
+		t.Errorf("Radius, RadiusLarge = %q, %q", got.Radius, got.RadiusLarge)
+	}
+	if got.FontFamily != `"Inter Variable", sans-serif` {
+		t.Errorf("FontFamily = %q", got.FontFamily)
+	}
+	if got.Density != DensityCompact {
+		t.Errorf("Density = %q", got.Density)
+	}
+}
+
+func TestImportThemeTokensShapeMap(t *testing.T) {
+	data := []byte(`{"corner": {"card": {"$value": "12px", "$type": "dimension"}}}`)
+	got, err := ImportThemeTokens(data, ThemeTokenOptions{Map: map[string]string{"corner.card": "RadiusLarge"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RadiusLarge != "12px" {
+		t.Errorf("RadiusLarge = %q", got.RadiusLarge)
+	}
+}
+
+func TestImportThemeTokensShapeInvalid(t *testing.T) {
+	data := []byte(`{
+		"radius": {"$value": "4px; color: red", "$type": "dimension"},
+		"font": {"family": {"$value": "Inter; }", "$type": "fontFamily"}},
+		"density": {"$value": "tight"}
+	}`)
+	got, err := ImportThemeTokens(data, ThemeTokenOptions{})
+	var tokErr *ThemeTokenError
+	if !errors.As(err, &tokErr) || len(tokErr.Invalid) != 3 {
+		t.Fatalf("err = %v, want 3 invalid tokens", err)
+	}
+	if got.Radius != DefaultLightColors.Radius || got.FontFamily != DefaultLightColors.FontFamily || got.Density != DefaultLightColors.Density {
+		t.Errorf("invalid tokens changed the theme: %+v", got)
+	}
+}
@@ -0,0 +1,602 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// TimePoint is a single sample in a TimeSeriesChart
+type TimePoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// TimeSeriesChartProps configures a TimeSeriesChart
+type TimeSeriesChartProps struct {
+	Data          []TimePoint
+	Width         string // default "100%"
+	Height        string // default "240px"
+	LineColor     string // default "#3b82f6"
+	FillColor     string // Fill under line (optional)
+	ShowPoints    bool
+	ShowGrid      bool
+	Start         time.Time     // Initial visible range start (zero = first point)
+	End           time.Time     // Initial visible range end (zero = last point)
+	MinSpan       time.Duration // Smallest zoom span (default 1 minute)
+	MaxSpan       time.Duration // Largest zoom span (0 = unlimited)
+	ValueFormat   func(v float64) string
+	ClassName     string
+	OnRangeChange func(start, end time.Time) // Fired after zoom/pan settles, e.g. to lazy load data
+}
+
+// TimeSeriesChart renders a line chart with a time-scaled X axis.
+// Mouse-wheel zooms around the cursor, dragging pans, double-click resets,
+// and +/-/arrow keys zoom and pan when focused.
+type TimeSeriesChart struct {
+	container   js.Value
+	svg         js.Value
+	props       TimeSeriesChartProps
+	data        []TimePoint
+	start       time.Time
+	end         time.Time
+	dragging    bool
+	dragX       float64
+	dragStart   time.Time
+	dragEnd     time.Time
+	clipID      string
+	notifyTimer js.Value
+	notifyFunc  js.Func
+	listeners   listeners
+}
+
+const (
+	tsWidth   = 600.0
+	tsHeight  = 240.0
+	tsPadL    = 48.0
+	tsPadR    = 12.0
+	tsPadT    = 12.0
+	tsPadB    = 28.0
+	tsSVGNS   = "http://www.w3.org/2000/svg"
+	tsZoomIn  = 1 / 1.25
+	tsZoomOut = 1.25
+
+	// tsMaxSpan is the widest range a zoom can reach, well inside the
+	// ~292 years a time.Duration holds
+	tsMaxSpan = 200 * 365 * 24 * time.Hour
+)
+
+// NewTimeSeriesChart creates a new TimeSeriesChart
+func NewTimeSeriesChart(props TimeSeriesChartProps) *TimeSeriesChart {
+	document := js.Global().Get("document")
+
+	if props.Width == "" {
+		props.Width = "100%"
+	}
+	if props.Height == "" {
+		props.Height = "240px"
+	}
+	if props.LineColor == "" {
+		props.LineColor = "#3b82f6"
+	}
+	if props.MinSpan <= 0 {
+		props.MinSpan = time.Minute
+	}
+	if props.ValueFormat == nil {
+		props.ValueFormat = formatNumber
+	}
+
+	c := &TimeSeriesChart{
+		props:  props,
+		clipID: "ts-clip-" + js.Global().Get("crypto").Call("randomUUID").String(),
+	}
+	c.setData(props.Data)
+	c.start, c.end = props.Start, props.End
+	if c.start.IsZero() || c.end.IsZero() {
+		c.resetRange()
+	}
+
+	container := document.Call("createElement", "div")
+	className := "w-full select-none focus:outline-none focus-visible:ring-2 focus-visible:ring-blue-500 rounded"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	container.Set("className", className)
+	container.Get("style").Set("width", props.Width)
+	container.Get("style").Set("height", props.Height)
+	container.Get("style").Set("maxWidth", "100%")
+	container.Get("style").Set("touchAction", "none")
+	container.Get("style").Set("cursor", "grab")
+	container.Set("tabIndex", 0)
+	container.Call("setAttribute", "role", "img")
+	c.container = container
+
+	svg := document.Call("createElementNS", tsSVGNS, "svg")
+	svg.Call("setAttribute", "width", "100%")
+	svg.Call("setAttribute", "height", "100%")
+	svg.Call("setAttribute", "viewBox", fmt.Sprintf("0 0 %.0f %.0f", tsWidth, tsHeight))
+	container.Call("appendChild", svg)
+	c.svg = svg
+
+	c.notifyFunc = c.listeners.fn(func(this js.Value, args []js.Value) any {
+		c.notifyTimer = js.Undefined()
+		if c.props.OnRangeChange != nil {
+			c.props.OnRangeChange(c.start, c.end)
+		}
+		return nil
+	})
+	c.listeners.onRelease(func() {
+		if !c.notifyTimer.IsUndefined() && !c.notifyTimer.IsNull() {
+			js.Global().Call("clearTimeout", c.notifyTimer)
+		}
+	})
+
+	c.attachEvents()
+	c.render()
+
+	onUnmount(c.container, c.Unmount)
+	return c
+}
+
+func (c *TimeSeriesChart) setData(data []TimePoint) {
+	c.data = make([]TimePoint, len(data))
+	copy(c.data, data)
+	sort.Slice(c.data, func(i, j int) bool { return c.data[i].Time.Before(c.data[j].Time) })
+}
+
+func (c *TimeSeriesChart) resetRange() {
+	if len(c.data) == 0 {
+		c.end = time.Now()
+		c.start = c.end.Add(-time.Hour)
+		return
+	}
+	c.start, c.end = c.data[0].Time, c.data[len(c.data)-1].Time
+	if !c.end.After(c.start) {
+		c.start = c.start.Add(-c.props.MinSpan / 2)
+		c.end = c.end.Add(c.props.MinSpan / 2)
+	}
+}
+
+// svgX converts a client X coordinate to SVG viewBox units,
+// accounting for the letterboxing of the default preserveAspectRatio
+func (c *TimeSeriesChart) svgX(clientX float64) float64 {
+	rect := c.svg.Call("getBoundingClientRect")
+	width, height := rect.Get("width").Float(), rect.Get("height").Float()
+	if width == 0 || height == 0 {
+		return 0
+	}
+	scale := math.Min(width/tsWidth, height/tsHeight)
+	offset := (width - tsWidth*scale) / 2
+	return (clientX - rect.Get("left").Float() - offset) / scale
+}
+
+func (c *TimeSeriesChart) attachEvents() {
+	c.container.Call("addEventListener", "wheel", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		event.Call("preventDefault")
+		frac := (c.svgX(event.Get("clientX").Float()) - tsPadL) / (tsWidth - tsPadL - tsPadR)
+		factor := tsZoomIn
+		if event.Get("deltaY").Float() > 0 {
+			factor = tsZoomOut
+		}
+		c.zoom(factor, math.Max(0, math.Min(1, frac)))
+		return nil
+	}), map[string]any{"passive": false})
+
+	c.container.Call("addEventListener", "pointerdown", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if event.Get("button").Int() != 0 {
+			return nil
+		}
+		c.dragging = true
+		c.dragX = c.svgX(event.Get("clientX").Float())
+		c.dragStart, c.dragEnd = c.start, c.end
+		c.container.Call("setPointerCapture", event.Get("pointerId"))
+		c.container.Get("style").Set("cursor", "grabbing")
+		return nil
+	}))
+
+	c.container.Call("addEventListener", "pointermove", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		if !c.dragging {
+			return nil
+		}
+		dx := c.svgX(args[0].Get("clientX").Float()) - c.dragX
+		span := c.dragEnd.Sub(c.dragStart)
+		shift := time.Duration(-dx / (tsWidth - tsPadL - tsPadR) * float64(span))
+		c.start, c.end = c.dragStart.Add(shift), c.dragEnd.Add(shift)
+		c.render()
+		return nil
+	}))
+
+	endDrag := c.listeners.fn(func(this js.Value, args []js.Value) any {
+		if !c.dragging {
+			return nil
+		}
+		c.dragging = false
+		c.container.Get("style").Set("cursor", "grab")
+		if !c.start.Equal(c.dragStart) {
+			c.scheduleNotify()
+		}
+		return nil
+	})
+	c.container.Call("addEventListener", "pointerup", endDrag)
+	c.container.Call("addEventListener", "pointercancel", endDrag)
+
+	c.container.Call("addEventListener", "dblclick", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		c.ResetZoom()
+		return nil
+	}))
+
+	c.container.Call("addEventListener", "keydown", c.listeners.fn(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		span := c.end.Sub(c.start)
+		switch event.Get("key").String() {
+		case "+", "=":
+			c.zoom(tsZoomIn, 0.5)
+		case "-", "_":
+			c.zoom(tsZoomOut, 0.5)
+		case "ArrowLeft":
+			c.SetRange(c.start.Add(-span/10), c.end.Add(-span/10))
+		case "ArrowRight":
+			c.SetRange(c.start.Add(span/10), c.end.Add(span/10))
+		case "0":
+			c.ResetZoom()
+		default:
+			return nil
+		}
+		event.Call("preventDefault")
+		return nil
+	}))
+}
+
+// zoom scales the visible span by factor, keeping the time at frac (0..1) fixed
+func (c *TimeSeriesChart) zoom(factor, frac float64) {
+	span := float64(c.end.Sub(c.start))
+	newSpan := span * factor
+	if newSpan < float64(c.props.MinSpan) {
+		newSpan = float64(c.props.MinSpan)
+	}
+	if c.props.MaxSpan > 0 && newSpan > float64(c.props.MaxSpan) {
+		newSpan = float64(c.props.MaxSpan)
+	}
+	if newSpan > float64(tsMaxSpan) {
+		newSpan = float64(tsMaxSpan)
+	}
+	if newSpan == span {
+		return
+	}
+	anchor := c.start.Add(time.Duration(frac * span))
+	c.start = anchor.Add(-time.Duration(frac * newSpan))
+	c.end = c.start.Add(time.Duration(newSpan))
+	c.render()
+	c.scheduleNotify()
+}
+
+// scheduleNotify debounces OnRangeChange so wheel bursts produce one callback
+func (c *TimeSeriesChart) scheduleNotify() {
+	if c.props.OnRangeChange == nil {
+		return
+	}
+	if !c.notifyTimer.IsUndefined() && !c.notifyTimer.IsNull() {
+		js.Global().Call("clearTimeout", c.notifyTimer)
+	}
+	c.notifyTimer = js.Global().Call("setTimeout", c.notifyFunc, 200)
+}
+
+// visible returns the points inside the range plus one neighbor on each side
+// so the line continues to the chart edges
+func (c *TimeSeriesChart) visible() []TimePoint {
+	lo := sort.Search(len(c.data), func(i int) bool { return !c.data[i].Time.Before(c.start) })
+	hi := sort.Search(len(c.data), func(i int) bool { return c.data[i].Time.After(c.end) })
+	if lo > 0 {
+		lo--
+	}
+	if hi < len(c.data) {
+		hi++
+	}
+	return c.data[lo:hi]
+}
+
+// decimate keeps the min and max of each pixel bucket so large ranges stay fast
+// without hiding spikes
+func decimate(points []TimePoint, buckets int) []TimePoint {
+	if len(points) <= buckets*2 {
+		return points
+	}
+	out := make([]TimePoint, 0, buckets*2)
+	size := float64(len(points)) / float64(buckets)
+	for b := 0; b < buckets; b++ {
+		from, to := int(float64(b)*size), int(float64(b+1)*size)
+		if to > len(points) {
+			to = len(points)
+		}
+		if from >= to {
+			continue
+		}
+		lo, hi := points[from], points[from]
+		for _, p := range points[from:to] {
+			if p.Value < lo.Value {
+				lo = p
+			}
+			if p.Value > hi.Value {
+				hi = p
+			}
+		}
+		if lo.Time.After(hi.Time) {
+			lo, hi = hi, lo
+		}
+		out = append(out, lo)
+		if !hi.Time.Equal(lo.Time) {
+			out = append(out, hi)
+		}
+	}
+	return out
+}
+
+func (c *TimeSeriesChart) render() {
+	document := js.Global().Get("document")
+	c.svg.Set("innerHTML", "")
+
+	chartW := tsWidth - tsPadL - tsPadR
+	chartH := tsHeight - tsPadT - tsPadB
+	span := float64(c.end.Sub(c.start))
+	xFor := func(t time.Time) float64 {
+		return tsPadL + float64(t.Sub(c.start))/span*chartW
+	}
+
+	c.container.Call("setAttribute", "aria-label", fmt.Sprintf("Time series chart from %s to %s",
+		c.start.Format(time.RFC1123), c.end.Format(time.RFC1123)))
+
+	points := decimate(c.visible(), int(chartW))
+
+	minVal, maxVal := 0.0, 1.0
+	if len(points) > 0 {
+		minVal, maxVal = points[0].Value, points[0].Value
+		for _, p := range points {
+			minVal = math.Min(minVal, p.Value)
+			maxVal = math.Max(maxVal, p.Value)
+		}
+	}
+	if minVal == maxVal {
+		minVal--
+		maxVal++
+	}
+	yFor := func(v float64) float64 {
+		return tsPadT + chartH - (v-minVal)/(maxVal-minVal)*chartH
+	}
+
+	newEl := func(tag string, attrs map[string]string) js.Value {
+		el := document.Call("createElementNS", tsSVGNS, tag)
+		for k, v := range attrs {
+			el.Call("setAttribute", k, v)
+		}
+		c.svg.Call("appendChild", el)
+		return el
+	}
+
+	// Clip line to the plot area while panning
+	clipID := c.clipID
+	defs := newEl("defs", nil)
+	clip := document.Call("createElementNS", tsSVGNS, "clipPath")
+	clip.Call("setAttribute", "id", clipID)
+	rect := document.Call("createElementNS", tsSVGNS, "rect")
+	rect.Call("setAttribute", "x", fmt.Sprintf("%.1f", tsPadL))
+	rect.Call("setAttribute", "y", fmt.Sprintf("%.1f", tsPadT))
+	rect.Call("setAttribute", "width", fmt.Sprintf("%.1f", chartW))
+	rect.Call("setAttribute", "height", fmt.Sprintf("%.1f", chartH))
+	clip.Call("appendChild", rect)
+	defs.Call("appendChild", clip)
+
+	// Y axis labels and grid
+	for i := 0; i <= 4; i++ {
+		v := minVal + (maxVal-minVal)*float64(i)/4
+		y := yFor(v)
+		if c.props.ShowGrid {
+			newEl("line", map[string]string{
+				"x1": fmt.Sprintf("%.1f", tsPadL), "x2": fmt.Sprintf("%.1f", tsWidth-tsPadR),
+				"y1": fmt.Sprintf("%.1f", y), "y2": fmt.Sprintf("%.1f", y),
+				"stroke": "#e5e7eb", "stroke-width": "1",
+			})
+		}
+		label := newEl("text", map[string]string{
+			"x": fmt.Sprintf("%.1f", tsPadL-6), "y": fmt.Sprintf("%.1f", y),
+			"text-anchor": "end", "dominant-baseline": "middle", "font-size": "10", "fill": "#6b7280",
+		})
+		label.Set("textContent", c.props.ValueFormat(v))
+	}
+
+	// Time axis ticks
+	interval, layout := timeTickInterval(c.end.Sub(c.start))
+	for _, t := range timeTicks(c.start, c.end, interval) {
+		x := xFor(t)
+		if c.props.ShowGrid {
+			newEl("line", map[string]string{
+				"x1": fmt.Sprintf("%.1f", x), "x2": fmt.Sprintf("%.1f", x),
+				"y1": fmt.Sprintf("%.1f", tsPadT), "y2": fmt.Sprintf("%.1f", tsPadT+chartH),
+				"stroke": "#f3f4f6", "stroke-width": "1",
+			})
+		}
+		label := newEl("text", map[string]string{
+			"x": fmt.Sprintf("%.1f", x), "y": fmt.Sprintf("%.1f", tsHeight-8),
+			"text-anchor": "middle", "font-size": "10", "fill": "#6b7280",
+		})
+		label.Set("textContent", t.Format(layout))
+	}
+
+	if len(points) == 0 {
+		return
+	}
+
+	var path strings.Builder
+	for i, p := range points {
+		cmd := "L"
+		if i == 0 {
+			cmd = "M"
+		}
+		fmt.Fprintf(&path, "%s %.1f %.1f ", cmd, xFor(p.Time), yFor(p.Value))
+	}
+
+	if c.props.FillColor != "" {
+		base := tsPadT + chartH
+		fill := fmt.Sprintf("M %.1f %.1f L %s L %.1f %.1f Z",
+			xFor(points[0].Time), base, strings.TrimPrefix(strings.TrimSpace(path.String()), "M "),
+			xFor(points[len(points)-1].Time), base)
+		newEl("path", map[string]string{
+			"d": fill, "fill": c.props.FillColor, "opacity": "0.3", "clip-path": "url(#" + clipID + ")",
+		})
+	}
+
+	newEl("path", map[string]string{
+		"d": strings.TrimSpace(path.String()), "fill": "none", "stroke": c.props.LineColor,
+		"stroke-width": "2", "stroke-linecap": "round", "stroke-linejoin": "round",
+		"vector-effect": "non-scaling-stroke", "clip-path": "url(#" + clipID + ")",
+	})
+
+	if c.props.ShowPoints && len(points) <= int(chartW)/4 {
+		for _, p := range points {
+			if p.Time.Before(c.start) || p.Time.After(c.end) {
+				continue
+			}
+			dot := newEl("circle", map[string]string{
+				"cx": fmt.Sprintf("%.1f", xFor(p.Time)), "cy": fmt.Sprintf("%.1f", yFor(p.Value)),
+				"r": "3", "fill": c.props.LineColor,
+			})
+			title := document.Call("createElementNS", tsSVGNS, "title")
+			title.Set("textContent", p.Time.Format(time.RFC1123)+": "+c.props.ValueFormat(p.Value))
+			dot.Call("appendChild", title)
+		}
+	}
+}
+
+// timeTickInterval picks a tick spacing yielding at most ~6 labels and a matching label layout
+func timeTickInterval(span time.Duration) (time.Duration, string) {
+	const day = 24 * time.Hour
+	steps := []struct {
+		interval time.Duration
+		layout   string
+	}{
+		{time.Second, "15:04:05"},
+		{5 * time.Second, "15:04:05"},
+		{15 * time.Second, "15:04:05"},
+		{30 * time.Second, "15:04:05"},
+		{time.Minute, "15:04"},
+		{5 * time.Minute, "15:04"},
+		{15 * time.Minute, "15:04"},
+		{30 * time.Minute, "15:04"},
+		{time.Hour, "15:04"},
+		{3 * time.Hour, "Jan 2 15:04"},
+		{6 * time.Hour, "Jan 2 15:04"},
+		{12 * time.Hour, "Jan 2 15:04"},
+		{day, "Jan 2"},
+		{7 * day, "Jan 2"},
+		{30 * day, "Jan 2006"},
+		{90 * day, "Jan 2006"},
+		{365 * day, "2006"},
+	}
+	for _, s := range steps {
+		if span/s.interval <= 6 {
+			return s.interval, s.layout
+		}
+	}
+	// Whole years, capped so the multiplication can't overflow a Duration
+	const year = 365 * day
+	years := span/year/6 + 1
+	if years > math.MaxInt64/year {
+		years = math.MaxInt64 / year
+	}
+	return years * year, "2006"
+}
+
+// timeTicks returns tick times aligned to calendar boundaries in local time
+func timeTicks(start, end time.Time, interval time.Duration) []time.Time {
+	const day = 24 * time.Hour
+	var ticks []time.Time
+
+	// Sub-day intervals align to wall-clock multiples; larger ones to days, months, or years
+	var t time.Time
+	var next func(time.Time) time.Time
+	switch {
+	case interval < day:
+		_, offset := start.Zone()
+		shift := time.Duration(offset) * time.Second
+		t = start.Add(shift).Truncate(interval).Add(-shift)
+		next = func(t time.Time) time.Time { return t.Add(interval) }
+	case interval < 30*day:
+		y, m, d := start.Date()
+		t = time.Date(y, m, d, 0, 0, 0, 0, start.Location())
+		days := int(interval / day)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, days) }
+	case interval < 365*day:
+		y, m, _ := start.Date()
+		t = time.Date(y, m, 1, 0, 0, 0, 0, start.Location())
+		months := int(interval / (30 * day))
+		next = func(t time.Time) time.Time { return t.AddDate(0, months, 0) }
+	default:
+		years := int(interval / (365 * day))
+		y := start.Year() - start.Year()%years
+		t = time.Date(y, 1, 1, 0, 0, 0, 0, start.Location())
+		next = func(t time.Time) time.Time { return t.AddDate(years, 0, 0) }
+	}
+
+	for ; !t.After(end) && len(ticks) < 50; t = next(t) {
+		if !t.Before(start) {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks
+}
+
+// Element returns the container DOM element
+func (c *TimeSeriesChart) Element() js.Value {
+	return c.container
+}
+
+// Mount appends the chart to parent
+func (c *TimeSeriesChart) Mount(parent js.Value) {
+	parent.Call("appendChild", c.container)
+}
+
+// Unmount removes the chart and releases its listeners
+func (c *TimeSeriesChart) Unmount() {
+	unmount(c.container)
+	c.listeners.release()
+}
+
+// SetData replaces the series and redraws without changing the visible range
+func (c *TimeSeriesChart) SetData(data []TimePoint) {
+	c.setData(data)
+	c.render()
+}
+
+// AppendData merges additional points (e.g. lazily loaded) and redraws
+func (c *TimeSeriesChart) AppendData(points ...TimePoint) {
+	c.setData(append(c.data, points...))
+	c.render()
+}
+
+// Range returns the visible time range
+func (c *TimeSeriesChart) Range() (time.Time, time.Time) {
+	return c.start, c.end
+}
+
+// SetRange sets the visible time range and fires OnRangeChange
+func (c *TimeSeriesChart) SetRange(start, end time.Time) {
+	if !end.After(start) {
+		return
+	}
+	c.start, c.end = start, end
+	c.render()
+	c.scheduleNotify()
+}
+
+// ResetZoom shows the full data range
+func (c *TimeSeriesChart) ResetZoom() {
+	c.resetRange()
+	c.render()
+	c.scheduleNotify()
+}
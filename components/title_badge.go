@@ -0,0 +1,224 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"strconv"
+	"syscall/js"
+)
+
+// TitleBadgeProps configures a TitleBadge
+type TitleBadgeProps struct {
+	// Title is the page title without a count (default: the document's
+	// title when the badge is created)
+	Title string
+
+	// Format builds the document title (default "(3) Dashboard", with
+	// counts over 99 shown as "99+")
+	Format func(count int, title string) string
+
+	// Favicon also draws the count on the page's favicon
+	Favicon bool
+
+	// ResetOnFocus clears the count when the user returns to the tab. After
+	// that only what arrives while they're away is counted, so a badge
+	// always means something new.
+	ResetOnFocus bool
+
+	// OnFocus is called when the user returns to the tab, e.g. to mark
+	// notifications read
+	OnFocus func()
+}
+
+// TitleBadge shows a count, such as unread notifications, in the browser
+// tab's title and favicon, so it's seen while the app is in the background
+type TitleBadge struct {
+	props     TitleBadgeProps
+	count     int
+	seen      int // the count when the user last returned, with ResetOnFocus
+	icon      js.Value
+	iconAdded bool     // no favicon link existed, so the badge added one
+	iconHref  string   // the favicon's original href
+	iconImage js.Value // the original favicon, once loaded
+	listeners listeners
+}
+
+// NewTitleBadge creates a TitleBadge with no count. Call SetCount, or use
+// BindTitleBadge or NotificationCenterProps.TitleBadge to follow a store.
+func NewTitleBadge(props TitleBadgeProps) *TitleBadge {
+	document := js.Global().Get("document")
+	if props.Title == "" {
+		props.Title = document.Get("title").String()
+	}
+	if props.Format == nil {
+		props.Format = formatTitleBadge
+	}
+	b := &TitleBadge{props: props}
+
+	if props.Favicon {
+		b.icon = document.Call("querySelector", `link[rel~="icon"]`)
+		if b.icon.IsNull() {
+			b.icon = document.Call("createElement", "link")
+			b.icon.Set("rel", "icon")
+			document.Get("head").Call("appendChild", b.icon)
+			b.iconAdded = true
+		} else if href := b.icon.Call("getAttribute", "href"); !href.IsNull() {
+			b.iconHref = href.String()
+			img := js.Global().Get("Image").New()
+			img.Set("crossOrigin", "anonymous")
+			b.listeners.on(img, "load", func(this js.Value, args []js.Value) any {
+				b.iconImage = img
+				b.render()
+				return nil
+			})
+			img.Set("src", b.iconHref)
+		}
+	}
+
+	returned := func(this js.Value, args []js.Value) any {
+		if document.Get("visibilityState").String() != "visible" {
+			return nil
+		}
+		if props.ResetOnFocus && b.seen != b.count {
+			b.seen = b.count
+			b.render()
+		}
+		if props.OnFocus != nil {
+			props.OnFocus()
+		}
+		return nil
+	}
+	b.listeners.on(document, "visibilitychange", returned)
+	b.listeners.on(js.Global(), "focus", returned)
+	return b
+}
+
+// BindTitleBadge creates a TitleBadge that follows the count derived from
+// a store, e.g. a *state.Store:
+//
+//	unread := state.New(0)
+//	components.BindTitleBadge(unread, func(n int) int { return n },
+//		components.TitleBadgeProps{Favicon: true})
+func BindTitleBadge[T any, S interface {
+	Get() T
+	Subscribe(func(T)) func()
+}](store S, count func(T) int, props TitleBadgeProps) *TitleBadge {
+	b := NewTitleBadge(props)
+	b.SetCount(count(store.Get()))
+	b.listeners.onRelease(store.Subscribe(func(state T) {
+		b.SetCount(count(state))
+	}))
+	return b
+}
+
+// SetCount shows count in the title and favicon, or just the title for 0
+func (b *TitleBadge) SetCount(count int) {
+	if count < 0 {
+		count = 0
+	}
+	b.count = count
+	if b.seen > count {
+		b.seen = count // some were read, so count again from here
+	}
+	b.render()
+}
+
+// Count returns the count shown, which excludes those seen when
+// ResetOnFocus is set
+func (b *TitleBadge) Count() int {
+	if b.props.ResetOnFocus {
+		return b.count - b.seen
+	}
+	return b.count
+}
+
+// SetTitle changes the title shown with the count, e.g. after navigation
+func (b *TitleBadge) SetTitle(title string) {
+	b.props.Title = title
+	b.render()
+}
+
+// Destroy restores the title and favicon and stops following the store
+func (b *TitleBadge) Destroy() {
+	b.listeners.release()
+	js.Global().Get("document").Set("title", b.props.Title)
+	switch {
+	case b.iconAdded:
+		b.icon.Call("remove")
+	case b.props.Favicon:
+		b.setIcon(b.iconHref)
+	}
+}
+
+func (b *TitleBadge) render() {
+	count := b.Count()
+	title := b.props.Title
+	if count > 0 {
+		title = b.props.Format(count, title)
+	}
+	js.Global().Get("document").Set("title", title)
+	if b.props.Favicon {
+		b.drawIcon(count)
+	}
+}
+
+func formatTitleBadge(count int, title string) string {
+	text := strconv.Itoa(count)
+	if count > 99 {
+		text = "99+"
+	}
+	if title == "" {
+		return "(" + text + ")"
+	}
+	return "(" + text + ") " + title
+}
+
+// drawIcon draws a red badge with count over the original favicon
+func (b *TitleBadge) drawIcon(count int) {
+	if count == 0 {
+		b.setIcon(b.iconHref)
+		return
+	}
+	defer func() {
+		// A cross-origin favicon served without CORS headers taints the
+		// canvas, and toDataURL throws; keep the plain icon then
+		if recover() != nil {
+			b.setIcon(b.iconHref)
+		}
+	}()
+
+	const size = 32
+	canvas := js.Global().Get("document").Call("createElement", "canvas")
+	canvas.Set("width", size)
+	canvas.Set("height", size)
+	ctx := canvas.Call("getContext", "2d")
+	if !b.iconImage.IsUndefined() {
+		ctx.Call("drawImage", b.iconImage, 0, 0, size, size)
+	}
+
+	text := strconv.Itoa(count)
+	if count > 9 {
+		text = "9+" // two characters are all that's legible at this size
+	}
+	ctx.Set("font", "bold 18px sans-serif")
+	width := max(20, ctx.Call("measureText", text).Get("width").Float()+8)
+	x := size - width
+	ctx.Set("fillStyle", "#dc2626")
+	ctx.Call("beginPath")
+	ctx.Call("roundRect", x, 0, width, 20, 10)
+	ctx.Call("fill")
+	ctx.Set("fillStyle", "#ffffff")
+	ctx.Set("textAlign", "center")
+	ctx.Set("textBaseline", "middle")
+	ctx.Call("fillText", text, x+width/2, 11)
+
+	b.setIcon(canvas.Call("toDataURL", "image/png").String())
+}
+
+func (b *TitleBadge) setIcon(href string) {
+	if href == "" {
+		b.icon.Call("removeAttribute", "href")
+		return
+	}
+	b.icon.Set("href", href)
+}
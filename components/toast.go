@@ -3,8 +3,12 @@
 package components
 
 import (
+	"errors"
+	"fmt"
 	"syscall/js"
 	"time"
+
+	"github.com/dougbarrett/gux/i18n"
 )
 
 // ToastVariant defines toast styling variants
@@ -28,15 +32,54 @@ var toastStyles = map[ToastVariant]struct {
 	ToastError:   {bg: "bg-red-600", text: "text-white", icon: "✕"},
 }
 
+// ToastPosition sets the corner or edge toasts stack from
+type ToastPosition string
+
+const (
+	ToastTopRight     ToastPosition = "top-right"
+	ToastTopLeft      ToastPosition = "top-left"
+	ToastTopCenter    ToastPosition = "top-center"
+	ToastBottomRight  ToastPosition = "bottom-right"
+	ToastBottomLeft   ToastPosition = "bottom-left"
+	ToastBottomCenter ToastPosition = "bottom-center"
+)
+
+var toastPositions = map[ToastPosition]struct {
+	container string
+	hidden    []any // classes applied while animating in and out
+}{
+	ToastTopRight:     {container: "top-4 right-4 items-end", hidden: []any{"translate-x-full", "opacity-0"}},
+	ToastTopLeft:      {container: "top-4 left-4 items-start", hidden: []any{"-translate-x-full", "opacity-0"}},
+	ToastTopCenter:    {container: "top-4 left-1/2 -translate-x-1/2 items-center", hidden: []any{"-translate-y-full", "opacity-0"}},
+	ToastBottomRight:  {container: "bottom-4 right-4 items-end", hidden: []any{"translate-x-full", "opacity-0"}},
+	ToastBottomLeft:   {container: "bottom-4 left-4 items-start", hidden: []any{"-translate-x-full", "opacity-0"}},
+	ToastBottomCenter: {container: "bottom-4 left-1/2 -translate-x-1/2 items-center", hidden: []any{"translate-y-full", "opacity-0"}},
+}
+
+// ToastOptions configures the toast manager
+type ToastOptions struct {
+	Position   ToastPosition // Default ToastTopRight
+	MaxVisible int           // Toasts shown at once; extras wait in a queue (default 5)
+}
+
 // ToastManager manages toast notifications
 type ToastManager struct {
-	container js.Value
+	container  js.Value
+	position   ToastPosition
+	maxVisible int
+	visible    []*ToastHandle
+	queue      []*ToastHandle
 }
 
 var globalToastManager *ToastManager
 
 // InitToasts initializes the global toast manager (call once on app startup)
 func InitToasts() *ToastManager {
+	return InitToastsWithOptions(ToastOptions{})
+}
+
+// InitToastsWithOptions initializes the global toast manager with a position and stacking limit
+func InitToastsWithOptions(opts ToastOptions) *ToastManager {
 	if globalToastManager != nil {
 		return globalToastManager
 	}
@@ -45,7 +88,6 @@ func InitToasts() *ToastManager {
 
 	container := document.Call("createElement", "div")
 	container.Set("id", "toast-container")
-	container.Set("className", "fixed top-4 right-4 z-[9999] flex flex-col gap-2")
 	// ARIA live region for toast notifications
 	container.Call("setAttribute", "role", "status")
 	container.Call("setAttribute", "aria-live", "polite")
@@ -54,91 +96,377 @@ func InitToasts() *ToastManager {
 	document.Get("body").Call("appendChild", container)
 
 	globalToastManager = &ToastManager{container: container}
+	globalToastManager.SetPosition(opts.Position)
+	globalToastManager.SetMaxVisible(opts.MaxVisible)
 	return globalToastManager
 }
 
+// SetPosition moves the toast stack. Toasts already shown move with it.
+func (tm *ToastManager) SetPosition(position ToastPosition) {
+	if _, ok := toastPositions[position]; !ok {
+		position = ToastTopRight
+	}
+	tm.position = position
+	tm.container.Set("className", "fixed z-[9999] flex flex-col gap-2 "+toastPositions[position].container)
+}
+
+// SetMaxVisible sets how many toasts are shown at once. Extra toasts are
+// queued and shown as visible ones are dismissed.
+func (tm *ToastManager) SetMaxVisible(n int) {
+	if n <= 0 {
+		n = 5
+	}
+	tm.maxVisible = n
+	tm.showQueued()
+}
+
+// ToastAction is a button shown in a toast, e.g. "Undo"
+type ToastAction struct {
+	Label    string
+	OnClick  func()
+	KeepOpen bool // Leave the toast open after the click (default dismisses it)
+}
+
 // ToastProps configures a toast notification
 type ToastProps struct {
-	Variant  ToastVariant
-	Message  string
-	Duration time.Duration // 0 = no auto-dismiss
+	Variant      ToastVariant
+	Message      string
+	Duration     time.Duration // Auto-dismiss delay (default 3s)
+	Persistent   bool          // Stay until dismissed by the user or the handle
+	Actions      []ToastAction
+	ShowProgress bool   // Show a bar counting down to auto-dismiss
+	OnDismiss    func() // Called once when the toast is dismissed
 }
 
-// Show displays a toast notification
-func (tm *ToastManager) Show(props ToastProps) {
-	document := js.Global().Get("document")
+// ToastHandle controls a toast after it is shown
+type ToastHandle struct {
+	manager   *ToastManager
+	props     ToastProps
+	el        js.Value
+	message   js.Value
+	bar       js.Value
+	timer     js.Value
+	timerFunc js.Func
+	remaining time.Duration
+	deadline  time.Time
+	progress  float64 // determinate progress set with SetProgress, or -1
+	shown     bool
+	dismissed bool
+	listeners listeners // released when the toast is removed
+	buttons   listeners // of the current content, released by setProps
+}
+
+// Show displays a toast notification and returns a handle to update or dismiss it
+func (tm *ToastManager) Show(props ToastProps) *ToastHandle {
+	h := &ToastHandle{manager: tm, progress: -1}
+	h.el = js.Global().Get("document").Call("createElement", "div")
+
+	// Countdown pauses while the pointer is over the toast
+	h.el.Call("addEventListener", "mouseenter", h.listeners.fn(func(this js.Value, args []js.Value) any {
+		h.pauseTimer()
+		return nil
+	}))
+	h.el.Call("addEventListener", "mouseleave", h.listeners.fn(func(this js.Value, args []js.Value) any {
+		h.startTimer()
+		return nil
+	}))
+
+	h.setProps(props)
+
+	if len(tm.visible) >= tm.maxVisible {
+		tm.queue = append(tm.queue, h)
+		return h
+	}
+	tm.display(h)
+	return h
+}
 
-	variant := props.Variant
-	if variant == "" {
-		variant = ToastInfo
+// DismissAll dismisses every visible and queued toast
+func (tm *ToastManager) DismissAll() {
+	for _, h := range append(append([]*ToastHandle(nil), tm.queue...), tm.visible...) {
+		h.Dismiss()
 	}
+}
+
+func (tm *ToastManager) display(h *ToastHandle) {
+	h.shown = true
+	tm.visible = append(tm.visible, h)
+	h.el.Get("classList").Call("add", toastPositions[tm.position].hidden...)
+	tm.container.Call("appendChild", h.el)
+
+	// Animate in
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		if !h.dismissed {
+			h.el.Get("classList").Call("remove", toastPositions[tm.position].hidden...)
+			h.startTimer()
+		}
+	}()
+}
+
+func (tm *ToastManager) showQueued() {
+	for len(tm.queue) > 0 && len(tm.visible) < tm.maxVisible {
+		h := tm.queue[0]
+		tm.queue = tm.queue[1:]
+		tm.display(h)
+	}
+}
+
+func (tm *ToastManager) remove(h *ToastHandle) {
+	for i, v := range tm.visible {
+		if v == h {
+			tm.visible = append(tm.visible[:i], tm.visible[i+1:]...)
+			break
+		}
+	}
+	for i, q := range tm.queue {
+		if q == h {
+			tm.queue = append(tm.queue[:i], tm.queue[i+1:]...)
+			break
+		}
+	}
+}
+
+// setProps renders the toast content and resets its countdown
+func (h *ToastHandle) setProps(props ToastProps) {
+	document := js.Global().Get("document")
 
-	style := toastStyles[variant]
-	duration := props.Duration
-	if duration == 0 {
-		duration = 3 * time.Second
+	if props.Variant == "" {
+		props.Variant = ToastInfo
+	}
+	if props.Duration == 0 {
+		props.Duration = 3 * time.Second
 	}
+	h.props = props
+	h.remaining = props.Duration
+	style := toastStyles[props.Variant]
 
-	toast := document.Call("createElement", "div")
-	toast.Set("className", style.bg+" "+style.text+" px-4 py-3 rounded-lg shadow-lg flex items-center gap-3 min-w-64 transform transition-all duration-300 translate-x-full opacity-0")
+	h.buttons.release()
+	h.el.Set("innerHTML", "")
+	h.el.Set("className", style.bg+" "+style.text+" relative overflow-hidden px-4 py-3 rounded-lg shadow-lg flex items-center gap-3 min-w-64 max-w-md transform transition-all duration-300")
 
 	// Icon (decorative)
 	icon := document.Call("createElement", "span")
 	icon.Set("className", "text-lg")
 	icon.Set("textContent", style.icon)
 	icon.Call("setAttribute", "aria-hidden", "true")
-	toast.Call("appendChild", icon)
+	h.el.Call("appendChild", icon)
 
 	// Message
-	message := document.Call("createElement", "span")
-	message.Set("className", "flex-1")
-	message.Set("textContent", props.Message)
-	toast.Call("appendChild", message)
+	h.message = document.Call("createElement", "span")
+	h.message.Set("className", "flex-1")
+	h.message.Set("textContent", props.Message)
+	h.el.Call("appendChild", h.message)
+
+	// Actions
+	for _, action := range props.Actions {
+		action := action
+		btn := document.Call("createElement", "button")
+		btn.Set("type", "button")
+		btn.Set("className", "px-2 py-1 text-sm font-semibold rounded bg-white/20 hover:bg-white/30 cursor-pointer")
+		btn.Set("textContent", action.Label)
+		btn.Call("addEventListener", "click", h.buttons.fn(func(this js.Value, args []js.Value) any {
+			if action.OnClick != nil {
+				action.OnClick()
+			}
+			if !action.KeepOpen {
+				h.Dismiss()
+			}
+			return nil
+		}))
+		h.el.Call("appendChild", btn)
+	}
 
 	// Close button
 	closeBtn := document.Call("createElement", "button")
+	closeBtn.Set("type", "button")
 	closeBtn.Set("className", "opacity-70 hover:opacity-100 cursor-pointer text-lg")
 	closeBtn.Set("textContent", "×")
-	closeBtn.Call("setAttribute", "aria-label", "Dismiss notification")
+	closeBtn.Call("setAttribute", "aria-label", i18n.T("gux.toast.dismiss"))
+	closeBtn.Call("addEventListener", "click", h.buttons.fn(func(this js.Value, args []js.Value) any {
+		h.Dismiss()
+		return nil
+	}))
+	h.el.Call("appendChild", closeBtn)
+
+	// Progress bar along the bottom edge
+	h.bar = js.Undefined()
+	if props.ShowProgress || h.progress >= 0 {
+		h.bar = document.Call("createElement", "div")
+		h.bar.Set("className", "absolute bottom-0 left-0 h-1 bg-white/60")
+		h.bar.Get("style").Set("width", "100%")
+		h.bar.Call("setAttribute", "aria-hidden", "true")
+		h.el.Call("appendChild", h.bar)
+		if h.progress >= 0 {
+			h.renderProgress()
+		}
+	}
+
+	if h.shown {
+		h.stopTimer()
+		h.startTimer()
+	}
+}
 
-	var removeToast js.Func
-	removeToast = js.FuncOf(func(this js.Value, args []js.Value) any {
-		toast.Get("classList").Call("add", "translate-x-full", "opacity-0")
+// Update replaces the toast's content and restarts its auto-dismiss
+// countdown, e.g. to turn a "Saving..." toast into "Saved"
+func (h *ToastHandle) Update(props ToastProps) {
+	if h.dismissed {
+		return
+	}
+	h.progress = -1
+	h.setProps(props)
+}
+
+// SetMessage changes the toast's message, keeping its other settings
+func (h *ToastHandle) SetMessage(message string) {
+	if h.dismissed {
+		return
+	}
+	h.message.Set("textContent", message)
+	h.props.Message = message
+}
+
+// SetProgress shows determinate progress from 0 to 1, e.g. for an upload.
+// It replaces the auto-dismiss countdown bar.
+func (h *ToastHandle) SetProgress(p float64) {
+	if h.dismissed {
+		return
+	}
+	if p < 0 {
+		p = 0
+	} else if p > 1 {
+		p = 1
+	}
+	first := h.progress < 0
+	h.progress = p
+	if first {
+		// Re-render to add the bar and drop the countdown
+		h.setProps(h.props)
+		return
+	}
+	h.renderProgress()
+}
+
+func (h *ToastHandle) renderProgress() {
+	style := h.bar.Get("style")
+	style.Set("transition", "width 200ms ease-out")
+	style.Set("width", fmt.Sprintf("%.1f%%", h.progress*100))
+}
+
+// Dismiss removes the toast, or drops it from the queue if not yet shown
+func (h *ToastHandle) Dismiss() {
+	if h.dismissed {
+		return
+	}
+	h.dismissed = true
+	h.stopTimer()
+
+	tm := h.manager
+	if !h.shown {
+		tm.remove(h)
+		h.release()
+	} else {
+		h.el.Get("classList").Call("add", toastPositions[tm.position].hidden...)
 		go func() {
 			time.Sleep(300 * time.Millisecond)
-			if toast.Get("parentNode").Truthy() {
-				tm.container.Call("removeChild", toast)
+			if h.el.Get("parentNode").Truthy() {
+				tm.container.Call("removeChild", h.el)
 			}
+			h.release()
+			tm.remove(h)
+			tm.showQueued()
 		}()
+	}
+
+	if h.props.OnDismiss != nil {
+		h.props.OnDismiss()
+	}
+}
+
+// release releases the listeners of a removed toast
+func (h *ToastHandle) release() {
+	h.buttons.release()
+	h.listeners.release()
+}
+
+// IsDismissed reports whether the toast has been dismissed
+func (h *ToastHandle) IsDismissed() bool {
+	return h.dismissed
+}
+
+// startTimer starts or resumes the auto-dismiss countdown
+func (h *ToastHandle) startTimer() {
+	if h.dismissed || !h.shown || h.props.Persistent || h.progress >= 0 || h.timer.Truthy() {
+		return
+	}
+
+	h.deadline = time.Now().Add(h.remaining)
+	h.timerFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+		h.timer = js.Undefined()
+		h.timerFunc.Release()
+		h.Dismiss()
 		return nil
 	})
+	h.timer = js.Global().Call("setTimeout", h.timerFunc, h.remaining.Milliseconds())
+
+	if h.bar.Truthy() {
+		// Jump to the remaining fraction, then shrink to zero over the remaining time
+		style := h.bar.Get("style")
+		style.Set("transition", "none")
+		style.Set("width", fmt.Sprintf("%.1f%%", float64(h.remaining)/float64(h.props.Duration)*100))
+		h.bar.Get("offsetWidth") // force reflow so the transition starts from here
+		style.Set("transition", fmt.Sprintf("width %dms linear", h.remaining.Milliseconds()))
+		style.Set("width", "0%")
+	}
+}
 
-	closeBtn.Call("addEventListener", "click", removeToast)
-	toast.Call("appendChild", closeBtn)
-
-	tm.container.Call("appendChild", toast)
+// pauseTimer stops the countdown, keeping the remaining time
+func (h *ToastHandle) pauseTimer() {
+	if !h.timer.Truthy() {
+		return
+	}
+	h.stopTimer()
+	h.remaining = time.Until(h.deadline)
+	if h.remaining < 0 {
+		h.remaining = 0
+	}
 
-	// Animate in
-	go func() {
-		time.Sleep(10 * time.Millisecond)
-		toast.Get("classList").Call("remove", "translate-x-full", "opacity-0")
+	if h.bar.Truthy() {
+		style := h.bar.Get("style")
+		style.Set("transition", "none")
+		style.Set("width", fmt.Sprintf("%.1f%%", float64(h.remaining)/float64(h.props.Duration)*100))
+	}
+}
 
-		// Auto-dismiss
-		if duration > 0 {
-			time.Sleep(duration)
-			removeToast.Invoke()
-		}
-	}()
+func (h *ToastHandle) stopTimer() {
+	if h.timer.Truthy() {
+		js.Global().Call("clearTimeout", h.timer)
+		h.timer = js.Undefined()
+		h.timerFunc.Release()
+	}
 }
 
 // Global toast functions for convenience
 
-// Toast shows a toast with the global manager
-func Toast(message string, variant ToastVariant) {
+// ShowToast shows a toast with the global manager and returns its handle
+func ShowToast(props ToastProps) *ToastHandle {
 	if globalToastManager == nil {
 		InitToasts()
 	}
-	globalToastManager.Show(ToastProps{Message: message, Variant: variant})
+	return globalToastManager.Show(props)
+}
+
+// DismissAllToasts dismisses every toast shown by the global manager
+func DismissAllToasts() {
+	if globalToastManager != nil {
+		globalToastManager.DismissAll()
+	}
+}
+
+// Toast shows a toast with the global manager
+func Toast(message string, variant ToastVariant) {
+	ShowToast(ToastProps{Message: message, Variant: variant})
 }
 
 // ShowInfo shows an info toast
@@ -161,10 +489,44 @@ func ShowError(message string) {
 	Toast(message, ToastError)
 }
 
+// ShowErrorToast shows an error toast for err. When err carries a request
+// ID, as generated API clients' errors do for responses from servers using
+// server.RequestID, the toast shows it with a button to copy it:
+// "Something went wrong (ref: 3f9a1c0e7b2d)". Users can quote it to
+// support, who find the server's log lines by it. Messages of server
+// errors (5xx) are replaced by "Something went wrong".
+func ShowErrorToast(err error) *ToastHandle {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	var ref string
+	var referenced interface{ Reference() string }
+	if errors.As(err, &referenced) {
+		ref = referenced.Reference()
+	}
+	var server interface{ ServerError() bool }
+	if errors.As(err, &server) && server.ServerError() || message == "" {
+		message = i18n.T("gux.toast.failed")
+	}
+	if ref == "" {
+		return ShowToast(ToastProps{Message: message, Variant: ToastError})
+	}
+	return ShowToast(ToastProps{
+		Message:  i18n.T("gux.toast.ref", message, ref),
+		Variant:  ToastError,
+		Duration: 10 * time.Second,
+		Actions: []ToastAction{{
+			Label: i18n.T("gux.toast.copyRef"),
+			OnClick: func() {
+				CopyToClipboard(ref)
+				ShowSuccess(i18n.T("gux.toast.refCopied"))
+			},
+		}},
+	})
+}
+
 // ToastWithDuration shows a toast with custom duration
 func ToastWithDuration(message string, variant ToastVariant, duration time.Duration) {
-	if globalToastManager == nil {
-		InitToasts()
-	}
-	globalToastManager.Show(ToastProps{Message: message, Variant: variant, Duration: duration})
+	ShowToast(ToastProps{Message: message, Variant: variant, Duration: duration})
 }
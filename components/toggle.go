@@ -31,6 +31,7 @@ type Toggle struct {
 	checked   bool
 	disabled  bool
 	onChange  func(bool)
+	listeners listeners
 }
 
 // NewToggle creates a new Toggle component
@@ -128,13 +129,13 @@ func NewToggle(props ToggleProps) *Toggle {
 
 	// Click handler
 	if !props.Disabled {
-		container.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		container.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
 			t.Toggle()
 			return nil
 		}))
 
 		// Keyboard handler
-		toggle.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		toggle.Call("addEventListener", "keydown", t.listeners.fn(func(this js.Value, args []js.Value) any {
 			key := args[0].Get("key").String()
 			if key == " " || key == "Enter" {
 				args[0].Call("preventDefault")
@@ -144,6 +145,7 @@ func NewToggle(props ToggleProps) *Toggle {
 		}))
 	}
 
+	onUnmount(t.container, t.Unmount)
 	return t
 }
 
@@ -166,6 +168,17 @@ func (t *Toggle) Element() js.Value {
 	return t.container
 }
 
+// Mount appends the toggle to parent
+func (t *Toggle) Mount(parent js.Value) {
+	parent.Call("appendChild", t.container)
+}
+
+// Unmount removes the toggle and releases its listeners
+func (t *Toggle) Unmount() {
+	unmount(t.container)
+	t.listeners.release()
+}
+
 // Checked returns whether the toggle is checked
 func (t *Toggle) Checked() bool {
 	return t.checked
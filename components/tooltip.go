@@ -60,19 +60,24 @@ func WithTooltip(element js.Value, props TooltipProps) js.Value {
 	}
 
 	var timeoutID js.Value
+	var funcs listeners
+	show := funcs.fn(func(this js.Value, args []js.Value) any {
+		tooltip.Get("classList").Call("remove", "opacity-0", "invisible")
+		tooltip.Get("classList").Call("add", "opacity-100", "visible")
+		return nil
+	})
+	funcs.onRelease(func() {
+		js.Global().Call("clearTimeout", timeoutID)
+	})
 
 	// Show on hover
-	element.Call("addEventListener", "mouseenter", js.FuncOf(func(this js.Value, args []js.Value) any {
-		timeoutID = js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-			tooltip.Get("classList").Call("remove", "opacity-0", "invisible")
-			tooltip.Get("classList").Call("add", "opacity-100", "visible")
-			return nil
-		}), delay)
+	element.Call("addEventListener", "mouseenter", funcs.fn(func(this js.Value, args []js.Value) any {
+		timeoutID = js.Global().Call("setTimeout", show, delay)
 		return nil
 	}))
 
 	// Hide on leave
-	element.Call("addEventListener", "mouseleave", js.FuncOf(func(this js.Value, args []js.Value) any {
+	element.Call("addEventListener", "mouseleave", funcs.fn(func(this js.Value, args []js.Value) any {
 		js.Global().Call("clearTimeout", timeoutID)
 		tooltip.Get("classList").Call("remove", "opacity-100", "visible")
 		tooltip.Get("classList").Call("add", "opacity-0", "invisible")
@@ -81,6 +86,7 @@ func WithTooltip(element js.Value, props TooltipProps) js.Value {
 
 	wrapper.Call("appendChild", element)
 	wrapper.Call("appendChild", tooltip)
+	onUnmount(wrapper, funcs.release)
 
 	return wrapper
 }
@@ -0,0 +1,656 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// TreeNode is a node in a TreeView
+type TreeNode struct {
+	ID       string
+	Label    string
+	Icon     string // icon name, e.g. "folder"
+	Children []TreeNode
+
+	// HasChildren marks a node whose children are not loaded yet. It is
+	// shown as expandable and OnExpand fetches its children.
+	HasChildren bool
+
+	Disabled bool
+	Data     any // app data
+}
+
+// TreeViewProps configures a TreeView
+type TreeViewProps struct {
+	Nodes    []TreeNode
+	Expanded []string // IDs of nodes expanded initially
+
+	// Checkboxes shows a checkbox on every node. Checking a parent checks its
+	// descendants, and a parent with some checked descendants is shown as
+	// indeterminate.
+	Checkboxes bool
+
+	OnSelect func(node TreeNode)
+	OnCheck  func(checked []string) // IDs of all fully checked nodes
+
+	// OnExpand is called whenever a node is expanded. It runs in a goroutine,
+	// so it can fetch. Returned children replace the node's children; return
+	// nil to keep them. A node with HasChildren and no children shows a
+	// loading row until it returns, and an error row with a retry button if
+	// it fails.
+	OnExpand func(node TreeNode) ([]TreeNode, error)
+
+	AriaLabel string
+	ClassName string
+}
+
+// TreeView shows hierarchical data, such as a file browser or an org chart,
+// with expand/collapse, optional tri-state checkboxes, and keyboard
+// navigation
+type TreeView struct {
+	container js.Value
+	props     TreeViewProps
+	nodes     []TreeNode
+
+	byID      map[string]*TreeNode
+	parents   map[string]string // node ID -> parent ID ("" for roots)
+	rows      []treeRow         // visible nodes in display order
+	expanded  map[string]bool
+	checked   map[string]bool // own state; parents with children derive theirs
+	loading   map[string]bool
+	errors    map[string]error
+	version   int // bumped by SetNodes, so loads for the old tree are dropped
+	selected  string
+	focused   string
+	listeners listeners
+}
+
+type treeRow struct {
+	node   *TreeNode
+	level  int
+	pos    int
+	setLen int
+}
+
+// NewTreeView creates a TreeView
+func NewTreeView(props TreeViewProps) *TreeView {
+	t := &TreeView{
+		props:    props,
+		expanded: make(map[string]bool),
+		checked:  make(map[string]bool),
+		loading:  make(map[string]bool),
+		errors:   make(map[string]error),
+	}
+	for _, id := range props.Expanded {
+		t.expanded[id] = true
+	}
+
+	t.container = Div("text-sm select-none " + props.ClassName)
+	t.container.Call("setAttribute", "role", "tree")
+	if props.AriaLabel != "" {
+		t.container.Call("setAttribute", "aria-label", props.AriaLabel)
+	}
+	if props.Checkboxes {
+		t.container.Call("setAttribute", "aria-multiselectable", "true")
+	}
+
+	// Listeners are delegated so re-rendering never creates js.Funcs
+	t.container.Call("addEventListener", "click", t.listeners.fn(func(this js.Value, args []js.Value) any {
+		t.handleClick(args[0])
+		return nil
+	}))
+	t.container.Call("addEventListener", "keydown", t.listeners.fn(func(this js.Value, args []js.Value) any {
+		t.handleKey(args[0])
+		return nil
+	}))
+
+	t.SetNodes(props.Nodes)
+	onUnmount(t.container, t.Unmount)
+	return t
+}
+
+// Element returns the tree element
+func (t *TreeView) Element() js.Value {
+	return t.container
+}
+
+// Mount appends the tree view to parent
+func (t *TreeView) Mount(parent js.Value) {
+	parent.Call("appendChild", t.container)
+}
+
+// Unmount removes the tree view and releases its listeners
+func (t *TreeView) Unmount() {
+	unmount(t.container)
+	t.listeners.release()
+}
+
+// SetNodes replaces the tree's nodes with a copy of nodes. Expanded and
+// checked state is kept for nodes whose IDs still exist; children still
+// loading for the old nodes are dropped.
+func (t *TreeView) SetNodes(nodes []TreeNode) {
+	t.nodes = cloneTreeNodes(nodes)
+	t.version++
+	t.loading = make(map[string]bool)
+	t.index()
+	t.render()
+}
+
+// Nodes returns a copy of the tree, including children loaded by OnExpand
+func (t *TreeView) Nodes() []TreeNode {
+	return cloneTreeNodes(t.nodes)
+}
+
+// cloneTreeNodes copies nodes and their descendants, so loading children
+// never writes into the caller's slices
+func cloneTreeNodes(nodes []TreeNode) []TreeNode {
+	if nodes == nil {
+		return nil
+	}
+	out := make([]TreeNode, len(nodes))
+	for i, n := range nodes {
+		n.Children = cloneTreeNodes(n.Children)
+		out[i] = n
+	}
+	return out
+}
+
+// Expand expands a node, calling OnExpand
+func (t *TreeView) Expand(id string) {
+	n, ok := t.byID[id]
+	if !ok || !expandable(n) || t.expanded[id] {
+		return
+	}
+	t.expanded[id] = true
+	t.render()
+	t.load(id)
+}
+
+// Collapse collapses a node
+func (t *TreeView) Collapse(id string) {
+	if !t.expanded[id] {
+		return
+	}
+	delete(t.expanded, id)
+	t.render()
+}
+
+// ExpandAll expands every node whose children are loaded. It does not call
+// OnExpand.
+func (t *TreeView) ExpandAll() {
+	for id, n := range t.byID {
+		if len(n.Children) > 0 {
+			t.expanded[id] = true
+		}
+	}
+	t.render()
+}
+
+// CollapseAll collapses every node
+func (t *TreeView) CollapseAll() {
+	t.expanded = make(map[string]bool)
+	t.render()
+}
+
+// Selected returns the ID of the selected node, or ""
+func (t *TreeView) Selected() string {
+	return t.selected
+}
+
+// Select selects a node and expands its ancestors. It does not call OnSelect.
+func (t *TreeView) Select(id string) {
+	if _, ok := t.byID[id]; !ok {
+		return
+	}
+	for p := t.parents[id]; p != ""; p = t.parents[p] {
+		t.expanded[p] = true
+	}
+	t.selected = id
+	t.focused = id
+	t.render()
+}
+
+// Checked returns the IDs of all fully checked nodes, parents included
+func (t *TreeView) Checked() []string {
+	var ids []string
+	var walk func(nodes []TreeNode)
+	walk = func(nodes []TreeNode) {
+		for i := range nodes {
+			n := &nodes[i]
+			if checked, _ := t.state(n); checked && !n.Disabled {
+				ids = append(ids, n.ID)
+			}
+			walk(n.Children)
+		}
+	}
+	walk(t.nodes)
+	return ids
+}
+
+// SetChecked checks the given nodes and their descendants and unchecks the
+// rest. It does not call OnCheck.
+func (t *TreeView) SetChecked(ids []string) {
+	t.checked = make(map[string]bool)
+	for _, id := range ids {
+		if n, ok := t.byID[id]; ok {
+			t.setChecked(n, true)
+		}
+	}
+	t.render()
+}
+
+// index rebuilds the ID and parent lookups after the tree changes
+func (t *TreeView) index() {
+	t.byID = make(map[string]*TreeNode)
+	t.parents = make(map[string]string)
+	var walk func(nodes []TreeNode, parent string)
+	walk = func(nodes []TreeNode, parent string) {
+		for i := range nodes {
+			n := &nodes[i]
+			t.byID[n.ID] = n
+			t.parents[n.ID] = parent
+			walk(n.Children, n.ID)
+		}
+	}
+	walk(t.nodes, "")
+}
+
+func expandable(n *TreeNode) bool {
+	return len(n.Children) > 0 || n.HasChildren
+}
+
+// load calls OnExpand for an expanded node in the background
+func (t *TreeView) load(id string) {
+	if t.props.OnExpand == nil || t.loading[id] {
+		return
+	}
+	n := t.byID[id]
+	lazy := len(n.Children) == 0
+	node := *n
+	node.Children = cloneTreeNodes(n.Children)
+	version := t.version
+	t.loading[id] = true
+	delete(t.errors, id)
+	if lazy {
+		t.render()
+	}
+
+	go func() {
+		children, err := t.props.OnExpand(node)
+		// SetNodes replaced the tree while loading; its nodes may differ
+		if version != t.version {
+			return
+		}
+		delete(t.loading, id)
+
+		n, ok := t.byID[id]
+		if !ok {
+			return
+		}
+		if err != nil {
+			t.errors[id] = err
+			Announce(i18n.T("gux.treeview.error", n.Label))
+		} else if children != nil {
+			checked, _ := t.state(n)
+			n.Children = cloneTreeNodes(children)
+			n.HasChildren = len(children) > 0
+			t.index()
+			if checked {
+				t.setChecked(t.byID[id], true)
+			}
+		}
+		t.render()
+	}()
+}
+
+// state returns whether a node is checked, and whether it is partly checked.
+// A node with enabled children derives its state from them.
+func (t *TreeView) state(n *TreeNode) (checked, mixed bool) {
+	all, some, counted := true, false, false
+	for i := range n.Children {
+		c := &n.Children[i]
+		if c.Disabled {
+			continue
+		}
+		counted = true
+		cc, cm := t.state(c)
+		if cc {
+			some = true
+		} else {
+			all = false
+		}
+		if cm {
+			some = true
+		}
+	}
+	if !counted {
+		return t.checked[n.ID], false
+	}
+	return all, some && !all
+}
+
+// setChecked sets a node and its enabled descendants
+func (t *TreeView) setChecked(n *TreeNode, checked bool) {
+	if n.Disabled {
+		return
+	}
+	if checked {
+		t.checked[n.ID] = true
+	} else {
+		delete(t.checked, n.ID)
+	}
+	for i := range n.Children {
+		t.setChecked(&n.Children[i], checked)
+	}
+}
+
+func (t *TreeView) toggleChecked(id string) {
+	n := t.byID[id]
+	checked, _ := t.state(n)
+	t.setChecked(n, !checked)
+	t.render()
+	if t.props.OnCheck != nil {
+		t.props.OnCheck(t.Checked())
+	}
+}
+
+func (t *TreeView) selectNode(id string) {
+	t.selected = id
+	t.focused = id
+	t.render()
+	if t.props.OnSelect != nil {
+		node := *t.byID[id]
+		node.Children = cloneTreeNodes(node.Children)
+		t.props.OnSelect(node)
+	}
+}
+
+func (t *TreeView) render() {
+	hadFocus := t.container.Call("contains", js.Global().Get("document").Get("activeElement")).Bool()
+
+	t.rows = t.rows[:0]
+	t.flatten(t.nodes, 1)
+
+	// Keep focus on a visible row: the nearest visible ancestor of the
+	// focused node, or the first row
+	visible := make(map[string]bool, len(t.rows))
+	for _, r := range t.rows {
+		visible[r.node.ID] = true
+	}
+	for t.focused != "" && !visible[t.focused] {
+		t.focused = t.parents[t.focused]
+	}
+	if t.focused == "" && len(t.rows) > 0 {
+		t.focused = t.rows[0].node.ID
+	}
+
+	t.container.Set("innerHTML", "")
+	for _, r := range t.rows {
+		t.container.Call("appendChild", t.renderRow(r))
+		id := r.node.ID
+		if !t.expanded[id] {
+			continue
+		}
+		if t.loading[id] && len(r.node.Children) == 0 {
+			t.container.Call("appendChild", t.renderStatus(r.level+1, i18n.T("gux.treeview.loading"), "", true))
+		} else if err, ok := t.errors[id]; ok {
+			t.container.Call("appendChild", t.renderStatus(r.level+1, i18n.T("gux.treeview.error", r.node.Label)+": "+err.Error(), id, false))
+		}
+	}
+
+	if hadFocus {
+		t.focusRow(t.focused)
+	}
+}
+
+func (t *TreeView) flatten(nodes []TreeNode, level int) {
+	for i := range nodes {
+		n := &nodes[i]
+		t.rows = append(t.rows, treeRow{node: n, level: level, pos: i + 1, setLen: len(nodes)})
+		if t.expanded[n.ID] {
+			t.flatten(n.Children, level+1)
+		}
+	}
+}
+
+func treeIndent(level int) string {
+	return fmt.Sprintf("%.2frem", 0.5+float64(level-1)*1.25)
+}
+
+func (t *TreeView) renderRow(r treeRow) js.Value {
+	n := r.node
+	document := js.Global().Get("document")
+
+	className := "flex items-center gap-1.5 py-1 pr-2 rounded cursor-pointer focus:outline-none focus:ring-2 focus:ring-blue-500"
+	switch {
+	case n.Disabled:
+		className += " text-disabled cursor-not-allowed"
+	case n.ID == t.selected:
+		className += " bg-blue-50 dark:bg-blue-900/30 text-blue-700 dark:text-blue-300"
+	default:
+		className += " text-primary hover:bg-gray-100 dark:hover:bg-gray-800"
+	}
+	row := Div(className)
+	row.Get("style").Set("paddingLeft", treeIndent(r.level))
+	row.Call("setAttribute", "role", "treeitem")
+	row.Call("setAttribute", "data-node", n.ID)
+	row.Call("setAttribute", "aria-level", r.level)
+	row.Call("setAttribute", "aria-posinset", r.pos)
+	row.Call("setAttribute", "aria-setsize", r.setLen)
+	row.Call("setAttribute", "aria-selected", fmt.Sprint(n.ID == t.selected))
+	if n.Disabled {
+		row.Call("setAttribute", "aria-disabled", "true")
+	}
+	if n.ID == t.focused {
+		row.Set("tabIndex", 0)
+	} else {
+		row.Set("tabIndex", -1)
+	}
+	if t.loading[n.ID] {
+		row.Call("setAttribute", "aria-busy", "true")
+	}
+
+	if expandable(n) {
+		row.Call("setAttribute", "aria-expanded", fmt.Sprint(t.expanded[n.ID]))
+		toggle := Span("flex-shrink-0 text-tertiary transition-transform", "")
+		if t.expanded[n.ID] {
+			toggle.Get("classList").Call("add", "rotate-90")
+		}
+		toggle.Call("setAttribute", "data-action", "toggle")
+		toggle.Call("appendChild", Icon(IconProps{Name: "chevron-right", Size: IconSM}))
+		row.Call("appendChild", toggle)
+	} else {
+		row.Call("appendChild", Span("w-4 flex-shrink-0", ""))
+	}
+
+	if t.props.Checkboxes {
+		checked, mixed := t.state(n)
+		switch {
+		case mixed:
+			row.Call("setAttribute", "aria-checked", "mixed")
+		default:
+			row.Call("setAttribute", "aria-checked", fmt.Sprint(checked))
+		}
+
+		// The treeitem carries the state, so the input is hidden from
+		// assistive technology and out of the tab order
+		box := document.Call("createElement", "input")
+		box.Set("type", "checkbox")
+		box.Set("className", "h-4 w-4 text-blue-600 border-default rounded focus:ring-blue-500 surface-base")
+		box.Set("checked", checked)
+		box.Set("indeterminate", mixed)
+		box.Set("disabled", n.Disabled)
+		box.Set("tabIndex", -1)
+		box.Call("setAttribute", "aria-hidden", "true")
+		box.Call("setAttribute", "data-action", "check")
+		row.Call("appendChild", box)
+	}
+
+	if n.Icon != "" {
+		row.Call("appendChild", Icon(IconProps{Name: n.Icon, Size: IconSM, ClassName: "flex-shrink-0 text-tertiary"}))
+	}
+	row.Call("appendChild", Span("truncate", n.Label))
+	return row
+}
+
+// renderStatus renders the loading or error row under a node. retryID adds
+// a retry button that reloads that node.
+func (t *TreeView) renderStatus(level int, message, retryID string, loading bool) js.Value {
+	row := Div("flex items-center gap-2 py-1 pr-2 text-xs")
+	row.Get("style").Set("paddingLeft", treeIndent(level))
+	row.Call("setAttribute", "role", "none")
+
+	if loading {
+		row.Get("classList").Call("add", "text-tertiary")
+		row.Call("appendChild", Span("inline-block h-3 w-3 border-2 border-current border-t-transparent rounded-full animate-spin", ""))
+	} else {
+		row.Get("classList").Call("add", "text-red-600", "dark:text-red-400")
+	}
+	row.Call("appendChild", Span("", message))
+
+	if retryID != "" {
+		retry := js.Global().Get("document").Call("createElement", "button")
+		retry.Set("type", "button")
+		retry.Set("className", "underline hover:no-underline")
+		retry.Set("textContent", i18n.T("gux.treeview.retry"))
+		retry.Call("setAttribute", "data-action", "retry")
+		retry.Call("setAttribute", "data-retry", retryID)
+		row.Call("appendChild", retry)
+	}
+	return row
+}
+
+func (t *TreeView) handleClick(e js.Value) {
+	target := e.Get("target")
+	if retry := target.Call("closest", "[data-retry]"); retry.Truthy() {
+		t.load(retry.Call("getAttribute", "data-retry").String())
+		return
+	}
+
+	row := target.Call("closest", "[data-node]")
+	if !row.Truthy() {
+		return
+	}
+	id := row.Call("getAttribute", "data-node").String()
+	n, ok := t.byID[id]
+	if !ok {
+		return
+	}
+
+	action := ""
+	if el := target.Call("closest", "[data-action]"); el.Truthy() {
+		action = el.Call("getAttribute", "data-action").String()
+	}
+	switch {
+	case action == "toggle":
+		t.focused = id
+		t.toggle(id)
+	case n.Disabled:
+	case action == "check":
+		t.focused = id
+		t.toggleChecked(id)
+	default:
+		t.selectNode(id)
+	}
+	t.focusRow(t.focused)
+}
+
+func (t *TreeView) toggle(id string) {
+	if t.expanded[id] {
+		t.Collapse(id)
+	} else {
+		t.Expand(id)
+	}
+}
+
+func (t *TreeView) handleKey(e js.Value) {
+	row := e.Get("target").Call("closest", "[data-node]")
+	if !row.Truthy() {
+		return
+	}
+	id := row.Call("getAttribute", "data-node").String()
+	pos := -1
+	for i, r := range t.rows {
+		if r.node.ID == id {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return
+	}
+	n := t.rows[pos].node
+
+	switch e.Get("key").String() {
+	case "ArrowDown":
+		if pos < len(t.rows)-1 {
+			t.moveFocus(t.rows[pos+1].node.ID)
+		}
+	case "ArrowUp":
+		if pos > 0 {
+			t.moveFocus(t.rows[pos-1].node.ID)
+		}
+	case "Home":
+		t.moveFocus(t.rows[0].node.ID)
+	case "End":
+		t.moveFocus(t.rows[len(t.rows)-1].node.ID)
+	case "ArrowRight":
+		switch {
+		case !expandable(n):
+		case !t.expanded[id]:
+			t.Expand(id)
+		case len(n.Children) > 0:
+			t.moveFocus(n.Children[0].ID)
+		}
+	case "ArrowLeft":
+		if expandable(n) && t.expanded[id] {
+			t.Collapse(id)
+		} else if parent := t.parents[id]; parent != "" {
+			t.moveFocus(parent)
+		}
+	case "Enter":
+		if !n.Disabled {
+			t.selectNode(id)
+		}
+	case " ":
+		if n.Disabled {
+			break
+		}
+		if t.props.Checkboxes {
+			t.toggleChecked(id)
+		} else {
+			t.selectNode(id)
+		}
+	default:
+		return
+	}
+	e.Call("preventDefault")
+}
+
+// moveFocus moves the roving tabindex to a row and focuses it
+func (t *TreeView) moveFocus(id string) {
+	if old := t.rowElement(t.focused); old.Truthy() {
+		old.Set("tabIndex", -1)
+	}
+	t.focused = id
+	t.focusRow(id)
+}
+
+func (t *TreeView) focusRow(id string) {
+	if el := t.rowElement(id); el.Truthy() {
+		el.Set("tabIndex", 0)
+		el.Call("focus")
+	}
+}
+
+func (t *TreeView) rowElement(id string) js.Value {
+	if id == "" {
+		return js.Null()
+	}
+	selector := `[data-node="` + js.Global().Get("CSS").Call("escape", id).String() + `"]`
+	return t.container.Call("querySelector", selector)
+}
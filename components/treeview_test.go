@@ -0,0 +1,49 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/components/testutil"
+)
+
+func TestTreeViewKeepsCallerNodes(t *testing.T) {
+	nodes := []components.TreeNode{{ID: "docs", Label: "Docs", Children: []components.TreeNode{{ID: "a", Label: "a.md"}}}}
+	tree := components.NewTreeView(components.TreeViewProps{
+		Nodes: nodes,
+		OnExpand: func(node components.TreeNode) ([]components.TreeNode, error) {
+			return []components.TreeNode{{ID: "b", Label: "b.md"}}, nil
+		},
+	})
+	root := testutil.Render(t, tree)
+
+	tree.Expand("docs")
+	testutil.WaitFor(t, func() bool { return len(testutil.QueryAll(root, "[data-node=b]")) == 1 }, time.Second)
+
+	if got := nodes[0].Children[0].ID; got != "a" {
+		t.Errorf("caller's child = %q, want a", got)
+	}
+}
+
+func TestTreeViewDropsStaleChildren(t *testing.T) {
+	release := make(chan struct{})
+	tree := components.NewTreeView(components.TreeViewProps{
+		Nodes: []components.TreeNode{{ID: "docs", Label: "Docs", HasChildren: true}},
+		OnExpand: func(node components.TreeNode) ([]components.TreeNode, error) {
+			<-release
+			return []components.TreeNode{{ID: "old", Label: "old.md"}}, nil
+		},
+	})
+	root := testutil.Render(t, tree)
+
+	tree.Expand("docs")
+	tree.SetNodes([]components.TreeNode{{ID: "docs", Label: "Docs", Children: []components.TreeNode{{ID: "new", Label: "new.md"}}}})
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	testutil.AssertExists(t, root, "[data-node=new]")
+	testutil.AssertMissing(t, root, "[data-node=old]")
+}
@@ -0,0 +1,499 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/internal/jsutil"
+	"github.com/dougbarrett/gux/storage"
+)
+
+// UploadStatus is the state of a file uploaded with FileUpload.UploadTo
+type UploadStatus string
+
+const (
+	UploadQueued    UploadStatus = "queued"    // waiting for a free upload slot
+	UploadUploading UploadStatus = "uploading" // sending chunks
+	UploadPaused    UploadStatus = "paused"    // paused by the user; resumable
+	UploadDone      UploadStatus = "done"      // received by the server
+	UploadFailed    UploadStatus = "failed"    // gave up after retries; resumable
+)
+
+// UploadOptions configures FileUpload.UploadTo
+type UploadOptions struct {
+	ChunkSize    int64             // Bytes per request (default 5 MB)
+	Concurrency  int               // Files uploaded at once (default 2)
+	Retries      int               // Attempts per chunk before the file fails (default 3)
+	Headers      map[string]string // Sent with every request
+	AuthProvider func() string     // Returns the Authorization header, called per request
+	OnProgress   func(file FileInfo)
+	OnComplete   func(file FileInfo, result json.RawMessage) // result is from server.UploadOptions.OnComplete
+	OnError      func(file FileInfo, err error)
+}
+
+// uploader uploads a FileUpload's files to a server.UploadHandler
+type uploader struct {
+	url    string
+	opts   UploadOptions
+	active int
+	queue  []*uploadFile
+	files  map[string]*uploadFile // by file name, like FileUpload.files
+}
+
+// uploadFile is the upload of one file
+type uploadFile struct {
+	name     string
+	file     js.Value
+	size     int64
+	id       string // server's upload ID, once started
+	offset   int64
+	status   UploadStatus
+	err      error
+	cancel   context.CancelFunc
+	resume   *storage.Value[string] // the upload ID, to resume after a reload
+	progress *Progress
+	label    js.Value
+	button   js.Value
+}
+
+// UploadTo uploads selected files to url, which is served by a
+// server.UploadHandler. Files are sent in chunks, so a dropped connection
+// only resends the current chunk, and each file's card shows its progress
+// with a button to pause and resume it. An upload interrupted by a reload
+// continues where it stopped when the same file is selected again.
+//
+//	upload := components.NewFileUpload(components.FileUploadProps{Multiple: true})
+//	upload.UploadTo("/api/uploads", components.UploadOptions{
+//		AuthProvider: func() string { return "Bearer " + auth.GetToken() },
+//		OnComplete: func(file components.FileInfo, result json.RawMessage) {
+//			components.ShowSuccess(file.Name + " uploaded")
+//		},
+//	})
+//
+// New selections are added to the files being uploaded rather than
+// replacing them. Removing a file cancels its upload.
+func (f *FileUpload) UploadTo(url string, opts UploadOptions) *FileUpload {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 5 << 20
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = 3
+	}
+	f.upload = &uploader{url: url, opts: opts, files: make(map[string]*uploadFile)}
+	for _, info := range f.files {
+		f.startUpload(info)
+	}
+	return f
+}
+
+// Pause pauses the upload of the named file. The chunk being sent is
+// abandoned and sent again on Resume.
+func (f *FileUpload) Pause(name string) {
+	if f.upload == nil {
+		return
+	}
+	u := f.upload.files[name]
+	if u == nil {
+		return
+	}
+	switch u.status {
+	case UploadUploading:
+		u.cancel() // run sets the status when the request ends
+	case UploadQueued:
+		f.upload.dequeue(u)
+		f.setUploadStatus(u, UploadPaused)
+	}
+}
+
+// Resume continues the paused or failed upload of the named file
+func (f *FileUpload) Resume(name string) {
+	if f.upload == nil {
+		return
+	}
+	if u := f.upload.files[name]; u != nil && (u.status == UploadPaused || u.status == UploadFailed) {
+		f.queueUpload(u)
+	}
+}
+
+// PauseAll pauses every upload in progress or waiting
+func (f *FileUpload) PauseAll() {
+	if f.upload == nil {
+		return
+	}
+	for name := range f.upload.files {
+		f.Pause(name)
+	}
+}
+
+// ResumeAll resumes every paused or failed upload
+func (f *FileUpload) ResumeAll() {
+	if f.upload == nil {
+		return
+	}
+	for name := range f.upload.files {
+		f.Resume(name)
+	}
+}
+
+// startUpload starts uploading a newly selected file
+func (f *FileUpload) startUpload(info FileInfo) {
+	if old := f.upload.files[info.Name]; old != nil {
+		f.cancelUpload(old)
+	}
+	lastModified := info.File.Get("lastModified")
+	key := "gux-upload:" + f.upload.url + "|" + info.Name + "|" + strconv.FormatInt(info.Size, 10)
+	if lastModified.Type() == js.TypeNumber {
+		key += "|" + strconv.FormatInt(int64(lastModified.Float()), 10)
+	}
+	u := &uploadFile{
+		name:   info.Name,
+		file:   info.File,
+		size:   info.Size,
+		resume: storage.Typed[string](key, storage.Options{TTL: 24 * time.Hour}),
+	}
+	f.upload.files[info.Name] = u
+	f.queueUpload(u)
+}
+
+func (f *FileUpload) queueUpload(u *uploadFile) {
+	u.err = nil
+	f.upload.queue = append(f.upload.queue, u)
+	f.setUploadStatus(u, UploadQueued)
+	f.pumpUploads()
+}
+
+// pumpUploads starts queued uploads while there are free slots
+func (f *FileUpload) pumpUploads() {
+	up := f.upload
+	for up.active < up.opts.Concurrency && len(up.queue) > 0 {
+		u := up.queue[0]
+		up.queue = up.queue[1:]
+		up.active++
+		ctx, cancel := context.WithCancel(context.Background())
+		u.cancel = cancel
+		f.setUploadStatus(u, UploadUploading)
+		go func() {
+			err := f.runUpload(ctx, u)
+			cancel()
+			up.active--
+			if up != f.upload || up.files[u.name] != u {
+				return // canceled by removal or a new UploadTo
+			}
+			switch {
+			case err == nil:
+				u.resume.Remove()
+			case ctx.Err() != nil:
+				f.setUploadStatus(u, UploadPaused)
+			default:
+				u.err = err
+				f.setUploadStatus(u, UploadFailed)
+				if up.opts.OnError != nil {
+					up.opts.OnError(f.fileInfo(u.name), err)
+				}
+			}
+			f.pumpUploads()
+		}()
+	}
+}
+
+func (up *uploader) dequeue(u *uploadFile) {
+	for i, q := range up.queue {
+		if q == u {
+			up.queue = append(up.queue[:i], up.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// cancelUpload stops an upload for good and tells the server to discard it
+func (f *FileUpload) cancelUpload(u *uploadFile) {
+	up := f.upload
+	up.dequeue(u)
+	delete(up.files, u.name)
+	if u.cancel != nil {
+		u.cancel()
+	}
+	u.resume.Remove()
+	if u.id != "" && u.status != UploadDone {
+		id := u.id
+		go up.request(context.Background(), "DELETE", "?id="+id, nil, js.Undefined())
+	}
+}
+
+// runUpload sends the file's remaining chunks
+func (f *FileUpload) runUpload(ctx context.Context, u *uploadFile) error {
+	up := f.upload
+
+	// Continue an upload started before a pause or reload
+	if u.id == "" {
+		if id, ok := u.resume.Get(); ok {
+			u.id = id
+		}
+	}
+	if u.id != "" {
+		var state struct{ Offset int64 }
+		if err := up.call(ctx, "GET", "?id="+u.id, nil, js.Undefined(), &state); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			u.id, u.offset = "", 0 // expired or unknown: start over
+		} else {
+			u.offset = state.Offset
+		}
+	}
+	if u.id == "" {
+		body, _ := json.Marshal(map[string]any{"name": u.name, "type": u.file.Get("type").String(), "size": u.size})
+		var state struct{ ID string }
+		if err := up.call(ctx, "POST", "", map[string]string{"Content-Type": "application/json"}, js.ValueOf(string(body)), &state); err != nil {
+			return err
+		}
+		u.id, u.offset = state.ID, 0
+		u.resume.Set(u.id)
+	}
+	f.uploadProgress(u)
+
+	for {
+		end := min(u.offset+up.opts.ChunkSize, u.size)
+		chunk := u.file.Call("slice", u.offset, end)
+		var state struct {
+			Offset int64
+			Done   bool
+			Result json.RawMessage
+		}
+		var err error
+		for attempt := 0; attempt < up.opts.Retries; attempt++ {
+			if attempt > 0 {
+				// Back off 1s, 2s, 4s, ... unless paused meanwhile
+				select {
+				case <-time.After(time.Second << (attempt - 1)):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			headers := map[string]string{"Upload-Offset": strconv.FormatInt(u.offset, 10), "Content-Type": "application/octet-stream"}
+			err = up.call(ctx, "PATCH", "?id="+u.id, headers, chunk, &state)
+			var statusErr *uploadError
+			if err == nil || ctx.Err() != nil || !errors.As(err, &statusErr) || statusErr.status != 409 && statusErr.status < 500 && statusErr.status != 0 {
+				break
+			}
+			if statusErr.status == 409 {
+				// The server has a different offset, e.g. after a lost
+				// response: continue from its offset
+				var current struct{ Offset int64 }
+				if err = up.call(ctx, "GET", "?id="+u.id, nil, js.Undefined(), &current); err == nil {
+					u.offset = current.Offset
+					end = min(u.offset+up.opts.ChunkSize, u.size)
+					chunk = u.file.Call("slice", u.offset, end)
+					attempt--
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		u.offset = state.Offset
+		if state.Done {
+			f.setUploadStatus(u, UploadDone)
+			if up.opts.OnComplete != nil {
+				up.opts.OnComplete(f.fileInfo(u.name), state.Result)
+			}
+			return nil
+		}
+		f.uploadProgress(u)
+	}
+}
+
+// uploadError is a non-2xx response from the upload endpoint, or a network
+// failure with status 0
+type uploadError struct {
+	status  int
+	message string
+}
+
+func (e *uploadError) Error() string {
+	return e.message
+}
+
+// call sends a request to the upload endpoint and decodes the JSON response
+// into v
+func (up *uploader) call(ctx context.Context, method, query string, headers map[string]string, body js.Value, v any) error {
+	text, err := up.request(ctx, method, query, headers, body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(text), v)
+}
+
+func (up *uploader) request(ctx context.Context, method, query string, headers map[string]string, body js.Value) (string, error) {
+	h := js.Global().Get("Object").New()
+	for k, v := range up.opts.Headers {
+		h.Set(k, v)
+	}
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	if up.opts.AuthProvider != nil {
+		if auth := up.opts.AuthProvider(); auth != "" {
+			h.Set("Authorization", auth)
+		}
+	}
+
+	controller := js.Global().Get("AbortController").New()
+	init := js.Global().Get("Object").New()
+	init.Set("method", method)
+	init.Set("headers", h)
+	init.Set("signal", controller.Get("signal"))
+	if !body.IsUndefined() {
+		init.Set("body", body)
+	}
+	stop := context.AfterFunc(ctx, func() { controller.Call("abort") })
+	defer stop()
+
+	resp, err := jsutil.Await(context.Background(), js.Global().Call("fetch", up.url+query, init), promiseError)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", &uploadError{message: err.Error()}
+	}
+	text, err := jsutil.Await(context.Background(), resp.Call("text"), promiseError)
+	if err != nil {
+		return "", &uploadError{message: err.Error()}
+	}
+	if !resp.Get("ok").Bool() {
+		uerr := &uploadError{status: resp.Get("status").Int(), message: resp.Get("statusText").String()}
+		var envelope struct{ Error struct{ Message string } }
+		if json.Unmarshal([]byte(text.String()), &envelope) == nil && envelope.Error.Message != "" {
+			uerr.message = envelope.Error.Message
+		}
+		return "", uerr
+	}
+	return text.String(), nil
+}
+
+// fileInfo returns the selected file with the given name
+func (f *FileUpload) fileInfo(name string) FileInfo {
+	for _, info := range f.files {
+		if info.Name == name {
+			return info
+		}
+	}
+	return FileInfo{Name: name}
+}
+
+// setUploadStatus records a file's status and shows it on its card
+func (f *FileUpload) setUploadStatus(u *uploadFile, status UploadStatus) {
+	u.status = status
+	for i := range f.files {
+		if f.files[i].Name == u.name {
+			f.files[i].Status = status
+		}
+	}
+	f.uploadProgress(u)
+}
+
+// uploadProgress updates a file's Progress and card, and calls OnProgress
+func (f *FileUpload) uploadProgress(u *uploadFile) {
+	percent := 100
+	if u.size > 0 {
+		percent = int(u.offset * 100 / u.size)
+	}
+	if u.status == UploadDone {
+		percent = 100
+	}
+	for i := range f.files {
+		if f.files[i].Name == u.name {
+			f.files[i].Progress = percent
+		}
+	}
+
+	if u.progress != nil {
+		u.progress.SetValue(percent)
+		var text, button, label string
+		switch u.status {
+		case UploadQueued:
+			text, button, label = i18n.T("gux.upload.queued"), "⏸", i18n.T("gux.upload.pause")
+		case UploadUploading:
+			text, button, label = i18n.T("gux.upload.uploading", percent), "⏸", i18n.T("gux.upload.pause")
+		case UploadPaused:
+			text, button, label = i18n.T("gux.upload.paused", percent), "▶", i18n.T("gux.upload.resume")
+		case UploadFailed:
+			text, button, label = i18n.T("gux.upload.failed"), "↻", i18n.T("gux.upload.retry")
+		case UploadDone:
+			text = i18n.T("gux.upload.done")
+		}
+		u.label.Set("textContent", text)
+		u.label.Set("title", "")
+		if u.err != nil {
+			u.label.Set("title", u.err.Error())
+		}
+		u.button.Set("textContent", button)
+		u.button.Call("setAttribute", "aria-label", label)
+		u.button.Set("hidden", button == "")
+	}
+
+	if f.upload.opts.OnProgress != nil {
+		f.upload.opts.OnProgress(f.fileInfo(u.name))
+	}
+}
+
+// addUploadControls adds a progress bar and a pause/resume button to the
+// preview card of an uploading file
+func (f *FileUpload) addUploadControls(card js.Value, name string) {
+	if f.upload == nil || f.upload.files[name] == nil {
+		return
+	}
+	u := f.upload.files[name]
+	document := js.Global().Get("document")
+
+	controls := document.Call("createElement", "div")
+	controls.Set("className", "relative z-10 mt-2 space-y-1")
+	u.progress = NewProgress(ProgressProps{Height: "h-1.5", AriaLabel: i18n.T("gux.upload.progress", name)})
+	controls.Call("appendChild", u.progress.Element())
+
+	row := document.Call("createElement", "div")
+	row.Set("className", "flex items-center justify-between gap-1 text-xs text-tertiary")
+	u.label = document.Call("createElement", "span")
+	u.label.Set("className", "truncate")
+	u.label.Call("setAttribute", "aria-live", "polite")
+	row.Call("appendChild", u.label)
+
+	u.button = document.Call("createElement", "button")
+	u.button.Set("type", "button")
+	u.button.Set("className", "px-1 rounded hover:surface-raised text-secondary")
+	u.button.Call("addEventListener", "click", f.previews.fn(func(this js.Value, args []js.Value) any {
+		args[0].Call("stopPropagation")
+		switch u.status {
+		case UploadQueued, UploadUploading:
+			f.Pause(name)
+		case UploadPaused, UploadFailed:
+			f.Resume(name)
+		}
+		return nil
+	}))
+	row.Call("appendChild", u.button)
+	controls.Call("appendChild", row)
+	card.Call("appendChild", controls)
+
+	f.uploadProgress(u)
+}
+
+// cancelUploads cancels the uploads of all selected files
+func (f *FileUpload) cancelUploads() {
+	if f.upload == nil {
+		return
+	}
+	for _, u := range f.upload.files {
+		f.cancelUpload(u)
+	}
+}
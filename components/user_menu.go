@@ -187,10 +187,12 @@ func createMenuItem(document js.Value, icon, label string, danger bool, onClick
 	item.Call("appendChild", labelSpan)
 
 	if onClick != nil {
-		item.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		click := js.FuncOf(func(this js.Value, args []js.Value) any {
 			onClick()
 			return nil
-		}))
+		})
+		item.Call("addEventListener", "click", click)
+		onUnmount(item, click.Release)
 	}
 
 	return item
@@ -201,6 +203,16 @@ func (u *UserMenu) Element() js.Value {
 	return u.element
 }
 
+// Mount appends the user menu to parent
+func (u *UserMenu) Mount(parent js.Value) {
+	parent.Call("appendChild", u.element)
+}
+
+// Unmount removes the user menu and releases its listeners
+func (u *UserMenu) Unmount() {
+	u.dropdown.Unmount()
+}
+
 // Open opens the dropdown menu
 func (u *UserMenu) Open() {
 	u.dropdown.Open()
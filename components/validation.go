@@ -0,0 +1,158 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dougbarrett/gux/validate"
+)
+
+// Format validation rules. Like Email, they pass empty values; combine them
+// with Required for mandatory fields. Request structs with the matching
+// validate tags get the same checks on the server from gux gen.
+var (
+	URL = ValidationRule{
+		Validate: optional(validate.URL),
+		Message:  "Please enter a valid URL",
+		Key:      "gux.validation.url",
+	}
+
+	UUID = ValidationRule{
+		Validate: optional(validate.UUID),
+		Message:  "Please enter a valid UUID",
+		Key:      "gux.validation.uuid",
+	}
+
+	Slug = ValidationRule{
+		Validate: optional(validate.Slug),
+		Message:  "Use only lowercase letters, numbers, and hyphens",
+		Key:      "gux.validation.slug",
+	}
+
+	IBAN = ValidationRule{
+		Validate: optional(validate.IBAN),
+		Message:  "Please enter a valid IBAN",
+		Key:      "gux.validation.iban",
+	}
+
+	// CreditCard checks the length and Luhn checksum of a card number
+	CreditCard = ValidationRule{
+		Validate: optional(validate.CreditCard),
+		Message:  "Please enter a valid card number",
+		Key:      "gux.validation.creditcard",
+	}
+)
+
+// Rules matching validate:"required" on bool and number fields, which the
+// server treats as missing when false or zero
+var (
+	// Accepted requires a checked checkbox, e.g. accepting the terms
+	Accepted = ValidationRule{
+		Validate: func(v string) bool { return v == "true" },
+		Message:  "This field is required",
+		Key:      "gux.validation.required",
+	}
+
+	// NonZero requires a number other than 0; text that isn't a number
+	// fails, as the server couldn't decode it
+	NonZero = ValidationRule{
+		Validate: func(v string) bool {
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return err == nil && n != 0 && !math.IsNaN(n)
+		},
+		Message: "This field is required",
+		Key:     "gux.validation.required",
+	}
+)
+
+// Phone creates a phone number rule for an ISO 3166 country code such as
+// "US" or "DE". With an empty country any international +E.164 number passes.
+func Phone(country string) ValidationRule {
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.Phone(v, country) }),
+		Message:  "Please enter a valid phone number",
+		Key:      "gux.validation.phone",
+	}
+}
+
+// Before creates a rule for date inputs that requires a date before t
+func Before(t time.Time) ValidationRule {
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.Before(v, t) }),
+		Message:  "Must be before " + t.Format(validate.DateLayout),
+		Key:      "gux.validation.before",
+		Args:     []any{localDate(t)},
+	}
+}
+
+// After creates a rule for date inputs that requires a date after t
+func After(t time.Time) ValidationRule {
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.After(v, t) }),
+		Message:  "Must be after " + t.Format(validate.DateLayout),
+		Key:      "gux.validation.after",
+		Args:     []any{localDate(t)},
+	}
+}
+
+// Range creates a rule that requires a number from min to max, inclusive
+func Range(min, max float64) ValidationRule {
+	lo, hi := validate.FormatNumber(min), validate.FormatNumber(max)
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.Range(v, min, max) }),
+		Message:  "Must be a number from " + lo + " to " + hi,
+		Key:      "gux.validation.range",
+		Args:     []any{lo, hi},
+	}
+}
+
+// Min creates a rule that requires a number of at least min
+func Min(min float64) ValidationRule {
+	lo := validate.FormatNumber(min)
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.Min(v, min) }),
+		Message:  "Must be at least " + lo,
+		Key:      "gux.validation.min",
+		Args:     []any{lo},
+	}
+}
+
+// Max creates a rule that requires a number of at most max
+func Max(max float64) ValidationRule {
+	hi := validate.FormatNumber(max)
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.Max(v, max) }),
+		Message:  "Must be at most " + hi,
+		Key:      "gux.validation.max",
+		Args:     []any{hi},
+	}
+}
+
+// OneOf creates a rule that requires one of the given values
+func OneOf(options ...string) ValidationRule {
+	list := strings.Join(options, ", ")
+	return ValidationRule{
+		Validate: optional(func(v string) bool { return validate.OneOf(v, options...) }),
+		Message:  "Must be one of: " + list,
+		Key:      "gux.validation.oneof",
+		Args:     []any{list},
+	}
+}
+
+// optional passes empty values and leaves them to Required
+func optional(check func(string) bool) func(string) bool {
+	return func(v string) bool {
+		return v == "" || check(v)
+	}
+}
+
+// localDate formats in the locale that is current when the message is shown
+type localDate time.Time
+
+func (d localDate) String() string {
+	return validate.FormatDate(time.Time(d))
+}
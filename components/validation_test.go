@@ -0,0 +1,27 @@
+//go:build js && wasm
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/dougbarrett/gux/components"
+)
+
+func TestNonZero(t *testing.T) {
+	for v, want := range map[string]bool{
+		"1":    true,
+		"-2.5": true,
+		" 3 ":  true,
+		"0":    false,
+		"0.0":  false,
+		"":     false,
+		"abc":  false,
+		"1e":   false,
+		"NaN":  false,
+	} {
+		if got := components.NonZero.Validate(v); got != want {
+			t.Errorf("NonZero(%q) = %t, want %t", v, got, want)
+		}
+	}
+}
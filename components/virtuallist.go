@@ -110,6 +110,7 @@ func NewVirtualList(props VirtualListProps) *VirtualList {
 	// Initial render
 	v.render()
 
+	onUnmount(container, v.Unmount)
 	return v
 }
 
@@ -145,7 +146,7 @@ func (v *VirtualList) render() {
 	v.endIndex = endIndex
 
 	// Clear existing items
-	v.content.Set("innerHTML", "")
+	UnmountChildren(v.content)
 
 	document := js.Global().Get("document")
 
@@ -171,6 +172,18 @@ func (v *VirtualList) Element() js.Value {
 	return v.container
 }
 
+// Mount appends the list to parent
+func (v *VirtualList) Mount(parent js.Value) {
+	parent.Call("appendChild", v.container)
+}
+
+// Unmount removes the list, unmounting its rendered items, and releases its
+// listeners
+func (v *VirtualList) Unmount() {
+	v.Destroy()
+	unmount(v.container)
+}
+
 // SetItems updates the items and re-renders
 func (v *VirtualList) SetItems(items []any) {
 	v.items = items
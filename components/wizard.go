@@ -0,0 +1,473 @@
+//go:build js && wasm
+
+package components
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/types"
+)
+
+// WizardStep is one step of a Wizard
+type WizardStep struct {
+	Title       string
+	Description string
+	Fields      []BuilderField
+
+	// Skip leaves the step out while it returns true for the values entered
+	// so far, e.g. a shipping step when "pickup" was chosen
+	Skip func(values map[string]any) bool
+
+	// Validate checks the step as a whole once its fields pass their rules,
+	// e.g. that two dates are in order. An error keeps the wizard on the step.
+	Validate func(values map[string]any) error
+}
+
+// WizardProps configures a Wizard
+type WizardProps struct {
+	Steps    []WizardStep
+	Values   map[string]any // initial values, by field name
+	Review   bool           // end with a summary of every value before OnComplete
+	Vertical bool
+
+	// OnComplete receives the values of every step that was not skipped. It
+	// runs in a goroutine, so it may call the server; an error is shown and
+	// the wizard stays on the last step.
+	OnComplete   func(values map[string]any) error
+	OnCancel     func()         // shows a Cancel button on the first step
+	OnStepChange func(step int) // index into Steps, or len(Steps) for the review
+
+	NextText   string
+	BackText   string
+	FinishText string
+	ClassName  string
+}
+
+// Wizard is a multi-step form: a Stepper showing progress above a
+// FormBuilder for the current step's fields. Next is only allowed once the
+// step validates, values are kept when moving back and forth, and steps
+// can be skipped depending on earlier answers.
+type Wizard struct {
+	props   WizardProps
+	element js.Value
+	status  js.Value
+	values  map[string]any
+	current int
+	form    *FormBuilder
+	errorEl js.Value
+	busy    bool
+}
+
+// NewWizard creates a new Wizard component
+func NewWizard(props WizardProps) *Wizard {
+	if props.NextText == "" {
+		props.NextText = i18n.T("gux.wizard.next")
+	}
+	if props.BackText == "" {
+		props.BackText = i18n.T("gux.wizard.back")
+	}
+	if props.FinishText == "" {
+		props.FinishText = i18n.T("gux.wizard.finish")
+	}
+
+	document := js.Global().Get("document")
+	element := document.Call("createElement", "div")
+	className := "w-full"
+	if props.ClassName != "" {
+		className += " " + props.ClassName
+	}
+	element.Set("className", className)
+
+	// Announces the step after each move, since the content is replaced
+	status := document.Call("createElement", "p")
+	status.Set("className", "sr-only")
+	status.Call("setAttribute", "aria-live", "polite")
+
+	w := &Wizard{
+		props:   props,
+		element: element,
+		status:  status,
+		values:  make(map[string]any),
+	}
+	for k, v := range props.Values {
+		w.values[k] = v
+	}
+	w.current = w.following(-1)
+	w.render(false)
+	return w
+}
+
+// Element returns the container DOM element
+func (w *Wizard) Element() js.Value {
+	return w.element
+}
+
+// Current returns the index in Steps of the current step, or len(Steps)
+// when the review is shown
+func (w *Wizard) Current() int {
+	return w.current
+}
+
+// Values returns the values entered so far, leaving out the fields of
+// skipped steps
+func (w *Wizard) Values() map[string]any {
+	w.keep()
+	values := make(map[string]any, len(w.values))
+	for k, v := range w.values {
+		values[k] = v
+	}
+	for i, step := range w.props.Steps {
+		if w.skipped(i) {
+			for _, f := range step.Fields {
+				delete(values, f.Name)
+			}
+		}
+	}
+	return values
+}
+
+// Next validates the current step and moves on, like clicking Next
+func (w *Wizard) Next() {
+	if w.current >= len(w.props.Steps) {
+		w.complete()
+		return
+	}
+	if w.form != nil {
+		w.form.handleSubmit()
+	}
+}
+
+// Back returns to the previous step without validating the current one
+func (w *Wizard) Back() {
+	w.keep()
+	for i := min(w.current, len(w.props.Steps)) - 1; i >= 0; i-- {
+		if !w.skipped(i) {
+			w.GoTo(i)
+			return
+		}
+	}
+}
+
+// GoTo shows a step, keeping what was entered on the current one. Skipped
+// steps can't be shown.
+func (w *Wizard) GoTo(step int) {
+	w.keep()
+	if step < 0 || step > len(w.props.Steps) || (step == len(w.props.Steps) && !w.props.Review) || w.skipped(step) {
+		return
+	}
+	w.current = step
+	w.render(true)
+	if w.props.OnStepChange != nil {
+		w.props.OnStepChange(step)
+	}
+}
+
+func (w *Wizard) skipped(i int) bool {
+	if i >= len(w.props.Steps) {
+		return false
+	}
+	skip := w.props.Steps[i].Skip
+	return skip != nil && skip(w.values)
+}
+
+// following returns the step after i, the review, or -1 when i is the last
+func (w *Wizard) following(i int) int {
+	for j := i + 1; j < len(w.props.Steps); j++ {
+		if !w.skipped(j) {
+			return j
+		}
+	}
+	if w.props.Review && i < len(w.props.Steps) {
+		return len(w.props.Steps)
+	}
+	return -1
+}
+
+// visible returns the steps that are not skipped, and the review
+func (w *Wizard) visible() []int {
+	var steps []int
+	for i := w.following(-1); i != -1; i = w.following(i) {
+		steps = append(steps, i)
+	}
+	return steps
+}
+
+// keep copies the current form's values, valid or not, so they are still
+// there when the step is shown again
+func (w *Wizard) keep() {
+	if w.form == nil {
+		return
+	}
+	for k, v := range w.form.GetValues() {
+		w.values[k] = v
+	}
+}
+
+// advance moves on after the current step's fields validated
+func (w *Wizard) advance(values map[string]any) {
+	for k, v := range values {
+		w.values[k] = v
+	}
+	if validate := w.props.Steps[w.current].Validate; validate != nil {
+		if err := validate(w.values); err != nil {
+			w.showError(err.Error())
+			return
+		}
+	}
+	if next := w.following(w.current); next != -1 {
+		w.GoTo(next)
+		return
+	}
+	w.complete()
+}
+
+func (w *Wizard) complete() {
+	if w.busy || w.props.OnComplete == nil {
+		return
+	}
+	values := w.Values()
+	w.setBusy(true)
+	w.hideError()
+	// OnComplete usually calls the server, which blocks, so it can't run on
+	// the event callback
+	go func() {
+		err := w.props.OnComplete(values)
+		w.setBusy(false)
+		if err != nil {
+			w.showError(i18n.T("gux.wizard.failed", err.Error()))
+		}
+	}()
+}
+
+func (w *Wizard) setBusy(busy bool) {
+	w.busy = busy
+	buttons := w.element.Call("querySelectorAll", "button")
+	for i := 0; i < buttons.Get("length").Int(); i++ {
+		buttons.Index(i).Set("disabled", busy)
+	}
+	w.element.Call("setAttribute", "aria-busy", fmt.Sprint(busy))
+}
+
+func (w *Wizard) showError(message string) {
+	w.errorEl.Set("innerHTML", "")
+	w.errorEl.Call("appendChild", AlertErrorMsg(message))
+}
+
+func (w *Wizard) hideError() {
+	w.errorEl.Set("innerHTML", "")
+}
+
+// render rebuilds the stepper for the steps that are not skipped, with the
+// current step's form, or the review, as its content
+func (w *Wizard) render(focus bool) {
+	document := js.Global().Get("document")
+
+	visible := w.visible()
+	pos := 0
+	for i, step := range visible {
+		if step == w.current {
+			pos = i
+		}
+	}
+
+	content := document.Call("createElement", "div")
+	content.Set("className", "space-y-4")
+	w.errorEl = document.Call("createElement", "div")
+	content.Call("appendChild", w.errorEl)
+
+	w.form = nil
+	if w.current >= len(w.props.Steps) {
+		content.Call("appendChild", w.renderReview(visible))
+	} else if w.current >= 0 {
+		content.Call("appendChild", w.renderStep(pos == 0, pos == len(visible)-1))
+	}
+
+	steps := make([]Step, len(visible))
+	for i, step := range visible {
+		steps[i] = Step{Title: w.stepTitle(step)}
+		if step < len(w.props.Steps) {
+			steps[i].Description = w.props.Steps[step].Description
+		}
+	}
+	if len(steps) > 0 {
+		steps[pos].Content = content
+	}
+	stepper := NewStepper(StepperProps{
+		Steps:       steps,
+		CurrentStep: pos,
+		Vertical:    w.props.Vertical,
+		OnStepClick: func(i int) {
+			// Completed steps can be revisited; later ones need Next
+			if i < pos {
+				w.GoTo(visible[i])
+			}
+		},
+	})
+
+	w.element.Set("innerHTML", "")
+	w.element.Call("appendChild", w.status)
+	w.element.Call("appendChild", stepper.Element())
+
+	if focus && len(visible) > 0 {
+		w.status.Set("textContent", i18n.T("gux.wizard.progress", pos+1, len(visible), w.stepTitle(w.current)))
+		target := content.Call("querySelector", "input:not([type=hidden]), select, textarea, button")
+		if !target.IsNull() {
+			target.Call("focus")
+		}
+	}
+}
+
+func (w *Wizard) stepTitle(step int) string {
+	if step >= len(w.props.Steps) {
+		return i18n.T("gux.wizard.review")
+	}
+	return w.props.Steps[step].Title
+}
+
+// renderStep builds the form for the current step, filled with the values
+// entered so far
+func (w *Wizard) renderStep(first, last bool) js.Value {
+	step := w.props.Steps[w.current]
+
+	fields := make([]BuilderField, len(step.Fields))
+	for i, f := range step.Fields {
+		if v, ok := w.values[f.Name]; ok {
+			f.DefaultValue = v
+		}
+		fields[i] = f
+	}
+
+	props := FormBuilderProps{
+		Fields:     fields,
+		SubmitText: w.props.NextText,
+		CancelText: w.props.BackText,
+		ShowCancel: !first,
+		OnCancel:   w.Back,
+		OnSubmit: func(values map[string]any) error {
+			w.advance(values)
+			return nil
+		},
+	}
+	if last {
+		props.SubmitText = w.props.FinishText
+	}
+	if first && w.props.OnCancel != nil {
+		props.ShowCancel = true
+		props.CancelText = i18n.T("gux.wizard.cancel")
+		props.OnCancel = w.props.OnCancel
+	}
+	w.form = NewFormBuilder(props)
+	return w.form.Element()
+}
+
+// renderReview lists every value by step, each step with an Edit button
+func (w *Wizard) renderReview(visible []int) js.Value {
+	document := js.Global().Get("document")
+
+	review := document.Call("createElement", "div")
+	review.Set("className", "space-y-6")
+
+	for _, i := range visible {
+		if i >= len(w.props.Steps) {
+			continue
+		}
+		step := w.props.Steps[i]
+
+		section := document.Call("createElement", "section")
+		section.Set("className", "space-y-2")
+
+		header := document.Call("createElement", "div")
+		header.Set("className", "flex items-center justify-between")
+		title := document.Call("createElement", "h3")
+		title.Set("className", "text-lg font-semibold text-primary")
+		title.Set("textContent", step.Title)
+		header.Call("appendChild", title)
+		index := i
+		edit := Button(ButtonProps{Text: i18n.T("gux.wizard.edit"), Variant: ButtonGhost, Size: ButtonSM, OnClick: func() {
+			w.GoTo(index)
+		}})
+		edit.Call("setAttribute", "aria-label", i18n.T("gux.wizard.edit.aria", step.Title))
+		header.Call("appendChild", edit)
+		section.Call("appendChild", header)
+
+		list := document.Call("createElement", "dl")
+		list.Set("className", "grid grid-cols-1 sm:grid-cols-3 gap-x-4 gap-y-2 text-sm")
+		for _, f := range step.Fields {
+			if f.Type == BuilderFieldHidden {
+				continue
+			}
+			dt := document.Call("createElement", "dt")
+			dt.Set("className", "text-secondary")
+			dt.Set("textContent", fieldLabelOrName(f))
+			dd := document.Call("createElement", "dd")
+			dd.Set("className", "sm:col-span-2 text-primary break-words")
+			dd.Set("textContent", reviewText(f, w.values[f.Name]))
+			list.Call("appendChild", dt)
+			list.Call("appendChild", dd)
+		}
+		section.Call("appendChild", list)
+		review.Call("appendChild", section)
+	}
+
+	buttons := document.Call("createElement", "div")
+	buttons.Set("className", "flex gap-3 pt-4")
+	buttons.Call("appendChild", Button(ButtonProps{Text: w.props.FinishText, OnClick: w.complete}))
+	buttons.Call("appendChild", Button(ButtonProps{Text: w.props.BackText, Variant: ButtonSecondary, OnClick: w.Back}))
+	review.Call("appendChild", buttons)
+
+	return review
+}
+
+func fieldLabelOrName(f BuilderField) string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
+}
+
+// reviewText formats a value for the review: option labels instead of
+// values, masked passwords, and file names
+func reviewText(f BuilderField, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "—"
+	case bool:
+		if v {
+			return i18n.T("gux.wizard.yes")
+		}
+		return i18n.T("gux.wizard.no")
+	case js.Value:
+		if f.Type == BuilderFieldFile && v.Truthy() {
+			var names []string
+			for i := 0; i < v.Get("length").Int(); i++ {
+				names = append(names, v.Index(i).Get("name").String())
+			}
+			if len(names) > 0 {
+				return strings.Join(names, ", ")
+			}
+		}
+		return "—"
+	case types.Money:
+		if v == (types.Money{}) {
+			return "—"
+		}
+		return v.Format()
+	}
+
+	text := fmt.Sprint(value)
+	if text == "" {
+		return "—"
+	}
+	if f.Type == BuilderFieldPassword {
+		return "••••••••"
+	}
+	for _, opt := range f.Options {
+		if opt.Value == text {
+			return opt.Label
+		}
+	}
+	return text
+}
@@ -0,0 +1,194 @@
+//go:build js && wasm
+
+package webcrypto
+
+import (
+	"context"
+	"errors"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/compat"
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+const (
+	nonceSize = 12 // AES-GCM's standard nonce
+	tagSize   = 16 // the 128-bit authentication tag SubtleCrypto appends
+
+	// DefaultIterations is the PBKDF2 iteration count OWASP recommends
+	// for SHA-256
+	DefaultIterations = 600000
+)
+
+var usages = []any{"encrypt", "decrypt"}
+
+// Key is an AES-GCM key held by the browser. Unless it was made
+// extractable its bytes never reach the page, though it can still be
+// stored in IndexedDB through Value.
+type Key struct {
+	key js.Value // CryptoKey
+}
+
+// KeyOptions configures GenerateKey and ImportKey
+type KeyOptions struct {
+	Bits        int  // 128 or 256 (default 256); ImportKey takes it from the key
+	Extractable bool // allow Export
+}
+
+// GenerateKey returns a new random key
+func GenerateKey(ctx context.Context, opts KeyOptions) (*Key, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	bits := opts.Bits
+	if bits == 0 {
+		bits = 256
+	}
+	algorithm := map[string]any{"name": "AES-GCM", "length": bits}
+	key, err := jsutil.Await(ctx, s.Call("generateKey", algorithm, opts.Extractable, usages), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{key: key}, nil
+}
+
+// ImportKey returns the key of raw's 16 or 32 bytes
+func ImportKey(ctx context.Context, raw []byte, opts KeyOptions) (*Key, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	key, err := jsutil.Await(ctx, s.Call("importKey", "raw", bytesToJS(raw), "AES-GCM", opts.Extractable, usages), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{key: key}, nil
+}
+
+// PBKDF2Params configures DeriveKey
+type PBKDF2Params struct {
+	Salt       []byte // random and stored with the data, see NewSalt
+	Iterations int    // default DefaultIterations
+	Hash       Hash   // default SHA256
+}
+
+// DeriveKey returns the 256-bit key derived from password with PBKDF2.
+// The same password, salt, iterations, and hash always give the same key,
+// so store the salt and parameters with what the key encrypts. The key
+// isn't extractable.
+func DeriveKey(ctx context.Context, password string, params PBKDF2Params) (*Key, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	if len(params.Salt) == 0 {
+		return nil, errors.New("webcrypto: PBKDF2 needs a salt")
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultIterations
+	}
+	if params.Hash == "" {
+		params.Hash = SHA256
+	}
+
+	base, err := jsutil.Await(ctx, s.Call("importKey", "raw", bytesToJS([]byte(password)), "PBKDF2", false, []any{"deriveKey"}), jsError)
+	if err != nil {
+		return nil, err
+	}
+	algorithm := map[string]any{
+		"name":       "PBKDF2",
+		"salt":       bytesToJS(params.Salt),
+		"iterations": params.Iterations,
+		"hash":       string(params.Hash),
+	}
+	key, err := jsutil.Await(ctx, s.Call("deriveKey", algorithm, base, map[string]any{"name": "AES-GCM", "length": 256}, false, usages), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{key: key}, nil
+}
+
+// KeyFromValue returns the Key of a CryptoKey read back from IndexedDB
+func KeyFromValue(v js.Value) (*Key, error) {
+	if !v.Truthy() || v.Get("algorithm").Get("name").String() != "AES-GCM" {
+		return nil, errors.New("webcrypto: not an AES-GCM CryptoKey")
+	}
+	return &Key{key: v}, nil
+}
+
+// Value returns the CryptoKey, which IndexedDB can store as it is, even
+// when it isn't extractable
+func (k *Key) Value() js.Value {
+	return k.key
+}
+
+// Export returns the key's bytes, if it was made extractable
+func (k *Key) Export(ctx context.Context) ([]byte, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	raw, err := jsutil.Await(ctx, s.Call("exportKey", "raw", k.key), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return bytesFromJS(raw), nil
+}
+
+// Encrypt seals plaintext with a random nonce, returning the nonce
+// followed by the ciphertext and its tag. additionalData, which may be
+// nil, isn't encrypted but must be given again to Decrypt, binding the
+// ciphertext to, say, the record's ID.
+func (k *Key) Encrypt(ctx context.Context, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	compat.Read(nonce)
+	ciphertext, err := k.seal(ctx, nonce, plaintext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+// Decrypt opens what Encrypt sealed, returning ErrDecrypt when the key or
+// additionalData is wrong or sealed was changed
+func (k *Key) Decrypt(ctx context.Context, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < nonceSize+tagSize {
+		return nil, ErrDecrypt
+	}
+	return k.open(ctx, sealed[:nonceSize], sealed[nonceSize:], additionalData)
+}
+
+// seal encrypts plaintext with nonce, returning the ciphertext and tag
+func (k *Key) seal(ctx context.Context, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	out, err := jsutil.Await(ctx, s.Call("encrypt", gcmParams(nonce, additionalData), k.key, bytesToJS(plaintext)), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return bytesFromJS(out), nil
+}
+
+// open decrypts and authenticates ciphertext sealed with nonce
+func (k *Key) open(ctx context.Context, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	out, err := jsutil.Await(ctx, s.Call("decrypt", gcmParams(nonce, additionalData), k.key, bytesToJS(ciphertext)), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return bytesFromJS(out), nil
+}
+
+func gcmParams(nonce, additionalData []byte) map[string]any {
+	params := map[string]any{"name": "AES-GCM", "iv": bytesToJS(nonce)}
+	if additionalData != nil {
+		params["additionalData"] = bytesToJS(additionalData)
+	}
+	return params
+}
@@ -0,0 +1,218 @@
+//go:build js && wasm
+
+package webcrypto
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/dougbarrett/gux/compat"
+)
+
+const (
+	streamVersion = 1
+	headerSize    = 12 // version, chunk size, and nonce prefix
+	prefixSize    = 7  // the random part of each chunk's nonce
+
+	// DefaultChunkSize is the plaintext size of a stream's chunks
+	DefaultChunkSize = 64 * 1024
+
+	// maxChunkSize keeps a corrupt header from asking for a huge buffer
+	maxChunkSize = 16 * 1024 * 1024
+)
+
+// StreamOptions configures NewEncryptWriter and NewDecryptReader
+type StreamOptions struct {
+	ChunkSize      int    // plaintext bytes per chunk (default DefaultChunkSize); the reader takes it from the stream
+	AdditionalData []byte // authenticated with every chunk, as with Encrypt
+}
+
+// NewEncryptWriter returns a writer encrypting what is written to it onto
+// w, a chunk at a time, so large data such as a file needn't be held
+// whole. Close writes the final chunk; without it the stream is
+// incomplete and won't decrypt. Close doesn't close w.
+//
+// The stream starts with a 12-byte header: a version, the chunk size, and
+// a random nonce prefix. Each chunk is sealed with AES-GCM under a nonce
+// of the prefix, the chunk's number, and whether it is the last, so
+// chunks can't be reordered, dropped, or cut off without Read failing.
+func (k *Key) NewEncryptWriter(ctx context.Context, w io.Writer, opts StreamOptions) (io.WriteCloser, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize > maxChunkSize {
+		return nil, errors.New("webcrypto: chunk size too large")
+	}
+	header := make([]byte, headerSize)
+	header[0] = streamVersion
+	binary.BigEndian.PutUint32(header[1:5], uint32(chunkSize))
+	compat.Read(header[5:])
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		ctx:       ctx,
+		key:       k,
+		w:         w,
+		header:    header,
+		aad:       append(append([]byte{}, header...), opts.AdditionalData...),
+		chunkSize: chunkSize,
+	}, nil
+}
+
+type encryptWriter struct {
+	ctx       context.Context
+	key       *Key
+	w         io.Writer
+	header    []byte
+	aad       []byte // the header and the caller's additional data
+	chunkSize int
+	buf       []byte
+	counter   uint32
+	err       error
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	e.buf = append(e.buf, p...)
+	// Keep the last chunk's bytes until Close, which seals it as the last
+	for len(e.buf) > e.chunkSize {
+		if e.err = e.writeChunk(e.buf[:e.chunkSize], false); e.err != nil {
+			return 0, e.err
+		}
+		e.buf = append(e.buf[:0], e.buf[e.chunkSize:]...)
+	}
+	return len(p), nil
+}
+
+func (e *encryptWriter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	e.err = e.writeChunk(e.buf, true)
+	if e.err == nil {
+		e.err = errors.New("webcrypto: write to closed stream")
+		return nil
+	}
+	return e.err
+}
+
+func (e *encryptWriter) writeChunk(plaintext []byte, last bool) error {
+	if e.counter == ^uint32(0) {
+		return errors.New("webcrypto: stream too long")
+	}
+	ciphertext, err := e.key.seal(e.ctx, chunkNonce(e.header, e.counter, last), plaintext, e.aad)
+	if err != nil {
+		return err
+	}
+	e.counter++
+	_, err = e.w.Write(ciphertext)
+	return err
+}
+
+// NewDecryptReader returns a reader of what NewEncryptWriter encrypted
+// onto r. Read returns ErrDecrypt if the key or additional data is wrong
+// or the stream was changed or cut short; the chunks read before it are
+// authentic, but the data as a whole is only once Read returns io.EOF.
+func (k *Key) NewDecryptReader(ctx context.Context, r io.Reader, opts StreamOptions) (io.Reader, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrDecrypt
+		}
+		return nil, err
+	}
+	chunkSize := int(binary.BigEndian.Uint32(header[1:5]))
+	if header[0] != streamVersion || chunkSize <= 0 || chunkSize > maxChunkSize {
+		return nil, ErrDecrypt
+	}
+	return &decryptReader{
+		ctx:       ctx,
+		key:       k,
+		r:         bufio.NewReader(r),
+		header:    header,
+		aad:       append(append([]byte{}, header...), opts.AdditionalData...),
+		chunkSize: chunkSize,
+	}, nil
+}
+
+type decryptReader struct {
+	ctx       context.Context
+	key       *Key
+	r         *bufio.Reader
+	header    []byte
+	aad       []byte
+	chunkSize int
+	counter   uint32
+	plain     []byte // decrypted and not yet read
+	done      bool   // the last chunk was read
+	err       error
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.err = d.readChunk()
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+// readChunk decrypts the next chunk. A chunk is the last when the stream
+// ends after it, which a full-size chunk only tells by looking ahead.
+func (d *decryptReader) readChunk() error {
+	chunk := make([]byte, d.chunkSize+tagSize)
+	n, err := io.ReadFull(d.r, chunk)
+	last := false
+	switch err {
+	case nil:
+		if _, err := d.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		last = true
+		chunk = chunk[:n]
+	case io.EOF:
+		return ErrDecrypt // the last chunk is missing
+	default:
+		return err
+	}
+	if len(chunk) < tagSize || d.counter == ^uint32(0) {
+		return ErrDecrypt
+	}
+
+	plain, err := d.key.open(d.ctx, chunkNonce(d.header, d.counter, last), chunk, d.aad)
+	if err != nil {
+		return err
+	}
+	d.counter++
+	d.plain = plain
+	d.done = last
+	return nil
+}
+
+// chunkNonce returns the nonce of chunk counter: the header's prefix, the
+// counter, and 1 for the last chunk or 0
+func chunkNonce(header []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, nonceSize)
+	copy(nonce, header[5:5+prefixSize])
+	binary.BigEndian.PutUint32(nonce[prefixSize:], counter)
+	if last {
+		nonce[nonceSize-1] = 1
+	}
+	return nonce
+}
@@ -0,0 +1,139 @@
+//go:build js && wasm
+
+package webcrypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func testKey(t *testing.T) *Key {
+	t.Helper()
+	if !Available() {
+		t.Skip("no SubtleCrypto")
+	}
+	key, err := ImportKey(context.Background(), bytes.Repeat([]byte{7}, 32), KeyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// encryptStream encrypts data in chunks of chunkSize, writing it in pieces
+// of write bytes
+func encryptStream(t *testing.T, key *Key, data []byte, chunkSize, write int, aad []byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := key.NewEncryptWriter(context.Background(), &out, StreamOptions{ChunkSize: chunkSize, AdditionalData: aad})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(data) > 0 {
+		n := min(write, len(data))
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func decryptStream(key *Key, sealed, aad []byte) ([]byte, error) {
+	r, err := key.NewDecryptReader(context.Background(), bytes.NewReader(sealed), StreamOptions{AdditionalData: aad})
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	key := testKey(t)
+	for _, size := range []int{0, 1, 15, 16, 17, 48, 100} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		sealed := encryptStream(t, key, data, 16, 7, []byte("file-1"))
+
+		chunks := max(1, (size+15)/16)
+		if want := headerSize + size + chunks*tagSize; len(sealed) != want {
+			t.Errorf("size %d: sealed %d bytes, want %d", size, len(sealed), want)
+		}
+		got, err := decryptStream(key, sealed, []byte("file-1"))
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("size %d: decrypted %d bytes, %v", size, len(got), err)
+		}
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	key := testKey(t)
+	data := bytes.Repeat([]byte("gux!"), 12) // three chunks of 16
+	sealed := encryptStream(t, key, data, 16, len(data), nil)
+	chunk := 16 + tagSize
+
+	swapped := append([]byte{}, sealed[:headerSize]...)
+	swapped = append(swapped, sealed[headerSize+chunk:headerSize+2*chunk]...)
+	swapped = append(swapped, sealed[headerSize:headerSize+chunk]...)
+	swapped = append(swapped, sealed[headerSize+2*chunk:]...)
+
+	flipped := append([]byte{}, sealed...)
+	flipped[headerSize+chunk+3] ^= 1
+
+	badHeader := append([]byte{}, sealed...)
+	badHeader[6] ^= 1 // the nonce prefix
+
+	tests := map[string][]byte{
+		"truncated to whole chunks": sealed[:headerSize+2*chunk],
+		"cut mid-chunk":             sealed[:len(sealed)-5],
+		"chunks reordered":          swapped,
+		"bit flipped":               flipped,
+		"header changed":            badHeader,
+		"header only":               sealed[:headerSize],
+		"short header":              sealed[:5],
+	}
+	for name, tampered := range tests {
+		if _, err := decryptStream(key, tampered, nil); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: err = %v, want ErrDecrypt", name, err)
+		}
+	}
+
+	if _, err := decryptStream(key, sealed, []byte("other")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong additional data: err = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestStreamHeader(t *testing.T) {
+	key := testKey(t)
+	sealed := encryptStream(t, key, []byte("hello"), 0, 5, nil)
+	if sealed[0] != streamVersion {
+		t.Errorf("version = %d", sealed[0])
+	}
+	if got := int(sealed[1])<<24 | int(sealed[2])<<16 | int(sealed[3])<<8 | int(sealed[4]); got != DefaultChunkSize {
+		t.Errorf("chunk size = %d, want %d", got, DefaultChunkSize)
+	}
+
+	huge := append([]byte{}, sealed...)
+	huge[1] = 0xff
+	if _, err := decryptStream(key, huge, nil); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("huge chunk size: err = %v, want ErrDecrypt", err)
+	}
+	if _, err := key.NewEncryptWriter(context.Background(), io.Discard, StreamOptions{ChunkSize: maxChunkSize + 1}); err == nil {
+		t.Error("NewEncryptWriter accepted a chunk size over the limit")
+	}
+}
+
+func TestChunkNonce(t *testing.T) {
+	header := []byte{1, 0, 0, 0, 16, 1, 2, 3, 4, 5, 6, 7}
+	got := chunkNonce(header, 0x01020304, true)
+	want := []byte{1, 2, 3, 4, 5, 6, 7, 1, 2, 3, 4, 1}
+	if !bytes.Equal(got, want) {
+		t.Errorf("chunkNonce = %v, want %v", got, want)
+	}
+}
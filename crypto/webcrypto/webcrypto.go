@@ -0,0 +1,110 @@
+//go:build js && wasm
+
+// Package webcrypto wraps the browser's SubtleCrypto for client-side
+// encryption without JavaScript libraries or a crypto package compiled to
+// WebAssembly: AES-GCM with random nonces, keys derived from passwords
+// with PBKDF2, SHA digests, and chunked encryption of streams.
+//
+//	salt := webcrypto.NewSalt()
+//	key, err := webcrypto.DeriveKey(ctx, password, webcrypto.PBKDF2Params{Salt: salt})
+//	sealed, err := key.Encrypt(ctx, draft, nil)
+//	// store salt and sealed, e.g. in IndexedDB
+//	draft, err = key.Decrypt(ctx, sealed, nil)
+//
+// SubtleCrypto is only available to pages served over HTTPS or from
+// localhost; elsewhere every function returns ErrUnavailable.
+package webcrypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/compat"
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+var (
+	// ErrUnavailable is returned when the page has no SubtleCrypto, as
+	// over plain HTTP
+	ErrUnavailable = errors.New("webcrypto: not available")
+
+	// ErrDecrypt is returned when data can't be decrypted: the key is
+	// wrong, or the data or its additional data were changed or cut short
+	ErrDecrypt = errors.New("webcrypto: decryption failed")
+)
+
+// Available reports whether the page has SubtleCrypto
+func Available() bool {
+	return subtle().Truthy()
+}
+
+// Hash is a digest algorithm
+type Hash string
+
+const (
+	SHA1   Hash = "SHA-1" // for compatibility only; not collision resistant
+	SHA256 Hash = "SHA-256"
+	SHA384 Hash = "SHA-384"
+	SHA512 Hash = "SHA-512"
+)
+
+// Digest returns the hash of data. SubtleCrypto has no incremental
+// digest, so hash streams with crypto/sha256 and friends instead.
+func Digest(ctx context.Context, hash Hash, data []byte) ([]byte, error) {
+	s := subtle()
+	if !s.Truthy() {
+		return nil, ErrUnavailable
+	}
+	buf, err := jsutil.Await(ctx, s.Call("digest", string(hash), bytesToJS(data)), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return bytesFromJS(buf), nil
+}
+
+// NewSalt returns 16 random bytes, to derive a key from a password with.
+// Store the salt with the data; it needn't be secret.
+func NewSalt() []byte {
+	salt := make([]byte, 16)
+	compat.Read(salt)
+	return salt
+}
+
+func subtle() js.Value {
+	c := js.Global().Get("crypto")
+	if !c.Truthy() {
+		return js.Undefined()
+	}
+	return c.Get("subtle")
+}
+
+// jsError converts a rejection's DOMException to the package's errors.
+// SubtleCrypto rejects a failed decryption with a bare OperationError.
+func jsError(reason js.Value) error {
+	if !reason.Truthy() {
+		return errors.New("webcrypto: failed")
+	}
+	if reason.Get("name").String() == "OperationError" {
+		return ErrDecrypt
+	}
+	if msg := reason.Get("message"); msg.Truthy() {
+		return fmt.Errorf("webcrypto: %s", msg.String())
+	}
+	return fmt.Errorf("webcrypto: %s", reason.Call("toString").String())
+}
+
+func bytesToJS(data []byte) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	return array
+}
+
+// bytesFromJS copies an ArrayBuffer's bytes into Go
+func bytesFromJS(buf js.Value) []byte {
+	array := js.Global().Get("Uint8Array").New(buf)
+	data := make([]byte, array.Get("length").Int())
+	js.CopyBytesToGo(data, array)
+	return data
+}
@@ -0,0 +1,371 @@
+//go:build js && wasm
+
+package debug
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+	"unicode"
+)
+
+// FuncCount is what StartFuncTracking knows about the js.Funcs one
+// component created
+type FuncCount struct {
+	Component string // e.g. "components.Table", from the function that called js.FuncOf
+	Created   int    // since tracking started
+	Live      int    // not yet garbage collected, because Go or JS still references them
+	Listening int    // attached as event listeners
+}
+
+// DetachedListener is an event listener js.Func attached to a DOM node that
+// is no longer in the document. Unless the js.Func is released and removed,
+// it keeps the node, and everything its closure captured, alive. Holding on
+// to Element keeps the node alive too.
+type DetachedListener struct {
+	Element     js.Value
+	Description string // e.g. "div#chart.card"
+	Event       string // e.g. "click"
+	Component   string
+	Site        string    // function, file, and line that called js.FuncOf
+	Created     time.Time // when the js.Func was created
+	Since       time.Time // when the node was first seen detached
+}
+
+type trackedFunc struct {
+	component string
+	site      string
+	created   time.Time
+	listeners []trackedListener
+}
+
+type trackedListener struct {
+	target js.Value // a WeakRef, so tracking doesn't keep targets alive
+	event  string
+}
+
+var tracker struct {
+	mu       sync.Mutex
+	on       bool
+	funcs    map[int]*trackedFunc
+	created  map[string]int // per component
+	nextID   int
+	ids      js.Value // WeakMap from function wrapper to ID
+	registry js.Value // FinalizationRegistry that forgets collected wrappers
+	collect  js.Func  // the registry's callback, kept for the session
+	undo     []func()
+}
+
+// StartFuncTracking instruments js.FuncOf and addEventListener so
+// FuncCounts can count live js.Funcs per component and DetachedListeners
+// can list listeners left on removed nodes. It patches wasm_exec.js
+// internals, so call it in development only, first thing in main to count
+// every js.Func. It returns false when the runtime doesn't expose them.
+func StartFuncTracking() bool {
+	tracker.mu.Lock()
+	if tracker.on {
+		tracker.mu.Unlock()
+		return true
+	}
+	tracker.mu.Unlock()
+
+	global := js.Global()
+	proto := global.Get("Go").Get("prototype")
+	if proto.Type() != js.TypeObject || proto.Get("_makeFuncWrapper").Type() != js.TypeFunction ||
+		!global.Get("FinalizationRegistry").Truthy() || !global.Get("WeakRef").Truthy() {
+		return false
+	}
+
+	if !tracker.registry.Truthy() {
+		tracker.collect = js.FuncOf(func(this js.Value, args []js.Value) any {
+			tracker.mu.Lock()
+			delete(tracker.funcs, args[0].Int())
+			tracker.mu.Unlock()
+			return nil
+		})
+		tracker.registry = global.Get("FinalizationRegistry").New(tracker.collect)
+	}
+
+	tracker.mu.Lock()
+	tracker.on = true
+	tracker.funcs = make(map[int]*trackedFunc)
+	tracker.created = make(map[string]int)
+	tracker.ids = global.Get("WeakMap").New()
+	tracker.mu.Unlock()
+
+	patch(proto, "_makeFuncWrapper", func(_, wrapper js.Value, _ []js.Value) {
+		trackFunc(wrapper)
+	})
+	if target := global.Get("EventTarget"); target.Truthy() {
+		patch(target.Get("prototype"), "addEventListener", func(this, _ js.Value, args []js.Value) {
+			trackListener(this, args, true)
+		})
+		patch(target.Get("prototype"), "removeEventListener", func(this, _ js.Value, args []js.Value) {
+			trackListener(this, args, false)
+		})
+	}
+	return true
+}
+
+// StopFuncTracking removes the instrumentation and forgets what it tracked
+func StopFuncTracking() {
+	tracker.mu.Lock()
+	if !tracker.on {
+		tracker.mu.Unlock()
+		return
+	}
+	tracker.on = false
+	undo := tracker.undo
+	tracker.undo = nil
+	tracker.funcs, tracker.created = nil, nil
+	tracker.mu.Unlock()
+
+	for j := len(undo) - 1; j >= 0; j-- {
+		undo[j]()
+	}
+}
+
+// FuncTracking returns whether StartFuncTracking is on
+func FuncTracking() bool {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.on
+}
+
+// patch replaces obj[name] with a function that calls the original, then
+// after with the same this and arguments and the original's result
+func patch(obj js.Value, name string, after func(this, result js.Value, args []js.Value)) {
+	original := obj.Get(name)
+	wrapped := js.FuncOf(func(this js.Value, args []js.Value) any {
+		jsArgs := make([]any, len(args))
+		for j, a := range args {
+			jsArgs[j] = a
+		}
+		result := original.Call("apply", this, jsArgs)
+		after(this, result, args)
+		return result
+	})
+	obj.Set(name, wrapped)
+	tracker.undo = append(tracker.undo, func() {
+		obj.Set(name, original)
+		wrapped.Release()
+	})
+}
+
+// trackFunc records a wrapper created by js.FuncOf. The patched
+// _makeFuncWrapper runs on the goroutine that called js.FuncOf, so the
+// caller is on the stack.
+func trackFunc(wrapper js.Value) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	var caller runtime.Frame
+	for found := false; ; {
+		frame, more := frames.Next()
+		if found {
+			caller = frame
+			break
+		}
+		found = frame.Function == "syscall/js.FuncOf"
+		if !more {
+			return
+		}
+	}
+	// Skip this package's own patches and the runtime's short-lived callbacks
+	// for file and console I/O
+	if strings.HasPrefix(caller.Function, "github.com/dougbarrett/gux/debug.") || strings.HasPrefix(caller.Function, "syscall.") {
+		return
+	}
+
+	tracker.mu.Lock()
+	if !tracker.on {
+		tracker.mu.Unlock()
+		return
+	}
+	id := tracker.nextID
+	tracker.nextID++
+	f := &trackedFunc{
+		component: componentOf(caller.Function),
+		site:      fmt.Sprintf("%s (%s:%d)", shortFunc(caller.Function), path.Join(path.Base(path.Dir(caller.File)), path.Base(caller.File)), caller.Line),
+		created:   time.Now(),
+	}
+	tracker.funcs[id] = f
+	tracker.created[f.component]++
+	ids, registry := tracker.ids, tracker.registry
+	tracker.mu.Unlock()
+
+	ids.Call("set", wrapper, id)
+	registry.Call("register", wrapper, id)
+}
+
+// trackListener records a tracked js.Func being added to or removed from
+// an event target. Listeners added with once or signal remove themselves
+// unseen, so they are not tracked.
+func trackListener(target js.Value, args []js.Value, add bool) {
+	tracker.mu.Lock()
+	on, ids := tracker.on, tracker.ids
+	tracker.mu.Unlock()
+	if !on || len(args) < 2 || args[1].Type() != js.TypeFunction {
+		return
+	}
+	idValue := ids.Call("get", args[1])
+	if idValue.Type() != js.TypeNumber {
+		return
+	}
+	if add && len(args) > 2 && args[2].Type() == js.TypeObject && (args[2].Get("once").Truthy() || args[2].Get("signal").Truthy()) {
+		return
+	}
+	event := args[0].String()
+	var ref js.Value
+	if add {
+		ref = js.Global().Get("WeakRef").New(target)
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	f := tracker.funcs[idValue.Int()]
+	if f == nil {
+		return
+	}
+	if add {
+		f.listeners = append(f.listeners, trackedListener{target: ref, event: event})
+		return
+	}
+	for j, l := range f.listeners {
+		if l.event == event && l.target.Call("deref").Equal(target) {
+			f.listeners = append(f.listeners[:j], f.listeners[j+1:]...)
+			return
+		}
+	}
+}
+
+// componentOf turns the function that created a js.Func into the component
+// it belongs to: methods count for their type and NewX constructors for X,
+// e.g. "github.com/dougbarrett/gux/components.(*Table).render.func2" is
+// "components.Table"
+func componentOf(function string) string {
+	name := shortFunc(function)
+	pkg, rest, ok := strings.Cut(name, ".")
+	if !ok {
+		return name
+	}
+	var typ string
+	if strings.HasPrefix(rest, "(") {
+		typ, _, _ = strings.Cut(rest[1:], ")")
+		typ = strings.TrimPrefix(typ, "*")
+	} else {
+		typ, _, _ = strings.Cut(rest, ".")
+		if r := []rune(typ); len(r) > 3 && strings.HasPrefix(typ, "New") && unicode.IsUpper(r[3]) {
+			typ = typ[3:]
+		}
+	}
+	typ, _, _ = strings.Cut(typ, "[")
+	return pkg + "." + typ
+}
+
+// shortFunc drops the import path from a function name
+func shortFunc(function string) string {
+	if j := strings.LastIndex(function, "/"); j >= 0 {
+		return function[j+1:]
+	}
+	return function
+}
+
+// FuncCounts returns the js.Funcs created per component since
+// StartFuncTracking, most live first. Live counts drop when JS garbage
+// collects a wrapper, which needs Go to have released the js.Func or
+// dropped every reference to it, and JS to have collected since.
+func FuncCounts() []FuncCount {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if !tracker.on {
+		return nil
+	}
+	counts := make(map[string]*FuncCount, len(tracker.created))
+	for component, n := range tracker.created {
+		counts[component] = &FuncCount{Component: component, Created: n}
+	}
+	for _, f := range tracker.funcs {
+		c := counts[f.component]
+		c.Live++
+		if len(f.listeners) > 0 {
+			c.Listening++
+		}
+	}
+	result := make([]FuncCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Live != result[b].Live {
+			return result[a].Live > result[b].Live
+		}
+		return result[a].Component < result[b].Component
+	})
+	return result
+}
+
+// DetachedListeners returns the event listeners created while
+// StartFuncTracking is on that are still attached to elements no longer in
+// the document, oldest detached first
+func DetachedListeners() []DetachedListener {
+	global := js.Global()
+	if !detachedSince.Truthy() {
+		detachedSince = global.Get("WeakMap").New()
+	}
+	nodeType := global.Get("Node")
+	now := time.Now()
+
+	tracker.mu.Lock()
+	var found []DetachedListener
+	for _, f := range tracker.funcs {
+		kept := f.listeners[:0]
+		for _, l := range f.listeners {
+			el := l.target.Call("deref")
+			if el.IsUndefined() {
+				continue // collected
+			}
+			kept = append(kept, l)
+			if !el.InstanceOf(nodeType) || el.Get("nodeType").Int() != 1 || el.Get("isConnected").Bool() {
+				continue
+			}
+			found = append(found, DetachedListener{
+				Element:   el,
+				Event:     l.event,
+				Component: f.component,
+				Site:      f.site,
+				Created:   f.created,
+			})
+		}
+		f.listeners = kept
+	}
+	tracker.mu.Unlock()
+
+	for j := range found {
+		l := &found[j]
+		l.Since = now
+		if t := detachedSince.Call("get", l.Element); t.Truthy() {
+			l.Since = time.UnixMilli(int64(t.Float()))
+		} else {
+			detachedSince.Call("set", l.Element, float64(now.UnixMilli()))
+		}
+		l.Description = describe(l.Element)
+	}
+	sort.SliceStable(found, func(a, b int) bool { return found[a].Since.Before(found[b].Since) })
+	return found
+}
+
+// liveFuncs returns the number of live tracked js.Funcs, or -1 when
+// tracking is off
+func liveFuncs() int {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if !tracker.on {
+		return -1
+	}
+	return len(tracker.funcs)
+}
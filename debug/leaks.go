@@ -0,0 +1,228 @@
+//go:build js && wasm
+
+package debug
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// DetachedMinAge is how long a node must stay detached before Leaks reports
+// it. Pages often build elements before mounting them or keep the previous
+// view briefly while swapping, so only nodes that stay detached count.
+const DetachedMinAge = 30 * time.Second
+
+// LeakKind identifies a leak heuristic
+type LeakKind string
+
+const (
+	LeakDetachedDOM      LeakKind = "detached-dom"      // detached nodes still referenced from Go
+	LeakDetachedListener LeakKind = "detached-listener" // js.Func listeners left on detached nodes
+	LeakGoHeapGrowth     LeakKind = "go-heap-growth"    // Go heap keeps growing across GCs
+	LeakJSRefGrowth      LeakKind = "js-ref-growth"     // Go holds ever more JS values
+	LeakFuncGrowth       LeakKind = "func-growth"       // ever more live js.Funcs
+	LeakDOMGrowth        LeakKind = "dom-growth"        // the document keeps getting larger
+)
+
+// Leak is a likely leak found by Leaks
+type Leak struct {
+	Kind      LeakKind
+	Message   string
+	Nodes     []DetachedNode     // for LeakDetachedDOM
+	Listeners []DetachedListener // for LeakDetachedListener
+}
+
+// DetachedNode is a DOM node that is no longer in the document but is still
+// referenced from Go, which keeps it and its subtree alive. The usual cause
+// is an event listener js.Func that captures the element and is never
+// released. Holding on to Element keeps the node alive too.
+type DetachedNode struct {
+	Element     js.Value
+	Description string    // e.g. "div#chart.card"
+	Since       time.Time // when the node was first seen detached
+}
+
+// detachedSince remembers when nodes were first seen detached without
+// keeping them alive
+var detachedSince js.Value
+
+// DetachedNodes runs the garbage collector and returns the DOM nodes in the
+// JS values referenced from Go that are not in the document. It returns nil
+// when the wasm_exec.js in use does not expose its value table.
+func DetachedNodes() []DetachedNode {
+	inst := goInstance()
+	if !inst.Truthy() {
+		return nil
+	}
+
+	// Collect garbage and let finalizers drop the JS references of dead js.Values
+	runtime.GC()
+	runtime.Gosched()
+	runtime.GC()
+
+	global := js.Global()
+	if !detachedSince.Truthy() {
+		detachedSince = global.Get("WeakMap").New()
+	}
+	nodeType := global.Get("Node")
+	now := time.Now()
+
+	values := inst.Get("_values")
+	n := values.Length()
+	var nodes []DetachedNode
+	for i := 0; i < n; i++ {
+		v := values.Index(i)
+		if v.Type() != js.TypeObject || !v.InstanceOf(nodeType) || v.Get("isConnected").Bool() {
+			continue
+		}
+		// Text and comment nodes are reported through their element
+		if v.Get("nodeType").Int() != 1 {
+			continue
+		}
+
+		since := now
+		if t := detachedSince.Call("get", v); t.Truthy() {
+			since = time.UnixMilli(int64(t.Float()))
+		} else {
+			detachedSince.Call("set", v, float64(now.UnixMilli()))
+		}
+		nodes = append(nodes, DetachedNode{Element: v, Description: describe(v), Since: since})
+	}
+	return nodes
+}
+
+// Leaks runs the leak heuristics: nodes that have stayed detached for
+// DetachedMinAge while Go still references them or, with StartFuncTracking,
+// still have js.Func listeners, and steady growth of the Go heap, Go-held JS
+// values, live js.Funcs, or the document across the monitor history.
+func Leaks() []Leak {
+	var leaks []Leak
+
+	var old []DetachedNode
+	for _, node := range DetachedNodes() {
+		if time.Since(node.Since) >= DetachedMinAge {
+			old = append(old, node)
+		}
+	}
+	if len(old) > 0 {
+		leaks = append(leaks, Leak{
+			Kind:    LeakDetachedDOM,
+			Message: fmt.Sprintf("%d detached DOM node(s) are still referenced from Go, usually by an unreleased js.Func", len(old)),
+			Nodes:   old,
+		})
+	}
+
+	var listeners []DetachedListener
+	for _, l := range DetachedListeners() {
+		if time.Since(l.Since) >= DetachedMinAge {
+			listeners = append(listeners, l)
+		}
+	}
+	if len(listeners) > 0 {
+		leaks = append(leaks, Leak{
+			Kind:      LeakDetachedListener,
+			Message:   fmt.Sprintf("%d event listener(s) are still attached to detached DOM nodes", len(listeners)),
+			Listeners: listeners,
+		})
+	}
+
+	history := History()
+	checks := []struct {
+		kind      LeakKind
+		what      string
+		value     func(MemStats) float64
+		minGrowth float64 // absolute growth below which growth is noise
+		format    func(float64) string
+	}{
+		{LeakGoHeapGrowth, "Go heap", func(s MemStats) float64 { return float64(s.GoHeapAlloc) }, 1 << 20, FormatBytes},
+		{LeakJSRefGrowth, "JS values referenced from Go", func(s MemStats) float64 { return float64(s.JSRefs) }, 500, formatCount},
+		{LeakFuncGrowth, "Live js.Funcs", func(s MemStats) float64 { return float64(s.JSFuncs) }, 200, formatCount},
+		{LeakDOMGrowth, "DOM nodes", func(s MemStats) float64 { return float64(s.DOMNodes) }, 500, formatCount},
+	}
+	for _, c := range checks {
+		if from, to, ok := steadyGrowth(history, c.value, c.minGrowth); ok {
+			leaks = append(leaks, Leak{
+				Kind:    c.kind,
+				Message: fmt.Sprintf("%s keeps growing: %s to %s", c.what, c.format(from), c.format(to)),
+			})
+		}
+	}
+	return leaks
+}
+
+// steadyGrowth reports whether the low point of each third of the history is
+// higher than the one before by more than 25% overall. Low points filter out
+// garbage that simply has not been collected yet.
+func steadyGrowth(history []MemStats, value func(MemStats) float64, minGrowth float64) (from, to float64, ok bool) {
+	if len(history) < 12 {
+		return 0, 0, false
+	}
+	third := len(history) / 3
+	lows := make([]float64, 3)
+	for i := range lows {
+		end := (i + 1) * third
+		if i == 2 {
+			end = len(history)
+		}
+		lows[i] = value(history[i*third])
+		for _, s := range history[i*third : end] {
+			if v := value(s); v < lows[i] {
+				lows[i] = v
+			}
+		}
+	}
+	if lows[0] < 0 || !(lows[0] < lows[1] && lows[1] < lows[2]) {
+		return 0, 0, false
+	}
+	growth := lows[2] - lows[0]
+	if growth < minGrowth || growth < lows[0]*0.25 {
+		return 0, 0, false
+	}
+	return lows[0], lows[2], true
+}
+
+// describe renders an element as tag#id.class
+func describe(el js.Value) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(el.Get("tagName").String()))
+	if id := el.Get("id").String(); id != "" {
+		b.WriteString("#" + id)
+	}
+	if class := el.Get("className"); class.Type() == js.TypeString {
+		classes := strings.Fields(class.String())
+		if len(classes) > 3 {
+			classes = classes[:3]
+		}
+		for _, c := range classes {
+			b.WriteString("." + c)
+		}
+	}
+	if text := []rune(strings.TrimSpace(el.Get("textContent").String())); len(text) > 0 {
+		if len(text) > 30 {
+			text = append(text[:30], '…')
+		}
+		fmt.Fprintf(&b, " %q", string(text))
+	}
+	return b.String()
+}
+
+// FormatBytes renders a byte count as B, KB, MB, or GB
+func FormatBytes(v float64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", v, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", v, units[i])
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprintf("%.0f", v)
+}
@@ -0,0 +1,250 @@
+//go:build js && wasm
+
+// Package debug helps find memory growth in long-running sessions. It reads
+// the Go heap from the runtime, the JS heap from performance.memory (Chromium
+// only), and the number of JS values Go holds references to, keeps a history
+// of samples, and flags patterns that usually mean a leak.
+//
+//	debug.StartMonitor(debug.MonitorOptions{})
+//
+//	stats := debug.MemoryStats()
+//	for _, leak := range debug.Leaks() {
+//		println(leak.Message)
+//	}
+//
+// The Inspector's Memory tab graphs the history and runs the leak checks.
+package debug
+
+import (
+	"runtime"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// DefaultInterval is the sampling interval when MonitorOptions.Interval is 0
+const DefaultInterval = 10 * time.Second
+
+// DefaultCapacity is the history size when MonitorOptions.Capacity is 0
+const DefaultCapacity = 600
+
+// MemStats is one memory sample
+type MemStats struct {
+	Time time.Time `json:"time"`
+
+	GoHeapAlloc   uint64 `json:"goHeapAlloc"`   // bytes in live and not yet collected heap objects
+	GoHeapSys     uint64 `json:"goHeapSys"`     // heap bytes obtained from the WASM memory
+	GoHeapObjects uint64 `json:"goHeapObjects"` // allocated heap objects
+	GoNumGC       uint32 `json:"goNumGC"`       // completed GC cycles
+	Goroutines    int    `json:"goroutines"`
+	WASMMemory    uint64 `json:"wasmMemory"` // WASM linear memory size; it grows but never shrinks
+
+	// JS heap from performance.memory, which only Chromium provides
+	JSHeapAvailable bool   `json:"jsHeapAvailable"`
+	JSHeapUsed      uint64 `json:"jsHeapUsed,omitempty"`
+	JSHeapTotal     uint64 `json:"jsHeapTotal,omitempty"`
+	JSHeapLimit     uint64 `json:"jsHeapLimit,omitempty"`
+
+	DOMNodes int `json:"domNodes"` // elements in the document
+	JSRefs   int `json:"jsRefs"`   // JS values referenced from Go, or -1 if unknown
+	JSFuncs  int `json:"jsFuncs"`  // live js.Funcs while StartFuncTracking is on, or -1
+}
+
+// MemoryStats takes a memory sample
+func MemoryStats() MemStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	s := MemStats{
+		Time:          time.Now(),
+		GoHeapAlloc:   m.HeapAlloc,
+		GoHeapSys:     m.HeapSys,
+		GoHeapObjects: m.HeapObjects,
+		GoNumGC:       m.NumGC,
+		Goroutines:    runtime.NumGoroutine(),
+		JSRefs:        -1,
+		JSFuncs:       liveFuncs(),
+	}
+
+	global := js.Global()
+	if mem := global.Get("performance").Get("memory"); mem.Truthy() {
+		s.JSHeapAvailable = true
+		s.JSHeapUsed = uint64(mem.Get("usedJSHeapSize").Float())
+		s.JSHeapTotal = uint64(mem.Get("totalJSHeapSize").Float())
+		s.JSHeapLimit = uint64(mem.Get("jsHeapSizeLimit").Float())
+	}
+	s.DOMNodes = global.Get("document").Call("getElementsByTagName", "*").Length()
+
+	if inst := goInstance(); inst.Truthy() {
+		// Go keeps a DataView of its memory; TinyGo only has the export
+		buf := inst.Get("mem").Get("buffer")
+		if !buf.Truthy() {
+			if exports := inst.Get("_inst").Get("exports"); exports.Truthy() && exports.Get("memory").Truthy() {
+				buf = exports.Get("memory").Get("buffer")
+			}
+		}
+		if buf.Truthy() {
+			s.WASMMemory = uint64(buf.Get("byteLength").Float())
+		}
+		values, pool := inst.Get("_values"), inst.Get("_idPool")
+		if values.Truthy() && pool.Truthy() {
+			s.JSRefs = values.Length() - pool.Length()
+		}
+	}
+	return s
+}
+
+// MonitorOptions configures StartMonitor
+type MonitorOptions struct {
+	// Interval between samples (default 10s)
+	Interval time.Duration
+
+	// Capacity is the number of samples kept (default 600). When the history
+	// is full every other sample is dropped, so it always spans the whole
+	// session at a decreasing resolution.
+	Capacity int
+}
+
+type monitor struct {
+	mu        sync.Mutex
+	opts      MonitorOptions
+	history   []MemStats
+	stop      chan struct{}
+	listeners map[int]func(MemStats)
+	nextID    int
+}
+
+var mon = &monitor{listeners: make(map[int]func(MemStats))}
+
+// StartMonitor samples memory in the background. Calling it again restarts
+// sampling with the new options and keeps the history.
+func StartMonitor(opts MonitorOptions) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Capacity < 2 {
+		opts.Capacity = DefaultCapacity
+	}
+
+	StopMonitor()
+
+	mon.mu.Lock()
+	mon.opts = opts
+	stop := make(chan struct{})
+	mon.stop = stop
+	mon.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		record(MemoryStats())
+		for {
+			select {
+			case <-ticker.C:
+				record(MemoryStats())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopMonitor stops sampling. The history is kept.
+func StopMonitor() {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	if mon.stop != nil {
+		close(mon.stop)
+		mon.stop = nil
+	}
+}
+
+// Monitoring returns whether the monitor is running
+func Monitoring() bool {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	return mon.stop != nil
+}
+
+// History returns the samples taken so far, oldest first
+func History() []MemStats {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	return append([]MemStats(nil), mon.history...)
+}
+
+// OnSample registers a callback for every new sample. Returns a function
+// that unregisters it.
+func OnSample(fn func(MemStats)) func() {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	mon.nextID++
+	id := mon.nextID
+	mon.listeners[id] = fn
+	return func() {
+		mon.mu.Lock()
+		defer mon.mu.Unlock()
+		delete(mon.listeners, id)
+	}
+}
+
+func record(s MemStats) {
+	mon.mu.Lock()
+	mon.history = append(mon.history, s)
+	if len(mon.history) > mon.opts.Capacity {
+		thinned := mon.history[:0]
+		for i, h := range mon.history {
+			if i%2 == 0 || i == len(mon.history)-1 {
+				thinned = append(thinned, h)
+			}
+		}
+		mon.history = thinned
+	}
+	listeners := make([]func(MemStats), 0, len(mon.listeners))
+	for _, fn := range mon.listeners {
+		listeners = append(listeners, fn)
+	}
+	mon.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(s)
+	}
+}
+
+var (
+	goInst     js.Value
+	goInstOnce sync.Once
+)
+
+// goInstance finds the Go instance created by wasm_exec.js, which holds the
+// table of JS values referenced from Go. It is not exposed globally, so
+// _makeFuncWrapper is patched for the duration of one js.FuncOf to see it.
+func goInstance() js.Value {
+	goInstOnce.Do(func() {
+		ctor := js.Global().Get("Go")
+		if ctor.Type() != js.TypeFunction {
+			return
+		}
+		proto := ctor.Get("prototype")
+		original := proto.Get("_makeFuncWrapper")
+		if original.Type() != js.TypeFunction {
+			return
+		}
+
+		capture := js.FuncOf(func(this js.Value, args []js.Value) any {
+			goInst = this
+			jsArgs := make([]any, len(args))
+			for i, a := range args {
+				jsArgs[i] = a
+			}
+			return original.Call("apply", this, jsArgs)
+		})
+		proto.Set("_makeFuncWrapper", capture)
+		probe := js.FuncOf(func(js.Value, []js.Value) any { return nil })
+		proto.Set("_makeFuncWrapper", original)
+
+		probe.Release()
+		capture.Release()
+	})
+	return goInst
+}
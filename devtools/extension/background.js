@@ -0,0 +1,50 @@
+// Routes messages between each tab's content script and its devtools panel
+const tabs = new Map(); // tabId -> { content, panel }
+
+function entry(tabId) {
+  if (!tabs.has(tabId)) {
+    tabs.set(tabId, {});
+  }
+  return tabs.get(tabId);
+}
+
+chrome.runtime.onConnect.addListener((port) => {
+  if (port.name === "gux-content") {
+    const tabId = port.sender.tab.id;
+    entry(tabId).content = port;
+    port.onMessage.addListener((msg) => {
+      const panel = tabs.get(tabId)?.panel;
+      if (panel) {
+        panel.postMessage(msg);
+      }
+    });
+    port.onDisconnect.addListener(() => {
+      const e = tabs.get(tabId);
+      if (e && e.content === port) {
+        e.content = null;
+      }
+    });
+    return;
+  }
+
+  if (port.name.startsWith("gux-panel:")) {
+    const tabId = Number(port.name.slice("gux-panel:".length));
+    entry(tabId).panel = port;
+    port.onMessage.addListener((msg) => {
+      const content = tabs.get(tabId)?.content;
+      if (content) {
+        content.postMessage(msg);
+      } else {
+        port.postMessage({ type: "disconnected" });
+      }
+    });
+    port.onDisconnect.addListener(() => {
+      const e = tabs.get(tabId);
+      if (e && e.panel === port) {
+        e.panel = null;
+      }
+    });
+  }
+});
+
+chrome.tabs.onRemoved.addListener((tabId) => tabs.delete(tabId));
@@ -0,0 +1,26 @@
+// Relays messages between the page's Gux app and the devtools panel.
+// The app answers messages with source "gux-devtools" and replies with
+// source "gux-page"; see components.EnableDevtools.
+let port = null;
+
+function connect() {
+  port = chrome.runtime.connect({ name: "gux-content" });
+  port.onMessage.addListener((msg) => {
+    window.postMessage(Object.assign({}, msg, { source: "gux-devtools" }), location.origin);
+  });
+  port.onDisconnect.addListener(() => {
+    port = null;
+  });
+}
+
+window.addEventListener("message", (e) => {
+  if (e.source !== window || !e.data || e.data.source !== "gux-page") {
+    return;
+  }
+  if (!port) {
+    connect();
+  }
+  port.postMessage(e.data);
+});
+
+connect();
@@ -0,0 +1,2 @@
+// Adds the Gux panel to the browser's devtools
+chrome.devtools.panels.create("Gux", "", "panel.html");
@@ -0,0 +1,218 @@
+// The Gux devtools panel. It talks to the app's devtools bridge
+// (components.EnableDevtools) through background.js and content.js.
+const port = chrome.runtime.connect({ name: "gux-panel:" + chrome.devtools.inspectedWindow.tabId });
+const pending = new Map(); // request id -> resolve
+let nextID = 1;
+
+function request(type, params) {
+  const id = nextID++;
+  port.postMessage(Object.assign({ id, type }, params));
+  return new Promise((resolve, reject) => {
+    pending.set(id, { resolve, reject });
+    setTimeout(() => {
+      if (pending.delete(id)) {
+        reject(new Error("No Gux app answered. Is EnableDevtools called, and the gux-devtools flag set if required?"));
+      }
+    }, 3000);
+  });
+}
+
+port.onMessage.addListener((msg) => {
+  switch (msg.type) {
+    case "result": {
+      const p = pending.get(msg.id);
+      if (p) {
+        pending.delete(msg.id);
+        msg.error ? p.reject(new Error(msg.error)) : p.resolve(msg.data);
+      }
+      break;
+    }
+    case "ready":
+      setStatus("Connected (protocol v" + msg.version + ")");
+      refresh();
+      break;
+    case "event":
+      if (msg.stream === "state") {
+        renderStore(msg.data);
+      } else if (msg.stream === "memory") {
+        renderMemory(msg.data);
+      }
+      break;
+    case "disconnected":
+      setStatus("Page not connected. Reload it with the panel open.");
+      break;
+  }
+});
+
+function $(id) {
+  return document.getElementById(id);
+}
+
+function el(tag, className, text) {
+  const e = document.createElement(tag);
+  if (className) e.className = className;
+  if (text !== undefined) e.textContent = text;
+  return e;
+}
+
+function setStatus(text) {
+  $("status").textContent = text;
+}
+
+function fail(err) {
+  setStatus(err.message);
+}
+
+// Tabs
+document.querySelectorAll("nav button").forEach((button) => {
+  button.addEventListener("click", () => {
+    document.querySelectorAll("nav button, section").forEach((e) => e.classList.remove("active"));
+    button.classList.add("active");
+    $(button.dataset.tab).classList.add("active");
+  });
+});
+
+// Components
+function loadComponents() {
+  request("components").then((root) => {
+    const tree = $("tree");
+    tree.textContent = "";
+    if (root) tree.appendChild(renderNode(root, []));
+  }, fail);
+}
+
+function renderNode(node, path) {
+  const li = el("li");
+  const label = el("div", "node");
+  label.appendChild(el("span", "name", node.name));
+  if (node.type && node.type !== node.name) label.appendChild(el("span", "type", " " + node.type));
+  if (node.owner) label.appendChild(el("span", "owner", node.owner.name));
+  label.addEventListener("mouseenter", () => request("highlight", { path }).catch(fail));
+  label.addEventListener("click", () => {
+    document.querySelectorAll(".node.selected").forEach((e) => e.classList.remove("selected"));
+    label.classList.add("selected");
+    request("element", { path }).then((info) => {
+      $("details").textContent = JSON.stringify({ props: node.props, owners: info && info.owners, html: info && info.html }, null, 2);
+    }, fail);
+  });
+  li.appendChild(label);
+  if (node.children && node.children.length) {
+    const ul = el("ul");
+    node.children.forEach((child, i) => ul.appendChild(renderNode(child, path.concat(i))));
+    li.appendChild(ul);
+  }
+  return li;
+}
+
+// State
+function loadStores() {
+  request("stores").then((stores) => {
+    $("stores").textContent = "";
+    (stores || []).forEach(renderStore);
+  }, fail);
+}
+
+function renderStore(store) {
+  const id = "store-" + store.name;
+  let box = document.getElementById(id);
+  if (!box) {
+    box = el("div", "store");
+    box.id = id;
+    box.appendChild(el("h4", "", store.name));
+    const editor = el("textarea");
+    const error = el("div", "error");
+    const apply = el("button", "", "Apply");
+    apply.addEventListener("click", () => {
+      let value;
+      try {
+        value = JSON.parse(editor.value);
+      } catch (err) {
+        error.textContent = err.message;
+        return;
+      }
+      request("setStore", { name: store.name, value }).then(() => (error.textContent = ""), (err) => (error.textContent = err.message));
+    });
+    box.append(editor, apply, error);
+    $("stores").appendChild(box);
+  }
+  const editor = box.querySelector("textarea");
+  if (document.activeElement !== editor) {
+    editor.value = JSON.stringify(store.value, null, 2);
+  }
+}
+
+$("watch-state").addEventListener("change", (e) => request("watch", { stream: "state", on: e.target.checked }).catch(fail));
+
+// Memory
+function loadMemory() {
+  request("memory").then((m) => renderMemory(m.current), fail);
+  request("funcs").then(renderFuncs, fail);
+}
+
+function renderMemory(stats) {
+  $("memory-current").textContent = JSON.stringify(stats, null, 2);
+}
+
+function renderFuncs(funcs) {
+  const table = $("funcs");
+  table.textContent = "";
+  $("track-funcs").disabled = funcs.tracking;
+  if (!funcs.counts.length) return;
+  const head = el("tr");
+  ["Component", "Created", "Live", "Listening"].forEach((h) => head.appendChild(el("th", "", h)));
+  table.appendChild(head);
+  funcs.counts.forEach((c) => {
+    const row = el("tr");
+    [c.component, c.created, c.live, c.listening].forEach((v) => row.appendChild(el("td", "", String(v))));
+    table.appendChild(row);
+  });
+}
+
+$("watch-memory").addEventListener("change", (e) => request("watch", { stream: "memory", on: e.target.checked }).catch(fail));
+$("track-funcs").addEventListener("click", () => request("trackFuncs").then(loadMemory, fail));
+
+// Leaks and accessibility
+function renderItems(container, items, render) {
+  container.textContent = "";
+  if (!items || !items.length) {
+    container.appendChild(el("p", "", "No issues found."));
+    return;
+  }
+  items.forEach((item) => container.appendChild(render(item)));
+}
+
+function loadLeaks() {
+  request("leaks").then((leaks) => renderItems($("leak-list"), leaks, (leak) => {
+    const div = el("div", "item");
+    div.appendChild(el("h4", "", leak.message));
+    leak.nodes.forEach((n) => div.appendChild(el("div", "", n)));
+    leak.listeners.forEach((l) => div.appendChild(el("div", "", l.event + " on " + l.description + (l.site ? " (" + l.site + ")" : ""))));
+    return div;
+  }), fail);
+}
+
+function loadA11y() {
+  request("a11y").then((issues) => renderItems($("a11y-list"), issues, (issue) => {
+    const div = el("div", issue.severity === "error" ? "item error" : "item");
+    div.appendChild(el("h4", "", issue.rule + ": " + issue.message));
+    div.appendChild(el("div", "", issue.description + (issue.owner && issue.owner.name ? " in " + issue.owner.name : "")));
+    return div;
+  }), fail);
+}
+
+$("refresh-components").addEventListener("click", loadComponents);
+$("refresh-stores").addEventListener("click", loadStores);
+$("refresh-memory").addEventListener("click", loadMemory);
+$("refresh-leaks").addEventListener("click", loadLeaks);
+$("refresh-a11y").addEventListener("click", loadA11y);
+
+function refresh() {
+  request("hello").then((app) => {
+    setStatus(app.title || app.url);
+    loadComponents();
+    loadStores();
+    loadMemory();
+  }, fail);
+}
+
+refresh();
@@ -0,0 +1,142 @@
+// Package di is a small service container for sharing API clients, stores,
+// feature flags, and other services between pages without package-level
+// globals. Services are keyed by type, so interfaces work as keys and pages
+// can be tested by providing fakes.
+//
+//	services := di.New()
+//	di.Provide[api.UsersAPI](services, api.NewUsersClient())
+//
+//	users := di.MustResolve[api.UsersAPI](services)
+//
+// The package has no build constraints, so the same wiring works in server
+// code and in tests.
+package di
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// key identifies a service by its type
+type key[T any] struct{}
+
+type entry struct {
+	mu      sync.Mutex // held while the factory runs, so concurrent callers wait for it
+	value   any
+	factory func(*Container) any
+}
+
+// Container holds services. A scope created with Scope resolves from its
+// parent when it has no service of its own.
+type Container struct {
+	mu      sync.RWMutex
+	parent  *Container
+	entries map[any]*entry
+
+	// A factory is called with a view of the container it was provided to,
+	// which carries the entries being built on that resolution chain, so
+	// concurrent resolutions don't mistake each other for a cycle
+	target *Container
+	chain  []*entry
+}
+
+// self returns the container a view stands for
+func (c *Container) self() *Container {
+	if c.target != nil {
+		return c.target
+	}
+	return c
+}
+
+// New creates an empty root container
+func New() *Container {
+	return &Container{entries: make(map[any]*entry)}
+}
+
+// Scope creates a child container. Services provided to the scope shadow
+// the parent's without changing it, e.g. to swap in a fake for one page.
+func (c *Container) Scope() *Container {
+	return &Container{parent: c.self(), entries: make(map[any]*entry)}
+}
+
+// Parent returns the container this scope was created from, or nil for a root
+func (c *Container) Parent() *Container {
+	return c.self().parent
+}
+
+// Provide registers value as the service for type T, replacing any previous one
+func Provide[T any](c *Container, value T) {
+	c = c.self()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key[T]{}] = &entry{value: value}
+}
+
+// ProvideFunc registers a factory for type T. It runs on first Resolve and
+// its result is reused, so dependencies can be resolved lazily in any order.
+func ProvideFunc[T any](c *Container, factory func(*Container) T) {
+	c = c.self()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key[T]{}] = &entry{factory: func(c *Container) any { return factory(c) }}
+}
+
+// Resolve returns the service for type T from c or its nearest ancestor
+func Resolve[T any](c *Container) (T, bool) {
+	var zero T
+	chain := c.chain
+	for scope := c.self(); scope != nil; scope = scope.parent {
+		scope.mu.RLock()
+		e, ok := scope.entries[key[T]{}]
+		scope.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		value, err := scope.build(e, typeName[T](), chain)
+		if err != nil {
+			panic(err)
+		}
+		v, ok := value.(T)
+		if !ok {
+			return zero, false
+		}
+		return v, true
+	}
+	return zero, false
+}
+
+// MustResolve returns the service for type T and panics if none is provided
+func MustResolve[T any](c *Container) T {
+	v, ok := Resolve[T](c)
+	if !ok {
+		panic("di: no service provided for " + typeName[T]())
+	}
+	return v
+}
+
+// build runs an entry's factory once, in the scope it was provided to.
+// chain holds the entries being built by the resolution that asked for e.
+func (c *Container) build(e *entry, name string, chain []*entry) (any, error) {
+	for _, building := range chain {
+		if building == e {
+			return nil, fmt.Errorf("di: dependency cycle resolving %s", name)
+		}
+	}
+
+	// The factory is left in place if it panics, so it can be retried
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.factory == nil {
+		return e.value, nil
+	}
+	view := &Container{target: c, chain: append(chain[:len(chain):len(chain)], e)}
+	e.value = e.factory(view)
+	e.factory = nil
+	return e.value, nil
+}
+
+// typeName returns a readable name for T, including interface types
+func typeName[T any]() string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", (*T)(nil)), "*")
+}
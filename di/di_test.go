@@ -0,0 +1,70 @@
+package di
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type db struct{ name string }
+type repo struct{ db *db }
+
+func TestResolveConcurrent(t *testing.T) {
+	c := New()
+	var builds atomic.Int32
+	ProvideFunc(c, func(c *Container) *db {
+		builds.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &db{name: "main"}
+	})
+	ProvideFunc(c, func(c *Container) *repo {
+		return &repo{db: MustResolve[*db](c)}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r := MustResolve[*repo](c); r.db.name != "main" {
+				t.Errorf("db = %q", r.db.name)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := builds.Load(); n != 1 {
+		t.Fatalf("factory ran %d times, want 1", n)
+	}
+}
+
+type a struct{}
+type b struct{}
+
+func TestResolveCycle(t *testing.T) {
+	c := New()
+	ProvideFunc(c, func(c *Container) *a { MustResolve[*b](c); return &a{} })
+	ProvideFunc(c, func(c *Container) *b { MustResolve[*a](c); return &b{} })
+
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+			t.Fatalf("recovered %v, want a cycle error", err)
+		}
+	}()
+	MustResolve[*a](c)
+}
+
+func TestScopeShadows(t *testing.T) {
+	c := New()
+	Provide(c, &db{name: "main"})
+	scope := c.Scope()
+	Provide(scope, &db{name: "fake"})
+	if got := MustResolve[*db](scope).name; got != "fake" {
+		t.Fatalf("scope resolved %q", got)
+	}
+	if got := MustResolve[*db](c).name; got != "main" {
+		t.Fatalf("parent resolved %q", got)
+	}
+}
@@ -0,0 +1,409 @@
+//go:build js && wasm
+
+// Package diagnostics gathers a support bundle — app version, current route,
+// recent console logs, session breadcrumbs, and sanitized state snapshots —
+// that users can download and attach to an issue.
+//
+//	diagnostics.Init(diagnostics.Options{Version: "1.4.2"})
+//	diagnostics.RegisterState("cart", func() any { return cartStore.Get() })
+//
+//	report := diagnostics.NewReportDialog(diagnostics.ReportDialogProps{})
+//	report.Open()
+//
+// Values under keys that look like secrets (passwords, tokens, cookies, API
+// keys) are replaced before anything leaves the page.
+package diagnostics
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/owner"
+	"github.com/dougbarrett/gux/trail"
+)
+
+// DefaultMaxLogs is the number of log entries kept when Options.MaxLogs is 0
+const DefaultMaxLogs = 200
+
+// Redacted replaces sensitive values in bundles
+const Redacted = "[redacted]"
+
+// defaultRedactKeys are key fragments whose values are never exported
+var defaultRedactKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "apikey", "api_key", "session"}
+
+// bearerPattern matches credentials that end up in log messages
+var bearerPattern = regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+// Format selects the download format
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatZIP  Format = "zip"
+)
+
+// Options configures diagnostics collection
+type Options struct {
+	Version string   // app version reported in bundles
+	MaxLogs int      // recent console entries kept (default 200)
+	Redact  []string // extra key fragments to redact, matched case-insensitively
+}
+
+// LogEntry is a captured console message
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// Bundle is everything collected for a problem report
+type Bundle struct {
+	Version     string                     `json:"version,omitempty"`
+	Time        time.Time                  `json:"time"`
+	Description string                     `json:"description,omitempty"`
+	URL         string                     `json:"url"`
+	Route       string                     `json:"route"`
+	Owner       *owner.Info                `json:"owner,omitempty"` // owner of the route
+	UserAgent   string                     `json:"userAgent"`
+	Locale      string                     `json:"locale"`
+	Viewport    string                     `json:"viewport"`
+	Online      bool                       `json:"online"`
+	Logs        []LogEntry                 `json:"logs"`
+	Breadcrumbs []trail.Crumb              `json:"breadcrumbs"`
+	State       map[string]json.RawMessage `json:"state,omitempty"`
+}
+
+type collector struct {
+	mu       sync.Mutex
+	opts     Options
+	redact   []string
+	logs     []LogEntry
+	states   map[string]func() any
+	restore  func()
+	nextID   int
+	stateIDs map[string]int
+}
+
+var c = &collector{states: make(map[string]func() any), stateIDs: make(map[string]int)}
+
+// Init configures collection and starts capturing console output. Calling it
+// again replaces the options and keeps the captured logs.
+func Init(opts Options) {
+	if opts.MaxLogs <= 0 {
+		opts.MaxLogs = DefaultMaxLogs
+	}
+	c.mu.Lock()
+	c.opts = opts
+	c.redact = append([]string(nil), defaultRedactKeys...)
+	for _, k := range opts.Redact {
+		c.redact = append(c.redact, strings.ToLower(k))
+	}
+	c.mu.Unlock()
+
+	if c.restore == nil {
+		c.restore = captureConsole()
+	}
+}
+
+// Stop restores the original console methods
+func Stop() {
+	if c.restore != nil {
+		c.restore()
+		c.restore = nil
+	}
+}
+
+// Log records an entry without writing it to the console
+func Log(level, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	max := c.opts.MaxLogs
+	if max <= 0 {
+		max = DefaultMaxLogs
+	}
+	c.logs = append(c.logs, LogEntry{Time: time.Now(), Level: level, Message: bearerPattern.ReplaceAllString(message, "$1 "+Redacted)})
+	if len(c.logs) > max {
+		c.logs = append(c.logs[:0:0], c.logs[len(c.logs)-max:]...)
+	}
+}
+
+// RegisterState adds a named state snapshot to bundles. The snapshot is
+// marshaled to JSON when a bundle is collected and sensitive keys are
+// redacted. Returns a function that unregisters it.
+func RegisterState(name string, snapshot func() any) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	id := c.nextID
+	c.states[name] = snapshot
+	c.stateIDs[name] = id
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.stateIDs[name] == id {
+			delete(c.states, name)
+			delete(c.stateIDs, name)
+		}
+	}
+}
+
+// Collect gathers a bundle of the current app state
+func Collect() Bundle {
+	global := js.Global()
+	location := global.Get("location")
+
+	route := location.Get("pathname").String()
+	if router := components.GetGlobalRouter(); router != nil {
+		route = router.CurrentPath()
+		if route == "" {
+			route = router.Path() // before the first route resolves
+		}
+	}
+
+	c.mu.Lock()
+	logs := append([]LogEntry(nil), c.logs...)
+	version := c.opts.Version
+	redact := c.redact
+	if redact == nil {
+		redact = defaultRedactKeys
+	}
+	states := make(map[string]func() any, len(c.states))
+	for name, fn := range c.states {
+		states[name] = fn
+	}
+	c.mu.Unlock()
+
+	b := Bundle{
+		Version:     version,
+		Time:        time.Now(),
+		URL:         location.Get("origin").String() + location.Get("pathname").String(),
+		Route:       route,
+		UserAgent:   global.Get("navigator").Get("userAgent").String(),
+		Locale:      i18n.Locale(),
+		Viewport:    fmt.Sprintf("%dx%d", global.Get("innerWidth").Int(), global.Get("innerHeight").Int()),
+		Online:      global.Get("navigator").Get("onLine").Bool(),
+		Logs:        logs,
+		Breadcrumbs: trail.Snapshot(),
+	}
+
+	if info, ok := owner.ForRoute(route); ok {
+		b.Owner = &info
+	}
+
+	if len(states) > 0 {
+		b.State = make(map[string]json.RawMessage, len(states))
+		for name, fn := range states {
+			b.State[name] = sanitize(fn(), redact)
+		}
+	}
+	return b
+}
+
+// JSON encodes the bundle as indented JSON
+func (b Bundle) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// ZIP packs the bundle as diagnostics.json plus a plain-text logs.txt and
+// one file per state snapshot, which is easier to skim in an issue tracker
+func (b Bundle) ZIP() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	write := func(name string, data []byte) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	data, err := b.JSON()
+	if err != nil {
+		return nil, err
+	}
+	if err := write("diagnostics.json", data); err != nil {
+		return nil, err
+	}
+
+	var logs strings.Builder
+	for _, l := range b.Logs {
+		fmt.Fprintf(&logs, "%s [%s] %s\n", l.Time.Format(time.RFC3339Nano), l.Level, l.Message)
+	}
+	if err := write("logs.txt", []byte(logs.String())); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(b.State))
+	for name := range b.State {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, b.State[name], "", "  ") != nil {
+			pretty.Reset()
+			pretty.Write(b.State[name])
+		}
+		if err := write("state/"+name+".json", pretty.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Download saves the bundle through the browser's download prompt
+func Download(b Bundle, format Format) error {
+	name := "diagnostics-" + b.Time.Format("20060102-150405")
+	if format == FormatZIP {
+		data, err := b.ZIP()
+		if err != nil {
+			return err
+		}
+		components.DownloadFile(data, name+".zip", "application/zip")
+		return nil
+	}
+	data, err := b.JSON()
+	if err != nil {
+		return err
+	}
+	components.DownloadFile(data, name+".json", "application/json")
+	return nil
+}
+
+// sanitize marshals v and redacts values under sensitive keys
+func sanitize(v any, redact []string) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		out, _ := json.Marshal(map[string]string{"error": err.Error()})
+		return out
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return data
+	}
+	out, err := json.Marshal(redactValue(tree, redact))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func redactValue(v any, redact []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if isSensitive(k, redact) {
+				v[k] = Redacted
+			} else {
+				v[k] = redactValue(child, redact)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child, redact)
+		}
+	case string:
+		return bearerPattern.ReplaceAllString(v, "$1 "+Redacted)
+	}
+	return v
+}
+
+func isSensitive(key string, redact []string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range redact {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureConsole wraps the console methods so their output is also kept in
+// the log buffer. Returns a function that restores the originals.
+func captureConsole() func() {
+	console := js.Global().Get("console")
+	levels := []string{"debug", "log", "info", "warn", "error"}
+	originals := make(map[string]js.Value, len(levels))
+	wrappers := make([]js.Func, 0, len(levels))
+
+	for _, level := range levels {
+		original := console.Get(level)
+		if original.Type() != js.TypeFunction {
+			continue
+		}
+		originals[level] = original
+		level := level
+		wrapper := js.FuncOf(func(this js.Value, args []js.Value) any {
+			Log(level, formatArgs(args))
+			jsArgs := make([]any, len(args))
+			for i, a := range args {
+				jsArgs[i] = a
+			}
+			return original.Call("apply", console, js.ValueOf(jsArgs))
+		})
+		wrappers = append(wrappers, wrapper)
+		console.Set(level, wrapper)
+	}
+
+	return func() {
+		for level, original := range originals {
+			console.Set(level, original)
+		}
+		for _, w := range wrappers {
+			w.Release()
+		}
+	}
+}
+
+// formatArgs renders console arguments the way the console prints them
+func formatArgs(args []js.Value) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		switch a.Type() {
+		case js.TypeString:
+			parts[i] = a.String()
+		case js.TypeObject:
+			if a.InstanceOf(js.Global().Get("Error")) {
+				parts[i] = a.Get("name").String() + ": " + a.Get("message").String()
+				continue
+			}
+			parts[i] = stringify(a)
+		default:
+			parts[i] = js.Global().Get("String").Invoke(a).String()
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// stringify JSON-encodes a JS object, falling back to String() for values
+// that cannot be encoded, such as DOM nodes or cyclic objects
+func stringify(v js.Value) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = js.Global().Get("String").Invoke(v).String()
+		}
+	}()
+	out := js.Global().Get("JSON").Call("stringify", v)
+	if out.Type() != js.TypeString {
+		return js.Global().Get("String").Invoke(v).String()
+	}
+	return out.String()
+}
@@ -0,0 +1,130 @@
+//go:build js && wasm
+
+package diagnostics
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// ReportDialogProps configures a ReportDialog
+type ReportDialogProps struct {
+	Title string // default: "Report a problem"
+
+	// OnSubmit sends the bundle, e.g. to a support endpoint. When set, the
+	// dialog shows a Send button next to the download buttons.
+	OnSubmit func(Bundle) error
+}
+
+// ReportDialog is a prebuilt "Report a problem" dialog. It collects a bundle
+// when opened, lets the user describe what happened, and downloads the
+// bundle as JSON or ZIP (or sends it with OnSubmit).
+type ReportDialog struct {
+	modal       *components.Modal
+	props       ReportDialogProps
+	description *components.TextArea
+	bundle      Bundle
+}
+
+// NewReportDialog creates a report dialog. Mount Element() once, like any modal.
+func NewReportDialog(props ReportDialogProps) *ReportDialog {
+	if props.Title == "" {
+		props.Title = i18n.T("gux.diagnostics.title")
+	}
+
+	d := &ReportDialog{props: props}
+
+	d.description = components.NewTextArea(components.TextAreaProps{
+		Label:       i18n.T("gux.diagnostics.description"),
+		Placeholder: i18n.T("gux.diagnostics.placeholder"),
+		Rows:        4,
+	})
+
+	content := components.Div("",
+		components.TextWithClass(i18n.T("gux.diagnostics.intro"), "text-sm text-secondary mb-4"),
+		d.description.Element(),
+		components.TextWithClass(i18n.T("gux.diagnostics.included"), "text-xs text-tertiary"),
+	)
+
+	buttons := []js.Value{
+		components.SecondaryButton(i18n.T("gux.diagnostics.cancel"), d.Close),
+		components.SecondaryButton(i18n.T("gux.diagnostics.downloadJSON"), func() { d.download(FormatJSON) }),
+		components.SecondaryButton(i18n.T("gux.diagnostics.downloadZIP"), func() { d.download(FormatZIP) }),
+	}
+	if props.OnSubmit != nil {
+		buttons = append(buttons, components.PrimaryButton(i18n.T("gux.diagnostics.send"), d.submit))
+	}
+
+	d.modal = components.NewModal(components.ModalProps{
+		Title:      props.Title,
+		Content:    content,
+		Footer:     components.Div("flex flex-wrap justify-end gap-2", buttons...),
+		Width:      "lg",
+		CloseOnEsc: true,
+	})
+	return d
+}
+
+// Element returns the dialog DOM element
+func (d *ReportDialog) Element() js.Value {
+	return d.modal.Element()
+}
+
+// Open collects a fresh bundle and shows the dialog
+func (d *ReportDialog) Open() {
+	d.bundle = Collect()
+	d.description.SetValue("")
+	d.modal.Open()
+}
+
+// Close hides the dialog
+func (d *ReportDialog) Close() {
+	d.modal.Close()
+}
+
+// IsOpen returns whether the dialog is currently open
+func (d *ReportDialog) IsOpen() bool {
+	return d.modal.IsOpen()
+}
+
+// Command returns a command palette entry that opens the dialog
+func (d *ReportDialog) Command() components.Command {
+	return components.Command{
+		ID:          "gux.diagnostics.report",
+		Label:       d.props.Title,
+		Description: i18n.T("gux.diagnostics.commandDesc"),
+		Icon:        "🐞",
+		Category:    i18n.T("gux.diagnostics.category"),
+		OnExecute:   d.Open,
+	}
+}
+
+// current returns the bundle collected on Open with the user's description
+func (d *ReportDialog) current() Bundle {
+	b := d.bundle
+	if b.Time.IsZero() {
+		b = Collect()
+	}
+	b.Description = d.description.Value()
+	return b
+}
+
+func (d *ReportDialog) download(format Format) {
+	if err := Download(d.current(), format); err != nil {
+		components.ShowError(i18n.T("gux.diagnostics.failed", err.Error()))
+	}
+}
+
+func (d *ReportDialog) submit() {
+	b := d.current()
+	go func() {
+		if err := d.props.OnSubmit(b); err != nil {
+			components.ShowError(i18n.T("gux.diagnostics.failed", err.Error()))
+			return
+		}
+		d.Close()
+		components.ShowSuccess(i18n.T("gux.diagnostics.sent"))
+	}()
+}
@@ -0,0 +1,541 @@
+//go:build js && wasm
+
+// Package downloads saves files from API endpoints with the request's
+// headers, which window.open and plain links cannot send. The response is
+// fetched, streamed into a Blob with progress reported along the way, and
+// saved through a temporary link, so protected endpoints work with bearer
+// tokens.
+//
+//	downloads.Default().SetAuthProvider(func() string { return "Bearer " + auth.GetToken() })
+//
+//	d := downloads.Start(ctx, "/api/reports/42/export", nil)
+//
+// A Manager keeps the recent and in-progress downloads, which the
+// components.DownloadsTray lists with their progress and a cancel button.
+package downloads
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/internal/jsutil"
+	"github.com/dougbarrett/gux/trail"
+)
+
+// Status is the state of a download
+type Status int
+
+const (
+	// Downloading is receiving the response
+	Downloading Status = iota
+	// Done has been saved
+	Done
+	// Failed ended with an error
+	Failed
+	// Canceled was canceled by Cancel or its context
+	Canceled
+)
+
+func (s Status) String() string {
+	switch s {
+	case Done:
+		return "done"
+	case Failed:
+		return "failed"
+	case Canceled:
+		return "canceled"
+	}
+	return "downloading"
+}
+
+// Download is a snapshot of one download
+type Download struct {
+	ID       int
+	URL      string
+	Filename string // from Options, the Content-Disposition header, or the URL
+	Status   Status
+	Loaded   int64 // bytes received
+	Total    int64 // from Content-Length, or 0 if unknown
+	Err      error // set when Failed
+	Started  time.Time
+	Finished time.Time // zero while downloading
+}
+
+// Progress returns the fraction received, from 0 to 1, or -1 when the size
+// is unknown
+func (d Download) Progress() float64 {
+	if d.Status == Done {
+		return 1
+	}
+	if d.Total <= 0 {
+		return -1
+	}
+	return min(float64(d.Loaded)/float64(d.Total), 1)
+}
+
+// Active reports whether the download is in progress
+func (d Download) Active() bool {
+	return d.Status == Downloading
+}
+
+// StatusError is a non-2xx response to a download
+type StatusError struct {
+	Status     int
+	StatusText string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("download failed: %d %s", e.Status, e.StatusText)
+}
+
+// Options configures one download
+type Options struct {
+	// Filename overrides the name from the Content-Disposition header or
+	// the URL
+	Filename string
+
+	// Method is the HTTP method (default GET). Exports that take filters
+	// can POST them in Body.
+	Method string
+	Body   string
+
+	// Headers are added to the manager's headers
+	Headers map[string]string
+}
+
+// ManagerOptions configures a Manager
+type ManagerOptions struct {
+	// Headers are sent with every download
+	Headers map[string]string
+
+	// AuthProvider returns the Authorization header value. It is called for
+	// every download, so refreshed tokens are picked up.
+	AuthProvider func() string
+
+	// Credentials sends cookies on cross-origin requests
+	Credentials bool
+
+	// Keep is the number of finished downloads remembered (default 20)
+	Keep int
+}
+
+// Manager runs downloads and keeps the in-progress and recent ones
+type Manager struct {
+	mu          sync.Mutex
+	opts        ManagerOptions
+	downloads   []*entry // oldest first
+	nextID      int
+	subscribers map[int]func(Download)
+	nextSub     int
+}
+
+type entry struct {
+	Download
+	cancel   context.CancelFunc
+	done     chan struct{} // closed when the download ends
+	notified time.Time     // when progress was last reported
+}
+
+// NewManager creates a Manager
+func NewManager(opts ManagerOptions) *Manager {
+	if opts.Keep <= 0 {
+		opts.Keep = 20
+	}
+	return &Manager{opts: opts, nextID: 1, subscribers: make(map[int]func(Download))}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultManager *Manager
+)
+
+// Default returns the shared Manager used by Start
+func Default() *Manager {
+	defaultOnce.Do(func() {
+		defaultManager = NewManager(ManagerOptions{})
+	})
+	return defaultManager
+}
+
+// Start downloads url with the default Manager
+func Start(ctx context.Context, url string, opts *Options) Download {
+	return Default().Start(ctx, url, opts)
+}
+
+// SetAuthProvider sets the function that provides the Authorization header
+func (m *Manager) SetAuthProvider(provider func() string) {
+	m.mu.Lock()
+	m.opts.AuthProvider = provider
+	m.mu.Unlock()
+}
+
+// Start begins downloading url in the background and returns at once. The
+// file is saved when the response has arrived; follow progress with
+// Subscribe and stop the download with Cancel or by canceling ctx.
+func (m *Manager) Start(ctx context.Context, url string, opts *Options) Download {
+	_, d := m.start(ctx, url, opts)
+	return d
+}
+
+// Download downloads url and waits until it is saved, returning the error
+// of a failed or canceled download
+func (m *Manager) Download(ctx context.Context, url string, opts *Options) error {
+	e, _ := m.start(ctx, url, opts)
+	<-e.done
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch e.Status {
+	case Failed:
+		return e.Err
+	case Canceled:
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return context.Canceled
+	}
+	return nil
+}
+
+// start adds a download and runs it in the background, returning its entry
+// and first snapshot
+func (m *Manager) start(ctx context.Context, url string, opts *Options) (*entry, Download) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	m.mu.Lock()
+	e := &entry{
+		Download: Download{
+			ID:       m.nextID,
+			URL:      url,
+			Filename: opts.Filename,
+			Started:  time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if e.Filename == "" {
+		e.Filename = filenameFromURL(url)
+	}
+	m.nextID++
+	m.downloads = append(m.downloads, e)
+	m.trim()
+	snapshot := e.Download
+	m.mu.Unlock()
+	m.notify(snapshot)
+
+	go func() {
+		defer cancel()
+		err := m.run(ctx, e, opts)
+
+		m.mu.Lock()
+		e.Finished = time.Now()
+		switch {
+		case err == nil:
+			e.Status = Done
+		case ctx.Err() != nil:
+			e.Status = Canceled
+		default:
+			e.Status, e.Err = Failed, err
+		}
+		m.trim()
+		snapshot := e.Download
+		m.mu.Unlock()
+		m.notify(snapshot)
+		close(e.done)
+	}()
+	return e, snapshot
+}
+
+// run fetches the file, reporting progress, and saves it
+func (m *Manager) run(ctx context.Context, e *entry, opts *Options) error {
+	start := time.Now()
+	method := opts.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	m.mu.Lock()
+	managerOpts := m.opts
+	m.mu.Unlock()
+
+	headers := js.Global().Get("Object").New()
+	for k, v := range managerOpts.Headers {
+		headers.Set(k, v)
+	}
+	for k, v := range opts.Headers {
+		headers.Set(k, v)
+	}
+	if managerOpts.AuthProvider != nil {
+		if auth := managerOpts.AuthProvider(); auth != "" {
+			headers.Set("Authorization", auth)
+		}
+	}
+
+	controller := js.Global().Get("AbortController").New()
+	init := js.Global().Get("Object").New()
+	init.Set("method", method)
+	init.Set("headers", headers)
+	init.Set("signal", controller.Get("signal"))
+	if opts.Body != "" {
+		init.Set("body", opts.Body)
+	}
+	if managerOpts.Credentials {
+		init.Set("credentials", "include")
+	}
+	// Abort the request, and any read in progress, when ctx ends
+	stop := context.AfterFunc(ctx, func() { controller.Call("abort") })
+	defer stop()
+
+	resp, err := jsutil.Await(context.Background(), js.Global().Call("fetch", e.URL, init), downloadError)
+	if err != nil {
+		trail.API(method, e.URL, 0, time.Since(start))
+		return err
+	}
+	status := resp.Get("status").Int()
+	trail.API(method, e.URL, status, time.Since(start))
+	if !resp.Get("ok").Bool() {
+		statusErr := &StatusError{Status: status, StatusText: resp.Get("statusText").String()}
+		if body, err := jsutil.Await(context.Background(), resp.Call("text"), downloadError); err == nil && body.Type() == js.TypeString {
+			statusErr.Body = body.String()
+		}
+		return statusErr
+	}
+
+	respHeaders := resp.Get("headers")
+	m.mu.Lock()
+	if opts.Filename == "" {
+		if name := filenameFromDisposition(header(respHeaders, "Content-Disposition")); name != "" {
+			e.Filename = name
+		}
+	}
+	if n, err := strconv.ParseInt(header(respHeaders, "Content-Length"), 10, 64); err == nil {
+		e.Total = n
+	}
+	m.mu.Unlock()
+
+	// Keep the chunks in JS; copying them into Go would double the memory
+	chunks := js.Global().Get("Array").New()
+	if body := resp.Get("body"); body.Truthy() {
+		reader := body.Call("getReader")
+		for {
+			chunk, err := jsutil.Await(context.Background(), reader.Call("read"), downloadError)
+			if err != nil {
+				return err
+			}
+			if chunk.Get("done").Bool() {
+				break
+			}
+			value := chunk.Get("value")
+			chunks.Call("push", value)
+			m.progress(e, int64(value.Get("byteLength").Int()))
+		}
+	} else {
+		// No streaming support: read the body in one go
+		buf, err := jsutil.Await(context.Background(), resp.Call("arrayBuffer"), downloadError)
+		if err != nil {
+			return err
+		}
+		chunks.Call("push", buf)
+		m.progress(e, int64(buf.Get("byteLength").Int()))
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	blobOpts := js.Global().Get("Object").New()
+	blobOpts.Set("type", header(respHeaders, "Content-Type"))
+	m.mu.Lock()
+	filename := e.Filename
+	m.mu.Unlock()
+	save(js.Global().Get("Blob").New(chunks, blobOpts), filename)
+	return nil
+}
+
+// progress adds received bytes, telling subscribers at most every 100ms
+func (m *Manager) progress(e *entry, n int64) {
+	m.mu.Lock()
+	e.Loaded += n
+	now := time.Now()
+	report := now.Sub(e.notified) >= 100*time.Millisecond
+	if report {
+		e.notified = now
+	}
+	snapshot := e.Download
+	m.mu.Unlock()
+	if report {
+		m.notify(snapshot)
+	}
+}
+
+// save hands a Blob to the browser's download prompt through a temporary link
+func save(blob js.Value, filename string) {
+	document := js.Global().Get("document")
+	URL := js.Global().Get("URL")
+	objectURL := URL.Call("createObjectURL", blob)
+
+	anchor := document.Call("createElement", "a")
+	anchor.Set("href", objectURL)
+	anchor.Set("download", filename)
+	anchor.Get("style").Set("display", "none")
+	document.Get("body").Call("appendChild", anchor)
+	anchor.Call("click")
+	document.Get("body").Call("removeChild", anchor)
+
+	// Some browsers start reading the Blob after click returns
+	var revoke js.Func
+	revoke = js.FuncOf(func(this js.Value, args []js.Value) any {
+		URL.Call("revokeObjectURL", objectURL)
+		revoke.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", revoke, 1000)
+}
+
+// Cancel stops a download in progress
+func (m *Manager) Cancel(id int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.downloads {
+		if e.ID == id {
+			e.cancel()
+			return
+		}
+	}
+}
+
+// Remove forgets a finished download
+func (m *Manager) Remove(id int) {
+	m.mu.Lock()
+	for j, e := range m.downloads {
+		if e.ID == id && !e.Active() {
+			m.downloads = append(m.downloads[:j], m.downloads[j+1:]...)
+			break
+		}
+	}
+	m.mu.Unlock()
+	m.notify(Download{ID: id})
+}
+
+// Clear forgets every finished download
+func (m *Manager) Clear() {
+	m.mu.Lock()
+	kept := m.downloads[:0]
+	for _, e := range m.downloads {
+		if e.Active() {
+			kept = append(kept, e)
+		}
+	}
+	m.downloads = kept
+	m.mu.Unlock()
+	m.notify(Download{})
+}
+
+// Downloads returns the in-progress and recent downloads, newest first
+func (m *Manager) Downloads() []Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]Download, len(m.downloads))
+	for j, e := range m.downloads {
+		list[len(list)-1-j] = e.Download
+	}
+	return list
+}
+
+// Subscribe calls fn when a download starts, makes progress, or finishes,
+// and when downloads are removed. It returns a function that unsubscribes.
+func (m *Manager) Subscribe(fn func(Download)) func() {
+	m.mu.Lock()
+	id := m.nextSub
+	m.nextSub++
+	m.subscribers[id] = fn
+	m.mu.Unlock()
+	return func() {
+		m.mu.Lock()
+		delete(m.subscribers, id)
+		m.mu.Unlock()
+	}
+}
+
+func (m *Manager) notify(d Download) {
+	m.mu.Lock()
+	fns := make([]func(Download), 0, len(m.subscribers))
+	for _, fn := range m.subscribers {
+		fns = append(fns, fn)
+	}
+	m.mu.Unlock()
+	for _, fn := range fns {
+		fn(d)
+	}
+}
+
+// trim drops the oldest finished downloads beyond Keep. m.mu must be held.
+func (m *Manager) trim() {
+	finished := 0
+	for _, e := range m.downloads {
+		if !e.Active() {
+			finished++
+		}
+	}
+	kept := m.downloads[:0]
+	for _, e := range m.downloads {
+		if !e.Active() && finished > m.opts.Keep {
+			finished--
+			continue
+		}
+		kept = append(kept, e)
+	}
+	m.downloads = kept
+}
+
+func header(headers js.Value, name string) string {
+	v := headers.Call("get", name)
+	if v.IsNull() {
+		return ""
+	}
+	return v.String()
+}
+
+// filenameFromDisposition reads the filename from a Content-Disposition
+// header, preferring the UTF-8 filename* form
+func filenameFromDisposition(disposition string) string {
+	if disposition == "" {
+		return ""
+	}
+	// mime decodes filename* into filename
+	_, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return ""
+	}
+	return path.Base(strings.ReplaceAll(params["filename"], `\`, "/"))
+}
+
+// filenameFromURL uses the last path segment of a URL, or "download"
+func filenameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "download"
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" || name == "" {
+		return "download"
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return name
+}
+
+// downloadError is a rejected fetch's error: its message, or "download failed"
+var downloadError = jsutil.Message("download failed")
@@ -0,0 +1,323 @@
+package email
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Variant picks a button's or badge's colors from the theme
+type Variant string
+
+const (
+	Primary   Variant = "primary"
+	Secondary Variant = "secondary"
+	Success   Variant = "success"
+	Warning   Variant = "warning"
+	Error     Variant = "error"
+	Info      Variant = "info"
+)
+
+// colors returns the variant's background and text colors
+func (v Variant) colors(t Theme) (bg, fg string) {
+	c := t.Colors
+	switch v {
+	case Secondary:
+		return c.Secondary, c.SecondaryText
+	case Success:
+		return c.Success, "#ffffff"
+	case Warning:
+		return c.Warning, "#ffffff"
+	case Error:
+		return c.Error, "#ffffff"
+	case Info:
+		return c.Info, "#ffffff"
+	default:
+		return c.Primary, c.PrimaryText
+	}
+}
+
+// Text is text, escaped
+func Text(text string) Node {
+	return nodeFunc(func(w *writer) {
+		w.escape(text)
+	})
+}
+
+// Raw is trusted HTML, inserted as is. It is left out of the plain text.
+func Raw(html string) Node {
+	return nodeFunc(func(w *writer) {
+		if !w.plain {
+			w.raw(html)
+		}
+	})
+}
+
+// Bold is bold text
+func Bold(text string) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.raw(text)
+			return
+		}
+		w.open("strong", "font-weight:600;")
+		w.escape(text)
+		w.close("strong")
+	})
+}
+
+// Heading is a heading of level 1 to 3
+func Heading(level int, text string) Node {
+	level = min(max(level, 1), 3)
+	size := []int{24, 20, 17}[level-1]
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.raw(text + "\n")
+			if level == 1 {
+				w.raw(strings.Repeat("=", len([]rune(text))) + "\n")
+			}
+			w.block()
+			return
+		}
+		tag := "h" + strconv.Itoa(level)
+		w.open(tag, "margin:0 0 16px;"+w.font(size, size+8)+"font-weight:700;color:"+w.theme.Colors.Text+";")
+		w.escape(text)
+		w.close(tag)
+	})
+}
+
+// Paragraph is a paragraph of text and inline nodes such as Link and Bold
+func Paragraph(children ...Node) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.nodes(children)
+			w.block()
+			return
+		}
+		w.open("p", "margin:0 0 16px;"+w.font(16, 24)+"color:"+w.theme.Colors.Text+";")
+		w.nodes(children)
+		w.close("p")
+	})
+}
+
+// Muted is small, muted text, e.g. for notes under a button
+func Muted(text string) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.raw(text)
+			w.block()
+			return
+		}
+		w.open("p", "margin:0 0 16px;"+w.font(13, 20)+"color:"+w.theme.Colors.TextMuted+";")
+		w.escape(text)
+		w.close("p")
+	})
+}
+
+// Link is an inline link. The plain text shows the URL after the text.
+func Link(text, href string) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			if text == href {
+				w.raw(href)
+			} else {
+				w.raw(text + " (" + href + ")")
+			}
+			return
+		}
+		w.open("a", "color:"+w.theme.Colors.Primary+";text-decoration:underline;", "href", href)
+		w.escape(text)
+		w.close("a")
+	})
+}
+
+// ButtonProps configures a Button
+type ButtonProps struct {
+	Text    string
+	Href    string
+	Variant Variant // default Primary
+}
+
+// Button is a link styled as a button. The colored area is a table cell,
+// so the button keeps its shape in clients that ignore padding on links.
+func Button(props ButtonProps) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.raw(props.Text + ": " + props.Href)
+			w.block()
+			return
+		}
+		bg, fg := props.Variant.colors(w.theme)
+		radius := strconv.Itoa(w.theme.Radius) + "px"
+		w.table("margin:0 0 16px;")
+		w.raw("<tr>")
+		w.open("td", "background-color:"+bg+";border-radius:"+radius+";", "bgcolor", bg, "align", "center")
+		w.open("a", "display:inline-block;padding:12px 24px;"+w.font(16, 20)+"font-weight:600;color:"+fg+";text-decoration:none;border-radius:"+radius+";", "href", props.Href)
+		w.escape(props.Text)
+		w.raw("</a></td></tr></table>")
+	})
+}
+
+// Badge is a small colored label, e.g. an order's status
+func Badge(text string, variant Variant) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.raw("[" + text + "]")
+			return
+		}
+		bg, fg := variant.colors(w.theme)
+		w.open("span", "display:inline-block;padding:2px 8px;"+w.font(12, 16)+"font-weight:600;color:"+fg+";background-color:"+bg+";border-radius:9999px;")
+		w.escape(text)
+		w.close("span")
+	})
+}
+
+// TableColumn is a column of a Table
+type TableColumn struct {
+	Header string
+	Align  string // "left" (default), "center" or "right", e.g. for amounts
+}
+
+// TableProps configures a Table
+type TableProps struct {
+	Columns []TableColumn
+	Rows    [][]Node // each row's cells, in column order
+	Striped bool     // shade every other row
+}
+
+// Table is a data table, such as an order's line items. The plain text
+// puts each row's cells on a line, separated by " | ".
+func Table(props TableProps) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			var headers []string
+			for _, col := range props.Columns {
+				headers = append(headers, col.Header)
+			}
+			if strings.Join(headers, "") != "" {
+				w.raw(strings.Join(headers, " | ") + "\n")
+			}
+			for _, row := range props.Rows {
+				for i, cell := range row {
+					if i > 0 {
+						w.raw(" | ")
+					}
+					w.nodes([]Node{cell})
+				}
+				w.raw("\n")
+			}
+			w.block()
+			return
+		}
+		c := w.theme.Colors
+		align := func(i int) string {
+			if i < len(props.Columns) && props.Columns[i].Align != "" {
+				return props.Columns[i].Align
+			}
+			return "left"
+		}
+		w.table("width:100%;margin:0 0 16px;border-collapse:collapse;", "width", "100%")
+		hasHeader := false
+		for _, col := range props.Columns {
+			hasHeader = hasHeader || col.Header != ""
+		}
+		if hasHeader {
+			w.raw("<tr>")
+			for i, col := range props.Columns {
+				w.open("th", "padding:8px;"+w.font(13, 18)+"font-weight:600;color:"+c.TextMuted+";text-align:"+align(i)+";border-bottom:2px solid "+c.Border+";", "align", align(i))
+				w.escape(col.Header)
+				w.close("th")
+			}
+			w.raw("</tr>")
+		}
+		for r, row := range props.Rows {
+			bg := ""
+			if props.Striped && r%2 == 1 {
+				bg = "background-color:" + c.BackgroundAlt + ";"
+			}
+			w.raw("<tr>")
+			for i, cell := range row {
+				w.open("td", "padding:8px;"+w.font(14, 20)+"color:"+c.Text+";text-align:"+align(i)+";border-bottom:1px solid "+c.Border+";"+bg, "align", align(i))
+				w.nodes([]Node{cell})
+				w.close("td")
+			}
+			w.raw("</tr>")
+		}
+		w.raw("</table>")
+	})
+}
+
+// Card groups nodes on a shaded, bordered panel, e.g. a summary
+func Card(children ...Node) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.nodes(children)
+			w.block()
+			return
+		}
+		c := w.theme.Colors
+		w.table("width:100%;margin:0 0 16px;background-color:"+c.BackgroundAlt+";border:1px solid "+c.Border+";border-radius:"+strconv.Itoa(w.theme.Radius)+"px;", "width", "100%")
+		w.raw("<tr>")
+		w.open("td", "padding:16px;"+w.font(16, 24)+"color:"+c.Text+";")
+		w.nodes(children)
+		w.raw("</td></tr></table>")
+	})
+}
+
+// ImageProps configures an Image
+type ImageProps struct {
+	Src   string // an absolute URL; clients don't resolve relative ones
+	Alt   string
+	Width int // pixels; the image scales down on narrow screens
+}
+
+// Image is a block image, shown as its alt text in the plain text
+func Image(props ImageProps) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			if props.Alt != "" {
+				w.block()
+				w.raw(props.Alt)
+				w.block()
+			}
+			return
+		}
+		attrs := []string{"src", props.Src, "alt", props.Alt}
+		style := "display:block;border:0;max-width:100%;height:auto;margin:0 0 16px;"
+		if props.Width > 0 {
+			attrs = append(attrs, "width", strconv.Itoa(props.Width))
+		}
+		w.open("img", style, attrs...)
+	})
+}
+
+// Divider is a horizontal rule
+func Divider() Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			w.raw(strings.Repeat("-", 40))
+			w.block()
+			return
+		}
+		w.open("hr", "border:0;border-top:1px solid "+w.theme.Colors.Border+";margin:24px 0;")
+	})
+}
+
+// Spacer is vertical space of height pixels
+func Spacer(height int) Node {
+	return nodeFunc(func(w *writer) {
+		if w.plain {
+			w.block()
+			return
+		}
+		h := strconv.Itoa(height)
+		w.open("div", "height:"+h+"px;line-height:"+h+"px;font-size:1px;")
+		w.raw("&nbsp;</div>")
+	})
+}
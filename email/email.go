@@ -0,0 +1,209 @@
+// Package email renders transactional emails from Go, with components like
+// the UI's (buttons, badges, tables) styled from the same theme colors.
+// Email clients ignore stylesheets and most modern CSS, so everything is
+// rendered as tables with inline styles, which they all display alike.
+//
+//	msg := email.Document{
+//		Title:     "Your order has shipped",
+//		Preheader: "Order #1042 is on its way",
+//		Body: []email.Node{
+//			email.Heading(1, "Your order has shipped"),
+//			email.Paragraph(email.Text("Order #1042 is on its way.")),
+//			email.Button(email.ButtonProps{Text: "Track package", Href: trackURL}),
+//		},
+//	}
+//	html, text := msg.HTML(), msg.Text()
+package email
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/dougbarrett/gux/components"
+)
+
+// Theme styles an email
+type Theme struct {
+	// Colors are the theme's colors (default components.DefaultLightColors,
+	// since most email clients show light backgrounds)
+	Colors components.ThemeTokens
+
+	FontFamily string // default Colors.FontFamily, or a system font stack
+	Width      int    // content width in pixels, default 600
+	Radius     int    // corner radius of buttons and cards in pixels, default 6
+}
+
+// DefaultTheme returns the default theme
+func DefaultTheme() Theme {
+	return Theme{}.withDefaults()
+}
+
+func (t Theme) withDefaults() Theme {
+	if t.Colors == (components.ThemeTokens{}) {
+		t.Colors = components.DefaultLightColors
+	}
+	if t.FontFamily == "" {
+		t.FontFamily = t.Colors.FontFamily
+	}
+	if t.FontFamily == "" {
+		t.FontFamily = "-apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Helvetica, Arial, sans-serif"
+	}
+	if t.Width == 0 {
+		t.Width = 600
+	}
+	if t.Radius == 0 {
+		t.Radius = 6
+	}
+	return t
+}
+
+// Document is an email's content and the frame around it
+type Document struct {
+	Title     string // the HTML title, usually the subject
+	Preheader string // preview shown after the subject in most inboxes
+	Lang      string // default "en"
+	Theme     Theme
+	Body      []Node
+	Footer    []Node // small print below the content, e.g. an unsubscribe link
+}
+
+// HTML renders the document as a complete HTML email
+func (d Document) HTML() string {
+	w := &writer{theme: d.Theme.withDefaults()}
+	c := w.theme.Colors
+	lang := d.Lang
+	if lang == "" {
+		lang = "en"
+	}
+
+	w.raw(`<!DOCTYPE html><html lang="` + attr(lang) + `"><head>`)
+	w.raw(`<meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">`)
+	w.raw(`<meta name="x-apple-disable-message-reformatting"><title>`)
+	w.escape(d.Title)
+	w.raw(`</title></head>`)
+	w.open("body", "margin:0;padding:0;background-color:"+c.BackgroundAlt+";")
+	if d.Preheader != "" {
+		w.open("div", "display:none;max-height:0;overflow:hidden;opacity:0;")
+		w.escape(d.Preheader)
+		w.close("div")
+	}
+
+	w.table("width:100%;background-color:"+c.BackgroundAlt+";", "width", "100%")
+	w.raw("<tr>")
+	w.open("td", "padding:24px 12px;", "align", "center")
+
+	width := strconv.Itoa(w.theme.Width)
+	w.table("width:100%;max-width:"+width+"px;background-color:"+c.Background+";border:1px solid "+c.Border+";border-radius:"+strconv.Itoa(w.theme.Radius)+"px;", "width", width)
+	w.raw("<tr>")
+	w.open("td", "padding:32px;"+w.font(16, 24)+"color:"+c.Text+";")
+	w.nodes(d.Body)
+	w.raw("</td></tr></table>")
+
+	if len(d.Footer) > 0 {
+		w.table("width:100%;max-width:"+width+"px;", "width", width)
+		w.raw("<tr>")
+		w.open("td", "padding:16px 32px;"+w.font(12, 18)+"color:"+c.TextMuted+";text-align:center;", "align", "center")
+		w.nodes(d.Footer)
+		w.raw("</td></tr></table>")
+	}
+
+	w.raw("</td></tr></table></body></html>")
+	return w.b.String()
+}
+
+// Text renders the document as plain text, for the text/plain part sent
+// alongside the HTML
+func (d Document) Text() string {
+	w := &writer{theme: d.Theme.withDefaults(), plain: true}
+	w.nodes(d.Body)
+	if len(d.Footer) > 0 {
+		w.block()
+		w.raw("--\n")
+		w.nodes(d.Footer)
+	}
+	return strings.TrimSpace(w.b.String()) + "\n"
+}
+
+// Node is part of an email
+type Node interface {
+	render(w *writer)
+}
+
+// nodeFunc renders a Node
+type nodeFunc func(w *writer)
+
+func (f nodeFunc) render(w *writer) { f(w) }
+
+// writer accumulates a document's HTML, or its plain text when plain is set
+type writer struct {
+	b     strings.Builder
+	theme Theme
+	plain bool
+}
+
+func (w *writer) raw(s string) {
+	w.b.WriteString(s)
+}
+
+func (w *writer) escape(s string) {
+	if w.plain {
+		w.b.WriteString(s)
+		return
+	}
+	w.b.WriteString(html.EscapeString(s))
+}
+
+// open writes a start tag with an inline style and attributes given as
+// name, value pairs
+func (w *writer) open(tag, style string, attrs ...string) {
+	w.b.WriteString("<" + tag)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		w.b.WriteString(" " + attrs[i] + `="` + attr(attrs[i+1]) + `"`)
+	}
+	if style != "" {
+		w.b.WriteString(` style="` + attr(style) + `"`)
+	}
+	w.b.WriteString(">")
+}
+
+func (w *writer) close(tag string) {
+	w.b.WriteString("</" + tag + ">")
+}
+
+// table opens a layout table, which email clients render consistently
+// where they don't support divs with widths or flexbox
+func (w *writer) table(style string, attrs ...string) {
+	w.open("table", style, append([]string{"role", "presentation", "cellpadding", "0", "cellspacing", "0", "border", "0"}, attrs...)...)
+}
+
+func (w *writer) nodes(nodes []Node) {
+	for _, n := range nodes {
+		if n != nil {
+			n.render(w)
+		}
+	}
+}
+
+// block ends the plain text's current line with a blank line, unless it
+// already ends with one
+func (w *writer) block() {
+	s := w.b.String()
+	switch {
+	case s == "" || strings.HasSuffix(s, "\n\n"):
+	case strings.HasSuffix(s, "\n"):
+		w.b.WriteString("\n")
+	default:
+		w.b.WriteString("\n\n")
+	}
+}
+
+// font returns the theme's font family with a size and line height
+func (w *writer) font(size, lineHeight int) string {
+	return "font-family:" + w.theme.FontFamily + ";font-size:" + strconv.Itoa(size) + "px;line-height:" + strconv.Itoa(lineHeight) + "px;"
+}
+
+// attr escapes an attribute value
+func attr(s string) string {
+	return html.EscapeString(s)
+}
@@ -4,12 +4,125 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	gqapi "github.com/dougbarrett/gux/api"
 	"github.com/dougbarrett/gux/fetch"
+	"github.com/dougbarrett/gux/fetch/sse"
 )
 
+// APIError is a non-2xx response. Code, Message, and Fields come from the
+// JSON error body written by the server; use AsAPIError or errors.As to get
+// at them. The prefix keeps it clear of an Error type of your own.
+// Messages the server sent with i18n keys are translated in the current
+// locale.
+type APIError struct {
+	Status    int                      // HTTP status code
+	Code      string                   // machine-readable code, e.g. "not_found"
+	Message   string                   // human-readable message
+	Fields    map[string]string        // validation messages keyed by field name
+	FieldKeys map[string]gqapi.Message // i18n keys of Fields, if the server sent them
+	RequestID string                   // server's request ID, to find its logs
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Reference returns the request ID, which components.ShowErrorToast shows
+// for the user to quote to support
+func (e *APIError) Reference() string {
+	return e.RequestID
+}
+
+// ServerError reports whether the server failed (5xx), in which case its
+// message isn't meant for users
+func (e *APIError) ServerError() bool {
+	return e.Status >= 500
+}
+
+// Field returns the validation message for a field, or "". Keyed messages
+// are translated in the locale current when it is called.
+func (e *APIError) Field(name string) string {
+	if msg, ok := e.FieldKeys[name]; ok {
+		return msg.String()
+	}
+	return e.Fields[name]
+}
+
+// Unavailable reports whether the server was down for maintenance (503) or
+// rate limiting the client (429). A components.ServiceBanner already tells
+// the user, so callers can skip their own error message.
+func (e *APIError) Unavailable() bool {
+	return e.Status == 503 || e.Status == 429
+}
+
+// AsAPIError returns the API error in err's chain, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// errorBody relies on encoding/json's case-insensitive field matching
+type errorBody struct {
+	Code      string
+	Message   string
+	Key       string
+	Params    []any
+	Fields    map[string]string
+	FieldKeys map[string]gqapi.Message
+	RequestID string
+}
+
+// responseError builds an APIError from a non-2xx response. It reads the
+// {"error": {...}} body written by gux servers and falls back to a flat
+// {"code": ..., "message": ...} body.
+func responseError(resp *fetch.Response) error {
+	var body errorBody
+	var envelope struct {
+		Error *errorBody
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &envelope); err == nil && envelope.Error != nil {
+		body = *envelope.Error
+	} else {
+		json.Unmarshal([]byte(resp.Body), &body)
+	}
+
+	apiErr := &APIError{
+		Status:    resp.Status,
+		Code:      body.Code,
+		Message:   body.Message,
+		Fields:    body.Fields,
+		FieldKeys: body.FieldKeys,
+		RequestID: body.RequestID,
+	}
+	// Translate keyed messages through the client's i18n catalogs, so one
+	// server serves every locale
+	if body.Key != "" {
+		apiErr.Message = gqapi.Msg(body.Key, body.Params...).String()
+	}
+	for field, msg := range body.FieldKeys {
+		if apiErr.Fields == nil {
+			apiErr.Fields = make(map[string]string)
+		}
+		apiErr.Fields[field] = msg.String()
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header("X-Request-ID")
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected status %d: %s", resp.Status, resp.StatusText)
+	}
+	return apiErr
+}
+
 // ClientOption configures a client
 type ClientOption func(*clientConfig)
 
@@ -18,6 +131,9 @@ type clientConfig struct {
 	basePath     string
 	headers      map[string]string
 	authProvider func() string
+	timeout      time.Duration
+	retries      int
+	dedupe       *requestGroup
 }
 
 // WithBaseURL sets the base URL for API calls (e.g., "https://api.example.com")
@@ -53,10 +169,148 @@ func WithAuthProvider(provider func() string) ClientOption {
 	}
 }
 
-func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, error) {
+// WithTimeout aborts requests that take longer than d. Per-call deadlines
+// can also be set on the context passed to each method.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = d
+	}
+}
+
+// WithRetries retries requests that fail with a network error, 429, or 5xx
+// up to n times, with exponential backoff. POST and PATCH are only retried
+// when the context carries an idempotency key (see fetch.WithIdempotencyKey).
+func WithRetries(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.retries = n
+	}
+}
+
+// WithDedupe makes identical GET requests share one network request: a
+// call made while the same request is in flight waits for it and gets the
+// same result, so several components loading the same data at once cost
+// one fetch. With a window above zero, a response is also reused for that
+// long after it arrives, which covers calls made one after another in a
+// render pass. Any other request through the client clears reused
+// responses, so reads after a write see it.
+func WithDedupe(window time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.dedupe = &requestGroup{window: window, calls: make(map[string]*sharedCall)}
+	}
+}
+
+type noDedupeKey struct{}
+
+// NoDedupe returns a context whose calls always make their own request,
+// on clients created WithDedupe
+func NoDedupe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDedupeKey{}, true)
+}
+
+// requestGroup shares GET responses between callers
+type requestGroup struct {
+	mu     sync.Mutex
+	window time.Duration
+	calls  map[string]*sharedCall
+}
+
+type sharedCall struct {
+	done     chan struct{}
+	resp     *fetch.Response
+	err      error
+	finished time.Time
+}
+
+// do returns the response of the call for key, making it with fn unless it
+// is in flight or finished within the window
+func (g *requestGroup) do(ctx context.Context, key string, fn func() (*fetch.Response, error)) (*fetch.Response, error) {
+	g.mu.Lock()
+	now := time.Now()
+	for k, c := range g.calls {
+		if !c.finished.IsZero() && now.Sub(c.finished) > g.window {
+			delete(g.calls, k)
+		}
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &sharedCall{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			c.resp, c.err = fn()
+			g.mu.Lock()
+			c.finished = time.Now()
+			// Failures go to the callers waiting now but are not reused
+			if (g.window == 0 || c.err != nil || !c.resp.OK) && g.calls[key] == c {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.resp, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forget drops reusable responses and keeps new calls from joining ones in flight
+func (g *requestGroup) forget() {
+	g.mu.Lock()
+	g.calls = make(map[string]*sharedCall)
+	g.mu.Unlock()
+}
+
+// send performs a request, sharing identical GETs on clients created WithDedupe
+func send(ctx context.Context, cfg *clientConfig, url string, opts *fetch.Options) (*fetch.Response, error) {
+	if cfg.dedupe == nil {
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	if opts.Method != "GET" {
+		defer cfg.dedupe.forget()
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	if skip, _ := ctx.Value(noDedupeKey{}).(bool); skip {
+		return fetch.FetchContext(ctx, url, opts)
+	}
+	key := url + "\n" + opts.Headers["Authorization"]
+	return cfg.dedupe.do(ctx, key, func() (*fetch.Response, error) {
+		// The request is shared, so one caller canceling must not fail the
+		// others; each caller still stops waiting when its own ctx is done
+		return fetch.FetchContext(context.WithoutCancel(ctx), url, opts)
+	})
+}
+
+type includeDeletedKey struct{}
+
+// IncludeDeleted returns a context that makes calls to APIs marked
+// @softdelete return soft-deleted records too (?include_deleted=true)
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// requestURL joins the base URL, base path, and path, adding the
+// include_deleted parameter when ctx asks for it. The base URL of the
+// environment set with fetch.UseEnvironment replaces the client's own.
+func requestURL(ctx context.Context, cfg *clientConfig, path string) string {
+	url := fetch.EnvironmentBaseURL(cfg.baseURL) + cfg.basePath + path
+	if include, _ := ctx.Value(includeDeletedKey{}).(bool); include {
+		if strings.Contains(url, "?") {
+			url += "&include_deleted=true"
+		} else {
+			url += "?include_deleted=true"
+		}
+	}
+	return url
+}
+
+func doRequest[T any](ctx context.Context, cfg *clientConfig, method, path string, body any) (T, error) {
 	var result T
 
-	url := cfg.baseURL + cfg.basePath + path
+	url := requestURL(ctx, cfg, path)
 
 	var bodyStr string
 	if body != nil {
@@ -80,17 +334,19 @@ func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, erro
 		headers["Content-Type"] = "application/json"
 	}
 
-	resp, err := fetch.Fetch(url, &fetch.Options{
+	resp, err := send(ctx, cfg, url, &fetch.Options{
 		Method:  method,
 		Headers: headers,
 		Body:    bodyStr,
+		Timeout: cfg.timeout,
+		Retries: cfg.retries,
 	})
 	if err != nil {
 		return result, fmt.Errorf("fetch failed: %w", err)
 	}
 
 	if !resp.OK {
-		return result, fmt.Errorf("unexpected status %d: %s", resp.Status, resp.StatusText)
+		return result, responseError(resp)
 	}
 
 	// For DELETE or no-content responses
@@ -105,8 +361,8 @@ func doRequest[T any](cfg *clientConfig, method, path string, body any) (T, erro
 	return result, nil
 }
 
-func doRequestNoResponse(cfg *clientConfig, method, path string) error {
-	url := cfg.baseURL + cfg.basePath + path
+func doRequestNoResponse(ctx context.Context, cfg *clientConfig, method, path string) error {
+	url := requestURL(ctx, cfg, path)
 
 	headers := make(map[string]string)
 	for k, v := range cfg.headers {
@@ -118,17 +374,64 @@ func doRequestNoResponse(cfg *clientConfig, method, path string) error {
 		}
 	}
 
-	resp, err := fetch.Fetch(url, &fetch.Options{
+	resp, err := send(ctx, cfg, url, &fetch.Options{
 		Method:  method,
 		Headers: headers,
+		Timeout: cfg.timeout,
+		Retries: cfg.retries,
 	})
 	if err != nil {
 		return fmt.Errorf("fetch failed: %w", err)
 	}
 
 	if !resp.OK {
-		return fmt.Errorf("unexpected status %d: %s", resp.Status, resp.StatusText)
+		return responseError(resp)
 	}
 
 	return nil
 }
+
+// doStream opens a Server-Sent Events stream and decodes the data of each
+// event as JSON. Dropped connections are reopened with Last-Event-ID; the
+// channel is closed when ctx is canceled or the server sends an "end" event
+// because the stream is finished.
+func doStream[T any](ctx context.Context, cfg *clientConfig, path string) (<-chan T, error) {
+	url := requestURL(ctx, cfg, path)
+
+	stream, err := sse.Connect(ctx, url, &sse.Options{
+		Headers:      cfg.headers,
+		AuthProvider: cfg.authProvider,
+	})
+	if err != nil {
+		var statusErr *sse.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, responseError(&fetch.Response{
+				Status:     statusErr.Status,
+				StatusText: statusErr.StatusText,
+				Body:       statusErr.Body,
+			})
+		}
+		return nil, fmt.Errorf("stream failed: %w", err)
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+		for ev := range stream.Events() {
+			if ev.Event == "end" {
+				return
+			}
+			var v T
+			if err := json.Unmarshal([]byte(ev.Data), &v); err != nil {
+				continue
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
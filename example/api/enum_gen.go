@@ -0,0 +1,84 @@
+// Code generated by gux. DO NOT EDIT.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// PostStatusValues lists every PostStatus in declaration order
+var PostStatusValues = []PostStatus{PostDraft, PostPublished, PostArchived}
+
+// ParsePostStatus returns the PostStatus for s, or an error if s is not one
+func ParsePostStatus(s string) (PostStatus, error) {
+	if e := PostStatus(s); e.Valid() {
+		return e, nil
+	}
+	return "", fmt.Errorf("invalid PostStatus %q (use draft, published, or archived)", s)
+}
+
+// Valid reports whether e is one of the declared values
+func (e PostStatus) Valid() bool {
+	switch e {
+	case PostDraft, PostPublished, PostArchived:
+		return true
+	}
+	return false
+}
+
+// String returns the wire value
+func (e PostStatus) String() string {
+	return string(e)
+}
+
+// Label returns the display name of e: the "enum.PostStatus.<value>" translation
+// when there is one, or the comment on its const
+func (e PostStatus) Label() string {
+	key := "enum.PostStatus." + string(e)
+	if label := i18n.T(key); label != key {
+		return label
+	}
+	switch e {
+	case PostDraft:
+		return "Draft"
+	case PostPublished:
+		return "Published"
+	case PostArchived:
+		return "Archived"
+	}
+	return string(e)
+}
+
+// Color returns the badge variant of e from its @color: "primary",
+// "success", "warning", "error", "info", or "" for the default
+func (e PostStatus) Color() string {
+	switch e {
+	case PostDraft:
+		return "warning"
+	case PostPublished:
+		return "success"
+	}
+	return ""
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (e PostStatus) MarshalText() ([]byte, error) {
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so JSON bodies and
+// query parameters with unknown values are rejected. "" is the zero value.
+func (e *PostStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*e = ""
+		return nil
+	}
+	v, err := ParsePostStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
@@ -0,0 +1,37 @@
+// Code generated by gux. DO NOT EDIT.
+//go:build js && wasm
+
+package api
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+)
+
+// PostStatusOptions returns every PostStatus as a SelectOption with its label, for
+// Select components and FormBuilder select and radio fields
+func PostStatusOptions() []components.SelectOption {
+	options := make([]components.SelectOption, len(PostStatusValues))
+	for i, e := range PostStatusValues {
+		options[i] = components.SelectOption{Label: e.Label(), Value: string(e)}
+	}
+	return options
+}
+
+// PostStatusBadge renders a PostStatus cell as a Badge in its color, for
+// TableColumn.Render. The value may be a PostStatus or a string.
+func PostStatusBadge(row map[string]any, value any) js.Value {
+	var e PostStatus
+	switch v := value.(type) {
+	case PostStatus:
+		e = v
+	case string:
+		e = PostStatus(v)
+	}
+	return components.Badge(components.BadgeProps{
+		Text:    e.Label(),
+		Variant: components.BadgeVariant(e.Color()),
+		Rounded: true,
+	})
+}
@@ -1,6 +1,11 @@
 package api
 
-import "context"
+import (
+	"context"
+
+	gqapi "github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/filter"
+)
 
 //go:generate go run gux/cmd/apigen -source=posts.go -output=posts_client_gen.go
 
@@ -12,6 +17,18 @@ type PostsAPI interface {
 	// @route GET /
 	GetAll(ctx context.Context) ([]Post, error)
 
+	// List returns a page of posts, newest first. Pass the previous page's
+	// NextCursor to get the next one.
+	// @route GET /page
+	// @query cursor, limit=20
+	List(ctx context.Context, cursor string, limit int) (gqapi.Page[Post], error)
+
+	// Search returns the posts matching a filter such as
+	// `title contains wasm and userId eq 1`
+	// @route GET /search
+	// @filter userId, title, body
+	Search(ctx context.Context, where filter.Filter) ([]Post, error)
+
 	// GetByID returns a single post by ID
 	// @route GET /{id}
 	GetByID(ctx context.Context, id int) (*Post, error)
@@ -3,8 +3,16 @@
 
 package api
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
 
+	gqapi "github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/filter"
+)
 
 // PostsClient is a client for PostsAPI
 type PostsClient struct {
@@ -23,42 +31,78 @@ func NewPostsClient(opts ...ClientOption) *PostsClient {
 	return &PostsClient{cfg: cfg}
 }
 
-
 // GetAll fetches data via GET /api/posts/
-func (c *PostsClient) GetAll() ([]Post, error) {
-	return doRequest[[]Post](c.cfg, "GET", "/", nil)
+func (c *PostsClient) GetAll(ctx context.Context) ([]Post, error) {
+	return doRequest[[]Post](ctx, c.cfg, "GET", "/", nil)
+}
+
+// List fetches data via GET /api/posts/page
+func (c *PostsClient) List(ctx context.Context, cursor string, limit int) (gqapi.Page[Post], error) {
+	gqPath := "/page"
+	gqQuery := url.Values{}
+	if cursor != "" {
+		gqQuery.Set("cursor", cursor)
+	}
+	if limit != 0 {
+		gqQuery.Set("limit", strconv.Itoa(limit))
+	}
+	if len(gqQuery) > 0 {
+		gqPath += "?" + gqQuery.Encode()
+	}
+
+	return doRequest[gqapi.Page[Post]](ctx, c.cfg, "GET", gqPath, nil)
+}
+
+// ListPages iterates over every page of List, following NextCursor
+// from the first page until the last, an error, or a break
+func (c *PostsClient) ListPages(ctx context.Context, limit int) iter.Seq2[gqapi.Page[Post], error] {
+	return gqapi.Pages(ctx, func(ctx context.Context, cursor string) (gqapi.Page[Post], error) {
+		return c.List(ctx, cursor, limit)
+	})
+}
+
+// Search fetches data via GET /api/posts/search
+func (c *PostsClient) Search(ctx context.Context, where filter.Filter) ([]Post, error) {
+	gqPath := "/search"
+	gqQuery := url.Values{}
+	if !where.IsZero() {
+		gqQuery.Set("where", where.String())
+	}
+	if len(gqQuery) > 0 {
+		gqPath += "?" + gqQuery.Encode()
+	}
+
+	return doRequest[[]Post](ctx, c.cfg, "GET", gqPath, nil)
 }
 
 // GetByID fetches data via GET /api/posts/{id}
-func (c *PostsClient) GetByID(id int) (*Post, error) {
-	result, err := doRequest[Post](c.cfg, "GET", fmt.Sprintf("/%d", id), nil)
-	if err != nil {
-		return nil, err
+func (c *PostsClient) GetByID(ctx context.Context, id int) (*Post, error) {
+	gqResult, gqErr := doRequest[Post](ctx, c.cfg, "GET", fmt.Sprintf("/%d", id), nil)
+	if gqErr != nil {
+		return nil, gqErr
 	}
-	return &result, nil
+	return &gqResult, nil
 }
 
 // Create creates data via POST /api/posts/
-func (c *PostsClient) Create(req CreatePostRequest) (*Post, error) {
-	result, err := doRequest[Post](c.cfg, "POST", "/", req)
-	if err != nil {
-		return nil, err
+func (c *PostsClient) Create(ctx context.Context, req CreatePostRequest) (*Post, error) {
+	gqResult, gqErr := doRequest[Post](ctx, c.cfg, "POST", "/", req)
+	if gqErr != nil {
+		return nil, gqErr
 	}
-	return &result, nil
+	return &gqResult, nil
 }
 
 // Update updates data via PUT /api/posts/{id}
-func (c *PostsClient) Update(id int, req CreatePostRequest) (*Post, error) {
-	result, err := doRequest[Post](c.cfg, "PUT", fmt.Sprintf("/%d", id), req)
-	if err != nil {
-		return nil, err
+func (c *PostsClient) Update(ctx context.Context, id int, req CreatePostRequest) (*Post, error) {
+	gqResult, gqErr := doRequest[Post](ctx, c.cfg, "PUT", fmt.Sprintf("/%d", id), req)
+	if gqErr != nil {
+		return nil, gqErr
 	}
-	return &result, nil
+	return &gqResult, nil
 }
 
 // Delete deletes data via DELETE /api/posts/{id}
-func (c *PostsClient) Delete(id int) error {
-	return doRequestNoResponse(c.cfg, "DELETE", fmt.Sprintf("/%d", id))
+func (c *PostsClient) Delete(ctx context.Context, id int) error {
+	return doRequestNoResponse(ctx, c.cfg, "DELETE", fmt.Sprintf("/%d", id))
 }
-
-
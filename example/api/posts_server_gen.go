@@ -9,9 +9,10 @@ import (
 	"strings"
 
 	gqapi "github.com/dougbarrett/gux/api"
+	"github.com/dougbarrett/gux/filter"
+	gqserver "github.com/dougbarrett/gux/server"
 )
 
-
 // PostsAPIHandler wraps a PostsAPI implementation with HTTP handlers
 type PostsAPIHandler struct {
 	service    PostsAPI
@@ -28,114 +29,172 @@ func (h *PostsAPIHandler) Use(mw ...func(http.Handler) http.Handler) {
 	h.middleware = append(h.middleware, mw...)
 }
 
-// wrap applies middleware chain to a handler
-func (h *PostsAPIHandler) wrap(handler http.HandlerFunc) http.Handler {
+// wrap applies middleware chain to a handler, and records its route for
+// server.Otel
+func (h *PostsAPIHandler) wrap(route string, handler http.HandlerFunc) http.Handler {
 	var result http.Handler = handler
 	for i := len(h.middleware) - 1; i >= 0; i-- {
 		result = h.middleware[i](result)
 	}
-	return result
+	return gqserver.WithRoute(route, result)
 }
 
 // RegisterRoutes registers all routes for PostsAPI
 func (h *PostsAPIHandler) RegisterRoutes(mux *http.ServeMux) {
-	mux.Handle("GET /api/posts/", h.wrap(h.handleGetAll))
-	mux.Handle("GET /api/posts/{id}", h.wrap(h.handleGetByID))
-	mux.Handle("POST /api/posts/", h.wrap(h.handleCreate))
-	mux.Handle("PUT /api/posts/{id}", h.wrap(h.handleUpdate))
-	mux.Handle("DELETE /api/posts/{id}", h.wrap(h.handleDelete))
+	mux.Handle("GET /api/posts/", h.wrap("/api/posts/", h.handleGetAll))
+	mux.Handle("GET /api/posts/page", h.wrap("/api/posts/page", h.handleList))
+	mux.Handle("GET /api/posts/search", h.wrap("/api/posts/search", h.handleSearch))
+	mux.Handle("GET /api/posts/{id}", h.wrap("/api/posts/{id}", h.handleGetByID))
+	mux.Handle("POST /api/posts/", h.wrap("/api/posts/", h.handleCreate))
+	mux.Handle("PUT /api/posts/{id}", h.wrap("/api/posts/{id}", h.handleUpdate))
+	mux.Handle("DELETE /api/posts/{id}", h.wrap("/api/posts/{id}", h.handleDelete))
 }
 
-
 func (h *PostsAPIHandler) handleGetAll(w http.ResponseWriter, r *http.Request) {
 
-	result, err := h.service.GetAll(r.Context())
-	if err != nil {
-		gqapi.WriteError(w, err)
+	gqResult, gqErr := h.service.GetAll(r.Context())
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(gqResult)
+}
+
+func (h *PostsAPIHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	gqQuery := r.URL.Query()
+	var cursor string
+	if gqV := gqQuery.Get("cursor"); gqV != "" {
+		cursor = gqV
+	}
+	limit := 20
+	if gqV := gqQuery.Get("limit"); gqV != "" {
+		gqParsed, gqErr := strconv.Atoi(gqV)
+		if gqErr != nil {
+			gqapi.WriteError(w, gqapi.BadRequest("invalid limit: must be an integer"))
+			return
+		}
+		limit = gqParsed
+	}
+
+	gqResult, gqErr := h.service.List(r.Context(), cursor, limit)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(gqResult)
+}
+
+func (h *PostsAPIHandler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	gqQuery := r.URL.Query()
+	var where filter.Filter
+	if gqV := gqQuery.Get("where"); gqV != "" {
+		gqParsed, gqErr := filter.Parse(gqV, "userId", "title", "body")
+		if gqErr != nil {
+			gqapi.WriteError(w, gqapi.BadRequest("invalid where: "+gqErr.Error()))
+			return
+		}
+		where = gqParsed
+	}
+
+	gqResult, gqErr := h.service.Search(r.Context(), where)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(gqResult)
 }
 
 func (h *PostsAPIHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
 	// Extract path parameters
-	path := strings.TrimPrefix(r.URL.Path, "/api/posts")
-	parts := strings.Split(strings.Trim(path, "/"), "/")
-	_ = parts // avoid unused variable if no params extracted
-	id, err := strconv.Atoi(parts[0])
-	if err != nil {
-		gqapi.WriteError(w, gqapi.BadRequest("invalid id: must be an integer"))
+	gqPath := strings.TrimPrefix(r.URL.Path, "/api/posts")
+	gqParts := strings.Split(strings.Trim(gqPath, "/"), "/")
+	_ = gqParts // avoid unused variable if no params extracted
+	id, gqErr := strconv.Atoi(gqParts[0])
+	if gqErr != nil {
+		gqapi.WriteError(w, gqapi.InvalidID("id", "must be an integer"))
 		return
 	}
 
-	result, err := h.service.GetByID(r.Context(), id)
-	if err != nil {
-		gqapi.WriteError(w, err)
+	gqResult, gqErr := h.service.GetByID(r.Context(), id)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(gqResult)
 }
 
 func (h *PostsAPIHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
-	var req CreatePostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var gqReq CreatePostRequest
+	if gqErr := json.NewDecoder(r.Body).Decode(&gqReq); gqErr != nil {
 		gqapi.WriteError(w, gqapi.BadRequest("invalid request body"))
 		return
 	}
+	if gqV, ok := any(&gqReq).(interface{ Validate() error }); ok {
+		if gqErr := gqV.Validate(); gqErr != nil {
+			gqapi.WriteError(w, gqapi.Invalid(gqErr))
+			return
+		}
+	}
 
-	result, err := h.service.Create(r.Context(), req)
-	if err != nil {
-		gqapi.WriteError(w, err)
+	gqResult, gqErr := h.service.Create(r.Context(), gqReq)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(gqResult)
 }
 
 func (h *PostsAPIHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	// Extract path parameters
-	path := strings.TrimPrefix(r.URL.Path, "/api/posts")
-	parts := strings.Split(strings.Trim(path, "/"), "/")
-	_ = parts // avoid unused variable if no params extracted
-	id, err := strconv.Atoi(parts[0])
-	if err != nil {
-		gqapi.WriteError(w, gqapi.BadRequest("invalid id: must be an integer"))
+	gqPath := strings.TrimPrefix(r.URL.Path, "/api/posts")
+	gqParts := strings.Split(strings.Trim(gqPath, "/"), "/")
+	_ = gqParts // avoid unused variable if no params extracted
+	id, gqErr := strconv.Atoi(gqParts[0])
+	if gqErr != nil {
+		gqapi.WriteError(w, gqapi.InvalidID("id", "must be an integer"))
 		return
 	}
-	var req CreatePostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var gqReq CreatePostRequest
+	if gqErr := json.NewDecoder(r.Body).Decode(&gqReq); gqErr != nil {
 		gqapi.WriteError(w, gqapi.BadRequest("invalid request body"))
 		return
 	}
+	if gqV, ok := any(&gqReq).(interface{ Validate() error }); ok {
+		if gqErr := gqV.Validate(); gqErr != nil {
+			gqapi.WriteError(w, gqapi.Invalid(gqErr))
+			return
+		}
+	}
 
-	result, err := h.service.Update(r.Context(), id, req)
-	if err != nil {
-		gqapi.WriteError(w, err)
+	gqResult, gqErr := h.service.Update(r.Context(), id, gqReq)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(gqResult)
 }
 
 func (h *PostsAPIHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 	// Extract path parameters
-	path := strings.TrimPrefix(r.URL.Path, "/api/posts")
-	parts := strings.Split(strings.Trim(path, "/"), "/")
-	_ = parts // avoid unused variable if no params extracted
-	id, err := strconv.Atoi(parts[0])
-	if err != nil {
-		gqapi.WriteError(w, gqapi.BadRequest("invalid id: must be an integer"))
+	gqPath := strings.TrimPrefix(r.URL.Path, "/api/posts")
+	gqParts := strings.Split(strings.Trim(gqPath, "/"), "/")
+	_ = gqParts // avoid unused variable if no params extracted
+	id, gqErr := strconv.Atoi(gqParts[0])
+	if gqErr != nil {
+		gqapi.WriteError(w, gqapi.InvalidID("id", "must be an integer"))
 		return
 	}
 
-	err = h.service.Delete(r.Context(), id)
-	if err != nil {
-		gqapi.WriteError(w, err)
+	gqErr = h.service.Delete(r.Context(), id)
+	if gqErr != nil {
+		gqapi.WriteError(w, gqErr)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
-
-
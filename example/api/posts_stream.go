@@ -13,12 +13,14 @@ type PostEvent struct {
 	ID   int    // The post ID (available for all events, especially deleted)
 }
 
-// Subscription manages a WebSocket connection for real-time updates
+// Subscription receives post events over the app's shared WebSocket
 type Subscription struct {
-	client *ws.Client
+	sub *ws.Subscription
 }
 
-// Subscribe connects to the WebSocket and calls handler for each event.
+// Subscribe calls handler for each post event, over the WebSocket shared
+// by every subscription to the same server (see ws.Shared), so following
+// posts, users, and notifications takes one connection.
 // Usage:
 //
 //	sub, err := posts.Subscribe(func(event api.PostEvent) {
@@ -33,52 +35,41 @@ type Subscription struct {
 //	})
 func (c *PostsClient) Subscribe(handler func(PostEvent)) (*Subscription, error) {
 	// Build WebSocket URL from HTTP base URL
-	wsURL := "ws://localhost:8093/ws/posts"
+	wsURL := "ws://localhost:8093/ws"
 	if c.cfg.baseURL != "" {
 		wsURL = c.cfg.baseURL
 		if len(wsURL) > 4 && wsURL[:4] == "http" {
 			wsURL = "ws" + wsURL[4:]
 		}
-		wsURL += "/ws/posts"
+		wsURL += "/ws"
 	}
 
-	sub := &Subscription{}
-	sub.client = ws.NewClient(wsURL)
-
-	if err := sub.client.Connect(); err != nil {
+	sub, err := ws.SubscribeTyped(ws.Shared(wsURL), "posts", func(event string, post Post) {
+		switch event {
+		case "post.created":
+			handler(PostEvent{Type: "created", Post: &post, ID: post.ID})
+		case "post.updated":
+			handler(PostEvent{Type: "updated", Post: &post, ID: post.ID})
+		case "post.deleted":
+			handler(PostEvent{Type: "deleted", ID: post.ID}) // only the ID is sent
+		}
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	// Register handlers for each event type
-	ws.OnTyped(sub.client, "post.created", func(post Post) {
-		handler(PostEvent{Type: "created", Post: &post, ID: post.ID})
-	})
-
-	ws.OnTyped(sub.client, "post.updated", func(post Post) {
-		handler(PostEvent{Type: "updated", Post: &post, ID: post.ID})
-	})
-
-	ws.OnTyped(sub.client, "post.deleted", func(data struct {
-		ID int `json:"id"`
-	}) {
-		handler(PostEvent{Type: "deleted", ID: data.ID})
-	})
-
-	// Subscribe to events on the server
-	sub.client.Send("posts.subscribe", struct{}{})
-
-	return sub, nil
+	return &Subscription{sub: sub}, nil
 }
 
-// Close closes the subscription
+// Close closes the subscription, and the shared connection if it was the
+// last one
 func (s *Subscription) Close() error {
-	if s.client != nil {
-		return s.client.Close()
+	if s.sub != nil {
+		return s.sub.Close()
 	}
 	return nil
 }
 
 // IsConnected returns true if connected
 func (s *Subscription) IsConnected() bool {
-	return s.client != nil && s.client.IsConnected()
+	return s.sub != nil && s.sub.IsConnected()
 }
@@ -0,0 +1,34 @@
+// Code generated by gux. DO NOT EDIT.
+
+package api
+
+import (
+	"encoding/json"
+
+	gqapi "github.com/dougbarrett/gux/api"
+)
+
+// MarshalJSON encodes Post with the formats in its time tags
+func (r Post) MarshalJSON() ([]byte, error) {
+	type plain Post
+	return json.Marshal(struct {
+		plain
+		CreatedAt *gqapi.TimeField `json:"createdAt"`
+	}{
+		plain:     plain(r),
+		CreatedAt: gqapi.TimeOf(&r.CreatedAt, "rfc3339"),
+	})
+}
+
+// UnmarshalJSON decodes Post with the formats in its time tags
+func (r *Post) UnmarshalJSON(data []byte) error {
+	type plain Post
+	aux := struct {
+		*plain
+		CreatedAt gqapi.TimeField `json:"createdAt"`
+	}{
+		plain:     (*plain)(r),
+		CreatedAt: *gqapi.TimeOf(&r.CreatedAt, "rfc3339"),
+	}
+	return json.Unmarshal(data, &aux)
+}
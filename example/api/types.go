@@ -1,16 +1,31 @@
 package api
 
+import "time"
+
 // Post represents a blog post
 type Post struct {
-	ID     int    `json:"id"`
-	UserID int    `json:"userId"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
+	ID        int        `json:"id"`
+	UserID    int        `json:"userId"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Status    PostStatus `json:"status"`
+	CreatedAt time.Time  `json:"createdAt" time:"rfc3339"`
 }
 
 // CreatePostRequest is the request body for creating/updating a post
 type CreatePostRequest struct {
-	UserID int    `json:"userId"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
+	UserID int        `json:"userId"`
+	Title  string     `json:"title" validate:"required,minlen=3"`
+	Body   string     `json:"body" validate:"required,minlen=10"`
+	Status PostStatus `json:"status,omitempty"`
 }
+
+// PostStatus is where a post is in its life
+// @enum
+type PostStatus string
+
+const (
+	PostDraft     PostStatus = "draft"     // Draft @color warning
+	PostPublished PostStatus = "published" // Published @color success
+	PostArchived  PostStatus = "archived"  // Archived
+)
@@ -0,0 +1,29 @@
+// Code generated by gux. DO NOT EDIT.
+
+package api
+
+import (
+	gqapi "github.com/dougbarrett/gux/api"
+)
+
+// Validate checks CreatePostRequest against its validate tags. It returns an
+// *api.Error with a translatable message for each invalid field.
+func (r CreatePostRequest) Validate() error {
+	fields := make(map[string]gqapi.Message)
+	switch {
+	case r.Title == "":
+		fields["title"] = gqapi.Msg("gux.validation.required")
+	case len(r.Title) < 3:
+		fields["title"] = gqapi.Msg("gux.validation.minlength", 3)
+	}
+	switch {
+	case r.Body == "":
+		fields["body"] = gqapi.Msg("gux.validation.required")
+	case len(r.Body) < 10:
+		fields["body"] = gqapi.Msg("gux.validation.minlength", 10)
+	}
+	if len(fields) > 0 {
+		return gqapi.ValidationMessages(fields)
+	}
+	return nil
+}
@@ -3,12 +3,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"syscall/js"
 	"time"
 
 	components "github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/diagnostics"
 	"github.com/dougbarrett/gux/example/api"
+	"github.com/dougbarrett/gux/example/app/pages"
+	"github.com/dougbarrett/gux/macros"
 	state "github.com/dougbarrett/gux/state"
+	"github.com/dougbarrett/gux/trail"
 )
 
 var (
@@ -20,6 +26,8 @@ var (
 	modal                *components.Modal
 	postsStore           *state.AsyncStore[[]api.Post]
 	commandPalette       *components.CommandPalette
+	macroRecorder        *macros.Recorder
+	reportDialog         *diagnostics.ReportDialog
 	connectionStatus     *components.ConnectionStatus
 	installPromptManager *components.InstallPromptManager
 	installPrompt        *components.InstallPrompt
@@ -32,6 +40,16 @@ func main() {
 	// Initialize toast notifications
 	components.InitToasts()
 
+	// Record session breadcrumbs and log them with uncaught errors
+	trail.Enable(trail.Options{
+		OnError: func(r trail.Report) {
+			js.Global().Get("console").Call("error", "gux: error report", r.Message, len(r.Breadcrumbs), "breadcrumbs")
+		},
+	})
+
+	// Capture console logs for "Report a problem" bundles
+	diagnostics.Init(diagnostics.Options{Version: "1.0.0"})
+
 	// Initialize API client
 	posts = api.NewPostsClient()
 
@@ -41,12 +59,23 @@ func main() {
 
 	// Initialize async posts store
 	postsStore = state.NewAsync[[]api.Post]()
+	diagnostics.RegisterState("posts", func() any { return postsStore.Get() })
 
+	// Pages, with the metadata the sidebar and command palette are built from
 	router.Register("/", showDashboard)
+	router.Meta("/", components.RouteMeta{Label: "Dashboard", Icon: "📊", Description: "Go to the main dashboard"})
 	router.Register("/api-test", showAPITest)
+	router.Meta("/api-test", components.RouteMeta{Label: "API Test", Icon: "🔌", Description: "Test API endpoints"})
 	router.Register("/create-post", showCreatePost)
+	router.Meta("/create-post", components.RouteMeta{Label: "Create Post", Icon: "✏️", Description: "Create a new blog post"})
+
+	// File-routed pages in ./pages, registered by generated code
+	pages.RegisterRoutes(router, func(page js.Value) { layout.SetContent(page) })
+
 	router.Register("/components", showComponents)
+	router.Meta("/components", components.RouteMeta{Label: "Components", Icon: "🧩", Description: "View component showcase"})
 	router.Register("/settings", showSettings)
+	router.Meta("/settings", components.RouteMeta{Label: "Settings", Icon: "⚙️", Description: "Manage application settings"})
 
 	// Update sidebar active state on navigation
 	router.OnNavigate(func(path string) {
@@ -103,17 +132,14 @@ func main() {
 		ShowLabel: false, // Just dot with tooltip
 	})
 
+	// Create the "Report a problem" dialog (also in the command palette)
+	reportDialog = diagnostics.NewReportDialog(diagnostics.ReportDialogProps{})
+	js.Global().Get("document").Get("body").Call("appendChild", reportDialog.Element())
+
 	// Create layout
 	layout = components.NewLayout(components.LayoutProps{
 		Sidebar: components.SidebarProps{
 			Title: "Admin Panel",
-			Items: []components.NavItem{
-				{Label: "Dashboard", Icon: "📊", Path: "/"},
-				{Label: "API Test", Icon: "🔌", Path: "/api-test"},
-				{Label: "Create Post", Icon: "✏️", Path: "/create-post"},
-				{Label: "Components", Icon: "🧩", Path: "/components"},
-				{Label: "Settings", Icon: "⚙️", Path: "/settings"},
-			},
 		},
 		Header: components.HeaderProps{
 			Title:              "Dashboard",
@@ -122,12 +148,16 @@ func main() {
 			UserMenu:           userMenu,
 			Actions: []components.HeaderAction{
 				{Label: "Refresh", OnClick: func() { js.Global().Get("location").Call("reload") }},
+				{Label: "Report a problem", OnClick: reportDialog.Open},
 			},
 		},
 	})
 
 	app.Mount(layout.Element())
 
+	// Sidebar items for the routes the user may see
+	router.BindSidebar(layout.Sidebar())
+
 	// Register keyboard shortcut for sidebar collapse (Cmd/Ctrl+B)
 	layout.Sidebar().RegisterKeyboardShortcut()
 
@@ -135,8 +165,8 @@ func main() {
 	commandPalette = components.NewCommandPalette(components.CommandPaletteProps{
 		Placeholder:  "Search commands...",
 		EmptyMessage: "No commands found",
-		Commands:     getCommandPaletteCommands(),
 	})
+	router.BindCommandPalette(commandPalette, getCommandPaletteCommands()...)
 
 	// Mount command palette to document body
 	js.Global().Get("document").Get("body").Call("appendChild", commandPalette.Element())
@@ -144,6 +174,27 @@ func main() {
 	// Register global Cmd+K / Ctrl+K shortcut
 	commandPalette.RegisterKeyboardShortcut()
 
+	// Record palette commands as macros (Alt+Shift+R), replayed with Alt+Shift+1-9
+	macroRecorder = macros.New(macros.Options{Palette: commandPalette, StepDelay: 150})
+	macroRecorder.Register(append(router.Commands(), getCommandPaletteCommands()...)...)
+	macroRecorder.RegisterKeyboardShortcuts()
+
+	// Go-to sequences ("g" then a letter) and "?" for the shortcut list
+	shortcuts := components.GetShortcutManager()
+	for _, nav := range []struct{ keys, label, path string }{
+		{"g d", "Go to dashboard", "/"},
+		{"g c", "Go to components", "/components"},
+		{"g s", "Go to settings", "/settings"},
+	} {
+		shortcuts.MustRegister(components.Shortcut{
+			Keys:        nav.keys,
+			Description: nav.label,
+			Group:       "Navigation",
+			Handler:     func() { router.Navigate(nav.path) },
+		})
+	}
+	shortcuts.RegisterHelpShortcut()
+
 	// Initialize PWA install prompt manager
 	installPromptManager = components.NewInstallPromptManager()
 
@@ -330,11 +381,11 @@ func feedbackDemo() js.Value {
 		components.Section("Confirmation Dialogs",
 			components.Div("flex gap-2",
 				components.PrimaryButton("Confirm Dialog", func() { confirmDialog.Open() }),
-				components.Button(components.ButtonProps{
+				trail.Track(components.Button(components.ButtonProps{
 					Text:    "Danger Confirm",
 					Variant: components.ButtonDanger,
 					OnClick: func() { dangerDialog.Open() },
-				}),
+				}), "components.danger-confirm"),
 			),
 			confirmDialog.Element(),
 			dangerDialog.Element(),
@@ -481,6 +532,17 @@ func newComponentsDemo() js.Value {
 		},
 	})
 
+	rangePicker := components.NewDatePicker(components.DatePickerProps{
+		Label: "Report Period", Mode: components.DatePickerRange,
+		OnRangeChange: func(start, end time.Time) {
+			components.Toast("Range: "+start.Format("Jan 2")+" – "+end.Format("Jan 2, 2006"), components.ToastInfo)
+		},
+	})
+
+	meetingPicker := components.NewDatePicker(components.DatePickerProps{
+		Label: "Meeting Time", Mode: components.DatePickerDateTime, MinuteStep: 15,
+	})
+
 	stepper := components.NewStepper(components.StepperProps{
 		Steps: []components.Step{
 			{Title: "Account", Description: "Create account"},
@@ -523,7 +585,7 @@ func newComponentsDemo() js.Value {
 		),
 		components.Section("Tooltip", tooltipBtn),
 		components.Section("Clipboard", components.CopyableText("npm install gux")),
-		components.Section("Date Picker", datePicker.Element()),
+		components.Section("Date Picker", datePicker.Element(), rangePicker.Element(), meetingPicker.Element()),
 		components.Section("Accordion", accordion.Element()),
 		components.Section("Stepper", stepper.Element()),
 	)
@@ -878,6 +940,11 @@ func utilitiesDemo() js.Value {
 						inspector.Open()
 					}
 				}),
+				components.SecondaryButton("Memory Profile", func() {
+					inspector := components.InitInspector()
+					inspector.ShowTab("memory")
+					inspector.Open()
+				}),
 			),
 		),
 	)
@@ -963,14 +1030,15 @@ func chartsDemo() js.Value {
 func showCreatePost() {
 	form := components.NewForm(components.FormProps{
 		Fields: []components.FormField{
-			{Name: "title", Label: "Title", Placeholder: "Enter post title", Rules: []components.ValidationRule{components.Required, components.MinLength(3)}},
-			{Name: "body", Label: "Body", Placeholder: "Write your post content...", Rules: []components.ValidationRule{components.Required, components.MinLength(10)}},
+			{Name: "title", Label: "Title", Placeholder: "Enter post title"},
+			{Name: "body", Label: "Body", Placeholder: "Write your post content..."},
 		},
+		Rules:       api.CreatePostRequestRules(), // from CreatePostRequest's validate tags
 		SubmitLabel: "Create Post",
 		CancelLabel: "Cancel",
 		OnSubmit: func(values map[string]string) {
 			go func() {
-				_, err := posts.Create(api.CreatePostRequest{
+				_, err := posts.Create(context.Background(), api.CreatePostRequest{
 					UserID: 1, Title: values["title"], Body: values["body"],
 				})
 				if err != nil {
@@ -1011,7 +1079,11 @@ func showSettings() {
 func fetchSinglePost() {
 	display.ShowLoading("Fetching post #1...")
 
-	post, err := posts.GetByID(1)
+	// Aborted if the user navigates away before it finishes
+	post, err := posts.GetByID(router.Context(), 1)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
 	if err != nil {
 		display.ShowError("Error: " + err.Error())
 		components.Toast("Failed to fetch post", components.ToastError)
@@ -1025,7 +1097,10 @@ func fetchSinglePost() {
 func fetchAllPosts() {
 	display.ShowLoading("Fetching all posts...")
 
-	allPosts, err := posts.GetAll()
+	allPosts, err := posts.GetAll(router.Context())
+	if errors.Is(err, context.Canceled) {
+		return
+	}
 	if err != nil {
 		display.ShowError("Error: " + err.Error())
 		components.Toast("Failed to fetch posts", components.ToastError)
@@ -1040,50 +1115,10 @@ func fetchAllPosts() {
 	components.Toast("Posts loaded successfully", components.ToastSuccess)
 }
 
-// getCommandPaletteCommands returns the commands for the command palette
+// getCommandPaletteCommands returns the command palette's actions, shown
+// after the navigation commands built from the routes
 func getCommandPaletteCommands() []components.Command {
 	return []components.Command{
-		// Navigation commands
-		{
-			ID:          "nav-dashboard",
-			Label:       "Dashboard",
-			Description: "Go to the main dashboard",
-			Icon:        "📊",
-			Category:    "Navigation",
-			OnExecute:   func() { router.Navigate("/") },
-		},
-		{
-			ID:          "nav-api-test",
-			Label:       "API Test",
-			Description: "Test API endpoints",
-			Icon:        "🔌",
-			Category:    "Navigation",
-			OnExecute:   func() { router.Navigate("/api-test") },
-		},
-		{
-			ID:          "nav-create-post",
-			Label:       "Create Post",
-			Description: "Create a new blog post",
-			Icon:        "✏️",
-			Category:    "Navigation",
-			OnExecute:   func() { router.Navigate("/create-post") },
-		},
-		{
-			ID:          "nav-components",
-			Label:       "Components",
-			Description: "View component showcase",
-			Icon:        "🧩",
-			Category:    "Navigation",
-			OnExecute:   func() { router.Navigate("/components") },
-		},
-		{
-			ID:          "nav-settings",
-			Label:       "Settings",
-			Description: "Manage application settings",
-			Icon:        "⚙️",
-			Category:    "Navigation",
-			OnExecute:   func() { router.Navigate("/settings") },
-		},
 		// Action commands
 		{
 			ID:          "action-toggle-sidebar",
@@ -1091,7 +1126,7 @@ func getCommandPaletteCommands() []components.Command {
 			Description: "Collapse or expand the sidebar",
 			Icon:        "📐",
 			Category:    "Actions",
-			Shortcut:    "Ctrl+B",
+			Shortcut:    components.FormatShortcut("mod+b"),
 			OnExecute:   func() { layout.Sidebar().ToggleCollapse() },
 		},
 		{
@@ -1125,5 +1160,6 @@ func getCommandPaletteCommands() []components.Command {
 				components.Toast("Notifications cleared", components.ToastSuccess)
 			},
 		},
+		reportDialog.Command(),
 	}
 }
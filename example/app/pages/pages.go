@@ -0,0 +1,8 @@
+//go:build js && wasm
+
+// Package pages holds the example app's file-routed pages. Each file's path
+// under this directory is its route, e.g. posts/id_param.go is /posts/{id};
+// routes_gen.go registers them:
+//
+//	gux gen routes -dir example/app/pages
+package pages
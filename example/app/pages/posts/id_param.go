@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+package posts
+
+import (
+	"context"
+	"strconv"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/example/api"
+)
+
+// LoadPost fetches the post in the URL before Post is shown
+// @loader
+func LoadPost(ctx context.Context, params map[string]string) (*api.Post, error) {
+	id, err := strconv.Atoi(params["id"])
+	if err != nil {
+		return nil, components.Redirect("/posts")
+	}
+	return client.GetByID(ctx, id)
+}
+
+// Post shows a post
+// @page
+// @title Post {id}
+func Post(params map[string]string, post *api.Post) js.Value {
+	return components.Div("space-y-4 max-w-2xl",
+		components.Breadcrumbs(components.BreadcrumbsProps{
+			Items: components.GetGlobalRouter().Breadcrumbs(),
+		}),
+		components.Div("flex items-center gap-3",
+			components.HeadingWithClass(2, post.Title, "text-2xl font-bold text-primary"),
+			api.PostStatusBadge(nil, post.Status),
+		),
+		components.Card(components.Text(post.Body)),
+	)
+}
@@ -0,0 +1,72 @@
+//go:build js && wasm
+
+package posts
+
+import (
+	"context"
+	"strconv"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/example/api"
+	"github.com/dougbarrett/gux/state"
+)
+
+var client = api.NewPostsClient()
+
+// LoadPosts fetches the posts before Posts is shown
+// @loader
+func LoadPosts(ctx context.Context, params map[string]string) ([]api.Post, error) {
+	return client.GetAll(ctx)
+}
+
+// Posts lists the posts, each linking to its page. Deleting a post
+// removes it at once and puts it back if the server refuses.
+// @page
+// @title Posts
+// @icon 📰
+// @description Browse the published posts
+func Posts(params map[string]string, posts []api.Post) js.Value {
+	store := state.NewAsyncWithDefault(posts)
+	controller := components.NewListController(store, components.ListControllerOptions[api.Post]{
+		Key: func(p api.Post) string { return strconv.Itoa(p.ID) },
+		Delete: func(ctx context.Context, p api.Post) error {
+			return client.Delete(ctx, p.ID)
+		},
+	})
+
+	list := components.Div("space-y-2")
+	render := func(posts []api.Post) {
+		components.UnmountChildren(list)
+		for _, post := range posts {
+			list.Call("appendChild", components.Div("flex items-center gap-2",
+				components.Link(components.LinkProps{
+					To:        "/posts/" + strconv.Itoa(post.ID),
+					ClassName: "flex-1 p-3 rounded-lg border border-default hover:surface-overlay",
+					Children: func(parent js.Value) {
+						parent.Call("appendChild", components.Div("flex justify-between items-center",
+							components.Text(post.Title),
+							api.PostStatusBadge(nil, post.Status),
+						))
+					},
+				}),
+				components.Button(components.ButtonProps{
+					Text:    "Delete",
+					Variant: components.ButtonDanger,
+					Size:    components.ButtonSM,
+					OnClick: func() { controller.Delete(post) },
+				}),
+			))
+		}
+		if len(posts) == 0 {
+			list.Call("appendChild", components.Text("No posts yet."))
+		}
+	}
+	render(posts)
+	store.Subscribe(func(s state.AsyncState[[]api.Post]) { render(s.Data) })
+
+	return components.Div("space-y-4",
+		components.HeadingWithClass(2, "Posts", "text-2xl font-bold text-primary"),
+		list,
+	)
+}
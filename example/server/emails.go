@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dougbarrett/gux/email"
+	"github.com/dougbarrett/gux/example/api"
+)
+
+// postPublishedEmail is the notification sent to subscribers when a post
+// is published
+func postPublishedEmail(post api.Post, baseURL string) email.Document {
+	link := baseURL + "/posts/" + strconv.Itoa(post.ID)
+	status := email.Secondary
+	switch post.Status {
+	case api.PostPublished:
+		status = email.Success
+	case api.PostDraft:
+		status = email.Warning
+	}
+	return email.Document{
+		Title:     "New post: " + post.Title,
+		Preheader: post.Title,
+		Body: []email.Node{
+			email.Heading(1, post.Title),
+			email.Badge(post.Status.Label(), status),
+			email.Spacer(16),
+			email.Paragraph(email.Text(post.Body)),
+			email.Button(email.ButtonProps{Text: "Read the post", Href: link}),
+			email.Table(email.TableProps{
+				Rows: [][]email.Node{
+					{email.Bold("Published"), email.Text(post.CreatedAt.Format("January 2, 2006"))},
+					{email.Bold("Link"), email.Link(link, link)},
+				},
+			}),
+		},
+		Footer: []email.Node{
+			email.Text("You're receiving this because you follow new posts. "),
+			email.Link("Unsubscribe", baseURL+"/settings"),
+		},
+	}
+}
+
+// emailPreviewHandler shows postPublishedEmail for the post in ?id=, as
+// HTML, or as plain text with ?format=text
+func emailPreviewHandler(posts *PostsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(r.URL.Query().Get("id"))
+		post, err := posts.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "post not found", http.StatusNotFound)
+			return
+		}
+		msg := postPublishedEmail(*post, "http://"+r.Host)
+		if r.URL.Query().Get("format") == "text" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(msg.Text()))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(msg.HTML()))
+	}
+}
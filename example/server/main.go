@@ -34,17 +34,33 @@ func main() {
 	postsWSHandler := NewPostsWSHandler(postsService)
 	mux.HandleFunc("/ws/posts", postsWSHandler.ServeHTTP)
 
+	// Topic hub shared by the client's subscriptions (posts, ...) over one
+	// connection, with replay of events missed while reconnecting
+	hub := server.NewWSHub(server.WSHubOptions{Upgrader: upgrader})
+	mux.Handle("/ws", hub)
+
 	// Wire up event callbacks so HTTP API changes broadcast to WebSocket clients
 	postsService.SetEventCallbacks(
-		func(post api.Post) { postsWSHandler.broadcastEvent("post.created", post) },
-		func(post api.Post) { postsWSHandler.broadcastEvent("post.updated", post) },
+		func(post api.Post) {
+			postsWSHandler.broadcastEvent("post.created", post)
+			hub.Publish("posts", "post.created", post)
+		},
+		func(post api.Post) {
+			postsWSHandler.broadcastEvent("post.updated", post)
+			hub.Publish("posts", "post.updated", post)
+		},
 		func(id int) {
-			postsWSHandler.broadcastEvent("post.deleted", struct {
+			deleted := struct {
 				ID int `json:"id"`
-			}{id})
+			}{id}
+			postsWSHandler.broadcastEvent("post.deleted", deleted)
+			hub.Publish("posts", "post.deleted", deleted)
 		},
 	)
 
+	// Preview of the email sent when a post is published
+	mux.HandleFunc("/emails/post-published", emailPreviewHandler(postsService))
+
 	// SPA handler for static files
 	spaHandler := server.NewSPAHandler(*dir)
 	mux.HandleFunc("/", spaHandler.ServeHTTP)
@@ -60,6 +76,9 @@ func main() {
 	fmt.Println("  DELETE /api/posts/:id  - Delete post")
 	fmt.Println("\nWebSocket:")
 	fmt.Println("  WS     /ws/posts       - Real-time posts API")
+	fmt.Println("  WS     /ws             - Topic subscriptions (posts)")
+	fmt.Println("\nEmails:")
+	fmt.Println("  GET    /emails/post-published?id=1[&format=text] - Preview the new post email")
 
 	if err := http.ListenAndServe(addr, mux); err != nil {
 		log.Fatal(err)
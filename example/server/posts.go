@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	gqapi "github.com/dougbarrett/gux/api"
 	"github.com/dougbarrett/gux/example/api"
+	"github.com/dougbarrett/gux/filter"
 )
 
 // PostsService implements api.PostsAPI
@@ -36,9 +39,9 @@ func NewPostsService() *PostsService {
 
 	// Add sample data
 	samplePosts := []api.Post{
-		{ID: 1, UserID: 1, Title: "Hello World", Body: "This is the first post from our Go WASM backend!"},
-		{ID: 2, UserID: 1, Title: "Getting Started with Go WASM", Body: "Learn how to build web apps with Go and WebAssembly."},
-		{ID: 3, UserID: 2, Title: "API Design Patterns", Body: "Best practices for designing clean APIs in Go."},
+		{ID: 1, UserID: 1, Title: "Hello World", Body: "This is the first post from our Go WASM backend!", Status: api.PostPublished, CreatedAt: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, UserID: 1, Title: "Getting Started with Go WASM", Body: "Learn how to build web apps with Go and WebAssembly.", Status: api.PostPublished, CreatedAt: time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC)},
+		{ID: 3, UserID: 2, Title: "API Design Patterns", Body: "Best practices for designing clean APIs in Go.", Status: api.PostDraft, CreatedAt: time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
 	}
 
 	for _, p := range samplePosts {
@@ -61,6 +64,31 @@ func (s *PostsService) GetAll(ctx context.Context) ([]api.Post, error) {
 	return posts, nil
 }
 
+// List returns a page of posts, newest first. The cursor is the ID of the
+// last post on the previous page, so posts created while a client pages
+// through the list don't shift the pages it hasn't seen yet.
+func (s *PostsService) List(ctx context.Context, cursor string, limit int) (gqapi.Page[api.Post], error) {
+	before := int(^uint(0) >> 1)
+	if err := gqapi.DecodeCursor(cursor, &before); err != nil {
+		return gqapi.Page[api.Post]{}, err
+	}
+	limit = gqapi.CursorLimit(limit)
+
+	posts, _ := s.GetAll(ctx)
+	sort.Slice(posts, func(i, j int) bool { return posts[i].ID > posts[j].ID })
+	start := sort.Search(len(posts), func(i int) bool { return posts[i].ID < before })
+	posts = posts[start:min(start+limit+1, len(posts))]
+
+	return gqapi.NewPage(posts, limit, func(p api.Post) any { return p.ID }), nil
+}
+
+// Search returns the posts matching where. A database-backed service would
+// build its query with where.SQL instead.
+func (s *PostsService) Search(ctx context.Context, where filter.Filter) ([]api.Post, error) {
+	posts, _ := s.GetAll(ctx)
+	return filter.Apply(posts, where), nil
+}
+
 // GetByID returns a single post by ID
 func (s *PostsService) GetByID(ctx context.Context, id int) (*api.Post, error) {
 	s.mu.RLock()
@@ -75,12 +103,19 @@ func (s *PostsService) GetByID(ctx context.Context, id int) (*api.Post, error) {
 
 // Create creates a new post
 func (s *PostsService) Create(ctx context.Context, req api.CreatePostRequest) (*api.Post, error) {
+	status := req.Status
+	if status == "" {
+		status = api.PostDraft
+	}
+
 	s.mu.Lock()
 	post := api.Post{
-		ID:     s.nextID,
-		UserID: req.UserID,
-		Title:  req.Title,
-		Body:   req.Body,
+		ID:        s.nextID,
+		UserID:    req.UserID,
+		Title:     req.Title,
+		Body:      req.Body,
+		Status:    status,
+		CreatedAt: time.Now(),
 	}
 	s.posts[post.ID] = post
 	s.nextID++
@@ -97,16 +132,23 @@ func (s *PostsService) Create(ctx context.Context, req api.CreatePostRequest) (*
 // Update updates an existing post
 func (s *PostsService) Update(ctx context.Context, id int, req api.CreatePostRequest) (*api.Post, error) {
 	s.mu.Lock()
-	if _, ok := s.posts[id]; !ok {
+	existing, ok := s.posts[id]
+	if !ok {
 		s.mu.Unlock()
 		return nil, gqapi.NotFoundf("post %d not found", id)
 	}
 
+	status := req.Status
+	if status == "" {
+		status = existing.Status
+	}
 	post := api.Post{
-		ID:     id,
-		UserID: req.UserID,
-		Title:  req.Title,
-		Body:   req.Body,
+		ID:        id,
+		UserID:    req.UserID,
+		Title:     req.Title,
+		Body:      req.Body,
+		Status:    status,
+		CreatedAt: existing.CreatedAt,
 	}
 	s.posts[id] = post
 	s.mu.Unlock()
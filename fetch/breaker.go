@@ -0,0 +1,324 @@
+//go:build js && wasm
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker for one endpoint
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests at once, without calling the server
+	CircuitOpen
+	// CircuitHalfOpen lets one probe request through to test the server
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// ErrCircuitOpen is matched by the errors of requests refused by a breaker
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError is returned for a request refused because its
+// endpoint's circuit is open
+type CircuitOpenError struct {
+	Endpoint   string
+	RetryAfter time.Duration // until the next probe is allowed
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s, retry in %s", e.Endpoint, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// BreakerOptions configures a Breaker
+type BreakerOptions struct {
+	// Threshold is the number of consecutive failures that opens an
+	// endpoint's circuit (default 5)
+	Threshold int
+
+	// Cooldown is how long a circuit stays open before a probe (default
+	// 5 seconds). It doubles after each failed probe, up to MaxCooldown
+	// (default 1 minute).
+	Cooldown    time.Duration
+	MaxCooldown time.Duration
+
+	// Endpoint groups request URLs into endpoints, each with its own
+	// circuit. The default is the origin and the first two path segments,
+	// so /api/users/12 and /api/users/me share the /api/users circuit.
+	Endpoint func(url string) string
+
+	// IsFailure reports whether a request counts against its endpoint. The
+	// default counts network errors, timeouts, 429, and 5xx responses; a
+	// canceled request never counts.
+	IsFailure func(resp *Response, err error) bool
+}
+
+// Breaker is a client-side circuit breaker. After Threshold consecutive
+// failures to an endpoint, requests to it fail at once with a
+// CircuitOpenError instead of waiting on a server that is down. After the
+// cooldown one probe request is let through: if it succeeds the circuit
+// closes, otherwise it opens again for twice as long.
+//
+// Install one with UseBreaker to cover every request made with this
+// package, including generated API clients.
+type Breaker struct {
+	opts        BreakerOptions
+	mu          sync.Mutex
+	circuits    map[string]*circuit
+	subscribers map[int]func(endpoint string, state CircuitState)
+	nextID      int
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int       // consecutive failures while closed
+	trips     int       // failed probes since the circuit last closed
+	openUntil time.Time // when an open circuit allows a probe
+	probing   bool      // a half-open probe is in flight
+}
+
+// NewBreaker creates a Breaker
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 5 * time.Second
+	}
+	if opts.MaxCooldown < opts.Cooldown {
+		opts.MaxCooldown = max(time.Minute, opts.Cooldown)
+	}
+	if opts.Endpoint == nil {
+		opts.Endpoint = DefaultEndpoint
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = isServiceFailure
+	}
+	return &Breaker{
+		opts:        opts,
+		circuits:    make(map[string]*circuit),
+		subscribers: make(map[int]func(string, CircuitState)),
+	}
+}
+
+var (
+	breakerMu     sync.RWMutex
+	activeBreaker *Breaker
+)
+
+// UseBreaker makes every request made with this package go through b. Pass
+// nil to turn the breaker off.
+func UseBreaker(b *Breaker) {
+	breakerMu.Lock()
+	activeBreaker = b
+	breakerMu.Unlock()
+}
+
+func currentBreaker() *Breaker {
+	breakerMu.RLock()
+	defer breakerMu.RUnlock()
+	return activeBreaker
+}
+
+// DefaultEndpoint returns the origin and first two path segments of rawURL,
+// e.g. "https://api.example.com/api/users" for
+// "https://api.example.com/api/users/12?full=1"
+func DefaultEndpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	path := "/" + strings.Join(segments, "/")
+	if u.Host == "" {
+		return path
+	}
+	return u.Scheme + "://" + u.Host + path
+}
+
+// isServiceFailure counts network errors, timeouts, 429, and 5xx responses
+func isServiceFailure(resp *Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.Status == 429 || resp.Status >= 500
+}
+
+// Subscribe calls fn whenever an endpoint's circuit changes state, e.g. to
+// show a "Service degraded" banner. It returns a function that unsubscribes.
+func (b *Breaker) Subscribe(fn func(endpoint string, state CircuitState)) func() {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = fn
+	b.mu.Unlock()
+	return func() {
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		b.mu.Unlock()
+	}
+}
+
+// State returns the state of the circuit for the endpoint a URL belongs to
+func (b *Breaker) State(rawURL string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.circuits[b.opts.Endpoint(rawURL)]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// Degraded returns the endpoints whose circuits are not closed
+func (b *Breaker) Degraded() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var endpoints []string
+	for endpoint, c := range b.circuits {
+		if c.state != CircuitClosed {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// Reset closes every circuit, e.g. when the browser comes back online
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	var reopened []string
+	for endpoint, c := range b.circuits {
+		if c.state != CircuitClosed {
+			reopened = append(reopened, endpoint)
+		}
+	}
+	b.circuits = make(map[string]*circuit)
+	b.mu.Unlock()
+	for _, endpoint := range reopened {
+		b.notify(endpoint, CircuitClosed)
+	}
+}
+
+// allow checks whether a request to endpoint may go out. probe is true for
+// the request that tests a half-open circuit.
+func (b *Breaker) allow(endpoint string) (probe bool, err error) {
+	b.mu.Lock()
+	c, ok := b.circuits[endpoint]
+	if !ok || c.state == CircuitClosed {
+		b.mu.Unlock()
+		return false, nil
+	}
+	now := time.Now()
+	if c.state == CircuitOpen && !now.Before(c.openUntil) {
+		c.state = CircuitHalfOpen
+		c.probing = true
+		b.mu.Unlock()
+		b.notify(endpoint, CircuitHalfOpen)
+		return true, nil
+	}
+	if c.state == CircuitHalfOpen && !c.probing {
+		c.probing = true
+		b.mu.Unlock()
+		return true, nil
+	}
+	retry := max(c.openUntil.Sub(now), 0)
+	b.mu.Unlock()
+	return false, &CircuitOpenError{Endpoint: endpoint, RetryAfter: retry}
+}
+
+// record updates endpoint's circuit with the outcome of a request
+func (b *Breaker) record(endpoint string, probe bool, resp *Response, err error) {
+	b.mu.Lock()
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+	if probe {
+		c.probing = false
+	}
+
+	// A canceled request says nothing about the server; a canceled probe
+	// leaves the circuit half-open for the next request to test
+	if errors.Is(err, context.Canceled) {
+		b.mu.Unlock()
+		return
+	}
+
+	before := c.state
+	if !b.opts.IsFailure(resp, err) {
+		if probe || c.state == CircuitClosed {
+			*c = circuit{}
+		}
+	} else if probe || c.state == CircuitClosed {
+		c.failures++
+		if probe || c.failures >= b.opts.Threshold {
+			c.state = CircuitOpen
+			c.openUntil = time.Now().Add(Backoff(c.trips, b.opts.Cooldown, b.opts.MaxCooldown))
+			if probe {
+				c.trips++
+			}
+		}
+	}
+	after := c.state
+	if after == CircuitClosed && c.failures == 0 {
+		delete(b.circuits, endpoint)
+	}
+	b.mu.Unlock()
+
+	if after != before {
+		b.notify(endpoint, after)
+	}
+}
+
+func (b *Breaker) notify(endpoint string, state CircuitState) {
+	b.mu.Lock()
+	fns := make([]func(string, CircuitState), 0, len(b.subscribers))
+	for _, fn := range b.subscribers {
+		fns = append(fns, fn)
+	}
+	b.mu.Unlock()
+	for _, fn := range fns {
+		fn(endpoint, state)
+	}
+}
+
+// Backoff returns the delay before retry number attempt (counting from 0):
+// base doubled for each attempt, capped at max, plus up to 20% jitter so
+// clients that failed together don't retry together
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base
+	for i := 0; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
@@ -0,0 +1,75 @@
+//go:build js && wasm
+
+package fetch
+
+import (
+	"strings"
+	"sync"
+)
+
+// Environment is a backend the app can be pointed at, such as local,
+// staging, or production
+type Environment struct {
+	Name string // e.g. "staging"
+
+	// BaseURL replaces the base URL of every generated API client, e.g.
+	// "https://staging.example.com". Empty keeps each client's own, which
+	// for most apps is the page's origin.
+	BaseURL string
+}
+
+var (
+	environmentMu     sync.RWMutex
+	activeEnvironment *Environment
+	environmentSubs   []*func(*Environment)
+)
+
+// UseEnvironment points the generated API clients at env's BaseURL from
+// their next request on, and notifies OnEnvironmentChange subscribers. Pass
+// nil to go back to the clients' own base URLs.
+// components.EnvironmentSwitcher calls it when the user picks a backend.
+func UseEnvironment(env *Environment) {
+	environmentMu.Lock()
+	activeEnvironment = env
+	subs := append([]*func(*Environment){}, environmentSubs...)
+	environmentMu.Unlock()
+	for _, fn := range subs {
+		(*fn)(env)
+	}
+}
+
+// CurrentEnvironment returns the environment set with UseEnvironment, or nil
+func CurrentEnvironment() *Environment {
+	environmentMu.RLock()
+	defer environmentMu.RUnlock()
+	return activeEnvironment
+}
+
+// EnvironmentBaseURL returns the current environment's BaseURL without a
+// trailing slash, or fallback when no environment sets one. Generated
+// clients use it in place of their configured base URL.
+func EnvironmentBaseURL(fallback string) string {
+	if env := CurrentEnvironment(); env != nil && env.BaseURL != "" {
+		return strings.TrimRight(env.BaseURL, "/")
+	}
+	return fallback
+}
+
+// OnEnvironmentChange calls fn whenever UseEnvironment is called. It
+// returns a function that unsubscribes.
+func OnEnvironmentChange(fn func(*Environment)) func() {
+	environmentMu.Lock()
+	defer environmentMu.Unlock()
+	p := &fn
+	environmentSubs = append(environmentSubs, p)
+	return func() {
+		environmentMu.Lock()
+		defer environmentMu.Unlock()
+		for i, s := range environmentSubs {
+			if s == p {
+				environmentSubs = append(environmentSubs[:i], environmentSubs[i+1:]...)
+				return
+			}
+		}
+	}
+}
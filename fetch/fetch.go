@@ -4,8 +4,13 @@
 package fetch
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/trail"
 )
 
 // Response represents an HTTP response
@@ -14,7 +19,26 @@ type Response struct {
 	StatusText string
 	OK         bool
 	Body       string
-	Headers    map[string]string
+	Headers    map[string]string // keyed by canonical name, e.g. "Retry-After"
+}
+
+// Header returns the value of the response header name, in any case
+func (r *Response) Header(name string) string {
+	return r.Headers[canonicalHeader(name)]
+}
+
+// canonicalHeader capitalizes the first letter of each dash-separated word
+// of a header name, as net/http does
+func canonicalHeader(name string) string {
+	b := []byte(strings.ToLower(name))
+	upper := true
+	for i, c := range b {
+		if upper && 'a' <= c && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+		upper = c == '-'
+	}
+	return string(b)
 }
 
 // Options configures a fetch request
@@ -22,6 +46,17 @@ type Options struct {
 	Method  string
 	Headers map[string]string
 	Body    string
+
+	// Timeout aborts the request if it has not completed in time (0 = no timeout)
+	Timeout time.Duration
+
+	// Retries is how many times a failed request is retried, with Backoff
+	// between attempts. Only GET, HEAD, PUT, DELETE, and requests with an
+	// Idempotency-Key are retried, and retrying stops when the breaker
+	// installed with UseBreaker opens the endpoint's circuit. A request
+	// failed if the breaker's IsFailure says so, or without a breaker, on
+	// network errors, timeouts, 429, and 5xx responses.
+	Retries int
 }
 
 // Error types
@@ -30,9 +65,106 @@ var (
 	ErrNetworkError = errors.New("network error")
 )
 
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context that makes FetchContext, and the
+// generated API clients, send key in the Idempotency-Key header. Forms
+// provide a key per submission through IdempotencyKey.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
 // Fetch performs an HTTP request using the browser's fetch API
 // This is synchronous and blocks until the request completes
 func Fetch(url string, opts *Options) (*Response, error) {
+	return FetchContext(context.Background(), url, opts)
+}
+
+// FetchContext performs an HTTP request that is aborted through an
+// AbortController when ctx is canceled or Options.Timeout elapses. The error
+// is then ctx.Err(), i.e. context.Canceled or context.DeadlineExceeded.
+//
+// When a breaker is installed with UseBreaker, requests to an endpoint whose
+// circuit is open fail at once with a *CircuitOpenError. When a throttle is
+// installed with UseThrottle, requests wait out a 429 or a 503 with a
+// Retry-After rather than being retried with Backoff.
+func FetchContext(ctx context.Context, url string, opts *Options) (*Response, error) {
+	retries := 0
+	if opts != nil && opts.Retries > 0 && retryable(ctx, opts) {
+		retries = opts.Retries
+	}
+
+	isFailure := isServiceFailure
+	if b := currentBreaker(); b != nil {
+		isFailure = b.opts.IsFailure
+	}
+	throttle := CurrentThrottle()
+	for attempt := 0; ; attempt++ {
+		resp, err := throttle.fetch(ctx, url, opts)
+		if attempt >= retries || !isFailure(resp, err) || errors.Is(err, ErrCircuitOpen) || ctx.Err() != nil {
+			return resp, err
+		}
+		if throttle != nil {
+			if _, _, held := throttle.hold(resp); held || errors.Is(err, ErrServiceUnavailable) {
+				return resp, err // the throttle has already waited and retried
+			}
+		}
+		timer := time.NewTimer(Backoff(attempt, retryBase, retryMax))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Delays between retries
+const (
+	retryBase = 250 * time.Millisecond
+	retryMax  = 5 * time.Second
+)
+
+// retryable reports whether sending a request twice is safe
+func retryable(ctx context.Context, opts *Options) bool {
+	switch opts.Method {
+	case "", "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key != "" || opts.Headers["Idempotency-Key"] != ""
+}
+
+// guardedFetch performs one request through the installed breaker, if any
+func guardedFetch(ctx context.Context, url string, opts *Options) (*Response, error) {
+	b := currentBreaker()
+	if b == nil {
+		return fetchOnce(ctx, url, opts)
+	}
+	endpoint := b.opts.Endpoint(url)
+	probe, err := b.allow(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := fetchOnce(ctx, url, opts)
+	b.record(endpoint, probe, resp, err)
+	return resp, err
+}
+
+// fetchOnce performs a single request
+func fetchOnce(ctx context.Context, url string, opts *Options) (*Response, error) {
+	start := time.Now()
+	method := "GET"
+
+	if opts != nil && opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	done := make(chan struct{})
 	var response *Response
 	var fetchErr error
@@ -40,68 +172,94 @@ func Fetch(url string, opts *Options) (*Response, error) {
 	// Build fetch options
 	jsOpts := js.Global().Get("Object").New()
 
+	headers := js.Global().Get("Object").New()
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		headers.Set("Idempotency-Key", key)
+	}
+
 	if opts != nil {
 		if opts.Method != "" {
 			jsOpts.Set("method", opts.Method)
+			method = opts.Method
 		}
 
-		if len(opts.Headers) > 0 {
-			headers := js.Global().Get("Object").New()
-			for k, v := range opts.Headers {
-				headers.Set(k, v)
-			}
-			jsOpts.Set("headers", headers)
+		for k, v := range opts.Headers {
+			headers.Set(k, v)
 		}
 
 		if opts.Body != "" {
 			jsOpts.Set("body", opts.Body)
 		}
 	}
+	jsOpts.Set("headers", headers)
 
-	// Success handler
-	thenFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
-		resp := args[0]
-
-		// Get response body as text
-		resp.Call("text").Call("then", js.FuncOf(func(this js.Value, args []js.Value) any {
-			bodyText := args[0].String()
-
-			response = &Response{
-				Status:     resp.Get("status").Int(),
-				StatusText: resp.Get("statusText").String(),
-				OK:         resp.Get("ok").Bool(),
-				Body:       bodyText,
-				Headers:    make(map[string]string),
-			}
+	// Abort the request when the context ends
+	var controller js.Value
+	if ctx.Done() != nil {
+		controller = js.Global().Get("AbortController").New()
+		jsOpts.Set("signal", controller.Get("signal"))
+	}
 
-			close(done)
-			return nil
-		}))
+	// Error handler, for both the request and reading the body
+	catchFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
+		fetchErr = errors.New(args[0].Get("message").String())
+		close(done)
+		return nil
+	})
 
+	var resp js.Value
+	response = &Response{Headers: make(map[string]string)}
+	headerFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
+		response.Headers[canonicalHeader(args[1].String())] = args[0].String()
 		return nil
 	})
+	textFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
+		response.Status = resp.Get("status").Int()
+		response.StatusText = resp.Get("statusText").String()
+		response.OK = resp.Get("ok").Bool()
+		response.Body = args[0].String()
+		resp.Get("headers").Call("forEach", headerFunc)
 
-	// Error handler
-	catchFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
-		fetchErr = errors.New(args[0].Get("message").String())
 		close(done)
 		return nil
 	})
 
+	// Success handler
+	thenFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
+		resp = args[0]
+
+		// Get response body as text
+		resp.Call("text").Call("then", textFunc).Call("catch", catchFunc)
+		return nil
+	})
+
 	// Execute fetch
 	js.Global().Call("fetch", url, jsOpts).Call("then", thenFunc).Call("catch", catchFunc)
 
-	// Wait for completion
-	<-done
+	// Wait for completion or cancellation. After aborting, wait for the
+	// rejection so the handlers are not released while still referenced.
+	select {
+	case <-done:
+	case <-ctx.Done():
+		controller.Call("abort")
+		<-done
+		if fetchErr != nil {
+			fetchErr = ctx.Err()
+		}
+	}
 
 	// Clean up
 	thenFunc.Release()
+	textFunc.Release()
+	headerFunc.Release()
 	catchFunc.Release()
 
 	if fetchErr != nil {
+		trail.API(method, url, 0, time.Since(start))
 		return nil, fetchErr
 	}
 
+	trail.API(method, url, response.Status, time.Since(start))
 	return response, nil
 }
 
@@ -0,0 +1,373 @@
+//go:build js && wasm
+
+// Package sse reads Server-Sent Events over the browser's fetch API. Unlike
+// EventSource it can send headers such as Authorization, and after a dropped
+// connection it reconnects with Last-Event-ID so the server can replay the
+// events that were missed.
+//
+//	stream, err := sse.Connect(ctx, "/api/events", &sse.Options{
+//		AuthProvider: func() string { return "Bearer " + auth.GetToken() },
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for ev := range stream.Events() {
+//		fmt.Println(ev.Event, ev.Data)
+//	}
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+// Event is one Server-Sent Event
+type Event struct {
+	ID    string // the last event ID the stream has seen
+	Event string // event name, "message" when the server sent none
+	Data  string
+}
+
+// Options configures a stream
+type Options struct {
+	// Headers are sent with every connection attempt
+	Headers map[string]string
+
+	// AuthProvider returns the Authorization header value. It is called for
+	// every connection attempt, so refreshed tokens are picked up.
+	AuthProvider func() string
+
+	// Credentials sends cookies on cross-origin requests
+	Credentials bool
+
+	// RetryDelay is the wait before reconnecting (default 3 seconds). A
+	// retry field from the server replaces it.
+	RetryDelay time.Duration
+
+	// LastEventID resumes a stream from an event seen earlier
+	LastEventID string
+
+	// OnOpen is called whenever a connection opens, including reconnects
+	OnOpen func()
+
+	// OnError is called when a connection drops or a reconnect fails,
+	// before the next attempt
+	OnError func(err error)
+}
+
+// StatusError is a non-2xx response to a connection attempt
+type StatusError struct {
+	Status     int
+	StatusText string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sse: unexpected status %d %s", e.Status, e.StatusText)
+}
+
+// ErrNotEventStream is returned when the response is not text/event-stream
+var ErrNotEventStream = errors.New("sse: response is not an event stream")
+
+// errNoContent means the server answered 204 to stop the client reconnecting
+var errNoContent = errors.New("sse: no content")
+
+// Stream is an open event stream
+type Stream struct {
+	url    string
+	opts   Options
+	events chan Event
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	lastID string
+	retry  time.Duration
+	err    error
+}
+
+// Connect opens an event stream at url. It returns once the server has
+// accepted the connection, or with the error from that first attempt; a
+// *StatusError carries the response. Later drops are retried until ctx is
+// canceled, Close is called, or the server refuses with a 4xx status or 204.
+func Connect(ctx context.Context, url string, opts *Options) (*Stream, error) {
+	s := &Stream{url: url, events: make(chan Event)}
+	if opts != nil {
+		s.opts = *opts
+	}
+	s.lastID = s.opts.LastEventID
+	s.retry = s.opts.RetryDelay
+	if s.retry <= 0 {
+		s.retry = 3 * time.Second
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	reader, stop, err := s.open(ctx)
+	if err != nil {
+		s.cancel()
+		if errors.Is(err, errNoContent) {
+			close(s.events)
+			return s, nil
+		}
+		return nil, err
+	}
+	go s.run(ctx, reader, stop)
+	return s, nil
+}
+
+// Events delivers the events in order. It is closed when the stream ends.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// LastEventID returns the ID of the last event received
+func (s *Stream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastID
+}
+
+// Err returns why the stream ended, or nil if it was closed or its context
+// was canceled
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the stream
+func (s *Stream) Close() {
+	s.cancel()
+}
+
+// run reads the stream, reconnecting after drops, until it ends
+func (s *Stream) run(ctx context.Context, reader js.Value, stop func() bool) {
+	defer close(s.events)
+	for {
+		err := s.read(ctx, reader)
+		stop()
+		if ctx.Err() != nil {
+			return
+		}
+		for {
+			s.fail(err)
+
+			s.mu.Lock()
+			delay := s.retry
+			s.mu.Unlock()
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			reader, stop, err = s.open(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if fatal(err) {
+				if !errors.Is(err, errNoContent) {
+					s.mu.Lock()
+					s.err = err
+					s.mu.Unlock()
+				}
+				return
+			}
+		}
+	}
+}
+
+func (s *Stream) fail(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+// fatal reports whether a failed connection attempt should not be retried
+func fatal(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status < 500
+	}
+	return errors.Is(err, errNoContent) || errors.Is(err, ErrNotEventStream)
+}
+
+// open makes one connection attempt. It returns the body reader and a func
+// that releases the abort hook once the connection is done with.
+func (s *Stream) open(ctx context.Context) (js.Value, func() bool, error) {
+	headers := js.Global().Get("Object").New()
+	headers.Set("Accept", "text/event-stream")
+	headers.Set("Cache-Control", "no-cache")
+	for k, v := range s.opts.Headers {
+		headers.Set(k, v)
+	}
+	if s.opts.AuthProvider != nil {
+		if auth := s.opts.AuthProvider(); auth != "" {
+			headers.Set("Authorization", auth)
+		}
+	}
+	if id := s.LastEventID(); id != "" {
+		headers.Set("Last-Event-ID", id)
+	}
+
+	controller := js.Global().Get("AbortController").New()
+	init := js.Global().Get("Object").New()
+	init.Set("headers", headers)
+	init.Set("signal", controller.Get("signal"))
+	if s.opts.Credentials {
+		init.Set("credentials", "include")
+	}
+	// Abort the request, and any read in progress, when the stream ends
+	stop := context.AfterFunc(ctx, func() { controller.Call("abort") })
+
+	resp, err := jsutil.Await(context.Background(), js.Global().Call("fetch", s.url, init), requestError)
+	if err != nil {
+		stop()
+		return js.Value{}, nil, err
+	}
+	status := resp.Get("status").Int()
+	if status == 204 {
+		stop()
+		return js.Value{}, nil, errNoContent
+	}
+	if !resp.Get("ok").Bool() {
+		body, _ := jsutil.Await(context.Background(), resp.Call("text"), requestError)
+		statusErr := &StatusError{Status: status, StatusText: resp.Get("statusText").String()}
+		if body.Type() == js.TypeString {
+			statusErr.Body = body.String()
+		}
+		stop()
+		return js.Value{}, nil, statusErr
+	}
+	contentType := resp.Get("headers").Call("get", "Content-Type")
+	if contentType.IsNull() || !strings.HasPrefix(contentType.String(), "text/event-stream") {
+		controller.Call("abort")
+		stop()
+		return js.Value{}, nil, ErrNotEventStream
+	}
+
+	if s.opts.OnOpen != nil {
+		s.opts.OnOpen()
+	}
+	return resp.Get("body").Call("getReader"), stop, nil
+}
+
+// read parses events from one connection until it ends
+func (s *Stream) read(ctx context.Context, reader js.Value) error {
+	decoder := js.Global().Get("TextDecoder").New()
+	decodeOpts := js.Global().Get("Object").New()
+	decodeOpts.Set("stream", true)
+
+	p := parser{lastID: s.LastEventID()}
+	for {
+		chunk, err := jsutil.Await(context.Background(), reader.Call("read"), requestError)
+		if err != nil {
+			return err
+		}
+		if chunk.Get("done").Bool() {
+			return io.EOF
+		}
+		text := decoder.Call("decode", chunk.Get("value"), decodeOpts).String()
+
+		for _, ev := range p.feed(text) {
+			select {
+			case s.events <- ev:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		s.mu.Lock()
+		s.lastID = p.lastID
+		if p.retry > 0 {
+			s.retry = p.retry
+		}
+		s.mu.Unlock()
+	}
+}
+
+// parser turns the text of an event stream into events, following the
+// WHATWG event stream interpretation rules
+type parser struct {
+	buf    string
+	event  string
+	data   strings.Builder
+	lastID string
+	retry  time.Duration
+}
+
+// feed adds text and returns the events it completes
+func (p *parser) feed(text string) []Event {
+	p.buf += text
+	var events []Event
+	for {
+		i := strings.IndexAny(p.buf, "\r\n")
+		// A trailing \r may be the first half of \r\n
+		if i < 0 || (p.buf[i] == '\r' && i == len(p.buf)-1) {
+			return events
+		}
+		line := p.buf[:i]
+		if p.buf[i] == '\r' && p.buf[i+1] == '\n' {
+			i++
+		}
+		p.buf = p.buf[i+1:]
+
+		if ev, ok := p.line(line); ok {
+			events = append(events, ev)
+		}
+	}
+}
+
+// line processes one line and reports whether it completed an event
+func (p *parser) line(line string) (Event, bool) {
+	if line == "" {
+		defer func() {
+			p.event = ""
+			p.data.Reset()
+		}()
+		if p.data.Len() == 0 {
+			return Event{}, false
+		}
+		ev := Event{ID: p.lastID, Event: p.event, Data: strings.TrimSuffix(p.data.String(), "\n")}
+		if ev.Event == "" {
+			ev.Event = "message"
+		}
+		return ev, true
+	}
+	if strings.HasPrefix(line, ":") {
+		return Event{}, false // comment, e.g. a keep-alive ping
+	}
+
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "event":
+		p.event = value
+	case "data":
+		p.data.WriteString(value)
+		p.data.WriteByte('\n')
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			p.lastID = value
+		}
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 && !strings.ContainsAny(value, "+-") {
+			p.retry = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return Event{}, false
+}
+
+// requestError is a rejected fetch's error: its message, or "sse: request failed"
+var requestError = jsutil.Message("sse: request failed")
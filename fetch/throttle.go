@@ -0,0 +1,330 @@
+//go:build js && wasm
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServiceState is what a Throttle knows about the server's availability
+type ServiceState int
+
+const (
+	// ServiceAvailable sends requests as usual
+	ServiceAvailable ServiceState = iota
+	// ServiceThrottled follows a 429: requests wait for the Retry-After
+	ServiceThrottled
+	// ServiceMaintenance follows a 503 with a Retry-After, such as during a
+	// deploy: requests wait for the Retry-After
+	ServiceMaintenance
+)
+
+func (s ServiceState) String() string {
+	switch s {
+	case ServiceThrottled:
+		return "throttled"
+	case ServiceMaintenance:
+		return "maintenance"
+	}
+	return "available"
+}
+
+// ServiceStatus is reported to a Throttle's subscribers
+type ServiceStatus struct {
+	State  ServiceState
+	Until  time.Time // when waiting requests are sent again
+	Queued int       // requests waiting for Until
+}
+
+// ErrServiceUnavailable is matched by the errors of requests refused by a
+// Throttle
+var ErrServiceUnavailable = errors.New("service unavailable")
+
+// ServiceUnavailableError is returned for a request refused because the
+// server asked to wait longer than ThrottleOptions.MaxWait
+type ServiceUnavailableError struct {
+	State      ServiceState
+	RetryAfter time.Duration // until the server expects requests again
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return fmt.Sprintf("service %s, retry in %s", e.State, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrServiceUnavailable) match
+func (e *ServiceUnavailableError) Is(target error) bool {
+	return target == ErrServiceUnavailable
+}
+
+// ThrottleOptions configures a Throttle
+type ThrottleOptions struct {
+	// MaxWait is the longest a request waits for a Retry-After (default
+	// 2 minutes). While the server asks for a longer wait, requests fail at
+	// once with a ServiceUnavailableError.
+	MaxWait time.Duration
+
+	// DefaultWait is the wait after a 429 without a Retry-After header
+	// (default 5 seconds). A 503 without one is an ordinary server error.
+	DefaultWait time.Duration
+
+	// MaxRetries is how many times a request is sent again after waiting
+	// (default 3)
+	MaxRetries int
+
+	// Queue reports whether a request that got a 429 or 503 may wait and be
+	// sent again. The default allows the requests Options.Retries would
+	// retry: GET, HEAD, PUT, DELETE, and requests with an Idempotency-Key.
+	// Requests that are refused return the response as is.
+	Queue func(ctx context.Context, url string, opts *Options) bool
+}
+
+// Throttle makes the client back off when the server asks it to. A 429, or
+// a 503 with a Retry-After header, holds every request until the
+// Retry-After has passed; queued requests are then sent again, so a deploy
+// window shows as a short wait rather than a wall of errors.
+//
+// Install one with UseThrottle to cover every request made with this
+// package, including generated API clients, and show its status with
+// Subscribe or the components.ServiceBanner.
+type Throttle struct {
+	opts        ThrottleOptions
+	mu          sync.Mutex
+	state       ServiceState
+	until       time.Time
+	queued      int
+	resume      chan struct{} // closed by Resume to wake waiting requests
+	subscribers map[int]func(ServiceStatus)
+	nextID      int
+}
+
+// NewThrottle creates a Throttle
+func NewThrottle(opts ThrottleOptions) *Throttle {
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = 2 * time.Minute
+	}
+	if opts.DefaultWait <= 0 {
+		opts.DefaultWait = 5 * time.Second
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.Queue == nil {
+		opts.Queue = func(ctx context.Context, url string, opts *Options) bool {
+			return retryable(ctx, opts)
+		}
+	}
+	return &Throttle{
+		opts:        opts,
+		resume:      make(chan struct{}),
+		subscribers: make(map[int]func(ServiceStatus)),
+	}
+}
+
+var (
+	throttleMu     sync.RWMutex
+	activeThrottle *Throttle
+)
+
+// UseThrottle makes every request made with this package go through t.
+// Pass nil to turn the throttle off.
+func UseThrottle(t *Throttle) {
+	throttleMu.Lock()
+	activeThrottle = t
+	throttleMu.Unlock()
+}
+
+// CurrentThrottle returns the throttle installed with UseThrottle, or nil
+func CurrentThrottle() *Throttle {
+	throttleMu.RLock()
+	defer throttleMu.RUnlock()
+	return activeThrottle
+}
+
+// RetryAfter returns the wait a response's Retry-After header asks for,
+// given in seconds or as an HTTP date
+func RetryAfter(resp *Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if t, err := time.Parse(httpTimeFormat, value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// httpTimeFormat is net/http's TimeFormat, kept here so the client doesn't
+// link net/http
+const httpTimeFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+// hold reports whether resp asks the client to wait, and for how long
+func (t *Throttle) hold(resp *Response) (ServiceState, time.Duration, bool) {
+	if resp == nil {
+		return ServiceAvailable, 0, false
+	}
+	wait, ok := RetryAfter(resp)
+	switch {
+	case resp.Status == 429 && ok:
+		return ServiceThrottled, wait, true
+	case resp.Status == 429:
+		return ServiceThrottled, t.opts.DefaultWait, true
+	case resp.Status == 503 && ok:
+		return ServiceMaintenance, wait, true
+	}
+	return ServiceAvailable, 0, false
+}
+
+// fetch performs a request, waiting while the server asked the client to
+// hold off and sending queueable requests again after a 429 or 503. A nil
+// Throttle just sends the request.
+func (t *Throttle) fetch(ctx context.Context, url string, opts *Options) (*Response, error) {
+	if t == nil {
+		return guardedFetch(ctx, url, opts)
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	for retry := 0; ; retry++ {
+		if err := t.wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := guardedFetch(ctx, url, opts)
+		state, wait, held := t.hold(resp)
+		if !held {
+			if err == nil {
+				t.recovered()
+			}
+			return resp, err
+		}
+		t.start(state, wait)
+		if retry >= t.opts.MaxRetries || wait > t.opts.MaxWait || !t.opts.Queue(ctx, url, opts) {
+			return resp, err
+		}
+	}
+}
+
+// wait blocks while requests are held, until the hold ends, Resume is
+// called, or ctx is done
+func (t *Throttle) wait(ctx context.Context) error {
+	t.mu.Lock()
+	remaining := time.Until(t.until)
+	if remaining <= 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	if remaining > t.opts.MaxWait {
+		state := t.state
+		t.mu.Unlock()
+		return &ServiceUnavailableError{State: state, RetryAfter: remaining}
+	}
+	t.queued++
+	resume := t.resume
+	t.mu.Unlock()
+	t.notify()
+
+	defer func() {
+		t.mu.Lock()
+		t.queued--
+		t.mu.Unlock()
+		t.notify()
+	}()
+
+	// Up to 10% jitter so the queue doesn't hit the server all at once
+	timer := time.NewTimer(remaining + time.Duration(rand.Int64N(int64(remaining)/10+1)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-resume:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// start holds requests for wait, or longer if an earlier hold ends later
+func (t *Throttle) start(state ServiceState, wait time.Duration) {
+	t.mu.Lock()
+	if until := time.Now().Add(wait); until.After(t.until) {
+		t.until = until
+	}
+	changed := t.state != state
+	t.state = state
+	t.mu.Unlock()
+	if changed {
+		t.notify()
+	}
+}
+
+// recovered marks the service available after a response that wasn't held,
+// once the hold has ended
+func (t *Throttle) recovered() {
+	t.mu.Lock()
+	if t.state == ServiceAvailable || time.Now().Before(t.until) {
+		t.mu.Unlock()
+		return
+	}
+	t.state = ServiceAvailable
+	t.until = time.Time{}
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Resume ends the hold now, sending the waiting requests at once, e.g. from
+// a "Retry now" button
+func (t *Throttle) Resume() {
+	t.mu.Lock()
+	t.until = time.Time{}
+	close(t.resume)
+	t.resume = make(chan struct{})
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Status returns the current service status
+func (t *Throttle) Status() ServiceStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return ServiceStatus{State: t.state, Until: t.until, Queued: t.queued}
+}
+
+// Subscribe calls fn whenever the service status changes or a request
+// starts or stops waiting, e.g. to show a maintenance banner. It returns a
+// function that unsubscribes.
+func (t *Throttle) Subscribe(fn func(ServiceStatus)) func() {
+	t.mu.Lock()
+	id := t.nextID
+	t.nextID++
+	t.subscribers[id] = fn
+	t.mu.Unlock()
+	return func() {
+		t.mu.Lock()
+		delete(t.subscribers, id)
+		t.mu.Unlock()
+	}
+}
+
+func (t *Throttle) notify() {
+	t.mu.Lock()
+	status := ServiceStatus{State: t.state, Until: t.until, Queued: t.queued}
+	fns := make([]func(ServiceStatus), 0, len(t.subscribers))
+	for _, fn := range t.subscribers {
+		fns = append(fns, fn)
+	}
+	t.mu.Unlock()
+	for _, fn := range fns {
+		fn(status)
+	}
+}
@@ -0,0 +1,192 @@
+//go:build js && wasm
+
+package files
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+// DirectoryOptions configures OpenDirectory
+type DirectoryOptions struct {
+	Write   bool   // ask for write access along with the directory
+	ID      string // remembers the picker's last directory per ID
+	StartIn string
+}
+
+// Directory is a directory the user opened. Without the API it is a
+// read-only snapshot of the files in it, whose Save downloads.
+type Directory struct {
+	name   string
+	handle js.Value         // FileSystemDirectoryHandle, undefined in the fallback
+	files  map[string]*File // the fallback's files, by path below the directory
+}
+
+// DirEntry is a file or directory in a Directory
+type DirEntry struct {
+	Name string
+	Dir  bool
+}
+
+// OpenDirectory shows the directory picker and returns the directory
+// chosen
+func OpenDirectory(ctx context.Context, opts DirectoryOptions) (*Directory, error) {
+	if js.Global().Get("showDirectoryPicker").Type() != js.TypeFunction {
+		list, err := pick(ctx, "", false, true)
+		if err != nil {
+			return nil, err
+		}
+		d := &Directory{handle: js.Undefined(), files: make(map[string]*File, list.Length())}
+		for i := 0; i < list.Length(); i++ {
+			blob := list.Index(i)
+			// webkitRelativePath starts with the directory's own name
+			root, rel, _ := strings.Cut(blob.Get("webkitRelativePath").String(), "/")
+			d.name = root
+			d.files[rel] = &File{name: blob.Get("name").String(), handle: js.Undefined(), blob: blob}
+		}
+		return d, nil
+	}
+
+	options := pickerOptions(nil, opts.ID, opts.StartIn)
+	if opts.Write {
+		options["mode"] = "readwrite"
+	}
+	handle, err := jsutil.Await(ctx, js.Global().Call("showDirectoryPicker", options), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return &Directory{name: handle.Get("name").String(), handle: handle}, nil
+}
+
+// Name returns the directory's name
+func (d *Directory) Name() string {
+	return d.name
+}
+
+// Value returns the FileSystemDirectoryHandle, or undefined when the
+// directory was opened through the fallback
+func (d *Directory) Value() js.Value {
+	return d.handle
+}
+
+// Entries lists the directory's files and subdirectories, directories
+// first, each sorted by name
+func (d *Directory) Entries(ctx context.Context) ([]DirEntry, error) {
+	var entries []DirEntry
+	if d.handle.Truthy() {
+		it := d.handle.Call("values")
+		for {
+			next, err := jsutil.Await(ctx, it.Call("next"), jsError)
+			if err != nil {
+				return nil, err
+			}
+			if next.Get("done").Bool() {
+				break
+			}
+			handle := next.Get("value")
+			entries = append(entries, DirEntry{Name: handle.Get("name").String(), Dir: handle.Get("kind").String() == "directory"})
+		}
+	} else {
+		seen := make(map[string]bool)
+		for path := range d.files {
+			name, _, dir := strings.Cut(path, "/")
+			if !seen[name] {
+				seen[name] = true
+				entries = append(entries, DirEntry{Name: name, Dir: dir})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// File returns the file named name in the directory, or ErrNotFound
+func (d *Directory) File(ctx context.Context, name string) (*File, error) {
+	if !d.handle.Truthy() {
+		if f, ok := d.files[name]; ok {
+			return f, nil
+		}
+		return nil, ErrNotFound
+	}
+	handle, err := jsutil.Await(ctx, d.handle.Call("getFileHandle", name), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return fileFromHandle(handle), nil
+}
+
+// CreateFile returns the file named name in the directory, creating it
+// empty if it doesn't exist. Without the API the file isn't created, and
+// its Save downloads.
+func (d *Directory) CreateFile(ctx context.Context, name string) (*File, error) {
+	if !d.handle.Truthy() {
+		if f, ok := d.files[name]; ok {
+			return f, nil
+		}
+		return &File{name: name, handle: js.Undefined()}, nil
+	}
+	handle, err := jsutil.Await(ctx, d.handle.Call("getFileHandle", name, map[string]any{"create": true}), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return fileFromHandle(handle), nil
+}
+
+// Directory returns the subdirectory named name, creating it when create
+// is set
+func (d *Directory) Directory(ctx context.Context, name string, create bool) (*Directory, error) {
+	if !d.handle.Truthy() {
+		sub := &Directory{name: name, handle: js.Undefined(), files: make(map[string]*File)}
+		for path, f := range d.files {
+			if rel, ok := strings.CutPrefix(path, name+"/"); ok {
+				sub.files[rel] = f
+			}
+		}
+		if len(sub.files) == 0 && !create {
+			return nil, ErrNotFound
+		}
+		return sub, nil
+	}
+	handle, err := jsutil.Await(ctx, d.handle.Call("getDirectoryHandle", name, map[string]any{"create": create}), jsError)
+	if err != nil {
+		return nil, err
+	}
+	return &Directory{name: handle.Get("name").String(), handle: handle}, nil
+}
+
+// Remove deletes the file or directory named name, with everything in it.
+// The fallback returns ErrNotSupported.
+func (d *Directory) Remove(ctx context.Context, name string) error {
+	if !d.handle.Truthy() {
+		return ErrNotSupported
+	}
+	_, err := jsutil.Await(ctx, d.handle.Call("removeEntry", name, map[string]any{"recursive": true}), jsError)
+	return err
+}
+
+// Permission returns the access granted to the directory, for writing
+// when write is set, without asking
+func (d *Directory) Permission(ctx context.Context, write bool) (Permission, error) {
+	if !d.handle.Truthy() && write {
+		return Denied, nil
+	}
+	return permission(ctx, d.handle, write, false)
+}
+
+// RequestPermission asks the user for access to the directory. Call it
+// during a user gesture.
+func (d *Directory) RequestPermission(ctx context.Context, write bool) (Permission, error) {
+	if !d.handle.Truthy() && write {
+		return Denied, nil
+	}
+	return permission(ctx, d.handle, write, true)
+}
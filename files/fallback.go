@@ -0,0 +1,118 @@
+//go:build js && wasm
+
+package files
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"syscall/js"
+)
+
+// pick shows a hidden file input's picker and returns the FileList chosen.
+// Browsers that fire no cancel event leave it waiting until ctx is done.
+func pick(ctx context.Context, accept string, multiple, directory bool) (js.Value, error) {
+	document := js.Global().Get("document")
+	input := document.Call("createElement", "input")
+	input.Set("type", "file")
+	if accept != "" {
+		input.Set("accept", accept)
+	}
+	input.Set("multiple", multiple)
+	if directory {
+		input.Set("webkitdirectory", true)
+	}
+	input.Get("style").Set("display", "none")
+	document.Get("body").Call("appendChild", input)
+
+	done := make(chan js.Value, 1)
+	change := js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- input.Get("files")
+		return nil
+	})
+	cancel := js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- js.Null()
+		return nil
+	})
+	input.Call("addEventListener", "change", change)
+	input.Call("addEventListener", "cancel", cancel)
+	defer func() {
+		input.Call("removeEventListener", "change", change)
+		input.Call("removeEventListener", "cancel", cancel)
+		change.Release()
+		cancel.Release()
+		input.Call("remove")
+	}()
+	input.Call("click")
+
+	select {
+	case list := <-done:
+		if !list.Truthy() || list.Length() == 0 {
+			return js.Undefined(), ErrCanceled
+		}
+		return list, nil
+	case <-ctx.Done():
+		return js.Undefined(), ctx.Err()
+	}
+}
+
+// accept returns the file input's accept attribute for types
+func accept(types []FileType) string {
+	var parts []string
+	for _, t := range types {
+		mimeTypes := make([]string, 0, len(t.Accept))
+		for mimeType := range t.Accept {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+		sort.Strings(mimeTypes)
+		for _, mimeType := range mimeTypes {
+			parts = append(parts, mimeType)
+			parts = append(parts, t.Accept[mimeType]...)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// firstMIMEType returns the first MIME type types accept, in sorted order
+func firstMIMEType(types []FileType) string {
+	for _, t := range types {
+		mimeTypes := make([]string, 0, len(t.Accept))
+		for mimeType := range t.Accept {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+		if len(mimeTypes) > 0 {
+			sort.Strings(mimeTypes)
+			return mimeTypes[0]
+		}
+	}
+	return ""
+}
+
+// download hands data to the browser's download prompt through a
+// temporary link
+func download(data []byte, filename, mimeType string) {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	document := js.Global().Get("document")
+	URL := js.Global().Get("URL")
+	blob := js.Global().Get("Blob").New([]any{bytesToJS(data)}, map[string]any{"type": mimeType})
+	objectURL := URL.Call("createObjectURL", blob)
+
+	anchor := document.Call("createElement", "a")
+	anchor.Set("href", objectURL)
+	anchor.Set("download", filename)
+	anchor.Get("style").Set("display", "none")
+	document.Get("body").Call("appendChild", anchor)
+	anchor.Call("click")
+	document.Get("body").Call("removeChild", anchor)
+
+	// Some browsers start reading the Blob after click returns
+	var revoke js.Func
+	revoke = js.FuncOf(func(this js.Value, args []js.Value) any {
+		URL.Call("revokeObjectURL", objectURL)
+		revoke.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", revoke, 1000)
+}
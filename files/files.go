@@ -0,0 +1,339 @@
+//go:build js && wasm
+
+// Package files opens and saves local files with the File System Access
+// API, so in-browser editors can save back to the file the user opened
+// instead of downloading a copy:
+//
+//	f, err := files.OpenFile(ctx, files.OpenOptions{
+//		Types: []files.FileType{{Description: "Config", Accept: map[string][]string{"application/json": {".json"}}}},
+//	})
+//	data, err := f.Read(ctx)
+//	// ... edit ...
+//	err = f.Save(ctx, data)
+//
+// Browsers without the API, such as Firefox and Safari, get the same
+// functions through a file input, and saves become downloads; Supported
+// and File.SavesInPlace tell the UI which it has. Handles can be
+// remembered across visits with Remember, for a list of recent files.
+//
+// The pickers need a user gesture: call these from a goroutine started in
+// a click handler, as they block until the user chooses.
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+var (
+	// ErrCanceled is returned when the user closes a picker without
+	// choosing
+	ErrCanceled = errors.New("files: canceled")
+
+	// ErrPermissionDenied is returned when the user or browser refuses
+	// access to a file or directory
+	ErrPermissionDenied = errors.New("files: permission denied")
+
+	// ErrNotFound is returned for a file, directory, or remembered handle
+	// that doesn't exist
+	ErrNotFound = errors.New("files: not found")
+
+	// ErrNotSupported is returned for what the fallback can't do, such as
+	// removing a file or remembering one
+	ErrNotSupported = errors.New("files: not supported by this browser")
+)
+
+// Supported reports whether the browser has the File System Access API.
+// Without it files open through a file input and Save downloads them.
+func Supported() bool {
+	return js.Global().Get("showOpenFilePicker").Type() == js.TypeFunction
+}
+
+// FileType is a kind of file the pickers offer
+type FileType struct {
+	Description string              // e.g. "JSON config"
+	Accept      map[string][]string // MIME types to extensions, e.g. {"application/json": {".json"}}
+}
+
+// OpenOptions configures OpenFile and Open
+type OpenOptions struct {
+	Types    []FileType
+	Multiple bool   // let Open return more than one file
+	ID       string // remembers the picker's last directory per ID
+	StartIn  string // well-known directory to start in: "documents", "desktop", "downloads", ...
+}
+
+// SaveOptions configures SaveAs
+type SaveOptions struct {
+	Types         []FileType
+	SuggestedName string // default "untitled"
+	ID            string
+	StartIn       string
+}
+
+// Permission is the state of access to a file or directory
+type Permission string
+
+const (
+	// Granted can be read, or written when asked for write access
+	Granted Permission = "granted"
+	// Denied was refused
+	Denied Permission = "denied"
+	// Prompt must be asked for with RequestPermission, during a user
+	// gesture
+	Prompt Permission = "prompt"
+)
+
+// Handle is a File or Directory
+type Handle interface {
+	Name() string
+	// Value returns the FileSystemHandle, or undefined when opened
+	// through the fallback
+	Value() js.Value
+}
+
+// File is a file the user opened or saved
+type File struct {
+	name   string
+	handle js.Value // FileSystemFileHandle, undefined in the fallback
+	blob   js.Value // the File chosen through the fallback
+}
+
+// Name returns the file's name, without its directory
+func (f *File) Name() string {
+	return f.name
+}
+
+// Value returns the FileSystemFileHandle, or undefined when the file was
+// opened through the fallback
+func (f *File) Value() js.Value {
+	return f.handle
+}
+
+// SavesInPlace reports whether Save writes to the file itself, rather than
+// downloading a copy
+func (f *File) SavesInPlace() bool {
+	return f.handle.Truthy()
+}
+
+// Read returns the file's contents, as they are now on disk
+func (f *File) Read(ctx context.Context) ([]byte, error) {
+	blob := f.blob
+	if f.handle.Truthy() {
+		var err error
+		if blob, err = jsutil.Await(ctx, f.handle.Call("getFile"), jsError); err != nil {
+			return nil, err
+		}
+	}
+	if !blob.Truthy() {
+		return nil, ErrNotFound
+	}
+	buf, err := jsutil.Await(ctx, blob.Call("arrayBuffer"), jsError)
+	if err != nil {
+		return nil, err
+	}
+	array := js.Global().Get("Uint8Array").New(buf)
+	data := make([]byte, array.Get("length").Int())
+	js.CopyBytesToGo(data, array)
+	return data, nil
+}
+
+// ReadText returns the file's contents as text
+func (f *File) ReadText(ctx context.Context) (string, error) {
+	data, err := f.Read(ctx)
+	return string(data), err
+}
+
+// Save replaces the file's contents with data, asking for write access if
+// it hasn't been granted. Without a handle it downloads data under the
+// file's name.
+func (f *File) Save(ctx context.Context, data []byte) error {
+	if !f.handle.Truthy() {
+		mimeType := ""
+		if f.blob.Truthy() {
+			mimeType = f.blob.Get("type").String()
+		}
+		download(data, f.name, mimeType)
+		return nil
+	}
+	if p, err := permission(ctx, f.handle, true, true); err != nil {
+		return err
+	} else if p != Granted {
+		return ErrPermissionDenied
+	}
+
+	writable, err := jsutil.Await(ctx, f.handle.Call("createWritable"), jsError)
+	if err != nil {
+		return err
+	}
+	if _, err := jsutil.Await(ctx, writable.Call("write", bytesToJS(data)), jsError); err != nil {
+		writable.Call("abort")
+		return err
+	}
+	_, err = jsutil.Await(ctx, writable.Call("close"), jsError)
+	return err
+}
+
+// Permission returns the access granted to the file, for writing when
+// write is set, without asking. Files from the fallback are always
+// Granted.
+func (f *File) Permission(ctx context.Context, write bool) (Permission, error) {
+	return permission(ctx, f.handle, write, false)
+}
+
+// RequestPermission asks the user for access to the file, which a
+// remembered file needs again after the page reloads. Call it during a
+// user gesture.
+func (f *File) RequestPermission(ctx context.Context, write bool) (Permission, error) {
+	return permission(ctx, f.handle, write, true)
+}
+
+// OpenFile shows the open file picker and returns the file chosen
+func OpenFile(ctx context.Context, opts OpenOptions) (*File, error) {
+	opts.Multiple = false
+	files, err := Open(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// Open shows the open file picker and returns the files chosen, more than
+// one only with Multiple
+func Open(ctx context.Context, opts OpenOptions) ([]*File, error) {
+	if !Supported() {
+		list, err := pick(ctx, accept(opts.Types), opts.Multiple, false)
+		if err != nil {
+			return nil, err
+		}
+		files := make([]*File, list.Length())
+		for i := range files {
+			blob := list.Index(i)
+			files[i] = &File{name: blob.Get("name").String(), handle: js.Undefined(), blob: blob}
+		}
+		return files, nil
+	}
+
+	options := pickerOptions(opts.Types, opts.ID, opts.StartIn)
+	options["multiple"] = opts.Multiple
+	handles, err := jsutil.Await(ctx, js.Global().Call("showOpenFilePicker", options), jsError)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*File, handles.Length())
+	for i := range files {
+		files[i] = fileFromHandle(handles.Index(i))
+	}
+	if len(files) == 0 {
+		return nil, ErrCanceled
+	}
+	return files, nil
+}
+
+// SaveAs shows the save file picker, writes data to the file chosen, and
+// returns it for later saves. Without the API it downloads data as
+// SuggestedName, and the File returned downloads again on Save.
+func SaveAs(ctx context.Context, data []byte, opts SaveOptions) (*File, error) {
+	name := opts.SuggestedName
+	if name == "" {
+		name = "untitled"
+	}
+	if !Supported() {
+		download(data, name, firstMIMEType(opts.Types))
+		return &File{name: name, handle: js.Undefined()}, nil
+	}
+
+	options := pickerOptions(opts.Types, opts.ID, opts.StartIn)
+	options["suggestedName"] = name
+	handle, err := jsutil.Await(ctx, js.Global().Call("showSaveFilePicker", options), jsError)
+	if err != nil {
+		return nil, err
+	}
+	f := fileFromHandle(handle)
+	if err := f.Save(ctx, data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func fileFromHandle(handle js.Value) *File {
+	return &File{name: handle.Get("name").String(), handle: handle, blob: js.Undefined()}
+}
+
+// pickerOptions returns the options the pickers share
+func pickerOptions(types []FileType, id, startIn string) map[string]any {
+	options := map[string]any{}
+	if len(types) > 0 {
+		list := make([]any, len(types))
+		for i, t := range types {
+			acceptMap := make(map[string]any, len(t.Accept))
+			for mimeType, exts := range t.Accept {
+				list := make([]any, len(exts))
+				for j, ext := range exts {
+					list[j] = ext
+				}
+				acceptMap[mimeType] = list
+			}
+			list[i] = map[string]any{"description": t.Description, "accept": acceptMap}
+		}
+		options["types"] = list
+	}
+	if id != "" {
+		options["id"] = id
+	}
+	if startIn != "" {
+		options["startIn"] = startIn
+	}
+	return options
+}
+
+// permission queries, or with request asks for, access to handle
+func permission(ctx context.Context, handle js.Value, write, request bool) (Permission, error) {
+	if !handle.Truthy() {
+		return Granted, nil
+	}
+	mode := "read"
+	if write {
+		mode = "readwrite"
+	}
+	descriptor := map[string]any{"mode": mode}
+	state, err := jsutil.Await(ctx, handle.Call("queryPermission", descriptor), jsError)
+	if err != nil {
+		return "", err
+	}
+	if request && state.String() == string(Prompt) {
+		if state, err = jsutil.Await(ctx, handle.Call("requestPermission", descriptor), jsError); err != nil {
+			return "", err
+		}
+	}
+	return Permission(state.String()), nil
+}
+
+// jsError converts a rejection's DOMException to the package's errors
+func jsError(reason js.Value) error {
+	if !reason.Truthy() {
+		return errors.New("files: failed")
+	}
+	switch reason.Get("name").String() {
+	case "AbortError":
+		return ErrCanceled
+	case "NotAllowedError", "SecurityError":
+		return ErrPermissionDenied
+	case "NotFoundError", "TypeMismatchError":
+		return ErrNotFound
+	}
+	if msg := reason.Get("message"); msg.Truthy() {
+		return fmt.Errorf("files: %s", msg.String())
+	}
+	return fmt.Errorf("files: %s", reason.Call("toString").String())
+}
+
+func bytesToJS(data []byte) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	return array
+}
@@ -0,0 +1,134 @@
+//go:build js && wasm
+
+package files
+
+import (
+	"context"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/internal/jsutil"
+)
+
+// handleDB and handleStore are the IndexedDB database and object store
+// remembered handles are kept in
+const (
+	handleDB    = "gux-files"
+	handleStore = "handles"
+)
+
+// Remember keeps h's handle in IndexedDB under key, so RecallFile or
+// RecallDirectory can reopen it on a later visit without the picker.
+// Browsers keep the access granted for some handles across visits and ask
+// again for others, so check Permission after recalling. The fallback
+// returns ErrNotSupported.
+//
+//	files.Remember(ctx, "config", f)
+//
+//	// on a later visit, in a click handler's goroutine
+//	f, err := files.RecallFile(ctx, "config")
+//	if err == nil {
+//		_, err = f.RequestPermission(ctx, true)
+//	}
+func Remember(ctx context.Context, key string, h Handle) error {
+	if !h.Value().Truthy() {
+		return ErrNotSupported
+	}
+	_, err := storeRequest(ctx, "readwrite", func(store js.Value) js.Value {
+		return store.Call("put", h.Value(), key)
+	})
+	return err
+}
+
+// RecallFile returns the file remembered under key, or ErrNotFound
+func RecallFile(ctx context.Context, key string) (*File, error) {
+	handle, err := recall(ctx, key, "file")
+	if err != nil {
+		return nil, err
+	}
+	return fileFromHandle(handle), nil
+}
+
+// RecallDirectory returns the directory remembered under key, or
+// ErrNotFound
+func RecallDirectory(ctx context.Context, key string) (*Directory, error) {
+	handle, err := recall(ctx, key, "directory")
+	if err != nil {
+		return nil, err
+	}
+	return &Directory{name: handle.Get("name").String(), handle: handle}, nil
+}
+
+// Forget removes the handle remembered under key
+func Forget(ctx context.Context, key string) error {
+	_, err := storeRequest(ctx, "readwrite", func(store js.Value) js.Value {
+		return store.Call("delete", key)
+	})
+	return err
+}
+
+// recall returns the handle of kind remembered under key
+func recall(ctx context.Context, key, kind string) (js.Value, error) {
+	handle, err := storeRequest(ctx, "readonly", func(store js.Value) js.Value {
+		return store.Call("get", key)
+	})
+	if err != nil {
+		return js.Undefined(), err
+	}
+	if !handle.Truthy() || handle.Get("kind").String() != kind {
+		return js.Undefined(), ErrNotFound
+	}
+	return handle, nil
+}
+
+// storeRequest opens the database and runs the request fn makes on the
+// handle store
+func storeRequest(ctx context.Context, mode string, fn func(store js.Value) js.Value) (js.Value, error) {
+	indexedDB := js.Global().Get("indexedDB")
+	if !indexedDB.Truthy() || !Supported() {
+		return js.Undefined(), ErrNotSupported
+	}
+	open := indexedDB.Call("open", handleDB, 1)
+	upgrade := js.FuncOf(func(this js.Value, args []js.Value) any {
+		open.Get("result").Call("createObjectStore", handleStore)
+		return nil
+	})
+	open.Set("onupgradeneeded", upgrade)
+	db, err := request(ctx, open)
+	open.Set("onupgradeneeded", js.Null())
+	upgrade.Release()
+	if err != nil {
+		return js.Undefined(), err
+	}
+	defer db.Call("close")
+
+	store := db.Call("transaction", handleStore, mode).Call("objectStore", handleStore)
+	return request(ctx, fn(store))
+}
+
+// request waits for an IndexedDB request to succeed or fail, or ctx to be
+// done
+func request(ctx context.Context, req js.Value) (js.Value, error) {
+	executor := js.FuncOf(func(this js.Value, args []js.Value) any {
+		resolve, reject := args[0], args[1]
+		var success, failure js.Func
+		success = js.FuncOf(func(this js.Value, _ []js.Value) any {
+			resolve.Invoke(req.Get("result"))
+			success.Release()
+			failure.Release()
+			return nil
+		})
+		failure = js.FuncOf(func(this js.Value, _ []js.Value) any {
+			reject.Invoke(req.Get("error"))
+			success.Release()
+			failure.Release()
+			return nil
+		})
+		req.Set("onsuccess", success)
+		req.Set("onerror", failure)
+		return nil
+	})
+	// The executor runs within New
+	promise := js.Global().Get("Promise").New(executor)
+	executor.Release()
+	return jsutil.Await(ctx, promise, jsError)
+}
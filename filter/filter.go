@@ -0,0 +1,259 @@
+// Package filter is a small search filter language shared by clients and
+// servers. A Filter is a condition on a field, or an and/or group of
+// filters; it travels as one query parameter in a readable form:
+//
+//	status eq active and (total gt 100 or name contains "acme corp")
+//
+// Clients build filters with Where, And, and Or (or the FilterBuilder
+// component) and send them with String. Servers Parse them, limiting the
+// fields that may be filtered on, and turn them into a SQL WHERE clause with
+// SQL or test records in memory with Match.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Op is a comparison operator
+type Op string
+
+// Operators
+const (
+	Eq       Op = "eq"       // equal
+	Ne       Op = "ne"       // not equal
+	Gt       Op = "gt"       // greater than
+	Gte      Op = "gte"      // greater than or equal
+	Lt       Op = "lt"       // less than
+	Lte      Op = "lte"      // less than or equal
+	Contains Op = "contains" // case-insensitive substring
+)
+
+// Ops lists the operators in the order they are usually offered
+var Ops = []Op{Eq, Ne, Gt, Gte, Lt, Lte, Contains}
+
+// opSymbols are accepted by Parse as spellings of the operators
+var opSymbols = map[string]Op{
+	"=":  Eq,
+	"==": Eq,
+	"!=": Ne,
+	">":  Gt,
+	">=": Gte,
+	"<":  Lt,
+	"<=": Lte,
+	"~":  Contains,
+}
+
+// Valid reports whether op is a known operator
+func (op Op) Valid() bool {
+	for _, o := range Ops {
+		if op == o {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter is a condition on one field, or a group of filters that must all
+// match (And) or of which one must match (Or). The zero Filter matches
+// everything.
+type Filter struct {
+	// Field, Op, and Value make a condition
+	Field string
+	Op    Op
+	Value string
+
+	// Filters make a group, joined with "or" if Any is set and "and" if not
+	Filters []Filter
+	Any     bool
+}
+
+// Limits that keep Parse cheap on untrusted input
+const (
+	maxLength     = 4096
+	maxConditions = 50
+	maxDepth      = 8
+)
+
+// Where returns a condition. The value is formatted with fmt.Sprint.
+func Where(field string, op Op, value any) Filter {
+	return Filter{Field: field, Op: op, Value: fmt.Sprint(value)}
+}
+
+// And returns a filter that matches when all of filters match. Zero filters
+// are dropped; a single remaining filter is returned as is.
+func And(filters ...Filter) Filter {
+	return group(false, filters)
+}
+
+// Or returns a filter that matches when any of filters matches. Zero filters
+// are dropped; a single remaining filter is returned as is.
+func Or(filters ...Filter) Filter {
+	return group(true, filters)
+}
+
+func group(or bool, filters []Filter) Filter {
+	var kept []Filter
+	for _, f := range filters {
+		switch {
+		case f.IsZero():
+		case f.isGroup() && f.Any == or:
+			kept = append(kept, f.Filters...) // (a and b) and c is a and b and c
+		default:
+			kept = append(kept, f)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return Filter{}
+	case 1:
+		return kept[0]
+	}
+	return Filter{Filters: kept, Any: or}
+}
+
+// IsZero reports whether the filter is empty and matches everything
+func (f Filter) IsZero() bool {
+	return f.Field == "" && len(f.Filters) == 0
+}
+
+func (f Filter) isGroup() bool {
+	return f.Field == "" && len(f.Filters) > 0
+}
+
+// Fields returns the fields the filter refers to, each once
+func (f Filter) Fields() []string {
+	var fields []string
+	seen := map[string]bool{}
+	f.walk(func(c Filter) {
+		if !seen[c.Field] {
+			seen[c.Field] = true
+			fields = append(fields, c.Field)
+		}
+	})
+	return fields
+}
+
+// walk calls fn for each condition in the filter
+func (f Filter) walk(fn func(Filter)) {
+	if f.Field != "" {
+		fn(f)
+		return
+	}
+	for _, child := range f.Filters {
+		child.walk(fn)
+	}
+}
+
+// Validate checks that every condition has a field and a known operator and,
+// if fields are given, that it only uses those fields
+func (f Filter) Validate(fields ...string) error {
+	var err error
+	f.walk(func(c Filter) {
+		if err != nil {
+			return
+		}
+		switch {
+		case !validField(c.Field):
+			err = fmt.Errorf("invalid field %q", c.Field)
+		case !c.Op.Valid():
+			err = fmt.Errorf("unknown operator %q", c.Op)
+		case len(fields) > 0 && !contains(fields, c.Field):
+			err = fmt.Errorf("cannot filter on %q", c.Field)
+		}
+	})
+	return err
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the filter in the form Parse reads, or "" for the zero
+// Filter
+func (f Filter) String() string {
+	var b strings.Builder
+	f.write(&b, false)
+	return b.String()
+}
+
+func (f Filter) write(b *strings.Builder, nested bool) {
+	if f.Field != "" {
+		b.WriteString(f.Field + " " + string(f.Op) + " " + quote(f.Value))
+		return
+	}
+	if nested && len(f.Filters) > 1 {
+		b.WriteByte('(')
+		defer b.WriteByte(')')
+	}
+	join := " and "
+	if f.Any {
+		join = " or "
+	}
+	for i, child := range f.Filters {
+		if i > 0 {
+			b.WriteString(join)
+		}
+		child.write(b, true)
+	}
+}
+
+// quote leaves simple values bare and quotes the rest
+func quote(v string) string {
+	if v == "" || isKeyword(v) || opSymbols[v] != "" {
+		return strconv.Quote(v)
+	}
+	for _, r := range v {
+		if !isBareRune(r) {
+			return strconv.Quote(v)
+		}
+	}
+	return v
+}
+
+func isBareRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("_.-:@+", r)
+}
+
+func isKeyword(s string) bool {
+	switch strings.ToLower(s) {
+	case "and", "or":
+		return true
+	}
+	return Op(strings.ToLower(s)).Valid()
+}
+
+func validField(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' || i > 0 && (r >= '0' && r <= '9' || r == '.')) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalText returns String, so filters can be used as JSON values and
+// query parameters
+func (f Filter) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText parses the form returned by String
+func (f *Filter) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
@@ -0,0 +1,217 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dougbarrett/gux/types"
+)
+
+// Match reports whether record satisfies the filter, for in-memory data and
+// client-side tables. record is a map[string]any or a struct, or a pointer
+// to one; struct fields are found by json tag, then by case-insensitive
+// name, and dotted fields such as "author.name" look inside nested values.
+//
+// Numbers, types.Money amounts, times (RFC 3339 or 2006-01-02), and
+// booleans are compared as such; other values as strings. A condition on a missing field, or whose
+// value cannot be compared, does not match, except ne.
+func (f Filter) Match(record any) bool {
+	if f.Field == "" {
+		if len(f.Filters) == 0 {
+			return true
+		}
+		for _, child := range f.Filters {
+			if child.Match(record) == f.Any {
+				return f.Any
+			}
+		}
+		return !f.Any
+	}
+
+	actual, found := lookup(reflect.ValueOf(record), strings.Split(f.Field, "."))
+	c, ok := 0, false
+	if found {
+		if f.Op == Contains {
+			return strings.Contains(strings.ToLower(fmt.Sprint(actual)), strings.ToLower(f.Value))
+		}
+		c, ok = compare(actual, f.Value)
+	}
+
+	switch f.Op {
+	case Eq:
+		return ok && c == 0
+	case Ne:
+		return !(ok && c == 0)
+	case Gt:
+		return ok && c > 0
+	case Gte:
+		return ok && c >= 0
+	case Lt:
+		return ok && c < 0
+	case Lte:
+		return ok && c <= 0
+	}
+	return false
+}
+
+// Apply returns the items that match the filter
+func Apply[T any](items []T, f Filter) []T {
+	if f.IsZero() {
+		return items
+	}
+	kept := items[:0:0]
+	for _, item := range items {
+		if f.Match(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// lookup finds the value at path in v
+func lookup(v reflect.Value, path []string) (any, bool) {
+	for _, name := range path {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return nil, false
+			}
+		case reflect.Struct:
+			field, ok := structField(v, name)
+			if !ok {
+				return nil, false
+			}
+			v = field
+		default:
+			return nil, false
+		}
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, true
+	}
+	return v.Interface(), true
+}
+
+// structField finds an exported field by json tag or case-insensitive name
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	var byName reflect.Value
+	for _, sf := range reflect.VisibleFields(v.Type()) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if tag == name {
+			return v.FieldByIndex(sf.Index), true
+		}
+		if tag == "" && !byName.IsValid() && strings.EqualFold(sf.Name, name) {
+			byName = v.FieldByIndex(sf.Index)
+		}
+	}
+	return byName, byName.IsValid()
+}
+
+// compare orders actual against the filter value, reporting false if they
+// cannot be compared
+func compare(actual any, value string) (int, bool) {
+	switch a := actual.(type) {
+	case nil:
+		return strings.Compare("", value), true
+	case time.Time:
+		t, ok := parseTime(value)
+		if !ok {
+			return 0, false
+		}
+		return a.Compare(t), true
+	case types.Money:
+		m, err := types.Parse(value, a.Currency())
+		if err != nil {
+			return 0, false
+		}
+		return a.Cmp(m), true
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case a == b:
+			return 0, true
+		case b:
+			return -1, true
+		}
+		return 1, true
+	case string:
+		// Strings that hold numbers or times, as in table rows, compare as such
+		if x, err := strconv.ParseFloat(a, 64); err == nil {
+			if y, err := strconv.ParseFloat(value, 64); err == nil {
+				return compareFloat(x, y), true
+			}
+		}
+		if x, ok := parseTime(a); ok {
+			if y, ok := parseTime(value); ok {
+				return x.Compare(y), true
+			}
+		}
+		return strings.Compare(a, value), true
+	}
+
+	rv := reflect.ValueOf(actual)
+	var x float64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		x = rv.Float()
+	default:
+		return strings.Compare(fmt.Sprint(actual), value), true
+	}
+	y, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return compareFloat(x, y), true
+}
+
+func compareFloat(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func parseTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
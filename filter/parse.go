@@ -0,0 +1,235 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse reads a filter in the form returned by String:
+//
+//	field op value [and|or field op value ...]
+//
+// Operators are eq, ne, gt, gte, lt, lte, and contains, or =, !=, >, >=, <,
+// <=, and ~. Values containing spaces or symbols are double-quoted. "and"
+// binds tighter than "or"; parentheses group. Keywords are
+// case-insensitive. An empty string is the zero Filter.
+//
+// If fields are given, conditions on any other field are rejected, so a
+// server only filters on what it means to expose.
+func Parse(s string, fields ...string) (Filter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Filter{}, nil
+	}
+	if len(s) > maxLength {
+		return Filter{}, fmt.Errorf("filter is longer than %d characters", maxLength)
+	}
+	tokens, err := lex(s)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	p := &parser{tokens: tokens}
+	f, err := p.or(0)
+	if err != nil {
+		return Filter{}, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return Filter{}, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	if err := f.Validate(fields...); err != nil {
+		return Filter{}, err
+	}
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokSymbol
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string // unquoted for strings
+	pos  int
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			text, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d", i)
+			}
+			tokens = append(tokens, token{tokString, text, i})
+			i = end + 1
+		case strings.IndexByte("=!<>~", c) >= 0:
+			end := i + 1
+			for end < len(s) && strings.IndexByte("=!<>~", s[end]) >= 0 {
+				end++
+			}
+			if _, ok := opSymbols[s[i:end]]; !ok {
+				return nil, fmt.Errorf("unknown operator %q at %d", s[i:end], i)
+			}
+			tokens = append(tokens, token{tokSymbol, s[i:end], i})
+			i = end
+		default:
+			end := i
+			for end < len(s) && isBareRune(rune(s[end])) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected %q at %d", s[i:i+1], i)
+			}
+			tokens = append(tokens, token{tokWord, s[i:end], i})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens     []token
+	pos        int
+	conditions int
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	end := 0
+	if len(p.tokens) > 0 {
+		last := p.tokens[len(p.tokens)-1]
+		end = last.pos + len(last.text)
+	}
+	return token{kind: tokEOF, pos: end}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the word kw, consuming it if so
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or(depth int) (Filter, error) {
+	var parts []Filter
+	for {
+		f, err := p.and(depth)
+		if err != nil {
+			return Filter{}, err
+		}
+		parts = append(parts, f)
+		if !p.keyword("or") {
+			return Or(parts...), nil
+		}
+	}
+}
+
+func (p *parser) and(depth int) (Filter, error) {
+	var parts []Filter
+	for {
+		f, err := p.unary(depth)
+		if err != nil {
+			return Filter{}, err
+		}
+		parts = append(parts, f)
+		if !p.keyword("and") {
+			return And(parts...), nil
+		}
+	}
+}
+
+func (p *parser) unary(depth int) (Filter, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		if depth >= maxDepth {
+			return Filter{}, fmt.Errorf("filter is nested more than %d levels deep", maxDepth)
+		}
+		f, err := p.or(depth + 1)
+		if err != nil {
+			return Filter{}, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return Filter{}, unexpected(closing, "\")\"")
+		}
+		return f, nil
+	case tokWord:
+		return p.condition(t)
+	}
+	return Filter{}, unexpected(t, "a field")
+}
+
+func (p *parser) condition(field token) (Filter, error) {
+	if !validField(field.text) {
+		return Filter{}, fmt.Errorf("invalid field %q at %d", field.text, field.pos)
+	}
+	p.conditions++
+	if p.conditions > maxConditions {
+		return Filter{}, fmt.Errorf("filter has more than %d conditions", maxConditions)
+	}
+
+	var op Op
+	switch t := p.next(); {
+	case t.kind == tokSymbol:
+		op = opSymbols[t.text]
+	case t.kind == tokWord && Op(strings.ToLower(t.text)).Valid():
+		op = Op(strings.ToLower(t.text))
+	default:
+		return Filter{}, unexpected(t, "an operator")
+	}
+
+	switch t := p.next(); t.kind {
+	case tokWord, tokString:
+		return Filter{Field: field.text, Op: op, Value: t.text}, nil
+	default:
+		return Filter{}, unexpected(t, "a value")
+	}
+}
+
+func unexpected(t token, want string) error {
+	if t.kind == tokEOF {
+		return fmt.Errorf("expected %s at end of filter", want)
+	}
+	return fmt.Errorf("expected %s at %d, found %q", want, t.pos, t.text)
+}
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Placeholder formats the nth query argument, counting from 1
+type Placeholder func(n int) string
+
+// Question is the placeholder style of MySQL and SQLite
+func Question(int) string { return "?" }
+
+// Dollar is the placeholder style of PostgreSQL
+func Dollar(n int) string { return "$" + strconv.Itoa(n) }
+
+// SQL returns a WHERE clause for the filter and its arguments, for use with
+// database/sql:
+//
+//	where, args, err := f.SQL(map[string]string{
+//		"status":  "o.status",
+//		"total":   "o.total_cents",
+//		"created": "o.created_at",
+//	}, filter.Dollar)
+//	if err != nil {
+//		return nil, api.BadRequest(err.Error())
+//	}
+//	rows, err := db.QueryContext(ctx, "SELECT ... FROM orders o WHERE "+where, args...)
+//
+// columns maps each field clients may filter on to its column expression,
+// which is written into the query as is; a condition on any other field is
+// an error, so nothing from the request reaches the SQL text. Values are
+// passed as string arguments and converted to the column type by the
+// database. contains is a case-insensitive LIKE.
+//
+// Groups are parenthesized, so the clause can be joined to others with AND.
+// The zero Filter, or one of only empty groups, returns "1=1"; empty groups
+// nested in others are left out. To number placeholders after arguments of
+// your own, offset them: func(n int) string { return filter.Dollar(n + 1) }.
+func (f Filter) SQL(columns map[string]string, placeholder Placeholder) (string, []any, error) {
+	if !f.hasConditions() {
+		return "1=1", nil, nil
+	}
+	var b strings.Builder
+	var args []any
+	if err := f.sql(&b, &args, columns, placeholder); err != nil {
+		return "", nil, err
+	}
+	return b.String(), args, nil
+}
+
+func (f Filter) sql(b *strings.Builder, args *[]any, columns map[string]string, placeholder Placeholder) error {
+	if f.Field == "" {
+		join := " AND "
+		if f.Any {
+			join = " OR "
+		}
+		b.WriteByte('(')
+		written := 0
+		for _, child := range f.Filters {
+			if !child.hasConditions() {
+				continue // "()" isn't valid SQL
+			}
+			if written > 0 {
+				b.WriteString(join)
+			}
+			if err := child.sql(b, args, columns, placeholder); err != nil {
+				return err
+			}
+			written++
+		}
+		b.WriteByte(')')
+		return nil
+	}
+
+	column, ok := columns[f.Field]
+	if !ok {
+		return fmt.Errorf("cannot filter on %q", f.Field)
+	}
+	*args = append(*args, f.Value)
+	arg := placeholder(len(*args))
+
+	switch f.Op {
+	case Eq:
+		b.WriteString(column + " = " + arg)
+	case Ne:
+		b.WriteString(column + " <> " + arg)
+	case Gt:
+		b.WriteString(column + " > " + arg)
+	case Gte:
+		b.WriteString(column + " >= " + arg)
+	case Lt:
+		b.WriteString(column + " < " + arg)
+	case Lte:
+		b.WriteString(column + " <= " + arg)
+	case Contains:
+		(*args)[len(*args)-1] = "%" + likeEscaper.Replace(strings.ToLower(f.Value)) + "%"
+		b.WriteString("LOWER(" + column + ") LIKE " + arg + " ESCAPE '!'")
+	default:
+		return fmt.Errorf("unknown operator %q", f.Op)
+	}
+	return nil
+}
+
+// hasConditions reports whether the filter has a condition at any depth
+func (f Filter) hasConditions() bool {
+	if f.Field != "" {
+		return true
+	}
+	for _, child := range f.Filters {
+		if child.hasConditions() {
+			return true
+		}
+	}
+	return false
+}
+
+// likeEscaper escapes LIKE wildcards with "!", which unlike a backslash
+// means the same in every database's string literals
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
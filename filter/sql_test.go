@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+var sqlColumns = map[string]string{
+	"status": "o.status",
+	"total":  "o.total_cents",
+	"name":   "c.name",
+}
+
+func TestSQL(t *testing.T) {
+	tests := []struct {
+		filter string
+		where  string
+		args   []any
+	}{
+		{"", "1=1", nil},
+		{"status eq active", "o.status = $1", []any{"active"}},
+		{"total >= 100 and total < 500", "(o.total_cents >= $1 AND o.total_cents < $2)", []any{"100", "500"}},
+		{
+			`status ne void and (total gt 100 or name contains "acme")`,
+			"(o.status <> $1 AND (o.total_cents > $2 OR LOWER(c.name) LIKE $3 ESCAPE '!'))",
+			[]any{"void", "100", "%acme%"},
+		},
+		{`name contains "50%_off!"`, "LOWER(c.name) LIKE $1 ESCAPE '!'", []any{"%50!%!_off!!%"}},
+		{`name contains "ACME"`, "LOWER(c.name) LIKE $1 ESCAPE '!'", []any{"%acme%"}},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.filter, err)
+		}
+		where, args, err := f.SQL(sqlColumns, Dollar)
+		if err != nil {
+			t.Errorf("SQL(%q): %v", tt.filter, err)
+			continue
+		}
+		if where != tt.where || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("SQL(%q) = %q, %v; want %q, %v", tt.filter, where, args, tt.where, tt.args)
+		}
+	}
+}
+
+func TestSQLPlaceholders(t *testing.T) {
+	f := And(Where("status", Eq, "active"), Where("total", Gt, 100))
+
+	where, _, err := f.SQL(sqlColumns, Question)
+	if err != nil || where != "(o.status = ? AND o.total_cents > ?)" {
+		t.Errorf("Question: %q, %v", where, err)
+	}
+
+	offset := func(n int) string { return Dollar(n + 2) }
+	where, _, err = f.SQL(sqlColumns, offset)
+	if err != nil || where != "(o.status = $3 AND o.total_cents > $4)" {
+		t.Errorf("offset: %q, %v", where, err)
+	}
+}
+
+func TestSQLSkipsEmptyGroups(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		where  string
+	}{
+		// Built by hand or decoded from JSON, unlike And and Or, which drop them
+		{Filter{Filters: []Filter{Where("status", Eq, "active"), {}, {Any: true, Filters: []Filter{{}}}}}, "(o.status = $1)"},
+		{Filter{Any: true, Filters: []Filter{{Filters: []Filter{{}}}, Where("total", Gt, 100), Where("name", Eq, "acme")}}, "(o.total_cents > $1 OR c.name = $2)"},
+		{Filter{Filters: []Filter{{}, {Filters: []Filter{{}}}}}, "1=1"},
+	}
+	for _, tt := range tests {
+		where, _, err := tt.filter.SQL(sqlColumns, Dollar)
+		if err != nil || where != tt.where {
+			t.Errorf("SQL(%+v) = %q, %v; want %q", tt.filter, where, err, tt.where)
+		}
+	}
+}
+
+func TestSQLRejectsUnknownFields(t *testing.T) {
+	for _, f := range []Filter{
+		Where("password", Eq, "x"),
+		Or(Where("status", Eq, "active"), Where("1=1; --", Eq, "x")),
+		{Field: "status", Op: "like", Value: "x"},
+	} {
+		if where, args, err := f.SQL(sqlColumns, Dollar); err == nil {
+			t.Errorf("SQL(%+v) = %q, %v; want an error", f, where, args)
+		}
+	}
+}
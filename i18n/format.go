@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format holds the date and number conventions of a locale
+type Format struct {
+	Months       [12]string   // Full month names, January first
+	ShortMonths  [12]string   // Abbreviated month names
+	Days         [7]string    // Abbreviated weekday names, Sunday first
+	FirstWeekday time.Weekday // First column of calendars
+	DateLayout   string       // Pattern for FormatDate, e.g. "MMM d, yyyy"
+	Decimal      string       // Decimal separator
+	Group        string       // Thousands separator
+	Currency     string       // Pattern for amounts of money: "¤" is the symbol and "n" the number, e.g. "¤n" or "n ¤"
+
+	// Plural returns the plural form ("one" or "other") for a count.
+	// Defaults to "one" for 1 and "other" otherwise.
+	Plural func(n int) string
+}
+
+var formats = map[string]Format{}
+
+// RegisterFormat sets the date and number conventions for a locale
+func RegisterFormat(locale string, f Format) {
+	mu.Lock()
+	defer mu.Unlock()
+	formats[Normalize(locale)] = f
+}
+
+// CurrentFormat returns the conventions for the current locale, falling back
+// to its base language and then DefaultLocale
+func CurrentFormat() Format {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, locale := range fallbacks(current) {
+		if f, ok := formats[locale]; ok {
+			return f
+		}
+	}
+	return formats[DefaultLocale]
+}
+
+func (f Format) plural(n int) string {
+	if f.Plural != nil {
+		return f.Plural(n)
+	}
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// MonthName returns the full name of a month in the current locale
+func MonthName(m time.Month) string {
+	return CurrentFormat().Months[m-1]
+}
+
+// ShortMonthName returns the abbreviated name of a month in the current locale
+func ShortMonthName(m time.Month) string {
+	return CurrentFormat().ShortMonths[m-1]
+}
+
+// DayName returns the abbreviated name of a weekday in the current locale
+func DayName(d time.Weekday) string {
+	return CurrentFormat().Days[d]
+}
+
+// FormatDate formats t with the current locale's DateLayout
+func FormatDate(t time.Time) string {
+	f := CurrentFormat()
+	return f.FormatDate(t, f.DateLayout)
+}
+
+// FormatDate formats t with a pattern using the tokens yyyy, MMMM (full
+// month), MMM (short month), MM, dd, and d. Other characters are copied.
+func (f Format) FormatDate(t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		rest := pattern[i:]
+		switch {
+		case strings.HasPrefix(rest, "yyyy"):
+			b.WriteString(strconv.Itoa(t.Year()))
+			i += 4
+		case strings.HasPrefix(rest, "MMMM"):
+			b.WriteString(f.Months[t.Month()-1])
+			i += 4
+		case strings.HasPrefix(rest, "MMM"):
+			b.WriteString(f.ShortMonths[t.Month()-1])
+			i += 3
+		case strings.HasPrefix(rest, "MM"):
+			b.WriteString(twoDigits(int(t.Month())))
+			i += 2
+		case strings.HasPrefix(rest, "dd"):
+			b.WriteString(twoDigits(t.Day()))
+			i += 2
+		case strings.HasPrefix(rest, "d"):
+			b.WriteString(strconv.Itoa(t.Day()))
+			i++
+		default:
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// FormatInt formats an integer with the current locale's thousands separator
+func FormatInt(n int) string {
+	return FormatNumber(float64(n), 0)
+}
+
+// FormatNumber formats v with the given number of decimals using the
+// current locale's separators
+func FormatNumber(v float64, decimals int) string {
+	return CurrentFormat().FormatNumber(v, decimals)
+}
+
+// FormatNumber formats v with the given number of decimals using f's separators
+func (f Format) FormatNumber(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(f.Group)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(f.Decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
@@ -0,0 +1,190 @@
+// Package i18n provides message catalogs, runtime locale switching, and
+// locale-aware date and number formatting. It has no build constraints, so
+// servers can render the same catalogs the WASM client uses.
+//
+// Messages are looked up by key in the current locale, then its base
+// language ("pt-BR" falls back to "pt"), then DefaultLocale. A key with no
+// translation anywhere is returned unchanged.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the fallback locale for missing messages and formats
+const DefaultLocale = "en"
+
+// Messages maps message keys to fmt format strings. Plural forms are stored
+// under "<key>.one" and "<key>.other" and selected with N.
+type Messages map[string]string
+
+var (
+	mu          sync.RWMutex
+	catalogs    = map[string]Messages{}
+	current     = DefaultLocale
+	subscribers = map[int]func(string){}
+	nextID      int
+)
+
+// Register adds messages to a locale's catalog, replacing existing keys
+func Register(locale string, messages Messages) {
+	locale = Normalize(locale)
+	mu.Lock()
+	defer mu.Unlock()
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = Messages{}
+		catalogs[locale] = catalog
+	}
+	for k, v := range messages {
+		catalog[k] = v
+	}
+}
+
+// LoadJSON registers a catalog from a flat JSON object of key/message pairs
+func LoadJSON(locale string, data []byte) error {
+	var messages Messages
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("i18n: load %s: %w", locale, err)
+	}
+	Register(locale, messages)
+	return nil
+}
+
+// Locales returns the locales with a registered catalog, sorted
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Locale returns the current locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetLocale changes the current locale and notifies subscribers if it
+// changed. Locales without a catalog are allowed and fall back per lookup.
+func SetLocale(locale string) {
+	locale = Normalize(locale)
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	mu.Lock()
+	if locale == current {
+		mu.Unlock()
+		return
+	}
+	current = locale
+	fns := make([]func(string), 0, len(subscribers))
+	for _, fn := range subscribers {
+		fns = append(fns, fn)
+	}
+	mu.Unlock()
+
+	for _, fn := range fns {
+		fn(locale)
+	}
+}
+
+// OnChange subscribes to locale changes and returns an unsubscribe function
+func OnChange(fn func(locale string)) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	id := nextID
+	nextID++
+	subscribers[id] = fn
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(subscribers, id)
+	}
+}
+
+// Match returns the registered locale that best serves a requested one,
+// such as a browser language, or DefaultLocale if none does
+func Match(requested string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, locale := range fallbacks(Normalize(requested)) {
+		if _, ok := catalogs[locale]; ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Normalize converts a language tag to the form used for catalog keys,
+// e.g. "pt_br" becomes "pt-BR"
+func Normalize(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 2 {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// fallbacks returns the lookup chain for a locale: itself, its base
+// language, then DefaultLocale
+func fallbacks(locale string) []string {
+	chain := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		chain = append(chain, locale[:i])
+	}
+	if chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+func lookup(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, locale := range fallbacks(current) {
+		if msg, ok := catalogs[locale][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// T translates key in the current locale. Args are applied with
+// fmt.Sprintf, so translations can reorder them with %[n]d verbs.
+func T(key string, args ...any) string {
+	msg, ok := lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N translates the plural form of key for count n, using the current
+// locale's plural rule. Args are passed to the message after n.
+func N(key string, n int, args ...any) string {
+	form := CurrentFormat().plural(n)
+	msg, ok := lookup(key + "." + form)
+	if !ok {
+		if msg, ok = lookup(key + ".other"); !ok {
+			msg = key
+		}
+	}
+	return fmt.Sprintf(msg, append([]any{n}, args...)...)
+}
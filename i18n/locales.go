@@ -0,0 +1,1141 @@
+package i18n
+
+import "time"
+
+// Built-in catalogs hold the strings used by the components package. Apps
+// override any of them by registering the same key.
+func init() {
+	Register("en", Messages{
+		"gux.pagination.label":         "Pagination",
+		"gux.pagination.showing":       "Showing %s-%s of %s items",
+		"gux.pagination.previous":      "Previous",
+		"gux.pagination.next":          "Next",
+		"gux.pagination.loadMore":      "Load more",
+		"gux.pagination.loading":       "Loading more",
+		"gux.pagination.loadFailed":    "Couldn't load more",
+		"gux.pagination.retry":         "Retry",
+		"gux.table.search":             "Search...",
+		"gux.table.filter":             "Filter table",
+		"gux.table.selected.one":       "%d item selected",
+		"gux.table.selected.other":     "%d items selected",
+		"gux.table.clearSelection":     "Clear selection",
+		"gux.table.selectAll":          "Select all rows",
+		"gux.table.selectRow":          "Select row",
+		"gux.table.columns":            "Columns",
+		"gux.table.resetColumns":       "Reset columns",
+		"gux.table.resizeColumn":       "Resize %s column",
+		"gux.table.columnMoved":        "%s moved to position %d",
+		"gux.table.editHint":           "Press Enter to edit",
+		"gux.table.invalidValue":       "Enter a valid value",
+		"gux.table.saveFailed":         "Couldn't save %s: %s",
+		"gux.table.sortedBy":           "Sorted by %s %s",
+		"gux.table.ascending":          "ascending",
+		"gux.table.descending":         "descending",
+		"gux.table.unsorted":           "Sort removed",
+		"gux.table.results.one":        "%d result",
+		"gux.table.results.other":      "%d results",
+		"gux.table.page":               "Page %d of %d",
+		"gux.empty.noData.title":       "No data",
+		"gux.empty.noData.desc":        "There's nothing here yet.",
+		"gux.empty.noResults.title":    "No results found",
+		"gux.empty.noResults.desc":     "Try adjusting your search or filter to find what you're looking for.",
+		"gux.empty.noResults.action":   "Clear filter",
+		"gux.empty.noSelection.title":  "Nothing selected",
+		"gux.empty.noSelection.desc":   "Select items to see details or perform actions.",
+		"gux.toast.dismiss":            "Dismiss notification",
+		"gux.toast.failed":             "Something went wrong",
+		"gux.toast.ref":                "%s (ref: %s)",
+		"gux.toast.copyRef":            "Copy ref",
+		"gux.toast.refCopied":          "Reference copied",
+		"gux.combobox.empty":           "No results found",
+		"gux.combobox.options":         "Options",
+		"gux.datepicker.placeholder":   "Select date",
+		"gux.datepicker.choose":        "Choose date",
+		"gux.datepicker.previousMonth": "Previous month",
+		"gux.datepicker.nextMonth":     "Next month",
+		"gux.datepicker.today":         "Today",
+
+		"gux.datepicker.rangePlaceholder": "Select date range",
+		"gux.datepicker.hour":             "Hour",
+		"gux.datepicker.minute":           "Minute",
+		"gux.datepicker.preset.today":     "Today",
+		"gux.datepicker.preset.last7":     "Last 7 days",
+		"gux.datepicker.preset.last30":    "Last 30 days",
+		"gux.datepicker.preset.thisMonth": "This month",
+
+		"gux.calendar.label":      "Calendar",
+		"gux.calendar.previous":   "Previous",
+		"gux.calendar.next":       "Next",
+		"gux.calendar.today":      "Today",
+		"gux.calendar.views":      "Calendar view",
+		"gux.calendar.month":      "Month",
+		"gux.calendar.week":       "Week",
+		"gux.calendar.day":        "Day",
+		"gux.calendar.allDay":     "All day",
+		"gux.calendar.more":       "+%d more",
+		"gux.calendar.loading":    "Loading events",
+		"gux.calendar.loadFailed": "Couldn't load events",
+
+		"gux.gantt.label":     "Gantt chart",
+		"gux.gantt.zoom":      "Zoom",
+		"gux.gantt.day":       "Day",
+		"gux.gantt.week":      "Week",
+		"gux.gantt.month":     "Month",
+		"gux.gantt.task":      "Task",
+		"gux.gantt.milestone": "Milestone",
+		"gux.gantt.progress":  "%d%% done",
+
+		"gux.permission.denied": "You don't have permission to do this",
+
+		"gux.chart.less": "Less",
+		"gux.chart.more": "More",
+
+		"gux.embed.title": "Embedded app",
+
+		"gux.macros.category":    "Macros",
+		"gux.macros.start":       "Start recording macro",
+		"gux.macros.stop":        "Stop recording macro",
+		"gux.macros.run":         "Run macro: %s",
+		"gux.macros.steps.one":   "%d step",
+		"gux.macros.steps.other": "%d steps",
+		"gux.macros.recording":   "Recording macro. Press %s to save.",
+		"gux.macros.saved":       "Saved macro \"%s\"",
+		"gux.macros.empty":       "No commands recorded; macro not saved",
+		"gux.macros.defaultName": "Macro %d",
+
+		"gux.diagnostics.title":        "Report a problem",
+		"gux.diagnostics.intro":        "Describe what happened. A diagnostics file will be created for you to attach to your report.",
+		"gux.diagnostics.description":  "What went wrong?",
+		"gux.diagnostics.placeholder":  "I clicked Save and nothing happened...",
+		"gux.diagnostics.included":     "Includes the app version, current page, recent logs, recent actions, and app state. Passwords and tokens are removed.",
+		"gux.diagnostics.cancel":       "Cancel",
+		"gux.diagnostics.downloadJSON": "Download JSON",
+		"gux.diagnostics.downloadZIP":  "Download ZIP",
+		"gux.diagnostics.send":         "Send report",
+		"gux.diagnostics.sent":         "Report sent. Thank you!",
+		"gux.diagnostics.failed":       "Could not create report: %s",
+		"gux.diagnostics.category":     "Help",
+		"gux.diagnostics.commandDesc":  "Download diagnostics for a support request",
+
+		"gux.kanban.card":         "card",
+		"gux.kanban.empty":        "Drop cards here",
+		"gux.kanban.moved":        "Moved %s to %s, position %d",
+		"gux.kanban.atLimit":      "%s is at its limit of %d cards",
+		"gux.kanban.column.one":   "%[2]s, %[1]d card",
+		"gux.kanban.column.other": "%[2]s, %[1]d cards",
+
+		"gux.treeview.loading": "Loading…",
+		"gux.treeview.error":   "Couldn't load %s",
+		"gux.treeview.retry":   "Retry",
+
+		"gux.validation.required":   "This field is required",
+		"gux.validation.email":      "Please enter a valid email address",
+		"gux.validation.minlength":  "Must be at least %d characters",
+		"gux.validation.maxlength":  "Must be at most %d characters",
+		"gux.validation.url":        "Please enter a valid URL",
+		"gux.validation.uuid":       "Please enter a valid UUID",
+		"gux.validation.slug":       "Use only lowercase letters, numbers, and hyphens",
+		"gux.validation.phone":      "Please enter a valid phone number",
+		"gux.validation.iban":       "Please enter a valid IBAN",
+		"gux.validation.creditcard": "Please enter a valid card number",
+		"gux.validation.before":     "Must be before %s",
+		"gux.validation.after":      "Must be after %s",
+		"gux.validation.range":      "Must be a number from %s to %s",
+		"gux.validation.min":        "Must be at least %s",
+		"gux.validation.max":        "Must be at most %s",
+		"gux.validation.oneof":      "Must be one of: %s",
+		"gux.validation.failed":     "Some fields are invalid",
+
+		"gux.notifications.unread.one":   "Notifications, %d unread",
+		"gux.notifications.unread.other": "Notifications, %d unread",
+		"gux.notifications.loadMore":     "Load older",
+		"gux.notifications.loading":      "Loading…",
+
+		"gux.shortcuts.title":          "Keyboard Shortcuts",
+		"gux.shortcuts.general":        "General",
+		"gux.shortcuts.then":           "then",
+		"gux.shortcuts.toggleSidebar":  "Toggle sidebar",
+		"gux.shortcuts.commandPalette": "Open command palette",
+		"gux.shortcuts.help":           "Show keyboard shortcuts",
+
+		"gux.filter.match":           "Match",
+		"gux.filter.matchAll":        "Match all conditions",
+		"gux.filter.matchAny":        "Match any condition",
+		"gux.filter.addCondition":    "Add condition",
+		"gux.filter.addGroup":        "Add group",
+		"gux.filter.removeCondition": "Remove condition",
+		"gux.filter.removeGroup":     "Remove group",
+		"gux.filter.field":           "Field",
+		"gux.filter.operator":        "Operator",
+		"gux.filter.value":           "Value",
+		"gux.filter.empty":           "No filters applied",
+		"gux.filter.op.eq":           "is",
+		"gux.filter.op.ne":           "is not",
+		"gux.filter.op.gt":           "greater than",
+		"gux.filter.op.gte":          "at least",
+		"gux.filter.op.lt":           "less than",
+		"gux.filter.op.lte":          "at most",
+		"gux.filter.op.contains":     "contains",
+
+		"gux.wizard.next":      "Next",
+		"gux.wizard.back":      "Back",
+		"gux.wizard.finish":    "Finish",
+		"gux.wizard.cancel":    "Cancel",
+		"gux.wizard.review":    "Review",
+		"gux.wizard.edit":      "Edit",
+		"gux.wizard.edit.aria": "Edit %s",
+		"gux.wizard.yes":       "Yes",
+		"gux.wizard.no":        "No",
+		"gux.wizard.progress":  "Step %d of %d: %s",
+		"gux.wizard.failed":    "Couldn't finish: %s",
+
+		"gux.service.maintenance": "We're down for maintenance.",
+		"gux.service.throttled":   "Too many requests. Slowing down.",
+		"gux.service.retryIn":     "Retrying in %s",
+		"gux.service.retrying":    "Retrying…",
+		"gux.service.retryNow":    "Retry now",
+
+		"gux.downloads.title":       "Downloads",
+		"gux.downloads.clear":       "Clear finished",
+		"gux.downloads.cancel":      "Cancel",
+		"gux.downloads.cancel.aria": "Cancel downloading %s",
+		"gux.downloads.remove.aria": "Remove %s from the list",
+		"gux.downloads.progress":    "%s of %s",
+		"gux.downloads.done":        "Done, %s",
+		"gux.downloads.canceled":    "Canceled",
+		"gux.downloads.failed":      "Download failed",
+
+		"gux.labels.preview": "Print preview",
+		"gux.labels.print":   "Print",
+		"gux.labels.cancel":  "Cancel",
+		"gux.labels.summary": "%d labels on %d pages",
+
+		"gux.router.loading":    "Loading page",
+		"gux.router.navigation": "Navigation",
+
+		"gux.list.createError": "Couldn't add the item: %s",
+		"gux.list.updateError": "Couldn't save the changes: %s",
+		"gux.list.deleteError": "Couldn't delete the item: %s",
+
+		"gux.popout.open":        "Open %s in a new window",
+		"gux.popout.placeholder": "%s is open in another window",
+		"gux.popout.focus":       "Show window",
+		"gux.popout.restore":     "Bring back",
+
+		"gux.combobox.loading": "Loading…",
+		"gux.combobox.error":   "Couldn't load options",
+		"gux.combobox.create":  "Create \"%s\"",
+		"gux.combobox.remove":  "Remove %s",
+
+		"gux.import.upload":              "Upload",
+		"gux.import.mapping":             "Map columns",
+		"gux.import.review":              "Review",
+		"gux.import.file":                "File to import",
+		"gux.import.hint":                "A CSV or Excel (.xlsx) file with a header row",
+		"gux.import.mapping.intro.one":   "%[2]s has %[1]d row. Choose the column to import into each field.",
+		"gux.import.mapping.intro.other": "%[2]s has %[1]d rows. Choose the column to import into each field.",
+		"gux.import.field":               "Field",
+		"gux.import.column":              "Column",
+		"gux.import.column.aria":         "Column for %s",
+		"gux.import.sample":              "First row",
+		"gux.import.skip":                "Don't import",
+		"gux.import.untitled":            "Column %d",
+		"gux.import.unmapped":            "Choose a column for %s",
+		"gux.import.empty":               "The file has no rows below the header",
+		"gux.import.read":                "Couldn't read %s: %s",
+		"gux.import.ready.one":           "%d row is ready to import.",
+		"gux.import.ready.other":         "%d rows are ready to import.",
+		"gux.import.invalid.one":         "%d row has errors and will be skipped.",
+		"gux.import.invalid.other":       "%d rows have errors and will be skipped.",
+		"gux.import.errors":              "Errors",
+		"gux.import.row":                 "Row",
+		"gux.import.value":               "Value",
+		"gux.import.error":               "Error",
+		"gux.import.download":            "Download errors",
+		"gux.import.confirm.one":         "Import %d row",
+		"gux.import.confirm.other":       "Import %d rows",
+		"gux.import.done.one":            "Imported %d row",
+		"gux.import.done.other":          "Imported %d rows",
+		"gux.import.again":               "Import another file",
+
+		"gux.pdf.page": "Page %d of %d",
+
+		"gux.upload.queued":    "Waiting",
+		"gux.upload.uploading": "Uploading %d%%",
+		"gux.upload.paused":    "Paused at %d%%",
+		"gux.upload.failed":    "Upload failed",
+		"gux.upload.done":      "Uploaded",
+		"gux.upload.pause":     "Pause upload",
+		"gux.upload.resume":    "Resume upload",
+		"gux.upload.retry":     "Retry upload",
+		"gux.upload.progress":  "Upload progress of %s",
+
+		"gux.env.title":      "Environment",
+		"gux.env.switch":     "Environment: %s. Switch environment",
+		"gux.env.sameOrigin": "Same origin",
+		"gux.env.signedIn":   "Signed in as %s",
+		"gux.env.signedOut":  "Not signed in",
+	})
+	RegisterFormat("en", Format{
+		Months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:  [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Days:         [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		FirstWeekday: time.Sunday,
+		DateLayout:   "MMM d, yyyy",
+		Decimal:      ".",
+		Group:        ",",
+		Currency:     "¤n",
+	})
+
+	Register("es", Messages{
+		"gux.pagination.label":         "Paginación",
+		"gux.pagination.showing":       "Mostrando %s-%s de %s elementos",
+		"gux.pagination.previous":      "Anterior",
+		"gux.pagination.next":          "Siguiente",
+		"gux.pagination.loadMore":      "Cargar más",
+		"gux.pagination.loading":       "Cargando más",
+		"gux.pagination.loadFailed":    "No se pudo cargar más",
+		"gux.pagination.retry":         "Reintentar",
+		"gux.table.search":             "Buscar...",
+		"gux.table.filter":             "Filtrar tabla",
+		"gux.table.selected.one":       "%d elemento seleccionado",
+		"gux.table.selected.other":     "%d elementos seleccionados",
+		"gux.table.clearSelection":     "Borrar selección",
+		"gux.table.selectAll":          "Seleccionar todas las filas",
+		"gux.table.selectRow":          "Seleccionar fila",
+		"gux.table.columns":            "Columnas",
+		"gux.table.resetColumns":       "Restablecer columnas",
+		"gux.table.resizeColumn":       "Redimensionar la columna %s",
+		"gux.table.columnMoved":        "%s movida a la posición %d",
+		"gux.table.editHint":           "Pulsa Intro para editar",
+		"gux.table.invalidValue":       "Introduce un valor válido",
+		"gux.table.saveFailed":         "No se pudo guardar %s: %s",
+		"gux.table.sortedBy":           "Ordenado por %s en orden %s",
+		"gux.table.ascending":          "ascendente",
+		"gux.table.descending":         "descendente",
+		"gux.table.unsorted":           "Orden eliminado",
+		"gux.table.results.one":        "%d resultado",
+		"gux.table.results.other":      "%d resultados",
+		"gux.table.page":               "Página %d de %d",
+		"gux.empty.noData.title":       "Sin datos",
+		"gux.empty.noData.desc":        "Todavía no hay nada aquí.",
+		"gux.empty.noResults.title":    "No se encontraron resultados",
+		"gux.empty.noResults.desc":     "Prueba a ajustar la búsqueda o el filtro para encontrar lo que buscas.",
+		"gux.empty.noResults.action":   "Borrar filtro",
+		"gux.empty.noSelection.title":  "Nada seleccionado",
+		"gux.empty.noSelection.desc":   "Selecciona elementos para ver detalles o realizar acciones.",
+		"gux.toast.dismiss":            "Descartar notificación",
+		"gux.toast.failed":             "Algo salió mal",
+		"gux.toast.ref":                "%s (ref.: %s)",
+		"gux.toast.copyRef":            "Copiar ref.",
+		"gux.toast.refCopied":          "Referencia copiada",
+		"gux.combobox.empty":           "No se encontraron resultados",
+		"gux.combobox.options":         "Opciones",
+		"gux.datepicker.placeholder":   "Seleccionar fecha",
+		"gux.datepicker.choose":        "Elegir fecha",
+		"gux.datepicker.previousMonth": "Mes anterior",
+		"gux.datepicker.nextMonth":     "Mes siguiente",
+		"gux.datepicker.today":         "Hoy",
+
+		"gux.datepicker.rangePlaceholder": "Seleccionar rango de fechas",
+		"gux.datepicker.hour":             "Hora",
+		"gux.datepicker.minute":           "Minuto",
+		"gux.datepicker.preset.today":     "Hoy",
+		"gux.datepicker.preset.last7":     "Últimos 7 días",
+		"gux.datepicker.preset.last30":    "Últimos 30 días",
+		"gux.datepicker.preset.thisMonth": "Este mes",
+
+		"gux.calendar.label":      "Calendario",
+		"gux.calendar.previous":   "Anterior",
+		"gux.calendar.next":       "Siguiente",
+		"gux.calendar.today":      "Hoy",
+		"gux.calendar.views":      "Vista del calendario",
+		"gux.calendar.month":      "Mes",
+		"gux.calendar.week":       "Semana",
+		"gux.calendar.day":        "Día",
+		"gux.calendar.allDay":     "Todo el día",
+		"gux.calendar.more":       "+%d más",
+		"gux.calendar.loading":    "Cargando eventos",
+		"gux.calendar.loadFailed": "No se pudieron cargar los eventos",
+
+		"gux.gantt.label":     "Diagrama de Gantt",
+		"gux.gantt.zoom":      "Zoom",
+		"gux.gantt.day":       "Día",
+		"gux.gantt.week":      "Semana",
+		"gux.gantt.month":     "Mes",
+		"gux.gantt.task":      "Tarea",
+		"gux.gantt.milestone": "Hito",
+		"gux.gantt.progress":  "%d%% completado",
+
+		"gux.permission.denied": "No tienes permiso para hacer esto",
+
+		"gux.chart.less": "Menos",
+		"gux.chart.more": "Más",
+
+		"gux.embed.title": "Aplicación integrada",
+
+		"gux.macros.category":    "Macros",
+		"gux.macros.start":       "Grabar macro",
+		"gux.macros.stop":        "Detener grabación de macro",
+		"gux.macros.run":         "Ejecutar macro: %s",
+		"gux.macros.steps.one":   "%d paso",
+		"gux.macros.steps.other": "%d pasos",
+		"gux.macros.recording":   "Grabando macro. Pulsa %s para guardarla.",
+		"gux.macros.saved":       "Macro \"%s\" guardada",
+		"gux.macros.empty":       "No se grabó ningún comando; la macro no se guardó",
+		"gux.macros.defaultName": "Macro %d",
+
+		"gux.diagnostics.title":        "Informar de un problema",
+		"gux.diagnostics.intro":        "Describe lo que ocurrió. Se creará un archivo de diagnóstico para adjuntarlo a tu informe.",
+		"gux.diagnostics.description":  "¿Qué salió mal?",
+		"gux.diagnostics.placeholder":  "Hice clic en Guardar y no pasó nada...",
+		"gux.diagnostics.included":     "Incluye la versión de la aplicación, la página actual, registros y acciones recientes y el estado de la aplicación. Se eliminan contraseñas y tokens.",
+		"gux.diagnostics.cancel":       "Cancelar",
+		"gux.diagnostics.downloadJSON": "Descargar JSON",
+		"gux.diagnostics.downloadZIP":  "Descargar ZIP",
+		"gux.diagnostics.send":         "Enviar informe",
+		"gux.diagnostics.sent":         "Informe enviado. ¡Gracias!",
+		"gux.diagnostics.failed":       "No se pudo crear el informe: %s",
+		"gux.diagnostics.category":     "Ayuda",
+		"gux.diagnostics.commandDesc":  "Descargar diagnósticos para una solicitud de soporte",
+
+		"gux.kanban.card":         "tarjeta",
+		"gux.kanban.empty":        "Suelta tarjetas aquí",
+		"gux.kanban.moved":        "%s movida a %s, posición %d",
+		"gux.kanban.atLimit":      "%s ha alcanzado su límite de %d tarjetas",
+		"gux.kanban.column.one":   "%[2]s, %[1]d tarjeta",
+		"gux.kanban.column.other": "%[2]s, %[1]d tarjetas",
+
+		"gux.treeview.loading": "Cargando…",
+		"gux.treeview.error":   "No se pudo cargar %s",
+		"gux.treeview.retry":   "Reintentar",
+
+		"gux.validation.required":   "Este campo es obligatorio",
+		"gux.validation.email":      "Introduce un correo electrónico válido",
+		"gux.validation.minlength":  "Debe tener al menos %d caracteres",
+		"gux.validation.maxlength":  "Debe tener como máximo %d caracteres",
+		"gux.validation.url":        "Introduce una URL válida",
+		"gux.validation.uuid":       "Introduce un UUID válido",
+		"gux.validation.slug":       "Usa solo minúsculas, números y guiones",
+		"gux.validation.phone":      "Introduce un número de teléfono válido",
+		"gux.validation.iban":       "Introduce un IBAN válido",
+		"gux.validation.creditcard": "Introduce un número de tarjeta válido",
+		"gux.validation.before":     "Debe ser anterior a %s",
+		"gux.validation.after":      "Debe ser posterior a %s",
+		"gux.validation.range":      "Debe ser un número entre %s y %s",
+		"gux.validation.min":        "Debe ser al menos %s",
+		"gux.validation.max":        "Debe ser como máximo %s",
+		"gux.validation.oneof":      "Debe ser uno de: %s",
+		"gux.validation.failed":     "Algunos campos no son válidos",
+
+		"gux.notifications.unread.one":   "Notificaciones, %d sin leer",
+		"gux.notifications.unread.other": "Notificaciones, %d sin leer",
+		"gux.notifications.loadMore":     "Cargar anteriores",
+		"gux.notifications.loading":      "Cargando…",
+
+		"gux.shortcuts.title":          "Atajos de teclado",
+		"gux.shortcuts.general":        "General",
+		"gux.shortcuts.then":           "luego",
+		"gux.shortcuts.toggleSidebar":  "Mostrar u ocultar la barra lateral",
+		"gux.shortcuts.commandPalette": "Abrir la paleta de comandos",
+		"gux.shortcuts.help":           "Mostrar atajos de teclado",
+
+		"gux.filter.match":           "Coincidencia",
+		"gux.filter.matchAll":        "Cumplir todas las condiciones",
+		"gux.filter.matchAny":        "Cumplir alguna condición",
+		"gux.filter.addCondition":    "Añadir condición",
+		"gux.filter.addGroup":        "Añadir grupo",
+		"gux.filter.removeCondition": "Quitar condición",
+		"gux.filter.removeGroup":     "Quitar grupo",
+		"gux.filter.field":           "Campo",
+		"gux.filter.operator":        "Operador",
+		"gux.filter.value":           "Valor",
+		"gux.filter.empty":           "Sin filtros",
+		"gux.filter.op.eq":           "es",
+		"gux.filter.op.ne":           "no es",
+		"gux.filter.op.gt":           "mayor que",
+		"gux.filter.op.gte":          "al menos",
+		"gux.filter.op.lt":           "menor que",
+		"gux.filter.op.lte":          "como máximo",
+		"gux.filter.op.contains":     "contiene",
+
+		"gux.wizard.next":      "Siguiente",
+		"gux.wizard.back":      "Atrás",
+		"gux.wizard.finish":    "Finalizar",
+		"gux.wizard.cancel":    "Cancelar",
+		"gux.wizard.review":    "Revisar",
+		"gux.wizard.edit":      "Editar",
+		"gux.wizard.edit.aria": "Editar %s",
+		"gux.wizard.yes":       "Sí",
+		"gux.wizard.no":        "No",
+		"gux.wizard.progress":  "Paso %d de %d: %s",
+		"gux.wizard.failed":    "No se pudo finalizar: %s",
+
+		"gux.service.maintenance": "Estamos en mantenimiento.",
+		"gux.service.throttled":   "Demasiadas solicitudes. Reduciendo el ritmo.",
+		"gux.service.retryIn":     "Reintentando en %s",
+		"gux.service.retrying":    "Reintentando…",
+		"gux.service.retryNow":    "Reintentar ahora",
+
+		"gux.downloads.title":       "Descargas",
+		"gux.downloads.clear":       "Borrar finalizadas",
+		"gux.downloads.cancel":      "Cancelar",
+		"gux.downloads.cancel.aria": "Cancelar la descarga de %s",
+		"gux.downloads.remove.aria": "Quitar %s de la lista",
+		"gux.downloads.progress":    "%s de %s",
+		"gux.downloads.done":        "Completada, %s",
+		"gux.downloads.canceled":    "Cancelada",
+		"gux.downloads.failed":      "Error en la descarga",
+
+		"gux.labels.preview": "Vista previa de impresión",
+		"gux.labels.print":   "Imprimir",
+		"gux.labels.cancel":  "Cancelar",
+		"gux.labels.summary": "%d etiquetas en %d páginas",
+
+		"gux.router.loading":    "Cargando la página",
+		"gux.router.navigation": "Navegación",
+
+		"gux.list.createError": "No se pudo añadir el elemento: %s",
+		"gux.list.updateError": "No se pudieron guardar los cambios: %s",
+		"gux.list.deleteError": "No se pudo eliminar el elemento: %s",
+
+		"gux.popout.open":        "Abrir %s en una ventana nueva",
+		"gux.popout.placeholder": "%s está abierto en otra ventana",
+		"gux.popout.focus":       "Mostrar ventana",
+		"gux.popout.restore":     "Traer de vuelta",
+
+		"gux.combobox.loading": "Cargando…",
+		"gux.combobox.error":   "No se pudieron cargar las opciones",
+		"gux.combobox.create":  "Crear \"%s\"",
+		"gux.combobox.remove":  "Quitar %s",
+
+		"gux.import.upload":              "Subir",
+		"gux.import.mapping":             "Asignar columnas",
+		"gux.import.review":              "Revisar",
+		"gux.import.file":                "Archivo para importar",
+		"gux.import.hint":                "Un archivo CSV o Excel (.xlsx) con una fila de encabezado",
+		"gux.import.mapping.intro.one":   "%[2]s tiene %[1]d fila. Elige la columna que se importa en cada campo.",
+		"gux.import.mapping.intro.other": "%[2]s tiene %[1]d filas. Elige la columna que se importa en cada campo.",
+		"gux.import.field":               "Campo",
+		"gux.import.column":              "Columna",
+		"gux.import.column.aria":         "Columna para %s",
+		"gux.import.sample":              "Primera fila",
+		"gux.import.skip":                "No importar",
+		"gux.import.untitled":            "Columna %d",
+		"gux.import.unmapped":            "Elige una columna para %s",
+		"gux.import.empty":               "El archivo no tiene filas debajo del encabezado",
+		"gux.import.read":                "No se pudo leer %s: %s",
+		"gux.import.ready.one":           "%d fila lista para importar.",
+		"gux.import.ready.other":         "%d filas listas para importar.",
+		"gux.import.invalid.one":         "%d fila tiene errores y se omitirá.",
+		"gux.import.invalid.other":       "%d filas tienen errores y se omitirán.",
+		"gux.import.errors":              "Errores",
+		"gux.import.row":                 "Fila",
+		"gux.import.value":               "Valor",
+		"gux.import.error":               "Error",
+		"gux.import.download":            "Descargar errores",
+		"gux.import.confirm.one":         "Importar %d fila",
+		"gux.import.confirm.other":       "Importar %d filas",
+		"gux.import.done.one":            "Se importó %d fila",
+		"gux.import.done.other":          "Se importaron %d filas",
+		"gux.import.again":               "Importar otro archivo",
+
+		"gux.pdf.page": "Página %d de %d",
+
+		"gux.upload.queued":    "En espera",
+		"gux.upload.uploading": "Subiendo %d%%",
+		"gux.upload.paused":    "En pausa al %d%%",
+		"gux.upload.failed":    "Error al subir",
+		"gux.upload.done":      "Subido",
+		"gux.upload.pause":     "Pausar subida",
+		"gux.upload.resume":    "Reanudar subida",
+		"gux.upload.retry":     "Reintentar subida",
+		"gux.upload.progress":  "Progreso de subida de %s",
+
+		"gux.env.title":      "Entorno",
+		"gux.env.switch":     "Entorno: %s. Cambiar de entorno",
+		"gux.env.sameOrigin": "Mismo origen",
+		"gux.env.signedIn":   "Sesión iniciada como %s",
+		"gux.env.signedOut":  "Sin sesión iniciada",
+	})
+	RegisterFormat("es", Format{
+		Months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:  [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Days:         [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		FirstWeekday: time.Monday,
+		DateLayout:   "d MMM yyyy",
+		Decimal:      ",",
+		Group:        ".",
+		Currency:     "n ¤",
+	})
+
+	Register("fr", Messages{
+		"gux.pagination.label":         "Pagination",
+		"gux.pagination.showing":       "Affichage de %s à %s sur %s éléments",
+		"gux.pagination.previous":      "Précédent",
+		"gux.pagination.next":          "Suivant",
+		"gux.pagination.loadMore":      "Charger plus",
+		"gux.pagination.loading":       "Chargement",
+		"gux.pagination.loadFailed":    "Impossible de charger la suite",
+		"gux.pagination.retry":         "Réessayer",
+		"gux.table.search":             "Rechercher...",
+		"gux.table.filter":             "Filtrer le tableau",
+		"gux.table.selected.one":       "%d élément sélectionné",
+		"gux.table.selected.other":     "%d éléments sélectionnés",
+		"gux.table.clearSelection":     "Effacer la sélection",
+		"gux.table.selectAll":          "Sélectionner toutes les lignes",
+		"gux.table.selectRow":          "Sélectionner la ligne",
+		"gux.table.columns":            "Colonnes",
+		"gux.table.resetColumns":       "Réinitialiser les colonnes",
+		"gux.table.resizeColumn":       "Redimensionner la colonne %s",
+		"gux.table.columnMoved":        "%s déplacée en position %d",
+		"gux.table.editHint":           "Appuyez sur Entrée pour modifier",
+		"gux.table.invalidValue":       "Saisissez une valeur valide",
+		"gux.table.saveFailed":         "Impossible d'enregistrer %s : %s",
+		"gux.table.sortedBy":           "Trié par %s, ordre %s",
+		"gux.table.ascending":          "croissant",
+		"gux.table.descending":         "décroissant",
+		"gux.table.unsorted":           "Tri supprimé",
+		"gux.table.results.one":        "%d résultat",
+		"gux.table.results.other":      "%d résultats",
+		"gux.table.page":               "Page %d sur %d",
+		"gux.empty.noData.title":       "Aucune donnée",
+		"gux.empty.noData.desc":        "Il n'y a encore rien ici.",
+		"gux.empty.noResults.title":    "Aucun résultat",
+		"gux.empty.noResults.desc":     "Essayez de modifier votre recherche ou votre filtre.",
+		"gux.empty.noResults.action":   "Effacer le filtre",
+		"gux.empty.noSelection.title":  "Aucune sélection",
+		"gux.empty.noSelection.desc":   "Sélectionnez des éléments pour voir les détails ou effectuer des actions.",
+		"gux.toast.dismiss":            "Fermer la notification",
+		"gux.toast.failed":             "Une erreur est survenue",
+		"gux.toast.ref":                "%s (réf. : %s)",
+		"gux.toast.copyRef":            "Copier la réf.",
+		"gux.toast.refCopied":          "Référence copiée",
+		"gux.combobox.empty":           "Aucun résultat",
+		"gux.combobox.options":         "Options",
+		"gux.datepicker.placeholder":   "Choisir une date",
+		"gux.datepicker.choose":        "Choisir une date",
+		"gux.datepicker.previousMonth": "Mois précédent",
+		"gux.datepicker.nextMonth":     "Mois suivant",
+		"gux.datepicker.today":         "Aujourd'hui",
+
+		"gux.datepicker.rangePlaceholder": "Choisir une période",
+		"gux.datepicker.hour":             "Heure",
+		"gux.datepicker.minute":           "Minute",
+		"gux.datepicker.preset.today":     "Aujourd'hui",
+		"gux.datepicker.preset.last7":     "7 derniers jours",
+		"gux.datepicker.preset.last30":    "30 derniers jours",
+		"gux.datepicker.preset.thisMonth": "Ce mois-ci",
+
+		"gux.calendar.label":      "Calendrier",
+		"gux.calendar.previous":   "Précédent",
+		"gux.calendar.next":       "Suivant",
+		"gux.calendar.today":      "Aujourd'hui",
+		"gux.calendar.views":      "Vue du calendrier",
+		"gux.calendar.month":      "Mois",
+		"gux.calendar.week":       "Semaine",
+		"gux.calendar.day":        "Jour",
+		"gux.calendar.allDay":     "Toute la journée",
+		"gux.calendar.more":       "+%d de plus",
+		"gux.calendar.loading":    "Chargement des événements",
+		"gux.calendar.loadFailed": "Impossible de charger les événements",
+
+		"gux.gantt.label":     "Diagramme de Gantt",
+		"gux.gantt.zoom":      "Zoom",
+		"gux.gantt.day":       "Jour",
+		"gux.gantt.week":      "Semaine",
+		"gux.gantt.month":     "Mois",
+		"gux.gantt.task":      "Tâche",
+		"gux.gantt.milestone": "Jalon",
+		"gux.gantt.progress":  "%d %% terminé",
+
+		"gux.permission.denied": "Vous n'avez pas l'autorisation de faire cela",
+
+		"gux.chart.less": "Moins",
+		"gux.chart.more": "Plus",
+
+		"gux.embed.title": "Application intégrée",
+
+		"gux.macros.category":    "Macros",
+		"gux.macros.start":       "Enregistrer une macro",
+		"gux.macros.stop":        "Arrêter l'enregistrement",
+		"gux.macros.run":         "Exécuter la macro : %s",
+		"gux.macros.steps.one":   "%d étape",
+		"gux.macros.steps.other": "%d étapes",
+		"gux.macros.recording":   "Enregistrement de la macro. Appuyez sur %s pour la sauvegarder.",
+		"gux.macros.saved":       "Macro « %s » enregistrée",
+		"gux.macros.empty":       "Aucune commande enregistrée ; la macro n'a pas été sauvegardée",
+		"gux.macros.defaultName": "Macro %d",
+
+		"gux.diagnostics.title":        "Signaler un problème",
+		"gux.diagnostics.intro":        "Décrivez ce qui s'est passé. Un fichier de diagnostic sera créé pour être joint à votre signalement.",
+		"gux.diagnostics.description":  "Que s'est-il passé ?",
+		"gux.diagnostics.placeholder":  "J'ai cliqué sur Enregistrer et rien ne s'est passé...",
+		"gux.diagnostics.included":     "Comprend la version de l'application, la page actuelle, les journaux et actions récents et l'état de l'application. Les mots de passe et jetons sont supprimés.",
+		"gux.diagnostics.cancel":       "Annuler",
+		"gux.diagnostics.downloadJSON": "Télécharger JSON",
+		"gux.diagnostics.downloadZIP":  "Télécharger ZIP",
+		"gux.diagnostics.send":         "Envoyer le signalement",
+		"gux.diagnostics.sent":         "Signalement envoyé. Merci !",
+		"gux.diagnostics.failed":       "Impossible de créer le signalement : %s",
+		"gux.diagnostics.category":     "Aide",
+		"gux.diagnostics.commandDesc":  "Télécharger les diagnostics pour une demande d'assistance",
+
+		"gux.kanban.card":         "carte",
+		"gux.kanban.empty":        "Déposez des cartes ici",
+		"gux.kanban.moved":        "%s déplacée vers %s, position %d",
+		"gux.kanban.atLimit":      "%s a atteint sa limite de %d cartes",
+		"gux.kanban.column.one":   "%[2]s, %[1]d carte",
+		"gux.kanban.column.other": "%[2]s, %[1]d cartes",
+
+		"gux.treeview.loading": "Chargement…",
+		"gux.treeview.error":   "Impossible de charger %s",
+		"gux.treeview.retry":   "Réessayer",
+
+		"gux.validation.required":   "Ce champ est obligatoire",
+		"gux.validation.email":      "Saisissez une adresse e-mail valide",
+		"gux.validation.minlength":  "Doit contenir au moins %d caractères",
+		"gux.validation.maxlength":  "Doit contenir au plus %d caractères",
+		"gux.validation.url":        "Saisissez une URL valide",
+		"gux.validation.uuid":       "Saisissez un UUID valide",
+		"gux.validation.slug":       "Utilisez uniquement des minuscules, des chiffres et des tirets",
+		"gux.validation.phone":      "Saisissez un numéro de téléphone valide",
+		"gux.validation.iban":       "Saisissez un IBAN valide",
+		"gux.validation.creditcard": "Saisissez un numéro de carte valide",
+		"gux.validation.before":     "Doit être antérieur au %s",
+		"gux.validation.after":      "Doit être postérieur au %s",
+		"gux.validation.range":      "Doit être un nombre entre %s et %s",
+		"gux.validation.min":        "Doit être au moins %s",
+		"gux.validation.max":        "Doit être au plus %s",
+		"gux.validation.oneof":      "Doit être l'une des valeurs : %s",
+		"gux.validation.failed":     "Certains champs ne sont pas valides",
+
+		"gux.notifications.unread.one":   "Notifications, %d non lue",
+		"gux.notifications.unread.other": "Notifications, %d non lues",
+		"gux.notifications.loadMore":     "Charger les plus anciennes",
+		"gux.notifications.loading":      "Chargement…",
+
+		"gux.shortcuts.title":          "Raccourcis clavier",
+		"gux.shortcuts.general":        "Général",
+		"gux.shortcuts.then":           "puis",
+		"gux.shortcuts.toggleSidebar":  "Afficher ou masquer la barre latérale",
+		"gux.shortcuts.commandPalette": "Ouvrir la palette de commandes",
+		"gux.shortcuts.help":           "Afficher les raccourcis clavier",
+
+		"gux.filter.match":           "Correspondance",
+		"gux.filter.matchAll":        "Correspond à toutes les conditions",
+		"gux.filter.matchAny":        "Correspond à au moins une condition",
+		"gux.filter.addCondition":    "Ajouter une condition",
+		"gux.filter.addGroup":        "Ajouter un groupe",
+		"gux.filter.removeCondition": "Supprimer la condition",
+		"gux.filter.removeGroup":     "Supprimer le groupe",
+		"gux.filter.field":           "Champ",
+		"gux.filter.operator":        "Opérateur",
+		"gux.filter.value":           "Valeur",
+		"gux.filter.empty":           "Aucun filtre",
+		"gux.filter.op.eq":           "est",
+		"gux.filter.op.ne":           "n'est pas",
+		"gux.filter.op.gt":           "supérieur à",
+		"gux.filter.op.gte":          "au moins",
+		"gux.filter.op.lt":           "inférieur à",
+		"gux.filter.op.lte":          "au plus",
+		"gux.filter.op.contains":     "contient",
+
+		"gux.wizard.next":      "Suivant",
+		"gux.wizard.back":      "Retour",
+		"gux.wizard.finish":    "Terminer",
+		"gux.wizard.cancel":    "Annuler",
+		"gux.wizard.review":    "Vérifier",
+		"gux.wizard.edit":      "Modifier",
+		"gux.wizard.edit.aria": "Modifier %s",
+		"gux.wizard.yes":       "Oui",
+		"gux.wizard.no":        "Non",
+		"gux.wizard.progress":  "Étape %d sur %d : %s",
+		"gux.wizard.failed":    "Impossible de terminer : %s",
+
+		"gux.service.maintenance": "Maintenance en cours.",
+		"gux.service.throttled":   "Trop de requêtes. Ralentissement en cours.",
+		"gux.service.retryIn":     "Nouvel essai dans %s",
+		"gux.service.retrying":    "Nouvel essai…",
+		"gux.service.retryNow":    "Réessayer maintenant",
+
+		"gux.downloads.title":       "Téléchargements",
+		"gux.downloads.clear":       "Effacer les terminés",
+		"gux.downloads.cancel":      "Annuler",
+		"gux.downloads.cancel.aria": "Annuler le téléchargement de %s",
+		"gux.downloads.remove.aria": "Retirer %s de la liste",
+		"gux.downloads.progress":    "%s sur %s",
+		"gux.downloads.done":        "Terminé, %s",
+		"gux.downloads.canceled":    "Annulé",
+		"gux.downloads.failed":      "Échec du téléchargement",
+
+		"gux.labels.preview": "Aperçu avant impression",
+		"gux.labels.print":   "Imprimer",
+		"gux.labels.cancel":  "Annuler",
+		"gux.labels.summary": "%d étiquettes sur %d pages",
+
+		"gux.router.loading":    "Chargement de la page",
+		"gux.router.navigation": "Navigation",
+
+		"gux.list.createError": "Impossible d'ajouter l'élément : %s",
+		"gux.list.updateError": "Impossible d'enregistrer les modifications : %s",
+		"gux.list.deleteError": "Impossible de supprimer l'élément : %s",
+
+		"gux.popout.open":        "Ouvrir %s dans une nouvelle fenêtre",
+		"gux.popout.placeholder": "%s est ouvert dans une autre fenêtre",
+		"gux.popout.focus":       "Afficher la fenêtre",
+		"gux.popout.restore":     "Ramener ici",
+
+		"gux.combobox.loading": "Chargement…",
+		"gux.combobox.error":   "Impossible de charger les options",
+		"gux.combobox.create":  "Créer « %s »",
+		"gux.combobox.remove":  "Retirer %s",
+
+		"gux.import.upload":              "Téléverser",
+		"gux.import.mapping":             "Associer les colonnes",
+		"gux.import.review":              "Vérifier",
+		"gux.import.file":                "Fichier à importer",
+		"gux.import.hint":                "Un fichier CSV ou Excel (.xlsx) avec une ligne d'en-tête",
+		"gux.import.mapping.intro.one":   "%[2]s contient %[1]d ligne. Choisissez la colonne à importer dans chaque champ.",
+		"gux.import.mapping.intro.other": "%[2]s contient %[1]d lignes. Choisissez la colonne à importer dans chaque champ.",
+		"gux.import.field":               "Champ",
+		"gux.import.column":              "Colonne",
+		"gux.import.column.aria":         "Colonne pour %s",
+		"gux.import.sample":              "Première ligne",
+		"gux.import.skip":                "Ne pas importer",
+		"gux.import.untitled":            "Colonne %d",
+		"gux.import.unmapped":            "Choisissez une colonne pour %s",
+		"gux.import.empty":               "Le fichier n'a aucune ligne sous l'en-tête",
+		"gux.import.read":                "Impossible de lire %s : %s",
+		"gux.import.ready.one":           "%d ligne prête à être importée.",
+		"gux.import.ready.other":         "%d lignes prêtes à être importées.",
+		"gux.import.invalid.one":         "%d ligne contient des erreurs et sera ignorée.",
+		"gux.import.invalid.other":       "%d lignes contiennent des erreurs et seront ignorées.",
+		"gux.import.errors":              "Erreurs",
+		"gux.import.row":                 "Ligne",
+		"gux.import.value":               "Valeur",
+		"gux.import.error":               "Erreur",
+		"gux.import.download":            "Télécharger les erreurs",
+		"gux.import.confirm.one":         "Importer %d ligne",
+		"gux.import.confirm.other":       "Importer %d lignes",
+		"gux.import.done.one":            "%d ligne importée",
+		"gux.import.done.other":          "%d lignes importées",
+		"gux.import.again":               "Importer un autre fichier",
+
+		"gux.pdf.page": "Page %d sur %d",
+
+		"gux.upload.queued":    "En attente",
+		"gux.upload.uploading": "Envoi %d %%",
+		"gux.upload.paused":    "En pause à %d %%",
+		"gux.upload.failed":    "Échec de l’envoi",
+		"gux.upload.done":      "Envoyé",
+		"gux.upload.pause":     "Mettre l’envoi en pause",
+		"gux.upload.resume":    "Reprendre l’envoi",
+		"gux.upload.retry":     "Réessayer l’envoi",
+		"gux.upload.progress":  "Progression de l’envoi de %s",
+
+		"gux.env.title":      "Environnement",
+		"gux.env.switch":     "Environnement : %s. Changer d’environnement",
+		"gux.env.sameOrigin": "Même origine",
+		"gux.env.signedIn":   "Connecté en tant que %s",
+		"gux.env.signedOut":  "Non connecté",
+	})
+	RegisterFormat("fr", Format{
+		Months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:  [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Days:         [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		FirstWeekday: time.Monday,
+		DateLayout:   "d MMM yyyy",
+		Decimal:      ",",
+		Group:        " ",
+		Currency:     "n ¤",
+		Plural: func(n int) string {
+			if n == 0 || n == 1 {
+				return "one"
+			}
+			return "other"
+		},
+	})
+
+	Register("de", Messages{
+		"gux.pagination.label":         "Seitennavigation",
+		"gux.pagination.showing":       "%s-%s von %s Einträgen",
+		"gux.pagination.previous":      "Zurück",
+		"gux.pagination.next":          "Weiter",
+		"gux.pagination.loadMore":      "Mehr laden",
+		"gux.pagination.loading":       "Wird geladen",
+		"gux.pagination.loadFailed":    "Weitere Einträge konnten nicht geladen werden",
+		"gux.pagination.retry":         "Erneut versuchen",
+		"gux.table.search":             "Suchen...",
+		"gux.table.filter":             "Tabelle filtern",
+		"gux.table.selected.one":       "%d Eintrag ausgewählt",
+		"gux.table.selected.other":     "%d Einträge ausgewählt",
+		"gux.table.clearSelection":     "Auswahl aufheben",
+		"gux.table.selectAll":          "Alle Zeilen auswählen",
+		"gux.table.selectRow":          "Zeile auswählen",
+		"gux.table.columns":            "Spalten",
+		"gux.table.resetColumns":       "Spalten zurücksetzen",
+		"gux.table.resizeColumn":       "Spalte %s in der Größe ändern",
+		"gux.table.columnMoved":        "%s an Position %d verschoben",
+		"gux.table.editHint":           "Zum Bearbeiten Eingabetaste drücken",
+		"gux.table.invalidValue":       "Bitte einen gültigen Wert eingeben",
+		"gux.table.saveFailed":         "%s konnte nicht gespeichert werden: %s",
+		"gux.table.sortedBy":           "Sortiert nach %s, %s",
+		"gux.table.ascending":          "aufsteigend",
+		"gux.table.descending":         "absteigend",
+		"gux.table.unsorted":           "Sortierung entfernt",
+		"gux.table.results.one":        "%d Ergebnis",
+		"gux.table.results.other":      "%d Ergebnisse",
+		"gux.table.page":               "Seite %d von %d",
+		"gux.empty.noData.title":       "Keine Daten",
+		"gux.empty.noData.desc":        "Hier ist noch nichts.",
+		"gux.empty.noResults.title":    "Keine Ergebnisse",
+		"gux.empty.noResults.desc":     "Passen Sie Ihre Suche oder Ihren Filter an.",
+		"gux.empty.noResults.action":   "Filter zurücksetzen",
+		"gux.empty.noSelection.title":  "Nichts ausgewählt",
+		"gux.empty.noSelection.desc":   "Wählen Sie Einträge aus, um Details zu sehen oder Aktionen auszuführen.",
+		"gux.toast.dismiss":            "Benachrichtigung schließen",
+		"gux.toast.failed":             "Etwas ist schiefgelaufen",
+		"gux.toast.ref":                "%s (Ref.: %s)",
+		"gux.toast.copyRef":            "Ref. kopieren",
+		"gux.toast.refCopied":          "Referenz kopiert",
+		"gux.combobox.empty":           "Keine Ergebnisse",
+		"gux.combobox.options":         "Optionen",
+		"gux.datepicker.placeholder":   "Datum wählen",
+		"gux.datepicker.choose":        "Datum wählen",
+		"gux.datepicker.previousMonth": "Vorheriger Monat",
+		"gux.datepicker.nextMonth":     "Nächster Monat",
+		"gux.datepicker.today":         "Heute",
+
+		"gux.datepicker.rangePlaceholder": "Zeitraum wählen",
+		"gux.datepicker.hour":             "Stunde",
+		"gux.datepicker.minute":           "Minute",
+		"gux.datepicker.preset.today":     "Heute",
+		"gux.datepicker.preset.last7":     "Letzte 7 Tage",
+		"gux.datepicker.preset.last30":    "Letzte 30 Tage",
+		"gux.datepicker.preset.thisMonth": "Dieser Monat",
+
+		"gux.calendar.label":      "Kalender",
+		"gux.calendar.previous":   "Zurück",
+		"gux.calendar.next":       "Weiter",
+		"gux.calendar.today":      "Heute",
+		"gux.calendar.views":      "Kalenderansicht",
+		"gux.calendar.month":      "Monat",
+		"gux.calendar.week":       "Woche",
+		"gux.calendar.day":        "Tag",
+		"gux.calendar.allDay":     "Ganztägig",
+		"gux.calendar.more":       "+%d weitere",
+		"gux.calendar.loading":    "Termine werden geladen",
+		"gux.calendar.loadFailed": "Termine konnten nicht geladen werden",
+
+		"gux.gantt.label":     "Gantt-Diagramm",
+		"gux.gantt.zoom":      "Zoom",
+		"gux.gantt.day":       "Tag",
+		"gux.gantt.week":      "Woche",
+		"gux.gantt.month":     "Monat",
+		"gux.gantt.task":      "Aufgabe",
+		"gux.gantt.milestone": "Meilenstein",
+		"gux.gantt.progress":  "%d %% erledigt",
+
+		"gux.permission.denied": "Dazu fehlt Ihnen die Berechtigung",
+
+		"gux.chart.less": "Weniger",
+		"gux.chart.more": "Mehr",
+
+		"gux.embed.title": "Eingebettete App",
+
+		"gux.macros.category":    "Makros",
+		"gux.macros.start":       "Makro aufzeichnen",
+		"gux.macros.stop":        "Aufzeichnung beenden",
+		"gux.macros.run":         "Makro ausführen: %s",
+		"gux.macros.steps.one":   "%d Schritt",
+		"gux.macros.steps.other": "%d Schritte",
+		"gux.macros.recording":   "Makro wird aufgezeichnet. Drücken Sie %s zum Speichern.",
+		"gux.macros.saved":       "Makro „%s“ gespeichert",
+		"gux.macros.empty":       "Keine Befehle aufgezeichnet; Makro nicht gespeichert",
+		"gux.macros.defaultName": "Makro %d",
+
+		"gux.diagnostics.title":        "Problem melden",
+		"gux.diagnostics.intro":        "Beschreiben Sie, was passiert ist. Es wird eine Diagnosedatei erstellt, die Sie Ihrer Meldung anhängen können.",
+		"gux.diagnostics.description":  "Was ist schiefgelaufen?",
+		"gux.diagnostics.placeholder":  "Ich habe auf Speichern geklickt und nichts ist passiert...",
+		"gux.diagnostics.included":     "Enthält App-Version, aktuelle Seite, letzte Protokolle und Aktionen sowie den App-Zustand. Passwörter und Tokens werden entfernt.",
+		"gux.diagnostics.cancel":       "Abbrechen",
+		"gux.diagnostics.downloadJSON": "JSON herunterladen",
+		"gux.diagnostics.downloadZIP":  "ZIP herunterladen",
+		"gux.diagnostics.send":         "Meldung senden",
+		"gux.diagnostics.sent":         "Meldung gesendet. Vielen Dank!",
+		"gux.diagnostics.failed":       "Meldung konnte nicht erstellt werden: %s",
+		"gux.diagnostics.category":     "Hilfe",
+		"gux.diagnostics.commandDesc":  "Diagnosedaten für eine Supportanfrage herunterladen",
+
+		"gux.kanban.card":         "Karte",
+		"gux.kanban.empty":        "Karten hier ablegen",
+		"gux.kanban.moved":        "%s nach %s verschoben, Position %d",
+		"gux.kanban.atLimit":      "%s hat das Limit von %d Karten erreicht",
+		"gux.kanban.column.one":   "%[2]s, %[1]d Karte",
+		"gux.kanban.column.other": "%[2]s, %[1]d Karten",
+
+		"gux.treeview.loading": "Wird geladen…",
+		"gux.treeview.error":   "%s konnte nicht geladen werden",
+		"gux.treeview.retry":   "Erneut versuchen",
+
+		"gux.validation.required":   "Dieses Feld ist erforderlich",
+		"gux.validation.email":      "Bitte eine gültige E-Mail-Adresse eingeben",
+		"gux.validation.minlength":  "Mindestens %d Zeichen",
+		"gux.validation.maxlength":  "Höchstens %d Zeichen",
+		"gux.validation.url":        "Bitte eine gültige URL eingeben",
+		"gux.validation.uuid":       "Bitte eine gültige UUID eingeben",
+		"gux.validation.slug":       "Nur Kleinbuchstaben, Ziffern und Bindestriche verwenden",
+		"gux.validation.phone":      "Bitte eine gültige Telefonnummer eingeben",
+		"gux.validation.iban":       "Bitte eine gültige IBAN eingeben",
+		"gux.validation.creditcard": "Bitte eine gültige Kartennummer eingeben",
+		"gux.validation.before":     "Muss vor dem %s liegen",
+		"gux.validation.after":      "Muss nach dem %s liegen",
+		"gux.validation.range":      "Muss eine Zahl von %s bis %s sein",
+		"gux.validation.min":        "Muss mindestens %s sein",
+		"gux.validation.max":        "Darf höchstens %s sein",
+		"gux.validation.oneof":      "Muss einer dieser Werte sein: %s",
+		"gux.validation.failed":     "Einige Felder sind ungültig",
+
+		"gux.notifications.unread.one":   "Benachrichtigungen, %d ungelesen",
+		"gux.notifications.unread.other": "Benachrichtigungen, %d ungelesen",
+		"gux.notifications.loadMore":     "Ältere laden",
+		"gux.notifications.loading":      "Wird geladen…",
+
+		"gux.shortcuts.title":          "Tastenkürzel",
+		"gux.shortcuts.general":        "Allgemein",
+		"gux.shortcuts.then":           "dann",
+		"gux.shortcuts.toggleSidebar":  "Seitenleiste ein- oder ausblenden",
+		"gux.shortcuts.commandPalette": "Befehlspalette öffnen",
+		"gux.shortcuts.help":           "Tastenkürzel anzeigen",
+
+		"gux.filter.match":           "Übereinstimmung",
+		"gux.filter.matchAll":        "Alle Bedingungen erfüllen",
+		"gux.filter.matchAny":        "Mindestens eine Bedingung erfüllen",
+		"gux.filter.addCondition":    "Bedingung hinzufügen",
+		"gux.filter.addGroup":        "Gruppe hinzufügen",
+		"gux.filter.removeCondition": "Bedingung entfernen",
+		"gux.filter.removeGroup":     "Gruppe entfernen",
+		"gux.filter.field":           "Feld",
+		"gux.filter.operator":        "Operator",
+		"gux.filter.value":           "Wert",
+		"gux.filter.empty":           "Keine Filter",
+		"gux.filter.op.eq":           "ist",
+		"gux.filter.op.ne":           "ist nicht",
+		"gux.filter.op.gt":           "größer als",
+		"gux.filter.op.gte":          "mindestens",
+		"gux.filter.op.lt":           "kleiner als",
+		"gux.filter.op.lte":          "höchstens",
+		"gux.filter.op.contains":     "enthält",
+
+		"gux.wizard.next":      "Weiter",
+		"gux.wizard.back":      "Zurück",
+		"gux.wizard.finish":    "Abschließen",
+		"gux.wizard.cancel":    "Abbrechen",
+		"gux.wizard.review":    "Überprüfen",
+		"gux.wizard.edit":      "Bearbeiten",
+		"gux.wizard.edit.aria": "%s bearbeiten",
+		"gux.wizard.yes":       "Ja",
+		"gux.wizard.no":        "Nein",
+		"gux.wizard.progress":  "Schritt %d von %d: %s",
+		"gux.wizard.failed":    "Abschließen fehlgeschlagen: %s",
+
+		"gux.service.maintenance": "Wir führen gerade Wartungsarbeiten durch.",
+		"gux.service.throttled":   "Zu viele Anfragen. Es wird langsamer gesendet.",
+		"gux.service.retryIn":     "Neuer Versuch in %s",
+		"gux.service.retrying":    "Neuer Versuch…",
+		"gux.service.retryNow":    "Jetzt erneut versuchen",
+
+		"gux.downloads.title":       "Downloads",
+		"gux.downloads.clear":       "Abgeschlossene entfernen",
+		"gux.downloads.cancel":      "Abbrechen",
+		"gux.downloads.cancel.aria": "Download von %s abbrechen",
+		"gux.downloads.remove.aria": "%s aus der Liste entfernen",
+		"gux.downloads.progress":    "%s von %s",
+		"gux.downloads.done":        "Fertig, %s",
+		"gux.downloads.canceled":    "Abgebrochen",
+		"gux.downloads.failed":      "Download fehlgeschlagen",
+
+		"gux.labels.preview": "Druckvorschau",
+		"gux.labels.print":   "Drucken",
+		"gux.labels.cancel":  "Abbrechen",
+		"gux.labels.summary": "%d Etiketten auf %d Seiten",
+
+		"gux.router.loading":    "Seite wird geladen",
+		"gux.router.navigation": "Navigation",
+
+		"gux.list.createError": "Element konnte nicht hinzugefügt werden: %s",
+		"gux.list.updateError": "Änderungen konnten nicht gespeichert werden: %s",
+		"gux.list.deleteError": "Element konnte nicht gelöscht werden: %s",
+
+		"gux.popout.open":        "%s in neuem Fenster öffnen",
+		"gux.popout.placeholder": "%s ist in einem anderen Fenster geöffnet",
+		"gux.popout.focus":       "Fenster anzeigen",
+		"gux.popout.restore":     "Zurückholen",
+
+		"gux.combobox.loading": "Wird geladen…",
+		"gux.combobox.error":   "Optionen konnten nicht geladen werden",
+		"gux.combobox.create":  "„%s“ erstellen",
+		"gux.combobox.remove":  "%s entfernen",
+
+		"gux.import.upload":              "Hochladen",
+		"gux.import.mapping":             "Spalten zuordnen",
+		"gux.import.review":              "Prüfen",
+		"gux.import.file":                "Zu importierende Datei",
+		"gux.import.hint":                "Eine CSV- oder Excel-Datei (.xlsx) mit Kopfzeile",
+		"gux.import.mapping.intro.one":   "%[2]s hat %[1]d Zeile. Wählen Sie für jedes Feld die zu importierende Spalte.",
+		"gux.import.mapping.intro.other": "%[2]s hat %[1]d Zeilen. Wählen Sie für jedes Feld die zu importierende Spalte.",
+		"gux.import.field":               "Feld",
+		"gux.import.column":              "Spalte",
+		"gux.import.column.aria":         "Spalte für %s",
+		"gux.import.sample":              "Erste Zeile",
+		"gux.import.skip":                "Nicht importieren",
+		"gux.import.untitled":            "Spalte %d",
+		"gux.import.unmapped":            "Wählen Sie eine Spalte für %s",
+		"gux.import.empty":               "Die Datei hat keine Zeilen unter der Kopfzeile",
+		"gux.import.read":                "%s konnte nicht gelesen werden: %s",
+		"gux.import.ready.one":           "%d Zeile bereit zum Import.",
+		"gux.import.ready.other":         "%d Zeilen bereit zum Import.",
+		"gux.import.invalid.one":         "%d Zeile enthält Fehler und wird übersprungen.",
+		"gux.import.invalid.other":       "%d Zeilen enthalten Fehler und werden übersprungen.",
+		"gux.import.errors":              "Fehler",
+		"gux.import.row":                 "Zeile",
+		"gux.import.value":               "Wert",
+		"gux.import.error":               "Fehler",
+		"gux.import.download":            "Fehler herunterladen",
+		"gux.import.confirm.one":         "%d Zeile importieren",
+		"gux.import.confirm.other":       "%d Zeilen importieren",
+		"gux.import.done.one":            "%d Zeile importiert",
+		"gux.import.done.other":          "%d Zeilen importiert",
+		"gux.import.again":               "Weitere Datei importieren",
+
+		"gux.pdf.page": "Seite %d von %d",
+
+		"gux.upload.queued":    "Wartet",
+		"gux.upload.uploading": "Wird hochgeladen: %d %%",
+		"gux.upload.paused":    "Pausiert bei %d %%",
+		"gux.upload.failed":    "Hochladen fehlgeschlagen",
+		"gux.upload.done":      "Hochgeladen",
+		"gux.upload.pause":     "Hochladen pausieren",
+		"gux.upload.resume":    "Hochladen fortsetzen",
+		"gux.upload.retry":     "Hochladen wiederholen",
+		"gux.upload.progress":  "Fortschritt beim Hochladen von %s",
+
+		"gux.env.title":      "Umgebung",
+		"gux.env.switch":     "Umgebung: %s. Umgebung wechseln",
+		"gux.env.sameOrigin": "Gleicher Ursprung",
+		"gux.env.signedIn":   "Angemeldet als %s",
+		"gux.env.signedOut":  "Nicht angemeldet",
+	})
+	RegisterFormat("de", Format{
+		Months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:  [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Days:         [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		FirstWeekday: time.Monday,
+		DateLayout:   "d. MMM yyyy",
+		Decimal:      ",",
+		Group:        ".",
+		Currency:     "n ¤",
+	})
+}
@@ -0,0 +1,276 @@
+//go:build js && wasm
+
+// Package idle runs background work while the browser is idle, so it
+// doesn't compete with rendering and input. It wraps requestIdleCallback,
+// and a Queue runs tasks such as prefetching data one at a time in idle
+// periods:
+//
+//	idle.Default().Add("posts", func(ctx context.Context) {
+//		state.GetAsyncCache().Warm("posts", loadPosts, time.Minute)
+//	})
+//
+// The router uses the default queue to prefetch the routes a user is
+// likely to open next; see Router.Prefetch in the components package.
+package idle
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Deadline is how long the browser expects to stay idle, as passed to a
+// Callback
+type Deadline struct {
+	v     js.Value  // the IdleDeadline, or undefined in the fallback
+	start time.Time // when the fallback started the callback
+}
+
+// fallbackIdle is the idle time assumed where requestIdleCallback isn't
+// available
+const fallbackIdle = 50 * time.Millisecond
+
+// TimeRemaining returns how much longer the browser expects to be idle.
+// Work that takes longer should be split up.
+func (d Deadline) TimeRemaining() time.Duration {
+	if d.v.IsUndefined() {
+		return max(0, fallbackIdle-time.Since(d.start))
+	}
+	return time.Duration(d.v.Call("timeRemaining").Float() * float64(time.Millisecond))
+}
+
+// DidTimeout reports whether the callback runs because its timeout passed
+// rather than because the browser is idle
+func (d Deadline) DidTimeout() bool {
+	return !d.v.IsUndefined() && d.v.Get("didTimeout").Bool()
+}
+
+// Callback calls fn once when the browser is next idle, or after timeout
+// at the latest; a timeout of 0 waits for an idle period however long it
+// takes. Where requestIdleCallback isn't available, as in Safari, fn runs
+// after a short delay. It returns a function that cancels the call.
+func Callback(fn func(Deadline), timeout time.Duration) func() {
+	var f js.Func
+	var handle js.Value
+	done := false
+	release := func() {
+		if !done {
+			done = true
+			f.Release()
+		}
+	}
+
+	if ric := js.Global().Get("requestIdleCallback"); ric.Truthy() {
+		f = js.FuncOf(func(this js.Value, args []js.Value) any {
+			release()
+			fn(Deadline{v: args[0]})
+			return nil
+		})
+		opts := map[string]any{}
+		if timeout > 0 {
+			opts["timeout"] = timeout.Milliseconds()
+		}
+		handle = js.Global().Call("requestIdleCallback", f, opts)
+		return func() {
+			if !done {
+				js.Global().Call("cancelIdleCallback", handle)
+				release()
+			}
+		}
+	}
+
+	f = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		fn(Deadline{v: js.Undefined(), start: time.Now()})
+		return nil
+	})
+	handle = js.Global().Call("setTimeout", f, 1)
+	return func() {
+		if !done {
+			js.Global().Call("clearTimeout", handle)
+			release()
+		}
+	}
+}
+
+// Task is background work run by a Queue. It runs in its own goroutine and
+// may block, e.g. on a fetch; the next task waits for it. It should return
+// early when ctx is canceled.
+type Task func(ctx context.Context)
+
+// Options configures a Queue
+type Options struct {
+	// MinIdle is the idle time a period must have left for a task to
+	// start in it (default 10ms)
+	MinIdle time.Duration
+
+	// Timeout runs the next task after this long even if the browser is
+	// never idle (default 0: only when idle)
+	Timeout time.Duration
+}
+
+type queued struct {
+	key  string
+	task Task
+}
+
+// Queue runs tasks one at a time in idle periods, in the order they were
+// added. It drops tasks while the user has asked to save data.
+type Queue struct {
+	mu         sync.Mutex
+	opts       Options
+	tasks      []queued
+	busy       bool   // waiting for an idle period or running a task
+	running    string // key of the running task
+	cancelIdle func()
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+var defaultQueue = NewQueue(Options{})
+
+// Default returns the queue the router prefetches with
+func Default() *Queue {
+	return defaultQueue
+}
+
+// NewQueue creates a Queue
+func NewQueue(opts Options) *Queue {
+	if opts.MinIdle <= 0 {
+		opts.MinIdle = 10 * time.Millisecond
+	}
+	q := &Queue{opts: opts}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	return q
+}
+
+// Add queues task under key. A task already queued or running under the
+// same key isn't added again; tasks without a key always are. Nothing is added while the browser reports
+// that the user wants to save data, or is on a 2G connection.
+func (q *Queue) Add(key string, task Task) {
+	if SaveData() {
+		return
+	}
+	q.mu.Lock()
+	if key != "" && q.running == key {
+		q.mu.Unlock()
+		return
+	}
+	for _, t := range q.tasks {
+		if key != "" && t.key == key {
+			q.mu.Unlock()
+			return
+		}
+	}
+	q.tasks = append(q.tasks, queued{key: key, task: task})
+	q.mu.Unlock()
+	q.schedule()
+}
+
+// Clear drops the queued tasks. A running task finishes.
+func (q *Queue) Clear() {
+	q.mu.Lock()
+	q.tasks = nil
+	q.mu.Unlock()
+}
+
+// ClearPrefix drops the queued tasks whose keys start with prefix, leaving
+// other work queued with them. A running task finishes.
+func (q *Queue) ClearPrefix(prefix string) {
+	q.mu.Lock()
+	q.tasks = slices.DeleteFunc(q.tasks, func(t queued) bool { return strings.HasPrefix(t.key, prefix) })
+	q.mu.Unlock()
+}
+
+// Stop drops the queued tasks and cancels the running one's context. Tasks
+// added afterwards run as usual.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	q.tasks = nil
+	q.cancel()
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	cancelIdle := q.cancelIdle
+	q.cancelIdle = nil
+	if q.running == "" {
+		q.busy = false
+	}
+	q.mu.Unlock()
+	if cancelIdle != nil {
+		cancelIdle()
+	}
+}
+
+// Len returns the number of queued tasks, not counting a running one
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.tasks)
+}
+
+// schedule waits for an idle period for the next task, unless one is
+// already waiting or running
+func (q *Queue) schedule() {
+	q.mu.Lock()
+	if q.busy || len(q.tasks) == 0 {
+		q.mu.Unlock()
+		return
+	}
+	q.busy = true
+	q.mu.Unlock()
+
+	cancel := Callback(q.idle, q.opts.Timeout)
+
+	q.mu.Lock()
+	if q.busy && q.running == "" {
+		q.cancelIdle = cancel
+	}
+	q.mu.Unlock()
+}
+
+// idle starts the next task if the idle period is long enough
+func (q *Queue) idle(d Deadline) {
+	q.mu.Lock()
+	q.cancelIdle = nil
+	if len(q.tasks) == 0 {
+		q.busy = false
+		q.mu.Unlock()
+		return
+	}
+	if d.TimeRemaining() < q.opts.MinIdle && !d.DidTimeout() {
+		q.busy = false
+		q.mu.Unlock()
+		q.schedule()
+		return
+	}
+	next := q.tasks[0]
+	q.tasks = q.tasks[1:]
+	q.running = next.key
+	ctx := q.ctx
+	q.mu.Unlock()
+
+	go func() {
+		next.task(ctx)
+		q.mu.Lock()
+		q.busy, q.running = false, ""
+		q.mu.Unlock()
+		q.schedule()
+	}()
+}
+
+// SaveData reports whether the user has asked the browser to reduce data
+// use, or is on a 2G connection, where background fetching should be
+// skipped
+func SaveData() bool {
+	conn := js.Global().Get("navigator").Get("connection")
+	if !conn.Truthy() {
+		return false
+	}
+	if conn.Get("saveData").Truthy() {
+		return true
+	}
+	t := conn.Get("effectiveType")
+	return t.Type() == js.TypeString && (t.String() == "2g" || t.String() == "slow-2g")
+}
@@ -0,0 +1,33 @@
+//go:build js && wasm
+
+package idle
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestClearPrefix(t *testing.T) {
+	q := NewQueue(Options{})
+	block := make(chan struct{})
+	defer close(block)
+	// A running task keeps the rest queued
+	q.Add("busy", func(ctx context.Context) { <-block })
+	q.Add("route:/a", func(ctx context.Context) {})
+	q.Add("posts", func(ctx context.Context) {})
+	q.Add("route:/b", func(ctx context.Context) {})
+
+	q.ClearPrefix("route:")
+
+	q.mu.Lock()
+	var keys []string
+	for _, task := range q.tasks {
+		keys = append(keys, task.key)
+	}
+	q.mu.Unlock()
+	// busy may have started already
+	if !slices.Equal(keys, []string{"busy", "posts"}) && !slices.Equal(keys, []string{"posts"}) {
+		t.Errorf("queued %v after ClearPrefix, want posts kept", keys)
+	}
+}
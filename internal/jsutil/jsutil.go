@@ -0,0 +1,65 @@
+//go:build js && wasm
+
+// Package jsutil holds the helpers gux's packages share for calling into
+// JavaScript.
+package jsutil
+
+import (
+	"context"
+	"errors"
+	"syscall/js"
+)
+
+// Await waits for a promise to settle, or ctx to be done. A rejection
+// becomes the error reject makes of its reason, which is undefined when the
+// promise was rejected without one, so each package keeps its own errors.
+// The promise can't be canceled; its callbacks release themselves when it
+// settles.
+func Await(ctx context.Context, promise js.Value, reject func(reason js.Value) error) (js.Value, error) {
+	type result struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan result, 1)
+
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) any {
+		value := js.Undefined()
+		if len(args) > 0 {
+			value = args[0]
+		}
+		done <- result{value: value}
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) any {
+		reason := js.Undefined()
+		if len(args) > 0 {
+			reason = args[0]
+		}
+		done <- result{value: js.Undefined(), err: reject(reason)}
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	promise.Call("then", then, catch)
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return js.Undefined(), ctx.Err()
+	}
+}
+
+// Message returns a reject function for Await whose error is the reason's
+// message, or fallback for a reason without one
+func Message(fallback string) func(reason js.Value) error {
+	return func(reason js.Value) error {
+		if reason.Type() == js.TypeObject && reason.Get("message").Truthy() {
+			return errors.New(reason.Get("message").String())
+		}
+		return errors.New(fallback)
+	}
+}
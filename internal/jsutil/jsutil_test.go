@@ -0,0 +1,37 @@
+//go:build js && wasm
+
+package jsutil
+
+import (
+	"context"
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+func TestAwait(t *testing.T) {
+	promise := js.Global().Get("Promise")
+	reject := Message("failed")
+
+	v, err := Await(context.Background(), promise.Call("resolve", 42), reject)
+	if err != nil || v.Int() != 42 {
+		t.Errorf("resolved: %v, %v; want 42", v, err)
+	}
+
+	_, err = Await(context.Background(), promise.Call("reject", js.Global().Get("Error").New("boom")), reject)
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("rejected with an Error: %v, want boom", err)
+	}
+	_, err = Await(context.Background(), promise.Call("reject"), reject)
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("rejected without a reason: %v, want failed", err)
+	}
+
+	// A promise that never settles
+	pending := promise.New(js.FuncOf(func(this js.Value, args []js.Value) any { return nil }))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := Await(ctx, pending, reject); err != context.DeadlineExceeded {
+		t.Errorf("canceled: %v, want %v", err, context.DeadlineExceeded)
+	}
+}
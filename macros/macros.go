@@ -0,0 +1,519 @@
+//go:build js && wasm
+
+// Package macros records sequences of command palette commands and replays
+// them with a single keyboard shortcut. Macros are stored in prefs.Layout,
+// so they persist per user and roam with preference sync.
+//
+//	recorder := macros.New(macros.Options{Palette: palette})
+//	recorder.Register(commands...)
+//	recorder.RegisterKeyboardShortcuts()
+//
+// Press Alt+Shift+R (or pick "Start recording macro" in the palette), run
+// commands from the palette, then press Alt+Shift+R again to save. Saved
+// macros run from the palette or their own shortcut (Alt+Shift+1 to 9).
+package macros
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/i18n"
+	"github.com/dougbarrett/gux/prefs"
+)
+
+// DefaultRecordShortcut toggles recording when Options.RecordShortcut is empty
+const DefaultRecordShortcut = "Alt+Shift+R"
+
+// commandPrefix marks the palette commands the recorder adds itself
+const commandPrefix = "gux.macros."
+
+// Macro is a named sequence of command IDs
+type Macro struct {
+	Name     string   `json:"name"`
+	Shortcut string   `json:"shortcut,omitempty"` // e.g. "Alt+Shift+1"
+	Steps    []string `json:"steps"`
+}
+
+// Options configures a Recorder
+type Options struct {
+	// Palette is updated with the registered commands plus the recorder's
+	// own commands whenever commands or macros change
+	Palette *components.CommandPalette
+
+	// RecordShortcut toggles recording (default DefaultRecordShortcut)
+	RecordShortcut string
+
+	// StepDelay waits between replayed steps in milliseconds, for commands
+	// that navigate or open UI the next step depends on. 0 runs steps
+	// back to back.
+	StepDelay int
+
+	// OnChange is called when recording starts, a step is recorded,
+	// recording stops, or the saved macros change
+	OnChange func()
+}
+
+// Recorder records and replays macros built from registered commands
+type Recorder struct {
+	opts        Options
+	commands    map[string]components.Command
+	order       []string
+	macros      []Macro
+	recording   bool
+	replaying   bool
+	saving      bool // persist is writing, so its own change isn't reloaded
+	steps       []string
+	keyListener js.Func
+	unsubscribe func()
+}
+
+// New creates a Recorder and loads the user's saved macros
+func New(opts Options) *Recorder {
+	if opts.RecordShortcut == "" {
+		opts.RecordShortcut = DefaultRecordShortcut
+	}
+
+	r := &Recorder{
+		opts:     opts,
+		commands: make(map[string]components.Command),
+	}
+	r.load()
+
+	// Follow macros synced from other devices
+	r.unsubscribe = prefs.Layout.Subscribe(prefs.KeyMacros, func() {
+		if r.saving {
+			return
+		}
+		r.load()
+		r.updatePalette()
+		r.changed()
+	})
+
+	r.updatePalette()
+	return r
+}
+
+// Register makes commands available for recording and replay. Commands
+// are matched by ID, so commands without one run but are not recorded.
+// Registering an existing ID replaces it.
+func (r *Recorder) Register(commands ...components.Command) {
+	for _, cmd := range commands {
+		if cmd.ID == "" {
+			continue
+		}
+		if _, exists := r.commands[cmd.ID]; !exists {
+			r.order = append(r.order, cmd.ID)
+		}
+		r.commands[cmd.ID] = cmd
+	}
+	r.updatePalette()
+}
+
+// Commands returns the registered commands wrapped so that running them
+// records a step, followed by the recorder's own commands (start/stop
+// recording and one per saved macro). Pass them to a CommandPalette that
+// was not given to Options.Palette.
+func (r *Recorder) Commands() []components.Command {
+	commands := make([]components.Command, 0, len(r.order)+len(r.macros)+1)
+	for _, id := range r.order {
+		cmd := r.commands[id]
+		cmdID := cmd.ID
+		cmd.OnExecute = func() {
+			r.Execute(cmdID)
+		}
+		commands = append(commands, cmd)
+	}
+
+	if r.recording {
+		commands = append(commands, components.Command{
+			ID:        commandPrefix + "stop",
+			Label:     i18n.T("gux.macros.stop"),
+			Icon:      "⏹",
+			Category:  i18n.T("gux.macros.category"),
+			Shortcut:  r.opts.RecordShortcut,
+			OnExecute: func() { r.StopRecording("") },
+		})
+	} else {
+		commands = append(commands, components.Command{
+			ID:        commandPrefix + "start",
+			Label:     i18n.T("gux.macros.start"),
+			Icon:      "⏺",
+			Category:  i18n.T("gux.macros.category"),
+			Shortcut:  r.opts.RecordShortcut,
+			OnExecute: r.StartRecording,
+		})
+	}
+
+	for _, m := range r.macros {
+		name := m.Name
+		commands = append(commands, components.Command{
+			ID:          commandPrefix + "run." + name,
+			Label:       i18n.T("gux.macros.run", name),
+			Description: i18n.N("gux.macros.steps", len(m.Steps)),
+			Icon:        "▶",
+			Category:    i18n.T("gux.macros.category"),
+			Shortcut:    m.Shortcut,
+			OnExecute: func() {
+				if err := r.Run(name); err != nil {
+					components.ShowError(err.Error())
+				}
+			},
+		})
+	}
+	return commands
+}
+
+// Execute runs a registered command by ID, recording it if recording
+func (r *Recorder) Execute(id string) error {
+	cmd, ok := r.commands[id]
+	if !ok {
+		return fmt.Errorf("macros: unknown command %q", id)
+	}
+	if r.recording && !r.replaying {
+		r.steps = append(r.steps, id)
+		r.changed()
+	}
+	if cmd.OnExecute != nil {
+		cmd.OnExecute()
+	}
+	return nil
+}
+
+// IsRecording reports whether a macro is being recorded
+func (r *Recorder) IsRecording() bool {
+	return r.recording
+}
+
+// RecordedSteps returns the command IDs recorded so far
+func (r *Recorder) RecordedSteps() []string {
+	return append([]string(nil), r.steps...)
+}
+
+// StartRecording begins recording a new macro, discarding any unsaved steps
+func (r *Recorder) StartRecording() {
+	r.recording = true
+	r.steps = nil
+	components.ShowInfo(i18n.T("gux.macros.recording", r.opts.RecordShortcut))
+	r.updatePalette()
+	r.changed()
+}
+
+// CancelRecording stops recording without saving
+func (r *Recorder) CancelRecording() {
+	if !r.recording {
+		return
+	}
+	r.recording = false
+	r.steps = nil
+	r.updatePalette()
+	r.changed()
+}
+
+// StopRecording ends recording and saves the steps as a macro. An empty
+// name picks "Macro N". The macro gets the first free Alt+Shift+digit
+// shortcut. Nothing is saved if no steps were recorded.
+func (r *Recorder) StopRecording(name string) (Macro, bool) {
+	if !r.recording {
+		return Macro{}, false
+	}
+	steps := r.steps
+	r.recording = false
+	r.steps = nil
+
+	if len(steps) == 0 {
+		components.ShowWarning(i18n.T("gux.macros.empty"))
+		r.updatePalette()
+		r.changed()
+		return Macro{}, false
+	}
+
+	if name == "" {
+		name = r.nextName()
+	}
+	m := Macro{Name: name, Shortcut: r.freeShortcut(), Steps: steps}
+	r.put(m)
+	components.ShowSuccess(i18n.T("gux.macros.saved", name))
+	return m, true
+}
+
+// Save stores a macro, replacing one with the same name
+func (r *Recorder) Save(m Macro) {
+	r.put(m)
+}
+
+// Macros returns the saved macros
+func (r *Recorder) Macros() []Macro {
+	return append([]Macro(nil), r.macros...)
+}
+
+// Get returns a saved macro by name
+func (r *Recorder) Get(name string) (Macro, bool) {
+	for _, m := range r.macros {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Macro{}, false
+}
+
+// Rename changes a macro's name, replacing any macro already using newName
+func (r *Recorder) Rename(oldName, newName string) {
+	if _, ok := r.Get(oldName); !ok || newName == "" || oldName == newName {
+		return
+	}
+	macros := make([]Macro, 0, len(r.macros))
+	for _, m := range r.macros {
+		if m.Name == newName {
+			continue // replaced by the renamed macro
+		}
+		if m.Name == oldName {
+			m.Name = newName
+		}
+		macros = append(macros, m)
+	}
+	r.macros = macros
+	r.persist()
+}
+
+// SetShortcut assigns a shortcut such as "Alt+Shift+5" to a macro. An empty
+// shortcut removes it.
+func (r *Recorder) SetShortcut(name, shortcut string) {
+	for i := range r.macros {
+		if r.macros[i].Name == name {
+			r.macros[i].Shortcut = shortcut
+		} else if shortcut != "" && r.macros[i].Shortcut == shortcut {
+			// Shortcuts are unique; move it rather than binding two macros
+			r.macros[i].Shortcut = ""
+		}
+	}
+	r.persist()
+}
+
+// Delete removes a saved macro
+func (r *Recorder) Delete(name string) {
+	for i, m := range r.macros {
+		if m.Name == name {
+			r.macros = append(r.macros[:i], r.macros[i+1:]...)
+			r.persist()
+			return
+		}
+	}
+}
+
+// Run replays a saved macro. It fails before running anything if a step
+// refers to a command that is no longer registered.
+func (r *Recorder) Run(name string) error {
+	m, ok := r.Get(name)
+	if !ok {
+		return fmt.Errorf("macros: no macro named %q", name)
+	}
+	if r.replaying {
+		return fmt.Errorf("macros: %q started while another macro is running", name)
+	}
+	for _, id := range m.Steps {
+		if _, ok := r.commands[id]; !ok {
+			return fmt.Errorf("macros: %q uses unknown command %q", name, id)
+		}
+	}
+
+	// Steps from a macro run while recording are recorded individually
+	if r.recording {
+		r.steps = append(r.steps, m.Steps...)
+		r.changed()
+	}
+
+	r.replaying = true
+	if r.opts.StepDelay <= 0 {
+		for _, id := range m.Steps {
+			r.Execute(id)
+		}
+		r.replaying = false
+		return nil
+	}
+	r.runDelayed(m.Steps)
+	return nil
+}
+
+// runDelayed executes steps one at a time with StepDelay between them
+func (r *Recorder) runDelayed(steps []string) {
+	r.Execute(steps[0])
+	if len(steps) == 1 {
+		r.replaying = false
+		return
+	}
+	var next js.Func
+	next = js.FuncOf(func(this js.Value, args []js.Value) any {
+		next.Release()
+		r.runDelayed(steps[1:])
+		return nil
+	})
+	js.Global().Call("setTimeout", next, r.opts.StepDelay)
+}
+
+// RegisterKeyboardShortcuts listens for the record shortcut and each
+// macro's shortcut
+func (r *Recorder) RegisterKeyboardShortcuts() {
+	if r.keyListener.Truthy() {
+		return
+	}
+	r.keyListener = js.FuncOf(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		if event.Get("repeat").Bool() {
+			return nil
+		}
+
+		if MatchShortcut(event, r.opts.RecordShortcut) {
+			event.Call("preventDefault")
+			if r.recording {
+				r.StopRecording("")
+			} else {
+				r.StartRecording()
+			}
+			return nil
+		}
+
+		for _, m := range r.macros {
+			if m.Shortcut != "" && MatchShortcut(event, m.Shortcut) {
+				event.Call("preventDefault")
+				if err := r.Run(m.Name); err != nil {
+					components.ShowError(err.Error())
+				}
+				return nil
+			}
+		}
+		return nil
+	})
+	js.Global().Get("document").Call("addEventListener", "keydown", r.keyListener)
+}
+
+// UnregisterKeyboardShortcuts removes the keyboard listener
+func (r *Recorder) UnregisterKeyboardShortcuts() {
+	if r.keyListener.Truthy() {
+		js.Global().Get("document").Call("removeEventListener", "keydown", r.keyListener)
+		r.keyListener.Release()
+		r.keyListener = js.Func{}
+	}
+}
+
+// Destroy removes listeners and stops following synced changes
+func (r *Recorder) Destroy() {
+	r.UnregisterKeyboardShortcuts()
+	if r.unsubscribe != nil {
+		r.unsubscribe()
+	}
+}
+
+// MatchShortcut reports whether a keydown event matches a shortcut such as
+// "Ctrl+Shift+P" or "Alt+Shift+1". Ctrl and Cmd are interchangeable so one
+// shortcut works on macOS and Windows/Linux.
+func MatchShortcut(event js.Value, shortcut string) bool {
+	parts := strings.Split(shortcut, "+")
+	if len(parts) == 0 {
+		return false
+	}
+	var ctrl, alt, shift bool
+	for _, mod := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(mod)) {
+		case "ctrl", "control", "cmd", "meta", "mod":
+			ctrl = true
+		case "alt", "option", "opt":
+			alt = true
+		case "shift":
+			shift = true
+		default:
+			return false
+		}
+	}
+	if ctrl != (event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool()) ||
+		alt != event.Get("altKey").Bool() ||
+		shift != event.Get("shiftKey").Bool() {
+		return false
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if len(key) == 1 {
+		// Alt and Shift change event.key ("!" for Shift+1, "®" for Option+R),
+		// so match letters and digits by physical key
+		c := strings.ToUpper(key)[0]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			return event.Get("code").String() == "Key"+string(c)
+		case c >= '0' && c <= '9':
+			return event.Get("code").String() == "Digit"+string(c)
+		}
+	}
+	return strings.EqualFold(event.Get("key").String(), key)
+}
+
+func (r *Recorder) put(m Macro) {
+	for i := range r.macros {
+		if r.macros[i].Name == m.Name {
+			r.macros[i] = m
+			r.persist()
+			return
+		}
+	}
+	r.macros = append(r.macros, m)
+	r.persist()
+}
+
+func (r *Recorder) nextName() string {
+	for n := len(r.macros) + 1; ; n++ {
+		name := i18n.T("gux.macros.defaultName", n)
+		if _, exists := r.Get(name); !exists {
+			return name
+		}
+	}
+}
+
+// freeShortcut returns the first Alt+Shift+digit not used by another macro
+func (r *Recorder) freeShortcut() string {
+	for d := 1; d <= 9; d++ {
+		shortcut := fmt.Sprintf("Alt+Shift+%d", d)
+		used := false
+		for _, m := range r.macros {
+			if m.Shortcut == shortcut {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return shortcut
+		}
+	}
+	return ""
+}
+
+func (r *Recorder) load() {
+	var saved []Macro
+	if err := prefs.Layout.Get(prefs.KeyMacros, &saved); err != nil {
+		js.Global().Get("console").Call("warn", "macros: discarding saved macros:", err.Error())
+		saved = nil
+	}
+	r.macros = saved
+}
+
+func (r *Recorder) persist() {
+	r.saving = true
+	err := prefs.Layout.Set(prefs.KeyMacros, r.macros)
+	r.saving = false
+	if err != nil {
+		js.Global().Get("console").Call("warn", "macros: save failed:", err.Error())
+	}
+	r.updatePalette()
+	r.changed()
+}
+
+func (r *Recorder) updatePalette() {
+	if r.opts.Palette != nil {
+		r.opts.Palette.SetCommands(r.Commands())
+	}
+}
+
+func (r *Recorder) changed() {
+	if r.opts.OnChange != nil {
+		r.opts.OnChange()
+	}
+}
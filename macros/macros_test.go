@@ -0,0 +1,24 @@
+//go:build js && wasm
+
+package macros_test
+
+import (
+	"testing"
+
+	"github.com/dougbarrett/gux/macros"
+)
+
+func TestSaveNotifiesOnce(t *testing.T) {
+	changes := 0
+	r := macros.New(macros.Options{OnChange: func() { changes++ }})
+	defer r.Destroy()
+
+	r.Save(macros.Macro{Name: "Tidy", Steps: []string{"format"}})
+
+	if changes != 1 {
+		t.Errorf("OnChange called %d times, want 1", changes)
+	}
+	if _, ok := r.Get("Tidy"); !ok {
+		t.Error("saved macro not found")
+	}
+}
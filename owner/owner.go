@@ -0,0 +1,177 @@
+//go:build js && wasm
+
+// Package owner records who owns a page or component: the team, the source
+// file, and a docs link. The Inspector shows the owner of the selected
+// element, and error reports and diagnostics bundles carry the owner of the
+// current route, so reports land with the right team in a multi-team app.
+//
+//	var billing = owner.Info{Name: "Billing", Team: "payments", Source: "billing/page.go"}
+//
+//	owner.Route("/billing/*", billing)
+//	owner.Annotate(invoiceTable.Element(), owner.Info{Name: "InvoiceTable", Team: "payments"})
+package owner
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+// Attr is the attribute that marks annotated elements
+const Attr = "data-owner"
+
+// Info describes who owns a page or component
+type Info struct {
+	Name   string `json:"name,omitempty"`   // page or component name
+	Team   string `json:"team,omitempty"`   // owning team
+	Source string `json:"source,omitempty"` // source file, e.g. "billing/page.go"
+	Docs   string `json:"docs,omitempty"`   // docs or runbook URL
+}
+
+// IsZero reports whether no field is set
+func (i Info) IsZero() bool {
+	return i == Info{}
+}
+
+// String renders the name and team, e.g. "Billing (payments)"
+func (i Info) String() string {
+	switch {
+	case i.Name != "" && i.Team != "":
+		return i.Name + " (" + i.Team + ")"
+	case i.Name != "":
+		return i.Name
+	default:
+		return i.Team
+	}
+}
+
+type registry struct {
+	mu       sync.RWMutex
+	ids      map[Info]string // the same Info always gets the same ID
+	infos    map[string]Info
+	routes   map[string]Info
+	basePath string
+}
+
+var reg = &registry{
+	ids:    make(map[Info]string),
+	infos:  make(map[string]Info),
+	routes: make(map[string]Info),
+}
+
+// Annotate marks el as owned by info and returns el. Elements store only an
+// ID, so annotating many elements with the same Info costs one entry.
+func Annotate(el js.Value, info Info) js.Value {
+	reg.mu.Lock()
+	id, ok := reg.ids[info]
+	if !ok {
+		id = strconv.Itoa(len(reg.ids) + 1)
+		reg.ids[info] = id
+		reg.infos[id] = info
+	}
+	reg.mu.Unlock()
+
+	el.Call("setAttribute", Attr, id)
+	return el
+}
+
+// Of returns the owner of el: its own annotation or that of its nearest
+// annotated ancestor
+func Of(el js.Value) (Info, bool) {
+	if !el.Truthy() || el.Get("closest").Type() != js.TypeFunction {
+		return Info{}, false
+	}
+	return lookup(el.Call("closest", "["+Attr+"]"))
+}
+
+// Chain returns the owners of el and its annotated ancestors, innermost first
+func Chain(el js.Value) []Info {
+	var chain []Info
+	for el.Truthy() && el.Get("closest").Type() == js.TypeFunction {
+		el = el.Call("closest", "["+Attr+"]")
+		info, ok := lookup(el)
+		if !ok {
+			break
+		}
+		chain = append(chain, info)
+		el = el.Get("parentElement")
+	}
+	return chain
+}
+
+// Own returns the annotation of el itself, ignoring its ancestors
+func Own(el js.Value) (Info, bool) {
+	if !el.Truthy() || el.Get("getAttribute").Type() != js.TypeFunction {
+		return Info{}, false
+	}
+	return lookup(el)
+}
+
+func lookup(el js.Value) (Info, bool) {
+	if !el.Truthy() {
+		return Info{}, false
+	}
+	id := el.Call("getAttribute", Attr)
+	if id.Type() != js.TypeString {
+		return Info{}, false
+	}
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	info, ok := reg.infos[id.String()]
+	return info, ok
+}
+
+// Route declares the owner of a route. A pattern ending in "/*" covers every
+// path below it; the longest matching pattern wins.
+func Route(pattern string, info Info) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.routes[pattern] = info
+}
+
+// ForRoute returns the owner declared for path
+func ForRoute(path string) (Info, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	if info, ok := reg.routes[path]; ok {
+		return info, true
+	}
+	best, found := -1, Info{}
+	for pattern, info := range reg.routes {
+		prefix, ok := strings.CutSuffix(pattern, "/*")
+		if !ok || len(prefix) <= best {
+			continue
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || prefix == "" {
+			best, found = len(prefix), info
+		}
+	}
+	return found, best >= 0
+}
+
+// SetBasePath sets the prefix of the app's URLs, such as "/app", which
+// Current removes before matching routes. The router's SetBasePath calls
+// it, so apps needn't.
+func SetBasePath(base string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.basePath = base
+}
+
+// Current returns the owner of the page being shown
+func Current() (Info, bool) {
+	path := js.Global().Get("location").Get("pathname").String()
+	reg.mu.RLock()
+	base := reg.basePath
+	reg.mu.RUnlock()
+	if base != "" {
+		if path == base {
+			path = "/"
+		} else if rest, ok := strings.CutPrefix(path, base+"/"); ok {
+			path = "/" + rest
+		}
+	}
+	return ForRoute(path)
+}
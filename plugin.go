@@ -0,0 +1,221 @@
+//go:build js && wasm
+
+package gux
+
+import (
+	"fmt"
+	"sort"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/di"
+)
+
+// Plugin is a reusable feature package that adds routes, sidebar items, and
+// settings panels to any gux app. Plugins register themselves from an init
+// function, so an app enables one with a blank import:
+//
+//	import _ "github.com/acme/auditlog"
+//
+//	// in package auditlog
+//	func init() { gux.RegisterPlugin(&Plugin{}) }
+type Plugin interface {
+	// Name uniquely identifies the plugin, e.g. "auditlog"
+	Name() string
+
+	// Init is called once by LoadPlugins. Use ctx to register routes,
+	// sidebar items, and settings panels.
+	Init(ctx *AppContext) error
+}
+
+// SettingsPanel is a section a plugin contributes to the app's settings page
+type SettingsPanel struct {
+	Title       string
+	Description string
+	Order       int             // Panels are sorted by Order, then registration order
+	Render      func() js.Value // Called each time the settings page is shown
+}
+
+var registeredPlugins []Plugin
+
+// RegisterPlugin makes a plugin available to LoadPlugins. It panics if a
+// plugin with the same name is already registered.
+func RegisterPlugin(p Plugin) {
+	for _, existing := range registeredPlugins {
+		if existing.Name() == p.Name() {
+			panic("gux: plugin " + p.Name() + " registered twice")
+		}
+	}
+	registeredPlugins = append(registeredPlugins, p)
+}
+
+// RegisteredPlugins returns the registered plugins in registration order
+func RegisteredPlugins() []Plugin {
+	return append([]Plugin(nil), registeredPlugins...)
+}
+
+// AppContext is the app surface exposed to a plugin during Init
+type AppContext struct {
+	host   *PluginHost
+	plugin string
+}
+
+// PluginName returns the name of the plugin being initialized
+func (c *AppContext) PluginName() string {
+	return c.plugin
+}
+
+// Router returns the app router
+func (c *AppContext) Router() *components.Router {
+	return c.host.router
+}
+
+// Services returns the app's service container. Resolve shared API clients,
+// stores, and flags from it, and provide services the plugin's pages need:
+//
+//	users := di.MustResolve[api.UsersAPI](ctx.Services())
+func (c *AppContext) Services() *di.Container {
+	return c.host.services
+}
+
+// Route registers a client-side route, applying the app's route guard if one is set
+func (c *AppContext) Route(path string, handler components.RouteHandler) {
+	if c.host.guard != nil {
+		handler = c.host.guard(handler)
+	}
+	c.host.router.Register(path, handler)
+	c.host.routes = append(c.host.routes, path)
+}
+
+// NavItem adds an item to the app sidebar
+func (c *AppContext) NavItem(item components.NavItem) {
+	c.host.navItems = append(c.host.navItems, item)
+}
+
+// SettingsPanel adds a panel to the app settings page
+func (c *AppContext) SettingsPanel(panel SettingsPanel) {
+	c.host.panels = append(c.host.panels, panel)
+}
+
+// SetContent replaces the main content area. Call it from route handlers.
+func (c *AppContext) SetContent(content js.Value) {
+	c.host.SetContent(content)
+}
+
+// Navigate navigates to a path
+func (c *AppContext) Navigate(path string) {
+	c.host.router.Navigate(path)
+}
+
+// PluginHost holds everything the loaded plugins contributed
+type PluginHost struct {
+	router     *components.Router
+	services   *di.Container
+	plugins    []Plugin
+	routes     []string
+	navItems   []components.NavItem
+	panels     []SettingsPanel
+	setContent func(js.Value)
+	guard      func(components.RouteHandler) components.RouteHandler
+}
+
+// PluginOption configures LoadPlugins
+type PluginOption func(*PluginHost)
+
+// WithRouteGuard wraps every plugin route handler, e.g. to require authentication
+func WithRouteGuard(guard func(components.RouteHandler) components.RouteHandler) PluginOption {
+	return func(h *PluginHost) {
+		h.guard = guard
+	}
+}
+
+// WithServices shares the app's service container with plugins. Without it
+// plugins get an empty container.
+func WithServices(services *di.Container) PluginOption {
+	return func(h *PluginHost) {
+		h.services = services
+	}
+}
+
+// LoadPlugins initializes every registered plugin against router. Call it
+// after registering the app's own routes and before creating the layout, so
+// the plugins' NavItems can be added to the sidebar.
+func LoadPlugins(router *components.Router, opts ...PluginOption) (*PluginHost, error) {
+	host := &PluginHost{router: router}
+	for _, opt := range opts {
+		opt(host)
+	}
+	if host.services == nil {
+		host.services = di.New()
+	}
+
+	for _, p := range registeredPlugins {
+		ctx := &AppContext{host: host, plugin: p.Name()}
+		if err := p.Init(ctx); err != nil {
+			return host, fmt.Errorf("gux: plugin %s: %w", p.Name(), err)
+		}
+		host.plugins = append(host.plugins, p)
+	}
+
+	sort.SliceStable(host.panels, func(i, j int) bool {
+		return host.panels[i].Order < host.panels[j].Order
+	})
+	return host, nil
+}
+
+// Services returns the service container shared with plugins
+func (h *PluginHost) Services() *di.Container {
+	return h.services
+}
+
+// Plugins returns the successfully initialized plugins
+func (h *PluginHost) Plugins() []Plugin {
+	return h.plugins
+}
+
+// Routes returns the paths registered by plugins
+func (h *PluginHost) Routes() []string {
+	return h.routes
+}
+
+// NavItems returns the sidebar items contributed by plugins
+func (h *PluginHost) NavItems() []components.NavItem {
+	return h.navItems
+}
+
+// SettingsPanels returns the settings panels contributed by plugins, in display order
+func (h *PluginHost) SettingsPanels() []SettingsPanel {
+	return h.panels
+}
+
+// SetLayout directs plugin content into the layout's content area
+func (h *PluginHost) SetLayout(layout *components.Layout) {
+	h.setContent = layout.SetContent
+}
+
+// SetContentFunc directs plugin content to a custom setter, for apps without a Layout
+func (h *PluginHost) SetContentFunc(fn func(js.Value)) {
+	h.setContent = fn
+}
+
+// SetContent renders content in the app's content area
+func (h *PluginHost) SetContent(content js.Value) {
+	if h.setContent == nil {
+		js.Global().Get("console").Call("warn", "gux: plugin content set before SetLayout")
+		return
+	}
+	h.setContent(content)
+}
+
+// SettingsContent renders all plugin settings panels as cards
+func (h *PluginHost) SettingsContent() js.Value {
+	cards := make([]js.Value, 0, len(h.panels))
+	for _, panel := range h.panels {
+		var children []js.Value
+		if panel.Render != nil {
+			children = append(children, panel.Render())
+		}
+		cards = append(cards, components.TitledCard(panel.Title, panel.Description, children...))
+	}
+	return components.Div("space-y-4", cards...)
+}
@@ -0,0 +1,280 @@
+//go:build js && wasm
+
+// Package prefs persists user layout preferences such as sidebar state,
+// drawer widths, theme, and density. Values are stored in localStorage and
+// can optionally be synced to a server so they roam across devices.
+package prefs
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/storage"
+)
+
+// storageKey is the localStorage key holding all layout preferences
+const storageKey = "gux-prefs"
+
+// Well-known preference keys used by the components package
+const (
+	KeySidebarCollapsed = "sidebar.collapsed"
+	KeyTheme            = "theme"
+	KeyDensity          = "density"
+	KeyLocale           = "locale"
+	KeyMacros           = "macros"
+)
+
+// DrawerWidthKey returns the preference key for a persisted drawer width
+func DrawerWidthKey(id string) string {
+	return "drawer." + id + ".width"
+}
+
+// TableColumnsKey returns the preference key for a persisted table column layout
+func TableColumnsKey(id string) string {
+	return "table." + id + ".columns"
+}
+
+// Syncer loads and saves the full preference set on a remote server
+type Syncer interface {
+	Load() (map[string]json.RawMessage, error)
+	Save(values map[string]json.RawMessage) error
+}
+
+// LayoutService stores layout preferences and notifies subscribers of changes
+type LayoutService struct {
+	values      map[string]json.RawMessage
+	loaded      bool
+	subscribers map[string]map[int]func()
+	nextID      int
+	syncer      Syncer
+	saveTimer   js.Value
+	saveFunc    js.Func
+}
+
+// Layout is the global layout preferences service
+var Layout = &LayoutService{}
+
+func (l *LayoutService) ensureLoaded() {
+	if l.loaded {
+		return
+	}
+	l.loaded = true
+	l.values = make(map[string]json.RawMessage)
+	l.subscribers = make(map[string]map[int]func())
+
+	saved := storage.Local.Get(storageKey)
+	if saved == "" {
+		return
+	}
+	// Corrupt data is discarded rather than blocking the app
+	if err := json.Unmarshal([]byte(saved), &l.values); err != nil {
+		l.values = make(map[string]json.RawMessage)
+	}
+}
+
+func (l *LayoutService) persist() {
+	if data, err := json.Marshal(l.values); err == nil {
+		storage.Local.Set(storageKey, string(data))
+	}
+	l.scheduleSync()
+}
+
+// scheduleSync debounces remote saves so rapid changes (e.g. resizing) send one request
+func (l *LayoutService) scheduleSync() {
+	if l.syncer == nil {
+		return
+	}
+	if l.saveFunc.IsUndefined() {
+		l.saveFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+			l.saveTimer = js.Undefined()
+			values := l.snapshot()
+			syncer := l.syncer
+			// Fetch blocks, so push from a goroutine
+			go func() {
+				if err := syncer.Save(values); err != nil {
+					js.Global().Get("console").Call("warn", "prefs: sync save failed:", err.Error())
+				}
+			}()
+			return nil
+		})
+	}
+	if !l.saveTimer.IsUndefined() && !l.saveTimer.IsNull() {
+		js.Global().Call("clearTimeout", l.saveTimer)
+	}
+	l.saveTimer = js.Global().Call("setTimeout", l.saveFunc, 1000)
+}
+
+func (l *LayoutService) snapshot() map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage, len(l.values))
+	for k, v := range l.values {
+		values[k] = v
+	}
+	return values
+}
+
+func (l *LayoutService) notify(key string) {
+	for _, fn := range l.subscribers[key] {
+		fn()
+	}
+}
+
+// EnableSync loads preferences from the server, overriding local values,
+// and pushes subsequent changes back. The load runs in a goroutine, and
+// its values are applied from a timer callback like any other event, so
+// they never change under a running handler.
+func (l *LayoutService) EnableSync(syncer Syncer) {
+	l.ensureLoaded()
+	l.syncer = syncer
+
+	go func() {
+		remote, err := syncer.Load()
+		if err != nil {
+			js.Global().Get("console").Call("warn", "prefs: sync load failed:", err.Error())
+			return
+		}
+		var apply js.Func
+		apply = js.FuncOf(func(this js.Value, args []js.Value) any {
+			apply.Release()
+			l.applyRemote(remote)
+			return nil
+		})
+		js.Global().Call("setTimeout", apply, 0)
+	}()
+}
+
+// applyRemote stores the values loaded from the server and notifies the
+// subscribers of those that changed
+func (l *LayoutService) applyRemote(remote map[string]json.RawMessage) {
+	var changed []string
+	for key, value := range remote {
+		if string(l.values[key]) != string(value) {
+			l.values[key] = value
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	if data, err := json.Marshal(l.values); err == nil {
+		storage.Local.Set(storageKey, string(data))
+	}
+	for _, key := range changed {
+		l.notify(key)
+	}
+}
+
+// Has reports whether a preference has been set
+func (l *LayoutService) Has(key string) bool {
+	l.ensureLoaded()
+	_, ok := l.values[key]
+	return ok
+}
+
+// Get unmarshals a preference into dest. It is a no-op if the key is not set.
+func (l *LayoutService) Get(key string, dest any) error {
+	l.ensureLoaded()
+	raw, ok := l.values[key]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// Set stores a JSON-serializable preference and notifies subscribers if it changed
+func (l *LayoutService) Set(key string, value any) error {
+	l.ensureLoaded()
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if string(l.values[key]) == string(data) {
+		return nil
+	}
+	l.values[key] = data
+	l.persist()
+	l.notify(key)
+	return nil
+}
+
+// Remove deletes a preference
+func (l *LayoutService) Remove(key string) {
+	l.ensureLoaded()
+	if _, ok := l.values[key]; !ok {
+		return
+	}
+	delete(l.values, key)
+	l.persist()
+	l.notify(key)
+}
+
+// Reset clears all preferences
+func (l *LayoutService) Reset() {
+	l.ensureLoaded()
+	keys := make([]string, 0, len(l.values))
+	for k := range l.values {
+		keys = append(keys, k)
+	}
+	l.values = make(map[string]json.RawMessage)
+	l.persist()
+	for _, k := range keys {
+		l.notify(k)
+	}
+}
+
+// GetBool returns a boolean preference or def if unset
+func (l *LayoutService) GetBool(key string, def bool) bool {
+	v := def
+	if err := l.Get(key, &v); err != nil {
+		return def
+	}
+	return v
+}
+
+// SetBool stores a boolean preference
+func (l *LayoutService) SetBool(key string, value bool) {
+	l.Set(key, value)
+}
+
+// GetString returns a string preference or def if unset
+func (l *LayoutService) GetString(key string, def string) string {
+	v := def
+	if err := l.Get(key, &v); err != nil {
+		return def
+	}
+	return v
+}
+
+// SetString stores a string preference
+func (l *LayoutService) SetString(key string, value string) {
+	l.Set(key, value)
+}
+
+// GetFloat returns a numeric preference or def if unset
+func (l *LayoutService) GetFloat(key string, def float64) float64 {
+	v := def
+	if err := l.Get(key, &v); err != nil {
+		return def
+	}
+	return v
+}
+
+// SetFloat stores a numeric preference
+func (l *LayoutService) SetFloat(key string, value float64) {
+	l.Set(key, value)
+}
+
+// Subscribe calls fn whenever key changes locally or via sync. Returns an unsubscribe function.
+func (l *LayoutService) Subscribe(key string, fn func()) func() {
+	l.ensureLoaded()
+	if l.subscribers[key] == nil {
+		l.subscribers[key] = make(map[int]func())
+	}
+	id := l.nextID
+	l.nextID++
+	l.subscribers[key][id] = fn
+
+	return func() {
+		delete(l.subscribers[key], id)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build js && wasm
+
+package prefs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type fakeSyncer struct {
+	remote map[string]json.RawMessage
+}
+
+func (f *fakeSyncer) Load() (map[string]json.RawMessage, error) { return f.remote, nil }
+func (f *fakeSyncer) Save(map[string]json.RawMessage) error     { return nil }
+
+func TestEnableSyncAppliesRemoteValues(t *testing.T) {
+	l := &LayoutService{}
+	l.SetBool(KeySidebarCollapsed, false)
+	notified := 0
+	l.Subscribe(KeySidebarCollapsed, func() { notified++ })
+
+	l.EnableSync(&fakeSyncer{remote: map[string]json.RawMessage{KeySidebarCollapsed: json.RawMessage("true")}})
+	for deadline := time.Now().Add(time.Second); !l.GetBool(KeySidebarCollapsed, false); {
+		if time.Now().After(deadline) {
+			t.Fatal("remote value never applied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if notified != 1 {
+		t.Errorf("subscriber called %d times, want 1", notified)
+	}
+}
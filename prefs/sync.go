@@ -0,0 +1,72 @@
+//go:build js && wasm
+
+package prefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dougbarrett/gux/fetch"
+)
+
+// HTTPSync syncs preferences with an endpoint that returns the preference
+// object on GET and replaces it on PUT, such as server.PreferencesHandler.
+type HTTPSync struct {
+	URL     string
+	Headers func() map[string]string // Optional, e.g. to add an Authorization header
+}
+
+// NewHTTPSync creates an HTTPSync for the given endpoint URL
+func NewHTTPSync(url string) *HTTPSync {
+	return &HTTPSync{URL: url}
+}
+
+func (h *HTTPSync) headers() map[string]string {
+	headers := map[string]string{"Accept": "application/json"}
+	if h.Headers != nil {
+		for k, v := range h.Headers() {
+			headers[k] = v
+		}
+	}
+	return headers
+}
+
+// Load fetches the stored preferences. A 404 is treated as an empty set.
+func (h *HTTPSync) Load() (map[string]json.RawMessage, error) {
+	resp, err := fetch.Get(h.URL, h.headers())
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == http.StatusNotFound {
+		return map[string]json.RawMessage{}, nil
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("prefs: load failed: %d %s", resp.Status, resp.StatusText)
+	}
+
+	values := make(map[string]json.RawMessage)
+	if resp.Body == "" {
+		return values, nil
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Save replaces the stored preferences
+func (h *HTTPSync) Save(values map[string]json.RawMessage) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	resp, err := fetch.Put(h.URL, string(data), h.headers())
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("prefs: save failed: %d %s", resp.Status, resp.StatusText)
+	}
+	return nil
+}
@@ -0,0 +1,473 @@
+//go:build js && wasm
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/components"
+	"github.com/dougbarrett/gux/fetch"
+)
+
+// NodeRenderer renders a node type. Register one to add custom node types
+// or override the built-in rendering.
+type NodeRenderer func(r *Renderer, node Node) js.Value
+
+// Options configures a Renderer
+type Options struct {
+	BaseURL string                   // Prefix for relative binding and form URLs
+	Headers func() map[string]string // Optional, e.g. to add an Authorization header
+	OnError func(err error)          // Called when loading or submitting fails (default: error toast)
+}
+
+// Renderer builds component trees from schemas and keeps their data bindings loaded
+type Renderer struct {
+	opts    Options
+	custom  map[NodeType]NodeRenderer
+	loaders []func()
+	timers  []js.Value
+	funcs   []js.Func
+}
+
+// NewRenderer creates a Renderer
+func NewRenderer(opts Options) *Renderer {
+	if opts.OnError == nil {
+		opts.OnError = func(err error) {
+			components.ShowError(err.Error())
+		}
+	}
+	return &Renderer{
+		opts:   opts,
+		custom: make(map[NodeType]NodeRenderer),
+	}
+}
+
+// Register sets the renderer for a node type
+func (r *Renderer) Register(nodeType NodeType, fn NodeRenderer) {
+	r.custom[nodeType] = fn
+}
+
+// Load fetches a schema from url. It blocks, so call it from a goroutine.
+func (r *Renderer) Load(url string) (*Schema, error) {
+	resp, err := fetch.Get(r.resolve(url), r.headers())
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("schema: load %s failed: %d %s", url, resp.Status, resp.StatusText)
+	}
+	var s Schema
+	if err := json.Unmarshal([]byte(resp.Body), &s); err != nil {
+		return nil, fmt.Errorf("schema: invalid schema: %w", err)
+	}
+	return &s, nil
+}
+
+// Render builds the schema's element tree and starts loading its data bindings.
+// Call Destroy before discarding the result to stop refresh timers.
+func (r *Renderer) Render(s *Schema) js.Value {
+	children := make([]js.Value, 0, len(s.Nodes)+1)
+	if s.Title != "" {
+		children = append(children, components.H1(s.Title))
+	}
+	for _, node := range s.Nodes {
+		children = append(children, r.RenderNode(node))
+	}
+	return components.Div("space-y-6", children...)
+}
+
+// RenderNode renders a single node, dispatching to registered renderers first
+func (r *Renderer) RenderNode(node Node) js.Value {
+	if fn, ok := r.custom[node.Type]; ok {
+		return fn(r, node)
+	}
+
+	switch node.Type {
+	case NodeSection:
+		return components.Section(node.Title, r.renderChildren(node)...)
+	case NodeCard:
+		if node.Title != "" {
+			return components.TitledCard(node.Title, node.Description, r.renderChildren(node)...)
+		}
+		return components.Card(r.renderChildren(node)...)
+	case NodeGrid:
+		return r.renderGrid(node)
+	case NodeHeading:
+		return components.Heading(node.Level, node.Text)
+	case NodeText:
+		return components.Text(node.Text)
+	case NodeStat:
+		return r.renderStat(node)
+	case NodeTable:
+		return r.renderTable(node)
+	case NodeForm:
+		return r.renderForm(node)
+	case NodeChart:
+		return r.renderChart(node)
+	}
+	return components.AlertWarningMsg(fmt.Sprintf("Unknown schema node type %q", node.Type))
+}
+
+// Reload re-fetches every data binding rendered so far
+func (r *Renderer) Reload() {
+	for _, load := range r.loaders {
+		go load()
+	}
+}
+
+// Destroy stops refresh timers and releases callbacks
+func (r *Renderer) Destroy() {
+	for _, t := range r.timers {
+		js.Global().Call("clearInterval", t)
+	}
+	for _, fn := range r.funcs {
+		fn.Release()
+	}
+	r.timers = nil
+	r.funcs = nil
+	r.loaders = nil
+}
+
+// Fetch loads a binding and returns the value at its path. It blocks, so
+// call it from a goroutine. Custom node renderers can use it to bind data.
+func (r *Renderer) Fetch(b *Binding) (any, error) {
+	resp, err := fetch.Get(r.resolve(b.URL), r.headers())
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("schema: load %s failed: %d %s", b.URL, resp.Status, resp.StatusText)
+	}
+	var data any
+	if err := json.Unmarshal([]byte(resp.Body), &data); err != nil {
+		return nil, err
+	}
+	return lookup(data, b.Path)
+}
+
+// Bind loads b now and on its refresh interval, passing each result to apply.
+// Errors are reported through Options.OnError.
+func (r *Renderer) Bind(b *Binding, apply func(data any)) {
+	if b == nil || b.URL == "" {
+		return
+	}
+	load := func() {
+		data, err := r.Fetch(b)
+		if err != nil {
+			r.opts.OnError(err)
+			return
+		}
+		apply(data)
+	}
+	r.loaders = append(r.loaders, load)
+	go load()
+
+	if b.Refresh > 0 {
+		fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+			go load()
+			return nil
+		})
+		r.funcs = append(r.funcs, fn)
+		r.timers = append(r.timers, js.Global().Call("setInterval", fn, b.Refresh*1000))
+	}
+}
+
+func (r *Renderer) renderChildren(node Node) []js.Value {
+	children := make([]js.Value, len(node.Children))
+	for i, child := range node.Children {
+		children[i] = r.RenderNode(child)
+	}
+	return children
+}
+
+// gridColumns spells out the column classes, since gux build's Tailwind
+// scan only finds string literals
+var gridColumns = map[int]string{
+	1:  "md:grid-cols-1",
+	2:  "md:grid-cols-2",
+	3:  "md:grid-cols-3",
+	4:  "md:grid-cols-4",
+	5:  "md:grid-cols-5",
+	6:  "md:grid-cols-6",
+	7:  "md:grid-cols-7",
+	8:  "md:grid-cols-8",
+	9:  "md:grid-cols-9",
+	10: "md:grid-cols-10",
+	11: "md:grid-cols-11",
+	12: "md:grid-cols-12",
+}
+
+func (r *Renderer) renderGrid(node Node) js.Value {
+	cols, ok := gridColumns[node.Columns]
+	if !ok {
+		cols = gridColumns[2]
+	}
+	return components.Div("grid grid-cols-1 "+cols+" gap-4", r.renderChildren(node)...)
+}
+
+func (r *Renderer) renderStat(node Node) js.Value {
+	value := components.HeadingWithClass(2, node.Text, "text-3xl font-bold text-primary")
+	if node.Data != nil && node.Text == "" {
+		value.Set("textContent", "—")
+	}
+
+	r.Bind(node.Data, func(data any) {
+		value.Set("textContent", formatValue(data))
+	})
+
+	return components.Card(
+		components.TextWithClass(node.Title, "text-sm text-muted"),
+		value,
+	)
+}
+
+func (r *Renderer) renderTable(node Node) js.Value {
+	spec := node.Table
+	if spec == nil {
+		spec = &TableSpec{}
+	}
+
+	columns := make([]components.TableColumn, len(spec.Columns))
+	for i, c := range spec.Columns {
+		columns[i] = components.TableColumn{Header: c.Header, Key: c.Key, Sortable: c.Sortable}
+	}
+
+	table := components.NewTable(components.TableProps{
+		Columns:    columns,
+		RowKey:     spec.RowKey,
+		Hoverable:  true,
+		Filterable: spec.Filterable,
+		Paginated:  spec.Paginated,
+		PageSize:   spec.PageSize,
+		EmptyTitle: spec.EmptyTitle,
+	})
+
+	r.Bind(node.Data, func(data any) {
+		table.SetData(rows(data))
+	})
+
+	return r.titled(node, table.Element())
+}
+
+func (r *Renderer) renderChart(node Node) js.Value {
+	spec := node.Chart
+	if spec == nil {
+		spec = &ChartSpec{}
+	}
+
+	container := components.Div("")
+	container.Call("appendChild", components.Skeleton(components.SkeletonProps{
+		Width: "w-full", Height: "h-48", Rounded: true, Animate: true,
+	}))
+
+	r.Bind(node.Data, func(data any) {
+		items := rows(data)
+		points := make([]components.ChartData, len(items))
+		for i, item := range items {
+			points[i] = components.ChartData{
+				Label: formatValue(item[spec.LabelKey]),
+				Value: toFloat(item[spec.ValueKey]),
+			}
+		}
+
+		var chart js.Value
+		switch spec.Kind {
+		case ChartLine:
+			chart = components.LineChart(components.LineChartProps{
+				Data: points, Height: spec.Height, ShowPoints: true, ShowLabels: true, ShowGrid: true,
+			})
+		case ChartPie:
+			chart = components.PieChart(components.PieChartProps{
+				Data: points, Size: spec.Height, ShowLegend: true,
+			})
+		default:
+			chart = components.BarChart(components.BarChartProps{
+				Data: points, Height: spec.Height, ShowLabels: true, ShowValues: true,
+			})
+		}
+		container.Set("innerHTML", "")
+		container.Call("appendChild", chart)
+	})
+
+	return r.titled(node, container)
+}
+
+func (r *Renderer) renderForm(node Node) js.Value {
+	spec := node.Form
+	if spec == nil {
+		return components.AlertWarningMsg("Form node is missing its form spec")
+	}
+
+	fields := make([]components.BuilderField, len(spec.Fields))
+	for i, f := range spec.Fields {
+		field := components.BuilderField{
+			Name:         f.Name,
+			Type:         components.BuilderFieldType(f.Type),
+			Label:        f.Label,
+			Placeholder:  f.Placeholder,
+			DefaultValue: f.Default,
+		}
+		if field.Type == "" {
+			field.Type = components.BuilderFieldText
+		}
+		if f.Required {
+			field.Rules = append(field.Rules, components.Required)
+		}
+		if field.Type == components.BuilderFieldEmail {
+			field.Rules = append(field.Rules, components.Email)
+		}
+		for _, o := range f.Options {
+			field.Options = append(field.Options, components.SelectOption{Label: o.Label, Value: o.Value})
+		}
+		fields[i] = field
+	}
+
+	var form *components.FormBuilder
+	form = components.NewFormBuilder(components.FormBuilderProps{
+		Fields:     fields,
+		SubmitText: spec.SubmitText,
+		OnSubmit: func(values map[string]any) error {
+			body, err := json.Marshal(values)
+			if err != nil {
+				return err
+			}
+			// Fetch blocks, so submit from a goroutine
+			go func() {
+				if err := r.submit(spec, string(body)); err != nil {
+					r.opts.OnError(err)
+					return
+				}
+				if spec.SuccessMessage != "" {
+					components.ShowSuccess(spec.SuccessMessage)
+				}
+				form.Reset()
+				if spec.Reload {
+					r.Reload()
+				}
+			}()
+			return nil
+		},
+	})
+
+	return r.titled(node, form.Element())
+}
+
+func (r *Renderer) submit(spec *FormSpec, body string) error {
+	method := strings.ToUpper(spec.Method)
+	if method == "" {
+		method = "POST"
+	}
+	headers := r.headers()
+	headers["Content-Type"] = "application/json"
+
+	resp, err := fetch.Fetch(r.resolve(spec.Action), &fetch.Options{
+		Method:  method,
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("schema: submit to %s failed: %d %s", spec.Action, resp.Status, resp.StatusText)
+	}
+	return nil
+}
+
+// titled wraps data-bound content in a titled card when the node has a title
+func (r *Renderer) titled(node Node, content js.Value) js.Value {
+	if node.Title == "" {
+		return content
+	}
+	return components.TitledCard(node.Title, node.Description, content)
+}
+
+func (r *Renderer) resolve(url string) string {
+	if r.opts.BaseURL != "" && strings.HasPrefix(url, "/") {
+		return strings.TrimSuffix(r.opts.BaseURL, "/") + url
+	}
+	return url
+}
+
+func (r *Renderer) headers() map[string]string {
+	headers := map[string]string{"Accept": "application/json"}
+	if r.opts.Headers != nil {
+		for k, v := range r.opts.Headers() {
+			headers[k] = v
+		}
+	}
+	return headers
+}
+
+// lookup follows a dot path through decoded JSON objects and arrays
+func lookup(data any, path string) (any, error) {
+	if path == "" {
+		return data, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		switch v := data.(type) {
+		case map[string]any:
+			next, ok := v[part]
+			if !ok {
+				return nil, fmt.Errorf("schema: path %q: key %q not found", path, part)
+			}
+			data = next
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("schema: path %q: invalid index %q", path, part)
+			}
+			data = v[i]
+		default:
+			return nil, fmt.Errorf("schema: path %q: cannot index into %T", path, data)
+		}
+	}
+	return data, nil
+}
+
+// rows converts a decoded JSON array of objects into table rows, skipping non-objects
+func rows(data any) []map[string]any {
+	items, _ := data.([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if row, ok := item.(map[string]any); ok {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
+
+func toFloat(v any) float64 {
+	switch val := v.(type) {
+	case float64:
+		return val
+	case string:
+		f, _ := strconv.ParseFloat(val, 64)
+		return f
+	case bool:
+		if val {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,112 @@
+// Package schema defines a JSON UI schema for server-driven pages and a
+// renderer that builds them from gux components. The types have no build
+// constraints so servers can construct and serve schemas, while the
+// renderer runs in the WASM client. Changing a dashboard then only requires
+// changing the JSON the server returns, not redeploying the WASM bundle.
+package schema
+
+// NodeType identifies how a node is rendered
+type NodeType string
+
+const (
+	NodeSection NodeType = "section" // Titled section wrapping children
+	NodeCard    NodeType = "card"    // Card wrapping children, with optional title/description
+	NodeGrid    NodeType = "grid"    // Responsive grid of children
+	NodeHeading NodeType = "heading" // Heading with Text at Level
+	NodeText    NodeType = "text"    // Paragraph of Text
+	NodeStat    NodeType = "stat"    // Single metric: Title label with a static or bound value
+	NodeTable   NodeType = "table"   // Table bound to an array of objects
+	NodeForm    NodeType = "form"    // Form that submits to an endpoint
+	NodeChart   NodeType = "chart"   // Bar, line, or pie chart bound to an array of objects
+)
+
+// Schema is a complete server-driven page
+type Schema struct {
+	Title string `json:"title,omitempty"`
+	Nodes []Node `json:"nodes"`
+}
+
+// Node is a single element in the schema tree. Only the spec matching Type is used.
+type Node struct {
+	Type        NodeType `json:"type"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Text        string   `json:"text,omitempty"`    // Heading/text content, or the static value of a stat
+	Level       int      `json:"level,omitempty"`   // Heading level 1-6 (default 2)
+	Columns     int      `json:"columns,omitempty"` // Grid columns on wide screens, 1 to 12 (default 2)
+	Children    []Node   `json:"children,omitempty"`
+
+	Data  *Binding   `json:"data,omitempty"` // Data source for stat, table, and chart nodes
+	Table *TableSpec `json:"table,omitempty"`
+	Form  *FormSpec  `json:"form,omitempty"`
+	Chart *ChartSpec `json:"chart,omitempty"`
+}
+
+// Binding loads node data from an API endpoint
+type Binding struct {
+	URL     string `json:"url"`
+	Path    string `json:"path,omitempty"`    // Dot path into the JSON response, e.g. "data.items" or "totals.0.count"
+	Refresh int    `json:"refresh,omitempty"` // Reload interval in seconds (0 = load once)
+}
+
+// Column describes a table column
+type Column struct {
+	Header   string `json:"header"`
+	Key      string `json:"key"`
+	Sortable bool   `json:"sortable,omitempty"`
+}
+
+// TableSpec configures a table node
+type TableSpec struct {
+	Columns    []Column `json:"columns"`
+	RowKey     string   `json:"rowKey,omitempty"`
+	Filterable bool     `json:"filterable,omitempty"`
+	Paginated  bool     `json:"paginated,omitempty"`
+	PageSize   int      `json:"pageSize,omitempty"`
+	EmptyTitle string   `json:"emptyTitle,omitempty"`
+}
+
+// Option is a choice for select and radio fields
+type Option struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Field describes a form field. Type matches the FormBuilder field types
+// (text, email, password, number, textarea, select, checkbox, radio, date, time).
+type Field struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label,omitempty"`
+	Type        string   `json:"type,omitempty"` // Default "text"
+	Placeholder string   `json:"placeholder,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Default     any      `json:"default,omitempty"`
+	Options     []Option `json:"options,omitempty"`
+}
+
+// FormSpec configures a form node
+type FormSpec struct {
+	Fields         []Field `json:"fields"`
+	Action         string  `json:"action"`           // Endpoint the JSON-encoded values are sent to
+	Method         string  `json:"method,omitempty"` // Default "POST"
+	SubmitText     string  `json:"submitText,omitempty"`
+	SuccessMessage string  `json:"successMessage,omitempty"`
+	Reload         bool    `json:"reload,omitempty"` // Reload all data bindings on the page after a successful submit
+}
+
+// ChartKind selects the chart component
+type ChartKind string
+
+const (
+	ChartBar  ChartKind = "bar"
+	ChartLine ChartKind = "line"
+	ChartPie  ChartKind = "pie"
+)
+
+// ChartSpec configures a chart node
+type ChartSpec struct {
+	Kind     ChartKind `json:"kind,omitempty"` // Default "bar"
+	LabelKey string    `json:"labelKey"`
+	ValueKey string    `json:"valueKey"`
+	Height   string    `json:"height,omitempty"`
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// nonceKey is the context key of the CSP nonce
+const nonceKey contextKey = "csp_nonce"
+
+// CSPOptions configures the CSP middleware
+type CSPOptions struct {
+	// Directives add to or replace the default policy's, by name:
+	//
+	//	Directives: map[string]string{
+	//		"img-src":     "'self' data: https://images.example.com",
+	//		"connect-src": "'self' https://api.example.com",
+	//		"report-uri":  "/csp-reports",
+	//	}
+	//
+	// The request's nonce is always added to script-src and style-src.
+	Directives map[string]string
+
+	// ReportOnly sends the policy as Content-Security-Policy-Report-Only,
+	// so violations are reported but nothing is blocked, for trying a
+	// policy out
+	ReportOnly bool
+}
+
+// defaultCSP is a strict policy a gux app runs under: scripts and styles
+// from the server or carrying the nonce, and WebAssembly compilation,
+// which script-src otherwise blocks
+var defaultCSP = map[string]string{
+	"default-src":     "'self'",
+	"script-src":      "'self' 'wasm-unsafe-eval'",
+	"style-src":       "'self'",
+	"img-src":         "'self' data: blob:",
+	"font-src":        "'self' data:",
+	"connect-src":     "'self'",
+	"object-src":      "'none'",
+	"base-uri":        "'self'",
+	"frame-ancestors": "'self'",
+}
+
+// CSP sends a Content-Security-Policy with a new nonce for each request,
+// so pages work without 'unsafe-inline'. The nonce is in the request
+// context (see Nonce): SPAHandler adds it to index.html's inline scripts
+// and styles, and templates add it to theirs. The components add it to the
+// styles they inject, reading it from the page's scripts.
+//
+//	handler := server.Chain(
+//		server.Logger(),
+//		server.CSP(server.CSPOptions{}),
+//	)(mux)
+//
+// The Tailwind CDN script LoadTailwind uses injects styles without the
+// nonce, so build the CSS with gux build for pages under a CSP.
+func CSP(opts CSPOptions) Middleware {
+	header := "Content-Security-Policy"
+	if opts.ReportOnly {
+		header = "Content-Security-Policy-Report-Only"
+	}
+	directives := make(map[string]string, len(defaultCSP)+len(opts.Directives))
+	for name, value := range defaultCSP {
+		directives[name] = value
+	}
+	for name, value := range opts.Directives {
+		directives[strings.ToLower(name)] = value
+	}
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nonce := newNonce()
+			policy := make([]string, 0, len(names))
+			for _, name := range names {
+				value := directives[name]
+				if name == "script-src" || name == "style-src" {
+					value = strings.TrimSpace(value + " 'nonce-" + nonce + "'")
+				}
+				policy = append(policy, strings.TrimSpace(name+" "+value))
+			}
+			w.Header().Set(header, strings.Join(policy, "; "))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), nonceKey, nonce)))
+		})
+	}
+}
+
+// Nonce returns the request's CSP nonce, set by CSP, or "". Put it on
+// inline scripts and styles in server-rendered HTML:
+//
+//	<script nonce="{{.Nonce}}">window.__STATE__ = {{.State}}</script>
+func Nonce(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceKey).(string)
+	return nonce
+}
+
+// newNonce returns 128 random bits, base64 encoded
+func newNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// inlineTag matches the start of a script or style tag
+var inlineTag = regexp.MustCompile(`(?i)<(script|style)(\s|>)`)
+
+// addNonce adds a nonce attribute to each script and style tag in html
+func addNonce(html []byte, nonce string) []byte {
+	return inlineTag.ReplaceAll(html, []byte(`<$1 nonce="`+nonce+`"$2`))
+}
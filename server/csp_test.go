@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCSP(t *testing.T) {
+	var nonce string
+	h := CSP(CSPOptions{Directives: map[string]string{
+		"IMG-SRC":    "'self' https://images.example.com",
+		"report-uri": "/csp-reports",
+	}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce = Nonce(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	policy := rec.Header().Get("Content-Security-Policy")
+	if nonce == "" {
+		t.Fatal("no nonce in the request context")
+	}
+	for _, want := range []string{
+		"script-src 'self' 'wasm-unsafe-eval' 'nonce-" + nonce + "'",
+		"style-src 'self' 'nonce-" + nonce + "'",
+		"img-src 'self' https://images.example.com",
+		"report-uri /csp-reports",
+		"object-src 'none'",
+	} {
+		if !strings.Contains(policy, want) {
+			t.Errorf("policy %q lacks %q", policy, want)
+		}
+	}
+	if strings.Contains(policy, "unsafe-inline") {
+		t.Errorf("policy %q allows inline code", policy)
+	}
+
+	first := nonce
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if nonce == first {
+		t.Error("two requests got the same nonce")
+	}
+}
+
+func TestCSPReportOnly(t *testing.T) {
+	h := CSP(CSPOptions{ReportOnly: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Header().Get("Content-Security-Policy") != "" || rec.Header().Get("Content-Security-Policy-Report-Only") == "" {
+		t.Errorf("headers = %v, want only the report-only policy", rec.Header())
+	}
+}
+
+func TestAddNonce(t *testing.T) {
+	html := `<head><SCRIPT>a()</SCRIPT><style media="print">b{}</style><scripts></head>`
+	want := `<head><SCRIPT nonce="n1">a()</SCRIPT><style nonce="n1" media="print">b{}</style><scripts></head>`
+	if got := string(addNonce([]byte(html), "n1")); got != want {
+		t.Errorf("addNonce = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dougbarrett/gux/api"
+)
+
+// IdempotencyStore records the idempotency keys that have been claimed.
+// Implement it over Redis or another shared store so duplicates are caught
+// across server instances; Claim must be atomic, e.g. SET NX with an expiry.
+type IdempotencyStore interface {
+	// Claim records key for ttl and reports whether it was not already taken
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Release forgets key so the request can be sent again
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyOptions configures the Idempotency middleware
+type IdempotencyOptions struct {
+	// Header carries the key (default "Idempotency-Key")
+	Header string
+
+	// Required rejects POST, PUT, PATCH, and DELETE requests without a key
+	// with 400. By default they are let through.
+	Required bool
+
+	// Key scopes keys to a client so one client cannot block another's
+	// submissions. Default: RateLimitByIP
+	Key func(r *http.Request) string
+
+	// TTL is how long a key is remembered after a successful request
+	// (default 24 hours)
+	TTL time.Duration
+
+	// Store holds the keys. Default: a new MemoryIdempotencyStore
+	Store IdempotencyStore
+
+	// SkipPaths are paths that are never checked.
+	// Supports exact matches and prefix matches with trailing *
+	SkipPaths []string
+
+	// ErrorHandler writes the response for duplicate and missing keys.
+	// Default: a JSON error body, 409 with code "duplicate_submission" for
+	// duplicates
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Idempotency returns middleware that rejects repeated POST, PUT, PATCH, and
+// DELETE requests carrying the same Idempotency-Key, such as a form submitted
+// twice by a double click or a retried request. A key is claimed when the
+// request starts; if the handler responds with an error status or panics the
+// key is released so the corrected request can be sent again. Store errors
+// are logged and the request is let through.
+//
+// Forms send their key with fetch.WithIdempotencyKey. Cross-origin clients
+// also need the header in CORSOptions.AllowHeaders.
+func Idempotency(opts IdempotencyOptions) Middleware {
+	if opts.Header == "" {
+		opts.Header = "Idempotency-Key"
+	}
+	if opts.Key == nil {
+		opts.Key = RateLimitByIP
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 24 * time.Hour
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryIdempotencyStore()
+	}
+	if opts.ErrorHandler == nil {
+		opts.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			api.WriteError(w, err)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if shouldSkipPath(r.URL.Path, opts.SkipPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			idem := r.Header.Get(opts.Header)
+			if idem == "" {
+				if opts.Required {
+					opts.ErrorHandler(w, r, api.BadRequest("missing "+opts.Header+" header"))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idem) > 255 {
+				opts.ErrorHandler(w, r, api.BadRequest(opts.Header+" header is too long"))
+				return
+			}
+
+			ctx := r.Context()
+			key := opts.Key(r) + "|" + r.Method + " " + r.URL.Path + "|" + idem
+			claimed, err := opts.Store.Claim(ctx, key, opts.TTL)
+			if err != nil {
+				log.Printf("idempotency: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !claimed {
+				opts.ErrorHandler(w, r, &api.Error{
+					Status:  http.StatusConflict,
+					Code:    "duplicate_submission",
+					Message: "this request has already been submitted",
+				})
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				if p := recover(); p != nil {
+					release(opts.Store, key)
+					panic(p)
+				}
+				if sw.status >= 400 {
+					release(opts.Store, key)
+				}
+			}()
+			next.ServeHTTP(sw, r)
+		})
+	}
+}
+
+// release forgets a key, even if the request context has been canceled
+func release(store IdempotencyStore, key string) {
+	if err := store.Release(context.Background(), key); err != nil {
+		log.Printf("idempotency: %v", err)
+	}
+}
+
+// statusWriter records the status code written by a handler
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// MemoryIdempotencyStore keeps keys in process memory. Duplicates are only
+// caught per server instance; use a shared store when running more than one.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time // key -> expiry
+	lastSweep time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{keys: make(map[string]time.Time), lastSweep: time.Now()}
+}
+
+// Claim records key until ttl elapses unless it is already recorded
+func (s *MemoryIdempotencyStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop expired keys now and then
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, expires := range s.keys {
+			if now.After(expires) {
+				delete(s.keys, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if expires, ok := s.keys[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Release forgets key
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	delete(s.keys, key)
+	s.mu.Unlock()
+	return nil
+}
@@ -177,6 +177,25 @@ func JWT(opts JWTOptions) Middleware {
 	}
 }
 
+// RequireAuth returns middleware that requires a signed-in user, as set by
+// JWT earlier in the chain
+func RequireAuth() Middleware {
+	return RequireAuthWithKey(defaultClaimsKey)
+}
+
+// RequireAuthWithKey returns middleware that requires a signed-in user using a custom context key
+func RequireAuthWithKey(claimsKey any) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if GetClaimsWithKey(r.Context(), claimsKey) == nil {
+				api.WriteError(w, api.Unauthorized("authentication required"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireRoles returns middleware that requires specific roles
 func RequireRoles(roles ...string) Middleware {
 	return RequireRolesWithKey(defaultClaimsKey, roles...)
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := Logger(LoggerOptions{
+		Logger:    slog.New(slog.NewJSONHandler(&buf, nil)),
+		SkipPaths: []string{"/health"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		GetLogger(r.Context()).Info("handled")
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("hello"))
+	}))
+
+	for _, path := range []string{"/posts", "/missing", "/health"} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-Request-ID", "req"+strings.TrimPrefix(path, "/"))
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("bad log line %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	// Three "handled" lines, and request lines for all but /health
+	if len(lines) != 5 {
+		t.Fatalf("logged %d lines, want 5:\n%s", len(lines), buf.String())
+	}
+
+	if lines[0]["msg"] != "handled" || lines[0]["request_id"] != "reqposts" {
+		t.Errorf("handler's line = %v, want it tagged with the request ID", lines[0])
+	}
+	posts := lines[1]
+	if posts["msg"] != "request" || posts["level"] != "INFO" || posts["path"] != "/posts" ||
+		posts["status"] != float64(200) || posts["bytes"] != float64(5) || posts["request_id"] != "reqposts" {
+		t.Errorf("request line = %v", posts)
+	}
+	if missing := lines[3]; missing["level"] != "WARN" || missing["status"] != float64(404) {
+		t.Errorf("404 line = %v, want a warning", missing)
+	}
+	if last := lines[4]; last["msg"] != "handled" || last["request_id"] != "reqhealth" {
+		t.Errorf("last line = %v, want /health's handler line only", last)
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	h := Logger(LoggerOptions{
+		Logger: slog.New(slog.NewTextHandler(&buf, nil)),
+		Level: func(r *http.Request, status int) slog.Level {
+			return slog.LevelDebug // below the handler's Info
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if buf.Len() != 0 {
+		t.Errorf("logged %q, want nothing", buf.String())
+	}
+}
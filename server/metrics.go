@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets are the latency histogram buckets in seconds, from 5ms to
+// 10s
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsOptions configures NewMetrics
+type MetricsOptions struct {
+	// Namespace prefixes the metric names, e.g. "blog" for
+	// blog_http_requests_total
+	Namespace string
+
+	// Buckets are the latency histogram's upper bounds in seconds (default
+	// DefaultBuckets)
+	Buckets []float64
+}
+
+// Metrics holds Prometheus request metrics recorded by Otel: a request
+// counter by method, route, and status, a latency histogram by method and
+// route, and an in-flight gauge. It serves them in the Prometheus text
+// format, so mount it where Prometheus scrapes:
+//
+//	metrics := server.NewMetrics()
+//	handler.Use(server.Otel(server.OtelOptions{Metrics: metrics}))
+//	mux.Handle("GET /metrics", metrics)
+type Metrics struct {
+	prefix   string
+	buckets  []float64
+	inFlight atomic.Int64
+
+	mu        sync.Mutex
+	requests  map[requestSeries]uint64
+	latencies map[latencySeries]*histogram
+}
+
+type requestSeries struct {
+	method, route string
+	status        int
+}
+
+type latencySeries struct {
+	method, route string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewMetrics creates an empty set of request metrics
+func NewMetrics(opts ...MetricsOptions) *Metrics {
+	var o MetricsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	buckets := o.Buckets
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	prefix := ""
+	if o.Namespace != "" {
+		prefix = o.Namespace + "_"
+	}
+	return &Metrics{
+		prefix:    prefix,
+		buckets:   buckets,
+		requests:  make(map[requestSeries]uint64),
+		latencies: make(map[latencySeries]*histogram),
+	}
+}
+
+// begin counts a request in flight until the returned function records it
+func (m *Metrics) begin() func(method, route string, status int, elapsed time.Duration) {
+	m.inFlight.Add(1)
+	return func(method, route string, status int, elapsed time.Duration) {
+		m.inFlight.Add(-1)
+		m.observe(method, route, status, elapsed)
+	}
+}
+
+func (m *Metrics) observe(method, route string, status int, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestSeries{method, route, status}]++
+
+	key := latencySeries{method, route}
+	h, ok := m.latencies[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[key] = h
+	}
+	seconds := elapsed.Seconds()
+	if i := sort.SearchFloat64s(m.buckets, seconds); i < len(m.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(m.text()))
+}
+
+func (m *Metrics) text() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var b strings.Builder
+
+	name := m.prefix + "http_requests_total"
+	fmt.Fprintf(&b, "# HELP %s HTTP requests served, by method, route, and status.\n# TYPE %s counter\n", name, name)
+	requests := make([]requestSeries, 0, len(m.requests))
+	for s := range m.requests {
+		requests = append(requests, s)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+	for _, s := range requests {
+		fmt.Fprintf(&b, "%s{method=%s,route=%s,status=\"%d\"} %d\n", name, labelValue(s.method), labelValue(s.route), s.status, m.requests[s])
+	}
+
+	name = m.prefix + "http_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s HTTP request latency in seconds, by method and route.\n# TYPE %s histogram\n", name, name)
+	latencies := make([]latencySeries, 0, len(m.latencies))
+	for s := range m.latencies {
+		latencies = append(latencies, s)
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		a, b := latencies[i], latencies[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		return a.method < b.method
+	})
+	for _, s := range latencies {
+		h := m.latencies[s]
+		labels := "method=" + labelValue(s.method) + ",route=" + labelValue(s.route)
+		var cumulative uint64
+		for i, le := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+
+	name = m.prefix + "http_requests_in_flight"
+	fmt.Fprintf(&b, "# HELP %s HTTP requests being served.\n# TYPE %s gauge\n%s %d\n", name, name, name, m.inFlight.Load())
+	return b.String()
+}
+
+// labelValue quotes a Prometheus label value
+func labelValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// routeKey is the context key of the request's route, recorded for Otel
+const routeKey contextKey = "route"
+
+// SetRoute records the route template that serves r, such as
+// "/api/posts/{id}", for Otel's span names and metric labels. Otel reads
+// ServeMux patterns itself when nothing copies the request between them;
+// generated handlers record their routes with WithRoute either way.
+func SetRoute(r *http.Request, route string) {
+	if p, ok := r.Context().Value(routeKey).(*string); ok {
+		*p = route
+	}
+}
+
+// WithRoute returns next with SetRoute called for route on each request
+func WithRoute(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetRoute(r, route)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withRouteRecorder returns ctx with a route for SetRoute to fill in
+func withRouteRecorder(ctx context.Context) (context.Context, *string) {
+	route := new(string)
+	return context.WithValue(ctx, routeKey, route), route
+}
+
+// patternRoute returns the path of a ServeMux pattern such as
+// "GET example.com/posts/{id}", without its method and host
+func patternRoute(pattern string) string {
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		return pattern[i:]
+	}
+	return pattern
+}
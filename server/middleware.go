@@ -1,10 +1,13 @@
 package server
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
+
+	"github.com/dougbarrett/gux/api"
 )
 
 // Middleware is a function that wraps an http.Handler
@@ -20,17 +23,6 @@ func Chain(middlewares ...Middleware) Middleware {
 	}
 }
 
-// Logger logs request method, path, and duration
-func Logger() Middleware {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			start := time.Now()
-			next.ServeHTTP(w, r)
-			log.Printf("%s %s %v", r.Method, r.URL.Path, time.Since(start))
-		})
-	}
-}
-
 // CORS adds Cross-Origin Resource Sharing headers
 func CORS(opts CORSOptions) Middleware {
 	if opts.AllowOrigin == "" {
@@ -65,14 +57,14 @@ type CORSOptions struct {
 	AllowHeaders string
 }
 
-// Recover catches panics and returns 500
+// Recover catches panics and returns a 500 JSON error
 func Recover() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if err := recover(); err != nil {
-					log.Printf("panic: %v", err)
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					// WriteError logs the panic and sends only the generic message
+					api.WriteError(w, fmt.Errorf("panic: %v: %w", err, api.InternalError("Internal server error")))
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -80,15 +72,59 @@ func Recover() Middleware {
 	}
 }
 
-// RequestID adds a unique request ID header
+// requestIDKey is the context key of the request ID
+const requestIDKey contextKey = "request_id"
+
+// RequestID gives each request an ID, in the X-Request-ID response header
+// and the request context. api.WriteError includes it in error responses
+// and logs it with server errors, so an ID a user quotes from an error
+// message finds the matching log lines. An X-Request-ID set by a proxy in
+// front of the server is kept.
 func RequestID() Middleware {
 	return func(next http.Handler) http.Handler {
-		var counter uint64
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			counter++
-			w.Header().Set("X-Request-ID", fmt.Sprintf("%d-%d", time.Now().UnixNano(), counter))
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, withRequestID(w, r))
 		})
 	}
 }
 
+// withRequestID returns r with a request ID in its context and w's
+// X-Request-ID header, keeping one an earlier RequestID or Logger set
+func withRequestID(w http.ResponseWriter, r *http.Request) *http.Request {
+	if GetRequestID(r.Context()) != "" {
+		return r
+	}
+	id := r.Header.Get(api.RequestIDHeader)
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+	w.Header().Set(api.RequestIDHeader, id)
+	return r.WithContext(context.WithValue(r.Context(), requestIDKey, id))
+}
+
+// GetRequestID returns the request ID set by RequestID, or ""
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// newRequestID returns 12 random hex digits, short enough to read out to
+// support
+func newRequestID() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID reports whether an incoming ID is safe to echo and log
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
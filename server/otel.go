@@ -0,0 +1,136 @@
+//go:build !(js && wasm)
+
+// Generated API packages import server for streams and auth, so this file
+// stays out of WASM builds to keep OpenTelemetry out of client binaries.
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelScope is the instrumentation scope of Otel's tracer
+const otelScope = "github.com/dougbarrett/gux/server"
+
+// OtelOptions configures Otel
+type OtelOptions struct {
+	// TracerProvider creates the tracer (default otel.GetTracerProvider(),
+	// which drops spans until the app sets up an SDK and exporter)
+	TracerProvider trace.TracerProvider
+
+	// Propagator reads the caller's trace context from the request headers
+	// (default otel.GetTextMapPropagator())
+	Propagator propagation.TextMapPropagator
+
+	// Metrics records Prometheus request metrics, if set
+	Metrics *Metrics
+
+	// SkipPaths are paths that are neither traced nor measured, such as
+	// "/health" and "/metrics"
+	SkipPaths []string
+}
+
+// Otel traces each request with an OpenTelemetry server span named by its
+// method and route template, such as "GET /api/posts/{id}", continuing
+// the caller's trace from traceparent headers. Spans of 5xx responses are
+// marked as errors. With Metrics set, it also records request metrics.
+//
+// Routes come from the ServeMux pattern or generated handlers, so IDs in
+// paths don't name separate spans. Requests no route matched are labeled
+// "unmatched".
+func Otel(opts ...OtelOptions) Middleware {
+	var o OtelOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.TracerProvider == nil {
+		o.TracerProvider = otel.GetTracerProvider()
+	}
+	if o.Propagator == nil {
+		o.Propagator = otel.GetTextMapPropagator()
+	}
+	tracer := o.TracerProvider.Tracer(otelScope)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(o.SkipPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := o.Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(requestAttributes(r)...))
+			ctx, route := withRouteRecorder(ctx)
+			var record func(method, route string, status int, elapsed time.Duration)
+			if o.Metrics != nil {
+				record = o.Metrics.begin()
+			}
+
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			req := r.WithContext(ctx)
+			defer func() {
+				if v := recover(); v != nil {
+					rec.status = http.StatusInternalServerError
+					span.SetStatus(codes.Error, fmt.Sprint(v))
+					defer panic(v)
+				}
+				name := *route
+				if name == "" && req.Pattern != "" {
+					name = patternRoute(req.Pattern)
+				}
+				if name != "" {
+					span.SetName(r.Method + " " + name)
+					span.SetAttributes(attribute.String("http.route", name))
+				} else {
+					name = "unmatched"
+				}
+				span.SetAttributes(attribute.Int("http.response.status_code", rec.status))
+				if rec.status >= 500 {
+					span.SetStatus(codes.Error, http.StatusText(rec.status))
+				}
+				span.End()
+				if record != nil {
+					record(r.Method, name, rec.status, time.Since(start))
+				}
+			}()
+			next.ServeHTTP(rec, req)
+		})
+	}
+}
+
+// requestAttributes are the span attributes known before the handler runs,
+// named by the OpenTelemetry HTTP semantic conventions
+func requestAttributes(r *http.Request) []attribute.KeyValue {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("url.scheme", scheme),
+		attribute.String("server.address", r.Host),
+		attribute.String("network.protocol.version", fmt.Sprintf("%d.%d", r.ProtoMajor, r.ProtoMinor)),
+	}
+	if ua := r.UserAgent(); ua != "" {
+		attrs = append(attrs, attribute.String("user_agent.original", ua))
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		attrs = append(attrs, attribute.String("client.address", host))
+	}
+	if r.Pattern != "" {
+		attrs = append(attrs, attribute.String("http.route", patternRoute(r.Pattern)))
+	}
+	return attrs
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// PreferencesStore persists per-user preference documents
+type PreferencesStore interface {
+	Get(userID string) (map[string]json.RawMessage, error)
+	Put(userID string, values map[string]json.RawMessage) error
+}
+
+// MemoryPreferencesStore is an in-memory PreferencesStore for development and tests
+type MemoryPreferencesStore struct {
+	mu    sync.RWMutex
+	users map[string]map[string]json.RawMessage
+}
+
+// NewMemoryPreferencesStore creates an empty in-memory store
+func NewMemoryPreferencesStore() *MemoryPreferencesStore {
+	return &MemoryPreferencesStore{users: make(map[string]map[string]json.RawMessage)}
+}
+
+// Get returns the stored preferences for a user (empty if none)
+func (s *MemoryPreferencesStore) Get(userID string) (map[string]json.RawMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make(map[string]json.RawMessage, len(s.users[userID]))
+	for k, v := range s.users[userID] {
+		values[k] = v
+	}
+	return values, nil
+}
+
+// Put replaces the stored preferences for a user
+func (s *MemoryPreferencesStore) Put(userID string, values map[string]json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[userID] = values
+	return nil
+}
+
+// PreferencesOptions configures PreferencesHandler
+type PreferencesOptions struct {
+	// UserID extracts the user from the request. Defaults to GetUserID on the
+	// JWT claims, so the handler is typically mounted behind the JWT middleware.
+	UserID func(r *http.Request) string
+
+	// MaxBytes limits the request body size (default 64KB)
+	MaxBytes int64
+}
+
+// PreferencesHandler serves the endpoint used by the client prefs.HTTPSync:
+// GET returns the user's preference object and PUT replaces it.
+func PreferencesHandler(store PreferencesStore, opts PreferencesOptions) http.Handler {
+	if opts.UserID == nil {
+		opts.UserID = func(r *http.Request) string {
+			return GetUserID(r.Context())
+		}
+	}
+	if opts.MaxBytes == 0 {
+		opts.MaxBytes = 64 << 10
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := opts.UserID(r)
+		if userID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			values, err := store.Get(userID)
+			if err != nil {
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "no-store")
+			json.NewEncoder(w).Encode(values)
+
+		case http.MethodPut:
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBytes))
+			if err != nil {
+				http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			values := make(map[string]json.RawMessage)
+			if err := json.Unmarshal(body, &values); err != nil {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+			if err := store.Put(userID, values); err != nil {
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,365 @@
+package server
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dougbarrett/gux/api"
+)
+
+// RateLimitRule is a token bucket that refills Limit tokens per Window and
+// holds at most Burst, so clients can burst briefly and then settle to the
+// average rate.
+type RateLimitRule struct {
+	// Limit is the number of requests allowed per Window.
+	// A rule with Limit 0 disables rate limiting.
+	Limit int
+
+	// Window is the refill period (default 1 minute)
+	Window time.Duration
+
+	// Burst is the bucket size (default Limit)
+	Burst int
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int           // bucket size
+	Remaining  int           // whole tokens left
+	Reset      time.Time     // when the bucket is full again
+	RetryAfter time.Duration // wait before the next token, when not allowed
+}
+
+// RateLimitStore holds token buckets. Implement it over Redis or another
+// shared store so limits apply across server instances; the refill and
+// take must happen atomically, e.g. in a Lua script.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error)
+}
+
+// RateLimitOptions configures the RateLimit middleware
+type RateLimitOptions struct {
+	// Limit, Window, and Burst set the default rule (default 60 per minute)
+	Limit  int
+	Window time.Duration
+	Burst  int
+
+	// Routes overrides the rule per route. Keys are ServeMux patterns such as
+	// "POST /api/login" or "DELETE /api/posts/{id}", matched against the
+	// request's method and path the way ServeMux matches them, so the
+	// middleware can wrap the whole mux. The most specific pattern wins. A
+	// trailing * matches any path with that prefix, after the patterns.
+	// Each route gets its own buckets.
+	Routes map[string]RateLimitRule
+
+	// Key identifies the client. Default: RateLimitByIP
+	Key func(r *http.Request) string
+
+	// Store holds the buckets. Default: a new MemoryRateLimitStore
+	Store RateLimitStore
+
+	// SkipPaths are paths that are never limited.
+	// Supports exact matches and prefix matches with trailing *
+	SkipPaths []string
+
+	// ErrorHandler writes the response when a request is limited.
+	// Default: 429 with a JSON error body
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// RateLimit returns middleware that limits requests per client with a token
+// bucket and sets X-RateLimit-Limit, X-RateLimit-Remaining, and
+// X-RateLimit-Reset (Unix seconds) on every limited route. Store errors are
+// logged and the request is let through.
+func RateLimit(opts RateLimitOptions) Middleware {
+	if opts.Limit == 0 {
+		opts.Limit = 60
+	}
+	if opts.Key == nil {
+		opts.Key = RateLimitByIP
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryRateLimitStore()
+	}
+	if opts.ErrorHandler == nil {
+		opts.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			api.WriteError(w, err)
+		}
+	}
+	defaultRule := normalizeRule(RateLimitRule{Limit: opts.Limit, Window: opts.Window, Burst: opts.Burst})
+	routes := make([]rateLimitRoute, 0, len(opts.Routes))
+	for pattern, rule := range opts.Routes {
+		routes = append(routes, newRateLimitRoute(pattern, normalizeRule(rule)))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if shouldSkipPath(r.URL.Path, opts.SkipPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route, rule := matchRateLimitRoute(r, routes)
+			if route == "" {
+				rule = defaultRule
+			}
+			if rule.Limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := opts.Store.Take(r.Context(), route+"|"+opts.Key(r), rule)
+			if err != nil {
+				log.Printf("rate limit: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("X-RateLimit-Reset", strconv.FormatInt(res.Reset.Unix(), 10))
+
+			if !res.Allowed {
+				retry := int(math.Ceil(res.RetryAfter.Seconds()))
+				if retry < 1 {
+					retry = 1
+				}
+				h.Set("Retry-After", strconv.Itoa(retry))
+				opts.ErrorHandler(w, r, api.TooManyRequests("rate limit exceeded"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimitByIP keys requests by the connection's remote IP
+func RateLimitByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// RateLimitByForwardedIP keys requests by the client IP reported by a reverse
+// proxy in X-Forwarded-For or X-Real-IP. Only use it behind a proxy that
+// overwrites these headers, since clients can set them.
+func RateLimitByForwardedIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		ip, _, _ := strings.Cut(fwd, ",")
+		return "ip:" + strings.TrimSpace(ip)
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return "ip:" + ip
+	}
+	return RateLimitByIP(r)
+}
+
+// RateLimitBySubject keys requests by the JWT subject, falling back to the
+// remote IP for anonymous requests. Mount it behind the JWT middleware.
+func RateLimitBySubject(r *http.Request) string {
+	if userID := GetUserID(r.Context()); userID != "" {
+		return "sub:" + userID
+	}
+	return RateLimitByIP(r)
+}
+
+// rateLimitRoute is a compiled RateLimitOptions.Routes key
+type rateLimitRoute struct {
+	pattern  string
+	rule     RateLimitRule
+	method   string   // "" for any
+	segments []string // the path's segments; a trailing "" matches any rest, as in "/api/"
+	prefix   string   // for a trailing *, what the path or "METHOD path" starts with
+}
+
+func newRateLimitRoute(pattern string, rule RateLimitRule) rateLimitRoute {
+	route := rateLimitRoute{pattern: pattern, rule: rule}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		route.prefix = prefix
+		return route
+	}
+	path := pattern
+	if method, rest, ok := strings.Cut(pattern, " "); ok && !strings.Contains(method, "/") {
+		route.method, path = method, strings.TrimSpace(rest)
+	}
+	route.segments = strings.Split(strings.TrimPrefix(path, "/"), "/")
+	return route
+}
+
+// match reports whether the route's pattern matches method and path, and
+// how specific the match is: literal segments count most, then a method
+func (route rateLimitRoute) match(method, path string) (int, bool) {
+	if route.method != "" && route.method != method && !(route.method == http.MethodGet && method == http.MethodHead) {
+		return 0, false
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	score := 0
+	for i, seg := range route.segments {
+		last := i == len(route.segments)-1
+		switch {
+		case seg == "{$}":
+			if i != len(parts)-1 || parts[i] != "" {
+				return 0, false
+			}
+			score += 2
+			continue
+		case last && seg == "":
+			// "/api/" matches the rest of the path, like ServeMux
+			if i >= len(parts) {
+				return 0, false
+			}
+			return score + 1, true
+		case last && strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "...}"):
+			// "/files/{rest...}" matches the rest of the path, even an empty
+			// one as in "/files/", like ServeMux; "/files" has no rest
+			return score, i < len(parts)
+		}
+		if i >= len(parts) {
+			return 0, false
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if parts[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if seg != parts[i] {
+			return 0, false
+		}
+		score += 2
+	}
+	if len(parts) != len(route.segments) {
+		return 0, false
+	}
+	if route.method != "" {
+		score++
+	}
+	return score, true
+}
+
+// matchRateLimitRoute finds the override for a request: the pattern that
+// served it, when the middleware runs inside a ServeMux, then the most
+// specific matching pattern, then the longest matching prefix
+func matchRateLimitRoute(r *http.Request, routes []rateLimitRoute) (string, RateLimitRule) {
+	if len(routes) == 0 {
+		return "", RateLimitRule{}
+	}
+	if r.Pattern != "" {
+		for _, route := range routes {
+			if route.pattern == r.Pattern {
+				return route.pattern, route.rule
+			}
+		}
+	}
+
+	var best *rateLimitRoute
+	bestScore := -1
+	for i, route := range routes {
+		if route.segments == nil {
+			continue
+		}
+		score, ok := route.match(r.Method, r.URL.Path)
+		if ok && (score > bestScore || score == bestScore && route.pattern < best.pattern) {
+			best, bestScore = &routes[i], score
+		}
+	}
+	if best != nil {
+		return best.pattern, best.rule
+	}
+
+	for i, route := range routes {
+		if route.segments != nil || (best != nil && len(route.prefix) <= len(best.prefix)) {
+			continue
+		}
+		if strings.HasPrefix(r.URL.Path, route.prefix) || strings.HasPrefix(r.Method+" "+r.URL.Path, route.prefix) {
+			best = &routes[i]
+		}
+	}
+	if best == nil {
+		return "", RateLimitRule{}
+	}
+	return best.pattern, best.rule
+}
+
+// normalizeRule fills in the default window and burst
+func normalizeRule(rule RateLimitRule) RateLimitRule {
+	if rule.Window <= 0 {
+		rule.Window = time.Minute
+	}
+	if rule.Burst <= 0 {
+		rule.Burst = rule.Limit
+	}
+	return rule
+}
+
+// MemoryRateLimitStore keeps buckets in process memory. Limits are per server
+// instance; use a shared store when running more than one.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket), lastSweep: time.Now()}
+}
+
+// Take refills the bucket for key and takes a token if one is available
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	rule = normalizeRule(rule)
+	now := time.Now()
+	rate := float64(rule.Limit) / rule.Window.Seconds()
+	burst := float64(rule.Burst)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop idle buckets now and then; a full bucket is the same as none
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, b := range s.buckets {
+			if now.After(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	res := RateLimitResult{Limit: rule.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.full = now.Add(time.Duration((burst - b.tokens) / rate * float64(time.Second)))
+	res.Remaining = int(b.tokens)
+	res.Reset = b.full
+	return res, nil
+}
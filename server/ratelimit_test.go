@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	handler := RateLimit(RateLimitOptions{Limit: 2, Window: time.Hour})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i, want := range []int{200, 200, 429} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/posts", nil))
+		if rec.Code != want {
+			t.Fatalf("request %d: %d, want %d", i, rec.Code, want)
+		}
+		if i == 2 && rec.Header().Get("Retry-After") == "" {
+			t.Fatal("429 without Retry-After")
+		}
+	}
+
+	// Another client has its own bucket
+	req := httptest.NewRequest("GET", "/api/posts", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != 200 || rec.Header().Get("X-RateLimit-Remaining") != "1" {
+		t.Fatalf("second client: %d, remaining %q", rec.Code, rec.Header().Get("X-RateLimit-Remaining"))
+	}
+}
+
+func TestRateLimitRoutesAroundMux(t *testing.T) {
+	mux := http.NewServeMux()
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	mux.HandleFunc("POST /api/auth/login", ok)
+	mux.HandleFunc("DELETE /api/posts/{id}", ok)
+	mux.HandleFunc("/api/", ok)
+
+	// Wrapping the mux: r.Pattern isn't set yet when the limiter runs
+	handler := RateLimit(RateLimitOptions{
+		Limit: 100,
+		Routes: map[string]RateLimitRule{
+			"POST /api/auth/login":   {Limit: 1},
+			"DELETE /api/posts/{id}": {Limit: 2},
+			"/api/public/*":          {Limit: 0},
+		},
+	})(mux)
+
+	limit := func(method, path string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Header().Get("X-RateLimit-Limit")
+	}
+	tests := []struct{ method, path, want string }{
+		{"POST", "/api/auth/login", "1"},
+		{"GET", "/api/auth/login", "100"},
+		{"DELETE", "/api/posts/7", "2"},
+		{"DELETE", "/api/posts/7/comments", "100"},
+		{"GET", "/api/public/feed", ""},
+		{"GET", "/api/posts", "100"},
+	}
+	for _, tt := range tests {
+		if got := limit(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s: limit %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchRateLimitRouteSpecificity(t *testing.T) {
+	var routes []rateLimitRoute
+	for _, p := range []string{"/api/", "/api/posts/{id}", "GET /api/posts/{id}", "/api/posts/new", "/files/{path...}", "/api/{$}"} {
+		routes = append(routes, newRateLimitRoute(p, RateLimitRule{Limit: 1}))
+	}
+	tests := []struct{ method, path, want string }{
+		{"GET", "/api/posts/new", "/api/posts/new"},
+		{"GET", "/api/posts/3", "GET /api/posts/{id}"},
+		{"HEAD", "/api/posts/3", "GET /api/posts/{id}"},
+		{"PUT", "/api/posts/3", "/api/posts/{id}"},
+		{"GET", "/api/", "/api/{$}"},
+		{"GET", "/api/users", "/api/"},
+		{"GET", "/files/a/b.txt", "/files/{path...}"},
+		{"GET", "/files/", "/files/{path...}"},
+		{"GET", "/files", ""},
+		{"GET", "/other", ""},
+	}
+	for _, tt := range tests {
+		got, _ := matchRateLimitRoute(httptest.NewRequest(tt.method, tt.path, nil), routes)
+		if got != tt.want {
+			t.Errorf("%s %s matched %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
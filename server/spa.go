@@ -1,15 +1,20 @@
 package server
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"html"
 	"io"
 	"io/fs"
 	"net/http"
 	"os"
 	"path"
-	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 // SPAHandler serves static files and falls back to index.html for client-side routing.
@@ -22,6 +27,14 @@ import (
 // When a WASM hash is configured, the handler automatically:
 //   - Injects the hash into index.html (replacing main.wasm with main.<hash>.wasm)
 //   - Routes requests for main.<hash>.wasm back to the embedded main.wasm
+//
+// In both modes responses carry an ETag, so unchanged files revalidate
+// with a 304. Files with content-hashed names, such as main.3f2a9c1e.wasm
+// or those listed in asset-manifest.json by gux build, are cached for
+// good, and index.html is always revalidated so it names the current
+// ones. When a file has .br or .gz variants, the one the client accepts
+// is served in its place. Behind the CSP middleware, index.html's inline
+// scripts and styles carry the request's nonce.
 type SPAHandler struct {
 	// fs is the filesystem to serve from (can be os.DirFS or embed.FS)
 	fs fs.FS
@@ -32,15 +45,25 @@ type SPAHandler struct {
 	// cachedIndex is the pre-processed index.html with hash injected
 	cachedIndex []byte
 
-	// legacyDir is for backwards compatibility with NewSPAHandler(dir string)
-	legacyDir string
+	// indexETag is the ETag of cachedIndex
+	indexETag string
+
+	// immutable holds the content-hashed file names from asset-manifest.json
+	immutable map[string]bool
+
+	// etags caches the content hashes of embedded files, which never change
+	etags sync.Map
+
+	// basePath is the prefix the app is served under, such as "/app"
+	basePath string
 }
 
 // NewSPAHandler creates a new SPA handler for the given directory.
-// This is the legacy constructor for filesystem-based serving (development mode).
+// Files are read on each request, so edits show up without a restart
+// (development mode).
 func NewSPAHandler(dir string) *SPAHandler {
 	return &SPAHandler{
-		legacyDir: dir,
+		fs: os.DirFS(dir),
 	}
 }
 
@@ -82,133 +105,311 @@ func NewEmbeddedSPAHandler(fsys fs.FS, subdir string) *SPAHandler {
 		}
 	}
 
+	// Hashed names change with their content, so they can be cached for good
+	if data, err := fs.ReadFile(rootFS, "asset-manifest.json"); err == nil {
+		var manifest map[string]string
+		if json.Unmarshal(data, &manifest) == nil {
+			h.immutable = make(map[string]bool, len(manifest))
+			for _, hashed := range manifest {
+				h.immutable[hashed] = true
+			}
+		}
+	}
+
 	// Pre-process index.html with hash if we have one
 	if h.wasmHash != "" {
-		if indexData, err := fs.ReadFile(rootFS, "index.html"); err == nil {
-			// Replace main.wasm with main.<hash>.wasm
-			content := string(indexData)
-			content = strings.ReplaceAll(content, `"main.wasm"`, fmt.Sprintf(`"main.%s.wasm"`, h.wasmHash))
-			content = strings.ReplaceAll(content, `"/main.wasm"`, fmt.Sprintf(`"/main.%s.wasm"`, h.wasmHash))
-			h.cachedIndex = []byte(content)
-		}
+		h.cacheIndex()
 	}
 
 	return h
 }
 
-func (h *SPAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Use legacy filesystem mode if configured
-	if h.legacyDir != "" {
-		h.serveLegacy(w, r)
-		return
+// SetBasePath serves the app under base, such as "/app" behind a reverse
+// proxy that forwards https://example.com/app/ to it, with or without the
+// prefix. In index.html, root-relative URLs such as "/main.wasm" get the
+// prefix, and a meta tag gives it to components.NewRouter, so routes and
+// links follow.
+func (h *SPAHandler) SetBasePath(base string) {
+	base = strings.TrimSuffix(base, "/")
+	if base != "" && !strings.HasPrefix(base, "/") {
+		base = "/" + base
 	}
-
-	// Embedded filesystem mode
-	h.serveEmbedded(w, r)
-}
-
-// serveLegacy handles requests using the legacy filesystem-based approach
-func (h *SPAHandler) serveLegacy(w http.ResponseWriter, r *http.Request) {
-	urlPath := filepath.Clean(r.URL.Path)
-	if urlPath == "/" {
-		urlPath = "/index.html"
+	h.basePath = base
+	if h.cachedIndex != nil {
+		h.cacheIndex()
 	}
+}
 
-	fullPath := filepath.Join(h.legacyDir, urlPath)
-
-	info, err := os.Stat(fullPath)
-	if err != nil || info.IsDir() {
-		// File doesn't exist or is a directory - serve index.html for SPA routing
-		h.serveFileLegacy(w, r, filepath.Join(h.legacyDir, "index.html"))
+// cacheIndex reads and rewrites index.html once, for embedded files
+func (h *SPAHandler) cacheIndex() {
+	data, err := fs.ReadFile(h.fs, "index.html")
+	if err != nil {
 		return
 	}
-
-	h.serveFileLegacy(w, r, fullPath)
+	h.cachedIndex = h.rewriteIndex(data)
+	sum := sha256.Sum256(h.cachedIndex)
+	h.indexETag = fmt.Sprintf(`"%x"`, sum[:8])
 }
 
-func (h *SPAHandler) serveFileLegacy(w http.ResponseWriter, r *http.Request, filePath string) {
-	setContentType(w, filePath)
-	http.ServeFile(w, r, filePath)
+// rootURL matches a root-relative URL in a src or href attribute, such as
+// src="/wasm_exec.js", and not the paths in scripts or text
+var rootURL = regexp.MustCompile(`(?i)(\s(?:src|href)\s*=\s*["'])/([^/"'])`)
+
+// headTag matches the head element's start tag
+var headTag = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+
+// rewriteIndex names the hashed main.wasm in index.html and puts URLs
+// under the base path
+func (h *SPAHandler) rewriteIndex(data []byte) []byte {
+	content := string(data)
+	if h.wasmHash != "" {
+		// Replace main.wasm with main.<hash>.wasm
+		content = strings.ReplaceAll(content, `"main.wasm"`, fmt.Sprintf(`"main.%s.wasm"`, h.wasmHash))
+		content = strings.ReplaceAll(content, `"/main.wasm"`, fmt.Sprintf(`"/main.%s.wasm"`, h.wasmHash))
+	}
+	if h.basePath != "" {
+		content = rootURL.ReplaceAllString(content, "${1}"+h.basePath+"/$2")
+		meta := `<meta name="gux-base-path" content="` + html.EscapeString(h.basePath) + `">`
+		if loc := headTag.FindStringIndex(content); loc != nil {
+			content = content[:loc[1]] + "\n    " + meta + content[loc[1]:]
+		}
+	}
+	return []byte(content)
 }
 
-// serveEmbedded handles requests using the embedded filesystem
-func (h *SPAHandler) serveEmbedded(w http.ResponseWriter, r *http.Request) {
+func (h *SPAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	urlPath := path.Clean(r.URL.Path)
+	if h.basePath != "" {
+		// The proxy may or may not have removed the prefix
+		if urlPath == h.basePath {
+			urlPath = "/"
+		} else if rest, ok := strings.CutPrefix(urlPath, h.basePath+"/"); ok {
+			urlPath = "/" + rest
+		}
+	}
 	if urlPath == "/" || urlPath == "" {
 		urlPath = "index.html"
 	} else {
 		urlPath = strings.TrimPrefix(urlPath, "/")
 	}
 
-	// Handle index.html with hash injection
-	if urlPath == "index.html" && h.cachedIndex != nil {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		w.Header().Set("Cache-Control", "no-cache")
-		w.Write(h.cachedIndex)
+	if urlPath == "index.html" {
+		h.serveIndex(w, r)
 		return
 	}
 
+	immutable := h.immutable[urlPath] || hashedName(urlPath)
+
 	// Handle hashed WASM request (main.<hash>.wasm -> main.wasm)
 	if h.wasmHash != "" && urlPath == fmt.Sprintf("main.%s.wasm", h.wasmHash) {
 		urlPath = "main.wasm"
+	}
+
+	// Anything that isn't a file is a client-side route
+	stat, err := fs.Stat(h.fs, urlPath)
+	if err != nil || stat.IsDir() {
+		h.serveIndex(w, r)
+		return
+	}
+
+	if immutable {
 		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
 	}
+	h.serveFile(w, r, urlPath)
+}
 
-	// Try to open the file
-	file, err := h.fs.Open(urlPath)
-	if err != nil {
-		// File not found - serve index.html for SPA routing
-		if h.cachedIndex != nil {
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Header().Set("Cache-Control", "no-cache")
-			w.Write(h.cachedIndex)
-			return
-		}
-		// Fallback to raw index.html
-		if indexData, err := fs.ReadFile(h.fs, "index.html"); err == nil {
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Write(indexData)
+// serveIndex serves index.html, which must be revalidated on every load
+// because it names the current assets
+func (h *SPAHandler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if nonce := Nonce(r.Context()); nonce != "" {
+		h.serveIndexWithNonce(w, r, nonce)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	if h.cachedIndex != nil {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("ETag", h.indexETag)
+		http.ServeContent(w, r, "index.html", time.Time{}, bytes.NewReader(h.cachedIndex))
+		return
+	}
+	if h.basePath != "" {
+		data, err := h.indexHTML()
+		if err != nil {
+			http.NotFound(w, r)
 			return
 		}
+		sum := sha256.Sum256(data)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sum[:8]))
+		http.ServeContent(w, r, "index.html", time.Time{}, bytes.NewReader(data))
+		return
+	}
+	h.serveFile(w, r, "index.html")
+}
+
+// indexHTML returns index.html as served, before adding a nonce
+func (h *SPAHandler) indexHTML() ([]byte, error) {
+	if h.cachedIndex != nil {
+		return h.cachedIndex, nil
+	}
+	data, err := fs.ReadFile(h.fs, "index.html")
+	if err != nil {
+		return nil, err
+	}
+	return h.rewriteIndex(data), nil
+}
+
+// serveIndexWithNonce serves index.html with the CSP middleware's nonce
+// on its inline scripts and styles. The nonce is new on every request, so
+// the page mustn't be stored.
+func (h *SPAHandler) serveIndexWithNonce(w http.ResponseWriter, r *http.Request, nonce string) {
+	data, err := h.indexHTML()
+	if err != nil {
 		http.NotFound(w, r)
 		return
 	}
-	defer file.Close()
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	http.ServeContent(w, r, "index.html", time.Time{}, bytes.NewReader(addNonce(data, nonce)))
+}
 
-	// Get file info for ServeContent
-	stat, err := file.Stat()
+// serveFile serves name from the filesystem, or its precompressed variant
+func (h *SPAHandler) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	file, err := h.fs.Open(name)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		http.NotFound(w, r)
 		return
 	}
+	defer file.Close()
 
-	// If it's a directory, serve index.html
-	if stat.IsDir() {
-		if h.cachedIndex != nil {
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Header().Set("Cache-Control", "no-cache")
-			w.Write(h.cachedIndex)
-			return
-		}
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
 		http.NotFound(w, r)
 		return
 	}
 
 	// Set content type
-	setContentType(w, urlPath)
+	setContentType(w, name)
 
-	// Serve the file
-	if seeker, ok := file.(io.ReadSeeker); ok {
-		http.ServeContent(w, r, urlPath, stat.ModTime(), seeker)
-	} else {
+	// Serve a precompressed variant instead when there is one
+	served := name
+	if encoded, encoding, ext := h.openPrecompressed(name, r); encoded != nil {
+		defer encoded.Close()
+		if encodedStat, err := encoded.Stat(); err == nil {
+			w.Header().Set("Content-Encoding", encoding)
+			file, stat = encoded, encodedStat
+			served = name + ext
+		}
+	}
+	if h.hasPrecompressed(name) {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	seeker, ok := file.(io.ReadSeeker)
+	if !ok {
 		// Fallback for non-seekable files
 		data, err := io.ReadAll(file)
 		if err != nil {
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
-		w.Write(data)
+		seeker = bytes.NewReader(data)
+	}
+	if etag := h.etag(served, stat, seeker); etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+
+	// ServeContent answers If-None-Match and If-Modified-Since with a 304
+	http.ServeContent(w, r, name, stat.ModTime(), seeker)
+}
+
+// etag returns a strong ETag from the content of an embedded file, which
+// has no modification time, or a weak one from the modification time and
+// size of a file on disk. The content hash is computed once per file.
+func (h *SPAHandler) etag(name string, stat fs.FileInfo, content io.ReadSeeker) string {
+	if !stat.ModTime().IsZero() {
+		return fmt.Sprintf(`W/"%x-%x"`, stat.ModTime().UnixNano(), stat.Size())
+	}
+	if etag, ok := h.etags.Load(name); ok {
+		return etag.(string)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return ""
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return ""
+	}
+	etag := fmt.Sprintf(`"%x"`, hash.Sum(nil)[:8])
+	h.etags.Store(name, etag)
+	return etag
+}
+
+// hashedName reports whether name looks like name.<hash>.ext, with a hash
+// of 8 or more hex digits, as gux build and most bundlers write them
+func hashedName(name string) bool {
+	base := path.Base(name)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	hash := strings.TrimPrefix(path.Ext(stem), ".")
+	if len(hash) < 8 || stem == "."+hash {
+		return false
+	}
+	for _, c := range hash {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// precompressed lists the encodings of the variants gux build writes, in
+// order of preference
+var precompressed = []struct{ encoding, ext string }{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// openPrecompressed opens the best variant of name that the client accepts,
+// returning its encoding and extension, or returns nil
+func (h *SPAHandler) openPrecompressed(name string, r *http.Request) (fs.File, string, string) {
+	accept := r.Header.Get("Accept-Encoding")
+	if accept == "" {
+		return nil, "", ""
+	}
+	for _, p := range precompressed {
+		if !acceptsEncoding(accept, p.encoding) {
+			continue
+		}
+		if f, err := h.fs.Open(name + p.ext); err == nil {
+			return f, p.encoding, p.ext
+		}
+	}
+	return nil, "", ""
+}
+
+// hasPrecompressed reports whether name has any precompressed variant, so
+// caches must key its responses on Accept-Encoding
+func (h *SPAHandler) hasPrecompressed(name string) bool {
+	for _, p := range precompressed {
+		if _, err := fs.Stat(h.fs, name+p.ext); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows encoding
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+		// q=0 means "not acceptable"
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
 	}
+	return false
 }
 
 // setContentType sets the Content-Type header based on file extension
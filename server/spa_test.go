@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSPABasePath(t *testing.T) {
+	index := `<html><head>
+<link rel="stylesheet" href="/app.css">
+<script src="/wasm_exec.js"></script>
+<script>fetch("/api/items"); const home = '/';</script>
+</head><body><a HREF='/about'>About</a> <a href="//cdn.example.com/x.js">CDN</a> <p>See "/docs"</p></body></html>`
+	h := NewEmbeddedSPAHandler(fstest.MapFS{"static/index.html": {Data: []byte(index)}}, "static")
+	h.SetBasePath("/app")
+
+	for _, target := range []string{"/", "/app/", "/app/users/1"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		body := rec.Body.String()
+		for _, want := range []string{
+			`href="/app/app.css"`,
+			`src="/app/wasm_exec.js"`,
+			`HREF='/app/about'`,
+			`<meta name="gux-base-path" content="/app">`,
+			`fetch("/api/items")`,
+			`const home = '/'`,
+			`href="//cdn.example.com/x.js"`,
+			`See "/docs"`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("GET %s: index lacks %q:\n%s", target, want, body)
+			}
+		}
+	}
+}
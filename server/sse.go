@@ -0,0 +1,417 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSEEvent is one Server-Sent Event
+type SSEEvent struct {
+	// ID is sent as the event id; browsers send the last one back in the
+	// Last-Event-ID header when they reconnect
+	ID string
+
+	// Event names the event. Empty events arrive as "message".
+	Event string
+
+	// Data is the payload. Strings and byte slices are sent as-is, other
+	// values as JSON. Clients ignore events with nil Data, which can still
+	// set the ID or Retry.
+	Data any
+
+	// Retry tells the client how long to wait before reconnecting
+	Retry time.Duration
+}
+
+// SSEWriter writes Server-Sent Events to a response. It is safe for
+// concurrent use.
+type SSEWriter struct {
+	mu sync.Mutex
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewSSEWriter sends the event stream headers and returns a writer for the
+// events. It fails if the response cannot be flushed, after the headers have
+// been written.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // stop nginx from buffering the stream
+
+	s := &SSEWriter{w: w, rc: http.NewResponseController(w)}
+	w.WriteHeader(http.StatusOK)
+	if err := s.rc.Flush(); err != nil {
+		return nil, fmt.Errorf("sse: %w", err)
+	}
+	return s, nil
+}
+
+// Send writes an event and flushes it to the client
+func (s *SSEWriter) Send(e SSEEvent) error {
+	var data string
+	switch v := e.Data.(type) {
+	case nil:
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("sse: marshal data: %w", err)
+		}
+		data = string(b)
+	}
+
+	var b strings.Builder
+	if e.ID != "" {
+		b.WriteString("id: " + sseLine(e.ID) + "\n")
+	}
+	if e.Event != "" {
+		b.WriteString("event: " + sseLine(e.Event) + "\n")
+	}
+	if e.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+	// Each line of the payload needs its own data field
+	if e.Data != nil {
+		for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+			b.WriteString("data: " + line + "\n")
+		}
+	}
+	b.WriteString("\n")
+	return s.write(b.String())
+}
+
+// Ping writes a comment line, which clients ignore, to keep proxies from
+// closing an idle connection
+func (s *SSEWriter) Ping() error {
+	return s.write(": ping\n\n")
+}
+
+func (s *SSEWriter) write(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write([]byte(text)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// sseLine drops line breaks, which would end an id or event field early
+func sseLine(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// SSEEndEvent is the event StreamSSE sends when its channel is closed.
+// Clients would otherwise reconnect; generated clients close the stream.
+const SSEEndEvent = "end"
+
+// StreamSSE sends each value from events as a JSON "message" event until the
+// channel is closed or the client disconnects, with a ping every 15 seconds.
+// Generated handlers use it for API methods that return a channel.
+func StreamSSE[T any](w http.ResponseWriter, r *http.Request, events <-chan T) {
+	sw, err := NewSSEWriter(w)
+	if err != nil {
+		log.Printf("sse: %v", err)
+		return
+	}
+
+	ping := time.NewTicker(15 * time.Second)
+	defer ping.Stop()
+	for {
+		select {
+		case v, ok := <-events:
+			if !ok {
+				sw.Send(SSEEvent{Event: SSEEndEvent, Data: ""})
+				return
+			}
+			if err := sw.Send(SSEEvent{Data: v}); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := sw.Ping(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// SSEOptions configures an SSEHandler
+type SSEOptions struct {
+	// KeepAlive is the interval between pings (default 15 seconds)
+	KeepAlive time.Duration
+
+	// Retry is the reconnection delay sent to clients when they connect.
+	// 0 leaves it to the client (about 3 seconds in browsers).
+	Retry time.Duration
+
+	// Buffer is the number of events queued per connection (default 32).
+	// A client that falls further behind is disconnected; it reconnects and
+	// catches up from History.
+	Buffer int
+
+	// History is the number of recent events kept for clients that
+	// reconnect with Last-Event-ID (default 100; negative disables)
+	History int
+
+	// Key groups connections for SendTo. Behind the JWT middleware, keying by
+	// GetUserID(r.Context()) lets SendTo reach one user. Default: none.
+	Key func(r *http.Request) string
+
+	// OnConnect is called when a client connects, before any events are
+	// sent. It can queue events with c.Send, e.g. a snapshot of the current
+	// state when c.LastEventID is empty or too old to replay.
+	OnConnect func(c *SSEConn)
+
+	// OnDisconnect is called after a client disconnects
+	OnDisconnect func(c *SSEConn)
+}
+
+// SSEHandler serves a Server-Sent Events stream and fans events out to every
+// connected client. Each connection has its own queue, idle connections get
+// keep-alive pings, and clients that reconnect with Last-Event-ID receive the
+// events they missed.
+//
+//	events := server.NewSSEHandler(server.SSEOptions{})
+//	mux.Handle("GET /api/events", events)
+//
+//	events.Broadcast(server.SSEEvent{Event: "post.created", Data: post})
+type SSEHandler struct {
+	opts SSEOptions
+
+	mu      sync.Mutex
+	conns   map[*SSEConn]struct{}
+	history []sseRecord
+	nextID  uint64
+	closed  bool
+}
+
+// sseRecord is an event in the replay history
+type sseRecord struct {
+	key   string // "" for broadcasts
+	event SSEEvent
+}
+
+// SSEConn is one connected client
+type SSEConn struct {
+	// Key is the connection's group from SSEOptions.Key
+	Key string
+
+	// LastEventID is the Last-Event-ID the client reconnected with, if any
+	LastEventID string
+
+	// Request is the request that opened the stream
+	Request *http.Request
+
+	events chan SSEEvent
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Send queues an event for this client. It reports false if the connection
+// is closed or the client is too far behind, in which case it is closed.
+func (c *SSEConn) Send(e SSEEvent) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.events <- e:
+		return true
+	default:
+		c.Close()
+		return false
+	}
+}
+
+// Close ends the stream
+func (c *SSEConn) Close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Done is closed when the stream ends
+func (c *SSEConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// NewSSEHandler creates an SSEHandler
+func NewSSEHandler(opts SSEOptions) *SSEHandler {
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = 15 * time.Second
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 32
+	}
+	if opts.History == 0 {
+		opts.History = 100
+	}
+	return &SSEHandler{opts: opts, conns: make(map[*SSEConn]struct{})}
+}
+
+// ServeHTTP streams events to the client until it disconnects
+func (h *SSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	closed := h.closed
+	h.mu.Unlock()
+	if closed {
+		http.Error(w, "event stream closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	sw, err := NewSSEWriter(w)
+	if err != nil {
+		log.Printf("sse: %v", err)
+		return
+	}
+
+	c := &SSEConn{
+		LastEventID: r.Header.Get("Last-Event-ID"),
+		Request:     r,
+		events:      make(chan SSEEvent, h.opts.Buffer),
+		done:        make(chan struct{}),
+	}
+	if h.opts.Key != nil {
+		c.Key = h.opts.Key(r)
+	}
+
+	// Register and take the missed events under one lock, so no event is
+	// both replayed and queued, or neither
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	missed := h.missed(c)
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+		c.Close()
+		if h.opts.OnDisconnect != nil {
+			h.opts.OnDisconnect(c)
+		}
+	}()
+
+	if h.opts.Retry > 0 {
+		if err := sw.write("retry: " + strconv.FormatInt(h.opts.Retry.Milliseconds(), 10) + "\n\n"); err != nil {
+			return
+		}
+	}
+	for _, e := range missed {
+		if err := sw.Send(e); err != nil {
+			return
+		}
+	}
+	if h.opts.OnConnect != nil {
+		h.opts.OnConnect(c)
+	}
+
+	ping := time.NewTicker(h.opts.KeepAlive)
+	defer ping.Stop()
+	for {
+		select {
+		case e := <-c.events:
+			if err := sw.Send(e); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := sw.Ping(); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// missed returns the events after c.LastEventID that are meant for c. An ID
+// that is not in the history replays nothing. h.mu must be held.
+func (h *SSEHandler) missed(c *SSEConn) []SSEEvent {
+	if c.LastEventID == "" {
+		return nil
+	}
+	for i := len(h.history) - 1; i >= 0; i-- {
+		if h.history[i].event.ID != c.LastEventID {
+			continue
+		}
+		var events []SSEEvent
+		for _, rec := range h.history[i+1:] {
+			if rec.key == "" || rec.key == c.Key {
+				events = append(events, rec.event)
+			}
+		}
+		return events
+	}
+	return nil
+}
+
+// Broadcast sends an event to every connected client. Events without an ID
+// get the next number in sequence, so clients can resume after them.
+func (h *SSEHandler) Broadcast(e SSEEvent) {
+	h.publish("", e)
+}
+
+// SendTo sends an event to the clients whose SSEOptions.Key is key
+func (h *SSEHandler) SendTo(key string, e SSEEvent) {
+	h.publish(key, e)
+}
+
+func (h *SSEHandler) publish(key string, e SSEEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	if e.ID == "" {
+		h.nextID++
+		e.ID = strconv.FormatUint(h.nextID, 10)
+	}
+	if h.opts.History > 0 {
+		h.history = append(h.history, sseRecord{key: key, event: e})
+		if len(h.history) > h.opts.History {
+			h.history = h.history[len(h.history)-h.opts.History:]
+		}
+	}
+	for c := range h.conns {
+		if key == "" || c.Key == key {
+			c.Send(e)
+		}
+	}
+}
+
+// Count returns the number of connected clients
+func (h *SSEHandler) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.conns)
+}
+
+// Close disconnects every client and refuses new connections, e.g. before
+// shutting down the server
+func (h *SSEHandler) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for c := range h.conns {
+		c.Close()
+	}
+}
@@ -0,0 +1,331 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dougbarrett/gux/api"
+)
+
+// Upload is a file received by UploadHandler
+type Upload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"` // base name of the file on the client
+	Type string `json:"type"` // MIME type reported by the browser
+	Size int64  `json:"size"`
+	Path string `json:"-"` // the assembled file, removed after OnComplete returns
+}
+
+// UploadOptions configures UploadHandler
+type UploadOptions struct {
+	// Dir holds partial uploads (default a "gux-uploads" directory in
+	// os.TempDir)
+	Dir string
+
+	// MaxSize is the largest file accepted, in bytes (0 = no limit)
+	MaxSize int64
+
+	// MaxChunk is the largest chunk accepted, in bytes (default 16 MB)
+	MaxChunk int64
+
+	// Expire removes partial uploads not added to for this long (default 24h)
+	Expire time.Duration
+
+	// Accept checks a new upload before any data is sent, e.g. its type
+	// or the user's quota. A returned *api.Error sets the response status.
+	Accept func(r *http.Request, upload Upload) error
+
+	// OnComplete is called when the last chunk arrives, with the file at
+	// upload.Path. Move or copy the file elsewhere to keep it; it's removed
+	// when OnComplete returns. The result is sent to the client as JSON,
+	// e.g. the stored file's URL.
+	OnComplete func(r *http.Request, upload Upload) (any, error)
+}
+
+// uploadState is the JSON response describing an upload
+type uploadState struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+	Done   bool   `json:"done,omitempty"`
+	Result any    `json:"result,omitempty"`
+}
+
+// UploadHandler receives files in chunks from components.FileUpload's
+// UploadTo, so large files survive flaky connections and can be paused and
+// resumed, even after a reload:
+//
+//	mux.Handle("/api/uploads", server.UploadHandler(server.UploadOptions{
+//		MaxSize: 2 << 30,
+//		OnComplete: func(r *http.Request, u server.Upload) (any, error) {
+//			url, err := store.Save(r.Context(), u.Name, u.Path)
+//			return map[string]string{"url": url}, err
+//		},
+//	}))
+//
+// The protocol:
+//
+//	POST   {"name", "type", "size"}          starts an upload: {"id", "offset": 0}
+//	GET    ?id=ID                            returns {"id", "offset"}
+//	PATCH  ?id=ID, Upload-Offset header      appends the body at offset: {"id", "offset", "done", "result"}
+//	DELETE ?id=ID                            cancels the upload
+//
+// A PATCH whose Upload-Offset isn't the bytes received so far gets a 409;
+// the client then asks for the offset and continues from there.
+func UploadHandler(opts UploadOptions) http.Handler {
+	if opts.Dir == "" {
+		opts.Dir = filepath.Join(os.TempDir(), "gux-uploads")
+	}
+	if opts.MaxChunk <= 0 {
+		opts.MaxChunk = 16 << 20
+	}
+	if opts.Expire <= 0 {
+		opts.Expire = 24 * time.Hour
+	}
+	return &uploadHandler{opts: opts}
+}
+
+type uploadHandler struct {
+	opts  UploadOptions
+	locks sync.Map // upload ID -> *sync.Mutex, so chunks of one upload are appended in turn
+
+	mu    sync.Mutex
+	swept time.Time
+}
+
+func (h *uploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := os.MkdirAll(h.opts.Dir, 0o700); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if r.Method == http.MethodPost {
+		h.create(w, r)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if !validUploadID(id) {
+		api.WriteError(w, api.BadRequest("invalid upload id"))
+		return
+	}
+	// Only uploads that exist get a lock, so unknown IDs can't grow the map
+	if _, err := h.load(id); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	lock, _ := h.locks.LoadOrStore(id, new(sync.Mutex))
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// Load again under the lock: the upload may have completed meanwhile
+	upload, err := h.load(id)
+	if err != nil {
+		h.locks.Delete(id)
+		api.WriteError(w, err)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		offset, err := h.offset(id)
+		if err != nil {
+			api.WriteError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, uploadState{ID: id, Offset: offset})
+	case http.MethodPatch:
+		h.append(w, r, upload)
+	case http.MethodDelete:
+		h.remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "POST, GET, PATCH, DELETE")
+		api.WriteError(w, &api.Error{Status: http.StatusMethodNotAllowed, Code: "method_not_allowed", Message: "method not allowed"})
+	}
+}
+
+// create starts an upload
+func (h *uploadHandler) create(w http.ResponseWriter, r *http.Request) {
+	h.sweep()
+
+	var upload Upload
+	if err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&upload); err != nil {
+		api.WriteError(w, api.BadRequest("invalid upload: "+err.Error()))
+		return
+	}
+	upload.Name = filepath.Base(filepath.Clean("/" + upload.Name))
+	if upload.Name == "/" || upload.Name == "." {
+		api.WriteError(w, api.BadRequest("missing file name"))
+		return
+	}
+	if upload.Size < 0 {
+		api.WriteError(w, api.BadRequest("invalid size"))
+		return
+	}
+	if h.opts.MaxSize > 0 && upload.Size > h.opts.MaxSize {
+		api.WriteError(w, &api.Error{Status: http.StatusRequestEntityTooLarge, Code: "too_large", Message: fmt.Sprintf("file is larger than %d bytes", h.opts.MaxSize)})
+		return
+	}
+	if h.opts.Accept != nil {
+		if err := h.opts.Accept(r, upload); err != nil {
+			api.WriteError(w, err)
+			return
+		}
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b)
+	upload.ID = hex.EncodeToString(b)
+	meta, err := json.Marshal(upload)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if err := os.WriteFile(h.path(upload.ID, ".json"), meta, 0o600); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if err := os.WriteFile(h.path(upload.ID, ".part"), nil, 0o600); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, uploadState{ID: upload.ID})
+}
+
+// append writes a chunk at the client's offset
+func (h *uploadHandler) append(w http.ResponseWriter, r *http.Request, upload Upload) {
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		api.WriteError(w, api.BadRequest("invalid Upload-Offset header"))
+		return
+	}
+	current, err := h.offset(upload.ID)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if offset != current {
+		api.WriteError(w, api.Conflict(fmt.Sprintf("upload is at offset %d", current)))
+		return
+	}
+
+	f, err := os.OpenFile(h.path(upload.ID, ".part"), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	limit := min(h.opts.MaxChunk, upload.Size-current)
+	n, err := io.Copy(f, io.LimitReader(r.Body, limit+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > limit {
+		err = api.BadRequest("chunk is larger than the upload or MaxChunk")
+	}
+	if err != nil {
+		// Drop what was written, so the client can send the chunk again
+		os.Truncate(h.path(upload.ID, ".part"), current)
+		api.WriteError(w, err)
+		return
+	}
+
+	state := uploadState{ID: upload.ID, Offset: current + n}
+	if state.Offset == upload.Size {
+		state.Done = true
+		upload.Path = h.path(upload.ID, ".part")
+		if h.opts.OnComplete != nil {
+			state.Result, err = h.opts.OnComplete(r, upload)
+		}
+		h.remove(upload.ID)
+		if err != nil {
+			api.WriteError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+// load reads an upload's description
+func (h *uploadHandler) load(id string) (Upload, error) {
+	var upload Upload
+	meta, err := os.ReadFile(h.path(id, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return upload, api.NotFound("upload not found")
+	}
+	if err != nil {
+		return upload, err
+	}
+	err = json.Unmarshal(meta, &upload)
+	return upload, err
+}
+
+// offset returns the bytes received so far
+func (h *uploadHandler) offset(id string) (int64, error) {
+	info, err := os.Stat(h.path(id, ".part"))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, api.NotFound("upload not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (h *uploadHandler) remove(id string) {
+	os.Remove(h.path(id, ".json"))
+	os.Remove(h.path(id, ".part"))
+	h.locks.Delete(id)
+}
+
+func (h *uploadHandler) path(id, ext string) string {
+	return filepath.Join(h.opts.Dir, id+ext)
+}
+
+// sweep removes expired partial uploads, at most once a minute
+func (h *uploadHandler) sweep() {
+	h.mu.Lock()
+	if time.Since(h.swept) < time.Minute {
+		h.mu.Unlock()
+		return
+	}
+	h.swept = time.Now()
+	h.mu.Unlock()
+
+	entries, err := os.ReadDir(h.opts.Dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if filepath.Ext(name) != ".part" {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) > h.opts.Expire {
+			h.remove(name[:len(name)-len(".part")])
+		}
+	}
+}
+
+// validUploadID reports whether id is one created by UploadHandler, so it's
+// safe in a file path
+func validUploadID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func uploadRequest(t *testing.T, h http.Handler, method, target, body string, header map[string]string) (int, uploadState) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var state uploadState
+	json.Unmarshal(rec.Body.Bytes(), &state)
+	return rec.Code, state
+}
+
+func TestUploadHandler(t *testing.T) {
+	var got []byte
+	h := UploadHandler(UploadOptions{
+		Dir:      t.TempDir(),
+		MaxChunk: 4,
+		OnComplete: func(r *http.Request, u Upload) (any, error) {
+			var err error
+			got, err = os.ReadFile(u.Path)
+			return map[string]string{"name": u.Name}, err
+		},
+	})
+
+	code, state := uploadRequest(t, h, "POST", "/", `{"name":"../notes.txt","size":6}`, nil)
+	if code != http.StatusCreated || state.ID == "" {
+		t.Fatalf("create: %d %+v", code, state)
+	}
+	target := "/?id=" + state.ID
+
+	patch := func(offset int, body string) (int, uploadState) {
+		return uploadRequest(t, h, "PATCH", target, body, map[string]string{"Upload-Offset": strconv.Itoa(offset)})
+	}
+	if code, state := patch(0, "hell"); code != http.StatusOK || state.Offset != 4 {
+		t.Fatalf("first chunk: %d %+v", code, state)
+	}
+	if code, _ := patch(2, "ll"); code != http.StatusConflict {
+		t.Fatalf("wrong offset: %d, want 409", code)
+	}
+	if code, _ := patch(4, "o!!"); code != http.StatusBadRequest {
+		t.Fatalf("chunk past the size: %d, want 400", code)
+	}
+	if code, state := uploadRequest(t, h, "GET", target, "", nil); code != http.StatusOK || state.Offset != 4 {
+		t.Fatalf("offset after the rejected chunk: %d %+v", code, state)
+	}
+	code, state = patch(4, "o!")
+	if code != http.StatusOK || !state.Done || string(got) != "hello!" {
+		t.Fatalf("last chunk: %d %+v, file %q", code, state, got)
+	}
+	if code, _ := uploadRequest(t, h, "GET", target, "", nil); code != http.StatusNotFound {
+		t.Fatalf("completed upload: %d, want 404", code)
+	}
+	if n := countLocks(h); n != 0 {
+		t.Fatalf("%d locks left after completion", n)
+	}
+}
+
+func TestUploadUnknownID(t *testing.T) {
+	h := UploadHandler(UploadOptions{Dir: t.TempDir()})
+	for i := 0; i < 10; i++ {
+		id := strings.Repeat(strconv.Itoa(i), 32)
+		if code, _ := uploadRequest(t, h, "GET", "/?id="+id, "", nil); code != http.StatusNotFound {
+			t.Fatalf("unknown id: %d, want 404", code)
+		}
+	}
+	if code, _ := uploadRequest(t, h, "GET", "/?id=../../etc", "", nil); code != http.StatusBadRequest {
+		t.Fatalf("invalid id: %d, want 400", code)
+	}
+	if n := countLocks(h); n != 0 {
+		t.Fatalf("unknown ids left %d locks", n)
+	}
+}
+
+func countLocks(h http.Handler) int {
+	n := 0
+	h.(*uploadHandler).locks.Range(func(_, _ any) bool { n++; return true })
+	return n
+}
@@ -0,0 +1,515 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Messages WSHub sends and handles itself
+const (
+	// WSHelloEvent is sent first on every connection, with the node that
+	// serves it and the process's epoch: {"node": "web-1", "epoch": "…"}
+	WSHelloEvent = "ws.hello"
+
+	// WSSubscribedEvent confirms a subscription, with the topic and its
+	// current sequence number, so the client can resume from there
+	WSSubscribedEvent = "ws.subscribed"
+
+	// WSResyncEvent tells a resuming client that events on the topic were
+	// missed and can't be replayed, e.g. because it reconnected to another
+	// node. Its seq is the topic's current number. Reload the data.
+	WSResyncEvent = "ws.resync"
+
+	// WSSubscribe and WSUnsubscribe are sent by clients with
+	// {"topics": ["posts"]}
+	WSSubscribe   = "subscribe"
+	WSUnsubscribe = "unsubscribe"
+)
+
+// WSMessage is the JSON envelope of WebSocket messages, the same as
+// ws.Message in the browser. Topic and Seq are set on published events.
+type WSMessage struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	ID      string          `json:"id,omitempty"` // request/response correlation
+	Topic   string          `json:"topic,omitempty"`
+	Seq     uint64          `json:"seq,omitempty"`
+}
+
+// WSHubOptions configures a WSHub
+type WSHubOptions struct {
+	// Node names this server in resume tokens and the sticky cookie
+	// (default: random per process). Behind a load balancer, give each
+	// instance a stable name, such as its hostname. Sequence numbers start
+	// over when the process does, so tokens also carry a random epoch per
+	// hub, and a client that reconnects to the same node after a restart
+	// is told to resync rather than replayed unrelated events.
+	Node string
+
+	// StickyCookie, when set, is the name of a cookie set to Node on the
+	// upgrade response. Configure the load balancer to route by it, so
+	// reconnecting clients return to the node that holds their history.
+	StickyCookie string
+
+	// History is the number of events kept per topic for clients that
+	// resume (default 100; negative disables)
+	History int
+
+	// Buffer is the number of messages queued per connection (default
+	// 64). A client that falls further behind is disconnected; it
+	// reconnects and resumes from History.
+	Buffer int
+
+	// KeepAlive is the interval between pings (default 30 seconds)
+	KeepAlive time.Duration
+
+	// Upgrader upgrades the connection. Set CheckOrigin to accept
+	// cross-origin clients.
+	Upgrader websocket.Upgrader
+
+	// Key groups connections for SendTo, like SSEOptions.Key
+	Key func(r *http.Request) string
+
+	// Authorize decides whether a connection may subscribe to a topic
+	// (default: any topic)
+	Authorize func(c *WSConn, topic string) bool
+
+	// OnConnect is called after the hello and any replayed events are
+	// queued
+	OnConnect func(c *WSConn)
+
+	// OnMessage is called for messages other than subscribe and
+	// unsubscribe, one at a time per connection. Answer requests with
+	// c.Reply.
+	OnMessage func(c *WSConn, msg WSMessage)
+
+	// OnDisconnect is called after a client disconnects
+	OnDisconnect func(c *WSConn)
+}
+
+// WSHub serves WebSocket connections that subscribe to topics, and fans
+// published events out to the subscribers. Each event gets the next
+// sequence number of its topic, and the last History events of a topic are
+// kept, so a client that reconnects with a resume token receives what it
+// missed while disconnected instead of leaving a gap.
+//
+//	hub := server.NewWSHub(server.WSHubOptions{Node: hostname})
+//	mux.Handle("GET /ws", hub)
+//
+//	hub.Publish("posts", "post.created", post)
+type WSHub struct {
+	opts  WSHubOptions
+	epoch string // random per hub, since sequence numbers start over with it
+
+	mu     sync.Mutex
+	conns  map[*WSConn]struct{}
+	topics map[string]*wsTopic
+	closed bool
+}
+
+// wsTopic is a topic's sequence number and replay history
+type wsTopic struct {
+	seq     uint64
+	history []WSMessage
+}
+
+// wsResume is the decoded resume token: the node and epoch the client was
+// connected to and the last sequence number it received for each topic
+type wsResume struct {
+	Node   string            `json:"node"`
+	Epoch  string            `json:"epoch"`
+	Topics map[string]uint64 `json:"topics"`
+}
+
+// WSConn is one connected client
+type WSConn struct {
+	// Key is the connection's group from WSHubOptions.Key
+	Key string
+
+	// Request is the request that opened the connection
+	Request *http.Request
+
+	// Resumed reports whether the client reconnected with a resume token
+	Resumed bool
+
+	hub    *WSHub
+	conn   *websocket.Conn
+	send   chan WSMessage
+	topics map[string]bool // guarded by hub.mu
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewWSHub creates a WSHub
+func NewWSHub(opts WSHubOptions) *WSHub {
+	if opts.Node == "" {
+		b := make([]byte, 6)
+		rand.Read(b)
+		opts.Node = hex.EncodeToString(b)
+	}
+	if opts.History == 0 {
+		opts.History = 100
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = 30 * time.Second
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return &WSHub{opts: opts, epoch: hex.EncodeToString(b), conns: make(map[*WSConn]struct{}), topics: make(map[string]*wsTopic)}
+}
+
+// ServeHTTP upgrades the connection and serves it until the client
+// disconnects. A "resume" query parameter resubscribes the topics in the
+// token and replays the events after the sequence numbers in it.
+func (h *WSHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	closed := h.closed
+	h.mu.Unlock()
+	if closed {
+		http.Error(w, "websocket hub closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	var header http.Header
+	if h.opts.StickyCookie != "" {
+		header = http.Header{}
+		header.Add("Set-Cookie", (&http.Cookie{Name: h.opts.StickyCookie, Value: h.opts.Node, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode}).String())
+	}
+	conn, err := h.opts.Upgrader.Upgrade(w, r, header)
+	if err != nil {
+		log.Printf("ws: upgrade: %v", err)
+		return // the upgrader has written the error response
+	}
+
+	c := &WSConn{
+		Request: r,
+		hub:     h,
+		conn:    conn,
+		topics:  make(map[string]bool),
+		done:    make(chan struct{}),
+	}
+	if h.opts.Key != nil {
+		c.Key = h.opts.Key(r)
+	}
+	var resume wsResume
+	if token := r.URL.Query().Get("resume"); token != "" {
+		if data, err := base64.RawURLEncoding.DecodeString(token); err == nil && json.Unmarshal(data, &resume) == nil {
+			c.Resumed = true
+		}
+	}
+
+	var topics []string
+	for topic := range resume.Topics {
+		if h.opts.Authorize == nil || h.opts.Authorize(c, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+
+	// Register and take the missed events under one lock, so no event is
+	// both replayed and queued, or neither
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		conn.Close()
+		return
+	}
+	hello, _ := json.Marshal(map[string]string{"node": h.opts.Node, "epoch": h.epoch})
+	queued := []WSMessage{{Type: WSHelloEvent, Payload: hello}}
+	for _, topic := range topics {
+		c.topics[topic] = true
+		queued = append(queued, h.missed(topic, resume, resume.Topics[topic])...)
+	}
+	c.send = make(chan WSMessage, h.opts.Buffer+len(queued))
+	for _, msg := range queued {
+		c.send <- msg
+	}
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.writeLoop(h.opts.KeepAlive)
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+		c.Close()
+		if h.opts.OnDisconnect != nil {
+			h.opts.OnDisconnect(c)
+		}
+	}()
+	if h.opts.OnConnect != nil {
+		h.opts.OnConnect(c)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * h.opts.KeepAlive))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.opts.KeepAlive))
+	})
+	for {
+		// Read and decode separately, so only a failed read disconnects;
+		// a message that doesn't decode, like {"type": 1}, is skipped
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.Send("error", map[string]string{"message": "invalid message format"})
+			continue
+		}
+		switch msg.Type {
+		case WSSubscribe, WSUnsubscribe:
+			var body struct {
+				Topics []string `json:"topics"`
+			}
+			json.Unmarshal(msg.Payload, &body)
+			var accepted []string
+			if msg.Type == WSSubscribe {
+				accepted = c.Subscribe(body.Topics...)
+			} else {
+				c.Unsubscribe(body.Topics...)
+				accepted = body.Topics
+			}
+			if msg.ID != "" {
+				c.Reply(msg, map[string][]string{"topics": accepted})
+			}
+		default:
+			if h.opts.OnMessage != nil {
+				h.opts.OnMessage(c, msg)
+			}
+		}
+	}
+}
+
+// missed returns what a client resuming topic from seq with the token
+// resume needs: the events after seq, or a resync when they can't be
+// replayed. h.mu must be held.
+func (h *WSHub) missed(topic string, resume wsResume, seq uint64) []WSMessage {
+	t := h.topics[topic]
+	current := uint64(0)
+	if t != nil {
+		current = t.seq
+	}
+	// The same process, so its sequence numbers are the token's
+	same := resume.Node == h.opts.Node && resume.Epoch == h.epoch
+	switch {
+	case resume.Node == "":
+		// Subscribed before the first connection, so nothing was missed
+		return []WSMessage{{Type: WSSubscribedEvent, Topic: topic, Seq: current}}
+	case same && seq == current:
+		return nil // nothing was published meanwhile
+	case same && seq < current && t != nil && len(t.history) > 0 && t.history[0].Seq <= seq+1:
+		var events []WSMessage
+		for _, msg := range t.history {
+			if msg.Seq > seq {
+				events = append(events, msg)
+			}
+		}
+		return events
+	}
+	// Another node, a restart, or older than the history
+	return []WSMessage{{Type: WSResyncEvent, Topic: topic, Seq: current}}
+}
+
+// Publish sends an event to the topic's subscribers and keeps it for
+// clients that resume. It fails only if payload can't be encoded as JSON.
+func (h *WSHub) Publish(topic, msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil
+	}
+	t := h.topics[topic]
+	if t == nil {
+		t = &wsTopic{}
+		h.topics[topic] = t
+	}
+	t.seq++
+	msg := WSMessage{Type: msgType, Payload: data, Topic: topic, Seq: t.seq}
+	if h.opts.History > 0 {
+		t.history = append(t.history, msg)
+		if len(t.history) > h.opts.History {
+			t.history = t.history[len(t.history)-h.opts.History:]
+		}
+	}
+	for c := range h.conns {
+		if c.topics[topic] {
+			c.queue(msg)
+		}
+	}
+	return nil
+}
+
+// SendTo sends a message, outside any topic, to the connections whose
+// WSHubOptions.Key is key. It isn't kept for replay.
+func (h *WSHub) SendTo(key, msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		if c.Key == key {
+			c.queue(WSMessage{Type: msgType, Payload: data})
+		}
+	}
+	return nil
+}
+
+// Count returns the number of connected clients
+func (h *WSHub) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.conns)
+}
+
+// Close disconnects every client and refuses new connections, e.g. before
+// shutting down the server
+func (h *WSHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for c := range h.conns {
+		c.Close()
+	}
+}
+
+// Subscribe subscribes the connection to topics it is authorized for and
+// confirms each with a WSSubscribedEvent. It returns the topics subscribed.
+func (c *WSConn) Subscribe(topics ...string) []string {
+	h := c.hub
+	var accepted []string
+	for _, topic := range topics {
+		if topic == "" || h.opts.Authorize != nil && !h.opts.Authorize(c, topic) {
+			continue
+		}
+		h.mu.Lock()
+		c.topics[topic] = true
+		var seq uint64
+		if t := h.topics[topic]; t != nil {
+			seq = t.seq
+		}
+		c.queue(WSMessage{Type: WSSubscribedEvent, Topic: topic, Seq: seq})
+		h.mu.Unlock()
+		accepted = append(accepted, topic)
+	}
+	return accepted
+}
+
+// Unsubscribe stops sending the topics' events to the connection
+func (c *WSConn) Unsubscribe(topics ...string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+}
+
+// Topics returns the topics the connection is subscribed to
+func (c *WSConn) Topics() []string {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Send queues a message for this client. It reports false if the payload
+// can't be encoded, the connection is closed, or the client is too far
+// behind, in which case it is closed.
+func (c *WSConn) Send(msgType string, payload any) bool {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false
+	}
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	return c.queue(WSMessage{Type: msgType, Payload: data})
+}
+
+// Reply answers a request from ws.Client.Request with a "<type>.response"
+// message carrying the request's ID
+func (c *WSConn) Reply(req WSMessage, payload any) bool {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false
+	}
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	return c.queue(WSMessage{Type: req.Type + ".response", Payload: data, ID: req.ID})
+}
+
+// queue adds msg to the send queue without blocking. c.hub.mu must be held.
+func (c *WSConn) queue(msg WSMessage) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		c.Close()
+		return false
+	}
+}
+
+// Close ends the connection
+func (c *WSConn) Close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Done is closed when the connection ends
+func (c *WSConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// writeLoop writes queued messages and pings, and closes the connection
+// when it ends, which also ends the read loop in ServeHTTP
+func (c *WSConn) writeLoop(keepAlive time.Duration) {
+	ping := time.NewTicker(keepAlive)
+	defer func() {
+		ping.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.Close()
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				c.Close()
+				return
+			}
+		case <-c.done:
+			c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			return
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialHub connects to a hub with a resume token and returns the messages
+// received before the connection goes quiet
+func dialHub(t *testing.T, hub *WSHub, resume wsResume) []WSMessage {
+	t.Helper()
+	srv := httptest.NewServer(hub)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	if resume.Topics != nil {
+		data, _ := json.Marshal(resume)
+		url += "?resume=" + base64.RawURLEncoding.EncodeToString(data)
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var msgs []WSMessage
+	for {
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		var msg WSMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func hello(t *testing.T, msgs []WSMessage) wsResume {
+	t.Helper()
+	if len(msgs) == 0 || msgs[0].Type != WSHelloEvent {
+		t.Fatalf("first message %+v, want %s", msgs, WSHelloEvent)
+	}
+	var h wsResume
+	json.Unmarshal(msgs[0].Payload, &h)
+	return h
+}
+
+func publish(hub *WSHub, n int) {
+	for i := 0; i < n; i++ {
+		hub.Publish("posts", "post.created", map[string]int{"n": i})
+	}
+}
+
+func TestWSHubResume(t *testing.T) {
+	hub := NewWSHub(WSHubOptions{Node: "web-1"})
+	defer hub.Close()
+	publish(hub, 3)
+
+	h := hello(t, dialHub(t, hub, wsResume{}))
+	if h.Node != "web-1" || h.Epoch == "" {
+		t.Fatalf("hello %+v", h)
+	}
+
+	msgs := dialHub(t, hub, wsResume{Node: h.Node, Epoch: h.Epoch, Topics: map[string]uint64{"posts": 1}})
+	if len(msgs) != 3 || msgs[1].Seq != 2 || msgs[2].Seq != 3 {
+		t.Fatalf("resume replayed %+v, want seqs 2 and 3", msgs)
+	}
+
+	msgs = dialHub(t, hub, wsResume{Node: "web-2", Epoch: h.Epoch, Topics: map[string]uint64{"posts": 1}})
+	if len(msgs) != 2 || msgs[1].Type != WSResyncEvent || msgs[1].Seq != 3 {
+		t.Fatalf("another node got %+v, want a resync", msgs)
+	}
+}
+
+func TestWSHubResumeAfterRestart(t *testing.T) {
+	before := NewWSHub(WSHubOptions{Node: "web-1"})
+	publish(before, 5)
+	h := hello(t, dialHub(t, before, wsResume{}))
+	before.Close()
+
+	// The restarted process has the same node name and fewer events
+	after := NewWSHub(WSHubOptions{Node: "web-1"})
+	defer after.Close()
+	publish(after, 3)
+
+	msgs := dialHub(t, after, wsResume{Node: h.Node, Epoch: h.Epoch, Topics: map[string]uint64{"posts": 2}})
+	if len(msgs) != 2 || msgs[1].Type != WSResyncEvent {
+		t.Fatalf("resume after a restart got %+v, want a resync", msgs)
+	}
+}
+
+func TestWSHubSkipsUndecodableMessages(t *testing.T) {
+	hub := NewWSHub(WSHubOptions{})
+	defer hub.Close()
+	srv := httptest.NewServer(hub)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, raw := range []string{`{"type": 1}`, `not json`, `{"type": "subscribe", "payload": {"topics": ["posts"]}}`} {
+		conn.WriteMessage(websocket.TextMessage, []byte(raw))
+	}
+
+	var types []string
+	for {
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		var msg WSMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			break
+		}
+		types = append(types, msg.Type)
+	}
+	want := []string{WSHelloEvent, "error", "error", WSSubscribedEvent}
+	if strings.Join(types, " ") != strings.Join(want, " ") {
+		t.Errorf("received %v, want %v", types, want)
+	}
+}
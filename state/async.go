@@ -2,16 +2,47 @@
 
 package state
 
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
 // AsyncState represents loading state for async operations
 type AsyncState[T any] struct {
 	Data    T
 	Loading bool
 	Error   error
+
+	// Revalidating is true while cached Data is shown and a background
+	// refetch is running (CacheOptions.SWR)
+	Revalidating bool
+}
+
+// CacheOptions configures how an AsyncStore uses the cache in LoadKey
+type CacheOptions struct {
+	// TTL is how long cached data is fresh. Fresh data is used without
+	// fetching; stale data is refetched. Default 0: always refetch, while
+	// still sharing in-flight requests.
+	TTL time.Duration
+
+	// SWR (stale-while-revalidate) shows stale cached data immediately and
+	// refetches in the background, setting Revalidating instead of Loading
+	SWR bool
+
+	// Cache holds the shared data. Default: GetAsyncCache()
+	Cache *AsyncCache
 }
 
 // AsyncStore is a store specialized for async data
 type AsyncStore[T any] struct {
 	*Store[AsyncState[T]]
+
+	cacheOpts CacheOptions
+	cacheMu   sync.Mutex
+	key       string
+	entry     *asyncEntry // the cache entry the store listens to
+	unlisten  func()
 }
 
 // NewAsync creates a new async store
@@ -28,6 +59,14 @@ func NewAsyncWithDefault[T any](defaultData T) *AsyncStore[T] {
 	}
 }
 
+// NewAsyncCached creates an async store that loads through the cache with LoadKey
+func NewAsyncCached[T any](opts CacheOptions) *AsyncStore[T] {
+	return &AsyncStore[T]{
+		Store:     New(AsyncState[T]{}),
+		cacheOpts: opts,
+	}
+}
+
 // Load starts a loading operation
 func (s *AsyncStore[T]) Load(fn func() (T, error)) {
 	s.Update(func(state *AsyncState[T]) {
@@ -84,3 +123,91 @@ func (s *AsyncStore[T]) Data() T {
 func (s *AsyncStore[T]) Err() error {
 	return s.Get().Error
 }
+
+// LoadKey loads data through the cache under key. Stores loading the same key
+// share one request, and the store keeps following the key: later fetches,
+// Invalidate, and AsyncCache.SetData update it too. Switching to another key
+// stops following the previous one.
+//
+//	posts := state.NewAsyncCached[[]api.Post](state.CacheOptions{TTL: time.Minute, SWR: true})
+//	posts.LoadKey("posts", func() ([]api.Post, error) {
+//		return client.GetAll(ctx)
+//	})
+func (s *AsyncStore[T]) LoadKey(key string, fn func() (T, error)) {
+	cache := s.cache()
+	s.follow(cache, key)
+	cache.load(key, func() (any, error) { return fn() }, s.cacheOpts.TTL)
+}
+
+// Invalidate refetches the store's current key; see AsyncCache.Invalidate
+func (s *AsyncStore[T]) Invalidate() {
+	s.cacheMu.Lock()
+	key := s.key
+	s.cacheMu.Unlock()
+	if key != "" {
+		s.cache().Invalidate(key)
+	}
+}
+
+// Detach stops following the cache key, e.g. when the page using the store
+// is left. The store keeps its current data.
+func (s *AsyncStore[T]) Detach() {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.unlisten != nil {
+		s.unlisten()
+		s.unlisten = nil
+	}
+	s.key = ""
+	s.entry = nil
+}
+
+func (s *AsyncStore[T]) cache() *AsyncCache {
+	if s.cacheOpts.Cache != nil {
+		return s.cacheOpts.Cache
+	}
+	return GetAsyncCache()
+}
+
+// follow subscribes the store to key, unless it already follows the
+// key's current entry. After AsyncCache.Remove the key has a new entry,
+// which the store listens to instead.
+func (s *AsyncStore[T]) follow(cache *AsyncCache, key string) {
+	s.cacheMu.Lock()
+	if s.key == key && s.unlisten != nil && cache.live(s.entry) {
+		s.cacheMu.Unlock()
+		return
+	}
+	if s.unlisten != nil {
+		s.unlisten()
+	}
+	s.key = key
+	s.cacheMu.Unlock()
+
+	entry, unlisten := cache.listen(key, func(snap asyncSnapshot) { s.apply(key, snap) })
+
+	s.cacheMu.Lock()
+	s.entry = entry
+	s.unlisten = unlisten
+	s.cacheMu.Unlock()
+}
+
+// apply copies a cache entry's state into the store
+func (s *AsyncStore[T]) apply(key string, snap asyncSnapshot) {
+	s.Update(func(state *AsyncState[T]) {
+		state.Error = snap.err
+		if snap.hasData {
+			if data, ok := snap.data.(T); ok {
+				state.Data = data
+			} else if snap.data == nil {
+				var zero T
+				state.Data = zero
+			} else {
+				state.Error = fmt.Errorf("state: cache key %q holds %T, not %T", key, snap.data, state.Data)
+			}
+		}
+		showStale := s.cacheOpts.SWR && snap.hasData
+		state.Loading = snap.fetching && !showStale
+		state.Revalidating = snap.fetching && showStale
+	})
+}
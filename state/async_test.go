@@ -0,0 +1,62 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or a second passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncStoreSharesKey(t *testing.T) {
+	cache := NewAsyncCache()
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"a"}, nil
+	}
+
+	first := NewAsyncCached[[]string](CacheOptions{Cache: cache, TTL: time.Minute})
+	first.LoadKey("posts", fetch)
+	waitFor(t, func() bool { return len(first.Data()) == 1 })
+
+	second := NewAsyncCached[[]string](CacheOptions{Cache: cache, TTL: time.Minute})
+	second.LoadKey("posts", fetch)
+	waitFor(t, func() bool { return len(second.Data()) == 1 })
+	if calls != 1 {
+		t.Fatalf("fetched %d times, want 1", calls)
+	}
+
+	cache.SetData("posts", []string{"a", "b"})
+	if len(first.Data()) != 2 || len(second.Data()) != 2 {
+		t.Fatalf("SetData didn't reach the stores: %v, %v", first.Data(), second.Data())
+	}
+}
+
+func TestAsyncStoreFollowsAfterRemove(t *testing.T) {
+	cache := NewAsyncCache()
+	store := NewAsyncCached[string](CacheOptions{Cache: cache})
+	store.LoadKey("user", func() (string, error) { return "ada", nil })
+	waitFor(t, func() bool { return store.Data() == "ada" })
+
+	cache.Remove("user")
+	store.LoadKey("user", func() (string, error) { return "grace", nil })
+	waitFor(t, func() bool { return store.Data() == "grace" })
+
+	// The store listens to the new entry, not the removed one
+	cache.SetData("user", "linus")
+	if got := store.Data(); got != "linus" {
+		t.Fatalf("Data() = %q after SetData, want linus", got)
+	}
+}
@@ -0,0 +1,239 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// AsyncCache shares AsyncStore data by key. Stores that load the same key
+// share one request and one result, so two pages showing the same posts
+// fetch them once.
+type AsyncCache struct {
+	mu      sync.Mutex
+	entries map[string]*asyncEntry
+	nextID  int
+}
+
+// asyncEntry is the cached result for one key
+type asyncEntry struct {
+	data      any
+	hasData   bool
+	err       error
+	fetchedAt time.Time
+	fetching  bool
+	fetcher   func() (any, error)
+	listeners map[int]func(asyncSnapshot)
+	removed   bool // dropped by Remove; stores following it listen again on their next load
+}
+
+// asyncSnapshot is what stores following a key are told on every change
+type asyncSnapshot struct {
+	data     any
+	hasData  bool
+	err      error
+	fetching bool
+}
+
+var globalAsyncCache = NewAsyncCache()
+
+// GetAsyncCache returns the global cache used by AsyncStore.LoadKey
+func GetAsyncCache() *AsyncCache {
+	return globalAsyncCache
+}
+
+// NewAsyncCache creates an empty cache, e.g. to isolate a feature's data
+// from the global cache
+func NewAsyncCache() *AsyncCache {
+	return &AsyncCache{entries: make(map[string]*asyncEntry)}
+}
+
+// Get returns the cached data for a key
+func (c *AsyncCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !e.hasData {
+		return nil, false
+	}
+	return e.data, true
+}
+
+// SetData replaces the cached data for a key and updates every store
+// following it, e.g. after a mutation returns the new list
+func (c *AsyncCache) SetData(key string, data any) {
+	c.mu.Lock()
+	e := c.entry(key)
+	e.data, e.hasData, e.err = data, true, nil
+	e.fetchedAt = time.Now()
+	c.mu.Unlock()
+	c.notify(key)
+}
+
+// Invalidate marks a key as stale. If any store is following it, the data is
+// refetched in the background right away; otherwise the next load refetches.
+func (c *AsyncCache) Invalidate(key string) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	e.fetchedAt = time.Time{}
+	start := len(e.listeners) > 0 && e.fetcher != nil && !e.fetching
+	if start {
+		e.fetching = true
+	}
+	c.mu.Unlock()
+
+	if start {
+		c.notify(key)
+		go c.fetch(key, e)
+	}
+}
+
+// InvalidateAll marks every key as stale and refetches the ones in use
+func (c *AsyncCache) InvalidateAll() {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.Invalidate(key)
+	}
+}
+
+// Remove drops a key from the cache. Stores following it keep their data
+// until they next load the key, which starts a new entry.
+func (c *AsyncCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.removed = true
+		delete(c.entries, key)
+	}
+}
+
+// load fetches key unless the cached data is younger than ttl or a fetch is
+// already in flight, in which case the caller shares its result
+func (c *AsyncCache) load(key string, fetcher func() (any, error), ttl time.Duration) {
+	c.mu.Lock()
+	e := c.entry(key)
+	e.fetcher = fetcher
+	fresh := e.hasData && e.err == nil && ttl > 0 && time.Since(e.fetchedAt) < ttl
+	start := !fresh && !e.fetching
+	if start {
+		e.fetching = true
+	}
+	c.mu.Unlock()
+
+	if start {
+		c.notify(key)
+		go c.fetch(key, e)
+	}
+}
+
+// Warm fetches key ahead of time, e.g. from an idle.Queue task, unless it
+// was fetched within ttl or a fetch is in flight. It waits for the fetch,
+// so a task warming several keys fetches them one at a time. A store that
+// then loads the key with LoadKey and the same TTL shows it without
+// waiting.
+func (c *AsyncCache) Warm(key string, fetcher func() (any, error), ttl time.Duration) {
+	c.mu.Lock()
+	e := c.entry(key)
+	fresh := e.hasData && e.err == nil && ttl > 0 && time.Since(e.fetchedAt) < ttl
+	start := !fresh && !e.fetching
+	if start {
+		e.fetcher = fetcher
+		e.fetching = true
+	}
+	c.mu.Unlock()
+
+	if start {
+		c.notify(key)
+		c.fetch(key, e)
+	}
+}
+
+func (c *AsyncCache) fetch(key string, e *asyncEntry) {
+	c.mu.Lock()
+	fetcher := e.fetcher
+	c.mu.Unlock()
+
+	data, err := fetcher()
+
+	c.mu.Lock()
+	e.fetching = false
+	if err != nil {
+		e.err = err
+	} else {
+		e.data, e.hasData, e.err = data, true, nil
+		e.fetchedAt = time.Now()
+	}
+	c.mu.Unlock()
+	c.notify(key)
+}
+
+// listen calls fn with the key's current state and again on every change.
+// Returns the entry listened to and a function that stops listening.
+func (c *AsyncCache) listen(key string, fn func(asyncSnapshot)) (*asyncEntry, func()) {
+	c.mu.Lock()
+	e := c.entry(key)
+	c.nextID++
+	id := c.nextID
+	e.listeners[id] = fn
+	snap := e.snapshot()
+	c.mu.Unlock()
+
+	fn(snap)
+
+	return e, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(e.listeners, id)
+	}
+}
+
+// live reports whether e is still the entry for its key
+func (c *AsyncCache) live(e *asyncEntry) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !e.removed
+}
+
+func (c *AsyncCache) notify(key string) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	snap := e.snapshot()
+	listeners := make([]func(asyncSnapshot), 0, len(e.listeners))
+	for _, fn := range e.listeners {
+		listeners = append(listeners, fn)
+	}
+	c.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(snap)
+	}
+}
+
+// entry returns the entry for key, creating it. Callers hold c.mu.
+func (c *AsyncCache) entry(key string) *asyncEntry {
+	e, ok := c.entries[key]
+	if !ok {
+		e = &asyncEntry{listeners: make(map[int]func(asyncSnapshot))}
+		c.entries[key] = e
+	}
+	return e
+}
+
+func (e *asyncEntry) snapshot() asyncSnapshot {
+	return asyncSnapshot{data: e.data, hasData: e.hasData, err: e.err, fetching: e.fetching}
+}
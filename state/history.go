@@ -0,0 +1,214 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryOptions configures a History
+type HistoryOptions[T any] struct {
+	// MaxDepth is the number of steps kept for Undo (default 100). The
+	// oldest steps are dropped first.
+	MaxDepth int
+
+	// GroupWindow merges changes made less than this long after the
+	// previous one into a single step, so typing a word is undone at once
+	// rather than letter by letter. 0 makes every change its own step.
+	GroupWindow time.Duration
+
+	// Clone copies a state for the history. It is needed when T holds
+	// slices, maps, or pointers that Update changes in place; otherwise the
+	// saved steps would change with the store.
+	Clone func(T) T
+}
+
+// History wraps a Store with undo and redo. Every change to the store is
+// recorded, including ones made through the store directly, and Undo and
+// Redo set the store back and forth between the recorded states.
+type History[T any] struct {
+	*Store[T]
+	opts HistoryOptions[T]
+
+	mu       sync.Mutex
+	undo     []T
+	redo     []T
+	current  T         // copy of the store's state as last recorded
+	last     time.Time // when the last change was recorded; zero starts a new step
+	grouping int       // depth of Group calls
+	grouped  bool      // the current Group already recorded a step
+	applying bool      // Undo or Redo is setting the store
+
+	listeners   map[int]func(canUndo, canRedo bool)
+	nextID      int
+	unsubscribe func()
+}
+
+// NewHistory starts recording the changes to store
+func NewHistory[T any](store *Store[T], opts HistoryOptions[T]) *History[T] {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 100
+	}
+	if opts.Clone == nil {
+		opts.Clone = func(v T) T { return v }
+	}
+	h := &History[T]{
+		Store:     store,
+		opts:      opts,
+		current:   opts.Clone(store.Get()),
+		listeners: make(map[int]func(bool, bool)),
+	}
+	h.unsubscribe = store.Subscribe(h.record)
+	return h
+}
+
+// record saves the state before a change as an undo step
+func (h *History[T]) record(state T) {
+	h.mu.Lock()
+	if h.applying {
+		h.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	merge := h.grouped ||
+		(h.grouping == 0 && h.opts.GroupWindow > 0 && !h.last.IsZero() && now.Sub(h.last) < h.opts.GroupWindow)
+	if !merge {
+		h.undo = append(h.undo, h.current)
+		if len(h.undo) > h.opts.MaxDepth {
+			h.undo = h.undo[len(h.undo)-h.opts.MaxDepth:]
+		}
+	}
+	if h.grouping > 0 {
+		h.grouped = true
+	}
+	h.redo = nil
+	h.current = h.opts.Clone(state)
+	h.last = now
+	h.mu.Unlock()
+
+	h.notify()
+}
+
+// Undo sets the store to the state before the last step. It returns false
+// when there is nothing to undo.
+func (h *History[T]) Undo() bool {
+	return h.move(&h.undo, &h.redo)
+}
+
+// Redo reapplies the last undone step. It returns false when there is
+// nothing to redo; any new change clears what could be redone.
+func (h *History[T]) Redo() bool {
+	return h.move(&h.redo, &h.undo)
+}
+
+// move pops a state from one stack, pushes the current state on the
+// other, and sets the store to the popped state
+func (h *History[T]) move(from, to *[]T) bool {
+	h.mu.Lock()
+	if len(*from) == 0 {
+		h.mu.Unlock()
+		return false
+	}
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, h.current)
+	h.current = state
+	h.last = time.Time{}
+	h.applying = true
+	h.mu.Unlock()
+
+	// The store gets a copy, so changing it in place leaves the step intact
+	h.Store.Set(h.opts.Clone(state))
+
+	h.mu.Lock()
+	h.applying = false
+	h.mu.Unlock()
+	h.notify()
+	return true
+}
+
+// CanUndo reports whether there is a step to undo
+func (h *History[T]) CanUndo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.undo) > 0
+}
+
+// CanRedo reports whether there is a step to redo
+func (h *History[T]) CanRedo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.redo) > 0
+}
+
+// Group records every change made by fn as one step, e.g. a "replace all"
+// that updates the store many times
+func (h *History[T]) Group(fn func()) {
+	h.mu.Lock()
+	h.grouping++
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		h.grouping--
+		if h.grouping == 0 {
+			h.grouped = false
+			h.last = time.Time{}
+		}
+		h.mu.Unlock()
+	}()
+	fn()
+}
+
+// Checkpoint ends the current step, so the next change starts a new one
+// even within GroupWindow, e.g. when a text field loses focus
+func (h *History[T]) Checkpoint() {
+	h.mu.Lock()
+	h.last = time.Time{}
+	h.mu.Unlock()
+}
+
+// Clear forgets every step, e.g. after the document is saved or loaded
+func (h *History[T]) Clear() {
+	h.mu.Lock()
+	h.undo = nil
+	h.redo = nil
+	h.last = time.Time{}
+	h.mu.Unlock()
+	h.notify()
+}
+
+// OnChange calls fn after each change to what can be undone or redone, to
+// enable or disable Undo and Redo buttons. It returns a function that
+// unsubscribes.
+func (h *History[T]) OnChange(fn func(canUndo, canRedo bool)) func() {
+	h.mu.Lock()
+	id := h.nextID
+	h.nextID++
+	h.listeners[id] = fn
+	h.mu.Unlock()
+	return func() {
+		h.mu.Lock()
+		delete(h.listeners, id)
+		h.mu.Unlock()
+	}
+}
+
+func (h *History[T]) notify() {
+	h.mu.Lock()
+	canUndo, canRedo := len(h.undo) > 0, len(h.redo) > 0
+	fns := make([]func(bool, bool), 0, len(h.listeners))
+	for _, fn := range h.listeners {
+		fns = append(fns, fn)
+	}
+	h.mu.Unlock()
+	for _, fn := range fns {
+		fn(canUndo, canRedo)
+	}
+}
+
+// Close stops recording changes to the store
+func (h *History[T]) Close() {
+	h.unsubscribe()
+}
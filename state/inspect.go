@@ -0,0 +1,182 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Inspectable is a named store as seen by debugging tools such as the
+// Inspector's State tab
+type Inspectable interface {
+	// Name is the name the store was registered under
+	Name() string
+	// Snapshot returns the current value, for display as JSON
+	Snapshot() any
+	// SetJSON replaces the value with one decoded from JSON, notifying
+	// subscribers as Set does
+	SetJSON(data []byte) error
+}
+
+var inspect struct {
+	mu       sync.Mutex
+	stores   []Inspectable
+	watchers map[int]func(Inspectable)
+	before   map[int]func(Inspectable) // BeforeChange watchers
+	nextID   int
+}
+
+// register adds store to the registry, replacing a store of the same name,
+// such as one created again when a page is mounted again
+func register(store Inspectable) {
+	inspect.mu.Lock()
+	defer inspect.mu.Unlock()
+	for i, s := range inspect.stores {
+		if s.Name() == store.Name() {
+			inspect.stores[i] = store
+			return
+		}
+	}
+	inspect.stores = append(inspect.stores, store)
+}
+
+// Stores returns the stores registered with Named, in the order they were
+// first registered
+func Stores() []Inspectable {
+	inspect.mu.Lock()
+	defer inspect.mu.Unlock()
+	return append([]Inspectable(nil), inspect.stores...)
+}
+
+// OnChange calls fn each time a store registered with Named changes, after
+// the store's subscribers have run, so the DOM already shows the new value.
+// It returns a function that stops the calls.
+func OnChange(fn func(Inspectable)) func() {
+	return watch(&inspect.watchers, fn)
+}
+
+// BeforeChange calls fn each time a store registered with Named is about to
+// notify its subscribers, e.g. to set aside DOM changes made before them.
+// It returns a function that stops the calls.
+func BeforeChange(fn func(Inspectable)) func() {
+	return watch(&inspect.before, fn)
+}
+
+// watch adds fn to watchers
+func watch(watchers *map[int]func(Inspectable), fn func(Inspectable)) func() {
+	inspect.mu.Lock()
+	defer inspect.mu.Unlock()
+	if *watchers == nil {
+		*watchers = make(map[int]func(Inspectable))
+	}
+	id := inspect.nextID
+	inspect.nextID++
+	(*watchers)[id] = fn
+	return func() {
+		inspect.mu.Lock()
+		defer inspect.mu.Unlock()
+		delete(*watchers, id)
+	}
+}
+
+// changing notifies the BeforeChange watchers about store
+func changing(store Inspectable) {
+	notifyWatchers(store, &inspect.before)
+}
+
+// changed notifies the OnChange watchers about store
+func changed(store Inspectable) {
+	notifyWatchers(store, &inspect.watchers)
+}
+
+func notifyWatchers(store Inspectable, from *map[int]func(Inspectable)) {
+	if store == nil {
+		return
+	}
+	inspect.mu.Lock()
+	watchers := make([]func(Inspectable), 0, len(*from))
+	for _, fn := range *from {
+		watchers = append(watchers, fn)
+	}
+	inspect.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn(store)
+	}
+}
+
+// Named registers the store under name so debugging tools can list, show,
+// and edit it, and returns the store:
+//
+//	var cart = state.New(Cart{}).Named("cart")
+func (s *Store[T]) Named(name string) *Store[T] {
+	s.mu.Lock()
+	s.name = name
+	s.inspected = s
+	s.mu.Unlock()
+	register(s)
+	return s
+}
+
+// Name returns the name the store was registered under with Named
+func (s *Store[T]) Name() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.name
+}
+
+// Snapshot returns the current state; it implements Inspectable
+func (s *Store[T]) Snapshot() any {
+	return s.Get()
+}
+
+// SetJSON replaces the state with one decoded from JSON; it implements
+// Inspectable. Fields the JSON leaves out, and unexported fields, are
+// zero.
+func (s *Store[T]) SetJSON(data []byte) error {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.Set(v)
+	return nil
+}
+
+// Named registers the store under name so debugging tools can list, show,
+// and edit it, and returns the store
+func (s *AsyncStore[T]) Named(name string) *AsyncStore[T] {
+	s.Store.mu.Lock()
+	s.Store.name = name
+	s.Store.inspected = s
+	s.Store.mu.Unlock()
+	register(s)
+	return s
+}
+
+// Snapshot returns the current data, loading flags, and error message; it
+// implements Inspectable
+func (s *AsyncStore[T]) Snapshot() any {
+	st := s.Get()
+	snap := map[string]any{
+		"data":         st.Data,
+		"loading":      st.Loading,
+		"revalidating": st.Revalidating,
+		"error":        nil,
+	}
+	if st.Error != nil {
+		snap["error"] = st.Error.Error()
+	}
+	return snap
+}
+
+// SetJSON replaces the data with one decoded from JSON, as SetData does; it
+// implements Inspectable
+func (s *AsyncStore[T]) SetJSON(data []byte) error {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.SetData(v)
+	return nil
+}
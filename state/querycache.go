@@ -297,6 +297,33 @@ func (c *QueryCache) Prefetch(key string, fetcher func() (any, error), options .
 	go c.fetch(key, fetcher, opts)
 }
 
+// Warm fetches key ahead of time, e.g. from an idle.Queue task, unless it is
+// cached and fresh or already loading. Unlike Prefetch, it waits for the
+// fetch, so a task warming several queries runs them one at a time.
+func (c *QueryCache) Warm(key string, fetcher func() (any, error), options ...QueryOptions) {
+	opts := c.defaultOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	c.mu.Lock()
+	entry, exists := c.entries[key]
+	if !exists {
+		entry = &cacheEntry{
+			status:    QueryIdle,
+			staleTime: opts.StaleTime,
+			cacheTime: opts.CacheTime,
+		}
+		c.entries[key] = entry
+	}
+	skip := entry.status == QueryLoading || !c.isStale(entry)
+	c.mu.Unlock()
+
+	if !skip {
+		c.fetch(key, fetcher, opts)
+	}
+}
+
 // UseQuery is a convenience function for querying with the global cache
 func UseQuery(key string, fetcher func() (any, error), options ...QueryOptions) *QueryResult {
 	return GetQueryCache().Query(key, fetcher, options...)
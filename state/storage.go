@@ -3,8 +3,9 @@
 package state
 
 import (
-	"encoding/json"
 	"syscall/js"
+
+	"github.com/dougbarrett/gux/compat"
 )
 
 // Storage provides a typed interface to localStorage/sessionStorage
@@ -46,12 +47,12 @@ func (s *Storage) GetJSON(key string, target any) error {
 	if val == "" {
 		return nil
 	}
-	return json.Unmarshal([]byte(val), target)
+	return compat.Unmarshal([]byte(val), target)
 }
 
 // SetJSON marshals and stores a value as JSON
 func (s *Storage) SetJSON(key string, value any) error {
-	data, err := json.Marshal(value)
+	data, err := compat.Marshal(value)
 	if err != nil {
 		return err
 	}
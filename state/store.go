@@ -11,6 +11,8 @@ type Store[T any] struct {
 	subscribers []func(T)
 	nextID      int
 	subIDs      map[int]int // maps subscription ID to index
+	name        string      // set by Named
+	inspected   Inspectable // the store Named registered, which may embed this one
 }
 
 // New creates a new store with initial state
@@ -34,11 +36,14 @@ func (s *Store[T]) Set(newState T) {
 	s.state = newState
 	subs := make([]func(T), len(s.subscribers))
 	copy(subs, s.subscribers)
+	inspected := s.inspected
 	s.mu.Unlock()
 
+	changing(inspected)
 	for _, sub := range subs {
 		sub(newState)
 	}
+	changed(inspected)
 }
 
 // Update applies a mutation function to the state
@@ -48,11 +53,14 @@ func (s *Store[T]) Update(fn func(*T)) {
 	newState := s.state
 	subs := make([]func(T), len(s.subscribers))
 	copy(subs, s.subscribers)
+	inspected := s.inspected
 	s.mu.Unlock()
 
+	changing(inspected)
 	for _, sub := range subs {
 		sub(newState)
 	}
+	changed(inspected)
 }
 
 // Subscribe registers a callback for state changes, returns unsubscribe function
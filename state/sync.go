@@ -0,0 +1,126 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// Sync keeps store in step with the stores synced under the same channel
+// name in the app's other windows and tabs, such as popout windows, over a
+// BroadcastChannel. Changes made in any window are sent to the others as
+// JSON, so T must round-trip through encoding/json. A window that starts
+// syncing asks the others for their value, so a popout opens showing the
+// main window's state rather than its own initial one. One window answers:
+// the longest-syncing one, elected with a Web Lock, or where Web Locks
+// aren't available, every window.
+//
+// It returns a function that stops syncing. Where BroadcastChannel isn't
+// available, Sync does nothing.
+func Sync[T any](store *Store[T], channel string) func() {
+	ctor := js.Global().Get("BroadcastChannel")
+	if ctor.IsUndefined() {
+		return func() {}
+	}
+	bc := ctor.New("gux-sync:" + channel)
+	responder, resign := elect("gux-sync:" + channel)
+
+	// last is the JSON of the value most recently sent or received; a
+	// change that matches it came from another window, so isn't sent back
+	var last string
+	send := func(value T) {
+		data, err := json.Marshal(value)
+		if err != nil || string(data) == last {
+			return
+		}
+		last = string(data)
+		bc.Call("postMessage", map[string]any{"type": "state", "data": last})
+	}
+
+	onMessage := js.FuncOf(func(this js.Value, args []js.Value) any {
+		msg := args[0].Get("data")
+		if msg.Type() != js.TypeObject {
+			return nil
+		}
+		switch msg.Get("type").String() {
+		case "hello":
+			// A window has just started syncing: send it our value, unless
+			// another window answers
+			if !responder() {
+				return nil
+			}
+			last = ""
+			send(store.Get())
+		case "state":
+			data := msg.Get("data").String()
+			if data == last {
+				return nil
+			}
+			var value T
+			if err := json.Unmarshal([]byte(data), &value); err != nil {
+				return nil
+			}
+			last = data
+			store.Set(value)
+		}
+		return nil
+	})
+	bc.Set("onmessage", onMessage)
+
+	unsubscribe := store.Subscribe(send)
+	bc.Call("postMessage", map[string]any{"type": "hello"})
+
+	return func() {
+		unsubscribe()
+		resign()
+		bc.Call("close")
+		onMessage.Release()
+	}
+}
+
+// elect requests the Web Lock name, which the browser grants to one window
+// at a time and then to the next in line, and returns whether this window
+// holds it and a function giving it up. Without Web Locks every window
+// holds it.
+func elect(name string) (held func() bool, resign func()) {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Truthy() || !navigator.Get("locks").Truthy() {
+		return func() bool { return true }, func() {}
+	}
+
+	holding, resigned := false, false
+	var release js.Value
+	var hold, granted, settled js.Func
+	hold = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release = args[0]
+		return nil
+	})
+	granted = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if resigned {
+			return nil // granted as the abort came too late; release it at once
+		}
+		holding = true
+		// The lock is held until this promise resolves
+		return js.Global().Get("Promise").New(hold)
+	})
+	// The request settles once the lock is released, or rejects when it is
+	// aborted while waiting
+	settled = js.FuncOf(func(this js.Value, args []js.Value) any {
+		hold.Release()
+		granted.Release()
+		settled.Release()
+		return nil
+	})
+	abort := js.Global().Get("AbortController").New()
+	navigator.Get("locks").Call("request", name, map[string]any{"signal": abort.Get("signal")}, granted).
+		Call("then", settled, settled)
+
+	return func() bool { return holding }, func() {
+		holding, resigned = false, true
+		abort.Call("abort")
+		if release.Truthy() {
+			release.Invoke()
+		}
+	}
+}
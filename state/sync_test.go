@@ -0,0 +1,77 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// fakeLocks is a minimal navigator.locks granting each name to one
+// requester at a time, in order
+const fakeLocks = `(() => {
+	const queues = {};
+	const next = name => {
+		const q = queues[name];
+		if (!q.length || q.held) return;
+		const { cb, resolve } = q.shift();
+		q.held = true;
+		Promise.resolve(cb({ name })).then(v => { q.held = false; resolve(v); next(name); });
+	};
+	return {
+		request(name, opts, cb) {
+			return new Promise((resolve, reject) => {
+				const q = queues[name] = queues[name] || [];
+				const req = { cb, resolve };
+				opts.signal.addEventListener("abort", () => {
+					const i = q.indexOf(req);
+					if (i >= 0) { q.splice(i, 1); reject(new Error("AbortError")); }
+				});
+				q.push(req);
+				next(name);
+			});
+		},
+	};
+})()`
+
+func TestSyncOneWindowAnswersHello(t *testing.T) {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Truthy() {
+		navigator = js.Global().Get("Object").New()
+		js.Global().Set("navigator", navigator)
+	}
+	previous := navigator.Get("locks")
+	navigator.Set("locks", js.Global().Call("eval", fakeLocks))
+	defer navigator.Set("locks", previous)
+
+	// Count the replies to hellos on the channel
+	replies := 0
+	spy := js.Global().Get("BroadcastChannel").New("gux-sync:counter")
+	onMessage := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if args[0].Get("data").Get("type").String() == "state" {
+			replies++
+		}
+		return nil
+	})
+	spy.Set("onmessage", onMessage)
+	defer func() {
+		spy.Call("close")
+		onMessage.Release()
+	}()
+
+	first, second := New(1), New(1)
+	defer Sync(first, "counter")()
+	defer Sync(second, "counter")()
+	time.Sleep(50 * time.Millisecond)
+
+	replies = 0
+	third := New(0)
+	defer Sync(third, "counter")()
+	waitFor(t, func() bool { return third.Get() == 1 })
+	time.Sleep(50 * time.Millisecond)
+
+	if replies != 1 {
+		t.Errorf("%d windows answered the hello, want 1", replies)
+	}
+}
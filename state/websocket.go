@@ -4,6 +4,8 @@ package state
 
 import (
 	"encoding/json"
+	"errors"
+	"math/rand/v2"
 	"syscall/js"
 	"time"
 )
@@ -16,8 +18,28 @@ const (
 	WSOpen
 	WSClosing
 	WSClosed
+	WSReconnecting // closed unexpectedly, waiting to reconnect
 )
 
+// String returns the state's name, e.g. "reconnecting"
+func (s WebSocketState) String() string {
+	switch s {
+	case WSConnecting:
+		return "connecting"
+	case WSOpen:
+		return "open"
+	case WSClosing:
+		return "closing"
+	case WSReconnecting:
+		return "reconnecting"
+	}
+	return "closed"
+}
+
+// ErrWSBufferFull is returned by Send while disconnected when
+// WebSocketConfig.BufferSize messages are already waiting
+var ErrWSBufferFull = errors.New("websocket: send buffer full")
+
 // WSMessage represents a WebSocket message
 type WSMessage struct {
 	Type string          `json:"type"`
@@ -26,25 +48,49 @@ type WSMessage struct {
 
 // WebSocketConfig configures the WebSocket connection
 type WebSocketConfig struct {
-	URL               string
-	Protocols         []string
-	ReconnectInterval time.Duration
-	MaxReconnects     int
-	OnOpen            func()
-	OnClose           func(code int, reason string)
-	OnError           func(err string)
-	OnMessage         func(data []byte)
-	OnStateChange     func(state WebSocketState)
+	URL       string
+	Protocols []string
+
+	// ReconnectInterval is the delay before the first reconnect (default
+	// 3s). Each failed attempt doubles it, with some jitter so clients
+	// don't all return at once, up to MaxReconnectInterval.
+	ReconnectInterval    time.Duration
+	MaxReconnectInterval time.Duration // default 30s
+	MaxReconnects        int           // attempts in a row (default 5; negative retries forever)
+
+	// BufferSize is the number of messages Send keeps while disconnected,
+	// sent in order once the connection is open again (default 100;
+	// negative disables buffering, and Send drops the message)
+	BufferSize int
+
+	// PingInterval sends PingMessage after this long without receiving
+	// anything (default 0, off). If nothing arrives within PongTimeout
+	// (default 10s), the connection is treated as lost and reconnected.
+	// The server must answer the ping with any message.
+	PingInterval time.Duration
+	PongTimeout  time.Duration
+	PingMessage  string // default {"type":"ping"}
+
+	OnOpen        func()
+	OnClose       func(code int, reason string)
+	OnError       func(err string)
+	OnMessage     func(data []byte)
+	OnStateChange func(state WebSocketState)
 }
 
 // WebSocket wraps the JavaScript WebSocket API
 type WebSocket struct {
-	config       WebSocketConfig
-	ws           js.Value
-	state        WebSocketState
-	reconnects   int
-	handlers     map[string][]func([]byte)
-	shouldClose  bool
+	config      WebSocketConfig
+	ws          js.Value
+	state       WebSocketState
+	reconnects  int
+	handlers    map[string][]func([]byte)
+	shouldClose bool
+	outbox      [][]byte    // sent while disconnected
+	retry       *time.Timer // the pending reconnect
+	lastMessage time.Time   // for keep-alive pings
+	pingTimer   *time.Timer
+	onBuffered  func(n int) // called when the number of buffered messages changes
 }
 
 // NewWebSocket creates a new WebSocket connection
@@ -52,9 +98,21 @@ func NewWebSocket(config WebSocketConfig) *WebSocket {
 	if config.ReconnectInterval == 0 {
 		config.ReconnectInterval = 3 * time.Second
 	}
+	if config.MaxReconnectInterval == 0 {
+		config.MaxReconnectInterval = 30 * time.Second
+	}
 	if config.MaxReconnects == 0 {
 		config.MaxReconnects = 5
 	}
+	if config.BufferSize == 0 {
+		config.BufferSize = 100
+	}
+	if config.PongTimeout == 0 {
+		config.PongTimeout = 10 * time.Second
+	}
+	if config.PingMessage == "" {
+		config.PingMessage = `{"type":"ping"}`
+	}
 
 	ws := &WebSocket{
 		config:   config,
@@ -68,6 +126,7 @@ func NewWebSocket(config WebSocketConfig) *WebSocket {
 // Connect establishes the WebSocket connection
 func (w *WebSocket) Connect() {
 	w.shouldClose = false
+	w.reconnects = 0
 	w.connect()
 }
 
@@ -75,6 +134,10 @@ func (w *WebSocket) connect() {
 	if w.shouldClose {
 		return
 	}
+	if w.retry != nil {
+		w.retry.Stop()
+		w.retry = nil
+	}
 
 	w.setState(WSConnecting)
 
@@ -91,49 +154,78 @@ func (w *WebSocket) connect() {
 
 	w.ws = ws
 
+	// The callbacks belong to this socket; they're released when it closes,
+	// and ignore it once Reconnect has replaced it
+	var onOpen, onClose, onError, onMessage js.Func
+	current := func() bool { return ws.Equal(w.ws) }
+
 	// onopen
-	ws.Set("onopen", js.FuncOf(func(this js.Value, args []js.Value) any {
+	onOpen = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if !current() {
+			return nil
+		}
 		w.setState(WSOpen)
 		w.reconnects = 0
+		w.lastMessage = time.Now()
+		w.keepAlive(ws)
 		if w.config.OnOpen != nil {
 			w.config.OnOpen()
 		}
+		w.flush()
 		return nil
-	}))
+	})
 
 	// onclose
-	ws.Set("onclose", js.FuncOf(func(this js.Value, args []js.Value) any {
+	onClose = js.FuncOf(func(this js.Value, args []js.Value) any {
+		onOpen.Release()
+		onClose.Release()
+		onError.Release()
+		onMessage.Release()
+		if !current() {
+			return nil
+		}
+		if w.pingTimer != nil {
+			w.pingTimer.Stop()
+		}
+
 		event := args[0]
 		code := event.Get("code").Int()
 		reason := event.Get("reason").String()
 
-		w.setState(WSClosed)
+		// Auto reconnect if not intentionally closed
+		retry := !w.shouldClose && (w.config.MaxReconnects < 0 || w.reconnects < w.config.MaxReconnects)
+		if retry {
+			w.setState(WSReconnecting)
+		} else {
+			w.setState(WSClosed)
+		}
 
 		if w.config.OnClose != nil {
 			w.config.OnClose(code, reason)
 		}
 
-		// Auto reconnect if not intentionally closed
-		if !w.shouldClose && w.reconnects < w.config.MaxReconnects {
+		if retry {
+			delay := w.backoff()
 			w.reconnects++
-			time.AfterFunc(w.config.ReconnectInterval, func() {
-				w.connect()
-			})
+			w.retry = time.AfterFunc(delay, w.connect)
 		}
-
 		return nil
-	}))
+	})
 
 	// onerror
-	ws.Set("onerror", js.FuncOf(func(this js.Value, args []js.Value) any {
-		if w.config.OnError != nil {
+	onError = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if current() && w.config.OnError != nil {
 			w.config.OnError("WebSocket error")
 		}
 		return nil
-	}))
+	})
 
 	// onmessage
-	ws.Set("onmessage", js.FuncOf(func(this js.Value, args []js.Value) any {
+	onMessage = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if !current() {
+			return nil
+		}
+		w.lastMessage = time.Now()
 		event := args[0]
 		data := event.Get("data").String()
 
@@ -152,7 +244,53 @@ func (w *WebSocket) connect() {
 		}
 
 		return nil
-	}))
+	})
+
+	ws.Set("onopen", onOpen)
+	ws.Set("onclose", onClose)
+	ws.Set("onerror", onError)
+	ws.Set("onmessage", onMessage)
+}
+
+// backoff returns the delay before the next reconnect attempt: the
+// interval doubled for each failed attempt, capped, with ±20% jitter
+func (w *WebSocket) backoff() time.Duration {
+	delay := w.config.ReconnectInterval
+	for i := 0; i < w.reconnects && delay < w.config.MaxReconnectInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, w.config.MaxReconnectInterval)
+	return time.Duration(float64(delay) * (0.8 + 0.4*rand.Float64()))
+}
+
+// keepAlive pings ws after PingInterval without messages, and closes it
+// when the ping goes unanswered for PongTimeout, which reconnects
+func (w *WebSocket) keepAlive(ws js.Value) {
+	if w.config.PingInterval <= 0 {
+		return
+	}
+	wait := w.config.PingInterval - time.Since(w.lastMessage)
+	w.pingTimer = time.AfterFunc(max(wait, 0), func() {
+		if !ws.Equal(w.ws) || w.state != WSOpen {
+			return
+		}
+		if time.Since(w.lastMessage) < w.config.PingInterval {
+			w.keepAlive(ws) // something arrived meanwhile
+			return
+		}
+		sent := time.Now()
+		ws.Call("send", w.config.PingMessage)
+		w.pingTimer = time.AfterFunc(w.config.PongTimeout, func() {
+			if !ws.Equal(w.ws) || w.state != WSOpen {
+				return
+			}
+			if w.lastMessage.Before(sent) {
+				ws.Call("close", 4000, "keep-alive timeout")
+				return
+			}
+			w.keepAlive(ws)
+		})
+	})
 }
 
 func (w *WebSocket) setState(state WebSocketState) {
@@ -162,33 +300,67 @@ func (w *WebSocket) setState(state WebSocketState) {
 	}
 }
 
-// Close closes the WebSocket connection
+// Close closes the WebSocket connection. Messages waiting to be sent are
+// dropped.
 func (w *WebSocket) Close() {
-	w.shouldClose = true
-	if !w.ws.IsUndefined() && !w.ws.IsNull() {
-		w.setState(WSClosing)
-		w.ws.Call("close")
-	}
+	w.CloseWithCode(1000, "")
 }
 
 // CloseWithCode closes with a specific code and reason
 func (w *WebSocket) CloseWithCode(code int, reason string) {
 	w.shouldClose = true
-	if !w.ws.IsUndefined() && !w.ws.IsNull() {
+	w.setOutbox(nil)
+	if w.retry != nil {
+		w.retry.Stop()
+		w.retry = nil
+		w.setState(WSClosed) // no socket is open to report the close
+		return
+	}
+	if !w.ws.IsUndefined() && !w.ws.IsNull() && w.state != WSClosed {
 		w.setState(WSClosing)
 		w.ws.Call("close", code, reason)
 	}
 }
 
-// Send sends raw data
+// Send sends raw data. While the connection is down it is kept and sent
+// once the connection is open again, up to BufferSize messages.
 func (w *WebSocket) Send(data []byte) error {
 	if w.state != WSOpen {
-		return nil // Silently fail if not connected
+		if w.config.BufferSize < 0 || w.shouldClose {
+			return nil // Silently fail if not connected
+		}
+		if len(w.outbox) >= w.config.BufferSize {
+			return ErrWSBufferFull
+		}
+		w.setOutbox(append(w.outbox, data))
+		return nil
 	}
 	w.ws.Call("send", string(data))
 	return nil
 }
 
+// flush sends the messages kept while disconnected
+func (w *WebSocket) flush() {
+	for len(w.outbox) > 0 && w.state == WSOpen {
+		data := w.outbox[0]
+		w.setOutbox(w.outbox[1:])
+		w.ws.Call("send", string(data))
+	}
+}
+
+func (w *WebSocket) setOutbox(outbox [][]byte) {
+	changed := len(outbox) != len(w.outbox)
+	w.outbox = outbox
+	if changed && w.onBuffered != nil {
+		w.onBuffered(len(outbox))
+	}
+}
+
+// Buffered returns the number of messages waiting to be sent
+func (w *WebSocket) Buffered() int {
+	return len(w.outbox)
+}
+
 // SendText sends a text message
 func (w *WebSocket) SendText(text string) error {
 	return w.Send([]byte(text))
@@ -236,17 +408,25 @@ func (w *WebSocket) IsConnected() bool {
 	return w.state == WSOpen
 }
 
-// Reconnect forces a reconnection
+// Reconnect forces a reconnection, keeping the messages waiting to be sent
 func (w *WebSocket) Reconnect() {
-	w.Close()
+	old := w.ws
+	w.shouldClose = false
 	w.reconnects = 0
-	w.Connect()
+	w.connect()
+	if !old.IsUndefined() && !old.IsNull() {
+		old.Call("close")
+	}
 }
 
 // WSStoreState represents the WebSocket store state
 type WSStoreState struct {
+	State        WebSocketState
 	Connected    bool
 	Connecting   bool
+	Reconnecting bool // waiting to reconnect after the connection dropped
+	Attempt      int  // reconnect attempts since the connection was last open
+	Queued       int  // messages waiting to be sent once connected
 	LastMessage  string
 	MessageCount int
 	Error        string
@@ -262,6 +442,7 @@ type WebSocketStore struct {
 // NewWebSocketStore creates a WebSocket with integrated state management
 func NewWebSocketStore(config WebSocketConfig) *WebSocketStore {
 	store := New(WSStoreState{
+		State:        WSClosed,
 		Connected:    false,
 		Connecting:   false,
 		LastMessage:  "",
@@ -280,6 +461,7 @@ func NewWebSocketStore(config WebSocketConfig) *WebSocketStore {
 		store.Update(func(s *WSStoreState) {
 			s.Connected = true
 			s.Connecting = false
+			s.Attempt = 0
 			s.Error = ""
 		})
 		if originalOnOpen != nil {
@@ -328,21 +510,36 @@ func NewWebSocketStore(config WebSocketConfig) *WebSocketStore {
 		}
 	}
 
+	originalOnStateChange := config.OnStateChange
 	config.OnStateChange = func(state WebSocketState) {
 		store.Update(func(s *WSStoreState) {
+			s.State = state
+			s.Connected = state == WSOpen
 			s.Connecting = state == WSConnecting
+			s.Reconnecting = state == WSReconnecting
+			if state == WSReconnecting {
+				s.Attempt++
+			}
+			if state == WSClosed {
+				s.Attempt = 0
+			}
 		})
+		if originalOnStateChange != nil {
+			originalOnStateChange(state)
+		}
 	}
 
 	wss.ws = NewWebSocket(config)
+	wss.ws.onBuffered = func(n int) {
+		store.Update(func(s *WSStoreState) {
+			s.Queued = n
+		})
+	}
 	return wss
 }
 
 // Connect connects the WebSocket
 func (wss *WebSocketStore) Connect() {
-	wss.store.Update(func(s *WSStoreState) {
-		s.Connecting = true
-	})
 	wss.ws.Connect()
 }
 
@@ -351,7 +548,7 @@ func (wss *WebSocketStore) Close() {
 	wss.ws.Close()
 }
 
-// Send sends data through the WebSocket
+// Send sends data through the WebSocket, or queues it until reconnected
 func (wss *WebSocketStore) Send(data []byte) error {
 	return wss.ws.Send(data)
 }
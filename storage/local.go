@@ -1,25 +1,31 @@
 //go:build js && wasm
 
+// Package storage wraps the browser's localStorage and sessionStorage.
+// Local and Session read and write raw strings; Typed stores JSON values
+// with expiry, per-app namespaces, and change subscriptions.
 package storage
 
-import (
-	"encoding/json"
-	"syscall/js"
-)
+import "encoding/json"
 
 // Local provides access to browser localStorage
 var Local = &localStorage{}
 
 type localStorage struct{}
 
-// Set stores a string value
+// Set stores a string value. It does nothing if storage is full or unavailable.
 func (l *localStorage) Set(key, value string) {
-	js.Global().Get("localStorage").Call("setItem", key, value)
+	if st, ok := storageArea("localStorage"); ok {
+		setItem(st, key, value)
+	}
 }
 
 // Get retrieves a string value
 func (l *localStorage) Get(key string) string {
-	val := js.Global().Get("localStorage").Call("getItem", key)
+	st, ok := storageArea("localStorage")
+	if !ok {
+		return ""
+	}
+	val := st.Call("getItem", key)
 	if val.IsNull() || val.IsUndefined() {
 		return ""
 	}
@@ -28,12 +34,16 @@ func (l *localStorage) Get(key string) string {
 
 // Remove deletes a key
 func (l *localStorage) Remove(key string) {
-	js.Global().Get("localStorage").Call("removeItem", key)
+	if st, ok := storageArea("localStorage"); ok {
+		st.Call("removeItem", key)
+	}
 }
 
 // Clear removes all keys
 func (l *localStorage) Clear() {
-	js.Global().Get("localStorage").Call("clear")
+	if st, ok := storageArea("localStorage"); ok {
+		st.Call("clear")
+	}
 }
 
 // SetJSON stores a JSON-serializable value
@@ -60,14 +70,20 @@ var Session = &sessionStorage{}
 
 type sessionStorage struct{}
 
-// Set stores a string value
+// Set stores a string value. It does nothing if storage is full or unavailable.
 func (s *sessionStorage) Set(key, value string) {
-	js.Global().Get("sessionStorage").Call("setItem", key, value)
+	if st, ok := storageArea("sessionStorage"); ok {
+		setItem(st, key, value)
+	}
 }
 
 // Get retrieves a string value
 func (s *sessionStorage) Get(key string) string {
-	val := js.Global().Get("sessionStorage").Call("getItem", key)
+	st, ok := storageArea("sessionStorage")
+	if !ok {
+		return ""
+	}
+	val := st.Call("getItem", key)
 	if val.IsNull() || val.IsUndefined() {
 		return ""
 	}
@@ -76,12 +92,16 @@ func (s *sessionStorage) Get(key string) string {
 
 // Remove deletes a key
 func (s *sessionStorage) Remove(key string) {
-	js.Global().Get("sessionStorage").Call("removeItem", key)
+	if st, ok := storageArea("sessionStorage"); ok {
+		st.Call("removeItem", key)
+	}
 }
 
 // Clear removes all keys
 func (s *sessionStorage) Clear() {
-	js.Global().Get("sessionStorage").Call("clear")
+	if st, ok := storageArea("sessionStorage"); ok {
+		st.Call("clear")
+	}
 }
 
 // SetJSON stores a JSON-serializable value
@@ -0,0 +1,332 @@
+//go:build js && wasm
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/compat"
+)
+
+var (
+	// ErrUnavailable is returned when the browser gives no access to
+	// storage, e.g. when it's disabled or blocked in an iframe
+	ErrUnavailable = errors.New("storage: not available")
+
+	// ErrQuotaExceeded is returned when a value doesn't fit in storage,
+	// even after evicting every other Typed value in its namespace
+	ErrQuotaExceeded = errors.New("storage: quota exceeded")
+)
+
+// touchInterval is how often reading a value updates its last use, which
+// decides what's evicted first
+const touchInterval = time.Minute
+
+// namespace prefixes the keys of Typed values
+var namespace string
+
+// SetNamespace prefixes the keys of Typed values with ns and ":", so apps
+// served from the same origin don't overwrite or evict each other's
+// values. Call it at startup, before creating any Typed values.
+func SetNamespace(ns string) {
+	namespace = ns
+}
+
+// Options configures a Typed value
+type Options struct {
+	Session   bool          // Store in sessionStorage instead of localStorage
+	TTL       time.Duration // Expire the value this long after it's set (default never)
+	Namespace string        // Key prefix, instead of the one set with SetNamespace
+}
+
+// Value is a JSON-encoded value of type T in localStorage or
+// sessionStorage, created with Typed
+type Value[T any] struct {
+	key    string // including the namespace
+	prefix string // the namespace prefix
+	area   string // "localStorage" or "sessionStorage"
+	ttl    time.Duration
+}
+
+// envelope is how a Value is stored
+type envelope struct {
+	Value   json.RawMessage `json:"v"`
+	Expires int64           `json:"exp,omitempty"` // Unix ms
+	Used    int64           `json:"used"`          // Unix ms of the last read or write
+}
+
+// Typed returns the value stored under key, in the app's namespace:
+//
+//	recent := storage.Typed[[]string]("recent-searches", storage.Options{TTL: 24 * time.Hour})
+//	searches, _ := recent.Get()
+//	recent.Set(append(searches, query))
+//
+// When storage is full, Set evicts the least recently used Typed values in
+// the same namespace, expired ones first, until the new value fits.
+func Typed[T any](key string, options ...Options) *Value[T] {
+	var opts Options
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	ns := opts.Namespace
+	if ns == "" {
+		ns = namespace
+	}
+	v := &Value[T]{area: "localStorage", ttl: opts.TTL}
+	if opts.Session {
+		v.area = "sessionStorage"
+	}
+	if ns != "" {
+		v.prefix = ns + ":"
+	}
+	v.key = v.prefix + key
+	return v
+}
+
+// Key returns the storage key, including the namespace
+func (v *Value[T]) Key() string {
+	return v.key
+}
+
+// Get returns the stored value, or false if there is none, it has expired,
+// or it can't be decoded as T. A value written as plain JSON, e.g. with
+// Local.SetJSON before moving to Typed, is read as one that never expires.
+func (v *Value[T]) Get() (T, bool) {
+	var value T
+	s, ok := storageArea(v.area)
+	if !ok {
+		return value, false
+	}
+	item := s.Call("getItem", v.key)
+	if item.Type() != js.TypeString {
+		return value, false
+	}
+	raw := []byte(item.String())
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil || env.Used == 0 {
+		if err := compat.Unmarshal(raw, &value); err != nil {
+			return value, false
+		}
+		return value, true
+	}
+
+	now := time.Now().UnixMilli()
+	if env.Expires > 0 && now >= env.Expires {
+		s.Call("removeItem", v.key)
+		return value, false
+	}
+	if err := compat.Unmarshal(env.Value, &value); err != nil {
+		return value, false
+	}
+	if now-env.Used >= touchInterval.Milliseconds() {
+		env.Used = now
+		if data, err := json.Marshal(env); err == nil {
+			setItem(s, v.key, string(data))
+		}
+	}
+	return value, true
+}
+
+// GetOr returns the stored value, or fallback if there is none
+func (v *Value[T]) GetOr(fallback T) T {
+	if value, ok := v.Get(); ok {
+		return value
+	}
+	return fallback
+}
+
+// Set stores value, restarting its TTL, and notifies subscribers. It
+// returns ErrQuotaExceeded if value doesn't fit even after eviction.
+func (v *Value[T]) Set(value T) error {
+	s, ok := storageArea(v.area)
+	if !ok {
+		return ErrUnavailable
+	}
+	raw, err := compat.Marshal(value)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	env := envelope{Value: raw, Used: now}
+	if v.ttl > 0 {
+		env.Expires = now + v.ttl.Milliseconds()
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	for {
+		err := setItem(s, v.key, string(data))
+		if err == nil {
+			notify(v.area, v.key)
+			return nil
+		}
+		if !errors.Is(err, ErrQuotaExceeded) || !v.evict(s) {
+			return err
+		}
+	}
+}
+
+// Remove deletes the value and notifies subscribers
+func (v *Value[T]) Remove() {
+	if s, ok := storageArea(v.area); ok {
+		s.Call("removeItem", v.key)
+		notify(v.area, v.key)
+	}
+}
+
+// Subscribe calls fn with the new value when it's set or removed, in this
+// tab or, for localStorage, in another one. ok is false after removal.
+// Expiry isn't notified. It returns a function that unsubscribes.
+func (v *Value[T]) Subscribe(fn func(value T, ok bool)) func() {
+	f := func() {
+		value, ok := v.Get()
+		fn(value, ok)
+	}
+	return subscribe(v.area, v.key, &f)
+}
+
+// evict makes room by removing the other Typed values in v's namespace
+// that have expired or, if none have, the least recently used one. It
+// returns false if there was nothing to remove.
+func (v *Value[T]) evict(s js.Value) bool {
+	now := time.Now().UnixMilli()
+	var expired []string
+	lru, lruUsed := "", int64(0)
+	for i := s.Get("length").Int() - 1; i >= 0; i-- {
+		key := s.Call("key", i)
+		if key.Type() != js.TypeString {
+			continue
+		}
+		k := key.String()
+		if k == v.key || !strings.HasPrefix(k, v.prefix) {
+			continue
+		}
+		var env envelope
+		item := s.Call("getItem", k)
+		if item.Type() != js.TypeString || json.Unmarshal([]byte(item.String()), &env) != nil || env.Used == 0 {
+			continue // not a Typed value
+		}
+		if env.Expires > 0 && now >= env.Expires {
+			expired = append(expired, k)
+		} else if lru == "" || env.Used < lruUsed {
+			lru, lruUsed = k, env.Used
+		}
+	}
+	if len(expired) == 0 && lru == "" {
+		return false
+	}
+	if len(expired) == 0 {
+		expired = []string{lru}
+	}
+	for _, k := range expired {
+		s.Call("removeItem", k)
+		notify(v.area, k)
+	}
+	return true
+}
+
+// storageArea returns window.localStorage or window.sessionStorage, which
+// can be missing, or throw when accessed if storage is blocked
+func storageArea(name string) (s js.Value, ok bool) {
+	defer func() {
+		if recover() != nil {
+			s, ok = js.Undefined(), false
+		}
+	}()
+	s = js.Global().Get(name)
+	return s, s.Truthy()
+}
+
+// setItem stores data under key, turning the exception thrown when storage
+// is full into ErrQuotaExceeded
+func setItem(s js.Value, key, data string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, ok := r.(js.Error)
+			if !ok {
+				panic(r)
+			}
+			switch jsErr.Get("name").String() {
+			case "QuotaExceededError", "NS_ERROR_DOM_QUOTA_REACHED":
+				err = ErrQuotaExceeded
+			default:
+				err = jsErr
+			}
+		}
+	}()
+	s.Call("setItem", key, data)
+	return nil
+}
+
+var (
+	// subscribers are the Subscribe functions, by storage area and key
+	subscribers   = make(map[string][]*func())
+	storageEvents js.Func
+)
+
+func subscribe(area, key string, fn *func()) func() {
+	if storageEvents.IsUndefined() {
+		// Follow changes made in other tabs
+		storageEvents = js.FuncOf(func(this js.Value, args []js.Value) any {
+			e := args[0]
+			key := e.Get("key")
+			if key.IsNull() {
+				// The other tab cleared storage
+				for id := range subscribers {
+					if key, ok := strings.CutPrefix(id, "localStorage:"); ok {
+						notify("localStorage", key)
+					}
+				}
+				return nil
+			}
+			if changed(e.Get("oldValue"), e.Get("newValue")) {
+				notify("localStorage", key.String())
+			}
+			return nil
+		})
+		js.Global().Call("addEventListener", "storage", storageEvents)
+	}
+
+	id := area + ":" + key
+	subscribers[id] = append(subscribers[id], fn)
+	return func() {
+		subs := subscribers[id]
+		for i, f := range subs {
+			if f == fn {
+				subscribers[id] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+		if len(subscribers[id]) == 0 {
+			delete(subscribers, id)
+		}
+	}
+}
+
+func notify(area, key string) {
+	subs := subscribers[area+":"+key]
+	for _, fn := range append([]*func(){}, subs...) {
+		(*fn)()
+	}
+}
+
+// changed reports whether a storage event changed the value itself, rather
+// than only when it was last used
+func changed(oldValue, newValue js.Value) bool {
+	if oldValue.Type() != js.TypeString || newValue.Type() != js.TypeString {
+		return true
+	}
+	var o, n envelope
+	if json.Unmarshal([]byte(oldValue.String()), &o) != nil || json.Unmarshal([]byte(newValue.String()), &n) != nil {
+		return oldValue.String() != newValue.String()
+	}
+	return !bytes.Equal(o.Value, n.Value) || o.Expires != n.Expires
+}
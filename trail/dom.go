@@ -0,0 +1,82 @@
+//go:build js && wasm
+
+package trail
+
+import (
+	"syscall/js"
+
+	"github.com/dougbarrett/gux/owner"
+)
+
+// trackAttr marks elements whose clicks are recorded
+const trackAttr = "data-trail"
+
+// Track registers el so clicks on it (or inside it) are recorded under name.
+// Only the name is recorded, never the element's text or input values.
+func Track(el js.Value, name string) js.Value {
+	el.Call("setAttribute", trackAttr, name)
+	return el
+}
+
+// listen installs the page listeners and returns a function that removes them
+func listen(r *recorder) func() {
+	document := js.Global().Get("document")
+	window := js.Global()
+
+	clickHandler := js.FuncOf(func(this js.Value, args []js.Value) any {
+		target := args[0].Get("target")
+		if !target.Truthy() || target.Get("closest").IsUndefined() {
+			return nil
+		}
+		if el := target.Call("closest", "["+trackAttr+"]"); el.Truthy() {
+			name := el.Call("getAttribute", trackAttr).String()
+			if info, ok := owner.Of(el); ok && info.Team != "" {
+				Add(KindClick, name, map[string]string{"owner": info.Team})
+			} else {
+				Click(name)
+			}
+		}
+		return nil
+	})
+
+	errorHandler := js.FuncOf(func(this js.Value, args []js.Value) any {
+		event := args[0]
+		stack := ""
+		if err := event.Get("error"); err.Truthy() && err.Get("stack").Truthy() {
+			stack = err.Get("stack").String()
+		}
+		report(event.Get("message").String(), stack, js.Undefined())
+		return nil
+	})
+
+	rejectionHandler := js.FuncOf(func(this js.Value, args []js.Value) any {
+		reason := args[0].Get("reason")
+		message, stack := "Unhandled promise rejection", ""
+		if reason.Truthy() {
+			if reason.Get("message").Truthy() {
+				message = reason.Get("message").String()
+			} else {
+				message = reason.Call("toString").String()
+			}
+			if reason.Get("stack").Truthy() {
+				stack = reason.Get("stack").String()
+			}
+		}
+		report(message, stack, js.Undefined())
+		return nil
+	})
+
+	// Capture phase so clicks are recorded even if a handler stops propagation
+	document.Call("addEventListener", "click", clickHandler, true)
+	window.Call("addEventListener", "error", errorHandler)
+	window.Call("addEventListener", "unhandledrejection", rejectionHandler)
+
+	return func() {
+		document.Call("removeEventListener", "click", clickHandler, true)
+		window.Call("removeEventListener", "error", errorHandler)
+		window.Call("removeEventListener", "unhandledrejection", rejectionHandler)
+		clickHandler.Release()
+		errorHandler.Release()
+		rejectionHandler.Release()
+	}
+}
@@ -0,0 +1,258 @@
+//go:build js && wasm
+
+// Package trail records a lightweight breadcrumb trail of the user's session
+// (navigations, clicks on tracked elements, and API calls with their status)
+// in a fixed-size in-memory ring buffer. When an error is reported the trail
+// is attached to it, so support can see what led up to the failure.
+//
+//	trail.Enable(trail.Options{
+//		OnError: func(r trail.Report) { sendToErrorService(r) },
+//	})
+//
+// Recording is opt-in: until Enable is called every hook is a no-op. Key
+// presses and input values are never recorded, and query strings are
+// stripped from URLs unless Options.KeepQuery is set.
+package trail
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dougbarrett/gux/owner"
+)
+
+// DefaultCapacity is the number of breadcrumbs kept when Options.Capacity is 0
+const DefaultCapacity = 50
+
+// Kind identifies what a breadcrumb records
+type Kind string
+
+const (
+	KindNavigation Kind = "navigation"
+	KindClick      Kind = "click"
+	KindAPI        Kind = "api"
+	KindError      Kind = "error"
+	KindCustom     Kind = "custom"
+)
+
+// Crumb is a single breadcrumb
+type Crumb struct {
+	Time    time.Time         `json:"time"`
+	Kind    Kind              `json:"kind"`
+	Message string            `json:"message"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+// Report is an error together with the breadcrumbs recorded before it
+type Report struct {
+	Message     string    `json:"message"`
+	Stack       string    `json:"stack,omitempty"`
+	URL         string    `json:"url"`
+	UserAgent   string    `json:"userAgent"`
+	Time        time.Time `json:"time"`
+	Breadcrumbs []Crumb   `json:"breadcrumbs"`
+
+	// Owner is the owner of the element the error came from, or of the
+	// current route (see the owner package)
+	Owner *owner.Info `json:"owner,omitempty"`
+}
+
+// Options configures the recorder
+type Options struct {
+	Capacity  int          // breadcrumbs kept, oldest dropped first (default 50)
+	KeepQuery bool         // keep query strings in recorded URLs
+	OnError   func(Report) // receives uncaught errors and CaptureError reports
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	opts    Options
+	crumbs  []Crumb
+	start   int
+	count   int
+	release func()
+}
+
+var active *recorder
+
+// Enable starts recording. Calling it again replaces the options and clears
+// the trail.
+func Enable(opts Options) {
+	Disable()
+	if opts.Capacity <= 0 {
+		opts.Capacity = DefaultCapacity
+	}
+	r := &recorder{opts: opts, crumbs: make([]Crumb, opts.Capacity)}
+	r.release = listen(r)
+	active = r
+}
+
+// Disable stops recording, removes the page listeners, and drops the trail
+func Disable() {
+	if active == nil {
+		return
+	}
+	active.release()
+	active = nil
+}
+
+// Enabled reports whether breadcrumbs are being recorded
+func Enabled() bool {
+	return active != nil
+}
+
+// Add records a breadcrumb. It is a no-op while recording is disabled.
+func Add(kind Kind, message string, data map[string]string) {
+	r := active
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := Crumb{Time: time.Now(), Kind: kind, Message: message, Data: data}
+	capacity := len(r.crumbs)
+	if r.count < capacity {
+		r.crumbs[(r.start+r.count)%capacity] = c
+		r.count++
+		return
+	}
+	r.crumbs[r.start] = c
+	r.start = (r.start + 1) % capacity
+}
+
+// Navigation records a route change
+func Navigation(path string) {
+	if active == nil {
+		return
+	}
+	path = active.cleanURL(path)
+	Add(KindNavigation, path, map[string]string{"path": path})
+}
+
+// Click records a click on a tracked element
+func Click(name string) {
+	Add(KindClick, name, nil)
+}
+
+// API records a finished API call. Use status 0 for network failures.
+func API(method, url string, status int, duration time.Duration) {
+	if active == nil {
+		return
+	}
+	if method == "" {
+		method = "GET"
+	}
+	url = active.cleanURL(url)
+	result := "failed"
+	if status > 0 {
+		result = fmt.Sprint(status)
+	}
+	Add(KindAPI, fmt.Sprintf("%s %s → %s", method, url, result), map[string]string{
+		"method":   method,
+		"url":      url,
+		"status":   fmt.Sprint(status),
+		"duration": duration.Round(time.Millisecond).String(),
+	})
+}
+
+// Snapshot returns the recorded breadcrumbs, oldest first
+func Snapshot() []Crumb {
+	r := active
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Crumb, r.count)
+	for i := range out {
+		out[i] = r.crumbs[(r.start+i)%len(r.crumbs)]
+	}
+	return out
+}
+
+// Clear drops the recorded breadcrumbs, e.g. after logout
+func Clear() {
+	r := active
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start, r.count = 0, 0
+}
+
+// NewReport builds a report for err with the current trail attached
+func NewReport(err error) Report {
+	return newReport(err.Error(), "", js.Undefined())
+}
+
+// CaptureError sends a report for err to Options.OnError. Use it for errors
+// the app handles itself, such as failed saves shown in a toast.
+func CaptureError(err error) {
+	if err == nil || active == nil {
+		return
+	}
+	report(err.Error(), "", js.Undefined())
+}
+
+// CaptureErrorIn is CaptureError for an error raised by the component
+// rendered in el. The report names el's owner instead of the route's.
+func CaptureErrorIn(el js.Value, err error) {
+	if err == nil || active == nil {
+		return
+	}
+	report(err.Error(), "", el)
+}
+
+func report(message, stack string, el js.Value) {
+	r := active
+	if r == nil {
+		return
+	}
+	rep := newReport(message, stack, el)
+	var data map[string]string
+	if rep.Owner != nil && rep.Owner.Team != "" {
+		data = map[string]string{"owner": rep.Owner.Team}
+	}
+	Add(KindError, message, data)
+	if r.opts.OnError != nil {
+		r.opts.OnError(rep)
+	}
+}
+
+func newReport(message, stack string, el js.Value) Report {
+	global := js.Global()
+	rep := Report{
+		Message:     message,
+		Stack:       stack,
+		URL:         active.cleanURL(global.Get("location").Get("href").String()),
+		UserAgent:   global.Get("navigator").Get("userAgent").String(),
+		Time:        time.Now(),
+		Breadcrumbs: Snapshot(),
+	}
+	info, ok := owner.Of(el)
+	if !ok {
+		info, ok = owner.Current()
+	}
+	if ok {
+		rep.Owner = &info
+	}
+	return rep
+}
+
+// cleanURL drops query strings and fragments, which often carry tokens.
+// Without a recorder, as for NewReport before Enable, they're dropped.
+func (r *recorder) cleanURL(url string) string {
+	if r != nil && r.opts.KeepQuery {
+		return url
+	}
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		return url[:i]
+	}
+	return url
+}
@@ -0,0 +1,583 @@
+// Package types holds value types shared by clients and servers that the
+// standard library lacks. It has no build constraints.
+//
+// Money is an amount of a currency kept as a whole number of minor units
+// (cents for USD, yen for JPY), so prices and totals add up exactly where
+// float64 would drift:
+//
+//	price := types.MustParse("19.99", "USD")
+//	total := price.Mul(3).Add(types.MustParse("4.50", "USD"))
+//	total.String()  // "64.47 USD"
+//	total.Format()  // "$64.47", or "64,47 $" in German
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// DefaultCurrency is used when an amount arrives without a currency, e.g.
+// from a plain JSON number or a database column
+var DefaultCurrency = "USD"
+
+// Money is an amount of one currency. The zero Money has no currency: it
+// adds to an amount of any currency, formats as zero in DefaultCurrency, and
+// travels as JSON null.
+//
+// Combining amounts of different currencies is a programming error and
+// panics; check SameCurrency first when the currencies come from input.
+type Money struct {
+	units    int64  // minor units, e.g. cents
+	currency string // ISO 4217 code, "" for the zero Money
+}
+
+// minorDigits lists the currencies that don't have two decimal places
+var minorDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// symbols are the signs Format shows; other currencies show their code
+var symbols = map[string]string{
+	"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "CN¥", "INR": "₹",
+	"KRW": "₩", "BRL": "R$", "CAD": "CA$", "AUD": "A$", "MXN": "MX$", "NZD": "NZ$",
+	"HKD": "HK$", "ILS": "₪", "VND": "₫", "PHP": "₱", "NGN": "₦", "UAH": "₴",
+	"TRY": "₺", "PLN": "zł", "RUB": "₽", "THB": "฿",
+}
+
+// Digits returns the number of decimal places of a currency, e.g. 2 for
+// USD and 0 for JPY
+func Digits(currency string) int {
+	if d, ok := minorDigits[strings.ToUpper(currency)]; ok {
+		return d
+	}
+	return 2
+}
+
+// Symbol returns the sign of a currency, such as "$" or "€", or its code
+// when it has none
+func Symbol(currency string) string {
+	currency = strings.ToUpper(currency)
+	if s, ok := symbols[currency]; ok {
+		return s
+	}
+	return currency
+}
+
+// New returns units minor units of currency: New(1999, "USD") is $19.99.
+// An empty currency is DefaultCurrency.
+func New(units int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{units: units, currency: strings.ToUpper(currency)}
+}
+
+// Zero returns no money in currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse parses a decimal amount such as "19.99" or "-5" in currency. It
+// rejects amounts with more decimal places than the currency has rather
+// than rounding them.
+func Parse(s, currency string) (Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+	if !validCurrency(currency) {
+		return Money{}, fmt.Errorf("invalid currency %q", currency)
+	}
+	units, err := parseUnits(strings.TrimSpace(s), Digits(currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("%w for %s", err, currency)
+	}
+	return Money{units: units, currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on error, for constants
+func MustParse(s, currency string) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic("types: " + err.Error())
+	}
+	return m
+}
+
+// ParseIn parses an amount as a user of the locale f types it: with its
+// decimal and thousands separators, and optionally the currency's symbol or
+// code, e.g. "1.234,50 €" with the German format
+func ParseIn(s, currency string, f i18n.Format) (Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	raw := strings.ReplaceAll(s, Symbol(currency), "")
+	raw = strings.ReplaceAll(raw, strings.ToUpper(currency), "")
+	raw = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\u202f' {
+			return -1
+		}
+		return r
+	}, raw)
+	if group := strings.TrimSpace(f.Group); group != "" {
+		raw = strings.ReplaceAll(raw, group, "")
+	}
+	if f.Decimal != "" && f.Decimal != "." {
+		raw = strings.ReplaceAll(raw, f.Decimal, ".")
+	}
+	m, err := Parse(raw, currency)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return m, nil
+}
+
+// FromFloat rounds f to the nearest minor unit of currency, half away from
+// zero. It is meant for migrating float64 fields; keep amounts in Money
+// from then on.
+func FromFloat(f float64, currency string) Money {
+	m := New(0, currency)
+	m.units = int64(math.Round(f * math.Pow10(Digits(m.currency))))
+	return m
+}
+
+// parseUnits turns a decimal string into minor units with digits places
+func parseUnits(s string, digits int) (int64, error) {
+	orig := s
+	invalid := fmt.Errorf("invalid amount %q", s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, invalid
+	}
+	for _, part := range []string{whole, frac} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return 0, invalid
+			}
+		}
+	}
+	if len(frac) > digits {
+		if strings.Trim(frac[digits:], "0") != "" {
+			return 0, fmt.Errorf("amount %q has more than %d decimal places", orig, digits)
+		}
+		frac = frac[:digits]
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+
+	units, err := strconv.ParseInt("0"+whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is too large", orig)
+	}
+	if neg {
+		units = -units
+	}
+	return units, nil
+}
+
+func validCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Units returns the amount in minor units, e.g. 1999 for $19.99
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Currency returns the ISO 4217 code, or "" for the zero Money
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero, in any currency
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// Sign returns -1, 0, or 1 for negative, zero, and positive amounts
+func (m Money) Sign() int {
+	switch {
+	case m.units < 0:
+		return -1
+	case m.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// SameCurrency reports whether m and o can be combined without panicking
+func (m Money) SameCurrency(o Money) bool {
+	return m.currency == o.currency || m.currency == "" || o.currency == ""
+}
+
+// currencyWith returns the currency of m and o combined, panicking when they
+// differ
+func (m Money) currencyWith(o Money, op string) string {
+	if !m.SameCurrency(o) {
+		panic(fmt.Sprintf("types: can't %s %s and %s amounts", op, m.currency, o.currency))
+	}
+	if m.currency == "" {
+		return o.currency
+	}
+	return m.currency
+}
+
+// Add returns m + o
+func (m Money) Add(o Money) Money {
+	return Money{units: m.units + o.units, currency: m.currencyWith(o, "add")}
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) Money {
+	return Money{units: m.units - o.units, currency: m.currencyWith(o, "subtract")}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{units: -m.units, currency: m.currency}
+}
+
+// Abs returns m without its sign
+func (m Money) Abs() Money {
+	if m.units < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Mul returns m times n, e.g. a unit price times a quantity
+func (m Money) Mul(n int64) Money {
+	return m.Scale(n, 1)
+}
+
+// Scale returns m * num / den rounded to the nearest minor unit, half away
+// from zero. A rate is written as a fraction: 8.25% tax is Scale(825, 10000).
+// It panics when den is zero or the result overflows.
+func (m Money) Scale(num, den int64) Money {
+	if den == 0 {
+		panic("types: Money.Scale by zero")
+	}
+	n := new(big.Int).Mul(big.NewInt(m.units), big.NewInt(num))
+	d := big.NewInt(den)
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	// Round half away from zero: 2|r| >= |d| moves q one unit further out
+	if new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(d)) >= 0 {
+		if n.Sign()*d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		panic("types: Money.Scale overflows")
+	}
+	return Money{units: q.Int64(), currency: m.currency}
+}
+
+// Allocate splits m into parts in proportion to ratios without losing a
+// cent: the minor units left over by rounding go one each to the first
+// parts. Allocate(1, 1, 1) of $100 is $33.34, $33.33, and $33.33.
+func (m Money) Allocate(ratios ...int) []Money {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			panic("types: Money.Allocate with a negative ratio")
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		panic("types: Money.Allocate needs a positive ratio")
+	}
+
+	parts := make([]Money, len(ratios))
+	left := m.units
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.units), big.NewInt(int64(r)))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Money{units: share.Int64(), currency: m.currency}
+		left -= parts[i].units
+	}
+	step := int64(1)
+	if left < 0 {
+		step = -1
+	}
+	for i := 0; left != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].units += step
+		left -= step
+	}
+	return parts
+}
+
+// Split divides m into n parts as equal as possible, e.g. a bill among n
+// people
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		panic("types: Money.Split needs at least one part")
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Cmp returns -1, 0, or 1 when m is less than, equal to, or greater than o
+func (m Money) Cmp(o Money) int {
+	m.currencyWith(o, "compare")
+	switch {
+	case m.units < o.units:
+		return -1
+	case m.units > o.units:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether m and o are the same amount of the same currency
+func (m Money) Equal(o Money) bool {
+	return m.units == o.units && (m.currency == o.currency || m.units == 0 && (m.currency == "" || o.currency == ""))
+}
+
+// Sum adds amounts of one currency. The sum of none is the zero Money.
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, m := range amounts {
+		total = total.Add(m)
+	}
+	return total
+}
+
+// Float64 returns the amount in major units, for charts and other
+// approximate uses. Don't do arithmetic on it.
+func (m Money) Float64() float64 {
+	return float64(m.units) / math.Pow10(Digits(m.currency))
+}
+
+// Decimal returns the amount with the currency's decimal places and a "."
+// separator, e.g. "19.99" or "-5.00". It is the format of JSON and SQL.
+func (m Money) Decimal() string {
+	digits := Digits(m.currency)
+	sign := ""
+	abs := uint64(m.units)
+	if m.units < 0 {
+		sign, abs = "-", uint64(-m.units)
+	}
+	s := strconv.FormatUint(abs, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// String returns the amount and currency code, e.g. "19.99 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currencyOrDefault()
+}
+
+func (m Money) currencyOrDefault() string {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// Format formats m for the current locale, e.g. "$1,234.50" in English and
+// "1.234,50 €" in German
+func (m Money) Format() string {
+	return m.FormatIn(i18n.CurrentFormat())
+}
+
+// FormatIn formats m with the separators and currency pattern of f
+func (m Money) FormatIn(f i18n.Format) string {
+	pattern := f.Currency
+	if pattern == "" {
+		pattern = "¤n"
+	}
+	s := strings.Replace(pattern, "n", m.Abs().AmountIn(f), 1)
+	s = strings.Replace(s, "¤", Symbol(m.currencyOrDefault()), 1)
+	if m.units < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// AmountIn formats the amount alone with the separators of f, e.g.
+// "1.234,50" in German, for inputs that show the symbol beside the field
+func (m Money) AmountIn(f i18n.Format) string {
+	s := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.Group)
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString(f.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler as "19.99 USD", for query
+// parameters and map keys
+func (m Money) MarshalText() ([]byte, error) {
+	if m == (Money{}) {
+		return nil, nil
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts
+// "19.99 USD", or "19.99" in DefaultCurrency; empty text is the zero Money.
+func (m *Money) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*m = Money{}
+		return nil
+	}
+	amount, currency, _ := strings.Cut(s, " ")
+	parsed, err := Parse(amount, strings.TrimSpace(currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler as {"amount":"19.99","currency":"USD"}.
+// The amount is a string so JavaScript and other float-based clients can't
+// round it.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m == (Money{}) {
+		return []byte("null"), nil
+	}
+	return []byte(`{"amount":"` + m.Decimal() + `","currency":"` + m.currency + `"}`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides the object written by
+// MarshalJSON it accepts null, a "19.99 USD" string, and a plain number in
+// DefaultCurrency, so float fields can be migrated without changing clients
+// at once.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	switch {
+	case s == "null":
+		*m = Money{}
+		return nil
+	case strings.HasPrefix(s, "{"):
+		var aux struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &aux); err != nil {
+			return err
+		}
+		amount := string(aux.Amount)
+		if strings.HasPrefix(amount, `"`) {
+			if err := json.Unmarshal(aux.Amount, &amount); err != nil {
+				return err
+			}
+		}
+		parsed, err := Parse(amount, aux.Currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case strings.HasPrefix(s, `"`):
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return m.UnmarshalText([]byte(text))
+	}
+	parsed, err := Parse(s, "")
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the amount as a decimal string
+// for a NUMERIC or DECIMAL column. Keep the currency in a column of its own
+// when it varies; the zero Money is NULL.
+func (m Money) Value() (driver.Value, error) {
+	if m == (Money{}) {
+		return nil, nil
+	}
+	return m.Decimal(), nil
+}
+
+// Scan implements sql.Scanner. It reads decimal strings, "19.99 USD" text,
+// and integer and float columns in major units. The currency is the one m
+// already has, so scan into a Money made with Zero for columns that aren't
+// in DefaultCurrency.
+func (m *Money) Scan(src any) error {
+	currency := m.currency
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		*m = FromFloat(v, currency)
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("types: can't scan %T into Money", src)
+	}
+
+	amount, code, _ := strings.Cut(strings.TrimSpace(s), " ")
+	if code != "" {
+		currency = code
+	}
+	parsed, err := Parse(amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,167 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s, currency string
+		units       int64
+		decimal     string
+	}{
+		{"19.99", "USD", 1999, "19.99"},
+		{"-5", "USD", -500, "-5.00"},
+		{"+0.5", "usd", 50, "0.50"},
+		{".07", "EUR", 7, "0.07"},
+		{"1.50", "JPY", 0, ""}, // too many places
+		{"1500", "JPY", 1500, "1500"},
+		{"1.2340", "KWD", 1234, "1.234"},
+		{"1,000", "USD", 0, ""},
+		{"12a", "USD", 0, ""},
+		{"99999999999999999999", "USD", 0, ""},
+	}
+	for _, tt := range tests {
+		m, err := Parse(tt.s, tt.currency)
+		if tt.decimal == "" {
+			if err == nil {
+				t.Errorf("Parse(%q, %q) = %v, want an error", tt.s, tt.currency, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %q): %v", tt.s, tt.currency, err)
+			continue
+		}
+		if m.Units() != tt.units || m.Decimal() != tt.decimal {
+			t.Errorf("Parse(%q, %q) = %d units, %q; want %d, %q", tt.s, tt.currency, m.Units(), m.Decimal(), tt.units, tt.decimal)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	price := MustParse("19.99", "USD")
+	total := price.Mul(3).Add(MustParse("4.50", "USD"))
+	if got := total.String(); got != "64.47 USD" {
+		t.Errorf("total = %s, want 64.47 USD", got)
+	}
+	if got := total.Sub(price).Neg().Abs(); !got.Equal(MustParse("44.48", "USD")) {
+		t.Errorf("Sub, Neg, Abs = %s", got)
+	}
+	if got := Sum(); got != (Money{}) {
+		t.Errorf("Sum() = %#v, want the zero Money", got)
+	}
+	if got := Sum(Money{}, price); !got.Equal(price) || got.Currency() != "USD" {
+		t.Errorf("zero Money + price = %s", got)
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		units, num, den, want int64
+	}{
+		{1000, 825, 10000, 83},   // 8.25% of $10.00 is 82.5 cents
+		{-1000, 825, 10000, -83}, // half away from zero
+		{1001, 1, 2, 501},
+		{1001, -1, 2, -501},
+		{999, 1, 3, 333},
+	}
+	for _, tt := range tests {
+		if got := New(tt.units, "USD").Scale(tt.num, tt.den).Units(); got != tt.want {
+			t.Errorf("%d * %d / %d = %d, want %d", tt.units, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		units  int64
+		ratios []int
+		want   []int64
+	}{
+		{10000, []int{1, 1, 1}, []int64{3334, 3333, 3333}},
+		{-10000, []int{1, 1, 1}, []int64{-3334, -3333, -3333}},
+		{5, []int{0, 1, 1}, []int64{0, 3, 2}},
+		{100, []int{70, 30}, []int64{70, 30}},
+	}
+	for _, tt := range tests {
+		parts := New(tt.units, "USD").Allocate(tt.ratios...)
+		var sum int64
+		for i, p := range parts {
+			sum += p.Units()
+			if p.Units() != tt.want[i] {
+				t.Errorf("Allocate(%d, %v)[%d] = %d, want %d", tt.units, tt.ratios, i, p.Units(), tt.want[i])
+			}
+		}
+		if sum != tt.units {
+			t.Errorf("Allocate(%d, %v) adds up to %d", tt.units, tt.ratios, sum)
+		}
+	}
+}
+
+func TestMixedCurrenciesPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("adding USD and EUR didn't panic")
+		}
+	}()
+	MustParse("1", "USD").Add(MustParse("1", "EUR"))
+}
+
+func TestJSON(t *testing.T) {
+	type order struct {
+		Total Money `json:"total"`
+		Tip   Money `json:"tip"`
+	}
+	data, err := json.Marshal(order{Total: MustParse("1234.5", "EUR")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"total":{"amount":"1234.50","currency":"EUR"},"tip":null}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	for _, in := range []string{
+		`{"amount":"1234.50","currency":"EUR"}`,
+		`{"amount":1234.5,"currency":"EUR"}`,
+		`"1234.50 EUR"`,
+	} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err != nil || !m.Equal(MustParse("1234.50", "EUR")) {
+			t.Errorf("Unmarshal(%s) = %v, %v", in, m, err)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`19.99`), &m); err != nil || m.String() != "19.99 "+DefaultCurrency {
+		t.Errorf("Unmarshal(19.99) = %v, %v", m, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{int64(1500), "1500 JPY"},
+		{"1500", "1500 JPY"},
+		{[]byte("7.25 USD"), "7.25 USD"},
+		{float64(12), "12 JPY"},
+	}
+	for _, tt := range tests {
+		m := Zero("JPY")
+		if err := m.Scan(tt.src); err != nil || m.String() != tt.want {
+			t.Errorf("Scan(%v) = %v, %v; want %s", tt.src, m, err, tt.want)
+		}
+	}
+
+	m := Zero("JPY")
+	if err := m.Scan("19.99"); err == nil {
+		t.Errorf("Scan(19.99) into JPY = %v, want an error", m)
+	}
+	m = MustParse("1", "USD")
+	if err := m.Scan(nil); err != nil || m != (Money{}) {
+		t.Errorf("Scan(nil) = %#v, %v", m, err)
+	}
+}
@@ -0,0 +1,279 @@
+// Package validate holds the input checks behind the form validation rules
+// in components and the Validate methods gux generates for request structs,
+// so the browser and the server accept exactly the same values. It has no
+// build constraints.
+//
+//	validate.URL("https://example.com") // true
+//	validate.Phone("(415) 555-0132", "US") // true
+//	validate.IBAN("DE89 3704 0044 0532 0130 00") // true
+//
+// The checks reject empty strings; the form rules and generated validators
+// leave empty values to the required rule.
+package validate
+
+import (
+	"math"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dougbarrett/gux/i18n"
+)
+
+// DateLayout is the date format of <input type="date"> values and date tags
+const DateLayout = "2006-01-02"
+
+var (
+	emailRe = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	uuidRe  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	slugRe  = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	e164Re  = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+)
+
+// Message returns the translated message for a rule, e.g. Message("url") or
+// Message("range", "1", "10"). Keys are "gux.validation." plus the rule name.
+func Message(rule string, args ...any) string {
+	return i18n.T("gux.validation."+rule, args...)
+}
+
+// Email reports whether s looks like an email address
+func Email(s string) bool {
+	return emailRe.MatchString(s)
+}
+
+// URL reports whether s is an absolute http or https URL with a host
+func URL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return u.Host != "" && !strings.ContainsAny(s, " \t\n")
+}
+
+// UUID reports whether s is a UUID in the canonical 8-4-4-4-12 hex form
+func UUID(s string) bool {
+	return uuidRe.MatchString(s)
+}
+
+// Slug reports whether s is lowercase letters and digits separated by
+// single hyphens, e.g. "hello-world-2"
+func Slug(s string) bool {
+	return slugRe.MatchString(s)
+}
+
+type phonePlan struct {
+	code     string         // country calling code
+	trunk    string         // national prefix dropped in international form
+	national *regexp.Regexp // national significant number
+}
+
+// phonePlans checks the length and leading digits of national numbers. They
+// catch typos, not unassigned numbers.
+var phonePlans = map[string]phonePlan{
+	"US": {"1", "1", regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)},
+	"CA": {"1", "1", regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)},
+	"GB": {"44", "0", regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"IE": {"353", "0", regexp.MustCompile(`^[1-9]\d{6,8}$`)},
+	"DE": {"49", "0", regexp.MustCompile(`^[1-9]\d{5,13}$`)},
+	"AT": {"43", "0", regexp.MustCompile(`^[1-9]\d{3,12}$`)},
+	"CH": {"41", "0", regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"FR": {"33", "0", regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"BE": {"32", "0", regexp.MustCompile(`^[1-9]\d{7,8}$`)},
+	"NL": {"31", "0", regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"ES": {"34", "", regexp.MustCompile(`^[6-9]\d{8}$`)},
+	"PT": {"351", "", regexp.MustCompile(`^[29]\d{8}$`)},
+	"IT": {"39", "", regexp.MustCompile(`^[03]\d{5,10}$`)},
+	"SE": {"46", "0", regexp.MustCompile(`^[1-9]\d{6,9}$`)},
+	"NO": {"47", "", regexp.MustCompile(`^[2-9]\d{7}$`)},
+	"DK": {"45", "", regexp.MustCompile(`^[2-9]\d{7}$`)},
+	"PL": {"48", "", regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"AU": {"61", "0", regexp.MustCompile(`^[2-478]\d{8}$`)},
+	"NZ": {"64", "0", regexp.MustCompile(`^[2-9]\d{7,9}$`)},
+	"IN": {"91", "0", regexp.MustCompile(`^[1-9]\d{9}$`)},
+	"JP": {"81", "0", regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"BR": {"55", "0", regexp.MustCompile(`^[1-9]{2}9?\d{8}$`)},
+	"MX": {"52", "", regexp.MustCompile(`^[1-9]\d{9}$`)},
+}
+
+// Phone reports whether s is a valid phone number for country, an ISO 3166
+// alpha-2 code such as "US" or "DE". National ("030 123456") and
+// international ("+49 30 123456", "0049 30 123456") forms are accepted, with
+// spaces, dots, hyphens, and parentheses. For an unknown or empty country, s
+// must be an international number in E.164 form.
+func Phone(s, country string) bool {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, s)
+	if strings.HasPrefix(digits, "00") {
+		digits = "+" + digits[2:]
+	}
+
+	plan, ok := phonePlans[strings.ToUpper(country)]
+	if !ok {
+		return e164Re.MatchString(digits)
+	}
+	if rest, ok := strings.CutPrefix(digits, "+"); ok {
+		national, ok := strings.CutPrefix(rest, plan.code)
+		if !ok {
+			return false
+		}
+		// "+44 (0)20 ..." keeps the trunk prefix in parentheses
+		if plan.trunk == "0" {
+			national = strings.TrimPrefix(national, "0")
+		}
+		return plan.national.MatchString(national)
+	}
+	if plan.trunk != "" {
+		if national, ok := strings.CutPrefix(digits, plan.trunk); ok && plan.national.MatchString(national) {
+			return true
+		}
+	}
+	return plan.national.MatchString(digits)
+}
+
+// ibanLengths is the IBAN length for each country in the IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22,
+	"DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18, "FO": 18, "FR": 27,
+	"GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24,
+	"ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24, "SC": 31,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28, "TL": 23, "TN": 24,
+	"TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// IBAN reports whether s is an IBAN with the right length for its country
+// and valid check digits. Spaces are ignored and letters may be lowercase.
+func IBAN(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 4 || ibanLengths[iban[:2]] != len(iban) {
+		return false
+	}
+
+	// Move the country code and check digits to the end, turn letters into
+	// numbers (A=10 ... Z=35), and check the result mod 97
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			numeric.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// CreditCard reports whether s is a 12 to 19 digit card number that passes
+// the Luhn check. Spaces and hyphens are ignored.
+func CreditCard(s string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	return len(digits) >= 12 && len(digits) <= 19 && Luhn(digits)
+}
+
+// Luhn reports whether digits passes the Luhn checksum
+func Luhn(digits string) bool {
+	if digits == "" {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0') // bytes below '0' wrap around
+		if d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ParseDate parses a date in DateLayout or an RFC 3339 timestamp
+func ParseDate(s string) (time.Time, bool) {
+	if t, err := time.Parse(DateLayout, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// MustDate parses a date in DateLayout and panics if it is invalid. It is
+// used for the bounds in generated validators.
+func MustDate(s string) time.Time {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic("validate: invalid date " + strconv.Quote(s))
+	}
+	return t
+}
+
+// Before reports whether s is a date before t
+func Before(s string, t time.Time) bool {
+	d, ok := ParseDate(s)
+	return ok && d.Before(t)
+}
+
+// After reports whether s is a date after t
+func After(s string, t time.Time) bool {
+	d, ok := ParseDate(s)
+	return ok && d.After(t)
+}
+
+// Range reports whether s is a number between min and max, inclusive
+func Range(s string, min, max float64) bool {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil && v >= min && v <= max
+}
+
+// Min reports whether s is a number of at least min
+func Min(s string, min float64) bool {
+	return Range(s, min, math.Inf(1))
+}
+
+// Max reports whether s is a number of at most max
+func Max(s string, max float64) bool {
+	return Range(s, math.Inf(-1), max)
+}
+
+// OneOf reports whether s equals one of options
+func OneOf(s string, options ...string) bool {
+	for _, o := range options {
+		if s == o {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatNumber formats a bound for a message: whole numbers without
+// decimals, others as short as possible
+func FormatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// FormatDate formats a date bound for a message in the current locale
+func FormatDate(t time.Time) string {
+	return i18n.FormatDate(t)
+}
@@ -0,0 +1,242 @@
+//go:build js && wasm
+
+package ws
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mux shares one Client among subscriptions to the topics of a
+// server.WSHub, so a page following posts, users, and notifications opens
+// one connection instead of one each. It connects on the first
+// subscription, sends a subscribe frame when a topic gains its first
+// subscriber and an unsubscribe frame when it loses its last, and closes
+// the connection when no subscriptions are left.
+type Mux struct {
+	client     *Client
+	mu         sync.Mutex
+	subs       map[string][]*Subscription // by topic
+	connecting bool
+}
+
+// Subscription receives the events of one topic through a Mux
+type Subscription struct {
+	mux      *Mux
+	topic    string
+	handler  func(Message)
+	onResync func()
+	closed   bool
+}
+
+var (
+	muxes   = make(map[string]*Mux)
+	muxesMu sync.Mutex
+)
+
+// Shared returns the Mux for url, creating it with opts on first use.
+// Generated and hand-written clients that call Shared with the same URL
+// share its connection.
+func Shared(url string, opts ...Option) *Mux {
+	muxesMu.Lock()
+	defer muxesMu.Unlock()
+	m, ok := muxes[url]
+	if !ok {
+		m = NewMux(url, opts...)
+		muxes[url] = m
+	}
+	return m
+}
+
+// NewMux creates a Mux with its own Client for url. The client reconnects
+// after a second, backing off from there, unless opts set WithReconnect.
+func NewMux(url string, opts ...Option) *Mux {
+	m := &Mux{subs: make(map[string][]*Subscription)}
+	m.client = NewClient(url, append([]Option{WithReconnect(time.Second)}, opts...)...)
+	m.client.onTopic = m.dispatch
+	return m
+}
+
+// Client returns the shared client, e.g. to send requests over the same
+// connection
+func (m *Mux) Client() *Client {
+	return m.client
+}
+
+// Subscribe calls handler with each event published to topic, connecting
+// first if needed. Events missed while reconnecting are replayed; when
+// they can't be, handler receives a message of type "ws.resync" and
+// should reload the topic's data. Close the subscription when done.
+func (m *Mux) Subscribe(topic string, handler func(Message)) (*Subscription, error) {
+	s := &Subscription{mux: m, topic: topic, handler: handler}
+	m.mu.Lock()
+	first := len(m.subs[topic]) == 0
+	m.subs[topic] = append(m.subs[topic], s)
+	m.mu.Unlock()
+
+	if first {
+		if err := m.client.Subscribe(topic); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+	if err := m.connect(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// SubscribeTyped calls handler with the type and decoded payload of each
+// event published to topic. Events whose payload doesn't decode as T are
+// skipped. Use Subscription.OnResync to reload after missed events.
+//
+//	sub, err := ws.SubscribeTyped(ws.Shared(url), "posts", func(event string, post api.Post) {
+//		switch event {
+//		case "post.created":
+//			...
+//		}
+//	})
+func SubscribeTyped[T any](m *Mux, topic string, handler func(msgType string, payload T)) (*Subscription, error) {
+	var s *Subscription
+	s, err := m.Subscribe(topic, func(msg Message) {
+		if msg.Type == resyncEvent {
+			if s != nil && s.onResync != nil {
+				s.onResync()
+			}
+			return
+		}
+		var payload T
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return
+		}
+		handler(msg.Type, payload)
+	})
+	return s, err
+}
+
+// connect opens the connection unless it is open or opening. The topics
+// subscribed so far go in the connection's URL; those subscribed while it
+// opens are sent once it is open.
+func (m *Mux) connect() error {
+	m.mu.Lock()
+	if m.connecting || m.client.State() != StateClosed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.connecting = true
+	sent := make(map[string]bool, len(m.subs))
+	for topic := range m.subs {
+		sent[topic] = true
+	}
+	m.mu.Unlock()
+
+	err := m.client.Connect()
+
+	m.mu.Lock()
+	m.connecting = false
+	var added []string
+	for topic := range m.subs {
+		if !sent[topic] {
+			added = append(added, topic)
+		}
+	}
+	empty := len(m.subs) == 0
+	m.mu.Unlock()
+	switch {
+	case err != nil:
+		return err
+	case empty:
+		m.client.Close() // every subscription closed while connecting
+	case len(added) > 0:
+		return m.client.Subscribe(added...)
+	}
+	return nil
+}
+
+// dispatch passes a topic's event to its subscriptions
+func (m *Mux) dispatch(msg Message) {
+	if msg.Type == subscribedEvent || (strings.HasPrefix(msg.Type, "ws.") && msg.Type != resyncEvent) {
+		return
+	}
+	m.mu.Lock()
+	subs := append([]*Subscription(nil), m.subs[msg.Topic]...)
+	m.mu.Unlock()
+	for _, s := range subs {
+		s.handler(msg)
+	}
+}
+
+// Topics returns the topics with at least one subscription
+func (m *Mux) Topics() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	topics := make([]string, 0, len(m.subs))
+	for topic := range m.subs {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// IsConnected returns true if the shared connection is open
+func (m *Mux) IsConnected() bool {
+	return m.client.IsConnected()
+}
+
+// Topic returns the subscription's topic
+func (s *Subscription) Topic() string {
+	return s.topic
+}
+
+// OnResync sets a callback for when the topic's missed events couldn't be
+// replayed after a reconnect, for subscriptions made with SubscribeTyped
+func (s *Subscription) OnResync(fn func()) {
+	s.onResync = fn
+}
+
+// IsConnected returns true if the shared connection is open
+func (s *Subscription) IsConnected() bool {
+	return !s.closed && s.mux.IsConnected()
+}
+
+// Close stops the subscription. The topic is unsubscribed when its last
+// subscription closes, and the connection when the Mux has none left.
+func (s *Subscription) Close() error {
+	m := s.mux
+	m.mu.Lock()
+	if s.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	subs := m.subs[s.topic]
+	for i, other := range subs {
+		if other == s {
+			subs = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) > 0 {
+		m.subs[s.topic] = subs
+	} else {
+		delete(m.subs, s.topic)
+	}
+	last := len(subs) == 0
+	empty := len(m.subs) == 0
+	connecting := m.connecting
+	m.mu.Unlock()
+
+	if !last {
+		return nil
+	}
+	err := m.client.Unsubscribe(s.topic)
+	if err == ErrNotConnected {
+		err = nil // dropped from the resume token; the server forgets it
+	}
+	if empty && !connecting {
+		m.client.Close() // also cancels a pending reconnect
+	}
+	return err
+}
@@ -5,11 +5,14 @@
 package ws
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"syscall/js"
+	"time"
 )
 
 // Common errors
@@ -34,9 +37,18 @@ const (
 type Message struct {
 	Type    string          `json:"type"`
 	Payload json.RawMessage `json:"payload"`
-	ID      string          `json:"id,omitempty"` // For request/response correlation
+	ID      string          `json:"id,omitempty"`    // For request/response correlation
+	Topic   string          `json:"topic,omitempty"` // Set on events published to a topic by server.WSHub
+	Seq     uint64          `json:"seq,omitempty"`   // The event's sequence number in Topic
 }
 
+// Messages exchanged with server.WSHub
+const (
+	helloEvent      = "ws.hello"
+	subscribedEvent = "ws.subscribed"
+	resyncEvent     = "ws.resync"
+)
+
 // Client is a type-safe WebSocket client
 type Client struct {
 	url        string
@@ -55,6 +67,16 @@ type Client struct {
 	onClose   func(code int, reason string)
 	onError   func(err error)
 	onMessage func(Message)
+	onResync  func(topic string)
+	onTopic   func(Message) // a Mux's dispatch
+
+	// Resuming after reconnects, with server.WSHub
+	reconnect time.Duration     // first delay; 0 disables reconnecting
+	delay     time.Duration     // the next delay, doubling up to 30s
+	closing   bool              // Close was called
+	node      string            // from the server's hello
+	epoch     string            // the server process's, from its hello
+	topics    map[string]uint64 // subscribed topics and the last seq received
 
 	// For cleanup
 	openFunc    js.Func
@@ -94,6 +116,25 @@ func WithOnMessage(fn func(Message)) Option {
 	}
 }
 
+// WithReconnect reconnects after the connection drops, waiting delay and
+// then twice as long after each failure, up to 30 seconds. With a
+// server.WSHub the client resumes its subscriptions and receives the
+// events it missed.
+func WithReconnect(delay time.Duration) Option {
+	return func(c *Client) {
+		c.reconnect = delay
+	}
+}
+
+// WithOnResync sets the callback for a topic whose missed events couldn't
+// be replayed after a reconnect, e.g. because the client reached another
+// server. Reload the topic's data there, e.g. with Table.Reload.
+func WithOnResync(fn func(topic string)) Option {
+	return func(c *Client) {
+		c.onResync = fn
+	}
+}
+
 // NewClient creates a new WebSocket client
 func NewClient(url string, opts ...Option) *Client {
 	c := &Client{
@@ -101,6 +142,7 @@ func NewClient(url string, opts ...Option) *Client {
 		state:       StateClosed,
 		handlers:    make(map[string][]func(json.RawMessage)),
 		pendingReqs: make(map[string]chan Message),
+		topics:      make(map[string]uint64),
 	}
 
 	for _, opt := range opts {
@@ -118,17 +160,30 @@ func (c *Client) Connect() error {
 		return ErrAlreadyConnected
 	}
 	c.state = StateConnecting
+	c.closing = false
+	url := c.url
+	if token := c.resumeToken(); token != "" {
+		sep := "?"
+		if strings.Contains(url, "?") {
+			sep = "&"
+		}
+		url += sep + "resume=" + token
+	}
 	c.mu.Unlock()
 
 	done := make(chan error, 1)
 
+	// Release the callbacks of the previous connection, when reconnecting
+	c.detach()
+
 	// Create WebSocket
-	c.ws = js.Global().Get("WebSocket").New(c.url)
+	c.ws = js.Global().Get("WebSocket").New(url)
 
 	// Setup event handlers
 	c.openFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
 		c.mu.Lock()
 		c.state = StateOpen
+		c.delay = c.reconnect
 		c.mu.Unlock()
 
 		if c.onOpen != nil {
@@ -140,7 +195,14 @@ func (c *Client) Connect() error {
 
 	c.closeFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
 		c.mu.Lock()
+		wasConnecting := c.state == StateConnecting
 		c.state = StateClosed
+		retry := c.reconnect > 0 && !c.closing
+		delay := c.delay
+		if delay <= 0 {
+			delay = c.reconnect
+		}
+		c.delay = min(2*delay, 30*time.Second)
 		c.mu.Unlock()
 
 		code := 1000
@@ -150,9 +212,26 @@ func (c *Client) Connect() error {
 			reason = args[0].Get("reason").String()
 		}
 
+		if wasConnecting {
+			// No error event preceded the close; don't leave Connect waiting
+			select {
+			case done <- ErrConnectionFailed:
+			default:
+			}
+		}
 		if c.onClose != nil {
 			c.onClose(code, reason)
 		}
+		if retry {
+			time.AfterFunc(delay, func() {
+				c.mu.RLock()
+				closing := c.closing
+				c.mu.RUnlock()
+				if !closing {
+					c.Connect()
+				}
+			})
+		}
 		return nil
 	})
 
@@ -164,8 +243,10 @@ func (c *Client) Connect() error {
 
 		c.mu.Lock()
 		if c.state == StateConnecting {
-			c.state = StateClosed
-			done <- err
+			select {
+			case done <- err:
+			default:
+			}
 		}
 		c.mu.Unlock()
 
@@ -191,6 +272,10 @@ func (c *Client) Connect() error {
 			return nil
 		}
 
+		if !c.track(msg) {
+			return nil // already received before a reconnect
+		}
+
 		// Check for pending request/response correlation
 		if msg.ID != "" {
 			c.pendingReqsMu.RLock()
@@ -202,6 +287,10 @@ func (c *Client) Connect() error {
 			}
 		}
 
+		if msg.Topic != "" && c.onTopic != nil {
+			c.onTopic(msg)
+		}
+
 		// Call type-specific handlers
 		c.handlersMu.RLock()
 		handlers := c.handlers[msg.Type]
@@ -233,20 +322,31 @@ func (c *Client) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.closing = true // also cancels a pending reconnect
 	if c.state != StateOpen {
 		return ErrNotConnected
 	}
 
-	c.state = StateClosing
+	// Cleanup JS functions. The socket's close event comes later, so it is
+	// closed from here on.
+	c.detach()
 	c.ws.Call("close")
+	c.state = StateClosed
 
-	// Cleanup JS functions
+	return nil
+}
+
+// detach removes the callbacks from the socket and releases them
+func (c *Client) detach() {
+	if !c.ws.IsUndefined() && !c.ws.IsNull() {
+		for _, event := range []string{"onopen", "onclose", "onerror", "onmessage"} {
+			c.ws.Set(event, js.Null())
+		}
+	}
 	c.openFunc.Release()
 	c.closeFunc.Release()
 	c.errorFunc.Release()
 	c.messageFunc.Release()
-
-	return nil
 }
 
 // State returns the current connection state
@@ -394,3 +494,88 @@ func RequestTyped[Req any, Resp any](c *Client, msgType string, req Req) (*Resp,
 
 	return &resp, nil
 }
+
+// Subscribe subscribes to topics of a server.WSHub. The subscriptions are
+// resumed after reconnects, with the events missed meanwhile. Topics
+// subscribed before Connect are sent when it connects.
+func (c *Client) Subscribe(topics ...string) error {
+	c.mu.Lock()
+	for _, topic := range topics {
+		if _, ok := c.topics[topic]; !ok {
+			c.topics[topic] = 0
+		}
+	}
+	c.mu.Unlock()
+	if !c.IsConnected() {
+		return nil
+	}
+	return c.Send("subscribe", map[string][]string{"topics": topics})
+}
+
+// Unsubscribe unsubscribes from topics of a server.WSHub
+func (c *Client) Unsubscribe(topics ...string) error {
+	c.mu.Lock()
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+	c.mu.Unlock()
+	return c.Send("unsubscribe", map[string][]string{"topics": topics})
+}
+
+// track records the server's node and the last sequence number received
+// on each topic, for resuming. It reports false for an event that was
+// already received, which a replay can repeat.
+func (c *Client) track(msg Message) bool {
+	c.mu.Lock()
+	switch msg.Type {
+	case helloEvent:
+		var hello struct {
+			Node  string `json:"node"`
+			Epoch string `json:"epoch"`
+		}
+		json.Unmarshal(msg.Payload, &hello)
+		c.node, c.epoch = hello.Node, hello.Epoch
+	case subscribedEvent:
+		if _, ok := c.topics[msg.Topic]; ok {
+			c.topics[msg.Topic] = msg.Seq
+		}
+	case resyncEvent:
+		_, ok := c.topics[msg.Topic]
+		if ok {
+			c.topics[msg.Topic] = msg.Seq
+		}
+		c.mu.Unlock()
+		if ok && c.onResync != nil {
+			c.onResync(msg.Topic)
+		}
+		return true
+	default:
+		if last, ok := c.topics[msg.Topic]; ok && msg.Seq > 0 {
+			if msg.Seq <= last {
+				c.mu.Unlock()
+				return false
+			}
+			c.topics[msg.Topic] = msg.Seq
+		}
+	}
+	c.mu.Unlock()
+	return true
+}
+
+// ResumeToken returns the token the client reconnects with: the server's
+// node and epoch, and the last sequence number received on each subscribed topic. It
+// is empty before the first subscription.
+func (c *Client) ResumeToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resumeToken()
+}
+
+// resumeToken is ResumeToken; c.mu must be held
+func (c *Client) resumeToken() string {
+	if len(c.topics) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(map[string]any{"node": c.node, "epoch": c.epoch, "topics": c.topics})
+	return base64.RawURLEncoding.EncodeToString(data)
+}